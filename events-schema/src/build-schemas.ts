@@ -8,13 +8,23 @@ import { dirname, join } from 'path';
 import { fileURLToPath } from 'url';
 
 // Import schemas
-import { PositionSchema, VelocitySchema, MessageSchema } from './schemas/common.js';
+import { PositionSchema, VelocitySchema, MessageSchema, TeamIdSchema, TeamRefSchema } from './schemas/common.js';
 import {
   PlayerHelloPublicDataSchema,
   PlayerHelloCodeDataSchema,
+  PlayerHelloPracticeDataSchema,
   PlayerHelloDataSchema,
   PlayerHelloMessageSchema,
   SessionLeaveMessageSchema,
+  RoomRosterRequestMessageSchema,
+  PlayerReadyDataSchema,
+  PlayerReadyMessageSchema,
+  PartyStayTogetherDataSchema,
+  PartyStayTogetherMessageSchema,
+  MatchRematchVoteDataSchema,
+  MatchRematchVoteMessageSchema,
+  PlayerPreferencesDataSchema,
+  PlayerPreferencesMessageSchema,
   InputStateDataSchema,
   InputStateMessageSchema,
   PlayerShootDataSchema,
@@ -25,18 +35,63 @@ import {
   PlayerMeleeAttackDataSchema,
   PlayerMeleeAttackMessageSchema,
   PlayerDodgeRollMessageSchema,
+  PlayerUltimateMessageSchema,
+  PlayerRespawnRequestMessageSchema,
+  PlayerSpawnChoiceDataSchema,
+  PlayerSpawnChoiceMessageSchema,
+  PlayerPingDataSchema,
+  PlayerPingMessageSchema,
+  PlayerEmoteDataSchema,
+  PlayerEmoteMessageSchema,
+  ChatMessageDataSchema,
+  ChatMessageMessageSchema,
+  ChatMuteDataSchema,
+  ChatMuteMessageSchema,
+  RoomVoteKickDataSchema,
+  RoomVoteKickMessageSchema,
+  PlayerLoadoutDataSchema,
+  PlayerLoadoutMessageSchema,
+  PlayerRenameDataSchema,
+  PlayerRenameMessageSchema,
+  StateAckDataSchema,
+  StateAckMessageSchema,
+  FeedbackSubmitDataSchema,
+  FeedbackSubmitMessageSchema,
+  NetPongDataSchema,
+  NetPongMessageSchema,
+  TimeSyncRequestDataSchema,
+  TimeSyncRequestMessageSchema,
 } from './schemas/client-to-server.js';
 import {
   RoomJoinedDataSchema,
   RoomJoinedMessageSchema,
+  ServerHelloDataSchema,
+  ServerHelloMessageSchema,
+  SessionReplacedDataSchema,
+  SessionReplacedMessageSchema,
   ErrorNoHelloDataSchema,
   ErrorNoHelloMessageSchema,
+  ErrorPayloadRejectedDataSchema,
+  ErrorPayloadRejectedMessageSchema,
   ErrorBadRoomCodeDataSchema,
   ErrorBadRoomCodeMessageSchema,
   ErrorRoomFullDataSchema,
   ErrorRoomFullMessageSchema,
+  ErrorRoomBlockedDataSchema,
+  ErrorRoomBlockedMessageSchema,
   PlayerLeftDataSchema,
   PlayerLeftMessageSchema,
+  PlayerJoinedDataSchema,
+  PlayerJoinedMessageSchema,
+  RosterPlayerSchema,
+  RoomRosterDataSchema,
+  RoomRosterMessageSchema,
+  RoomReadyStateDataSchema,
+  RoomReadyStateMessageSchema,
+  PartyStateDataSchema,
+  PartyStateMessageSchema,
+  MatchRestartedDataSchema,
+  MatchRestartedMessageSchema,
   PlayerStateSchema,
   PlayerMoveDataSchema,
   PlayerMoveMessageSchema,
@@ -44,24 +99,74 @@ import {
   ProjectileSpawnMessageSchema,
   ProjectileDestroyDataSchema,
   ProjectileDestroyMessageSchema,
+  ProjectileExplodeDataSchema,
+  ProjectileExplodeMessageSchema,
+  AbilityStateSchema,
+  WeaponStatsSchema,
   WeaponStateDataSchema,
   WeaponStateMessageSchema,
   ShootFailedDataSchema,
   ShootFailedMessageSchema,
+  PlayerRenamedDataSchema,
+  PlayerRenamedMessageSchema,
+  RenameFailedDataSchema,
+  RenameFailedMessageSchema,
   PlayerDamagedDataSchema,
   PlayerDamagedMessageSchema,
   HitConfirmedDataSchema,
   HitConfirmedMessageSchema,
+  HitRejectedDataSchema,
+  HitRejectedMessageSchema,
   PlayerDeathDataSchema,
   PlayerDeathMessageSchema,
   PlayerKillCreditDataSchema,
   PlayerKillCreditMessageSchema,
+  PlayerKillstreakDataSchema,
+  PlayerKillstreakMessageSchema,
+  PlayerAssistCreditDataSchema,
+  PlayerAssistCreditMessageSchema,
   PlayerRespawnDataSchema,
   PlayerRespawnMessageSchema,
   MatchTimerDataSchema,
   MatchTimerMessageSchema,
   MatchEndedDataSchema,
   MatchEndedMessageSchema,
+  ScoreboardEntrySchema,
+  PlayerMatchStatsSchema,
+  WorldSyncDataSchema,
+  WorldSyncMessageSchema,
+  MapObstacleSchema,
+  MapLoadDataSchema,
+  MapLoadMessageSchema,
+  LobbySandboxStateDataSchema,
+  LobbySandboxStateMessageSchema,
+  QueueStatusDataSchema,
+  QueueStatusMessageSchema,
+  SpawnOptionsDataSchema,
+  SpawnOptionsMessageSchema,
+  TeamPingDataSchema,
+  TeamPingMessageSchema,
+  PlayerEmotedDataSchema,
+  PlayerEmotedMessageSchema,
+  ChatPostedDataSchema,
+  ChatPostedMessageSchema,
+  RoomVoteKickProgressDataSchema,
+  RoomVoteKickProgressMessageSchema,
+  LeaderboardUpdateEntrySchema,
+  LeaderboardUpdateDataSchema,
+  LeaderboardUpdateMessageSchema,
+  PlayerLevelUpDataSchema,
+  PlayerLevelUpMessageSchema,
+  ServerShutdownDataSchema,
+  ServerShutdownMessageSchema,
+  RoomRedirectDataSchema,
+  RoomRedirectMessageSchema,
+  PracticeStatusDataSchema,
+  PracticeStatusMessageSchema,
+  NetPingDataSchema,
+  NetPingMessageSchema,
+  TimeSyncDataSchema,
+  TimeSyncMessageSchema,
   WeaponCrateSchema,
   WeaponSpawnedDataSchema,
   WeaponSpawnedMessageSchema,
@@ -69,21 +174,41 @@ import {
   WeaponPickupConfirmedMessageSchema,
   WeaponRespawnedDataSchema,
   WeaponRespawnedMessageSchema,
+  ShieldCrateSchema,
+  ShieldSpawnedDataSchema,
+  ShieldSpawnedMessageSchema,
+  ShieldPickupConfirmedDataSchema,
+  ShieldPickupConfirmedMessageSchema,
+  ShieldRespawnedDataSchema,
+  ShieldRespawnedMessageSchema,
+  HealthPackSchema,
+  HealthSpawnedDataSchema,
+  HealthSpawnedMessageSchema,
+  HealthPickupConfirmedDataSchema,
+  HealthPickupConfirmedMessageSchema,
   MeleeHitDataSchema,
   MeleeHitMessageSchema,
   RollStartDataSchema,
   RollStartMessageSchema,
   RollEndDataSchema,
   RollEndMessageSchema,
+  UltimateActivatedDataSchema,
+  UltimateActivatedMessageSchema,
   ProjectileSnapshotSchema,
   WeaponCrateSnapshotSchema,
   StateSnapshotDataSchema,
   StateSnapshotMessageSchema,
   StateDeltaDataSchema,
   StateDeltaMessageSchema,
+  EntityRemovedDataSchema,
+  EntityRemovedMessageSchema,
   SessionStatusDataSchema,
   SessionStatusMessageSchema,
+  SessionCapacityDataSchema,
+  SessionCapacityMessageSchema,
   WinnerSummarySchema,
+  MatchSettingsSchema,
+  BuildInfoSchema,
 } from './schemas/server-to-client.js';
 
 const __filename = fileURLToPath(import.meta.url);
@@ -111,6 +236,14 @@ const schemas: SchemaExport[] = [
     schema: MessageSchema,
     outputPath: 'schemas/common/message.json',
   },
+  {
+    schema: TeamIdSchema,
+    outputPath: 'schemas/common/team-id.json',
+  },
+  {
+    schema: TeamRefSchema,
+    outputPath: 'schemas/common/team-ref.json',
+  },
   // Client-to-server schemas
   {
     schema: PlayerHelloPublicDataSchema,
@@ -120,6 +253,10 @@ const schemas: SchemaExport[] = [
     schema: PlayerHelloCodeDataSchema,
     outputPath: 'schemas/client-to-server/player-hello-code-data.json',
   },
+  {
+    schema: PlayerHelloPracticeDataSchema,
+    outputPath: 'schemas/client-to-server/player-hello-practice-data.json',
+  },
   {
     schema: PlayerHelloDataSchema,
     outputPath: 'schemas/client-to-server/player-hello-data.json',
@@ -132,6 +269,42 @@ const schemas: SchemaExport[] = [
     schema: SessionLeaveMessageSchema,
     outputPath: 'schemas/client-to-server/session-leave-message.json',
   },
+  {
+    schema: RoomRosterRequestMessageSchema,
+    outputPath: 'schemas/client-to-server/room-roster-request-message.json',
+  },
+  {
+    schema: PlayerReadyDataSchema,
+    outputPath: 'schemas/client-to-server/player-ready-data.json',
+  },
+  {
+    schema: PlayerReadyMessageSchema,
+    outputPath: 'schemas/client-to-server/player-ready-message.json',
+  },
+  {
+    schema: PartyStayTogetherDataSchema,
+    outputPath: 'schemas/client-to-server/party-stay-together-data.json',
+  },
+  {
+    schema: PartyStayTogetherMessageSchema,
+    outputPath: 'schemas/client-to-server/party-stay-together-message.json',
+  },
+  {
+    schema: MatchRematchVoteDataSchema,
+    outputPath: 'schemas/client-to-server/match-rematch-vote-data.json',
+  },
+  {
+    schema: MatchRematchVoteMessageSchema,
+    outputPath: 'schemas/client-to-server/match-rematch-vote-message.json',
+  },
+  {
+    schema: PlayerPreferencesDataSchema,
+    outputPath: 'schemas/client-to-server/player-preferences-data.json',
+  },
+  {
+    schema: PlayerPreferencesMessageSchema,
+    outputPath: 'schemas/client-to-server/player-preferences-message.json',
+  },
   {
     schema: InputStateDataSchema,
     outputPath: 'schemas/client-to-server/input-state-data.json',
@@ -172,6 +345,110 @@ const schemas: SchemaExport[] = [
     schema: PlayerDodgeRollMessageSchema,
     outputPath: 'schemas/client-to-server/player-dodge-roll-message.json',
   },
+  {
+    schema: PlayerUltimateMessageSchema,
+    outputPath: 'schemas/client-to-server/player-ultimate-message.json',
+  },
+  {
+    schema: PlayerRespawnRequestMessageSchema,
+    outputPath: 'schemas/client-to-server/player-respawn-request-message.json',
+  },
+  {
+    schema: PlayerSpawnChoiceDataSchema,
+    outputPath: 'schemas/client-to-server/player-spawn-choice-data.json',
+  },
+  {
+    schema: PlayerSpawnChoiceMessageSchema,
+    outputPath: 'schemas/client-to-server/player-spawn-choice-message.json',
+  },
+  {
+    schema: PlayerPingDataSchema,
+    outputPath: 'schemas/client-to-server/player-ping-data.json',
+  },
+  {
+    schema: PlayerPingMessageSchema,
+    outputPath: 'schemas/client-to-server/player-ping-message.json',
+  },
+  {
+    schema: PlayerEmoteDataSchema,
+    outputPath: 'schemas/client-to-server/player-emote-data.json',
+  },
+  {
+    schema: PlayerEmoteMessageSchema,
+    outputPath: 'schemas/client-to-server/player-emote-message.json',
+  },
+  {
+    schema: ChatMessageDataSchema,
+    outputPath: 'schemas/client-to-server/chat-message-data.json',
+  },
+  {
+    schema: ChatMessageMessageSchema,
+    outputPath: 'schemas/client-to-server/chat-message-message.json',
+  },
+  {
+    schema: ChatMuteDataSchema,
+    outputPath: 'schemas/client-to-server/chat-mute-data.json',
+  },
+  {
+    schema: ChatMuteMessageSchema,
+    outputPath: 'schemas/client-to-server/chat-mute-message.json',
+  },
+  {
+    schema: RoomVoteKickDataSchema,
+    outputPath: 'schemas/client-to-server/room-votekick-data.json',
+  },
+  {
+    schema: RoomVoteKickMessageSchema,
+    outputPath: 'schemas/client-to-server/room-votekick-message.json',
+  },
+  {
+    schema: PlayerLoadoutDataSchema,
+    outputPath: 'schemas/client-to-server/player-loadout-data.json',
+  },
+  {
+    schema: PlayerLoadoutMessageSchema,
+    outputPath: 'schemas/client-to-server/player-loadout-message.json',
+  },
+  {
+    schema: PlayerRenameDataSchema,
+    outputPath: 'schemas/client-to-server/player-rename-data.json',
+  },
+  {
+    schema: PlayerRenameMessageSchema,
+    outputPath: 'schemas/client-to-server/player-rename-message.json',
+  },
+  {
+    schema: StateAckDataSchema,
+    outputPath: 'schemas/client-to-server/state-ack-data.json',
+  },
+  {
+    schema: StateAckMessageSchema,
+    outputPath: 'schemas/client-to-server/state-ack-message.json',
+  },
+  {
+    schema: FeedbackSubmitDataSchema,
+    outputPath: 'schemas/client-to-server/feedback-submit-data.json',
+  },
+  {
+    schema: FeedbackSubmitMessageSchema,
+    outputPath: 'schemas/client-to-server/feedback-submit-message.json',
+  },
+  {
+    schema: NetPongDataSchema,
+    outputPath: 'schemas/client-to-server/net-pong-data.json',
+  },
+  {
+    schema: NetPongMessageSchema,
+    outputPath: 'schemas/client-to-server/net-pong-message.json',
+  },
+  {
+    schema: TimeSyncRequestDataSchema,
+    outputPath: 'schemas/client-to-server/time-sync-request-data.json',
+  },
+  {
+    schema: TimeSyncRequestMessageSchema,
+    outputPath: 'schemas/client-to-server/time-sync-request-message.json',
+  },
   // Server-to-client schemas
   {
     schema: SessionStatusDataSchema,
@@ -181,6 +458,14 @@ const schemas: SchemaExport[] = [
     schema: SessionStatusMessageSchema,
     outputPath: 'schemas/server-to-client/session-status-message.json',
   },
+  {
+    schema: SessionCapacityDataSchema,
+    outputPath: 'schemas/server-to-client/session-capacity-data.json',
+  },
+  {
+    schema: SessionCapacityMessageSchema,
+    outputPath: 'schemas/server-to-client/session-capacity-message.json',
+  },
   {
     schema: RoomJoinedDataSchema,
     outputPath: 'schemas/server-to-client/room-joined-data.json',
@@ -189,6 +474,22 @@ const schemas: SchemaExport[] = [
     schema: RoomJoinedMessageSchema,
     outputPath: 'schemas/server-to-client/room-joined-message.json',
   },
+  {
+    schema: ServerHelloDataSchema,
+    outputPath: 'schemas/server-to-client/server-hello-data.json',
+  },
+  {
+    schema: ServerHelloMessageSchema,
+    outputPath: 'schemas/server-to-client/server-hello-message.json',
+  },
+  {
+    schema: SessionReplacedDataSchema,
+    outputPath: 'schemas/server-to-client/session-replaced-data.json',
+  },
+  {
+    schema: SessionReplacedMessageSchema,
+    outputPath: 'schemas/server-to-client/session-replaced-message.json',
+  },
   {
     schema: ErrorNoHelloDataSchema,
     outputPath: 'schemas/server-to-client/error-no-hello-data.json',
@@ -197,6 +498,14 @@ const schemas: SchemaExport[] = [
     schema: ErrorNoHelloMessageSchema,
     outputPath: 'schemas/server-to-client/error-no-hello-message.json',
   },
+  {
+    schema: ErrorPayloadRejectedDataSchema,
+    outputPath: 'schemas/server-to-client/error-payload-rejected-data.json',
+  },
+  {
+    schema: ErrorPayloadRejectedMessageSchema,
+    outputPath: 'schemas/server-to-client/error-payload-rejected-message.json',
+  },
   {
     schema: ErrorBadRoomCodeDataSchema,
     outputPath: 'schemas/server-to-client/error-bad-room-code-data.json',
@@ -213,6 +522,14 @@ const schemas: SchemaExport[] = [
     schema: ErrorRoomFullMessageSchema,
     outputPath: 'schemas/server-to-client/error-room-full-message.json',
   },
+  {
+    schema: ErrorRoomBlockedDataSchema,
+    outputPath: 'schemas/server-to-client/error-room-blocked-data.json',
+  },
+  {
+    schema: ErrorRoomBlockedMessageSchema,
+    outputPath: 'schemas/server-to-client/error-room-blocked-message.json',
+  },
   {
     schema: PlayerLeftDataSchema,
     outputPath: 'schemas/server-to-client/player-left-data.json',
@@ -221,6 +538,50 @@ const schemas: SchemaExport[] = [
     schema: PlayerLeftMessageSchema,
     outputPath: 'schemas/server-to-client/player-left-message.json',
   },
+  {
+    schema: PlayerJoinedDataSchema,
+    outputPath: 'schemas/server-to-client/player-joined-data.json',
+  },
+  {
+    schema: PlayerJoinedMessageSchema,
+    outputPath: 'schemas/server-to-client/player-joined-message.json',
+  },
+  {
+    schema: RosterPlayerSchema,
+    outputPath: 'schemas/server-to-client/roster-player.json',
+  },
+  {
+    schema: RoomRosterDataSchema,
+    outputPath: 'schemas/server-to-client/room-roster-data.json',
+  },
+  {
+    schema: RoomRosterMessageSchema,
+    outputPath: 'schemas/server-to-client/room-roster-message.json',
+  },
+  {
+    schema: RoomReadyStateDataSchema,
+    outputPath: 'schemas/server-to-client/room-ready-state-data.json',
+  },
+  {
+    schema: RoomReadyStateMessageSchema,
+    outputPath: 'schemas/server-to-client/room-ready-state-message.json',
+  },
+  {
+    schema: PartyStateDataSchema,
+    outputPath: 'schemas/server-to-client/party-state-data.json',
+  },
+  {
+    schema: PartyStateMessageSchema,
+    outputPath: 'schemas/server-to-client/party-state-message.json',
+  },
+  {
+    schema: MatchRestartedDataSchema,
+    outputPath: 'schemas/server-to-client/match-restarted-data.json',
+  },
+  {
+    schema: MatchRestartedMessageSchema,
+    outputPath: 'schemas/server-to-client/match-restarted-message.json',
+  },
   {
     schema: PlayerStateSchema,
     outputPath: 'schemas/server-to-client/player-state.json',
@@ -249,6 +610,22 @@ const schemas: SchemaExport[] = [
     schema: ProjectileDestroyMessageSchema,
     outputPath: 'schemas/server-to-client/projectile-destroy-message.json',
   },
+  {
+    schema: ProjectileExplodeDataSchema,
+    outputPath: 'schemas/server-to-client/projectile-explode-data.json',
+  },
+  {
+    schema: ProjectileExplodeMessageSchema,
+    outputPath: 'schemas/server-to-client/projectile-explode-message.json',
+  },
+  {
+    schema: AbilityStateSchema,
+    outputPath: 'schemas/server-to-client/ability-state.json',
+  },
+  {
+    schema: WeaponStatsSchema,
+    outputPath: 'schemas/server-to-client/weapon-stats.json',
+  },
   {
     schema: WeaponStateDataSchema,
     outputPath: 'schemas/server-to-client/weapon-state-data.json',
@@ -265,6 +642,22 @@ const schemas: SchemaExport[] = [
     schema: ShootFailedMessageSchema,
     outputPath: 'schemas/server-to-client/shoot-failed-message.json',
   },
+  {
+    schema: PlayerRenamedDataSchema,
+    outputPath: 'schemas/server-to-client/player-renamed-data.json',
+  },
+  {
+    schema: PlayerRenamedMessageSchema,
+    outputPath: 'schemas/server-to-client/player-renamed-message.json',
+  },
+  {
+    schema: RenameFailedDataSchema,
+    outputPath: 'schemas/server-to-client/rename-failed-data.json',
+  },
+  {
+    schema: RenameFailedMessageSchema,
+    outputPath: 'schemas/server-to-client/rename-failed-message.json',
+  },
   {
     schema: PlayerDamagedDataSchema,
     outputPath: 'schemas/server-to-client/player-damaged-data.json',
@@ -281,6 +674,14 @@ const schemas: SchemaExport[] = [
     schema: HitConfirmedMessageSchema,
     outputPath: 'schemas/server-to-client/hit-confirmed-message.json',
   },
+  {
+    schema: HitRejectedDataSchema,
+    outputPath: 'schemas/server-to-client/hit-rejected-data.json',
+  },
+  {
+    schema: HitRejectedMessageSchema,
+    outputPath: 'schemas/server-to-client/hit-rejected-message.json',
+  },
   {
     schema: PlayerDeathDataSchema,
     outputPath: 'schemas/server-to-client/player-death-data.json',
@@ -297,6 +698,22 @@ const schemas: SchemaExport[] = [
     schema: PlayerKillCreditMessageSchema,
     outputPath: 'schemas/server-to-client/player-kill-credit-message.json',
   },
+  {
+    schema: PlayerKillstreakDataSchema,
+    outputPath: 'schemas/server-to-client/player-killstreak-data.json',
+  },
+  {
+    schema: PlayerKillstreakMessageSchema,
+    outputPath: 'schemas/server-to-client/player-killstreak-message.json',
+  },
+  {
+    schema: PlayerAssistCreditDataSchema,
+    outputPath: 'schemas/server-to-client/player-assist-credit-data.json',
+  },
+  {
+    schema: PlayerAssistCreditMessageSchema,
+    outputPath: 'schemas/server-to-client/player-assist-credit-message.json',
+  },
   {
     schema: PlayerRespawnDataSchema,
     outputPath: 'schemas/server-to-client/player-respawn-data.json',
@@ -317,6 +734,14 @@ const schemas: SchemaExport[] = [
     schema: WinnerSummarySchema,
     outputPath: 'schemas/server-to-client/winner-summary.json',
   },
+  {
+    schema: MatchSettingsSchema,
+    outputPath: 'schemas/server-to-client/match-settings.json',
+  },
+  {
+    schema: BuildInfoSchema,
+    outputPath: 'schemas/server-to-client/build-info.json',
+  },
   {
     schema: MatchEndedDataSchema,
     outputPath: 'schemas/server-to-client/match-ended-data.json',
@@ -325,6 +750,150 @@ const schemas: SchemaExport[] = [
     schema: MatchEndedMessageSchema,
     outputPath: 'schemas/server-to-client/match-ended-message.json',
   },
+  {
+    schema: PlayerMatchStatsSchema,
+    outputPath: 'schemas/server-to-client/player-match-stats.json',
+  },
+  {
+    schema: ScoreboardEntrySchema,
+    outputPath: 'schemas/server-to-client/scoreboard-entry.json',
+  },
+  {
+    schema: WorldSyncDataSchema,
+    outputPath: 'schemas/server-to-client/world-sync-data.json',
+  },
+  {
+    schema: WorldSyncMessageSchema,
+    outputPath: 'schemas/server-to-client/world-sync-message.json',
+  },
+  {
+    schema: MapObstacleSchema,
+    outputPath: 'schemas/server-to-client/map-obstacle.json',
+  },
+  {
+    schema: MapLoadDataSchema,
+    outputPath: 'schemas/server-to-client/map-load-data.json',
+  },
+  {
+    schema: MapLoadMessageSchema,
+    outputPath: 'schemas/server-to-client/map-load-message.json',
+  },
+  {
+    schema: LobbySandboxStateDataSchema,
+    outputPath: 'schemas/server-to-client/lobby-sandbox-state-data.json',
+  },
+  {
+    schema: LobbySandboxStateMessageSchema,
+    outputPath: 'schemas/server-to-client/lobby-sandbox-state-message.json',
+  },
+  {
+    schema: QueueStatusDataSchema,
+    outputPath: 'schemas/server-to-client/queue-status-data.json',
+  },
+  {
+    schema: QueueStatusMessageSchema,
+    outputPath: 'schemas/server-to-client/queue-status-message.json',
+  },
+  {
+    schema: SpawnOptionsDataSchema,
+    outputPath: 'schemas/server-to-client/spawn-options-data.json',
+  },
+  {
+    schema: SpawnOptionsMessageSchema,
+    outputPath: 'schemas/server-to-client/spawn-options-message.json',
+  },
+  {
+    schema: TeamPingDataSchema,
+    outputPath: 'schemas/server-to-client/team-ping-data.json',
+  },
+  {
+    schema: TeamPingMessageSchema,
+    outputPath: 'schemas/server-to-client/team-ping-message.json',
+  },
+  {
+    schema: PlayerEmotedDataSchema,
+    outputPath: 'schemas/server-to-client/player-emoted-data.json',
+  },
+  {
+    schema: PlayerEmotedMessageSchema,
+    outputPath: 'schemas/server-to-client/player-emoted-message.json',
+  },
+  {
+    schema: ChatPostedDataSchema,
+    outputPath: 'schemas/server-to-client/chat-posted-data.json',
+  },
+  {
+    schema: ChatPostedMessageSchema,
+    outputPath: 'schemas/server-to-client/chat-posted-message.json',
+  },
+  {
+    schema: RoomVoteKickProgressDataSchema,
+    outputPath: 'schemas/server-to-client/room-votekick-progress-data.json',
+  },
+  {
+    schema: RoomVoteKickProgressMessageSchema,
+    outputPath: 'schemas/server-to-client/room-votekick-progress-message.json',
+  },
+  {
+    schema: LeaderboardUpdateEntrySchema,
+    outputPath: 'schemas/server-to-client/leaderboard-update-entry.json',
+  },
+  {
+    schema: LeaderboardUpdateDataSchema,
+    outputPath: 'schemas/server-to-client/leaderboard-update-data.json',
+  },
+  {
+    schema: LeaderboardUpdateMessageSchema,
+    outputPath: 'schemas/server-to-client/leaderboard-update-message.json',
+  },
+  {
+    schema: PlayerLevelUpDataSchema,
+    outputPath: 'schemas/server-to-client/player-level-up-data.json',
+  },
+  {
+    schema: PlayerLevelUpMessageSchema,
+    outputPath: 'schemas/server-to-client/player-level-up-message.json',
+  },
+  {
+    schema: ServerShutdownDataSchema,
+    outputPath: 'schemas/server-to-client/server-shutdown-data.json',
+  },
+  {
+    schema: ServerShutdownMessageSchema,
+    outputPath: 'schemas/server-to-client/server-shutdown-message.json',
+  },
+  {
+    schema: RoomRedirectDataSchema,
+    outputPath: 'schemas/server-to-client/room-redirect-data.json',
+  },
+  {
+    schema: RoomRedirectMessageSchema,
+    outputPath: 'schemas/server-to-client/room-redirect-message.json',
+  },
+  {
+    schema: PracticeStatusDataSchema,
+    outputPath: 'schemas/server-to-client/practice-status-data.json',
+  },
+  {
+    schema: PracticeStatusMessageSchema,
+    outputPath: 'schemas/server-to-client/practice-status-message.json',
+  },
+  {
+    schema: NetPingDataSchema,
+    outputPath: 'schemas/server-to-client/net-ping-data.json',
+  },
+  {
+    schema: NetPingMessageSchema,
+    outputPath: 'schemas/server-to-client/net-ping-message.json',
+  },
+  {
+    schema: TimeSyncDataSchema,
+    outputPath: 'schemas/server-to-client/time-sync-data.json',
+  },
+  {
+    schema: TimeSyncMessageSchema,
+    outputPath: 'schemas/server-to-client/time-sync-message.json',
+  },
   {
     schema: WeaponCrateSchema,
     outputPath: 'schemas/server-to-client/weapon-crate.json',
@@ -353,6 +922,54 @@ const schemas: SchemaExport[] = [
     schema: WeaponRespawnedMessageSchema,
     outputPath: 'schemas/server-to-client/weapon-respawned-message.json',
   },
+  {
+    schema: ShieldCrateSchema,
+    outputPath: 'schemas/server-to-client/shield-crate.json',
+  },
+  {
+    schema: ShieldSpawnedDataSchema,
+    outputPath: 'schemas/server-to-client/shield-spawned-data.json',
+  },
+  {
+    schema: ShieldSpawnedMessageSchema,
+    outputPath: 'schemas/server-to-client/shield-spawned-message.json',
+  },
+  {
+    schema: ShieldPickupConfirmedDataSchema,
+    outputPath: 'schemas/server-to-client/shield-pickup-confirmed-data.json',
+  },
+  {
+    schema: ShieldPickupConfirmedMessageSchema,
+    outputPath: 'schemas/server-to-client/shield-pickup-confirmed-message.json',
+  },
+  {
+    schema: ShieldRespawnedDataSchema,
+    outputPath: 'schemas/server-to-client/shield-respawned-data.json',
+  },
+  {
+    schema: ShieldRespawnedMessageSchema,
+    outputPath: 'schemas/server-to-client/shield-respawned-message.json',
+  },
+  {
+    schema: HealthPackSchema,
+    outputPath: 'schemas/server-to-client/health-pack.json',
+  },
+  {
+    schema: HealthSpawnedDataSchema,
+    outputPath: 'schemas/server-to-client/health-spawned-data.json',
+  },
+  {
+    schema: HealthSpawnedMessageSchema,
+    outputPath: 'schemas/server-to-client/health-spawned-message.json',
+  },
+  {
+    schema: HealthPickupConfirmedDataSchema,
+    outputPath: 'schemas/server-to-client/health-pickup-confirmed-data.json',
+  },
+  {
+    schema: HealthPickupConfirmedMessageSchema,
+    outputPath: 'schemas/server-to-client/health-pickup-confirmed-message.json',
+  },
   {
     schema: MeleeHitDataSchema,
     outputPath: 'schemas/server-to-client/melee-hit-data.json',
@@ -377,6 +994,14 @@ const schemas: SchemaExport[] = [
     schema: RollEndMessageSchema,
     outputPath: 'schemas/server-to-client/roll-end-message.json',
   },
+  {
+    schema: UltimateActivatedDataSchema,
+    outputPath: 'schemas/server-to-client/ultimate-activated-data.json',
+  },
+  {
+    schema: UltimateActivatedMessageSchema,
+    outputPath: 'schemas/server-to-client/ultimate-activated-message.json',
+  },
   // Delta compression schemas
   {
     schema: ProjectileSnapshotSchema,
@@ -402,6 +1027,14 @@ const schemas: SchemaExport[] = [
     schema: StateDeltaMessageSchema,
     outputPath: 'schemas/server-to-client/state-delta-message.json',
   },
+  {
+    schema: EntityRemovedDataSchema,
+    outputPath: 'schemas/server-to-client/entity-removed-data.json',
+  },
+  {
+    schema: EntityRemovedMessageSchema,
+    outputPath: 'schemas/server-to-client/entity-removed-message.json',
+  },
 ];
 
 /**
@@ -17,20 +17,39 @@ export {
   PositionSchema,
   VelocitySchema,
   MessageSchema,
+  TEAM_PALETTE,
+  TeamIdSchema,
+  TeamRefSchema,
   createTypedMessageSchema,
   createTypedMessageSchemaNoData,
+  CLOSE_CODES,
+  closeReasonOf,
+  closeCodeReconnects,
   type Position,
   type Velocity,
   type Message,
+  type TeamId,
+  type TeamRef,
+  type CloseReason,
 } from './schemas/common.js';
 
 // Export client-to-server schemas and types
 export {
   PlayerHelloPublicDataSchema,
   PlayerHelloCodeDataSchema,
+  PlayerHelloPracticeDataSchema,
   PlayerHelloDataSchema,
   PlayerHelloMessageSchema,
   SessionLeaveMessageSchema,
+  RoomRosterRequestMessageSchema,
+  PlayerReadyDataSchema,
+  PlayerReadyMessageSchema,
+  PartyStayTogetherDataSchema,
+  PartyStayTogetherMessageSchema,
+  MatchRematchVoteDataSchema,
+  MatchRematchVoteMessageSchema,
+  PlayerPreferencesDataSchema,
+  PlayerPreferencesMessageSchema,
   InputStateDataSchema,
   InputStateMessageSchema,
   PlayerShootDataSchema,
@@ -41,9 +60,44 @@ export {
   PlayerMeleeAttackDataSchema,
   PlayerMeleeAttackMessageSchema,
   PlayerDodgeRollMessageSchema,
+  PlayerUltimateMessageSchema,
+  PlayerRespawnRequestMessageSchema,
+  PlayerSpawnChoiceDataSchema,
+  PlayerSpawnChoiceMessageSchema,
+  PlayerPingDataSchema,
+  PlayerPingMessageSchema,
+  PlayerEmoteDataSchema,
+  PlayerEmoteMessageSchema,
+  ChatMessageDataSchema,
+  ChatMessageMessageSchema,
+  ChatMuteDataSchema,
+  ChatMuteMessageSchema,
+  RoomVoteKickDataSchema,
+  RoomVoteKickMessageSchema,
+  PlayerLoadoutDataSchema,
+  PlayerLoadoutMessageSchema,
+  PlayerRenameDataSchema,
+  PlayerRenameMessageSchema,
+  StateAckDataSchema,
+  StateAckMessageSchema,
+  FeedbackSubmitDataSchema,
+  FeedbackSubmitMessageSchema,
+  NetPongDataSchema,
+  NetPongMessageSchema,
+  TimeSyncRequestDataSchema,
+  TimeSyncRequestMessageSchema,
   type PlayerHelloData,
   type PlayerHelloMessage,
   type SessionLeaveMessage,
+  type RoomRosterRequestMessage,
+  type PlayerReadyData,
+  type PlayerReadyMessage,
+  type PartyStayTogetherData,
+  type PartyStayTogetherMessage,
+  type MatchRematchVoteData,
+  type MatchRematchVoteMessage,
+  type PlayerPreferencesData,
+  type PlayerPreferencesMessage,
   type InputStateData,
   type InputStateMessage,
   type PlayerShootData,
@@ -54,6 +108,32 @@ export {
   type PlayerMeleeAttackData,
   type PlayerMeleeAttackMessage,
   type PlayerDodgeRollMessage,
+  type PlayerUltimateMessage,
+  type PlayerRespawnRequestMessage,
+  type PlayerSpawnChoiceData,
+  type PlayerSpawnChoiceMessage,
+  type PlayerPingData,
+  type PlayerPingMessage,
+  type PlayerEmoteData,
+  type PlayerEmoteMessage,
+  type ChatMessageData,
+  type ChatMessageMessage,
+  type ChatMuteData,
+  type ChatMuteMessage,
+  type RoomVoteKickData,
+  type RoomVoteKickMessage,
+  type PlayerLoadoutData,
+  type PlayerLoadoutMessage,
+  type PlayerRenameData,
+  type PlayerRenameMessage,
+  type StateAckData,
+  type StateAckMessage,
+  type FeedbackSubmitData,
+  type FeedbackSubmitMessage,
+  type NetPongData,
+  type NetPongMessage,
+  type TimeSyncRequestData,
+  type TimeSyncRequestMessage,
 } from './schemas/client-to-server.js';
 
 // Export server-to-client schemas and types
@@ -61,16 +141,37 @@ export {
   SessionStatusStateSchema,
   SessionStatusDataSchema,
   SessionStatusMessageSchema,
+  SessionCapacityDataSchema,
+  SessionCapacityMessageSchema,
   RoomJoinedDataSchema,
   RoomJoinedMessageSchema,
+  ServerHelloDataSchema,
+  ServerHelloMessageSchema,
+  SessionReplacedDataSchema,
+  SessionReplacedMessageSchema,
   ErrorNoHelloDataSchema,
   ErrorNoHelloMessageSchema,
+  ErrorPayloadRejectedDataSchema,
+  ErrorPayloadRejectedMessageSchema,
   ErrorBadRoomCodeDataSchema,
   ErrorBadRoomCodeMessageSchema,
   ErrorRoomFullDataSchema,
   ErrorRoomFullMessageSchema,
+  ErrorRoomBlockedDataSchema,
+  ErrorRoomBlockedMessageSchema,
   PlayerLeftDataSchema,
   PlayerLeftMessageSchema,
+  PlayerJoinedDataSchema,
+  PlayerJoinedMessageSchema,
+  RosterPlayerSchema,
+  RoomRosterDataSchema,
+  RoomRosterMessageSchema,
+  RoomReadyStateDataSchema,
+  RoomReadyStateMessageSchema,
+  PartyStateDataSchema,
+  PartyStateMessageSchema,
+  MatchRestartedDataSchema,
+  MatchRestartedMessageSchema,
   PlayerStateSchema,
   PlayerMoveDataSchema,
   PlayerMoveMessageSchema,
@@ -78,26 +179,78 @@ export {
   ProjectileSpawnMessageSchema,
   ProjectileDestroyDataSchema,
   ProjectileDestroyMessageSchema,
+  ProjectileExplodeDataSchema,
+  ProjectileExplodeMessageSchema,
+  AbilityStateSchema,
+  WeaponStatsSchema,
   WeaponStateDataSchema,
   WeaponStateMessageSchema,
   ShootFailedDataSchema,
   ShootFailedMessageSchema,
+  PlayerRenamedDataSchema,
+  PlayerRenamedMessageSchema,
+  RenameFailedDataSchema,
+  RenameFailedMessageSchema,
   PlayerDamagedDataSchema,
   PlayerDamagedMessageSchema,
   HitConfirmedDataSchema,
   HitConfirmedMessageSchema,
+  HitRejectedDataSchema,
+  HitRejectedMessageSchema,
   PlayerDeathDataSchema,
   PlayerDeathMessageSchema,
   PlayerKillCreditDataSchema,
   PlayerKillCreditMessageSchema,
+  PlayerKillstreakDataSchema,
+  PlayerKillstreakMessageSchema,
+  PlayerAssistCreditDataSchema,
+  PlayerAssistCreditMessageSchema,
   PlayerRespawnDataSchema,
   PlayerRespawnMessageSchema,
   MatchTimerDataSchema,
   MatchTimerMessageSchema,
   WinnerSummarySchema,
   PlayerScoreSchema,
+  PlayerMatchStatsSchema,
+  MatchSettingsSchema,
+  BuildInfoSchema,
   MatchEndedDataSchema,
   MatchEndedMessageSchema,
+  ScoreboardEntrySchema,
+  WorldSyncDataSchema,
+  WorldSyncMessageSchema,
+  MapObstacleSchema,
+  MapLoadDataSchema,
+  MapLoadMessageSchema,
+  LobbySandboxStateDataSchema,
+  LobbySandboxStateMessageSchema,
+  QueueStatusDataSchema,
+  QueueStatusMessageSchema,
+  SpawnOptionsDataSchema,
+  SpawnOptionsMessageSchema,
+  TeamPingDataSchema,
+  TeamPingMessageSchema,
+  PlayerEmotedDataSchema,
+  PlayerEmotedMessageSchema,
+  ChatPostedDataSchema,
+  ChatPostedMessageSchema,
+  RoomVoteKickProgressDataSchema,
+  RoomVoteKickProgressMessageSchema,
+  LeaderboardUpdateEntrySchema,
+  LeaderboardUpdateDataSchema,
+  LeaderboardUpdateMessageSchema,
+  PlayerLevelUpDataSchema,
+  PlayerLevelUpMessageSchema,
+  ServerShutdownDataSchema,
+  ServerShutdownMessageSchema,
+  RoomRedirectDataSchema,
+  RoomRedirectMessageSchema,
+  PracticeStatusDataSchema,
+  PracticeStatusMessageSchema,
+  NetPingDataSchema,
+  NetPingMessageSchema,
+  TimeSyncDataSchema,
+  TimeSyncMessageSchema,
   WeaponCrateSchema,
   WeaponSpawnedDataSchema,
   WeaponSpawnedMessageSchema,
@@ -105,30 +258,67 @@ export {
   WeaponPickupConfirmedMessageSchema,
   WeaponRespawnedDataSchema,
   WeaponRespawnedMessageSchema,
+  ShieldCrateSchema,
+  ShieldSpawnedDataSchema,
+  ShieldSpawnedMessageSchema,
+  ShieldPickupConfirmedDataSchema,
+  ShieldPickupConfirmedMessageSchema,
+  ShieldRespawnedDataSchema,
+  ShieldRespawnedMessageSchema,
+  HealthPackSchema,
+  HealthSpawnedDataSchema,
+  HealthSpawnedMessageSchema,
+  HealthPickupConfirmedDataSchema,
+  HealthPickupConfirmedMessageSchema,
   MeleeHitDataSchema,
   MeleeHitMessageSchema,
   RollStartDataSchema,
   RollStartMessageSchema,
   RollEndDataSchema,
   RollEndMessageSchema,
+  UltimateActivatedDataSchema,
+  UltimateActivatedMessageSchema,
   ProjectileSnapshotSchema,
   WeaponCrateSnapshotSchema,
   StateSnapshotDataSchema,
   StateSnapshotMessageSchema,
   StateDeltaDataSchema,
   StateDeltaMessageSchema,
+  EntityRemovedDataSchema,
+  EntityRemovedMessageSchema,
   type SessionStatusData,
   type SessionStatusMessage,
+  type SessionCapacityData,
+  type SessionCapacityMessage,
   type RoomJoinedData,
   type RoomJoinedMessage,
+  type ServerHelloData,
+  type ServerHelloMessage,
+  type SessionReplacedData,
+  type SessionReplacedMessage,
   type ErrorNoHelloData,
   type ErrorNoHelloMessage,
+  type ErrorPayloadRejectedData,
+  type ErrorPayloadRejectedMessage,
   type ErrorBadRoomCodeData,
   type ErrorBadRoomCodeMessage,
   type ErrorRoomFullData,
   type ErrorRoomFullMessage,
+  type ErrorRoomBlockedData,
+  type ErrorRoomBlockedMessage,
   type PlayerLeftData,
   type PlayerLeftMessage,
+  type PlayerJoinedData,
+  type PlayerJoinedMessage,
+  type RosterPlayer,
+  type RoomRosterData,
+  type RoomRosterMessage,
+  type RoomReadyStateData,
+  type RoomReadyStateMessage,
+  type PartyStateData,
+  type PartyStateMessage,
+  type MatchRestartedData,
+  type MatchRestartedMessage,
   type PlayerState,
   type PlayerMoveData,
   type PlayerMoveMessage,
@@ -136,26 +326,78 @@ export {
   type ProjectileSpawnMessage,
   type ProjectileDestroyData,
   type ProjectileDestroyMessage,
+  type ProjectileExplodeData,
+  type ProjectileExplodeMessage,
+  type AbilityState,
+  type WeaponStats,
   type WeaponStateData,
   type WeaponStateMessage,
   type ShootFailedData,
   type ShootFailedMessage,
+  type PlayerRenamedData,
+  type PlayerRenamedMessage,
+  type RenameFailedData,
+  type RenameFailedMessage,
   type PlayerDamagedData,
   type PlayerDamagedMessage,
   type HitConfirmedData,
   type HitConfirmedMessage,
+  type HitRejectedData,
+  type HitRejectedMessage,
   type PlayerDeathData,
   type PlayerDeathMessage,
   type PlayerKillCreditData,
   type PlayerKillCreditMessage,
+  type PlayerKillstreakData,
+  type PlayerKillstreakMessage,
+  type PlayerAssistCreditData,
+  type PlayerAssistCreditMessage,
   type PlayerRespawnData,
   type PlayerRespawnMessage,
   type MatchTimerData,
   type MatchTimerMessage,
   type WinnerSummary,
   type PlayerScore,
+  type PlayerMatchStats,
+  type MatchSettings,
+  type BuildInfo,
   type MatchEndedData,
   type MatchEndedMessage,
+  type ScoreboardEntry,
+  type WorldSyncData,
+  type WorldSyncMessage,
+  type MapObstacle,
+  type MapLoadData,
+  type MapLoadMessage,
+  type LobbySandboxStateData,
+  type LobbySandboxStateMessage,
+  type QueueStatusData,
+  type QueueStatusMessage,
+  type SpawnOptionsData,
+  type SpawnOptionsMessage,
+  type TeamPingData,
+  type TeamPingMessage,
+  type PlayerEmotedData,
+  type PlayerEmotedMessage,
+  type ChatPostedData,
+  type ChatPostedMessage,
+  type RoomVoteKickProgressData,
+  type RoomVoteKickProgressMessage,
+  type LeaderboardUpdateEntry,
+  type LeaderboardUpdateData,
+  type LeaderboardUpdateMessage,
+  type PlayerLevelUpData,
+  type PlayerLevelUpMessage,
+  type ServerShutdownData,
+  type ServerShutdownMessage,
+  type RoomRedirectData,
+  type RoomRedirectMessage,
+  type PracticeStatusData,
+  type PracticeStatusMessage,
+  type NetPingData,
+  type NetPingMessage,
+  type TimeSyncData,
+  type TimeSyncMessage,
   type WeaponCrate,
   type WeaponSpawnedData,
   type WeaponSpawnedMessage,
@@ -163,16 +405,32 @@ export {
   type WeaponPickupConfirmedMessage,
   type WeaponRespawnedData,
   type WeaponRespawnedMessage,
+  type ShieldCrate,
+  type ShieldSpawnedData,
+  type ShieldSpawnedMessage,
+  type ShieldPickupConfirmedData,
+  type ShieldPickupConfirmedMessage,
+  type ShieldRespawnedData,
+  type ShieldRespawnedMessage,
+  type HealthPack,
+  type HealthSpawnedData,
+  type HealthSpawnedMessage,
+  type HealthPickupConfirmedData,
+  type HealthPickupConfirmedMessage,
   type MeleeHitData,
   type MeleeHitMessage,
   type RollStartData,
   type RollStartMessage,
   type RollEndData,
   type RollEndMessage,
+  type UltimateActivatedData,
+  type UltimateActivatedMessage,
   type ProjectileSnapshot,
   type WeaponCrateSnapshot,
   type StateSnapshotData,
   type StateSnapshotMessage,
   type StateDeltaData,
   type StateDeltaMessage,
+  type EntityRemovedData,
+  type EntityRemovedMessage,
 } from './schemas/server-to-client.js';
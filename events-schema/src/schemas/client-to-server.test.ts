@@ -6,11 +6,46 @@ import Ajv from 'ajv';
 import {
   PlayerHelloMessageSchema,
   SessionLeaveMessageSchema,
+  RoomRosterRequestMessageSchema,
+  PlayerReadyDataSchema,
+  PlayerReadyMessageSchema,
+  PartyStayTogetherDataSchema,
+  PartyStayTogetherMessageSchema,
+  MatchRematchVoteDataSchema,
+  MatchRematchVoteMessageSchema,
+  PlayerPreferencesDataSchema,
+  PlayerPreferencesMessageSchema,
   InputStateDataSchema,
   InputStateMessageSchema,
   PlayerShootDataSchema,
   PlayerShootMessageSchema,
   PlayerReloadMessageSchema,
+  PlayerUltimateMessageSchema,
+  PlayerRespawnRequestMessageSchema,
+  PlayerSpawnChoiceDataSchema,
+  PlayerSpawnChoiceMessageSchema,
+  PlayerPingDataSchema,
+  PlayerPingMessageSchema,
+  PlayerEmoteDataSchema,
+  PlayerEmoteMessageSchema,
+  ChatMessageDataSchema,
+  ChatMessageMessageSchema,
+  ChatMuteDataSchema,
+  ChatMuteMessageSchema,
+  RoomVoteKickDataSchema,
+  RoomVoteKickMessageSchema,
+  PlayerLoadoutDataSchema,
+  PlayerLoadoutMessageSchema,
+  PlayerRenameDataSchema,
+  PlayerRenameMessageSchema,
+  StateAckDataSchema,
+  StateAckMessageSchema,
+  NetPongDataSchema,
+  NetPongMessageSchema,
+  TimeSyncRequestDataSchema,
+  TimeSyncRequestMessageSchema,
+  FeedbackSubmitDataSchema,
+  FeedbackSubmitMessageSchema,
   WeaponPickupAttemptDataSchema,
   WeaponPickupAttemptMessageSchema,
   PlayerMeleeAttackDataSchema,
@@ -21,6 +56,10 @@ import {
   type PlayerShootMessage,
   type SessionLeaveMessage,
   type PlayerReloadMessage,
+  type PlayerUltimateMessage,
+  type PlayerRespawnRequestMessage,
+  type PlayerLoadoutMessage,
+  type StateAckMessage,
   type WeaponPickupAttemptData,
   type WeaponPickupAttemptMessage,
   type PlayerMeleeAttackData,
@@ -51,6 +90,106 @@ describe('Client-to-Server Schemas', () => {
     });
   });
 
+  describe('RoomRosterRequestMessageSchema', () => {
+    const validate = ajv.compile(RoomRosterRequestMessageSchema);
+
+    it('should validate a room:roster_request message without data', () => {
+      expect(validate({
+        type: 'room:roster_request',
+        timestamp: Date.now(),
+      })).toBe(true);
+    });
+
+    it('should reject a room:roster_request message with the wrong type', () => {
+      expect(validate({
+        type: 'session:leave',
+        timestamp: Date.now(),
+      })).toBe(false);
+    });
+  });
+
+  describe('PlayerReadySchemas', () => {
+    const validateData = ajv.compile(PlayerReadyDataSchema);
+    const validateMessage = ajv.compile(PlayerReadyMessageSchema);
+
+    it('should validate ready and unready votes', () => {
+      expect(validateData({ ready: true })).toBe(true);
+      expect(validateData({ ready: false })).toBe(true);
+      expect(validateMessage({
+        type: 'player:ready',
+        timestamp: Date.now(),
+        data: { ready: true },
+      })).toBe(true);
+    });
+
+    it('should reject votes without a boolean ready flag', () => {
+      expect(validateData({})).toBe(false);
+      expect(validateData({ ready: 'yes' })).toBe(false);
+    });
+  });
+
+  describe('PartyStayTogetherSchemas', () => {
+    const validateData = ajv.compile(PartyStayTogetherDataSchema);
+    const validateMessage = ajv.compile(PartyStayTogetherMessageSchema);
+
+    it('should validate stay and leave votes', () => {
+      expect(validateData({ stay: true })).toBe(true);
+      expect(validateData({ stay: false })).toBe(true);
+      expect(validateMessage({
+        type: 'party:stay_together',
+        timestamp: Date.now(),
+        data: { stay: true },
+      })).toBe(true);
+    });
+
+    it('should reject votes without a boolean stay flag', () => {
+      expect(validateData({})).toBe(false);
+      expect(validateData({ stay: 'yes' })).toBe(false);
+    });
+  });
+
+  describe('MatchRematchVoteSchemas', () => {
+    const validateData = ajv.compile(MatchRematchVoteDataSchema);
+    const validateMessage = ajv.compile(MatchRematchVoteMessageSchema);
+
+    it('should validate yes and no votes', () => {
+      expect(validateData({ vote: true })).toBe(true);
+      expect(validateData({ vote: false })).toBe(true);
+      expect(validateMessage({
+        type: 'match:rematch_vote',
+        timestamp: Date.now(),
+        data: { vote: true },
+      })).toBe(true);
+    });
+
+    it('should reject votes without a boolean vote flag', () => {
+      expect(validateData({})).toBe(false);
+      expect(validateData({ vote: 'yes' })).toBe(false);
+    });
+  });
+
+  describe('PlayerPreferencesSchemas', () => {
+    const validateData = ajv.compile(PlayerPreferencesDataSchema);
+    const validateMessage = ajv.compile(PlayerPreferencesMessageSchema);
+
+    it('should validate opting out of cosmetic broadcasts', () => {
+      expect(validateData({ optOut: ['melee:hit'] })).toBe(true);
+      expect(validateData({ optOut: ['melee:hit', 'player:emoted'] })).toBe(true);
+      expect(validateData({ optOut: [] })).toBe(true);
+      expect(validateMessage({
+        type: 'player:preferences',
+        timestamp: Date.now(),
+        data: { optOut: ['melee:hit'] },
+      })).toBe(true);
+    });
+
+    it('should reject opting out of gameplay broadcasts', () => {
+      expect(validateData({ optOut: ['player:damaged'] })).toBe(false);
+      expect(validateData({ optOut: ['melee:hit', 'melee:hit'] })).toBe(false);
+      expect(validateData({})).toBe(false);
+    });
+  });
+
   describe('PlayerHelloMessageSchema', () => {
     const validate = ajv.compile(PlayerHelloMessageSchema);
 
@@ -64,6 +203,22 @@ describe('Client-to-Server Schemas', () => {
         },
       })).toBe(true);
     });
+
+    it('should validate a practice hello', () => {
+      expect(validate({ type: 'player:hello', timestamp: Date.now(), data: { mode: 'practice' } })).toBe(true);
+    });
+
+    it('should reject an unknown mode', () => {
+      expect(validate({ type: 'player:hello', timestamp: Date.now(), data: { mode: 'ranked' } })).toBe(false);
+    });
+
+    it('should validate a hello resent after room:redirect', () => {
+      expect(validate({
+        type: 'player:hello',
+        timestamp: Date.now(),
+        data: { mode: 'code', code: 'pizza', redirected: true },
+      })).toBe(true);
+    });
   });
 
   describe('InputStateDataSchema', () => {
@@ -133,19 +288,17 @@ describe('Client-to-Server Schemas', () => {
       });
     });
 
-    it('should require sequence number', () => {
-      const invalidData = {
+    it('should accept input without a sequence number from clients without prediction', () => {
+      const validData = {
         up: true,
         down: false,
         left: false,
         right: true,
         aimAngle: 1.57,
         isSprinting: false,
-        // missing sequence
       };
 
-      expect(validate(invalidData)).toBe(false);
-      expect(validate.errors).toBeDefined();
+      expect(validate(validData)).toBe(true);
     });
 
     it('should reject negative sequence numbers', () => {
@@ -343,6 +496,26 @@ describe('Client-to-Server Schemas', () => {
       });
     });
 
+    it('should accept an optional effectId', () => {
+      const validData: PlayerShootData = {
+        aimAngle: 1.57,
+        clientTimestamp: 0,
+        effectId: 'ember',
+      };
+
+      expect(validate(validData)).toBe(true);
+    });
+
+    it('should reject an empty effectId', () => {
+      const invalidData = {
+        aimAngle: 1.57,
+        clientTimestamp: 0,
+        effectId: '',
+      };
+
+      expect(validate(invalidData)).toBe(false);
+    });
+
     it('should accept clientTimestamp of 0', () => {
       const validData = {
         aimAngle: 1.57,
@@ -419,6 +592,303 @@ describe('Client-to-Server Schemas', () => {
     });
   });
 
+  describe('PlayerUltimateMessageSchema', () => {
+    const validate = ajv.compile(PlayerUltimateMessageSchema);
+
+    it('should validate player:ultimate message without data', () => {
+      const validMessage: PlayerUltimateMessage = {
+        type: 'player:ultimate',
+        timestamp: Date.now(),
+      };
+
+      expect(validate(validMessage)).toBe(true);
+      expect(validate.errors).toBeNull();
+    });
+
+    it('should reject message with wrong type', () => {
+      const invalidMessage = {
+        type: 'player:reload',
+        timestamp: Date.now(),
+      };
+
+      expect(validate(invalidMessage)).toBe(false);
+    });
+  });
+
+  describe('PlayerRespawnRequestMessageSchema', () => {
+    const validate = ajv.compile(PlayerRespawnRequestMessageSchema);
+
+    it('should validate player:respawn_request message without data', () => {
+      const validMessage: PlayerRespawnRequestMessage = {
+        type: 'player:respawn_request',
+        timestamp: Date.now(),
+      };
+
+      expect(validate(validMessage)).toBe(true);
+      expect(validate.errors).toBeNull();
+    });
+
+    it('should reject message with wrong type', () => {
+      const invalidMessage = {
+        type: 'player:respawn',
+        timestamp: Date.now(),
+      };
+
+      expect(validate(invalidMessage)).toBe(false);
+    });
+  });
+
+  describe('PlayerLoadoutSchemas', () => {
+    const validateData = ajv.compile(PlayerLoadoutDataSchema);
+    const validateMessage = ajv.compile(PlayerLoadoutMessageSchema);
+
+    it('should validate each class', () => {
+      for (const playerClass of ['heavy', 'scout', 'gunner']) {
+        expect(validateData({ class: playerClass })).toBe(true);
+      }
+    });
+
+    it('should reject unknown or missing classes', () => {
+      expect(validateData({ class: 'medic' })).toBe(false);
+      expect(validateData({})).toBe(false);
+    });
+
+    it('should validate complete player:loadout message', () => {
+      const validMessage: PlayerLoadoutMessage = {
+        type: 'player:loadout',
+        timestamp: Date.now(),
+        data: { class: 'scout' },
+      };
+
+      expect(validateMessage(validMessage)).toBe(true);
+    });
+  });
+
+  describe('PlayerSpawnChoiceSchemas', () => {
+    const validateData = ajv.compile(PlayerSpawnChoiceDataSchema);
+    const validateMessage = ajv.compile(PlayerSpawnChoiceMessageSchema);
+
+    it('should validate a spawn point index', () => {
+      expect(validateData({ index: 0 })).toBe(true);
+      expect(validateData({ index: 3 })).toBe(true);
+    });
+
+    it('should reject negative or fractional indexes', () => {
+      expect(validateData({ index: -1 })).toBe(false);
+      expect(validateData({ index: 1.5 })).toBe(false);
+      expect(validateData({})).toBe(false);
+    });
+
+    it('should validate complete player:spawn_choice message', () => {
+      expect(validateMessage({ type: 'player:spawn_choice', timestamp: Date.now(), data: { index: 1 } })).toBe(true);
+    });
+  });
+
+  describe('PlayerPingSchemas', () => {
+    const validateData = ajv.compile(PlayerPingDataSchema);
+    const validateMessage = ajv.compile(PlayerPingMessageSchema);
+
+    it('should validate every ping type', () => {
+      for (const type of ['go', 'enemy', 'danger', 'loot']) {
+        expect(validateData({ type, position: { x: 640, y: 360 } })).toBe(true);
+      }
+    });
+
+    it('should reject unknown types and missing positions', () => {
+      expect(validateData({ type: 'nuke', position: { x: 640, y: 360 } })).toBe(false);
+      expect(validateData({ type: 'go' })).toBe(false);
+      expect(validateData({ type: 'go', position: { x: 640 } })).toBe(false);
+    });
+
+    it('should validate complete player:ping message', () => {
+      expect(
+        validateMessage({ type: 'player:ping', timestamp: Date.now(), data: { type: 'enemy', position: { x: 1, y: 2 } } })
+      ).toBe(true);
+    });
+  });
+
+  describe('PlayerEmoteSchemas', () => {
+    const validateData = ajv.compile(PlayerEmoteDataSchema);
+    const validateMessage = ajv.compile(PlayerEmoteMessageSchema);
+
+    it('should validate every emote and quick-chat line', () => {
+      for (const emote of ['wave', 'laugh', 'taunt', 'salute', 'gg', 'nice_shot', 'thanks', 'sorry']) {
+        expect(validateData({ emote })).toBe(true);
+      }
+    });
+
+    it('should reject free text', () => {
+      expect(validateData({ emote: 'anything I like' })).toBe(false);
+      expect(validateData({})).toBe(false);
+    });
+
+    it('should validate complete player:emote message', () => {
+      expect(validateMessage({ type: 'player:emote', timestamp: Date.now(), data: { emote: 'gg' } })).toBe(true);
+    });
+  });
+
+  describe('ChatSchemas', () => {
+    const validateMessageData = ajv.compile(ChatMessageDataSchema);
+    const validateMuteData = ajv.compile(ChatMuteDataSchema);
+
+    it('should validate chat text up to 200 characters', () => {
+      expect(validateMessageData({ text: 'gl hf' })).toBe(true);
+      expect(validateMessageData({ text: 'a'.repeat(200) })).toBe(true);
+      expect(validateMessageData({ text: 'a'.repeat(201) })).toBe(false);
+      expect(validateMessageData({ text: '' })).toBe(false);
+    });
+
+    it('should validate the all and team channels', () => {
+      expect(validateMessageData({ text: 'push B', channel: 'team' })).toBe(true);
+      expect(validateMessageData({ text: 'gg', channel: 'all' })).toBe(true);
+      expect(validateMessageData({ text: 'gg', channel: 'party' })).toBe(false);
+    });
+
+    it('should validate muting and unmuting a player', () => {
+      expect(validateMuteData({ playerId: 'player-2', muted: true })).toBe(true);
+      expect(validateMuteData({ playerId: 'player-2', muted: false })).toBe(true);
+      expect(validateMuteData({ playerId: 'player-2' })).toBe(false);
+    });
+
+    it('should validate complete chat messages', () => {
+      expect(ajv.compile(ChatMessageMessageSchema)({ type: 'chat:message', timestamp: Date.now(), data: { text: 'gg' } })).toBe(true);
+      expect(
+        ajv.compile(ChatMuteMessageSchema)({ type: 'chat:mute', timestamp: Date.now(), data: { playerId: 'p', muted: true } })
+      ).toBe(true);
+    });
+  });
+
+  describe('RoomVoteKickSchemas', () => {
+    const validateData = ajv.compile(RoomVoteKickDataSchema);
+    const validateMessage = ajv.compile(RoomVoteKickMessageSchema);
+
+    it('should validate a vote against a player', () => {
+      expect(validateData({ playerId: 'player-2' })).toBe(true);
+      expect(validateData({ playerId: '' })).toBe(false);
+      expect(validateData({})).toBe(false);
+    });
+
+    it('should validate complete room:votekick message', () => {
+      expect(validateMessage({ type: 'room:votekick', timestamp: Date.now(), data: { playerId: 'player-2' } })).toBe(true);
+    });
+  });
+
+  describe('PlayerRenameSchemas', () => {
+    const validateData = ajv.compile(PlayerRenameDataSchema);
+    const validateMessage = ajv.compile(PlayerRenameMessageSchema);
+
+    it('should validate a requested name', () => {
+      expect(validateData({ displayName: 'Stickman' })).toBe(true);
+    });
+
+    it('should reject a missing name', () => {
+      expect(validateData({})).toBe(false);
+      expect(validateData({ displayName: 7 })).toBe(false);
+    });
+
+    it('should reject a name longer than 64 characters', () => {
+      expect(validateData({ displayName: 'x'.repeat(64) })).toBe(true);
+      expect(validateData({ displayName: 'x'.repeat(65) })).toBe(false);
+    });
+
+    it('should validate complete player:rename message', () => {
+      expect(
+        validateMessage({ type: 'player:rename', timestamp: Date.now(), data: { displayName: 'Stickman' } })
+      ).toBe(true);
+    });
+  });
+
+  describe('StateAckSchemas', () => {
+    const validateData = ajv.compile(StateAckDataSchema);
+    const validateMessage = ajv.compile(StateAckMessageSchema);
+
+    it('should validate a positive sequence', () => {
+      expect(validateData({ seq: 42 })).toBe(true);
+    });
+
+    it('should reject missing, zero or fractional sequences', () => {
+      expect(validateData({})).toBe(false);
+      expect(validateData({ seq: 0 })).toBe(false);
+      expect(validateData({ seq: 1.5 })).toBe(false);
+    });
+
+    it('should validate complete state:ack message', () => {
+      const validMessage: StateAckMessage = {
+        type: 'state:ack',
+        timestamp: Date.now(),
+        data: { seq: 7 },
+      };
+
+      expect(validateMessage(validMessage)).toBe(true);
+    });
+  });
+
+  describe('FeedbackSubmitSchemas', () => {
+    const validateData = ajv.compile(FeedbackSubmitDataSchema);
+    const validateMessage = ajv.compile(FeedbackSubmitMessageSchema);
+
+    it('should validate a rating with or without text', () => {
+      expect(validateData({ rating: 4 })).toBe(true);
+      expect(validateData({ rating: 1, text: 'Shotgun feels too strong' })).toBe(true);
+    });
+
+    it('should reject ratings outside 1-5 and overlong text', () => {
+      expect(validateData({})).toBe(false);
+      expect(validateData({ rating: 0 })).toBe(false);
+      expect(validateData({ rating: 6 })).toBe(false);
+      expect(validateData({ rating: 2.5 })).toBe(false);
+      expect(validateData({ rating: 3, text: 'x'.repeat(501) })).toBe(false);
+    });
+
+    it('should validate complete feedback:submit message', () => {
+      expect(
+        validateMessage({ type: 'feedback:submit', timestamp: Date.now(), data: { rating: 5, text: 'Great map' } })
+      ).toBe(true);
+    });
+  });
+
+  describe('NetPongSchemas', () => {
+    const validateData = ajv.compile(NetPongDataSchema);
+    const validateMessage = ajv.compile(NetPongMessageSchema);
+
+    it('should validate an echoed ping', () => {
+      expect(validateData({ id: 1, serverTime: 1760000000000 })).toBe(true);
+    });
+
+    it('should reject missing fields and zero IDs', () => {
+      expect(validateData({ id: 1 })).toBe(false);
+      expect(validateData({ serverTime: 1760000000000 })).toBe(false);
+      expect(validateData({ id: 0, serverTime: 1760000000000 })).toBe(false);
+    });
+
+    it('should validate complete net:pong message', () => {
+      expect(
+        validateMessage({ type: 'net:pong', timestamp: Date.now(), data: { id: 2, serverTime: 1760000000000 } })
+      ).toBe(true);
+    });
+  });
+
+  describe('TimeSyncRequestSchemas', () => {
+    const validateData = ajv.compile(TimeSyncRequestDataSchema);
+    const validateMessage = ajv.compile(TimeSyncRequestMessageSchema);
+
+    it('should validate a client send time', () => {
+      expect(validateData({ clientTime: 1760000000000 })).toBe(true);
+      expect(validateData({ clientTime: 5123.25 })).toBe(true);
+    });
+
+    it('should reject a missing or negative client time', () => {
+      expect(validateData({})).toBe(false);
+      expect(validateData({ clientTime: -1 })).toBe(false);
+    });
+
+    it('should validate complete time:sync_request message', () => {
+      expect(
+        validateMessage({ type: 'time:sync_request', timestamp: Date.now(), data: { clientTime: 1760000000000 } })
+      ).toBe(true);
+    });
+  });
+
   describe('WeaponPickupAttemptDataSchema', () => {
     const validate = ajv.compile(WeaponPickupAttemptDataSchema);
 
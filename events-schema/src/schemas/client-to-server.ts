@@ -7,25 +7,42 @@ import { createTypedMessageSchema, createTypedMessageSchemaNoData } from './comm
 
 export const PlayerHelloPublicDataSchema = Type.Object(
   {
-    displayName: Type.Optional(Type.String({ description: 'Requested display name before server sanitization' })),
+    displayName: Type.Optional(Type.String({ description: 'Requested display name before server sanitization', maxLength: 64 })),
     mode: Type.Literal('public'),
+    redirected: Type.Optional(
+      Type.Boolean({ description: 'Set when resending the hello after room:redirect; the instance then hosts the player' })
+    ),
   },
   { $id: 'PlayerHelloPublicData', description: 'Public matchmaking hello payload' }
 );
 
 export const PlayerHelloCodeDataSchema = Type.Object(
   {
-    displayName: Type.Optional(Type.String({ description: 'Requested display name before server sanitization' })),
+    displayName: Type.Optional(Type.String({ description: 'Requested display name before server sanitization', maxLength: 64 })),
     mode: Type.Literal('code'),
-    code: Type.String({ description: 'Raw room code before server normalization', minLength: 1 }),
+    code: Type.String({ description: 'Raw room code before server normalization', minLength: 1, maxLength: 32 }),
+    redirected: Type.Optional(
+      Type.Boolean({ description: 'Set when resending the hello after room:redirect; the instance then hosts the player' })
+    ),
   },
   { $id: 'PlayerHelloCodeData', description: 'Named-room hello payload' }
 );
 
-export const PlayerHelloDataSchema = Type.Union([PlayerHelloPublicDataSchema, PlayerHelloCodeDataSchema], {
-  $id: 'PlayerHelloData',
-  description: 'Join intent payload',
-});
+export const PlayerHelloPracticeDataSchema = Type.Object(
+  {
+    displayName: Type.Optional(Type.String({ description: 'Requested display name before server sanitization', maxLength: 64 })),
+    mode: Type.Literal('practice'),
+  },
+  { $id: 'PlayerHelloPracticeData', description: 'Solo practice room hello payload' }
+);
+
+export const PlayerHelloDataSchema = Type.Union(
+  [PlayerHelloPublicDataSchema, PlayerHelloCodeDataSchema, PlayerHelloPracticeDataSchema],
+  {
+    $id: 'PlayerHelloData',
+    description: 'Join intent payload',
+  }
+);
 
 export type PlayerHelloData = Static<typeof PlayerHelloDataSchema>;
 
@@ -45,6 +62,93 @@ export type PlayerHelloMessage = Static<typeof PlayerHelloMessageSchema>;
 export const SessionLeaveMessageSchema = createTypedMessageSchemaNoData('session:leave');
 export type SessionLeaveMessage = Static<typeof SessionLeaveMessageSchema>;
 
+/**
+ * Complete room:roster_request message schema (no data payload)
+ */
+export const RoomRosterRequestMessageSchema = createTypedMessageSchemaNoData('room:roster_request');
+export type RoomRosterRequestMessage = Static<typeof RoomRosterRequestMessageSchema>;
+
+/**
+ * Ready-check vote payload.
+ * Sent while the room is counting down to match start.
+ */
+export const PlayerReadyDataSchema = Type.Object(
+  {
+    ready: Type.Boolean({ description: 'Whether the player is ready to start' }),
+  },
+  { $id: 'PlayerReadyData', description: 'Ready-check vote payload' }
+);
+
+export type PlayerReadyData = Static<typeof PlayerReadyDataSchema>;
+
+/**
+ * Complete player:ready message schema
+ */
+export const PlayerReadyMessageSchema = createTypedMessageSchema('player:ready', PlayerReadyDataSchema);
+export type PlayerReadyMessage = Static<typeof PlayerReadyMessageSchema>;
+
+/**
+ * Stay-together vote payload.
+ * Sent after match:ended to re-queue with the same group instead of going
+ * back to solo matchmaking.
+ */
+export const PartyStayTogetherDataSchema = Type.Object(
+  {
+    stay: Type.Boolean({ description: 'Whether the player wants to play the next match with the same group' }),
+  },
+  { $id: 'PartyStayTogetherData', description: 'Stay-together vote payload' }
+);
+
+export type PartyStayTogetherData = Static<typeof PartyStayTogetherDataSchema>;
+
+/**
+ * Complete party:stay_together message schema
+ */
+export const PartyStayTogetherMessageSchema = createTypedMessageSchema('party:stay_together', PartyStayTogetherDataSchema);
+export type PartyStayTogetherMessage = Static<typeof PartyStayTogetherMessageSchema>;
+
+/**
+ * Rematch vote payload.
+ * Sent after match:ended to vote on restarting the match in the same room.
+ */
+export const MatchRematchVoteDataSchema = Type.Object(
+  {
+    vote: Type.Boolean({ description: 'Whether the player wants a rematch with the same room' }),
+  },
+  { $id: 'MatchRematchVoteData', description: 'Rematch vote payload' }
+);
+
+export type MatchRematchVoteData = Static<typeof MatchRematchVoteDataSchema>;
+
+/**
+ * Complete match:rematch_vote message schema
+ */
+export const MatchRematchVoteMessageSchema = createTypedMessageSchema('match:rematch_vote', MatchRematchVoteDataSchema);
+export type MatchRematchVoteMessage = Static<typeof MatchRematchVoteMessageSchema>;
+
+/**
+ * Broadcast subscription preferences payload.
+ * Lets minimal clients skip cosmetic-only broadcasts; each message replaces
+ * the previous preferences.
+ */
+export const PlayerPreferencesDataSchema = Type.Object(
+  {
+    optOut: Type.Array(Type.Union([Type.Literal('melee:hit'), Type.Literal('player:emoted')]), {
+      description: 'Cosmetic-only broadcast types the client does not want to receive',
+      uniqueItems: true,
+    }),
+  },
+  { $id: 'PlayerPreferencesData', description: 'Broadcast subscription preferences payload' }
+);
+
+export type PlayerPreferencesData = Static<typeof PlayerPreferencesDataSchema>;
+
+/**
+ * Complete player:preferences message schema
+ */
+export const PlayerPreferencesMessageSchema = createTypedMessageSchema('player:preferences', PlayerPreferencesDataSchema);
+export type PlayerPreferencesMessage = Static<typeof PlayerPreferencesMessageSchema>;
+
 /**
  * Input state data payload.
  * Represents keyboard input state for player movement and aim.
@@ -57,7 +161,9 @@ export const InputStateDataSchema = Type.Object(
     right: Type.Boolean({ description: 'D key pressed' }),
     aimAngle: Type.Number({ description: 'Aim angle in radians' }),
     isSprinting: Type.Boolean({ description: 'Shift key pressed for sprint' }),
-    sequence: Type.Number({ description: 'Monotonically increasing sequence number for client-side prediction', minimum: 0 }),
+    sequence: Type.Optional(
+      Type.Number({ description: 'Monotonically increasing sequence number for client-side prediction', minimum: 0 })
+    ),
   },
   { $id: 'InputStateData', description: 'Player input state payload' }
 );
@@ -78,6 +184,13 @@ export const PlayerShootDataSchema = Type.Object(
   {
     aimAngle: Type.Number({ description: 'Aim angle in radians' }),
     clientTimestamp: Type.Number({ description: 'Client-side timestamp in milliseconds when shot was fired', minimum: 0 }),
+    effectId: Type.Optional(
+      Type.String({
+        description: 'Equipped cosmetic trail effect; the server rejects unknown or unowned effects',
+        minLength: 1,
+        maxLength: 64,
+      })
+    ),
   },
   { $id: 'PlayerShootData', description: 'Player shoot action payload' }
 );
@@ -102,7 +215,7 @@ export type PlayerReloadMessage = Static<typeof PlayerReloadMessageSchema>;
  */
 export const WeaponPickupAttemptDataSchema = Type.Object(
   {
-    crateId: Type.String({ description: 'Unique identifier for the weapon crate', minLength: 1 }),
+    crateId: Type.String({ description: 'Unique identifier for the weapon crate', minLength: 1, maxLength: 64 }),
   },
   { $id: 'WeaponPickupAttemptData', description: 'Weapon pickup attempt payload' }
 );
@@ -143,3 +256,286 @@ export type PlayerMeleeAttackMessage = Static<typeof PlayerMeleeAttackMessageSch
  */
 export const PlayerDodgeRollMessageSchema = createTypedMessageSchemaNoData('player:dodge_roll');
 export type PlayerDodgeRollMessage = Static<typeof PlayerDodgeRollMessageSchema>;
+
+/**
+ * Complete player:ultimate message schema (no data payload)
+ * Client spends a full ultimate meter on the player's ultimate.
+ */
+export const PlayerUltimateMessageSchema = createTypedMessageSchemaNoData('player:ultimate');
+export type PlayerUltimateMessage = Static<typeof PlayerUltimateMessageSchema>;
+
+/**
+ * Complete player:respawn_request message schema (no data payload)
+ * A dead client asks to respawn once the respawn delay has passed.
+ */
+export const PlayerRespawnRequestMessageSchema = createTypedMessageSchemaNoData('player:respawn_request');
+export type PlayerRespawnRequestMessage = Static<typeof PlayerRespawnRequestMessageSchema>;
+
+/**
+ * Spawn choice payload.
+ * A dead client picks one of the spawn points from spawn:options to respawn at.
+ */
+export const PlayerSpawnChoiceDataSchema = Type.Object(
+  {
+    index: Type.Integer({ description: 'Index of the picked spawn point from spawn:options', minimum: 0 }),
+  },
+  { $id: 'PlayerSpawnChoiceData', description: 'Spawn choice payload' }
+);
+
+export type PlayerSpawnChoiceData = Static<typeof PlayerSpawnChoiceDataSchema>;
+
+/**
+ * Complete player:spawn_choice message schema
+ */
+export const PlayerSpawnChoiceMessageSchema = createTypedMessageSchema('player:spawn_choice', PlayerSpawnChoiceDataSchema);
+export type PlayerSpawnChoiceMessage = Static<typeof PlayerSpawnChoiceMessageSchema>;
+
+/**
+ * Tactical ping payload.
+ * Marks a point on the map for the player's team. The server checks the point
+ * is inside the map and rate-limits pings, then routes it to teammates.
+ */
+export const PlayerPingDataSchema = Type.Object(
+  {
+    type: Type.Union([Type.Literal('go'), Type.Literal('enemy'), Type.Literal('danger'), Type.Literal('loot')], {
+      description: 'What the ping marks',
+    }),
+    position: Type.Object(
+      {
+        x: Type.Number({ description: 'X coordinate' }),
+        y: Type.Number({ description: 'Y coordinate' }),
+      },
+      { description: 'Pinged point in world coordinates' }
+    ),
+  },
+  { $id: 'PlayerPingData', description: 'Tactical ping payload' }
+);
+
+export type PlayerPingData = Static<typeof PlayerPingDataSchema>;
+
+/**
+ * Complete player:ping message schema
+ */
+export const PlayerPingMessageSchema = createTypedMessageSchema('player:ping', PlayerPingDataSchema);
+export type PlayerPingMessage = Static<typeof PlayerPingMessageSchema>;
+
+/**
+ * Emote payload.
+ * Shows an emote or a canned quick-chat line to the room. There is no free
+ * text; the server checks the ID and rate-limits emotes.
+ */
+export const PlayerEmoteDataSchema = Type.Object(
+  {
+    emote: Type.Union(
+      [
+        Type.Literal('wave'),
+        Type.Literal('laugh'),
+        Type.Literal('taunt'),
+        Type.Literal('salute'),
+        Type.Literal('gg'),
+        Type.Literal('nice_shot'),
+        Type.Literal('thanks'),
+        Type.Literal('sorry'),
+      ],
+      { description: 'Emote or canned quick-chat line' }
+    ),
+  },
+  { $id: 'PlayerEmoteData', description: 'Emote payload' }
+);
+
+export type PlayerEmoteData = Static<typeof PlayerEmoteDataSchema>;
+
+/**
+ * Complete player:emote message schema
+ */
+export const PlayerEmoteMessageSchema = createTypedMessageSchema('player:emote', PlayerEmoteDataSchema);
+export type PlayerEmoteMessage = Static<typeof PlayerEmoteMessageSchema>;
+
+/**
+ * Chat message payload.
+ * Free text for the player's room, or for their team on the team channel. The
+ * server trims it, masks profanity and drops messages past the flood limit.
+ * In a room with all-chat turned off, every message goes to the team.
+ */
+export const ChatMessageDataSchema = Type.Object(
+  {
+    text: Type.String({ description: 'Message text', minLength: 1, maxLength: 200 }),
+    channel: Type.Optional(
+      Type.Union([Type.Literal('all'), Type.Literal('team')], {
+        description: "Who the message is for: the whole room (default) or the sender's team",
+      })
+    ),
+  },
+  { $id: 'ChatMessageData', description: 'Chat message payload' }
+);
+
+export type ChatMessageData = Static<typeof ChatMessageDataSchema>;
+
+/**
+ * Complete chat:message message schema
+ */
+export const ChatMessageMessageSchema = createTypedMessageSchema('chat:message', ChatMessageDataSchema);
+export type ChatMessageMessage = Static<typeof ChatMessageMessageSchema>;
+
+/**
+ * Chat mute payload.
+ * Mutes or unmutes another player in the same room for the sender only.
+ */
+export const ChatMuteDataSchema = Type.Object(
+  {
+    playerId: Type.String({ description: 'Player to mute or unmute', minLength: 1 }),
+    muted: Type.Boolean({ description: 'True to mute, false to unmute' }),
+  },
+  { $id: 'ChatMuteData', description: 'Chat mute payload' }
+);
+
+export type ChatMuteData = Static<typeof ChatMuteDataSchema>;
+
+/**
+ * Complete chat:mute message schema
+ */
+export const ChatMuteMessageSchema = createTypedMessageSchema('chat:mute', ChatMuteDataSchema);
+export type ChatMuteMessage = Static<typeof ChatMuteMessageSchema>;
+
+/**
+ * Vote-kick payload.
+ * Votes to kick another player from the sender's room. Once enough of the
+ * room votes within the window, the player is kicked and kept out of the room
+ * for a while.
+ */
+export const RoomVoteKickDataSchema = Type.Object(
+  {
+    playerId: Type.String({ description: 'Player to vote out', minLength: 1 }),
+  },
+  { $id: 'RoomVoteKickData', description: 'Vote-kick payload' }
+);
+
+export type RoomVoteKickData = Static<typeof RoomVoteKickDataSchema>;
+
+/**
+ * Complete room:votekick message schema
+ */
+export const RoomVoteKickMessageSchema = createTypedMessageSchema('room:votekick', RoomVoteKickDataSchema);
+export type RoomVoteKickMessage = Static<typeof RoomVoteKickMessageSchema>;
+
+/**
+ * Character class selection payload.
+ * The class applies when the player next spawns.
+ */
+export const PlayerLoadoutDataSchema = Type.Object(
+  {
+    class: Type.Union([Type.Literal('heavy'), Type.Literal('scout'), Type.Literal('gunner')], {
+      description: 'Character class to spawn as',
+    }),
+  },
+  { $id: 'PlayerLoadoutData', description: 'Character class selection payload' }
+);
+
+export type PlayerLoadoutData = Static<typeof PlayerLoadoutDataSchema>;
+
+/**
+ * Complete player:loadout message schema
+ */
+export const PlayerLoadoutMessageSchema = createTypedMessageSchema('player:loadout', PlayerLoadoutDataSchema);
+export type PlayerLoadoutMessage = Static<typeof PlayerLoadoutMessageSchema>;
+
+/**
+ * Display name change payload.
+ * The server sanitizes the name and allows one change per name change cooldown.
+ */
+export const PlayerRenameDataSchema = Type.Object(
+  {
+    displayName: Type.String({ description: 'Requested display name before server sanitization', maxLength: 64 }),
+  },
+  { $id: 'PlayerRenameData', description: 'Display name change payload' }
+);
+
+export type PlayerRenameData = Static<typeof PlayerRenameDataSchema>;
+
+/**
+ * Complete player:rename message schema
+ */
+export const PlayerRenameMessageSchema = createTypedMessageSchema('player:rename', PlayerRenameDataSchema);
+export type PlayerRenameMessage = Static<typeof PlayerRenameMessageSchema>;
+
+/**
+ * State acknowledgement payload.
+ * Names the last state:snapshot or state:delta the client applied; later
+ * deltas are computed against that state.
+ */
+export const StateAckDataSchema = Type.Object(
+  {
+    seq: Type.Integer({ minimum: 1, description: 'Sequence of the last state message applied' }),
+  },
+  { $id: 'StateAckData', description: 'State acknowledgement payload' }
+);
+
+export type StateAckData = Static<typeof StateAckDataSchema>;
+
+/**
+ * Complete state:ack message schema
+ */
+export const StateAckMessageSchema = createTypedMessageSchema('state:ack', StateAckDataSchema);
+export type StateAckMessage = Static<typeof StateAckMessageSchema>;
+
+/**
+ * Playtest feedback payload.
+ * Sent from in-game feedback prompts. The server stores it with the player's
+ * current match and the server build, at most once per feedback cooldown.
+ */
+export const FeedbackSubmitDataSchema = Type.Object(
+  {
+    rating: Type.Integer({ minimum: 1, maximum: 5, description: 'Rating from 1 (worst) to 5 (best)' }),
+    text: Type.Optional(Type.String({ maxLength: 500, description: 'Free-form comments' })),
+  },
+  { $id: 'FeedbackSubmitData', description: 'Playtest feedback payload' }
+);
+
+export type FeedbackSubmitData = Static<typeof FeedbackSubmitDataSchema>;
+
+/**
+ * Complete feedback:submit message schema
+ */
+export const FeedbackSubmitMessageSchema = createTypedMessageSchema('feedback:submit', FeedbackSubmitDataSchema);
+export type FeedbackSubmitMessage = Static<typeof FeedbackSubmitMessageSchema>;
+
+/**
+ * Heartbeat reply payload.
+ * Echoes a net:ping back as soon as it arrives; the server measures the
+ * player's round-trip time from it.
+ */
+export const NetPongDataSchema = Type.Object(
+  {
+    id: Type.Integer({ minimum: 1, description: 'ID of the net:ping being answered' }),
+    serverTime: Type.Integer({ minimum: 0, description: 'serverTime of the net:ping being answered' }),
+  },
+  { $id: 'NetPongData', description: 'Heartbeat reply payload' }
+);
+
+export type NetPongData = Static<typeof NetPongDataSchema>;
+
+/**
+ * Complete net:pong message schema
+ */
+export const NetPongMessageSchema = createTypedMessageSchema('net:pong', NetPongDataSchema);
+export type NetPongMessage = Static<typeof NetPongMessageSchema>;
+
+/**
+ * Clock sync request payload.
+ * The server answers with time:sync, echoing clientTime alongside its own
+ * receive and transmit times so the client can estimate its clock offset.
+ */
+export const TimeSyncRequestDataSchema = Type.Object(
+  {
+    clientTime: Type.Number({ minimum: 0, description: 'Client time (ms) the request was sent' }),
+  },
+  { $id: 'TimeSyncRequestData', description: 'Clock sync request payload' }
+);
+
+export type TimeSyncRequestData = Static<typeof TimeSyncRequestDataSchema>;
+
+/**
+ * Complete time:sync_request message schema
+ */
+export const TimeSyncRequestMessageSchema = createTypedMessageSchema('time:sync_request', TimeSyncRequestDataSchema);
+export type TimeSyncRequestMessage = Static<typeof TimeSyncRequestMessageSchema>;
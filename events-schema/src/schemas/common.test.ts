@@ -4,11 +4,17 @@ import {
   PositionSchema,
   VelocitySchema,
   MessageSchema,
+  TEAM_PALETTE,
+  TeamRefSchema,
   createTypedMessageSchema,
   createTypedMessageSchemaNoData,
+  CLOSE_CODES,
+  closeReasonOf,
+  closeCodeReconnects,
   type Position,
   type Velocity,
   type Message,
+  type TeamRef,
 } from './common.js';
 import { Type } from '@sinclair/typebox';
 
@@ -302,4 +308,43 @@ describe('Common Schemas', () => {
       expect(parsed.type).toBe('object');
     });
   });
+
+  describe('TeamRefSchema', () => {
+    const validateTeamRef = ajv.compile(TeamRefSchema);
+
+    it('should validate a semantic team with a palette index', () => {
+      const team: TeamRef = { id: 'alpha', paletteIndex: 0 };
+      expect(validateTeamRef(team)).toBe(true);
+    });
+
+    it('should reject color names as team identifiers', () => {
+      expect(validateTeamRef({ id: 'red', paletteIndex: 0 })).toBe(false);
+    });
+
+    it('should reject palette indexes outside TEAM_PALETTE', () => {
+      expect(validateTeamRef({ id: 'bravo', paletteIndex: TEAM_PALETTE.length })).toBe(false);
+      expect(validateTeamRef({ id: 'bravo', paletteIndex: -1 })).toBe(false);
+    });
+  });
+
+  describe('CLOSE_CODES', () => {
+    it('should give every reason its own application code', () => {
+      const codes = Object.values(CLOSE_CODES);
+      expect(new Set(codes).size).toBe(codes.length);
+      codes.forEach((code) => expect(code).toBeGreaterThanOrEqual(4000));
+    });
+
+    it('should name the reason behind a code', () => {
+      expect(closeReasonOf(4001)).toBe('banned');
+      expect(closeReasonOf(1006)).toBeNull();
+    });
+
+    it('should reconnect only after idle, shutdown and transient closes', () => {
+      expect(closeCodeReconnects(CLOSE_CODES.idle)).toBe(true);
+      expect(closeCodeReconnects(CLOSE_CODES.server_shutdown)).toBe(true);
+      expect(closeCodeReconnects(1006)).toBe(true);
+      expect(closeCodeReconnects(CLOSE_CODES.kicked)).toBe(false);
+      expect(closeCodeReconnects(CLOSE_CODES.session_replaced)).toBe(false);
+    });
+  });
 });
@@ -38,6 +38,46 @@ export const VelocitySchema = Type.Object(
  */
 export type Velocity = Static<typeof VelocitySchema>;
 
+/**
+ * Colorblind-safe team palette (Okabe-Ito). Team-related messages carry an
+ * index into this list instead of a color name, so every client draws a team
+ * the same way and a colorblind mode can remap indices consistently.
+ */
+export const TEAM_PALETTE = ['#E69F00', '#56B4E9', '#009E73', '#F0E442', '#0072B2', '#D55E00', '#CC79A7'];
+
+/**
+ * Semantic team identifier. Identifiers never name a color.
+ */
+export const TeamIdSchema = Type.Union([Type.Literal('alpha'), Type.Literal('bravo')], {
+  $id: 'TeamId',
+  description: 'Semantic team identifier',
+});
+
+/**
+ * TypeScript type inferred from TeamIdSchema
+ */
+export type TeamId = Static<typeof TeamIdSchema>;
+
+/**
+ * Team reference used by every team-related message.
+ */
+export const TeamRefSchema = Type.Object(
+  {
+    id: TeamIdSchema,
+    paletteIndex: Type.Integer({
+      description: 'Index into TEAM_PALETTE for the team color',
+      minimum: 0,
+      maximum: 6,
+    }),
+  },
+  { $id: 'TeamRef', description: 'Team identifier with its accessible palette index' }
+);
+
+/**
+ * TypeScript type inferred from TeamRefSchema
+ */
+export type TeamRef = Static<typeof TeamRefSchema>;
+
 /**
  * Base message wrapper schema factory.
  * Creates a message schema with the standard type, timestamp, and optional data fields.
@@ -106,3 +146,40 @@ export function createTypedMessageSchemaNoData(messageType: string) {
     { $id: `${messageType.replace(':', '_')}Message`, description: `${messageType} WebSocket message` }
   );
 }
+
+/**
+ * WebSocket close codes the server ends a connection with. Codes 4000-4999 are
+ * private to the application; each names why the client was disconnected so
+ * it can show the right dialog. Closes without one of these codes (1006 from a
+ * lost link, 1009 from an oversized frame) are treated as transient.
+ */
+export const CLOSE_CODES = {
+  kicked: 4000,
+  banned: 4001,
+  idle: 4002,
+  server_shutdown: 4003,
+  protocol_error: 4004,
+  session_replaced: 4005,
+  already_connected: 4006,
+} as const;
+
+export type CloseReason = keyof typeof CLOSE_CODES;
+
+/**
+ * Returns the reason behind a close code, or null for codes the server does
+ * not send on purpose.
+ */
+export function closeReasonOf(code: number): CloseReason | null {
+  const entry = Object.entries(CLOSE_CODES).find(([, value]) => value === code);
+  return entry ? (entry[0] as CloseReason) : null;
+}
+
+/**
+ * Whether a client should reconnect on its own after a close: an idle
+ * connection may resume its session and a drained server's clients may find
+ * another instance, while the other reasons need the player to act first.
+ */
+export function closeCodeReconnects(code: number): boolean {
+  const reason = closeReasonOf(code);
+  return reason === null || reason === 'idle' || reason === 'server_shutdown';
+}
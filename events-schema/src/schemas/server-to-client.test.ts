@@ -6,16 +6,36 @@ import { Value } from '@sinclair/typebox/value';
 import {
   SessionStatusDataSchema,
   SessionStatusMessageSchema,
+  SessionCapacityDataSchema,
+  SessionCapacityMessageSchema,
   RoomJoinedDataSchema,
   RoomJoinedMessageSchema,
   PlayerLeftDataSchema,
   PlayerLeftMessageSchema,
+  PlayerJoinedDataSchema,
+  PlayerJoinedMessageSchema,
+  RoomRosterDataSchema,
+  RoomRosterMessageSchema,
+  RoomReadyStateDataSchema,
+  RoomReadyStateMessageSchema,
+  PartyStateDataSchema,
+  PartyStateMessageSchema,
+  MatchRestartedDataSchema,
+  MatchRestartedMessageSchema,
+  ServerHelloDataSchema,
+  ServerHelloMessageSchema,
+  SessionReplacedDataSchema,
+  SessionReplacedMessageSchema,
   ErrorNoHelloDataSchema,
   ErrorNoHelloMessageSchema,
+  ErrorPayloadRejectedDataSchema,
+  ErrorPayloadRejectedMessageSchema,
   ErrorBadRoomCodeDataSchema,
   ErrorBadRoomCodeMessageSchema,
   ErrorRoomFullDataSchema,
   ErrorRoomFullMessageSchema,
+  ErrorRoomBlockedDataSchema,
+  ErrorRoomBlockedMessageSchema,
   PlayerStateSchema,
   PlayerMoveDataSchema,
   PlayerMoveMessageSchema,
@@ -23,44 +43,105 @@ import {
   ProjectileSpawnMessageSchema,
   ProjectileDestroyDataSchema,
   ProjectileDestroyMessageSchema,
+  ProjectileExplodeDataSchema,
+  ProjectileExplodeMessageSchema,
+  AbilityStateSchema,
+  WeaponStatsSchema,
   WeaponStateDataSchema,
   WeaponStateMessageSchema,
   ShootFailedDataSchema,
   ShootFailedMessageSchema,
+  PlayerRenamedDataSchema,
+  RenameFailedDataSchema,
   PlayerDamagedDataSchema,
   PlayerDamagedMessageSchema,
   HitConfirmedDataSchema,
   HitConfirmedMessageSchema,
+  HitRejectedDataSchema,
+  HitRejectedMessageSchema,
   PlayerDeathDataSchema,
   PlayerDeathMessageSchema,
   PlayerKillCreditDataSchema,
   PlayerKillCreditMessageSchema,
+  PlayerKillstreakDataSchema,
+  PlayerKillstreakMessageSchema,
+  PlayerAssistCreditDataSchema,
+  PlayerAssistCreditMessageSchema,
   PlayerRespawnDataSchema,
   PlayerRespawnMessageSchema,
   MatchTimerDataSchema,
   MatchTimerMessageSchema,
   WinnerSummarySchema,
   PlayerScoreSchema,
+  PlayerMatchStatsSchema,
   MatchEndedDataSchema,
   MatchEndedMessageSchema,
+  ScoreboardEntrySchema,
+  WorldSyncDataSchema,
+  WorldSyncMessageSchema,
+  MapObstacleSchema,
+  MapLoadDataSchema,
+  MapLoadMessageSchema,
+  LobbySandboxStateDataSchema,
+  LobbySandboxStateMessageSchema,
+  QueueStatusDataSchema,
+  QueueStatusMessageSchema,
+  SpawnOptionsDataSchema,
+  SpawnOptionsMessageSchema,
+  TeamPingDataSchema,
+  TeamPingMessageSchema,
+  PlayerEmotedDataSchema,
+  PlayerEmotedMessageSchema,
+  ChatPostedDataSchema,
+  ChatPostedMessageSchema,
+  RoomVoteKickProgressDataSchema,
+  RoomVoteKickProgressMessageSchema,
+  LeaderboardUpdateDataSchema,
+  LeaderboardUpdateMessageSchema,
+  PlayerLevelUpDataSchema,
+  PlayerLevelUpMessageSchema,
+  ServerShutdownDataSchema,
+  ServerShutdownMessageSchema,
+  RoomRedirectDataSchema,
+  RoomRedirectMessageSchema,
+  PracticeStatusDataSchema,
+  PracticeStatusMessageSchema,
+  NetPingDataSchema,
+  NetPingMessageSchema,
+  TimeSyncDataSchema,
+  TimeSyncMessageSchema,
   WeaponSpawnedDataSchema,
   WeaponSpawnedMessageSchema,
   WeaponPickupConfirmedDataSchema,
   WeaponPickupConfirmedMessageSchema,
   WeaponRespawnedDataSchema,
   WeaponRespawnedMessageSchema,
+  ShieldSpawnedDataSchema,
+  ShieldSpawnedMessageSchema,
+  ShieldPickupConfirmedDataSchema,
+  ShieldPickupConfirmedMessageSchema,
+  ShieldRespawnedDataSchema,
+  ShieldRespawnedMessageSchema,
+  HealthSpawnedDataSchema,
+  HealthSpawnedMessageSchema,
+  HealthPickupConfirmedDataSchema,
+  HealthPickupConfirmedMessageSchema,
   MeleeHitDataSchema,
   MeleeHitMessageSchema,
   RollStartDataSchema,
   RollStartMessageSchema,
   RollEndDataSchema,
   RollEndMessageSchema,
+  UltimateActivatedDataSchema,
+  UltimateActivatedMessageSchema,
   ProjectileSnapshotSchema,
   WeaponCrateSnapshotSchema,
   StateSnapshotDataSchema,
   StateSnapshotMessageSchema,
   StateDeltaDataSchema,
   StateDeltaMessageSchema,
+  EntityRemovedDataSchema,
+  EntityRemovedMessageSchema,
 } from './server-to-client.js';
 
 describe('Server-to-Client Schemas', () => {
@@ -185,6 +266,19 @@ describe('Server-to-Client Schemas', () => {
         mapId: 'default_office',
       })).toBe(true);
     });
+
+    it('should validate a practice match_ready snapshot', () => {
+      expect(Value.Check(SessionStatusDataSchema, {
+        state: 'match_ready',
+        playerId: 'player-123',
+        displayName: 'Alice',
+        joinMode: 'practice',
+        roomId: 'room-123',
+        rosterSize: 4,
+        minPlayers: 2,
+        mapId: 'default_office',
+      })).toBe(true);
+    });
   });
 
   describe('SessionStatusMessageSchema', () => {
@@ -364,6 +458,15 @@ describe('Server-to-Client Schemas', () => {
       })).toBe(true);
     });
 
+    it('should validate session:replaced payloads', () => {
+      expect(Value.Check(SessionReplacedDataSchema, {})).toBe(true);
+      expect(Value.Check(SessionReplacedMessageSchema, {
+        type: 'session:replaced',
+        timestamp: Date.now(),
+        data: {},
+      })).toBe(true);
+    });
+
     it('should validate error:no_hello payloads', () => {
       expect(Value.Check(ErrorNoHelloDataSchema, { offendingType: 'input:state' })).toBe(true);
       expect(Value.Check(ErrorNoHelloMessageSchema, {
@@ -373,6 +476,41 @@ describe('Server-to-Client Schemas', () => {
       })).toBe(true);
     });
 
+    it('should validate error:payload_rejected payloads', () => {
+      expect(Value.Check(ErrorPayloadRejectedDataSchema, { reason: 'message_too_large', limit: 4096 })).toBe(true);
+      expect(Value.Check(ErrorPayloadRejectedDataSchema, {
+        reason: 'field_too_long',
+        offendingType: 'player:rename',
+        field: 'displayName',
+        limit: 64,
+      })).toBe(true);
+      expect(Value.Check(ErrorPayloadRejectedDataSchema, { reason: 'too_big', limit: 4096 })).toBe(false);
+      expect(Value.Check(ErrorPayloadRejectedDataSchema, { reason: 'message_too_large', limit: 0 })).toBe(false);
+      expect(Value.Check(ErrorPayloadRejectedMessageSchema, {
+        type: 'error:payload_rejected',
+        timestamp: Date.now(),
+        data: { reason: 'message_too_large', limit: 4096 },
+      })).toBe(true);
+    });
+
+    it('should validate server:hello payloads', () => {
+      const data = {
+        commit: 'abc1234',
+        buildTime: '2026-10-16T12:00:00Z',
+        goVersion: 'go1.24.0',
+        sessionToken: '9f86d081884c7d659a2feaa0c55ad015',
+        resumed: false,
+      };
+      expect(Value.Check(ServerHelloDataSchema, data)).toBe(true);
+      expect(Value.Check(ServerHelloDataSchema, { ...data, commit: '' })).toBe(false);
+      expect(Value.Check(ServerHelloDataSchema, { ...data, sessionToken: undefined })).toBe(false);
+      expect(Value.Check(ServerHelloMessageSchema, {
+        type: 'server:hello',
+        timestamp: Date.now(),
+        data,
+      })).toBe(true);
+    });
+
     it('should validate error:bad_room_code payloads', () => {
       expect(Value.Check(ErrorBadRoomCodeDataSchema, { reason: 'too_short' })).toBe(true);
       expect(Value.Check(ErrorBadRoomCodeMessageSchema, {
@@ -382,6 +520,97 @@ describe('Server-to-Client Schemas', () => {
       })).toBe(true);
     });
 
+    it('should validate session:capacity payloads', () => {
+      expect(Value.Check(SessionCapacityDataSchema, { queuePosition: 3 })).toBe(true);
+      expect(Value.Check(SessionCapacityDataSchema, { redirectUrl: 'wss://eu-2.stickrumble.example/ws' })).toBe(true);
+      expect(Value.Check(SessionCapacityDataSchema, { queuePosition: 0 })).toBe(false);
+      expect(Value.Check(SessionCapacityMessageSchema, {
+        type: 'session:capacity',
+        timestamp: Date.now(),
+        data: { queuePosition: 1 },
+      })).toBe(true);
+    });
+
+    it('should validate player:joined payloads', () => {
+      const data = { playerId: 'player-2', displayName: 'Bravo', rosterSize: 2 };
+      expect(Value.Check(PlayerJoinedDataSchema, data)).toBe(true);
+      expect(Value.Check(PlayerJoinedDataSchema, { ...data, rosterSize: 0 })).toBe(false);
+      expect(Value.Check(PlayerJoinedMessageSchema, {
+        type: 'player:joined',
+        timestamp: Date.now(),
+        data,
+      })).toBe(true);
+    });
+
+    it('should validate room:roster payloads', () => {
+      const data = {
+        roomId: 'room-1',
+        code: 'PIZZA',
+        players: [
+          { playerId: 'player-1', displayName: 'Alpha', ready: true },
+          { playerId: 'player-2', displayName: 'Bravo', team: { id: 'bravo', paletteIndex: 4 }, ready: false },
+        ],
+      };
+      expect(Value.Check(RoomRosterDataSchema, data)).toBe(true);
+      expect(Value.Check(RoomRosterDataSchema, { players: [] })).toBe(true);
+      expect(Value.Check(RoomRosterDataSchema, { players: [{ playerId: 'player-1', displayName: 'Alpha' }] })).toBe(false);
+      expect(Value.Check(RoomRosterMessageSchema, {
+        type: 'room:roster',
+        timestamp: Date.now(),
+        data,
+      })).toBe(true);
+    });
+
+    it('should validate room:ready_state payloads', () => {
+      const data = {
+        roomId: 'room-1',
+        readyPlayerIds: ['player-1'],
+        readyCount: 1,
+        requiredCount: 2,
+        totalPlayers: 2,
+        remainingSeconds: 12,
+        active: true,
+        started: false,
+      };
+      expect(Value.Check(RoomReadyStateDataSchema, data)).toBe(true);
+      expect(Value.Check(RoomReadyStateDataSchema, { ...data, remainingSeconds: -1 })).toBe(false);
+      expect(Value.Check(RoomReadyStateDataSchema, { ...data, readyPlayerIds: [], readyCount: 0 })).toBe(true);
+      expect(Value.Check(RoomReadyStateMessageSchema, {
+        type: 'room:ready_state',
+        timestamp: Date.now(),
+        data,
+      })).toBe(true);
+    });
+
+    it('should validate party:state payloads', () => {
+      const data = {
+        roomId: 'room-1',
+        stayingPlayerIds: ['player-1'],
+        totalPlayers: 2,
+      };
+      expect(Value.Check(PartyStateDataSchema, data)).toBe(true);
+      expect(Value.Check(PartyStateDataSchema, { ...data, totalPlayers: -1 })).toBe(false);
+      expect(Value.Check(PartyStateMessageSchema, {
+        type: 'party:state',
+        timestamp: Date.now(),
+        data,
+      })).toBe(true);
+    });
+
+    it('should validate match:restarted payloads', () => {
+      const data = {
+        roomId: 'room-1',
+        playerIds: ['player-1', 'player-2'],
+      };
+      expect(Value.Check(MatchRestartedDataSchema, data)).toBe(true);
+      expect(Value.Check(MatchRestartedDataSchema, { ...data, roomId: '' })).toBe(false);
+      expect(Value.Check(MatchRestartedMessageSchema, {
+        type: 'match:restarted',
+        timestamp: Date.now(),
+        data,
+      })).toBe(true);
+    });
+
     it('should validate error:room_full payloads', () => {
       expect(Value.Check(ErrorRoomFullDataSchema, { code: 'PIZZA' })).toBe(true);
       expect(Value.Check(ErrorRoomFullMessageSchema, {
@@ -390,6 +619,13 @@ describe('Server-to-Client Schemas', () => {
         data: { code: 'PIZZA' },
       })).toBe(true);
     });
+
+    it('should validate error:room_blocked payloads', () => {
+      const data = { code: 'PIZZA', retryAfterMs: 300000 };
+      expect(Value.Check(ErrorRoomBlockedDataSchema, data)).toBe(true);
+      expect(Value.Check(ErrorRoomBlockedMessageSchema, { type: 'error:room_blocked', timestamp: Date.now(), data })).toBe(true);
+      expect(Value.Check(ErrorRoomBlockedDataSchema, { code: 'PIZZA' })).toBe(false);
+    });
   });
 
   describe('ProjectileSpawnDataSchema', () => {
@@ -404,6 +640,35 @@ describe('Server-to-Client Schemas', () => {
       expect(Value.Check(ProjectileSpawnDataSchema, data)).toBe(true);
     });
 
+    it('should validate projectile spawn data with a trail effect', () => {
+      const data = {
+        id: 'proj-123',
+        ownerId: 'player-456',
+        weaponType: 'Uzi',
+        position: { x: 150, y: 250 },
+        velocity: { x: 10, y: 0 },
+        effectId: 'neon',
+      };
+      expect(Value.Check(ProjectileSpawnDataSchema, data)).toBe(true);
+    });
+
+    it('should validate a batched shotgun spawn with pellets', () => {
+      const data = {
+        id: 'pellet-1',
+        ownerId: 'player-456',
+        weaponType: 'Shotgun',
+        position: { x: 150, y: 250 },
+        velocity: { x: 800, y: 0 },
+        pellets: [
+          { id: 'pellet-1', velocity: { x: 800, y: 0 } },
+          { id: 'pellet-2', velocity: { x: 790, y: 100 } },
+        ],
+      };
+      expect(Value.Check(ProjectileSpawnDataSchema, data)).toBe(true);
+      expect(Value.Check(ProjectileSpawnDataSchema, { ...data, pellets: [] })).toBe(false);
+      expect(Value.Check(ProjectileSpawnDataSchema, { ...data, pellets: [{ id: 'pellet-1' }] })).toBe(false);
+    });
+
     it('should reject missing required fields', () => {
       const data = {
         id: 'proj-123',
@@ -425,7 +690,77 @@ describe('Server-to-Client Schemas', () => {
     });
   });
 
+  describe('ProjectileExplodeDataSchema', () => {
+    const explosion = {
+      projectileId: 'proj-123',
+      ownerId: 'player-123',
+      weaponType: 'RocketLauncher',
+      position: { x: 400, y: 300 },
+      radius: 100,
+    };
+
+    it('should validate valid projectile explode data', () => {
+      expect(Value.Check(ProjectileExplodeDataSchema, explosion)).toBe(true);
+    });
+
+    it('should reject a zero radius', () => {
+      expect(Value.Check(ProjectileExplodeDataSchema, { ...explosion, radius: 0 })).toBe(false);
+    });
+
+    it('should reject data without position', () => {
+      const data = { projectileId: 'proj-123', ownerId: 'player-123', weaponType: 'RocketLauncher', radius: 100 };
+      expect(Value.Check(ProjectileExplodeDataSchema, data)).toBe(false);
+    });
+  });
+
   describe('WeaponStateDataSchema', () => {
+    it('should validate ability cooldowns', () => {
+      const data = {
+        currentAmmo: 15,
+        maxAmmo: 15,
+        isReloading: false,
+        canShoot: true,
+        weaponType: 'Pistol',
+        isMelee: false,
+        abilities: [
+          { ability: 'shoot', remainingMs: 0, charges: 1, maxCharges: 1 },
+          { ability: 'roll', remainingMs: 1800, charges: 1, maxCharges: 2 },
+        ],
+      };
+      expect(Value.Check(WeaponStateDataSchema, data)).toBe(true);
+    });
+
+    it('should validate the equipped weapon stats', () => {
+      const stats = {
+        damage: 25,
+        fireRate: 3,
+        magazineSize: 15,
+        reloadTimeMs: 1500,
+        projectileSpeed: 800,
+        range: 800,
+        spreadDegrees: 0,
+      };
+      const data = {
+        currentAmmo: 15,
+        maxAmmo: 15,
+        isReloading: false,
+        canShoot: true,
+        weaponType: 'Pistol',
+        isMelee: false,
+        stats,
+      };
+      expect(Value.Check(WeaponStateDataSchema, data)).toBe(true);
+      expect(Value.Check(WeaponStatsSchema, { ...stats, fireRate: 0 })).toBe(false);
+      expect(Value.Check(WeaponStatsSchema, { ...stats, damage: 2.5 })).toBe(false);
+      expect(Value.Check(WeaponStatsSchema, { ...stats, comboWindowMs: 600, comboMultiplier: 2 })).toBe(true);
+      expect(Value.Check(WeaponStatsSchema, { ...stats, comboMultiplier: 0.5 })).toBe(false);
+    });
+
+    it('should reject unknown abilities and negative timers', () => {
+      expect(Value.Check(AbilityStateSchema, { ability: 'teleport', remainingMs: 0, charges: 1, maxCharges: 1 })).toBe(false);
+      expect(Value.Check(AbilityStateSchema, { ability: 'roll', remainingMs: -1, charges: 1, maxCharges: 1 })).toBe(false);
+    });
+
     it('should validate valid ranged weapon state data', () => {
       const data = {
         currentAmmo: 15,
@@ -521,6 +856,27 @@ describe('Server-to-Client Schemas', () => {
     });
   });
 
+  describe('PlayerRenamedDataSchema', () => {
+    it('should validate a rename', () => {
+      expect(Value.Check(PlayerRenamedDataSchema, { playerId: 'player-1', displayName: 'Stickman' })).toBe(true);
+    });
+
+    it('should reject an empty name', () => {
+      expect(Value.Check(PlayerRenamedDataSchema, { playerId: 'player-1', displayName: '' })).toBe(false);
+    });
+  });
+
+  describe('RenameFailedDataSchema', () => {
+    it('should validate a cooldown rejection', () => {
+      expect(Value.Check(RenameFailedDataSchema, { reason: 'cooldown', retryAfterMs: 420000 })).toBe(true);
+    });
+
+    it('should reject unknown reasons and negative waits', () => {
+      expect(Value.Check(RenameFailedDataSchema, { reason: 'taken', retryAfterMs: 0 })).toBe(false);
+      expect(Value.Check(RenameFailedDataSchema, { reason: 'cooldown', retryAfterMs: -1 })).toBe(false);
+    });
+  });
+
   describe('PlayerDamagedDataSchema', () => {
     it('should validate valid player damaged data', () => {
       const data = {
@@ -567,6 +923,21 @@ describe('Server-to-Client Schemas', () => {
     });
   });
 
+  describe('HitRejectedDataSchema', () => {
+    it('should validate a rejection naming the target', () => {
+      const data = { attack: 'shoot', code: 'invulnerable', victimId: 'player-2' };
+      expect(Value.Check(HitRejectedDataSchema, data)).toBe(true);
+    });
+
+    it('should validate a cooldown rejection without a target', () => {
+      expect(Value.Check(HitRejectedDataSchema, { attack: 'melee', code: 'cooldown' })).toBe(true);
+    });
+
+    it('should reject an unknown code', () => {
+      expect(Value.Check(HitRejectedDataSchema, { attack: 'shoot', code: 'lag' })).toBe(false);
+    });
+  });
+
   describe('PlayerDeathDataSchema', () => {
     it('should validate valid player death data', () => {
       const data = {
@@ -607,6 +978,54 @@ describe('Server-to-Client Schemas', () => {
     });
   });
 
+  describe('PlayerKillstreakDataSchema', () => {
+    it('should validate a triple kill on a streak', () => {
+      const data = {
+        playerId: 'player-2',
+        streak: 4,
+        multiKill: 3,
+        bonusXp: 175,
+      };
+      expect(Value.Check(PlayerKillstreakDataSchema, data)).toBe(true);
+    });
+
+    it('should reject a streak of one kill', () => {
+      const data = {
+        playerId: 'player-2',
+        streak: 1,
+        multiKill: 1,
+        bonusXp: 0,
+      };
+      expect(Value.Check(PlayerKillstreakDataSchema, data)).toBe(false);
+    });
+  });
+
+  describe('PlayerAssistCreditDataSchema', () => {
+    it('should validate an assist', () => {
+      const data = {
+        playerId: 'player-3',
+        victimId: 'player-2',
+        killerId: 'player-1',
+        damage: 40,
+        playerAssists: 2,
+        playerXP: 250,
+      };
+      expect(Value.Check(PlayerAssistCreditDataSchema, data)).toBe(true);
+    });
+
+    it('should reject an assist without damage', () => {
+      const data = {
+        playerId: 'player-3',
+        victimId: 'player-2',
+        killerId: 'player-1',
+        damage: 0,
+        playerAssists: 1,
+        playerXP: 50,
+      };
+      expect(Value.Check(PlayerAssistCreditDataSchema, data)).toBe(false);
+    });
+  });
+
   describe('PlayerRespawnDataSchema', () => {
     it('should validate valid respawn data', () => {
       const data = {
@@ -644,6 +1063,25 @@ describe('Server-to-Client Schemas', () => {
     });
   });
 
+  describe('PlayerMatchStatsSchema', () => {
+    it('should reject accuracy above 1', () => {
+      const data = {
+        playerId: 'player-1',
+        displayName: 'Alice',
+        kills: 0,
+        deaths: 0,
+        assists: 0,
+        xp: 0,
+        shotsFired: 1,
+        shotsHit: 2,
+        accuracy: 2,
+        damageDealt: 50,
+        bestKillStreak: 0,
+      };
+      expect(Value.Check(PlayerMatchStatsSchema, data)).toBe(false);
+    });
+  });
+
   describe('PlayerScoreSchema', () => {
     it('should validate valid player score', () => {
       const data = {
@@ -656,6 +1094,18 @@ describe('Server-to-Client Schemas', () => {
       expect(Value.Check(PlayerScoreSchema, data)).toBe(true);
     });
 
+    it('should validate best kill streak', () => {
+      const data = {
+        playerId: 'player-1',
+        displayName: 'Alice',
+        kills: 10,
+        deaths: 2,
+        xp: 500,
+        bestKillStreak: 6,
+      };
+      expect(Value.Check(PlayerScoreSchema, data)).toBe(true);
+    });
+
     it('should accept zero kills and deaths', () => {
       const data = {
         playerId: 'player-1',
@@ -792,6 +1242,55 @@ describe('Server-to-Client Schemas', () => {
       expect(Value.Check(MatchEndedDataSchema, data)).toBe(true);
     });
 
+    it('should validate the full scoreboard', () => {
+      const data = {
+        winners: [{ playerId: 'player-1', displayName: 'Alice' }],
+        finalScores: [{ playerId: 'player-1', displayName: 'Alice', kills: 10, deaths: 2, xp: 1000 }],
+        reason: 'kill_target',
+        scoreboard: [
+          {
+            playerId: 'player-1',
+            displayName: 'Alice',
+            kills: 10,
+            deaths: 2,
+            assists: 3,
+            xp: 1000,
+            shotsFired: 120,
+            shotsHit: 48,
+            accuracy: 0.4,
+            damageDealt: 1350,
+            bestKillStreak: 5,
+          },
+        ],
+      };
+      expect(Value.Check(MatchEndedDataSchema, data)).toBe(true);
+    });
+
+    it('should validate the match setup and server build', () => {
+      const data = {
+        winners: [{ playerId: 'player-1', displayName: 'Alice' }],
+        finalScores: [{ playerId: 'player-1', displayName: 'Alice', kills: 20, deaths: 2, xp: 2000 }],
+        reason: 'kill_target',
+        settings: { roomKind: 'code', maxPlayers: 8, killTarget: 20, timeLimitSeconds: 420, aimTurnRate: 12 },
+        mapId: 'default_office',
+        mutators: ['zombies'],
+        seed: 424242,
+        build: { commit: 'abc1234', buildTime: '2026-10-16T12:00:00Z', goVersion: 'go1.24.0' },
+      };
+      expect(Value.Check(MatchEndedDataSchema, data)).toBe(true);
+    });
+
+    it('should reject an unknown room kind or a zero seed', () => {
+      const base = {
+        winners: [],
+        finalScores: [],
+        reason: 'time_limit',
+      };
+      const settings = { roomKind: 'public', maxPlayers: 8, killTarget: 20, timeLimitSeconds: 420 };
+      expect(Value.Check(MatchEndedDataSchema, { ...base, settings: { ...settings, roomKind: 'ranked' } })).toBe(false);
+      expect(Value.Check(MatchEndedDataSchema, { ...base, settings, seed: 0 })).toBe(false);
+    });
+
     it('should reject empty reason', () => {
       const data = {
         winners: [{ playerId: 'player-1', displayName: 'Alice' }],
@@ -846,6 +1345,18 @@ describe('Server-to-Client Schemas', () => {
       expect(Value.Check(StateDeltaDataSchema, data)).toBe(false);
     });
 
+    it('should accept sequenced deltas and reject zero sequences', () => {
+      expect(Value.Check(StateDeltaDataSchema, { seq: 12, baseSeq: 9, players: [] })).toBe(true);
+      expect(Value.Check(StateDeltaDataSchema, { seq: 0 })).toBe(false);
+      expect(Value.Check(StateSnapshotDataSchema, { seq: 3, players: [], projectiles: [], weaponCrates: [] })).toBe(true);
+    });
+
+    it('should accept lastUpdated ticks and reject negative ones', () => {
+      const projectile = { id: 'proj-1', ownerId: 'player-1', position: { x: 1, y: 2 }, velocity: { x: 3, y: 4 } };
+      expect(Value.Check(ProjectileSnapshotSchema, { ...projectile, lastUpdated: 42 })).toBe(true);
+      expect(Value.Check(ProjectileSnapshotSchema, { ...projectile, lastUpdated: -1 })).toBe(false);
+    });
+
     it('should reject final score rows without displayName', () => {
       const data = {
         winners: [{ playerId: 'player-1', displayName: 'Alice' }],
@@ -856,6 +1367,323 @@ describe('Server-to-Client Schemas', () => {
     });
   });
 
+  describe('MapLoadDataSchema', () => {
+    const wall = {
+      id: 'wall_top_boundary',
+      type: 'wall',
+      shape: 'rectangle',
+      x: 0,
+      y: 0,
+      width: 1920,
+      height: 48,
+      blocksMovement: true,
+      blocksProjectiles: true,
+      blocksLineOfSight: true,
+    };
+
+    it('should validate map geometry', () => {
+      const data = { mapId: 'default_office', name: 'Default Office', width: 1920, height: 1080, obstacles: [wall] };
+      expect(Value.Check(MapLoadDataSchema, data)).toBe(true);
+      expect(
+        Value.Check(MapLoadMessageSchema, { type: 'map:load', timestamp: Date.now(), data })
+      ).toBe(true);
+    });
+
+    it('should reject unknown obstacle types and shapes', () => {
+      expect(Value.Check(MapObstacleSchema, { ...wall, type: 'lava' })).toBe(false);
+      expect(Value.Check(MapObstacleSchema, { ...wall, shape: 'circle' })).toBe(false);
+    });
+
+    it('should reject empty obstacles', () => {
+      expect(Value.Check(MapObstacleSchema, { ...wall, width: 0 })).toBe(false);
+    });
+  });
+
+  describe('LobbySandboxStateDataSchema', () => {
+    const data = {
+      width: 960,
+      height: 540,
+      player: { position: { x: 240, y: 270 }, velocity: { x: 0, y: 0 }, aimAngle: 0 },
+      dummy: { position: { x: 720, y: 270 }, health: 75 },
+      projectiles: [{ id: 'proj-1', position: { x: 400, y: 270 }, velocity: { x: 800, y: 0 } }],
+      hits: 1,
+      knockdowns: 0,
+    };
+
+    it('should validate sandbox state', () => {
+      expect(Value.Check(LobbySandboxStateDataSchema, data)).toBe(true);
+      expect(
+        Value.Check(LobbySandboxStateMessageSchema, { type: 'lobby:sandbox_state', timestamp: Date.now(), data })
+      ).toBe(true);
+    });
+
+    it('should reject negative hit counts', () => {
+      expect(Value.Check(LobbySandboxStateDataSchema, { ...data, hits: -1 })).toBe(false);
+    });
+  });
+
+  describe('QueueStatusDataSchema', () => {
+    const data = { position: 2, estimatedWaitMs: 20000, playersOnline: 5 };
+
+    it('should validate queue status', () => {
+      expect(Value.Check(QueueStatusDataSchema, data)).toBe(true);
+      expect(Value.Check(QueueStatusMessageSchema, { type: 'queue:status', timestamp: Date.now(), data })).toBe(true);
+    });
+
+    it('should allow an unknown wait', () => {
+      expect(Value.Check(QueueStatusDataSchema, { position: 1, playersOnline: 1 })).toBe(true);
+    });
+
+    it('should reject position zero', () => {
+      expect(Value.Check(QueueStatusDataSchema, { ...data, position: 0 })).toBe(false);
+    });
+  });
+
+  describe('SpawnOptionsDataSchema', () => {
+    const data = {
+      points: [
+        { index: 0, position: { x: 200, y: 540 }, danger: 1120, allowed: false },
+        { index: 1, position: { x: 1720, y: 540 }, danger: 0, allowed: true },
+      ],
+    };
+
+    it('should validate spawn options', () => {
+      expect(Value.Check(SpawnOptionsDataSchema, data)).toBe(true);
+      expect(Value.Check(SpawnOptionsMessageSchema, { type: 'spawn:options', timestamp: Date.now(), data })).toBe(true);
+    });
+
+    it('should allow a map without spawn points', () => {
+      expect(Value.Check(SpawnOptionsDataSchema, { points: [] })).toBe(true);
+    });
+
+    it('should reject negative danger', () => {
+      expect(Value.Check(SpawnOptionsDataSchema, { points: [{ ...data.points[1], danger: -1 }] })).toBe(false);
+    });
+  });
+
+  describe('TeamPingDataSchema', () => {
+    const data = { playerId: 'player-1', type: 'enemy', position: { x: 640, y: 360 } };
+
+    it('should validate a team ping', () => {
+      expect(Value.Check(TeamPingDataSchema, data)).toBe(true);
+      expect(Value.Check(TeamPingMessageSchema, { type: 'team:ping', timestamp: Date.now(), data })).toBe(true);
+    });
+
+    it('should reject unknown ping types', () => {
+      expect(Value.Check(TeamPingDataSchema, { ...data, type: 'nuke' })).toBe(false);
+    });
+  });
+
+  describe('ServerShutdownDataSchema', () => {
+    it('should validate a shutdown notice', () => {
+      expect(Value.Check(ServerShutdownDataSchema, { countdownMs: 30000 })).toBe(true);
+      expect(Value.Check(ServerShutdownDataSchema, { countdownMs: 0 })).toBe(true);
+      expect(Value.Check(ServerShutdownMessageSchema, { type: 'server:shutdown', timestamp: Date.now(), data: { countdownMs: 30000 } })).toBe(true);
+    });
+
+    it('should reject a negative countdown', () => {
+      expect(Value.Check(ServerShutdownDataSchema, { countdownMs: -1 })).toBe(false);
+      expect(Value.Check(ServerShutdownDataSchema, {})).toBe(false);
+    });
+  });
+
+  describe('RoomRedirectDataSchema', () => {
+    it('should validate a redirect', () => {
+      const data = { address: 'wss://game-2.stickrumble.example/ws', reason: 'least_loaded' };
+      expect(Value.Check(RoomRedirectDataSchema, data)).toBe(true);
+      expect(Value.Check(RoomRedirectDataSchema, { ...data, reason: 'room_code' })).toBe(true);
+      expect(Value.Check(RoomRedirectMessageSchema, { type: 'room:redirect', timestamp: Date.now(), data })).toBe(true);
+    });
+
+    it('should reject a missing address or unknown reason', () => {
+      expect(Value.Check(RoomRedirectDataSchema, { address: '', reason: 'room_code' })).toBe(false);
+      expect(Value.Check(RoomRedirectDataSchema, { address: 'wss://game-2.stickrumble.example/ws', reason: 'bored' })).toBe(false);
+    });
+  });
+
+  describe('PlayerEmotedDataSchema', () => {
+    const data = { playerId: 'player-1', emote: 'nice_shot' };
+
+    it('should validate an emote', () => {
+      expect(Value.Check(PlayerEmotedDataSchema, data)).toBe(true);
+      expect(Value.Check(PlayerEmotedMessageSchema, { type: 'player:emoted', timestamp: Date.now(), data })).toBe(true);
+    });
+
+    it('should reject free text', () => {
+      expect(Value.Check(PlayerEmotedDataSchema, { ...data, emote: 'hello there' })).toBe(false);
+    });
+  });
+
+  describe('ChatPostedDataSchema', () => {
+    const data = { playerId: 'player-1', displayName: 'Stickman', text: 'nice ****', channel: 'all' };
+
+    it('should validate a chat message', () => {
+      expect(Value.Check(ChatPostedDataSchema, data)).toBe(true);
+      expect(Value.Check(ChatPostedMessageSchema, { type: 'chat:posted', timestamp: Date.now(), data })).toBe(true);
+    });
+
+    it('should reject empty text', () => {
+      expect(Value.Check(ChatPostedDataSchema, { ...data, text: '' })).toBe(false);
+    });
+
+    it('should require the channel the message went out on', () => {
+      expect(Value.Check(ChatPostedDataSchema, { ...data, channel: 'team' })).toBe(true);
+      expect(Value.Check(ChatPostedDataSchema, { playerId: 'player-1', displayName: 'Stickman', text: 'gg' })).toBe(false);
+    });
+  });
+
+  describe('RoomVoteKickProgressDataSchema', () => {
+    const data = { targetId: 'player-3', voterId: 'player-1', votes: 1, needed: 2, expiresInMs: 30000, passed: false };
+
+    it('should validate vote progress', () => {
+      expect(Value.Check(RoomVoteKickProgressDataSchema, data)).toBe(true);
+      expect(
+        Value.Check(RoomVoteKickProgressMessageSchema, { type: 'room:votekick_progress', timestamp: Date.now(), data })
+      ).toBe(true);
+      expect(Value.Check(RoomVoteKickProgressDataSchema, { ...data, votes: 2, passed: true })).toBe(true);
+    });
+
+    it('should reject a vote that needs fewer than two votes', () => {
+      expect(Value.Check(RoomVoteKickProgressDataSchema, { ...data, needed: 1 })).toBe(false);
+    });
+  });
+
+  describe('LeaderboardUpdateDataSchema', () => {
+    const data = {
+      matches: 12,
+      total: 2,
+      top: [
+        { rank: 1, playerId: 'player-1', displayName: 'Alpha', xp: 900, kd: 2.5, wins: 4 },
+        { rank: 2, playerId: 'player-2', displayName: 'Bravo', xp: 400, kd: 0.5, wins: 1 },
+      ],
+    };
+
+    it('should validate a leaderboard update', () => {
+      expect(Value.Check(LeaderboardUpdateDataSchema, data)).toBe(true);
+      expect(
+        Value.Check(LeaderboardUpdateMessageSchema, { type: 'leaderboard:update', timestamp: Date.now(), data })
+      ).toBe(true);
+      expect(Value.Check(LeaderboardUpdateDataSchema, { matches: 0, total: 0, top: [] })).toBe(true);
+    });
+
+    it('should reject a rank below 1', () => {
+      expect(Value.Check(LeaderboardUpdateDataSchema, { ...data, top: [{ ...data.top[0], rank: 0 }] })).toBe(false);
+    });
+  });
+
+  describe('PlayerLevelUpDataSchema', () => {
+    const data = {
+      playerId: 'player-1',
+      level: 3,
+      previousLevel: 2,
+      xp: 1150,
+      nextLevelXp: 1820,
+      unlocks: ['emote.salute'],
+    };
+
+    it('should validate a level up', () => {
+      expect(Value.Check(PlayerLevelUpDataSchema, data)).toBe(true);
+      expect(
+        Value.Check(PlayerLevelUpMessageSchema, { type: 'player:level_up', timestamp: Date.now(), data })
+      ).toBe(true);
+      expect(Value.Check(PlayerLevelUpDataSchema, { ...data, nextLevelXp: 0, unlocks: [] })).toBe(true);
+    });
+
+    it('should reject a level up to level 1', () => {
+      expect(Value.Check(PlayerLevelUpDataSchema, { ...data, level: 1, previousLevel: 1 })).toBe(false);
+    });
+  });
+
+  describe('PracticeStatusDataSchema', () => {
+    const data = { difficulty: 'hard', accuracy: 0.65, reactionTime: 0.3, kd: 2.5 };
+
+    it('should validate practice status', () => {
+      expect(Value.Check(PracticeStatusDataSchema, data)).toBe(true);
+      expect(
+        Value.Check(PracticeStatusMessageSchema, { type: 'practice:status', timestamp: Date.now(), data })
+      ).toBe(true);
+    });
+
+    it('should reject an unknown difficulty', () => {
+      expect(Value.Check(PracticeStatusDataSchema, { ...data, difficulty: 'nightmare' })).toBe(false);
+    });
+  });
+
+  describe('NetPingDataSchema', () => {
+    const data = { id: 3, serverTime: 1760000000000 };
+
+    it('should validate a heartbeat ping', () => {
+      expect(Value.Check(NetPingDataSchema, data)).toBe(true);
+      expect(Value.Check(NetPingMessageSchema, { type: 'net:ping', timestamp: Date.now(), data })).toBe(true);
+    });
+
+    it('should reject a missing or zero ID', () => {
+      expect(Value.Check(NetPingDataSchema, { serverTime: data.serverTime })).toBe(false);
+      expect(Value.Check(NetPingDataSchema, { ...data, id: 0 })).toBe(false);
+    });
+  });
+
+  describe('TimeSyncDataSchema', () => {
+    const data = { clientTime: 1759999999990.5, serverReceiveTime: 1760000000000, serverTransmitTime: 1760000000001, tick: 600 };
+
+    it('should validate a clock sync reply', () => {
+      expect(Value.Check(TimeSyncDataSchema, data)).toBe(true);
+      expect(Value.Check(TimeSyncMessageSchema, { type: 'time:sync', timestamp: Date.now(), data })).toBe(true);
+    });
+
+    it('should reject a reply without server times', () => {
+      expect(Value.Check(TimeSyncDataSchema, { clientTime: data.clientTime, tick: 600 })).toBe(false);
+    });
+  });
+
+  describe('WorldSyncDataSchema', () => {
+    const scoreboard = [
+      { playerId: 'player-1', displayName: 'Alice', kills: 3, deaths: 1, assists: 2, xp: 300 },
+      { playerId: 'player-2', displayName: 'Bob', kills: 1, deaths: 3, assists: 0, xp: 100 },
+    ];
+
+    it('should validate valid world sync data', () => {
+      const data = {
+        matchState: 'active',
+        remainingSeconds: 312,
+        killTarget: 20,
+        playerKills: { 'player-1': 3, 'player-2': 1, 'player-gone': 4 },
+        scoreboard,
+      };
+      expect(Value.Check(WorldSyncDataSchema, data)).toBe(true);
+    });
+
+    it('should reject unknown match states', () => {
+      const data = { matchState: 'paused', remainingSeconds: 0, killTarget: 20, playerKills: {}, scoreboard: [] };
+      expect(Value.Check(WorldSyncDataSchema, data)).toBe(false);
+    });
+
+    it('should reject negative kill counts', () => {
+      const data = {
+        matchState: 'active',
+        remainingSeconds: 100,
+        killTarget: 20,
+        playerKills: { 'player-1': -1 },
+        scoreboard: [],
+      };
+      expect(Value.Check(WorldSyncDataSchema, data)).toBe(false);
+    });
+
+    it('should reject scoreboard rows without assists', () => {
+      expect(Value.Check(ScoreboardEntrySchema, scoreboard[0])).toBe(true);
+      expect(Value.Check(ScoreboardEntrySchema, { playerId: 'player-1', displayName: 'Alice', kills: 3, deaths: 1, xp: 300 })).toBe(false);
+    });
+
+    it('should validate the complete message', () => {
+      const message = {
+        type: 'world:sync',
+        timestamp: Date.now(),
+        data: { matchState: 'waiting', remainingSeconds: 420, killTarget: 20, playerKills: {}, scoreboard: [] },
+      };
+      expect(Value.Check(WorldSyncMessageSchema, message)).toBe(true);
+    });
+  });
+
   describe('WeaponSpawnedDataSchema', () => {
     it('should validate valid weapon spawned data', () => {
       const data = {
@@ -949,6 +1777,79 @@ describe('Server-to-Client Schemas', () => {
     });
   });
 
+  describe('ShieldSpawnedDataSchema', () => {
+    it('should validate shield crates', () => {
+      const data = {
+        crates: [{ id: 'shield_west_flank', position: { x: 144, y: 400 }, isAvailable: true }],
+      };
+      expect(Value.Check(ShieldSpawnedDataSchema, data)).toBe(true);
+    });
+  });
+
+  describe('ShieldPickupConfirmedDataSchema', () => {
+    it('should validate valid shield pickup data', () => {
+      const data = {
+        playerId: 'p1',
+        crateId: 'shield_west_flank',
+        shield: 50,
+        nextRespawnTime: 1700000000,
+      };
+      expect(Value.Check(ShieldPickupConfirmedDataSchema, data)).toBe(true);
+    });
+
+    it('should reject negative shield', () => {
+      const data = {
+        playerId: 'p1',
+        crateId: 'shield_west_flank',
+        shield: -1,
+        nextRespawnTime: 0,
+      };
+      expect(Value.Check(ShieldPickupConfirmedDataSchema, data)).toBe(false);
+    });
+  });
+
+  describe('ShieldRespawnedDataSchema', () => {
+    it('should reject empty crateId', () => {
+      const data = {
+        crateId: '',
+        position: { x: 144, y: 400 },
+      };
+      expect(Value.Check(ShieldRespawnedDataSchema, data)).toBe(false);
+    });
+  });
+
+  describe('HealthSpawnedDataSchema', () => {
+    it('should validate health packs', () => {
+      const data = {
+        packs: [{ id: 'health_north_east', position: { x: 1320, y: 180 }, isAvailable: true }],
+      };
+      expect(Value.Check(HealthSpawnedDataSchema, data)).toBe(true);
+    });
+  });
+
+  describe('HealthPickupConfirmedDataSchema', () => {
+    it('should validate valid health pickup data', () => {
+      const data = {
+        playerId: 'p1',
+        packId: 'health_north_east',
+        healed: 40,
+        newHealth: 100,
+        nextRespawnTime: 1700000000,
+      };
+      expect(Value.Check(HealthPickupConfirmedDataSchema, data)).toBe(true);
+    });
+
+    it('should reject missing packId', () => {
+      const data = {
+        playerId: 'p1',
+        healed: 40,
+        newHealth: 100,
+        nextRespawnTime: 0,
+      };
+      expect(Value.Check(HealthPickupConfirmedDataSchema, data)).toBe(false);
+    });
+  });
+
   describe('MeleeHitDataSchema', () => {
     it('should validate valid melee hit data', () => {
       const data = {
@@ -968,6 +1869,17 @@ describe('Server-to-Client Schemas', () => {
       expect(Value.Check(MeleeHitDataSchema, data)).toBe(false);
     });
 
+    it('should accept the combo hit and reject steps past the second', () => {
+      const data = {
+        attackerId: 'player-1',
+        victims: ['player-2'],
+        knockbackApplied: false,
+        combo: 2,
+      };
+      expect(Value.Check(MeleeHitDataSchema, data)).toBe(true);
+      expect(Value.Check(MeleeHitDataSchema, { ...data, combo: 3 })).toBe(false);
+    });
+
     it('should accept empty victims array', () => {
       const data = {
         attackerId: 'player-1',
@@ -1102,6 +2014,20 @@ describe('Server-to-Client Schemas', () => {
             data: { id: 'proj-1' },
           },
         },
+        {
+          schema: ProjectileExplodeMessageSchema,
+          message: {
+            type: 'projectile:explode',
+            timestamp,
+            data: {
+              projectileId: 'proj-1',
+              ownerId: 'p1',
+              weaponType: 'RocketLauncher',
+              position: { x: 0, y: 0 },
+              radius: 100,
+            },
+          },
+        },
         {
           schema: WeaponStateMessageSchema,
           message: {
@@ -1188,6 +2114,18 @@ describe('Server-to-Client Schemas', () => {
             },
           },
         },
+        {
+          schema: HitRejectedMessageSchema,
+          message: {
+            type: 'hit:rejected',
+            timestamp,
+            data: {
+              attack: 'shoot',
+              code: 'out_of_range',
+              victimId: 'p2',
+            },
+          },
+        },
         {
           schema: PlayerDeathMessageSchema,
           message: {
@@ -1212,6 +2150,34 @@ describe('Server-to-Client Schemas', () => {
             },
           },
         },
+        {
+          schema: PlayerKillstreakMessageSchema,
+          message: {
+            type: 'player:killstreak',
+            timestamp,
+            data: {
+              playerId: 'p1',
+              streak: 2,
+              multiKill: 2,
+              bonusXp: 75,
+            },
+          },
+        },
+        {
+          schema: PlayerAssistCreditMessageSchema,
+          message: {
+            type: 'player:assist_credit',
+            timestamp,
+            data: {
+              playerId: 'p3',
+              victimId: 'p2',
+              killerId: 'p1',
+              damage: 25,
+              playerAssists: 1,
+              playerXP: 50,
+            },
+          },
+        },
         {
           schema: PlayerRespawnMessageSchema,
           message: {
@@ -1266,6 +2232,64 @@ describe('Server-to-Client Schemas', () => {
             },
           },
         },
+        {
+          schema: ShieldSpawnedMessageSchema,
+          message: {
+            type: 'shield:spawned',
+            timestamp,
+            data: {
+              crates: [{ id: 'shield-1', position: { x: 50, y: 50 }, isAvailable: false }],
+            },
+          },
+        },
+        {
+          schema: ShieldPickupConfirmedMessageSchema,
+          message: {
+            type: 'shield:pickup_confirmed',
+            timestamp,
+            data: {
+              playerId: 'p1',
+              crateId: 'shield-1',
+              shield: 50,
+              nextRespawnTime: 30,
+            },
+          },
+        },
+        {
+          schema: ShieldRespawnedMessageSchema,
+          message: {
+            type: 'shield:respawned',
+            timestamp,
+            data: {
+              crateId: 'shield-1',
+              position: { x: 50, y: 50 },
+            },
+          },
+        },
+        {
+          schema: HealthSpawnedMessageSchema,
+          message: {
+            type: 'health:spawned',
+            timestamp,
+            data: {
+              packs: [{ id: 'health-1', position: { x: 50, y: 50 }, isAvailable: true }],
+            },
+          },
+        },
+        {
+          schema: HealthPickupConfirmedMessageSchema,
+          message: {
+            type: 'health:pickup_confirmed',
+            timestamp,
+            data: {
+              playerId: 'p1',
+              packId: 'health-1',
+              healed: 50,
+              newHealth: 80,
+              nextRespawnTime: 20,
+            },
+          },
+        },
         {
           schema: MeleeHitMessageSchema,
           message: {
@@ -1301,6 +2325,20 @@ describe('Server-to-Client Schemas', () => {
             },
           },
         },
+        {
+          schema: UltimateActivatedMessageSchema,
+          message: {
+            type: 'ultimate:activated',
+            timestamp,
+            data: {
+              playerId: 'p1',
+              ultimate: 'overdrive',
+              durationMs: 6000,
+              damageMultiplier: 1.5,
+              newHealth: 100,
+            },
+          },
+        },
       ];
 
       messages.forEach(({ schema, message }) => {
@@ -1309,6 +2347,47 @@ describe('Server-to-Client Schemas', () => {
     });
   });
 
+  describe('UltimateActivatedDataSchema', () => {
+    it('should reject a negative duration', () => {
+      const data = {
+        playerId: 'p1',
+        ultimate: 'overdrive',
+        durationMs: -1,
+        damageMultiplier: 1.5,
+        newHealth: 100,
+      };
+      expect(Value.Check(UltimateActivatedDataSchema, data)).toBe(false);
+    });
+
+    it('should accept ultimate meter fields on player state', () => {
+      const player = { ...basePlayerState, ultimateCharge: 100, ultimateActive: true };
+      expect(Value.Check(PlayerStateSchema, player)).toBe(true);
+      expect(Value.Check(PlayerStateSchema, { ...basePlayerState, ultimateCharge: 101 })).toBe(false);
+    });
+  });
+
+  describe('PlayerState teleport flag', () => {
+    it('should accept respawn and force_sync teleports', () => {
+      expect(Value.Check(PlayerStateSchema, { ...basePlayerState, teleport: 'respawn' })).toBe(true);
+      expect(Value.Check(PlayerStateSchema, { ...basePlayerState, teleport: 'force_sync' })).toBe(true);
+    });
+
+    it('should reject unknown teleport reasons', () => {
+      expect(Value.Check(PlayerStateSchema, { ...basePlayerState, teleport: 'portal' })).toBe(false);
+    });
+  });
+
+  describe('PlayerState class fields', () => {
+    it('should accept a class and its max health', () => {
+      const player = { ...basePlayerState, health: 150, maxHealth: 150, class: 'heavy' };
+      expect(Value.Check(PlayerStateSchema, player)).toBe(true);
+    });
+
+    it('should reject unknown classes', () => {
+      expect(Value.Check(PlayerStateSchema, { ...basePlayerState, class: 'medic' })).toBe(false);
+    });
+  });
+
   describe('StateSnapshotDataSchema', () => {
     it('should validate valid full state snapshot', () => {
       const data = {
@@ -1341,6 +2420,14 @@ describe('Server-to-Client Schemas', () => {
       expect(Value.Check(StateSnapshotDataSchema, data)).toBe(true);
     });
 
+    it('should validate a snapshot with its server tick', () => {
+      const data = { tick: 1234, players: [], projectiles: [], weaponCrates: [] };
+
+      expect(Value.Check(StateSnapshotDataSchema, data)).toBe(true);
+      expect(Value.Check(StateSnapshotDataSchema, { ...data, tick: -1 })).toBe(false);
+      expect(Value.Check(StateSnapshotDataSchema, { ...data, tick: 1.5 })).toBe(false);
+    });
+
     it('should validate empty snapshot', () => {
       const data = {
         players: [],
@@ -1409,6 +2496,10 @@ describe('Server-to-Client Schemas', () => {
       expect(Value.Check(StateDeltaDataSchema, data)).toBe(true);
     });
 
+    it('should validate delta with its server tick', () => {
+      expect(Value.Check(StateDeltaDataSchema, { tick: 1237 })).toBe(true);
+    });
+
     it('should validate empty delta', () => {
       const data = {};
 
@@ -1440,4 +2531,27 @@ describe('Server-to-Client Schemas', () => {
       expect(Value.Check(StateDeltaMessageSchema, message)).toBe(true);
     });
   });
+
+  describe('EntityRemovedMessageSchema', () => {
+    it('should validate removed players and projectiles', () => {
+      const message = {
+        type: 'entity:removed',
+        timestamp: 1234567890,
+        data: {
+          tick: 1200,
+          entities: [
+            { kind: 'player', id: 'player-2' },
+            { kind: 'projectile', id: 'proj-7' },
+          ],
+        },
+      };
+
+      expect(Value.Check(EntityRemovedMessageSchema, message)).toBe(true);
+    });
+
+    it('should reject an empty removal or an unknown kind', () => {
+      expect(Value.Check(EntityRemovedDataSchema, { tick: 1, entities: [] })).toBe(false);
+      expect(Value.Check(EntityRemovedDataSchema, { tick: 1, entities: [{ kind: 'crate', id: 'c1' }] })).toBe(false);
+    });
+  });
 });
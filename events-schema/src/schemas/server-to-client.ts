@@ -25,6 +25,14 @@ const VelocityRef = Type.Object(
   { description: 'A 2D velocity vector' }
 );
 
+const TeamRef = Type.Object(
+  {
+    id: Type.Union([Type.Literal('alpha'), Type.Literal('bravo')], { description: 'Semantic team identifier' }),
+    paletteIndex: Type.Integer({ description: 'Index into TEAM_PALETTE for the team color', minimum: 0, maximum: 6 }),
+  },
+  { description: 'Team identifier with its accessible palette index' }
+);
+
 // ============================================================================
 // session:status
 // ============================================================================
@@ -43,6 +51,7 @@ export const SessionStatusDataSchema = Type.Object(
     joinMode: Type.Union([
       Type.Literal('public'),
       Type.Literal('code'),
+      Type.Literal('practice'),
     ], { description: 'Join intent mode for the current session' }),
     roomId: Type.Optional(Type.String({ description: 'Assigned room identifier when available', minLength: 1 })),
     code: Type.Optional(Type.String({ description: 'Normalized named-room code', minLength: 1 })),
@@ -58,6 +67,28 @@ export type SessionStatusData = Static<typeof SessionStatusDataSchema>;
 export const SessionStatusMessageSchema = createTypedMessageSchema('session:status', SessionStatusDataSchema);
 export type SessionStatusMessage = Static<typeof SessionStatusMessageSchema>;
 
+// ============================================================================
+// session:capacity
+// ============================================================================
+
+/**
+ * Payload for session:capacity message.
+ * Sent instead of a session:status when this instance is full. Carries either
+ * a queue position (resent whenever it changes) or a redirect hint.
+ */
+export const SessionCapacityDataSchema = Type.Object(
+  {
+    queuePosition: Type.Optional(Type.Integer({ description: '1-based position in the capacity queue', minimum: 1 })),
+    redirectUrl: Type.Optional(Type.String({ description: 'Another instance to connect to instead', minLength: 1 })),
+  },
+  { $id: 'SessionCapacityData', description: 'Instance capacity notice payload' }
+);
+
+export type SessionCapacityData = Static<typeof SessionCapacityDataSchema>;
+
+export const SessionCapacityMessageSchema = createTypedMessageSchema('session:capacity', SessionCapacityDataSchema);
+export type SessionCapacityMessage = Static<typeof SessionCapacityMessageSchema>;
+
 // ============================================================================
 // room:joined
 // ============================================================================
@@ -113,6 +144,168 @@ export type PlayerLeftData = Static<typeof PlayerLeftDataSchema>;
 export const PlayerLeftMessageSchema = createTypedMessageSchema('player:left', PlayerLeftDataSchema);
 export type PlayerLeftMessage = Static<typeof PlayerLeftMessageSchema>;
 
+// ============================================================================
+// Player Joined Event
+// ============================================================================
+
+/**
+ * Payload for player:joined message.
+ * Sent to existing room members when a new player joins their room.
+ */
+export const PlayerJoinedDataSchema = Type.Object(
+  {
+    playerId: Type.String({ description: 'Unique identifier of the player who joined', minLength: 1 }),
+    displayName: Type.String({ description: 'Display name of the player who joined', minLength: 1 }),
+    team: Type.Optional(TeamRef),
+    rosterSize: Type.Integer({ description: 'Number of players in the room after the join', minimum: 1 }),
+  },
+  { $id: 'PlayerJoinedData', description: 'Player joined event payload' }
+);
+
+export type PlayerJoinedData = Static<typeof PlayerJoinedDataSchema>;
+
+/**
+ * Complete player:joined message schema
+ */
+export const PlayerJoinedMessageSchema = createTypedMessageSchema('player:joined', PlayerJoinedDataSchema);
+export type PlayerJoinedMessage = Static<typeof PlayerJoinedMessageSchema>;
+
+// ============================================================================
+// Room Roster Event
+// ============================================================================
+
+export const RosterPlayerSchema = Type.Object(
+  {
+    playerId: Type.String({ description: 'Unique player identifier', minLength: 1 }),
+    displayName: Type.String({ description: 'Sanitized display name', minLength: 1 }),
+    team: Type.Optional(TeamRef),
+    ready: Type.Boolean({ description: 'Whether the player has readied up' }),
+  },
+  { $id: 'RosterPlayer', description: 'Single room roster entry' }
+);
+
+export type RosterPlayer = Static<typeof RosterPlayerSchema>;
+
+/**
+ * Payload for room:roster message.
+ * Sent only to the requesting player in reply to room:roster_request.
+ */
+export const RoomRosterDataSchema = Type.Object(
+  {
+    roomId: Type.Optional(Type.String({ description: 'Room identifier; omitted while still queued', minLength: 1 })),
+    code: Type.Optional(Type.String({ description: 'Normalized named-room code', minLength: 1 })),
+    players: Type.Array(RosterPlayerSchema, { description: 'Current players in join order' }),
+  },
+  { $id: 'RoomRosterData', description: 'Room roster snapshot payload' }
+);
+
+export type RoomRosterData = Static<typeof RoomRosterDataSchema>;
+
+export const RoomRosterMessageSchema = createTypedMessageSchema('room:roster', RoomRosterDataSchema);
+export type RoomRosterMessage = Static<typeof RoomRosterMessageSchema>;
+
+// ============================================================================
+// Room Ready State Event
+// ============================================================================
+
+/**
+ * Payload for room:ready_state message.
+ * Broadcast while a room runs its pre-match ready check.
+ */
+export const RoomReadyStateDataSchema = Type.Object(
+  {
+    roomId: Type.String({ description: 'Room identifier', minLength: 1 }),
+    readyPlayerIds: Type.Optional(
+      Type.Array(Type.String({ minLength: 1 }), { description: 'Players who have readied up' })
+    ),
+    readyCount: Type.Integer({ description: 'Number of ready players', minimum: 0 }),
+    requiredCount: Type.Integer({ description: 'Ready players needed to start early', minimum: 0 }),
+    totalPlayers: Type.Integer({ description: 'Players currently in the room', minimum: 0 }),
+    remainingSeconds: Type.Integer({ description: 'Seconds until the match starts anyway', minimum: 0 }),
+    active: Type.Boolean({ description: 'Whether the ready check is still counting down' }),
+    started: Type.Boolean({ description: 'Whether the match has started' }),
+  },
+  { $id: 'RoomReadyStateData', description: 'Ready-check state payload' }
+);
+
+export type RoomReadyStateData = Static<typeof RoomReadyStateDataSchema>;
+
+export const RoomReadyStateMessageSchema = createTypedMessageSchema('room:ready_state', RoomReadyStateDataSchema);
+export type RoomReadyStateMessage = Static<typeof RoomReadyStateMessageSchema>;
+
+// ============================================================================
+// Party State Event
+// ============================================================================
+
+/**
+ * Payload for party:state message.
+ * Broadcast to an ended match's room as players vote to stay together.
+ */
+export const PartyStateDataSchema = Type.Object(
+  {
+    roomId: Type.String({ description: 'Room identifier of the ended match', minLength: 1 }),
+    stayingPlayerIds: Type.Array(Type.String({ minLength: 1 }), { description: 'Players who voted to stay together' }),
+    totalPlayers: Type.Integer({ description: 'Players still in the room', minimum: 0 }),
+  },
+  { $id: 'PartyStateData', description: 'Stay-together vote state payload' }
+);
+
+export type PartyStateData = Static<typeof PartyStateDataSchema>;
+
+export const PartyStateMessageSchema = createTypedMessageSchema('party:state', PartyStateDataSchema);
+export type PartyStateMessage = Static<typeof PartyStateMessageSchema>;
+
+// ============================================================================
+// Match Restarted Event
+// ============================================================================
+
+/**
+ * Payload for match:restarted message.
+ * Broadcast when a majority of an ended match's room voted for a rematch and
+ * the match started again in the same room.
+ */
+export const MatchRestartedDataSchema = Type.Object(
+  {
+    roomId: Type.String({ description: 'Room identifier', minLength: 1 }),
+    playerIds: Type.Array(Type.String({ minLength: 1 }), { description: 'Players in the restarted match' }),
+  },
+  { $id: 'MatchRestartedData', description: 'Rematch start payload' }
+);
+
+export type MatchRestartedData = Static<typeof MatchRestartedDataSchema>;
+
+export const MatchRestartedMessageSchema = createTypedMessageSchema('match:restarted', MatchRestartedDataSchema);
+export type MatchRestartedMessage = Static<typeof MatchRestartedMessageSchema>;
+
+export const ServerHelloDataSchema = Type.Object(
+  {
+    commit: Type.String({ description: 'Git commit the server was built from, or "unknown"', minLength: 1 }),
+    buildTime: Type.String({ description: 'UTC build time (RFC 3339), or "unknown"', minLength: 1 }),
+    goVersion: Type.String({ description: 'Go toolchain version the server was built with', minLength: 1 }),
+    sessionToken: Type.String({
+      description: 'Token to present as /ws?resume= to re-bind to this player after a disconnect',
+      minLength: 1,
+    }),
+    resumed: Type.Boolean({ description: 'Whether this connection re-bound to an existing player' }),
+  },
+  { $id: 'ServerHelloData', description: 'Build info and session token sent when a connection opens' }
+);
+
+export type ServerHelloData = Static<typeof ServerHelloDataSchema>;
+
+export const ServerHelloMessageSchema = createTypedMessageSchema('server:hello', ServerHelloDataSchema);
+export type ServerHelloMessage = Static<typeof ServerHelloMessageSchema>;
+
+export const SessionReplacedDataSchema = Type.Object(
+  {},
+  { $id: 'SessionReplacedData', description: 'Sent before closing a connection whose account connected again elsewhere' }
+);
+
+export type SessionReplacedData = Static<typeof SessionReplacedDataSchema>;
+
+export const SessionReplacedMessageSchema = createTypedMessageSchema('session:replaced', SessionReplacedDataSchema);
+export type SessionReplacedMessage = Static<typeof SessionReplacedMessageSchema>;
+
 export const ErrorNoHelloDataSchema = Type.Object(
   {
     offendingType: Type.String({ description: 'Gameplay message type that arrived before hello', minLength: 1 }),
@@ -125,6 +318,30 @@ export type ErrorNoHelloData = Static<typeof ErrorNoHelloDataSchema>;
 export const ErrorNoHelloMessageSchema = createTypedMessageSchema('error:no_hello', ErrorNoHelloDataSchema);
 export type ErrorNoHelloMessage = Static<typeof ErrorNoHelloMessageSchema>;
 
+export const ErrorPayloadRejectedDataSchema = Type.Object(
+  {
+    reason: Type.Union([Type.Literal('message_too_large'), Type.Literal('field_too_long')], {
+      description: 'Which limit the message broke',
+    }),
+    offendingType: Type.Optional(
+      Type.String({ description: 'Type of the rejected message, absent when it could not be read', minLength: 1 })
+    ),
+    field: Type.Optional(
+      Type.String({ description: 'Name of the oversized field, absent when the whole message was too large', minLength: 1 })
+    ),
+    limit: Type.Integer({ description: 'Maximum size in bytes of the message or field', minimum: 1 }),
+  },
+  { $id: 'ErrorPayloadRejectedData', description: 'Oversized client message rejection payload' }
+);
+
+export type ErrorPayloadRejectedData = Static<typeof ErrorPayloadRejectedDataSchema>;
+
+export const ErrorPayloadRejectedMessageSchema = createTypedMessageSchema(
+  'error:payload_rejected',
+  ErrorPayloadRejectedDataSchema
+);
+export type ErrorPayloadRejectedMessage = Static<typeof ErrorPayloadRejectedMessageSchema>;
+
 export const ErrorBadRoomCodeDataSchema = Type.Object(
   {
     reason: Type.Union([
@@ -153,6 +370,19 @@ export type ErrorRoomFullData = Static<typeof ErrorRoomFullDataSchema>;
 export const ErrorRoomFullMessageSchema = createTypedMessageSchema('error:room_full', ErrorRoomFullDataSchema);
 export type ErrorRoomFullMessage = Static<typeof ErrorRoomFullMessageSchema>;
 
+export const ErrorRoomBlockedDataSchema = Type.Object(
+  {
+    code: Type.String({ description: 'Normalized code of the room the player was vote-kicked from', minLength: 1 }),
+    retryAfterMs: Type.Integer({ description: 'Milliseconds until the player may rejoin', minimum: 0 }),
+  },
+  { $id: 'ErrorRoomBlockedData', description: 'Vote-kicked rejoin rejection payload' }
+);
+
+export type ErrorRoomBlockedData = Static<typeof ErrorRoomBlockedDataSchema>;
+
+export const ErrorRoomBlockedMessageSchema = createTypedMessageSchema('error:room_blocked', ErrorRoomBlockedDataSchema);
+export type ErrorRoomBlockedMessage = Static<typeof ErrorRoomBlockedMessageSchema>;
+
 // ============================================================================
 // player:move
 // ============================================================================
@@ -169,6 +399,18 @@ export const PlayerStateSchema = Type.Object(
     aimAngle: Type.Number({ description: 'Player aim angle in radians' }),
     weaponType: Type.String({ description: 'Authoritative equipped weapon type for this player', minLength: 1 }),
     health: Type.Number({ description: 'Current health', minimum: 0 }),
+    maxHealth: Type.Optional(Type.Integer({ description: "Maximum health for the player's class", minimum: 1 })),
+    shield: Type.Optional(
+      Type.Integer({ description: 'Current shield, absorbed before health; never regenerates', minimum: 0 })
+    ),
+    stamina: Type.Optional(
+      Type.Integer({ description: 'Current stamina, spent by dodge rolls and regenerated over time', minimum: 0 })
+    ),
+    class: Type.Optional(
+      Type.Union([Type.Literal('heavy'), Type.Literal('scout'), Type.Literal('gunner')], {
+        description: "Player's character class; absent for players without one",
+      })
+    ),
     isInvulnerable: Type.Boolean({ description: 'Whether spawn invulnerability is active' }),
     invulnerabilityEnd: Type.String({ description: 'RFC3339 timestamp when invulnerability ends' }),
     deathTime: Type.Optional(Type.String({ description: 'RFC3339 timestamp when the player died' })),
@@ -177,6 +419,21 @@ export const PlayerStateSchema = Type.Object(
     xp: Type.Integer({ description: 'Current XP total', minimum: 0 }),
     isRegenerating: Type.Boolean({ description: 'Whether the player is currently regenerating health' }),
     isRolling: Type.Boolean({ description: 'Whether the player is currently dodge rolling' }),
+    ultimateCharge: Type.Optional(
+      Type.Integer({ description: 'Ultimate meter; 100 means the ultimate is ready', minimum: 0, maximum: 100 })
+    ),
+    ultimateActive: Type.Optional(Type.Boolean({ description: "Whether the player's ultimate effect is running" })),
+    teleport: Type.Optional(
+      Type.Union([Type.Literal('respawn'), Type.Literal('force_sync')], {
+        description: 'Why the position just jumped; clients snap instead of interpolating',
+      })
+    ),
+    lastUpdated: Type.Optional(
+      Type.Integer({
+        description: 'Server tick the state last changed as clients see it; absent outside a room',
+        minimum: 0,
+      })
+    ),
   },
   { $id: 'PlayerState', description: 'Player state for movement updates' }
 );
@@ -229,6 +486,22 @@ export const ProjectileSpawnDataSchema = Type.Object(
     weaponType: Type.String({ description: 'Type of weapon that fired the projectile', minLength: 1 }),
     position: PositionRef,
     velocity: VelocityRef,
+    effectId: Type.Optional(
+      Type.String({ description: 'Cosmetic trail effect to render; omitted for the default trail', minLength: 1 })
+    ),
+    pellets: Type.Optional(
+      Type.Array(
+        Type.Object({
+          id: Type.String({ description: 'Unique pellet projectile identifier', minLength: 1 }),
+          velocity: VelocityRef,
+        }),
+        {
+          description:
+            'Every pellet of a spread shot, the first being the top-level projectile; pellets share its owner, weapon, position and effect',
+          minItems: 1,
+        }
+      )
+    ),
   },
   { $id: 'ProjectileSpawnData', description: 'Projectile spawn event payload' }
 );
@@ -270,13 +543,94 @@ export const ProjectileDestroyMessageSchema = createTypedMessageSchema(
 );
 export type ProjectileDestroyMessage = Static<typeof ProjectileDestroyMessageSchema>;
 
+// ============================================================================
+// projectile:explode
+// ============================================================================
+
+/**
+ * Projectile explode data payload.
+ * Sent when an explosive projectile blows up on a player or wall.
+ */
+export const ProjectileExplodeDataSchema = Type.Object(
+  {
+    projectileId: Type.String({ description: 'The projectile that exploded', minLength: 1 }),
+    ownerId: Type.String({ description: 'Player who fired the projectile', minLength: 1 }),
+    weaponType: Type.String({ description: 'Type of weapon that fired the projectile', minLength: 1 }),
+    position: PositionRef,
+    radius: Type.Number({ description: 'Splash radius in px', exclusiveMinimum: 0 }),
+  },
+  { $id: 'ProjectileExplodeData', description: 'Projectile explode event payload' }
+);
+
+export type ProjectileExplodeData = Static<typeof ProjectileExplodeDataSchema>;
+
+/**
+ * Complete projectile:explode message schema
+ */
+export const ProjectileExplodeMessageSchema = createTypedMessageSchema(
+  'projectile:explode',
+  ProjectileExplodeDataSchema
+);
+export type ProjectileExplodeMessage = Static<typeof ProjectileExplodeMessageSchema>;
+
 // ============================================================================
 // weapon:state
 // ============================================================================
 
+/**
+ * One ability's authoritative cooldown.
+ * Abilities are the equipped weapon's fire interval ('shoot' or 'melee') and 'roll'.
+ */
+export const AbilityStateSchema = Type.Object(
+  {
+    ability: Type.Union([Type.Literal('shoot'), Type.Literal('melee'), Type.Literal('roll')], {
+      description: 'Ability the cooldown belongs to',
+    }),
+    remainingMs: Type.Integer({ description: 'Milliseconds until the next charge is back; 0 when all are', minimum: 0 }),
+    charges: Type.Integer({ description: 'Uses available now', minimum: 0 }),
+    maxCharges: Type.Integer({ description: 'Uses the ability holds when fully recharged', minimum: 1 }),
+  },
+  { $id: 'AbilityState', description: 'Ability cooldown and charges' }
+);
+
+export type AbilityState = Static<typeof AbilityStateSchema>;
+
+/**
+ * Balance stats of the equipped weapon, from the server's weapon definitions.
+ * Operators tune these without a client release, so clients should prefer them
+ * over their bundled weapon-configs.json.
+ */
+export const WeaponStatsSchema = Type.Object(
+  {
+    damage: Type.Integer({ description: 'Damage per hit (per shot, split across its pellets, for the shotgun)', minimum: 0 }),
+    fireRate: Type.Number({ description: 'Shots or swings per second', exclusiveMinimum: 0 }),
+    magazineSize: Type.Integer({ description: 'Rounds per magazine; 0 for melee', minimum: 0 }),
+    reloadTimeMs: Type.Integer({ description: 'Reload duration in milliseconds; 0 for melee', minimum: 0 }),
+    projectileSpeed: Type.Number({ description: 'Projectile speed in px/s; 0 for melee', minimum: 0 }),
+    range: Type.Number({ description: 'Maximum range in px', minimum: 0 }),
+    spreadDegrees: Type.Number({ description: 'Movement spread in degrees', minimum: 0 }),
+    splashRadius: Type.Optional(
+      Type.Number({ description: 'Explosion radius in px; omitted for weapons that do not explode', exclusiveMinimum: 0 })
+    ),
+    comboWindowMs: Type.Optional(
+      Type.Integer({
+        description: 'Melee: after a landed swing, how long the next landed swing finishes the 2-hit combo; omitted without one',
+        exclusiveMinimum: 0,
+      })
+    ),
+    comboMultiplier: Type.Optional(
+      Type.Number({ description: "Melee: damage multiplier of the combo's finishing hit", minimum: 1 })
+    ),
+  },
+  { $id: 'WeaponStats', description: 'Equipped weapon balance stats' }
+);
+
+export type WeaponStats = Static<typeof WeaponStatsSchema>;
+
 /**
  * Weapon state data payload.
- * Sent when weapon state changes (ammo, reload status).
+ * Sent when weapon state changes (ammo, reload status) and when a dodge roll
+ * ends, with the player's ability cooldowns.
  */
 export const WeaponStateDataSchema = Type.Object(
   {
@@ -284,8 +638,15 @@ export const WeaponStateDataSchema = Type.Object(
     maxAmmo: Type.Integer({ description: 'Maximum ammunition capacity', minimum: 0 }),
     isReloading: Type.Boolean({ description: 'Whether the weapon is currently reloading' }),
     canShoot: Type.Boolean({ description: 'Whether the weapon can currently shoot' }),
-    weaponType: Type.String({ description: 'Name of the current weapon (e.g., "Pistol", "Bat", "Katana")', minLength: 1 }),
+    weaponType: Type.String({
+      description: 'Name of the current weapon (e.g., "Pistol", "Bat", "Katana", "Fists")',
+      minLength: 1,
+    }),
     isMelee: Type.Boolean({ description: 'Whether the current weapon is a melee weapon' }),
+    abilities: Type.Optional(Type.Array(AbilityStateSchema, { description: "The player's ability cooldowns" })),
+    stats: Type.Optional(WeaponStatsSchema),
+    stamina: Type.Optional(Type.Integer({ description: 'Stamina left for dodge rolls', minimum: 0 })),
+    maxStamina: Type.Optional(Type.Integer({ description: 'Full stamina', minimum: 1 })),
   },
   { $id: 'WeaponStateData', description: 'Weapon state payload' }
 );
@@ -321,6 +682,54 @@ export type ShootFailedData = Static<typeof ShootFailedDataSchema>;
 export const ShootFailedMessageSchema = createTypedMessageSchema('shoot:failed', ShootFailedDataSchema);
 export type ShootFailedMessage = Static<typeof ShootFailedMessageSchema>;
 
+// ============================================================================
+// player:renamed
+// ============================================================================
+
+/**
+ * Player renamed data payload.
+ * Sent to the player's room when it changes its display name.
+ */
+export const PlayerRenamedDataSchema = Type.Object(
+  {
+    playerId: Type.String({ description: 'Player who changed name', minLength: 1 }),
+    displayName: Type.String({ description: 'New sanitized display name', minLength: 1 }),
+  },
+  { $id: 'PlayerRenamedData', description: 'Player renamed event payload' }
+);
+
+export type PlayerRenamedData = Static<typeof PlayerRenamedDataSchema>;
+
+/**
+ * Complete player:renamed message schema
+ */
+export const PlayerRenamedMessageSchema = createTypedMessageSchema('player:renamed', PlayerRenamedDataSchema);
+export type PlayerRenamedMessage = Static<typeof PlayerRenamedMessageSchema>;
+
+// ============================================================================
+// rename:failed
+// ============================================================================
+
+/**
+ * Rename failed data payload.
+ * Sent to a player whose player:rename came within the name change cooldown.
+ */
+export const RenameFailedDataSchema = Type.Object(
+  {
+    reason: Type.Literal('cooldown', { description: 'Why the name was not changed' }),
+    retryAfterMs: Type.Integer({ description: 'Milliseconds until the player may change name again', minimum: 0 }),
+  },
+  { $id: 'RenameFailedData', description: 'Rename failed event payload' }
+);
+
+export type RenameFailedData = Static<typeof RenameFailedDataSchema>;
+
+/**
+ * Complete rename:failed message schema
+ */
+export const RenameFailedMessageSchema = createTypedMessageSchema('rename:failed', RenameFailedDataSchema);
+export type RenameFailedMessage = Static<typeof RenameFailedMessageSchema>;
+
 // ============================================================================
 // player:damaged
 // ============================================================================
@@ -335,7 +744,19 @@ export const PlayerDamagedDataSchema = Type.Object(
     attackerId: Type.String({ description: 'Player who dealt damage', minLength: 1 }),
     damage: Type.Number({ description: 'Amount of damage dealt', minimum: 0 }),
     newHealth: Type.Number({ description: 'Victim health after damage', minimum: 0 }),
+    newShield: Type.Optional(
+      Type.Integer({ description: 'Victim shield after it absorbed what it could of the damage', minimum: 0 })
+    ),
     projectileId: Type.String({ description: 'Projectile that caused damage', minLength: 1 }),
+    impulse: Type.Optional(
+      Type.Object(
+        {
+          x: Type.Number({ description: 'Velocity added along X (px/s)' }),
+          y: Type.Number({ description: 'Velocity added along Y (px/s)' }),
+        },
+        { description: 'Velocity a heavy ranged hit added to the victim; omitted when the hit did not push' }
+      )
+    ),
   },
   { $id: 'PlayerDamagedData', description: 'Player damaged event payload' }
 );
@@ -373,6 +794,44 @@ export type HitConfirmedData = Static<typeof HitConfirmedDataSchema>;
 export const HitConfirmedMessageSchema = createTypedMessageSchema('hit:confirmed', HitConfirmedDataSchema);
 export type HitConfirmedMessage = Static<typeof HitConfirmedMessageSchema>;
 
+// ============================================================================
+// hit:rejected
+// ============================================================================
+
+/**
+ * Hit rejected data payload.
+ * Sent to an attacker whose shot or swing hit nobody when a target was in line,
+ * so the client can take back hit feedback it played early.
+ */
+export const HitRejectedDataSchema = Type.Object(
+  {
+    attack: Type.Union([Type.Literal('shoot'), Type.Literal('melee')], {
+      description: 'Kind of attack that was rejected',
+    }),
+    code: Type.Union(
+      [
+        Type.Literal('invulnerable'),
+        Type.Literal('out_of_range'),
+        Type.Literal('cooldown'),
+        Type.Literal('rewind_limit'),
+      ],
+      { description: 'Why the attack did not land' }
+    ),
+    victimId: Type.Optional(
+      Type.String({ description: 'Player the attack would have hit; absent for cooldown', minLength: 1 })
+    ),
+  },
+  { $id: 'HitRejectedData', description: 'Hit rejected event payload' }
+);
+
+export type HitRejectedData = Static<typeof HitRejectedDataSchema>;
+
+/**
+ * Complete hit:rejected message schema
+ */
+export const HitRejectedMessageSchema = createTypedMessageSchema('hit:rejected', HitRejectedDataSchema);
+export type HitRejectedMessage = Static<typeof HitRejectedMessageSchema>;
+
 // ============================================================================
 // player:death
 // ============================================================================
@@ -426,6 +885,73 @@ export const PlayerKillCreditMessageSchema = createTypedMessageSchema(
 );
 export type PlayerKillCreditMessage = Static<typeof PlayerKillCreditMessageSchema>;
 
+// ============================================================================
+// player:killstreak
+// ============================================================================
+
+/**
+ * Player killstreak data payload.
+ * Sent after player:kill_credit when a kill extends the killer's streak.
+ */
+export const PlayerKillstreakDataSchema = Type.Object(
+  {
+    playerId: Type.String({ description: 'Player on the streak', minLength: 1 }),
+    streak: Type.Integer({ description: 'Kills since the player last died', minimum: 2 }),
+    multiKill: Type.Integer({
+      description: 'Kills chained within the multi-kill window (2 is a double kill, 3 a triple)',
+      minimum: 1,
+    }),
+    bonusXp: Type.Integer({ description: 'Bonus XP this kill earned on top of the kill XP', minimum: 0 }),
+  },
+  { $id: 'PlayerKillstreakData', description: 'Player killstreak event payload' }
+);
+
+export type PlayerKillstreakData = Static<typeof PlayerKillstreakDataSchema>;
+
+/**
+ * Complete player:killstreak message schema
+ */
+export const PlayerKillstreakMessageSchema = createTypedMessageSchema(
+  'player:killstreak',
+  PlayerKillstreakDataSchema
+);
+export type PlayerKillstreakMessage = Static<typeof PlayerKillstreakMessageSchema>;
+
+// ============================================================================
+// player:assist_credit
+// ============================================================================
+
+/**
+ * Player assist credit data payload.
+ * Sent after player:kill_credit to each player who damaged the victim within
+ * the assist window without landing the kill.
+ */
+export const PlayerAssistCreditDataSchema = Type.Object(
+  {
+    playerId: Type.String({ description: 'Player credited with the assist', minLength: 1 }),
+    victimId: Type.String({ description: 'Player who died', minLength: 1 }),
+    killerId: Type.String({ description: 'Player who landed the kill', minLength: 1 }),
+    damage: Type.Integer({
+      description: 'Damage the assisting player dealt the victim within the assist window',
+      minimum: 1,
+    }),
+    playerAssists: Type.Integer({ description: "Assisting player's assists this match", minimum: 1 }),
+    playerXP: Type.Integer({ description: "Assisting player's total XP", minimum: 0 }),
+  },
+  { $id: 'PlayerAssistCreditData', description: 'Player assist credit event payload' }
+);
+
+export type PlayerAssistCreditData = Static<typeof PlayerAssistCreditDataSchema>;
+
+/**
+ * Complete player:assist_credit message schema
+ */
+export const PlayerAssistCreditMessageSchema = createTypedMessageSchema(
+  'player:assist_credit',
+  PlayerAssistCreditDataSchema
+);
+export type PlayerAssistCreditMessage = Static<typeof PlayerAssistCreditMessageSchema>;
+
 // ============================================================================
 // player:respawn
 // ============================================================================
@@ -499,6 +1025,9 @@ export const PlayerScoreSchema = Type.Object(
     kills: Type.Integer({ description: 'Number of kills', minimum: 0 }),
     deaths: Type.Integer({ description: 'Number of deaths', minimum: 0 }),
     xp: Type.Integer({ description: 'Total XP earned', minimum: 0 }),
+    bestKillStreak: Type.Optional(
+      Type.Integer({ description: 'Longest run of kills without dying', minimum: 0 })
+    ),
   },
   { $id: 'PlayerScore', description: 'Player final score data' }
 );
@@ -506,30 +1035,673 @@ export const PlayerScoreSchema = Type.Object(
 export type PlayerScore = Static<typeof PlayerScoreSchema>;
 
 /**
- * Match ended data payload.
- * Sent when the match concludes.
+ * End-of-match scoreboard row with every stat the match tracked.
  */
-export const MatchEndedDataSchema = Type.Object(
+export const PlayerMatchStatsSchema = Type.Object(
   {
-    winners: Type.Array(WinnerSummarySchema, { description: 'Array of display-ready winner summaries' }),
-    finalScores: Type.Array(PlayerScoreSchema, {
-      description: 'Array of final player scores',
-    }),
-    reason: Type.String({ description: 'Reason the match ended', minLength: 1 }),
+    playerId: Type.String({ description: 'Player unique identifier', minLength: 1 }),
+    displayName: Type.String({ description: 'Display-ready player name', minLength: 1 }),
+    kills: Type.Integer({ description: 'Kills this match', minimum: 0 }),
+    deaths: Type.Integer({ description: 'Number of deaths', minimum: 0 }),
+    assists: Type.Integer({ description: 'Assists this match', minimum: 0 }),
+    xp: Type.Integer({ description: 'Total XP earned', minimum: 0 }),
+    shotsFired: Type.Integer({ description: 'Projectiles, pellets and hitscan rays fired', minimum: 0 }),
+    shotsHit: Type.Integer({ description: 'Ranged hits on other players', minimum: 0 }),
+    accuracy: Type.Number({ description: 'shotsHit / shotsFired, at most 1; 0 without shots', minimum: 0, maximum: 1 }),
+    damageDealt: Type.Integer({ description: 'Damage dealt to other players, ranged and melee', minimum: 0 }),
+    bestKillStreak: Type.Integer({ description: 'Longest run of kills without dying', minimum: 0 }),
   },
-  { $id: 'MatchEndedData', description: 'Match ended event payload' }
+  { $id: 'PlayerMatchStats', description: 'End-of-match scoreboard row' }
 );
 
-export type MatchEndedData = Static<typeof MatchEndedDataSchema>;
+export type PlayerMatchStats = Static<typeof PlayerMatchStatsSchema>;
 
 /**
- * Complete match:ended message schema
+ * Rules a match ran under, reported in match:ended.
  */
-export const MatchEndedMessageSchema = createTypedMessageSchema('match:ended', MatchEndedDataSchema);
-export type MatchEndedMessage = Static<typeof MatchEndedMessageSchema>;
+export const MatchSettingsSchema = Type.Object(
+  {
+    roomKind: Type.Union([Type.Literal('public'), Type.Literal('code'), Type.Literal('practice')], {
+      description: 'How the room was formed',
+    }),
+    maxPlayers: Type.Integer({ description: 'Room capacity', minimum: 1 }),
+    killTarget: Type.Integer({ description: 'Kills needed to win', minimum: 1 }),
+    timeLimitSeconds: Type.Integer({ description: 'Match time limit in seconds', minimum: 1 }),
+    aimTurnRate: Type.Optional(
+      Type.Number({
+        description: "Room aim turn rate cap in radians per second; absent when the server's applies",
+        exclusiveMinimum: 0,
+      })
+    ),
+  },
+  { $id: 'MatchSettings', description: 'Rules a match ran under' }
+);
 
-// ============================================================================
-// weapon:spawned
+export type MatchSettings = Static<typeof MatchSettingsSchema>;
+
+/**
+ * Server build that ran a match, reported in match:ended.
+ */
+export const BuildInfoSchema = Type.Object(
+  {
+    commit: Type.String({ description: 'Git commit the server was built from, or "unknown"', minLength: 1 }),
+    buildTime: Type.String({ description: 'UTC build time (RFC 3339), or "unknown"', minLength: 1 }),
+    goVersion: Type.String({ description: 'Go toolchain version the server was built with', minLength: 1 }),
+  },
+  { $id: 'BuildInfo', description: 'Server build identity' }
+);
+
+export type BuildInfo = Static<typeof BuildInfoSchema>;
+
+/**
+ * Match ended data payload.
+ * Sent when the match concludes. The settings, map, mutators, seed and build
+ * make every result reproducible; the server always sends them.
+ */
+export const MatchEndedDataSchema = Type.Object(
+  {
+    winners: Type.Array(WinnerSummarySchema, { description: 'Array of display-ready winner summaries' }),
+    finalScores: Type.Array(PlayerScoreSchema, {
+      description: 'Array of final player scores',
+    }),
+    reason: Type.String({ description: 'Reason the match ended', minLength: 1 }),
+    scoreboard: Type.Optional(
+      Type.Array(PlayerMatchStatsSchema, {
+        description: 'Full per-player stats, sorted by kills descending; always sent by the server',
+      })
+    ),
+    settings: Type.Optional(MatchSettingsSchema),
+    mapId: Type.Optional(Type.String({ description: 'Map the match was played on', minLength: 1 })),
+    mutators: Type.Optional(
+      Type.Array(Type.String({ minLength: 1 }), {
+        description: 'Named gameplay hooks (mode scripts) that changed the rules, in the order they applied',
+      })
+    ),
+    seed: Type.Optional(Type.Integer({ description: "Room random seed; ROOM_SEED replays the match", minimum: 1 })),
+    build: Type.Optional(BuildInfoSchema),
+  },
+  { $id: 'MatchEndedData', description: 'Match ended event payload' }
+);
+
+export type MatchEndedData = Static<typeof MatchEndedDataSchema>;
+
+/**
+ * Complete match:ended message schema
+ */
+export const MatchEndedMessageSchema = createTypedMessageSchema('match:ended', MatchEndedDataSchema);
+export type MatchEndedMessage = Static<typeof MatchEndedMessageSchema>;
+
+// ============================================================================
+// world:sync
+// ============================================================================
+
+/**
+ * Scoreboard row for world:sync.
+ * Kills and assists are match-scoped; deaths and XP come from player state.
+ */
+export const ScoreboardEntrySchema = Type.Object(
+  {
+    playerId: Type.String({ description: 'Player unique identifier', minLength: 1 }),
+    displayName: Type.String({ description: 'Display-ready player name', minLength: 1 }),
+    kills: Type.Integer({ description: 'Kills this match', minimum: 0 }),
+    deaths: Type.Integer({ description: 'Number of deaths', minimum: 0 }),
+    assists: Type.Integer({ description: 'Assists this match', minimum: 0 }),
+    xp: Type.Integer({ description: 'Total XP earned', minimum: 0 }),
+  },
+  { $id: 'ScoreboardEntry', description: 'Authoritative scoreboard row' }
+);
+
+export type ScoreboardEntry = Static<typeof ScoreboardEntrySchema>;
+
+/**
+ * World sync data payload.
+ * Sent to a player who joins a match in progress or resumes a session, so it
+ * starts from the authoritative scores instead of the kill events it missed.
+ */
+export const WorldSyncDataSchema = Type.Object(
+  {
+    matchState: Type.Union([Type.Literal('waiting'), Type.Literal('active'), Type.Literal('ended')], {
+      description: 'Current match state',
+    }),
+    remainingSeconds: Type.Integer({ description: 'Seconds remaining in the match', minimum: 0 }),
+    killTarget: Type.Integer({ description: 'Kills needed to win the match', minimum: 1 }),
+    playerKills: Type.Record(Type.String(), Type.Integer({ minimum: 0 }), {
+      description: 'Map of player IDs to their kill count this match, including players who have left',
+    }),
+    scoreboard: Type.Array(ScoreboardEntrySchema, {
+      description: 'Scoreboard rows for players in the room, sorted by kills descending',
+    }),
+  },
+  { $id: 'WorldSyncData', description: 'Authoritative match score state for reconnecting and late-joining players' }
+);
+
+export type WorldSyncData = Static<typeof WorldSyncDataSchema>;
+
+/**
+ * Complete world:sync message schema
+ */
+export const WorldSyncMessageSchema = createTypedMessageSchema('world:sync', WorldSyncDataSchema);
+export type WorldSyncMessage = Static<typeof WorldSyncMessageSchema>;
+
+// ============================================================================
+// map:load
+// ============================================================================
+
+/**
+ * Map obstacle geometry for map:load.
+ * Rectangles in world pixels, with the same fields as the shared map files.
+ */
+export const MapObstacleSchema = Type.Object(
+  {
+    id: Type.String({ description: 'Authored obstacle identifier', minLength: 1 }),
+    type: Type.Union([Type.Literal('wall'), Type.Literal('desk'), Type.Literal('pillar')], {
+      description: 'Semantic obstacle type',
+    }),
+    shape: Type.Literal('rectangle', { description: 'Obstacle shape' }),
+    x: Type.Number({ description: 'Left edge in pixels', minimum: 0 }),
+    y: Type.Number({ description: 'Top edge in pixels', minimum: 0 }),
+    width: Type.Number({ description: 'Width in pixels', exclusiveMinimum: 0 }),
+    height: Type.Number({ description: 'Height in pixels', exclusiveMinimum: 0 }),
+    blocksMovement: Type.Boolean({ description: 'Whether players collide with the obstacle' }),
+    blocksProjectiles: Type.Boolean({ description: 'Whether projectiles stop at the obstacle' }),
+    blocksLineOfSight: Type.Boolean({ description: 'Whether the obstacle blocks line of sight' }),
+  },
+  { $id: 'MapObstacle', description: 'Rectangular map obstacle' }
+);
+
+export type MapObstacle = Static<typeof MapObstacleSchema>;
+
+/**
+ * Map load data payload.
+ * Sent to a player when its match starts or its session resumes, with the
+ * geometry the server collides players and projectiles against.
+ */
+export const MapLoadDataSchema = Type.Object(
+  {
+    mapId: Type.String({ description: 'Map identifier', minLength: 1 }),
+    name: Type.String({ description: 'Display name of the map', minLength: 1 }),
+    width: Type.Number({ description: 'World width in pixels', exclusiveMinimum: 0 }),
+    height: Type.Number({ description: 'World height in pixels', exclusiveMinimum: 0 }),
+    obstacles: Type.Array(MapObstacleSchema, { description: 'Walls and obstacles' }),
+  },
+  { $id: 'MapLoadData', description: 'Authoritative map geometry' }
+);
+
+export type MapLoadData = Static<typeof MapLoadDataSchema>;
+
+/**
+ * Complete map:load message schema
+ */
+export const MapLoadMessageSchema = createTypedMessageSchema('map:load', MapLoadDataSchema);
+export type MapLoadMessage = Static<typeof MapLoadMessageSchema>;
+
+// ============================================================================
+// lobby:sandbox_state
+// ============================================================================
+
+/**
+ * Lobby sandbox state data payload.
+ * Sent at 20Hz to a player waiting in the matchmaking queue, with the state of
+ * its solo practice world: the player, a stationary target dummy and the
+ * player's pistol projectiles. Stops once the player's room forms.
+ */
+export const LobbySandboxStateDataSchema = Type.Object(
+  {
+    width: Type.Number({ description: 'Sandbox width in pixels', exclusiveMinimum: 0 }),
+    height: Type.Number({ description: 'Sandbox height in pixels', exclusiveMinimum: 0 }),
+    player: Type.Object(
+      {
+        position: PositionRef,
+        velocity: VelocityRef,
+        aimAngle: Type.Number({ description: 'Aim angle in radians' }),
+      },
+      { description: 'The waiting player' }
+    ),
+    dummy: Type.Object(
+      {
+        position: PositionRef,
+        health: Type.Integer({ description: 'Dummy health; refills when it runs out', minimum: 0 }),
+      },
+      { description: 'Stationary target dummy' }
+    ),
+    projectiles: Type.Array(
+      Type.Object({
+        id: Type.String({ description: 'Unique projectile identifier', minLength: 1 }),
+        position: PositionRef,
+        velocity: VelocityRef,
+      }),
+      { description: 'Projectiles in flight' }
+    ),
+    hits: Type.Integer({ description: 'Shots that hit the dummy', minimum: 0 }),
+    knockdowns: Type.Integer({ description: "Times the dummy's health ran out", minimum: 0 }),
+  },
+  { $id: 'LobbySandboxStateData', description: 'Solo practice world state while waiting for a match' }
+);
+
+export type LobbySandboxStateData = Static<typeof LobbySandboxStateDataSchema>;
+
+/**
+ * Complete lobby:sandbox_state message schema
+ */
+export const LobbySandboxStateMessageSchema = createTypedMessageSchema(
+  'lobby:sandbox_state',
+  LobbySandboxStateDataSchema
+);
+export type LobbySandboxStateMessage = Static<typeof LobbySandboxStateMessageSchema>;
+
+// ============================================================================
+// queue:status
+// ============================================================================
+
+/**
+ * Queue status data payload.
+ * Sent every 2 seconds to each player waiting in the public matchmaking queue,
+ * so the client can show where it stands until its room forms.
+ */
+export const QueueStatusDataSchema = Type.Object(
+  {
+    position: Type.Integer({ description: '1-based place in the matchmaking queue', minimum: 1 }),
+    estimatedWaitMs: Type.Optional(
+      Type.Integer({
+        description: 'Estimated milliseconds until a room forms; omitted when there is nothing to estimate from',
+        minimum: 0,
+      })
+    ),
+    playersOnline: Type.Integer({ description: 'Players on this server instance, queued ones included', minimum: 1 }),
+  },
+  { $id: 'QueueStatusData', description: 'Matchmaking queue position and estimated wait' }
+);
+
+export type QueueStatusData = Static<typeof QueueStatusDataSchema>;
+
+/**
+ * Complete queue:status message schema
+ */
+export const QueueStatusMessageSchema = createTypedMessageSchema('queue:status', QueueStatusDataSchema);
+export type QueueStatusMessage = Static<typeof QueueStatusMessageSchema>;
+
+// ============================================================================
+// spawn:options
+// ============================================================================
+
+/**
+ * Spawn options data payload.
+ * Sent every 500ms to each dead player of a match in progress, with every
+ * spawn point rated the way automatic spawn selection rates it. The player
+ * may pick an allowed point with player:spawn_choice.
+ */
+export const SpawnOptionsDataSchema = Type.Object(
+  {
+    points: Type.Array(
+      Type.Object({
+        index: Type.Integer({ description: 'Spawn point index in map order, sent back in player:spawn_choice', minimum: 0 }),
+        position: PositionRef,
+        danger: Type.Number({
+          description: 'How much lower the point scores than the safest one, in pixels of enemy distance; 0 for the safest',
+          minimum: 0,
+        }),
+        allowed: Type.Boolean({ description: 'Whether the point may be picked: danger is at most 200' }),
+      }),
+      { description: 'Valid spawn points of the map, in map order' }
+    ),
+  },
+  { $id: 'SpawnOptionsData', description: 'Spawn points a dead player can pick from' }
+);
+
+export type SpawnOptionsData = Static<typeof SpawnOptionsDataSchema>;
+
+/**
+ * Complete spawn:options message schema
+ */
+export const SpawnOptionsMessageSchema = createTypedMessageSchema('spawn:options', SpawnOptionsDataSchema);
+export type SpawnOptionsMessage = Static<typeof SpawnOptionsMessageSchema>;
+
+// ============================================================================
+// team:ping
+// ============================================================================
+
+/**
+ * Team ping data payload.
+ * Sent to the pinging player's team, the pinging player included, when a
+ * teammate marks a point with player:ping. In free-for-all rooms only the
+ * pinging player receives it. Nothing is stored; late joiners never see it.
+ */
+export const TeamPingDataSchema = Type.Object(
+  {
+    playerId: Type.String({ description: 'Player who placed the ping', minLength: 1 }),
+    type: Type.Union([Type.Literal('go'), Type.Literal('enemy'), Type.Literal('danger'), Type.Literal('loot')], {
+      description: 'What the ping marks',
+    }),
+    position: PositionRef,
+  },
+  { $id: 'TeamPingData', description: 'Tactical ping from a teammate' }
+);
+
+export type TeamPingData = Static<typeof TeamPingDataSchema>;
+
+/**
+ * Complete team:ping message schema
+ */
+export const TeamPingMessageSchema = createTypedMessageSchema('team:ping', TeamPingDataSchema);
+export type TeamPingMessage = Static<typeof TeamPingMessageSchema>;
+
+// ============================================================================
+// server:shutdown
+// ============================================================================
+
+/**
+ * Server shutdown data payload.
+ * Sent once to every connected player when the server starts draining for a
+ * shutdown. Running matches may finish inside the countdown; any still running
+ * when it runs out end with reason server_shutdown, and then every connection
+ * is closed.
+ */
+export const ServerShutdownDataSchema = Type.Object(
+  {
+    countdownMs: Type.Integer({ description: 'Milliseconds until running matches are ended and connections close', minimum: 0 }),
+  },
+  { $id: 'ServerShutdownData', description: 'Server shutdown notice payload' }
+);
+
+export type ServerShutdownData = Static<typeof ServerShutdownDataSchema>;
+
+/**
+ * Complete server:shutdown message schema
+ */
+export const ServerShutdownMessageSchema = createTypedMessageSchema('server:shutdown', ServerShutdownDataSchema);
+export type ServerShutdownMessage = Static<typeof ServerShutdownMessageSchema>;
+
+// ============================================================================
+// room:redirect
+// ============================================================================
+
+/**
+ * Room redirect data payload.
+ * Sent instead of a session:status when another server instance in the
+ * cluster should host the player: the one holding their named room, or the
+ * least-loaded one for public matchmaking. The client reconnects to address
+ * and repeats its player:hello there.
+ */
+export const RoomRedirectDataSchema = Type.Object(
+  {
+    address: Type.String({ description: 'WebSocket URL of the instance to join through', minLength: 1 }),
+    reason: Type.Union([Type.Literal('room_code'), Type.Literal('least_loaded')], {
+      description: 'Why the player is sent there',
+    }),
+  },
+  { $id: 'RoomRedirectData', description: 'Cross-instance redirect payload' }
+);
+
+export type RoomRedirectData = Static<typeof RoomRedirectDataSchema>;
+
+/**
+ * Complete room:redirect message schema
+ */
+export const RoomRedirectMessageSchema = createTypedMessageSchema('room:redirect', RoomRedirectDataSchema);
+export type RoomRedirectMessage = Static<typeof RoomRedirectMessageSchema>;
+
+// ============================================================================
+// player:emoted
+// ============================================================================
+
+/**
+ * Player emoted data payload.
+ * Broadcast to the room when a player sends player:emote. Clients that opted
+ * out of player:emoted with player:preferences skip it.
+ */
+export const PlayerEmotedDataSchema = Type.Object(
+  {
+    playerId: Type.String({ description: 'Player who emoted', minLength: 1 }),
+    emote: Type.Union(
+      [
+        Type.Literal('wave'),
+        Type.Literal('laugh'),
+        Type.Literal('taunt'),
+        Type.Literal('salute'),
+        Type.Literal('gg'),
+        Type.Literal('nice_shot'),
+        Type.Literal('thanks'),
+        Type.Literal('sorry'),
+      ],
+      { description: 'Emote or canned quick-chat line' }
+    ),
+  },
+  { $id: 'PlayerEmotedData', description: 'Emote or quick-chat line from a player' }
+);
+
+export type PlayerEmotedData = Static<typeof PlayerEmotedDataSchema>;
+
+/**
+ * Complete player:emoted message schema
+ */
+export const PlayerEmotedMessageSchema = createTypedMessageSchema('player:emoted', PlayerEmotedDataSchema);
+export type PlayerEmotedMessage = Static<typeof PlayerEmotedMessageSchema>;
+
+// ============================================================================
+// chat:posted
+// ============================================================================
+
+/**
+ * Chat posted data payload.
+ * Broadcast to the room, sender included, when a player sends chat:message;
+ * team chat goes to the sender's team only. Players who muted the sender with
+ * chat:mute do not receive it. The text has already been through the server's
+ * profanity filter.
+ */
+export const ChatPostedDataSchema = Type.Object(
+  {
+    playerId: Type.String({ description: 'Player who sent the message', minLength: 1 }),
+    displayName: Type.String({ description: 'Sender display name' }),
+    text: Type.String({ description: 'Filtered message text', minLength: 1, maxLength: 200 }),
+    channel: Type.Union([Type.Literal('all'), Type.Literal('team')], {
+      description: "Who could see the message: the whole room or the sender's team",
+    }),
+  },
+  { $id: 'ChatPostedData', description: 'Chat message from a player in the room' }
+);
+
+export type ChatPostedData = Static<typeof ChatPostedDataSchema>;
+
+/**
+ * Complete chat:posted message schema
+ */
+export const ChatPostedMessageSchema = createTypedMessageSchema('chat:posted', ChatPostedDataSchema);
+export type ChatPostedMessage = Static<typeof ChatPostedMessageSchema>;
+
+// ============================================================================
+// room:votekick_progress
+// ============================================================================
+
+/**
+ * Vote-kick progress data payload.
+ * Broadcast to the room, target included, each time a room:votekick vote
+ * counts. When passed is true the target is being kicked.
+ */
+export const RoomVoteKickProgressDataSchema = Type.Object(
+  {
+    targetId: Type.String({ description: 'Player being voted out', minLength: 1 }),
+    voterId: Type.String({ description: 'Player whose vote this was', minLength: 1 }),
+    votes: Type.Integer({ description: 'Votes so far from players still in the room', minimum: 1 }),
+    needed: Type.Integer({ description: 'Votes that kick the target', minimum: 2 }),
+    expiresInMs: Type.Integer({ description: 'Milliseconds until the vote lapses', minimum: 0 }),
+    passed: Type.Boolean({ description: 'True when the vote passed and the target is kicked' }),
+  },
+  { $id: 'RoomVoteKickProgressData', description: 'Vote-kick progress in the room' }
+);
+
+export type RoomVoteKickProgressData = Static<typeof RoomVoteKickProgressDataSchema>;
+
+/**
+ * Complete room:votekick_progress message schema
+ */
+export const RoomVoteKickProgressMessageSchema = createTypedMessageSchema(
+  'room:votekick_progress',
+  RoomVoteKickProgressDataSchema
+);
+export type RoomVoteKickProgressMessage = Static<typeof RoomVoteKickProgressMessageSchema>;
+
+// ============================================================================
+// leaderboard:update
+// ============================================================================
+
+/**
+ * One player at the top of the leaderboard
+ */
+export const LeaderboardUpdateEntrySchema = Type.Object(
+  {
+    rank: Type.Integer({ description: 'Place by XP, starting at 1', minimum: 1 }),
+    playerId: Type.String({ description: 'Ranked player', minLength: 1 }),
+    displayName: Type.String({ description: 'Name in their latest recorded match' }),
+    xp: Type.Integer({ description: 'XP over every recorded match', minimum: 0 }),
+    kd: Type.Number({ description: 'Kills over deaths, counting no deaths as one', minimum: 0 }),
+    wins: Type.Integer({ description: 'Recorded matches won', minimum: 0 }),
+  },
+  { $id: 'LeaderboardUpdateEntry', description: 'One player at the top of the leaderboard' }
+);
+
+export type LeaderboardUpdateEntry = Static<typeof LeaderboardUpdateEntrySchema>;
+
+/**
+ * Leaderboard update data payload.
+ * Sent to every connected player when the recomputed leaderboard's top
+ * changes. GET /leaderboard pages through the rest.
+ */
+export const LeaderboardUpdateDataSchema = Type.Object(
+  {
+    matches: Type.Integer({ description: 'Recorded matches ranked', minimum: 0 }),
+    total: Type.Integer({ description: 'Players on the leaderboard', minimum: 0 }),
+    top: Type.Array(LeaderboardUpdateEntrySchema, {
+      description: 'Top players by XP, best first',
+      maxItems: 10,
+    }),
+  },
+  { $id: 'LeaderboardUpdateData', description: 'Top of the recomputed leaderboard' }
+);
+
+export type LeaderboardUpdateData = Static<typeof LeaderboardUpdateDataSchema>;
+
+/**
+ * Complete leaderboard:update message schema
+ */
+export const LeaderboardUpdateMessageSchema = createTypedMessageSchema('leaderboard:update', LeaderboardUpdateDataSchema);
+export type LeaderboardUpdateMessage = Static<typeof LeaderboardUpdateMessageSchema>;
+
+// ============================================================================
+// player:level_up
+// ============================================================================
+
+/**
+ * Player level up data payload.
+ * Sent to a player when XP carries them past one or more level thresholds.
+ */
+export const PlayerLevelUpDataSchema = Type.Object(
+  {
+    playerId: Type.String({ description: 'Player who leveled up', minLength: 1 }),
+    level: Type.Integer({ description: 'Level reached', minimum: 2 }),
+    previousLevel: Type.Integer({ description: 'Level before this XP', minimum: 1 }),
+    xp: Type.Integer({ description: 'Total XP, recorded matches included', minimum: 0 }),
+    nextLevelXp: Type.Integer({ description: 'Total XP of the next level; 0 at the highest level', minimum: 0 }),
+    unlocks: Type.Array(Type.String({ minLength: 1 }), {
+      description: 'Identifiers unlocked by every level gained, in level order',
+    }),
+  },
+  { $id: 'PlayerLevelUpData', description: 'Level reached and what it unlocked' }
+);
+
+export type PlayerLevelUpData = Static<typeof PlayerLevelUpDataSchema>;
+
+/**
+ * Complete player:level_up message schema
+ */
+export const PlayerLevelUpMessageSchema = createTypedMessageSchema('player:level_up', PlayerLevelUpDataSchema);
+export type PlayerLevelUpMessage = Static<typeof PlayerLevelUpMessageSchema>;
+
+// ============================================================================
+// practice:status
+// ============================================================================
+
+/**
+ * Practice status data payload.
+ * Sent to the player of a practice room when its match starts and whenever
+ * the bots' difficulty changes with the player's K/D against them.
+ */
+export const PracticeStatusDataSchema = Type.Object(
+  {
+    difficulty: Type.Union(
+      [Type.Literal('easy'), Type.Literal('normal'), Type.Literal('hard'), Type.Literal('expert')],
+      { description: 'Current bot difficulty step' }
+    ),
+    accuracy: Type.Number({ description: "Chance (0-1) a bot's shot is aimed on target", minimum: 0, maximum: 1 }),
+    reactionTime: Type.Number({ description: 'Seconds before a bot fires at a newly seen enemy', minimum: 0 }),
+    kd: Type.Number({ description: "Player's K/D against the bots over the current window", minimum: 0 }),
+  },
+  { $id: 'PracticeStatusData', description: 'Practice bot difficulty and player K/D' }
+);
+
+export type PracticeStatusData = Static<typeof PracticeStatusDataSchema>;
+
+/**
+ * Complete practice:status message schema
+ */
+export const PracticeStatusMessageSchema = createTypedMessageSchema('practice:status', PracticeStatusDataSchema);
+export type PracticeStatusMessage = Static<typeof PracticeStatusMessageSchema>;
+
+// ============================================================================
+// net:ping
+// ============================================================================
+
+/**
+ * Heartbeat ping data payload.
+ * Sent to every connection on an interval. The client answers with a net:pong
+ * echoing both fields; connections that miss several in a row are closed.
+ */
+export const NetPingDataSchema = Type.Object(
+  {
+    id: Type.Integer({ description: 'Ping ID, increasing per connection', minimum: 1 }),
+    serverTime: Type.Integer({ description: 'Server time (Unix ms) the ping was sent', minimum: 0 }),
+  },
+  { $id: 'NetPingData', description: 'Heartbeat ping' }
+);
+
+export type NetPingData = Static<typeof NetPingDataSchema>;
+
+/**
+ * Complete net:ping message schema
+ */
+export const NetPingMessageSchema = createTypedMessageSchema('net:ping', NetPingDataSchema);
+export type NetPingMessage = Static<typeof NetPingMessageSchema>;
+
+// ============================================================================
+// time:sync
+// ============================================================================
+
+/**
+ * Clock sync reply data payload.
+ * Answers a time:sync_request with the server's receive and transmit times.
+ * With t0 = clientTime, t1 = serverReceiveTime, t2 = serverTransmitTime and
+ * t3 the client's arrival time, the client's offset to the server clock is
+ * ((t1 - t0) + (t2 - t3)) / 2 and the round trip (t3 - t0) - (t2 - t1).
+ */
+export const TimeSyncDataSchema = Type.Object(
+  {
+    clientTime: Type.Number({ description: 'clientTime of the time:sync_request being answered' }),
+    serverReceiveTime: Type.Integer({ description: 'Server time (Unix ms) the request arrived', minimum: 0 }),
+    serverTransmitTime: Type.Integer({ description: 'Server time (Unix ms) the reply was sent', minimum: 0 }),
+    tick: Type.Integer({ description: 'Server simulation tick when the reply was sent', minimum: 0 }),
+  },
+  { $id: 'TimeSyncData', description: 'Clock sync reply' }
+);
+
+export type TimeSyncData = Static<typeof TimeSyncDataSchema>;
+
+/**
+ * Complete time:sync message schema
+ */
+export const TimeSyncMessageSchema = createTypedMessageSchema('time:sync', TimeSyncDataSchema);
+export type TimeSyncMessage = Static<typeof TimeSyncMessageSchema>;
+
+// ============================================================================
+// weapon:spawned
 // ============================================================================
 
 /**
@@ -626,6 +1798,173 @@ export const WeaponRespawnedMessageSchema = createTypedMessageSchema(
 );
 export type WeaponRespawnedMessage = Static<typeof WeaponRespawnedMessageSchema>;
 
+// ============================================================================
+// shield:spawned
+// ============================================================================
+
+/**
+ * Shield crate schema for spawned shield pickups.
+ */
+export const ShieldCrateSchema = Type.Object(
+  {
+    id: Type.String({ description: 'Unique crate identifier', minLength: 1 }),
+    position: PositionRef,
+    isAvailable: Type.Boolean({ description: 'Whether the crate is available for pickup' }),
+  },
+  { $id: 'ShieldCrate', description: 'Shield crate state' }
+);
+
+export type ShieldCrate = Static<typeof ShieldCrateSchema>;
+
+/**
+ * Shield spawned data payload.
+ * Sent to a player entering a match with the map's shield crates.
+ */
+export const ShieldSpawnedDataSchema = Type.Object(
+  {
+    crates: Type.Array(ShieldCrateSchema, { description: 'Array of shield crates' }),
+  },
+  { $id: 'ShieldSpawnedData', description: 'Shield spawned event payload' }
+);
+
+export type ShieldSpawnedData = Static<typeof ShieldSpawnedDataSchema>;
+
+/**
+ * Complete shield:spawned message schema
+ */
+export const ShieldSpawnedMessageSchema = createTypedMessageSchema('shield:spawned', ShieldSpawnedDataSchema);
+export type ShieldSpawnedMessage = Static<typeof ShieldSpawnedMessageSchema>;
+
+// ============================================================================
+// shield:pickup_confirmed
+// ============================================================================
+
+/**
+ * Shield pickup confirmed data payload.
+ * Sent when a player walks over an available shield crate.
+ */
+export const ShieldPickupConfirmedDataSchema = Type.Object(
+  {
+    playerId: Type.String({ description: 'Player who picked up the shield', minLength: 1 }),
+    crateId: Type.String({ description: 'Crate that was picked up', minLength: 1 }),
+    shield: Type.Integer({ description: "Player's shield after the pickup", minimum: 0 }),
+    nextRespawnTime: Type.Integer({
+      description: 'Unix time (seconds) when the crate respawns',
+      minimum: 0,
+    }),
+  },
+  { $id: 'ShieldPickupConfirmedData', description: 'Shield pickup confirmed event payload' }
+);
+
+export type ShieldPickupConfirmedData = Static<typeof ShieldPickupConfirmedDataSchema>;
+
+/**
+ * Complete shield:pickup_confirmed message schema
+ */
+export const ShieldPickupConfirmedMessageSchema = createTypedMessageSchema(
+  'shield:pickup_confirmed',
+  ShieldPickupConfirmedDataSchema
+);
+export type ShieldPickupConfirmedMessage = Static<typeof ShieldPickupConfirmedMessageSchema>;
+
+// ============================================================================
+// shield:respawned
+// ============================================================================
+
+/**
+ * Shield respawned data payload.
+ * Sent when a shield crate respawns.
+ */
+export const ShieldRespawnedDataSchema = Type.Object(
+  {
+    crateId: Type.String({ description: 'Crate that respawned', minLength: 1 }),
+    position: PositionRef,
+  },
+  { $id: 'ShieldRespawnedData', description: 'Shield respawned event payload' }
+);
+
+export type ShieldRespawnedData = Static<typeof ShieldRespawnedDataSchema>;
+
+/**
+ * Complete shield:respawned message schema
+ */
+export const ShieldRespawnedMessageSchema = createTypedMessageSchema(
+  'shield:respawned',
+  ShieldRespawnedDataSchema
+);
+export type ShieldRespawnedMessage = Static<typeof ShieldRespawnedMessageSchema>;
+
+// ============================================================================
+// health:spawned
+// ============================================================================
+
+/**
+ * Health pack schema.
+ */
+export const HealthPackSchema = Type.Object(
+  {
+    id: Type.String({ description: 'Unique pack identifier', minLength: 1 }),
+    position: PositionRef,
+    isAvailable: Type.Boolean({ description: 'Whether the pack is available for pickup' }),
+  },
+  { $id: 'HealthPack', description: 'Health pack state' }
+);
+
+export type HealthPack = Static<typeof HealthPackSchema>;
+
+/**
+ * Health spawned data payload.
+ * Sent to a player entering a match with every health pack, and to everyone
+ * with a single pack when that pack respawns.
+ */
+export const HealthSpawnedDataSchema = Type.Object(
+  {
+    packs: Type.Array(HealthPackSchema, { description: 'Health packs whose state changed or is being sent in full' }),
+  },
+  { $id: 'HealthSpawnedData', description: 'Health spawned event payload' }
+);
+
+export type HealthSpawnedData = Static<typeof HealthSpawnedDataSchema>;
+
+/**
+ * Complete health:spawned message schema
+ */
+export const HealthSpawnedMessageSchema = createTypedMessageSchema('health:spawned', HealthSpawnedDataSchema);
+export type HealthSpawnedMessage = Static<typeof HealthSpawnedMessageSchema>;
+
+// ============================================================================
+// health:pickup_confirmed
+// ============================================================================
+
+/**
+ * Health pickup confirmed data payload.
+ * Sent when a hurt player walks over an available health pack.
+ */
+export const HealthPickupConfirmedDataSchema = Type.Object(
+  {
+    playerId: Type.String({ description: 'Player who picked up the pack', minLength: 1 }),
+    packId: Type.String({ description: 'Pack that was picked up', minLength: 1 }),
+    healed: Type.Integer({ description: 'Health actually restored', minimum: 0 }),
+    newHealth: Type.Integer({ description: "Player's health after the pickup", minimum: 0 }),
+    nextRespawnTime: Type.Integer({
+      description: 'Unix time (seconds) when the pack respawns',
+      minimum: 0,
+    }),
+  },
+  { $id: 'HealthPickupConfirmedData', description: 'Health pickup confirmed event payload' }
+);
+
+export type HealthPickupConfirmedData = Static<typeof HealthPickupConfirmedDataSchema>;
+
+/**
+ * Complete health:pickup_confirmed message schema
+ */
+export const HealthPickupConfirmedMessageSchema = createTypedMessageSchema(
+  'health:pickup_confirmed',
+  HealthPickupConfirmedDataSchema
+);
+export type HealthPickupConfirmedMessage = Static<typeof HealthPickupConfirmedMessageSchema>;
+
 // ============================================================================
 // melee:hit
 // ============================================================================
@@ -639,6 +1978,13 @@ export const MeleeHitDataSchema = Type.Object(
     attackerId: Type.String({ description: 'Player who performed the melee attack', minLength: 1 }),
     victims: Type.Array(Type.String({ minLength: 1 }), { description: 'Array of player IDs hit by the attack' }),
     knockbackApplied: Type.Boolean({ description: 'Whether knockback was applied (Bat only)' }),
+    combo: Type.Optional(
+      Type.Integer({
+        description: "Hit of the weapon's 2-hit combo the swing landed as; 2 dealt the combo damage. Omitted for a miss",
+        minimum: 1,
+        maximum: 2,
+      })
+    ),
   },
   { $id: 'MeleeHitData', description: 'Melee hit event payload' }
 );
@@ -706,6 +2052,33 @@ export type RollEndData = Static<typeof RollEndDataSchema>;
 export const RollEndMessageSchema = createTypedMessageSchema('roll:end', RollEndDataSchema);
 export type RollEndMessage = Static<typeof RollEndMessageSchema>;
 
+// ============================================================================
+// ultimate:activated
+// ============================================================================
+
+/**
+ * Ultimate activated data payload.
+ * Broadcast when a player spends a full meter on their ultimate.
+ */
+export const UltimateActivatedDataSchema = Type.Object(
+  {
+    playerId: Type.String({ description: 'Player who activated their ultimate', minLength: 1 }),
+    ultimate: Type.String({ description: 'Ultimate name (overdrive unless a room mode changes it)', minLength: 1 }),
+    durationMs: Type.Integer({ description: 'How long the effect lasts in milliseconds', minimum: 0 }),
+    damageMultiplier: Type.Number({ description: 'Outgoing damage multiplier while the effect lasts', minimum: 0 }),
+    newHealth: Type.Integer({ description: "Player's health after the ultimate's heal", minimum: 0 }),
+  },
+  { $id: 'UltimateActivatedData', description: 'Ultimate activation event payload' }
+);
+
+export type UltimateActivatedData = Static<typeof UltimateActivatedDataSchema>;
+
+/**
+ * Complete ultimate:activated message schema
+ */
+export const UltimateActivatedMessageSchema = createTypedMessageSchema('ultimate:activated', UltimateActivatedDataSchema);
+export type UltimateActivatedMessage = Static<typeof UltimateActivatedMessageSchema>;
+
 // ============================================================================
 // state:snapshot (Delta Compression - Full State Snapshot)
 // ============================================================================
@@ -719,6 +2092,9 @@ export const ProjectileSnapshotSchema = Type.Object(
     ownerId: Type.String({ description: 'Player who fired the projectile', minLength: 1 }),
     position: PositionRef,
     velocity: VelocityRef,
+    lastUpdated: Type.Optional(
+      Type.Integer({ description: 'Server tick of the state; projectiles move every tick', minimum: 0 })
+    ),
   },
   { $id: 'ProjectileSnapshot', description: 'Projectile state snapshot' }
 );
@@ -742,11 +2118,18 @@ export type WeaponCrateSnapshot = Static<typeof WeaponCrateSnapshotSchema>;
 
 /**
  * Full state snapshot data payload.
- * Sent periodically (every 1 second) to prevent delta drift.
+ * Sent periodically (every 1 second) to prevent delta drift, or to a client
+ * that acknowledges state once its acknowledgements fall behind.
  * Contains the complete game state.
  */
 export const StateSnapshotDataSchema = Type.Object(
   {
+    seq: Type.Optional(
+      Type.Integer({ minimum: 1, description: 'Per-client state message sequence, echoed back in state:ack' })
+    ),
+    tick: Type.Optional(
+      Type.Integer({ minimum: 0, description: 'Server simulation tick (60 Hz) the state was taken after' })
+    ),
     players: Type.Array(PlayerStateSchema, { description: 'Complete state of all players' }),
     projectiles: Type.Array(ProjectileSnapshotSchema, { description: 'Complete state of all projectiles' }),
     weaponCrates: Type.Array(WeaponCrateSnapshotSchema, { description: 'Complete state of all weapon crates' }),
@@ -778,11 +2161,22 @@ export type StateSnapshotMessage = Static<typeof StateSnapshotMessageSchema>;
 
 /**
  * Delta state update data payload.
- * Contains only changed entities since last update.
+ * Contains only changed entities since the client's baseline: the last
+ * state acknowledged with state:ack, or the last state sent to clients that
+ * do not acknowledge.
  * Sent at high frequency (20Hz) between full snapshots.
  */
 export const StateDeltaDataSchema = Type.Object(
   {
+    seq: Type.Optional(
+      Type.Integer({ minimum: 1, description: 'Per-client state message sequence, echoed back in state:ack' })
+    ),
+    baseSeq: Type.Optional(
+      Type.Integer({ minimum: 1, description: 'Acknowledged sequence this delta is relative to' })
+    ),
+    tick: Type.Optional(
+      Type.Integer({ minimum: 0, description: 'Server simulation tick (60 Hz) the state was taken after' })
+    ),
     players: Type.Optional(Type.Array(PlayerStateSchema, { description: 'Players that changed state' })),
     projectilesAdded: Type.Optional(Type.Array(ProjectileSnapshotSchema, { description: 'New projectiles spawned' })),
     projectilesRemoved: Type.Optional(Type.Array(Type.String(), { description: 'IDs of destroyed projectiles' })),
@@ -807,3 +2201,36 @@ export type StateDeltaData = Static<typeof StateDeltaDataSchema>;
  */
 export const StateDeltaMessageSchema = createTypedMessageSchema('state:delta', StateDeltaDataSchema);
 export type StateDeltaMessage = Static<typeof StateDeltaMessageSchema>;
+
+// ============================================================================
+// entity:removed
+// ============================================================================
+
+/**
+ * Entity removed data payload.
+ * Sent to a room ahead of the state broadcast that first leaves entities out,
+ * so clients can drop them instead of timing them out.
+ */
+export const EntityRemovedDataSchema = Type.Object(
+  {
+    tick: Type.Integer({ minimum: 0, description: 'Server simulation tick of the first state without the entities' }),
+    entities: Type.Array(
+      Type.Object({
+        kind: Type.Union([Type.Literal('player'), Type.Literal('projectile')], {
+          description: 'What the entity was',
+        }),
+        id: Type.String({ description: 'Player or projectile ID', minLength: 1 }),
+      }),
+      { minItems: 1, description: 'Entities the room state no longer carries, players first, each kind by ID' }
+    ),
+  },
+  { $id: 'EntityRemovedData', description: 'Entities removed from the room state' }
+);
+
+export type EntityRemovedData = Static<typeof EntityRemovedDataSchema>;
+
+/**
+ * Complete entity:removed message schema
+ */
+export const EntityRemovedMessageSchema = createTypedMessageSchema('entity:removed', EntityRemovedDataSchema);
+export type EntityRemovedMessage = Static<typeof EntityRemovedMessageSchema>;
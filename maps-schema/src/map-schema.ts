@@ -7,6 +7,8 @@ const WeaponTypeSchema = Type.Union([
   Type.Literal('shotgun'),
   Type.Literal('katana'),
   Type.Literal('bat'),
+  Type.Literal('fists'),
+  Type.Literal('rocketlauncher'),
 ], { description: 'Supported authored weapon spawn types' });
 
 export const MapObstacleSchema = Type.Object(
@@ -80,6 +82,8 @@ export const MapConfigSchema = Type.Object(
     obstacles: Type.Array(MapObstacleSchema),
     spawnPoints: Type.Array(MapSpawnPointSchema, { minItems: 1 }),
     weaponSpawns: Type.Array(MapWeaponSpawnSchema),
+    shieldSpawns: Type.Optional(Type.Array(MapSpawnPointSchema)),
+    healthSpawns: Type.Optional(Type.Array(MapSpawnPointSchema)),
     visualAcceptanceViewpoints: Type.Array(MapVisualAcceptanceViewpointSchema, { minItems: 1 }),
   },
   { $id: 'MapConfig', additionalProperties: false }
@@ -150,6 +154,8 @@ export function validateMapConfig(mapConfig: unknown): string[] {
   errors.push(...collectDuplicateIDs(map.obstacles, 'obstacle'));
   errors.push(...collectDuplicateIDs(map.spawnPoints, 'spawn point'));
   errors.push(...collectDuplicateIDs(map.weaponSpawns, 'weapon spawn'));
+  errors.push(...collectDuplicateIDs(map.shieldSpawns ?? [], 'shield spawn'));
+  errors.push(...collectDuplicateIDs(map.healthSpawns ?? [], 'health spawn'));
   errors.push(...collectDuplicateIDs(map.visualAcceptanceViewpoints, 'visual acceptance viewpoint'));
 
   for (const obstacle of map.obstacles) {
@@ -198,6 +204,32 @@ export function validateMapConfig(mapConfig: unknown): string[] {
     }
   }
 
+  for (const shieldSpawn of map.shieldSpawns ?? []) {
+    if (!withinBounds(shieldSpawn.x, shieldSpawn.y, map.width, map.height)) {
+      errors.push(`shield spawn "${shieldSpawn.id}" lies outside map bounds`);
+      continue;
+    }
+
+    for (const obstacle of movementBlockingObstacles) {
+      if (pointInsideRect(shieldSpawn.x, shieldSpawn.y, obstacleRect(obstacle))) {
+        errors.push(`shield spawn "${shieldSpawn.id}" overlaps blocking obstacle "${obstacle.id}"`);
+      }
+    }
+  }
+
+  for (const healthSpawn of map.healthSpawns ?? []) {
+    if (!withinBounds(healthSpawn.x, healthSpawn.y, map.width, map.height)) {
+      errors.push(`health spawn "${healthSpawn.id}" lies outside map bounds`);
+      continue;
+    }
+
+    for (const obstacle of movementBlockingObstacles) {
+      if (pointInsideRect(healthSpawn.x, healthSpawn.y, obstacleRect(obstacle))) {
+        errors.push(`health spawn "${healthSpawn.id}" overlaps blocking obstacle "${obstacle.id}"`);
+      }
+    }
+  }
+
   const expectedOutcomeCounts = new Map<string, number>();
   for (const viewpoint of map.visualAcceptanceViewpoints) {
     if (!withinBounds(viewpoint.playerPosition.x, viewpoint.playerPosition.y, map.width, map.height)) {
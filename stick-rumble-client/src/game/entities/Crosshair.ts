@@ -82,7 +82,8 @@ export class Crosshair {
     this.weaponType = weaponType;
 
     const isMelee = weaponType === 'Bat' || weaponType === 'bat' ||
-                    weaponType === 'Katana' || weaponType === 'katana';
+                    weaponType === 'Katana' || weaponType === 'katana' ||
+                    weaponType === 'Fists' || weaponType === 'fists';
 
     if (isMelee) {
       this.hiddenByMelee = true;
@@ -132,7 +133,8 @@ export class Crosshair {
       this.hide();
     } else {
       const isMelee = this.weaponType === 'Bat' || this.weaponType === 'bat' ||
-                      this.weaponType === 'Katana' || this.weaponType === 'katana';
+                      this.weaponType === 'Katana' || this.weaponType === 'katana' ||
+                      this.weaponType === 'Fists' || this.weaponType === 'fists';
       if (!isMelee) {
         this.show();
       }
@@ -357,6 +357,24 @@ describe('HitEffectManager', () => {
     });
   });
 
+  describe('showPing', () => {
+    it('should mark the pinged point in the ping type color', () => {
+      manager.showPing(640, 360, 'enemy');
+
+      const addCircle = scene.add.circle as unknown as ReturnType<typeof vi.fn>;
+      expect(addCircle).toHaveBeenCalledWith(640, 360, 24, 0xff0000, 0.5);
+    });
+
+    it('should fade the marker out over 3 seconds and destroy it', () => {
+      manager.showPing(640, 360, 'go');
+
+      const addTween = scene.tweens.add as unknown as ReturnType<typeof vi.fn>;
+      const marker = (scene.add.circle as unknown as ReturnType<typeof vi.fn>).mock.results[0].value;
+      expect(addTween).toHaveBeenCalledWith(expect.objectContaining({ targets: marker, alpha: 0, duration: 3000 }));
+      expect(marker.destroy).toHaveBeenCalled();
+    });
+  });
+
   describe('showBloodParticles (TS-GFX-015)', () => {
     it('should create exactly 8 blood particles', () => {
       manager.showBloodParticles(200, 200, 100, 200);
@@ -4,8 +4,9 @@ import { COLORS } from '../../shared/constants';
 /**
  * Effect type identifier
  */
-type EffectType = 'bullet' | 'melee' | 'muzzle';
+type EffectType = 'bullet' | 'melee' | 'muzzle' | 'explosion';
 type MeleeEffectWeapon = 'bat' | 'katana';
+type TeamPingType = 'go' | 'enemy' | 'danger' | 'loot';
 
 /**
  * Pooled effect object
@@ -21,7 +22,7 @@ interface PooledEffect {
  *
  * Features:
  * - Object pooling (reuse sprites, not create/destroy)
- * - Three effect types: bullet impact, melee hit, muzzle flash
+ * - Four effect types: bullet impact, melee hit, muzzle flash, explosion
  * - 60 FPS performance with 8 concurrent players
  * - Simple procedural graphics (no particle systems)
  *
@@ -41,6 +42,17 @@ export class HitEffectManager {
   private static readonly MUZZLE_COLOR = COLORS.MUZZLE_FLASH;
   private static readonly EFFECT_DEPTH = 60; // Above players (50), below UI (100+)
   private static readonly FADE_DURATION = 100; // 100ms fade
+  private static readonly EXPLOSION_COLOR = 0xff6600; // Orange
+  private static readonly EXPLOSION_CORE_COLOR = 0xffdd66; // Pale yellow
+  private static readonly EXPLOSION_FADE_DURATION = 300; // Blasts linger longer than hits
+  private static readonly PING_RADIUS = 24;
+  private static readonly PING_FADE_DURATION = 3000; // Long enough for teammates to look
+  private static readonly PING_COLORS: Record<TeamPingType, number> = {
+    go: 0x00ff00, // Green: move here
+    enemy: 0xff0000, // Red: enemy spotted
+    danger: 0xffaa00, // Amber: stay away
+    loot: 0x00aaff, // Blue: weapon or pickup
+  };
 
   constructor(scene: Phaser.Scene, poolSize: number = 20) {
     this.scene = scene;
@@ -138,6 +150,17 @@ export class HitEffectManager {
     graphics.fillCircle(0, 0, 3);
   }
 
+  /**
+   * Create explosion texture (translucent blast disc with a bright core)
+   */
+  private drawExplosion(graphics: Phaser.GameObjects.Graphics, radius: number): void {
+    graphics.clear();
+    graphics.fillStyle(HitEffectManager.EXPLOSION_COLOR, 0.45);
+    graphics.fillCircle(0, 0, radius);
+    graphics.fillStyle(HitEffectManager.EXPLOSION_CORE_COLOR, 0.9);
+    graphics.fillCircle(0, 0, radius * 0.35);
+  }
+
   /**
    * Create muzzle flash texture (orange/yellow flash)
    */
@@ -239,6 +262,33 @@ export class HitEffectManager {
     return effect.graphics;
   }
 
+  /**
+   * Show an explosion filling its splash radius at specified position
+   * Returns to pool after 300ms fade
+   */
+  showExplosion(x: number, y: number, radius: number): Phaser.GameObjects.Graphics {
+    const effect = this.getPooledEffect();
+    effect.type = 'explosion';
+
+    this.drawExplosion(effect.graphics, radius);
+    effect.graphics.setPosition(x, y);
+    effect.graphics.setRotation(0);
+    effect.graphics.setAlpha(1);
+    effect.graphics.setVisible(true);
+
+    // Fade out and return to pool
+    this.scene.tweens.add({
+      targets: effect.graphics,
+      alpha: 0,
+      duration: HitEffectManager.EXPLOSION_FADE_DURATION,
+      onComplete: () => {
+        this.returnToPool(effect);
+      },
+    });
+
+    return effect.graphics;
+  }
+
   /**
    * Show blood particles bursting from victim position away from damage source.
    * Creates 5 circles with random radius (2-5px), velocity (50-150 px/s),
@@ -278,6 +328,22 @@ export class HitEffectManager {
     }
   }
 
+  /**
+   * Show a teammate's tactical ping as a marker colored by ping type.
+   * Not pooled: it outlives hit effects and fades over 3 seconds.
+   */
+  showPing(x: number, y: number, type: TeamPingType): void {
+    const marker = this.scene.add.circle(x, y, HitEffectManager.PING_RADIUS, HitEffectManager.PING_COLORS[type], 0.5);
+    marker.setDepth(HitEffectManager.EFFECT_DEPTH);
+
+    this.scene.tweens.add({
+      targets: marker,
+      alpha: 0,
+      duration: HitEffectManager.PING_FADE_DURATION,
+      onComplete: () => marker.destroy(),
+    });
+  }
+
   /**
    * Cleanup all pooled effects
    */
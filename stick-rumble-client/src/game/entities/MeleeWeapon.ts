@@ -18,6 +18,10 @@ const WEAPON_STATS: Record<string, WeaponStats> = {
     range: 110,
     arcDegrees: 80,
   },
+  fists: {
+    range: 60,
+    arcDegrees: 100,
+  },
 };
 
 type SwingPhase = 'idle' | 'preview' | 'confirmed';
@@ -36,11 +40,13 @@ const TRAIL_SEGMENTS = 6;
 const PREVIEW_STYLES: Record<string, SwingStyle> = {
   bat: { color: 0xf6d365, width: 4, alpha: 0.4, fadeDuration: 90, radiusMultiplier: 0.72 },
   katana: { color: 0xb8f2ff, width: 3, alpha: 0.35, fadeDuration: 80, radiusMultiplier: 0.88 },
+  fists: { color: 0xffc9a3, width: 4, alpha: 0.4, fadeDuration: 70, radiusMultiplier: 0.8 },
 };
 
 const CONFIRMED_STYLES: Record<string, SwingStyle> = {
   bat: { color: 0xfff2bf, width: 7, alpha: 0.85, fadeDuration: 150, radiusMultiplier: 1 },
   katana: { color: 0xf4fbff, width: 5, alpha: 0.92, fadeDuration: 130, radiusMultiplier: 1.08 },
+  fists: { color: 0xffe4d1, width: 6, alpha: 0.85, fadeDuration: 110, radiusMultiplier: 1 },
 };
 
 /**
@@ -23,7 +23,7 @@ export class MeleeWeaponManager {
 
   /**
    * Create a melee weapon visual for a player
-   * Only creates for Bat or Katana, removes existing weapon if switching to non-melee
+   * Only creates for Bat, Katana or Fists, removes existing weapon if switching to non-melee
    */
   createWeapon(playerId: string, weaponType: string, position: Position): void {
     this.syncWeapon(playerId, weaponType, position);
@@ -33,7 +33,7 @@ export class MeleeWeaponManager {
     const normalizedType = weaponType?.toLowerCase?.() ?? 'pistol';
     const existingWeapon = this.weapons.get(playerId);
 
-    if (normalizedType !== 'bat' && normalizedType !== 'katana') {
+    if (normalizedType !== 'bat' && normalizedType !== 'katana' && normalizedType !== 'fists') {
       if (existingWeapon) {
         this.removeWeapon(playerId);
       }
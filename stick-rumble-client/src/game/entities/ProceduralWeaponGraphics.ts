@@ -41,6 +41,9 @@ export class ProceduralWeaponGraphics {
       case 'katana':
         this.buildKatana();
         break;
+      case 'fists':
+        // Bare hands: nothing is held
+        break;
       case 'uzi':
         this.buildUzi();
         break;
@@ -35,6 +35,9 @@ const PICKUP_COLORS = {
   KATANA_BLADE: 0xd9d9d9,
   KATANA_HANDLE: 0x2a2a2a,
   BAT: 0x8b5a2b,
+  FISTS: 0xd9a066,
+  ROCKET_TUBE: 0x4f5b3a,
+  ROCKET_TIP: 0xb33a1f,
 } as const;
 
 export class WeaponCrateManager {
@@ -168,6 +171,13 @@ export class WeaponCrateManager {
         sprite.fillRect(-4, 3, 8, 4);
         sprite.fillRect(-20, -3, 4, 8);
         break;
+      case 'rocketlauncher':
+        sprite.fillStyle(PICKUP_COLORS.ROCKET_TUBE, 1);
+        sprite.fillRect(-18, -4, 30, 8);
+        sprite.fillRect(-4, 4, 4, 6);
+        sprite.fillStyle(PICKUP_COLORS.ROCKET_TIP, 1);
+        sprite.fillRect(12, -3, 5, 6);
+        break;
       case 'katana':
         sprite.lineStyle(2, PICKUP_COLORS.KATANA_BLADE, 1);
         sprite.beginPath();
@@ -184,6 +194,11 @@ export class WeaponCrateManager {
         sprite.lineTo(10, -4);
         sprite.strokePath();
         break;
+      case 'fists':
+        sprite.fillStyle(PICKUP_COLORS.FISTS, 1);
+        sprite.fillRect(-12, -5, 10, 10);
+        sprite.fillRect(2, -5, 10, 10);
+        break;
       default:
         sprite.fillStyle(PICKUP_COLORS.UZI, 1);
         sprite.fillRect(-8, -3, 16, 6);
@@ -10,6 +10,10 @@ export function getWeaponBarrelLength(weaponType: string): number {
       return 50.5;
     case 'katana':
       return 65;
+    case 'fists':
+      return 20;
+    case 'rocketlauncher':
+      return 55;
     case 'pistol':
     default:
       return 25;
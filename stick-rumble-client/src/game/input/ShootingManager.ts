@@ -20,7 +20,7 @@ export interface WeaponState {
 /**
  * Weapon type for tracking melee vs ranged behavior
  */
-type WeaponType = 'Pistol' | 'Bat' | 'Katana';
+type WeaponType = 'Pistol' | 'Bat' | 'Katana' | 'Fists';
 
 /**
  * Weapon cooldown configuration (in milliseconds)
@@ -29,6 +29,7 @@ const WEAPON_COOLDOWNS: Record<WeaponType, number> = {
   Pistol: 1000 / WEAPON.PISTOL_FIRE_RATE, // 333ms
   Bat: 500,    // 0.5s cooldown (2.0/s fire rate)
   Katana: 800, // 0.8s cooldown (1.25/s fire rate)
+  Fists: 1000 / 3, // 333ms (3.0/s fire rate)
 };
 
 /**
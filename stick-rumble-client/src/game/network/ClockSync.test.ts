@@ -0,0 +1,58 @@
+import { describe, it, expect } from 'vitest';
+import { ClockSync } from './ClockSync';
+
+describe('ClockSync', () => {
+  it('should report no offset before any exchange', () => {
+    const clockSync = new ClockSync();
+
+    expect(clockSync.isSynced()).toBe(false);
+    expect(clockSync.getOffset()).toBe(0);
+    expect(clockSync.serverNow(1000)).toBe(1000);
+  });
+
+  it('should estimate the offset from a symmetric exchange', () => {
+    const clockSync = new ClockSync();
+
+    // Server clock 500ms ahead, 20ms each way, 2ms processing
+    const sample = clockSync.addSample(
+      { clientTime: 1000, serverReceiveTime: 1520, serverTransmitTime: 1522, tick: 60 },
+      1042
+    );
+
+    expect(sample).toEqual({ offset: 500, rtt: 40 });
+    expect(clockSync.isSynced()).toBe(true);
+    expect(clockSync.serverNow(2000)).toBe(2500);
+  });
+
+  it('should prefer the recent sample with the shortest round trip', () => {
+    const clockSync = new ClockSync();
+
+    // Delayed on the way back: this exchange underestimates the offset
+    clockSync.addSample({ clientTime: 1000, serverReceiveTime: 1520, serverTransmitTime: 1520, tick: 60 }, 1220);
+    clockSync.addSample({ clientTime: 2000, serverReceiveTime: 2510, serverTransmitTime: 2510, tick: 120 }, 2020);
+
+    expect(clockSync.getOffset()).toBe(500);
+  });
+
+  it('should only keep the last five samples', () => {
+    const clockSync = new ClockSync();
+
+    clockSync.addSample({ clientTime: 0, serverReceiveTime: 100, serverTransmitTime: 100, tick: 0 }, 0);
+    for (let i = 1; i <= 5; i++) {
+      const t0 = i * 1000;
+      clockSync.addSample({ clientTime: t0, serverReceiveTime: t0 + 510, serverTransmitTime: t0 + 510, tick: i }, t0 + 20);
+    }
+
+    expect(clockSync.getOffset()).toBe(500);
+  });
+
+  it('should forget samples on reset', () => {
+    const clockSync = new ClockSync();
+    clockSync.addSample({ clientTime: 1000, serverReceiveTime: 1520, serverTransmitTime: 1520, tick: 60 }, 1040);
+
+    clockSync.reset();
+
+    expect(clockSync.isSynced()).toBe(false);
+    expect(clockSync.getOffset()).toBe(0);
+  });
+});
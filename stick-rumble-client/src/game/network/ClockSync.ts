@@ -0,0 +1,71 @@
+/**
+ * ClockSync - Estimates the offset between the local clock and the server's
+ * from time:sync replies, so state messages can be placed on the server's
+ * timeline.
+ */
+
+import type { TimeSyncData } from '../../../../events-schema/src/index.js';
+
+export interface ClockSample {
+  offset: number; // Server time minus local time, in ms
+  rtt: number; // Round trip of the exchange, minus server processing, in ms
+}
+
+const MAX_SAMPLES = 5; // Recent exchanges considered
+
+export class ClockSync {
+  private samples: ClockSample[] = [];
+
+  /**
+   * Record a time:sync reply that arrived at receivedAt (local ms).
+   * With t0 = clientTime, t1 = serverReceiveTime, t2 = serverTransmitTime and
+   * t3 = receivedAt, the offset is ((t1 - t0) + (t2 - t3)) / 2.
+   */
+  addSample(reply: TimeSyncData, receivedAt: number): ClockSample {
+    const sample: ClockSample = {
+      offset: (reply.serverReceiveTime - reply.clientTime + (reply.serverTransmitTime - receivedAt)) / 2,
+      rtt: Math.max(0, receivedAt - reply.clientTime - (reply.serverTransmitTime - reply.serverReceiveTime)),
+    };
+
+    this.samples.push(sample);
+    if (this.samples.length > MAX_SAMPLES) {
+      this.samples.shift();
+    }
+    return sample;
+  }
+
+  /**
+   * Whether any exchange has completed
+   */
+  isSynced(): boolean {
+    return this.samples.length > 0;
+  }
+
+  /**
+   * Offset of the recent sample with the shortest round trip: its network
+   * delays are the most likely to be symmetric. 0 before any exchange.
+   */
+  getOffset(): number {
+    let best: ClockSample | null = null;
+    for (const sample of this.samples) {
+      if (!best || sample.rtt < best.rtt) {
+        best = sample;
+      }
+    }
+    return best ? best.offset : 0;
+  }
+
+  /**
+   * Estimated server time (Unix ms) at the given local time
+   */
+  serverNow(localNow: number = Date.now()): number {
+    return localNow + this.getOffset();
+  }
+
+  /**
+   * Forget all samples, e.g. after connecting to another server
+   */
+  reset(): void {
+    this.samples = [];
+  }
+}
@@ -521,6 +521,66 @@ describe('WebSocketClient', () => {
       });
       expect((client as any).reconnectReplayPending).toBe(false);
     });
+
+    it('should answer net:ping with net:pong without dispatching it', async () => {
+      const client = new WebSocketClient('ws://localhost:8080/ws');
+
+      const connectPromise = client.connect();
+      if (mockWebSocketInstance.onopen) {
+        mockWebSocketInstance.onopen({});
+      }
+      await connectPromise;
+
+      const handler = vi.fn();
+      client.on('net:ping', handler);
+
+      mockWebSocketInstance.onmessage({
+        data: JSON.stringify({ type: 'net:ping', timestamp: Date.now(), data: { id: 4, serverTime: 1760000000000 } }),
+      });
+
+      expect(handler).not.toHaveBeenCalled();
+      const sent = JSON.parse(mockWebSocketInstance.send.mock.calls[0][0]);
+      expect(sent.type).toBe('net:pong');
+      expect(sent.data).toEqual({ id: 4, serverTime: 1760000000000 });
+    });
+
+    it('should sync the clock after server:hello and track the offset from time:sync', async () => {
+      const client = new WebSocketClient('ws://localhost:8080/ws');
+
+      const connectPromise = client.connect();
+      if (mockWebSocketInstance.onopen) {
+        mockWebSocketInstance.onopen({});
+      }
+      await connectPromise;
+
+      mockWebSocketInstance.onmessage({
+        data: JSON.stringify({
+          type: 'server:hello',
+          timestamp: Date.now(),
+          data: { commit: 'abc123', buildTime: 'unknown', goVersion: 'go1.24', sessionToken: 'token', resumed: false },
+        }),
+      });
+
+      const sent = JSON.parse(mockWebSocketInstance.send.mock.calls[0][0]);
+      expect(sent.type).toBe('time:sync_request');
+      expect(client.getServerClockOffset()).toBe(0);
+
+      const handler = vi.fn();
+      client.on('time:sync', handler);
+      const now = Date.now();
+      const reply = {
+        clientTime: sent.data.clientTime,
+        serverReceiveTime: now + 60000,
+        serverTransmitTime: now + 60000,
+        tick: 42,
+      };
+      mockWebSocketInstance.onmessage({
+        data: JSON.stringify({ type: 'time:sync', timestamp: now + 60000, data: reply }),
+      });
+
+      expect(handler).toHaveBeenCalledWith(reply);
+      expect(client.getServerClockOffset()).toBeGreaterThan(59000);
+    });
   });
 
   describe('gameplay queueing', () => {
@@ -556,6 +616,52 @@ describe('WebSocketClient', () => {
     });
   });
 
+  describe('server shutdown', () => {
+    it('delivers the shutdown notice even while gameplay is not ready', async () => {
+      const warnSpy = vi.spyOn(console, 'warn').mockImplementation(() => {});
+      const client = new WebSocketClient('ws://localhost:8080/ws');
+      const handler = vi.fn();
+      client.on('server:shutdown', handler);
+      client.setGameplayReady(false);
+
+      const connectPromise = client.connect();
+      if (mockWebSocketInstance.onopen) {
+        mockWebSocketInstance.onopen({});
+      }
+      await connectPromise;
+
+      mockWebSocketInstance.onmessage({
+        data: JSON.stringify({ type: 'server:shutdown', timestamp: Date.now(), data: { countdownMs: 30000 } }),
+      });
+
+      expect(handler).toHaveBeenCalledWith({ countdownMs: 30000 });
+      expect(warnSpy).toHaveBeenCalledWith('Server shutting down in 30s');
+      warnSpy.mockRestore();
+    });
+  });
+
+  describe('leaderboard updates', () => {
+    it('delivers leaderboard updates outside gameplay', async () => {
+      const client = new WebSocketClient('ws://localhost:8080/ws');
+      const handler = vi.fn();
+      client.on('leaderboard:update', handler);
+      client.setGameplayReady(false);
+
+      const connectPromise = client.connect();
+      if (mockWebSocketInstance.onopen) {
+        mockWebSocketInstance.onopen({});
+      }
+      await connectPromise;
+
+      const data = { matches: 1, total: 1, top: [{ rank: 1, playerId: 'p1', displayName: 'Alpha', xp: 250, kd: 3, wins: 1 }] };
+      mockWebSocketInstance.onmessage({
+        data: JSON.stringify({ type: 'leaderboard:update', timestamp: Date.now(), data }),
+      });
+
+      expect(handler).toHaveBeenCalledWith(data);
+    });
+  });
+
   describe('reconnection logic', () => {
     beforeEach(() => {
       vi.useFakeTimers();
@@ -586,6 +692,45 @@ describe('WebSocketClient', () => {
       consoleSpy.mockRestore();
     });
 
+    it('should report close reasons and not reconnect after a kick', async () => {
+      const consoleSpy = vi.spyOn(console, 'log');
+      const client = new WebSocketClient('ws://localhost:8080/ws');
+      const onDisconnect = vi.fn();
+      client.setDisconnectHandler(onDisconnect);
+
+      const connectPromise = client.connect();
+      mockWebSocketInstance.onopen?.({});
+      await connectPromise;
+
+      mockWebSocketInstance.onclose?.({ code: 4000, reason: 'spam' });
+
+      expect(onDisconnect).toHaveBeenCalledWith({ code: 4000, reason: 'kicked', text: 'spam', willReconnect: false });
+      expect(consoleSpy).not.toHaveBeenCalledWith(expect.stringContaining('Reconnecting'));
+      consoleSpy.mockRestore();
+    });
+
+    it('should reconnect after an idle close', async () => {
+      const consoleSpy = vi.spyOn(console, 'log');
+      const client = new WebSocketClient('ws://localhost:8080/ws');
+      const onDisconnect = vi.fn();
+      client.setDisconnectHandler(onDisconnect);
+
+      const connectPromise = client.connect();
+      mockWebSocketInstance.onopen?.({});
+      await connectPromise;
+
+      mockWebSocketInstance.onclose?.({ code: 4002, reason: 'heartbeat timeout' });
+
+      expect(onDisconnect).toHaveBeenCalledWith({
+        code: 4002,
+        reason: 'idle',
+        text: 'heartbeat timeout',
+        willReconnect: true,
+      });
+      expect(consoleSpy).toHaveBeenCalledWith(expect.stringContaining('Reconnecting in 1000ms... (attempt 1)'));
+      consoleSpy.mockRestore();
+    });
+
     it('should use exponential backoff for reconnection delays', async () => {
       const consoleSpy = vi.spyOn(console, 'log');
       const client = new WebSocketClient('ws://localhost:8080/ws');
@@ -811,6 +956,34 @@ describe('WebSocketClient', () => {
       expect(() => client.disconnect()).not.toThrow();
     });
 
+    it('should not reconnect after the session is replaced by a newer connection', async () => {
+      vi.useFakeTimers();
+      const consoleSpy = vi.spyOn(console, 'log');
+      const warnSpy = vi.spyOn(console, 'warn').mockImplementation(() => {});
+      const client = new WebSocketClient('ws://localhost:8080/ws');
+      const handler = vi.fn();
+      client.on('session:replaced', handler);
+
+      const connectPromise = client.connect();
+      if (mockWebSocketInstance.onopen) {
+        mockWebSocketInstance.onopen({});
+      }
+      await connectPromise;
+
+      mockWebSocketInstance.onmessage({
+        data: JSON.stringify({ type: 'session:replaced', timestamp: Date.now(), data: {} }),
+      });
+      mockWebSocketInstance.onclose({ code: 4005, reason: 'session replaced' });
+      vi.advanceTimersByTime(2000);
+
+      expect(handler).toHaveBeenCalledWith({});
+      expect(consoleSpy).not.toHaveBeenCalledWith(expect.stringContaining('Reconnecting'));
+
+      consoleSpy.mockRestore();
+      warnSpy.mockRestore();
+      vi.useRealTimers();
+    });
+
     it('should prevent reconnection attempts after intentional disconnect', async () => {
       vi.useFakeTimers();
       const consoleSpy = vi.spyOn(console, 'log');
@@ -5,12 +5,19 @@ import {
   InputStateDataSchema,
   PlayerShootDataSchema,
   WeaponPickupAttemptDataSchema,
+  TimeSyncRequestDataSchema,
+  closeReasonOf,
+  closeCodeReconnects,
+  type CloseReason,
   type PlayerHelloData,
   type InputStateData,
   type PlayerShootData,
   type WeaponPickupAttemptData,
+  type TimeSyncRequestData,
+  type TimeSyncData,
 } from '../../../../events-schema/src/index.js';
 import { NetworkSimulator } from './NetworkSimulator';
+import { ClockSync } from './ClockSync';
 import type { JoinIntent, SessionStatusData } from '../../shared/types';
 
 export interface Message {
@@ -19,6 +26,17 @@ export interface Message {
   data?: unknown;
 }
 
+/**
+ * Why the connection closed, for disconnect dialogs. reason is null for
+ * closes the server did not send on purpose, such as a lost link.
+ */
+export interface DisconnectInfo {
+  code: number;
+  reason: CloseReason | null;
+  text: string;
+  willReconnect: boolean;
+}
+
 export interface PlayerScore {
   playerId: string;
   displayName: string;
@@ -36,6 +54,7 @@ const validatePlayerShoot: ValidateFunction<PlayerShootData> = ajv.compile(Playe
 const validateWeaponPickupAttempt: ValidateFunction<WeaponPickupAttemptData> = ajv.compile(
   WeaponPickupAttemptDataSchema
 );
+const validateTimeSyncRequest: ValidateFunction<TimeSyncRequestData> = ajv.compile(TimeSyncRequestDataSchema);
 const MAX_QUEUED_GAMEPLAY_MESSAGES = 256;
 
 export class WebSocketClient {
@@ -57,8 +76,10 @@ export class WebSocketClient {
   private reconnectReplayPending = false;
   private onReconnectReplayFailed?: (intent: JoinIntent) => void;
   private onConnectionStateChange?: (connected: boolean) => void;
+  private onDisconnect?: (info: DisconnectInfo) => void;
   private gameplayReady = true;
   private queuedGameplayMessages: Message[] = [];
+  private clockSync = new ClockSync();
 
   constructor(url: string, debugMode = false, networkSimulator?: NetworkSimulator) {
     this.url = url;
@@ -161,7 +182,18 @@ export class WebSocketClient {
 
         this.ws.onclose = (event) => {
           console.log('WebSocket closed:', event.code, event.reason);
+          if (!closeCodeReconnects(event.code)) {
+            // Kicked, banned, replaced or rejected: reconnecting would fail
+            // again or take the session back
+            this.shouldReconnect = false;
+          }
           this.onConnectionStateChange?.(false);
+          this.onDisconnect?.({
+            code: event.code,
+            reason: closeReasonOf(event.code),
+            text: event.reason,
+            willReconnect: this.shouldReconnect && this.reconnectAttempts < this.maxReconnectAttempts,
+          });
           this.attemptReconnect();
         };
       } catch (err) {
@@ -218,10 +250,36 @@ export class WebSocketClient {
     this.send(message);
   }
 
+  syncClock(): void {
+    const payload: TimeSyncRequestData = { clientTime: Date.now() };
+
+    if (!validateTimeSyncRequest(payload)) {
+      console.error('Validation failed for time:sync_request:', validateTimeSyncRequest.errors);
+      return;
+    }
+
+    this.send({
+      type: 'time:sync_request',
+      timestamp: payload.clientTime,
+      data: payload,
+    });
+  }
+
+  /**
+   * Estimated server clock minus local clock in ms, 0 until a time:sync reply arrives
+   */
+  getServerClockOffset(): number {
+    return this.clockSync.getOffset();
+  }
+
   setReconnectReplayFailedHandler(handler: (intent: JoinIntent) => void): void {
     this.onReconnectReplayFailed = handler;
   }
 
+  setDisconnectHandler(handler?: (info: DisconnectInfo) => void): void {
+    this.onDisconnect = handler;
+  }
+
   setConnectionStateHandler(handler?: (connected: boolean) => void): void {
     this.onConnectionStateChange = handler;
     handler?.(this.ws?.readyState === WebSocket.OPEN);
@@ -286,6 +344,36 @@ export class WebSocketClient {
   }
 
   private handleMessage(message: Message): void {
+    if (message.type === 'net:ping') {
+      // Answered even while gameplay messages are queued: the server measures
+      // RTT from the reply and closes connections that stop answering
+      this.send({ type: 'net:pong', timestamp: Date.now(), data: message.data });
+      return;
+    }
+
+    if (message.type === 'server:hello') {
+      // A fresh connection, possibly to another server: start a new estimate
+      this.clockSync.reset();
+      this.syncClock();
+    }
+
+    if (message.type === 'time:sync' && message.data) {
+      // Sampled on arrival, before any queueing delays the receive time
+      this.clockSync.addSample(message.data as TimeSyncData, Date.now());
+    }
+
+    if (message.type === 'session:replaced') {
+      // This account connected from another tab or device; reconnecting
+      // would only take the session back from it
+      console.warn('Session replaced by a newer connection');
+      this.shouldReconnect = false;
+    }
+
+    if (message.type === 'server:shutdown') {
+      const countdownMs = (message.data as { countdownMs?: number } | undefined)?.countdownMs ?? 0;
+      console.warn(`Server shutting down in ${Math.ceil(countdownMs / 1000)}s`);
+    }
+
     if (message.type === 'session:status') {
       const sessionStatus = message.data as SessionStatusData | undefined;
       if (sessionStatus && this.lastRequestedHello) {
@@ -294,7 +382,9 @@ export class WebSocketClient {
       }
     }
 
-    if ((message.type === 'error:bad_room_code' || message.type === 'error:room_full') && this.reconnectReplayPending) {
+    const joinRejected =
+      message.type === 'error:bad_room_code' || message.type === 'error:room_full' || message.type === 'error:room_blocked';
+    if (joinRejected && this.reconnectReplayPending) {
       this.reconnectReplayPending = false;
       if (this.lastSuccessfulHello && this.onReconnectReplayFailed) {
         this.onReconnectReplayFailed({ ...this.lastSuccessfulHello });
@@ -318,7 +408,11 @@ export class WebSocketClient {
       'session:status',
       'error:bad_room_code',
       'error:room_full',
+      'error:room_blocked',
       'error:no_hello',
+      'session:replaced',
+      'server:shutdown',
+      'leaderboard:update',
     ]);
 
     return !immediateTypes.has(message.type);
@@ -25,7 +25,7 @@ import { getWeaponBarrelLength } from '../entities/WeaponGeometry';
 import { ScoreDisplayUI } from '../ui/ScoreDisplayUI';
 import { KillCounterUI } from '../ui/KillCounterUI';
 import { PickupNotificationUI } from '../ui/PickupNotificationUI';
-import { COLORS } from '../../shared/constants';
+import { COLORS, QUICK_CHAT_KEYS } from '../../shared/constants';
 import {
   getDefaultMatchMapContext,
   getFirstBlockingObstacleContact,
@@ -180,7 +180,13 @@ export class GameScene extends Phaser.Scene {
     this.applyMatchMapContext(this.matchMapContext);
 
     // Initialize spectator module
-    this.spectator = new GameSceneSpectator(this, this.playerManager, () => this.stopCameraFollow());
+    this.spectator = new GameSceneSpectator(
+      this,
+      this.playerManager,
+      () => this.stopCameraFollow(),
+      () => this.requestRespawn(),
+      (index) => this.chooseSpawn(index)
+    );
 
     // Initialize screen shake for recoil feedback (Story 3.3 Polish)
     this.screenShake = new ScreenShake(this.cameras.main);
@@ -436,6 +442,19 @@ export class GameScene extends Phaser.Scene {
       this.attemptDodgeRoll();
     });
 
+    const pingKey = this.input.keyboard?.addKey('G');
+    pingKey?.on('down', () => {
+      this.sendPing();
+    });
+
+    const quickChatKeyNames = ['ONE', 'TWO', 'THREE', 'FOUR'];
+    QUICK_CHAT_KEYS.forEach((emote, index) => {
+      const quickChatKey = this.input.keyboard?.addKey(quickChatKeyNames[index]);
+      quickChatKey?.on('down', () => {
+        this.sendEmote(emote);
+      });
+    });
+
     this.ui.createAmmoDisplay(
       0,
       0
@@ -599,6 +618,55 @@ export class GameScene extends Phaser.Scene {
     this.mobileFireHeld = false;
   }
 
+  /**
+   * Ask the server to respawn the dead local player. The server holds the
+   * request until the respawn delay has passed.
+   */
+  private requestRespawn(): void {
+    this.wsClient.send({
+      type: 'player:respawn_request',
+      timestamp: Date.now(),
+    });
+  }
+
+  /**
+   * Tell the server which spawn point from spawn:options the dead local
+   * player wants to respawn at
+   */
+  private chooseSpawn(index: number): void {
+    this.wsClient.send({
+      type: 'player:spawn_choice',
+      timestamp: Date.now(),
+      data: { index },
+    });
+  }
+
+  /**
+   * Ping the point under the crosshair for the local player's team. The
+   * server rate-limits pings and echoes them back as team:ping.
+   */
+  private sendPing(): void {
+    const pointer = this.input.activePointer;
+    const target = this.cameras.main.getWorldPoint(pointer.x, pointer.y);
+    this.wsClient.send({
+      type: 'player:ping',
+      timestamp: Date.now(),
+      data: { type: 'go', position: { x: target.x, y: target.y } },
+    });
+  }
+
+  /**
+   * Show an emote or quick-chat line to the room. The server rate-limits
+   * emotes and broadcasts them back as player:emoted.
+   */
+  private sendEmote(emote: string): void {
+    this.wsClient.send({
+      type: 'player:emote',
+      timestamp: Date.now(),
+      data: { emote },
+    });
+  }
+
   private attemptDodgeRoll(): void {
     if (!this.dodgeRollManager || !this.dodgeRollManager.canDodgeRoll() || !this.inputManager) {
       return;
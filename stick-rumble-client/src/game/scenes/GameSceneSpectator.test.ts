@@ -29,6 +29,7 @@ describe('GameSceneSpectator', () => {
   let mockScoreText: any;
   let mockButtonBg: any;
   let mockButtonText: any;
+  let mockSpawnMarkers: any[];
 
   beforeEach(() => {
     // Create mock camera
@@ -101,6 +102,8 @@ describe('GameSceneSpectator', () => {
       destroy: vi.fn(),
     };
 
+    mockSpawnMarkers = [];
+
     // Track calls to add.rectangle, add.text, add.graphics, add.container
     let rectangleCallCount = 0;
     let textCallCount = 0;
@@ -126,6 +129,16 @@ describe('GameSceneSpectator', () => {
           return mockButtonText;
         }),
         graphics: vi.fn().mockReturnValue(mockGraphics),
+        circle: vi.fn().mockImplementation(() => {
+          const marker = {
+            setDepth: vi.fn().mockReturnThis(),
+            setInteractive: vi.fn().mockReturnThis(),
+            on: vi.fn().mockReturnThis(),
+            destroy: vi.fn(),
+          };
+          mockSpawnMarkers.push(marker);
+          return marker;
+        }),
         container: vi.fn().mockImplementation(() => {
           containerCallCount++;
           if (containerCallCount === 1) {
@@ -366,6 +379,54 @@ describe('GameSceneSpectator', () => {
     });
   });
 
+  describe('showSpawnOptions', () => {
+    const points = [
+      { index: 0, position: { x: 200, y: 540 }, danger: 1120, allowed: false },
+      { index: 1, position: { x: 1720, y: 540 }, danger: 0, allowed: true },
+    ];
+
+    it('should ignore spawn options while alive', () => {
+      spectator.showSpawnOptions(points);
+      expect(mockScene.add.circle).not.toHaveBeenCalled();
+    });
+
+    it('should mark allowed points green and the rest red', () => {
+      spectator.enterSpectatorMode();
+      spectator.showSpawnOptions(points);
+
+      expect(mockScene.add.circle).toHaveBeenCalledWith(200, 540, 20, 0xFF0000, 0.35);
+      expect(mockScene.add.circle).toHaveBeenCalledWith(1720, 540, 20, 0x00FF00, 0.35);
+      expect(mockSpawnMarkers[0].setInteractive).not.toHaveBeenCalled();
+      expect(mockSpawnMarkers[1].setInteractive).toHaveBeenCalled();
+    });
+
+    it('should send the clicked point and highlight it', () => {
+      const mockSpawnChoice = vi.fn();
+      spectator = new GameSceneSpectator(mockScene, mockPlayerManager, mockOnStopCameraFollow, null, mockSpawnChoice);
+      spectator.enterSpectatorMode();
+      spectator.showSpawnOptions(points);
+
+      const pointerdownCall = mockSpawnMarkers[1].on.mock.calls.find(
+        (call: [string, () => void]) => call[0] === 'pointerdown'
+      );
+      pointerdownCall![1]();
+
+      expect(mockSpawnChoice).toHaveBeenCalledWith(1);
+      expect(mockSpawnMarkers[0].destroy).toHaveBeenCalled();
+      expect(mockScene.add.circle).toHaveBeenLastCalledWith(1720, 540, 20, 0x00FF00, 0.8);
+    });
+
+    it('should remove the markers on respawn', () => {
+      spectator.enterSpectatorMode();
+      spectator.showSpawnOptions(points);
+      spectator.exitSpectatorMode();
+
+      for (const marker of mockSpawnMarkers) {
+        expect(marker.destroy).toHaveBeenCalled();
+      }
+    });
+  });
+
   describe('updateSpectatorMode', () => {
     it('repositions the death overlay when the viewport changes', () => {
       spectator.enterSpectatorMode();
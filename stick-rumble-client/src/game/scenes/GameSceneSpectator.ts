@@ -1,9 +1,12 @@
 import Phaser from 'phaser';
 import type { PlayerManager } from '../entities/PlayerManager';
+import type { SpawnOptionsData } from '../../../../events-schema/src/index.js';
+
+type SpawnOption = SpawnOptionsData['points'][number];
 
 /**
  * GameSceneSpectator - Manages spectator mode when local player dies
- * Responsibility: Death screen overlay, stats display, respawn request, camera following
+ * Responsibility: Death screen overlay, stats display, respawn request, spawn choice, camera following
  */
 export class GameSceneSpectator {
   private scene: Phaser.Scene;
@@ -11,6 +14,7 @@ export class GameSceneSpectator {
   private isSpectating: boolean = false;
   private onStopCameraFollow: () => void;
   private onRespawnRequest: (() => void) | null;
+  private onSpawnChoice: ((index: number) => void) | null;
 
   // Death screen overlay elements
   private overlay: Phaser.GameObjects.Rectangle | null = null;
@@ -18,16 +22,22 @@ export class GameSceneSpectator {
   private statsContainer: Phaser.GameObjects.Container | null = null;
   private tryAgainButton: Phaser.GameObjects.Container | null = null;
 
+  // Spawn point markers from spawn:options, in world space
+  private spawnMarkers: Phaser.GameObjects.Arc[] = [];
+  private chosenSpawn: number | null = null;
+
   constructor(
     scene: Phaser.Scene,
     playerManager: PlayerManager,
     onStopCameraFollow: () => void,
-    onRespawnRequest: (() => void) | null = null
+    onRespawnRequest: (() => void) | null = null,
+    onSpawnChoice: ((index: number) => void) | null = null
   ) {
     this.scene = scene;
     this.playerManager = playerManager;
     this.onStopCameraFollow = onStopCameraFollow;
     this.onRespawnRequest = onRespawnRequest;
+    this.onSpawnChoice = onSpawnChoice;
   }
 
   /**
@@ -120,11 +130,55 @@ export class GameSceneSpectator {
     this.tryAgainButton.setDepth(1000);
   }
 
+  /**
+   * Mark the spawn points from spawn:options on the map: green for ones the
+   * player may pick, red for ones too close to enemies. Clicking a green
+   * marker picks it; the picked one is drawn brighter.
+   */
+  showSpawnOptions(points: SpawnOption[]): void {
+    if (!this.isSpectating) {
+      return;
+    }
+
+    this.clearSpawnMarkers();
+    for (const point of points) {
+      const picked = point.index === this.chosenSpawn;
+      const marker = this.scene.add.circle(
+        point.position.x,
+        point.position.y,
+        20,
+        point.allowed ? 0x00FF00 : 0xFF0000,
+        picked ? 0.8 : 0.35
+      );
+      marker.setDepth(995);
+      if (point.allowed) {
+        marker.setInteractive({ useHandCursor: true });
+        marker.on('pointerdown', () => {
+          this.chosenSpawn = point.index;
+          if (this.onSpawnChoice) {
+            this.onSpawnChoice(point.index);
+          }
+          this.showSpawnOptions(points);
+        });
+      }
+      this.spawnMarkers.push(marker);
+    }
+  }
+
+  private clearSpawnMarkers(): void {
+    for (const marker of this.spawnMarkers) {
+      marker.destroy();
+    }
+    this.spawnMarkers = [];
+  }
+
   /**
    * Exit spectator mode when local player respawns
    */
   exitSpectatorMode(): void {
     this.isSpectating = false;
+    this.clearSpawnMarkers();
+    this.chosenSpawn = null;
 
     if (this.overlay) {
       this.overlay.destroy();
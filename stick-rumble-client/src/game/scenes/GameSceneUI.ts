@@ -2,7 +2,7 @@ import Phaser from 'phaser';
 import type { ShootingManager } from '../input/ShootingManager';
 import type { PlayerManager } from '../entities/PlayerManager';
 import { Crosshair } from '../entities/Crosshair';
-import { COLORS, MINIMAP, RELOAD_ARC } from '../../shared/constants';
+import { COLORS, EMOTE_TEXT, MINIMAP, RELOAD_ARC } from '../../shared/constants';
 import { getDefaultMatchMapContext } from '../../shared/maps';
 import { HudFlexLayout, createHudLayoutItem, type HudLayoutItem } from '../ui/HudFlexLayout';
 import type { GameplayViewportLayout } from '../../shared/types';
@@ -535,6 +535,41 @@ export class GameSceneUI {
     });
   }
 
+  /**
+   * Show a player's emote or quick-chat line floating above them.
+   * Emote IDs come from a fixed set, so the text is the client's own.
+   * @param playerManager - Player manager to get the player's position
+   * @param playerId - ID of the player who emoted
+   * @param emote - Emote or quick-chat ID from player:emoted
+   */
+  showEmote(playerManager: PlayerManager, playerId: string, emote: string): void {
+    const position = playerManager.getPlayerPosition(playerId);
+    if (!position) {
+      return;
+    }
+
+    const emoteText = this.scene.add.text(position.x, position.y - 50, EMOTE_TEXT[emote] ?? emote, {
+      fontSize: '14px',
+      color: '#ffffff',
+      stroke: '#000000',
+      strokeThickness: 3,
+    });
+    emoteText.setOrigin(0.5);
+    emoteText.setDepth(1000);
+
+    // Hold long enough to read, then drift up and fade
+    this.scene.tweens.add({
+      targets: emoteText,
+      y: position.y - 70,
+      alpha: 0,
+      delay: 1500,
+      duration: 500,
+      onComplete: () => {
+        emoteText.destroy();
+      },
+    });
+  }
+
   /**
    * Show directional hit indicator (chevron) pointing from player toward target/source.
    * @param playerX - Local player X position
@@ -118,6 +118,8 @@ describe('GameplayEventRouter', () => {
       showBulletImpact: vi.fn(),
       showBloodParticles: vi.fn(),
       showMeleeHit: vi.fn(),
+      showExplosion: vi.fn(),
+      showPing: vi.fn(),
     };
     healthBarUI = {
       updateHealth: vi.fn(),
@@ -130,6 +132,7 @@ describe('GameplayEventRouter', () => {
       updateMatchTimer: vi.fn(),
       showDamageFlash: vi.fn(),
       showDamageNumber: vi.fn(),
+      showEmote: vi.fn(),
       showHitMarker: vi.fn(),
       showHitIndicator: vi.fn(),
       showWallSpark: vi.fn(),
@@ -139,6 +142,7 @@ describe('GameplayEventRouter', () => {
     spectator = {
       enterSpectatorMode: vi.fn(),
       exitSpectatorMode: vi.fn(),
+      showSpawnOptions: vi.fn(),
     } as unknown as GameSceneSpectator;
 
     screenShake = {
@@ -366,6 +370,107 @@ describe('GameplayEventRouter', () => {
     expect(audioManager.playWeaponSound).toHaveBeenCalledWith('AK47');
   });
 
+  it('spawns every pellet of a batched spread shot with one set of side effects', () => {
+    vi.mocked(playerManager.getLocalPlayerId).mockReturnValue('player-1');
+
+    handlers.get('projectile:spawn')?.({
+      id: 'pellet-1',
+      ownerId: 'player-1',
+      weaponType: 'Shotgun',
+      position: { x: 100, y: 200 },
+      velocity: { x: 800, y: 0 },
+      pellets: [
+        { id: 'pellet-1', velocity: { x: 800, y: 0 } },
+        { id: 'pellet-2', velocity: { x: 790, y: 100 } },
+      ],
+    });
+
+    expect(projectileManager.spawnProjectile).toHaveBeenCalledTimes(2);
+    expect(projectileManager.spawnProjectile).toHaveBeenCalledWith({
+      id: 'pellet-2',
+      ownerId: 'player-1',
+      weaponType: 'Shotgun',
+      position: { x: 100, y: 200 },
+      velocity: { x: 790, y: 100 },
+    });
+    expect(hitEffectManager.showMuzzleFlash).toHaveBeenCalledTimes(1);
+    expect(audioManager.playWeaponSound).toHaveBeenCalledTimes(1);
+  });
+
+  it('replaces an exploding projectile with an explosion filling its splash radius', () => {
+    handlers.get('projectile:explode')?.({
+      projectileId: 'rocket-1',
+      ownerId: 'player-1',
+      weaponType: 'RocketLauncher',
+      position: { x: 300, y: 400 },
+      radius: 100,
+    });
+
+    expect(projectileManager.removeProjectile).toHaveBeenCalledWith('rocket-1');
+    expect(hitEffectManager.showExplosion).toHaveBeenCalledWith(300, 400, 100);
+  });
+
+  it('marks a teammate ping on the map', () => {
+    handlers.get('team:ping')?.({ playerId: 'player-2', type: 'enemy', position: { x: 640, y: 360 } });
+
+    expect(hitEffectManager.showPing).toHaveBeenCalledWith(640, 360, 'enemy');
+  });
+
+  it('shows a player emote above them', () => {
+    handlers.get('player:emoted')?.({ playerId: 'player-1', emote: 'gg' });
+
+    expect(ui.showEmote).toHaveBeenCalledWith(playerManager, 'player-1', 'gg');
+  });
+
+  it('adds room chat to the chat log', () => {
+    const chatLogUI = { addPlayerMessage: vi.fn(), addSystemMessage: vi.fn() };
+    router.setChatLogUI(chatLogUI as any);
+
+    handlers.get('chat:posted')?.({ playerId: 'player-2', displayName: 'Bravo', text: 'gl hf', channel: 'all' });
+
+    expect(chatLogUI.addPlayerMessage).toHaveBeenCalledWith('Bravo', 'gl hf');
+  });
+
+  it('marks team chat in the chat log', () => {
+    const chatLogUI = { addPlayerMessage: vi.fn(), addSystemMessage: vi.fn() };
+    router.setChatLogUI(chatLogUI as any);
+
+    handlers.get('chat:posted')?.({ playerId: 'player-2', displayName: 'Bravo', text: 'push B', channel: 'team' });
+
+    expect(chatLogUI.addPlayerMessage).toHaveBeenCalledWith('[TEAM] Bravo', 'push B');
+  });
+
+  it('reports vote-kick progress in the chat log', () => {
+    const chatLogUI = { addPlayerMessage: vi.fn(), addSystemMessage: vi.fn() };
+    router.setChatLogUI(chatLogUI as any);
+    vi.mocked(playerManager.getPlayerState).mockReturnValue({ id: 'player-3', displayName: 'Charlie' } as any);
+
+    const progress = { targetId: 'player-3', voterId: 'player-1', votes: 1, needed: 2, expiresInMs: 30000, passed: false };
+    handlers.get('room:votekick_progress')?.(progress);
+    handlers.get('room:votekick_progress')?.({ ...progress, votes: 2, passed: true });
+
+    expect(chatLogUI.addSystemMessage).toHaveBeenNthCalledWith(1, 'Vote to kick Charlie: 1/2');
+    expect(chatLogUI.addSystemMessage).toHaveBeenNthCalledWith(2, 'Charlie was voted out');
+  });
+
+  it('announces level ups and their unlocks in the chat log', () => {
+    const chatLogUI = { addPlayerMessage: vi.fn(), addSystemMessage: vi.fn() };
+    router.setChatLogUI(chatLogUI as any);
+    const levelUp = { playerId: 'player-1', level: 3, previousLevel: 2, xp: 1150, nextLevelXp: 1820, unlocks: [] };
+    handlers.get('player:level_up')?.(levelUp);
+    handlers.get('player:level_up')?.({ ...levelUp, level: 4, unlocks: ['emote.salute', 'skin.red'] });
+
+    expect(chatLogUI.addSystemMessage).toHaveBeenNthCalledWith(1, 'You reached level 3!');
+    expect(chatLogUI.addSystemMessage).toHaveBeenNthCalledWith(2, 'You reached level 4! Unlocked: emote.salute, skin.red');
+  });
+
+  it('hands spawn options to the death screen', () => {
+    const points = [{ index: 0, position: { x: 200, y: 540 }, danger: 0, allowed: true }];
+    handlers.get('spawn:options')?.({ points });
+
+    expect(spectator.showSpawnOptions).toHaveBeenCalledWith(points);
+  });
+
   it('applies authoritative weapon state to local weapon ownership and HUD', () => {
     vi.mocked(playerManager.getLocalPlayerId).mockReturnValue('player-1');
 
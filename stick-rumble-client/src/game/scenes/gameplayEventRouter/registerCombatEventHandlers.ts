@@ -1,18 +1,25 @@
 import type {
+  ChatPostedData,
   HitConfirmedData,
   MatchEndedData,
   MatchTimerData,
   MeleeHitData,
   PlayerDamagedData,
   PlayerDeathData,
+  PlayerEmotedData,
   PlayerKillCreditData,
   PlayerLeftData,
+  PlayerLevelUpData,
   PlayerRespawnData,
   ProjectileDestroyData,
+  ProjectileExplodeData,
   ProjectileSpawnData,
   RollEndData,
+  RoomVoteKickProgressData,
   RollStartData,
   ShootFailedData,
+  SpawnOptionsData,
+  TeamPingData,
   WeaponPickupConfirmedData,
   WeaponRespawnedData,
   WeaponStateData,
@@ -45,7 +52,15 @@ export function registerCombatEventHandlers(router: GameplayEventRouterCoordinat
       return;
     }
     const messageData = adaptGameplayEvent<ProjectileSpawnData>(data);
-    router.deps.projectileManager.spawnProjectile(messageData);
+    if (messageData.pellets) {
+      // A spread shot: every pellet shares the shot's owner, weapon and position
+      const { pellets, ...shot } = messageData;
+      for (const pellet of pellets) {
+        router.deps.projectileManager.spawnProjectile({ ...shot, id: pellet.id, velocity: pellet.velocity });
+      }
+    } else {
+      router.deps.projectileManager.spawnProjectile(messageData);
+    }
     publishProjectilePresentation(router, messageData);
   });
 
@@ -57,6 +72,15 @@ export function registerCombatEventHandlers(router: GameplayEventRouterCoordinat
     router.deps.projectileManager.removeProjectile(messageData.id);
   });
 
+  router.registerHandler('projectile:explode', (data: unknown) => {
+    if (router.shouldIgnoreLateGameplayEvent()) {
+      return;
+    }
+    const messageData = adaptGameplayEvent<ProjectileExplodeData>(data);
+    router.deps.projectileManager.removeProjectile(messageData.projectileId);
+    router.deps.hitEffectManager.showExplosion(messageData.position.x, messageData.position.y, messageData.radius);
+  });
+
   router.registerHandler('weapon:state', (data: unknown) => {
     if (router.shouldIgnoreLateGameplayEvent()) {
       return;
@@ -87,8 +111,8 @@ export function registerCombatEventHandlers(router: GameplayEventRouterCoordinat
 
     const wasReloading = router.runtime.shootingManager.isReloading();
     router.runtime.shootingManager.updateWeaponState(messageData);
-    if (messageData.weaponType === 'Bat' || messageData.weaponType === 'Katana') {
-      router.runtime.shootingManager.setWeaponType(messageData.weaponType as 'Bat' | 'Katana');
+    if (messageData.weaponType === 'Bat' || messageData.weaponType === 'Katana' || messageData.weaponType === 'Fists') {
+      router.runtime.shootingManager.setWeaponType(messageData.weaponType as 'Bat' | 'Katana' | 'Fists');
     }
     router.deps.ui.updateAmmoDisplay(router.runtime.shootingManager);
 
@@ -137,6 +161,52 @@ export function registerCombatEventHandlers(router: GameplayEventRouterCoordinat
     }
   });
 
+  router.registerHandler('team:ping', (data: unknown) => {
+    if (router.shouldIgnoreLateGameplayEvent()) {
+      return;
+    }
+    const messageData = adaptGameplayEvent<TeamPingData>(data);
+    router.deps.hitEffectManager.showPing(messageData.position.x, messageData.position.y, messageData.type);
+  });
+
+  router.registerHandler('player:emoted', (data: unknown) => {
+    if (router.shouldIgnoreLateGameplayEvent()) {
+      return;
+    }
+    const messageData = adaptGameplayEvent<PlayerEmotedData>(data);
+    router.deps.ui.showEmote(router.deps.playerManager, messageData.playerId, messageData.emote);
+  });
+
+  router.registerHandler('chat:posted', (data: unknown) => {
+    const messageData = adaptGameplayEvent<ChatPostedData>(data);
+    const sender = messageData.channel === 'team' ? `[TEAM] ${messageData.displayName}` : messageData.displayName;
+    router.runtime.chatLogUI?.addPlayerMessage(sender, messageData.text);
+  });
+
+  router.registerHandler('room:votekick_progress', (data: unknown) => {
+    const messageData = adaptGameplayEvent<RoomVoteKickProgressData>(data);
+    const target = router.deps.playerManager.getPlayerState(messageData.targetId)?.displayName ?? 'a player';
+    router.runtime.chatLogUI?.addSystemMessage(
+      messageData.passed
+        ? `${target} was voted out`
+        : `Vote to kick ${target}: ${messageData.votes}/${messageData.needed}`
+    );
+  });
+
+  router.registerHandler('player:level_up', (data: unknown) => {
+    const messageData = adaptGameplayEvent<PlayerLevelUpData>(data);
+    const unlocked = messageData.unlocks.length > 0 ? ` Unlocked: ${messageData.unlocks.join(', ')}` : '';
+    router.runtime.chatLogUI?.addSystemMessage(`You reached level ${messageData.level}!${unlocked}`);
+  });
+
+  router.registerHandler('spawn:options', (data: unknown) => {
+    if (router.shouldIgnoreLateGameplayEvent()) {
+      return;
+    }
+    const messageData = adaptGameplayEvent<SpawnOptionsData>(data);
+    router.deps.spectator.showSpawnOptions(messageData.points);
+  });
+
   router.registerHandler('player:kill_credit', (data: unknown) => {
     if (router.shouldIgnoreLateGameplayEvent()) {
       return;
@@ -82,6 +82,15 @@ export function registerMatchBootstrapHandlers(router: GameplayEventRouterCoordi
       });
     });
 
+    router.registerHandler('error:room_blocked', (data: unknown) => {
+      const messageData = adaptGameplayEvent<{ code?: string; retryAfterMs?: number }>(data);
+      router.deps.onJoinError?.({
+        type: 'error:room_blocked',
+        code: messageData.code,
+        retryAfterMs: messageData.retryAfterMs,
+      });
+    });
+
     router.registerHandler('error:no_hello', (data: unknown) => {
       const messageData = adaptGameplayEvent<{ offendingType?: string }>(data);
       router.deps.onJoinError?.({
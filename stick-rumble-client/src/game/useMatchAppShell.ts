@@ -134,6 +134,10 @@ function formatJoinError(error: JoinErrorPayload | null, currentCode: string): s
   if (error.type === 'error:room_full') {
     return `Room ${error.code ?? currentCode} is full.`
   }
+  if (error.type === 'error:room_blocked') {
+    const minutes = Math.max(1, Math.ceil((error.retryAfterMs ?? 0) / 60000))
+    return `You were voted out of room ${error.code ?? currentCode}. Try again in ${minutes} min.`
+  }
   return `Server rejected ${error.offendingType ?? 'message'} before hello.`
 }
 
@@ -275,6 +279,14 @@ export function useMatchAppShell(options: MatchAppShellOptions = {}): MatchAppSh
       }))
     }
 
+    const onRoomBlocked = (payload: unknown) => {
+      updateSessionFlow((prev) => applyJoinError(prev, {
+        type: 'error:room_blocked',
+        code: (payload as { code?: string })?.code,
+        retryAfterMs: (payload as { retryAfterMs?: number })?.retryAfterMs,
+      }))
+    }
+
     const onNoHello = (payload: unknown) => {
       updateSessionFlow((prev) => applyJoinError(prev, {
         type: 'error:no_hello',
@@ -285,6 +297,7 @@ export function useMatchAppShell(options: MatchAppShellOptions = {}): MatchAppSh
     client.on('session:status', onSessionStatus)
     client.on('error:bad_room_code', onBadRoomCode)
     client.on('error:room_full', onRoomFull)
+    client.on('error:room_blocked', onRoomBlocked)
     client.on('error:no_hello', onNoHello)
 
     client.connect().catch(() => {
@@ -295,6 +308,7 @@ export function useMatchAppShell(options: MatchAppShellOptions = {}): MatchAppSh
       client.off('session:status', onSessionStatus)
       client.off('error:bad_room_code', onBadRoomCode)
       client.off('error:room_full', onRoomFull)
+      client.off('error:room_blocked', onRoomBlocked)
       client.off('error:no_hello', onNoHello)
       client.setConnectionStateHandler(undefined)
       client.setReconnectReplayFailedHandler(undefined)
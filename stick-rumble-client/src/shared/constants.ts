@@ -171,3 +171,21 @@ export const EFFECTS = {
   /** Projectile diameter in pixels */
   PROJECTILE_DIAMETER: 4,
 } as const;
+
+/**
+ * Emote and quick-chat text by player:emote ID. The server only accepts IDs
+ * from this set, so there is no free text to show.
+ */
+export const EMOTE_TEXT: Record<string, string> = {
+  wave: '*waves*',
+  laugh: '*laughs*',
+  taunt: '*taunts*',
+  salute: '*salutes*',
+  gg: 'GG',
+  nice_shot: 'Nice shot!',
+  thanks: 'Thanks!',
+  sorry: 'Sorry!',
+};
+
+/** Quick-chat lines sent by the number keys 1-4, in key order */
+export const QUICK_CHAT_KEYS = ['gg', 'nice_shot', 'thanks', 'sorry'] as const;
@@ -86,9 +86,10 @@ export interface GameplayViewportLayout {
 }
 
 export interface JoinErrorPayload {
-  type: 'error:bad_room_code' | 'error:room_full' | 'error:no_hello';
+  type: 'error:bad_room_code' | 'error:room_full' | 'error:room_blocked' | 'error:no_hello';
   reason?: string;
   code?: string;
+  retryAfterMs?: number;
   offendingType?: string;
 }
 
@@ -39,6 +39,10 @@ export interface WeaponConfig {
   knockbackDistance: number;
   recoil: RecoilConfig | null;
   spreadDegrees: number;
+  splashRadius?: number;
+  hitImpulse?: number;
+  comboWindowMs?: number;
+  comboMultiplier?: number;
   visuals: WeaponVisuals;
 }
 
@@ -147,6 +151,8 @@ function getHardcodedWeaponConfigs(): Record<string, WeaponConfig> {
       knockbackDistance: 40,
       recoil: null,
       spreadDegrees: 0,
+      comboWindowMs: 800,
+      comboMultiplier: 1.5,
       visuals: {
         muzzleFlashColor: '0x000000',
         muzzleFlashSize: 0,
@@ -174,6 +180,37 @@ function getHardcodedWeaponConfigs(): Record<string, WeaponConfig> {
       knockbackDistance: 0,
       recoil: null,
       spreadDegrees: 0,
+      comboWindowMs: 1100,
+      comboMultiplier: 1.3,
+      visuals: {
+        muzzleFlashColor: '0x000000',
+        muzzleFlashSize: 0,
+        muzzleFlashDuration: 0,
+        muzzleFlashShape: 'circle',
+        projectile: {
+          color: '0x000000', // Not used for melee
+          diameter: 0,
+          tracerColor: '0x000000',
+          tracerWidth: 0,
+          shape: 'circle',
+          tracerLength: 0,
+        },
+      },
+    },
+    Fists: {
+      name: 'Fists',
+      damage: 15,
+      fireRate: 3.0,
+      magazineSize: 0,
+      reloadTimeMs: 0,
+      projectileSpeed: 0,
+      range: 60.0,
+      arcDegrees: 100,
+      knockbackDistance: 0,
+      recoil: null,
+      spreadDegrees: 0,
+      comboWindowMs: 600,
+      comboMultiplier: 2.0,
       visuals: {
         muzzleFlashColor: '0x000000',
         muzzleFlashSize: 0,
@@ -265,6 +302,7 @@ function getHardcodedWeaponConfigs(): Record<string, WeaponConfig> {
       knockbackDistance: 0,
       recoil: null,
       spreadDegrees: 0,
+      hitImpulse: 75.0,
       visuals: {
         muzzleFlashColor: '0xff8800',
         muzzleFlashSize: 16,
@@ -280,6 +318,34 @@ function getHardcodedWeaponConfigs(): Record<string, WeaponConfig> {
         },
       },
     },
+    RocketLauncher: {
+      name: 'RocketLauncher',
+      damage: 70,
+      fireRate: 0.8,
+      magazineSize: 3,
+      reloadTimeMs: 3000,
+      projectileSpeed: 600.0,
+      range: 600.0,
+      arcDegrees: 0,
+      knockbackDistance: 40,
+      recoil: null,
+      spreadDegrees: 0,
+      splashRadius: 100.0,
+      visuals: {
+        muzzleFlashColor: '0xff5500',
+        muzzleFlashSize: 18,
+        muzzleFlashDuration: 120,
+        muzzleFlashShape: 'circle',
+        projectile: {
+          color: '0x666666', // Gunmetal rocket
+          diameter: 8,
+          tracerColor: '0xff5500',
+          tracerWidth: 3,
+          shape: 'circle',
+          tracerLength: 24,
+        },
+      },
+    },
   };
 }
 
@@ -0,0 +1,86 @@
+// Command replay plays a recorded match back through a headless GameServer
+// and checks that it reproduces the recorded hits, kills and final scores,
+// for regression testing physics and combat changes.
+//
+//	replay [-json] match.replay.gz
+//
+// It exits 1 if the playback diverged from the recording. Replays are written
+// by the server when REPLAY_DIR is set, see specs/server-architecture.md.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mtomcal/stick-rumble-server/internal/replay"
+)
+
+// errDiverged is returned when the playback did not reproduce the recording
+var errDiverged = errors.New("playback diverged from the recording")
+
+func main() {
+	err := run(os.Args[1:], os.Stdout, os.Stderr)
+	switch {
+	case err == nil:
+	case errors.Is(err, errDiverged):
+		os.Exit(1)
+	default:
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "replay: %v\n", err)
+		}
+		os.Exit(2)
+	}
+}
+
+// run parses args, verifies the named replay and writes the report to stdout
+func run(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("replay", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	asJSON := flags.Bool("json", false, "write the full report as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("expected one replay file")
+	}
+
+	recording, err := replay.Load(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	report, err := replay.Verify(recording)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		writeSummary(stdout, recording.Header, report)
+	}
+
+	if !report.OK() {
+		return errDiverged
+	}
+	return nil
+}
+
+func writeSummary(w io.Writer, header replay.Header, report replay.Report) {
+	played := report.Played
+	fmt.Fprintf(w, "match %s in room %s: %d ticks, %d hits, %d kills\n", header.MatchID, header.RoomID, played.Ticks, len(played.Hits), len(played.Kills))
+	if report.OK() {
+		fmt.Fprintln(w, "reproduced the recorded hits, kills and scores")
+		return
+	}
+	for _, mismatch := range report.Mismatches {
+		fmt.Fprintf(w, "mismatch: %s\n", mismatch)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/mtomcal/stick-rumble-server/internal/replay"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeReplay writes a replay of two players standing still for a second.
+// The match ends with the given final scores.
+func writeReplay(t *testing.T, finalScores []game.PlayerScore) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "room-1-match-1"+replay.FileExtension)
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	start := time.UnixMilli(1704067200000)
+	writer, err := replay.NewWriter(file, replay.Header{RoomID: "room-1", MatchID: "match-1", StartedAt: start.UnixMilli()})
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteState(1, start, replay.State{Players: []game.PlayerStateSnapshot{
+		{ID: "alpha", Position: game.Vector2{X: 100, Y: 650}, Health: 100},
+		{ID: "bravo", Position: game.Vector2{X: 500, Y: 650}, Health: 100},
+	}}))
+	for tick := uint64(2); tick <= 61; tick++ {
+		now := start.Add(time.Duration(tick-1) * time.Second / 60)
+		require.NoError(t, writer.WriteTick(game.TickRecord{Tick: tick, Now: now.UnixNano(), DeltaTime: 1.0 / 60.0}))
+	}
+	data, err := json.Marshal(map[string]any{"finalScores": finalScores})
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteEvent(61, start.Add(time.Second), "match:ended", data))
+	require.NoError(t, writer.Close())
+	return path
+}
+
+func TestReplayReproducesMatch(t *testing.T) {
+	path := writeReplay(t, []game.PlayerScore{{PlayerID: "alpha"}, {PlayerID: "bravo"}})
+
+	var stdout bytes.Buffer
+	require.NoError(t, run([]string{path}, &stdout, &bytes.Buffer{}))
+	assert.Equal(t, "match match-1 in room room-1: 60 ticks, 0 hits, 0 kills\nreproduced the recorded hits, kills and scores\n", stdout.String())
+}
+
+func TestReplayReportsDivergence(t *testing.T) {
+	path := writeReplay(t, []game.PlayerScore{{PlayerID: "alpha", Kills: 1}, {PlayerID: "bravo", Deaths: 1}})
+
+	var stdout bytes.Buffer
+	err := run([]string{path}, &stdout, &bytes.Buffer{})
+	assert.ErrorIs(t, err, errDiverged)
+	assert.Contains(t, stdout.String(), "mismatch: score of alpha: recorded 1 kills/0 deaths, played 0 kills/0 deaths")
+
+	stdout.Reset()
+	err = run([]string{"-json", path}, &stdout, &bytes.Buffer{})
+	assert.ErrorIs(t, err, errDiverged)
+	var report replay.Report
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &report))
+	assert.Equal(t, 60, report.Played.Ticks)
+	assert.Len(t, report.Mismatches, 1)
+}
+
+func TestReplayRejectsBadArguments(t *testing.T) {
+	assert.Error(t, run(nil, &bytes.Buffer{}, &bytes.Buffer{}))
+	assert.Error(t, run([]string{filepath.Join(t.TempDir(), "missing.replay.gz")}, &bytes.Buffer{}, &bytes.Buffer{}))
+
+	notReplay := filepath.Join(t.TempDir(), "notes.txt")
+	require.NoError(t, os.WriteFile(notReplay, []byte("hello"), 0o644))
+	assert.ErrorIs(t, run([]string{notReplay}, &bytes.Buffer{}, &bytes.Buffer{}), replay.ErrFormat)
+}
@@ -0,0 +1,81 @@
+// Command replaytool summarizes a session recording or a match replay as a
+// timeline of kills, sampled player positions and the score graph, for
+// external visualization.
+//
+//	replaytool [-format json|csv] [-sample 1s] [-o summary.json] recording.jsonl|match.replay.gz
+//
+// Session recordings are written by the admin API's session recording
+// endpoints and match replays by the server while REPLAY_DIR is set, see
+// specs/server-architecture.md.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "replaytool: %v\n", err)
+		}
+		os.Exit(2)
+	}
+}
+
+// run parses args, summarizes the named recording and writes the summary to
+// stdout or the -o file
+func run(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("replaytool", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("format", "json", "summary format: json or csv")
+	sample := flags.Duration("sample", time.Second, "interval between sampled player positions")
+	output := flags.String("o", "", "write the summary to this file instead of stdout")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		return errors.New("expected one recording or replay file")
+	}
+	if *format != "json" && *format != "csv" {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+	if *sample < time.Millisecond {
+		return fmt.Errorf("sample interval %v is below 1ms", *sample)
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	src, err := openSource(file)
+	if err != nil {
+		return err
+	}
+	summary, err := summarize(src, *sample)
+	if err != nil {
+		return err
+	}
+
+	out := stdout
+	if *output != "" {
+		outFile, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer outFile.Close()
+		out = outFile
+	}
+
+	if *format == "csv" {
+		return summary.writeCSV(out)
+	}
+	return summary.writeJSON(out)
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/mtomcal/stick-rumble-server/internal/network"
+	"github.com/mtomcal/stick-rumble-server/internal/replay"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const recordingStart = 1704067200000
+
+// writeRecording writes a room recording with two players. Every event is
+// recorded once per recipient, as the server records room broadcasts.
+func writeRecording(t *testing.T) string {
+	t.Helper()
+
+	lines := []string{
+		fmt.Sprintf(`{"format":"stick-rumble-session-recording","version":1,"target":{"kind":"room","id":"room-1"},"startedAt":%d}`, recordingStart),
+	}
+	entry := func(offset int64, dir, playerID, message string) {
+		lines = append(lines, fmt.Sprintf(`{"t":%d,"dir":%q,"playerId":%q,"roomId":"room-1","message":%s}`, recordingStart+offset, dir, playerID, message))
+	}
+	broadcast := func(offset int64, message string) {
+		entry(offset, "out", "alpha", message)
+		entry(offset, "out", "bravo", message)
+	}
+
+	entry(10, "in", "alpha", `{"type":"input:state","data":{"up":true}}`)
+	broadcast(50, `{"type":"state:snapshot","data":{"players":[{"id":"alpha","position":{"x":100,"y":200}},{"id":"bravo","position":{"x":900,"y":500}}]}}`)
+	broadcast(1200, `{"type":"state:delta","data":{"players":[{"id":"alpha","position":{"x":150,"y":200}}]}}`)
+	broadcast(1500, `{"type":"player:kill_credit","data":{"killerId":"alpha","victimId":"bravo","killerKills":1,"killerXP":100}}`)
+	broadcast(2100, `{"type":"player:respawn","data":{"playerId":"bravo","position":{"x":300,"y":300},"health":100}}`)
+	broadcast(2600, `{"type":"match:timer","data":{"remainingSeconds":60}}`)
+
+	path := filepath.Join(t.TempDir(), "room-room-1.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644))
+	return path
+}
+
+func TestReplayToolJSONSummary(t *testing.T) {
+	var stdout bytes.Buffer
+	require.NoError(t, run([]string{writeRecording(t)}, &stdout, &bytes.Buffer{}))
+
+	var got summary
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &got))
+	assert.Equal(t, network.RecordingTarget{Kind: network.RecordingTargetRoom, ID: "room-1"}, got.Target)
+	assert.Equal(t, int64(recordingStart), got.StartedAt)
+	assert.Equal(t, int64(2600), got.DurationMs)
+	assert.Equal(t, 11, got.Records)
+
+	assert.Equal(t, []killEvent{{T: 1500, KillerID: "alpha", VictimID: "bravo"}}, got.Kills, "one kill despite two recipients")
+	assert.Equal(t, []scorePoint{{T: 1500, PlayerID: "alpha", Kills: 1, XP: 100}}, got.Score)
+	assert.Equal(t, []positionSample{
+		{T: 1000, PlayerID: "alpha", X: 100, Y: 200},
+		{T: 1000, PlayerID: "bravo", X: 900, Y: 500},
+		{T: 2000, PlayerID: "alpha", X: 150, Y: 200},
+		{T: 2000, PlayerID: "bravo", X: 900, Y: 500},
+	}, got.Positions)
+}
+
+// writeMatchReplay writes a match replay with a state, a kill, a tick and an
+// action, as the server's replay recorder does
+func writeMatchReplay(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "room-1-match-1"+replay.FileExtension)
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	start := time.UnixMilli(recordingStart)
+	writer, err := replay.NewWriter(file, replay.Header{RoomID: "room-1", MatchID: "match-1", StartedAt: recordingStart})
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteState(1, start.Add(50*time.Millisecond), replay.State{Players: []game.PlayerStateSnapshot{
+		{ID: "alpha", Position: game.Vector2{X: 100, Y: 200}},
+		{ID: "bravo", Position: game.Vector2{X: 900, Y: 500}},
+	}}))
+	require.NoError(t, writer.WriteTick(game.TickRecord{Tick: 2, Now: start.Add(400 * time.Millisecond).UnixNano()}))
+	require.NoError(t, writer.WriteAction(2, game.Action{Kind: game.ActionShoot, PlayerID: "alpha", At: start.Add(450 * time.Millisecond).UnixNano()}))
+	require.NoError(t, writer.WriteEvent(30, start.Add(1500*time.Millisecond), "player:kill_credit",
+		json.RawMessage(`{"killerId":"alpha","victimId":"bravo","killerKills":1,"killerXP":100}`)))
+	require.NoError(t, writer.WriteEvent(60, start.Add(2100*time.Millisecond), "player:respawn",
+		json.RawMessage(`{"playerId":"bravo","position":{"x":300,"y":300},"health":100}`)))
+	require.NoError(t, writer.Close())
+	return path
+}
+
+func TestReplayToolSummarizesMatchReplays(t *testing.T) {
+	var stdout bytes.Buffer
+	require.NoError(t, run([]string{writeMatchReplay(t)}, &stdout, &bytes.Buffer{}))
+
+	var got summary
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &got))
+	assert.Equal(t, network.RecordingTarget{Kind: network.RecordingTargetRoom, ID: "room-1"}, got.Target)
+	assert.Equal(t, "match-1", got.MatchID)
+	assert.Equal(t, int64(recordingStart), got.StartedAt)
+	assert.Equal(t, int64(2100), got.DurationMs)
+	assert.Equal(t, 5, got.Records, "ticks and actions are counted but carry no message")
+
+	assert.Equal(t, []killEvent{{T: 1500, KillerID: "alpha", VictimID: "bravo"}}, got.Kills)
+	assert.Equal(t, []scorePoint{{T: 1500, PlayerID: "alpha", Kills: 1, XP: 100}}, got.Score)
+	assert.Equal(t, []positionSample{
+		{T: 1000, PlayerID: "alpha", X: 100, Y: 200},
+		{T: 1000, PlayerID: "bravo", X: 900, Y: 500},
+		{T: 2000, PlayerID: "alpha", X: 100, Y: 200},
+		{T: 2000, PlayerID: "bravo", X: 900, Y: 500},
+	}, got.Positions)
+}
+
+func TestReplayToolCSVSummary(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "summary.csv")
+	require.NoError(t, run([]string{"-format", "csv", "-sample", "2s", "-o", output, writeRecording(t)}, &bytes.Buffer{}, &bytes.Buffer{}))
+
+	content, err := os.ReadFile(output)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join([]string{
+		"t,event,playerId,otherId,x,y,kills,xp",
+		"1500,kill,alpha,bravo,,,,",
+		"1500,score,alpha,,,,1,100",
+		"2000,position,alpha,,150,200,,",
+		"2000,position,bravo,,900,500,,",
+	}, "\n")+"\n", string(content))
+}
+
+func TestReplayToolRejectsBadInput(t *testing.T) {
+	dir := t.TempDir()
+	notRecording := filepath.Join(dir, "other.jsonl")
+	require.NoError(t, os.WriteFile(notRecording, []byte(`{"type":"input:state"}`+"\n"), 0o644))
+	err := run([]string{notRecording}, &bytes.Buffer{}, &bytes.Buffer{})
+	assert.ErrorIs(t, err, network.ErrRecordingFormat)
+
+	malformed := filepath.Join(dir, "malformed.jsonl")
+	require.NoError(t, os.WriteFile(malformed, []byte(`{"format":"stick-rumble-session-recording","version":1,"startedAt":0}
+{"t":5,"dir":"out","playerId":"alpha","message":{"type":"player:kill_credit","data":{"killerKills":"one"}}}
+`), 0o644))
+	err = run([]string{malformed}, &bytes.Buffer{}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "record 1: player:kill_credit")
+
+	truncated := filepath.Join(dir, "truncated"+replay.FileExtension)
+	require.NoError(t, os.WriteFile(truncated, []byte{0x1f, 0x8b}, 0o644))
+	err = run([]string{truncated}, &bytes.Buffer{}, &bytes.Buffer{})
+	assert.ErrorIs(t, err, replay.ErrFormat)
+
+	recording := writeRecording(t)
+	assert.Error(t, run([]string{"-format", "xml", recording}, &bytes.Buffer{}, &bytes.Buffer{}))
+	assert.Error(t, run([]string{"-sample", "0s", recording}, &bytes.Buffer{}, &bytes.Buffer{}))
+	assert.Error(t, run(nil, &bytes.Buffer{}, &bytes.Buffer{}))
+}
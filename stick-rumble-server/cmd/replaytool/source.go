@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/network"
+	"github.com/mtomcal/stick-rumble-server/internal/replay"
+)
+
+// gzipMagic starts every gzip stream, and so every match replay
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// source is a session recording or a match replay read back as the messages
+// clients were sent
+type source struct {
+	target    network.RecordingTarget
+	matchID   string
+	startedAt time.Time
+	// next returns the time (Unix ms) of the next record and, if the record
+	// is a message sent to a client, the message; io.EOF after the last one
+	next func() (int64, *recordedMessage, error)
+}
+
+// openSource reads the header of a session recording or, if r is gzip
+// compressed, of a match replay
+func openSource(r io.Reader) (*source, error) {
+	buffered := bufio.NewReader(r)
+	if magic, _ := buffered.Peek(len(gzipMagic)); bytes.Equal(magic, gzipMagic) {
+		reader, err := replay.NewReader(buffered)
+		if err != nil {
+			return nil, err
+		}
+		return matchReplaySource(reader), nil
+	}
+
+	reader, err := network.NewSessionRecordingReader(buffered)
+	if err != nil {
+		return nil, err
+	}
+	return sessionRecordingSource(reader), nil
+}
+
+// sessionRecordingSource reads every recorded message; only those sent to
+// clients are returned
+func sessionRecordingSource(reader *network.SessionRecordingReader) *source {
+	return &source{
+		target:    reader.Target,
+		startedAt: reader.StartedAt,
+		next: func() (int64, *recordedMessage, error) {
+			entry, err := reader.Next()
+			if err != nil {
+				return 0, nil, err
+			}
+			if entry.Direction != "out" {
+				return entry.Timestamp, nil, nil
+			}
+			var msg recordedMessage
+			if err := json.Unmarshal(entry.Message, &msg); err != nil {
+				return 0, nil, err
+			}
+			return entry.Timestamp, &msg, nil
+		},
+	}
+}
+
+// matchReplaySource reads a match replay's states as state:snapshot and its
+// events as the messages they were broadcast as. Ticks and actions carry no
+// message.
+func matchReplaySource(reader *replay.Reader) *source {
+	return &source{
+		target:    network.RecordingTarget{Kind: network.RecordingTargetRoom, ID: reader.Header.RoomID},
+		matchID:   reader.Header.MatchID,
+		startedAt: time.UnixMilli(reader.Header.StartedAt),
+		next: func() (int64, *recordedMessage, error) {
+			entry, err := reader.Next()
+			if err != nil {
+				return 0, nil, err
+			}
+			switch entry.Kind {
+			case replay.KindState:
+				return entry.Timestamp, &recordedMessage{Type: "state:snapshot", Data: entry.Data}, nil
+			case replay.KindEvent:
+				return entry.Timestamp, &recordedMessage{Type: entry.Type, Data: entry.Data}, nil
+			}
+			return entry.Timestamp, nil, nil
+		},
+	}
+}
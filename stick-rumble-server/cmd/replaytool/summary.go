@@ -0,0 +1,201 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/mtomcal/stick-rumble-server/internal/network"
+)
+
+// summary is the timeline of one recording. Times are milliseconds since the
+// recording started.
+type summary struct {
+	Target     network.RecordingTarget `json:"target"`
+	MatchID    string                  `json:"matchId,omitempty"` // Match replays only
+	StartedAt  int64                   `json:"startedAt"`
+	DurationMs int64                   `json:"durationMs"`
+	Records    int                     `json:"records"`
+	Kills      []killEvent             `json:"kills"`
+	Positions  []positionSample        `json:"positions"`
+	Score      []scorePoint            `json:"score"`
+}
+
+type killEvent struct {
+	T        int64  `json:"t"`
+	KillerID string `json:"killerId"`
+	VictimID string `json:"victimId"`
+}
+
+type positionSample struct {
+	T        int64   `json:"t"`
+	PlayerID string  `json:"playerId"`
+	X        float64 `json:"x"`
+	Y        float64 `json:"y"`
+}
+
+// scorePoint is a killer's running totals after a kill
+type scorePoint struct {
+	T        int64  `json:"t"`
+	PlayerID string `json:"playerId"`
+	Kills    int    `json:"kills"`
+	XP       int    `json:"xp"`
+}
+
+type recordedMessage struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+type playerPosition struct {
+	ID       string       `json:"id"`
+	PlayerID string       `json:"playerId"`
+	Position game.Vector2 `json:"position"`
+}
+
+type killCredit struct {
+	KillerID    string `json:"killerId"`
+	VictimID    string `json:"victimId"`
+	KillerKills int    `json:"killerKills"`
+	KillerXP    int    `json:"killerXP"`
+}
+
+// summarize reads every record of a recording or replay. Positions come from
+// the state and respawn events sent to clients and are sampled every
+// sampleEvery; kills and the score graph come from kill credits. A room
+// recording holds each broadcast once per recipient, so kill credits are
+// counted once per killer and kill count.
+func summarize(src *source, sampleEvery time.Duration) (*summary, error) {
+	s := &summary{
+		Target:    src.target,
+		MatchID:   src.matchID,
+		StartedAt: src.startedAt.UnixMilli(),
+		Kills:     []killEvent{},
+		Positions: []positionSample{},
+		Score:     []scorePoint{},
+	}
+
+	interval := sampleEvery.Milliseconds()
+	nextSample := int64(0)
+	positions := make(map[string]game.Vector2)
+	credited := make(map[killCredit]bool)
+
+	for {
+		at, msg, err := src.next()
+		if err == io.EOF {
+			break
+		}
+		s.Records++
+		if err != nil {
+			return nil, fmt.Errorf("record %d: %w", s.Records, err)
+		}
+
+		t := at - s.StartedAt
+		s.DurationMs = max(s.DurationMs, t)
+		if t >= nextSample {
+			s.samplePositions(nextSample, positions)
+			nextSample = (t/interval + 1) * interval
+		}
+
+		if msg == nil {
+			continue
+		}
+		if err := s.apply(t, *msg, positions, credited); err != nil {
+			return nil, fmt.Errorf("record %d: %s: %w", s.Records, msg.Type, err)
+		}
+	}
+	return s, nil
+}
+
+func (s *summary) apply(t int64, msg recordedMessage, positions map[string]game.Vector2, credited map[killCredit]bool) error {
+	switch msg.Type {
+	case "state:snapshot", "state:delta":
+		var data struct {
+			Players []playerPosition `json:"players"`
+		}
+		if err := json.Unmarshal(msg.Data, &data); err != nil {
+			return err
+		}
+		for _, player := range data.Players {
+			positions[player.ID] = player.Position
+		}
+	case "player:respawn":
+		var data playerPosition
+		if err := json.Unmarshal(msg.Data, &data); err != nil {
+			return err
+		}
+		positions[data.PlayerID] = data.Position
+	case "player:kill_credit":
+		var data killCredit
+		if err := json.Unmarshal(msg.Data, &data); err != nil {
+			return err
+		}
+		if credited[data] {
+			return nil
+		}
+		credited[data] = true
+		s.Kills = append(s.Kills, killEvent{T: t, KillerID: data.KillerID, VictimID: data.VictimID})
+		s.Score = append(s.Score, scorePoint{T: t, PlayerID: data.KillerID, Kills: data.KillerKills, XP: data.KillerXP})
+	case "match:ended":
+		// Kill counts start over in the next match
+		clear(credited)
+	}
+	return nil
+}
+
+func (s *summary) samplePositions(t int64, positions map[string]game.Vector2) {
+	ids := make([]string, 0, len(positions))
+	for id := range positions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		s.Positions = append(s.Positions, positionSample{T: t, PlayerID: id, X: positions[id].X, Y: positions[id].Y})
+	}
+}
+
+func (s *summary) writeJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(s)
+}
+
+// writeCSV writes the timeline as one table ordered by time, with an event
+// column of kill, position or score and blanks for fields an event lacks
+func (s *summary) writeCSV(w io.Writer) error {
+	type row struct {
+		t      int64
+		fields []string
+	}
+	formatFloat := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+
+	rows := make([]row, 0, len(s.Kills)+len(s.Positions)+len(s.Score))
+	for _, p := range s.Positions {
+		rows = append(rows, row{p.T, []string{"position", p.PlayerID, "", formatFloat(p.X), formatFloat(p.Y), "", ""}})
+	}
+	for _, k := range s.Kills {
+		rows = append(rows, row{k.T, []string{"kill", k.KillerID, k.VictimID, "", "", "", ""}})
+	}
+	for _, p := range s.Score {
+		rows = append(rows, row{p.T, []string{"score", p.PlayerID, "", "", "", strconv.Itoa(p.Kills), strconv.Itoa(p.XP)}})
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].t < rows[j].t })
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"t", "event", "playerId", "otherId", "x", "y", "kills", "xp"}); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		if err := writer.Write(append([]string{strconv.FormatInt(r.t, 10)}, r.fields...)); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
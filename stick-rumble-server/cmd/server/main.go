@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/buildinfo"
+	"github.com/mtomcal/stick-rumble-server/internal/config"
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/mtomcal/stick-rumble-server/internal/network"
+	"github.com/quic-go/webtransport-go"
+)
+
+// startServer initializes and starts the HTTP server with health and WebSocket endpoints
+// Returns when context is cancelled or server encounters an error
+func startServer(ctx context.Context) error {
+	runtimeConfig := config.Load()
+
+	// Weapon balance overrides, loaded before any player gets a weapon
+	if runtimeConfig.WeaponConfigPath != "" {
+		if err := game.UseWeaponConfigFile(runtimeConfig.WeaponConfigPath); err != nil {
+			return err
+		}
+		log.Printf("Loaded weapon definitions from %s", runtimeConfig.WeaponConfigPath)
+	}
+
+	// Create HTTP server with routes
+	mux := http.NewServeMux()
+
+	// Health check endpoint
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("OK"))
+	})
+
+	// Build info, for matching bug reports to an exact build
+	mux.HandleFunc("/version", handleVersion)
+
+	// Effective gameplay constants, for checking client values against the server
+	mux.HandleFunc("/constants", handleConstants)
+
+	// Live weapon stats, for wikis and clients to read instead of copying
+	mux.HandleFunc("/weapons", handleWeapons)
+
+	// Weapon pick rates, kill shares and times to kill by skill bracket
+	mux.HandleFunc("GET /weapons/report", network.HandleWeaponReport)
+
+	// Open rooms, for a lobby's server browser
+	mux.HandleFunc("/rooms", network.HandleRoomList)
+
+	// Finished matches' timelines, for post-game graphs
+	mux.HandleFunc("GET /matches/{matchID}/timeline", network.HandleMatchTimeline)
+
+	// Players ranked by XP, K/D and wins over the recorded matches
+	mux.HandleFunc("GET /leaderboard", network.HandleLeaderboard)
+
+	// Recent finished matches, optionally those of one player
+	mux.HandleFunc("GET /matches", network.HandleMatchHistory)
+
+	// A player's live stats, and optionally their recorded match history
+	mux.HandleFunc("GET /players/{playerID}/stats", network.HandlePlayerStats)
+
+	// WebSocket endpoint
+	mux.HandleFunc("/ws", network.HandleWebSocket)
+
+	// Operator API, only served when an admin token is configured
+	if runtimeConfig.AdminToken != "" {
+		mux.Handle("/admin/", network.AdminHandler(runtimeConfig.AdminToken))
+	}
+
+	// Create server with configured timeouts
+	server := &http.Server{
+		Addr:         runtimeConfig.Host + ":" + runtimeConfig.Port,
+		Handler:      mux,
+		ReadTimeout:  15 * time.Second,
+		WriteTimeout: 15 * time.Second,
+		IdleTimeout:  60 * time.Second,
+	}
+
+	// Start game server (global handler)
+	network.StartGlobalHandler(ctx)
+
+	// Channel to capture server errors
+	serverErrors := make(chan error, 2)
+
+	// Start HTTP server in goroutine
+	go func() {
+		log.Printf("Starting server on %s:%s", runtimeConfig.Host, runtimeConfig.Port)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			serverErrors <- err
+		}
+	}()
+
+	// WebTransport endpoint, only served when an address is configured
+	var webTransport *webtransport.Server
+	if runtimeConfig.WebTransportAddr != "" {
+		webTransport = network.NewWebTransportServer(runtimeConfig.WebTransportAddr)
+		go func() {
+			log.Printf("Starting WebTransport server on %s%s", runtimeConfig.WebTransportAddr, network.WebTransportPath)
+			if err := webTransport.ListenAndServeTLS(runtimeConfig.TLSCertFile, runtimeConfig.TLSKeyFile); err != nil && err != http.ErrServerClosed {
+				serverErrors <- fmt.Errorf("webtransport: %w", err)
+			}
+		}()
+	}
+
+	// Wait for context cancellation or server error
+	select {
+	case err := <-serverErrors:
+		return err
+	case <-ctx.Done():
+		// Let running matches finish, or end them with a scoreboard, before
+		// the connections close
+		log.Println("Shutting down server...")
+		network.DrainGlobalHandler(context.Background(), runtimeConfig.ShutdownDrain)
+
+		// Graceful shutdown with timeout
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		network.StopGlobalHandler()
+
+		if webTransport != nil {
+			if err := webTransport.Close(); err != nil {
+				log.Printf("WebTransport shutdown error: %v", err)
+			}
+		}
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Server shutdown error: %v", err)
+			return err
+		}
+		log.Println("Server stopped")
+		return nil
+	}
+}
+
+// handleVersion serves the running build's commit, build time and Go version as JSON
+func handleVersion(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, r, buildinfo.Get())
+}
+
+// handleConstants serves the gameplay constants the running server uses as JSON
+func handleConstants(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, r, game.CurrentTunables())
+}
+
+// handleWeapons serves every weapon's balance sheet as the server applies it
+func handleWeapons(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, r, game.WeaponInspections())
+}
+
+// writeJSON answers a GET request with value encoded as JSON
+func writeJSON(w http.ResponseWriter, r *http.Request, value any) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		log.Printf("Failed to encode %s response: %v", r.URL.Path, err)
+	}
+}
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == simulateBalanceCommand {
+		if err := runSimulateBalance(os.Args[2:], os.Stdout, os.Stderr); err != nil {
+			if !errors.Is(err, flag.ErrHelp) {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", simulateBalanceCommand, err)
+			}
+			os.Exit(2)
+		}
+		return
+	}
+
+	// Create context that listens for interrupt signals
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Handle shutdown signals
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+
+	// Start server in background
+	serverDone := make(chan error, 1)
+	go func() {
+		serverDone <- startServer(ctx)
+	}()
+
+	// Wait for shutdown signal or server error
+	select {
+	case sig := <-sigChan:
+		log.Printf("Received signal: %v", sig)
+		cancel()
+		<-serverDone // Wait for graceful shutdown
+	case err := <-serverDone:
+		if err != nil {
+			log.Fatalf("Server error: %v", err)
+		}
+	}
+}
@@ -0,0 +1,323 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/buildinfo"
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+// TestHealthEndpoint verifies the /health endpoint returns 200 OK
+func TestHealthEndpoint(t *testing.T) {
+	// Set test port to avoid conflicts
+	os.Setenv("PORT", "18080")
+	defer os.Unsetenv("PORT")
+
+	// Start server in background
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		if err := startServer(ctx); err != nil && err != http.ErrServerClosed {
+			t.Logf("Server error: %v", err)
+		}
+	}()
+
+	// Wait for server to start (with timeout)
+	client := &http.Client{Timeout: 2 * time.Second}
+	maxAttempts := 20
+	var resp *http.Response
+	var err error
+
+	for i := 0; i < maxAttempts; i++ {
+		resp, err = client.Get("http://localhost:18080/health")
+		if err == nil {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	if err != nil {
+		t.Fatalf("Failed to connect to health endpoint after %d attempts: %v", maxAttempts, err)
+	}
+	defer resp.Body.Close()
+
+	// Verify status code
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+
+	// Verify response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read response body: %v", err)
+	}
+
+	expected := "OK"
+	if string(body) != expected {
+		t.Errorf("Expected body %q, got %q", expected, string(body))
+	}
+}
+
+// TestConstantsEndpoint verifies /constants reports the server's gameplay constants
+func TestConstantsEndpoint(t *testing.T) {
+	rec := httptest.NewRecorder()
+	handleConstants(rec, httptest.NewRequest(http.MethodGet, "/constants", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("Expected JSON content type, got %q", got)
+	}
+
+	var constants game.Tunables
+	if err := json.Unmarshal(rec.Body.Bytes(), &constants); err != nil {
+		t.Fatalf("Failed to decode constants: %v", err)
+	}
+	if constants.Movement.Speed != game.MovementSpeed {
+		t.Errorf("Expected movement speed %v, got %v", game.MovementSpeed, constants.Movement.Speed)
+	}
+	if constants.Network.ServerTickRate != game.ServerTickRate {
+		t.Errorf("Expected tick rate %d, got %d", game.ServerTickRate, constants.Network.ServerTickRate)
+	}
+	if _, ok := constants.Weapons["Pistol"]; !ok {
+		t.Error("Expected weapon configs to include Pistol")
+	}
+
+	rec = httptest.NewRecorder()
+	handleConstants(rec, httptest.NewRequest(http.MethodPost, "/constants", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405 for POST, got %d", rec.Code)
+	}
+}
+
+// TestWeaponsEndpoint verifies /weapons lists the server's weapon stats
+func TestWeaponsEndpoint(t *testing.T) {
+	rec := httptest.NewRecorder()
+	handleWeapons(rec, httptest.NewRequest(http.MethodGet, "/weapons", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+
+	var weapons []game.WeaponInspection
+	if err := json.Unmarshal(rec.Body.Bytes(), &weapons); err != nil {
+		t.Fatalf("Failed to decode weapons: %v", err)
+	}
+	if len(weapons) != len(game.WeaponDefinitions()) {
+		t.Fatalf("Expected %d weapons, got %d", len(game.WeaponDefinitions()), len(weapons))
+	}
+	for _, weapon := range weapons {
+		if want := game.WeaponDefinitions()[weapon.Name].Damage; weapon.Damage != want {
+			t.Errorf("Expected %s damage %d, got %d", weapon.Name, want, weapon.Damage)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	handleWeapons(rec, httptest.NewRequest(http.MethodPost, "/weapons", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405 for POST, got %d", rec.Code)
+	}
+}
+
+// TestVersionEndpoint verifies /version reports the build info
+func TestVersionEndpoint(t *testing.T) {
+	commit := buildinfo.Commit
+	defer func() { buildinfo.Commit = commit }()
+	buildinfo.Commit = "abc1234"
+
+	rec := httptest.NewRecorder()
+	handleVersion(rec, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+
+	var info buildinfo.Info
+	if err := json.Unmarshal(rec.Body.Bytes(), &info); err != nil {
+		t.Fatalf("Failed to decode version: %v", err)
+	}
+	if info.Commit != "abc1234" {
+		t.Errorf("Expected commit %q, got %q", "abc1234", info.Commit)
+	}
+	if info.GoVersion == "" || info.BuildTime == "" {
+		t.Errorf("Expected Go version and build time, got %+v", info)
+	}
+}
+
+// TestWebSocketEndpoint verifies the /ws endpoint is registered
+func TestWebSocketEndpoint(t *testing.T) {
+	// Set test port to avoid conflicts
+	os.Setenv("PORT", "18081")
+	defer os.Unsetenv("PORT")
+
+	// Start server in background
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		if err := startServer(ctx); err != nil && err != http.ErrServerClosed {
+			t.Logf("Server error: %v", err)
+		}
+	}()
+
+	// Wait for server to start
+	client := &http.Client{Timeout: 2 * time.Second}
+	maxAttempts := 20
+
+	for i := 0; i < maxAttempts; i++ {
+		_, err := client.Get("http://localhost:18081/health")
+		if err == nil {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// Try to connect to WebSocket endpoint (should get upgrade error, not 404)
+	resp, err := client.Get("http://localhost:18081/ws")
+	if err != nil {
+		t.Fatalf("Failed to connect to /ws endpoint: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// WebSocket endpoint should exist (return 400 Bad Request for non-WS connection)
+	// Not 404 Not Found
+	if resp.StatusCode == http.StatusNotFound {
+		t.Errorf("WebSocket endpoint not registered (got 404)")
+	}
+}
+
+// TestServerGracefulShutdown verifies the server shuts down cleanly
+func TestServerGracefulShutdown(t *testing.T) {
+	// Set test port to avoid conflicts
+	os.Setenv("PORT", "18082")
+	defer os.Unsetenv("PORT")
+
+	// Start server in background
+	ctx, cancel := context.WithCancel(context.Background())
+
+	serverDone := make(chan error, 1)
+	go func() {
+		serverDone <- startServer(ctx)
+	}()
+
+	// Wait for server to start
+	client := &http.Client{Timeout: 2 * time.Second}
+	maxAttempts := 20
+
+	for i := 0; i < maxAttempts; i++ {
+		resp, err := client.Get("http://localhost:18082/health")
+		if err == nil {
+			resp.Body.Close()
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// Trigger shutdown
+	cancel()
+
+	// Wait for server to stop (should complete within timeout)
+	select {
+	case err := <-serverDone:
+		if err != nil && err != http.ErrServerClosed && err != context.Canceled {
+			t.Errorf("Server shutdown error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("Server did not shut down within timeout")
+	}
+}
+
+// TestServerDefaultPort verifies the server uses default port when PORT env is not set
+func TestServerDefaultPort(t *testing.T) {
+	// Ensure PORT is not set
+	os.Unsetenv("PORT")
+
+	// Start server in background
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		if err := startServer(ctx); err != nil && err != http.ErrServerClosed {
+			t.Logf("Server error: %v", err)
+		}
+	}()
+
+	// Wait for server to start on default port 8080
+	client := &http.Client{Timeout: 2 * time.Second}
+	maxAttempts := 20
+
+	for i := 0; i < maxAttempts; i++ {
+		resp, err := client.Get("http://localhost:8080/health")
+		if err == nil {
+			resp.Body.Close()
+			// Server started successfully on default port
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	t.Error("Server did not start on default port 8080")
+}
+
+// TestServerPortConflict tests startServer error path when port is already in use
+func TestServerPortConflict(t *testing.T) {
+	// Occupy a port first
+	listener, err := net.Listen("tcp", ":18083")
+	if err != nil {
+		t.Skipf("Could not occupy port 18083: %v", err)
+	}
+	defer listener.Close()
+
+	os.Setenv("PORT", "18083")
+	defer os.Unsetenv("PORT")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	// startServer should return an error because port is occupied
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- startServer(ctx)
+	}()
+
+	select {
+	case err := <-serverErr:
+		// Should get an error about address already in use
+		if err == nil {
+			t.Log("Expected error from port conflict, got nil (server may have started on different mechanism)")
+		}
+		// Either way, test covers the error path in startServer
+	case <-time.After(4 * time.Second):
+		// Context timeout — cancel should have triggered shutdown
+		cancel()
+	}
+}
+
+// TestServerWebTransportNeedsCertificate tests that a WebTransport address
+// without a usable certificate stops startServer
+func TestServerWebTransportNeedsCertificate(t *testing.T) {
+	t.Setenv("PORT", "18084")
+	t.Setenv("WEBTRANSPORT_ADDR", "127.0.0.1:0")
+	t.Setenv("TLS_CERT_FILE", filepath.Join(t.TempDir(), "missing.pem"))
+	t.Setenv("TLS_KEY_FILE", filepath.Join(t.TempDir(), "missing-key.pem"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := startServer(ctx)
+	if err == nil || !strings.Contains(err.Error(), "webtransport") {
+		t.Fatalf("startServer() error = %v, want a webtransport certificate error", err)
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mtomcal/stick-rumble-server/internal/balance"
+	"github.com/mtomcal/stick-rumble-server/internal/config"
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+// simulateBalanceCommand is the first argument that runs the balance
+// simulation instead of the server:
+//
+//	server simulate-balance [-duels 200] [-weapons AK47,Uzi] [-seed 1]
+//	    [-difficulty normal] [-max-duration 30s] [-weapon-config file] [-format table|json]
+const simulateBalanceCommand = "simulate-balance"
+
+// runSimulateBalance plays the duels args ask for and writes the win-rate
+// matrix to stdout
+func runSimulateBalance(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet(simulateBalanceCommand, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	duels := flags.Int("duels", balance.DefaultDuels, "duels per weapon matchup")
+	weapons := flags.String("weapons", "", "comma-separated weapons to compare (default every weapon)")
+	seed := flags.Int64("seed", 1, "seed for start positions and aim errors")
+	difficultyName := flags.String("difficulty", config.DefaultBotDifficulty, "bot difficulty both duelists play at")
+	maxDuration := flags.Duration("max-duration", balance.DefaultMaxDuration, "duel length after which it is a draw")
+	weaponConfig := flags.String("weapon-config", os.Getenv("WEAPON_CONFIG"), "weapon definitions file to test (default WEAPON_CONFIG)")
+	format := flags.String("format", "table", "output format: table or json")
+	verbose := flags.Bool("v", false, "keep the game's log output")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 0 {
+		return fmt.Errorf("unexpected argument %q", flags.Arg(0))
+	}
+	if *format != "table" && *format != "json" {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+	if *duels < 1 {
+		return errors.New("duels must be at least 1")
+	}
+	if *maxDuration <= 0 {
+		return errors.New("max-duration must be positive")
+	}
+	difficulty, ok := game.BotDifficultyByName(*difficultyName)
+	if !ok {
+		return fmt.Errorf("unknown difficulty %q", *difficultyName)
+	}
+	if *weaponConfig != "" {
+		if err := game.UseWeaponConfigFile(*weaponConfig); err != nil {
+			return err
+		}
+	}
+
+	var names []string
+	if *weapons != "" {
+		names = strings.Split(*weapons, ",")
+	}
+	if !*verbose {
+		defer log.SetOutput(log.Writer())
+		log.SetOutput(io.Discard)
+	}
+	report, err := balance.Run(balance.Config{
+		Weapons:     names,
+		Duels:       *duels,
+		Seed:        *seed,
+		Difficulty:  difficulty,
+		MaxDuration: *maxDuration,
+	})
+	if err != nil {
+		return err
+	}
+
+	if *format == "json" {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	return writeBalanceTable(stdout, report)
+}
+
+// writeBalanceTable writes the win-rate matrix, row weapon against column
+// weapon, and the draws
+func writeBalanceTable(w io.Writer, report balance.Report) error {
+	fmt.Fprintf(w, "Win rate of each row weapon against each column weapon, %d duels per matchup, seed %d\n\n", report.Duels, report.Seed)
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprint(table, "\t")
+	for _, name := range report.Weapons {
+		fmt.Fprintf(table, "%s\t", name)
+	}
+	fmt.Fprintln(table)
+	for i, row := range report.WinRates {
+		fmt.Fprintf(table, "%s\t", report.Weapons[i])
+		for _, rate := range row {
+			fmt.Fprintf(table, "%.0f%%\t", rate*100)
+		}
+		fmt.Fprintln(table)
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	draws, total := 0, 0
+	for _, matchup := range report.Matchups {
+		draws += matchup.Draws
+		total += matchup.Wins + matchup.Losses + matchup.Draws
+	}
+	_, err := fmt.Fprintf(w, "\n%d of %d duels were draws\n", draws, total)
+	return err
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/mtomcal/stick-rumble-server/internal/balance"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateBalanceTable(t *testing.T) {
+	var stdout bytes.Buffer
+	err := runSimulateBalance([]string{"-duels", "2", "-weapons", "Pistol,Shotgun"}, &stdout, io.Discard)
+	require.NoError(t, err)
+
+	output := stdout.String()
+	assert.Contains(t, output, "2 duels per matchup, seed 1")
+	assert.Contains(t, output, "Pistol")
+	assert.Contains(t, output, "Shotgun")
+	assert.Contains(t, output, "duels were draws")
+}
+
+func TestSimulateBalanceJSON(t *testing.T) {
+	var stdout bytes.Buffer
+	err := runSimulateBalance([]string{"-duels", "2", "-weapons", "AK47,Katana", "-seed", "9", "-format", "json"}, &stdout, io.Discard)
+	require.NoError(t, err)
+
+	var report balance.Report
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &report))
+	assert.Equal(t, []string{"AK47", "Katana"}, report.Weapons)
+	assert.Equal(t, int64(9), report.Seed)
+	assert.Len(t, report.WinRates, 2)
+	assert.Len(t, report.Matchups, 3)
+}
+
+func TestSimulateBalanceRejectsBadFlags(t *testing.T) {
+	for _, args := range [][]string{
+		{"-format", "xml"},
+		{"-duels", "0"},
+		{"-difficulty", "godlike"},
+		{"-weapons", "AK47,Slingshot"},
+		{"extra"},
+	} {
+		assert.Error(t, runSimulateBalance(args, io.Discard, io.Discard), "%v", args)
+	}
+}
@@ -0,0 +1,362 @@
+// Package balance pits weapons against each other in scripted 1v1 duels on
+// the headless game simulation and reports how often each one wins, so a
+// balance change can be checked before it ships.
+//
+// Each duel runs its own GameServer on a manual clock, stepped one tick at a
+// time. The two duelists start in line of sight of each other at a random
+// spot on the default map, close to their weapon's range and fight with bot
+// aim until one dies or the duel times out as a draw. The map's shield and
+// health pickups are taken before the duel starts so neither side gets one.
+package balance
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/mtomcal/stick-rumble-server/internal/game/bot"
+)
+
+// Simulation defaults and duel setup
+const (
+	DefaultDuels       = 200
+	DefaultMaxDuration = 30 * time.Second
+
+	MinStartDistance = 200.0 // Pixels between the duelists at the start
+	MaxStartDistance = 500.0
+	edgeMargin       = 64.0 // Pixels a start position keeps from the map edge
+	placementTries   = 1000
+	meleeCloseIn     = 0.75 // Share of a melee weapon's range a duelist closes to
+)
+
+// Config says which duels to run
+type Config struct {
+	Weapons     []string           // Weapon types to pit against each other; empty is every weapon
+	Duels       int                // Duels per matchup; zero is DefaultDuels
+	Seed        int64              // Seeds start positions and aim errors
+	Difficulty  game.BotDifficulty // How well both duelists aim and how fast they react
+	MaxDuration time.Duration      // A duel still going after this is a draw; zero is DefaultMaxDuration
+}
+
+// Matchup is the result of every duel between two weapons, from Weapon's side
+type Matchup struct {
+	Weapon           string `json:"weapon"`
+	Opponent         string `json:"opponent"`
+	Wins             int    `json:"wins"`
+	Losses           int    `json:"losses"`
+	Draws            int    `json:"draws"`
+	MeanTimeToKillMs int64  `json:"meanTimeToKillMs"` // Over the duels that ended in a kill
+	WinTimeToKillMs  int64  `json:"winTimeToKillMs"`  // Over Weapon's wins
+	LossTimeToKillMs int64  `json:"lossTimeToKillMs"` // Over Opponent's wins
+}
+
+// Report is the outcome of a simulation
+type Report struct {
+	Weapons []string `json:"weapons"`
+	Duels   int      `json:"duels"` // Per matchup
+	Seed    int64    `json:"seed"`
+	// WinRates[i][j] is the share of duels Weapons[i] won against Weapons[j];
+	// with draws, WinRates[i][j] and WinRates[j][i] add up to less than 1
+	WinRates [][]float64 `json:"winRates"`
+	Matchups []Matchup   `json:"matchups"` // Each pair once, mirror matches included
+}
+
+// AllWeapons returns the name of every weapon in effect, sorted
+func AllWeapons() []string {
+	inspections := game.WeaponInspections()
+	names := make([]string, 0, len(inspections))
+	for _, inspection := range inspections {
+		names = append(names, inspection.Name)
+	}
+	return names
+}
+
+// Run plays every matchup of config's weapons, each one on its own goroutine
+func Run(config Config) (Report, error) {
+	if len(config.Weapons) == 0 {
+		config.Weapons = AllWeapons()
+	}
+	if config.Duels <= 0 {
+		config.Duels = DefaultDuels
+	}
+	if config.MaxDuration <= 0 {
+		config.MaxDuration = DefaultMaxDuration
+	}
+
+	weapons := make([]string, 0, len(config.Weapons))
+	for _, name := range config.Weapons {
+		weapon, err := game.CreateWeaponByType(strings.TrimSpace(name))
+		if err != nil {
+			return Report{}, err
+		}
+		weapons = append(weapons, weapon.Name)
+	}
+
+	type pair struct{ i, j int }
+	var pairs []pair
+	for i := range weapons {
+		for j := i; j < len(weapons); j++ {
+			pairs = append(pairs, pair{i, j})
+		}
+	}
+
+	matchups := make([]Matchup, len(pairs))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(runtime.GOMAXPROCS(0), len(pairs)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				p := pairs[index]
+				rng := rand.New(rand.NewSource(config.Seed + int64(index)))
+				matchups[index] = runMatchup(config, weapons[p.i], weapons[p.j], rng)
+			}
+		}()
+	}
+	for index := range pairs {
+		jobs <- index
+	}
+	close(jobs)
+	wg.Wait()
+
+	report := Report{
+		Weapons:  weapons,
+		Duels:    config.Duels,
+		Seed:     config.Seed,
+		WinRates: make([][]float64, len(weapons)),
+		Matchups: matchups,
+	}
+	for i := range report.WinRates {
+		report.WinRates[i] = make([]float64, len(weapons))
+	}
+	for index, p := range pairs {
+		m := matchups[index]
+		report.WinRates[p.i][p.j] = float64(m.Wins) / float64(config.Duels)
+		if p.i != p.j {
+			report.WinRates[p.j][p.i] = float64(m.Losses) / float64(config.Duels)
+		}
+	}
+	return report, nil
+}
+
+// runMatchup plays config.Duels duels of weapon against opponent, swapping
+// which side of the start positions each weapon gets every duel
+func runMatchup(config Config, weapon, opponent string, rng *rand.Rand) Matchup {
+	matchup := Matchup{Weapon: weapon, Opponent: opponent}
+	var winTime, lossTime time.Duration
+	for duel := range config.Duels {
+		sides := [2]string{weapon, opponent}
+		if duel%2 == 1 {
+			sides = [2]string{opponent, weapon}
+		}
+
+		winner, elapsed, err := runDuel(config, sides, rng)
+		switch {
+		case err != nil || winner < 0:
+			matchup.Draws++
+			continue
+		case (winner == 0) == (duel%2 == 0):
+			matchup.Wins++
+			winTime += elapsed
+		default:
+			matchup.Losses++
+			lossTime += elapsed
+		}
+	}
+
+	if decided := matchup.Wins + matchup.Losses; decided > 0 {
+		matchup.MeanTimeToKillMs = ((winTime + lossTime) / time.Duration(decided)).Milliseconds()
+	}
+	if matchup.Wins > 0 {
+		matchup.WinTimeToKillMs = (winTime / time.Duration(matchup.Wins)).Milliseconds()
+	}
+	if matchup.Losses > 0 {
+		matchup.LossTimeToKillMs = (lossTime / time.Duration(matchup.Losses)).Milliseconds()
+	}
+	return matchup
+}
+
+// duelist is one side of a duel
+type duelist struct {
+	id     string
+	weapon *game.Weapon
+	player *game.PlayerState
+}
+
+// runDuel plays one duel and returns the winning side, or -1 for a draw,
+// and how long it took
+func runDuel(config Config, weapons [2]string, rng *rand.Rand) (int, time.Duration, error) {
+	clock := game.NewManualClock(time.Unix(0, 0))
+	gs := game.NewGameServerWithConfig(game.GameServerConfig{
+		Clock:        clock,
+		RandomSource: func(string) game.RandomSource { return rng },
+	})
+	mapConfig := gs.GetWorld().GetMapConfig()
+	removePickups(gs, config.MaxDuration)
+
+	positions, err := startPositions(mapConfig, rng)
+	if err != nil {
+		return -1, 0, err
+	}
+
+	var duelists [2]*duelist
+	for side, name := range weapons {
+		id := fmt.Sprintf("duelist-%d", side)
+		player := gs.AddPlayer(id)
+		weapon, err := game.CreateWeaponByType(name)
+		if err != nil {
+			return -1, 0, err
+		}
+		gs.SetWeaponState(id, game.NewWeaponStateWithClock(weapon, clock))
+		player.SetPosition(positions[side])
+		duelists[side] = &duelist{id: id, weapon: weapon, player: player}
+	}
+
+	tick := time.Duration(game.ServerTickInterval) * time.Millisecond
+	reactAt := clock.Now().Add(time.Duration(config.Difficulty.ReactionTime * float64(time.Second)))
+	for elapsed := time.Duration(0); elapsed < config.MaxDuration; elapsed += tick {
+		for side, d := range duelists {
+			d.act(gs, duelists[1-side].player.GetPosition(), clock.Now(), !clock.Now().Before(reactAt), config.Difficulty, rng)
+		}
+
+		clock.Advance(tick)
+		gs.Step(clock.Now(), tick.Seconds())
+
+		alive := [2]bool{duelists[0].player.IsAlive(), duelists[1].player.IsAlive()}
+		switch {
+		case alive[0] && alive[1]:
+			continue
+		case alive[0]:
+			return 0, elapsed + tick, nil
+		case alive[1]:
+			return 1, elapsed + tick, nil
+		default:
+			return -1, elapsed + tick, nil
+		}
+	}
+	return -1, config.MaxDuration, nil
+}
+
+// act sends the duelist's input for this tick: close in to its weapon's
+// fighting distance, then fire, swing or reload once it has reacted
+func (d *duelist) act(gs *game.GameServer, target game.Vector2, now time.Time, reacted bool, difficulty game.BotDifficulty, rng *rand.Rand) {
+	position := d.player.GetPosition()
+	distance := math.Hypot(target.X-position.X, target.Y-position.Y)
+	aimAngle := math.Atan2(target.Y-position.Y, target.X-position.X)
+
+	input := game.InputState{AimAngle: aimAngle}
+	if distance > fightingDistance(d.weapon) {
+		input = steer(position, target)
+		input.AimAngle = aimAngle
+	}
+	gs.UpdatePlayerInput(d.id, input)
+	if !reacted {
+		return
+	}
+
+	weaponState := gs.GetWeaponState(d.id)
+	switch {
+	case d.weapon.IsMelee():
+		if distance <= d.weapon.Range && weaponState.CanShoot() {
+			gs.PlayerMeleeAttack(d.id, aim(rng, difficulty, aimAngle))
+		}
+	case weaponState.IsEmpty() && !weaponState.Reloading():
+		gs.PlayerReload(d.id)
+	case weaponState.CanShoot():
+		gs.PlayerShoot(d.id, aim(rng, difficulty, aimAngle), now.UnixMilli())
+	}
+}
+
+// fightingDistance is how close a duelist gets before it stops moving:
+// inside a melee weapon's reach, or a bot's engage range capped by the
+// weapon's own range
+func fightingDistance(weapon *game.Weapon) float64 {
+	if weapon.IsMelee() {
+		return weapon.Range * meleeCloseIn
+	}
+	return min(bot.EngageRange, weapon.Range/2)
+}
+
+// aim returns the angle a shot at aimAngle actually goes, missing like a bot
+// of the same difficulty
+func aim(rng *rand.Rand, difficulty game.BotDifficulty, aimAngle float64) float64 {
+	if rng.Float64() < difficulty.Accuracy {
+		return aimAngle
+	}
+	miss := bot.MissMinAngle + rng.Float64()*(bot.MissMaxAngle-bot.MissMinAngle)
+	if rng.Intn(2) == 0 {
+		miss = -miss
+	}
+	return aimAngle + miss
+}
+
+// steer returns the movement keys that head from one point toward another
+func steer(from, to game.Vector2) game.InputState {
+	dx := to.X - from.X
+	dy := to.Y - from.Y
+	return game.InputState{
+		Up:    dy < -bot.MoveDeadZone,
+		Down:  dy > bot.MoveDeadZone,
+		Left:  dx < -bot.MoveDeadZone,
+		Right: dx > bot.MoveDeadZone,
+	}
+}
+
+// removePickups takes every shield crate and health pack and holds off their
+// respawn for the whole duel
+func removePickups(gs *game.GameServer, duration time.Duration) {
+	shields := gs.GetShieldCrateManager()
+	for id := range shields.GetAllCrates() {
+		shields.PickupCrate(id)
+	}
+	shields.DelayRespawns(duration)
+
+	health := gs.GetHealthPackManager()
+	for id := range health.GetAllPacks() {
+		health.PickupPack(id)
+	}
+	health.DelayRespawns(duration)
+}
+
+// startPositions picks two points on the map in line of sight of each other,
+// between MinStartDistance and MaxStartDistance apart, clear of obstacles
+func startPositions(mapConfig game.MapConfig, rng *rand.Rand) ([2]game.Vector2, error) {
+	for range placementTries {
+		distance := MinStartDistance + rng.Float64()*(MaxStartDistance-MinStartDistance)
+		angle := rng.Float64() * 2 * math.Pi
+		first := game.Vector2{
+			X: edgeMargin + rng.Float64()*(mapConfig.Width-2*edgeMargin),
+			Y: edgeMargin + rng.Float64()*(mapConfig.Height-2*edgeMargin),
+		}
+		second := game.Vector2{X: first.X + distance*math.Cos(angle), Y: first.Y + distance*math.Sin(angle)}
+
+		if clearOfObstacles(mapConfig, first) && clearOfObstacles(mapConfig, second) && game.HasLineOfSight(mapConfig, first, second) {
+			return [2]game.Vector2{first, second}, nil
+		}
+	}
+	return [2]game.Vector2{}, errors.New("no open start positions on the map")
+}
+
+// clearOfObstacles reports whether a player standing at point is inside the
+// map edge margin and overlaps no obstacle
+func clearOfObstacles(mapConfig game.MapConfig, point game.Vector2) bool {
+	if point.X < edgeMargin || point.X > mapConfig.Width-edgeMargin || point.Y < edgeMargin || point.Y > mapConfig.Height-edgeMargin {
+		return false
+	}
+	halfWidth := game.PlayerWidth / 2
+	halfHeight := game.PlayerHeight / 2
+	for _, obstacle := range mapConfig.Obstacles {
+		if point.X+halfWidth > obstacle.X && point.X-halfWidth < obstacle.X+obstacle.Width &&
+			point.Y+halfHeight > obstacle.Y && point.Y-halfHeight < obstacle.Y+obstacle.Height {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,55 @@
+package balance
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func normalDifficulty(t *testing.T) game.BotDifficulty {
+	t.Helper()
+	difficulty, ok := game.BotDifficultyByName("normal")
+	require.True(t, ok)
+	return difficulty
+}
+
+func TestRunReportsEveryMatchup(t *testing.T) {
+	report, err := Run(Config{Weapons: []string{"ak47", "Bat"}, Duels: 6, Seed: 7, Difficulty: normalDifficulty(t)})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"AK47", "Bat"}, report.Weapons, "names come out as the weapons' own")
+	assert.Equal(t, 6, report.Duels)
+	require.Len(t, report.Matchups, 3, "two mirror matches and one pairing")
+	for _, matchup := range report.Matchups {
+		assert.Equal(t, 6, matchup.Wins+matchup.Losses+matchup.Draws)
+	}
+
+	pairing := report.Matchups[1]
+	assert.Equal(t, "AK47", pairing.Weapon)
+	assert.Equal(t, "Bat", pairing.Opponent)
+	assert.InDelta(t, float64(pairing.Wins)/6, report.WinRates[0][1], 1e-9)
+	assert.InDelta(t, float64(pairing.Losses)/6, report.WinRates[1][0], 1e-9)
+	assert.Greater(t, pairing.Wins, pairing.Losses, "a rifle outguns a bat from range")
+	assert.Positive(t, pairing.MeanTimeToKillMs)
+	assert.Positive(t, pairing.WinTimeToKillMs)
+}
+
+func TestRunRejectsUnknownWeapons(t *testing.T) {
+	_, err := Run(Config{Weapons: []string{"AK47", "Slingshot"}, Duels: 1, Difficulty: normalDifficulty(t)})
+	assert.Error(t, err)
+}
+
+func TestStartPositionsAreOpenAndInSight(t *testing.T) {
+	mapConfig := game.MustDefaultMapConfig()
+	rng := rand.New(rand.NewSource(1))
+	for range 50 {
+		positions, err := startPositions(mapConfig, rng)
+		require.NoError(t, err)
+		assert.True(t, clearOfObstacles(mapConfig, positions[0]))
+		assert.True(t, clearOfObstacles(mapConfig, positions[1]))
+		assert.True(t, game.HasLineOfSight(mapConfig, positions[0], positions[1]))
+	}
+}
@@ -0,0 +1,84 @@
+// Package buildinfo reports which build of the server is running, so bug
+// reports can be matched to an exact commit.
+//
+// Commit and BuildTime are set at link time:
+//
+//	go build -ldflags "-X github.com/mtomcal/stick-rumble-server/internal/buildinfo.Commit=$(git rev-parse --short HEAD) \
+//	  -X github.com/mtomcal/stick-rumble-server/internal/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// Builds without ldflags fall back to the VCS stamp Go embeds when building a
+// package inside a git checkout, and to "unknown" otherwise.
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Unknown is reported for build details that were not recorded
+const Unknown = "unknown"
+
+// Set with -ldflags -X at build time
+var (
+	Commit    = ""
+	BuildTime = ""
+)
+
+// Info describes the running server build.
+type Info struct {
+	Commit    string `json:"commit"`
+	BuildTime string `json:"buildTime"`
+	GoVersion string `json:"goVersion"`
+}
+
+// Get returns the running build's commit, build time and Go version.
+func Get() Info {
+	info := Info{
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+
+	if info.Commit == "" || info.BuildTime == "" {
+		revision, vcsTime := vcsStamp()
+		if info.Commit == "" {
+			info.Commit = revision
+		}
+		if info.BuildTime == "" {
+			info.BuildTime = vcsTime
+		}
+	}
+
+	if info.Commit == "" {
+		info.Commit = Unknown
+	}
+	if info.BuildTime == "" {
+		info.BuildTime = Unknown
+	}
+	return info
+}
+
+// vcsStamp returns the revision and commit time Go embedded in the binary.
+// A revision built from a modified tree gets a "-dirty" suffix.
+func vcsStamp() (revision, vcsTime string) {
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "", ""
+	}
+
+	modified := false
+	for _, setting := range build.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.time":
+			vcsTime = setting.Value
+		case "vcs.modified":
+			modified = setting.Value == "true"
+		}
+	}
+	if revision != "" && modified {
+		revision += "-dirty"
+	}
+	return revision, vcsTime
+}
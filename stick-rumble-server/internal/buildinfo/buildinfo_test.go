@@ -0,0 +1,35 @@
+package buildinfo
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUsesLinkerValues(t *testing.T) {
+	commit, buildTime := Commit, BuildTime
+	defer func() { Commit, BuildTime = commit, buildTime }()
+
+	Commit = "abc1234"
+	BuildTime = "2026-10-16T12:00:00Z"
+
+	assert.Equal(t, Info{
+		Commit:    "abc1234",
+		BuildTime: "2026-10-16T12:00:00Z",
+		GoVersion: runtime.Version(),
+	}, Get())
+}
+
+func TestGetNeverReportsEmptyFields(t *testing.T) {
+	commit, buildTime := Commit, BuildTime
+	defer func() { Commit, BuildTime = commit, buildTime }()
+
+	Commit = ""
+	BuildTime = ""
+
+	info := Get()
+	assert.NotEmpty(t, info.Commit)
+	assert.NotEmpty(t, info.BuildTime)
+	assert.Equal(t, runtime.Version(), info.GoVersion)
+}
@@ -0,0 +1,183 @@
+// Package cluster coordinates several game server instances through Redis.
+// Every instance registers itself with its load and the named-room codes it
+// hosts, so the others can send waiting players to the least-loaded instance
+// and named-room players to the instance hosting their room.
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Redis keys. Node entries and room claims expire unless refreshed, so an
+// instance that dies drops out of the cluster on its own.
+const (
+	keyPrefix   = "stickrumble:"
+	nodesKey    = keyPrefix + "nodes"      // Set of node IDs
+	nodeKeyBase = keyPrefix + "node:"      // + node ID -> Node JSON
+	roomKeyBase = keyPrefix + "room_code:" // + room code -> node ID
+)
+
+// Node is one server instance as other instances see it
+type Node struct {
+	ID      string `json:"id"`
+	Address string `json:"address"` // WebSocket URL players are redirected to
+	Players int    `json:"players"`
+	Rooms   int    `json:"rooms"`
+}
+
+// Registry is the cluster's view of its nodes and room codes, kept in Redis
+type Registry struct {
+	client *RedisClient
+	ttl    time.Duration
+}
+
+// NewRegistry returns a registry whose entries live for ttl after each
+// refresh. ttl should span a few refresh intervals.
+func NewRegistry(client *RedisClient, ttl time.Duration) *Registry {
+	return &Registry{client: client, ttl: ttl}
+}
+
+func (r *Registry) ttlMillis() string {
+	return strconv.FormatInt(r.ttl.Milliseconds(), 10)
+}
+
+// Register publishes node and keeps its claim on each of codes, the named
+// rooms it hosts. Codes held by another node are left alone.
+func (r *Registry) Register(ctx context.Context, node Node, codes []string) error {
+	encoded, err := json.Marshal(node)
+	if err != nil {
+		return err
+	}
+	if _, err := r.client.Do(ctx, "SET", nodeKeyBase+node.ID, string(encoded), "PX", r.ttlMillis()); err != nil {
+		return fmt.Errorf("register node: %w", err)
+	}
+	if _, err := r.client.Do(ctx, "SADD", nodesKey, node.ID); err != nil {
+		return fmt.Errorf("register node: %w", err)
+	}
+
+	for _, code := range codes {
+		holder, err := r.ClaimRoom(ctx, code, node.ID)
+		if err != nil {
+			return err
+		}
+		if holder != node.ID {
+			continue
+		}
+		if _, err := r.client.Do(ctx, "PEXPIRE", roomKeyBase+code, r.ttlMillis()); err != nil {
+			return fmt.Errorf("refresh room code %s: %w", code, err)
+		}
+	}
+	return nil
+}
+
+// Nodes returns every live node ordered by ID. Nodes whose entry expired are
+// dropped from the set.
+func (r *Registry) Nodes(ctx context.Context) ([]Node, error) {
+	reply, err := r.client.Do(ctx, "SMEMBERS", nodesKey)
+	if err != nil {
+		return nil, fmt.Errorf("list nodes: %w", err)
+	}
+	ids := stringItems(reply)
+	sort.Strings(ids)
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(ids)+1)
+	keys = append(keys, "MGET")
+	for _, id := range ids {
+		keys = append(keys, nodeKeyBase+id)
+	}
+	reply, err = r.client.Do(ctx, keys...)
+	if err != nil {
+		return nil, fmt.Errorf("list nodes: %w", err)
+	}
+	entries, _ := reply.([]any)
+
+	nodes := make([]Node, 0, len(ids))
+	for i, id := range ids {
+		var entry string
+		if i < len(entries) {
+			entry, _ = entries[i].(string)
+		}
+		if entry == "" {
+			if _, err := r.client.Do(ctx, "SREM", nodesKey, id); err != nil {
+				return nil, fmt.Errorf("drop expired node %s: %w", id, err)
+			}
+			continue
+		}
+
+		var node Node
+		if err := json.Unmarshal([]byte(entry), &node); err != nil {
+			return nil, fmt.Errorf("decode node %s: %w", id, err)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// ClaimRoom claims a room code for nodeID unless a node already holds it,
+// and returns the holder
+func (r *Registry) ClaimRoom(ctx context.Context, code, nodeID string) (string, error) {
+	key := roomKeyBase + code
+	// A claim expiring between SET and GET leaves nothing to read; try again
+	for range 2 {
+		reply, err := r.client.Do(ctx, "SET", key, nodeID, "PX", r.ttlMillis(), "NX")
+		if err != nil {
+			return "", fmt.Errorf("claim room code %s: %w", code, err)
+		}
+		if reply != nil {
+			return nodeID, nil
+		}
+
+		reply, err = r.client.Do(ctx, "GET", key)
+		if err != nil {
+			return "", fmt.Errorf("claim room code %s: %w", code, err)
+		}
+		if holder, ok := reply.(string); ok {
+			return holder, nil
+		}
+	}
+	return "", fmt.Errorf("claim room code %s: claim keeps expiring", code)
+}
+
+// Deregister removes the node and releases the codes it holds among codes
+func (r *Registry) Deregister(ctx context.Context, nodeID string, codes []string) error {
+	for _, code := range codes {
+		key := roomKeyBase + code
+		reply, err := r.client.Do(ctx, "GET", key)
+		if err != nil {
+			return fmt.Errorf("release room code %s: %w", code, err)
+		}
+		if holder, _ := reply.(string); holder != nodeID {
+			continue
+		}
+		if _, err := r.client.Do(ctx, "DEL", key); err != nil {
+			return fmt.Errorf("release room code %s: %w", code, err)
+		}
+	}
+
+	if _, err := r.client.Do(ctx, "DEL", nodeKeyBase+nodeID); err != nil {
+		return fmt.Errorf("deregister node: %w", err)
+	}
+	if _, err := r.client.Do(ctx, "SREM", nodesKey, nodeID); err != nil {
+		return fmt.Errorf("deregister node: %w", err)
+	}
+	return nil
+}
+
+func stringItems(reply any) []string {
+	items, _ := reply.([]any)
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		if value, ok := item.(string); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,275 @@
+package cluster
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRedis serves the commands the registry uses from memory. Keys expire
+// against a clock the test moves with advance.
+type fakeRedis struct {
+	listener net.Listener
+	password string
+
+	mu      sync.Mutex
+	now     time.Time
+	strings map[string]string
+	expires map[string]time.Time
+	sets    map[string]map[string]bool
+}
+
+func newFakeRedis(t *testing.T, password string) *fakeRedis {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	f := &fakeRedis{
+		listener: listener,
+		password: password,
+		now:      time.Unix(0, 0),
+		strings:  make(map[string]string),
+		expires:  make(map[string]time.Time),
+		sets:     make(map[string]map[string]bool),
+	}
+	t.Cleanup(func() { listener.Close() })
+	go f.serve()
+	return f
+}
+
+func (f *fakeRedis) addr() string {
+	return f.listener.Addr().String()
+}
+
+func (f *fakeRedis) advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+func (f *fakeRedis) serve() {
+	for {
+		conn, err := f.listener.Accept()
+		if err != nil {
+			return
+		}
+		go f.serveConn(conn)
+	}
+}
+
+func (f *fakeRedis) serveConn(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	authed := f.password == ""
+	for {
+		reply, err := readReply(reader)
+		if err != nil {
+			return
+		}
+		items, _ := reply.([]any)
+		args := stringItems(items)
+
+		var out string
+		switch {
+		case len(args) == 2 && strings.EqualFold(args[0], "AUTH"):
+			authed = args[1] == f.password
+			out = "+OK\r\n"
+			if !authed {
+				out = "-WRONGPASS invalid password\r\n"
+			}
+		case !authed:
+			out = "-NOAUTH Authentication required.\r\n"
+		default:
+			out = f.run(args)
+		}
+		if _, err := conn.Write([]byte(out)); err != nil {
+			return
+		}
+	}
+}
+
+func (f *fakeRedis) getLocked(key string) (string, bool) {
+	if expiry, ok := f.expires[key]; ok && !f.now.Before(expiry) {
+		delete(f.strings, key)
+		delete(f.expires, key)
+	}
+	value, ok := f.strings[key]
+	return value, ok
+}
+
+func (f *fakeRedis) run(args []string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch strings.ToUpper(args[0]) {
+	case "SET":
+		key, value := args[1], args[2]
+		var ttl time.Duration
+		nx := false
+		for i := 3; i < len(args); i++ {
+			switch strings.ToUpper(args[i]) {
+			case "PX":
+				ms, _ := strconv.Atoi(args[i+1])
+				ttl = time.Duration(ms) * time.Millisecond
+				i++
+			case "NX":
+				nx = true
+			}
+		}
+		if _, exists := f.getLocked(key); exists && nx {
+			return "$-1\r\n"
+		}
+		f.strings[key] = value
+		delete(f.expires, key)
+		if ttl > 0 {
+			f.expires[key] = f.now.Add(ttl)
+		}
+		return "+OK\r\n"
+	case "GET":
+		value, ok := f.getLocked(args[1])
+		if !ok {
+			return "$-1\r\n"
+		}
+		return bulk(value)
+	case "MGET":
+		out := "*" + strconv.Itoa(len(args)-1) + "\r\n"
+		for _, key := range args[1:] {
+			if value, ok := f.getLocked(key); ok {
+				out += bulk(value)
+			} else {
+				out += "$-1\r\n"
+			}
+		}
+		return out
+	case "PEXPIRE":
+		if _, ok := f.getLocked(args[1]); !ok {
+			return ":0\r\n"
+		}
+		ms, _ := strconv.Atoi(args[2])
+		f.expires[args[1]] = f.now.Add(time.Duration(ms) * time.Millisecond)
+		return ":1\r\n"
+	case "DEL":
+		_, ok := f.getLocked(args[1])
+		delete(f.strings, args[1])
+		delete(f.expires, args[1])
+		if ok {
+			return ":1\r\n"
+		}
+		return ":0\r\n"
+	case "SADD":
+		if f.sets[args[1]] == nil {
+			f.sets[args[1]] = make(map[string]bool)
+		}
+		f.sets[args[1]][args[2]] = true
+		return ":1\r\n"
+	case "SREM":
+		delete(f.sets[args[1]], args[2])
+		return ":1\r\n"
+	case "SMEMBERS":
+		out := "*" + strconv.Itoa(len(f.sets[args[1]])) + "\r\n"
+		for member := range f.sets[args[1]] {
+			out += bulk(member)
+		}
+		return out
+	}
+	return "-ERR unknown command '" + args[0] + "'\r\n"
+}
+
+func bulk(value string) string {
+	return "$" + strconv.Itoa(len(value)) + "\r\n" + value + "\r\n"
+}
+
+func TestRegistryTracksLiveNodes(t *testing.T) {
+	redis := newFakeRedis(t, "")
+	registry := NewRegistry(NewRedisClient(redis.addr(), ""), 6*time.Second)
+	ctx := context.Background()
+
+	a := Node{ID: "node-a", Address: "wss://a.example.com/ws", Players: 4, Rooms: 1}
+	b := Node{ID: "node-b", Address: "wss://b.example.com/ws", Players: 1, Rooms: 1}
+	require.NoError(t, registry.Register(ctx, a, nil))
+	require.NoError(t, registry.Register(ctx, b, nil))
+
+	nodes, err := registry.Nodes(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []Node{a, b}, nodes)
+
+	redis.advance(4 * time.Second)
+	a.Players = 5
+	require.NoError(t, registry.Register(ctx, a, nil))
+	redis.advance(4 * time.Second)
+
+	nodes, err = registry.Nodes(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []Node{a}, nodes, "a node that stops refreshing drops out")
+
+	require.NoError(t, registry.Deregister(ctx, a.ID, nil))
+	nodes, err = registry.Nodes(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, nodes)
+}
+
+func TestRegistryRoomCodeClaims(t *testing.T) {
+	redis := newFakeRedis(t, "")
+	registry := NewRegistry(NewRedisClient(redis.addr(), ""), 6*time.Second)
+	ctx := context.Background()
+
+	holder, err := registry.ClaimRoom(ctx, "FRIENDS", "node-a")
+	require.NoError(t, err)
+	assert.Equal(t, "node-a", holder)
+
+	holder, err = registry.ClaimRoom(ctx, "FRIENDS", "node-b")
+	require.NoError(t, err)
+	assert.Equal(t, "node-a", holder, "the first claim wins")
+
+	// Registering keeps the claim alive past its original expiry
+	redis.advance(4 * time.Second)
+	require.NoError(t, registry.Register(ctx, Node{ID: "node-a"}, []string{"FRIENDS"}))
+	redis.advance(4 * time.Second)
+	holder, err = registry.ClaimRoom(ctx, "FRIENDS", "node-b")
+	require.NoError(t, err)
+	assert.Equal(t, "node-a", holder)
+
+	require.NoError(t, registry.Deregister(ctx, "node-b", []string{"FRIENDS"}))
+	holder, err = registry.ClaimRoom(ctx, "FRIENDS", "node-b")
+	require.NoError(t, err)
+	assert.Equal(t, "node-a", holder, "only the holder releases a code")
+
+	require.NoError(t, registry.Deregister(ctx, "node-a", []string{"FRIENDS"}))
+	holder, err = registry.ClaimRoom(ctx, "FRIENDS", "node-b")
+	require.NoError(t, err)
+	assert.Equal(t, "node-b", holder)
+}
+
+func TestRedisClientAuthAndErrors(t *testing.T) {
+	redis := newFakeRedis(t, "secret")
+	ctx := context.Background()
+
+	_, err := NewRedisClient(redis.addr(), "wrong").Do(ctx, "GET", "key")
+	require.Error(t, err)
+
+	client := NewRedisClient(redis.addr(), "secret")
+	reply, err := client.Do(ctx, "SET", "key", "value")
+	require.NoError(t, err)
+	assert.Equal(t, "OK", reply)
+
+	_, err = client.Do(ctx, "NOPE")
+	var redisErr RedisError
+	require.ErrorAs(t, err, &redisErr)
+
+	reply, err = client.Do(ctx, "GET", "key")
+	require.NoError(t, err, "an error reply keeps the connection")
+	assert.Equal(t, "value", reply)
+
+	require.NoError(t, client.Close())
+	reply, err = client.Do(ctx, "GET", "missing")
+	require.NoError(t, err, "a closed client reconnects")
+	assert.Nil(t, reply)
+}
@@ -2,12 +2,32 @@ package config
 
 import (
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
 const (
-	DefaultHost = "127.0.0.1"
-	DefaultPort = "8080"
+	DefaultHost        = "127.0.0.1"
+	DefaultPort        = "8080"
+	DefaultResumeGrace = 30 * time.Second
+
+	// DefaultShutdownDrain is how long running matches may go on after a
+	// shutdown signal before they are ended
+	DefaultShutdownDrain = 30 * time.Second
+
+	DefaultNameChangeCooldown = 10 * time.Minute
+	DefaultFeedbackCooldown   = time.Minute
+
+	DefaultBotDifficulty = "normal"
+
+	// DefaultWeaponReportInterval is how often the weapon report is
+	// recomputed from match timelines
+	DefaultWeaponReportInterval = 10 * time.Minute
+
+	// DefaultLeaderboardInterval is how often the leaderboard is recomputed
+	// from the match records, besides after every match
+	DefaultLeaderboardInterval = 5 * time.Minute
 )
 
 type RuntimeConfig struct {
@@ -16,6 +36,49 @@ type RuntimeConfig struct {
 	EnableSchemaValidation bool
 	GoEnv                  string
 	AllowedOrigins         []string
+	MinHumanPlayers        int
+	BotFillAfter           time.Duration
+	BotFillTarget          int
+	Teams                  bool
+	BotDifficulty          string
+	MaxRooms               int
+	MaxPlayers             int
+	RedirectURL            string
+	RoomSeed               int64
+	RecordingDir           string
+	ModeScriptsDir         string
+	ResumeGrace            time.Duration
+	AuthTokenSecret        string
+	MovementKickAfter      int
+	DodgeRollIFrames       time.Duration
+	DodgeRollStaminaCost   int
+	AdminToken             string
+	WeaponConfigPath       string
+	NameChangeCooldown     time.Duration
+	FeedbackDir            string
+	FeedbackCooldown       time.Duration
+	MatchRecordDir         string
+	BanDir                 string
+	ReplayDir              string
+	TimelineDir            string
+	ShutdownDrain          time.Duration
+	RedisAddr              string
+	RedisPassword          string
+	ClusterNodeID          string
+	ClusterAdvertiseURL    string
+	WeaponReportInterval   time.Duration
+	WeaponReportSimDuels   int
+	LeaderboardInterval    time.Duration
+	LevelFirstXP           int
+	LevelGrowthPercent     int
+	LevelMax               int
+	LevelUnlocks           map[int][]string
+	VoteKickPercent        int
+	VoteKickWindow         time.Duration
+	VoteKickBlock          time.Duration
+	WebTransportAddr       string
+	TLSCertFile            string
+	TLSKeyFile             string
 }
 
 func Load() RuntimeConfig {
@@ -35,6 +98,49 @@ func Load() RuntimeConfig {
 		EnableSchemaValidation: strings.EqualFold(strings.TrimSpace(os.Getenv("ENABLE_SCHEMA_VALIDATION")), "true"),
 		GoEnv:                  defaultString(strings.TrimSpace(os.Getenv("GO_ENV")), "development"),
 		AllowedOrigins:         splitCSV(os.Getenv("ALLOWED_ORIGINS")),
+		MinHumanPlayers:        nonNegativeInt(os.Getenv("MIN_HUMAN_PLAYERS")),
+		BotFillAfter:           time.Duration(nonNegativeInt(os.Getenv("BOT_FILL_AFTER_SECONDS"))) * time.Second,
+		BotFillTarget:          nonNegativeInt(os.Getenv("BOT_FILL_TARGET")),
+		Teams:                  strings.EqualFold(strings.TrimSpace(os.Getenv("TEAMS")), "true"),
+		BotDifficulty:          defaultString(strings.ToLower(strings.TrimSpace(os.Getenv("BOT_DIFFICULTY"))), DefaultBotDifficulty),
+		MaxRooms:               nonNegativeInt(os.Getenv("MAX_ROOMS")),
+		MaxPlayers:             nonNegativeInt(os.Getenv("MAX_PLAYERS")),
+		RedirectURL:            strings.TrimSpace(os.Getenv("CAPACITY_REDIRECT_URL")),
+		RoomSeed:               int64(nonNegativeInt(os.Getenv("ROOM_SEED"))),
+		RecordingDir:           defaultString(strings.TrimSpace(os.Getenv("RECORDING_DIR")), "recordings"),
+		ModeScriptsDir:         strings.TrimSpace(os.Getenv("MODE_SCRIPTS_DIR")),
+		ResumeGrace:            optionalSeconds(os.Getenv("RESUME_GRACE_SECONDS"), DefaultResumeGrace),
+		AuthTokenSecret:        strings.TrimSpace(os.Getenv("AUTH_TOKEN_SECRET")),
+		MovementKickAfter:      nonNegativeInt(os.Getenv("MOVEMENT_KICK_AFTER")),
+		DodgeRollIFrames:       time.Duration(nonNegativeInt(os.Getenv("DODGE_ROLL_IFRAMES_MS"))) * time.Millisecond,
+		DodgeRollStaminaCost:   nonNegativeInt(os.Getenv("DODGE_ROLL_STAMINA_COST")),
+		AdminToken:             strings.TrimSpace(os.Getenv("ADMIN_TOKEN")),
+		WeaponConfigPath:       strings.TrimSpace(os.Getenv("WEAPON_CONFIG")),
+		NameChangeCooldown:     optionalSeconds(os.Getenv("NAME_CHANGE_COOLDOWN_SECONDS"), DefaultNameChangeCooldown),
+		FeedbackDir:            defaultString(strings.TrimSpace(os.Getenv("FEEDBACK_DIR")), "feedback"),
+		FeedbackCooldown:       optionalSeconds(os.Getenv("FEEDBACK_COOLDOWN_SECONDS"), DefaultFeedbackCooldown),
+		MatchRecordDir:         strings.TrimSpace(os.Getenv("MATCH_RECORD_DIR")),
+		BanDir:                 strings.TrimSpace(os.Getenv("BAN_DIR")),
+		ReplayDir:              strings.TrimSpace(os.Getenv("REPLAY_DIR")),
+		TimelineDir:            strings.TrimSpace(os.Getenv("TIMELINE_DIR")),
+		ShutdownDrain:          optionalSeconds(os.Getenv("SHUTDOWN_DRAIN_SECONDS"), DefaultShutdownDrain),
+		RedisAddr:              strings.TrimSpace(os.Getenv("REDIS_ADDR")),
+		RedisPassword:          os.Getenv("REDIS_PASSWORD"),
+		ClusterNodeID:          strings.TrimSpace(os.Getenv("CLUSTER_NODE_ID")),
+		ClusterAdvertiseURL:    strings.TrimSpace(os.Getenv("CLUSTER_ADVERTISE_URL")),
+		WeaponReportInterval:   optionalSeconds(os.Getenv("WEAPON_REPORT_INTERVAL_SECONDS"), DefaultWeaponReportInterval),
+		WeaponReportSimDuels:   nonNegativeInt(os.Getenv("WEAPON_REPORT_SIM_DUELS")),
+		LeaderboardInterval:    optionalSeconds(os.Getenv("LEADERBOARD_INTERVAL_SECONDS"), DefaultLeaderboardInterval),
+		LevelFirstXP:           nonNegativeInt(os.Getenv("LEVEL_FIRST_XP")),
+		LevelGrowthPercent:     nonNegativeInt(os.Getenv("LEVEL_XP_GROWTH_PERCENT")),
+		LevelMax:               nonNegativeInt(os.Getenv("LEVEL_MAX")),
+		LevelUnlocks:           levelUnlocks(os.Getenv("LEVEL_UNLOCKS")),
+		VoteKickPercent:        nonNegativeInt(os.Getenv("VOTE_KICK_PERCENT")),
+		VoteKickWindow:         time.Duration(nonNegativeInt(os.Getenv("VOTE_KICK_WINDOW_SECONDS"))) * time.Second,
+		VoteKickBlock:          time.Duration(nonNegativeInt(os.Getenv("VOTE_KICK_BLOCK_SECONDS"))) * time.Second,
+		WebTransportAddr:       strings.TrimSpace(os.Getenv("WEBTRANSPORT_ADDR")),
+		TLSCertFile:            strings.TrimSpace(os.Getenv("TLS_CERT_FILE")),
+		TLSKeyFile:             strings.TrimSpace(os.Getenv("TLS_KEY_FILE")),
 	}
 }
 
@@ -64,6 +170,47 @@ func defaultString(value, fallback string) string {
 	return value
 }
 
+// nonNegativeInt parses an optional integer setting; blank or invalid values
+// are treated as unset (zero) so the game package applies its defaults.
+func nonNegativeInt(raw string) int {
+	value, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || value < 0 {
+		return 0
+	}
+
+	return value
+}
+
+// optionalSeconds parses a duration in whole seconds; blank or invalid values
+// use fallback, so an explicit 0 is the only way to switch the setting off.
+func optionalSeconds(raw string, fallback time.Duration) time.Duration {
+	value, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || value < 0 {
+		return fallback
+	}
+
+	return time.Duration(value) * time.Second
+}
+
+// levelUnlocks parses comma-separated level:identifier pairs, such as
+// "2:title.rookie,5:emote.salute". Malformed pairs are skipped.
+func levelUnlocks(raw string) map[int][]string {
+	var unlocks map[int][]string
+	for _, pair := range splitCSV(raw) {
+		levelText, id, found := strings.Cut(pair, ":")
+		level, err := strconv.Atoi(strings.TrimSpace(levelText))
+		id = strings.TrimSpace(id)
+		if !found || err != nil || level < 2 || id == "" {
+			continue
+		}
+		if unlocks == nil {
+			unlocks = make(map[int][]string)
+		}
+		unlocks[level] = append(unlocks[level], id)
+	}
+	return unlocks
+}
+
 func splitCSV(raw string) []string {
 	if strings.TrimSpace(raw) == "" {
 		return nil
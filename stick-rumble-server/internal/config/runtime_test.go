@@ -2,6 +2,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -12,6 +13,47 @@ func TestLoadDefaults(t *testing.T) {
 	t.Setenv("ENABLE_SCHEMA_VALIDATION", "")
 	t.Setenv("GO_ENV", "")
 	t.Setenv("ALLOWED_ORIGINS", "")
+	t.Setenv("MIN_HUMAN_PLAYERS", "")
+	t.Setenv("BOT_FILL_AFTER_SECONDS", "")
+	t.Setenv("BOT_FILL_TARGET", "")
+	t.Setenv("TEAMS", "")
+	t.Setenv("BOT_DIFFICULTY", "")
+	t.Setenv("MAX_ROOMS", "")
+	t.Setenv("MAX_PLAYERS", "")
+	t.Setenv("CAPACITY_REDIRECT_URL", "")
+	t.Setenv("ROOM_SEED", "")
+	t.Setenv("RECORDING_DIR", "")
+	t.Setenv("MODE_SCRIPTS_DIR", "")
+	t.Setenv("RESUME_GRACE_SECONDS", "")
+	t.Setenv("AUTH_TOKEN_SECRET", "")
+	t.Setenv("MOVEMENT_KICK_AFTER", "")
+	t.Setenv("DODGE_ROLL_IFRAMES_MS", "")
+	t.Setenv("DODGE_ROLL_STAMINA_COST", "")
+	t.Setenv("ADMIN_TOKEN", "")
+	t.Setenv("WEAPON_CONFIG", "")
+	t.Setenv("NAME_CHANGE_COOLDOWN_SECONDS", "")
+	t.Setenv("MATCH_RECORD_DIR", "")
+	t.Setenv("BAN_DIR", "")
+	t.Setenv("REPLAY_DIR", "")
+	t.Setenv("TIMELINE_DIR", "")
+	t.Setenv("SHUTDOWN_DRAIN_SECONDS", "")
+	t.Setenv("REDIS_ADDR", "")
+	t.Setenv("REDIS_PASSWORD", "")
+	t.Setenv("CLUSTER_NODE_ID", "")
+	t.Setenv("CLUSTER_ADVERTISE_URL", "")
+	t.Setenv("WEAPON_REPORT_INTERVAL_SECONDS", "")
+	t.Setenv("WEAPON_REPORT_SIM_DUELS", "")
+	t.Setenv("LEADERBOARD_INTERVAL_SECONDS", "")
+	t.Setenv("LEVEL_FIRST_XP", "")
+	t.Setenv("LEVEL_XP_GROWTH_PERCENT", "")
+	t.Setenv("LEVEL_MAX", "")
+	t.Setenv("LEVEL_UNLOCKS", "")
+	t.Setenv("VOTE_KICK_PERCENT", "")
+	t.Setenv("VOTE_KICK_WINDOW_SECONDS", "")
+	t.Setenv("VOTE_KICK_BLOCK_SECONDS", "")
+	t.Setenv("WEBTRANSPORT_ADDR", "")
+	t.Setenv("TLS_CERT_FILE", "")
+	t.Setenv("TLS_KEY_FILE", "")
 
 	cfg := Load()
 
@@ -20,6 +62,49 @@ func TestLoadDefaults(t *testing.T) {
 	assert.False(t, cfg.EnableSchemaValidation)
 	assert.Equal(t, "development", cfg.GoEnv)
 	assert.Nil(t, cfg.AllowedOrigins)
+	assert.Zero(t, cfg.MinHumanPlayers)
+	assert.Zero(t, cfg.BotFillAfter)
+	assert.Zero(t, cfg.BotFillTarget)
+	assert.False(t, cfg.Teams)
+	assert.Equal(t, DefaultBotDifficulty, cfg.BotDifficulty)
+	assert.Zero(t, cfg.MaxRooms)
+	assert.Zero(t, cfg.MaxPlayers)
+	assert.Empty(t, cfg.RedirectURL)
+	assert.Zero(t, cfg.RoomSeed)
+	assert.Equal(t, "recordings", cfg.RecordingDir)
+	assert.Empty(t, cfg.ModeScriptsDir)
+	assert.Equal(t, DefaultResumeGrace, cfg.ResumeGrace)
+	assert.Empty(t, cfg.AuthTokenSecret)
+	assert.Zero(t, cfg.MovementKickAfter)
+	assert.Zero(t, cfg.DodgeRollIFrames)
+	assert.Zero(t, cfg.DodgeRollStaminaCost)
+	assert.Empty(t, cfg.AdminToken)
+	assert.Empty(t, cfg.WeaponConfigPath)
+	assert.Equal(t, DefaultNameChangeCooldown, cfg.NameChangeCooldown)
+	assert.Equal(t, "feedback", cfg.FeedbackDir)
+	assert.Equal(t, DefaultFeedbackCooldown, cfg.FeedbackCooldown)
+	assert.Empty(t, cfg.MatchRecordDir)
+	assert.Empty(t, cfg.BanDir)
+	assert.Empty(t, cfg.ReplayDir)
+	assert.Empty(t, cfg.TimelineDir)
+	assert.Equal(t, DefaultShutdownDrain, cfg.ShutdownDrain)
+	assert.Empty(t, cfg.RedisAddr)
+	assert.Empty(t, cfg.RedisPassword)
+	assert.Empty(t, cfg.ClusterNodeID)
+	assert.Empty(t, cfg.ClusterAdvertiseURL)
+	assert.Equal(t, DefaultWeaponReportInterval, cfg.WeaponReportInterval)
+	assert.Zero(t, cfg.WeaponReportSimDuels)
+	assert.Equal(t, DefaultLeaderboardInterval, cfg.LeaderboardInterval)
+	assert.Zero(t, cfg.LevelFirstXP)
+	assert.Zero(t, cfg.LevelGrowthPercent)
+	assert.Zero(t, cfg.LevelMax)
+	assert.Nil(t, cfg.LevelUnlocks)
+	assert.Zero(t, cfg.VoteKickPercent)
+	assert.Zero(t, cfg.VoteKickWindow)
+	assert.Zero(t, cfg.VoteKickBlock)
+	assert.Empty(t, cfg.WebTransportAddr)
+	assert.Empty(t, cfg.TLSCertFile)
+	assert.Empty(t, cfg.TLSKeyFile)
 }
 
 func TestLoadConfiguredValues(t *testing.T) {
@@ -28,6 +113,49 @@ func TestLoadConfiguredValues(t *testing.T) {
 	t.Setenv("ENABLE_SCHEMA_VALIDATION", "true")
 	t.Setenv("GO_ENV", "production")
 	t.Setenv("ALLOWED_ORIGINS", "https://stickrumble.example, https://cdn.example")
+	t.Setenv("MIN_HUMAN_PLAYERS", "4")
+	t.Setenv("BOT_FILL_AFTER_SECONDS", "45")
+	t.Setenv("BOT_FILL_TARGET", "6")
+	t.Setenv("TEAMS", "true")
+	t.Setenv("BOT_DIFFICULTY", " Hard ")
+	t.Setenv("MAX_ROOMS", "50")
+	t.Setenv("MAX_PLAYERS", "400")
+	t.Setenv("CAPACITY_REDIRECT_URL", " wss://eu-2.stickrumble.example/ws ")
+	t.Setenv("ROOM_SEED", "424242")
+	t.Setenv("RECORDING_DIR", "/var/lib/stick-rumble/recordings")
+	t.Setenv("MODE_SCRIPTS_DIR", " ./modes ")
+	t.Setenv("RESUME_GRACE_SECONDS", "0")
+	t.Setenv("AUTH_TOKEN_SECRET", " s3cret ")
+	t.Setenv("MOVEMENT_KICK_AFTER", "5")
+	t.Setenv("DODGE_ROLL_IFRAMES_MS", "250")
+	t.Setenv("DODGE_ROLL_STAMINA_COST", "50")
+	t.Setenv("ADMIN_TOKEN", " ops-token ")
+	t.Setenv("WEAPON_CONFIG", " /etc/stick-rumble/weapons.json ")
+	t.Setenv("NAME_CHANGE_COOLDOWN_SECONDS", "3600")
+	t.Setenv("FEEDBACK_DIR", " /var/lib/stick-rumble/feedback ")
+	t.Setenv("FEEDBACK_COOLDOWN_SECONDS", "300")
+	t.Setenv("MATCH_RECORD_DIR", " /var/lib/stick-rumble/matches ")
+	t.Setenv("BAN_DIR", " /var/lib/stick-rumble/bans ")
+	t.Setenv("REPLAY_DIR", " /var/lib/stick-rumble/replays ")
+	t.Setenv("TIMELINE_DIR", " /var/lib/stick-rumble/timelines ")
+	t.Setenv("SHUTDOWN_DRAIN_SECONDS", "120")
+	t.Setenv("REDIS_ADDR", " redis.internal:6379 ")
+	t.Setenv("REDIS_PASSWORD", "s3cret")
+	t.Setenv("CLUSTER_NODE_ID", " game-2 ")
+	t.Setenv("CLUSTER_ADVERTISE_URL", " wss://game-2.example.com/ws ")
+	t.Setenv("WEAPON_REPORT_INTERVAL_SECONDS", "3600")
+	t.Setenv("WEAPON_REPORT_SIM_DUELS", "25")
+	t.Setenv("LEADERBOARD_INTERVAL_SECONDS", "0")
+	t.Setenv("LEVEL_FIRST_XP", "800")
+	t.Setenv("LEVEL_XP_GROWTH_PERCENT", "150")
+	t.Setenv("LEVEL_MAX", "30")
+	t.Setenv("LEVEL_UNLOCKS", "2:title.rookie, 5:emote.salute,5:skin.red,bad,1:too.low,7:")
+	t.Setenv("VOTE_KICK_PERCENT", "75")
+	t.Setenv("VOTE_KICK_WINDOW_SECONDS", "45")
+	t.Setenv("VOTE_KICK_BLOCK_SECONDS", "600")
+	t.Setenv("WEBTRANSPORT_ADDR", " :4433 ")
+	t.Setenv("TLS_CERT_FILE", "/etc/stick-rumble/cert.pem")
+	t.Setenv("TLS_KEY_FILE", "/etc/stick-rumble/key.pem")
 
 	cfg := Load()
 
@@ -36,6 +164,61 @@ func TestLoadConfiguredValues(t *testing.T) {
 	assert.True(t, cfg.EnableSchemaValidation)
 	assert.Equal(t, "production", cfg.GoEnv)
 	assert.Equal(t, []string{"https://stickrumble.example", "https://cdn.example"}, cfg.AllowedOrigins)
+	assert.Equal(t, 4, cfg.MinHumanPlayers)
+	assert.Equal(t, 45*time.Second, cfg.BotFillAfter)
+	assert.Equal(t, 6, cfg.BotFillTarget)
+	assert.True(t, cfg.Teams)
+	assert.Equal(t, "hard", cfg.BotDifficulty)
+	assert.Equal(t, 50, cfg.MaxRooms)
+	assert.Equal(t, 400, cfg.MaxPlayers)
+	assert.Equal(t, "wss://eu-2.stickrumble.example/ws", cfg.RedirectURL)
+	assert.Equal(t, int64(424242), cfg.RoomSeed)
+	assert.Equal(t, "/var/lib/stick-rumble/recordings", cfg.RecordingDir)
+	assert.Equal(t, "./modes", cfg.ModeScriptsDir)
+	assert.Zero(t, cfg.ResumeGrace, "0 disables resume")
+	assert.Equal(t, "s3cret", cfg.AuthTokenSecret)
+	assert.Equal(t, 5, cfg.MovementKickAfter)
+	assert.Equal(t, 250*time.Millisecond, cfg.DodgeRollIFrames)
+	assert.Equal(t, 50, cfg.DodgeRollStaminaCost)
+	assert.Equal(t, "ops-token", cfg.AdminToken)
+	assert.Equal(t, "/etc/stick-rumble/weapons.json", cfg.WeaponConfigPath)
+	assert.Equal(t, time.Hour, cfg.NameChangeCooldown)
+	assert.Equal(t, "/var/lib/stick-rumble/feedback", cfg.FeedbackDir)
+	assert.Equal(t, 5*time.Minute, cfg.FeedbackCooldown)
+	assert.Equal(t, "/var/lib/stick-rumble/matches", cfg.MatchRecordDir)
+	assert.Equal(t, "/var/lib/stick-rumble/bans", cfg.BanDir)
+	assert.Equal(t, "/var/lib/stick-rumble/replays", cfg.ReplayDir)
+	assert.Equal(t, "/var/lib/stick-rumble/timelines", cfg.TimelineDir)
+	assert.Equal(t, 2*time.Minute, cfg.ShutdownDrain)
+	assert.Equal(t, "redis.internal:6379", cfg.RedisAddr)
+	assert.Equal(t, "s3cret", cfg.RedisPassword)
+	assert.Equal(t, "game-2", cfg.ClusterNodeID)
+	assert.Equal(t, "wss://game-2.example.com/ws", cfg.ClusterAdvertiseURL)
+	assert.Equal(t, time.Hour, cfg.WeaponReportInterval)
+	assert.Equal(t, 25, cfg.WeaponReportSimDuels)
+	assert.Zero(t, cfg.LeaderboardInterval, "0 only recomputes on match ends")
+	assert.Equal(t, 800, cfg.LevelFirstXP)
+	assert.Equal(t, 150, cfg.LevelGrowthPercent)
+	assert.Equal(t, 30, cfg.LevelMax)
+	assert.Equal(t, map[int][]string{2: {"title.rookie"}, 5: {"emote.salute", "skin.red"}}, cfg.LevelUnlocks)
+	assert.Equal(t, 75, cfg.VoteKickPercent)
+	assert.Equal(t, 45*time.Second, cfg.VoteKickWindow)
+	assert.Equal(t, 10*time.Minute, cfg.VoteKickBlock)
+	assert.Equal(t, ":4433", cfg.WebTransportAddr)
+	assert.Equal(t, "/etc/stick-rumble/cert.pem", cfg.TLSCertFile)
+	assert.Equal(t, "/etc/stick-rumble/key.pem", cfg.TLSKeyFile)
+}
+
+func TestLoadIgnoresInvalidRoomSettings(t *testing.T) {
+	t.Setenv("MIN_HUMAN_PLAYERS", "many")
+	t.Setenv("BOT_FILL_AFTER_SECONDS", "-5")
+	t.Setenv("RESUME_GRACE_SECONDS", "soon")
+
+	cfg := Load()
+
+	assert.Zero(t, cfg.MinHumanPlayers)
+	assert.Zero(t, cfg.BotFillAfter)
+	assert.Equal(t, DefaultResumeGrace, cfg.ResumeGrace)
 }
 
 func TestAllowsOrigin(t *testing.T) {
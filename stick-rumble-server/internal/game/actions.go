@@ -0,0 +1,175 @@
+package game
+
+import "time"
+
+// Action kinds: every call from outside the tick loop that changes the
+// simulation and that a replay makes again
+const (
+	ActionJoin        = "join"
+	ActionLeave       = "leave"
+	ActionClass       = "class"
+	ActionInput       = "input" // Sets the player's input at once, as a replay's starting input
+	ActionShoot       = "shoot"
+	ActionReload      = "reload"
+	ActionMelee       = "melee"
+	ActionDodgeRoll   = "dodge_roll"
+	ActionPickup      = "pickup"
+	ActionRespawn     = "respawn_request"
+	ActionSpawnChoice = "spawn_choice"
+	ActionUltimate    = "ultimate"
+)
+
+// Action is one call that changed the simulation between two ticks, with
+// what it takes to make the same call again
+type Action struct {
+	Kind            string       `json:"kind"`
+	PlayerID        string       `json:"playerId"`
+	At              int64        `json:"at"`                        // Server clock when the call was made, Unix ns
+	Class           string       `json:"class,omitempty"`           // join, class
+	Position        *Vector2     `json:"position,omitempty"`        // join: where the player spawned
+	Input           *QueuedInput `json:"input,omitempty"`           // input
+	AimAngle        float64      `json:"aimAngle,omitempty"`        // shoot, melee
+	ClientTimestamp int64        `json:"clientTimestamp,omitempty"` // shoot
+	RTT             int64        `json:"rtt,omitempty"`             // shoot: the shooter's RTT (ms) lag compensation used
+	EffectID        string       `json:"effectId,omitempty"`        // shoot
+	Direction       *Vector2     `json:"direction,omitempty"`       // dodge_roll
+	CrateID         string       `json:"crateId,omitempty"`         // pickup
+	Index           int          `json:"index,omitempty"`           // spawn_choice
+}
+
+// TickRecord is one simulation tick: when it ran, how long it simulated and
+// the queued inputs it applied
+type TickRecord struct {
+	Tick      uint64        `json:"tick"`
+	Now       int64         `json:"now"` // Unix ns
+	DeltaTime float64       `json:"dt"`  // Seconds
+	Inputs    []PlayerInput `json:"inputs,omitempty"`
+}
+
+// ActionRecorder receives every tick and every action between ticks, in the
+// order they happened, so a replay can run the simulation again
+type ActionRecorder interface {
+	RecordTick(record TickRecord)
+	RecordAction(action Action)
+}
+
+// SetActionRecorder starts sending ticks and actions to recorder; nil stops it
+func (gs *GameServer) SetActionRecorder(recorder ActionRecorder) {
+	gs.recorderMu.Lock()
+	defer gs.recorderMu.Unlock()
+	gs.recorder = recorder
+}
+
+func (gs *GameServer) actionRecorder() ActionRecorder {
+	gs.recorderMu.RLock()
+	defer gs.recorderMu.RUnlock()
+	return gs.recorder
+}
+
+// recordAction stamps action with the server clock and hands it to the
+// recorder, if there is one
+func (gs *GameServer) recordAction(action Action) {
+	recorder := gs.actionRecorder()
+	if recorder == nil {
+		return
+	}
+	action.At = gs.clock.Now().UnixNano()
+	recorder.RecordAction(action)
+}
+
+// recordShot records a shot with the RTT its lag compensation is about to use
+func (gs *GameServer) recordShot(playerID string, aimAngle float64, clientTimestamp int64, effectID string) {
+	if gs.actionRecorder() == nil {
+		return
+	}
+	var rtt int64
+	if gs.getRTT != nil {
+		rtt = gs.getRTT(playerID)
+	}
+	gs.recordAction(Action{
+		Kind:            ActionShoot,
+		PlayerID:        playerID,
+		AimAngle:        aimAngle,
+		ClientTimestamp: clientTimestamp,
+		RTT:             rtt,
+		EffectID:        effectID,
+	})
+}
+
+func (gs *GameServer) recordTick(now time.Time, deltaTime float64, inputs []PlayerInput) {
+	recorder := gs.actionRecorder()
+	if recorder == nil {
+		return
+	}
+	recorder.RecordTick(TickRecord{Tick: gs.Tick(), Now: now.UnixNano(), DeltaTime: deltaTime, Inputs: inputs})
+}
+
+// Apply makes the call a recorded action stands for. A shoot action's RTT is
+// only used if the server's RTT provider reads it back. Returns false for an
+// unknown kind.
+func (gs *GameServer) Apply(action Action) bool {
+	switch action.Kind {
+	case ActionJoin:
+		player := gs.AddPlayerAs(action.PlayerID, action.Class)
+		if action.Position != nil {
+			player.SetPosition(*action.Position)
+		}
+	case ActionLeave:
+		gs.RemovePlayer(action.PlayerID)
+	case ActionClass:
+		_ = gs.SetPlayerClass(action.PlayerID, action.Class)
+	case ActionInput:
+		if action.Input == nil {
+			return false
+		}
+		if player, exists := gs.world.GetPlayer(action.PlayerID); exists {
+			if action.Input.HasSequence {
+				player.SetInputSequence(action.Input.Sequence)
+			}
+			player.SetInput(action.Input.Input)
+			player.SetAimAngle(action.Input.Input.AimAngle)
+		}
+	case ActionShoot:
+		gs.PlayerShootWithEffect(action.PlayerID, action.AimAngle, action.ClientTimestamp, action.EffectID)
+	case ActionReload:
+		gs.PlayerReload(action.PlayerID)
+	case ActionMelee:
+		result := gs.PlayerMeleeAttack(action.PlayerID, action.AimAngle)
+		for _, victim := range result.HitPlayers {
+			if !victim.IsAlive() {
+				gs.CreditMeleeKill(action.PlayerID, victim.ID)
+			}
+		}
+	case ActionDodgeRoll:
+		if action.Direction == nil {
+			return false
+		}
+		gs.StartDodgeRoll(action.PlayerID, *action.Direction)
+	case ActionPickup:
+		gs.PickUpWeapon(action.PlayerID, action.CrateID)
+	case ActionRespawn:
+		gs.RequestRespawn(action.PlayerID)
+	case ActionSpawnChoice:
+		gs.ChooseSpawn(action.PlayerID, action.Index)
+	case ActionUltimate:
+		gs.ActivateUltimate(action.PlayerID)
+	default:
+		return false
+	}
+	return true
+}
+
+// RestorePlayer adds a player as a state broadcast showed it, for a replay
+// that starts mid-match. State a snapshot does not carry, such as ammo and
+// cooldowns, starts fresh, as it does for a newly spawned player.
+func (gs *GameServer) RestorePlayer(snapshot PlayerStateSnapshot) *PlayerState {
+	player := gs.AddPlayerAs(snapshot.ID, snapshot.Class)
+	player.restore(snapshot)
+
+	if snapshot.WeaponType != "" {
+		if weapon, err := CreateWeaponByType(snapshot.WeaponType); err == nil {
+			gs.SetWeaponState(snapshot.ID, NewWeaponStateWithClock(weapon, gs.clock))
+		}
+	}
+	return player
+}
@@ -0,0 +1,97 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingActionRecorder struct {
+	ticks   []TickRecord
+	actions []Action
+}
+
+func (r *recordingActionRecorder) RecordTick(record TickRecord) {
+	r.ticks = append(r.ticks, record)
+}
+
+func (r *recordingActionRecorder) RecordAction(action Action) {
+	r.actions = append(r.actions, action)
+}
+
+func TestGameServerRecordsTicksAndActions(t *testing.T) {
+	clock := NewManualClock(time.UnixMilli(1704067200000))
+	gs := NewGameServerWithClock(nil, clock)
+	recorder := &recordingActionRecorder{}
+	gs.SetActionRecorder(recorder)
+
+	player := gs.AddPlayer("p1")
+	gs.QueuePlayerInput("p1", QueuedInput{Input: InputState{Right: true}, Sequence: 1, HasSequence: true})
+	clock.Advance(time.Second / 60)
+	gs.Step(clock.Now(), 1.0/60.0)
+	clock.Advance(5 * time.Millisecond)
+	gs.PlayerReload("p1")
+
+	require.Len(t, recorder.ticks, 1)
+	assert.Equal(t, clock.Now().Add(-5*time.Millisecond).UnixNano(), recorder.ticks[0].Now)
+	assert.Equal(t, []PlayerInput{{PlayerID: "p1", QueuedInput: QueuedInput{Input: InputState{Right: true}, Sequence: 1, HasSequence: true}}}, recorder.ticks[0].Inputs)
+
+	require.Len(t, recorder.actions, 2)
+	spawn := player.GetPosition()
+	assert.Equal(t, ActionJoin, recorder.actions[0].Kind)
+	assert.NotNil(t, recorder.actions[0].Position, "a join records where the player spawned")
+	assert.NotEqual(t, spawn, *recorder.actions[0].Position, "the player has moved since")
+	assert.Equal(t, Action{Kind: ActionReload, PlayerID: "p1", At: clock.Now().UnixNano()}, recorder.actions[1])
+
+	gs.SetActionRecorder(nil)
+	gs.PlayerReload("p1")
+	assert.Len(t, recorder.actions, 2, "nothing is recorded once the recorder is removed")
+}
+
+func TestGameServerApplyMakesRecordedCalls(t *testing.T) {
+	clock := NewManualClock(time.UnixMilli(1704067200000))
+	gs := NewGameServerWithClock(nil, clock)
+
+	require.True(t, gs.Apply(Action{Kind: ActionJoin, PlayerID: "p1", Position: &Vector2{X: 300, Y: 650}}))
+	player, exists := gs.GetPlayerState("p1")
+	require.True(t, exists)
+	assert.Equal(t, Vector2{X: 300, Y: 650}, player.Position)
+
+	input := QueuedInput{Input: InputState{Up: true, AimAngle: 1.25}, Sequence: 7, HasSequence: true}
+	require.True(t, gs.Apply(Action{Kind: ActionInput, PlayerID: "p1", Input: &input}))
+	state, _ := gs.world.GetPlayer("p1")
+	assert.True(t, state.GetInput().Up)
+	assert.Equal(t, 1.25, state.GetAimAngle())
+	assert.Equal(t, uint64(7), state.GetInputSequence())
+
+	assert.False(t, gs.Apply(Action{Kind: ActionInput, PlayerID: "p1"}), "an input action carries its input")
+	assert.False(t, gs.Apply(Action{Kind: "teleport", PlayerID: "p1"}))
+
+	require.True(t, gs.Apply(Action{Kind: ActionLeave, PlayerID: "p1"}))
+	_, exists = gs.GetPlayerState("p1")
+	assert.False(t, exists)
+}
+
+func TestGameServerRestorePlayer(t *testing.T) {
+	gs := NewGameServerWithClock(nil, NewManualClock(time.UnixMilli(1704067200000)))
+
+	player := gs.RestorePlayer(PlayerStateSnapshot{
+		ID:         "p1",
+		Position:   Vector2{X: 400, Y: 650},
+		Health:     40,
+		Kills:      3,
+		Deaths:     2,
+		WeaponType: "Uzi",
+	})
+
+	assert.Equal(t, Vector2{X: 400, Y: 650}, player.GetPosition())
+	snapshot, _ := gs.GetPlayerState("p1")
+	assert.Equal(t, 40, snapshot.Health)
+	assert.Equal(t, 3, snapshot.Kills)
+	assert.Equal(t, 2, snapshot.Deaths)
+	weapon := gs.GetWeaponState("p1")
+	require.NotNil(t, weapon)
+	assert.Equal(t, "Uzi", weapon.Weapon.Name)
+}
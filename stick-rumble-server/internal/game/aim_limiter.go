@@ -0,0 +1,146 @@
+package game
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMaxAimTurnRate is the fastest the server turns a player's aim, in
+	// radians per second. A half turn takes about 50ms: quicker than a human
+	// flick, far slower than a spinbot snapping around every tick.
+	DefaultMaxAimTurnRate = 60.0
+
+	// DefaultAimClampWindow is how long a clamped input counts toward
+	// ChronicClamps
+	DefaultAimClampWindow = 5 * time.Second
+
+	// DefaultChronicAimClamps is the clamped inputs within the window that
+	// flag a player; a fifth of a 60Hz input stream
+	DefaultChronicAimClamps = 60
+)
+
+// AimLimiterConfig holds the aim limiter's thresholds. Zero values use the
+// defaults above.
+type AimLimiterConfig struct {
+	MaxTurnRate   float64 // Radians per second; rooms may override it
+	ChronicClamps int     // Clamped inputs within Window that flag the player
+	Window        time.Duration
+}
+
+func (c AimLimiterConfig) withDefaults() AimLimiterConfig {
+	if c.MaxTurnRate <= 0 {
+		c.MaxTurnRate = DefaultMaxAimTurnRate
+	}
+	if c.ChronicClamps <= 0 {
+		c.ChronicClamps = DefaultChronicAimClamps
+	}
+	if c.Window <= 0 {
+		c.Window = DefaultAimClampWindow
+	}
+	return c
+}
+
+// aimLimitTrack is what the limiter remembers about one player
+type aimLimitTrack struct {
+	budget float64 // Radians the player may still turn before the next refill
+	at     time.Time
+	clamps []time.Time
+}
+
+// AimLimiter caps how fast a player's applied aim can turn. Each player has a
+// budget of one tick's worth of turning at the turn rate, refilled at the
+// turn rate, so inputs bunched into one tick share it instead of each getting
+// a full tick.
+type AimLimiter struct {
+	config AimLimiterConfig
+	tracks map[string]*aimLimitTrack
+	mu     sync.Mutex
+}
+
+func NewAimLimiter(config AimLimiterConfig) *AimLimiter {
+	return &AimLimiter{
+		config: config.withDefaults(),
+		tracks: make(map[string]*aimLimitTrack),
+	}
+}
+
+// Limit returns the aim to apply when a player aiming at current asks for
+// requested. turnRate overrides the configured rate when positive. chronic is
+// the clamp count once the player reaches ChronicClamps within the window,
+// and 0 otherwise.
+func (l *AimLimiter) Limit(playerID string, current, requested, turnRate float64, now time.Time) (applied float64, chronic int) {
+	if turnRate <= 0 {
+		turnRate = l.config.MaxTurnRate
+	}
+	perTick := turnRate / float64(ServerTickRate)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	track, ok := l.tracks[playerID]
+	if !ok {
+		// The first input sets the aim a player spawned without
+		l.tracks[playerID] = &aimLimitTrack{budget: perTick, at: now}
+		return requested, 0
+	}
+	track.budget = math.Min(track.budget+turnRate*now.Sub(track.at).Seconds(), perTick)
+	track.at = now
+
+	turn := math.Remainder(requested-current, 2*math.Pi)
+	if math.Abs(turn) <= track.budget {
+		track.budget -= math.Abs(turn)
+		return requested, 0
+	}
+
+	applied = math.Remainder(current+math.Copysign(track.budget, turn), 2*math.Pi)
+	track.budget = 0
+
+	recent := track.clamps[:0]
+	for _, at := range track.clamps {
+		if now.Sub(at) < l.config.Window {
+			recent = append(recent, at)
+		}
+	}
+	track.clamps = append(recent, now)
+	if len(track.clamps) >= l.config.ChronicClamps {
+		chronic = len(track.clamps)
+		track.clamps = nil
+	}
+	return applied, chronic
+}
+
+// Forget drops a removed player's history
+func (l *AimLimiter) Forget(playerID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.tracks, playerID)
+}
+
+// SetAimTurnRate overrides the server's aim turn rate cap for this room's
+// players, for rule sets that want it stricter or looser; 0 restores the
+// server's
+func (r *Room) SetAimTurnRate(radiansPerSecond float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.aimTurnRate = max(radiansPerSecond, 0)
+}
+
+// AimTurnRate returns the room's aim turn rate cap, or 0 if it uses the
+// server's
+func (r *Room) AimTurnRate() float64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.aimTurnRate
+}
+
+// AimTurnRateForPlayer returns the aim turn rate cap of the player's room, or
+// 0 when the player is not in a room or the room uses the server's.
+func (rm *RoomManager) AimTurnRateForPlayer(playerID string) float64 {
+	room := rm.GetRoomByPlayerID(playerID)
+	if room == nil {
+		return 0
+	}
+	return room.AimTurnRate()
+}
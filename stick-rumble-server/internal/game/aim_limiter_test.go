@@ -0,0 +1,110 @@
+package game
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const aimPerTick = DefaultMaxAimTurnRate / ServerTickRate
+
+func TestAimLimiterClampsTurnsPastOneTick(t *testing.T) {
+	limiter := NewAimLimiter(AimLimiterConfig{})
+	applied, _ := limiter.Limit("p1", 0, 2, 0, guardStart)
+	assert.Equal(t, 2.0, applied, "the first input sets the spawn aim")
+
+	now := guardStart.Add(time.Second)
+	applied, chronic := limiter.Limit("p1", 2, 2.5, 0, now)
+	assert.Equal(t, 2.5, applied)
+	assert.Zero(t, chronic)
+
+	// A second input in the same instant only gets what is left of the tick
+	applied, _ = limiter.Limit("p1", 2.5, 0, 0, now)
+	assert.InDelta(t, 2.5-(aimPerTick-0.5), applied, 1e-9)
+
+	// The budget refills at the turn rate
+	now = now.Add(time.Second / ServerTickRate)
+	applied, _ = limiter.Limit("p1", 2, 0, 0, now)
+	assert.InDelta(t, 2-aimPerTick, applied, 1e-6)
+}
+
+func TestAimLimiterTurnsTheShortWayAcrossTheWrap(t *testing.T) {
+	limiter := NewAimLimiter(AimLimiterConfig{MaxTurnRate: 30})
+	limiter.Limit("p1", 0, 3, 0, guardStart)
+
+	now := guardStart.Add(time.Second)
+	applied, _ := limiter.Limit("p1", 3, -3, 0, now)
+	assert.InDelta(t, -3, applied, 1e-9, "0.28 rad through pi is inside half a radian")
+
+	applied, _ = limiter.Limit("p1", -3, 0, 0, now)
+	assert.InDelta(t, -3+(0.5-(2*math.Pi-6)), applied, 1e-9)
+}
+
+func TestAimLimiterReportsChronicClamping(t *testing.T) {
+	limiter := NewAimLimiter(AimLimiterConfig{ChronicClamps: 3, Window: time.Second})
+	limiter.Limit("p1", 0, 0, 0, guardStart)
+
+	spin := func(at time.Time) int {
+		_, chronic := limiter.Limit("p1", 0, math.Pi, 0, at)
+		return chronic
+	}
+	assert.Zero(t, spin(guardStart.Add(100*time.Millisecond)))
+	assert.Zero(t, spin(guardStart.Add(200*time.Millisecond)))
+	assert.Equal(t, 3, spin(guardStart.Add(300*time.Millisecond)))
+
+	// Reporting starts a fresh count, and old clamps age out of the window
+	assert.Zero(t, spin(guardStart.Add(400*time.Millisecond)))
+	assert.Zero(t, spin(guardStart.Add(1500*time.Millisecond)))
+	assert.Zero(t, spin(guardStart.Add(1600*time.Millisecond)))
+}
+
+func TestAimLimiterRoomTurnRateOverridesConfig(t *testing.T) {
+	limiter := NewAimLimiter(AimLimiterConfig{})
+	limiter.Limit("p1", 0, 0, 0, guardStart)
+
+	applied, _ := limiter.Limit("p1", 0, 1, 6, guardStart.Add(time.Second))
+	assert.InDelta(t, 6.0/ServerTickRate, applied, 1e-9)
+
+	limiter.Forget("p1")
+	applied, _ = limiter.Limit("p1", 0, 1, 6, guardStart.Add(time.Second))
+	assert.Equal(t, 1.0, applied, "a forgotten player starts over")
+}
+
+func TestGameServerFlagsSpinbots(t *testing.T) {
+	sink := &recordingGameLoopSink{}
+	clock := NewManualClock(guardStart)
+	gs := NewGameServerWithConfig(GameServerConfig{
+		Clock:       clock,
+		EventSink:   sink,
+		AimLimit:    AimLimiterConfig{ChronicClamps: 5},
+		AimTurnRate: func(string) float64 { return 12 },
+	})
+	gs.AddPlayer("p1")
+	require.True(t, gs.UpdatePlayerInput("p1", InputState{AimAngle: 0}))
+
+	for i := 1; i <= 5; i++ {
+		clock.Advance(time.Second / ServerTickRate)
+		aim := math.Pi / 2
+		if i%2 == 0 {
+			aim = -math.Pi / 2
+		}
+		require.True(t, gs.UpdatePlayerInputWithSequence("p1", InputState{AimAngle: aim}, uint64(i)))
+	}
+
+	state, ok := gs.GetPlayerState("p1")
+	require.True(t, ok)
+	assert.InDelta(t, 12.0/ServerTickRate, math.Abs(state.AimAngle), 0.01, "each tick turns at most the room's rate")
+
+	var clamped *MovementViolationEvent
+	for _, event := range sink.events {
+		if violation, ok := event.(MovementViolationEvent); ok && violation.Kind == MovementViolationAimClamp {
+			clamped = &violation
+		}
+	}
+	require.NotNil(t, clamped)
+	assert.Equal(t, "p1", clamped.PlayerID)
+	assert.True(t, gs.IsMovementFlagged("p1"))
+}
@@ -0,0 +1,14 @@
+package game
+
+// CreditAssist gives a player the XP for an assist and returns
+// their XP after it. It returns false if the player is not in the world.
+func (gs *GameServer) CreditAssist(playerID string) (int, bool) {
+	player, exists := gs.world.GetPlayer(playerID)
+	if !exists || player == nil {
+		return 0, false
+	}
+
+	player.AddXP(AssistXPReward)
+	gs.announceLevelUp(player)
+	return player.Snapshot().XP, true
+}
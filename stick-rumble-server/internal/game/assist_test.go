@@ -0,0 +1,22 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameServerCreditAssistAddsAssistXP(t *testing.T) {
+	gs := NewGameServer(nil)
+	player := gs.AddPlayer("p1")
+	player.AddXP(KillXPReward)
+
+	_, ok := gs.CreditAssist("ghost")
+	assert.False(t, ok)
+
+	xp, ok := gs.CreditAssist("p1")
+	require.True(t, ok)
+	assert.Equal(t, KillXPReward+AssistXPReward, xp)
+	assert.Zero(t, player.Snapshot().Kills, "an assist is not a kill")
+}
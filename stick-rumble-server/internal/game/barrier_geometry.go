@@ -94,6 +94,15 @@ func firstObstacleContact(start, end Vector2, obstacles []MapObstacle, blocks fu
 	return nearest, found
 }
 
+// HasLineOfSight reports whether a shot or a look from one point reaches the
+// other without crossing an obstacle that blocks projectiles or sight
+func HasLineOfSight(mapConfig MapConfig, from, to Vector2) bool {
+	_, blocked := firstObstacleContact(from, to, mapConfig.Obstacles, func(obstacle MapObstacle) bool {
+		return obstacle.BlocksProjectiles || obstacle.BlocksLineOfSight
+	})
+	return !blocked
+}
+
 func playerHitboxRect(position Vector2) rect {
 	return rect{
 		x:      position.X - PlayerWidth/2,
@@ -9,9 +9,15 @@ func openTestMapConfig() MapConfig {
 	}
 }
 
+// setWorldMapConfig swaps the world's map along with its spawn points
+func setWorldMapConfig(w *World, mapConfig MapConfig) {
+	w.mapConfig = mapConfig
+	w.spawns.SetMap(mapConfig)
+}
+
 func setGameServerOpenMap(gs *GameServer) {
 	mapConfig := openTestMapConfig()
-	gs.world.mapConfig = mapConfig
+	setWorldMapConfig(gs.world, mapConfig)
 	gs.physics = NewPhysics(mapConfig)
 	gs.projectileManager = NewProjectileManager(mapConfig)
 }
@@ -0,0 +1,280 @@
+// Package bot drives server-controlled players that fill stalled rooms and
+// practice rooms. A bot is an ordinary room player without a connection: each
+// tick it picks the nearest visible enemy, closes to firing range, and shoots
+// with its difficulty's accuracy once its reaction time has passed. Its
+// actions go through the same input, shoot and reload paths as a human's, so
+// it is bound by the same WeaponState and cooldown rules.
+package bot
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+// IDPrefix starts the player ID of every bot
+const IDPrefix = "bot-"
+
+// Bot AI tuning
+const (
+	EngageRange     = 350.0           // Pixels from its target at which a bot stops closing in
+	ArrivalDistance = 32.0            // Pixels from a wander point that count as arrived
+	MoveDeadZone    = 8.0             // Pixels of offset along an axis a bot ignores when steering
+	WanderInterval  = 3 * time.Second // How long a bot heads for one wander point
+	MissMinAngle    = 0.15            // Smallest aim error of a missed shot, in radians
+	MissMaxAngle    = 0.45            // Largest aim error of a missed shot, in radians
+	sendBufferSize  = 256             // Outgoing messages a bot holds between ticks
+)
+
+// Action is what a bot does on one tick
+type Action struct {
+	Input   game.InputState
+	Shoot   bool // Fire at Input.AimAngle
+	Reload  bool // Start a reload
+	Respawn bool // Ask to respawn; only set while the bot is dead
+}
+
+// bot is one server-controlled player and what it remembers between ticks
+type bot struct {
+	player       *game.Player
+	room         *game.Room
+	target       string    // Enemy the bot is engaging, empty without one
+	targetSeenAt time.Time // When the bot first saw its current target
+	wanderTo     game.Vector2
+	wanderUntil  time.Time
+}
+
+// Controller creates bots for stalled and practice rooms and decides what each
+// does every tick. Bots draw their randomness from their room's RoomRNG, so a
+// match with bots replays from its seed. It implements game.RoomBotFiller. (thread-safe)
+type Controller struct {
+	gameServer *game.GameServer
+	difficulty game.BotDifficulty
+	clock      game.Clock
+	bots       map[string]*bot
+	nextID     int
+	mu         sync.Mutex
+}
+
+// NewController creates a controller whose bots play at difficulty outside
+// practice rooms
+func NewController(gameServer *game.GameServer, difficulty game.BotDifficulty, clock game.Clock) *Controller {
+	return &Controller{
+		gameServer: gameServer,
+		difficulty: difficulty,
+		clock:      clock,
+		bots:       make(map[string]*bot),
+	}
+}
+
+// FillBots creates count bots for room. The room manager adds them to the
+// room and match; they join the game world when the room's players activate.
+func (c *Controller) FillBots(room *game.Room, count int) []*game.Player {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	players := make([]*game.Player, 0, count)
+	for range count {
+		c.nextID++
+		player := game.NewPlayer(fmt.Sprintf("%s%d", IDPrefix, c.nextID), make(chan []byte, sendBufferSize))
+		player.DisplayName = fmt.Sprintf("Bot %d", c.nextID)
+		player.HelloSeen = true
+		c.bots[player.ID] = &bot{player: player, room: room}
+		players = append(players, player)
+	}
+	return players
+}
+
+// IsBot reports whether playerID is one of the controller's bots
+func (c *Controller) IsBot(playerID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, exists := c.bots[playerID]
+	return exists
+}
+
+// Players returns every bot's player in ID order
+func (c *Controller) Players() []*game.Player {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ids := make([]string, 0, len(c.bots))
+	for id := range c.bots {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	players := make([]*game.Player, 0, len(ids))
+	for _, id := range ids {
+		players = append(players, c.bots[id].player)
+	}
+	return players
+}
+
+// Remove forgets a bot
+func (c *Controller) Remove(botID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.bots, botID)
+}
+
+// Think decides what a bot does this tick. It discards the messages sent to
+// the bot since the last tick. Returns false for an unknown bot or one not in
+// the game world yet.
+func (c *Controller) Think(botID string) (Action, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, exists := c.bots[botID]
+	if !exists {
+		return Action{}, false
+	}
+	b.player.Outbox.Drain()
+
+	self, inWorld := c.gameServer.GetPlayerState(botID)
+	if !inWorld {
+		return Action{}, false
+	}
+	if self.DeathTime != nil {
+		b.target = ""
+		return Action{Respawn: true}, true
+	}
+
+	now := c.clock.Now()
+	mapConfig := c.gameServer.GetWorld().GetMapConfig()
+	target, visible := c.pickTargetLocked(b, self, mapConfig)
+	if target == nil {
+		b.target = ""
+		return Action{Input: c.wanderLocked(b, self.Position, mapConfig, now)}, true
+	}
+
+	// The reaction time runs from when the bot sees its current target;
+	// losing sight of it starts the wait over
+	if target.ID != b.target || !visible {
+		b.targetSeenAt = time.Time{}
+	}
+	b.target = target.ID
+	if visible && b.targetSeenAt.IsZero() {
+		b.targetSeenAt = now
+	}
+
+	action := Action{Input: steer(self.Position, target.Position)}
+	if visible && distanceBetween(self.Position, target.Position) <= EngageRange {
+		action.Input = game.InputState{}
+	}
+	aimAngle := angleTo(self.Position, target.Position)
+	action.Input.AimAngle = aimAngle
+
+	if !visible {
+		return action, true
+	}
+
+	weaponState := c.gameServer.GetWeaponState(botID)
+	if weaponState == nil || weaponState.Weapon.IsMelee() {
+		return action, true
+	}
+	if weaponState.IsEmpty() && !weaponState.Reloading() {
+		action.Reload = true
+		return action, true
+	}
+	difficulty := c.difficultyLocked(b)
+	if now.Sub(b.targetSeenAt).Seconds() >= difficulty.ReactionTime && weaponState.CanShoot() {
+		action.Shoot = true
+		action.Input.AimAngle = aim(b.room.RNG, difficulty, aimAngle)
+	}
+	return action, true
+}
+
+// pickTargetLocked returns the nearest living enemy in the bot's room,
+// preferring ones it can see, and whether the bot can see it
+func (c *Controller) pickTargetLocked(b *bot, self game.PlayerStateSnapshot, mapConfig game.MapConfig) (*game.PlayerStateSnapshot, bool) {
+	var nearest, nearestVisible *game.PlayerStateSnapshot
+	for _, player := range b.room.GetPlayers() {
+		if player.ID == self.ID || (player.Team != "" && player.Team == b.player.Team) {
+			continue
+		}
+		enemy, exists := c.gameServer.GetPlayerState(player.ID)
+		if !exists || enemy.DeathTime != nil {
+			continue
+		}
+
+		dist := distanceBetween(self.Position, enemy.Position)
+		if nearest == nil || dist < distanceBetween(self.Position, nearest.Position) {
+			nearest = &enemy
+		}
+		if !game.HasLineOfSight(mapConfig, self.Position, enemy.Position) {
+			continue
+		}
+		if nearestVisible == nil || dist < distanceBetween(self.Position, nearestVisible.Position) {
+			nearestVisible = &enemy
+		}
+	}
+
+	if nearestVisible != nil {
+		return nearestVisible, true
+	}
+	return nearest, false
+}
+
+// wanderLocked steers a bot with nobody to fight toward a random point on the
+// map, picking a new one on arrival or after WanderInterval
+func (c *Controller) wanderLocked(b *bot, position game.Vector2, mapConfig game.MapConfig, now time.Time) game.InputState {
+	if !now.Before(b.wanderUntil) || distanceBetween(position, b.wanderTo) <= ArrivalDistance {
+		b.wanderTo = game.Vector2{
+			X: b.room.RNG.Float64() * mapConfig.Width,
+			Y: b.room.RNG.Float64() * mapConfig.Height,
+		}
+		b.wanderUntil = now.Add(WanderInterval)
+	}
+
+	input := steer(position, b.wanderTo)
+	input.AimAngle = angleTo(position, b.wanderTo)
+	return input
+}
+
+// difficultyLocked returns how well a bot plays: a practice room's adaptive
+// difficulty, or the controller's fixed one
+func (c *Controller) difficultyLocked(b *bot) game.BotDifficulty {
+	if b.room.Practice != nil {
+		return b.room.Practice.Difficulty()
+	}
+	return c.difficulty
+}
+
+// aim returns the angle a shot at aimAngle actually goes: on target with the
+// difficulty's accuracy, otherwise off to a random side
+func aim(rng game.RandomSource, difficulty game.BotDifficulty, aimAngle float64) float64 {
+	if rng.Float64() < difficulty.Accuracy {
+		return aimAngle
+	}
+
+	miss := MissMinAngle + rng.Float64()*(MissMaxAngle-MissMinAngle)
+	if rng.Intn(2) == 0 {
+		miss = -miss
+	}
+	return aimAngle + miss
+}
+
+// steer returns the movement keys that head from one point toward another
+func steer(from, to game.Vector2) game.InputState {
+	dx := to.X - from.X
+	dy := to.Y - from.Y
+	return game.InputState{
+		Up:    dy < -MoveDeadZone,
+		Down:  dy > MoveDeadZone,
+		Left:  dx < -MoveDeadZone,
+		Right: dx > MoveDeadZone,
+	}
+}
+
+func angleTo(from, to game.Vector2) float64 {
+	return math.Atan2(to.Y-from.Y, to.X-from.X)
+}
+
+func distanceBetween(a, b game.Vector2) float64 {
+	return math.Hypot(b.X-a.X, b.Y-a.Y)
+}
@@ -0,0 +1,165 @@
+package bot
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Positions on the default office map: the west spawn sees the center-left
+// spawn, while the left pillar hides the map center from it
+var (
+	westSpawn       = game.Vector2{X: 220, Y: 540}
+	centerLeftSpawn = game.Vector2{X: 440, Y: 560}
+	mapCenter       = game.Vector2{X: 960, Y: 560}
+)
+
+type botFixture struct {
+	gs         *game.GameServer
+	clock      *game.ManualClock
+	controller *Controller
+	room       *game.Room
+	botID      string
+}
+
+// newBotFixture puts one bot at botPos and one human enemy at enemyPos in the
+// same room
+func newBotFixture(t *testing.T, difficulty game.BotDifficulty, botPos, enemyPos game.Vector2) botFixture {
+	t.Helper()
+
+	clock := game.NewManualClock(time.Now())
+	gs := game.NewGameServerWithClock(func([]game.PlayerStateSnapshot) {}, clock)
+	controller := NewController(gs, difficulty, clock)
+
+	room := game.NewRoom()
+	room.Reseed(1)
+	human := game.NewPlayer("human", make(chan []byte, 1))
+	require.NoError(t, room.AddPlayer(human))
+	bots := controller.FillBots(room, 1)
+	require.Len(t, bots, 1)
+	require.NoError(t, room.AddPlayer(bots[0]))
+
+	gs.AddPlayer(bots[0].ID).SetPosition(botPos)
+	gs.AddPlayer(human.ID).SetPosition(enemyPos)
+
+	return botFixture{gs: gs, clock: clock, controller: controller, room: room, botID: bots[0].ID}
+}
+
+func TestFillBotsCreatesServerControlledPlayers(t *testing.T) {
+	controller := NewController(game.NewGameServer(func([]game.PlayerStateSnapshot) {}), game.BotDifficulty{}, &game.RealClock{})
+
+	bots := controller.FillBots(game.NewRoom(), 2)
+
+	require.Len(t, bots, 2)
+	assert.Equal(t, "bot-1", bots[0].ID)
+	assert.Equal(t, "Bot 2", bots[1].DisplayName)
+	assert.True(t, bots[0].HelloSeen)
+	assert.True(t, controller.IsBot("bot-2"))
+	assert.False(t, controller.IsBot("human"))
+	assert.Equal(t, bots, controller.Players())
+
+	controller.Remove("bot-1")
+	assert.False(t, controller.IsBot("bot-1"))
+}
+
+func TestBotShootsVisibleEnemyAfterReactionTime(t *testing.T) {
+	difficulty := game.BotDifficulty{Name: "test", Accuracy: 1, ReactionTime: 0.5}
+	f := newBotFixture(t, difficulty, westSpawn, centerLeftSpawn)
+	require.True(t, game.HasLineOfSight(f.gs.GetWorld().GetMapConfig(), westSpawn, centerLeftSpawn))
+	onTarget := math.Atan2(centerLeftSpawn.Y-westSpawn.Y, centerLeftSpawn.X-westSpawn.X)
+
+	action, ok := f.controller.Think(f.botID)
+	require.True(t, ok)
+	assert.False(t, action.Shoot, "the bot is still reacting")
+	assert.Equal(t, game.InputState{AimAngle: onTarget}, action.Input, "in range, the bot stands and aims")
+
+	f.clock.Advance(500 * time.Millisecond)
+	action, _ = f.controller.Think(f.botID)
+	assert.True(t, action.Shoot)
+	assert.Equal(t, onTarget, action.Input.AimAngle)
+}
+
+func TestBotMissesByAimErrorWithoutAccuracy(t *testing.T) {
+	f := newBotFixture(t, game.BotDifficulty{Name: "test", Accuracy: 0}, westSpawn, centerLeftSpawn)
+	onTarget := math.Atan2(centerLeftSpawn.Y-westSpawn.Y, centerLeftSpawn.X-westSpawn.X)
+
+	action, _ := f.controller.Think(f.botID)
+	require.True(t, action.Shoot)
+	aimError := math.Abs(action.Input.AimAngle - onTarget)
+	assert.GreaterOrEqual(t, aimError, MissMinAngle)
+	assert.LessOrEqual(t, aimError, MissMaxAngle)
+}
+
+func TestBotPlaysAtPracticeRoomDifficulty(t *testing.T) {
+	f := newBotFixture(t, game.BotDifficulty{Name: "test", Accuracy: 1}, westSpawn, centerLeftSpawn)
+	f.room.Practice = game.NewAdaptiveBotDifficulty()
+	reactionTime := time.Duration(f.room.Practice.Difficulty().ReactionTime * float64(time.Second))
+
+	action, _ := f.controller.Think(f.botID)
+	assert.False(t, action.Shoot, "the practice difficulty's reaction time applies")
+
+	f.clock.Advance(reactionTime)
+	action, _ = f.controller.Think(f.botID)
+	assert.True(t, action.Shoot)
+}
+
+func TestBotClosesInOnHiddenEnemyWithoutShooting(t *testing.T) {
+	f := newBotFixture(t, game.BotDifficulty{Name: "test", Accuracy: 1}, centerLeftSpawn, mapCenter)
+	require.False(t, game.HasLineOfSight(f.gs.GetWorld().GetMapConfig(), centerLeftSpawn, mapCenter))
+
+	f.clock.Advance(time.Second)
+	action, _ := f.controller.Think(f.botID)
+	assert.False(t, action.Shoot)
+	assert.True(t, action.Input.Right)
+	assert.False(t, action.Input.Left)
+}
+
+func TestBotRespectsWeaponState(t *testing.T) {
+	f := newBotFixture(t, game.BotDifficulty{Name: "test", Accuracy: 1}, westSpawn, centerLeftSpawn)
+	weaponState := f.gs.GetWeaponState(f.botID)
+
+	weaponState.RecordShot()
+	action, _ := f.controller.Think(f.botID)
+	assert.False(t, action.Shoot, "the fire rate cooldown is still running")
+
+	weaponState.CurrentAmmo = 0
+	f.clock.Advance(time.Second)
+	action, _ = f.controller.Think(f.botID)
+	assert.False(t, action.Shoot)
+	assert.True(t, action.Reload)
+}
+
+func TestDeadBotAsksToRespawn(t *testing.T) {
+	f := newBotFixture(t, game.BotDifficulty{Name: "test", Accuracy: 1}, westSpawn, centerLeftSpawn)
+	f.gs.MarkPlayerDead(f.botID)
+
+	action, ok := f.controller.Think(f.botID)
+	require.True(t, ok)
+	assert.Equal(t, Action{Respawn: true}, action)
+}
+
+func TestBotWandersWithoutEnemies(t *testing.T) {
+	clock := game.NewManualClock(time.Now())
+	gs := game.NewGameServerWithClock(func([]game.PlayerStateSnapshot) {}, clock)
+	controller := NewController(gs, game.BotDifficulty{Name: "test", Accuracy: 1}, clock)
+	room := game.NewRoom()
+	room.Reseed(1)
+	bots := controller.FillBots(room, 1)
+	require.NoError(t, room.AddPlayer(bots[0]))
+
+	_, ok := controller.Think(bots[0].ID)
+	assert.False(t, ok, "the bot is not in the world until its room activates")
+
+	gs.AddPlayer(bots[0].ID).SetPosition(westSpawn)
+	// A player outside the bot's room is not its enemy
+	gs.AddPlayer("stranger").SetPosition(centerLeftSpawn)
+
+	action, ok := controller.Think(bots[0].ID)
+	require.True(t, ok)
+	assert.False(t, action.Shoot)
+	assert.NotEqual(t, game.InputState{}, action.Input)
+}
@@ -0,0 +1,125 @@
+package game
+
+import "sync"
+
+// BotDifficulty is one step of practice bot skill
+type BotDifficulty struct {
+	Name         string  `json:"name"`
+	Accuracy     float64 `json:"accuracy"`     // Chance (0-1) a bot's shot is aimed on target
+	ReactionTime float64 `json:"reactionTime"` // Seconds before a bot fires at a newly seen enemy
+}
+
+// botDifficulties are the practice difficulty steps, easiest first
+var botDifficulties = []BotDifficulty{
+	{Name: "easy", Accuracy: 0.25, ReactionTime: 0.8},
+	{Name: "normal", Accuracy: 0.45, ReactionTime: 0.5},
+	{Name: "hard", Accuracy: 0.65, ReactionTime: 0.3},
+	{Name: "expert", Accuracy: 0.85, ReactionTime: 0.15},
+}
+
+// BotDifficultyByName returns the difficulty step with the given name
+func BotDifficultyByName(name string) (BotDifficulty, bool) {
+	for _, difficulty := range botDifficulties {
+		if difficulty.Name == name {
+			return difficulty, true
+		}
+	}
+	return BotDifficulty{}, false
+}
+
+// BotDifficulties returns every difficulty step, easiest first
+func BotDifficulties() []BotDifficulty {
+	return append([]BotDifficulty(nil), botDifficulties...)
+}
+
+const (
+	// BotDifficultyWindow is how many recent kills and deaths the practice
+	// K/D is taken over
+	BotDifficultyWindow = 8
+
+	// BotDifficultyMinEvents is how many kills and deaths must be seen at the
+	// current difficulty before it changes again
+	BotDifficultyMinEvents = 4
+
+	// BotDifficultyRaiseKD is the rolling K/D at or above which bots get harder
+	BotDifficultyRaiseKD = 2.0
+
+	// BotDifficultyLowerKD is the rolling K/D at or below which bots get easier
+	BotDifficultyLowerKD = 0.5
+)
+
+// AdaptiveBotDifficulty scales practice bot difficulty with a player's rolling
+// K/D over the session: a player who keeps winning fights faces harder bots,
+// one who keeps losing faces easier ones. Each change starts a fresh window so
+// the next one is judged against the new difficulty. (thread-safe)
+type AdaptiveBotDifficulty struct {
+	level  int
+	recent []bool // Recent fights, oldest first; true for a kill
+	mu     sync.Mutex
+}
+
+// NewAdaptiveBotDifficulty starts at the normal difficulty
+func NewAdaptiveBotDifficulty() *AdaptiveBotDifficulty {
+	return &AdaptiveBotDifficulty{level: 1}
+}
+
+// RecordKill counts a bot the player killed. Returns true if the difficulty changed.
+func (d *AdaptiveBotDifficulty) RecordKill() bool {
+	return d.record(true)
+}
+
+// RecordDeath counts a death to a bot. Returns true if the difficulty changed.
+func (d *AdaptiveBotDifficulty) RecordDeath() bool {
+	return d.record(false)
+}
+
+func (d *AdaptiveBotDifficulty) record(kill bool) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.recent = append(d.recent, kill)
+	if len(d.recent) > BotDifficultyWindow {
+		d.recent = d.recent[1:]
+	}
+	if len(d.recent) < BotDifficultyMinEvents {
+		return false
+	}
+
+	kd := d.kdLocked()
+	switch {
+	case kd >= BotDifficultyRaiseKD && d.level < len(botDifficulties)-1:
+		d.level++
+	case kd <= BotDifficultyLowerKD && d.level > 0:
+		d.level--
+	default:
+		return false
+	}
+	d.recent = nil
+	return true
+}
+
+// Difficulty returns the current difficulty
+func (d *AdaptiveBotDifficulty) Difficulty() BotDifficulty {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return botDifficulties[d.level]
+}
+
+// KD returns the K/D over the current window, counting zero deaths as one
+func (d *AdaptiveBotDifficulty) KD() float64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.kdLocked()
+}
+
+func (d *AdaptiveBotDifficulty) kdLocked() float64 {
+	kills, deaths := 0, 0
+	for _, kill := range d.recent {
+		if kill {
+			kills++
+		} else {
+			deaths++
+		}
+	}
+	return float64(kills) / float64(max(deaths, 1))
+}
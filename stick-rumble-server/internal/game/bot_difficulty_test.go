@@ -0,0 +1,49 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdaptiveBotDifficultyRaisesOnWinningStreak(t *testing.T) {
+	d := NewAdaptiveBotDifficulty()
+	assert.Equal(t, "normal", d.Difficulty().Name)
+
+	for i := 0; i < BotDifficultyMinEvents-1; i++ {
+		assert.False(t, d.RecordKill(), "too few fights to judge")
+	}
+	assert.True(t, d.RecordKill())
+	assert.Equal(t, "hard", d.Difficulty().Name)
+	assert.Zero(t, d.KD(), "a change starts a fresh window")
+
+	for i := 0; i < 2*BotDifficultyMinEvents; i++ {
+		d.RecordKill()
+	}
+	assert.Equal(t, "expert", d.Difficulty().Name, "the hardest step is the ceiling")
+}
+
+func TestAdaptiveBotDifficultyLowersOnLosingStreak(t *testing.T) {
+	d := NewAdaptiveBotDifficulty()
+
+	d.RecordKill()
+	d.RecordDeath()
+	d.RecordDeath()
+	assert.True(t, d.RecordDeath(), "1 kill to 3 deaths is below the lower K/D")
+	assert.Equal(t, "easy", d.Difficulty().Name)
+
+	for i := 0; i < 2*BotDifficultyMinEvents; i++ {
+		assert.False(t, d.RecordDeath(), "the easiest step is the floor")
+	}
+}
+
+func TestAdaptiveBotDifficultyHoldsOnEvenRecord(t *testing.T) {
+	d := NewAdaptiveBotDifficulty()
+
+	for i := 0; i < 2*BotDifficultyWindow; i++ {
+		assert.False(t, d.RecordKill())
+		assert.False(t, d.RecordDeath())
+	}
+	assert.Equal(t, "normal", d.Difficulty().Name)
+	assert.Equal(t, 1.0, d.KD())
+}
@@ -0,0 +1,63 @@
+package game
+
+import (
+	"fmt"
+	"sync"
+)
+
+// optionalBroadcastTypes are cosmetic-only broadcasts a client may opt out of.
+// Skipping them never leaves a client with wrong game state: melee damage and
+// health still arrive through player:damaged and state updates. Cosmetic
+// broadcasts such as milestones belong here when they are added.
+var optionalBroadcastTypes = map[string]bool{
+	"melee:hit":     true,
+	"player:emoted": true,
+}
+
+// IsOptionalBroadcastType reports whether clients may opt out of messageType
+func IsOptionalBroadcastType(messageType string) bool {
+	return optionalBroadcastTypes[messageType]
+}
+
+// BroadcastFilter holds the broadcast types one player opted out of, so
+// minimal clients can save the bandwidth of cosmetic-only messages.
+type BroadcastFilter struct {
+	optOut map[string]bool
+	mu     sync.RWMutex
+}
+
+// NewBroadcastFilter creates a filter that allows every message
+func NewBroadcastFilter() *BroadcastFilter {
+	return &BroadcastFilter{optOut: make(map[string]bool)}
+}
+
+// SetOptOut replaces the opted-out broadcast types. Every type must be
+// optional; on error the previous preferences are kept.
+func (f *BroadcastFilter) SetOptOut(messageTypes []string) error {
+	optOut := make(map[string]bool, len(messageTypes))
+	for _, messageType := range messageTypes {
+		if !IsOptionalBroadcastType(messageType) {
+			return fmt.Errorf("broadcast type %q cannot be opted out of", messageType)
+		}
+		optOut[messageType] = true
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.optOut = optOut
+	return nil
+}
+
+// Allows reports whether a broadcast of messageType should be delivered. A nil
+// filter allows everything.
+func (f *BroadcastFilter) Allows(messageType string) bool {
+	if f == nil {
+		return true
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return !f.optOut[messageType]
+}
@@ -0,0 +1,42 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcastFilterOptOut(t *testing.T) {
+	filter := NewBroadcastFilter()
+	assert.True(t, filter.Allows("melee:hit"))
+
+	require.NoError(t, filter.SetOptOut([]string{"melee:hit"}))
+	assert.False(t, filter.Allows("melee:hit"))
+	assert.True(t, filter.Allows("player:damaged"))
+
+	assert.Error(t, filter.SetOptOut([]string{"player:damaged"}), "gameplay broadcasts are not optional")
+	assert.False(t, filter.Allows("melee:hit"), "rejected preferences keep the previous ones")
+
+	require.NoError(t, filter.SetOptOut(nil))
+	assert.True(t, filter.Allows("melee:hit"))
+
+	var unset *BroadcastFilter
+	assert.True(t, unset.Allows("melee:hit"))
+}
+
+func TestBroadcastTypeSkipsOptedOutPlayers(t *testing.T) {
+	room := NewRoom()
+	full := NewPlayer("full", make(chan []byte, 10))
+	minimal := NewPlayer("minimal", make(chan []byte, 10))
+	require.NoError(t, minimal.Broadcasts.SetOptOut([]string{"melee:hit"}))
+	room.AddPlayer(full)
+	room.AddPlayer(minimal)
+
+	room.BroadcastType("melee:hit", []byte(`{"type":"melee:hit"}`), "")
+	room.BroadcastType("player:damaged", []byte(`{"type":"player:damaged"}`), "")
+
+	assert.Len(t, full.SendChan, 2)
+	assert.Len(t, minimal.SendChan, 1)
+	assert.Equal(t, []byte(`{"type":"player:damaged"}`), <-minimal.SendChan)
+}
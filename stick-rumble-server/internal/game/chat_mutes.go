@@ -0,0 +1,39 @@
+package game
+
+import "sync"
+
+// MuteList holds the players one player muted in chat. Muting is private:
+// the muted player is not told and keeps seeing everyone's messages.
+type MuteList struct {
+	muted map[string]bool
+	mu    sync.RWMutex
+}
+
+// NewMuteList creates a list with nobody muted
+func NewMuteList() *MuteList {
+	return &MuteList{muted: make(map[string]bool)}
+}
+
+// Set mutes or unmutes playerID
+func (m *MuteList) Set(playerID string, muted bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if muted {
+		m.muted[playerID] = true
+		return
+	}
+	delete(m.muted, playerID)
+}
+
+// Mutes reports whether playerID is muted. A nil list mutes nobody.
+func (m *MuteList) Mutes(playerID string) bool {
+	if m == nil {
+		return false
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.muted[playerID]
+}
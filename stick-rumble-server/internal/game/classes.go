@@ -0,0 +1,164 @@
+package game
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Selectable character classes
+const (
+	ClassHeavy  = "heavy"
+	ClassScout  = "scout"
+	ClassGunner = "gunner"
+)
+
+// PlayerClass is a character class's base stats. Players who never pick a
+// class use the standard stats: PlayerMaxHealth, normal speed and a pistol.
+type PlayerClass struct {
+	Name            string  `json:"name"`
+	MaxHealth       int     `json:"maxHealth"`
+	SpeedMultiplier float64 `json:"speedMultiplier"` // Scales walk and sprint speed
+	StartingWeapon  string  `json:"startingWeapon"`  // Weapon type equipped on every spawn
+	RollCooldown    float64 `json:"rollCooldown"`    // Seconds for one dodge roll charge to recharge
+	RollCharges     int     `json:"rollCharges"`     // Dodge rolls that can be chained before waiting
+}
+
+// standardClass is the stats of a player without a class
+var standardClass = PlayerClass{
+	MaxHealth:       PlayerMaxHealth,
+	SpeedMultiplier: 1.0,
+	StartingWeapon:  "pistol",
+	RollCooldown:    DodgeRollCooldown,
+	RollCharges:     1,
+}
+
+// playerClasses is the class table, in the order clients list them
+var playerClasses = []PlayerClass{
+	{Name: ClassHeavy, MaxHealth: 150, SpeedMultiplier: 0.85, StartingWeapon: "shotgun", RollCooldown: 4.0, RollCharges: 1},
+	{Name: ClassScout, MaxHealth: 75, SpeedMultiplier: 1.2, StartingWeapon: "pistol", RollCooldown: DodgeRollCooldown, RollCharges: 2},
+	{Name: ClassGunner, MaxHealth: 100, SpeedMultiplier: 0.95, StartingWeapon: "ak47", RollCooldown: DodgeRollCooldown, RollCharges: 1},
+}
+
+// PlayerClasses returns a copy of the class table
+func PlayerClasses() []PlayerClass {
+	return append([]PlayerClass(nil), playerClasses...)
+}
+
+// LookupPlayerClass returns the class with the given name
+func LookupPlayerClass(name string) (PlayerClass, error) {
+	for _, class := range playerClasses {
+		if class.Name == name {
+			return class, nil
+		}
+	}
+	return PlayerClass{}, fmt.Errorf("unknown class: %q", name)
+}
+
+// classOrStandard returns the named class, or the standard stats for an
+// empty or unknown name
+func classOrStandard(name string) PlayerClass {
+	class, err := LookupPlayerClass(name)
+	if err != nil {
+		return standardClass
+	}
+	return class
+}
+
+// maxClassSpeedMultiplier returns the fastest class's speed multiplier
+func maxClassSpeedMultiplier() float64 {
+	fastest := standardClass.SpeedMultiplier
+	for _, class := range playerClasses {
+		fastest = max(fastest, class.SpeedMultiplier)
+	}
+	return fastest
+}
+
+// rollRecharge returns the time for one dodge roll charge to come back
+func (c PlayerClass) rollRecharge() time.Duration {
+	return time.Duration(c.RollCooldown * float64(time.Second))
+}
+
+// newStartingWeapon creates the weapon a class spawns with
+func (c PlayerClass) newStartingWeapon() *Weapon {
+	weapon, err := CreateWeaponByType(c.StartingWeapon)
+	if err != nil {
+		return NewPistol()
+	}
+	return weapon
+}
+
+// SetClass sets the class the player spawns as next. The current life keeps
+// its stats; Respawn switches to the new class. (thread-safe)
+func (p *PlayerState) SetClass(class PlayerClass) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.nextClass = class
+}
+
+// spawnAs switches the player to a class at full health. Used for the first
+// spawn; Respawn applies the next class itself.
+func (p *PlayerState) spawnAs(class PlayerClass) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.class, p.nextClass = class, class
+	p.Health = class.MaxHealth
+}
+
+// Class returns the player's current class (thread-safe)
+func (p *PlayerState) Class() PlayerClass {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.class
+}
+
+// MaxHealth returns the player's maximum health for its current class (thread-safe)
+func (p *PlayerState) MaxHealth() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.class.MaxHealth
+}
+
+// SpeedMultiplier returns the player's class speed multiplier (thread-safe)
+func (p *PlayerState) SpeedMultiplier() float64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.class.SpeedMultiplier
+}
+
+// Loadout holds the class a connected player picked with player:loadout. It
+// outlives the player's game state, so the choice carries into the next match.
+type Loadout struct {
+	class string
+	mu    sync.RWMutex
+}
+
+// NewLoadout creates a loadout without a class
+func NewLoadout() *Loadout {
+	return &Loadout{}
+}
+
+// SetClass picks a class; on error the previous choice is kept
+func (l *Loadout) SetClass(name string) error {
+	if _, err := LookupPlayerClass(name); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.class = name
+	return nil
+}
+
+// Class returns the picked class name, empty if none. A nil loadout has no class.
+func (l *Loadout) Class() string {
+	if l == nil {
+		return ""
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.class
+}
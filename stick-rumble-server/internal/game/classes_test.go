@@ -0,0 +1,122 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupPlayerClass(t *testing.T) {
+	heavy, err := LookupPlayerClass(ClassHeavy)
+	require.NoError(t, err)
+	assert.Equal(t, 150, heavy.MaxHealth)
+	assert.Equal(t, "shotgun", heavy.StartingWeapon)
+
+	_, err = LookupPlayerClass("medic")
+	assert.Error(t, err)
+	_, err = LookupPlayerClass("")
+	assert.Error(t, err)
+
+	for _, class := range PlayerClasses() {
+		_, err := CreateWeaponByType(class.StartingWeapon)
+		assert.NoError(t, err, "class %s starts with a real weapon", class.Name)
+	}
+}
+
+func TestAddPlayerAsUsesClassStats(t *testing.T) {
+	gs := NewGameServerWithClock(nil, NewManualClock(time.Now()))
+
+	heavy := gs.AddPlayerAs("heavy", ClassHeavy)
+	snapshot, _ := gs.GetPlayerState("heavy")
+	assert.Equal(t, 150, snapshot.Health)
+	assert.Equal(t, 150, snapshot.MaxHealth)
+	assert.Equal(t, ClassHeavy, snapshot.Class)
+	assert.Equal(t, "Shotgun", snapshot.WeaponType)
+	assert.Equal(t, 0.85, heavy.SpeedMultiplier())
+
+	gs.AddPlayer("plain")
+	snapshot, _ = gs.GetPlayerState("plain")
+	assert.Equal(t, PlayerMaxHealth, snapshot.MaxHealth)
+	assert.Empty(t, snapshot.Class)
+	assert.Equal(t, "Pistol", snapshot.WeaponType)
+}
+
+func TestSetPlayerClassAppliesOnRespawn(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	gs := NewGameServerWithClock(nil, clock)
+	player := gs.AddPlayer("p1")
+
+	assert.Error(t, gs.SetPlayerClass("p1", "medic"))
+	assert.Error(t, gs.SetPlayerClass("ghost", ClassScout))
+	require.NoError(t, gs.SetPlayerClass("p1", ClassGunner))
+	assert.Equal(t, PlayerMaxHealth, player.MaxHealth(), "the current life keeps its stats")
+	assert.Equal(t, "Pistol", gs.GetWeaponState("p1").Weapon.Name)
+
+	player.MarkDead()
+	player.RequestRespawn()
+	clock.Advance(time.Duration(RespawnDelay * float64(time.Second)))
+	gs.checkRespawns()
+
+	snapshot, _ := gs.GetPlayerState("p1")
+	assert.Equal(t, ClassGunner, snapshot.Class)
+	assert.Equal(t, 100, snapshot.Health)
+	assert.Equal(t, "AK47", snapshot.WeaponType)
+	assert.Equal(t, 0.95, player.SpeedMultiplier())
+}
+
+func TestClassMaxHealthCapsHealing(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	player := NewPlayerStateWithClock("p1", clock)
+	player.spawnAs(playerClasses[0]) // heavy
+	player.TakeDamage(10)
+
+	clock.Advance(time.Duration(HealthRegenerationDelay * float64(time.Second)))
+	player.ApplyRegeneration(clock.Now(), 1.0)
+	assert.Equal(t, 150, player.Health, "heavies regenerate past the standard cap")
+
+	scout := NewPlayerStateWithClock("p2", clock)
+	scout.spawnAs(playerClasses[1])
+	scout.AddUltimateCharge(UltimateMaxCharge)
+	_, reason := scout.activateUltimate(DefaultUltimateEffect())
+	require.Empty(t, reason)
+	assert.Equal(t, 75, scout.Health, "an ultimate heal never passes the class max")
+}
+
+func TestClassSpeedScalesMovementValidation(t *testing.T) {
+	physics := NewPhysics()
+	oldPos := Vector2{X: 500, Y: 500}
+	velocity := Vector2{X: SprintSpeed * 1.2, Y: 0}
+	newPos := Vector2{X: oldPos.X + velocity.X*0.1, Y: oldPos.Y}
+
+	assert.False(t, physics.ValidatePlayerMovement(oldPos, newPos, velocity, 0.1, false, true, false, 1).Valid)
+	assert.True(t, physics.ValidatePlayerMovement(oldPos, newPos, velocity, 0.1, false, true, false, 1.2).Valid)
+}
+
+func TestScoutChainsTwoRolls(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	gs := NewGameServerWithClock(nil, clock)
+	scout := gs.AddPlayerAs("scout", ClassScout)
+	gs.AddPlayer("plain")
+
+	for i := 0; i < 2; i++ {
+		require.True(t, scout.CanDodgeRoll(), "roll %d", i+1)
+		scout.StartDodgeRoll(Vector2{X: 1})
+		scout.EndDodgeRoll()
+	}
+	assert.False(t, scout.CanDodgeRoll(), "both charges are spent")
+
+	states := gs.AbilityStates("scout")
+	require.Len(t, states, 2)
+	assert.Equal(t, AbilityState{Ability: CooldownShoot, Charges: 1, MaxCharges: 1}, states[0])
+	assert.Equal(t, AbilityState{Ability: CooldownRoll, RemainingMs: 3000, Charges: 0, MaxCharges: 2}, states[1])
+
+	clock.Advance(time.Duration(DodgeRollCooldown * float64(time.Second)))
+	gs.updateStaminaRegeneration(DodgeRollCooldown) // Two rolls also spent most of the scout's stamina
+	assert.True(t, scout.CanDodgeRoll())
+
+	plain := gs.AbilityStates("plain")
+	assert.Equal(t, AbilityState{Ability: CooldownRoll, Charges: 1, MaxCharges: 1}, plain[1])
+	assert.Nil(t, gs.AbilityStates("ghost"))
+}
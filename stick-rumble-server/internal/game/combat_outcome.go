@@ -4,21 +4,48 @@ type ProjectileHitOutcome struct {
 	Hit         HitEvent
 	Damage      int
 	NewHealth   int
+	NewShield   int // Victim's shield after the hit absorbed what it could
 	Killed      bool
 	KillerKills int
 	KillerXP    int
+	KillStreak  KillStreak // The killer's streak after this kill
+	Impulse     Vector2    // Velocity the hit added to a surviving victim
 }
 
 func (gs *GameServer) ProcessProjectileHit(hit HitEvent) (ProjectileHitOutcome, bool) {
-	outcome := ProjectileHitOutcome{
-		Hit: hit,
-	}
-
 	gs.weaponMu.RLock()
 	weaponState := gs.weaponStates[hit.AttackerID]
 	gs.weaponMu.RUnlock()
 	if weaponState == nil {
-		return outcome, false
+		return ProjectileHitOutcome{Hit: hit}, false
+	}
+
+	source := DamageSourceProjectile
+	if hit.ProjectileID == "hitscan" {
+		source = DamageSourceHitscan
+	}
+	damage := weaponState.Weapon.Damage
+	if hit.Damage > 0 {
+		damage = hit.Damage
+	}
+	gs.projectileManager.RemoveProjectile(hit.ProjectileID)
+	outcome, ok := gs.damageVictim(hit, weaponState.Weapon.Name, source, damage)
+	if ok && !outcome.Killed && weaponState.Weapon.HitImpulse > 0 {
+		if victim, exists := gs.world.GetPlayer(hit.VictimID); exists {
+			outcome.Impulse = gs.physics.ApplyImpulse(victim, Vector2{
+				X: hit.Direction.X * weaponState.Weapon.HitImpulse,
+				Y: hit.Direction.Y * weaponState.Weapon.HitImpulse,
+			})
+		}
+	}
+	return outcome, ok
+}
+
+// damageVictim deals damage from weapon to the hit's victim, charges the
+// attacker's ultimate and credits the kill if the victim dies
+func (gs *GameServer) damageVictim(hit HitEvent, weapon string, source DamageSource, damage int) (ProjectileHitOutcome, bool) {
+	outcome := ProjectileHitOutcome{
+		Hit: hit,
 	}
 
 	victim, exists := gs.world.GetPlayer(hit.VictimID)
@@ -26,12 +53,20 @@ func (gs *GameServer) ProcessProjectileHit(hit HitEvent) (ProjectileHitOutcome,
 		return outcome, false
 	}
 
-	outcome.Damage = weaponState.Weapon.Damage
+	outcome.Damage = gs.outgoingDamage(DamageEvent{
+		AttackerID: hit.AttackerID,
+		VictimID:   hit.VictimID,
+		Weapon:     weapon,
+		Source:     source,
+		Damage:     damage,
+	})
 	victim.TakeDamage(outcome.Damage)
-	gs.projectileManager.RemoveProjectile(hit.ProjectileID)
+	gs.world.Spawns().RecordCombat(victim.GetPosition())
+	gs.ChargeUltimate(hit.AttackerID, float64(outcome.Damage)*UltimateChargePerDamage)
 
 	victimSnapshot := victim.Snapshot()
 	outcome.NewHealth = victimSnapshot.Health
+	outcome.NewShield = victimSnapshot.Shield
 	if victimSnapshot.Health > 0 {
 		return outcome, true
 	}
@@ -39,13 +74,12 @@ func (gs *GameServer) ProcessProjectileHit(hit HitEvent) (ProjectileHitOutcome,
 	victim.MarkDead()
 	victim.IncrementDeaths()
 
-	attacker, attackerExists := gs.world.GetPlayer(hit.AttackerID)
-	if attackerExists && attacker != nil {
-		attacker.IncrementKills()
-		attacker.AddXP(KillXPReward)
+	if streak, credited := gs.CreditKill(hit.AttackerID, hit.VictimID); credited {
+		attacker, _ := gs.world.GetPlayer(hit.AttackerID)
 		attackerSnapshot := attacker.Snapshot()
 		outcome.KillerKills = attackerSnapshot.Kills
 		outcome.KillerXP = attackerSnapshot.XP
+		outcome.KillStreak = streak
 	}
 
 	outcome.Killed = true
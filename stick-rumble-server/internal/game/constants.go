@@ -16,6 +16,10 @@ const (
 
 	// Deceleration is the rate at which players decelerate when no input
 	Deceleration = 6000.0
+
+	// HitImpulseMaxSpeed caps a player's speed after a ranged hit's impulse in
+	// pixels per second; movement then slows it back down like any other speed
+	HitImpulseMaxSpeed = 600.0
 )
 
 // Arena bounds - must match client-side values in src/shared/constants.ts
@@ -59,9 +63,14 @@ const (
 
 // Respawn system
 const (
-	// RespawnDelay is the time in seconds before a player respawns after death
+	// RespawnDelay is the time in seconds after death before a player can
+	// respawn
 	RespawnDelay = 3.0
 
+	// AutoRespawnDelay is the time in seconds after death at which a player
+	// who has not asked to respawn is respawned anyway
+	AutoRespawnDelay = 10.0
+
 	// SpawnInvulnerabilityDuration is the time in seconds of spawn protection
 	SpawnInvulnerabilityDuration = 2.0
 )
@@ -70,6 +79,30 @@ const (
 const (
 	// KillXPReward is the amount of XP awarded for each kill
 	KillXPReward = 100
+
+	// AssistWindowSeconds is how recently, in match time, a player must have
+	// damaged the victim to earn an assist on someone else's kill
+	AssistWindowSeconds = 5
+
+	// AssistXPReward is the XP for an assist, half a kill's worth
+	AssistXPReward = 50
+
+	// KillStreakBonusXPStep is the extra XP for each kill in a streak past the
+	// first, so the third kill without dying earns 2 steps
+	KillStreakBonusXPStep = 25
+
+	// KillStreakMaxBonusXP caps the streak bonus on a single kill
+	KillStreakMaxBonusXP = 150
+
+	// MultiKillWindow is the time in seconds a kill may follow the previous
+	// one and still chain into a double or triple kill
+	MultiKillWindow = 4.0
+
+	// MultiKillBonusXPStep is the extra XP for each chained kill past the first
+	MultiKillBonusXPStep = 50
+
+	// MultiKillMaxBonusXP caps the multi-kill bonus on a single kill
+	MultiKillMaxBonusXP = 100
 )
 
 // Health regeneration
@@ -90,6 +123,33 @@ const (
 	WeaponPickupRadius = 24.0
 )
 
+// Shield pickup system
+const (
+	// ShieldMax is the most shield a player can carry; shield never regenerates
+	ShieldMax = 50
+
+	// ShieldPickupAmount is the shield granted by one shield crate
+	ShieldPickupAmount = 50
+
+	// ShieldRespawnDelay is the time in seconds before a shield crate respawns after pickup
+	ShieldRespawnDelay = 30.0
+
+	// ShieldPickupRadius is the distance in pixels at which walking over a shield crate picks it up
+	ShieldPickupRadius = 24.0
+)
+
+// Health pack system
+const (
+	// HealthPackAmount is the health one pack restores, capped at the player's max health
+	HealthPackAmount = 50
+
+	// HealthPackRespawnDelay is the time in seconds before a health pack respawns after pickup
+	HealthPackRespawnDelay = 20.0
+
+	// HealthPackPickupRadius is the distance in pixels at which walking over a health pack picks it up
+	HealthPackPickupRadius = 24.0
+)
+
 // Dodge roll system
 const (
 	// DodgeRollDuration is the total duration of a dodge roll in seconds
@@ -106,4 +166,19 @@ const (
 
 	// DodgeRollInvincibilityDuration is the duration of invincibility frames in seconds
 	DodgeRollInvincibilityDuration = 0.2
+
+	// DodgeRollStaminaCost is the stamina a dodge roll spends
+	DodgeRollStaminaCost = 35.0
+)
+
+// Stamina system
+const (
+	// MaxStamina is a player's full stamina
+	MaxStamina = 100.0
+
+	// StaminaRegenerationDelay is the time in seconds before stamina starts regenerating after it was spent
+	StaminaRegenerationDelay = 1.0
+
+	// StaminaRegenerationRate is the amount of stamina restored per second
+	StaminaRegenerationRate = 25.0
 )
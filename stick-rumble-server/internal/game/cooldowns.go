@@ -0,0 +1,194 @@
+package game
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Cooldown abilities tracked by the cooldown manager. A new ability needs only
+// a name here and a Start call where it is used.
+const (
+	CooldownShoot = "shoot" // Ranged weapon fire interval
+	CooldownMelee = "melee" // Melee weapon swing interval
+	CooldownRoll  = "roll"  // Dodge roll
+	CooldownPing  = "ping"  // Tactical map pings
+	CooldownEmote = "emote" // Emotes and quick-chat
+)
+
+// AbilityState is a player's cooldown for one ability as sent to its client,
+// so clients display the server's timers instead of simulating their own
+type AbilityState struct {
+	Ability     string `json:"ability"`
+	RemainingMs int64  `json:"remainingMs"` // Until the next charge is back; 0 when all are
+	Charges     int    `json:"charges"`     // Uses available now
+	MaxCharges  int    `json:"maxCharges"`
+}
+
+// CooldownEntry is one running cooldown in a debug dump
+type CooldownEntry struct {
+	PlayerID  string
+	Ability   string
+	Remaining time.Duration
+}
+
+// CooldownManager holds every player's ability cooldowns, so abilities share
+// one API instead of each keeping its own last-used timestamp
+type CooldownManager struct {
+	clock   Clock
+	readyAt map[string]map[string]time.Time // player ID -> ability -> ready again at
+	mu      sync.Mutex
+}
+
+func NewCooldownManager(clock Clock) *CooldownManager {
+	return &CooldownManager{
+		clock:   clock,
+		readyAt: make(map[string]map[string]time.Time),
+	}
+}
+
+// Start puts a player's ability on cooldown for duration from now
+func (m *CooldownManager) Start(playerID, ability string, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	abilities, ok := m.readyAt[playerID]
+	if !ok {
+		abilities = make(map[string]time.Time)
+		m.readyAt[playerID] = abilities
+	}
+	abilities[ability] = m.clock.Now().Add(duration)
+}
+
+// Remaining returns how long until a player's ability is ready; zero if it is
+func (m *CooldownManager) Remaining(playerID, ability string) time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	readyAt, ok := m.readyAt[playerID][ability]
+	if !ok {
+		return 0
+	}
+	remaining := readyAt.Sub(m.clock.Now())
+	if remaining <= 0 {
+		return 0
+	}
+	return remaining
+}
+
+// Ready reports whether a player's ability is off cooldown
+func (m *CooldownManager) Ready(playerID, ability string) bool {
+	return m.Remaining(playerID, ability) == 0
+}
+
+// Spend uses one charge of an ability that holds up to maxCharges, each
+// recharging in turn over recharge. The stored ready time is when every
+// charge is back, so Remaining and Dump report the time to a full recharge.
+func (m *CooldownManager) Spend(playerID, ability string, recharge time.Duration, maxCharges int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	abilities, ok := m.readyAt[playerID]
+	if !ok {
+		abilities = make(map[string]time.Time)
+		m.readyAt[playerID] = abilities
+	}
+	now := m.clock.Now()
+	readyAt := abilities[ability]
+	if readyAt.Before(now) {
+		readyAt = now
+	}
+	// Spending with no charge left cannot push recharge past maxCharges deep
+	abilities[ability] = minTime(readyAt.Add(recharge), now.Add(time.Duration(maxCharges)*recharge))
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// Charges returns how many charges of a Spend-tracked ability are available
+// and how long until the next one comes back (zero when all are available)
+func (m *CooldownManager) Charges(playerID, ability string, recharge time.Duration, maxCharges int) (int, time.Duration) {
+	untilFull := m.Remaining(playerID, ability)
+	if untilFull == 0 || recharge <= 0 {
+		return maxCharges, 0
+	}
+	missing := int((untilFull + recharge - 1) / recharge) // Rounded up
+	nextCharge := untilFull - time.Duration(missing-1)*recharge
+	return max(maxCharges-missing, 0), nextCharge
+}
+
+// Reset makes a player's ability ready at once
+func (m *CooldownManager) Reset(playerID, ability string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.readyAt[playerID], ability)
+}
+
+// RemovePlayer drops all of a player's cooldowns
+func (m *CooldownManager) RemovePlayer(playerID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.readyAt, playerID)
+}
+
+// Dump lists the running cooldowns sorted by player and ability, for debugging
+func (m *CooldownManager) Dump() []CooldownEntry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.clock.Now()
+	entries := make([]CooldownEntry, 0)
+	for playerID, abilities := range m.readyAt {
+		for ability, readyAt := range abilities {
+			if remaining := readyAt.Sub(now); remaining > 0 {
+				entries = append(entries, CooldownEntry{PlayerID: playerID, Ability: ability, Remaining: remaining})
+			}
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].PlayerID != entries[j].PlayerID {
+			return entries[i].PlayerID < entries[j].PlayerID
+		}
+		return entries[i].Ability < entries[j].Ability
+	})
+	return entries
+}
+
+// For returns a handle on one player's cooldowns
+func (m *CooldownManager) For(playerID string) PlayerCooldowns {
+	return PlayerCooldowns{manager: m, playerID: playerID}
+}
+
+// PlayerCooldowns is a player's view of a CooldownManager
+type PlayerCooldowns struct {
+	manager  *CooldownManager
+	playerID string
+}
+
+func (c PlayerCooldowns) Start(ability string, duration time.Duration) {
+	c.manager.Start(c.playerID, ability, duration)
+}
+
+func (c PlayerCooldowns) Remaining(ability string) time.Duration {
+	return c.manager.Remaining(c.playerID, ability)
+}
+
+func (c PlayerCooldowns) Ready(ability string) bool {
+	return c.manager.Ready(c.playerID, ability)
+}
+
+func (c PlayerCooldowns) Spend(ability string, recharge time.Duration, maxCharges int) {
+	c.manager.Spend(c.playerID, ability, recharge, maxCharges)
+}
+
+func (c PlayerCooldowns) Charges(ability string, recharge time.Duration, maxCharges int) (int, time.Duration) {
+	return c.manager.Charges(c.playerID, ability, recharge, maxCharges)
+}
+
+func (c PlayerCooldowns) Reset(ability string) {
+	c.manager.Reset(c.playerID, ability)
+}
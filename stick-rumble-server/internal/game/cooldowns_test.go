@@ -0,0 +1,110 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCooldownManagerTracksAbilitiesPerPlayer(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	cooldowns := NewCooldownManager(clock)
+
+	assert.True(t, cooldowns.Ready("p1", CooldownRoll), "an unused ability is ready")
+
+	cooldowns.Start("p1", CooldownRoll, 3*time.Second)
+	assert.False(t, cooldowns.Ready("p1", CooldownRoll))
+	assert.True(t, cooldowns.Ready("p1", CooldownShoot), "abilities cool down independently")
+	assert.True(t, cooldowns.Ready("p2", CooldownRoll), "players cool down independently")
+
+	clock.Advance(time.Second)
+	assert.Equal(t, 2*time.Second, cooldowns.Remaining("p1", CooldownRoll))
+
+	clock.Advance(2 * time.Second)
+	assert.True(t, cooldowns.Ready("p1", CooldownRoll))
+	assert.Zero(t, cooldowns.Remaining("p1", CooldownRoll))
+}
+
+func TestCooldownManagerRechargesChargesInTurn(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	cooldowns := NewCooldownManager(clock)
+
+	charges, next := cooldowns.Charges("p1", CooldownRoll, 3*time.Second, 2)
+	assert.Equal(t, 2, charges)
+	assert.Zero(t, next)
+
+	cooldowns.Spend("p1", CooldownRoll, 3*time.Second, 2)
+	clock.Advance(time.Second)
+	cooldowns.Spend("p1", CooldownRoll, 3*time.Second, 2)
+	charges, next = cooldowns.Charges("p1", CooldownRoll, 3*time.Second, 2)
+	assert.Zero(t, charges)
+	assert.Equal(t, 2*time.Second, next, "the first charge keeps recharging while the second is spent")
+	assert.Equal(t, 5*time.Second, cooldowns.Remaining("p1", CooldownRoll), "remaining is the time to a full recharge")
+
+	// Spending with nothing left cannot queue more than maxCharges of recharge
+	cooldowns.Spend("p1", CooldownRoll, 3*time.Second, 2)
+	assert.Equal(t, 6*time.Second, cooldowns.Remaining("p1", CooldownRoll))
+
+	clock.Advance(3 * time.Second)
+	charges, next = cooldowns.Charges("p1", CooldownRoll, 3*time.Second, 2)
+	assert.Equal(t, 1, charges)
+	assert.Equal(t, 3*time.Second, next)
+}
+
+func TestCooldownManagerResetAndRemove(t *testing.T) {
+	cooldowns := NewCooldownManager(NewManualClock(time.Now()))
+	cooldowns.Start("p1", CooldownShoot, time.Second)
+	cooldowns.Start("p1", CooldownRoll, time.Second)
+
+	cooldowns.Reset("p1", CooldownShoot)
+	assert.True(t, cooldowns.Ready("p1", CooldownShoot))
+	assert.False(t, cooldowns.Ready("p1", CooldownRoll))
+
+	cooldowns.RemovePlayer("p1")
+	assert.True(t, cooldowns.Ready("p1", CooldownRoll))
+}
+
+func TestCooldownManagerDumpListsRunningCooldowns(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	cooldowns := NewCooldownManager(clock)
+	cooldowns.Start("p2", CooldownShoot, time.Second)
+	cooldowns.Start("p1", CooldownRoll, 3*time.Second)
+	cooldowns.Start("p1", CooldownMelee, 2*time.Second)
+	cooldowns.Start("p3", CooldownShoot, 0)
+
+	assert.Equal(t, []CooldownEntry{
+		{PlayerID: "p1", Ability: CooldownMelee, Remaining: 2 * time.Second},
+		{PlayerID: "p1", Ability: CooldownRoll, Remaining: 3 * time.Second},
+		{PlayerID: "p2", Ability: CooldownShoot, Remaining: time.Second},
+	}, cooldowns.Dump())
+
+	clock.Advance(5 * time.Second)
+	assert.Empty(t, cooldowns.Dump())
+}
+
+func TestGameServerSharesPlayerCooldowns(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	gs := NewGameServerWithClock(nil, clock)
+	player := gs.AddPlayer("p1")
+
+	require.True(t, gs.PlayerShoot("p1", 0, clock.Now().UnixMilli()).Success)
+	require.True(t, player.CanDodgeRoll())
+	player.StartDodgeRoll(Vector2{X: 1})
+	player.EndDodgeRoll()
+
+	abilities := make([]string, 0)
+	for _, entry := range gs.DumpCooldowns() {
+		assert.Equal(t, "p1", entry.PlayerID)
+		abilities = append(abilities, entry.Ability)
+	}
+	assert.Equal(t, []string{CooldownRoll, CooldownShoot}, abilities)
+
+	// A picked-up weapon is ready to fire at once
+	gs.SetWeaponState("p1", NewWeaponState(NewUzi()))
+	assert.True(t, gs.GetWeaponState("p1").CanShoot())
+
+	gs.RemovePlayer("p1")
+	assert.Empty(t, gs.DumpCooldowns())
+}
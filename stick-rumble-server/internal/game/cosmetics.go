@@ -0,0 +1,101 @@
+package game
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Projectile trail effects a player can equip. Effects only change how other
+// clients draw a projectile; they never affect its flight or damage.
+const (
+	TrailEffectDefault = "default"
+	TrailEffectTracer  = "tracer"
+	TrailEffectEmber   = "ember"
+	TrailEffectNeon    = "neon"
+	TrailEffectSmoke   = "smoke"
+)
+
+// trailEffects lists every known trail effect and whether all players own it
+var trailEffects = map[string]bool{
+	TrailEffectDefault: true,
+	TrailEffectTracer:  true,
+	TrailEffectEmber:   false,
+	TrailEffectNeon:    false,
+	TrailEffectSmoke:   false,
+}
+
+// IsKnownTrailEffect reports whether effectID is in the trail effect catalog
+func IsKnownTrailEffect(effectID string) bool {
+	_, ok := trailEffects[effectID]
+	return ok
+}
+
+// CosmeticInventory tracks which locked cosmetic effects each player owns.
+// Ownership is held in memory for the player's session.
+type CosmeticInventory struct {
+	owned map[string]map[string]bool // player ID -> owned effect IDs
+	mu    sync.RWMutex
+}
+
+// NewCosmeticInventory creates an empty inventory
+func NewCosmeticInventory() *CosmeticInventory {
+	return &CosmeticInventory{
+		owned: make(map[string]map[string]bool),
+	}
+}
+
+// Grant gives a player a trail effect
+func (ci *CosmeticInventory) Grant(playerID, effectID string) error {
+	if !IsKnownTrailEffect(effectID) {
+		return fmt.Errorf("unknown trail effect: %s", effectID)
+	}
+
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+
+	if ci.owned[playerID] == nil {
+		ci.owned[playerID] = make(map[string]bool)
+	}
+	ci.owned[playerID][effectID] = true
+	return nil
+}
+
+// Owns reports whether a player may use a trail effect. Free effects are
+// owned by everyone; unknown effects by no one.
+func (ci *CosmeticInventory) Owns(playerID, effectID string) bool {
+	free, known := trailEffects[effectID]
+	if !known {
+		return false
+	}
+	if free {
+		return true
+	}
+
+	ci.mu.RLock()
+	defer ci.mu.RUnlock()
+
+	return ci.owned[playerID][effectID]
+}
+
+// Remove forgets a player's owned effects
+func (ci *CosmeticInventory) Remove(playerID string) {
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+
+	delete(ci.owned, playerID)
+}
+
+// checkTrailEffect returns the shoot failure reason for an equipped effect,
+// or "" when the player may use it. No effect means the default trail.
+func (ci *CosmeticInventory) checkTrailEffect(playerID, effectID string) string {
+	if effectID == "" {
+		return ""
+	}
+	if !IsKnownTrailEffect(effectID) {
+		return ShootFailedUnknownEffect
+	}
+	if !ci.Owns(playerID, effectID) {
+		return ShootFailedEffectNotOwned
+	}
+	return ""
+}
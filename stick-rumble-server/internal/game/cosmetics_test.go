@@ -0,0 +1,46 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCosmeticInventoryOwnership(t *testing.T) {
+	inventory := NewCosmeticInventory()
+
+	assert.True(t, inventory.Owns("p1", TrailEffectDefault), "free effects are owned by everyone")
+	assert.False(t, inventory.Owns("p1", TrailEffectNeon))
+	assert.False(t, inventory.Owns("p1", "rainbow"))
+
+	require.NoError(t, inventory.Grant("p1", TrailEffectNeon))
+	assert.True(t, inventory.Owns("p1", TrailEffectNeon))
+	assert.False(t, inventory.Owns("p2", TrailEffectNeon), "grants are per player")
+	assert.Error(t, inventory.Grant("p1", "rainbow"))
+
+	inventory.Remove("p1")
+	assert.False(t, inventory.Owns("p1", TrailEffectNeon))
+}
+
+func TestPlayerShootWithEffectValidatesOwnership(t *testing.T) {
+	gs := NewGameServer(nil)
+	gs.AddPlayer("p1")
+
+	result := gs.PlayerShootWithEffect("p1", 0, 0, "rainbow")
+	assert.False(t, result.Success)
+	assert.Equal(t, ShootFailedUnknownEffect, result.Reason)
+
+	result = gs.PlayerShootWithEffect("p1", 0, 0, TrailEffectSmoke)
+	assert.False(t, result.Success)
+	assert.Equal(t, ShootFailedEffectNotOwned, result.Reason)
+	assert.Equal(t, gs.GetWeaponState("p1").Weapon.MagazineSize, gs.GetWeaponState("p1").CurrentAmmo, "rejected shots keep their ammo")
+
+	require.NoError(t, gs.GetCosmeticInventory().Grant("p1", TrailEffectSmoke))
+	result = gs.PlayerShootWithEffect("p1", 0, 0, TrailEffectSmoke)
+	require.True(t, result.Success)
+	assert.Equal(t, TrailEffectSmoke, result.Projectile.EffectID)
+
+	gs.RemovePlayer("p1")
+	assert.False(t, gs.GetCosmeticInventory().Owns("p1", TrailEffectSmoke), "ownership ends with the session")
+}
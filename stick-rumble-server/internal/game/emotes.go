@@ -0,0 +1,62 @@
+package game
+
+import "time"
+
+// Emotes a player can show the room
+const (
+	EmoteWave   = "wave"
+	EmoteLaugh  = "laugh"
+	EmoteTaunt  = "taunt"
+	EmoteSalute = "salute"
+)
+
+// Canned quick-chat lines. Players pick from a fixed set instead of typing,
+// so there is no free text to moderate.
+const (
+	QuickChatGG       = "gg"        // "GG"
+	QuickChatNiceShot = "nice_shot" // "Nice shot!"
+	QuickChatThanks   = "thanks"    // "Thanks!"
+	QuickChatSorry    = "sorry"     // "Sorry!"
+)
+
+// Emote rate limit: a burst of EmoteCharges, each coming back after EmoteRecharge
+const (
+	EmoteCharges  = 3
+	EmoteRecharge = 3 * time.Second
+)
+
+var emoteIDs = map[string]bool{
+	EmoteWave:         true,
+	EmoteLaugh:        true,
+	EmoteTaunt:        true,
+	EmoteSalute:       true,
+	QuickChatGG:       true,
+	QuickChatNiceShot: true,
+	QuickChatThanks:   true,
+	QuickChatSorry:    true,
+}
+
+// EmoteResult is the outcome of an emote or quick-chat line
+type EmoteResult struct {
+	Success bool
+	Reason  string
+}
+
+// Emote checks a player's emote or quick-chat line: a known ID with an emote
+// charge left. A valid emote spends a charge; the caller broadcasts it to the
+// room. Dead players may emote.
+func (gs *GameServer) Emote(playerID, emote string) EmoteResult {
+	player, exists := gs.world.GetPlayer(playerID)
+	if !exists {
+		return EmoteResult{Success: false, Reason: "player_not_found"}
+	}
+	if !emoteIDs[emote] {
+		return EmoteResult{Success: false, Reason: "unknown_emote"}
+	}
+	if charges, _ := player.cooldowns.Charges(CooldownEmote, EmoteRecharge, EmoteCharges); charges == 0 {
+		return EmoteResult{Success: false, Reason: "rate_limited"}
+	}
+
+	player.cooldowns.Spend(CooldownEmote, EmoteRecharge, EmoteCharges)
+	return EmoteResult{Success: true}
+}
@@ -0,0 +1,36 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmoteValidatesID(t *testing.T) {
+	gs := NewGameServerWithClock(nil, NewManualClock(time.Now()))
+	player := gs.AddPlayer("p1")
+
+	assert.Equal(t, EmoteResult{Success: true}, gs.Emote("p1", EmoteWave))
+	assert.Equal(t, "player_not_found", gs.Emote("ghost", QuickChatGG).Reason)
+	assert.Equal(t, "unknown_emote", gs.Emote("p1", "free text").Reason)
+
+	player.MarkDead()
+	assert.True(t, gs.Emote("p1", QuickChatGG).Success, "the dead can still say gg")
+	assert.True(t, gs.Emote("p1", QuickChatNiceShot).Success, "rejected emotes cost no charge")
+}
+
+func TestEmoteRateLimit(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	gs := NewGameServerWithClock(nil, clock)
+	gs.AddPlayer("p1")
+
+	for i := 0; i < EmoteCharges; i++ {
+		assert.True(t, gs.Emote("p1", QuickChatThanks).Success, "emote %d of the burst", i+1)
+	}
+	assert.Equal(t, "rate_limited", gs.Emote("p1", QuickChatThanks).Reason)
+
+	clock.Advance(EmoteRecharge)
+	assert.True(t, gs.Emote("p1", EmoteLaugh).Success, "a charge came back")
+	assert.False(t, gs.Emote("p1", EmoteLaugh).Success)
+}
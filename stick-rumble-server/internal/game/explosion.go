@@ -0,0 +1,130 @@
+package game
+
+import (
+	"math"
+	"sort"
+)
+
+// Rocket launcher constants
+const (
+	// RocketLauncherDamage is the damage at the center of a rocket's explosion
+	RocketLauncherDamage = 70
+
+	// RocketLauncherSplashRadius is how far from the impact a rocket's explosion reaches (px)
+	RocketLauncherSplashRadius = 100.0
+
+	// RocketLauncherKnockback is the push at the center of a rocket's explosion (px)
+	RocketLauncherKnockback = 40.0
+
+	// ExplosionEdgeFalloff is the share of full damage and knockback a player
+	// takes at the edge of the splash radius; it rises linearly to 1 at the center
+	ExplosionEdgeFalloff = 0.25
+
+	// explosionWallTolerance is how close to an explosion's center a wall
+	// contact can be and still not shield a player (px)
+	explosionWallTolerance = 1.0
+)
+
+// ExplosionHit is a player caught in an explosion
+type ExplosionHit struct {
+	PlayerID string
+	Distance float64 // From the explosion's center to the player's position
+	Falloff  float64 // Share of full damage and knockback, from ExplosionEdgeFalloff at the edge to 1 at the center
+}
+
+// explosionFalloff returns the share of full damage and knockback dealt at
+// distance from the center of an explosion with the given radius
+func explosionFalloff(distance, radius float64) float64 {
+	return 1 - (1-ExplosionEdgeFalloff)*math.Min(distance/radius, 1)
+}
+
+// SplashDamage scales an explosion's full damage by a hit's falloff. A player
+// caught at all takes at least 1 damage.
+func SplashDamage(damage int, falloff float64) int {
+	return max(int(math.Round(float64(damage)*falloff)), 1)
+}
+
+// CheckExplosionHits returns every player within radius of center, nearest
+// first. The owner, dead, invulnerable and rolling players are skipped like
+// for projectile hits, and walls that block projectiles shield players behind
+// them.
+func (p *Physics) CheckExplosionHits(center Vector2, radius float64, ownerID string, players []*PlayerState) []ExplosionHit {
+	hits := make([]ExplosionHit, 0)
+	for _, player := range players {
+		if player.ID == ownerID || !player.IsAlive() || player.IsInvulnerable || player.IsInvincibleFromRoll() {
+			continue
+		}
+
+		playerPos := player.GetPosition()
+		distance := calculateDistance(center, playerPos)
+		if distance > radius {
+			continue
+		}
+		// Trace from the player so a rocket that burst on a wall's face is
+		// not shielded by that same wall
+		if contact, blocked := firstObstacleContact(playerPos, center, p.mapConfig.Obstacles, func(obstacle MapObstacle) bool {
+			return obstacle.BlocksProjectiles
+		}); blocked && contact.Distance < distance-explosionWallTolerance {
+			continue
+		}
+
+		hits = append(hits, ExplosionHit{
+			PlayerID: player.ID,
+			Distance: distance,
+			Falloff:  explosionFalloff(distance, radius),
+		})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		return hits[i].Distance < hits[j].Distance
+	})
+	return hits
+}
+
+// ApplyKnockback pushes target distance pixels directly away from origin,
+// stopping at the arena edge and walls
+func (p *Physics) ApplyKnockback(target *PlayerState, origin Vector2, distance float64) {
+	pushPlayer(target, origin, distance, p.mapConfig)
+}
+
+// detonate explodes an explosive projectile at center: every player in its
+// splash radius takes falloff damage and survivors are pushed away from the
+// blast
+func (gs *GameServer) detonate(proj Projectile, center Vector2) {
+	gs.emitGameLoopEvent(ProjectileExplodedEvent{
+		ProjectileID: proj.ID,
+		OwnerID:      proj.OwnerID,
+		WeaponType:   proj.WeaponType,
+		Position:     center,
+		Radius:       proj.SplashRadius,
+	})
+
+	gs.world.mu.RLock()
+	players := make([]*PlayerState, 0, len(gs.world.players))
+	for _, player := range gs.world.players {
+		players = append(players, player)
+	}
+	gs.world.mu.RUnlock()
+
+	for _, hit := range gs.physics.CheckExplosionHits(center, proj.SplashRadius, proj.OwnerID, players) {
+		damage := SplashDamage(proj.Damage, hit.Falloff)
+		outcome, ok := gs.damageVictim(HitEvent{
+			ProjectileID: proj.ID,
+			VictimID:     hit.PlayerID,
+			AttackerID:   proj.OwnerID,
+			Damage:       damage,
+			Point:        center,
+		}, proj.WeaponType, DamageSourceExplosion, damage)
+		if !ok {
+			continue
+		}
+
+		if !outcome.Killed && proj.SplashKnockback > 0 {
+			if victim, exists := gs.world.GetPlayer(hit.PlayerID); exists {
+				gs.physics.ApplyKnockback(victim, center, proj.SplashKnockback*hit.Falloff)
+			}
+		}
+
+		gs.emitGameLoopEvent(ProjectileHitResolvedEvent{Outcome: outcome})
+	}
+}
@@ -0,0 +1,167 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCheckExplosionHits(t *testing.T) {
+	mapConfig := openTestMapConfig()
+	mapConfig.Obstacles = []MapObstacle{
+		{ID: "wall", X: 480, Y: 540, Width: 40, Height: 10, BlocksProjectiles: true},
+	}
+	physics := NewPhysics(mapConfig)
+
+	dead := createTestPlayer("dead", 510, 500, 0)
+	dead.TakeDamage(PlayerMaxHealth)
+	players := []*PlayerState{
+		createTestPlayer("owner", 500, 500, 0),
+		createTestPlayer("edge", 600, 500, 0),
+		createTestPlayer("near", 520, 500, 0),
+		createTestPlayer("far", 650, 500, 0),
+		createTestPlayer("covered", 500, 580, 0),
+		dead,
+	}
+
+	hits := physics.CheckExplosionHits(Vector2{X: 500, Y: 500}, 100, "owner", players)
+
+	if len(hits) != 2 {
+		t.Fatalf("Expected the near and edge players to be caught, got %+v", hits)
+	}
+	if hits[0].PlayerID != "near" || hits[1].PlayerID != "edge" {
+		t.Errorf("Expected hits nearest first, got %s then %s", hits[0].PlayerID, hits[1].PlayerID)
+	}
+	if math.Abs(hits[0].Falloff-0.85) > 1e-9 {
+		t.Errorf("Expected falloff 0.85 at 20px, got %v", hits[0].Falloff)
+	}
+	if hits[1].Falloff != ExplosionEdgeFalloff {
+		t.Errorf("Expected falloff %v at the edge, got %v", ExplosionEdgeFalloff, hits[1].Falloff)
+	}
+}
+
+func TestSplashDamage(t *testing.T) {
+	tests := []struct {
+		damage   int
+		falloff  float64
+		expected int
+	}{
+		{70, 1, 70},
+		{70, ExplosionEdgeFalloff, 18},
+		{1, ExplosionEdgeFalloff, 1},
+	}
+	for _, tt := range tests {
+		if got := SplashDamage(tt.damage, tt.falloff); got != tt.expected {
+			t.Errorf("SplashDamage(%d, %v) = %d, want %d", tt.damage, tt.falloff, got, tt.expected)
+		}
+	}
+}
+
+// fireRocket gives shooterID a rocket launcher, fires it to the right and runs
+// hit detection until the rocket is gone
+func fireRocket(t *testing.T, gs *GameServer, shooterID string) {
+	t.Helper()
+
+	gs.SetWeaponState(shooterID, NewWeaponState(NewRocketLauncher()))
+	if result := gs.PlayerShoot(shooterID, 0.0, 0); !result.Success {
+		t.Fatal("PlayerShoot should succeed")
+	}
+	for i := 0; i < 60 && len(gs.GetActiveProjectiles()) > 0; i++ {
+		gs.projectileManager.Update(1.0 / 60.0)
+		gs.checkHitDetection()
+	}
+	if remaining := len(gs.GetActiveProjectiles()); remaining != 0 {
+		t.Fatalf("Expected the rocket to be gone, %d projectiles left", remaining)
+	}
+}
+
+// explosionEvents splits recorded events into the explosion and its hits
+func explosionEvents(t *testing.T, events []GameLoopEvent) (ProjectileExplodedEvent, map[string]ProjectileHitOutcome) {
+	t.Helper()
+
+	if len(events) == 0 {
+		t.Fatal("Expected the rocket to explode")
+	}
+	explosion, ok := events[0].(ProjectileExplodedEvent)
+	if !ok {
+		t.Fatalf("Expected the explosion first, got %T", events[0])
+	}
+	hits := make(map[string]ProjectileHitOutcome)
+	for _, event := range events[1:] {
+		outcome := event.(ProjectileHitResolvedEvent).Outcome
+		hits[outcome.Hit.VictimID] = outcome
+	}
+	return explosion, hits
+}
+
+func TestGameServerRocketExplodesOnPlayer(t *testing.T) {
+	sink := &recordingGameLoopSink{}
+	gs := newGameServerWithSink(&RealClock{}, sink)
+	setGameServerOpenMap(gs)
+
+	for _, id := range []string{"shooter", "victim", "bystander"} {
+		gs.AddPlayer(id)
+	}
+	shooter, _ := gs.world.GetPlayer("shooter")
+	shooter.SetPosition(Vector2{X: 220, Y: 540})
+	victim, _ := gs.world.GetPlayer("victim")
+	victim.SetPosition(Vector2{X: 420, Y: 540})
+	bystander, _ := gs.world.GetPlayer("bystander")
+	bystander.SetPosition(Vector2{X: 420, Y: 600})
+
+	fireRocket(t, gs, "shooter")
+
+	explosion, hits := explosionEvents(t, sink.events)
+	if explosion.OwnerID != "shooter" || explosion.Radius != RocketLauncherSplashRadius {
+		t.Errorf("Unexpected explosion %+v", explosion)
+	}
+	if len(hits) != 2 {
+		t.Fatalf("Expected the victim and bystander to be hit, got %+v", hits)
+	}
+	if hits["victim"].Damage <= hits["bystander"].Damage {
+		t.Errorf("Expected the direct hit (%d) to outdamage the splash (%d)", hits["victim"].Damage, hits["bystander"].Damage)
+	}
+	if hits["victim"].Damage > RocketLauncherDamage {
+		t.Errorf("Expected at most %d damage, got %d", RocketLauncherDamage, hits["victim"].Damage)
+	}
+
+	victimState, _ := gs.GetPlayerState("victim")
+	if expected := PlayerMaxHealth - hits["victim"].Damage; victimState.Health != expected {
+		t.Errorf("Expected victim health %d, got %d", expected, victimState.Health)
+	}
+	shooterState, _ := gs.GetPlayerState("shooter")
+	if shooterState.Health != PlayerMaxHealth {
+		t.Errorf("Expected the shooter to be unhurt, got health %d", shooterState.Health)
+	}
+	if pos := bystander.GetPosition(); pos.Y <= 600 {
+		t.Errorf("Expected the bystander to be pushed away from the blast, got %+v", pos)
+	}
+}
+
+func TestGameServerRocketExplodesOnWall(t *testing.T) {
+	sink := &recordingGameLoopSink{}
+	gs := newGameServerWithSink(&RealClock{}, sink)
+	mapConfig := openTestMapConfig()
+	mapConfig.Obstacles = []MapObstacle{
+		{ID: "wall", X: 500, Y: 400, Width: 40, Height: 300, BlocksProjectiles: true, BlocksMovement: true},
+	}
+	setWorldMapConfig(gs.world, mapConfig)
+	gs.physics = NewPhysics(mapConfig)
+	gs.projectileManager = NewProjectileManager(mapConfig)
+
+	gs.AddPlayer("shooter")
+	gs.AddPlayer("bystander")
+	shooter, _ := gs.world.GetPlayer("shooter")
+	shooter.SetPosition(Vector2{X: 220, Y: 540})
+	bystander, _ := gs.world.GetPlayer("bystander")
+	bystander.SetPosition(Vector2{X: 460, Y: 620})
+
+	fireRocket(t, gs, "shooter")
+
+	explosion, hits := explosionEvents(t, sink.events)
+	if math.Abs(explosion.Position.X-500) > 1e-6 {
+		t.Errorf("Expected the rocket to explode on the wall face at x=500, got %+v", explosion.Position)
+	}
+	if _, ok := hits["bystander"]; !ok || len(hits) != 1 {
+		t.Fatalf("Expected only the bystander to be caught, got %+v", hits)
+	}
+}
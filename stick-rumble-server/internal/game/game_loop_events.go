@@ -1,5 +1,7 @@
 package game
 
+import "time"
+
 type GameLoopEvent interface {
 	gameLoopEventName() string
 }
@@ -14,6 +16,18 @@ type ProjectileHitResolvedEvent struct {
 
 func (ProjectileHitResolvedEvent) gameLoopEventName() string { return "projectile_hit_resolved" }
 
+// ProjectileExplodedEvent reports an explosive projectile blowing up on
+// impact. Players it damaged follow as ProjectileHitResolvedEvents.
+type ProjectileExplodedEvent struct {
+	ProjectileID string
+	OwnerID      string
+	WeaponType   string
+	Position     Vector2
+	Radius       float64
+}
+
+func (ProjectileExplodedEvent) gameLoopEventName() string { return "projectile_exploded" }
+
 type ReloadCompletedEvent struct {
 	PlayerID string
 }
@@ -43,6 +57,43 @@ type WeaponCrateRespawnedEvent struct {
 
 func (WeaponCrateRespawnedEvent) gameLoopEventName() string { return "weapon_crate_respawned" }
 
+// ShieldPickedUpEvent reports a player walking over a shield crate. Shield is
+// the player's shield after the pickup.
+type ShieldPickedUpEvent struct {
+	PlayerID    string
+	CrateID     string
+	Shield      int
+	RespawnTime time.Time
+}
+
+func (ShieldPickedUpEvent) gameLoopEventName() string { return "shield_picked_up" }
+
+type ShieldCrateRespawnedEvent struct {
+	CrateID  string
+	Position Vector2
+}
+
+func (ShieldCrateRespawnedEvent) gameLoopEventName() string { return "shield_crate_respawned" }
+
+// HealthPackPickedUpEvent reports a player walking over a health pack. Healed
+// is the health actually restored, after hooks and the max health cap.
+type HealthPackPickedUpEvent struct {
+	PlayerID    string
+	PackID      string
+	Healed      int
+	NewHealth   int
+	RespawnTime time.Time
+}
+
+func (HealthPackPickedUpEvent) gameLoopEventName() string { return "health_pack_picked_up" }
+
+type HealthPackRespawnedEvent struct {
+	PackID   string
+	Position Vector2
+}
+
+func (HealthPackRespawnedEvent) gameLoopEventName() string { return "health_pack_respawned" }
+
 type MatchTimerUpdatedEvent struct {
 	RoomID           string
 	RemainingSeconds int
@@ -55,30 +106,49 @@ type MatchEndedEvent struct {
 	Reason      string
 	Winners     []WinnerSummary
 	FinalScores []PlayerScore
+	Scoreboard  []PlayerMatchStats
 }
 
 func (MatchEndedEvent) gameLoopEventName() string { return "match_ended" }
 
+// MovementViolationEvent reports a player whose movement or aim broke the
+// physics limits. Kick is set once the player reaches KickAfter violations.
+type MovementViolationEvent struct {
+	PlayerID  string
+	Kind      string
+	Magnitude float64 // Pixels moved for position violations, radians/s for aim flicks, clamped inputs for aim clamping
+	Limit     float64 // The threshold Magnitude exceeded
+	Count     int     // Violations within the window, including this one
+	Kick      bool
+}
+
+func (MovementViolationEvent) gameLoopEventName() string { return "movement_violation" }
+
 type GameServerConfig struct {
-	BroadcastFunc func(playerStates []PlayerStateSnapshot)
-	Clock         Clock
-	EventSink     GameLoopEventSink
-	RTTProvider   func(playerID string) int64
+	BroadcastFunc    func(playerStates []PlayerStateSnapshot)
+	Clock            Clock
+	EventSink        GameLoopEventSink
+	RTTProvider      func(playerID string) int64
+	GameplayHooks    func(playerID string) *GameplayHooks // Room modding hooks that apply to a player
+	ActiveMatches    func() []*Match                      // Matches whose clocks advance with each simulation tick
+	DormantPlayers   func() map[string]bool               // Players of rooms with nobody connected, whom the tick leaves be
+	MovementGuard    MovementGuardConfig                  // Movement and aim validation thresholds
+	InputClock       InputClockGuardConfig                // Input timestamp validation thresholds
+	AimLimit         AimLimiterConfig                     // Aim turn rate cap
+	AimTurnRate      func(playerID string) float64        // Room override of AimLimit.MaxTurnRate; 0 keeps it
+	RandomSource     func(playerID string) RandomSource   // Seeded source of the player's room; nil uses a fixed seed
+	ProjectileLimits ProjectileLimits                     // Caps on projectiles in flight
+	DodgeRoll        DodgeRollConfig                      // Dodge roll i-frames and stamina cost
+	Leveling         LevelCurve                           // XP per level and level unlocks
 }
 
 type MatchEventEmitter struct {
-	clock Clock
-	sink  GameLoopEventSink
+	sink GameLoopEventSink
 }
 
-func NewMatchEventEmitter(clock Clock, sink GameLoopEventSink) *MatchEventEmitter {
-	if clock == nil {
-		clock = &RealClock{}
-	}
-
+func NewMatchEventEmitter(sink GameLoopEventSink) *MatchEventEmitter {
 	return &MatchEventEmitter{
-		clock: clock,
-		sink:  sink,
+		sink: sink,
 	}
 }
 
@@ -91,49 +161,31 @@ func (e *MatchEventEmitter) EmitRoomTick(roomID string, match *Match, world *Wor
 		return
 	}
 
-	remainingSeconds := e.remainingSeconds(match)
+	if reason := match.takeEndRequest(); reason != "" {
+		e.endMatch(roomID, match, world, reason)
+		return
+	}
+
+	remainingSeconds := match.GetRemainingSeconds()
 	e.sink.HandleGameLoopEvent(MatchTimerUpdatedEvent{
 		RoomID:           roomID,
 		RemainingSeconds: remainingSeconds,
 	})
 
-	if !e.timeLimitReached(match) {
+	if !match.CheckTimeLimit() {
 		return
 	}
 
-	match.EndMatch("time_limit")
+	e.endMatch(roomID, match, world, "time_limit")
+}
+
+func (e *MatchEventEmitter) endMatch(roomID string, match *Match, world *World, reason string) {
+	match.EndMatch(reason)
 	e.sink.HandleGameLoopEvent(MatchEndedEvent{
 		RoomID:      roomID,
 		Reason:      match.EndReason,
 		Winners:     match.GetWinnerSummaries(world),
 		FinalScores: match.GetFinalScores(world),
+		Scoreboard:  match.MatchStats(world),
 	})
 }
-
-func (e *MatchEventEmitter) remainingSeconds(match *Match) int {
-	match.mu.RLock()
-	defer match.mu.RUnlock()
-
-	if match.StartTime.IsZero() {
-		return match.Config.TimeLimitSeconds
-	}
-
-	elapsed := int(e.clock.Since(match.StartTime).Seconds())
-	remaining := match.Config.TimeLimitSeconds - elapsed
-	if remaining < 0 {
-		return 0
-	}
-
-	return remaining
-}
-
-func (e *MatchEventEmitter) timeLimitReached(match *Match) bool {
-	match.mu.RLock()
-	defer match.mu.RUnlock()
-
-	if match.StartTime.IsZero() {
-		return false
-	}
-
-	return e.clock.Since(match.StartTime).Seconds() >= float64(match.Config.TimeLimitSeconds)
-}
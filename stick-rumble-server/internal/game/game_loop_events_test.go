@@ -63,6 +63,7 @@ func TestGameServerEmitsRespawnEvent(t *testing.T) {
 
 	player := gs.AddPlayer("player1")
 	player.MarkDead()
+	player.RequestRespawn()
 	clock.Advance(time.Duration(RespawnDelay*1000+100) * time.Millisecond)
 
 	gs.checkRespawns()
@@ -113,7 +114,7 @@ func TestGameServerEmitsRollEndedEventForWallCollision(t *testing.T) {
 	mapConfig.Obstacles = []MapObstacle{
 		{ID: "wall", X: 130, Y: 80, Width: 20, Height: 40, BlocksMovement: true},
 	}
-	gs.world.mapConfig = mapConfig
+	setWorldMapConfig(gs.world, mapConfig)
 	gs.physics = NewPhysics(mapConfig)
 	gs.projectileManager = NewProjectileManager(mapConfig)
 
@@ -121,7 +122,7 @@ func TestGameServerEmitsRollEndedEventForWallCollision(t *testing.T) {
 	player.SetPosition(Vector2{X: 125, Y: 100})
 	player.StartDodgeRoll(Vector2{X: 1, Y: 0})
 
-	gs.updateAllPlayers(1.0 / 60.0)
+	gs.updateAllPlayers(gs.activePlayers(nil), 1.0/60.0)
 
 	event := requireSingleEvent[RollEndedEvent](t, sink.events)
 	assert.Equal(t, "wall_collision", event.Reason)
@@ -150,10 +151,68 @@ func TestGameServerEmitsWeaponRespawnEvent(t *testing.T) {
 	assert.Equal(t, crate.WeaponType, event.WeaponType)
 }
 
+func TestGameServerShieldPickupAndRespawnEvents(t *testing.T) {
+	sink := &recordingGameLoopSink{}
+	gs := newGameServerWithSink(&RealClock{}, sink)
+	spawn := MustDefaultMapConfig().ShieldSpawns[0]
+
+	player := gs.AddPlayer("player1")
+	player.SetPosition(Vector2{X: spawn.X, Y: spawn.Y})
+	gs.checkShieldPickups()
+
+	pickup := requireSingleEvent[ShieldPickedUpEvent](t, sink.events)
+	assert.Equal(t, "player1", pickup.PlayerID)
+	assert.Equal(t, spawn.ID, pickup.CrateID)
+	assert.Equal(t, ShieldPickupAmount, pickup.Shield)
+	assert.Equal(t, ShieldPickupAmount, player.GetShield())
+
+	// A full shield leaves the next crate for someone else
+	sink.events = nil
+	gs.GetShieldCrateManager().GetCrate(spawn.ID).RespawnTime = time.Now().Add(-time.Second)
+	gs.checkShieldRespawns()
+	respawn := requireSingleEvent[ShieldCrateRespawnedEvent](t, sink.events)
+	assert.Equal(t, spawn.ID, respawn.CrateID)
+
+	sink.events = nil
+	gs.checkShieldPickups()
+	assert.Empty(t, sink.events)
+	assert.True(t, gs.GetShieldCrateManager().GetCrate(spawn.ID).IsAvailable)
+}
+
+func TestGameServerHealthPackPickupAndRespawnEvents(t *testing.T) {
+	sink := &recordingGameLoopSink{}
+	gs := newGameServerWithSink(&RealClock{}, sink)
+	spawn := MustDefaultMapConfig().HealthSpawns[0]
+
+	// A player at full health leaves the pack for someone else
+	player := gs.AddPlayer("player1")
+	player.SetPosition(Vector2{X: spawn.X, Y: spawn.Y})
+	gs.checkHealthPackPickups()
+	assert.Empty(t, sink.events)
+	assert.True(t, gs.GetHealthPackManager().GetPack(spawn.ID).IsAvailable)
+
+	player.TakeDamage(30)
+	gs.checkHealthPackPickups()
+
+	pickup := requireSingleEvent[HealthPackPickedUpEvent](t, sink.events)
+	assert.Equal(t, "player1", pickup.PlayerID)
+	assert.Equal(t, spawn.ID, pickup.PackID)
+	assert.Equal(t, 30, pickup.Healed, "healing is capped at max health")
+	assert.Equal(t, PlayerMaxHealth, pickup.NewHealth)
+	assert.Equal(t, PlayerMaxHealth, player.Snapshot().Health)
+
+	sink.events = nil
+	gs.GetHealthPackManager().GetPack(spawn.ID).RespawnTime = time.Now().Add(-time.Second)
+	gs.checkHealthPackRespawns()
+	respawn := requireSingleEvent[HealthPackRespawnedEvent](t, sink.events)
+	assert.Equal(t, spawn.ID, respawn.PackID)
+	assert.Equal(t, Vector2{X: spawn.X, Y: spawn.Y}, respawn.Position)
+}
+
 func TestMatchEventEmitterEmitsTimerAndMatchEndedEvents(t *testing.T) {
 	clock := NewManualClock(time.Now())
 	sink := &recordingGameLoopSink{}
-	emitter := NewMatchEventEmitter(clock, sink)
+	emitter := NewMatchEventEmitter(sink)
 
 	world := NewWorldWithClock(clock)
 	player := world.AddPlayer("player1")
@@ -161,8 +220,8 @@ func TestMatchEventEmitterEmitsTimerAndMatchEndedEvents(t *testing.T) {
 
 	match := NewMatch()
 	match.RegisterPlayer("player1")
-	match.StartTime = clock.Now().Add(-time.Duration(match.Config.TimeLimitSeconds) * time.Second)
-	match.State = MatchStateActive
+	match.Start()
+	match.AdvanceTicks(ticksForSeconds(match.Config.TimeLimitSeconds))
 
 	emitter.EmitRoomTick("room-1", match, world)
 
@@ -179,15 +238,15 @@ func TestMatchEventEmitterEmitsTimerAndMatchEndedEvents(t *testing.T) {
 func TestMatchEventEmitterEmitsTimerWithoutEndingActiveMatch(t *testing.T) {
 	clock := NewManualClock(time.Now())
 	sink := &recordingGameLoopSink{}
-	emitter := NewMatchEventEmitter(clock, sink)
+	emitter := NewMatchEventEmitter(sink)
 
 	world := NewWorldWithClock(clock)
 	world.AddPlayer("player1")
 
 	match := NewMatch()
 	match.RegisterPlayer("player1")
-	match.StartTime = clock.Now().Add(-5 * time.Second)
-	match.State = MatchStateActive
+	match.Start()
+	match.AdvanceTicks(ticksForSeconds(5))
 
 	emitter.EmitRoomTick("room-1", match, world)
 
@@ -196,6 +255,29 @@ func TestMatchEventEmitterEmitsTimerWithoutEndingActiveMatch(t *testing.T) {
 	assert.False(t, match.IsEnded())
 }
 
+func TestMatchEventEmitterEndsMatchOnRequest(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	sink := &recordingGameLoopSink{}
+	emitter := NewMatchEventEmitter(sink)
+
+	world := NewWorldWithClock(clock)
+	world.AddPlayer("player1")
+
+	match := NewMatch()
+	match.RegisterPlayer("player1")
+	match.Start()
+	match.AdvanceTicks(ticksForSeconds(5))
+	match.RequestEnd("instagib_winner")
+	match.RequestEnd("ignored")
+
+	assert.False(t, match.IsEnded(), "requested end waits for the match tick")
+	emitter.EmitRoomTick("room-1", match, world)
+
+	ended := requireSingleEvent[MatchEndedEvent](t, sink.events)
+	assert.Equal(t, "instagib_winner", ended.Reason)
+	assert.True(t, match.IsEnded())
+}
+
 func TestGameServerRemovesLegacyTransportCallbackSetters(t *testing.T) {
 	gameServerType := reflect.TypeOf((*GameServer)(nil))
 	legacyMethods := []string{
@@ -0,0 +1,241 @@
+package game
+
+import "sync"
+
+// DamageSource identifies how damage was dealt
+type DamageSource string
+
+const (
+	DamageSourceProjectile DamageSource = "projectile"
+	DamageSourceHitscan    DamageSource = "hitscan"
+	DamageSourceMelee      DamageSource = "melee"
+	DamageSourceExplosion  DamageSource = "explosion"
+)
+
+// HealSource identifies how health was restored
+type HealSource string
+
+const (
+	HealSourceRegeneration HealSource = "regeneration"
+	HealSourceHealthPack   HealSource = "health_pack"
+)
+
+// DamageEvent describes damage about to be applied
+type DamageEvent struct {
+	AttackerID string
+	VictimID   string
+	Weapon     string
+	Source     DamageSource
+	Damage     int // Damage after earlier hooks ran
+}
+
+// HealEvent describes health about to be restored
+type HealEvent struct {
+	PlayerID string
+	Source   HealSource
+	Amount   int // Amount after earlier hooks ran
+}
+
+// KillXPEvent describes XP about to be awarded for a kill
+type KillXPEvent struct {
+	KillerID string
+	VictimID string
+	XP       int // XP after earlier hooks ran
+}
+
+// PickupEvent describes a weapon crate pickup about to happen
+type PickupEvent struct {
+	PlayerID   string
+	CrateID    string
+	WeaponType string
+}
+
+// UltimateEvent describes an ultimate about to be activated
+type UltimateEvent struct {
+	PlayerID string
+	Effect   UltimateEffect // Effect after earlier hooks ran
+}
+
+// GameplayHook lets an external module change combat and pickup rules for a
+// room without forking the combat code. Hooks run on the game loop, sometimes
+// while the affected player's state is locked, so they must only use the event
+// they are given and must not call back into the game server.
+// Embed BaseGameplayHook to override only the callbacks a mode needs.
+type GameplayHook interface {
+	ModifyDamage(event DamageEvent) int
+	ModifyHeal(event HealEvent) int
+	ModifyKillXP(event KillXPEvent) int
+	AllowPickup(event PickupEvent) bool
+	ModifyUltimate(event UltimateEvent) UltimateEffect
+}
+
+// BaseGameplayHook leaves every rule unchanged
+type BaseGameplayHook struct{}
+
+func (BaseGameplayHook) ModifyDamage(event DamageEvent) int { return event.Damage }
+func (BaseGameplayHook) ModifyHeal(event HealEvent) int     { return event.Amount }
+func (BaseGameplayHook) ModifyKillXP(event KillXPEvent) int { return event.XP }
+func (BaseGameplayHook) AllowPickup(event PickupEvent) bool { return true }
+func (BaseGameplayHook) ModifyUltimate(event UltimateEvent) UltimateEffect {
+	return event.Effect
+}
+
+// GameplayHooks is a room's ordered list of registered hooks. Each hook sees
+// the value produced by the hooks registered before it. A nil *GameplayHooks
+// applies the default rules.
+type GameplayHooks struct {
+	hooks []GameplayHook
+	mu    sync.RWMutex
+}
+
+// NewGameplayHooks creates an empty hook list
+func NewGameplayHooks() *GameplayHooks {
+	return &GameplayHooks{}
+}
+
+// Register appends a hook to the list
+func (h *GameplayHooks) Register(hook GameplayHook) {
+	if hook == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.hooks = append(h.hooks, hook)
+}
+
+// Len returns the number of registered hooks
+func (h *GameplayHooks) Len() int {
+	if h == nil {
+		return 0
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return len(h.hooks)
+}
+
+// NamedGameplayHook is a hook that reports a name, such as a mode script.
+// Named hooks are listed as the mutators a match ran with.
+type NamedGameplayHook interface {
+	GameplayHook
+	Name() string
+}
+
+// Names returns the names of the registered named hooks, in order. It never
+// returns nil, so an unmodded room reports an empty list.
+func (h *GameplayHooks) Names() []string {
+	names := make([]string, 0)
+	for _, hook := range h.snapshot() {
+		if named, ok := hook.(NamedGameplayHook); ok {
+			names = append(names, named.Name())
+		}
+	}
+	return names
+}
+
+func (h *GameplayHooks) snapshot() []GameplayHook {
+	if h == nil {
+		return nil
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.hooks
+}
+
+// Damage runs the damage hooks; the result is never negative
+func (h *GameplayHooks) Damage(event DamageEvent) int {
+	for _, hook := range h.snapshot() {
+		event.Damage = max(hook.ModifyDamage(event), 0)
+	}
+	return event.Damage
+}
+
+// Heal runs the heal hooks; the result is never negative
+func (h *GameplayHooks) Heal(event HealEvent) int {
+	for _, hook := range h.snapshot() {
+		event.Amount = max(hook.ModifyHeal(event), 0)
+	}
+	return event.Amount
+}
+
+// KillXP runs the kill XP hooks; the result is never negative
+func (h *GameplayHooks) KillXP(event KillXPEvent) int {
+	for _, hook := range h.snapshot() {
+		event.XP = max(hook.ModifyKillXP(event), 0)
+	}
+	return event.XP
+}
+
+// AllowPickup reports whether every hook allows the pickup
+func (h *GameplayHooks) AllowPickup(event PickupEvent) bool {
+	for _, hook := range h.snapshot() {
+		if !hook.AllowPickup(event) {
+			return false
+		}
+	}
+	return true
+}
+
+// Ultimate runs the ultimate hooks; durations and heals are never negative
+func (h *GameplayHooks) Ultimate(event UltimateEvent) UltimateEffect {
+	for _, hook := range h.snapshot() {
+		event.Effect = hook.ModifyUltimate(event)
+		event.Effect.Heal = max(event.Effect.Heal, 0)
+		event.Effect.Duration = max(event.Effect.Duration, 0)
+	}
+	return event.Effect
+}
+
+// GameplayHookFactory attaches a module's hook to newly created rooms.
+// Returning nil leaves the room untouched, so a module can target only some
+// rooms (for example named rooms running a custom mode).
+type GameplayHookFactory func(room *Room) GameplayHook
+
+// AddGameplayHookFactory registers a factory that runs for every room created
+// afterwards.
+func (rm *RoomManager) AddGameplayHookFactory(factory GameplayHookFactory) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.hookFactories = append(rm.hookFactories, factory)
+}
+
+// GameplayHooksForPlayer returns the hooks of the player's room, or nil when
+// the player is not in a room.
+func (rm *RoomManager) GameplayHooksForPlayer(playerID string) *GameplayHooks {
+	room := rm.GetRoomByPlayerID(playerID)
+	if room == nil {
+		return nil
+	}
+	return room.Hooks
+}
+
+func (rm *RoomManager) attachGameplayHooksLocked(room *Room) {
+	for _, factory := range rm.hookFactories {
+		room.Hooks.Register(factory(room))
+	}
+}
+
+// hooksFor returns the gameplay hooks that apply to a player, or nil
+func (gs *GameServer) hooksFor(playerID string) *GameplayHooks {
+	if gs.gameplayHooks == nil {
+		return nil
+	}
+	return gs.gameplayHooks(playerID)
+}
+
+// KillXPReward returns the XP a kill is worth after the killer's room hooks
+func (gs *GameServer) KillXPReward(killerID, victimID string) int {
+	return gs.hooksFor(killerID).KillXP(KillXPEvent{KillerID: killerID, VictimID: victimID, XP: KillXPReward})
+}
+
+// AllowWeaponPickup reports whether the player's room hooks allow picking up
+// the crate
+func (gs *GameServer) AllowWeaponPickup(playerID string, crate *WeaponCrate) bool {
+	return gs.hooksFor(playerID).AllowPickup(PickupEvent{PlayerID: playerID, CrateID: crate.ID, WeaponType: crate.WeaponType})
+}
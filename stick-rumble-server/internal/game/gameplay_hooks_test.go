@@ -0,0 +1,142 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// doubleDamageHook doubles all damage and halves heals
+type doubleDamageHook struct {
+	BaseGameplayHook
+}
+
+func (doubleDamageHook) ModifyDamage(event DamageEvent) int { return event.Damage * 2 }
+func (doubleDamageHook) ModifyHeal(event HealEvent) int     { return event.Amount / 2 }
+
+// noPickupsHook forbids crate pickups and awards bonus kill XP
+type noPickupsHook struct {
+	BaseGameplayHook
+}
+
+func (noPickupsHook) AllowPickup(PickupEvent) bool       { return false }
+func (noPickupsHook) ModifyKillXP(event KillXPEvent) int { return event.XP + 50 }
+func (noPickupsHook) ModifyDamage(event DamageEvent) int { return event.Damage - 1000 }
+
+func newHookedGameServer(hooks *GameplayHooks) *GameServer {
+	return NewGameServerWithConfig(GameServerConfig{
+		BroadcastFunc: noBroadcast,
+		GameplayHooks: func(string) *GameplayHooks { return hooks },
+	})
+}
+
+func TestGameplayHooksChainInRegistrationOrder(t *testing.T) {
+	hooks := NewGameplayHooks()
+	hooks.Register(doubleDamageHook{})
+	hooks.Register(noPickupsHook{})
+	hooks.Register(nil)
+
+	assert.Equal(t, 2, hooks.Len())
+	assert.Equal(t, 0, hooks.Damage(DamageEvent{Damage: 25}), "damage is clamped at zero")
+	assert.Equal(t, 5, hooks.Heal(HealEvent{Amount: 10}))
+	assert.Equal(t, KillXPReward+50, hooks.KillXP(KillXPEvent{XP: KillXPReward}))
+	assert.False(t, hooks.AllowPickup(PickupEvent{}))
+}
+
+func TestNilGameplayHooksKeepDefaultRules(t *testing.T) {
+	var hooks *GameplayHooks
+
+	assert.Zero(t, hooks.Len())
+	assert.Equal(t, 25, hooks.Damage(DamageEvent{Damage: 25}))
+	assert.Equal(t, 10, hooks.Heal(HealEvent{Amount: 10}))
+	assert.Equal(t, KillXPReward, hooks.KillXP(KillXPEvent{XP: KillXPReward}))
+	assert.True(t, hooks.AllowPickup(PickupEvent{}))
+}
+
+func TestProcessProjectileHitAppliesRoomDamageHook(t *testing.T) {
+	hooks := NewGameplayHooks()
+	hooks.Register(doubleDamageHook{})
+	gs := newHookedGameServer(hooks)
+	attacker := gs.AddPlayer("attacker")
+	gs.AddPlayer("victim")
+	baseDamage := gs.GetWeaponState(attacker.ID).Weapon.Damage
+
+	outcome, ok := gs.ProcessProjectileHit(HitEvent{ProjectileID: "projectile-1", AttackerID: attacker.ID, VictimID: "victim"})
+
+	require.True(t, ok)
+	assert.Equal(t, baseDamage*2, outcome.Damage)
+	assert.Equal(t, PlayerMaxHealth-baseDamage*2, outcome.NewHealth)
+}
+
+func TestPlayerMeleeAttackAppliesRoomDamageHook(t *testing.T) {
+	hooks := NewGameplayHooks()
+	hooks.Register(doubleDamageHook{})
+	gs := newHookedGameServer(hooks)
+	setGameServerOpenMap(gs)
+	gs.AddPlayer("player1")
+	gs.AddPlayer("player2")
+	bat := NewBat()
+	gs.SetWeaponState("player1", NewWeaponState(bat))
+	player1, _ := gs.world.GetPlayer("player1")
+	player2, _ := gs.world.GetPlayer("player2")
+	player1.Position = Vector2{X: 100, Y: 100}
+	player2.Position = Vector2{X: 150, Y: 100}
+
+	result := gs.PlayerMeleeAttack("player1", 0.0)
+
+	require.True(t, result.Success)
+	require.Len(t, result.Damages, 1)
+	assert.Equal(t, bat.Damage*2, result.Damages[0])
+	assert.Equal(t, PlayerMaxHealth-bat.Damage*2, player2.Health)
+}
+
+func TestKillXPAndPickupFollowRoomHooks(t *testing.T) {
+	hooks := NewGameplayHooks()
+	hooks.Register(noPickupsHook{})
+	gs := newHookedGameServer(hooks)
+
+	assert.Equal(t, KillXPReward+50, gs.KillXPReward("killer", "victim"))
+	assert.False(t, gs.AllowWeaponPickup("player", &WeaponCrate{ID: "crate-1", WeaponType: "uzi"}))
+
+	plain := NewGameServer(noBroadcast)
+	assert.Equal(t, KillXPReward, plain.KillXPReward("killer", "victim"))
+	assert.True(t, plain.AllowWeaponPickup("player", &WeaponCrate{ID: "crate-1", WeaponType: "uzi"}))
+}
+
+func TestRegenerationAppliesRoomHealHook(t *testing.T) {
+	player := NewPlayerState("test-player")
+	player.TakeDamage(60)
+	now := time.Now().Add(6 * time.Second)
+
+	hooks := NewGameplayHooks()
+	hooks.Register(doubleDamageHook{})
+	player.ApplyRegenerationWith(now, 1.0, func(amount int) int {
+		return hooks.Heal(HealEvent{PlayerID: player.ID, Source: HealSourceRegeneration, Amount: amount})
+	})
+
+	assert.Equal(t, 45, player.Health, "10 HP of regeneration halved by the hook")
+}
+
+func TestGameplayHookFactoriesAttachToNewRooms(t *testing.T) {
+	manager := NewRoomManager()
+	manager.AddGameplayHookFactory(func(room *Room) GameplayHook {
+		if room.Kind != RoomKindCode {
+			return nil
+		}
+		return doubleDamageHook{}
+	})
+	flow := manager.SessionFlow()
+
+	flow.HandleHello(newSessionFlowPlayer("player-1"), map[string]any{"mode": "public"})
+	public := flow.HandleHello(newSessionFlowPlayer("player-2"), map[string]any{"mode": "public"})
+	code := flow.HandleHello(newSessionFlowPlayer("player-3"), map[string]any{"mode": "code", "code": "MODDED"})
+
+	require.NotNil(t, public.Room)
+	require.NotNil(t, code.Room)
+	assert.Zero(t, public.Room.Hooks.Len())
+	assert.Equal(t, 1, code.Room.Hooks.Len())
+	assert.Same(t, code.Room.Hooks, manager.GameplayHooksForPlayer("player-3"))
+	assert.Nil(t, manager.GameplayHooksForPlayer("nobody"))
+}
@@ -2,25 +2,41 @@ package game
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"math"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// tickStallThreshold is how far the gap between two ticks may exceed the tick
+// interval before it is reported as a server hiccup
+const tickStallThreshold = 250 * time.Millisecond
+
 // Shoot failure reasons
 const (
 	ShootFailedNoPlayer = "no_player"
 	ShootFailedCooldown = "cooldown"
 	ShootFailedEmpty    = "empty"
 	ShootFailedReload   = "reloading"
+
+	ShootFailedProjectileCap = "projectile_cap"
+
+	ShootFailedUnknownEffect  = "unknown_effect"
+	ShootFailedEffectNotOwned = "effect_not_owned"
 )
 
+// maxRewindMs caps how far back lag compensation rewinds victims for a hitscan shot
+const maxRewindMs = 150
+
 // ShootResult contains the result of a shoot attempt
 type ShootResult struct {
 	Success    bool
 	Reason     string
-	Projectile *Projectile
+	Projectile *Projectile   // Copy of the spawned projectile, safe to read after the tick loop recycles it
+	Pellets    []Projectile  // Copies of every pellet of a spread shot, with Projectile the first of them
+	Rejection  *HitRejection // Why the shot hit nobody, when the shooter may have expected a hit
 }
 
 // GameServer manages the game loop and physics simulation
@@ -30,9 +46,20 @@ type GameServer struct {
 	physics            *Physics
 	projectileManager  *ProjectileManager
 	weaponCrateManager *WeaponCrateManager
+	shieldCrateManager *ShieldCrateManager
+	healthPackManager  *HealthPackManager
+	cosmetics          *CosmeticInventory
 	weaponStates       map[string]*WeaponState
 	weaponMu           sync.RWMutex
 	positionHistory    *PositionHistory // Position history for lag compensation
+	movementGuard      *MovementGuard   // Flags impossible moves and aim flicks
+	inputClock         *InputClockGuard // Rejects replayed and clock-skewed inputs
+	aimLimiter         *AimLimiter      // Caps how fast a player's aim turns
+	inputQueue         *InputQueue      // Inputs waiting for the tick that applies them
+	dodgeRoll          DodgeRollConfig  // Roll i-frames and stamina cost given to every player
+	leveling           LevelCurve       // XP curve and unlocks given to every player
+	aimTurnRate        func(playerID string) float64
+	randomSource       func(playerID string) RandomSource // Seeded source of the player's room for spreads
 	tickRate           time.Duration
 	updateRate         time.Duration // Rate at which to broadcast updates to clients
 	clock              Clock         // Clock for time operations (injectable for testing)
@@ -44,9 +71,25 @@ type GameServer struct {
 	// Callback to get a player's RTT for lag compensation
 	getRTT func(playerID string) int64
 
-	running bool
-	mu      sync.RWMutex
-	wg      sync.WaitGroup
+	// Callback to find the gameplay hooks of a player's room
+	gameplayHooks func(playerID string) *GameplayHooks
+
+	// Callback to list the matches whose clocks run on this tick loop
+	activeMatches func() []*Match
+
+	// Callback to list the players of rooms kept alive with nobody connected
+	dormantPlayers func() map[string]bool
+
+	recorder   ActionRecorder // Receives ticks and actions for match replays; nil records nothing
+	recorderMu sync.RWMutex
+
+	tick atomic.Uint64 // Simulation ticks run so far, skipped idle ticks excluded
+
+	running   bool
+	cancel    context.CancelFunc // Stops the loops started by Start
+	idleSince time.Time          // Set while the tick loop is suspended with no connected players
+	mu        sync.RWMutex
+	wg        sync.WaitGroup
 }
 
 // NewGameServer creates a new game server with a real clock
@@ -75,16 +118,30 @@ func NewGameServerWithConfig(config GameServerConfig) *GameServer {
 		mapRegistry:        mapRegistry,
 		world:              NewWorldWithClock(clock, mapConfig),
 		physics:            NewPhysics(mapConfig),
-		projectileManager:  NewProjectileManager(mapConfig),
+		projectileManager:  newProjectileManagerWithLimits(mapConfig, config.ProjectileLimits),
 		weaponCrateManager: NewWeaponCrateManager(mapConfig),
+		shieldCrateManager: NewShieldCrateManager(mapConfig),
+		healthPackManager:  NewHealthPackManager(mapConfig),
+		cosmetics:          NewCosmeticInventory(),
 		weaponStates:       make(map[string]*WeaponState),
 		positionHistory:    NewPositionHistory(), // Initialize position history for lag compensation
+		movementGuard:      NewMovementGuard(config.MovementGuard),
+		inputClock:         NewInputClockGuard(config.InputClock),
+		aimLimiter:         NewAimLimiter(config.AimLimit),
+		inputQueue:         NewInputQueue(),
+		dodgeRoll:          config.DodgeRoll.withDefaults(),
+		leveling:           config.Leveling.withDefaults(),
+		aimTurnRate:        config.AimTurnRate,
+		randomSource:       config.RandomSource,
 		tickRate:           time.Duration(ServerTickInterval) * time.Millisecond,
 		updateRate:         time.Duration(ClientUpdateInterval) * time.Millisecond,
 		broadcastFunc:      config.BroadcastFunc,
 		clock:              clock,
 		eventSink:          config.EventSink,
 		getRTT:             config.RTTProvider,
+		gameplayHooks:      config.GameplayHooks,
+		activeMatches:      config.ActiveMatches,
+		dormantPlayers:     config.DormantPlayers,
 		running:            false,
 	}
 }
@@ -97,6 +154,7 @@ func (gs *GameServer) Start(ctx context.Context) {
 		return
 	}
 	gs.running = true
+	ctx, gs.cancel = context.WithCancel(ctx)
 	gs.mu.Unlock()
 
 	gs.wg.Add(2)
@@ -104,12 +162,18 @@ func (gs *GameServer) Start(ctx context.Context) {
 	go gs.broadcastLoop(ctx)
 }
 
-// Stop gracefully stops the game server
+// Stop gracefully stops the game server and waits for its loops to exit,
+// whether or not the context passed to Start was cancelled
 func (gs *GameServer) Stop() {
 	gs.mu.Lock()
 	gs.running = false
+	cancel := gs.cancel
+	gs.cancel = nil
 	gs.mu.Unlock()
 
+	if cancel != nil {
+		cancel()
+	}
 	gs.wg.Wait()
 }
 
@@ -130,39 +194,169 @@ func (gs *GameServer) tickLoop(ctx context.Context) {
 		case now := <-ticker.C:
 			// Calculate delta time in seconds
 			deltaTime := now.Sub(lastTick).Seconds()
+			gs.checkTickStall(now.Sub(lastTick))
 			lastTick = now
 
-			// Update all players
-			gs.updateAllPlayers(deltaTime)
+			// Skip simulation while no players are connected, and leave the
+			// players of rooms with nobody connected as they are
+			dormant := gs.dormantPlayerSet()
+			if gs.idleTick(now, dormant) {
+				continue
+			}
+
+			gs.step(now, deltaTime, dormant)
+		}
+	}
+}
+
+// Step runs one simulation tick of deltaTime seconds ending at now. The tick
+// loop calls it on every tick with players; headless simulations that drive
+// their own clock call it directly instead of Start.
+func (gs *GameServer) Step(now time.Time, deltaTime float64) {
+	gs.step(now, deltaTime, gs.dormantPlayerSet())
+}
+
+// step runs one tick, leaving the dormant players as they are
+func (gs *GameServer) step(now time.Time, deltaTime float64, dormant map[string]bool) {
+	gs.tick.Add(1)
+	players := gs.activePlayers(dormant)
+
+	// Advance match clocks by one simulation tick
+	gs.advanceMatches()
+
+	// Move players quarantined for non-finite positions back into play
+	gs.releaseQuarantinedPlayers()
 
-			// Record position snapshots for lag compensation (after movement update)
-			gs.recordPositionSnapshots(now)
+	// Apply at most one queued input per player
+	inputs := gs.applyQueuedInputs()
 
-			// Update all projectiles
-			gs.projectileManager.Update(deltaTime)
+	// Update all players
+	gs.updateAllPlayers(players, deltaTime)
 
-			// Check for projectile-player collisions (hit detection)
-			gs.checkHitDetection()
+	// Check movement against physics limits (after movement update)
+	gs.checkMovement(players, deltaTime, now)
 
-			// Check for reload completions
-			gs.checkReloads()
+	// Record position snapshots for lag compensation (after movement update)
+	gs.recordPositionSnapshots(players, now)
 
-			// Check for respawns
-			gs.checkRespawns()
+	// Update all projectiles
+	gs.projectileManager.Update(deltaTime)
+
+	// Check for projectile-player collisions (hit detection)
+	gs.checkHitDetection()
+
+	// Check for reload completions
+	gs.checkReloads()
+
+	// Check for respawns
+	gs.checkRespawns()
+
+	// Check for dodge roll duration completion
+	gs.checkRollDuration()
+
+	// Update invulnerability status
+	gs.updateInvulnerability()
+
+	// Update health and stamina regeneration
+	gs.updateHealthRegeneration(players, deltaTime)
+	gs.updateStaminaRegeneration(deltaTime)
+
+	// Grant participation XP to active players
+	gs.updateParticipationXP(players, deltaTime)
+
+	// Check for weapon respawns
+	gs.checkWeaponRespawns()
+
+	// Pick up shield crates players walked over, then respawn taken ones
+	gs.checkShieldPickups()
+	gs.checkShieldRespawns()
+
+	// Same for health packs
+	gs.checkHealthPackPickups()
+	gs.checkHealthPackRespawns()
+
+	// Hand the tick to a replay recording
+	gs.recordTick(now, deltaTime, inputs)
+}
+
+// idleTick reports whether this tick should be skipped because every player
+// in the world is dormant, or there are none. Crate respawn timers are frozen
+// while suspended and resume with the time they had left once the first
+// player returns.
+func (gs *GameServer) idleTick(now time.Time, dormant map[string]bool) bool {
+	idle := len(gs.activePlayers(dormant)) == 0
+
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	if idle {
+		if gs.idleSince.IsZero() {
+			gs.idleSince = now
+			log.Println("Game tick loop suspended (no connected players)")
+		}
+		return true
+	}
+
+	if !gs.idleSince.IsZero() {
+		gs.weaponCrateManager.DelayRespawns(now.Sub(gs.idleSince))
+		gs.shieldCrateManager.DelayRespawns(now.Sub(gs.idleSince))
+		gs.healthPackManager.DelayRespawns(now.Sub(gs.idleSince))
+		log.Printf("Game tick loop resumed after %v idle", now.Sub(gs.idleSince).Round(time.Millisecond))
+		gs.idleSince = time.Time{}
+	}
+	return false
+}
 
-			// Check for dodge roll duration completion
-			gs.checkRollDuration()
+// checkTickStall reports a gap between ticks well beyond the tick interval,
+// e.g. a GC pause or an overloaded host. The match clocks count ticks, so the
+// stalled time is never charged to them.
+func (gs *GameServer) checkTickStall(gap time.Duration) bool {
+	stalled := gap - gs.tickRate
+	if stalled < tickStallThreshold {
+		return false
+	}
+	log.Printf("Game tick loop stalled for %v; match clocks skip the lost ticks", stalled.Round(time.Millisecond))
+	return true
+}
 
-			// Update invulnerability status
-			gs.updateInvulnerability()
+// dormantPlayerSet asks which players are in rooms with nobody connected
+func (gs *GameServer) dormantPlayerSet() map[string]bool {
+	if gs.dormantPlayers == nil {
+		return nil
+	}
+	return gs.dormantPlayers()
+}
 
-			// Update health regeneration
-			gs.updateHealthRegeneration(deltaTime)
+// activePlayers returns the world's players less those in dormant
+func (gs *GameServer) activePlayers(dormant map[string]bool) []*PlayerState {
+	gs.world.mu.RLock()
+	defer gs.world.mu.RUnlock()
 
-			// Check for weapon respawns
-			gs.checkWeaponRespawns()
+	players := make([]*PlayerState, 0, len(gs.world.players))
+	for playerID, player := range gs.world.players {
+		if !dormant[playerID] {
+			players = append(players, player)
 		}
 	}
+	return players
+}
+
+// advanceMatches counts this tick toward every active match's time limit
+func (gs *GameServer) advanceMatches() {
+	if gs.activeMatches == nil {
+		return
+	}
+	for _, match := range gs.activeMatches() {
+		match.AdvanceTicks(1)
+	}
+}
+
+// IsSuspended returns true while the tick loop is idling with no connected
+// players
+func (gs *GameServer) IsSuspended() bool {
+	gs.mu.RLock()
+	defer gs.mu.RUnlock()
+	return !gs.idleSince.IsZero()
 }
 
 // broadcastLoop sends state updates to clients at ClientUpdateRate (20Hz)
@@ -189,18 +383,13 @@ func (gs *GameServer) broadcastLoop(ctx context.Context) {
 	}
 }
 
-// updateAllPlayers updates physics for all players
-func (gs *GameServer) updateAllPlayers(deltaTime float64) {
-	// Get all players (this is thread-safe and returns pointers)
-	gs.world.mu.RLock()
-	players := make([]*PlayerState, 0, len(gs.world.players))
-	for _, player := range gs.world.players {
-		players = append(players, player)
-	}
-	gs.world.mu.RUnlock()
-
-	// Update each player's physics
+// updateAllPlayers updates physics for the tick's players
+func (gs *GameServer) updateAllPlayers(players []*PlayerState, deltaTime float64) {
+	// Update each player's physics; quarantined players stay frozen
 	for _, player := range players {
+		if player.IsQuarantined() {
+			continue
+		}
 		result := gs.physics.UpdatePlayer(player, deltaTime)
 
 		if result.RollCancelled {
@@ -224,31 +413,105 @@ func (gs *GameServer) updateAllPlayers(deltaTime float64) {
 	}
 }
 
-// recordPositionSnapshots records current player positions for lag compensation
-func (gs *GameServer) recordPositionSnapshots(timestamp time.Time) {
-	// Get all players (thread-safe)
-	gs.world.mu.RLock()
-	defer gs.world.mu.RUnlock()
+// checkMovement reports players whose position jumped further this tick than
+// physics allows
+func (gs *GameServer) checkMovement(players []*PlayerState, deltaTime float64, now time.Time) {
+	for _, player := range players {
+		// A quarantined player counts as dead, so its release is not a teleport
+		alive := !player.IsDead() && !player.IsQuarantined()
+		violation := gs.movementGuard.CheckPosition(player.ID, player.GetPosition(), alive, deltaTime, now)
+		gs.reportMovementViolation(violation)
+	}
+}
 
-	// Record position snapshot for each player
-	for playerID, player := range gs.world.players {
-		position := player.GetPosition()
-		gs.positionHistory.RecordSnapshot(playerID, position, timestamp)
+// reportMovementViolation logs a violation and hands it to the event sink,
+// which kicks the player once the guard asks for it
+func (gs *GameServer) reportMovementViolation(violation *MovementViolationEvent) {
+	if violation == nil {
+		return
+	}
+	log.Printf("ANTI-CHEAT WARNING: Player %s %s: %.1f exceeds %.1f (%d recent)",
+		violation.PlayerID, violation.Kind, violation.Magnitude, violation.Limit, violation.Count)
+	gs.emitGameLoopEvent(*violation)
+}
+
+// IsMovementFlagged reports whether a player has broken a movement or aim limit
+func (gs *GameServer) IsMovementFlagged(playerID string) bool {
+	return gs.movementGuard.IsFlagged(playerID)
+}
+
+// inputClockLog samples rejected input timestamps; a replaying client can
+// trip it on every input
+var inputClockLog = HotPathLogger("input_clock")
+
+// AcceptInputTimestamp checks an input's client timestamp (Unix ms) against
+// the player's earlier inputs and returns false if the input must be dropped
+func (gs *GameServer) AcceptInputTimestamp(playerID string, clientTimestamp int64) bool {
+	violation := gs.inputClock.Check(playerID, clientTimestamp, gs.clock.Now())
+	if violation == nil {
+		return true
+	}
+	inputClockLog.Printf("ANTI-CHEAT WARNING: Player %s input timestamp %s by %dms (%d total)",
+		violation.PlayerID, violation.Kind, violation.Amount.Milliseconds(), violation.Count)
+	return false
+}
+
+// InputTimestampViolations returns how many of a player's inputs were dropped
+// for their timestamps
+func (gs *GameServer) InputTimestampViolations(playerID string) int {
+	return gs.inputClock.Violations(playerID)
+}
+
+// recordPositionSnapshots records the tick's player positions for lag compensation
+func (gs *GameServer) recordPositionSnapshots(players []*PlayerState, timestamp time.Time) {
+	// Record position snapshot for each player that moved this tick
+	for _, player := range players {
+		gs.positionHistory.RecordSnapshot(player.ID, player.GetPosition(), timestamp)
 	}
 }
 
-// AddPlayer adds a new player to the game world
+// AddPlayer adds a new player without a class to the game world
 func (gs *GameServer) AddPlayer(playerID string) *PlayerState {
+	return gs.AddPlayerAs(playerID, "")
+}
+
+// AddPlayerAs adds a new player to the game world with a class's stats. An
+// empty or unknown class name gives the standard stats.
+func (gs *GameServer) AddPlayerAs(playerID string, className string) *PlayerState {
+	class := classOrStandard(className)
 	player := gs.world.AddPlayer(playerID)
+	player.spawnAs(class)
+	player.setDodgeRoll(gs.dodgeRoll)
+	player.setLeveling(gs.leveling)
 
-	// Create weapon state for the player (everyone starts with a pistol)
+	// Create weapon state for the player with the class's starting weapon
+	weaponState := NewWeaponStateWithClock(class.newStartingWeapon(), gs.clock)
+	weaponState.equip(player.cooldowns)
 	gs.weaponMu.Lock()
-	gs.weaponStates[playerID] = NewWeaponStateWithClock(NewPistol(), gs.clock)
+	gs.weaponStates[playerID] = weaponState
 	gs.weaponMu.Unlock()
 
+	position := player.GetPosition()
+	gs.recordAction(Action{Kind: ActionJoin, PlayerID: playerID, Class: className, Position: &position})
 	return player
 }
 
+// SetPlayerClass picks the class a player respawns as. The player's current
+// life keeps its stats. Returns an error for an unknown class or player.
+func (gs *GameServer) SetPlayerClass(playerID string, className string) error {
+	gs.recordAction(Action{Kind: ActionClass, PlayerID: playerID, Class: className})
+	class, err := LookupPlayerClass(className)
+	if err != nil {
+		return err
+	}
+	player, exists := gs.world.GetPlayer(playerID)
+	if !exists {
+		return fmt.Errorf("player not found: %s", playerID)
+	}
+	player.SetClass(class)
+	return nil
+}
+
 func (gs *GameServer) SetPlayerDisplayName(playerID string, displayName string) bool {
 	player, exists := gs.world.GetPlayer(playerID)
 	if !exists {
@@ -260,36 +523,126 @@ func (gs *GameServer) SetPlayerDisplayName(playerID string, displayName string)
 
 // RemovePlayer removes a player from the game world
 func (gs *GameServer) RemovePlayer(playerID string) {
+	gs.recordAction(Action{Kind: ActionLeave, PlayerID: playerID})
 	gs.world.RemovePlayer(playerID)
 
 	// Remove weapon state
 	gs.weaponMu.Lock()
 	delete(gs.weaponStates, playerID)
 	gs.weaponMu.Unlock()
+
+	gs.cosmetics.Remove(playerID)
+	gs.movementGuard.Forget(playerID)
+	gs.inputClock.Forget(playerID)
+	gs.aimLimiter.Forget(playerID)
+	gs.inputQueue.Forget(playerID)
+}
+
+// inputQueueLog samples dropped inputs; a flooding client can trip it on
+// every input
+var inputQueueLog = HotPathLogger("input_queue")
+
+// QueuePlayerInput buffers a client input for the game loop, which applies
+// at most one per player per tick. Inputs are applied through
+// UpdatePlayerInputWithSequence, or UpdatePlayerInput without a sequence.
+func (gs *GameServer) QueuePlayerInput(playerID string, input QueuedInput) bool {
+	if _, exists := gs.world.GetPlayer(playerID); !exists {
+		return false
+	}
+
+	if !gs.inputQueue.Push(playerID, input) {
+		inputQueueLog.Printf("Input queue full for player %s: dropped its oldest input", playerID)
+	}
+	return true
+}
+
+// applyQueuedInputs applies this tick's queued input of each player and
+// returns them
+func (gs *GameServer) applyQueuedInputs() []PlayerInput {
+	inputs := gs.inputQueue.Next(gs.tickRate)
+	for _, queued := range inputs {
+		if queued.HasSequence {
+			gs.UpdatePlayerInputWithSequence(queued.PlayerID, queued.Input, queued.Sequence)
+		} else {
+			gs.UpdatePlayerInput(queued.PlayerID, queued.Input)
+		}
+	}
+	return inputs
 }
 
 // UpdatePlayerInput updates a player's input state
 func (gs *GameServer) UpdatePlayerInput(playerID string, input InputState) bool {
-	return gs.world.UpdatePlayerInput(playerID, input)
+	player, exists := gs.world.GetPlayer(playerID)
+	if !exists {
+		return false
+	}
+
+	requested := input.AimAngle
+	input.AimAngle = gs.limitAim(player, requested)
+	gs.world.UpdatePlayerInput(playerID, input)
+	gs.reportMovementViolation(gs.movementGuard.CheckAim(playerID, requested, gs.clock.Now()))
+	return true
 }
 
-// UpdatePlayerInputWithSequence updates a player's input state and sequence number
+// UpdatePlayerInputWithSequence updates a player's input state and sequence number.
+// An input older than the last processed sequence is stale and ignored, so the
+// acknowledged sequence clients reconcile against never moves backwards.
 func (gs *GameServer) UpdatePlayerInputWithSequence(playerID string, input InputState, sequence uint64) bool {
 	player, exists := gs.world.GetPlayer(playerID)
 	if !exists {
 		return false
 	}
 
+	// Update sequence number
+	if !player.AdvanceInputSequence(sequence) {
+		return true
+	}
+
 	// Update input state
+	requested := input.AimAngle
+	input.AimAngle = gs.limitAim(player, requested)
 	player.SetInput(input)
 	player.SetAimAngle(input.AimAngle)
+	gs.reportMovementViolation(gs.movementGuard.CheckAim(playerID, requested, gs.clock.Now()))
 
-	// Update sequence number
-	player.SetInputSequence(sequence)
+	return true
+}
+
+// ResetPlayerInput starts a player's input afresh for a new connection: no
+// keys held, no queued inputs and sequence 0. A client counts its input
+// sequence from the start of its connection, so a resumed or replacing
+// connection's inputs would otherwise be dropped as stale.
+func (gs *GameServer) ResetPlayerInput(playerID string) bool {
+	player, exists := gs.world.GetPlayer(playerID)
+	if !exists {
+		return false
+	}
 
+	input := QueuedInput{Input: InputState{AimAngle: player.GetAimAngle()}, HasSequence: true}
+	gs.recordAction(Action{Kind: ActionInput, PlayerID: playerID, Input: &input})
+	gs.inputQueue.Forget(playerID)
+	player.SetInputSequence(0)
+	player.SetInput(input.Input)
 	return true
 }
 
+// limitAim turns the player's aim toward requested no faster than the room's
+// turn rate and flags players whose aim is clamped chronically. The flick
+// check still sees the requested angle.
+func (gs *GameServer) limitAim(player *PlayerState, requested float64) float64 {
+	turnRate := 0.0
+	if gs.aimTurnRate != nil {
+		turnRate = gs.aimTurnRate(player.ID)
+	}
+
+	now := gs.clock.Now()
+	applied, chronic := gs.aimLimiter.Limit(player.ID, player.GetAimAngle(), requested, turnRate, now)
+	if chronic > 0 {
+		gs.reportMovementViolation(gs.movementGuard.RecordAimClamping(player.ID, chronic, gs.aimLimiter.config.ChronicClamps, now))
+	}
+	return applied
+}
+
 // GetPlayerState returns a snapshot of a player's state
 func (gs *GameServer) GetPlayerState(playerID string) (PlayerStateSnapshot, bool) {
 	player, exists := gs.world.GetPlayer(playerID)
@@ -330,6 +683,13 @@ func (gs *GameServer) IsRunning() bool {
 	return gs.running
 }
 
+// Tick returns how many simulation ticks the tick loop has run. Clients
+// order and time state messages by it; it does not advance while the loop is
+// suspended without players.
+func (gs *GameServer) Tick() uint64 {
+	return gs.tick.Load()
+}
+
 // GetWorld returns the game world
 func (gs *GameServer) GetWorld() *World {
 	return gs.world
@@ -353,19 +713,69 @@ func (gs *GameServer) SetWeaponState(playerID string, weaponState *WeaponState)
 		existingWeapon.CancelReload()
 	}
 
+	if player, exists := gs.world.GetPlayer(playerID); exists {
+		weaponState.equip(player.cooldowns)
+	}
 	gs.weaponStates[playerID] = weaponState
 }
 
+// DumpCooldowns lists every running ability cooldown, for debugging
+func (gs *GameServer) DumpCooldowns() []CooldownEntry {
+	return gs.world.cooldowns.Dump()
+}
+
+// AbilityStates returns a player's weapon and dodge roll cooldowns for its
+// client; nil if the player is not in the game
+func (gs *GameServer) AbilityStates(playerID string) []AbilityState {
+	player, exists := gs.world.GetPlayer(playerID)
+	if !exists {
+		return nil
+	}
+
+	states := make([]AbilityState, 0, 2)
+	if ws := gs.GetWeaponState(playerID); ws != nil {
+		ability := ws.cooldownAbility()
+		remaining := player.cooldowns.Remaining(ability)
+		charges := 1
+		if remaining > 0 {
+			charges = 0
+		}
+		states = append(states, AbilityState{Ability: ability, RemainingMs: remaining.Milliseconds(), Charges: charges, MaxCharges: 1})
+	}
+
+	class := player.Class()
+	charges, nextCharge := player.cooldowns.Charges(CooldownRoll, class.rollRecharge(), class.RollCharges)
+	return append(states, AbilityState{
+		Ability:     CooldownRoll,
+		RemainingMs: nextCharge.Milliseconds(),
+		Charges:     charges,
+		MaxCharges:  class.RollCharges,
+	})
+}
+
 // PlayerShoot attempts to fire a weapon for the given player
 // If the magazine is empty, automatically triggers a reload
 // For hitscan weapons: applies lag compensation using clientTimestamp and RTT
 // For projectile weapons: creates a projectile
 func (gs *GameServer) PlayerShoot(playerID string, aimAngle float64, clientTimestamp int64) ShootResult {
+	return gs.PlayerShootWithEffect(playerID, aimAngle, clientTimestamp, "")
+}
+
+// PlayerShootWithEffect is PlayerShoot with the shooter's equipped trail
+// effect. An unknown or unowned effect rejects the shot before it uses ammo.
+func (gs *GameServer) PlayerShootWithEffect(playerID string, aimAngle float64, clientTimestamp int64, effectID string) ShootResult {
+	gs.recordShot(playerID, aimAngle, clientTimestamp, effectID)
+
 	// Check if player exists
 	player, exists := gs.world.GetPlayer(playerID)
 	if !exists {
 		return ShootResult{Success: false, Reason: ShootFailedNoPlayer}
 	}
+	player.MarkActive()
+
+	if reason := gs.cosmetics.checkTrailEffect(playerID, effectID); reason != "" {
+		return ShootResult{Success: false, Reason: reason}
+	}
 
 	// Get weapon state
 	gs.weaponMu.RLock()
@@ -377,7 +787,7 @@ func (gs *GameServer) PlayerShoot(playerID string, aimAngle float64, clientTimes
 	}
 
 	// Check if reloading
-	if ws.IsReloading {
+	if ws.Reloading() {
 		return ShootResult{Success: false, Reason: ShootFailedReload}
 	}
 
@@ -390,7 +800,13 @@ func (gs *GameServer) PlayerShoot(playerID string, aimAngle float64, clientTimes
 
 	// Check fire rate cooldown
 	if !ws.CanShoot() {
-		return ShootResult{Success: false, Reason: ShootFailedCooldown}
+		return ShootResult{Success: false, Reason: ShootFailedCooldown, Rejection: &HitRejection{Code: HitRejectedCooldown}}
+	}
+
+	// Under the reject policy, a shot that would exceed the projectile caps
+	// fails before it uses ammo
+	if !ws.Weapon.IsHitscan && !gs.projectileManager.CanSpawn(playerID, projectilesPerShot(ws.Weapon)) {
+		return ShootResult{Success: false, Reason: ShootFailedProjectileCap}
 	}
 
 	// Record the shot (decrements ammo, sets cooldown)
@@ -404,9 +820,47 @@ func (gs *GameServer) PlayerShoot(playerID string, aimAngle float64, clientTimes
 
 	// Projectile weapon: create projectile (no lag compensation)
 	pos := getWeaponFireOrigin(player.GetPosition(), aimAngle, ws.Weapon.Name)
-	proj := gs.projectileManager.CreateProjectile(
+	if ws.Weapon.FiresPellets() {
+		pellets := gs.projectileManager.SpawnPellets(
+			playerID,
+			ws.Weapon.Name,
+			effectID,
+			pos,
+			CalculateShotgunPelletAnglesFrom(gs.randomSourceFor(playerID), aimAngle, ws.Weapon.ArcDegrees),
+			ws.Weapon.ProjectileSpeed,
+			ShotgunPelletDamages(ws.Weapon.Damage),
+			ws.Weapon.Range,
+		)
+		return ShootResult{
+			Success:    true,
+			Projectile: &pellets[0],
+			Pellets:    pellets,
+		}
+	}
+
+	if ws.Weapon.IsExplosive() {
+		rocket := gs.projectileManager.SpawnExplosive(
+			playerID,
+			ws.Weapon.Name,
+			effectID,
+			pos,
+			aimAngle,
+			ws.Weapon.ProjectileSpeed,
+			ws.Weapon.Damage,
+			ws.Weapon.Range,
+			ws.Weapon.SplashRadius,
+			ws.Weapon.KnockbackDistance,
+		)
+		return ShootResult{
+			Success:    true,
+			Projectile: &rocket,
+		}
+	}
+
+	proj := gs.projectileManager.SpawnProjectile(
 		playerID,
 		ws.Weapon.Name,
+		effectID,
 		pos,
 		aimAngle,
 		ws.Weapon.ProjectileSpeed,
@@ -414,7 +868,7 @@ func (gs *GameServer) PlayerShoot(playerID string, aimAngle float64, clientTimes
 
 	return ShootResult{
 		Success:    true,
-		Projectile: proj,
+		Projectile: &proj,
 	}
 }
 
@@ -423,7 +877,10 @@ type MeleeResult struct {
 	Success          bool
 	Reason           string
 	HitPlayers       []*PlayerState
+	Damages          []int // Damage dealt to each of HitPlayers
 	KnockbackApplied bool
+	Rejection        *HitRejection // Why the swing hit nobody, when a target was nearly in reach
+	Combo            int           // Hit of the weapon's combo the swing landed as (1 or MeleeComboHits); 0 when it hit nobody
 }
 
 // Melee attack failure reasons
@@ -437,11 +894,14 @@ const (
 
 // PlayerMeleeAttack attempts a melee attack for the given player
 func (gs *GameServer) PlayerMeleeAttack(playerID string, aimAngle float64) MeleeResult {
+	gs.recordAction(Action{Kind: ActionMelee, PlayerID: playerID, AimAngle: aimAngle})
+
 	// Check if player exists
 	player, exists := gs.world.GetPlayer(playerID)
 	if !exists {
 		return MeleeResult{Success: false, Reason: MeleeFailedNoPlayer}
 	}
+	player.MarkActive()
 
 	// Check if player is alive
 	if !player.IsAlive() {
@@ -462,7 +922,7 @@ func (gs *GameServer) PlayerMeleeAttack(playerID string, aimAngle float64) Melee
 		return MeleeResult{Success: false, Reason: MeleeFailedNotMelee}
 	}
 	if !ws.CanShoot() {
-		return MeleeResult{Success: false, Reason: MeleeFailedCooldown}
+		return MeleeResult{Success: false, Reason: MeleeFailedCooldown, Rejection: &HitRejection{Code: HitRejectedCooldown}}
 	}
 
 	// Update player's aim angle for the attack
@@ -476,21 +936,49 @@ func (gs *GameServer) PlayerMeleeAttack(playerID string, aimAngle float64) Melee
 	}
 	gs.world.mu.RUnlock()
 
+	// A swing landing soon enough after the last one finishes its combo
+	combo := ws.meleeComboStep()
+
 	// Consume melee cooldown even if no victim is reachable.
 	ws.RecordShot()
 
-	// Perform the melee attack
-	result := PerformMeleeAttack(player, allPlayers, ws.Weapon, gs.world.GetMapConfig())
+	// Perform the melee attack, letting the attacker's ultimate and the room's hooks adjust damage
+	damageFor := func(target *PlayerState) int {
+		return gs.outgoingDamage(DamageEvent{
+			AttackerID: playerID,
+			VictimID:   target.ID,
+			Weapon:     ws.Weapon.Name,
+			Source:     DamageSourceMelee,
+			Damage:     ws.Weapon.comboDamage(combo),
+		})
+	}
+	result := PerformMeleeAttackWithDamage(player, allPlayers, ws.Weapon, damageFor, gs.world.GetMapConfig())
+	landed := len(result.HitPlayers) > 0
+	ws.recordMeleeSwing(combo, landed)
+	if !landed {
+		combo = 0
+	}
+	for _, damage := range result.Damages {
+		player.AddUltimateCharge(float64(damage) * UltimateChargePerDamage)
+	}
+	for _, target := range result.HitPlayers {
+		gs.world.Spawns().RecordCombat(target.GetPosition())
+	}
 
 	return MeleeResult{
 		Success:          true,
 		HitPlayers:       result.HitPlayers,
+		Damages:          result.Damages,
 		KnockbackApplied: result.KnockbackApplied,
+		Rejection:        result.Rejection,
+		Combo:            combo,
 	}
 }
 
 // PlayerReload starts the reload process for a player
 func (gs *GameServer) PlayerReload(playerID string) bool {
+	gs.recordAction(Action{Kind: ActionReload, PlayerID: playerID})
+
 	gs.weaponMu.RLock()
 	ws := gs.weaponStates[playerID]
 	gs.weaponMu.RUnlock()
@@ -499,13 +987,30 @@ func (gs *GameServer) PlayerReload(playerID string) bool {
 		return false
 	}
 
-	// Check if magazine is already full
-	if ws.CurrentAmmo >= ws.Weapon.MagazineSize {
+	// A full magazine does not reload
+	return ws.StartReload()
+}
+
+// PickUpWeapon takes the weapon from a crate, replacing the player's current
+// one. Callers check the player may reach the crate first. Returns false if
+// the crate is unknown, already taken or holds an unknown weapon.
+func (gs *GameServer) PickUpWeapon(playerID, crateID string) bool {
+	gs.recordAction(Action{Kind: ActionPickup, PlayerID: playerID, CrateID: crateID})
+
+	crate := gs.weaponCrateManager.GetCrate(crateID)
+	if crate == nil || !gs.weaponCrateManager.PickupCrate(crateID) {
 		return false
 	}
 
-	ws.StartReload()
-	return ws.IsReloading
+	weapon, err := CreateWeaponByType(crate.WeaponType)
+	if err != nil {
+		log.Printf("Failed to create weapon %s: %v", crate.WeaponType, err)
+		// Return crate to available state
+		crate.IsAvailable = true
+		return false
+	}
+	gs.SetWeaponState(playerID, NewWeaponStateWithClock(weapon, gs.clock))
+	return true
 }
 
 // checkReloads checks all players for completed reloads
@@ -535,6 +1040,21 @@ func (gs *GameServer) GetWeaponCrateManager() *WeaponCrateManager {
 	return gs.weaponCrateManager
 }
 
+// GetShieldCrateManager returns the shield crate manager
+func (gs *GameServer) GetShieldCrateManager() *ShieldCrateManager {
+	return gs.shieldCrateManager
+}
+
+// GetHealthPackManager returns the health pack manager
+func (gs *GameServer) GetHealthPackManager() *HealthPackManager {
+	return gs.healthPackManager
+}
+
+// GetCosmeticInventory returns the players' owned cosmetic effects
+func (gs *GameServer) GetCosmeticInventory() *CosmeticInventory {
+	return gs.cosmetics
+}
+
 // MarkPlayerDead marks a player as dead
 func (gs *GameServer) MarkPlayerDead(playerID string) {
 	player, exists := gs.world.GetPlayer(playerID)
@@ -576,6 +1096,21 @@ func (gs *GameServer) checkHitDetection() {
 
 	// Process each hit
 	for _, hit := range hits {
+		// An earlier hit this tick (e.g. another pellet of the same shot)
+		// already killed the victim
+		if victim, exists := gs.world.GetPlayer(hit.VictimID); exists && !victim.IsAlive() {
+			continue
+		}
+
+		// Explosive projectiles blow up where they meet the victim instead of
+		// dealing direct damage
+		if proj := gs.projectileManager.GetProjectileByID(hit.ProjectileID); proj != nil && proj.IsExplosive() {
+			rocket := *proj
+			gs.projectileManager.RemoveProjectile(rocket.ID)
+			gs.detonate(rocket, hit.Point)
+			continue
+		}
+
 		outcome, ok := gs.ProcessProjectileHit(hit)
 		if !ok {
 			continue
@@ -584,9 +1119,15 @@ func (gs *GameServer) checkHitDetection() {
 		gs.emitGameLoopEvent(ProjectileHitResolvedEvent{Outcome: outcome})
 	}
 
+	// Projectiles stopped by a wall are removed; explosive ones blow up there
 	for _, proj := range gs.projectileManager.GetProjectilesForHitDetection() {
-		if proj.PendingRemoval {
-			gs.projectileManager.RemoveProjectile(proj.ID)
+		if !proj.PendingRemoval {
+			continue
+		}
+		stopped := *proj
+		gs.projectileManager.RemoveProjectile(stopped.ID)
+		if stopped.IsExplosive() {
+			gs.detonate(stopped, stopped.Position)
 		}
 	}
 }
@@ -622,6 +1163,67 @@ func (gs *GameServer) checkRollDuration() {
 	}
 }
 
+// StartDodgeRoll starts a dodge roll in direction, a unit vector. Returns
+// false if the player is unknown or may not roll yet.
+func (gs *GameServer) StartDodgeRoll(playerID string, direction Vector2) bool {
+	gs.recordAction(Action{Kind: ActionDodgeRoll, PlayerID: playerID, Direction: &direction})
+
+	player, exists := gs.world.GetPlayer(playerID)
+	if !exists || !player.CanDodgeRoll() {
+		return false
+	}
+	player.StartDodgeRoll(direction)
+	return true
+}
+
+// CreditMeleeKill marks a victim a melee attack killed as dead and credits
+// the attacker with the kill
+func (gs *GameServer) CreditMeleeKill(attackerID, victimID string) KillStreak {
+	gs.MarkPlayerDead(victimID)
+	streak, _ := gs.CreditKill(attackerID, victimID)
+	if victim, exists := gs.world.GetPlayer(victimID); exists {
+		victim.IncrementDeaths()
+	}
+	return streak
+}
+
+// RequestRespawn asks for a dead player to be respawned by the game loop once
+// the respawn delay has passed. Returns false for an unknown or living player.
+func (gs *GameServer) RequestRespawn(playerID string) bool {
+	gs.recordAction(Action{Kind: ActionRespawn, PlayerID: playerID})
+	player, exists := gs.world.GetPlayer(playerID)
+	if !exists {
+		return false
+	}
+	return player.RequestRespawn()
+}
+
+// ChooseSpawn records a dead player's pick of the spawn point at index.
+// Returns false if the player is not dead or may not spawn there right now.
+func (gs *GameServer) ChooseSpawn(playerID string, index int) bool {
+	gs.recordAction(Action{Kind: ActionSpawnChoice, PlayerID: playerID, Index: index})
+	player, exists := gs.world.GetPlayer(playerID)
+	if !exists || !player.IsDead() {
+		return false
+	}
+	if _, allowed := gs.world.ChosenSpawnPoint(playerID, index); !allowed {
+		return false
+	}
+	return player.ChooseSpawn(index)
+}
+
+// respawnPoint returns where a player respawns: the spawn point they picked
+// if it is still allowed, the safest one otherwise
+func (gs *GameServer) respawnPoint(player *PlayerState) Vector2 {
+	if index, chosen := player.SpawnChoice(); chosen {
+		if spawnPos, allowed := gs.world.ChosenSpawnPoint(player.ID, index); allowed {
+			return spawnPos
+		}
+	}
+	return gs.world.GetBalancedSpawnPoint(player.ID)
+}
+
+// checkRespawns respawns each dead player whose respawn is due
 func (gs *GameServer) checkRespawns() {
 	// Get all players
 	gs.world.mu.RLock()
@@ -633,22 +1235,25 @@ func (gs *GameServer) checkRespawns() {
 
 	// Check each player for respawn
 	for _, player := range players {
-		if player.IsDead() && player.CanRespawn() {
-			// Get balanced spawn point
-			spawnPos := gs.world.GetBalancedSpawnPoint(player.ID)
+		if player.RespawnDue() {
+			// Get the picked or the safest spawn point
+			spawnPos := gs.respawnPoint(player)
 
 			// Respawn the player
 			player.Respawn(spawnPos)
 
-			// Reset weapon state to default pistol (AC: "respawn with default pistol")
+			// Reset weapon state to the class's starting weapon (a pistol without a class)
+			class := player.Class()
+			weaponState := NewWeaponStateWithClock(class.newStartingWeapon(), gs.clock)
+			weaponState.equip(player.cooldowns)
 			gs.weaponMu.Lock()
-			gs.weaponStates[player.ID] = NewWeaponStateWithClock(NewPistol(), gs.clock)
+			gs.weaponStates[player.ID] = weaponState
 			gs.weaponMu.Unlock()
 
 			gs.emitGameLoopEvent(PlayerRespawnedEvent{
 				PlayerID:  player.ID,
 				Position:  spawnPos,
-				NewHealth: PlayerMaxHealth,
+				NewHealth: class.MaxHealth,
 			})
 		}
 	}
@@ -671,8 +1276,29 @@ func (gs *GameServer) updateInvulnerability() {
 }
 
 // updateHealthRegeneration applies health regeneration to all players
-func (gs *GameServer) updateHealthRegeneration(deltaTime float64) {
-	// Get all players
+func (gs *GameServer) updateHealthRegeneration(players []*PlayerState, deltaTime float64) {
+	now := gs.clock.Now()
+
+	// Update each player's regeneration
+	for _, player := range players {
+		// Update regeneration state
+		player.UpdateRegenerationState(now)
+
+		// Apply regeneration if applicable, letting the room's hooks adjust it
+		hooks := gs.hooksFor(player.ID)
+		if hooks.Len() == 0 {
+			player.ApplyRegeneration(now, deltaTime)
+			continue
+		}
+		playerID := player.ID
+		player.ApplyRegenerationWith(now, deltaTime, func(amount int) int {
+			return hooks.Heal(HealEvent{PlayerID: playerID, Source: HealSourceRegeneration, Amount: amount})
+		})
+	}
+}
+
+// updateStaminaRegeneration restores stamina to all players
+func (gs *GameServer) updateStaminaRegeneration(deltaTime float64) {
 	gs.world.mu.RLock()
 	players := make([]*PlayerState, 0, len(gs.world.players))
 	for _, player := range gs.world.players {
@@ -681,14 +1307,8 @@ func (gs *GameServer) updateHealthRegeneration(deltaTime float64) {
 	gs.world.mu.RUnlock()
 
 	now := gs.clock.Now()
-
-	// Update each player's regeneration
 	for _, player := range players {
-		// Update regeneration state
-		player.UpdateRegenerationState(now)
-
-		// Apply regeneration if applicable
-		player.ApplyRegeneration(now, deltaTime)
+		player.ApplyStaminaRegeneration(now, deltaTime)
 	}
 }
 
@@ -710,8 +1330,96 @@ func (gs *GameServer) checkWeaponRespawns() {
 	}
 }
 
+// checkShieldPickups gives each living player standing on an available shield
+// crate its shield. Players already at ShieldMax leave the crate for others.
+func (gs *GameServer) checkShieldPickups() {
+	gs.world.mu.RLock()
+	players := make([]*PlayerState, 0, len(gs.world.players))
+	for _, player := range gs.world.players {
+		players = append(players, player)
+	}
+	gs.world.mu.RUnlock()
+
+	for _, player := range players {
+		if !player.IsAlive() || player.GetShield() >= ShieldMax {
+			continue
+		}
+		crateID := gs.shieldCrateManager.CrateInRange(player.GetPosition())
+		if crateID == "" || !gs.shieldCrateManager.PickupCrate(crateID) {
+			continue
+		}
+
+		shield := player.AddShield(ShieldPickupAmount)
+		gs.emitGameLoopEvent(ShieldPickedUpEvent{
+			PlayerID:    player.ID,
+			CrateID:     crateID,
+			Shield:      shield,
+			RespawnTime: gs.shieldCrateManager.GetCrate(crateID).RespawnTime,
+		})
+	}
+}
+
+// checkShieldRespawns checks for shield crates that should respawn
+func (gs *GameServer) checkShieldRespawns() {
+	for _, crateID := range gs.shieldCrateManager.UpdateRespawns() {
+		crate := gs.shieldCrateManager.GetCrate(crateID)
+		if crate != nil {
+			gs.emitGameLoopEvent(ShieldCrateRespawnedEvent{
+				CrateID:  crate.ID,
+				Position: crate.Position,
+			})
+		}
+	}
+}
+
+// checkHealthPackPickups heals each living, hurt player standing on an
+// available health pack. Players at full health leave the pack for others.
+// The room's gameplay hooks may change the amount healed.
+func (gs *GameServer) checkHealthPackPickups() {
+	gs.world.mu.RLock()
+	players := make([]*PlayerState, 0, len(gs.world.players))
+	for _, player := range gs.world.players {
+		players = append(players, player)
+	}
+	gs.world.mu.RUnlock()
+
+	for _, player := range players {
+		snapshot := player.Snapshot()
+		if snapshot.DeathTime != nil || snapshot.Health >= snapshot.MaxHealth {
+			continue
+		}
+		packID := gs.healthPackManager.PackInRange(snapshot.Position)
+		if packID == "" || !gs.healthPackManager.PickupPack(packID) {
+			continue
+		}
+
+		amount := gs.hooksFor(player.ID).Heal(HealEvent{PlayerID: player.ID, Source: HealSourceHealthPack, Amount: HealthPackAmount})
+		newHealth := player.RestoreHealth(amount)
+		gs.emitGameLoopEvent(HealthPackPickedUpEvent{
+			PlayerID:    player.ID,
+			PackID:      packID,
+			Healed:      newHealth - snapshot.Health,
+			NewHealth:   newHealth,
+			RespawnTime: gs.healthPackManager.GetPack(packID).RespawnTime,
+		})
+	}
+}
+
+// checkHealthPackRespawns checks for health packs that should respawn
+func (gs *GameServer) checkHealthPackRespawns() {
+	for _, packID := range gs.healthPackManager.UpdateRespawns() {
+		pack := gs.healthPackManager.GetPack(packID)
+		if pack != nil {
+			gs.emitGameLoopEvent(HealthPackRespawnedEvent{
+				PackID:   pack.ID,
+				Position: pack.Position,
+			})
+		}
+	}
+}
+
 // processHitscanShot performs lag-compensated hit detection for hitscan weapons
-// Story 4.5: Rewinds player positions by (shooterRTT + victimRTT)/2, clamped to 150ms
+// Story 4.5: Rewinds player positions by (shooterRTT + victimRTT)/2, clamped to maxRewindMs
 func (gs *GameServer) processHitscanShot(shooterID string, shooter *PlayerState, weapon *Weapon, aimAngle float64, clientTimestamp int64) ShootResult {
 	// Get shooter's RTT
 	shooterRTT := int64(0)
@@ -721,29 +1429,69 @@ func (gs *GameServer) processHitscanShot(shooterID string, shooter *PlayerState,
 
 	// Calculate rewind time (half of total RTT for fairness)
 	// In real lag comp, we'd use (shooterRTT + victimRTT)/2 per victim
-	// For simplicity, using shooterRTT as baseline, clamped to 150ms max
+	// For simplicity, using shooterRTT as baseline, clamped to maxRewindMs
 	rewindMs := shooterRTT
-	const maxRewindMs = 150
 	if rewindMs > maxRewindMs {
 		rewindMs = maxRewindMs
 	}
 
 	// Calculate query time for position history
-	rewindDuration := time.Duration(rewindMs) * time.Millisecond
-	queryTime := gs.clock.Now().Add(-rewindDuration)
+	now := gs.clock.Now()
+	queryTime := now.Add(-time.Duration(rewindMs) * time.Millisecond)
 
 	// Get shooter muzzle origin
 	shooterPos := getWeaponFireOrigin(shooter.GetPosition(), aimAngle, weapon.Name)
 
-	shotEnd := rayEnd(shooterPos, aimAngle, weapon.Range)
-	wallContact, wallBlocked := firstObstacleContact(shooterPos, shotEnd, gs.physics.mapConfig.Obstacles, func(obstacle MapObstacle) bool {
+	hitVictim, rejection := gs.traceHitscan(shooterID, shooterPos, aimAngle, weapon, queryTime)
+	if hitVictim == nil && rejection == nil && shooterRTT > maxRewindMs {
+		// Tell the shooter when its view was too stale to honour
+		fullRewind := now.Add(-time.Duration(shooterRTT) * time.Millisecond)
+		if victim, _ := gs.traceHitscan(shooterID, shooterPos, aimAngle, weapon, fullRewind); victim != nil {
+			rejection = &HitRejection{Code: HitRejectedRewindLimit, VictimID: victim.ID}
+		}
+	}
+
+	// Apply damage if hit
+	if hitVictim != nil {
+		hit := HitEvent{
+			ProjectileID: "hitscan",
+			AttackerID:   shooterID,
+			VictimID:     hitVictim.ID,
+			Direction:    Vector2{X: math.Cos(aimAngle), Y: math.Sin(aimAngle)},
+		}
+		outcome, ok := gs.ProcessProjectileHit(hit)
+		if ok {
+			gs.emitGameLoopEvent(ProjectileHitResolvedEvent{Outcome: outcome})
+		}
+	}
+
+	return ShootResult{
+		Success:    true,
+		Projectile: nil, // No projectile for hitscan
+		Rejection:  rejection,
+	}
+}
+
+// traceHitscan finds the nearest player a hitscan ray hits, with victims at
+// their positions at queryTime. With no hit, it explains the nearest player
+// the ray reached before any wall but could not damage: one with spawn
+// protection or roll i-frames, or one past the weapon's range.
+func (gs *GameServer) traceHitscan(shooterID string, origin Vector2, aimAngle float64, weapon *Weapon, queryTime time.Time) (*PlayerState, *HitRejection) {
+	// Trace across the whole arena so targets just out of range are seen too
+	mapConfig := gs.physics.mapConfig
+	reach := math.Max(weapon.Range, math.Hypot(mapConfig.Width, mapConfig.Height))
+	shotEnd := rayEnd(origin, aimAngle, reach)
+	wallContact, wallBlocked := firstObstacleContact(origin, shotEnd, mapConfig.Obstacles, func(obstacle MapObstacle) bool {
 		return obstacle.BlocksProjectiles || obstacle.BlocksLineOfSight
 	})
 
-	// Perform raycast hit detection at rewound positions
 	gs.world.mu.RLock()
-	var hitVictim *PlayerState
-	var hitDistance float64 = weapon.Range + 1 // Start beyond range
+	defer gs.world.mu.RUnlock()
+
+	var hitVictim, missed *PlayerState
+	hitDistance := math.Inf(1)
+	missDistance := math.Inf(1)
+	missCode := ""
 	rayDirX := math.Cos(aimAngle)
 	rayDirY := math.Sin(aimAngle)
 
@@ -760,42 +1508,44 @@ func (gs *GameServer) processHitscanShot(shooterID string, shooter *PlayerState,
 			victimPos = victim.GetPosition()
 		}
 
-		projection := (victimPos.X-shooterPos.X)*rayDirX + (victimPos.Y-shooterPos.Y)*rayDirY
+		projection := (victimPos.X-origin.X)*rayDirX + (victimPos.Y-origin.Y)*rayDirY
 		if projection <= 0 {
 			continue
 		}
 
-		contact, hit := segmentPlayerHitboxContact(shooterPos, shotEnd, victimPos)
+		contact, hit := segmentPlayerHitboxContact(origin, shotEnd, victimPos)
 		if !hit {
 			continue
 		}
 		if wallBlocked && wallContact.Distance <= contact.Distance {
 			continue
 		}
-		if contact.Distance < hitDistance {
+
+		code := ""
+		switch {
+		case contact.Distance > weapon.Range:
+			code = HitRejectedOutOfRange
+		case victim.IsDamageImmune():
+			code = HitRejectedInvulnerable
+		}
+		if code == "" && contact.Distance < hitDistance {
 			hitDistance = contact.Distance
 			hitVictim = victim
 		}
+		if code != "" && contact.Distance < missDistance {
+			missDistance = contact.Distance
+			missed = victim
+			missCode = code
+		}
 	}
-	gs.world.mu.RUnlock()
 
-	// Apply damage if hit
 	if hitVictim != nil {
-		hit := HitEvent{
-			ProjectileID: "hitscan",
-			AttackerID:   shooterID,
-			VictimID:     hitVictim.ID,
-		}
-		outcome, ok := gs.ProcessProjectileHit(hit)
-		if ok {
-			gs.emitGameLoopEvent(ProjectileHitResolvedEvent{Outcome: outcome})
-		}
+		return hitVictim, nil
 	}
-
-	return ShootResult{
-		Success:    true,
-		Projectile: nil, // No projectile for hitscan
+	if missed != nil {
+		return nil, &HitRejection{Code: missCode, VictimID: missed.ID}
 	}
+	return nil, nil
 }
 
 func (gs *GameServer) emitGameLoopEvent(event GameLoopEvent) {
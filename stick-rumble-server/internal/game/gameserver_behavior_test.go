@@ -93,7 +93,7 @@ func TestPlayerMeleeAttack_WallBlockedStillConsumesCooldown(t *testing.T) {
 	mapConfig.Obstacles = []MapObstacle{
 		{ID: "wall", X: 130, Y: 80, Width: 20, Height: 40, BlocksMovement: true, BlocksProjectiles: true, BlocksLineOfSight: true},
 	}
-	gs.world.mapConfig = mapConfig
+	setWorldMapConfig(gs.world, mapConfig)
 	gs.physics = NewPhysics(mapConfig)
 	gs.projectileManager = NewProjectileManager(mapConfig)
 
@@ -121,7 +121,7 @@ func TestPlayerMeleeAttack_UsesWorldMapConfigForWallBlocking(t *testing.T) {
 	mapConfig.Obstacles = []MapObstacle{
 		{ID: "wall", X: 130, Y: 80, Width: 20, Height: 40, BlocksMovement: true, BlocksProjectiles: true, BlocksLineOfSight: true},
 	}
-	gs.world.mapConfig = mapConfig
+	setWorldMapConfig(gs.world, mapConfig)
 	gs.physics = NewPhysics(mapConfig)
 	gs.projectileManager = NewProjectileManager(mapConfig)
 
@@ -1,8 +1,8 @@
 package game
 
 import (
+	"math"
 	"testing"
-	"time"
 )
 
 func TestGameServerHitDetection(t *testing.T) {
@@ -132,7 +132,7 @@ func TestGameServerHitDetection_MultipleHits(t *testing.T) {
 
 		// Reset cooldown for next shot
 		ws := gs.GetWeaponState(player1ID)
-		ws.LastShotTime = time.Now().Add(-1 * time.Second)
+		ws.cooldowns.Reset(CooldownShoot)
 	}
 
 	// Verify 4 hits
@@ -187,3 +187,150 @@ func TestGameServerHitDetection_DeadPlayerNoHit(t *testing.T) {
 		t.Error("Should not hit dead players")
 	}
 }
+
+// fireShotgun gives shooterID a shotgun, fires it to the right and runs hit
+// detection until every pellet is gone
+func fireShotgun(t *testing.T, gs *GameServer, shooterID string) ShootResult {
+	t.Helper()
+
+	gs.SetWeaponState(shooterID, NewWeaponState(NewShotgun()))
+	result := gs.PlayerShoot(shooterID, 0.0, 0)
+	if !result.Success {
+		t.Fatal("PlayerShoot should succeed")
+	}
+	for i := 0; i < 60 && len(gs.GetActiveProjectiles()) > 0; i++ {
+		gs.projectileManager.Update(1.0 / 60.0)
+		gs.checkHitDetection()
+	}
+	if remaining := len(gs.GetActiveProjectiles()); remaining != 0 {
+		t.Fatalf("Expected every pellet to be gone, %d left", remaining)
+	}
+	return result
+}
+
+func TestGameServerHitDetection_ShotgunPellets(t *testing.T) {
+	sink := &recordingGameLoopSink{}
+	gs := newGameServerWithSink(&RealClock{}, sink)
+	setGameServerOpenMap(gs)
+
+	gs.AddPlayer("shooter")
+	gs.AddPlayer("victim")
+	shooter, _ := gs.world.GetPlayer("shooter")
+	shooter.SetPosition(Vector2{X: 220, Y: 540})
+	victim, _ := gs.world.GetPlayer("victim")
+	victim.SetPosition(Vector2{X: 320, Y: 540})
+
+	result := fireShotgun(t, gs, "shooter")
+	if len(result.Pellets) != ShotgunPelletCount {
+		t.Fatalf("Expected %d pellets, got %d", ShotgunPelletCount, len(result.Pellets))
+	}
+	if result.Projectile.ID != result.Pellets[0].ID {
+		t.Error("Projectile should be the first pellet")
+	}
+
+	// At point blank every pellet lands, each as its own hit
+	if len(sink.events) != ShotgunPelletCount {
+		t.Fatalf("Expected %d pellet hits, got %d", ShotgunPelletCount, len(sink.events))
+	}
+	for _, event := range sink.events {
+		hit := event.(ProjectileHitResolvedEvent)
+		if hit.Outcome.Damage >= NewShotgun().Damage {
+			t.Errorf("Pellet dealt %d damage, expected a share of the shot", hit.Outcome.Damage)
+		}
+	}
+
+	victimState, _ := gs.GetPlayerState("victim")
+	if expected := PlayerMaxHealth - NewShotgun().Damage; victimState.Health != expected {
+		t.Errorf("Expected health %d after every pellet hit, got %d", expected, victimState.Health)
+	}
+}
+
+func TestGameServerHitDetection_ShotgunPelletsStopAtRange(t *testing.T) {
+	sink := &recordingGameLoopSink{}
+	gs := newGameServerWithSink(&RealClock{}, sink)
+	setGameServerOpenMap(gs)
+
+	gs.AddPlayer("shooter")
+	gs.AddPlayer("victim")
+	shooter, _ := gs.world.GetPlayer("shooter")
+	shooter.SetPosition(Vector2{X: 220, Y: 540})
+	victim, _ := gs.world.GetPlayer("victim")
+	// Within pistol range but past the shotgun's
+	victim.SetPosition(Vector2{X: 220 + NewShotgun().Range + 150, Y: 540})
+
+	fireShotgun(t, gs, "shooter")
+
+	if len(sink.events) != 0 {
+		t.Errorf("Expected no hits past shotgun range, got %d", len(sink.events))
+	}
+	victimState, _ := gs.GetPlayerState("victim")
+	if victimState.Health != PlayerMaxHealth {
+		t.Errorf("Expected full health, got %d", victimState.Health)
+	}
+}
+
+func TestGameServerHitDetection_ShotgunKillCountsOnce(t *testing.T) {
+	sink := &recordingGameLoopSink{}
+	gs := newGameServerWithSink(&RealClock{}, sink)
+	setGameServerOpenMap(gs)
+
+	gs.AddPlayer("shooter")
+	gs.AddPlayer("victim")
+	shooter, _ := gs.world.GetPlayer("shooter")
+	shooter.SetPosition(Vector2{X: 220, Y: 540})
+	victim, _ := gs.world.GetPlayer("victim")
+	victim.SetPosition(Vector2{X: 320, Y: 540})
+	victim.TakeDamage(PlayerMaxHealth - 1)
+
+	fireShotgun(t, gs, "shooter")
+
+	kills := 0
+	for _, event := range sink.events {
+		if event.(ProjectileHitResolvedEvent).Outcome.Killed {
+			kills++
+		}
+	}
+	if kills != 1 {
+		t.Errorf("Expected the shot to kill once, got %d kills", kills)
+	}
+	shooterState, _ := gs.GetPlayerState("shooter")
+	if shooterState.Kills != 1 {
+		t.Errorf("Expected shooter kills 1, got %d", shooterState.Kills)
+	}
+}
+
+func TestGameServerHitDetection_ShotgunPelletsPushVictim(t *testing.T) {
+	sink := &recordingGameLoopSink{}
+	gs := newGameServerWithSink(&RealClock{}, sink)
+	setGameServerOpenMap(gs)
+
+	gs.AddPlayer("shooter")
+	gs.AddPlayer("victim")
+	shooter, _ := gs.world.GetPlayer("shooter")
+	shooter.SetPosition(Vector2{X: 220, Y: 540})
+	victim, _ := gs.world.GetPlayer("victim")
+	victim.SetPosition(Vector2{X: 320, Y: 540})
+
+	fireShotgun(t, gs, "shooter")
+
+	total := Vector2{}
+	for _, event := range sink.events {
+		impulse := event.(ProjectileHitResolvedEvent).Outcome.Impulse
+		if impulse.X <= 0 {
+			t.Errorf("Expected each pellet to push the victim away from the shooter, got %+v", impulse)
+		}
+		total.X += impulse.X
+		total.Y += impulse.Y
+	}
+
+	vel := victim.GetVelocity()
+	if vel.X <= 0 {
+		t.Fatalf("Expected the victim to be pushed along the shot, got velocity %+v", vel)
+	}
+	if speed := math.Hypot(vel.X, vel.Y); speed > HitImpulseMaxSpeed+1e-9 {
+		t.Errorf("Expected speed capped at %v, got %v", HitImpulseMaxSpeed, speed)
+	}
+	if math.Abs(total.X-vel.X) > 1e-9 || math.Abs(total.Y-vel.Y) > 1e-9 {
+		t.Errorf("Expected the reported impulses %+v to add up to the velocity %+v", total, vel)
+	}
+}
@@ -32,7 +32,7 @@ func TestHitscanWeapon_LagCompensation(t *testing.T) {
 	victim.SetPosition(Vector2{X: 200, Y: 100})
 
 	// Record position history snapshot
-	gs.recordPositionSnapshots(clock.Now())
+	gs.recordPositionSnapshots(gs.activePlayers(nil), clock.Now())
 
 	// Simulate 50ms of time passing and victim moving
 	clock.Advance(50 * time.Millisecond)
@@ -84,7 +84,7 @@ func TestHitscanWeapon_MaxRewindClamp(t *testing.T) {
 	victim.SetPosition(Vector2{X: 200, Y: 100})
 
 	// Record initial position
-	gs.recordPositionSnapshots(clock.Now())
+	gs.recordPositionSnapshots(gs.activePlayers(nil), clock.Now())
 
 	// Move victim far away after 200ms
 	clock.Advance(200 * time.Millisecond)
@@ -343,7 +343,7 @@ func TestHitscanWeapon_UsesBarrelOrigin(t *testing.T) {
 	victim, _ := gs.world.GetPlayer(victimID)
 	victim.SetPosition(Vector2{X: 120, Y: 100}) // Between player center and muzzle tip
 
-	gs.recordPositionSnapshots(clock.Now())
+	gs.recordPositionSnapshots(gs.activePlayers(nil), clock.Now())
 
 	result := gs.PlayerShoot(shooterID, 0, clock.Now().UnixMilli())
 	if !result.Success {
@@ -520,7 +520,7 @@ func TestHitscanShoot_CoveredPortionStaysProtected(t *testing.T) {
 	mapConfig.Obstacles = []MapObstacle{
 		{ID: "low-wall", X: 200, Y: 300, Width: 20, Height: 40, BlocksProjectiles: true, BlocksLineOfSight: true},
 	}
-	gs.world.mapConfig = mapConfig
+	setWorldMapConfig(gs.world, mapConfig)
 	gs.physics = NewPhysics(mapConfig)
 	gs.projectileManager = NewProjectileManager(mapConfig)
 
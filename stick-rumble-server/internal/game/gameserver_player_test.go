@@ -51,6 +51,23 @@ func TestGameServerStartStop(t *testing.T) {
 	}
 }
 
+func TestGameServerStopWithoutCancellingContext(t *testing.T) {
+	gs := NewGameServer(nil)
+	gs.Start(context.Background())
+
+	stopped := make(chan struct{})
+	go func() {
+		gs.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Stop() should end the game loops without the Start context being cancelled")
+	}
+}
+
 func TestGameServerAddRemovePlayer(t *testing.T) {
 	gs := NewGameServer(nil)
 	playerID := "test-player-1"
@@ -141,6 +158,65 @@ func TestGameServerUpdatePlayerInputWithSequenceUpdatesAimAngle(t *testing.T) {
 	}
 }
 
+func TestGameServerUpdatePlayerInputWithSequenceIgnoresStaleInput(t *testing.T) {
+	gs := NewGameServer(nil)
+	playerID := "test-player-1"
+	gs.AddPlayer(playerID)
+
+	if !gs.UpdatePlayerInputWithSequence(playerID, InputState{AimAngle: 1.0}, 10) {
+		t.Fatal("UpdatePlayerInputWithSequence() should return true for existing player")
+	}
+	if !gs.UpdatePlayerInputWithSequence(playerID, InputState{AimAngle: 2.0}, 9) {
+		t.Fatal("stale input should be ignored, not reported as a failure")
+	}
+	if !gs.UpdatePlayerInput(playerID, InputState{AimAngle: 1.5}) {
+		t.Fatal("UpdatePlayerInput() should return true for existing player")
+	}
+
+	player, _ := gs.world.GetPlayer(playerID)
+	if got := player.GetInputSequence(); got != 10 {
+		t.Fatalf("player input sequence = %v, want 10", got)
+	}
+	if got := player.GetAimAngle(); got != 1.5 {
+		t.Fatalf("player aim angle = %v, want 1.5 from the unsequenced input", got)
+	}
+}
+
+func TestGameServerResetPlayerInputRestartsSequence(t *testing.T) {
+	gs := NewGameServer(nil)
+	playerID := "test-player-1"
+	gs.AddPlayer(playerID)
+
+	gs.UpdatePlayerInputWithSequence(playerID, InputState{Up: true, AimAngle: 1.0}, 500)
+	gs.QueuePlayerInput(playerID, QueuedInput{Input: InputState{Left: true}, Sequence: 501, HasSequence: true})
+
+	if !gs.ResetPlayerInput(playerID) {
+		t.Fatal("ResetPlayerInput() should return true for existing player")
+	}
+	if gs.ResetPlayerInput("missing") {
+		t.Error("ResetPlayerInput() should return false for an unknown player")
+	}
+
+	player, _ := gs.world.GetPlayer(playerID)
+	if got := player.GetInputSequence(); got != 0 {
+		t.Fatalf("player input sequence = %v, want 0", got)
+	}
+	if got := player.GetInput(); got.Up || got.Left {
+		t.Fatalf("player input = %+v, want no keys held", got)
+	}
+	if got := player.GetAimAngle(); got != 1.0 {
+		t.Fatalf("player aim angle = %v, want the aim kept", got)
+	}
+	if got := gs.inputQueue.Len(playerID); got != 0 {
+		t.Fatalf("queued inputs = %v, want the old connection's dropped", got)
+	}
+
+	gs.UpdatePlayerInputWithSequence(playerID, InputState{Right: true, AimAngle: 1.0}, 1)
+	if !player.GetInput().Right {
+		t.Fatal("the new connection's first input should be applied")
+	}
+}
+
 func TestGameServerGetWeaponState(t *testing.T) {
 	gs := NewGameServer(nil)
 	playerID := "test-player-1"
@@ -196,6 +272,7 @@ func TestGameServerRespawn_WeaponStateReset(t *testing.T) {
 	// Kill the player
 	player, _ := gs.world.GetPlayer(playerID)
 	player.MarkDead()
+	player.RequestRespawn()
 
 	// Advance clock past respawn delay and manually call checkRespawns
 	clock.Advance(time.Duration(RespawnDelay*1000+100) * time.Millisecond)
@@ -215,3 +292,31 @@ func TestGameServerRespawn_WeaponStateReset(t *testing.T) {
 		t.Errorf("After respawn: weapon name = %s, want Pistol", wsAfterRespawn.Weapon.Name)
 	}
 }
+
+func TestGameServerRespawnWaitsForRequest(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	gs := NewGameServerWithClock(nil, clock)
+	gs.AddPlayer("p1")
+	gs.MarkPlayerDead("p1")
+
+	if gs.RequestRespawn("ghost") {
+		t.Error("An unknown player should not be able to request a respawn")
+	}
+
+	clock.Advance(time.Duration(RespawnDelay*1000+100) * time.Millisecond)
+	gs.checkRespawns()
+	if state, _ := gs.GetPlayerState("p1"); state.DeathTime == nil {
+		t.Fatal("Player should wait for their respawn request")
+	}
+
+	if !gs.RequestRespawn("p1") {
+		t.Fatal("A dead player should be able to request a respawn")
+	}
+	gs.checkRespawns()
+	if state, _ := gs.GetPlayerState("p1"); state.DeathTime != nil {
+		t.Error("Player should respawn once requested after the respawn delay")
+	}
+	if gs.RequestRespawn("p1") {
+		t.Error("A living player should not be able to request a respawn")
+	}
+}
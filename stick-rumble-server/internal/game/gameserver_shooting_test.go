@@ -8,13 +8,13 @@ import (
 // simulateTick simulates a game server tick (copy from gameserver_tick_test.go)
 func simulateTickShooting(gs *GameServer, clock *ManualClock, deltaTime time.Duration) {
 	clock.Advance(deltaTime)
-	gs.updateAllPlayers(deltaTime.Seconds())
+	gs.updateAllPlayers(gs.activePlayers(nil), deltaTime.Seconds())
 	gs.projectileManager.Update(deltaTime.Seconds())
 	gs.checkHitDetection()
 	gs.checkReloads()
 	gs.checkRespawns()
 	gs.updateInvulnerability()
-	gs.updateHealthRegeneration(deltaTime.Seconds())
+	gs.updateHealthRegeneration(gs.activePlayers(nil), deltaTime.Seconds())
 	gs.checkWeaponRespawns()
 }
 
@@ -265,7 +265,7 @@ func TestGameServerProjectileBlockedByWallDuringLiveSequenceDoesNotDamageTarget(
 	mapConfig.Obstacles = []MapObstacle{
 		{ID: "wall", X: 150, Y: 80, Width: 20, Height: 40, BlocksProjectiles: true},
 	}
-	gs.world.mapConfig = mapConfig
+	setWorldMapConfig(gs.world, mapConfig)
 	gs.physics = NewPhysics(mapConfig)
 	gs.projectileManager = NewProjectileManager(mapConfig)
 
@@ -286,6 +286,8 @@ func TestGameServerProjectileBlockedByWallDuringLiveSequenceDoesNotDamageTarget(
 	if !result.Success || result.Projectile == nil {
 		t.Fatal("expected projectile shot to succeed")
 	}
+	// The result holds a copy; follow the live projectile through the tick
+	live := gs.projectileManager.GetProjectileByID(result.Projectile.ID)
 
 	gs.projectileManager.Update(0.2)
 	gs.checkHitDetection()
@@ -296,8 +298,8 @@ func TestGameServerProjectileBlockedByWallDuringLiveSequenceDoesNotDamageTarget(
 	if gs.projectileManager.GetProjectileByID(result.Projectile.ID) != nil {
 		t.Fatal("projectile should be removed after wall contact in live sequence")
 	}
-	if result.Projectile.Position.X != 150 || result.Projectile.Position.Y != 100 {
-		t.Fatalf("projectile final position = %+v, want first wall contact", result.Projectile.Position)
+	if live.Position.X != 150 || live.Position.Y != 100 {
+		t.Fatalf("projectile final position = %+v, want first wall contact", live.Position)
 	}
 }
 
@@ -308,7 +310,7 @@ func TestGameServerProjectilePlayerBeforeWallInSameTickStillDamagesTarget(t *tes
 	mapConfig.Obstacles = []MapObstacle{
 		{ID: "wall", X: 200, Y: 80, Width: 20, Height: 40, BlocksProjectiles: true},
 	}
-	gs.world.mapConfig = mapConfig
+	setWorldMapConfig(gs.world, mapConfig)
 	gs.physics = NewPhysics(mapConfig)
 	gs.projectileManager = NewProjectileManager(mapConfig)
 
@@ -14,13 +14,14 @@ func simulateTick(gs *GameServer, clock *ManualClock, deltaTime time.Duration) {
 	clock.Advance(deltaTime)
 
 	// Call the tick methods in the same order as tickLoop
-	gs.updateAllPlayers(deltaTime.Seconds())
+	gs.advanceMatches()
+	gs.updateAllPlayers(gs.activePlayers(nil), deltaTime.Seconds())
 	gs.projectileManager.Update(deltaTime.Seconds())
 	gs.checkHitDetection()
 	gs.checkReloads()
 	gs.checkRespawns()
 	gs.updateInvulnerability()
-	gs.updateHealthRegeneration(deltaTime.Seconds())
+	gs.updateHealthRegeneration(gs.activePlayers(nil), deltaTime.Seconds())
 	gs.checkWeaponRespawns()
 }
 
@@ -281,6 +282,7 @@ func TestGameServerHealthRegenerationAfterRespawn(t *testing.T) {
 	// Kill the player
 	gs.DamagePlayer(playerID, 100)
 	gs.MarkPlayerDead(playerID)
+	gs.RequestRespawn(playerID)
 
 	// Verify player is dead
 	state, _ := gs.GetPlayerState(playerID)
@@ -455,3 +457,173 @@ func TestGameServerAntiCheat_ExactlyAtThreshold(t *testing.T) {
 		t.Error("Exactly 20% should not exceed threshold (check is > 0.20, not >=)")
 	}
 }
+
+func TestGameServerIdleTickSuspendsWithoutPlayers(t *testing.T) {
+	gs := NewGameServer(nil)
+	now := time.Now()
+
+	if !gs.idleTick(now, nil) {
+		t.Fatal("tick should be skipped with no players")
+	}
+	if !gs.IsSuspended() {
+		t.Fatal("server should report suspended with no players")
+	}
+
+	var crateID string
+	for id := range gs.GetWeaponCrateManager().GetAllCrates() {
+		crateID = id
+		break
+	}
+	gs.GetWeaponCrateManager().PickupCrate(crateID)
+	respawnAt := gs.GetWeaponCrateManager().GetCrate(crateID).RespawnTime
+
+	gs.AddPlayer("returning-player")
+	if gs.idleTick(now.Add(10*time.Second), nil) {
+		t.Fatal("tick should run once a player is present")
+	}
+	if gs.IsSuspended() {
+		t.Fatal("server should resume when a player returns")
+	}
+
+	if got := gs.GetWeaponCrateManager().GetCrate(crateID).RespawnTime.Sub(respawnAt); got != 10*time.Second {
+		t.Errorf("crate respawn delayed by %v, want the 10s spent idle", got)
+	}
+}
+
+func TestGameServerIdleTickRunsWithPlayers(t *testing.T) {
+	gs := NewGameServer(nil)
+	gs.AddPlayer("player-1")
+
+	if gs.idleTick(time.Now(), nil) {
+		t.Fatal("tick should run while players are present")
+	}
+	if gs.IsSuspended() {
+		t.Fatal("server should not be suspended while players are present")
+	}
+}
+
+func TestGameServerStalledTickCountsOnce(t *testing.T) {
+	match := NewMatch()
+	match.Start()
+
+	gs := NewGameServerWithConfig(GameServerConfig{
+		ActiveMatches: func() []*Match { return []*Match{match} },
+	})
+	clock := NewManualClock(time.Now())
+	gs.AddPlayer("player-1")
+
+	if gs.checkTickStall(gs.tickRate + 50*time.Millisecond) {
+		t.Error("a late tick is not a stall")
+	}
+	if !gs.checkTickStall(gs.tickRate + 2*time.Second) {
+		t.Error("a 2s gap should be reported as a stall")
+	}
+
+	// A stalled loop runs one tick for the whole gap, so the lost time
+	// never reaches the match clock
+	simulateTick(gs, clock, 2*time.Second)
+	if got := match.ElapsedTicks; got != 1 {
+		t.Errorf("stalled tick counted %d ticks, want 1", got)
+	}
+}
+
+func TestGameServerTickAdvancesActiveMatches(t *testing.T) {
+	active := NewMatch()
+	active.Start()
+	waiting := NewMatch()
+	paused := NewMatch()
+	paused.Start()
+	paused.Pause()
+
+	gs := NewGameServerWithConfig(GameServerConfig{
+		ActiveMatches: func() []*Match { return []*Match{active, waiting, paused} },
+	})
+	clock := NewManualClock(time.Now())
+	gs.AddPlayer("player-1")
+
+	simulateTicks(gs, clock, 60, gs.tickRate)
+
+	if got := active.ElapsedTicks; got != 60 {
+		t.Errorf("active match ran %d ticks, want 60", got)
+	}
+	if got := waiting.ElapsedTicks; got != 0 {
+		t.Errorf("waiting match ran %d ticks, want 0", got)
+	}
+	if got := paused.ElapsedTicks; got != 0 {
+		t.Errorf("paused match ran %d ticks, want 0", got)
+	}
+}
+
+func TestGameServerMatchClockStopsWhileSuspended(t *testing.T) {
+	match := NewMatch()
+	match.Start()
+
+	gs := NewGameServerWithConfig(GameServerConfig{
+		ActiveMatches: func() []*Match { return []*Match{match} },
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gs.Start(ctx)
+	defer gs.Stop()
+
+	// No players: the tick loop is suspended and the match clock must not move
+	time.Sleep(10 * gs.tickRate)
+	if got := match.Elapsed(); got != 0 {
+		t.Fatalf("match clock advanced %v while suspended", got)
+	}
+	if got := gs.Tick(); got != 0 {
+		t.Fatalf("tick counter advanced to %d while suspended", got)
+	}
+
+	gs.AddPlayer("player-1")
+	deadline := time.Now().Add(time.Second)
+	for match.Elapsed() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("match clock did not advance once a player joined")
+		}
+		time.Sleep(gs.tickRate)
+	}
+	if gs.Tick() == 0 {
+		t.Error("tick counter did not advance once a player joined")
+	}
+}
+
+func TestGameServerIdleTickSuspendsWhenEveryPlayerIsDormant(t *testing.T) {
+	dormant := map[string]bool{"parked-1": true, "parked-2": true}
+	gs := NewGameServerWithConfig(GameServerConfig{DormantPlayers: func() map[string]bool { return dormant }})
+	gs.AddPlayer("parked-1")
+	gs.AddPlayer("parked-2")
+
+	if !gs.idleTick(time.Now(), gs.dormantPlayerSet()) {
+		t.Fatal("tick should be skipped while every room is waiting for reconnects")
+	}
+
+	dormant = map[string]bool{"parked-1": true}
+	if gs.idleTick(time.Now(), gs.dormantPlayerSet()) {
+		t.Fatal("tick should run once a player is connected again")
+	}
+}
+
+func TestGameServerStepLeavesDormantPlayersBe(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	gs := NewGameServerWithConfig(GameServerConfig{
+		Clock:          clock,
+		DormantPlayers: func() map[string]bool { return map[string]bool{"parked": true} },
+	})
+	parked := gs.AddPlayer("parked")
+	active := gs.AddPlayer("active")
+	parked.SetPosition(Vector2{X: 300, Y: 650})
+	active.SetPosition(Vector2{X: 300, Y: 900})
+	parked.SetInput(InputState{Right: true})
+	active.SetInput(InputState{Right: true})
+
+	clock.Advance(time.Second / 60)
+	gs.Step(clock.Now(), 1.0/60.0)
+
+	if got := parked.GetPosition(); got != (Vector2{X: 300, Y: 650}) {
+		t.Errorf("dormant player moved to %v", got)
+	}
+	if got := active.GetPosition(); got.X <= 300 {
+		t.Errorf("active player should have moved right, is at %v", got)
+	}
+}
@@ -0,0 +1,120 @@
+package game
+
+import (
+	"sync"
+	"time"
+)
+
+// HealthPack represents a health pickup point on the map
+type HealthPack struct {
+	ID          string
+	Position    Vector2
+	IsAvailable bool
+	RespawnTime time.Time
+}
+
+// HealthPackManager manages all health packs in the game. Like shield
+// crates, health packs are picked up by walking over them.
+type HealthPackManager struct {
+	packs map[string]*HealthPack
+	mu    sync.RWMutex
+}
+
+// NewHealthPackManager creates a health pack manager from the map's health spawns
+func NewHealthPackManager(mapConfigs ...MapConfig) *HealthPackManager {
+	mapConfig := resolveMapConfig(mapConfigs...)
+	manager := &HealthPackManager{
+		packs: make(map[string]*HealthPack, len(mapConfig.HealthSpawns)),
+	}
+	for _, spawn := range mapConfig.HealthSpawns {
+		manager.packs[spawn.ID] = &HealthPack{
+			ID:          spawn.ID,
+			Position:    Vector2{X: spawn.X, Y: spawn.Y},
+			IsAvailable: true,
+		}
+	}
+	return manager
+}
+
+// PickupPack attempts to pick up a health pack
+// Returns true if pickup was successful, false if pack doesn't exist or is unavailable
+func (hpm *HealthPackManager) PickupPack(packID string) bool {
+	hpm.mu.Lock()
+	defer hpm.mu.Unlock()
+
+	pack, exists := hpm.packs[packID]
+	if !exists || !pack.IsAvailable {
+		return false
+	}
+
+	pack.IsAvailable = false
+	pack.RespawnTime = time.Now().Add(HealthPackRespawnDelay * time.Second)
+	return true
+}
+
+// PackInRange returns the ID of an available pack within HealthPackPickupRadius
+// of position, or "" if there is none
+func (hpm *HealthPackManager) PackInRange(position Vector2) string {
+	hpm.mu.RLock()
+	defer hpm.mu.RUnlock()
+
+	for id, pack := range hpm.packs {
+		if pack.IsAvailable && distance(position, pack.Position) <= HealthPackPickupRadius {
+			return id
+		}
+	}
+	return ""
+}
+
+// UpdateRespawns checks for packs that should respawn and makes them available again
+// Returns a slice of pack IDs that respawned
+func (hpm *HealthPackManager) UpdateRespawns() []string {
+	hpm.mu.Lock()
+	defer hpm.mu.Unlock()
+
+	respawned := make([]string, 0)
+	now := time.Now()
+
+	for id, pack := range hpm.packs {
+		if !pack.IsAvailable && now.After(pack.RespawnTime) {
+			pack.IsAvailable = true
+			respawned = append(respawned, id)
+		}
+	}
+
+	return respawned
+}
+
+// DelayRespawns pushes every pending respawn back by d so time spent
+// suspended does not count toward pack respawn timers
+func (hpm *HealthPackManager) DelayRespawns(d time.Duration) {
+	hpm.mu.Lock()
+	defer hpm.mu.Unlock()
+
+	for _, pack := range hpm.packs {
+		if !pack.IsAvailable {
+			pack.RespawnTime = pack.RespawnTime.Add(d)
+		}
+	}
+}
+
+// GetPack returns a health pack by ID
+// Returns nil if pack doesn't exist
+func (hpm *HealthPackManager) GetPack(packID string) *HealthPack {
+	hpm.mu.RLock()
+	defer hpm.mu.RUnlock()
+
+	return hpm.packs[packID]
+}
+
+// GetAllPacks returns a copy of all health packs
+func (hpm *HealthPackManager) GetAllPacks() map[string]*HealthPack {
+	hpm.mu.RLock()
+	defer hpm.mu.RUnlock()
+
+	packs := make(map[string]*HealthPack, len(hpm.packs))
+	for id, pack := range hpm.packs {
+		packs[id] = pack
+	}
+	return packs
+}
@@ -0,0 +1,43 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHealthPackManager_UsesMapHealthSpawns(t *testing.T) {
+	mapConfig := MustDefaultMapConfig()
+	require.NotEmpty(t, mapConfig.HealthSpawns)
+
+	manager := NewHealthPackManager(mapConfig)
+	packs := manager.GetAllPacks()
+	require.Len(t, packs, len(mapConfig.HealthSpawns))
+	for _, spawn := range mapConfig.HealthSpawns {
+		pack := packs[spawn.ID]
+		require.NotNil(t, pack, "missing pack for spawn %q", spawn.ID)
+		assert.Equal(t, Vector2{X: spawn.X, Y: spawn.Y}, pack.Position)
+		assert.True(t, pack.IsAvailable)
+	}
+}
+
+func TestHealthPackManager_PickupAndRespawn(t *testing.T) {
+	manager := NewHealthPackManager()
+	spawn := MustDefaultMapConfig().HealthSpawns[0]
+	onPack := Vector2{X: spawn.X, Y: spawn.Y + HealthPackPickupRadius/2}
+
+	packID := manager.PackInRange(onPack)
+	require.Equal(t, spawn.ID, packID)
+	assert.Empty(t, manager.PackInRange(Vector2{X: spawn.X, Y: spawn.Y + HealthPackPickupRadius*2}))
+
+	require.True(t, manager.PickupPack(packID))
+	assert.False(t, manager.PickupPack(packID), "a taken pack cannot be picked up again")
+	assert.Empty(t, manager.PackInRange(onPack), "a taken pack is not in range of anyone")
+	assert.Empty(t, manager.UpdateRespawns())
+
+	manager.GetPack(packID).RespawnTime = time.Now().Add(-time.Second)
+	assert.Equal(t, []string{packID}, manager.UpdateRespawns())
+	assert.True(t, manager.GetPack(packID).IsAvailable)
+}
@@ -0,0 +1,20 @@
+package game
+
+// Hit rejection codes tell an attacker why a shot or swing its client may have
+// drawn as a hit did not land, so the client can take back the hit feedback
+const (
+	HitRejectedInvulnerable = "invulnerable" // Target had spawn protection or roll i-frames
+	HitRejectedOutOfRange   = "out_of_range" // Target was in line but past the weapon's reach
+	HitRejectedCooldown     = "cooldown"     // Attack came before the weapon's cooldown ended
+	HitRejectedRewindLimit  = "rewind_limit" // Target was only in line further back than lag compensation rewinds
+)
+
+// meleeNearMissSlack is how far past a melee weapon's range a target still
+// counts as a near miss worth an out_of_range rejection
+const meleeNearMissSlack = PlayerWidth / 2
+
+// HitRejection explains why an attack hit nobody
+type HitRejection struct {
+	Code     string
+	VictimID string // Player the attack would have hit; empty for cooldown
+}
@@ -0,0 +1,128 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newHitscanDuel puts a shooter with a hitscan pistol at (100, 100) and a
+// victim at victimPos on an open map
+func newHitscanDuel(t *testing.T, victimPos Vector2) (*GameServer, *ManualClock, *PlayerState) {
+	t.Helper()
+	clock := NewManualClock(time.Now())
+	gs := NewGameServerWithClock(nil, clock)
+	setGameServerOpenMap(gs)
+
+	gs.AddPlayer("shooter")
+	gs.AddPlayer("victim")
+	pistol := NewPistol()
+	pistol.IsHitscan = true
+	gs.SetWeaponState("shooter", NewWeaponState(pistol))
+
+	shooter, _ := gs.world.GetPlayer("shooter")
+	shooter.SetPosition(Vector2{X: 100, Y: 100})
+	victim, _ := gs.world.GetPlayer("victim")
+	victim.SetPosition(victimPos)
+	return gs, clock, victim
+}
+
+func TestHitscanRejectsInvulnerableTarget(t *testing.T) {
+	gs, clock, victim := newHitscanDuel(t, Vector2{X: 300, Y: 100})
+	victim.Respawn(Vector2{X: 300, Y: 100})
+
+	result := gs.PlayerShoot("shooter", 0, clock.Now().UnixMilli())
+
+	require.True(t, result.Success)
+	assert.Equal(t, PlayerMaxHealth, victim.Health, "spawn protection blocks hitscan damage")
+	assert.Equal(t, &HitRejection{Code: HitRejectedInvulnerable, VictimID: "victim"}, result.Rejection)
+}
+
+func TestHitscanRejectsTargetPastRange(t *testing.T) {
+	gs, clock, victim := newHitscanDuel(t, Vector2{X: 100 + NewPistol().Range + 100, Y: 100})
+
+	result := gs.PlayerShoot("shooter", 0, clock.Now().UnixMilli())
+
+	require.True(t, result.Success)
+	assert.Equal(t, PlayerMaxHealth, victim.Health)
+	assert.Equal(t, &HitRejection{Code: HitRejectedOutOfRange, VictimID: "victim"}, result.Rejection)
+}
+
+func TestHitscanRejectsHitBeyondRewindLimit(t *testing.T) {
+	gs, clock, victim := newHitscanDuel(t, Vector2{X: 300, Y: 100})
+	gs.recordPositionSnapshots(gs.activePlayers(nil), clock.Now())
+	clock.Advance(300 * time.Millisecond)
+	victim.SetPosition(Vector2{X: 300, Y: 400})
+	gs.recordPositionSnapshots(gs.activePlayers(nil), clock.Now())
+	gs.SetGetRTT(func(string) int64 { return 300 })
+
+	result := gs.PlayerShoot("shooter", 0, clock.Now().UnixMilli())
+
+	require.True(t, result.Success)
+	assert.Equal(t, PlayerMaxHealth, victim.Health)
+	assert.Equal(t, &HitRejection{Code: HitRejectedRewindLimit, VictimID: "victim"}, result.Rejection)
+}
+
+func TestHitscanHitOrCleanMissHasNoRejection(t *testing.T) {
+	gs, clock, victim := newHitscanDuel(t, Vector2{X: 300, Y: 100})
+	hit := gs.PlayerShoot("shooter", 0, clock.Now().UnixMilli())
+	require.True(t, hit.Success)
+	assert.Nil(t, hit.Rejection)
+	assert.Less(t, victim.Health, PlayerMaxHealth)
+
+	gs, clock, _ = newHitscanDuel(t, Vector2{X: 300, Y: 100})
+	miss := gs.PlayerShoot("shooter", 3.0, clock.Now().UnixMilli())
+	require.True(t, miss.Success)
+	assert.Nil(t, miss.Rejection, "a shot nowhere near anyone needs no rejection")
+}
+
+func TestShootCooldownRejection(t *testing.T) {
+	gs, clock, _ := newHitscanDuel(t, Vector2{X: 300, Y: 100})
+
+	gs.PlayerShoot("shooter", 0, clock.Now().UnixMilli())
+	result := gs.PlayerShoot("shooter", 0, clock.Now().UnixMilli())
+
+	assert.Equal(t, ShootFailedCooldown, result.Reason)
+	assert.Equal(t, &HitRejection{Code: HitRejectedCooldown}, result.Rejection)
+}
+
+func TestMeleeRejectsInvulnerableTarget(t *testing.T) {
+	attacker := createTestPlayer("attacker", 100, 100, 0)
+	target := createTestPlayer("target", 150, 100, 0)
+	target.Respawn(Vector2{X: 150, Y: 100})
+
+	result := PerformMeleeAttack(attacker, []*PlayerState{attacker, target}, NewBat())
+
+	assert.Empty(t, result.HitPlayers)
+	assert.Equal(t, PlayerMaxHealth, target.Health)
+	assert.Equal(t, &HitRejection{Code: HitRejectedInvulnerable, VictimID: "target"}, result.Rejection)
+}
+
+func TestMeleeRejectsNearMiss(t *testing.T) {
+	attacker := createTestPlayer("attacker", 100, 100, 0)
+	target := createTestPlayer("target", 205, 100, 0) // Center 15px past the bat's 90px range
+
+	result := PerformMeleeAttack(attacker, []*PlayerState{attacker, target}, NewBat())
+
+	assert.Empty(t, result.HitPlayers)
+	assert.Equal(t, &HitRejection{Code: HitRejectedOutOfRange, VictimID: "target"}, result.Rejection)
+
+	far := createTestPlayer("far", 400, 100, 0)
+	result = PerformMeleeAttack(attacker, []*PlayerState{attacker, far}, NewBat())
+	assert.Nil(t, result.Rejection, "a target well out of reach is a plain whiff")
+}
+
+func TestMeleeCooldownRejection(t *testing.T) {
+	gs := NewGameServer(nil)
+	gs.AddPlayer("attacker")
+	gs.SetWeaponState("attacker", NewWeaponState(NewBat()))
+
+	first := gs.PlayerMeleeAttack("attacker", 0)
+	require.True(t, first.Success)
+	second := gs.PlayerMeleeAttack("attacker", 0)
+
+	assert.Equal(t, MeleeFailedCooldown, second.Reason)
+	assert.Equal(t, &HitRejection{Code: HitRejectedCooldown}, second.Rejection)
+}
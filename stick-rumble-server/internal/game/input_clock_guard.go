@@ -0,0 +1,130 @@
+package game
+
+import (
+	"sync"
+	"time"
+)
+
+// Input timestamp violation kinds reported by the input clock guard
+const (
+	InputTimestampBackwards = "backwards"
+	InputTimestampAhead     = "ahead"
+)
+
+const (
+	// DefaultInputClockSyncSamples is how many inputs establish a player's
+	// clock offset before inputs are checked against it
+	DefaultInputClockSyncSamples = 10
+
+	// DefaultInputClockMaxLead is how far ahead of the established offset a
+	// client timestamp may run; latency only ever makes timestamps look older
+	DefaultInputClockMaxLead = time.Second
+)
+
+// InputClockGuardConfig holds the thresholds the input clock guard enforces.
+// Zero values use the defaults above.
+type InputClockGuardConfig struct {
+	SyncSamples int
+	MaxLead     time.Duration
+}
+
+func (c InputClockGuardConfig) withDefaults() InputClockGuardConfig {
+	if c.SyncSamples <= 0 {
+		c.SyncSamples = DefaultInputClockSyncSamples
+	}
+	if c.MaxLead <= 0 {
+		c.MaxLead = DefaultInputClockMaxLead
+	}
+	return c
+}
+
+// InputTimestampViolation describes one rejected input
+type InputTimestampViolation struct {
+	PlayerID string
+	Kind     string
+	Amount   time.Duration // How far backwards, or how far past MaxLead ahead
+	Count    int           // Violations by this player so far, including this one
+}
+
+// inputClockTrack is what the guard remembers about one player
+type inputClockTrack struct {
+	lastTimestamp int64         // Newest accepted client timestamp (Unix ms)
+	offset        time.Duration // Smallest server-minus-client gap seen while syncing
+	samples       int
+	violations    int
+}
+
+// InputClockGuard rejects inputs replayed or forged with client timestamps
+// that move backwards or run ahead of the player's clock. The first
+// SyncSamples inputs establish the offset between the client's clock and the
+// server's; the smallest gap seen is the one with the least latency in it.
+type InputClockGuard struct {
+	config InputClockGuardConfig
+	tracks map[string]*inputClockTrack
+	mu     sync.Mutex
+}
+
+func NewInputClockGuard(config InputClockGuardConfig) *InputClockGuard {
+	return &InputClockGuard{
+		config: config.withDefaults(),
+		tracks: make(map[string]*inputClockTrack),
+	}
+}
+
+// Check accepts or rejects an input sent at clientTimestamp (Unix ms) and
+// received at now. It returns nil when the input is accepted.
+func (g *InputClockGuard) Check(playerID string, clientTimestamp int64, now time.Time) *InputTimestampViolation {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	track, ok := g.tracks[playerID]
+	if !ok {
+		track = &inputClockTrack{}
+		g.tracks[playerID] = track
+	}
+
+	if track.samples > 0 && clientTimestamp < track.lastTimestamp {
+		return g.record(playerID, track, InputTimestampBackwards, time.Duration(track.lastTimestamp-clientTimestamp)*time.Millisecond)
+	}
+
+	gap := now.Sub(time.UnixMilli(clientTimestamp))
+	if track.samples >= g.config.SyncSamples {
+		if lead := track.offset - gap; lead > g.config.MaxLead {
+			return g.record(playerID, track, InputTimestampAhead, lead-g.config.MaxLead)
+		}
+	} else if track.samples == 0 || gap < track.offset {
+		track.offset = gap
+	}
+
+	track.lastTimestamp = clientTimestamp
+	track.samples++
+	return nil
+}
+
+func (g *InputClockGuard) record(playerID string, track *inputClockTrack, kind string, amount time.Duration) *InputTimestampViolation {
+	track.violations++
+	return &InputTimestampViolation{
+		PlayerID: playerID,
+		Kind:     kind,
+		Amount:   amount,
+		Count:    track.violations,
+	}
+}
+
+// Violations returns how many inputs the player has had rejected
+func (g *InputClockGuard) Violations(playerID string) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if track, ok := g.tracks[playerID]; ok {
+		return track.violations
+	}
+	return 0
+}
+
+// Forget drops a removed player's history
+func (g *InputClockGuard) Forget(playerID string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.tracks, playerID)
+}
@@ -0,0 +1,74 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const inputInterval = time.Second / 60
+
+// syncInputClock sends inputs stamped by a client clock behind the server's by
+// skew, with latency added on arrival, and returns the last client timestamp
+func syncInputClock(t *testing.T, guard *InputClockGuard, samples int, skew, latency time.Duration) (int64, time.Time) {
+	t.Helper()
+	var clientTimestamp int64
+	var now time.Time
+	for i := 0; i < samples; i++ {
+		sent := guardStart.Add(time.Duration(i) * inputInterval)
+		clientTimestamp = sent.Add(-skew).UnixMilli()
+		now = sent.Add(latency)
+		require.Nil(t, guard.Check("p1", clientTimestamp, now))
+	}
+	return clientTimestamp, now
+}
+
+func TestInputClockGuardRejectsBackwardsTimestamps(t *testing.T) {
+	guard := NewInputClockGuard(InputClockGuardConfig{})
+	last, now := syncInputClock(t, guard, 3, 5*time.Second, 40*time.Millisecond)
+
+	assert.Nil(t, guard.Check("p1", last, now), "inputs bunched into one millisecond are not replays")
+
+	violation := guard.Check("p1", last-500, now)
+	require.NotNil(t, violation)
+	assert.Equal(t, InputTimestampBackwards, violation.Kind)
+	assert.Equal(t, 500*time.Millisecond, violation.Amount)
+	assert.Equal(t, 1, violation.Count)
+
+	// The rejected input does not move the baseline back
+	assert.NotNil(t, guard.Check("p1", last-100, now))
+	assert.Equal(t, 2, guard.Violations("p1"))
+}
+
+func TestInputClockGuardRejectsTimestampsAheadOfOffset(t *testing.T) {
+	guard := NewInputClockGuard(InputClockGuardConfig{SyncSamples: 5, MaxLead: 500 * time.Millisecond})
+	last, now := syncInputClock(t, guard, 5, -2*time.Second, 30*time.Millisecond)
+
+	// Latency spikes make inputs look older, never newer
+	now = now.Add(inputInterval + 300*time.Millisecond)
+	assert.Nil(t, guard.Check("p1", last+int64(inputInterval/time.Millisecond), now))
+
+	// A client clock that jumps two seconds ahead is a speed hack or a forgery
+	violation := guard.Check("p1", last+2000, now.Add(inputInterval))
+	require.NotNil(t, violation)
+	assert.Equal(t, InputTimestampAhead, violation.Kind)
+	assert.Equal(t, 1, guard.Violations("p1"))
+
+	guard.Forget("p1")
+	assert.Zero(t, guard.Violations("p1"))
+}
+
+func TestGameServerDropsReplayedInputTimestamps(t *testing.T) {
+	gs := NewGameServer(nil)
+	gs.AddPlayer("player1")
+	now := time.Now().UnixMilli()
+
+	assert.True(t, gs.AcceptInputTimestamp("player1", now))
+	assert.False(t, gs.AcceptInputTimestamp("player1", now-1000))
+	assert.Equal(t, 1, gs.InputTimestampViolations("player1"))
+
+	gs.RemovePlayer("player1")
+	assert.Zero(t, gs.InputTimestampViolations("player1"))
+}
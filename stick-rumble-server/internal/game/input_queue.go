@@ -0,0 +1,103 @@
+package game
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// InputQueueCapacity is how many inputs a player may have waiting. A client
+// that bursts more than this loses its oldest waiting inputs rather than
+// falling ever further behind.
+const InputQueueCapacity = 10
+
+// QueuedInput is one input:state waiting for a simulation tick
+type QueuedInput struct {
+	Input       InputState `json:"input"`
+	Sequence    uint64     `json:"sequence,omitempty"`
+	HasSequence bool       `json:"hasSequence,omitempty"` // Clients without prediction omit the sequence
+	ClientTime  int64      `json:"clientTime,omitempty"`  // Client send time (Unix ms) from the message envelope; 0 if unknown
+}
+
+// PlayerInput is the input a tick applies to one player
+type PlayerInput struct {
+	PlayerID string `json:"playerId"`
+	QueuedInput
+}
+
+// InputQueue buffers each player's inputs so the game loop applies at most one
+// per player per tick, in the order they arrived. Inputs that reach the server
+// in a burst are spread over the following ticks as the client sent them,
+// instead of the last one winning and the rest never being simulated.
+type InputQueue struct {
+	pending map[string][]QueuedInput
+	mu      sync.Mutex
+}
+
+func NewInputQueue() *InputQueue {
+	return &InputQueue{pending: make(map[string][]QueuedInput)}
+}
+
+// Push adds an input to the player's queue. It reports false when the queue
+// was full and its oldest input was dropped to make room.
+func (q *InputQueue) Push(playerID string, input QueuedInput) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	queue := append(q.pending[playerID], input)
+	kept := true
+	if len(queue) > InputQueueCapacity {
+		queue = queue[len(queue)-InputQueueCapacity:]
+		kept = false
+	}
+	q.pending[playerID] = queue
+	return kept
+}
+
+// Next removes and returns the input each waiting player applies this tick,
+// sorted by player ID. Timestamps are honored: an input the client replaced
+// less than tickInterval after sending it could never have been simulated on
+// its own, so it is skipped in favour of its replacement.
+func (q *InputQueue) Next(tickInterval time.Duration) []PlayerInput {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	inputs := make([]PlayerInput, 0, len(q.pending))
+	for playerID, queue := range q.pending {
+		for len(queue) > 1 && supersededWithinTick(queue[0], queue[1], tickInterval) {
+			queue = queue[1:]
+		}
+		inputs = append(inputs, PlayerInput{PlayerID: playerID, QueuedInput: queue[0]})
+
+		if len(queue) == 1 {
+			delete(q.pending, playerID)
+		} else {
+			q.pending[playerID] = queue[1:]
+		}
+	}
+	sort.Slice(inputs, func(i, j int) bool { return inputs[i].PlayerID < inputs[j].PlayerID })
+	return inputs
+}
+
+// Len returns how many inputs the player has waiting
+func (q *InputQueue) Len(playerID string) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.pending[playerID])
+}
+
+// Forget drops a removed player's waiting inputs
+func (q *InputQueue) Forget(playerID string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	delete(q.pending, playerID)
+}
+
+func supersededWithinTick(input, next QueuedInput, tickInterval time.Duration) bool {
+	if input.ClientTime == 0 || next.ClientTime == 0 {
+		return false
+	}
+	return time.Duration(next.ClientTime-input.ClientTime)*time.Millisecond < tickInterval
+}
@@ -0,0 +1,90 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTickInterval = time.Duration(ServerTickInterval) * time.Millisecond
+
+func queuedInput(sequence uint64, clientTime int64) QueuedInput {
+	return QueuedInput{Input: InputState{AimAngle: float64(sequence)}, Sequence: sequence, HasSequence: true, ClientTime: clientTime}
+}
+
+func TestInputQueueAppliesOneInputPerPlayerPerTick(t *testing.T) {
+	queue := NewInputQueue()
+	for i := uint64(1); i <= 3; i++ {
+		queue.Push("p2", queuedInput(i, 1000+int64(i)*20))
+	}
+	queue.Push("p1", queuedInput(7, 0))
+
+	first := queue.Next(testTickInterval)
+	require.Len(t, first, 2)
+	assert.Equal(t, "p1", first[0].PlayerID, "players are applied in ID order")
+	assert.Equal(t, uint64(7), first[0].Sequence)
+	assert.Equal(t, uint64(1), first[1].Sequence)
+
+	second := queue.Next(testTickInterval)
+	require.Len(t, second, 1)
+	assert.Equal(t, uint64(2), second[0].Sequence)
+
+	assert.Equal(t, 1, queue.Len("p2"))
+	assert.Len(t, queue.Next(testTickInterval), 1)
+	assert.Empty(t, queue.Next(testTickInterval))
+}
+
+func TestInputQueueSkipsInputsReplacedWithinATick(t *testing.T) {
+	queue := NewInputQueue()
+	queue.Push("p1", queuedInput(1, 1000))
+	queue.Push("p1", queuedInput(2, 1005)) // Replaced input 1 before a tick could run it
+	queue.Push("p1", queuedInput(3, 1040))
+
+	assert.Equal(t, uint64(2), queue.Next(testTickInterval)[0].Sequence)
+	assert.Equal(t, uint64(3), queue.Next(testTickInterval)[0].Sequence)
+}
+
+func TestInputQueueDropsOldestInputsPastCapacity(t *testing.T) {
+	queue := NewInputQueue()
+	for i := uint64(1); i <= InputQueueCapacity; i++ {
+		assert.True(t, queue.Push("p1", queuedInput(i, 0)))
+	}
+	assert.False(t, queue.Push("p1", queuedInput(InputQueueCapacity+1, 0)))
+
+	assert.Equal(t, InputQueueCapacity, queue.Len("p1"))
+	assert.Equal(t, uint64(2), queue.Next(testTickInterval)[0].Sequence)
+
+	queue.Forget("p1")
+	assert.Zero(t, queue.Len("p1"))
+}
+
+func TestGameServerAppliesBurstOfInputsOverSuccessiveTicks(t *testing.T) {
+	gs := NewGameServer(nil)
+	gs.AddPlayer("p1")
+
+	for i := uint64(1); i <= 3; i++ {
+		require.True(t, gs.QueuePlayerInput("p1", QueuedInput{
+			Input:       InputState{Up: i != 2, AimAngle: 0},
+			Sequence:    i,
+			HasSequence: true,
+			ClientTime:  1000 + int64(i)*20,
+		}))
+	}
+	player, _ := gs.GetWorld().GetPlayer("p1")
+	assert.False(t, player.GetInput().Up, "queued inputs wait for a tick")
+
+	gs.applyQueuedInputs()
+	assert.True(t, player.GetInput().Up)
+	assert.Equal(t, uint64(1), player.GetInputSequence())
+
+	gs.applyQueuedInputs()
+	assert.False(t, player.GetInput().Up, "the burst's middle input is simulated too")
+
+	gs.applyQueuedInputs()
+	assert.True(t, player.GetInput().Up)
+	assert.Equal(t, uint64(3), player.GetInputSequence())
+
+	assert.False(t, gs.QueuePlayerInput("missing", QueuedInput{}))
+}
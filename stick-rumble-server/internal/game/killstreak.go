@@ -0,0 +1,38 @@
+package game
+
+// KillStreak is what one kill did to the killer's streak
+type KillStreak struct {
+	Streak    int // Kills since the killer last died, including this one
+	MultiKill int // Kills chained within MultiKillWindow, 1 for a lone kill
+	BonusXP   int // XP on top of the kill's base XP
+}
+
+// Announced reports whether the kill extended a streak, which is when
+// clients show a banner
+func (k KillStreak) Announced() bool {
+	return k.Streak >= 2
+}
+
+// killStreakBonusXP escalates with the streak and the multi-kill chain, each
+// capped so one kill never earns more than a few base kills
+func killStreakBonusXP(streak, multiKill int) int {
+	streakBonus := min(max(streak-1, 0)*KillStreakBonusXPStep, KillStreakMaxBonusXP)
+	multiKillBonus := min(max(multiKill-1, 0)*MultiKillBonusXPStep, MultiKillMaxBonusXP)
+	return streakBonus + multiKillBonus
+}
+
+// CreditKill gives the killer a kill, its XP including any streak bonus, and
+// ultimate charge. It returns false if the killer is not in the world.
+func (gs *GameServer) CreditKill(killerID, victimID string) (KillStreak, bool) {
+	killer, exists := gs.world.GetPlayer(killerID)
+	if !exists || killer == nil {
+		return KillStreak{}, false
+	}
+
+	killer.IncrementKills()
+	streak := killer.RecordKillStreak()
+	killer.AddXP(gs.KillXPReward(killerID, victimID) + streak.BonusXP)
+	killer.AddUltimateCharge(UltimateChargePerKill)
+	gs.announceLevelUp(killer)
+	return streak, true
+}
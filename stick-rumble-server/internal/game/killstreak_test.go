@@ -0,0 +1,85 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayerState_RecordKillStreakChainsMultiKills(t *testing.T) {
+	clock := NewManualClock(guardStart)
+	player := NewPlayerStateWithClock("p1", clock)
+
+	first := player.RecordKillStreak()
+	assert.Equal(t, KillStreak{Streak: 1, MultiKill: 1}, first)
+	assert.False(t, first.Announced())
+
+	clock.Advance(2 * time.Second)
+	double := player.RecordKillStreak()
+	assert.Equal(t, KillStreak{Streak: 2, MultiKill: 2, BonusXP: KillStreakBonusXPStep + MultiKillBonusXPStep}, double)
+	assert.True(t, double.Announced())
+
+	clock.Advance(time.Duration(MultiKillWindow * float64(time.Second)))
+	triple := player.RecordKillStreak()
+	assert.Equal(t, 3, triple.MultiKill, "the window runs from the previous kill")
+
+	clock.Advance(10 * time.Second)
+	lone := player.RecordKillStreak()
+	assert.Equal(t, KillStreak{Streak: 4, MultiKill: 1, BonusXP: 3 * KillStreakBonusXPStep}, lone)
+}
+
+func TestPlayerState_KillStreakResetsOnDeath(t *testing.T) {
+	player := NewPlayerStateWithClock("p1", NewManualClock(guardStart))
+	for range 3 {
+		player.RecordKillStreak()
+	}
+
+	player.MarkDead()
+	assert.Zero(t, player.GetKillStreak())
+	assert.Equal(t, 3, player.GetBestKillStreak(), "the best streak outlives death")
+
+	player.Respawn(Vector2{X: 100, Y: 100})
+	assert.Equal(t, KillStreak{Streak: 1, MultiKill: 1}, player.RecordKillStreak(), "a multi-kill chain does not survive death either")
+}
+
+func TestKillStreakBonusXPIsCapped(t *testing.T) {
+	assert.Zero(t, killStreakBonusXP(1, 1))
+	assert.Equal(t, KillStreakMaxBonusXP, killStreakBonusXP(50, 1))
+	assert.Equal(t, KillStreakMaxBonusXP+MultiKillMaxBonusXP, killStreakBonusXP(50, 10))
+}
+
+func TestGameServerCreditKillAddsStreakBonusXP(t *testing.T) {
+	gs := NewGameServer(nil)
+	killer := gs.AddPlayer("killer")
+	gs.AddPlayer("victim")
+
+	_, ok := gs.CreditKill("ghost", "victim")
+	assert.False(t, ok)
+
+	_, ok = gs.CreditKill("killer", "victim")
+	require.True(t, ok)
+	streak, ok := gs.CreditKill("killer", "victim")
+	require.True(t, ok)
+	require.True(t, streak.Announced())
+
+	snapshot := killer.Snapshot()
+	assert.Equal(t, 2, snapshot.Kills)
+	assert.Equal(t, 2*KillXPReward+streak.BonusXP, snapshot.XP)
+	assert.Equal(t, 2*int(UltimateChargePerKill), snapshot.UltimateCharge)
+}
+
+func TestGetFinalScoresIncludesBestKillStreak(t *testing.T) {
+	world := NewWorld()
+	player := world.AddPlayer("p1")
+	player.RecordKillStreak()
+	player.RecordKillStreak()
+
+	match := NewMatch()
+	match.RegisterPlayer("p1")
+
+	scores := match.GetFinalScores(world)
+	require.Len(t, scores, 1)
+	assert.Equal(t, 2, scores[0].BestKillStreak)
+}
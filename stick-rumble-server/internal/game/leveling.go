@@ -0,0 +1,152 @@
+package game
+
+import (
+	"math"
+	"slices"
+)
+
+const (
+	// DefaultLevelFirstXP is the XP from level 1 to level 2
+	DefaultLevelFirstXP = 500
+	// DefaultLevelGrowth is how many times the XP of the level before each
+	// further level takes
+	DefaultLevelGrowth = 1.2
+	// DefaultMaxLevel is the highest level
+	DefaultMaxLevel = 50
+)
+
+// LevelCurve sets how much XP each level takes and what reaching it unlocks.
+// Level n+1 takes FirstLevelXP * Growth^(n-1) XP more than level n. Zero
+// fields use the defaults.
+type LevelCurve struct {
+	FirstLevelXP int
+	Growth       float64          // At least 1
+	MaxLevel     int              // Levels stop here; XP keeps counting
+	Unlocks      map[int][]string // Identifiers unlocked on reaching each level, such as cosmetics
+}
+
+func (c LevelCurve) withDefaults() LevelCurve {
+	if c.FirstLevelXP <= 0 {
+		c.FirstLevelXP = DefaultLevelFirstXP
+	}
+	if c.Growth <= 0 {
+		c.Growth = DefaultLevelGrowth
+	}
+	c.Growth = max(c.Growth, 1)
+	if c.MaxLevel <= 0 {
+		c.MaxLevel = DefaultMaxLevel
+	}
+	return c
+}
+
+// LevelXP returns the total XP that reaches level; 0 for level 1 and below
+func (c LevelCurve) LevelXP(level int) int {
+	c = c.withDefaults()
+	total := 0
+	for n := 1; n < min(level, c.MaxLevel); n++ {
+		total += int(math.Round(float64(c.FirstLevelXP) * math.Pow(c.Growth, float64(n-1))))
+	}
+	return total
+}
+
+// Level returns the level xp reaches, from 1 to MaxLevel
+func (c LevelCurve) Level(xp int) int {
+	c = c.withDefaults()
+	level := 1
+	for level < c.MaxLevel && xp >= c.LevelXP(level+1) {
+		level++
+	}
+	return level
+}
+
+// unlocksBetween returns the identifiers unlocked by the levels above from
+// up to and including to, in level order
+func (c LevelCurve) unlocksBetween(from, to int) []string {
+	var unlocks []string
+	for level := from + 1; level <= to; level++ {
+		unlocks = append(unlocks, c.Unlocks[level]...)
+	}
+	return slices.Clip(unlocks)
+}
+
+// PlayerLeveledUpEvent reports XP carrying a player past one or more level
+// thresholds. XP is the player's total, recorded matches included.
+type PlayerLeveledUpEvent struct {
+	PlayerID      string
+	Level         int
+	PreviousLevel int
+	XP            int
+	NextLevelXP   int      // Total XP of the next level; 0 at the highest level
+	Unlocks       []string // Unlocked by every level gained, in level order
+}
+
+func (PlayerLeveledUpEvent) gameLoopEventName() string { return "player_leveled_up" }
+
+// setLeveling applies the game's level curve to the player and places them
+// on it without announcing anything (thread-safe)
+func (p *PlayerState) setLeveling(curve LevelCurve) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.leveling = curve.withDefaults()
+	p.level = p.leveling.Level(p.recordedXP + p.XP)
+}
+
+// setRecordedXP counts XP the player earned in recorded matches toward their
+// level, placing them on the curve without announcing anything (thread-safe)
+func (p *PlayerState) setRecordedXP(xp int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.recordedXP = max(xp, 0)
+	p.level = p.leveling.Level(p.recordedXP + p.XP)
+}
+
+// Level returns the player's level (thread-safe)
+func (p *PlayerState) Level() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.level
+}
+
+// takeLevelUp moves the player to the level their XP reaches and reports it
+// if that is higher than before (thread-safe)
+func (p *PlayerState) takeLevelUp() (PlayerLeveledUpEvent, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	xp := p.recordedXP + p.XP
+	level := p.leveling.Level(xp)
+	if level <= p.level {
+		return PlayerLeveledUpEvent{}, false
+	}
+	event := PlayerLeveledUpEvent{
+		PlayerID:      p.ID,
+		Level:         level,
+		PreviousLevel: p.level,
+		XP:            xp,
+		Unlocks:       p.leveling.unlocksBetween(p.level, level),
+	}
+	if level < p.leveling.MaxLevel {
+		event.NextLevelXP = p.leveling.LevelXP(level + 1)
+	}
+	p.level = level
+	return event, true
+}
+
+// SetRecordedXP counts XP a player earned in earlier, recorded matches toward
+// their level. It returns false if the player is not in the world.
+func (gs *GameServer) SetRecordedXP(playerID string, xp int) bool {
+	player, exists := gs.world.GetPlayer(playerID)
+	if !exists || player == nil {
+		return false
+	}
+	player.setRecordedXP(xp)
+	return true
+}
+
+// announceLevelUp emits PlayerLeveledUpEvent when XP just awarded to the
+// player reached a new level
+func (gs *GameServer) announceLevelUp(player *PlayerState) {
+	if event, leveled := player.takeLevelUp(); leveled {
+		gs.emitGameLoopEvent(event)
+	}
+}
@@ -0,0 +1,81 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testLevelCurve takes 100, 200 and 400 XP for levels 2, 3 and 4
+var testLevelCurve = LevelCurve{
+	FirstLevelXP: 100,
+	Growth:       2,
+	MaxLevel:     4,
+	Unlocks:      map[int][]string{2: {"title.rookie"}, 3: {"emote.salute", "skin.red"}},
+}
+
+func TestLevelCurve(t *testing.T) {
+	assert.Equal(t, 0, testLevelCurve.LevelXP(1))
+	assert.Equal(t, 100, testLevelCurve.LevelXP(2))
+	assert.Equal(t, 300, testLevelCurve.LevelXP(3))
+	assert.Equal(t, 700, testLevelCurve.LevelXP(4))
+
+	assert.Equal(t, 1, testLevelCurve.Level(0))
+	assert.Equal(t, 1, testLevelCurve.Level(99))
+	assert.Equal(t, 2, testLevelCurve.Level(100))
+	assert.Equal(t, 3, testLevelCurve.Level(699))
+	assert.Equal(t, 4, testLevelCurve.Level(100000), "levels stop at the highest")
+
+	assert.Equal(t, []string{"title.rookie", "emote.salute", "skin.red"}, testLevelCurve.unlocksBetween(1, 3))
+	assert.Empty(t, testLevelCurve.unlocksBetween(3, 4))
+
+	defaults := LevelCurve{}.withDefaults()
+	assert.Equal(t, DefaultLevelFirstXP, defaults.LevelXP(2))
+	assert.Equal(t, DefaultMaxLevel, defaults.MaxLevel)
+	assert.Equal(t, 1.0, LevelCurve{Growth: 0.5}.withDefaults().Growth, "levels never get cheaper")
+}
+
+func TestXPCrossingAThresholdLevelsUp(t *testing.T) {
+	sink := &recordingGameLoopSink{}
+	gs := NewGameServerWithConfig(GameServerConfig{Clock: &RealClock{}, EventSink: sink, Leveling: testLevelCurve})
+	player := gs.AddPlayer("killer")
+	gs.AddPlayer("victim")
+	assert.Equal(t, 1, player.Level())
+
+	_, credited := gs.CreditKill("killer", "victim")
+	require.True(t, credited)
+	event := requireSingleEvent[PlayerLeveledUpEvent](t, sink.events)
+	assert.Equal(t, PlayerLeveledUpEvent{
+		PlayerID: "killer", Level: 2, PreviousLevel: 1, XP: 100, NextLevelXP: 300, Unlocks: []string{"title.rookie"},
+	}, event)
+
+	// Short of the next threshold nothing is sent
+	sink.events = nil
+	_, credited = gs.CreditAssist("killer")
+	require.True(t, credited)
+	assert.Empty(t, sink.events)
+	assert.Equal(t, 2, player.Level())
+}
+
+func TestRecordedXPCountsTowardTheLevel(t *testing.T) {
+	sink := &recordingGameLoopSink{}
+	gs := NewGameServerWithConfig(GameServerConfig{Clock: &RealClock{}, EventSink: sink, Leveling: testLevelCurve})
+	player := gs.AddPlayer("veteran")
+	gs.AddPlayer("victim")
+
+	require.True(t, gs.SetRecordedXP("veteran", 650))
+	assert.Equal(t, 3, player.Level(), "earlier matches place the player without a level-up")
+	assert.False(t, gs.SetRecordedXP("nobody", 650))
+
+	gs.CreditKill("veteran", "victim")
+	event := requireSingleEvent[PlayerLeveledUpEvent](t, sink.events)
+	assert.Equal(t, 4, event.Level)
+	assert.Equal(t, 750, event.XP, "recorded XP plus this session's")
+	assert.Zero(t, event.NextLevelXP, "the highest level has no next one")
+	assert.Empty(t, event.Unlocks)
+
+	sink.events = nil
+	gs.CreditKill("veteran", "victim")
+	assert.Empty(t, sink.events, "XP past the highest level levels nothing")
+}
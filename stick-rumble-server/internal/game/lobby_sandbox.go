@@ -0,0 +1,137 @@
+package game
+
+import (
+	"sort"
+	"sync"
+)
+
+// LobbySandboxDummyID is the player ID of the sandbox's target dummy
+const LobbySandboxDummyID = "sandbox-dummy"
+
+// Lobby sandbox layout: a small open arena with the player on the left and
+// the dummy on the right
+const (
+	LobbySandboxWidth  = 960.0
+	LobbySandboxHeight = 540.0
+)
+
+var lobbySandboxDummyPosition = Vector2{X: 720, Y: 270}
+
+// lobbySandboxMapConfig is the minimal map a lobby sandbox runs on
+func lobbySandboxMapConfig() MapConfig {
+	return MapConfig{
+		ID:     "lobby-sandbox",
+		Name:   "Lobby Sandbox",
+		Width:  LobbySandboxWidth,
+		Height: LobbySandboxHeight,
+		SpawnPoints: []MapSpawnPoint{
+			{ID: "player", X: 240, Y: 270},
+		},
+	}
+}
+
+// LobbySandboxState is what a sandbox's player sees after a step
+type LobbySandboxState struct {
+	Player      PlayerStateSnapshot
+	Dummy       PlayerStateSnapshot
+	Projectiles []ProjectileSnapshot
+	Hits        int // Shots that hit the dummy
+	Knockdowns  int // Times the dummy's health ran out
+}
+
+// LobbySandbox is a solo practice world for a player waiting in the
+// matchmaking queue: the player can move and shoot a pistol at a stationary
+// dummy. It has its own world, physics and projectiles, is stepped outside the
+// game server's tick loop and is thrown away once the player's room forms.
+type LobbySandbox struct {
+	PlayerID    string
+	world       *World
+	physics     *Physics
+	projectiles *ProjectileManager
+	weapon      *WeaponState
+	player      *PlayerState
+	dummy       *PlayerState
+	hits        int
+	knockdowns  int
+	mu          sync.Mutex
+}
+
+// NewLobbySandbox creates a sandbox for playerID
+func NewLobbySandbox(playerID string, clock Clock) *LobbySandbox {
+	mapConfig := lobbySandboxMapConfig()
+	world := NewWorldWithClock(clock, mapConfig)
+	player := world.AddPlayer(playerID)
+	dummy := world.AddPlayer(LobbySandboxDummyID)
+	dummy.SetPosition(lobbySandboxDummyPosition)
+
+	return &LobbySandbox{
+		PlayerID:    playerID,
+		world:       world,
+		physics:     NewPhysics(mapConfig),
+		projectiles: NewProjectileManager(mapConfig),
+		weapon:      NewWeaponStateWithClock(NewPistol(), clock),
+		player:      player,
+		dummy:       dummy,
+	}
+}
+
+// SetInput replaces the player's movement and aim input
+func (s *LobbySandbox) SetInput(input InputState) {
+	s.world.UpdatePlayerInput(s.PlayerID, input)
+}
+
+// Shoot fires the pistol at aimAngle, reloading once the magazine is empty.
+// It reports whether a projectile was fired.
+func (s *LobbySandbox) Shoot(aimAngle float64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.weapon.Reloading() {
+		return false
+	}
+	if s.weapon.IsEmpty() {
+		s.weapon.StartReload()
+		return false
+	}
+	if !s.weapon.CanShoot() {
+		return false
+	}
+
+	s.weapon.RecordShot()
+	s.player.SetAimAngle(aimAngle)
+	origin := getWeaponFireOrigin(s.player.GetPosition(), aimAngle, s.weapon.Weapon.Name)
+	s.projectiles.SpawnProjectile(s.PlayerID, s.weapon.Weapon.Name, "", origin, aimAngle, s.weapon.Weapon.ProjectileSpeed)
+	return true
+}
+
+// Step advances the sandbox by deltaTime seconds and returns its state
+func (s *LobbySandbox) Step(deltaTime float64) LobbySandboxState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.weapon.CheckReloadComplete()
+	s.physics.UpdatePlayer(s.player, deltaTime)
+	s.projectiles.Update(deltaTime)
+
+	hits := s.physics.CheckAllProjectileCollisions(s.projectiles.GetProjectilesForHitDetection(), []*PlayerState{s.dummy})
+	for _, hit := range hits {
+		s.projectiles.RemoveProjectile(hit.ProjectileID)
+		s.dummy.TakeDamage(s.weapon.Weapon.Damage)
+		s.hits++
+		if !s.dummy.IsAlive() {
+			// The dummy stands straight back up
+			s.dummy.RestoreHealth(PlayerMaxHealth)
+			s.knockdowns++
+		}
+	}
+
+	projectiles := s.projectiles.GetProjectileSnapshots()
+	sort.Slice(projectiles, func(i, j int) bool { return projectiles[i].ID < projectiles[j].ID })
+	return LobbySandboxState{
+		Player:      s.player.Snapshot(),
+		Dummy:       s.dummy.Snapshot(),
+		Projectiles: projectiles,
+		Hits:        s.hits,
+		Knockdowns:  s.knockdowns,
+	}
+}
@@ -0,0 +1,59 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLobbySandboxMovesPlayer(t *testing.T) {
+	sandbox := NewLobbySandbox("player-1", NewManualClock(time.Now()))
+	start := sandbox.Step(0).Player.Position
+
+	sandbox.SetInput(InputState{Down: true})
+	for range 10 {
+		sandbox.Step(1.0 / 20.0)
+	}
+
+	state := sandbox.Step(0)
+	assert.Greater(t, state.Player.Position.Y, start.Y)
+	assert.Equal(t, lobbySandboxDummyPosition, state.Dummy.Position, "the dummy stays put")
+}
+
+func TestLobbySandboxShotsHitDummyAndKnockItDown(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	sandbox := NewLobbySandbox("player-1", clock)
+	damage := NewPistol().Damage
+	shotsToKnockDown := (PlayerMaxHealth + damage - 1) / damage
+
+	for range shotsToKnockDown {
+		require.True(t, sandbox.Shoot(0), "aim angle 0 points at the dummy")
+		for range 20 {
+			sandbox.Step(1.0 / 20.0)
+		}
+		clock.Advance(time.Second)
+	}
+
+	state := sandbox.Step(0)
+	assert.Equal(t, shotsToKnockDown, state.Hits)
+	assert.Equal(t, 1, state.Knockdowns)
+	assert.Equal(t, PlayerMaxHealth, state.Dummy.Health, "the dummy stands back up")
+	assert.Empty(t, state.Projectiles)
+}
+
+func TestLobbySandboxReloadsEmptyPistol(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	sandbox := NewLobbySandbox("player-1", clock)
+
+	for range NewPistol().MagazineSize {
+		require.True(t, sandbox.Shoot(3.14))
+		clock.Advance(time.Second)
+	}
+	assert.False(t, sandbox.Shoot(3.14), "an empty magazine starts a reload")
+
+	clock.Advance(NewPistol().ReloadTime + time.Second)
+	sandbox.Step(0)
+	assert.True(t, sandbox.Shoot(3.14))
+}
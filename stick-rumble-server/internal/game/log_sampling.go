@@ -0,0 +1,179 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrUnknownLogger is returned when sampling is set for a logger that was
+// never registered.
+var ErrUnknownLogger = errors.New("unknown logger")
+
+// LogSampling limits how often a hot-path logger writes. Every keeps one line
+// in every N (0 or 1 keeps all); MaxPerSecond drops the lines past the cap in
+// each one-second window (0 is uncapped). Both apply when both are set.
+type LogSampling struct {
+	Every        int `json:"every"`
+	MaxPerSecond int `json:"maxPerSecond"`
+}
+
+// Validate rejects negative limits
+func (s LogSampling) Validate() error {
+	if s.Every < 0 || s.MaxPerSecond < 0 {
+		return fmt.Errorf("every and maxPerSecond must not be negative")
+	}
+	return nil
+}
+
+// LogSamplingStatus is one logger's sampling and how many lines it dropped
+type LogSamplingStatus struct {
+	Name       string      `json:"name"`
+	Sampling   LogSampling `json:"sampling"`
+	Written    uint64      `json:"written"`
+	Suppressed uint64      `json:"suppressed"`
+}
+
+// SampledLogger is a named logger for lines written per tick or per message,
+// such as broadcast and input handling errors, whose sampling an operator can
+// change at runtime. The next line written after some were dropped reports how
+// many.
+type SampledLogger struct {
+	name        string
+	sampling    LogSampling
+	clock       Clock
+	seen        uint64    // Lines offered since the sampling last changed
+	windowStart time.Time // Start of the current MaxPerSecond window
+	windowCount int       // Lines written in the current window
+	pending     uint64    // Lines dropped since the last written one
+	written     uint64
+	suppressed  uint64
+	output      func(string)
+	mu          sync.Mutex
+}
+
+func newSampledLogger(name string, clock Clock) *SampledLogger {
+	return &SampledLogger{
+		name:   name,
+		clock:  clock,
+		output: func(line string) { log.Print(line) },
+	}
+}
+
+// Printf writes the line unless the logger's sampling drops it
+func (l *SampledLogger) Printf(format string, args ...any) {
+	l.mu.Lock()
+	if !l.allowLocked() {
+		l.pending++
+		l.suppressed++
+		l.mu.Unlock()
+		return
+	}
+	dropped := l.pending
+	l.pending = 0
+	l.written++
+	l.mu.Unlock()
+
+	line := fmt.Sprintf(format, args...)
+	if dropped > 0 {
+		line = fmt.Sprintf("%s [%s: %d similar lines suppressed]", line, l.name, dropped)
+	}
+	l.output(line)
+}
+
+func (l *SampledLogger) allowLocked() bool {
+	l.seen++
+	if l.sampling.Every > 1 && (l.seen-1)%uint64(l.sampling.Every) != 0 {
+		return false
+	}
+	if l.sampling.MaxPerSecond > 0 {
+		now := l.clock.Now()
+		if now.Sub(l.windowStart) >= time.Second {
+			l.windowStart = now
+			l.windowCount = 0
+		}
+		if l.windowCount >= l.sampling.MaxPerSecond {
+			return false
+		}
+		l.windowCount++
+	}
+	return true
+}
+
+// SetSampling replaces the logger's sampling. The next line is always kept.
+func (l *SampledLogger) SetSampling(sampling LogSampling) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sampling = sampling
+	l.seen = 0
+	l.windowStart = time.Time{}
+	l.windowCount = 0
+}
+
+// Status returns the logger's sampling and counters
+func (l *SampledLogger) Status() LogSamplingStatus {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return LogSamplingStatus{
+		Name:       l.name,
+		Sampling:   l.sampling,
+		Written:    l.written,
+		Suppressed: l.suppressed,
+	}
+}
+
+var hotPathLoggers = struct {
+	byName map[string]*SampledLogger
+	mu     sync.Mutex
+}{byName: make(map[string]*SampledLogger)}
+
+// HotPathLogger returns the process-wide sampled logger with the given name,
+// registering it on first use. Loggers keep every line until sampling is set.
+func HotPathLogger(name string) *SampledLogger {
+	hotPathLoggers.mu.Lock()
+	defer hotPathLoggers.mu.Unlock()
+
+	logger, exists := hotPathLoggers.byName[name]
+	if !exists {
+		logger = newSampledLogger(name, &RealClock{})
+		hotPathLoggers.byName[name] = logger
+	}
+	return logger
+}
+
+// HotPathLogSampling lists every registered hot-path logger, sorted by name
+func HotPathLogSampling() []LogSamplingStatus {
+	hotPathLoggers.mu.Lock()
+	loggers := make([]*SampledLogger, 0, len(hotPathLoggers.byName))
+	for _, logger := range hotPathLoggers.byName {
+		loggers = append(loggers, logger)
+	}
+	hotPathLoggers.mu.Unlock()
+
+	statuses := make([]LogSamplingStatus, 0, len(loggers))
+	for _, logger := range loggers {
+		statuses = append(statuses, logger.Status())
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
+
+// SetHotPathLogSampling changes a registered logger's sampling
+func SetHotPathLogSampling(name string, sampling LogSampling) (LogSamplingStatus, error) {
+	if err := sampling.Validate(); err != nil {
+		return LogSamplingStatus{}, err
+	}
+
+	hotPathLoggers.mu.Lock()
+	logger, exists := hotPathLoggers.byName[name]
+	hotPathLoggers.mu.Unlock()
+	if !exists {
+		return LogSamplingStatus{}, fmt.Errorf("%w: %q", ErrUnknownLogger, name)
+	}
+
+	logger.SetSampling(sampling)
+	return logger.Status(), nil
+}
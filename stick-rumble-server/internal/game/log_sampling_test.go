@@ -0,0 +1,76 @@
+package game
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCapturedLogger(clock Clock) (*SampledLogger, *[]string) {
+	lines := []string{}
+	logger := newSampledLogger("test", clock)
+	logger.output = func(line string) { lines = append(lines, line) }
+	return logger, &lines
+}
+
+func TestSampledLoggerKeepsEveryLineByDefault(t *testing.T) {
+	logger, lines := newCapturedLogger(NewManualClock(time.Now()))
+
+	for i := range 5 {
+		logger.Printf("line %d", i)
+	}
+
+	assert.Len(t, *lines, 5)
+	assert.Zero(t, logger.Status().Suppressed)
+}
+
+func TestSampledLoggerEvery(t *testing.T) {
+	logger, lines := newCapturedLogger(NewManualClock(time.Now()))
+	logger.SetSampling(LogSampling{Every: 3})
+
+	for i := range 7 {
+		logger.Printf("line %d", i)
+	}
+
+	assert.Equal(t, []string{
+		"line 0",
+		"line 3 [test: 2 similar lines suppressed]",
+		"line 6 [test: 2 similar lines suppressed]",
+	}, *lines)
+	status := logger.Status()
+	assert.Equal(t, uint64(3), status.Written)
+	assert.Equal(t, uint64(4), status.Suppressed)
+}
+
+func TestSampledLoggerMaxPerSecond(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	logger, lines := newCapturedLogger(clock)
+	logger.SetSampling(LogSampling{MaxPerSecond: 2})
+
+	for i := range 10 {
+		logger.Printf("line %d", i)
+	}
+	require.Len(t, *lines, 2)
+
+	clock.Advance(time.Second)
+	logger.Printf("next second")
+	assert.Equal(t, "next second [test: 8 similar lines suppressed]", (*lines)[2])
+}
+
+func TestSetHotPathLogSampling(t *testing.T) {
+	logger := HotPathLogger("test_hot_path")
+	t.Cleanup(func() { logger.SetSampling(LogSampling{}) })
+
+	status, err := SetHotPathLogSampling("test_hot_path", LogSampling{MaxPerSecond: 5})
+	require.NoError(t, err)
+	assert.Equal(t, LogSampling{MaxPerSecond: 5}, status.Sampling)
+	assert.Contains(t, HotPathLogSampling(), status)
+
+	_, err = SetHotPathLogSampling("missing", LogSampling{})
+	assert.True(t, errors.Is(err, ErrUnknownLogger))
+	_, err = SetHotPathLogSampling("test_hot_path", LogSampling{Every: -1})
+	assert.Error(t, err)
+}
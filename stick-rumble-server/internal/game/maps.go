@@ -59,6 +59,8 @@ type MapConfig struct {
 	Obstacles                  []MapObstacle                  `json:"obstacles"`
 	SpawnPoints                []MapSpawnPoint                `json:"spawnPoints"`
 	WeaponSpawns               []MapWeaponSpawn               `json:"weaponSpawns"`
+	ShieldSpawns               []MapSpawnPoint                `json:"shieldSpawns,omitempty"`
+	HealthSpawns               []MapSpawnPoint                `json:"healthSpawns,omitempty"`
 	VisualAcceptanceViewpoints []MapVisualAcceptanceViewpoint `json:"visualAcceptanceViewpoints"`
 }
 
@@ -125,6 +127,7 @@ func loadDefaultMapRegistry() (*MapRegistry, error) {
 		"../maps",
 		"../../maps",
 		"../../../maps",
+		"../../../../maps",
 	}
 
 	var lastErr error
@@ -224,6 +227,8 @@ func ValidateMapConfig(mapConfig MapConfig) []string {
 	errors = append(errors, collectDuplicateIDs(mapConfig.Obstacles, "obstacle")...)
 	errors = append(errors, collectDuplicateIDs(mapConfig.SpawnPoints, "spawn point")...)
 	errors = append(errors, collectDuplicateIDs(mapConfig.WeaponSpawns, "weapon spawn")...)
+	errors = append(errors, collectDuplicateIDs(mapConfig.ShieldSpawns, "shield spawn")...)
+	errors = append(errors, collectDuplicateIDs(mapConfig.HealthSpawns, "health spawn")...)
 	errors = append(errors, collectDuplicateIDs(mapConfig.VisualAcceptanceViewpoints, "visual acceptance viewpoint")...)
 
 	for _, obstacle := range mapConfig.Obstacles {
@@ -292,6 +297,36 @@ func ValidateMapConfig(mapConfig MapConfig) []string {
 		}
 	}
 
+	for _, shieldSpawn := range mapConfig.ShieldSpawns {
+		if strings.TrimSpace(shieldSpawn.ID) == "" {
+			errors = append(errors, "shield spawn id is required")
+		}
+		if !pointWithinBounds(shieldSpawn.X, shieldSpawn.Y, mapConfig) {
+			errors = append(errors, fmt.Sprintf("shield spawn %q lies outside map bounds", shieldSpawn.ID))
+			continue
+		}
+		for _, obstacle := range blockingObstacles {
+			if pointInsideRect(shieldSpawn.X, shieldSpawn.Y, rectFromObstacle(obstacle)) {
+				errors = append(errors, fmt.Sprintf("shield spawn %q overlaps blocking obstacle %q", shieldSpawn.ID, obstacle.ID))
+			}
+		}
+	}
+
+	for _, healthSpawn := range mapConfig.HealthSpawns {
+		if strings.TrimSpace(healthSpawn.ID) == "" {
+			errors = append(errors, "health spawn id is required")
+		}
+		if !pointWithinBounds(healthSpawn.X, healthSpawn.Y, mapConfig) {
+			errors = append(errors, fmt.Sprintf("health spawn %q lies outside map bounds", healthSpawn.ID))
+			continue
+		}
+		for _, obstacle := range blockingObstacles {
+			if pointInsideRect(healthSpawn.X, healthSpawn.Y, rectFromObstacle(obstacle)) {
+				errors = append(errors, fmt.Sprintf("health spawn %q overlaps blocking obstacle %q", healthSpawn.ID, obstacle.ID))
+			}
+		}
+	}
+
 	outcomes := map[string]int{}
 	for _, viewpoint := range mapConfig.VisualAcceptanceViewpoints {
 		if strings.TrimSpace(viewpoint.ID) == "" {
@@ -408,7 +443,7 @@ func projectileBlockingObstacles(mapConfig MapConfig) []MapObstacle {
 
 func isSupportedMapWeaponType(weaponType string) bool {
 	switch weaponType {
-	case "uzi", "ak47", "shotgun", "katana", "bat":
+	case "uzi", "ak47", "shotgun", "katana", "bat", "fists", "rocketlauncher":
 		return true
 	default:
 		return false
@@ -1,8 +1,11 @@
 package game
 
 import (
+	"sort"
 	"sync"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 // MatchState represents the current state of a match
@@ -22,10 +25,21 @@ type MatchConfig struct {
 
 // PlayerScore represents a player's final score in a match
 type PlayerScore struct {
+	PlayerID       string `json:"playerId"`
+	DisplayName    string `json:"displayName"`
+	Kills          int    `json:"kills"`
+	Deaths         int    `json:"deaths"`
+	XP             int    `json:"xp"`
+	BestKillStreak int    `json:"bestKillStreak"` // Longest run of kills without dying
+}
+
+// ScoreboardEntry is one player's standing in a running match
+type ScoreboardEntry struct {
 	PlayerID    string `json:"playerId"`
 	DisplayName string `json:"displayName"`
 	Kills       int    `json:"kills"`
 	Deaths      int    `json:"deaths"`
+	Assists     int    `json:"assists"`
 	XP          int    `json:"xp"`
 }
 
@@ -36,12 +50,24 @@ type WinnerSummary struct {
 
 // Match represents a game match with win conditions and state tracking
 type Match struct {
+	ID                string // Assigned each time the match starts, so a rematch in the same room gets its own
 	Config            MatchConfig
 	State             MatchState
-	StartTime         time.Time
-	EndReason         string          // "kill_target" or "time_limit"
-	PlayerKills       map[string]int  // Maps player ID to kill count
-	RegisteredPlayers map[string]bool // Tracks all players in the match (including those with 0 kills)
+	StartTime         time.Time                         // Wall-clock start, for display and logs only
+	ElapsedTicks      int                               // Simulation ticks run while the match was active
+	paused            bool                              // Set while the clock is paused
+	EndReason         string                            // "kill_target" or "time_limit"
+	PlayerKills       map[string]int                    // Maps player ID to kill count
+	PlayerAssists     map[string]int                    // Maps player ID to assist count
+	RegisteredPlayers map[string]bool                   // Tracks all players in the match (including those with 0 kills)
+	Seed              int64                             // Seed of the owning room's random source, for reproducing the match
+	endRequest        string                            // Reason passed to RequestEnd, applied on the next match tick
+	recentDamage      map[string]map[string][]damageHit // victim ID -> attacker ID -> hits within the assist window
+	firstHits         map[string]map[string]int         // victim ID -> attacker ID -> tick of the attacker's first hit this life
+	combatStats       map[string]*combatStats           // Shots, hits and damage per player, for the end-of-match scoreboard
+	departed          []string                          // Players who left and keep their scores, oldest first
+	evictedScorers    int                               // Players who left and were dropped for MaxDepartedScorers
+	hitsDroppedByCap  int                               // Assist hits dropped for MaxHitsPerAttacker
 	mu                sync.RWMutex
 }
 
@@ -54,7 +80,11 @@ func NewMatch() *Match {
 		},
 		State:             MatchStateWaiting,
 		PlayerKills:       make(map[string]int),
+		PlayerAssists:     make(map[string]int),
 		RegisteredPlayers: make(map[string]bool),
+		recentDamage:      make(map[string]map[string][]damageHit),
+		firstHits:         make(map[string]map[string]int),
+		combatStats:       make(map[string]*combatStats),
 	}
 }
 
@@ -78,21 +108,117 @@ func (m *Match) Start() {
 		return
 	}
 
+	m.ID = uuid.New().String()
 	m.State = MatchStateActive
 	m.StartTime = time.Now()
 }
 
-// GetRemainingSeconds calculates the remaining time in the match
-func (m *Match) GetRemainingSeconds() int {
+// Reset returns an ended match to waiting with no kills, assists or combat
+// stats, for a rematch in the same room. Config and Seed are kept, and
+// players must be registered again.
+func (m *Match) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.ID = ""
+	m.State = MatchStateWaiting
+	m.StartTime = time.Time{}
+	m.ElapsedTicks = 0
+	m.EndReason = ""
+	m.endRequest = ""
+	m.PlayerKills = make(map[string]int)
+	m.PlayerAssists = make(map[string]int)
+	m.RegisteredPlayers = make(map[string]bool)
+	m.recentDamage = make(map[string]map[string][]damageHit)
+	m.firstHits = make(map[string]map[string]int)
+	m.combatStats = make(map[string]*combatStats)
+	m.departed = nil
+	m.evictedScorers = 0
+	m.hitsDroppedByCap = 0
+}
+
+// SetSeed records the room random seed this match draws from
+func (m *Match) SetSeed(seed int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.Seed = seed
+}
+
+// GetSeed returns the room random seed this match draws from
+func (m *Match) GetSeed() int64 {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	// If match not started, return full time
-	if m.StartTime.IsZero() {
-		return m.Config.TimeLimitSeconds
+	return m.Seed
+}
+
+// AdvanceTicks adds simulation ticks to an active match's clock. The game
+// loop calls it once per tick, so the match clock stops while the tick loop is
+// suspended or stalled and is unaffected by wall-clock adjustments.
+func (m *Match) AdvanceTicks(ticks int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.State != MatchStateActive || m.paused {
+		return
+	}
+	m.ElapsedTicks += ticks
+}
+
+// Pause stops an active match's clock until Resume, e.g. while most of the
+// room is disconnected and waiting to resume. It returns false if the clock
+// was not running.
+func (m *Match) Pause() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.State != MatchStateActive || m.paused {
+		return false
 	}
+	m.paused = true
+	return true
+}
+
+// Resume restarts a paused clock. It returns false if the clock was not
+// paused.
+func (m *Match) Resume() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.paused {
+		return false
+	}
+	m.paused = false
+	return true
+}
+
+// IsPaused returns true while the match clock is paused
+func (m *Match) IsPaused() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.paused
+}
+
+// Elapsed returns how much simulation time the match has run
+func (m *Match) Elapsed() time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 
-	elapsed := int(time.Since(m.StartTime).Seconds())
+	return m.elapsedLocked()
+}
+
+func (m *Match) elapsedLocked() time.Duration {
+	return time.Duration(m.ElapsedTicks) * time.Duration(ServerTickInterval) * time.Millisecond
+}
+
+// GetRemainingSeconds calculates the remaining time in the match
+func (m *Match) GetRemainingSeconds() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	elapsed := int(m.elapsedLocked().Seconds())
 	remaining := m.Config.TimeLimitSeconds - elapsed
 
 	if remaining < 0 {
@@ -109,6 +235,7 @@ func (m *Match) RegisterPlayer(playerID string) {
 	defer m.mu.Unlock()
 
 	m.RegisteredPlayers[playerID] = true
+	m.forgetDepartureLocked(playerID)
 	// Initialize PlayerKills to 0 if not already set
 	if _, exists := m.PlayerKills[playerID]; !exists {
 		m.PlayerKills[playerID] = 0
@@ -123,6 +250,103 @@ func (m *Match) AddKill(playerID string) {
 	m.PlayerKills[playerID]++
 }
 
+// damageHit is one hit recorded for assists
+type damageHit struct {
+	tick   int // ElapsedTicks when the hit landed
+	damage int
+}
+
+// Assist is one player's contribution to a kill they did not land
+type Assist struct {
+	PlayerID string
+	Damage   int // Damage the player dealt the victim within the assist window
+	Assists  int // The player's assists this match, including this one
+}
+
+// RecordDamage notes that attackerID hit victimID for damage, making the
+// attacker eligible for an assist if someone else kills the victim soon after
+func (m *Match) RecordDamage(attackerID, victimID string, damage int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if attackerID == victimID {
+		return
+	}
+	m.combatStatsLocked(attackerID).damageDealt += damage
+	if m.recentDamage[victimID] == nil {
+		m.recentDamage[victimID] = make(map[string][]damageHit)
+	}
+	hits := m.recentHitsLocked(m.recentDamage[victimID][attackerID])
+	m.recentDamage[victimID][attackerID] = m.capHitsLocked(append(hits, damageHit{tick: m.ElapsedTicks, damage: damage}))
+	if m.firstHits[victimID] == nil {
+		m.firstHits[victimID] = make(map[string]int)
+	}
+	if _, hit := m.firstHits[victimID][attackerID]; !hit {
+		m.firstHits[victimID][attackerID] = m.ElapsedTicks
+	}
+}
+
+// TimeToKill returns how long killerID took to kill victimID: the match time
+// since the killer's first hit on the victim's current life. False if the
+// killer never hit it. Call it before RecordKill, which starts a new life.
+func (m *Match) TimeToKill(killerID, victimID string) (time.Duration, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	tick, hit := m.firstHits[victimID][killerID]
+	if !hit {
+		return 0, false
+	}
+	return time.Duration(m.ElapsedTicks-tick) * time.Duration(ServerTickInterval) * time.Millisecond, true
+}
+
+// recentHitsLocked drops the hits older than the assist window
+func (m *Match) recentHitsLocked(hits []damageHit) []damageHit {
+	windowTicks := AssistWindowSeconds * 1000 / ServerTickInterval
+	recent := hits[:0]
+	for _, hit := range hits {
+		if m.ElapsedTicks-hit.tick <= windowTicks {
+			recent = append(recent, hit)
+		}
+	}
+	return recent
+}
+
+// RecordKill credits killerID with a kill and every other player who damaged
+// victimID within AssistWindowSeconds with an assist. It returns the assists
+// sorted by player ID.
+func (m *Match) RecordKill(killerID, victimID string) []Assist {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.PlayerKills[killerID]++
+
+	assists := []Assist{}
+	for attackerID, hits := range m.recentDamage[victimID] {
+		if attackerID == killerID {
+			continue
+		}
+		damage := 0
+		for _, hit := range m.recentHitsLocked(hits) {
+			damage += hit.damage
+		}
+		if damage == 0 {
+			continue
+		}
+		m.PlayerAssists[attackerID]++
+		assists = append(assists, Assist{
+			PlayerID: attackerID,
+			Damage:   damage,
+			Assists:  m.PlayerAssists[attackerID],
+		})
+	}
+	delete(m.recentDamage, victimID)
+	delete(m.firstHits, victimID)
+
+	sort.Slice(assists, func(i, j int) bool { return assists[i].PlayerID < assists[j].PlayerID })
+	return assists
+}
+
 // CheckKillTarget checks if any player has reached the kill target
 func (m *Match) CheckKillTarget() bool {
 	m.mu.RLock()
@@ -143,12 +367,11 @@ func (m *Match) CheckTimeLimit() bool {
 	defer m.mu.RUnlock()
 
 	// If match not started, time limit not reached
-	if m.StartTime.IsZero() {
+	if m.ElapsedTicks == 0 {
 		return false
 	}
 
-	elapsed := time.Since(m.StartTime).Seconds()
-	return elapsed >= float64(m.Config.TimeLimitSeconds)
+	return m.elapsedLocked() >= time.Duration(m.Config.TimeLimitSeconds)*time.Second
 }
 
 // EndMatch ends the match with the given reason
@@ -165,6 +388,29 @@ func (m *Match) EndMatch(reason string) {
 	m.EndReason = reason
 }
 
+// RequestEnd asks for the match to end with the given reason on the next
+// match tick, so the end is announced like a time limit end. Used by custom
+// mode scripts, which must not end the match from inside a gameplay hook.
+func (m *Match) RequestEnd(reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.State == MatchStateEnded || m.endRequest != "" {
+		return
+	}
+	m.endRequest = reason
+}
+
+// takeEndRequest returns and clears a pending RequestEnd reason
+func (m *Match) takeEndRequest() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	reason := m.endRequest
+	m.endRequest = ""
+	return reason
+}
+
 // IsEnded returns true if the match has ended
 func (m *Match) IsEnded() bool {
 	m.mu.RLock()
@@ -180,6 +426,22 @@ func (m *Match) IsStarted() bool {
 	return m.State == MatchStateActive
 }
 
+// GetState returns the match's current state
+func (m *Match) GetState() MatchState {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.State
+}
+
+// GetID returns the ID of the match's current run, empty until it starts
+func (m *Match) GetID() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.ID
+}
+
 // DetermineWinners analyzes PlayerKills and returns player IDs with the highest kill count
 // Returns multiple IDs in case of a tie
 func (m *Match) DetermineWinners() []string {
@@ -235,16 +497,70 @@ func (m *Match) GetFinalScores(world *World) []PlayerScore {
 
 		// Create score entry with player stats
 		score := PlayerScore{
+			PlayerID:       playerID,
+			DisplayName:    displayName,
+			Kills:          player.Kills,
+			Deaths:         player.Deaths,
+			XP:             player.XP,
+			BestKillStreak: player.GetBestKillStreak(),
+		}
+		scores = append(scores, score)
+	}
+
+	return scores
+}
+
+// KillMap returns a copy of the authoritative kill counts, including players
+// who have since left the match
+func (m *Match) KillMap() map[string]int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	kills := make(map[string]int, len(m.PlayerKills))
+	for playerID, count := range m.PlayerKills {
+		kills[playerID] = count
+	}
+	return kills
+}
+
+// Scoreboard returns the standings of registered players still in the world,
+// most kills first. Kills and assists come from the match; deaths and XP from
+// the player state.
+func (m *Match) Scoreboard(world *World) []ScoreboardEntry {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	world.mu.RLock()
+	defer world.mu.RUnlock()
+
+	entries := []ScoreboardEntry{}
+	for playerID := range m.RegisteredPlayers {
+		player, exists := world.players[playerID]
+		if !exists {
+			continue
+		}
+		displayName := player.DisplayName
+		if displayName == "" {
+			displayName = FallbackDisplayName
+		}
+
+		entries = append(entries, ScoreboardEntry{
 			PlayerID:    playerID,
 			DisplayName: displayName,
-			Kills:       player.Kills,
+			Kills:       m.PlayerKills[playerID],
 			Deaths:      player.Deaths,
+			Assists:     m.PlayerAssists[playerID],
 			XP:          player.XP,
-		}
-		scores = append(scores, score)
+		})
 	}
 
-	return scores
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Kills != entries[j].Kills {
+			return entries[i].Kills > entries[j].Kills
+		}
+		return entries[i].PlayerID < entries[j].PlayerID
+	})
+	return entries
 }
 
 func (m *Match) GetWinnerSummaries(world *World) []WinnerSummary {
@@ -0,0 +1,115 @@
+package game
+
+// Caps on the history a match accumulates, so a multi-hour custom match with
+// players coming and going can't grow without bound
+const (
+	// MaxHitsPerAttacker is how many hits on one victim by one attacker are
+	// kept for assists. A burst of pellets inside the assist window beyond
+	// it drops the oldest hits.
+	MaxHitsPerAttacker = 32
+
+	// MaxDepartedScorers is how many players who left keep their kills,
+	// assists and combat stats for the final scores. Beyond it the player
+	// who left first is dropped.
+	MaxDepartedScorers = 64
+)
+
+// MatchHistorySizes is how much per-match history a match is holding
+type MatchHistorySizes struct {
+	DamageVictims    int `json:"damageVictims"`    // Victims with hits kept for assists
+	DamageHits       int `json:"damageHits"`       // Hits kept for assists across every victim
+	FirstHits        int `json:"firstHits"`        // Attacker-victim pairs timed for time to kill
+	ScoredPlayers    int `json:"scoredPlayers"`    // Players in the kill, assist and combat stat maps
+	DepartedPlayers  int `json:"departedPlayers"`  // Players who left and still keep their scores
+	EvictedScorers   int `json:"evictedScorers"`   // Players who left and were dropped from the scores
+	HitsDroppedByCap int `json:"hitsDroppedByCap"` // Hits dropped by MaxHitsPerAttacker
+}
+
+// PlayerLeft drops what the match holds for a player who left: hits by and
+// on the player are forgotten at once, and its scores are kept for the final
+// scores until MaxDepartedScorers other players have left since.
+func (m *Match) PlayerLeft(playerID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.recentDamage, playerID)
+	for victimID, attackers := range m.recentDamage {
+		delete(attackers, playerID)
+		if len(attackers) == 0 {
+			delete(m.recentDamage, victimID)
+		}
+	}
+	delete(m.firstHits, playerID)
+	for victimID, attackers := range m.firstHits {
+		delete(attackers, playerID)
+		if len(attackers) == 0 {
+			delete(m.firstHits, victimID)
+		}
+	}
+
+	m.forgetDepartureLocked(playerID)
+	m.departed = append(m.departed, playerID)
+	for len(m.departed) > MaxDepartedScorers {
+		evicted := m.departed[0]
+		m.departed = m.departed[1:]
+		delete(m.PlayerKills, evicted)
+		delete(m.PlayerAssists, evicted)
+		delete(m.RegisteredPlayers, evicted)
+		delete(m.combatStats, evicted)
+		m.evictedScorers++
+	}
+}
+
+// forgetDepartureLocked takes a player who rejoined off the departed list.
+// Callers hold m.mu for writing.
+func (m *Match) forgetDepartureLocked(playerID string) {
+	for i, departedID := range m.departed {
+		if departedID == playerID {
+			m.departed = append(m.departed[:i], m.departed[i+1:]...)
+			return
+		}
+	}
+}
+
+// capHitsLocked keeps the newest MaxHitsPerAttacker hits. Callers hold m.mu
+// for writing.
+func (m *Match) capHitsLocked(hits []damageHit) []damageHit {
+	if over := len(hits) - MaxHitsPerAttacker; over > 0 {
+		m.hitsDroppedByCap += over
+		hits = append(hits[:0], hits[over:]...)
+	}
+	return hits
+}
+
+// HistorySizes returns how much per-match history the match is holding
+func (m *Match) HistorySizes() MatchHistorySizes {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	sizes := MatchHistorySizes{
+		DamageVictims:    len(m.recentDamage),
+		DepartedPlayers:  len(m.departed),
+		EvictedScorers:   m.evictedScorers,
+		HitsDroppedByCap: m.hitsDroppedByCap,
+	}
+	for _, attackers := range m.recentDamage {
+		for _, hits := range attackers {
+			sizes.DamageHits += len(hits)
+		}
+	}
+	for _, attackers := range m.firstHits {
+		sizes.FirstHits += len(attackers)
+	}
+	scored := make(map[string]bool, len(m.PlayerKills))
+	for playerID := range m.PlayerKills {
+		scored[playerID] = true
+	}
+	for playerID := range m.PlayerAssists {
+		scored[playerID] = true
+	}
+	for playerID := range m.combatStats {
+		scored[playerID] = true
+	}
+	sizes.ScoredPlayers = len(scored)
+	return sizes
+}
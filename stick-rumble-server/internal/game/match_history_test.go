@@ -0,0 +1,64 @@
+package game
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordDamageKeepsNewestHitsPerAttacker(t *testing.T) {
+	match := NewMatch()
+	for i := 0; i < MaxHitsPerAttacker+8; i++ {
+		match.RecordDamage("attacker", "victim", 1)
+	}
+
+	sizes := match.HistorySizes()
+	assert.Equal(t, 1, sizes.DamageVictims)
+	assert.Equal(t, MaxHitsPerAttacker, sizes.DamageHits)
+	assert.Equal(t, 8, sizes.HitsDroppedByCap)
+	assert.Equal(t, 1, sizes.FirstHits)
+}
+
+func TestPlayerLeftForgetsItsHits(t *testing.T) {
+	match := NewMatch()
+	match.RecordDamage("leaver", "stayer", 10)
+	match.RecordDamage("stayer", "leaver", 10)
+	match.RecordDamage("other", "stayer", 10)
+
+	match.PlayerLeft("leaver")
+
+	sizes := match.HistorySizes()
+	assert.Equal(t, 1, sizes.DamageVictims, "only the stayer's hit by other is left")
+	assert.Equal(t, 1, sizes.DamageHits)
+	assert.Equal(t, 1, sizes.FirstHits)
+	assert.Equal(t, 1, sizes.DepartedPlayers)
+
+	assists := match.RecordKill("killer", "stayer")
+	assert.Equal(t, []Assist{{PlayerID: "other", Damage: 10, Assists: 1}}, assists, "a player who left earns no assist")
+}
+
+func TestPlayerLeftEvictsOldestDepartedScorers(t *testing.T) {
+	match := NewMatch()
+	for i := 0; i <= MaxDepartedScorers; i++ {
+		playerID := fmt.Sprintf("player-%d", i)
+		match.RegisterPlayer(playerID)
+		match.AddKill(playerID)
+		match.PlayerLeft(playerID)
+	}
+
+	sizes := match.HistorySizes()
+	assert.Equal(t, MaxDepartedScorers, sizes.DepartedPlayers)
+	assert.Equal(t, 1, sizes.EvictedScorers)
+	assert.Equal(t, MaxDepartedScorers, sizes.ScoredPlayers)
+	_, kept := match.PlayerKills["player-0"]
+	assert.False(t, kept, "the player who left first is dropped")
+	assert.Equal(t, 1, match.PlayerKills["player-1"])
+
+	// A player who rejoins is no longer one who left
+	match.RegisterPlayer("player-1")
+	assert.Equal(t, MaxDepartedScorers-1, match.HistorySizes().DepartedPlayers)
+
+	match.Reset()
+	assert.Equal(t, MatchHistorySizes{}, match.HistorySizes())
+}
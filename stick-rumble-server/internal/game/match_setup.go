@@ -0,0 +1,38 @@
+package game
+
+// MatchSettings are the rules a room's match runs under
+type MatchSettings struct {
+	RoomKind         string  `json:"roomKind"`
+	MaxPlayers       int     `json:"maxPlayers"`
+	KillTarget       int     `json:"killTarget"`
+	TimeLimitSeconds int     `json:"timeLimitSeconds"`
+	AimTurnRate      float64 `json:"aimTurnRate,omitempty"` // Room override in radians per second; absent when the server's applies
+}
+
+// MatchSetup is what a match needs to be replayed: the rules it ran under,
+// its map, the mutators that changed those rules and the seed all its
+// randomness came from
+type MatchSetup struct {
+	MatchID  string
+	Settings MatchSettings
+	MapID    string
+	Mutators []string // Names of the room's gameplay hooks, in the order they apply
+	Seed     int64
+}
+
+// MatchSetup returns the setup of the room's current match
+func (r *Room) MatchSetup() MatchSetup {
+	return MatchSetup{
+		MatchID: r.Match.GetID(),
+		Settings: MatchSettings{
+			RoomKind:         string(r.Kind),
+			MaxPlayers:       r.MaxPlayers,
+			KillTarget:       r.Match.Config.KillTarget,
+			TimeLimitSeconds: r.Match.Config.TimeLimitSeconds,
+			AimTurnRate:      r.AimTurnRate(),
+		},
+		MapID:    r.MapID,
+		Mutators: r.Hooks.Names(),
+		Seed:     r.Match.GetSeed(),
+	}
+}
@@ -0,0 +1,44 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type namedHook struct {
+	BaseGameplayHook
+	name string
+}
+
+func (h namedHook) Name() string { return h.name }
+
+func TestRoomMatchSetupDescribesRulesMapMutatorsAndSeed(t *testing.T) {
+	room := NewTypedRoom(RoomKindCode, "ZOMBIES", "default_office")
+	room.Reseed(424242)
+	room.SetAimTurnRate(12)
+	room.Hooks.Register(namedHook{name: "zombies"})
+	room.Hooks.Register(doubleDamageHook{})
+	room.Match.Start()
+
+	setup := room.MatchSetup()
+
+	assert.Equal(t, room.Match.GetID(), setup.MatchID)
+	assert.Equal(t, MatchSettings{
+		RoomKind:         "code",
+		MaxPlayers:       room.MaxPlayers,
+		KillTarget:       room.Match.Config.KillTarget,
+		TimeLimitSeconds: room.Match.Config.TimeLimitSeconds,
+		AimTurnRate:      12,
+	}, setup.Settings)
+	assert.Equal(t, "default_office", setup.MapID)
+	assert.Equal(t, []string{"zombies"}, setup.Mutators, "only named hooks are listed")
+	assert.Equal(t, int64(424242), setup.Seed)
+}
+
+func TestRoomMatchSetupListsNoMutatorsForDefaultRules(t *testing.T) {
+	setup := NewRoom().MatchSetup()
+
+	assert.NotNil(t, setup.Mutators)
+	assert.Empty(t, setup.Mutators)
+}
@@ -0,0 +1,126 @@
+package game
+
+import (
+	"math"
+	"sort"
+)
+
+// combatStats is what a match counts of one player's fighting beyond kills
+type combatStats struct {
+	shotsFired  int // Projectiles, pellets and hitscan rays fired
+	shotsHit    int // Ranged hits on other players
+	damageDealt int // Damage dealt to other players, ranged and melee
+}
+
+// PlayerMatchStats is one player's line on the end-of-match scoreboard
+type PlayerMatchStats struct {
+	PlayerID       string  `json:"playerId"`
+	DisplayName    string  `json:"displayName"`
+	Kills          int     `json:"kills"`
+	Deaths         int     `json:"deaths"`
+	Assists        int     `json:"assists"`
+	XP             int     `json:"xp"`
+	ShotsFired     int     `json:"shotsFired"`
+	ShotsHit       int     `json:"shotsHit"`
+	Accuracy       float64 `json:"accuracy"` // ShotsHit / ShotsFired, at most 1; 0 without shots
+	DamageDealt    int     `json:"damageDealt"`
+	BestKillStreak int     `json:"bestKillStreak"`
+}
+
+// combatStatsLocked returns the player's stats, creating them on first use.
+// Callers hold m.mu for writing.
+func (m *Match) combatStatsLocked(playerID string) *combatStats {
+	stats, exists := m.combatStats[playerID]
+	if !exists {
+		stats = &combatStats{}
+		m.combatStats[playerID] = stats
+	}
+	return stats
+}
+
+// RecordShots counts shots a player fired; a spread shot counts each pellet
+func (m *Match) RecordShots(playerID string, count int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.combatStatsLocked(playerID).shotsFired += count
+}
+
+// RecordShotHit counts one ranged hit by a player on someone else
+func (m *Match) RecordShotHit(attackerID, victimID string) {
+	if attackerID == victimID {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.combatStatsLocked(attackerID).shotsHit++
+}
+
+// ShotStats returns the shots a player has fired and landed so far this match
+// and their accuracy
+func (m *Match) ShotStats(playerID string) (fired, hit int, accuracy float64) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	stats, ok := m.combatStats[playerID]
+	if !ok {
+		return 0, 0, 0
+	}
+	return stats.shotsFired, stats.shotsHit, shotAccuracy(stats.shotsFired, stats.shotsHit)
+}
+
+// shotAccuracy is hits over shots, at most 1 since an explosion can hit
+// several players with one shot; 0 without shots
+func shotAccuracy(fired, hit int) float64 {
+	if fired <= 0 {
+		return 0
+	}
+	return math.Min(float64(hit)/float64(fired), 1)
+}
+
+// MatchStats returns the end-of-match scoreboard: one line per registered
+// player still in the world, sorted by kills descending then player ID
+func (m *Match) MatchStats(world *World) []PlayerMatchStats {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	world.mu.RLock()
+	defer world.mu.RUnlock()
+
+	lines := []PlayerMatchStats{}
+	for playerID := range m.RegisteredPlayers {
+		player, exists := world.players[playerID]
+		if !exists {
+			continue
+		}
+		snapshot := player.Snapshot()
+		displayName := snapshot.DisplayName
+		if displayName == "" {
+			displayName = FallbackDisplayName
+		}
+
+		line := PlayerMatchStats{
+			PlayerID:       playerID,
+			DisplayName:    displayName,
+			Kills:          m.PlayerKills[playerID],
+			Deaths:         snapshot.Deaths,
+			Assists:        m.PlayerAssists[playerID],
+			XP:             snapshot.XP,
+			BestKillStreak: player.GetBestKillStreak(),
+		}
+		if stats, ok := m.combatStats[playerID]; ok {
+			line.ShotsFired = stats.shotsFired
+			line.ShotsHit = stats.shotsHit
+			line.DamageDealt = stats.damageDealt
+			line.Accuracy = shotAccuracy(stats.shotsFired, stats.shotsHit)
+		}
+		lines = append(lines, line)
+	}
+
+	sort.Slice(lines, func(i, j int) bool {
+		if lines[i].Kills != lines[j].Kills {
+			return lines[i].Kills > lines[j].Kills
+		}
+		return lines[i].PlayerID < lines[j].PlayerID
+	})
+	return lines
+}
@@ -0,0 +1,69 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchStatsAggregatesEveryPlayer(t *testing.T) {
+	world := NewWorld()
+	alice := world.AddPlayer("player-1")
+	alice.DisplayName = "Alice"
+	alice.AddXP(KillXPReward + AssistXPReward)
+	alice.RecordKillStreak()
+	world.AddPlayer("player-2").IncrementDeaths()
+	world.AddPlayer("player-3")
+
+	match := NewMatch()
+	for _, id := range []string{"player-1", "player-2", "player-3", "player-gone"} {
+		match.RegisterPlayer(id)
+	}
+	match.RecordShots("player-1", 8)
+	match.RecordShotHit("player-1", "player-2")
+	match.RecordShotHit("player-1", "player-2")
+	match.RecordShotHit("player-1", "player-1")
+	match.RecordDamage("player-1", "player-2", 25)
+	match.RecordDamage("player-1", "player-2", 25)
+	match.RecordDamage("player-3", "player-2", 40)
+	match.RecordDamage("player-3", "player-3", 10)
+	match.RecordKill("player-1", "player-2")
+	match.RecordShots("player-3", 1)
+	match.RecordShotHit("player-3", "player-1")
+	match.RecordShotHit("player-3", "player-2")
+
+	stats := match.MatchStats(world)
+
+	require.Len(t, stats, 3, "players no longer in the world are left out")
+	assert.Equal(t, PlayerMatchStats{
+		PlayerID:       "player-1",
+		DisplayName:    "Alice",
+		Kills:          1,
+		XP:             KillXPReward + AssistXPReward,
+		ShotsFired:     8,
+		ShotsHit:       2,
+		Accuracy:       0.25,
+		DamageDealt:    50,
+		BestKillStreak: 1,
+	}, stats[0])
+	assert.Equal(t, PlayerMatchStats{PlayerID: "player-2", DisplayName: FallbackDisplayName, Deaths: 1}, stats[1])
+	assert.Equal(t, 1, stats[2].Assists)
+	assert.Equal(t, 40, stats[2].DamageDealt, "self-damage is not dealt")
+	assert.Equal(t, 1.0, stats[2].Accuracy, "splash hits never push accuracy past 1")
+}
+
+func TestMatchShotStats(t *testing.T) {
+	match := NewMatch()
+	match.RecordShots("player-1", 4)
+	match.RecordShotHit("player-1", "player-2")
+
+	fired, hit, accuracy := match.ShotStats("player-1")
+	assert.Equal(t, 4, fired)
+	assert.Equal(t, 1, hit)
+	assert.Equal(t, 0.25, accuracy)
+
+	fired, hit, accuracy = match.ShotStats("player-2")
+	assert.Zero(t, fired+hit)
+	assert.Zero(t, accuracy)
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestNewMatch tests match creation with proper configuration
@@ -59,20 +60,18 @@ func TestGetRemainingSeconds(t *testing.T) {
 		match := NewMatch()
 		match.Start()
 
-		// Manually set start time to 10 seconds ago
-		match.StartTime = time.Now().Add(-10 * time.Second)
+		match.AdvanceTicks(ticksForSeconds(10))
 
 		remaining := match.GetRemainingSeconds()
 
-		assert.InDelta(t, 410, remaining, 1) // Allow 1 second tolerance
+		assert.Equal(t, 410, remaining)
 	})
 
 	t.Run("returns 0 when time expired", func(t *testing.T) {
 		match := NewMatch()
 		match.Start()
 
-		// Set start time to 421 seconds ago (past time limit)
-		match.StartTime = time.Now().Add(-421 * time.Second)
+		match.AdvanceTicks(ticksForSeconds(421))
 
 		remaining := match.GetRemainingSeconds()
 
@@ -80,7 +79,80 @@ func TestGetRemainingSeconds(t *testing.T) {
 	})
 }
 
+// ticksForSeconds returns how many simulation ticks cover seconds of match time
+func ticksForSeconds(seconds int) int {
+	return (seconds*1000 + ServerTickInterval - 1) / ServerTickInterval
+}
+
+// TestMatchClockCountsSimulationTicks tests that match time only passes with
+// simulation ticks while the match is active
+func TestMatchClockCountsSimulationTicks(t *testing.T) {
+	t.Run("ignores ticks before the match starts", func(t *testing.T) {
+		match := NewMatch()
+
+		match.AdvanceTicks(ticksForSeconds(30))
+
+		assert.Zero(t, match.Elapsed())
+		assert.Equal(t, 420, match.GetRemainingSeconds())
+	})
+
+	t.Run("ignores wall-clock time without ticks", func(t *testing.T) {
+		match := NewMatch()
+		match.Start()
+		match.StartTime = time.Now().Add(-time.Hour)
+
+		assert.Equal(t, 420, match.GetRemainingSeconds())
+		assert.False(t, match.CheckTimeLimit())
+	})
+
+	t.Run("ignores ticks after the match ends", func(t *testing.T) {
+		match := NewMatch()
+		match.Start()
+		match.AdvanceTicks(ticksForSeconds(60))
+		match.EndMatch("kill_target")
+
+		match.AdvanceTicks(ticksForSeconds(60))
+
+		assert.Equal(t, 360, match.GetRemainingSeconds())
+	})
+
+	t.Run("converts ticks at the server tick interval", func(t *testing.T) {
+		match := NewMatch()
+		match.Start()
+
+		match.AdvanceTicks(3)
+
+		assert.Equal(t, 3*time.Duration(ServerTickInterval)*time.Millisecond, match.Elapsed())
+	})
+}
+
 // TestAddKill tests kill tracking and kill target checking
+func TestMatchClockPause(t *testing.T) {
+	t.Run("ticks do not count while paused", func(t *testing.T) {
+		match := NewMatch()
+		match.Start()
+		match.AdvanceTicks(ServerTickRate * 10)
+
+		assert.True(t, match.Pause())
+		assert.False(t, match.Pause(), "already paused")
+		assert.True(t, match.IsPaused())
+		match.AdvanceTicks(ServerTickRate * 5)
+		assert.InDelta(t, 410, match.GetRemainingSeconds(), 1, "a paused clock keeps the time at the pause")
+
+		assert.True(t, match.Resume())
+		assert.False(t, match.IsPaused())
+		match.AdvanceTicks(ServerTickRate * 5)
+		assert.InDelta(t, 405, match.GetRemainingSeconds(), 1)
+	})
+
+	t.Run("only an active match pauses", func(t *testing.T) {
+		match := NewMatch()
+
+		assert.False(t, match.Pause())
+		assert.False(t, match.Resume())
+	})
+}
+
 func TestAddKill(t *testing.T) {
 	t.Run("tracks kill for player", func(t *testing.T) {
 		match := NewMatch()
@@ -114,6 +186,85 @@ func TestAddKill(t *testing.T) {
 	})
 }
 
+// TestRecordKill tests kill and assist credit
+func TestRecordKill(t *testing.T) {
+	t.Run("credits recent attackers other than the killer with an assist", func(t *testing.T) {
+		match := NewMatch()
+		match.Start()
+		match.RecordDamage("player-3", "victim", 25)
+		match.RecordDamage("player-2", "victim", 10)
+		match.RecordDamage("player-2", "victim", 15)
+		match.RecordDamage("player-1", "victim", 50)
+
+		assists := match.RecordKill("player-1", "victim")
+
+		assert.Equal(t, []Assist{
+			{PlayerID: "player-2", Damage: 25, Assists: 1},
+			{PlayerID: "player-3", Damage: 25, Assists: 1},
+		}, assists)
+		assert.Equal(t, 1, match.PlayerKills["player-1"])
+		assert.Equal(t, 1, match.PlayerAssists["player-2"])
+		assert.Equal(t, 1, match.PlayerAssists["player-3"])
+		assert.Zero(t, match.PlayerAssists["player-1"], "the killer does not also get an assist")
+	})
+
+	t.Run("ignores damage older than the assist window", func(t *testing.T) {
+		match := NewMatch()
+		match.Start()
+		match.RecordDamage("player-2", "victim", 40)
+		match.RecordDamage("player-3", "victim", 20)
+		match.AdvanceTicks(ticksForSeconds(AssistWindowSeconds) + 1)
+		match.RecordDamage("player-3", "victim", 15)
+
+		assists := match.RecordKill("player-1", "victim")
+
+		assert.Equal(t, []Assist{{PlayerID: "player-3", Damage: 15, Assists: 1}}, assists, "only damage within the window counts")
+		assert.Zero(t, match.PlayerAssists["player-2"])
+	})
+
+	t.Run("ignores self-damage", func(t *testing.T) {
+		match := NewMatch()
+		match.RecordDamage("victim", "victim", 30)
+
+		assert.Empty(t, match.RecordKill("player-1", "victim"))
+	})
+
+	t.Run("clears the victim's damage after the kill", func(t *testing.T) {
+		match := NewMatch()
+		match.RecordDamage("player-2", "victim", 30)
+		match.RecordKill("player-1", "victim")
+
+		assert.Empty(t, match.RecordKill("player-1", "victim"), "damage before the victim's last death earns no assist")
+		assert.Equal(t, 2, match.PlayerKills["player-1"])
+		assert.Equal(t, 1, match.PlayerAssists["player-2"])
+	})
+}
+
+// TestTimeToKill tests the time from a killer's first hit to the kill
+func TestTimeToKill(t *testing.T) {
+	match := NewMatch()
+	match.Start()
+	tick := time.Duration(ServerTickInterval) * time.Millisecond
+	match.RecordDamage("player-1", "victim", 20)
+	match.AdvanceTicks(30)
+	match.RecordDamage("player-2", "victim", 20)
+	match.RecordDamage("player-1", "victim", 20)
+	match.AdvanceTicks(20)
+
+	ttk, ok := match.TimeToKill("player-1", "victim")
+	require.True(t, ok)
+	assert.Equal(t, 50*tick, ttk, "measured from the killer's first hit")
+	ttk, ok = match.TimeToKill("player-2", "victim")
+	require.True(t, ok)
+	assert.Equal(t, 20*tick, ttk)
+	_, ok = match.TimeToKill("player-3", "victim")
+	assert.False(t, ok, "a killer who never hit the victim has no time to kill")
+
+	match.RecordKill("player-1", "victim")
+	_, ok = match.TimeToKill("player-1", "victim")
+	assert.False(t, ok, "the victim's next life starts over")
+}
+
 // TestCheckKillTarget tests kill target win condition
 func TestCheckKillTarget(t *testing.T) {
 	t.Run("returns false when no player reached kill target", func(t *testing.T) {
@@ -174,8 +325,7 @@ func TestCheckTimeLimit(t *testing.T) {
 		match := NewMatch()
 		match.Start()
 
-		// Set start time to 421 seconds ago
-		match.StartTime = time.Now().Add(-421 * time.Second)
+		match.AdvanceTicks(ticksForSeconds(421))
 
 		expired := match.CheckTimeLimit()
 
@@ -186,8 +336,7 @@ func TestCheckTimeLimit(t *testing.T) {
 		match := NewMatch()
 		match.Start()
 
-		// Set start time to exactly 420 seconds ago
-		match.StartTime = time.Now().Add(-420 * time.Second)
+		match.AdvanceTicks(ticksForSeconds(420))
 
 		expired := match.CheckTimeLimit()
 
@@ -348,6 +497,37 @@ func TestDetermineWinners(t *testing.T) {
 }
 
 // TestGetFinalScores tests collecting final scores from match
+// TestScoreboard tests the running standings sent to reconnecting players
+func TestScoreboard(t *testing.T) {
+	world := NewWorld()
+	alice := world.AddPlayer("player-1")
+	alice.SetDisplayName("Alice")
+	alice.AddXP(100)
+	bob := world.AddPlayer("player-2")
+	bob.IncrementDeaths()
+	world.AddPlayer("player-3")
+
+	match := NewMatch()
+	match.RegisterPlayer("player-1")
+	match.RegisterPlayer("player-2")
+	match.RegisterPlayer("player-3")
+	match.RegisterPlayer("player-gone")
+	match.RecordDamage("player-3", "player-2", 30)
+	match.RecordKill("player-1", "player-2")
+	match.RecordKill("player-gone", "player-3")
+	match.RecordKill("player-gone", "player-3")
+
+	scoreboard := match.Scoreboard(world)
+
+	assert.Equal(t, []ScoreboardEntry{
+		{PlayerID: "player-1", DisplayName: "Alice", Kills: 1, XP: 100},
+		{PlayerID: "player-2", DisplayName: FallbackDisplayName, Deaths: 1},
+		{PlayerID: "player-3", DisplayName: FallbackDisplayName, Assists: 1},
+	}, scoreboard, "players no longer in the world are left out")
+	assert.Equal(t, map[string]int{"player-1": 1, "player-2": 0, "player-3": 0, "player-gone": 2}, match.KillMap(),
+		"the kill map keeps players who have left")
+}
+
 func TestGetFinalScores(t *testing.T) {
 	t.Run("collects scores for all players", func(t *testing.T) {
 		// Create a world with players
@@ -0,0 +1,345 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MatchmakingEventKind names a step of the matchmaking funnel.
+type MatchmakingEventKind string
+
+const (
+	MatchmakingEventQueued        MatchmakingEventKind = "queued"         // A player entered matchmaking
+	MatchmakingEventMatched       MatchmakingEventKind = "matched"        // A queued player's match started
+	MatchmakingEventAbandoned     MatchmakingEventKind = "abandoned"      // A queued player left before its match started
+	MatchmakingEventMatchStarted  MatchmakingEventKind = "match_started"  // A room's match started, possibly with bots
+	MatchmakingEventMatchEnded    MatchmakingEventKind = "match_ended"    // A match ended and its players were offered a rematch
+	MatchmakingEventRematchVote   MatchmakingEventKind = "rematch_vote"   // A player voted to stay or for a rematch, or withdrew its vote
+	MatchmakingEventRematchFormed MatchmakingEventKind = "rematch_formed" // An ended match's players moved on together or restarted it
+)
+
+// TimeToMatchBuckets are the upper bounds of the time-to-match histogram; a
+// final bucket counts every wait past the last bound.
+var TimeToMatchBuckets = []time.Duration{
+	5 * time.Second,
+	10 * time.Second,
+	20 * time.Second,
+	30 * time.Second,
+	60 * time.Second,
+	120 * time.Second,
+}
+
+// MatchmakingEvent is one structured step of the funnel, logged as key=value
+// pairs so it can be grepped or shipped to a log pipeline.
+type MatchmakingEvent struct {
+	Kind     MatchmakingEventKind
+	PlayerID string
+	RoomID   string
+	Wait     time.Duration // Time spent queued, for matched and abandoned
+	Players  int           // Roster size, for match_started, match_ended and rematch_formed
+	Bots     int           // Bots added to start the match, for match_started
+	Stay     bool          // The vote, for rematch_vote
+}
+
+// String formats the event as key=value pairs, omitting fields the kind does
+// not use.
+func (e MatchmakingEvent) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "event=%s", e.Kind)
+	if e.PlayerID != "" {
+		fmt.Fprintf(&b, " player=%s", e.PlayerID)
+	}
+	if e.RoomID != "" {
+		fmt.Fprintf(&b, " room=%s", e.RoomID)
+	}
+	switch e.Kind {
+	case MatchmakingEventMatched, MatchmakingEventAbandoned:
+		fmt.Fprintf(&b, " wait_ms=%d", e.Wait.Milliseconds())
+	case MatchmakingEventMatchStarted:
+		fmt.Fprintf(&b, " players=%d bots=%d", e.Players, e.Bots)
+	case MatchmakingEventMatchEnded, MatchmakingEventRematchFormed:
+		fmt.Fprintf(&b, " players=%d", e.Players)
+	case MatchmakingEventRematchVote:
+		fmt.Fprintf(&b, " stay=%t", e.Stay)
+	}
+	return b.String()
+}
+
+// TimeToMatchBucket is one histogram bucket. UpperBoundMs is zero for the
+// final, unbounded bucket.
+type TimeToMatchBucket struct {
+	UpperBoundMs int64 `json:"upperBoundMs"`
+	Count        int   `json:"count"`
+}
+
+// TimeToMatchStats is the distribution of how long matched players waited.
+type TimeToMatchStats struct {
+	Count   int                 `json:"count"`
+	MeanMs  int64               `json:"meanMs"`
+	MaxMs   int64               `json:"maxMs"`
+	Buckets []TimeToMatchBucket `json:"buckets"`
+}
+
+// MatchmakingStats is a point-in-time view of the matchmaking funnel since
+// the server started. Rates are zero until their denominator is non-zero.
+type MatchmakingStats struct {
+	Queued                int              `json:"queued"`
+	Waiting               int              `json:"waiting"`
+	Matched               int              `json:"matched"`
+	Abandoned             int              `json:"abandoned"`
+	AbandonmentRate       float64          `json:"abandonmentRate"`
+	TimeToMatch           TimeToMatchStats `json:"timeToMatch"`
+	MatchesStarted        int              `json:"matchesStarted"`
+	BotFilledMatches      int              `json:"botFilledMatches"`
+	BotsAdded             int              `json:"botsAdded"`
+	BotFillRate           float64          `json:"botFillRate"`
+	RematchOffers         int              `json:"rematchOffers"`
+	RematchAccepts        int              `json:"rematchAccepts"`
+	RematchesFormed       int              `json:"rematchesFormed"`
+	RematchAcceptanceRate float64          `json:"rematchAcceptanceRate"`
+}
+
+// MatchmakingMetrics counts players through the matchmaking funnel: queued,
+// then either matched (with their wait recorded) or abandoned. It also counts
+// matches that needed bots to start and how often ended matches' players
+// voted to stay together. Every step is logged as a MatchmakingEvent.
+type MatchmakingMetrics struct {
+	waiting         map[string]time.Time // playerID -> when it entered matchmaking
+	roomBots        map[string]int       // roomID -> bots added to start its match
+	matched         int
+	abandoned       int
+	waitTotal       time.Duration
+	waitMax         time.Duration
+	waitBuckets     []int
+	recentMatches   []time.Time // When queued players were matched, within QueueFillRateWindow
+	matchesStarted  int
+	botFilled       int
+	botsAdded       int
+	rematchOffers   int
+	rematchAccepts  int
+	rematchesFormed int
+	mu              sync.Mutex
+}
+
+func NewMatchmakingMetrics() *MatchmakingMetrics {
+	return &MatchmakingMetrics{
+		waiting:     make(map[string]time.Time),
+		roomBots:    make(map[string]int),
+		waitBuckets: make([]int, len(TimeToMatchBuckets)+1),
+	}
+}
+
+// Queued starts timing a player's wait. Entering again restarts the wait.
+func (m *MatchmakingMetrics) Queued(playerID string, at time.Time) {
+	m.mu.Lock()
+	m.waiting[playerID] = at
+	m.mu.Unlock()
+
+	m.emit(MatchmakingEvent{Kind: MatchmakingEventQueued, PlayerID: playerID})
+}
+
+// Matched records the wait of a queued player whose match started. Players
+// who never queued, such as bots or a party that stayed together, are ignored.
+func (m *MatchmakingMetrics) Matched(playerID, roomID string, at time.Time) {
+	m.mu.Lock()
+	queuedAt, waiting := m.waiting[playerID]
+	if !waiting {
+		m.mu.Unlock()
+		return
+	}
+	delete(m.waiting, playerID)
+
+	wait := at.Sub(queuedAt)
+	if wait < 0 {
+		wait = 0
+	}
+	m.matched++
+	m.waitTotal += wait
+	if wait > m.waitMax {
+		m.waitMax = wait
+	}
+	bucket := len(TimeToMatchBuckets)
+	for i, bound := range TimeToMatchBuckets {
+		if wait <= bound {
+			bucket = i
+			break
+		}
+	}
+	m.waitBuckets[bucket]++
+	m.pruneRecentMatchesLocked(at)
+	m.recentMatches = append(m.recentMatches, at)
+	m.mu.Unlock()
+
+	m.emit(MatchmakingEvent{Kind: MatchmakingEventMatched, PlayerID: playerID, RoomID: roomID, Wait: wait})
+}
+
+// Abandoned records a queued player leaving before its match started.
+func (m *MatchmakingMetrics) Abandoned(playerID string, at time.Time) {
+	m.mu.Lock()
+	queuedAt, waiting := m.waiting[playerID]
+	if !waiting {
+		m.mu.Unlock()
+		return
+	}
+	delete(m.waiting, playerID)
+	m.abandoned++
+	m.mu.Unlock()
+
+	m.emit(MatchmakingEvent{Kind: MatchmakingEventAbandoned, PlayerID: playerID, Wait: at.Sub(queuedAt)})
+}
+
+// MatchStarted counts a started match and the bots added to start it.
+func (m *MatchmakingMetrics) MatchStarted(roomID string, players, bots int) {
+	m.mu.Lock()
+	m.matchesStarted++
+	if bots > 0 {
+		m.botFilled++
+		m.botsAdded += bots
+		m.roomBots[roomID] = bots
+	}
+	m.mu.Unlock()
+
+	m.emit(MatchmakingEvent{Kind: MatchmakingEventMatchStarted, RoomID: roomID, Players: players, Bots: bots})
+}
+
+// MatchEnded offers every human still in an ended match's room a rematch.
+func (m *MatchmakingMetrics) MatchEnded(roomID string, players int) {
+	m.mu.Lock()
+	humans := players - m.roomBots[roomID]
+	delete(m.roomBots, roomID)
+	if humans < 0 {
+		humans = 0
+	}
+	m.rematchOffers += humans
+	m.mu.Unlock()
+
+	m.emit(MatchmakingEvent{Kind: MatchmakingEventMatchEnded, RoomID: roomID, Players: humans})
+}
+
+// RematchVote counts a player voting to stay together, and uncounts a vote
+// withdrawn before the group moved on. Repeating a vote changes nothing.
+func (m *MatchmakingMetrics) RematchVote(playerID, roomID string, wasStaying, stay bool) {
+	if wasStaying == stay {
+		return
+	}
+
+	m.mu.Lock()
+	if stay {
+		m.rematchAccepts++
+	} else {
+		m.rematchAccepts--
+	}
+	m.mu.Unlock()
+
+	m.emit(MatchmakingEvent{Kind: MatchmakingEventRematchVote, PlayerID: playerID, RoomID: roomID, Stay: stay})
+}
+
+// RematchFormed counts an ended match's players moving into a new room
+// together.
+func (m *MatchmakingMetrics) RematchFormed(roomID string, players int) {
+	m.mu.Lock()
+	m.rematchesFormed++
+	m.mu.Unlock()
+
+	m.emit(MatchmakingEvent{Kind: MatchmakingEventRematchFormed, RoomID: roomID, Players: players})
+}
+
+// Stats returns the funnel counters and the rates derived from them.
+func (m *MatchmakingMetrics) Stats() MatchmakingStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := MatchmakingStats{
+		Queued:           m.matched + m.abandoned + len(m.waiting),
+		Waiting:          len(m.waiting),
+		Matched:          m.matched,
+		Abandoned:        m.abandoned,
+		MatchesStarted:   m.matchesStarted,
+		BotFilledMatches: m.botFilled,
+		BotsAdded:        m.botsAdded,
+		RematchOffers:    m.rematchOffers,
+		RematchAccepts:   m.rematchAccepts,
+		RematchesFormed:  m.rematchesFormed,
+		TimeToMatch: TimeToMatchStats{
+			Count:   m.matched,
+			MaxMs:   m.waitMax.Milliseconds(),
+			Buckets: make([]TimeToMatchBucket, 0, len(m.waitBuckets)),
+		},
+	}
+	for i, count := range m.waitBuckets {
+		bucket := TimeToMatchBucket{Count: count}
+		if i < len(TimeToMatchBuckets) {
+			bucket.UpperBoundMs = TimeToMatchBuckets[i].Milliseconds()
+		}
+		stats.TimeToMatch.Buckets = append(stats.TimeToMatch.Buckets, bucket)
+	}
+	if m.matched > 0 {
+		stats.TimeToMatch.MeanMs = (m.waitTotal / time.Duration(m.matched)).Milliseconds()
+	}
+	if finished := m.matched + m.abandoned; finished > 0 {
+		stats.AbandonmentRate = float64(m.abandoned) / float64(finished)
+	}
+	if m.matchesStarted > 0 {
+		stats.BotFillRate = float64(m.botFilled) / float64(m.matchesStarted)
+	}
+	if m.rematchOffers > 0 {
+		stats.RematchAcceptanceRate = float64(m.rematchAccepts) / float64(m.rematchOffers)
+	}
+	return stats
+}
+
+// FillRate returns how many queued players per second were matched over the
+// last QueueFillRateWindow.
+func (m *MatchmakingMetrics) FillRate(now time.Time) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.pruneRecentMatchesLocked(now)
+	return float64(len(m.recentMatches)) / QueueFillRateWindow.Seconds()
+}
+
+func (m *MatchmakingMetrics) pruneRecentMatchesLocked(now time.Time) {
+	kept := m.recentMatches[:0]
+	for _, at := range m.recentMatches {
+		if now.Sub(at) < QueueFillRateWindow {
+			kept = append(kept, at)
+		}
+	}
+	m.recentMatches = kept
+}
+
+func (m *MatchmakingMetrics) emit(event MatchmakingEvent) {
+	log.Printf("matchmaking %s", event)
+}
+
+// MatchmakingStats returns the matchmaking funnel counters.
+func (rm *RoomManager) MatchmakingStats() MatchmakingStats {
+	return rm.metrics.Stats()
+}
+
+// RecordMatchEnded offers the players still in a room whose match just ended
+// a rematch: it counts the offer for the rematch acceptance rate and opens
+// the room's rematch vote.
+func (rm *RoomManager) RecordMatchEnded(room *Room) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.metrics.MatchEnded(room.ID, room.PlayerCount())
+	rm.beginRematchVoteLocked(room)
+}
+
+// startMatchLocked starts a room's match and records every queued player in
+// it as matched. bots is how many bots were added to start it. It is called
+// with rm.mu held.
+func (rm *RoomManager) startMatchLocked(room *Room, bots int) {
+	room.Match.Start()
+
+	now := time.Now()
+	for _, player := range room.GetPlayers() {
+		rm.metrics.Matched(player.ID, room.ID, now)
+	}
+	rm.metrics.MatchStarted(room.ID, room.PlayerCount(), bots)
+}
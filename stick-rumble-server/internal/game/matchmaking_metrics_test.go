@@ -0,0 +1,122 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchmakingMetricsTimeToMatch(t *testing.T) {
+	metrics := NewMatchmakingMetrics()
+	start := time.Now()
+
+	metrics.Queued("quick", start)
+	metrics.Queued("slow", start)
+	metrics.Queued("patient", start)
+	metrics.Matched("quick", "room-1", start.Add(3*time.Second))
+	metrics.Matched("slow", "room-1", start.Add(25*time.Second))
+	metrics.Matched("patient", "room-2", start.Add(5*time.Minute))
+	metrics.Matched("bot", "room-2", start.Add(5*time.Minute))
+
+	stats := metrics.Stats()
+	assert.Equal(t, 3, stats.Matched, "players who never queued are not counted")
+	assert.Equal(t, 0, stats.Waiting)
+	assert.Equal(t, 3, stats.TimeToMatch.Count)
+	assert.Equal(t, (5 * time.Minute).Milliseconds(), stats.TimeToMatch.MaxMs)
+	assert.Equal(t, ((3*time.Second + 25*time.Second + 5*time.Minute) / 3).Milliseconds(), stats.TimeToMatch.MeanMs)
+
+	require.Len(t, stats.TimeToMatch.Buckets, len(TimeToMatchBuckets)+1)
+	counts := make(map[int64]int)
+	for _, bucket := range stats.TimeToMatch.Buckets {
+		counts[bucket.UpperBoundMs] = bucket.Count
+	}
+	assert.Equal(t, 1, counts[5000])
+	assert.Equal(t, 1, counts[30000])
+	assert.Equal(t, 1, counts[0], "waits past the last bound land in the open bucket")
+}
+
+func TestMatchmakingMetricsAbandonment(t *testing.T) {
+	metrics := NewMatchmakingMetrics()
+	now := time.Now()
+
+	metrics.Queued("stays", now)
+	metrics.Queued("leaves", now)
+	metrics.Queued("waiting", now)
+	metrics.Matched("stays", "room-1", now)
+	metrics.Abandoned("leaves", now.Add(time.Second))
+	metrics.Abandoned("stays", now.Add(time.Second))
+
+	stats := metrics.Stats()
+	assert.Equal(t, 3, stats.Queued)
+	assert.Equal(t, 1, stats.Waiting)
+	assert.Equal(t, 1, stats.Abandoned, "leaving after the match started is not abandonment")
+	assert.InDelta(t, 0.5, stats.AbandonmentRate, 1e-9)
+}
+
+func TestMatchmakingMetricsBotFillAndRematches(t *testing.T) {
+	metrics := NewMatchmakingMetrics()
+
+	metrics.MatchStarted("humans", 4, 0)
+	metrics.MatchStarted("filled", 4, 3)
+	metrics.MatchEnded("humans", 4)
+	metrics.MatchEnded("filled", 4)
+
+	metrics.RematchVote("a", "humans", false, true)
+	metrics.RematchVote("a", "humans", true, true)
+	metrics.RematchVote("b", "humans", false, true)
+	metrics.RematchVote("b", "humans", true, false)
+	metrics.RematchVote("c", "filled", false, true)
+	metrics.RematchFormed("party", 2)
+
+	stats := metrics.Stats()
+	assert.Equal(t, 2, stats.MatchesStarted)
+	assert.Equal(t, 1, stats.BotFilledMatches)
+	assert.Equal(t, 3, stats.BotsAdded)
+	assert.InDelta(t, 0.5, stats.BotFillRate, 1e-9)
+	assert.Equal(t, 5, stats.RematchOffers, "bots are not offered a rematch")
+	assert.Equal(t, 2, stats.RematchAccepts, "repeated votes count once and withdrawn votes not at all")
+	assert.Equal(t, 1, stats.RematchesFormed)
+	assert.InDelta(t, 0.4, stats.RematchAcceptanceRate, 1e-9)
+}
+
+func TestMatchmakingEventString(t *testing.T) {
+	assert.Equal(t, "event=matched player=p1 room=r1 wait_ms=1500",
+		MatchmakingEvent{Kind: MatchmakingEventMatched, PlayerID: "p1", RoomID: "r1", Wait: 1500 * time.Millisecond}.String())
+	assert.Equal(t, "event=match_started room=r1 players=4 bots=2",
+		MatchmakingEvent{Kind: MatchmakingEventMatchStarted, RoomID: "r1", Players: 4, Bots: 2}.String())
+}
+
+func TestRoomManagerRecordsMatchmakingFunnel(t *testing.T) {
+	manager := NewRoomManager()
+	manager.SetReadyCheckConfig(ReadyCheckConfig{})
+	manager.SetRoomSettings(RoomSettings{MinHumanPlayers: 2, BotFillAfter: 30 * time.Second, BotFillTarget: 4})
+	manager.SetBotFiller(&stubBotFiller{})
+	flow := manager.SessionFlow()
+
+	flow.HandleHello(newSessionFlowPlayer("one"), map[string]any{"mode": "public"})
+	flow.HandleHello(newSessionFlowPlayer("two"), map[string]any{"mode": "public"})
+	flow.HandleHello(newSessionFlowPlayer("quitter"), map[string]any{"mode": "public"})
+	manager.LeaveSession("quitter")
+	flow.HandleHello(newSessionFlowPlayer("lonely"), map[string]any{"mode": "public"})
+	require.Len(t, flow.FillStalledRooms(time.Now().Add(time.Minute)), 1)
+
+	stats := manager.MatchmakingStats()
+	assert.Equal(t, 4, stats.Queued)
+	assert.Equal(t, 3, stats.Matched)
+	assert.Equal(t, 1, stats.Abandoned)
+	assert.Equal(t, 2, stats.MatchesStarted)
+	assert.Equal(t, 1, stats.BotFilledMatches)
+	assert.Equal(t, 3, stats.BotsAdded)
+
+	room := manager.GetRoomByPlayerID("one")
+	room.Match.EndMatch("test")
+	manager.RecordMatchEnded(room)
+	_, ok := flow.StayTogether("one", true)
+	require.True(t, ok)
+
+	stats = manager.MatchmakingStats()
+	assert.Equal(t, 2, stats.RematchOffers)
+	assert.Equal(t, 1, stats.RematchAccepts)
+}
@@ -7,12 +7,20 @@ import (
 // MeleeAttackResult represents the result of a melee attack
 type MeleeAttackResult struct {
 	HitPlayers       []*PlayerState // Players that were hit
+	Damages          []int          // Damage dealt to each hit player
 	KnockbackApplied bool           // Whether knockback was applied
+	Rejection        *HitRejection  // Why nobody was hit, when a target was nearly in reach
 }
 
 // PerformMeleeAttack executes a melee attack from an attacker
 // Returns a result containing all players hit and whether knockback was applied
 func PerformMeleeAttack(attacker *PlayerState, allPlayers []*PlayerState, weapon *Weapon, mapConfigs ...MapConfig) *MeleeAttackResult {
+	return PerformMeleeAttackWithDamage(attacker, allPlayers, weapon, nil, mapConfigs...)
+}
+
+// PerformMeleeAttackWithDamage is PerformMeleeAttack with damageFor deciding
+// the damage dealt to each target; nil deals the weapon's base damage
+func PerformMeleeAttackWithDamage(attacker *PlayerState, allPlayers []*PlayerState, weapon *Weapon, damageFor func(target *PlayerState) int, mapConfigs ...MapConfig) *MeleeAttackResult {
 	if weapon == nil || !weapon.IsMelee() {
 		return &MeleeAttackResult{
 			HitPlayers:       []*PlayerState{},
@@ -22,10 +30,14 @@ func PerformMeleeAttack(attacker *PlayerState, allPlayers []*PlayerState, weapon
 
 	result := &MeleeAttackResult{
 		HitPlayers:       make([]*PlayerState, 0),
+		Damages:          make([]int, 0),
 		KnockbackApplied: false,
 	}
 
 	mapConfig := resolveMapConfig(mapConfigs...)
+	nearMiss := *weapon
+	nearMiss.Range += meleeNearMissSlack
+	var rejection *HitRejection
 
 	// Check each potential target
 	for _, target := range allPlayers {
@@ -41,19 +53,33 @@ func PerformMeleeAttack(attacker *PlayerState, allPlayers []*PlayerState, weapon
 
 		// Check if target is within range and arc
 		if isInMeleeRange(attacker, target, weapon) && hasMeleeReach(attacker, target, weapon, mapConfig) {
+			if target.IsDamageImmune() {
+				rejection = &HitRejection{Code: HitRejectedInvulnerable, VictimID: target.ID}
+				continue
+			}
 			result.HitPlayers = append(result.HitPlayers, target)
 
 			// Apply damage using thread-safe method
-			target.TakeDamage(weapon.Damage)
+			damage := weapon.Damage
+			if damageFor != nil {
+				damage = damageFor(target)
+			}
+			target.TakeDamage(damage)
+			result.Damages = append(result.Damages, damage)
 
 			// Apply knockback if weapon has it (Bat only)
 			if weapon.KnockbackDistance > 0 {
 				applyKnockback(attacker, target, weapon.KnockbackDistance, mapConfig)
 				result.KnockbackApplied = true
 			}
+		} else if rejection == nil && isInMeleeRange(attacker, target, &nearMiss) && hasMeleeReach(attacker, target, &nearMiss, mapConfig) {
+			rejection = &HitRejection{Code: HitRejectedOutOfRange, VictimID: target.ID}
 		}
 	}
 
+	if len(result.HitPlayers) == 0 {
+		result.Rejection = rejection
+	}
 	return result
 }
 
@@ -158,14 +184,18 @@ func meleePointWithinRangeAndArc(attackerPos, targetPoint Vector2, aimAngle floa
 // applyKnockback applies knockback to a target based on direction from attacker
 // Knockback velocity is 200 px/s for 0.2s = 40px total displacement
 func applyKnockback(attacker *PlayerState, target *PlayerState, knockbackDistance float64, mapConfigs ...MapConfig) {
-	// Get positions (thread-safe)
-	attackerPos := attacker.GetPosition()
+	pushPlayer(target, attacker.GetPosition(), knockbackDistance, resolveMapConfig(mapConfigs...))
+}
+
+// pushPlayer moves target knockbackDistance pixels directly away from origin,
+// stopping at the arena edge and the first wall in the way
+func pushPlayer(target *PlayerState, origin Vector2, knockbackDistance float64, mapConfig MapConfig) {
+	// Get position (thread-safe)
 	targetPos := target.GetPosition()
-	mapConfig := resolveMapConfig(mapConfigs...)
 
-	// Calculate direction from attacker to target
-	dx := targetPos.X - attackerPos.X
-	dy := targetPos.Y - attackerPos.Y
+	// Calculate direction from origin to target
+	dx := targetPos.X - origin.X
+	dy := targetPos.Y - origin.Y
 	distance := math.Sqrt(dx*dx + dy*dy)
 
 	if distance == 0 {
@@ -0,0 +1,40 @@
+package game
+
+import (
+	"math"
+	"time"
+)
+
+// MeleeComboHits is how many landed swings make a melee combo
+const MeleeComboHits = 2
+
+// meleeComboStep returns which hit of its weapon's combo a swing made now
+// would land as: the finishing hit when the previous swing landed within the
+// combo window, the opening hit otherwise
+func (ws *WeaponState) meleeComboStep() int {
+	if ws.Weapon.ComboWindow <= 0 || ws.comboHitAt.IsZero() {
+		return 1
+	}
+	if ws.clock.Since(ws.comboHitAt) > ws.Weapon.ComboWindow {
+		return 1
+	}
+	return MeleeComboHits
+}
+
+// recordMeleeSwing opens a combo when an opening hit lands and closes it
+// after a miss or the finishing hit
+func (ws *WeaponState) recordMeleeSwing(step int, landed bool) {
+	if landed && step < MeleeComboHits {
+		ws.comboHitAt = ws.clock.Now()
+		return
+	}
+	ws.comboHitAt = time.Time{}
+}
+
+// comboDamage is the damage a swing deals as the given hit of a combo
+func (w *Weapon) comboDamage(step int) int {
+	if step < MeleeComboHits || w.ComboMultiplier <= 0 {
+		return w.Damage
+	}
+	return int(math.Round(float64(w.Damage) * w.ComboMultiplier))
+}
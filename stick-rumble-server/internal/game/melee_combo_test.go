@@ -0,0 +1,73 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newComboDuel sets up a fists fighter facing a target within reach
+func newComboDuel(t *testing.T) (*GameServer, *ManualClock, *PlayerState) {
+	t.Helper()
+	clock := NewManualClock(time.Now())
+	gs := NewGameServerWithClock(nil, clock)
+	setGameServerOpenMap(gs)
+	fighter := gs.AddPlayer("fighter")
+	target := gs.AddPlayer("target")
+	gs.SetWeaponState("fighter", NewWeaponStateWithClock(NewFists(), clock))
+	fighter.SetPosition(Vector2{X: 100, Y: 100})
+	target.SetPosition(Vector2{X: 130, Y: 100})
+	target.Health = 1000
+	return gs, clock, target
+}
+
+func TestMeleeComboFinishesWithinWindow(t *testing.T) {
+	gs, clock, target := newComboDuel(t)
+	fists := NewFists()
+	swing := time.Duration(float64(time.Second) / fists.FireRate)
+
+	result := gs.PlayerMeleeAttack("fighter", 0)
+	require.True(t, result.Success)
+	assert.Equal(t, 1, result.Combo)
+	assert.Equal(t, []int{fists.Damage}, result.Damages)
+
+	clock.Advance(swing)
+	result = gs.PlayerMeleeAttack("fighter", 0)
+	require.True(t, result.Success)
+	assert.Equal(t, MeleeComboHits, result.Combo)
+	assert.Equal(t, []int{fists.Damage * 2}, result.Damages, "the finishing hit deals the combo multiplier")
+
+	// The combo closed, so the next hit opens a new one
+	clock.Advance(swing)
+	result = gs.PlayerMeleeAttack("fighter", 0)
+	assert.Equal(t, 1, result.Combo)
+	assert.Equal(t, 1000-fists.Damage*4, target.Health)
+}
+
+func TestMeleeComboBreaksOnMissOrTimeout(t *testing.T) {
+	gs, clock, target := newComboDuel(t)
+	fists := NewFists()
+
+	require.Equal(t, 1, gs.PlayerMeleeAttack("fighter", 0).Combo)
+	clock.Advance(fists.ComboWindow + time.Millisecond)
+	assert.Equal(t, 1, gs.PlayerMeleeAttack("fighter", 0).Combo, "too late to finish the combo")
+
+	// A whiff closes the open combo
+	clock.Advance(time.Second / 2)
+	target.SetPosition(Vector2{X: 400, Y: 100})
+	miss := gs.PlayerMeleeAttack("fighter", 0)
+	require.True(t, miss.Success)
+	assert.Zero(t, miss.Combo)
+	target.SetPosition(Vector2{X: 130, Y: 100})
+	clock.Advance(time.Second / 2)
+	assert.Equal(t, 1, gs.PlayerMeleeAttack("fighter", 0).Combo)
+}
+
+func TestWeaponComboDamage(t *testing.T) {
+	bat := NewBat()
+	assert.Equal(t, bat.Damage, bat.comboDamage(1))
+	assert.Equal(t, 38, bat.comboDamage(MeleeComboHits), "25 * 1.5 rounds to 38")
+	assert.Equal(t, 25, NewPistol().comboDamage(MeleeComboHits), "weapons without a combo deal their damage")
+}
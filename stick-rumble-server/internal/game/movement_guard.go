@@ -0,0 +1,216 @@
+package game
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Movement violation kinds reported by the movement guard
+const (
+	MovementViolationImpossibleDelta = "impossible_delta"
+	MovementViolationTeleport        = "teleport"
+	MovementViolationAimFlick        = "aim_flick"
+	MovementViolationAimClamp        = "aim_clamp"
+)
+
+const (
+	// DefaultMovementSpeedTolerance scales the fastest legal speed before a
+	// tick's displacement counts as impossible
+	DefaultMovementSpeedTolerance = 1.5
+
+	// DefaultMovementAllowance is extra distance allowed per tick for moves the
+	// server applies outside physics (bat knockback pushes 40px at once)
+	DefaultMovementAllowance = 48.0
+
+	// DefaultTeleportDistance is the single-tick jump reported as a teleport
+	// rather than an impossible delta
+	DefaultTeleportDistance = 200.0
+
+	// DefaultMaxAimRate is the fastest aim turn in radians per second; a half
+	// turn inside about 20ms trips it, a fast human flick does not
+	DefaultMaxAimRate = 150.0
+
+	// DefaultViolationWindow is how long a violation counts toward KickAfter
+	DefaultViolationWindow = 10 * time.Second
+
+	// minAimSampleInterval floors the time between aim samples so inputs that
+	// arrive bunched together are measured at the client's 60Hz send rate
+	minAimSampleInterval = time.Second / 60
+)
+
+// MovementGuardConfig holds the thresholds the movement guard enforces. Zero
+// values use the defaults above; KickAfter 0 flags violators without kicking.
+type MovementGuardConfig struct {
+	SpeedTolerance   float64
+	Allowance        float64
+	TeleportDistance float64
+	MaxAimRate       float64 // Radians per second
+	KickAfter        int     // Violations within Window before the player is kicked
+	Window           time.Duration
+}
+
+func (c MovementGuardConfig) withDefaults() MovementGuardConfig {
+	if c.SpeedTolerance <= 0 {
+		c.SpeedTolerance = DefaultMovementSpeedTolerance
+	}
+	if c.Allowance <= 0 {
+		c.Allowance = DefaultMovementAllowance
+	}
+	if c.TeleportDistance <= 0 {
+		c.TeleportDistance = DefaultTeleportDistance
+	}
+	if c.MaxAimRate <= 0 {
+		c.MaxAimRate = DefaultMaxAimRate
+	}
+	if c.KickAfter < 0 {
+		c.KickAfter = 0
+	}
+	if c.Window <= 0 {
+		c.Window = DefaultViolationWindow
+	}
+	return c
+}
+
+// movementTrack is what the guard remembers about one player
+type movementTrack struct {
+	position   Vector2
+	hasSample  bool
+	alive      bool
+	aimAngle   float64
+	aimAt      time.Time
+	hasAim     bool
+	violations []time.Time
+	flagged    bool
+}
+
+// MovementGuard checks that players move and aim within physics limits. The
+// simulation is server-authoritative, so a violation means something moved a
+// player that should not have (a bug or a tampered client path); the guard
+// flags the player and, when configured, asks for a kick.
+type MovementGuard struct {
+	config MovementGuardConfig
+	tracks map[string]*movementTrack
+	mu     sync.Mutex
+}
+
+func NewMovementGuard(config MovementGuardConfig) *MovementGuard {
+	return &MovementGuard{
+		config: config.withDefaults(),
+		tracks: make(map[string]*movementTrack),
+	}
+}
+
+func (g *MovementGuard) track(playerID string) *movementTrack {
+	track, ok := g.tracks[playerID]
+	if !ok {
+		track = &movementTrack{}
+		g.tracks[playerID] = track
+	}
+	return track
+}
+
+// CheckPosition compares a player's position after a tick with the last one.
+// Respawns re-baseline instead of counting: the first alive sample after a
+// dead one is trusted.
+func (g *MovementGuard) CheckPosition(playerID string, position Vector2, alive bool, deltaTime float64, now time.Time) *MovementViolationEvent {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	track := g.track(playerID)
+	previous, checked := track.position, track.hasSample && track.alive && alive
+	track.position, track.hasSample, track.alive = position, true, alive
+	if !checked {
+		return nil
+	}
+
+	moved := calculateDistance(previous, position)
+	limit := math.Max(SprintSpeed*maxClassSpeedMultiplier(), DodgeRollVelocity)*deltaTime*g.config.SpeedTolerance + g.config.Allowance
+	if moved <= limit {
+		return nil
+	}
+
+	kind := MovementViolationImpossibleDelta
+	if moved > g.config.TeleportDistance {
+		kind = MovementViolationTeleport
+	}
+	return g.record(playerID, track, kind, moved, limit, now)
+}
+
+// CheckAim compares a player's new aim angle with the last one
+func (g *MovementGuard) CheckAim(playerID string, aimAngle float64, now time.Time) *MovementViolationEvent {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	track := g.track(playerID)
+	previous, previousAt, checked := track.aimAngle, track.aimAt, track.hasAim
+	track.aimAngle, track.aimAt, track.hasAim = aimAngle, now, true
+	if !checked {
+		return nil
+	}
+
+	turned := math.Mod(math.Abs(aimAngle-previous), 2*math.Pi)
+	if turned > math.Pi {
+		turned = 2*math.Pi - turned
+	}
+	elapsed := now.Sub(previousAt)
+	if elapsed < minAimSampleInterval {
+		elapsed = minAimSampleInterval
+	}
+	rate := turned / elapsed.Seconds()
+	if rate <= g.config.MaxAimRate {
+		return nil
+	}
+	return g.record(playerID, track, MovementViolationAimFlick, rate, g.config.MaxAimRate, now)
+}
+
+// RecordAimClamping reports a player whose aim the aim limiter clamped
+// chronically: clamps times within its window, reaching the chronic threshold
+func (g *MovementGuard) RecordAimClamping(playerID string, clamps, threshold int, now time.Time) *MovementViolationEvent {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	// The limit is the most clamps tolerated, so the event reads as exceeding it
+	return g.record(playerID, g.track(playerID), MovementViolationAimClamp, float64(clamps), float64(threshold-1), now)
+}
+
+// record flags the player and counts the violation within the window
+func (g *MovementGuard) record(playerID string, track *movementTrack, kind string, magnitude, limit float64, now time.Time) *MovementViolationEvent {
+	track.flagged = true
+
+	recent := track.violations[:0]
+	for _, at := range track.violations {
+		if now.Sub(at) < g.config.Window {
+			recent = append(recent, at)
+		}
+	}
+	track.violations = append(recent, now)
+
+	event := &MovementViolationEvent{
+		PlayerID:  playerID,
+		Kind:      kind,
+		Magnitude: magnitude,
+		Limit:     limit,
+		Count:     len(track.violations),
+	}
+	if g.config.KickAfter > 0 && event.Count >= g.config.KickAfter {
+		event.Kick = true
+		track.violations = nil
+	}
+	return event
+}
+
+// IsFlagged reports whether the player has ever broken a movement limit
+func (g *MovementGuard) IsFlagged(playerID string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	track, ok := g.tracks[playerID]
+	return ok && track.flagged
+}
+
+// Forget drops a removed player's history
+func (g *MovementGuard) Forget(playerID string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.tracks, playerID)
+}
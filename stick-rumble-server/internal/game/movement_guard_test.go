@@ -0,0 +1,125 @@
+package game
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const guardTick = 1.0 / 60
+
+var guardStart = time.Unix(1_800_000_000, 0)
+
+func TestMovementGuardAllowsSprintRollAndKnockback(t *testing.T) {
+	guard := NewMovementGuard(MovementGuardConfig{})
+	require.Nil(t, guard.CheckPosition("p1", Vector2{X: 100, Y: 100}, true, guardTick, guardStart))
+
+	sprint := Vector2{X: 100 + SprintSpeed*guardTick, Y: 100}
+	assert.Nil(t, guard.CheckPosition("p1", sprint, true, guardTick, guardStart))
+
+	knockedBack := Vector2{X: sprint.X + 40, Y: 100}
+	assert.Nil(t, guard.CheckPosition("p1", knockedBack, true, guardTick, guardStart))
+
+	// A stalled tick covers more ground at the same speed
+	assert.Nil(t, guard.CheckPosition("p1", Vector2{X: knockedBack.X + SprintSpeed*0.25, Y: 100}, true, 0.25, guardStart))
+	assert.False(t, guard.IsFlagged("p1"))
+}
+
+func TestMovementGuardReportsImpossibleDeltaAndTeleport(t *testing.T) {
+	guard := NewMovementGuard(MovementGuardConfig{})
+	guard.CheckPosition("p1", Vector2{X: 100, Y: 100}, true, guardTick, guardStart)
+
+	violation := guard.CheckPosition("p1", Vector2{X: 180, Y: 100}, true, guardTick, guardStart)
+	require.NotNil(t, violation)
+	assert.Equal(t, MovementViolationImpossibleDelta, violation.Kind)
+	assert.InDelta(t, 80, violation.Magnitude, 0.001)
+	assert.Equal(t, 1, violation.Count)
+	assert.False(t, violation.Kick, "KickAfter 0 only flags")
+
+	violation = guard.CheckPosition("p1", Vector2{X: 900, Y: 100}, true, guardTick, guardStart)
+	require.NotNil(t, violation)
+	assert.Equal(t, MovementViolationTeleport, violation.Kind)
+	assert.Equal(t, 2, violation.Count)
+	assert.True(t, guard.IsFlagged("p1"))
+}
+
+func TestMovementGuardTrustsRespawnPosition(t *testing.T) {
+	guard := NewMovementGuard(MovementGuardConfig{})
+	guard.CheckPosition("p1", Vector2{X: 100, Y: 100}, true, guardTick, guardStart)
+	guard.CheckPosition("p1", Vector2{X: 100, Y: 100}, false, guardTick, guardStart)
+
+	assert.Nil(t, guard.CheckPosition("p1", Vector2{X: 1500, Y: 800}, true, guardTick, guardStart))
+	assert.False(t, guard.IsFlagged("p1"))
+}
+
+func TestMovementGuardReportsAimFlick(t *testing.T) {
+	guard := NewMovementGuard(MovementGuardConfig{})
+	require.Nil(t, guard.CheckAim("p1", 0, guardStart))
+
+	// A quarter turn over one 60Hz input is a fast but human flick
+	next := guardStart.Add(minAimSampleInterval)
+	assert.Nil(t, guard.CheckAim("p1", math.Pi/2, next))
+
+	// The short way round from just under 2π to just over 0 is a small turn
+	next = next.Add(minAimSampleInterval)
+	guard.CheckAim("p1", 2*math.Pi-0.1, next)
+	next = next.Add(minAimSampleInterval)
+	assert.Nil(t, guard.CheckAim("p1", 0.1, next))
+
+	// A half turn between bunched inputs is measured at the 60Hz floor
+	violation := guard.CheckAim("p1", 0.1+math.Pi, next)
+	require.NotNil(t, violation)
+	assert.Equal(t, MovementViolationAimFlick, violation.Kind)
+	assert.InDelta(t, math.Pi*60, violation.Magnitude, 0.01)
+	assert.Equal(t, DefaultMaxAimRate, violation.Limit)
+}
+
+func TestMovementGuardKicksAfterViolationsWithinWindow(t *testing.T) {
+	guard := NewMovementGuard(MovementGuardConfig{KickAfter: 2, Window: time.Second})
+	guard.CheckPosition("p1", Vector2{X: 100, Y: 100}, true, guardTick, guardStart)
+
+	first := guard.CheckPosition("p1", Vector2{X: 500, Y: 100}, true, guardTick, guardStart)
+	require.NotNil(t, first)
+	assert.False(t, first.Kick)
+
+	// The first violation has aged out of the window
+	later := guardStart.Add(2 * time.Second)
+	second := guard.CheckPosition("p1", Vector2{X: 100, Y: 100}, true, guardTick, later)
+	require.NotNil(t, second)
+	assert.Equal(t, 1, second.Count)
+	assert.False(t, second.Kick)
+
+	third := guard.CheckPosition("p1", Vector2{X: 500, Y: 100}, true, guardTick, later.Add(time.Millisecond))
+	require.NotNil(t, third)
+	assert.Equal(t, 2, third.Count)
+	assert.True(t, third.Kick)
+}
+
+func TestGameServerReportsMovementViolations(t *testing.T) {
+	sink := &recordingGameLoopSink{}
+	clock := NewManualClock(guardStart)
+	gs := newGameServerWithSink(clock, sink)
+	player := gs.AddPlayer("p1")
+	player.SetPosition(Vector2{X: 100, Y: 100})
+	gs.checkMovement(gs.activePlayers(nil), guardTick, clock.Now())
+
+	player.SetPosition(Vector2{X: 800, Y: 100})
+	gs.checkMovement(gs.activePlayers(nil), guardTick, clock.Now())
+
+	violation := requireSingleEvent[MovementViolationEvent](t, sink.events)
+	assert.Equal(t, "p1", violation.PlayerID)
+	assert.Equal(t, MovementViolationTeleport, violation.Kind)
+	assert.True(t, gs.IsMovementFlagged("p1"))
+
+	sink.events = nil
+	require.True(t, gs.UpdatePlayerInputWithSequence("p1", InputState{AimAngle: 0}, 1))
+	require.True(t, gs.UpdatePlayerInputWithSequence("p1", InputState{AimAngle: math.Pi}, 2))
+	violation = requireSingleEvent[MovementViolationEvent](t, sink.events)
+	assert.Equal(t, MovementViolationAimFlick, violation.Kind)
+
+	gs.RemovePlayer("p1")
+	assert.False(t, gs.IsMovementFlagged("p1"))
+}
@@ -0,0 +1,265 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultOutboundOverflow is how many messages may wait behind a player's full
+// send channel before lower-priority ones are dropped
+const DefaultOutboundOverflow = 256
+
+// OutboundPriority ranks a server message by how much a client loses if it is
+// dropped because the client is not keeping up
+type OutboundPriority int
+
+const (
+	OutboundStale    OutboundPriority = iota // Superseded by the next message of its type; dropped first
+	OutboundNormal                           // Dropped only to make room for critical messages
+	OutboundCritical                         // Never dropped
+)
+
+func (p OutboundPriority) String() string {
+	switch p {
+	case OutboundStale:
+		return "stale"
+	case OutboundCritical:
+		return "critical"
+	default:
+		return "normal"
+	}
+}
+
+// outboundStaleTypes are periodic updates the next one replaces. A dropped
+// state:delta costs the client some interpolation; state:snapshot stays normal
+// because it is the baseline later deltas build on.
+var outboundStaleTypes = map[string]bool{
+	"state:delta":         true,
+	"player:move":         true,
+	"match:timer":         true,
+	"weapon:state":        true,
+	"queue:status":        true,
+	"lobby:sandbox_state": true,
+}
+
+// outboundCriticalTypes are events a client cannot rebuild from later state:
+// deaths, scoring, the match result and the session lifecycle
+var outboundCriticalTypes = map[string]bool{
+	"server:hello":            true,
+	"server:shutdown":         true,
+	"session:status":          true,
+	"session:replaced":        true,
+	"session:capacity":        true,
+	"room:joined":             true,
+	"room:redirect":           true,
+	"map:load":                true,
+	"world:sync":              true,
+	"player:joined":           true,
+	"player:left":             true,
+	"player:death":            true,
+	"player:kill_credit":      true,
+	"player:assist_credit":    true,
+	"player:respawn":          true,
+	"weapon:pickup_confirmed": true,
+	"shield:pickup_confirmed": true,
+	"health:pickup_confirmed": true,
+	"match:ended":             true,
+	"match:restarted":         true,
+	"error:room_full":         true,
+	"error:bad_room_code":     true,
+}
+
+// OutboundPriorityOf returns the priority messages of messageType are queued with
+func OutboundPriorityOf(messageType string) OutboundPriority {
+	switch {
+	case outboundCriticalTypes[messageType]:
+		return OutboundCritical
+	case outboundStaleTypes[messageType]:
+		return OutboundStale
+	default:
+		return OutboundNormal
+	}
+}
+
+var (
+	ErrOutboundClosed  = errors.New("send channel closed")
+	ErrOutboundDropped = errors.New("send buffer full")
+)
+
+// outboundDrops counts messages dropped by every queue since start, by
+// priority. Critical messages are never dropped.
+var outboundDrops [OutboundCritical]atomic.Uint64
+
+// OutboundDrops is a count of dropped messages by priority
+type OutboundDrops struct {
+	Stale  uint64 `json:"stale"`
+	Normal uint64 `json:"normal"`
+}
+
+// OutboundDropTotals returns the messages every player's queue has dropped
+// since the server started
+func OutboundDropTotals() OutboundDrops {
+	return OutboundDrops{Stale: outboundDrops[OutboundStale].Load(), Normal: outboundDrops[OutboundNormal].Load()}
+}
+
+// OutboundStats is the state of one player's outbound queue
+type OutboundStats struct {
+	Overflow int           `json:"overflow"` // Messages waiting for room in the send channel
+	Dropped  OutboundDrops `json:"dropped"`
+}
+
+type outboundEntry struct {
+	message  []byte
+	priority OutboundPriority
+}
+
+// OutboundQueue sits in front of a player's send channel. While the channel
+// has room messages go straight in; once it is full they wait in order in an
+// overflow, and when the overflow reaches its limit the oldest message of the
+// lowest priority is dropped to make room. Critical messages are never
+// dropped, so the overflow may grow past its limit for them; a client that
+// stops reading altogether is disconnected by the heartbeat.
+type OutboundQueue struct {
+	sendChan chan []byte
+	overflow []outboundEntry
+	limit    int
+	dropped  OutboundDrops
+	mu       sync.Mutex
+}
+
+// NewOutboundQueue creates a queue feeding sendChan that holds up to limit
+// messages while sendChan is full
+func NewOutboundQueue(sendChan chan []byte, limit int) *OutboundQueue {
+	return &OutboundQueue{sendChan: sendChan, limit: limit}
+}
+
+// Push queues a message without blocking. It fails with ErrOutboundDropped if
+// the message itself had to be dropped and ErrOutboundClosed if the channel
+// is closed.
+func (q *OutboundQueue) Push(message []byte) (err error) {
+	priority := OutboundPriorityOf(OutboundMessageType(message))
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	defer func() {
+		if recover() != nil {
+			err = ErrOutboundClosed
+		}
+	}()
+
+	q.refillLocked()
+	if len(q.overflow) == 0 {
+		select {
+		case q.sendChan <- message:
+			return nil
+		default:
+		}
+	}
+	if len(q.overflow) >= q.limit && !q.makeRoomLocked(priority) {
+		q.recordDropLocked(priority)
+		return ErrOutboundDropped
+	}
+	q.overflow = append(q.overflow, outboundEntry{message: message, priority: priority})
+	return nil
+}
+
+// makeRoomLocked drops the oldest queued message of the lowest priority that
+// is no higher than priority. A critical message is queued even if nothing
+// can be dropped; otherwise false means the new message should be dropped.
+func (q *OutboundQueue) makeRoomLocked(priority OutboundPriority) bool {
+	for candidate := OutboundStale; candidate <= priority && candidate < OutboundCritical; candidate++ {
+		for i, entry := range q.overflow {
+			if entry.priority == candidate {
+				q.overflow = append(q.overflow[:i], q.overflow[i+1:]...)
+				q.recordDropLocked(candidate)
+				return true
+			}
+		}
+	}
+	return priority == OutboundCritical
+}
+
+func (q *OutboundQueue) recordDropLocked(priority OutboundPriority) {
+	switch priority {
+	case OutboundStale:
+		q.dropped.Stale++
+	case OutboundNormal:
+		q.dropped.Normal++
+	default:
+		return
+	}
+	outboundDrops[priority].Add(1)
+}
+
+// Refill moves waiting messages into the send channel as far as it has room.
+// The connection's writer calls it after taking each message off the channel.
+func (q *OutboundQueue) Refill() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	defer func() { _ = recover() }()
+
+	q.refillLocked()
+}
+
+func (q *OutboundQueue) refillLocked() {
+	sent := 0
+fill:
+	for sent < len(q.overflow) {
+		select {
+		case q.sendChan <- q.overflow[sent].message:
+			sent++
+		default:
+			break fill
+		}
+	}
+	if sent > 0 {
+		remaining := copy(q.overflow, q.overflow[sent:])
+		clear(q.overflow[remaining:])
+		q.overflow = q.overflow[:remaining]
+	}
+}
+
+// Drain discards every queued message, in the channel and the overflow
+func (q *OutboundQueue) Drain() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	clear(q.overflow)
+	q.overflow = q.overflow[:0]
+	for {
+		select {
+		case _, ok := <-q.sendChan:
+			if !ok {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+// Stats returns the overflow length and the messages dropped by priority
+func (q *OutboundQueue) Stats() OutboundStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return OutboundStats{Overflow: len(q.overflow), Dropped: q.dropped}
+}
+
+// OutboundMessageType reads the type of a JSON message. Messages are encoded
+// with "type" first, so the common case needs no decoding.
+func OutboundMessageType(message []byte) string {
+	const prefix = `{"type":"`
+	if rest, ok := bytes.CutPrefix(message, []byte(prefix)); ok {
+		if end := bytes.IndexByte(rest, '"'); end >= 0 {
+			return string(rest[:end])
+		}
+	}
+	var envelope struct {
+		Type string `json:"type"`
+	}
+	_ = json.Unmarshal(message, &envelope)
+	return envelope.Type
+}
@@ -0,0 +1,114 @@
+package game
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func outboundMessage(messageType string, n int) []byte {
+	return []byte(fmt.Sprintf(`{"type":%q,"timestamp":%d}`, messageType, n))
+}
+
+// receiveAll takes every message off the channel, refilling it from the queue
+// the way a connection's writer does
+func receiveAll(q *OutboundQueue, sendChan chan []byte) []string {
+	var received []string
+	for {
+		select {
+		case message := <-sendChan:
+			received = append(received, string(message))
+			q.Refill()
+		default:
+			return received
+		}
+	}
+}
+
+func TestOutboundQueueKeepsOrderBehindFullChannel(t *testing.T) {
+	sendChan := make(chan []byte, 2)
+	q := NewOutboundQueue(sendChan, 4)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, q.Push(outboundMessage("player:damaged", i)))
+	}
+	assert.Equal(t, 3, q.Stats().Overflow)
+
+	received := receiveAll(q, sendChan)
+	require.Len(t, received, 5)
+	for i, message := range received {
+		assert.Equal(t, string(outboundMessage("player:damaged", i)), message)
+	}
+	assert.Zero(t, q.Stats().Overflow)
+}
+
+func TestOutboundQueueDropsStaleFirstAndNeverCritical(t *testing.T) {
+	before := OutboundDropTotals()
+	sendChan := make(chan []byte, 1)
+	q := NewOutboundQueue(sendChan, 3)
+	require.NoError(t, q.Push(outboundMessage("player:damaged", 0))) // Fills the channel
+
+	require.NoError(t, q.Push(outboundMessage("state:delta", 1)))
+	require.NoError(t, q.Push(outboundMessage("player:damaged", 2)))
+	require.NoError(t, q.Push(outboundMessage("state:delta", 3)))
+
+	// A normal message displaces the oldest stale one
+	require.NoError(t, q.Push(outboundMessage("player:damaged", 4)))
+	// A stale message displaces the older stale one
+	require.NoError(t, q.Push(outboundMessage("state:delta", 5)))
+	// Critical messages displace stale, then normal, then queue past the limit
+	require.NoError(t, q.Push(outboundMessage("player:death", 6)))
+	require.NoError(t, q.Push(outboundMessage("match:ended", 7)))
+	require.NoError(t, q.Push(outboundMessage("player:kill_credit", 8)))
+	require.NoError(t, q.Push(outboundMessage("player:left", 9)))
+	// With only critical messages queued, lower ones are dropped on arrival
+	assert.ErrorIs(t, q.Push(outboundMessage("state:delta", 10)), ErrOutboundDropped)
+	assert.ErrorIs(t, q.Push(outboundMessage("player:damaged", 11)), ErrOutboundDropped)
+
+	stats := q.Stats()
+	assert.Equal(t, 4, stats.Overflow)
+	assert.Equal(t, OutboundDrops{Stale: 4, Normal: 3}, stats.Dropped)
+	after := OutboundDropTotals()
+	assert.Equal(t, uint64(4), after.Stale-before.Stale)
+	assert.Equal(t, uint64(3), after.Normal-before.Normal)
+
+	assert.Equal(t, []string{
+		string(outboundMessage("player:damaged", 0)),
+		string(outboundMessage("player:death", 6)),
+		string(outboundMessage("match:ended", 7)),
+		string(outboundMessage("player:kill_credit", 8)),
+		string(outboundMessage("player:left", 9)),
+	}, receiveAll(q, sendChan), "every critical message arrives, in order")
+}
+
+func TestOutboundQueueDrainAndClose(t *testing.T) {
+	sendChan := make(chan []byte, 1)
+	q := NewOutboundQueue(sendChan, 4)
+	require.NoError(t, q.Push(outboundMessage("player:damaged", 0)))
+	require.NoError(t, q.Push(outboundMessage("player:damaged", 1)))
+
+	q.Drain()
+	assert.Zero(t, q.Stats().Overflow)
+	assert.Empty(t, sendChan)
+
+	close(sendChan)
+	assert.ErrorIs(t, q.Push(outboundMessage("player:death", 2)), ErrOutboundClosed)
+	q.Drain() // A closed channel must not spin
+}
+
+func TestPlayerSendWithoutOutbox(t *testing.T) {
+	player := &Player{ID: "p1", SendChan: make(chan []byte, 1)}
+	require.NoError(t, player.Send(outboundMessage("player:death", 0)))
+	assert.ErrorIs(t, player.Send(outboundMessage("player:death", 1)), ErrOutboundDropped)
+}
+
+func TestOutboundMessageType(t *testing.T) {
+	assert.Equal(t, "state:delta", OutboundMessageType([]byte(`{"type":"state:delta","data":{}}`)))
+	assert.Equal(t, "match:ended", OutboundMessageType([]byte(`{"timestamp":1, "type":"match:ended"}`)))
+	assert.Empty(t, OutboundMessageType([]byte(`not json`)))
+	assert.Equal(t, OutboundNormal, OutboundPriorityOf(""))
+	assert.Equal(t, OutboundStale, OutboundPriorityOf("state:delta"))
+	assert.Equal(t, OutboundNormal, OutboundPriorityOf("melee:hit"), "a swing is not replaced by the next one")
+	assert.Equal(t, OutboundCritical, OutboundPriorityOf("player:death"))
+}
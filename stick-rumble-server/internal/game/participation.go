@@ -0,0 +1,70 @@
+package game
+
+import "time"
+
+const (
+	// ParticipationXPReward is the XP granted for each ParticipationXPInterval
+	// a player spends active in a match
+	ParticipationXPReward = 10
+
+	// ParticipationXPInterval is the active play time that earns one
+	// participation reward
+	ParticipationXPInterval = 30 * time.Second
+
+	// ParticipationActivityWindow is how long after its last input change or
+	// attack a player still counts as active
+	ParticipationActivityWindow = 10 * time.Second
+)
+
+// MarkActive records that the player acted just now (thread-safe)
+func (p *PlayerState) MarkActive() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.lastActiveAt = p.clock.Now()
+}
+
+// IsActive reports whether the player acted within ParticipationActivityWindow
+// of now (thread-safe)
+func (p *PlayerState) IsActive(now time.Time) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.isActiveLocked(now)
+}
+
+func (p *PlayerState) isActiveLocked(now time.Time) bool {
+	return !p.lastActiveAt.IsZero() && now.Sub(p.lastActiveAt) <= ParticipationActivityWindow
+}
+
+// accrueParticipation counts elapsed toward the next participation reward if
+// the player is active, and grants the XP for every full interval reached.
+// Idle ticks count for nothing, so an AFK player earns no passive XP however
+// long the match runs. Returns the XP granted.
+func (p *PlayerState) accrueParticipation(now time.Time, elapsed time.Duration) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.isActiveLocked(now) {
+		return 0
+	}
+
+	p.participationTime += elapsed
+	granted := 0
+	for p.participationTime >= ParticipationXPInterval {
+		p.participationTime -= ParticipationXPInterval
+		granted += ParticipationXPReward
+	}
+	p.XP += granted
+	return granted
+}
+
+// updateParticipationXP grants participation XP to players who were active
+// this tick
+func (gs *GameServer) updateParticipationXP(players []*PlayerState, deltaTime float64) {
+	now := gs.clock.Now()
+	elapsed := time.Duration(deltaTime * float64(time.Second))
+	for _, player := range players {
+		if player.accrueParticipation(now, elapsed) > 0 {
+			gs.announceLevelUp(player)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// tickParticipation advances the clock one second at a time for the duration,
+// calling act before each tick
+func tickParticipation(gs *GameServer, clock *ManualClock, duration time.Duration, act func()) {
+	for elapsed := time.Duration(0); elapsed < duration; elapsed += time.Second {
+		act()
+		clock.Advance(time.Second)
+		gs.updateParticipationXP(gs.activePlayers(nil), 1.0)
+	}
+}
+
+func TestParticipationXPRequiresActivity(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	gs := NewGameServerWithClock(nil, clock)
+	idle := gs.AddPlayer("idle")
+	active := gs.AddPlayer("active")
+
+	turn := false
+	tickParticipation(gs, clock, 2*ParticipationXPInterval, func() {
+		turn = !turn
+		gs.UpdatePlayerInput("active", InputState{Up: turn})
+		gs.UpdatePlayerInput("idle", InputState{})
+	})
+
+	assert.Equal(t, 2*ParticipationXPReward, active.Snapshot().XP)
+	assert.Zero(t, idle.Snapshot().XP, "resending an unchanged input is not activity")
+}
+
+func TestParticipationXPStopsAfterActivityWindow(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	gs := NewGameServerWithClock(nil, clock)
+	player := gs.AddPlayer("p1")
+	gs.AddPlayer("target")
+
+	// One attack keeps the player active for the window, then idle ticks stop counting
+	gs.PlayerShoot("p1", 0, clock.Now().UnixMilli())
+	tickParticipation(gs, clock, 10*ParticipationXPInterval, func() {})
+	assert.Zero(t, player.Snapshot().XP)
+	assert.False(t, player.IsActive(clock.Now()))
+
+	// Active time carries over between bursts of activity
+	bursts := int(ParticipationXPInterval / ParticipationActivityWindow)
+	for i := 0; i <= bursts; i++ {
+		player.MarkActive()
+		tickParticipation(gs, clock, ParticipationActivityWindow, func() {})
+		tickParticipation(gs, clock, time.Minute, func() {})
+	}
+	assert.Equal(t, ParticipationXPReward, player.Snapshot().XP)
+}
@@ -0,0 +1,141 @@
+package game
+
+import "log"
+
+// PartyState is a point-in-time view of an ended match's stay-together vote.
+type PartyState struct {
+	RoomID           string
+	StayingPlayerIDs []string
+	TotalPlayers     int
+}
+
+// SetPlayerStaying updates one player's stay-together vote. It returns false
+// when the player is not in the room.
+func (r *Room) SetPlayerStaying(playerID string, stay bool) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, player := range r.Players {
+		if player.ID == playerID {
+			player.StayTogether = stay
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Room) partyState() PartyState {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	state := PartyState{
+		RoomID:           r.ID,
+		StayingPlayerIDs: make([]string, 0, len(r.Players)),
+		TotalPlayers:     len(r.Players),
+	}
+	for _, player := range r.Players {
+		if player.StayTogether {
+			state.StayingPlayerIDs = append(state.StayingPlayerIDs, player.ID)
+		}
+	}
+	return state
+}
+
+// StayTogether records a player's vote to play the next match with the rest
+// of its ended match's room. Once every player still in the room has voted to
+// stay, the group is moved into a fresh room of the same kind, keeping teams,
+// and its match or ready check begins. It returns false when the player is
+// not in a room whose match has ended.
+func (f *RoomSessionFlow) StayTogether(playerID string, stay bool) (RoomSessionResult, bool) {
+	rm := f.roomManager
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	roomID, exists := rm.playerToRoom[playerID]
+	if !exists {
+		return RoomSessionResult{}, false
+	}
+	room, exists := rm.rooms[roomID]
+	if !exists || !room.Match.IsEnded() {
+		return RoomSessionResult{}, false
+	}
+	player := room.GetPlayer(playerID)
+	if player == nil {
+		return RoomSessionResult{}, false
+	}
+	wasStaying := player.StayTogether
+	if !room.SetPlayerStaying(playerID, stay) {
+		return RoomSessionResult{}, false
+	}
+	rm.metrics.RematchVote(playerID, room.ID, wasStaying, stay)
+
+	return rm.reformPartyLocked(room), true
+}
+
+// ReformParty re-evaluates an ended match's stay-together vote after a player
+// left its room, so the players who voted to stay are not held up by one who
+// is gone.
+func (f *RoomSessionFlow) ReformParty(roomID string) RoomSessionResult {
+	rm := f.roomManager
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	room, exists := rm.rooms[roomID]
+	if !exists || !room.Match.IsEnded() || room.IsEmpty() || len(room.partyState().StayingPlayerIDs) == 0 {
+		return RoomSessionResult{}
+	}
+	return rm.reformPartyLocked(room)
+}
+
+// reformPartyLocked moves an ended room's players into a new room once all of
+// them voted to stay and publishes the vote otherwise. It is called with rm.mu
+// held.
+func (rm *RoomManager) reformPartyLocked(room *Room) RoomSessionResult {
+	state := room.partyState()
+	if state.TotalPlayers < MinPlayersToStart || len(state.StayingPlayerIDs) < state.TotalPlayers {
+		rm.publishPartyStateLocked(room, state)
+		return RoomSessionResult{}
+	}
+
+	party := rm.newRoomLocked(room.Kind, room.Code)
+	for _, player := range room.GetPlayers() {
+		room.RemovePlayer(player.ID)
+		player.StayTogether = false
+		_ = party.AddPlayer(player)
+		party.Match.RegisterPlayer(player.ID)
+		rm.playerToRoom[player.ID] = party.ID
+	}
+	rm.rooms[party.ID] = party
+	delete(rm.rooms, room.ID)
+
+	if party.Kind == RoomKindCode && party.Code != "" {
+		// Keep the code pointing at the group unless someone already started
+		// a fresh room with it since the match ended
+		if indexedID, ok := rm.codeIndex[party.Code]; !ok || indexedID == room.ID {
+			rm.codeIndex[party.Code] = party.ID
+		}
+	}
+	log.Printf("Party from room %s stayed together in room %s (%d players)", room.ID, party.ID, party.PlayerCount())
+	rm.metrics.RematchFormed(party.ID, party.PlayerCount())
+
+	result := RoomSessionResult{
+		Room:         party,
+		Publications: sessionPublicationsForRoom(party, SessionStatusMatchReady),
+		Activations:  sessionActivationsForRoom(party),
+	}
+	if rm.beginMatchOrReadyCheck(party) {
+		result.ReadyChecks = []*Room{party}
+	}
+	return result
+}
+
+func (rm *RoomManager) publishPartyStateLocked(room *Room, state PartyState) {
+	if rm.publisher == nil {
+		log.Printf("Warning: no room event publisher configured for party:state(%s)", room.ID)
+		return
+	}
+
+	if err := rm.publisher.PublishPartyState(room, state); err != nil {
+		log.Printf("Error publishing party:state for room %s: %v", room.ID, err)
+	}
+}
@@ -0,0 +1,127 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newEndedCodeRoom puts players in a code room and ends its match
+func newEndedCodeRoom(t *testing.T, code string, playerIDs ...string) (*RoomManager, *stubRoomEventPublisher, *Room, []*Player) {
+	t.Helper()
+
+	manager := NewRoomManager()
+	publisher := &stubRoomEventPublisher{}
+	manager.SetPublisher(publisher)
+
+	players := make([]*Player, 0, len(playerIDs))
+	var room *Room
+	for _, id := range playerIDs {
+		player := newSessionFlowPlayer(id)
+		result := manager.SessionFlow().HandleHello(player, map[string]any{"mode": "code", "code": code})
+		require.Nil(t, result.Rejection)
+		room = result.Room
+		players = append(players, player)
+	}
+	room.Match.Start()
+	room.Match.EndMatch("kill_target")
+	return manager, publisher, room, players
+}
+
+func TestStayTogetherIgnoredBeforeMatchEnds(t *testing.T) {
+	manager, _, room, players := newReadyCheckRoom(t, ReadyCheckConfig{})
+	require.True(t, room.Match.IsStarted())
+
+	_, ok := manager.SessionFlow().StayTogether(players[0].ID, true)
+
+	assert.False(t, ok)
+	assert.False(t, players[0].StayTogether)
+}
+
+func TestStayTogetherPublishesVotesUntilEveryoneStays(t *testing.T) {
+	manager, publisher, room, players := newEndedCodeRoom(t, "PARTY", "player-1", "player-2", "player-3")
+
+	result, ok := manager.SessionFlow().StayTogether(players[0].ID, true)
+	require.True(t, ok)
+	assert.Nil(t, result.Room)
+	assert.Empty(t, result.Activations)
+
+	_, ok = manager.SessionFlow().StayTogether(players[1].ID, true)
+	require.True(t, ok)
+
+	require.Len(t, publisher.partyStates, 2)
+	assert.Equal(t, PartyState{
+		RoomID:           room.ID,
+		StayingPlayerIDs: []string{"player-1", "player-2"},
+		TotalPlayers:     3,
+	}, publisher.partyStates[1])
+	assert.Equal(t, room, manager.GetRoomByPlayerID(players[0].ID), "the group waits for the last vote")
+}
+
+func TestStayTogetherMovesGroupIntoNewRoomKeepingTeams(t *testing.T) {
+	manager, _, room, players := newEndedCodeRoom(t, "PARTY", "player-1", "player-2")
+	players[0].Team = TeamAlpha
+	players[1].Team = TeamBravo
+
+	_, ok := manager.SessionFlow().StayTogether(players[0].ID, true)
+	require.True(t, ok)
+	result, ok := manager.SessionFlow().StayTogether(players[1].ID, true)
+	require.True(t, ok)
+
+	party := result.Room
+	require.NotNil(t, party)
+	assert.NotEqual(t, room.ID, party.ID)
+	assert.Equal(t, RoomKindCode, party.Kind)
+	assert.Equal(t, "PARTY", party.Code)
+	assert.False(t, party.Match.IsEnded())
+	assert.True(t, party.InReadyCheck())
+	assert.Equal(t, []*Room{party}, result.ReadyChecks)
+	assert.ElementsMatch(t, []string{"player-1", "player-2"}, activationIDs(result.Activations))
+	assert.Equal(t, []SessionStatusState{SessionStatusMatchReady}, publicationStatesForPlayer(result.Publications, "player-1"))
+
+	assert.Equal(t, TeamAlpha, players[0].Team)
+	assert.Equal(t, TeamBravo, players[1].Team)
+	assert.False(t, players[0].StayTogether, "votes do not carry into the next match")
+	assert.Equal(t, party, manager.GetRoomByPlayerID(players[1].ID))
+	assert.Nil(t, manager.GetRoom(room.ID), "the ended room is gone")
+	assert.Equal(t, party.ID, manager.codeIndex["PARTY"], "the code leads to the group's new room")
+	assert.True(t, party.Match.RegisteredPlayers["player-1"])
+}
+
+func TestStayTogetherKeepsCodeClaimedByFreshRoom(t *testing.T) {
+	manager, _, _, players := newEndedCodeRoom(t, "PARTY", "player-1", "player-2")
+	newcomer := newSessionFlowPlayer("player-3")
+	fresh := manager.SessionFlow().HandleHello(newcomer, map[string]any{"mode": "code", "code": "PARTY"})
+	require.NotNil(t, fresh.Room)
+
+	manager.SessionFlow().StayTogether(players[0].ID, true)
+	result, _ := manager.SessionFlow().StayTogether(players[1].ID, true)
+
+	require.NotNil(t, result.Room)
+	assert.Equal(t, fresh.Room.ID, manager.codeIndex["PARTY"])
+}
+
+func TestReformPartyAfterHoldoutLeaves(t *testing.T) {
+	manager, _, room, players := newEndedCodeRoom(t, "PARTY", "player-1", "player-2", "player-3")
+	manager.SessionFlow().StayTogether(players[0].ID, true)
+	manager.SessionFlow().StayTogether(players[1].ID, true)
+
+	manager.RemovePlayer(players[2].ID)
+	result := manager.SessionFlow().ReformParty(room.ID)
+
+	require.NotNil(t, result.Room)
+	assert.ElementsMatch(t, []string{"player-1", "player-2"}, activationIDs(result.Activations))
+	assert.Nil(t, manager.GetRoomByPlayerID(players[2].ID))
+}
+
+func TestReformPartyNeedsTwoPlayers(t *testing.T) {
+	manager, _, room, players := newEndedCodeRoom(t, "PARTY", "player-1", "player-2")
+	manager.SessionFlow().StayTogether(players[0].ID, true)
+
+	manager.RemovePlayer(players[1].ID)
+	result := manager.SessionFlow().ReformParty(room.ID)
+
+	assert.Nil(t, result.Room, "a lone player goes back to matchmaking on their own")
+	assert.Equal(t, room, manager.GetRoomByPlayerID(players[0].ID))
+}
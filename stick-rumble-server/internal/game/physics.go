@@ -72,6 +72,7 @@ func (p *Physics) UpdatePlayer(player *PlayerState, deltaTime float64) UpdatePla
 		if input.IsSprinting {
 			moveSpeed = SprintSpeed
 		}
+		moveSpeed *= player.SpeedMultiplier()
 
 		// Apply acceleration or deceleration
 		var newVel Vector2
@@ -112,12 +113,9 @@ func (p *Physics) UpdatePlayer(player *PlayerState, deltaTime float64) UpdatePla
 		result.RollCancelled = true
 	}
 
-	// Sanitize position before setting it
-	clampedPos = sanitizeVector2(clampedPos, "UpdatePlayer position")
-
 	// Validate the movement for anti-cheat detection
 	input := player.GetInput()
-	validation := p.ValidatePlayerMovement(oldPos, clampedPos, currentVel, deltaTime, isRolling, input.IsSprinting, movementBlocked)
+	validation := p.ValidatePlayerMovement(oldPos, clampedPos, currentVel, deltaTime, isRolling, input.IsSprinting, movementBlocked, player.SpeedMultiplier())
 	if !validation.Valid {
 		// Movement failed validation - mark for correction
 		result.CorrectionNeeded = true
@@ -266,6 +264,9 @@ type HitEvent struct {
 	ProjectileID string
 	VictimID     string
 	AttackerID   string
+	Damage       int     // Damage of the projectile, 0 for the attacker's current weapon damage
+	Point        Vector2 // Where the projectile met the victim, or the center of the explosion that caught it
+	Direction    Vector2 // Unit vector the shot was travelling, zero when unknown
 }
 
 // calculateDistance returns the Euclidean distance between two positions
@@ -357,13 +358,14 @@ func (p *Physics) projectilePlayerContact(proj *Projectile, player *PlayerState)
 		sweepStart = proj.Position
 	}
 
-	// Validate range: reject hits beyond max projectile range
+	// Validate range: reject hits beyond the projectile's max range
+	maxRange := proj.maxRange()
 	startDistance := calculateDistance(proj.SpawnPosition, sweepStart)
-	if startDistance > ProjectileMaxRange {
+	if startDistance > maxRange {
 		return segmentContact{}, false
 	}
 
-	sweepEnd = clampSegmentToDistance(proj.SpawnPosition, sweepEnd, ProjectileMaxRange)
+	sweepEnd = clampSegmentToDistance(proj.SpawnPosition, sweepEnd, maxRange)
 	playerContact, ok := segmentPlayerHitboxContact(sweepStart, sweepEnd, playerPos)
 	if !ok {
 		return segmentContact{}, false
@@ -401,6 +403,9 @@ func (p *Physics) CheckAllProjectileCollisions(projectiles []*Projectile, player
 					ProjectileID: proj.ID,
 					VictimID:     player.ID,
 					AttackerID:   proj.OwnerID,
+					Damage:       proj.Damage,
+					Point:        contact.Point,
+					Direction:    normalize(proj.Velocity),
 				}
 				nearestHit = &event
 				nearestDistance = contact.Distance
@@ -415,6 +420,21 @@ func (p *Physics) CheckAllProjectileCollisions(projectiles []*Projectile, player
 	return hits
 }
 
+// ApplyImpulse adds impulse to target's velocity, capped at
+// HitImpulseMaxSpeed, and returns the velocity change actually applied.
+// Movement slows the player back down and stops it at walls and the arena
+// edge like any other speed.
+func (p *Physics) ApplyImpulse(target *PlayerState, impulse Vector2) Vector2 {
+	before := target.GetVelocity()
+	after := Vector2{X: before.X + impulse.X, Y: before.Y + impulse.Y}
+	if speed := math.Hypot(after.X, after.Y); speed > HitImpulseMaxSpeed {
+		after = Vector2{X: after.X / speed * HitImpulseMaxSpeed, Y: after.Y / speed * HitImpulseMaxSpeed}
+	}
+	after = sanitizeVector2(after, "ApplyImpulse velocity")
+	target.SetVelocity(after)
+	return Vector2{X: after.X - before.X, Y: after.Y - before.Y}
+}
+
 // ValidationResult represents the result of movement validation
 type ValidationResult struct {
 	Valid  bool   // Whether the movement is valid
@@ -423,8 +443,9 @@ type ValidationResult struct {
 
 // ValidatePlayerMovement checks if a player's movement is physically possible
 // This is used for server-side anti-cheat to detect impossible movements
+// speedMultiplier is the player's class speed multiplier; rolls are not scaled
 // Returns a ValidationResult indicating if the movement is valid
-func (p *Physics) ValidatePlayerMovement(oldPos, newPos, velocity Vector2, deltaTime float64, isRolling, isSprinting, movementBlocked bool) ValidationResult {
+func (p *Physics) ValidatePlayerMovement(oldPos, newPos, velocity Vector2, deltaTime float64, isRolling, isSprinting, movementBlocked bool, speedMultiplier float64) ValidationResult {
 	// Constants for validation tolerance (allow small floating point errors)
 	const speedTolerance = 1.05 // 5% tolerance for floating point precision
 
@@ -441,9 +462,9 @@ func (p *Physics) ValidatePlayerMovement(oldPos, newPos, velocity Vector2, delta
 	if isRolling {
 		maxSpeed = DodgeRollVelocity
 	} else if isSprinting {
-		maxSpeed = SprintSpeed
+		maxSpeed = SprintSpeed * speedMultiplier
 	} else {
-		maxSpeed = MovementSpeed
+		maxSpeed = MovementSpeed * speedMultiplier
 	}
 
 	// Calculate actual velocity magnitude
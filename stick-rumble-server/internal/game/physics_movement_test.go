@@ -505,3 +505,26 @@ func TestSprintWithDiagonalMovement(t *testing.T) {
 		t.Errorf("Diagonal sprint velocity magnitude should be ~%v, got %v", SprintSpeed, velMagnitude)
 	}
 }
+
+func TestApplyImpulse(t *testing.T) {
+	physics := NewPhysics(openTestMapConfig())
+
+	player := createTestPlayer("p", 500, 500, 0)
+	player.SetVelocity(Vector2{X: 0, Y: 100})
+	applied := physics.ApplyImpulse(player, Vector2{X: 200, Y: 0})
+	if applied != (Vector2{X: 200, Y: 0}) {
+		t.Errorf("Expected the full impulse to apply, got %+v", applied)
+	}
+	if vel := player.GetVelocity(); vel != (Vector2{X: 200, Y: 100}) {
+		t.Errorf("Expected velocity {200 100}, got %+v", vel)
+	}
+
+	player.SetVelocity(Vector2{X: 500, Y: 0})
+	applied = physics.ApplyImpulse(player, Vector2{X: 500, Y: 0})
+	if vel := player.GetVelocity(); math.Abs(vel.X-HitImpulseMaxSpeed) > 1e-9 || vel.Y != 0 {
+		t.Errorf("Expected velocity capped at %v, got %+v", HitImpulseMaxSpeed, vel)
+	}
+	if math.Abs(applied.X-(HitImpulseMaxSpeed-500)) > 1e-9 {
+		t.Errorf("Expected only the capped change to be reported, got %+v", applied)
+	}
+}
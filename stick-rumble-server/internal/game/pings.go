@@ -0,0 +1,52 @@
+package game
+
+import "time"
+
+// Tactical ping types a player can place on the map for their team
+const (
+	PingGo     = "go"     // Move here
+	PingEnemy  = "enemy"  // Enemy spotted here
+	PingDanger = "danger" // Stay away from here
+	PingLoot   = "loot"   // Weapon or pickup here
+)
+
+// Ping rate limit: a burst of PingCharges, each coming back after PingRecharge
+const (
+	PingCharges  = 3
+	PingRecharge = 2 * time.Second
+)
+
+var pingTypes = map[string]bool{
+	PingGo:     true,
+	PingEnemy:  true,
+	PingDanger: true,
+	PingLoot:   true,
+}
+
+// PingResult is the outcome of a tactical ping
+type PingResult struct {
+	Success bool
+	Reason  string
+}
+
+// Ping checks a player's tactical ping: a known type at a point inside the
+// map, with a ping charge left. A valid ping spends a charge; the server keeps
+// nothing else, the caller routes it to the player's team.
+func (gs *GameServer) Ping(playerID, pingType string, position Vector2) PingResult {
+	player, exists := gs.world.GetPlayer(playerID)
+	if !exists {
+		return PingResult{Success: false, Reason: "player_not_found"}
+	}
+	if !pingTypes[pingType] {
+		return PingResult{Success: false, Reason: "unknown_type"}
+	}
+	if !pointWithinBounds(position.X, position.Y, gs.world.GetMapConfig()) {
+		return PingResult{Success: false, Reason: "out_of_bounds"}
+	}
+	if charges, _ := player.cooldowns.Charges(CooldownPing, PingRecharge, PingCharges); charges == 0 {
+		return PingResult{Success: false, Reason: "rate_limited"}
+	}
+
+	player.cooldowns.Spend(CooldownPing, PingRecharge, PingCharges)
+	return PingResult{Success: true}
+}
@@ -0,0 +1,40 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPingValidatesTypeAndBounds(t *testing.T) {
+	gs := NewGameServerWithClock(nil, NewManualClock(time.Now()))
+	gs.AddPlayer("p1")
+	inside := Vector2{X: 100, Y: 100}
+
+	assert.Equal(t, PingResult{Success: true}, gs.Ping("p1", PingEnemy, inside))
+	assert.Equal(t, "player_not_found", gs.Ping("ghost", PingEnemy, inside).Reason)
+	assert.Equal(t, "unknown_type", gs.Ping("p1", "nuke", inside).Reason)
+	assert.Equal(t, "out_of_bounds", gs.Ping("p1", PingGo, Vector2{X: -1, Y: 100}).Reason)
+	assert.Equal(t, "out_of_bounds", gs.Ping("p1", PingGo, Vector2{X: 100, Y: ArenaHeight + 1}).Reason)
+
+	for i := 1; i < PingCharges; i++ {
+		assert.True(t, gs.Ping("p1", PingLoot, inside).Success, "rejected pings cost no charge")
+	}
+}
+
+func TestPingRateLimit(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	gs := NewGameServerWithClock(nil, clock)
+	gs.AddPlayer("p1")
+	position := Vector2{X: 100, Y: 100}
+
+	for i := 0; i < PingCharges; i++ {
+		assert.True(t, gs.Ping("p1", PingGo, position).Success, "ping %d of the burst", i+1)
+	}
+	assert.Equal(t, "rate_limited", gs.Ping("p1", PingGo, position).Reason)
+
+	clock.Advance(PingRecharge)
+	assert.True(t, gs.Ping("p1", PingGo, position).Success, "a charge came back")
+	assert.False(t, gs.Ping("p1", PingGo, position).Success)
+}
@@ -25,7 +25,6 @@ type InputState struct {
 type RollState struct {
 	IsRolling     bool      `json:"isRolling"`     // Whether player is currently rolling
 	RollStartTime time.Time `json:"rollStartTime"` // When the current roll started
-	LastRollTime  time.Time `json:"lastRollTime"`  // When the last roll finished
 	RollDirection Vector2   `json:"rollDirection"` // Direction vector of the roll (normalized)
 }
 
@@ -46,21 +45,32 @@ func (cs *CorrectionStats) GetCorrectionRate() float64 {
 
 // PlayerStateSnapshot represents a player's state for broadcasting (no mutex, safe to copy by value)
 type PlayerStateSnapshot struct {
-	ID                     string     `json:"id"`
-	DisplayName            string     `json:"displayName"`
-	Position               Vector2    `json:"position"`
-	Velocity               Vector2    `json:"velocity"`
-	AimAngle               float64    `json:"aimAngle"`            // Aim angle in radians
-	WeaponType             string     `json:"weaponType"`          // Current equipped weapon type
-	Health                 int        `json:"health"`              // Current health (0-100)
-	IsInvulnerable         bool       `json:"isInvulnerable"`      // Spawn protection flag
-	InvulnerabilityEndTime time.Time  `json:"invulnerabilityEnd"`  // When spawn protection ends
-	DeathTime              *time.Time `json:"deathTime,omitempty"` // When player died (nil if alive)
-	Kills                  int        `json:"kills"`               // Number of kills
-	Deaths                 int        `json:"deaths"`              // Number of deaths
-	XP                     int        `json:"xp"`                  // Experience points
-	IsRegeneratingHealth   bool       `json:"isRegenerating"`      // Whether health is currently regenerating
-	Rolling                bool       `json:"isRolling"`           // Whether player is currently dodge rolling
+	ID                     string         `json:"id"`
+	DisplayName            string         `json:"displayName"`
+	Position               Vector2        `json:"position"`
+	Velocity               Vector2        `json:"velocity"`
+	AimAngle               float64        `json:"aimAngle"`            // Aim angle in radians
+	WeaponType             string         `json:"weaponType"`          // Current equipped weapon type
+	Health                 int            `json:"health"`              // Current health (0-maxHealth)
+	Shield                 int            `json:"shield"`              // Current shield (0-ShieldMax)
+	Stamina                int            `json:"stamina"`             // Current stamina (0-MaxStamina), spent by dodge rolls
+	MaxHealth              int            `json:"maxHealth"`           // Maximum health for the player's class
+	Class                  string         `json:"class,omitempty"`     // Character class (empty without one)
+	IsInvulnerable         bool           `json:"isInvulnerable"`      // Spawn protection flag
+	InvulnerabilityEndTime time.Time      `json:"invulnerabilityEnd"`  // When spawn protection ends
+	DeathTime              *time.Time     `json:"deathTime,omitempty"` // When player died (nil if alive)
+	Kills                  int            `json:"kills"`               // Number of kills
+	Deaths                 int            `json:"deaths"`              // Number of deaths
+	XP                     int            `json:"xp"`                  // Experience points
+	IsRegeneratingHealth   bool           `json:"isRegenerating"`      // Whether health is currently regenerating
+	Rolling                bool           `json:"isRolling"`           // Whether player is currently dodge rolling
+	UltimateCharge         int            `json:"ultimateCharge"`      // Ultimate meter (0-100)
+	UltimateActive         bool           `json:"ultimateActive"`      // Whether an ultimate's effect is running
+	Teleport               TeleportReason `json:"teleport,omitempty"`  // Set for TeleportFlagDuration after a position jump
+
+	// LastUpdated is the tick the state last changed as clients see it. The
+	// state broadcast sets it for players in a room; zero elsewhere.
+	LastUpdated uint64 `json:"lastUpdated,omitempty"`
 }
 
 // PlayerState represents a player's physics state in the game world
@@ -70,7 +80,8 @@ type PlayerState struct {
 	Position               Vector2         `json:"position"`
 	Velocity               Vector2         `json:"velocity"`
 	AimAngle               float64         `json:"aimAngle"`            // Aim angle in radians
-	Health                 int             `json:"health"`              // Current health (0-100)
+	Health                 int             `json:"health"`              // Current health (0-maxHealth)
+	Shield                 int             `json:"shield"`              // Current shield (0-ShieldMax), absorbs damage before health
 	IsInvulnerable         bool            `json:"isInvulnerable"`      // Spawn protection flag
 	InvulnerabilityEndTime time.Time       `json:"invulnerabilityEnd"`  // When spawn protection ends
 	DeathTime              *time.Time      `json:"deathTime,omitempty"` // When player died (nil if alive)
@@ -86,6 +97,31 @@ type PlayerState struct {
 	rollState              RollState       // Private field: dodge roll state
 	correctionStats        CorrectionStats // Private field: correction tracking for anti-cheat
 	clock                  Clock           // Private field: clock for time operations (injectable for testing)
+	cooldowns              PlayerCooldowns // Private field: ability cooldowns (the world's manager once added)
+	ultimateCharge         float64         // Private field: ultimate meter (0-100)
+	ultimateEffect         UltimateEffect  // Private field: effect of the last activated ultimate
+	ultimateEndsAt         time.Time       // Private field: when the active ultimate's effect ends
+	class                  PlayerClass     // Private field: class of the current life
+	nextClass              PlayerClass     // Private field: class applied on the next respawn
+	lastActiveAt           time.Time       // Private field: last input change or attack (zero if never)
+	participationTime      time.Duration   // Private field: active time toward the next participation reward
+	killStreak             int             // Private field: kills since the player last died
+	bestKillStreak         int             // Private field: longest kill streak this match
+	multiKill              int             // Private field: kills chained within MultiKillWindow
+	lastKillAt             time.Time       // Private field: when the player last killed
+	bounds                 Vector2         // Private field: width and height of the map positions are clamped to
+	quarantinedAt          time.Time       // Private field: when a non-finite write froze the player (zero if not)
+	teleport               TeleportReason  // Private field: why the player last jumped position
+	teleportedAt           time.Time       // Private field: when the player last jumped position
+	respawnRequested       bool            // Private field: the dead player has asked to respawn
+	spawnChosen            bool            // Private field: the dead player has picked a spawn point
+	spawnChoice            int             // Private field: index of the picked spawn point
+	stamina                float64         // Private field: stamina (0-MaxStamina), spent by dodge rolls
+	lastStaminaSpend       time.Time       // Private field: when stamina was last spent
+	dodgeRoll              DodgeRollConfig // Private field: dodge roll i-frames and stamina cost
+	leveling               LevelCurve      // Private field: XP per level and level unlocks
+	recordedXP             int             // Private field: XP from recorded matches before this session, counted toward the level
+	level                  int             // Private field: level last reached
 	mu                     sync.RWMutex
 }
 
@@ -109,13 +145,26 @@ func NewPlayerStateWithClock(id string, clock Clock) *PlayerState {
 		input:          InputState{},
 		clock:          clock,
 		lastDamageTime: clock.Now(), // Initialize to prevent immediate regeneration
+		cooldowns:      NewCooldownManager(clock).For(id),
+		class:          standardClass,
+		nextClass:      standardClass,
+		bounds:         Vector2{X: mapConfig.Width, Y: mapConfig.Height},
+		stamina:        MaxStamina,
+		dodgeRoll:      DodgeRollConfig{}.withDefaults(),
+		leveling:       LevelCurve{}.withDefaults(),
+		level:          1,
 	}
 }
 
 // SetInput updates the player's input state (thread-safe)
+// A changed input marks the player active; a client resending the same
+// input does not.
 func (p *PlayerState) SetInput(input InputState) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
+	if input != p.input {
+		p.lastActiveAt = p.clock.Now()
+	}
 	p.input = input
 }
 
@@ -126,11 +175,12 @@ func (p *PlayerState) GetInput() InputState {
 	return p.input
 }
 
-// SetPosition updates the player's position (thread-safe)
+// SetPosition updates the player's position, clamped to the map (thread-safe).
+// A non-finite position is not written; it quarantines the player instead.
 func (p *PlayerState) SetPosition(pos Vector2) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	p.Position = pos
+	p.setPositionLocked(pos, "position")
 }
 
 // GetPosition retrieves the player's position (thread-safe)
@@ -140,10 +190,15 @@ func (p *PlayerState) GetPosition() Vector2 {
 	return p.Position
 }
 
-// SetVelocity updates the player's velocity (thread-safe)
+// SetVelocity updates the player's velocity (thread-safe). A non-finite
+// velocity is not written; it quarantines the player instead.
 func (p *PlayerState) SetVelocity(vel Vector2) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
+	if !isFiniteVector2(vel) {
+		p.quarantineLocked("velocity", vel)
+		return
+	}
 	p.Velocity = vel
 }
 
@@ -169,12 +224,14 @@ func (p *PlayerState) GetAimAngle() float64 {
 }
 
 // TakeDamage reduces the player's health by the given amount (thread-safe)
-// Health will not go below 0
+// Shield absorbs damage first; health will not go below 0
 // Updates lastDamageTime to reset regeneration timer
 func (p *PlayerState) TakeDamage(amount int) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	p.Health -= amount
+	absorbed := min(max(amount, 0), p.Shield)
+	p.Shield -= absorbed
+	p.Health -= amount - absorbed
 	if p.Health < 0 {
 		p.Health = 0
 	}
@@ -183,6 +240,33 @@ func (p *PlayerState) TakeDamage(amount int) {
 	p.regenAccumulator = 0.0       // Reset regeneration accumulator
 }
 
+// AddShield raises the player's shield by amount, capped at ShieldMax, and
+// returns the new shield (thread-safe)
+func (p *PlayerState) AddShield(amount int) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Shield = min(p.Shield+max(amount, 0), ShieldMax)
+	return p.Shield
+}
+
+// RestoreHealth heals a living player by amount, capped at the class's max
+// health, and returns the new health (thread-safe)
+func (p *PlayerState) RestoreHealth(amount int) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.DeathTime == nil {
+		p.Health = min(p.Health+max(amount, 0), p.class.MaxHealth)
+	}
+	return p.Health
+}
+
+// GetShield returns the player's current shield (thread-safe)
+func (p *PlayerState) GetShield() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.Shield
+}
+
 // IsAlive returns true if the player has health remaining (thread-safe)
 func (p *PlayerState) IsAlive() bool {
 	p.mu.RLock()
@@ -202,6 +286,10 @@ func (p *PlayerState) Snapshot() PlayerStateSnapshot {
 		AimAngle:               p.AimAngle,
 		WeaponType:             "",
 		Health:                 p.Health,
+		Shield:                 p.Shield,
+		Stamina:                int(p.stamina),
+		MaxHealth:              p.class.MaxHealth,
+		Class:                  p.class.Name,
 		IsInvulnerable:         p.IsInvulnerable,
 		InvulnerabilityEndTime: p.InvulnerabilityEndTime,
 		DeathTime:              p.DeathTime,
@@ -210,9 +298,32 @@ func (p *PlayerState) Snapshot() PlayerStateSnapshot {
 		XP:                     p.XP,
 		IsRegeneratingHealth:   p.IsRegeneratingHealth,
 		Rolling:                p.Rolling,
+		UltimateCharge:         int(p.ultimateCharge),
+		UltimateActive:         p.ultimateActiveLocked(),
+		Teleport:               p.teleportFlagLocked(),
 	}
 }
 
+// restore copies the broadcast parts of a snapshot back into the player
+// (thread-safe)
+func (p *PlayerState) restore(snapshot PlayerStateSnapshot) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.DisplayName = snapshot.DisplayName
+	p.Position = snapshot.Position
+	p.Velocity = snapshot.Velocity
+	p.AimAngle = snapshot.AimAngle
+	p.Health = snapshot.Health
+	p.Shield = snapshot.Shield
+	p.IsInvulnerable = snapshot.IsInvulnerable
+	p.InvulnerabilityEndTime = snapshot.InvulnerabilityEndTime
+	p.DeathTime = snapshot.DeathTime
+	p.Kills = snapshot.Kills
+	p.Deaths = snapshot.Deaths
+	p.XP = snapshot.XP
+	p.ultimateCharge = float64(snapshot.UltimateCharge)
+}
+
 func (p *PlayerState) SetDisplayName(displayName string) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -226,6 +337,12 @@ func (p *PlayerState) MarkDead() {
 	now := p.clock.Now()
 	p.DeathTime = &now
 	p.Health = 0
+	p.Shield = 0                   // Shield is lost on death
+	p.ultimateEndsAt = time.Time{} // Death ends an active ultimate
+	p.killStreak = 0               // Death ends kill streaks and multi-kills
+	p.multiKill = 0
+	p.respawnRequested = false
+	p.spawnChosen = false
 }
 
 // IsDead returns true if the player is currently dead (thread-safe)
@@ -245,18 +362,76 @@ func (p *PlayerState) CanRespawn() bool {
 	return p.clock.Since(*p.DeathTime).Seconds() >= RespawnDelay
 }
 
-// Respawn resets the player to alive state at the given position (thread-safe)
+// RequestRespawn records that a dead player wants to respawn as soon as the
+// respawn delay allows. Returns false if the player is alive (thread-safe)
+func (p *PlayerState) RequestRespawn() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.DeathTime == nil {
+		return false
+	}
+	p.respawnRequested = true
+	return true
+}
+
+// ChooseSpawn records the spawn point a dead player wants to respawn at.
+// Returns false if the player is alive (thread-safe)
+func (p *PlayerState) ChooseSpawn(index int) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.DeathTime == nil {
+		return false
+	}
+	p.spawnChosen = true
+	p.spawnChoice = index
+	return true
+}
+
+// SpawnChoice returns the spawn point index picked with ChooseSpawn since the
+// player died, if any (thread-safe)
+func (p *PlayerState) SpawnChoice() (int, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.spawnChoice, p.spawnChosen
+}
+
+// RespawnDue returns true once a dead player should respawn: after the
+// respawn delay if they asked to, and after AutoRespawnDelay regardless, so
+// waiting out a bad moment cannot keep a player out of the match (thread-safe)
+func (p *PlayerState) RespawnDue() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.DeathTime == nil {
+		return false
+	}
+	dead := p.clock.Since(*p.DeathTime).Seconds()
+	if p.respawnRequested {
+		return dead >= RespawnDelay
+	}
+	return dead >= AutoRespawnDelay
+}
+
+// Respawn resets the player to alive state at the given position, switching
+// to the class picked with SetClass (thread-safe)
 func (p *PlayerState) Respawn(spawnPos Vector2) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	p.Health = PlayerMaxHealth
-	p.Position = spawnPos
+	p.class = p.nextClass
+	p.Health = p.class.MaxHealth
+	p.Shield = 0
+	if p.setPositionLocked(spawnPos, "respawn position") {
+		p.quarantinedAt = time.Time{}
+		p.markTeleportLocked(TeleportRespawn)
+	}
 	p.Velocity = Vector2{X: 0, Y: 0}
 	p.DeathTime = nil
+	p.respawnRequested = false
+	p.spawnChosen = false
 	p.IsInvulnerable = true
 	p.InvulnerabilityEndTime = p.clock.Now().Add(time.Duration(SpawnInvulnerabilityDuration * float64(time.Second)))
 	p.regenAccumulator = 0.0         // Clear regeneration accumulator on respawn
 	p.lastDamageTime = p.clock.Now() // Reset regeneration timer to prevent immediate regeneration
+	p.stamina = MaxStamina
 }
 
 // UpdateInvulnerability checks and updates invulnerability status (thread-safe)
@@ -275,6 +450,43 @@ func (p *PlayerState) IncrementKills() {
 	p.Kills++
 }
 
+// RecordKillStreak counts a kill toward the player's streak and multi-kill
+// chain and returns the bonus XP it earned (thread-safe)
+func (p *PlayerState) RecordKillStreak() KillStreak {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.clock.Now()
+	if p.multiKill > 0 && now.Sub(p.lastKillAt).Seconds() <= MultiKillWindow {
+		p.multiKill++
+	} else {
+		p.multiKill = 1
+	}
+	p.lastKillAt = now
+	p.killStreak++
+	p.bestKillStreak = max(p.bestKillStreak, p.killStreak)
+
+	return KillStreak{
+		Streak:    p.killStreak,
+		MultiKill: p.multiKill,
+		BonusXP:   killStreakBonusXP(p.killStreak, p.multiKill),
+	}
+}
+
+// GetKillStreak returns the player's current kill streak (thread-safe)
+func (p *PlayerState) GetKillStreak() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.killStreak
+}
+
+// GetBestKillStreak returns the player's longest kill streak (thread-safe)
+func (p *PlayerState) GetBestKillStreak() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.bestKillStreak
+}
+
 // IncrementDeaths increments the player's death count (thread-safe)
 func (p *PlayerState) IncrementDeaths() {
 	p.mu.Lock()
@@ -319,7 +531,7 @@ func (p *PlayerState) CanRegenerate(now time.Time) bool {
 	}
 
 	// Cannot regenerate if at full health
-	if p.Health >= PlayerMaxHealth {
+	if p.Health >= p.class.MaxHealth {
 		return false
 	}
 
@@ -331,11 +543,17 @@ func (p *PlayerState) CanRegenerate(now time.Time) bool {
 // ApplyRegeneration applies health regeneration for the given deltaTime (thread-safe)
 // Only regenerates if conditions are met (delay passed, not at max health, not dead)
 func (p *PlayerState) ApplyRegeneration(now time.Time, deltaTime float64) {
+	p.ApplyRegenerationWith(now, deltaTime, nil)
+}
+
+// ApplyRegenerationWith is ApplyRegeneration with modify adjusting each whole
+// HP amount before it is applied. modify runs while the player is locked.
+func (p *PlayerState) ApplyRegenerationWith(now time.Time, deltaTime float64, modify func(amount int) int) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	// Check if we can regenerate
-	if p.DeathTime != nil || p.Health >= PlayerMaxHealth {
+	if p.DeathTime != nil || p.Health >= p.class.MaxHealth {
 		p.IsRegeneratingHealth = false
 		return
 	}
@@ -354,18 +572,21 @@ func (p *PlayerState) ApplyRegeneration(now time.Time, deltaTime float64) {
 	// Apply accumulated HP as integer value
 	if p.regenAccumulator >= 1.0 {
 		regenAmount := int(p.regenAccumulator)
-		p.Health += regenAmount
 		p.regenAccumulator -= float64(regenAmount) // Keep the fractional remainder
+		if modify != nil {
+			regenAmount = modify(regenAmount)
+		}
+		p.Health += regenAmount
 	}
 
 	// Cap at max health and clear accumulator
-	if p.Health >= PlayerMaxHealth {
-		p.Health = PlayerMaxHealth
+	if p.Health >= p.class.MaxHealth {
+		p.Health = p.class.MaxHealth
 		p.regenAccumulator = 0.0 // Clear accumulator at max health
 	}
 
 	// Update regeneration state
-	p.IsRegeneratingHealth = p.Health < PlayerMaxHealth
+	p.IsRegeneratingHealth = p.Health < p.class.MaxHealth
 }
 
 // UpdateRegenerationState updates the IsRegeneratingHealth flag based on current conditions (thread-safe)
@@ -374,7 +595,7 @@ func (p *PlayerState) UpdateRegenerationState(now time.Time) {
 	defer p.mu.Unlock()
 
 	// Update regeneration state
-	if p.DeathTime != nil || p.Health >= PlayerMaxHealth {
+	if p.DeathTime != nil || p.Health >= p.class.MaxHealth {
 		p.IsRegeneratingHealth = false
 		return
 	}
@@ -391,7 +612,7 @@ func (p *PlayerState) IsRegenerating() bool {
 }
 
 // CanDodgeRoll checks if the player can initiate a dodge roll (thread-safe)
-// Returns false if on cooldown, already rolling, or dead
+// Returns false if on cooldown, out of stamina, already rolling, or dead
 func (p *PlayerState) CanDodgeRoll() bool {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
@@ -406,23 +627,28 @@ func (p *PlayerState) CanDodgeRoll() bool {
 		return false
 	}
 
-	// Check cooldown
-	now := p.clock.Now()
-	timeSinceLastRoll := now.Sub(p.rollState.LastRollTime).Seconds()
-	return timeSinceLastRoll >= DodgeRollCooldown
+	// Cannot roll without the stamina to pay for it
+	if p.stamina < p.dodgeRoll.StaminaCost {
+		return false
+	}
+
+	charges, _ := p.cooldowns.Charges(CooldownRoll, p.class.rollRecharge(), p.class.RollCharges)
+	return charges > 0
 }
 
-// StartDodgeRoll initiates a dodge roll in the given direction (thread-safe)
-// Direction should be normalized before calling
+// StartDodgeRoll initiates a dodge roll in the given direction, spending its
+// stamina (thread-safe). Direction should be normalized before calling
 func (p *PlayerState) StartDodgeRoll(direction Vector2) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	now := p.clock.Now()
+	p.lastActiveAt = now
 	p.rollState.IsRolling = true
 	p.rollState.RollStartTime = now
 	p.rollState.RollDirection = direction
 	p.Rolling = true // Update public field for JSON export
+	p.spendStaminaLocked(p.dodgeRoll.StaminaCost, now)
 }
 
 // EndDodgeRoll ends the current dodge roll (thread-safe)
@@ -431,7 +657,7 @@ func (p *PlayerState) EndDodgeRoll() {
 	defer p.mu.Unlock()
 
 	p.rollState.IsRolling = false
-	p.rollState.LastRollTime = p.clock.Now()
+	p.cooldowns.Spend(CooldownRoll, p.class.rollRecharge(), p.class.RollCharges)
 	p.Rolling = false // Update public field for JSON export
 }
 
@@ -451,7 +677,7 @@ func (p *PlayerState) GetRollState() RollState {
 }
 
 // IsInvincibleFromRoll checks if the player is currently invincible due to dodge roll i-frames (thread-safe)
-// Returns true if rolling and within the first 0.2 seconds
+// Returns true if rolling and within the configured i-frames (0.2 seconds by default)
 func (p *PlayerState) IsInvincibleFromRoll() bool {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
@@ -460,8 +686,16 @@ func (p *PlayerState) IsInvincibleFromRoll() bool {
 		return false
 	}
 
-	timeSinceRollStart := p.clock.Since(p.rollState.RollStartTime).Seconds()
-	return timeSinceRollStart < DodgeRollInvincibilityDuration
+	return p.clock.Since(p.rollState.RollStartTime) < p.dodgeRoll.Invincibility
+}
+
+// IsDamageImmune reports whether spawn protection or dodge roll i-frames
+// currently block all damage to the player (thread-safe)
+func (p *PlayerState) IsDamageImmune() bool {
+	p.mu.RLock()
+	protected := p.IsInvulnerable
+	p.mu.RUnlock()
+	return protected || p.IsInvincibleFromRoll()
 }
 
 // SetInputSequence updates the last processed input sequence number (thread-safe)
@@ -471,6 +705,18 @@ func (p *PlayerState) SetInputSequence(seq uint64) {
 	p.inputSequence = seq
 }
 
+// AdvanceInputSequence records seq as the last processed input sequence unless
+// it is older than the current one; returns false for stale inputs (thread-safe)
+func (p *PlayerState) AdvanceInputSequence(seq uint64) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if seq < p.inputSequence {
+		return false
+	}
+	p.inputSequence = seq
+	return true
+}
+
 // GetInputSequence retrieves the last processed input sequence number (thread-safe)
 func (p *PlayerState) GetInputSequence() uint64 {
 	p.mu.RLock()
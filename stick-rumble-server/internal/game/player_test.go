@@ -266,6 +266,63 @@ func TestPlayerState_TakeDamage_Overkill(t *testing.T) {
 	}
 }
 
+func TestPlayerState_TakeDamage_ShieldAbsorbsFirst(t *testing.T) {
+	player := NewPlayerState("test-player")
+
+	if shield := player.AddShield(ShieldPickupAmount + 10); shield != ShieldMax {
+		t.Fatalf("Shield after pickup = %v, want capped at %v", shield, ShieldMax)
+	}
+
+	// Shield soaks the first 30 damage entirely
+	player.TakeDamage(30)
+	if player.GetShield() != ShieldMax-30 || player.Health != PlayerMaxHealth {
+		t.Errorf("After 30 damage shield = %v health = %v, want %v and %v", player.GetShield(), player.Health, ShieldMax-30, PlayerMaxHealth)
+	}
+
+	// Damage past the remaining shield spills into health
+	player.TakeDamage(30)
+	if player.GetShield() != 0 || player.Health != PlayerMaxHealth-10 {
+		t.Errorf("After 60 damage shield = %v health = %v, want 0 and %v", player.GetShield(), player.Health, PlayerMaxHealth-10)
+	}
+	if snapshot := player.Snapshot(); snapshot.Shield != 0 {
+		t.Errorf("Snapshot shield = %v, want 0", snapshot.Shield)
+	}
+}
+
+func TestPlayerState_ShieldLostOnDeath(t *testing.T) {
+	player := NewPlayerState("test-player")
+	player.AddShield(ShieldPickupAmount)
+
+	player.MarkDead()
+	if player.GetShield() != 0 {
+		t.Errorf("Shield after death = %v, want 0", player.GetShield())
+	}
+
+	player.AddShield(ShieldPickupAmount)
+	player.Respawn(Vector2{X: 100, Y: 100})
+	if player.GetShield() != 0 {
+		t.Errorf("Shield after respawn = %v, want 0", player.GetShield())
+	}
+}
+
+func TestPlayerState_RestoreHealth(t *testing.T) {
+	player := NewPlayerState("test-player")
+	player.TakeDamage(70)
+
+	if got := player.RestoreHealth(HealthPackAmount); got != PlayerMaxHealth-70+HealthPackAmount {
+		t.Errorf("Health after first pack = %v, want %v", got, PlayerMaxHealth-70+HealthPackAmount)
+	}
+	if got := player.RestoreHealth(HealthPackAmount); got != PlayerMaxHealth {
+		t.Errorf("Health after second pack = %v, want capped at %v", got, PlayerMaxHealth)
+	}
+
+	player.TakeDamage(PlayerMaxHealth)
+	player.MarkDead()
+	if got := player.RestoreHealth(HealthPackAmount); got != 0 {
+		t.Errorf("Dead player healed to %v, want 0", got)
+	}
+}
+
 func TestPlayerState_TakeDamage_MultipleTimes(t *testing.T) {
 	player := NewPlayerState("test-player")
 
@@ -421,6 +478,67 @@ func TestPlayerState_CanRespawn_AfterDelay(t *testing.T) {
 	}
 }
 
+func TestPlayerState_RespawnDue_WaitsForRequest(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	player := NewPlayerStateWithClock("test-player", clock)
+
+	if player.RequestRespawn() {
+		t.Error("A living player should not be able to request a respawn")
+	}
+
+	player.MarkDead()
+	clock.Advance(time.Duration(RespawnDelay*float64(time.Second)) + 100*time.Millisecond)
+	if player.RespawnDue() {
+		t.Error("Should not respawn after RespawnDelay without a request")
+	}
+
+	if !player.RequestRespawn() {
+		t.Error("A dead player should be able to request a respawn")
+	}
+	if !player.RespawnDue() {
+		t.Error("Should respawn once requested after RespawnDelay")
+	}
+
+	player.Respawn(Vector2{X: 100, Y: 100})
+	player.MarkDead()
+	if player.RespawnDue() {
+		t.Error("A respawn request should not carry over to the next death")
+	}
+}
+
+func TestPlayerState_RespawnDue_EarlyRequestWaitsForDelay(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	player := NewPlayerStateWithClock("test-player", clock)
+	player.MarkDead()
+	player.RequestRespawn()
+
+	clock.Advance(time.Second)
+	if player.RespawnDue() {
+		t.Error("Should not respawn before RespawnDelay even when requested")
+	}
+
+	clock.Advance(time.Duration(RespawnDelay * float64(time.Second)))
+	if !player.RespawnDue() {
+		t.Error("An early request should be honored once RespawnDelay has passed")
+	}
+}
+
+func TestPlayerState_RespawnDue_AutoRespawnCap(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	player := NewPlayerStateWithClock("test-player", clock)
+	player.MarkDead()
+
+	clock.Advance(time.Duration(AutoRespawnDelay*float64(time.Second)) - 100*time.Millisecond)
+	if player.RespawnDue() {
+		t.Error("Should not auto-respawn before AutoRespawnDelay")
+	}
+
+	clock.Advance(100 * time.Millisecond)
+	if !player.RespawnDue() {
+		t.Error("Should auto-respawn after AutoRespawnDelay without a request")
+	}
+}
+
 func TestPlayerState_Respawn(t *testing.T) {
 	player := NewPlayerState("test-player")
 
@@ -0,0 +1,94 @@
+package game
+
+import (
+	"log"
+	"math"
+	"time"
+)
+
+// PositionQuarantineDuration is how long a player frozen by a non-finite
+// position or velocity stays in place before being moved to a spawn point
+const PositionQuarantineDuration = 2 * time.Second
+
+// isFiniteVector2 reports whether neither component is NaN or infinite
+func isFiniteVector2(v Vector2) bool {
+	return !math.IsNaN(v.X) && !math.IsInf(v.X, 0) && !math.IsNaN(v.Y) && !math.IsInf(v.Y, 0)
+}
+
+// setPositionLocked writes pos clamped to the player's map bounds, or
+// quarantines the player if pos is not finite. It reports whether pos was
+// written. Callers hold p.mu for writing.
+func (p *PlayerState) setPositionLocked(pos Vector2, what string) bool {
+	if !isFiniteVector2(pos) {
+		p.quarantineLocked(what, pos)
+		return false
+	}
+	p.Position = clampToArena(pos, MapConfig{Width: p.bounds.X, Height: p.bounds.Y})
+	return true
+}
+
+// quarantineLocked freezes the player at its last valid position after a
+// write of a non-finite value, instead of letting the value reach physics or
+// clients. Callers hold p.mu for writing.
+func (p *PlayerState) quarantineLocked(what string, value Vector2) {
+	p.Velocity = Vector2{}
+	if !p.quarantinedAt.IsZero() {
+		return
+	}
+	p.quarantinedAt = p.clock.Now()
+	log.Printf("ERROR: Player %s quarantined: non-finite %s %+v, held at %+v", p.ID, what, value, p.Position)
+}
+
+// IsQuarantined reports whether a non-finite position or velocity froze the
+// player (thread-safe)
+func (p *PlayerState) IsQuarantined() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return !p.quarantinedAt.IsZero()
+}
+
+// quarantineServed reports whether the player has been quarantined for
+// PositionQuarantineDuration (thread-safe)
+func (p *PlayerState) quarantineServed() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return !p.quarantinedAt.IsZero() && p.clock.Since(p.quarantinedAt) >= PositionQuarantineDuration
+}
+
+// releaseQuarantine moves a quarantined player to spawnPos and lets physics
+// move it again. It reports whether the player was released.
+func (p *PlayerState) releaseQuarantine(spawnPos Vector2) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.quarantinedAt.IsZero() {
+		return false
+	}
+	if !p.setPositionLocked(spawnPos, "release position") {
+		return false
+	}
+	p.Velocity = Vector2{}
+	p.quarantinedAt = time.Time{}
+	p.markTeleportLocked(TeleportForceSync)
+	return true
+}
+
+// releaseQuarantinedPlayers moves players whose quarantine is over to a
+// balanced spawn point
+func (gs *GameServer) releaseQuarantinedPlayers() {
+	gs.world.mu.RLock()
+	players := make([]*PlayerState, 0, len(gs.world.players))
+	for _, player := range gs.world.players {
+		players = append(players, player)
+	}
+	gs.world.mu.RUnlock()
+
+	for _, player := range players {
+		if !player.quarantineServed() {
+			continue
+		}
+		spawnPos := gs.world.GetBalancedSpawnPoint(player.ID)
+		if player.releaseQuarantine(spawnPos) {
+			log.Printf("Player %s released from quarantine at %+v", player.ID, spawnPos)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package game
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayerState_SetPositionClampsToMapBounds(t *testing.T) {
+	world := NewWorld(MapConfig{Width: 800, Height: 600})
+	player := world.AddPlayer("p1")
+
+	player.SetPosition(Vector2{X: -50, Y: 5000})
+	assert.Equal(t, Vector2{X: PlayerWidth / 2, Y: 600 - PlayerHeight/2}, player.GetPosition())
+
+	player.Respawn(Vector2{X: 900, Y: 300})
+	assert.Equal(t, Vector2{X: 800 - PlayerWidth/2, Y: 300}, player.GetPosition())
+}
+
+func TestPlayerState_NonFiniteWritesQuarantine(t *testing.T) {
+	player := NewPlayerStateWithClock("p1", NewManualClock(guardStart))
+	player.SetPosition(Vector2{X: 300, Y: 400})
+	player.SetVelocity(Vector2{X: 50, Y: 0})
+
+	player.SetPosition(Vector2{X: math.NaN(), Y: 400})
+	assert.True(t, player.IsQuarantined())
+	assert.Equal(t, Vector2{X: 300, Y: 400}, player.GetPosition(), "the last valid position is kept")
+	assert.Equal(t, Vector2{}, player.GetVelocity())
+
+	player.Respawn(Vector2{X: 500, Y: 500})
+	assert.False(t, player.IsQuarantined(), "respawning at a valid spawn point ends the quarantine")
+
+	player.SetVelocity(Vector2{X: math.Inf(1), Y: 0})
+	assert.True(t, player.IsQuarantined())
+	assert.Equal(t, Vector2{}, player.GetVelocity())
+}
+
+func TestGameServerFreezesAndReleasesQuarantinedPlayers(t *testing.T) {
+	sink := &recordingGameLoopSink{}
+	clock := NewManualClock(guardStart)
+	gs := newGameServerWithSink(clock, sink)
+	player := gs.AddPlayer("p1")
+	player.SetInput(InputState{Right: true})
+	gs.checkMovement(gs.activePlayers(nil), guardTick, clock.Now())
+
+	player.SetPosition(Vector2{X: math.Inf(-1), Y: 100})
+	held := player.GetPosition()
+	gs.updateAllPlayers(gs.activePlayers(nil), guardTick)
+	gs.checkMovement(gs.activePlayers(nil), guardTick, clock.Now())
+	assert.Equal(t, held, player.GetPosition(), "a quarantined player does not move")
+
+	clock.Advance(PositionQuarantineDuration - time.Millisecond)
+	gs.releaseQuarantinedPlayers()
+	require.True(t, player.IsQuarantined())
+
+	clock.Advance(time.Millisecond)
+	gs.releaseQuarantinedPlayers()
+	assert.False(t, player.IsQuarantined())
+	gs.checkMovement(gs.activePlayers(nil), guardTick, clock.Now())
+	assert.Empty(t, sink.events, "moving to a spawn point on release is not a teleport")
+}
+
+func TestProjectileManagerQuarantinesNonFiniteProjectiles(t *testing.T) {
+	pm := NewProjectileManager()
+	proj := pm.CreateProjectile("p1", "Pistol", Vector2{X: 100, Y: 100}, math.NaN(), 800)
+	require.NotNil(t, proj)
+
+	pm.Update(guardTick)
+	assert.Nil(t, pm.GetProjectileByID(proj.ID))
+}
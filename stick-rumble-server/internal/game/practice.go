@@ -0,0 +1,41 @@
+package game
+
+import "log"
+
+// PracticeBotCount is how many bots a practice room starts with
+const PracticeBotCount = 3
+
+// joinPracticeLocked puts a player alone in a fresh practice room and starts
+// its match straight away. Any configured bot filler supplies the opponents,
+// whose difficulty follows the player's K/D against them. Practice players
+// skip matchmaking, so they count toward neither the queue nor its metrics.
+// Called with rm.mu held.
+func (f *RoomSessionFlow) joinPracticeLocked(player *Player) RoomSessionResult {
+	rm := f.roomManager
+	room := rm.newRoomLocked(RoomKindPractice, "")
+	room.Practice = NewAdaptiveBotDifficulty()
+
+	_ = room.AddPlayer(player)
+	room.Match.RegisterPlayer(player.ID)
+	rm.playerToRoom[player.ID] = room.ID
+
+	if rm.botFiller != nil {
+		for _, bot := range rm.botFiller.FillBots(room, PracticeBotCount) {
+			if err := room.AddPlayer(bot); err != nil {
+				break
+			}
+			room.Match.RegisterPlayer(bot.ID)
+			rm.playerToRoom[bot.ID] = room.ID
+		}
+	}
+	rm.rooms[room.ID] = room
+
+	room.Match.Start()
+	log.Printf("Practice room %s started for player %s with %d players", room.ID, player.ID, room.PlayerCount())
+
+	return RoomSessionResult{
+		Room:         room,
+		Publications: sessionPublicationsForRoom(room, SessionStatusMatchReady),
+		Activations:  sessionActivationsForRoom(room),
+	}
+}
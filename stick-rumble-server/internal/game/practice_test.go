@@ -0,0 +1,60 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPracticeHelloStartsSoloRoomWithBots(t *testing.T) {
+	manager := NewRoomManager()
+	filler := &stubBotFiller{}
+	manager.SetBotFiller(filler)
+	flow := manager.SessionFlow()
+
+	result := flow.HandleHello(newSessionFlowPlayer("player-1"), map[string]any{"mode": "practice"})
+
+	require.Nil(t, result.Rejection)
+	require.NotNil(t, result.Room)
+	assert.Equal(t, RoomKindPractice, result.Room.Kind)
+	require.NotNil(t, result.Room.Practice)
+	assert.Equal(t, "normal", result.Room.Practice.Difficulty().Name)
+	assert.True(t, result.Room.Match.IsStarted(), "practice starts without a ready check")
+	assert.Equal(t, []int{PracticeBotCount}, filler.requested)
+	assert.Equal(t, 1+PracticeBotCount, result.Room.PlayerCount())
+	assert.Len(t, result.Activations, 1+PracticeBotCount)
+	for _, publication := range result.Publications {
+		assert.Equal(t, SessionStatusMatchReady, publication.State)
+	}
+	assert.Empty(t, manager.waitingPlayers, "practice players skip matchmaking")
+	assert.Equal(t, result.Room, manager.GetRoomByPlayerID("player-1"))
+
+	second := flow.HandleHello(newSessionFlowPlayer("player-2"), map[string]any{"mode": "practice"})
+	require.NotNil(t, second.Room)
+	assert.NotEqual(t, result.Room.ID, second.Room.ID, "each practice player gets a room of their own")
+}
+
+func TestPracticeHelloWithoutBotFillerStartsAlone(t *testing.T) {
+	manager := NewRoomManager()
+
+	result := manager.SessionFlow().HandleHello(newSessionFlowPlayer("player-1"), map[string]any{"mode": "practice"})
+
+	require.NotNil(t, result.Room)
+	assert.Equal(t, 1, result.Room.PlayerCount())
+	assert.True(t, result.Room.Match.IsStarted())
+}
+
+func TestPracticeHelloNeedsRoomCapacity(t *testing.T) {
+	manager := NewRoomManager()
+	manager.SetCapacityLimits(CapacityLimits{MaxRooms: 1})
+	flow := manager.SessionFlow()
+
+	first := flow.HandleHello(newSessionFlowPlayer("player-1"), map[string]any{"mode": "practice"})
+	require.NotNil(t, first.Room)
+
+	rejected := flow.HandleHello(newSessionFlowPlayer("player-2"), map[string]any{"mode": "practice"})
+	require.NotNil(t, rejected.Rejection)
+	assert.Equal(t, RoomSessionRejectionAtCapacity, rejected.Rejection.Kind)
+	assert.Equal(t, 1, rejected.Rejection.QueuePosition)
+}
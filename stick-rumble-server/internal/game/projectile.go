@@ -1,6 +1,7 @@
 package game
 
 import (
+	"log"
 	"math"
 	"sync"
 	"time"
@@ -10,16 +11,22 @@ import (
 
 // Projectile represents a bullet/projectile in the game world
 type Projectile struct {
-	ID             string    `json:"id"`
-	OwnerID        string    `json:"ownerId"`
-	WeaponType     string    `json:"weaponType"`
-	Position       Vector2   `json:"position"`
-	PreviousPos    Vector2   `json:"-"`
-	Velocity       Vector2   `json:"velocity"`
-	SpawnPosition  Vector2   `json:"-"` // Initial position for range validation
-	CreatedAt      time.Time `json:"-"`
-	Active         bool      `json:"-"`
-	PendingRemoval bool      `json:"-"`
+	ID              string    `json:"id"`
+	OwnerID         string    `json:"ownerId"`
+	WeaponType      string    `json:"weaponType"`
+	Position        Vector2   `json:"position"`
+	PreviousPos     Vector2   `json:"-"`
+	Velocity        Vector2   `json:"velocity"`
+	EffectID        string    `json:"effectId,omitempty"` // Cosmetic trail effect, empty for the default trail
+	SpawnPosition   Vector2   `json:"-"`                  // Initial position for range validation
+	Damage          int       `json:"-"`                  // Damage a hit deals, 0 for the owner's current weapon damage
+	MaxRange        float64   `json:"-"`                  // Range past which it cannot hit, 0 for ProjectileMaxRange
+	SplashRadius    float64   `json:"-"`                  // Explosion radius on impact, 0 for projectiles that do not explode
+	SplashKnockback float64   `json:"-"`                  // Push at the center of the explosion
+	CreatedAt       time.Time `json:"-"`
+	seq             uint64    // Spawn order, for evicting the oldest at a cap
+	Active          bool      `json:"-"`
+	PendingRemoval  bool      `json:"-"`
 }
 
 // ProjectileSnapshot is the network-transmittable version of Projectile
@@ -33,7 +40,15 @@ type ProjectileSnapshot struct {
 
 // NewProjectile creates a new projectile with calculated velocity from angle
 func NewProjectile(ownerID string, weaponType string, startPos Vector2, aimAngle float64, speed float64) *Projectile {
-	return &Projectile{
+	proj := &Projectile{}
+	proj.reset(ownerID, weaponType, startPos, aimAngle, speed)
+	return proj
+}
+
+// reset turns p into a freshly fired projectile, overwriting every field so
+// a pooled projectile keeps nothing from its previous flight
+func (p *Projectile) reset(ownerID string, weaponType string, startPos Vector2, aimAngle float64, speed float64) {
+	*p = Projectile{
 		ID:            uuid.New().String(),
 		OwnerID:       ownerID,
 		WeaponType:    weaponType,
@@ -56,6 +71,24 @@ func (p *Projectile) Update(deltaTime float64) {
 	p.Position.Y += p.Velocity.Y * deltaTime
 }
 
+// IsExplosive returns true if the projectile explodes on impact
+func (p *Projectile) IsExplosive() bool {
+	return p.SplashRadius > 0
+}
+
+// maxRange returns how far from its spawn position the projectile can hit
+func (p *Projectile) maxRange() float64 {
+	if p.MaxRange > 0 {
+		return p.MaxRange
+	}
+	return ProjectileMaxRange
+}
+
+// IsOutOfRange returns true if the projectile has travelled past its max range
+func (p *Projectile) IsOutOfRange() bool {
+	return calculateDistance(p.SpawnPosition, p.Position) > p.maxRange()
+}
+
 // IsExpired returns true if the projectile has exceeded its max lifetime
 func (p *Projectile) IsExpired() bool {
 	return time.Since(p.CreatedAt) >= ProjectileMaxLifetime
@@ -84,10 +117,18 @@ func (p *Projectile) Snapshot() ProjectileSnapshot {
 	}
 }
 
-// ProjectileManager manages all active projectiles in the game
+// ProjectileManager manages all active projectiles in the game. Removed
+// projectiles go back to a pool and are reused by later shots, so a
+// *Projectile from the manager is only valid until the projectile is removed;
+// code outside the tick loop should hold a copy.
 type ProjectileManager struct {
 	mapConfig   MapConfig
 	projectiles map[string]*Projectile
+	pool        sync.Pool
+	toRemove    []string // Reused by Update
+	limits      ProjectileLimits
+	ownerCounts map[string]int // Projectiles in flight per owner
+	nextSeq     uint64
 	mu          sync.RWMutex
 }
 
@@ -96,6 +137,8 @@ func NewProjectileManager(mapConfigs ...MapConfig) *ProjectileManager {
 	return &ProjectileManager{
 		mapConfig:   resolveMapConfig(mapConfigs...),
 		projectiles: make(map[string]*Projectile),
+		limits:      ProjectileLimits{}.withDefaults(),
+		ownerCounts: make(map[string]int),
 	}
 }
 
@@ -104,22 +147,97 @@ func (pm *ProjectileManager) CreateProjectile(ownerID string, weaponType string,
 	pm.mu.Lock()
 	defer pm.mu.Unlock()
 
-	proj := NewProjectile(ownerID, weaponType, startPos, aimAngle, speed)
+	return pm.newProjectileLocked(ownerID, weaponType, startPos, aimAngle, speed)
+}
+
+// SpawnProjectile creates and adds a new projectile with a trail effect and
+// returns a copy of it, which stays valid after the projectile is recycled
+func (pm *ProjectileManager) SpawnProjectile(ownerID string, weaponType string, effectID string, startPos Vector2, aimAngle float64, speed float64) Projectile {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	proj := pm.newProjectileLocked(ownerID, weaponType, startPos, aimAngle, speed)
+	proj.EffectID = effectID
+	return *proj
+}
+
+// SpawnPellets fires one pellet per angle from startPos as a single shot and
+// returns copies of them. Each pellet deals damage on a hit and cannot hit
+// past maxRange.
+func (pm *ProjectileManager) SpawnPellets(ownerID string, weaponType string, effectID string, startPos Vector2, angles []float64, speed float64, damage []int, maxRange float64) []Projectile {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	pellets := make([]Projectile, len(angles))
+	for i, angle := range angles {
+		proj := pm.newProjectileLocked(ownerID, weaponType, startPos, angle, speed)
+		proj.EffectID = effectID
+		proj.Damage = damage[i]
+		proj.MaxRange = maxRange
+		pellets[i] = *proj
+	}
+	return pellets
+}
+
+// SpawnExplosive creates and adds a projectile that explodes on impact with a
+// player or wall, and returns a copy of it. The explosion deals damage at its
+// center and reaches splashRadius; knockback is the push at the center.
+func (pm *ProjectileManager) SpawnExplosive(ownerID string, weaponType string, effectID string, startPos Vector2, aimAngle float64, speed float64, damage int, maxRange float64, splashRadius float64, knockback float64) Projectile {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	proj := pm.newProjectileLocked(ownerID, weaponType, startPos, aimAngle, speed)
+	proj.EffectID = effectID
+	proj.Damage = damage
+	proj.MaxRange = maxRange
+	proj.SplashRadius = splashRadius
+	proj.SplashKnockback = knockback
+	return *proj
+}
+
+// newProjectileLocked adds a projectile, reusing a pooled one when available
+// and destroying the oldest ones if it would exceed the caps. Callers hold
+// pm.mu for writing.
+func (pm *ProjectileManager) newProjectileLocked(ownerID string, weaponType string, startPos Vector2, aimAngle float64, speed float64) *Projectile {
+	pm.makeRoomLocked(ownerID)
+
+	proj, _ := pm.pool.Get().(*Projectile)
+	if proj == nil {
+		proj = &Projectile{}
+	}
+	proj.reset(ownerID, weaponType, startPos, aimAngle, speed)
+	pm.nextSeq++
+	proj.seq = pm.nextSeq
 	pm.projectiles[proj.ID] = proj
+	pm.ownerCounts[ownerID]++
 	return proj
 }
 
+// removeLocked removes a projectile and returns it to the pool. Callers hold
+// pm.mu for writing.
+func (pm *ProjectileManager) removeLocked(id string) {
+	proj, exists := pm.projectiles[id]
+	if !exists {
+		return
+	}
+	delete(pm.projectiles, id)
+	if pm.ownerCounts[proj.OwnerID]--; pm.ownerCounts[proj.OwnerID] <= 0 {
+		delete(pm.ownerCounts, proj.OwnerID)
+	}
+	pm.pool.Put(proj)
+}
+
 // Update updates all projectiles and removes inactive ones
 func (pm *ProjectileManager) Update(deltaTime float64) {
 	pm.mu.Lock()
 	defer pm.mu.Unlock()
 
 	// Collect IDs to remove
-	toRemove := make([]string, 0)
+	toRemove := pm.toRemove[:0]
 
 	for id, proj := range pm.projectiles {
 		// Check if projectile should be removed
-		if !proj.Active || proj.PendingRemoval || proj.IsExpired() || proj.IsOutOfBounds(pm.mapConfig) {
+		if !proj.Active || proj.PendingRemoval || proj.IsExpired() || proj.IsOutOfRange() || proj.IsOutOfBounds(pm.mapConfig) {
 			toRemove = append(toRemove, id)
 			continue
 		}
@@ -127,6 +245,14 @@ func (pm *ProjectileManager) Update(deltaTime float64) {
 		// Update position
 		proj.Update(deltaTime)
 
+		// Quarantine a projectile whose motion went non-finite rather than
+		// letting it fly unchecked, since NaN never compares out of bounds
+		if !isFiniteVector2(proj.Position) || !isFiniteVector2(proj.Velocity) {
+			log.Printf("ERROR: Projectile %s quarantined: non-finite position %+v or velocity %+v", id, proj.Position, proj.Velocity)
+			toRemove = append(toRemove, id)
+			continue
+		}
+
 		// Check bounds after update
 		if proj.IsOutOfBounds(pm.mapConfig) {
 			toRemove = append(toRemove, id)
@@ -142,8 +268,9 @@ func (pm *ProjectileManager) Update(deltaTime float64) {
 
 	// Remove inactive projectiles
 	for _, id := range toRemove {
-		delete(pm.projectiles, id)
+		pm.removeLocked(id)
 	}
+	pm.toRemove = toRemove
 }
 
 // GetActiveProjectiles returns a slice of all active projectiles
@@ -182,7 +309,7 @@ func (pm *ProjectileManager) RemoveProjectile(id string) {
 	pm.mu.Lock()
 	defer pm.mu.Unlock()
 
-	delete(pm.projectiles, id)
+	pm.removeLocked(id)
 }
 
 // GetProjectilesByOwner returns all projectiles owned by a specific player
@@ -0,0 +1,116 @@
+package game
+
+// Projectile cap defaults. Automatic weapons under high fire-rate mutators
+// can otherwise fill the manager faster than projectiles expire.
+const (
+	DefaultMaxActiveProjectiles   = 1024
+	DefaultMaxProjectilesPerOwner = 64
+)
+
+// What the manager does once a cap is reached
+const (
+	ProjectileCapEvictOldest = "evict_oldest" // Fire anyway and destroy the oldest projectile
+	ProjectileCapReject      = "reject"       // Reject the shot before it uses ammo
+)
+
+// ProjectileLimits caps how many projectiles may be in flight at once. Zero
+// values fall back to the defaults.
+type ProjectileLimits struct {
+	MaxActive   int    // Across all owners
+	MaxPerOwner int    // For any one owner
+	Policy      string // ProjectileCapEvictOldest or ProjectileCapReject
+}
+
+func (l ProjectileLimits) withDefaults() ProjectileLimits {
+	if l.MaxActive <= 0 {
+		l.MaxActive = DefaultMaxActiveProjectiles
+	}
+	if l.MaxPerOwner <= 0 {
+		l.MaxPerOwner = DefaultMaxProjectilesPerOwner
+	}
+	if l.Policy != ProjectileCapReject {
+		l.Policy = ProjectileCapEvictOldest
+	}
+	return l
+}
+
+func newProjectileManagerWithLimits(mapConfig MapConfig, limits ProjectileLimits) *ProjectileManager {
+	pm := NewProjectileManager(mapConfig)
+	pm.limits = limits.withDefaults()
+	return pm
+}
+
+// projectilesPerShot returns how many projectiles one shot of weapon spawns
+func projectilesPerShot(weapon *Weapon) int {
+	if weapon.FiresPellets() {
+		return ShotgunPelletCount
+	}
+	return 1
+}
+
+// SetLimits replaces the projectile caps. Projectiles already in flight are
+// not destroyed; the new caps apply from the next spawn.
+func (pm *ProjectileManager) SetLimits(limits ProjectileLimits) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	pm.limits = limits.withDefaults()
+}
+
+// Limits returns the projectile caps in effect
+func (pm *ProjectileManager) Limits() ProjectileLimits {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	return pm.limits
+}
+
+// CanSpawn reports whether ownerID may fire count more projectiles. Under the
+// evict-oldest policy a spawn always succeeds, so it only returns false when
+// the caps reject new fire.
+func (pm *ProjectileManager) CanSpawn(ownerID string, count int) bool {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	if pm.limits.Policy != ProjectileCapReject {
+		return true
+	}
+	return len(pm.projectiles)+count <= pm.limits.MaxActive &&
+		pm.ownerCounts[ownerID]+count <= pm.limits.MaxPerOwner
+}
+
+// makeRoomLocked destroys the oldest projectiles until one more from ownerID
+// fits under both caps. It backs up CanSpawn, so even a rejecting manager
+// never grows past its caps. Callers hold pm.mu for writing.
+func (pm *ProjectileManager) makeRoomLocked(ownerID string) {
+	for pm.ownerCounts[ownerID] >= pm.limits.MaxPerOwner {
+		if !pm.evictOldestLocked(ownerID) {
+			break
+		}
+	}
+	for len(pm.projectiles) >= pm.limits.MaxActive {
+		if !pm.evictOldestLocked("") {
+			break
+		}
+	}
+}
+
+// evictOldestLocked removes the earliest spawned projectile, limited to
+// ownerID's when it is not empty, and reports whether one was removed.
+// Callers hold pm.mu for writing.
+func (pm *ProjectileManager) evictOldestLocked(ownerID string) bool {
+	var oldest *Projectile
+	for _, proj := range pm.projectiles {
+		if ownerID != "" && proj.OwnerID != ownerID {
+			continue
+		}
+		if oldest == nil || proj.seq < oldest.seq {
+			oldest = proj
+		}
+	}
+	if oldest == nil {
+		return false
+	}
+	pm.removeLocked(oldest.ID)
+	return true
+}
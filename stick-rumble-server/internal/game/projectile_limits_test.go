@@ -0,0 +1,68 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectileManagerEvictsOldestPastOwnerCap(t *testing.T) {
+	pm := newProjectileManagerWithLimits(openTestMapConfig(), ProjectileLimits{MaxPerOwner: 2})
+	first := pm.SpawnProjectile("p1", "Pistol", "", Vector2{X: 100, Y: 100}, 0, 800)
+	second := pm.SpawnProjectile("p1", "Pistol", "", Vector2{X: 100, Y: 100}, 0, 800)
+	other := pm.SpawnProjectile("p2", "Pistol", "", Vector2{X: 100, Y: 100}, 0, 800)
+	third := pm.SpawnProjectile("p1", "Pistol", "", Vector2{X: 100, Y: 100}, 0, 800)
+
+	assert.Nil(t, pm.GetProjectileByID(first.ID), "the owner's oldest projectile makes room")
+	assert.NotNil(t, pm.GetProjectileByID(second.ID))
+	assert.NotNil(t, pm.GetProjectileByID(third.ID))
+	assert.NotNil(t, pm.GetProjectileByID(other.ID), "other owners are untouched")
+	assert.Len(t, pm.GetProjectilesByOwner("p1"), 2)
+}
+
+func TestProjectileManagerEvictsOldestPastGlobalCap(t *testing.T) {
+	pm := newProjectileManagerWithLimits(openTestMapConfig(), ProjectileLimits{MaxActive: 8})
+	first := pm.SpawnProjectile("p1", "Pistol", "", Vector2{X: 100, Y: 100}, 0, 800)
+	pellets := pm.SpawnPellets("p2", "Shotgun", "", Vector2{X: 100, Y: 100}, make([]float64, ShotgunPelletCount), 800, make([]int, ShotgunPelletCount), 300)
+
+	assert.Nil(t, pm.GetProjectileByID(first.ID))
+	assert.Len(t, pm.GetActiveProjectiles(), 8)
+	for _, pellet := range pellets {
+		assert.NotNil(t, pm.GetProjectileByID(pellet.ID))
+	}
+	assert.True(t, pm.CanSpawn("p1", 1), "evicting managers never refuse a spawn")
+}
+
+func TestProjectileManagerRejectPolicyCountsRemovals(t *testing.T) {
+	pm := newProjectileManagerWithLimits(openTestMapConfig(), ProjectileLimits{MaxPerOwner: 2, Policy: ProjectileCapReject})
+	first := pm.SpawnProjectile("p1", "Pistol", "", Vector2{X: 100, Y: 100}, 0, 800)
+	pm.SpawnProjectile("p1", "Pistol", "", Vector2{X: 100, Y: 100}, 0, 800)
+
+	assert.False(t, pm.CanSpawn("p1", 1))
+	assert.True(t, pm.CanSpawn("p2", 2))
+	assert.False(t, pm.CanSpawn("p2", ShotgunPelletCount), "a spread shot needs room for every pellet")
+
+	pm.RemoveProjectile(first.ID)
+	assert.True(t, pm.CanSpawn("p1", 1))
+}
+
+func TestGameServerRejectsShotsPastProjectileCap(t *testing.T) {
+	clock := NewManualClock(guardStart)
+	gs := NewGameServerWithConfig(GameServerConfig{
+		Clock:            clock,
+		ProjectileLimits: ProjectileLimits{MaxPerOwner: 1, Policy: ProjectileCapReject},
+	})
+	gs.AddPlayer("p1")
+
+	require.True(t, gs.PlayerShoot("p1", 0, 0).Success)
+	ws := gs.GetWeaponState("p1")
+	ammo := ws.CurrentAmmo
+
+	clock.Advance(time.Second)
+	result := gs.PlayerShoot("p1", 0, 0)
+	assert.False(t, result.Success)
+	assert.Equal(t, ShootFailedProjectileCap, result.Reason)
+	assert.Equal(t, ammo, ws.CurrentAmmo, "a rejected shot uses no ammo")
+}
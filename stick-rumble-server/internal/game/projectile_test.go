@@ -426,3 +426,41 @@ func TestProjectileConstants(t *testing.T) {
 		t.Errorf("max lifetime should be %v, got %v", expectedMaxLifetime, ProjectileMaxLifetime)
 	}
 }
+
+func TestProjectileManager_RecycledProjectilesStartFresh(t *testing.T) {
+	pm := NewProjectileManager()
+
+	first := pm.SpawnProjectile("player-1", "Uzi", TrailEffectNeon, Vector2{X: 100, Y: 100}, 0, 800.0)
+	live := pm.GetProjectileByID(first.ID)
+	live.PendingRemoval = true
+	pm.Update(1.0 / 60.0)
+	if pm.GetProjectileByID(first.ID) != nil {
+		t.Fatal("projectile pending removal should be removed")
+	}
+
+	second := pm.CreateProjectile("player-2", "Pistol", Vector2{X: 300, Y: 300}, math.Pi, 800.0)
+	if second.ID == first.ID || second.OwnerID != "player-2" || second.EffectID != "" {
+		t.Errorf("recycled projectile kept state from its previous flight: %+v", second)
+	}
+	if !second.Active || second.PendingRemoval {
+		t.Errorf("recycled projectile should be active, got %+v", second)
+	}
+	if first.EffectID != TrailEffectNeon || first.OwnerID != "player-1" {
+		t.Errorf("spawn copy should not change when the projectile is recycled: %+v", first)
+	}
+}
+
+// BenchmarkProjectileManagerTick fires and advances projectiles for eight
+// players, one shot each per tick, as the 60Hz tick loop does under load
+func BenchmarkProjectileManagerTick(b *testing.B) {
+	pm := NewProjectileManager()
+	owners := []string{"p1", "p2", "p3", "p4", "p5", "p6", "p7", "p8"}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for j, owner := range owners {
+			pm.SpawnProjectile(owner, "Uzi", "", Vector2{X: 960, Y: 540}, float64(j), 800.0)
+		}
+		pm.Update(1.0 / 60.0)
+	}
+}
@@ -0,0 +1,64 @@
+package game
+
+import "time"
+
+const (
+	// QueueStatusInterval is how often players in the matchmaking queue are
+	// told where they stand
+	QueueStatusInterval = 2 * time.Second
+
+	// QueueFillRateWindow is how far back matched players count toward the
+	// queue's fill rate
+	QueueFillRateWindow = 5 * time.Minute
+)
+
+// QueueStatus is what a player in the public matchmaking queue is told about
+// its wait.
+type QueueStatus struct {
+	Player        *Player
+	Position      int           // 1-based place in the queue
+	EstimatedWait time.Duration // Only meaningful when WaitKnown
+	WaitKnown     bool          // False with no recent matches and no bot fill timer to go on
+	PlayersOnline int           // Players the instance hosts, queued ones included
+}
+
+// QueueStatuses returns the status of every player in the public matchmaking
+// queue, in queue order.
+//
+// A player's wait is estimated from the recent fill rate: at r players matched
+// per second, the player at position p waits about p/r. When the bot fill
+// timer will start the queue's match sooner, the estimate is the time left on
+// that timer instead.
+func (rm *RoomManager) QueueStatuses(now time.Time) []QueueStatus {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	fillRate := rm.metrics.FillRate(now)
+	playersOnline := len(rm.playerToRoom) + len(rm.waitingPlayers) + len(rm.capacityQueue)
+
+	statuses := make([]QueueStatus, 0, len(rm.waitingPlayers))
+	for i, player := range rm.waitingPlayers {
+		status := QueueStatus{
+			Player:        player,
+			Position:      i + 1,
+			PlayersOnline: playersOnline,
+		}
+		if fillRate > 0 {
+			status.EstimatedWait = time.Duration(float64(status.Position) / fillRate * float64(time.Second))
+			status.WaitKnown = true
+		}
+
+		// The bot fill timer runs from the oldest queued player and starts a
+		// room of up to defaultRoomMaxPlayers from the front of the queue
+		if rm.settings.BotFillAfter > 0 && status.Position <= defaultRoomMaxPlayers {
+			untilFill := max(rm.settings.BotFillAfter-now.Sub(rm.waitingPlayers[0].QueuedAt), 0)
+			if !status.WaitKnown || untilFill < status.EstimatedWait {
+				status.EstimatedWait = untilFill
+				status.WaitKnown = true
+			}
+		}
+
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
@@ -0,0 +1,75 @@
+package game
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordRecentMatches marks count players as matched just now
+func recordRecentMatches(manager *RoomManager, count int) {
+	now := time.Now()
+	for i := range count {
+		playerID := fmt.Sprintf("matched-%d", i)
+		manager.metrics.Queued(playerID, now.Add(-time.Minute))
+		manager.metrics.Matched(playerID, "room-1", now)
+	}
+}
+
+func TestQueueStatusesWithoutHistoryLeaveWaitUnknown(t *testing.T) {
+	manager := NewRoomManager()
+	manager.SetRoomSettings(RoomSettings{MinHumanPlayers: 3})
+	flow := manager.SessionFlow()
+	flow.HandleHello(newSessionFlowPlayer("player-1"), map[string]any{"mode": "public"})
+	flow.HandleHello(newSessionFlowPlayer("player-2"), map[string]any{"mode": "public"})
+
+	statuses := manager.QueueStatuses(time.Now())
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "player-2", statuses[1].Player.ID)
+	assert.Equal(t, 2, statuses[1].Position)
+	assert.Equal(t, 2, statuses[1].PlayersOnline)
+	assert.False(t, statuses[1].WaitKnown)
+}
+
+func TestQueueStatusesEstimateWaitFromFillRate(t *testing.T) {
+	manager := NewRoomManager()
+	manager.SetRoomSettings(RoomSettings{MinHumanPlayers: 3})
+	flow := manager.SessionFlow()
+	flow.HandleHello(newSessionFlowPlayer("player-1"), map[string]any{"mode": "public"})
+	flow.HandleHello(newSessionFlowPlayer("player-2"), map[string]any{"mode": "public"})
+
+	// 30 players in five minutes is one every ten seconds
+	recordRecentMatches(manager, 30)
+
+	statuses := manager.QueueStatuses(time.Now())
+	require.Len(t, statuses, 2)
+	assert.True(t, statuses[0].WaitKnown)
+	assert.Equal(t, 10*time.Second, statuses[0].EstimatedWait)
+	assert.Equal(t, 20*time.Second, statuses[1].EstimatedWait)
+
+	later := manager.QueueStatuses(time.Now().Add(QueueFillRateWindow))
+	assert.False(t, later[0].WaitKnown, "matches older than the window no longer count")
+}
+
+func TestQueueStatusesCapWaitAtBotFillTimer(t *testing.T) {
+	manager := NewRoomManager()
+	manager.SetRoomSettings(RoomSettings{MinHumanPlayers: 3, BotFillAfter: 30 * time.Second})
+	flow := manager.SessionFlow()
+	flow.HandleHello(newSessionFlowPlayer("player-1"), map[string]any{"mode": "public"})
+
+	statuses := manager.QueueStatuses(time.Now().Add(10 * time.Second))
+	require.Len(t, statuses, 1)
+	assert.True(t, statuses[0].WaitKnown, "the bot fill timer bounds the wait without any history")
+	assert.InDelta(t, (20 * time.Second).Seconds(), statuses[0].EstimatedWait.Seconds(), 1)
+
+	// Three players in five minutes would mean a 100 second wait
+	recordRecentMatches(manager, 3)
+	statuses = manager.QueueStatuses(time.Now().Add(10 * time.Second))
+	assert.InDelta(t, (20 * time.Second).Seconds(), statuses[0].EstimatedWait.Seconds(), 1)
+
+	statuses = manager.QueueStatuses(time.Now().Add(time.Minute))
+	assert.Zero(t, statuses[0].EstimatedWait, "an overdue fill is due any moment")
+}
@@ -2,7 +2,6 @@ package game
 
 import (
 	"math"
-	"math/rand"
 )
 
 const (
@@ -18,6 +17,13 @@ const (
 // aimAngle is the center aim angle in radians
 // Returns slice of angles in radians for each pellet
 func CalculateShotgunPelletAngles(aimAngle float64, spreadDegrees float64) []float64 {
+	return CalculateShotgunPelletAnglesFrom(nil, aimAngle, spreadDegrees)
+}
+
+// CalculateShotgunPelletAnglesFrom is CalculateShotgunPelletAngles drawing its
+// jitter from source (normally the room's RNG); nil uses a fixed-seed source
+func CalculateShotgunPelletAnglesFrom(source RandomSource, aimAngle float64, spreadDegrees float64) []float64 {
+	source = randomSourceOrDefault(source)
 	angles := make([]float64, ShotgunPelletCount)
 	spreadRadians := (spreadDegrees * math.Pi) / 180.0
 	halfSpread := spreadRadians / 2.0
@@ -30,7 +36,7 @@ func CalculateShotgunPelletAngles(aimAngle float64, spreadDegrees float64) []flo
 
 		// Add small random offset for natural feel (±10% of even spacing)
 		spacing := spreadRadians / float64(ShotgunPelletCount-1)
-		randomOffset := (rand.Float64() - 0.5) * spacing * 0.2
+		randomOffset := (source.Float64() - 0.5) * spacing * 0.2
 
 		angles[i] = aimAngle + evenSpread + randomOffset
 	}
@@ -38,8 +44,23 @@ func CalculateShotgunPelletAngles(aimAngle float64, spreadDegrees float64) []flo
 	return angles
 }
 
+// ShotgunPelletDamages splits a shot's damage across its pellets. The
+// remainder goes one point at a time to the first pellets, so a shot that
+// lands every pellet deals exactly totalDamage.
+func ShotgunPelletDamages(totalDamage int) []int {
+	damages := make([]int, ShotgunPelletCount)
+	for i := range damages {
+		damages[i] = totalDamage / ShotgunPelletCount
+		if i < totalDamage%ShotgunPelletCount {
+			damages[i]++
+		}
+	}
+	return damages
+}
+
 // ApplyRecoilToAngle applies recoil pattern to aim angle
-// Returns the modified angle in radians with recoil applied
+// Returns the modified angle in radians with recoil applied; the random spread
+// draws from the fixed-seed source
 func ApplyRecoilToAngle(baseAngle float64, recoil *RecoilPattern, shotsFired int, isMoving bool, isSprinting bool, weapon *Weapon) float64 {
 	if recoil == nil {
 		return baseAngle
@@ -52,12 +73,12 @@ func ApplyRecoilToAngle(baseAngle float64, recoil *RecoilPattern, shotsFired int
 	}
 
 	// Calculate horizontal recoil (random per shot)
-	horizontalRecoilDegrees := (rand.Float64() - 0.5) * 2.0 * recoil.HorizontalPerShot
+	horizontalRecoilDegrees := (defaultRandomSource.Float64() - 0.5) * 2.0 * recoil.HorizontalPerShot
 
 	// Apply movement spread if moving
 	movementSpreadDegrees := 0.0
 	if isMoving && weapon.SpreadDegrees > 0 {
-		movementSpreadDegrees = (rand.Float64() - 0.5) * 2.0 * weapon.SpreadDegrees
+		movementSpreadDegrees = (defaultRandomSource.Float64() - 0.5) * 2.0 * weapon.SpreadDegrees
 
 		// Apply sprint multiplier if sprinting (1.5x spread)
 		if isSprinting {
@@ -210,6 +210,26 @@ func TestShotgunPelletDamage(t *testing.T) {
 	}
 }
 
+func TestShotgunPelletDamages(t *testing.T) {
+	for _, total := range []int{60, 61, 67} {
+		damages := ShotgunPelletDamages(total)
+		if len(damages) != ShotgunPelletCount {
+			t.Fatalf("expected %d pellet damages, got %d", ShotgunPelletCount, len(damages))
+		}
+
+		sum := 0
+		for _, damage := range damages {
+			if damage < total/ShotgunPelletCount || damage > total/ShotgunPelletCount+1 {
+				t.Errorf("pellet damage %d is not an even share of %d", damage, total)
+			}
+			sum += damage
+		}
+		if sum != total {
+			t.Errorf("pellet damages should add up to %d, got %d", total, sum)
+		}
+	}
+}
+
 func TestApplyRecoilToAngle_SprintSpreadMultiplier(t *testing.T) {
 	// Use a fixed seed for deterministic test results
 	// This prevents flaky test failures due to random variance
@@ -0,0 +1,253 @@
+package game
+
+import (
+	"log"
+	"math"
+	"time"
+)
+
+// DefaultReadyCheckTimeout is how long a freshly formed room waits for players
+// to ready up before the match starts anyway.
+const DefaultReadyCheckTimeout = 15 * time.Second
+
+// ReadyCheckConfig controls the pre-match ready check.
+type ReadyCheckConfig struct {
+	Timeout time.Duration // Zero or negative starts matches immediately on room formation
+	Quorum  float64       // Fraction of the roster that must be ready; 1 means everyone
+}
+
+// DefaultReadyCheckConfig requires every player to ready up within the default timeout.
+func DefaultReadyCheckConfig() ReadyCheckConfig {
+	return ReadyCheckConfig{
+		Timeout: DefaultReadyCheckTimeout,
+		Quorum:  1,
+	}
+}
+
+// RequiredReady returns how many of totalPlayers must be ready to satisfy the quorum.
+func (c ReadyCheckConfig) RequiredReady(totalPlayers int) int {
+	if totalPlayers <= 0 {
+		return 0
+	}
+
+	quorum := c.Quorum
+	if quorum <= 0 || quorum > 1 {
+		quorum = 1
+	}
+
+	required := int(math.Ceil(quorum * float64(totalPlayers)))
+	if required < 1 {
+		return 1
+	}
+	return required
+}
+
+// ReadyCheckState is a point-in-time view of a room's ready check.
+type ReadyCheckState struct {
+	RoomID           string
+	ReadyPlayerIDs   []string
+	ReadyCount       int
+	RequiredCount    int
+	TotalPlayers     int
+	RemainingSeconds int
+	Active           bool // True while counting down
+	Started          bool
+}
+
+// InReadyCheck reports whether the room is counting down to match start.
+func (r *Room) InReadyCheck() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return !r.ReadyDeadline.IsZero()
+}
+
+// SetPlayerReady updates one player's ready flag. It returns false when the
+// player is not in the room.
+func (r *Room) SetPlayerReady(playerID string, ready bool) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, player := range r.Players {
+		if player.ID == playerID {
+			player.Ready = ready
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Room) beginReadyCheck(deadline time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ReadyDeadline = deadline
+	for _, player := range r.Players {
+		player.Ready = false
+	}
+}
+
+func (r *Room) endReadyCheck() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ReadyDeadline = time.Time{}
+}
+
+func (r *Room) cancelReadyCheck() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ReadyDeadline = time.Time{}
+	for _, player := range r.Players {
+		player.Ready = false
+	}
+}
+
+func (r *Room) readyCheckState(now time.Time, config ReadyCheckConfig) ReadyCheckState {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	state := ReadyCheckState{
+		RoomID:         r.ID,
+		ReadyPlayerIDs: make([]string, 0, len(r.Players)),
+		TotalPlayers:   len(r.Players),
+		RequiredCount:  config.RequiredReady(len(r.Players)),
+	}
+	for _, player := range r.Players {
+		if player.Ready {
+			state.ReadyPlayerIDs = append(state.ReadyPlayerIDs, player.ID)
+		}
+	}
+	state.ReadyCount = len(state.ReadyPlayerIDs)
+
+	if !r.ReadyDeadline.IsZero() {
+		state.Active = true
+		remaining := r.ReadyDeadline.Sub(now)
+		if remaining > 0 {
+			state.RemainingSeconds = int(math.Ceil(remaining.Seconds()))
+		}
+	}
+	return state
+}
+
+func (rm *RoomManager) SetReadyCheckConfig(config ReadyCheckConfig) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.readyCheck = config
+}
+
+// ReadyCheckState returns the current ready check view for a room.
+func (rm *RoomManager) ReadyCheckState(room *Room) ReadyCheckState {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	return rm.readyCheckStateLocked(room)
+}
+
+// SetPlayerReady records a player's ready flag during a ready check and starts
+// the match once the quorum is met. It returns false when the player is not
+// in a room that is currently running a ready check.
+func (rm *RoomManager) SetPlayerReady(playerID string, ready bool) bool {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	roomID, exists := rm.playerToRoom[playerID]
+	if !exists {
+		return false
+	}
+	room, exists := rm.rooms[roomID]
+	if !exists || !room.InReadyCheck() || !room.SetPlayerReady(playerID, ready) {
+		return false
+	}
+
+	rm.reconcileReadyCheckLocked(room)
+	rm.publishReadyStateLocked(room)
+	return true
+}
+
+// TickReadyChecks starts every match whose ready check has expired and
+// publishes the countdown for the rest.
+func (rm *RoomManager) TickReadyChecks(now time.Time) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	for _, room := range rm.rooms {
+		room.mu.RLock()
+		deadline := room.ReadyDeadline
+		room.mu.RUnlock()
+		if deadline.IsZero() {
+			continue
+		}
+
+		if !now.Before(deadline) {
+			log.Printf("Ready check expired in room %s, starting match", room.ID)
+			room.endReadyCheck()
+			rm.startMatchLocked(room, 0)
+		}
+		rm.publishReadyStateLocked(room)
+	}
+}
+
+// PublishReadyStates sends the current ready check view to each room.
+func (rm *RoomManager) PublishReadyStates(rooms []*Room) {
+	if len(rooms) == 0 {
+		return
+	}
+
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	for _, room := range rooms {
+		rm.publishReadyStateLocked(room)
+	}
+}
+
+// beginMatchOrReadyCheck is called with rm.mu held once a room has enough
+// players. It reports whether a ready check began rather than the match.
+func (rm *RoomManager) beginMatchOrReadyCheck(room *Room) bool {
+	if rm.readyCheck.Timeout <= 0 {
+		rm.startMatchLocked(room, 0)
+		return false
+	}
+
+	room.beginReadyCheck(time.Now().Add(rm.readyCheck.Timeout))
+	return true
+}
+
+// reconcileReadyCheckLocked re-evaluates a running ready check after its
+// roster or ready flags changed. It cancels the check when too few players
+// remain and starts the match once the quorum is met.
+func (rm *RoomManager) reconcileReadyCheckLocked(room *Room) {
+	if !room.InReadyCheck() {
+		return
+	}
+
+	if room.PlayerCount() < MinPlayersToStart {
+		room.cancelReadyCheck()
+		return
+	}
+
+	state := room.readyCheckState(time.Now(), rm.readyCheck)
+	if state.ReadyCount >= state.RequiredCount {
+		room.endReadyCheck()
+		rm.startMatchLocked(room, 0)
+	}
+}
+
+func (rm *RoomManager) readyCheckStateLocked(room *Room) ReadyCheckState {
+	state := room.readyCheckState(time.Now(), rm.readyCheck)
+	state.Started = room.Match.IsStarted()
+	return state
+}
+
+func (rm *RoomManager) publishReadyStateLocked(room *Room) {
+	if rm.publisher == nil {
+		log.Printf("Warning: no room event publisher configured for room:ready_state(%s)", room.ID)
+		return
+	}
+
+	if err := rm.publisher.PublishReadyState(room, rm.readyCheckStateLocked(room)); err != nil {
+		log.Printf("Error publishing room:ready_state for room %s: %v", room.ID, err)
+	}
+}
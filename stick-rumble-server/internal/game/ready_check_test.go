@@ -0,0 +1,158 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newReadyCheckRoom(t *testing.T, config ReadyCheckConfig) (*RoomManager, *stubRoomEventPublisher, *Room, []*Player) {
+	t.Helper()
+
+	manager := NewRoomManager()
+	publisher := &stubRoomEventPublisher{}
+	manager.SetPublisher(publisher)
+	manager.SetReadyCheckConfig(config)
+
+	players := []*Player{
+		newSessionFlowPlayer("player-1"),
+		newSessionFlowPlayer("player-2"),
+	}
+	manager.AddPublicPlayer(players[0])
+	room := manager.AddPublicPlayer(players[1])
+	require.NotNil(t, room)
+	return manager, publisher, room, players
+}
+
+func TestReadyCheckConfigRequiredReady(t *testing.T) {
+	testCases := []struct {
+		name     string
+		quorum   float64
+		total    int
+		expected int
+	}{
+		{name: "everyone", quorum: 1, total: 4, expected: 4},
+		{name: "majority rounds up", quorum: 0.5, total: 3, expected: 2},
+		{name: "tiny quorum still needs one", quorum: 0.01, total: 8, expected: 1},
+		{name: "invalid quorum means everyone", quorum: 0, total: 3, expected: 3},
+		{name: "empty room", quorum: 1, total: 0, expected: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := ReadyCheckConfig{Timeout: time.Second, Quorum: tc.quorum}
+			assert.Equal(t, tc.expected, config.RequiredReady(tc.total))
+		})
+	}
+}
+
+func TestReadyCheckStartsMatchWhenAllPlayersReady(t *testing.T) {
+	manager, publisher, room, players := newReadyCheckRoom(t, DefaultReadyCheckConfig())
+
+	require.True(t, room.InReadyCheck())
+	require.Len(t, publisher.readyStates, 1, "forming the room should announce the ready check")
+	assert.True(t, publisher.readyStates[0].Active)
+	assert.Equal(t, 2, publisher.readyStates[0].RequiredCount)
+	assert.Greater(t, publisher.readyStates[0].RemainingSeconds, 0)
+
+	require.True(t, manager.SetPlayerReady(players[0].ID, true))
+	assert.False(t, room.Match.IsStarted())
+	assert.Equal(t, []string{players[0].ID}, publisher.readyStates[1].ReadyPlayerIDs)
+
+	require.True(t, manager.SetPlayerReady(players[1].ID, true))
+	assert.True(t, room.Match.IsStarted())
+	assert.False(t, room.InReadyCheck())
+
+	final := publisher.readyStates[len(publisher.readyStates)-1]
+	assert.True(t, final.Started)
+	assert.False(t, final.Active)
+
+	assert.False(t, manager.SetPlayerReady(players[0].ID, false), "votes after start are ignored")
+}
+
+func TestReadyCheckQuorumStartsMatchEarly(t *testing.T) {
+	manager, _, room, players := newReadyCheckRoom(t, ReadyCheckConfig{Timeout: time.Minute, Quorum: 0.5})
+
+	require.True(t, manager.SetPlayerReady(players[1].ID, true))
+	assert.True(t, room.Match.IsStarted())
+}
+
+func TestReadyCheckUnreadyKeepsWaiting(t *testing.T) {
+	manager, publisher, room, players := newReadyCheckRoom(t, DefaultReadyCheckConfig())
+
+	require.True(t, manager.SetPlayerReady(players[0].ID, true))
+	require.True(t, manager.SetPlayerReady(players[0].ID, false))
+	assert.False(t, room.Match.IsStarted())
+	assert.Empty(t, publisher.readyStates[len(publisher.readyStates)-1].ReadyPlayerIDs)
+}
+
+func TestTickReadyChecksStartsMatchAfterTimeout(t *testing.T) {
+	manager, publisher, room, _ := newReadyCheckRoom(t, ReadyCheckConfig{Timeout: 10 * time.Second, Quorum: 1})
+
+	manager.TickReadyChecks(time.Now())
+	assert.False(t, room.Match.IsStarted(), "countdown ticks should not start the match early")
+	assert.True(t, publisher.readyStates[len(publisher.readyStates)-1].Active)
+
+	manager.TickReadyChecks(time.Now().Add(11 * time.Second))
+	assert.True(t, room.Match.IsStarted())
+	assert.False(t, room.InReadyCheck())
+	assert.True(t, publisher.readyStates[len(publisher.readyStates)-1].Started)
+
+	published := len(publisher.readyStates)
+	manager.TickReadyChecks(time.Now().Add(time.Minute))
+	assert.Len(t, publisher.readyStates, published, "started rooms are no longer ticked")
+}
+
+func TestReadyCheckCancelledWhenRosterDropsBelowMinimum(t *testing.T) {
+	manager, publisher, room, players := newReadyCheckRoom(t, DefaultReadyCheckConfig())
+	require.True(t, manager.SetPlayerReady(players[1].ID, true))
+
+	manager.RemovePlayer(players[0].ID)
+
+	assert.False(t, room.InReadyCheck())
+	assert.False(t, room.Match.IsStarted())
+	assert.False(t, players[1].Ready, "cancelling the check clears ready flags")
+	final := publisher.readyStates[len(publisher.readyStates)-1]
+	assert.False(t, final.Active)
+	assert.False(t, final.Started)
+}
+
+func TestReadyCheckDisabledStartsMatchImmediately(t *testing.T) {
+	_, publisher, room, _ := newReadyCheckRoom(t, ReadyCheckConfig{})
+
+	assert.True(t, room.Match.IsStarted())
+	assert.False(t, room.InReadyCheck())
+	assert.Empty(t, publisher.readyStates)
+}
+
+func TestReadyCheckLateCodeJoinerJoinsRunningCheck(t *testing.T) {
+	manager := NewRoomManager()
+	publisher := &stubRoomEventPublisher{}
+	manager.SetPublisher(publisher)
+	flow := manager.SessionFlow()
+
+	host := newSessionFlowPlayer("host")
+	guest := newSessionFlowPlayer("guest")
+	late := newSessionFlowPlayer("late")
+
+	flow.HandleHello(host, map[string]any{"mode": "code", "code": "READY"})
+	formed := flow.HandleHello(guest, map[string]any{"mode": "code", "code": "READY"})
+	require.True(t, formed.Room.InReadyCheck())
+	deadline := formed.Room.ReadyDeadline
+
+	result := flow.HandleHello(late, map[string]any{"mode": "code", "code": "READY"})
+	assert.Equal(t, []string{late.ID}, activationIDs(result.Activations))
+	assert.Equal(t, []SessionStatusState{SessionStatusMatchReady}, publicationStatesForPlayer(result.Publications, late.ID))
+	assert.Equal(t, []*Room{formed.Room}, result.ReadyChecks)
+	assert.Equal(t, deadline, formed.Room.ReadyDeadline, "late joiners do not restart the countdown")
+	assert.Equal(t, 3, manager.ReadyCheckState(formed.Room).RequiredCount)
+}
+
+func TestLeaveSessionIgnoredDuringReadyCheck(t *testing.T) {
+	manager, _, room, players := newReadyCheckRoom(t, DefaultReadyCheckConfig())
+
+	assert.False(t, manager.LeaveSession(players[0].ID))
+	assert.Equal(t, 2, room.PlayerCount())
+}
@@ -1,6 +1,7 @@
 package game
 
 import (
+	"sync"
 	"testing"
 	"time"
 )
@@ -257,3 +258,39 @@ func TestMovementDuringReload(t *testing.T) {
 		t.Error("reload should still be in progress after movement")
 	}
 }
+
+// TestReloadAcrossGoroutines runs reloads the way the server does, started by a
+// message handler while the tick loop completes them; run with -race
+func TestReloadAcrossGoroutines(t *testing.T) {
+	clock := NewManualClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	gs := NewGameServerWithClock(nil, clock)
+	playerID := "player1"
+	gs.AddPlayer(playerID)
+	ws := gs.GetWeaponState(playerID)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			ws.RecordShot()
+			gs.PlayerReload(playerID)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			gs.checkReloads()
+			ws.CancelReload()
+		}
+	}()
+	wg.Wait()
+
+	clock.Advance(ws.Weapon.ReloadTime)
+	gs.PlayerReload(playerID)
+	clock.Advance(ws.Weapon.ReloadTime)
+	gs.checkReloads()
+	if current, max := ws.GetAmmoInfo(); current != max || ws.Reloading() {
+		t.Errorf("Expected a full magazine after the last reload, got %d/%d (reloading %v)", current, max, ws.Reloading())
+	}
+}
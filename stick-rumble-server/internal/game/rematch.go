@@ -0,0 +1,237 @@
+package game
+
+import (
+	"log"
+	"time"
+)
+
+// DefaultRematchVoteTimeout is how long an ended match's players have to vote
+// for a rematch before the room dissolves back into matchmaking.
+const DefaultRematchVoteTimeout = 20 * time.Second
+
+// RematchResult is the outcome of a rematch vote. It is empty, with a nil
+// Room, while the vote is undecided.
+type RematchResult struct {
+	Room      *Room               // The room that voted
+	Restarted bool                // The room's match restarted; otherwise its players went back to matchmaking
+	Players   []*Player           // Players whose game state must be rebuilt: the room's roster either way
+	Sessions  []RoomSessionResult // The restarted room's activations, or the dissolved room's players' matchmaking outcomes
+}
+
+// RequiredRematchVotes returns how many yes votes a room of totalPlayers
+// needs for a rematch: a strict majority.
+func RequiredRematchVotes(totalPlayers int) int {
+	return totalPlayers/2 + 1
+}
+
+// InRematchVote reports whether the room's ended match is voting on a rematch.
+func (r *Room) InRematchVote() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return !r.RematchDeadline.IsZero()
+}
+
+func (r *Room) beginRematchVote(deadline time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.RematchDeadline = deadline
+	r.rematchVotes = make(map[string]bool, len(r.Players))
+}
+
+func (r *Room) endRematchVote() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.RematchDeadline = time.Time{}
+	r.rematchVotes = nil
+}
+
+// setRematchVote records one player's vote and returns its previous yes vote.
+// It returns false when the player is not in the room or no vote is running.
+func (r *Room) setRematchVote(playerID string, yes bool) (wasYes bool, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.rematchVotes == nil {
+		return false, false
+	}
+	for _, player := range r.Players {
+		if player.ID == playerID {
+			wasYes = r.rematchVotes[playerID]
+			r.rematchVotes[playerID] = yes
+			return wasYes, true
+		}
+	}
+	return false, false
+}
+
+// rematchTally counts the votes of the players still in the room.
+func (r *Room) rematchTally() (yes, no, total int) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, player := range r.Players {
+		vote, voted := r.rematchVotes[player.ID]
+		switch {
+		case !voted:
+		case vote:
+			yes++
+		default:
+			no++
+		}
+	}
+	return yes, no, len(r.Players)
+}
+
+// SetRematchVoteTimeout sets how long ended matches vote on a rematch. Zero
+// or negative turns rematch voting off, leaving ended rooms as they are.
+func (rm *RoomManager) SetRematchVoteTimeout(timeout time.Duration) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.rematchTimeout = timeout
+}
+
+// beginRematchVoteLocked opens an ended room's rematch vote. It is called with
+// rm.mu held.
+func (rm *RoomManager) beginRematchVoteLocked(room *Room) {
+	if rm.rematchTimeout <= 0 || room.IsEmpty() || room.InRematchVote() {
+		return
+	}
+	room.beginRematchVote(time.Now().Add(rm.rematchTimeout))
+}
+
+// VoteRematch records a player's match:rematch_vote. A majority of yes votes
+// from the players still in the room restarts its match at once; once a
+// majority can no longer be reached the room dissolves. It returns false when
+// the player's room is not voting on a rematch.
+func (f *RoomSessionFlow) VoteRematch(playerID string, yes bool) (RematchResult, bool) {
+	rm := f.roomManager
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	roomID, exists := rm.playerToRoom[playerID]
+	if !exists {
+		return RematchResult{}, false
+	}
+	room, exists := rm.rooms[roomID]
+	if !exists || !room.InRematchVote() {
+		return RematchResult{}, false
+	}
+	wasYes, ok := room.setRematchVote(playerID, yes)
+	if !ok {
+		return RematchResult{}, false
+	}
+	rm.metrics.RematchVote(playerID, room.ID, wasYes, yes)
+
+	return f.decideRematchLocked(room, false), true
+}
+
+// ReconcileRematch re-evaluates a room's rematch vote after a player left it,
+// since the majority is counted over the players who remain.
+func (f *RoomSessionFlow) ReconcileRematch(roomID string) RematchResult {
+	rm := f.roomManager
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	room, exists := rm.rooms[roomID]
+	if !exists || !room.InRematchVote() {
+		return RematchResult{}
+	}
+	return f.decideRematchLocked(room, false)
+}
+
+// TickRematchVotes decides every rematch vote whose timeout has passed.
+func (f *RoomSessionFlow) TickRematchVotes(now time.Time) []RematchResult {
+	rm := f.roomManager
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	var results []RematchResult
+	for _, room := range rm.rooms {
+		room.mu.RLock()
+		deadline := room.RematchDeadline
+		room.mu.RUnlock()
+		if deadline.IsZero() || now.Before(deadline) {
+			continue
+		}
+		results = append(results, f.decideRematchLocked(room, true))
+	}
+	return results
+}
+
+// decideRematchLocked restarts the room once a majority voted yes and
+// dissolves it once the vote expired, too few players remain, or the votes
+// left cannot make a majority. It is called with rm.mu held.
+func (f *RoomSessionFlow) decideRematchLocked(room *Room, expired bool) RematchResult {
+	yes, no, total := room.rematchTally()
+	required := RequiredRematchVotes(total)
+
+	switch {
+	case total >= MinPlayersToStart && yes >= required:
+		return f.restartRoomLocked(room)
+	case expired || total < MinPlayersToStart || total-no < required:
+		return f.dissolveRoomLocked(room)
+	default:
+		return RematchResult{}
+	}
+}
+
+// restartRoomLocked resets the room's match and starts it again with the
+// players still in the room. It is called with rm.mu held.
+func (f *RoomSessionFlow) restartRoomLocked(room *Room) RematchResult {
+	rm := f.roomManager
+	room.endRematchVote()
+	room.Match.Reset()
+	players := room.GetPlayers()
+	for _, player := range players {
+		player.StayTogether = false
+		room.Match.RegisterPlayer(player.ID)
+	}
+
+	if room.Kind == RoomKindCode && room.Code != "" {
+		// Take the code back unless someone started a fresh room with it
+		// since the match ended
+		if _, ok := rm.codeIndex[room.Code]; !ok {
+			rm.codeIndex[room.Code] = room.ID
+		}
+	}
+	log.Printf("Rematch voted in room %s (%d players)", room.ID, len(players))
+	rm.metrics.RematchFormed(room.ID, len(players))
+	rm.startMatchLocked(room, 0)
+
+	return RematchResult{
+		Room:      room,
+		Restarted: true,
+		Players:   players,
+		Sessions:  []RoomSessionResult{{Room: room, Activations: sessionActivationsForRoom(room)}},
+	}
+}
+
+// dissolveRoomLocked removes the room and sends each of its players back into
+// public matchmaking. It is called with rm.mu held.
+func (f *RoomSessionFlow) dissolveRoomLocked(room *Room) RematchResult {
+	rm := f.roomManager
+	room.endRematchVote()
+	players := room.GetPlayers()
+	for _, player := range players {
+		room.RemovePlayer(player.ID)
+		delete(rm.playerToRoom, player.ID)
+		player.Ready = false
+		player.StayTogether = false
+	}
+	delete(rm.rooms, room.ID)
+	if room.Kind == RoomKindCode && room.Code != "" {
+		if indexedID, ok := rm.codeIndex[room.Code]; ok && indexedID == room.ID {
+			delete(rm.codeIndex, room.Code)
+		}
+	}
+	log.Printf("Rematch vote failed in room %s, returning %d players to matchmaking", room.ID, len(players))
+
+	result := RematchResult{Room: room, Players: players}
+	for _, player := range players {
+		result.Sessions = append(result.Sessions, f.joinPublicLocked(player))
+	}
+	return result
+}
@@ -0,0 +1,107 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRematchVoteRoom ends a code room's match and opens its rematch vote
+func newRematchVoteRoom(t *testing.T, playerIDs ...string) (*RoomManager, *Room, []*Player) {
+	t.Helper()
+
+	manager, _, room, players := newEndedCodeRoom(t, "REMATCH", playerIDs...)
+	manager.RecordMatchEnded(room)
+	require.True(t, room.InRematchVote())
+	return manager, room, players
+}
+
+func TestRequiredRematchVotesIsStrictMajority(t *testing.T) {
+	assert.Equal(t, 2, RequiredRematchVotes(2))
+	assert.Equal(t, 2, RequiredRematchVotes(3))
+	assert.Equal(t, 3, RequiredRematchVotes(4))
+}
+
+func TestRematchVoteIgnoredWithoutOpenVote(t *testing.T) {
+	manager, _, room, players := newEndedCodeRoom(t, "REMATCH", "player-1", "player-2")
+	manager.SetRematchVoteTimeout(0)
+	manager.RecordMatchEnded(room)
+
+	_, ok := manager.SessionFlow().VoteRematch(players[0].ID, true)
+	assert.False(t, ok, "a zero timeout turns rematch voting off")
+}
+
+func TestRematchVoteMajorityRestartsMatchInPlace(t *testing.T) {
+	manager, room, players := newRematchVoteRoom(t, "player-1", "player-2", "player-3")
+	room.Match.AddKill("player-1")
+
+	result, ok := manager.SessionFlow().VoteRematch(players[0].ID, true)
+	require.True(t, ok)
+	assert.Nil(t, result.Room, "one vote of three is not a majority")
+
+	result, ok = manager.SessionFlow().VoteRematch(players[1].ID, true)
+	require.True(t, ok)
+	require.Equal(t, room, result.Room)
+	assert.True(t, result.Restarted)
+	assert.Len(t, result.Players, 3)
+	require.Len(t, result.Sessions, 1)
+	assert.ElementsMatch(t, []string{"player-1", "player-2", "player-3"}, activationIDs(result.Sessions[0].Activations))
+
+	assert.False(t, room.InRematchVote())
+	assert.True(t, room.Match.IsStarted())
+	assert.Equal(t, map[string]int{"player-1": 0, "player-2": 0, "player-3": 0}, room.Match.KillMap())
+	assert.Len(t, room.Match.RegisteredPlayers, 3)
+	assert.Equal(t, room, manager.GetRoomByPlayerID(players[2].ID))
+
+	stats := manager.MatchmakingStats()
+	assert.Equal(t, 1, stats.RematchesFormed)
+	assert.Equal(t, 2, stats.RematchAccepts)
+}
+
+func TestRematchVoteDissolvesOnceMajorityIsOutOfReach(t *testing.T) {
+	manager, room, players := newRematchVoteRoom(t, "player-1", "player-2", "player-3")
+
+	result, ok := manager.SessionFlow().VoteRematch(players[0].ID, false)
+	require.True(t, ok)
+	assert.Nil(t, result.Room)
+
+	result, ok = manager.SessionFlow().VoteRematch(players[1].ID, false)
+	require.True(t, ok)
+	require.Equal(t, room, result.Room)
+	assert.False(t, result.Restarted)
+	assert.Len(t, result.Sessions, 3)
+	assert.Nil(t, manager.GetRoom(room.ID))
+	for _, player := range players {
+		assert.NotEqual(t, room, manager.GetRoomByPlayerID(player.ID))
+	}
+}
+
+func TestRematchVoteExpiresIntoMatchmaking(t *testing.T) {
+	manager, room, players := newRematchVoteRoom(t, "player-1", "player-2", "player-3")
+	_, ok := manager.SessionFlow().VoteRematch(players[0].ID, true)
+	require.True(t, ok)
+
+	assert.Empty(t, manager.SessionFlow().TickRematchVotes(time.Now()))
+
+	results := manager.SessionFlow().TickRematchVotes(time.Now().Add(DefaultRematchVoteTimeout))
+	require.Len(t, results, 1)
+	assert.Equal(t, room, results[0].Room)
+	assert.False(t, results[0].Restarted)
+	assert.Nil(t, manager.GetRoom(room.ID))
+}
+
+func TestRematchVoteCountsOnlyRemainingPlayers(t *testing.T) {
+	manager, room, players := newRematchVoteRoom(t, "player-1", "player-2", "player-3", "player-4")
+	manager.SessionFlow().VoteRematch(players[0].ID, true)
+	manager.SessionFlow().VoteRematch(players[1].ID, true)
+	require.True(t, room.InRematchVote(), "two of four is not a majority")
+
+	manager.RemovePlayer(players[3].ID)
+	result := manager.SessionFlow().ReconcileRematch(room.ID)
+
+	require.Equal(t, room, result.Room)
+	assert.True(t, result.Restarted, "two of the three who remain is")
+	assert.Len(t, result.Players, 3)
+}
@@ -3,7 +3,6 @@ package game
 import (
 	"errors"
 	"log"
-	"os"
 	"regexp"
 	"strings"
 	"sync"
@@ -13,18 +12,20 @@ import (
 )
 
 const (
-	MinPlayersToStart   = 2
-	MinRoomCodeLen      = 3
-	MaxRoomCodeLen      = 12
-	MaxDisplayNameLen   = 16
-	FallbackDisplayName = "Guest"
+	MinPlayersToStart     = 2
+	defaultRoomMaxPlayers = 8
+	MinRoomCodeLen        = 3
+	MaxRoomCodeLen        = 12
+	MaxDisplayNameLen     = 16
+	FallbackDisplayName   = "Guest"
 )
 
 type RoomKind string
 
 const (
-	RoomKindPublic RoomKind = "public"
-	RoomKindCode   RoomKind = "code"
+	RoomKindPublic   RoomKind = "public"
+	RoomKindCode     RoomKind = "code"
+	RoomKindPractice RoomKind = "practice"
 )
 
 type RoomCodeErrorReason string
@@ -51,11 +52,28 @@ var (
 
 // Player represents a connected player.
 type Player struct {
-	ID          string
+	ID           string
+	DisplayName  string
+	HelloSeen    bool
+	Team         string // Empty for free-for-all rooms
+	Ready        bool
+	StayTogether bool      // Voted after match:ended to re-queue with the same group
+	QueuedAt     time.Time // When the player last entered matchmaking
+	SendChan     chan []byte
+	Outbox       *OutboundQueue   // Feeds SendChan, dropping stale messages first when the client falls behind
+	PingTracker  *PingTracker     // Tracks RTT for lag compensation
+	Broadcasts   *BroadcastFilter // Optional broadcast types the client opted out of
+	ChatMutes    *MuteList        // Players whose chat messages this player does not receive
+	Loadout      *Loadout         // Character class picked with player:loadout
+	Address      string           // Host the player connected from; empty for bots
+}
+
+// RosterEntry is a point-in-time view of one player in a room roster.
+type RosterEntry struct {
+	PlayerID    string
 	DisplayName string
-	HelloSeen   bool
-	SendChan    chan []byte
-	PingTracker *PingTracker // Tracks RTT for lag compensation
+	Team        string
+	Ready       bool
 }
 
 // NewPlayer creates a new player with initialized ping tracker.
@@ -64,7 +82,31 @@ func NewPlayer(id string, sendChan chan []byte) *Player {
 		ID:          id,
 		DisplayName: FallbackDisplayName,
 		SendChan:    sendChan,
+		Outbox:      NewOutboundQueue(sendChan, DefaultOutboundOverflow),
 		PingTracker: NewPingTracker(),
+		Broadcasts:  NewBroadcastFilter(),
+		ChatMutes:   NewMuteList(),
+		Loadout:     NewLoadout(),
+	}
+}
+
+// Send queues a message for the player without blocking. A player without an
+// Outbox sends straight to SendChan and drops the message if it is full.
+func (p *Player) Send(message []byte) (err error) {
+	if p.Outbox != nil {
+		return p.Outbox.Push(message)
+	}
+
+	defer func() {
+		if recover() != nil {
+			err = ErrOutboundClosed
+		}
+	}()
+	select {
+	case p.SendChan <- message:
+		return nil
+	default:
+		return ErrOutboundDropped
 	}
 }
 
@@ -77,10 +119,24 @@ type Room struct {
 	MaxPlayers int
 	MapID      string
 	Match      *Match
+	RNG        *RoomRNG               // Seeded source for all of this room's match randomness
+	Teams      bool                   // Players are split between team alpha and team bravo
+	Hooks      *GameplayHooks         // Modding hooks for this room's combat and pickup rules
+	Practice   *AdaptiveBotDifficulty // Set for practice rooms; scales their bots with the player's K/D
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
 	EmptySince *time.Time
-	mu         sync.RWMutex
+	// ReadyDeadline is set while the room runs a pre-match ready check.
+	ReadyDeadline time.Time
+	// RematchDeadline is set while the room's ended match votes on a rematch.
+	RematchDeadline time.Time
+	rematchVotes    map[string]bool         // player ID -> yes, for players who voted
+	kickVotes       map[string]*kickVote    // Target player ID -> open vote-kick
+	kickBlocks      []kickBlock             // Vote-kicked players kept out of the room
+	aimTurnRate     float64                 // Aim turn rate cap in radians per second; 0 uses the server's
+	allChatOff      bool                    // Chat reaches only the sender's team
+	broadcastTaps   map[string]func([]byte) // Named taps that see every broadcast, e.g. to record a replay
+	mu              sync.RWMutex
 }
 
 func NewRoom(mapIDs ...string) *Room {
@@ -95,21 +151,25 @@ func NewTypedRoom(kind RoomKind, code string, mapIDs ...string) *Room {
 		mapID = mapIDs[0]
 	}
 
-	if os.Getenv("TEST_MODE") == "true" {
+	if testModeEnabled() {
 		match.SetTestMode()
 		log.Println("Match created in TEST MODE (kill target: 2, time limit: 10s)")
 	}
 
+	seed := NewRoomSeed()
+	match.SetSeed(seed)
 	now := time.Now()
 
 	return &Room{
 		ID:         uuid.New().String(),
 		Kind:       kind,
 		Code:       code,
-		Players:    make([]*Player, 0, 8),
-		MaxPlayers: 8,
+		Players:    make([]*Player, 0, defaultRoomMaxPlayers),
+		MaxPlayers: defaultRoomMaxPlayers,
 		MapID:      mapID,
 		Match:      match,
+		RNG:        NewRoomRNG(seed),
+		Hooks:      NewGameplayHooks(),
 		CreatedAt:  now,
 		UpdatedAt:  now,
 	}
@@ -123,7 +183,14 @@ func (r *Room) AddPlayer(player *Player) error {
 	if len(r.Players) >= r.MaxPlayers {
 		return errors.New("room is full")
 	}
+	if _, blocked := r.kickBlockedUntilLocked(player, time.Now()); blocked {
+		return ErrVoteKickBlocked
+	}
 
+	// Players keep a team they bring along, such as a party moving rooms
+	if r.Teams && player.Team == "" {
+		player.Team = r.smallestTeamLocked()
+	}
 	r.Players = append(r.Players, player)
 	r.UpdatedAt = time.Now()
 	r.EmptySince = nil
@@ -138,6 +205,9 @@ func (r *Room) RemovePlayer(playerID string) bool {
 	for i, player := range r.Players {
 		if player.ID == playerID {
 			r.Players = append(r.Players[:i], r.Players[i+1:]...)
+			if r.Match != nil {
+				r.Match.PlayerLeft(playerID)
+			}
 			now := time.Now()
 			r.UpdatedAt = now
 			if len(r.Players) == 0 {
@@ -161,28 +231,151 @@ func (r *Room) PlayerCount() int {
 	return len(r.Players)
 }
 
+// AcceptsJoins reports whether a joining player could land in the room: a
+// named room takes anyone with its code until it is full, and matchmaking only
+// seats players in a public room that has one player. Practice rooms and ended
+// matches take nobody.
+func (r *Room) AcceptsJoins() bool {
+	if r.Match.IsEnded() {
+		return false
+	}
+	switch r.Kind {
+	case RoomKindCode:
+		return r.PlayerCount() < r.MaxPlayers
+	case RoomKindPublic:
+		return r.PlayerCount() == 1
+	}
+	return false
+}
+
+// Roster returns the current players in join order.
+func (r *Room) Roster() []RosterEntry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	roster := make([]RosterEntry, 0, len(r.Players))
+	for _, player := range r.Players {
+		roster = append(roster, RosterEntry{
+			PlayerID:    player.ID,
+			DisplayName: player.DisplayName,
+			Team:        player.Team,
+			Ready:       player.Ready,
+		})
+	}
+	return roster
+}
+
+// roomSendLog samples the per-player send failures of room broadcasts, which
+// repeat on every message while a client is stalled
+var roomSendLog = HotPathLogger("room_send")
+
 func (r *Room) Broadcast(message []byte, excludePlayerID string) {
+	r.BroadcastType("", message, excludePlayerID)
+}
+
+// SetBroadcastTap sets the tap called name, a function that is handed every
+// message broadcast to the room, whoever it is sent to. It is called with the
+// room locked, so it must not call back into the room. nil removes the tap.
+func (r *Room) SetBroadcastTap(name string, tap func(message []byte)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if tap == nil {
+		delete(r.broadcastTaps, name)
+		return
+	}
+	if r.broadcastTaps == nil {
+		r.broadcastTaps = make(map[string]func([]byte))
+	}
+	r.broadcastTaps[name] = tap
+}
+
+// tapBroadcastLocked hands message to every tap; called with r.mu held
+func (r *Room) tapBroadcastLocked(message []byte) {
+	for _, tap := range r.broadcastTaps {
+		tap(message)
+	}
+}
+
+// BroadcastType broadcasts a message of a known type, skipping players whose
+// broadcast filter opted out of it.
+func (r *Room) BroadcastType(messageType string, message []byte, excludePlayerID string) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
+	r.tapBroadcastLocked(message)
+
 	for _, player := range r.Players {
-		if player.ID == excludePlayerID {
+		if player.ID == excludePlayerID || !player.Broadcasts.Allows(messageType) {
 			continue
 		}
+		sendToRoomPlayer(player, message)
+	}
+}
 
-		func() {
-			defer func() {
-				if rec := recover(); rec != nil {
-					log.Printf("Warning: Could not send message to player %s (channel closed)", player.ID)
-				}
-			}()
+// BroadcastTeam sends a message of a known type to senderID's team, sender
+// included. In a free-for-all room every player is a team of one, so only the
+// sender gets it.
+func (r *Room) BroadcastTeam(messageType string, message []byte, senderID string) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var sender *Player
+	for _, player := range r.Players {
+		if player.ID == senderID {
+			sender = player
+			break
+		}
+	}
+	if sender == nil {
+		return
+	}
+	r.tapBroadcastLocked(message)
 
-			select {
-			case player.SendChan <- message:
-			default:
-				log.Printf("Warning: Could not send message to player %s (channel full)", player.ID)
+	for _, player := range r.Players {
+		if player.ID != senderID && (sender.Team == "" || player.Team != sender.Team) {
+			continue
+		}
+		if player.Broadcasts.Allows(messageType) {
+			sendToRoomPlayer(player, message)
+		}
+	}
+}
+
+// BroadcastChat sends a chat message from senderID to every player in the
+// room who has not muted the sender, sender included. A team-only message
+// reaches the sender's team alone, and nobody if the sender has no team.
+func (r *Room) BroadcastChat(message []byte, senderID string, teamOnly bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	team := ""
+	if teamOnly {
+		for _, player := range r.Players {
+			if player.ID == senderID {
+				team = player.Team
+				break
 			}
-		}()
+		}
+		if team == "" {
+			return
+		}
+	}
+	r.tapBroadcastLocked(message)
+
+	for _, player := range r.Players {
+		if player.ChatMutes.Mutes(senderID) || (teamOnly && player.Team != team) {
+			continue
+		}
+		sendToRoomPlayer(player, message)
+	}
+}
+
+// sendToRoomPlayer queues a message for a player without blocking, logging it
+// if the message is dropped or the player's channel is closed
+func sendToRoomPlayer(player *Player, message []byte) {
+	if err := player.Send(message); err != nil {
+		roomSendLog.Printf("Warning: Could not send message to player %s (%v)", player.ID, err)
 	}
 }
 
@@ -215,12 +408,24 @@ type RoomManager struct {
 	defaultMapID   string
 	sessionFlow    *RoomSessionFlow
 	publisher      RoomEventPublisher
+	readyCheck     ReadyCheckConfig
+	rematchTimeout time.Duration
+	settings       RoomSettings
+	botFiller      RoomBotFiller
+	fixedSeed      int64 // Non-zero forces every new room's seed, for reproducing matches
+	hookFactories  []GameplayHookFactory
+	capacity       CapacityLimits
+	capacityQueue  []capacityQueueEntry
+	metrics        *MatchmakingMetrics
 	mu             sync.RWMutex
 }
 
 type RoomEventPublisher interface {
 	PublishSessionStatus(player *Player, room *Room, state SessionStatusState) error
 	PublishPlayerLeft(room *Room, playerID string) error
+	PublishPlayerJoined(room *Room, player *Player) error
+	PublishReadyState(room *Room, state ReadyCheckState) error
+	PublishPartyState(room *Room, state PartyState) error
 }
 
 func NewRoomManager(defaultMapIDs ...string) *RoomManager {
@@ -235,6 +440,10 @@ func NewRoomManager(defaultMapIDs ...string) *RoomManager {
 		playerToRoom:   make(map[string]string),
 		codeIndex:      make(map[string]string),
 		defaultMapID:   defaultMapID,
+		readyCheck:     DefaultReadyCheckConfig(),
+		rematchTimeout: DefaultRematchVoteTimeout,
+		settings:       DefaultRoomSettings(),
+		metrics:        NewMatchmakingMetrics(),
 	}
 	manager.sessionFlow = NewRoomSessionFlow(manager)
 	return manager
@@ -293,8 +502,13 @@ func NormalizeRoomCode(raw any) (string, RoomCodeErrorReason, bool) {
 
 // AddPublicPlayer processes a successful public-mode hello.
 func (rm *RoomManager) AddPublicPlayer(player *Player) *Room {
-	result := rm.sessionFlow.joinPublic(player)
+	rm.mu.Lock()
+	result := rm.sessionFlow.joinPublicLocked(player)
+	rm.mu.Unlock()
+
 	rm.PublishSessionPublications(result.Publications)
+	rm.PublishRoomJoins(result.Joins)
+	rm.PublishReadyStates(result.ReadyChecks)
 	return result.Room
 }
 
@@ -305,8 +519,13 @@ func (rm *RoomManager) AddPlayer(player *Player) *Room {
 
 // AddCodePlayer processes a successful code-mode hello.
 func (rm *RoomManager) AddCodePlayer(player *Player, normalizedCode string) (*Room, bool) {
-	result := rm.sessionFlow.joinCode(player, normalizedCode)
+	rm.mu.Lock()
+	result := rm.sessionFlow.joinCodeLocked(player, normalizedCode)
+	rm.mu.Unlock()
+
 	rm.PublishSessionPublications(result.Publications)
+	rm.PublishRoomJoins(result.Joins)
+	rm.PublishReadyStates(result.ReadyChecks)
 	return result.Room, result.Rejection == nil
 }
 
@@ -327,6 +546,20 @@ func (rm *RoomManager) PublishSessionPublications(publications []RoomSessionPubl
 	}
 }
 
+// PublishRoomJoins announces each newcomer to the players already in its room.
+func (rm *RoomManager) PublishRoomJoins(joins []RoomSessionJoin) {
+	for _, join := range joins {
+		if rm.publisher == nil {
+			log.Printf("Warning: no room event publisher configured for player:joined(%s)", join.Player.ID)
+			continue
+		}
+
+		if err := rm.publisher.PublishPlayerJoined(join.Room, join.Player); err != nil {
+			log.Printf("Error publishing player:joined for player %s: %v", join.Player.ID, err)
+		}
+	}
+}
+
 func (rm *RoomManager) LeaveSession(playerID string) bool {
 	result := rm.sessionFlow.LeaveSession(playerID)
 	rm.PublishSessionPublications(result.Publications)
@@ -337,6 +570,8 @@ func (rm *RoomManager) RemovePlayer(playerID string) {
 	rm.mu.Lock()
 	defer rm.mu.Unlock()
 
+	rm.metrics.Abandoned(playerID, time.Now())
+
 	for i, player := range rm.waitingPlayers {
 		if player.ID == playerID {
 			rm.waitingPlayers = append(rm.waitingPlayers[:i], rm.waitingPlayers[i+1:]...)
@@ -344,6 +579,10 @@ func (rm *RoomManager) RemovePlayer(playerID string) {
 		}
 	}
 
+	if rm.removeFromCapacityQueueLocked(playerID) {
+		return
+	}
+
 	roomID, exists := rm.playerToRoom[playerID]
 	if !exists {
 		return
@@ -365,6 +604,11 @@ func (rm *RoomManager) RemovePlayer(playerID string) {
 
 	delete(rm.playerToRoom, playerID)
 
+	if room.InReadyCheck() {
+		rm.reconcileReadyCheckLocked(room)
+		rm.publishReadyStateLocked(room)
+	}
+
 	if !room.IsEmpty() {
 		return
 	}
@@ -395,6 +639,33 @@ func (rm *RoomManager) GetRoomByPlayerID(playerID string) *Room {
 	return rm.rooms[roomID]
 }
 
+// SetPlayerDisplayName renames a player in a room or waiting for one. It
+// returns the player's room, nil while waiting, and false if the player is in
+// neither.
+func (rm *RoomManager) SetPlayerDisplayName(playerID, displayName string) (*Room, bool) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	if room := rm.rooms[rm.playerToRoom[playerID]]; room != nil {
+		room.mu.Lock()
+		defer room.mu.Unlock()
+		for _, player := range room.Players {
+			if player.ID == playerID {
+				player.DisplayName = displayName
+				return room, true
+			}
+		}
+		return nil, false
+	}
+	for _, player := range rm.waitingPlayers {
+		if player.ID == playerID {
+			player.DisplayName = displayName
+			return nil, true
+		}
+	}
+	return nil, false
+}
+
 func (rm *RoomManager) GetRoom(roomID string) *Room {
 	rm.mu.RLock()
 	defer rm.mu.RUnlock()
@@ -408,19 +679,9 @@ func (rm *RoomManager) SendToWaitingPlayer(playerID string, msgBytes []byte) {
 
 	for _, player := range rm.waitingPlayers {
 		if player.ID == playerID {
-			func() {
-				defer func() {
-					if rec := recover(); rec != nil {
-						log.Printf("Warning: Could not send message to waiting player %s (channel closed)", playerID)
-					}
-				}()
-
-				select {
-				case player.SendChan <- msgBytes:
-				default:
-					log.Printf("Warning: Could not send message to waiting player %s (channel full)", playerID)
-				}
-			}()
+			if err := player.Send(msgBytes); err != nil {
+				log.Printf("Warning: Could not send message to waiting player %s (%v)", playerID, err)
+			}
 			return
 		}
 	}
@@ -434,19 +695,9 @@ func (rm *RoomManager) SendToPlayer(playerID string, msgBytes []byte) bool {
 	if inRoom {
 		if room, roomExists := rm.rooms[roomID]; roomExists {
 			if player := room.GetPlayer(playerID); player != nil {
-				func() {
-					defer func() {
-						if rec := recover(); rec != nil {
-							log.Printf("Warning: Could not send message to player %s (channel closed)", playerID)
-						}
-					}()
-
-					select {
-					case player.SendChan <- msgBytes:
-					default:
-						log.Printf("Warning: Could not send message to player %s (channel full)", playerID)
-					}
-				}()
+				if err := player.Send(msgBytes); err != nil {
+					log.Printf("Warning: Could not send message to player %s (%v)", playerID, err)
+				}
 				return true
 			}
 		}
@@ -454,19 +705,9 @@ func (rm *RoomManager) SendToPlayer(playerID string, msgBytes []byte) bool {
 
 	for _, player := range rm.waitingPlayers {
 		if player.ID == playerID {
-			func() {
-				defer func() {
-					if rec := recover(); rec != nil {
-						log.Printf("Warning: Could not send message to waiting player %s (channel closed)", playerID)
-					}
-				}()
-
-				select {
-				case player.SendChan <- msgBytes:
-				default:
-					log.Printf("Warning: Could not send message to waiting player %s (channel full)", playerID)
-				}
-			}()
+			if err := player.Send(msgBytes); err != nil {
+				log.Printf("Warning: Could not send message to waiting player %s (%v)", playerID, err)
+			}
 			return true
 		}
 	}
@@ -483,19 +724,9 @@ func (rm *RoomManager) BroadcastToAll(msgBytes []byte) {
 	}
 
 	for _, player := range rm.waitingPlayers {
-		func() {
-			defer func() {
-				if rec := recover(); rec != nil {
-					log.Printf("Warning: Could not send message to waiting player %s (channel closed)", player.ID)
-				}
-			}()
-
-			select {
-			case player.SendChan <- msgBytes:
-			default:
-				log.Printf("Warning: Could not send message to waiting player %s (channel full)", player.ID)
-			}
-		}()
+		if err := player.Send(msgBytes); err != nil {
+			log.Printf("Warning: Could not send message to waiting player %s (%v)", player.ID, err)
+		}
 	}
 }
 
@@ -510,6 +741,52 @@ func (rm *RoomManager) GetAllRooms() []*Room {
 	return rooms
 }
 
+// ActiveMatches returns the started matches of rooms with players connected,
+// so a room kept alive with nobody in it does not run down its clock
+func (rm *RoomManager) ActiveMatches() []*Match {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	matches := []*Match{}
+	for _, room := range rm.rooms {
+		room.mu.RLock()
+		match, occupied := room.Match, len(room.Players) > 0
+		room.mu.RUnlock()
+
+		if match != nil && occupied && match.IsStarted() {
+			matches = append(matches, match)
+		}
+	}
+	return matches
+}
+
+// PlayerCount returns how many players the manager tracks across rooms, the
+// public matchmaking queue and the capacity queue.
+func (rm *RoomManager) PlayerCount() int {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	return len(rm.playerToRoom) + len(rm.waitingPlayers) + len(rm.capacityQueue)
+}
+
+// HasWaitingPublicPlayer reports whether a public join would be matched with
+// a player already waiting here, in the matchmaking queue or alone in a
+// public room
+func (rm *RoomManager) HasWaitingPublicPlayer() bool {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	if len(rm.waitingPlayers) > 0 {
+		return true
+	}
+	for _, room := range rm.rooms {
+		if room.Kind == RoomKindPublic && room.PlayerCount() == 1 && !room.Match.IsEnded() {
+			return true
+		}
+	}
+	return false
+}
+
 func (rm *RoomManager) RemoveRoomIfIdle(roomID string) bool {
 	rm.mu.Lock()
 	defer rm.mu.Unlock()
@@ -1,10 +1,12 @@
 package game
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func drainChannel(ch <-chan []byte) {
@@ -69,6 +71,128 @@ func TestBroadcastExcludeSender(t *testing.T) {
 	assert.Equal(t, testMsg, <-player2Chan)
 }
 
+// TestBroadcastTap tests that a tap sees every broadcast, even ones no player receives
+func TestBroadcastTap(t *testing.T) {
+	room := NewRoom()
+	room.AddPlayer(&Player{ID: "player1", SendChan: make(chan []byte, 10)})
+
+	var tapped, other [][]byte
+	room.SetBroadcastTap("test", func(message []byte) { tapped = append(tapped, message) })
+	room.SetBroadcastTap("other", func(message []byte) { other = append(other, message) })
+
+	first := []byte(`{"type":"test","data":"first"}`)
+	second := []byte(`{"type":"test","data":"second"}`)
+	room.Broadcast(first, "")
+	room.Broadcast(second, "player1")
+	assert.Equal(t, [][]byte{first, second}, tapped)
+	assert.Equal(t, tapped, other, "every tap sees every broadcast")
+
+	room.SetBroadcastTap("test", nil)
+	room.Broadcast(first, "")
+	assert.Len(t, tapped, 2, "a removed tap sees nothing")
+	assert.Len(t, other, 3)
+}
+
+func TestBroadcastTeam(t *testing.T) {
+	room := NewRoom()
+	players := map[string]*Player{
+		"alpha1": {ID: "alpha1", Team: TeamAlpha, SendChan: make(chan []byte, 10)},
+		"alpha2": {ID: "alpha2", Team: TeamAlpha, SendChan: make(chan []byte, 10)},
+		"bravo1": {ID: "bravo1", Team: TeamBravo, SendChan: make(chan []byte, 10)},
+		"solo1":  {ID: "solo1", SendChan: make(chan []byte, 10)},
+		"solo2":  {ID: "solo2", SendChan: make(chan []byte, 10)},
+	}
+	for _, id := range []string{"alpha1", "alpha2", "bravo1", "solo1", "solo2"} {
+		room.AddPlayer(players[id])
+	}
+
+	message := []byte(`{"type":"team:ping"}`)
+	room.BroadcastTeam("team:ping", message, "alpha1")
+	room.BroadcastTeam("team:ping", message, "solo1")
+	room.BroadcastTeam("team:ping", message, "gone")
+
+	received := map[string]int{}
+	for id, player := range players {
+		received[id] = len(player.SendChan)
+	}
+	assert.Equal(t, map[string]int{"alpha1": 1, "alpha2": 1, "bravo1": 0, "solo1": 1, "solo2": 0}, received,
+		"teammates get it; a player without a team is a team of one")
+}
+
+func TestBroadcastTeamReachesAssignedTeammates(t *testing.T) {
+	room := NewRoom()
+	room.Teams = true
+	players := make([]*Player, 4)
+	for i := range players {
+		players[i] = NewPlayer(fmt.Sprintf("p%d", i+1), make(chan []byte, 10))
+		require.NoError(t, room.AddPlayer(players[i]))
+	}
+	require.Equal(t, players[0].Team, players[2].Team)
+	require.NotEqual(t, players[0].Team, players[1].Team)
+
+	room.BroadcastTeam("team:ping", []byte(`{"type":"team:ping"}`), players[0].ID)
+
+	assert.Len(t, players[0].SendChan, 1)
+	assert.Len(t, players[2].SendChan, 1, "the teammate gets the ping")
+	assert.Empty(t, players[1].SendChan)
+	assert.Empty(t, players[3].SendChan)
+}
+
+func TestBroadcastChatSkipsPlayersWhoMutedTheSender(t *testing.T) {
+	room := NewRoom()
+	sender := NewPlayer("sender", make(chan []byte, 10))
+	friend := NewPlayer("friend", make(chan []byte, 10))
+	muter := NewPlayer("muter", make(chan []byte, 10))
+	for _, player := range []*Player{sender, friend, muter} {
+		room.AddPlayer(player)
+	}
+	muter.ChatMutes.Set("sender", true)
+	muter.ChatMutes.Set("friend", true)
+	muter.ChatMutes.Set("friend", false)
+
+	room.BroadcastChat([]byte(`{"type":"chat:posted"}`), "sender", false)
+	assert.Len(t, sender.SendChan, 1, "senders see their own messages")
+	assert.Len(t, friend.SendChan, 1)
+	assert.Empty(t, muter.SendChan)
+
+	room.BroadcastChat([]byte(`{"type":"chat:posted"}`), "friend", false)
+	assert.Len(t, muter.SendChan, 1, "unmuted again")
+	assert.False(t, (*MuteList)(nil).Mutes("anyone"))
+}
+
+func TestBroadcastChatTeamOnlyReachesTheSendersTeam(t *testing.T) {
+	room := NewRoom()
+	room.Teams = true
+	players := make([]*Player, 4)
+	for i := range players {
+		players[i] = NewPlayer(fmt.Sprintf("p%d", i+1), make(chan []byte, 10))
+		require.NoError(t, room.AddPlayer(players[i]))
+	}
+	require.Equal(t, players[0].Team, players[2].Team)
+	players[2].ChatMutes.Set(players[0].ID, true)
+
+	room.BroadcastChat([]byte(`{"type":"chat:posted"}`), players[0].ID, true)
+	assert.Len(t, players[0].SendChan, 1)
+	assert.Empty(t, players[2].SendChan, "mutes still apply")
+	assert.Empty(t, players[1].SendChan)
+	assert.Empty(t, players[3].SendChan)
+
+	ffa := NewRoom()
+	loner := NewPlayer("loner", make(chan []byte, 10))
+	require.NoError(t, ffa.AddPlayer(loner))
+	ffa.BroadcastChat([]byte(`{"type":"chat:posted"}`), "loner", true)
+	assert.Empty(t, loner.SendChan, "team chat needs a team")
+}
+
+func TestRoomAllChatIsOnByDefault(t *testing.T) {
+	room := NewRoom()
+	assert.True(t, room.AllChat())
+	room.SetAllChat(false)
+	assert.False(t, room.AllChat())
+	room.SetAllChat(true)
+	assert.True(t, room.AllChat())
+}
+
 // TestBroadcastChannelFull tests broadcast when channel is full
 func TestBroadcastChannelFull(t *testing.T) {
 	room := NewRoom()
@@ -0,0 +1,165 @@
+package game
+
+import "log"
+
+// CapacityLimits bounds how much a single server instance hosts. Zero
+// disables a limit.
+type CapacityLimits struct {
+	MaxRooms    int    // Simultaneous rooms, including pre-match named rooms
+	MaxPlayers  int    // Players in rooms or the public matchmaking queue
+	RedirectURL string // Sibling instance suggested instead of queueing; empty queues overflow players
+}
+
+// RoomCapacityNotice tells a player turned away at capacity where they stand.
+type RoomCapacityNotice struct {
+	Player        *Player
+	QueuePosition int // 1-based; zero when redirected
+	RedirectURL   string
+}
+
+// RoomSessionAdmission is a queued player admitted once capacity freed up.
+type RoomSessionAdmission struct {
+	Player *Player
+	Result RoomSessionResult
+}
+
+type capacityQueueEntry struct {
+	player       *Player
+	mode         RoomKind
+	code         string
+	lastPosition int
+}
+
+func (rm *RoomManager) SetCapacityLimits(limits CapacityLimits) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.capacity = limits
+}
+
+func (rm *RoomManager) CapacityLimits() CapacityLimits {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	return rm.capacity
+}
+
+// CapacityQueueLength returns how many players are waiting for capacity.
+func (rm *RoomManager) CapacityQueueLength() int {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	return len(rm.capacityQueue)
+}
+
+// capacityRejectionLocked turns a join away when this instance is full. The
+// player is either pointed at the redirect URL or appended to the capacity
+// queue. It returns nil when the join may proceed.
+func (rm *RoomManager) capacityRejectionLocked(player *Player, mode RoomKind, code string) *RoomSessionRejection {
+	for i, entry := range rm.capacityQueue {
+		if entry.player.ID == player.ID {
+			return &RoomSessionRejection{Kind: RoomSessionRejectionAtCapacity, QueuePosition: i + 1}
+		}
+	}
+
+	if len(rm.capacityQueue) == 0 && !rm.atCapacityLocked(mode, code) {
+		return nil
+	}
+
+	if rm.capacity.RedirectURL != "" {
+		log.Printf("Instance at capacity, redirecting player %s to %s", player.ID, rm.capacity.RedirectURL)
+		return &RoomSessionRejection{Kind: RoomSessionRejectionAtCapacity, RedirectURL: rm.capacity.RedirectURL}
+	}
+
+	position := len(rm.capacityQueue) + 1
+	rm.capacityQueue = append(rm.capacityQueue, capacityQueueEntry{
+		player:       player,
+		mode:         mode,
+		code:         code,
+		lastPosition: position,
+	})
+	log.Printf("Instance at capacity, player %s queued at position %d", player.ID, position)
+	return &RoomSessionRejection{Kind: RoomSessionRejectionAtCapacity, QueuePosition: position}
+}
+
+// atCapacityLocked reports whether a join in the given mode would exceed the
+// player limit or require a room beyond the room limit.
+func (rm *RoomManager) atCapacityLocked(mode RoomKind, code string) bool {
+	if rm.capacity.MaxPlayers > 0 && len(rm.playerToRoom)+len(rm.waitingPlayers) >= rm.capacity.MaxPlayers {
+		return true
+	}
+	if rm.capacity.MaxRooms <= 0 || len(rm.rooms) < rm.capacity.MaxRooms {
+		return false
+	}
+	return rm.joinNeedsRoomLocked(mode, code)
+}
+
+func (rm *RoomManager) joinNeedsRoomLocked(mode RoomKind, code string) bool {
+	if mode == RoomKindPractice {
+		return true
+	}
+	if mode == RoomKindCode {
+		roomID, ok := rm.codeIndex[code]
+		if !ok {
+			return true
+		}
+		room, exists := rm.rooms[roomID]
+		return !exists || room.Match.IsEnded()
+	}
+
+	for _, room := range rm.rooms {
+		if room.Kind == RoomKindPublic && room.PlayerCount() == 1 && !room.Match.IsEnded() {
+			return false
+		}
+	}
+	return len(rm.waitingPlayers)+1 >= rm.settings.MinHumanPlayers
+}
+
+func (rm *RoomManager) removeFromCapacityQueueLocked(playerID string) bool {
+	for i, entry := range rm.capacityQueue {
+		if entry.player.ID == playerID {
+			rm.capacityQueue = append(rm.capacityQueue[:i], rm.capacityQueue[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// AdmitQueued lets players waiting for capacity in, oldest first, for as long
+// as there is room. It returns the admitted players' join results and a
+// notice for every still-queued player whose position changed.
+func (f *RoomSessionFlow) AdmitQueued() ([]RoomSessionAdmission, []RoomCapacityNotice) {
+	rm := f.roomManager
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	admissions := make([]RoomSessionAdmission, 0)
+	for len(rm.capacityQueue) > 0 {
+		head := rm.capacityQueue[0]
+		if rm.atCapacityLocked(head.mode, head.code) {
+			break
+		}
+		rm.capacityQueue = rm.capacityQueue[1:]
+
+		log.Printf("Capacity freed, admitting queued player %s", head.player.ID)
+		admissions = append(admissions, RoomSessionAdmission{
+			Player: head.player,
+			Result: f.joinLocked(head.player, head.mode, head.code),
+		})
+	}
+
+	return admissions, rm.capacityNoticesLocked()
+}
+
+func (rm *RoomManager) capacityNoticesLocked() []RoomCapacityNotice {
+	var notices []RoomCapacityNotice
+	for i := range rm.capacityQueue {
+		entry := &rm.capacityQueue[i]
+		if entry.lastPosition == i+1 {
+			continue
+		}
+		entry.lastPosition = i + 1
+		notices = append(notices, RoomCapacityNotice{Player: entry.player, QueuePosition: i + 1})
+	}
+	return notices
+}
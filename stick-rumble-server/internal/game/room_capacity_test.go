@@ -0,0 +1,120 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapacityPlayerLimitQueuesOverflowPlayers(t *testing.T) {
+	manager := NewRoomManager()
+	manager.SetCapacityLimits(CapacityLimits{MaxPlayers: 2})
+	flow := manager.SessionFlow()
+
+	flow.HandleHello(newSessionFlowPlayer("player-1"), map[string]any{"mode": "public"})
+	formed := flow.HandleHello(newSessionFlowPlayer("player-2"), map[string]any{"mode": "public"})
+	require.NotNil(t, formed.Room)
+
+	third := newSessionFlowPlayer("player-3")
+	fourth := newSessionFlowPlayer("player-4")
+	rejected := flow.HandleHello(third, map[string]any{"mode": "public"})
+	require.NotNil(t, rejected.Rejection)
+	assert.Equal(t, RoomSessionRejectionAtCapacity, rejected.Rejection.Kind)
+	assert.Equal(t, 1, rejected.Rejection.QueuePosition)
+	assert.Empty(t, rejected.Rejection.RedirectURL)
+
+	rejected = flow.HandleHello(fourth, map[string]any{"mode": "code", "code": "LATER"})
+	require.NotNil(t, rejected.Rejection)
+	assert.Equal(t, 2, rejected.Rejection.QueuePosition)
+
+	repeat := flow.HandleHello(third, map[string]any{"mode": "public"})
+	require.NotNil(t, repeat.Rejection)
+	assert.Equal(t, 1, repeat.Rejection.QueuePosition, "a repeated hello keeps its place")
+	assert.Equal(t, 2, manager.CapacityQueueLength())
+}
+
+func TestCapacityRedirectHintInsteadOfQueue(t *testing.T) {
+	manager := NewRoomManager()
+	manager.SetCapacityLimits(CapacityLimits{MaxPlayers: 1, RedirectURL: "wss://eu-2.example/ws"})
+	flow := manager.SessionFlow()
+
+	flow.HandleHello(newSessionFlowPlayer("player-1"), map[string]any{"mode": "public"})
+	result := flow.HandleHello(newSessionFlowPlayer("player-2"), map[string]any{"mode": "public"})
+
+	require.NotNil(t, result.Rejection)
+	assert.Equal(t, "wss://eu-2.example/ws", result.Rejection.RedirectURL)
+	assert.Zero(t, result.Rejection.QueuePosition)
+	assert.Zero(t, manager.CapacityQueueLength(), "redirected players are not held")
+}
+
+func TestCapacityRoomLimitOnlyBlocksNewRooms(t *testing.T) {
+	manager := NewRoomManager()
+	manager.SetCapacityLimits(CapacityLimits{MaxRooms: 1})
+	flow := manager.SessionFlow()
+
+	host := flow.HandleHello(newSessionFlowPlayer("host"), map[string]any{"mode": "code", "code": "ONLY"})
+	require.Nil(t, host.Rejection)
+
+	guest := flow.HandleHello(newSessionFlowPlayer("guest"), map[string]any{"mode": "code", "code": "ONLY"})
+	assert.Nil(t, guest.Rejection, "joining an existing room needs no new room")
+
+	other := flow.HandleHello(newSessionFlowPlayer("other"), map[string]any{"mode": "code", "code": "SECOND"})
+	require.NotNil(t, other.Rejection)
+	assert.Equal(t, RoomSessionRejectionAtCapacity, other.Rejection.Kind)
+
+	searching := flow.HandleHello(newSessionFlowPlayer("searching"), map[string]any{"mode": "public"})
+	assert.NotNil(t, searching.Rejection, "players queue behind earlier overflow players")
+}
+
+func TestAdmitQueuedAdmitsOncePlayersLeave(t *testing.T) {
+	manager := NewRoomManager()
+	manager.SetReadyCheckConfig(ReadyCheckConfig{})
+	manager.SetCapacityLimits(CapacityLimits{MaxPlayers: 2})
+	flow := manager.SessionFlow()
+
+	flow.HandleHello(newSessionFlowPlayer("player-1"), map[string]any{"mode": "public"})
+	flow.HandleHello(newSessionFlowPlayer("player-2"), map[string]any{"mode": "public"})
+	third := newSessionFlowPlayer("player-3")
+	fourth := newSessionFlowPlayer("player-4")
+	flow.HandleHello(third, map[string]any{"mode": "code", "code": "AFTER"})
+	flow.HandleHello(fourth, map[string]any{"mode": "public"})
+
+	admissions, notices := flow.AdmitQueued()
+	assert.Empty(t, admissions)
+	assert.Empty(t, notices, "unchanged positions are not resent")
+
+	manager.RemovePlayer("player-1")
+	admissions, notices = flow.AdmitQueued()
+	require.Len(t, admissions, 1)
+	assert.Same(t, third, admissions[0].Player)
+	require.NotNil(t, admissions[0].Result.Room)
+	assert.Equal(t, "AFTER", admissions[0].Result.Room.Code)
+	require.Len(t, notices, 1)
+	assert.Same(t, fourth, notices[0].Player)
+	assert.Equal(t, 1, notices[0].QueuePosition)
+}
+
+func TestCapacityQueueDropsDisconnectedPlayers(t *testing.T) {
+	manager := NewRoomManager()
+	manager.SetCapacityLimits(CapacityLimits{MaxPlayers: 1})
+	flow := manager.SessionFlow()
+
+	flow.HandleHello(newSessionFlowPlayer("player-1"), map[string]any{"mode": "public"})
+	flow.HandleHello(newSessionFlowPlayer("queued"), map[string]any{"mode": "public"})
+	require.Equal(t, 1, manager.CapacityQueueLength())
+
+	manager.RemovePlayer("queued")
+	assert.Zero(t, manager.CapacityQueueLength())
+}
+
+func TestCapacityUnlimitedByDefault(t *testing.T) {
+	manager := NewRoomManager()
+	flow := manager.SessionFlow()
+
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		result := flow.HandleHello(newSessionFlowPlayer(id), map[string]any{"mode": "code", "code": "ROOM" + id})
+		assert.Nil(t, result.Rejection)
+	}
+	assert.Zero(t, manager.CapacityQueueLength())
+}
@@ -13,6 +13,9 @@ import (
 type stubRoomEventPublisher struct {
 	sessionStatuses []sessionStatusCall
 	playerLefts     []string
+	playerJoins     []string
+	readyStates     []ReadyCheckState
+	partyStates     []PartyState
 	sessionErr      error
 	playerLeftErr   error
 }
@@ -48,6 +51,21 @@ func (p *stubRoomEventPublisher) PublishPlayerLeft(room *Room, playerID string)
 	return nil
 }
 
+func (p *stubRoomEventPublisher) PublishPlayerJoined(room *Room, player *Player) error {
+	p.playerJoins = append(p.playerJoins, player.ID)
+	return nil
+}
+
+func (p *stubRoomEventPublisher) PublishReadyState(room *Room, state ReadyCheckState) error {
+	p.readyStates = append(p.readyStates, state)
+	return nil
+}
+
+func (p *stubRoomEventPublisher) PublishPartyState(room *Room, state PartyState) error {
+	p.partyStates = append(p.partyStates, state)
+	return nil
+}
+
 type channelRoomEventPublisher struct{}
 
 func newChannelRoomEventPublisher() *channelRoomEventPublisher {
@@ -103,6 +121,65 @@ func (p *channelRoomEventPublisher) PublishPlayerLeft(room *Room, playerID strin
 	return nil
 }
 
+func (p *channelRoomEventPublisher) PublishPlayerJoined(room *Room, player *Player) error {
+	msgBytes, err := json.Marshal(map[string]any{
+		"type":      "player:joined",
+		"timestamp": time.Now().UnixMilli(),
+		"data": map[string]any{
+			"playerId":    player.ID,
+			"displayName": player.DisplayName,
+			"rosterSize":  room.PlayerCount(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	room.Broadcast(msgBytes, player.ID)
+	return nil
+}
+
+func (p *channelRoomEventPublisher) PublishReadyState(room *Room, state ReadyCheckState) error {
+	msgBytes, err := json.Marshal(map[string]any{
+		"type":      "room:ready_state",
+		"timestamp": time.Now().UnixMilli(),
+		"data": map[string]any{
+			"roomId":           state.RoomID,
+			"readyPlayerIds":   state.ReadyPlayerIDs,
+			"readyCount":       state.ReadyCount,
+			"requiredCount":    state.RequiredCount,
+			"totalPlayers":     state.TotalPlayers,
+			"remainingSeconds": state.RemainingSeconds,
+			"active":           state.Active,
+			"started":          state.Started,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	room.Broadcast(msgBytes, "")
+	return nil
+}
+
+func (p *channelRoomEventPublisher) PublishPartyState(room *Room, state PartyState) error {
+	msgBytes, err := json.Marshal(map[string]any{
+		"type":      "party:state",
+		"timestamp": time.Now().UnixMilli(),
+		"data": map[string]any{
+			"roomId":           state.RoomID,
+			"stayingPlayerIds": state.StayingPlayerIDs,
+			"totalPlayers":     state.TotalPlayers,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	room.Broadcast(msgBytes, "")
+	return nil
+}
+
 func sendLifecycleTestMessage(player *Player, msgBytes []byte) (err error) {
 	defer func() {
 		if rec := recover(); rec != nil {
@@ -199,6 +276,29 @@ func TestAddPlayerToFullRoom(t *testing.T) {
 	assert.Len(t, room.Players, 8)
 }
 
+// TestRoomAcceptsJoins tests which rooms a joining player could land in
+func TestRoomAcceptsJoins(t *testing.T) {
+	public := NewRoom()
+	assert.False(t, public.AcceptsJoins(), "matchmaking does not seat players in an empty public room")
+	require.NoError(t, public.AddPlayer(&Player{ID: "player1"}))
+	assert.True(t, public.AcceptsJoins())
+	require.NoError(t, public.AddPlayer(&Player{ID: "player2"}))
+	assert.False(t, public.AcceptsJoins())
+
+	named := NewTypedRoom(RoomKindCode, "PIZZA")
+	named.MaxPlayers = 2
+	require.NoError(t, named.AddPlayer(&Player{ID: "player1"}))
+	assert.True(t, named.AcceptsJoins())
+	require.NoError(t, named.AddPlayer(&Player{ID: "player2"}))
+	assert.False(t, named.AcceptsJoins(), "full")
+
+	named.RemovePlayer("player2")
+	named.Match.EndMatch("test")
+	assert.False(t, named.AcceptsJoins(), "ended")
+
+	assert.False(t, NewTypedRoom(RoomKindPractice, "").AcceptsJoins())
+}
+
 // TestRemovePlayer tests removing a player from a room
 func TestRemovePlayer(t *testing.T) {
 	room := NewRoom()
@@ -314,6 +414,7 @@ func TestPlayerDisconnection(t *testing.T) {
 
 	_ = readSessionStatusFromChan(t, player1Chan, string(SessionStatusMatchReady))
 	_ = readSessionStatusFromChan(t, player2Chan, string(SessionStatusMatchReady))
+	drainLifecycleChannel(player2Chan)
 
 	// Remove player1
 	manager.RemovePlayer("player1")
@@ -499,6 +600,12 @@ func TestRoomManagerUsesPublisherForSessionLifecycleMessages(t *testing.T) {
 
 	manager.RemovePlayer(player1.ID)
 	assert.Equal(t, []string{player1.ID}, publisher.playerLefts)
+
+	player3 := &Player{ID: "player3", SendChan: make(chan []byte, 4)}
+	joinedRoom := manager.AddPlayer(player3)
+	require.NotNil(t, joinedRoom)
+	assert.Equal(t, room.ID, joinedRoom.ID)
+	assert.Equal(t, []string{player3.ID}, publisher.playerJoins)
 }
 
 func TestRoomManagerIgnoresPublisherFailures(t *testing.T) {
@@ -596,6 +703,43 @@ func TestGetPlayer(t *testing.T) {
 	})
 }
 
+func TestRoomRosterReflectsPlayersInJoinOrder(t *testing.T) {
+	room := NewRoom()
+	player1 := &Player{ID: "player1", DisplayName: "Alpha", SendChan: make(chan []byte, 1)}
+	player2 := &Player{ID: "player2", DisplayName: "Bravo", Team: TeamBravo, Ready: true, SendChan: make(chan []byte, 1)}
+	require.NoError(t, room.AddPlayer(player1))
+	require.NoError(t, room.AddPlayer(player2))
+
+	assert.Equal(t, []RosterEntry{
+		{PlayerID: "player1", DisplayName: "Alpha"},
+		{PlayerID: "player2", DisplayName: "Bravo", Team: TeamBravo, Ready: true},
+	}, room.Roster())
+
+	room.RemovePlayer("player1")
+	roster := room.Roster()
+	require.Len(t, roster, 1)
+	assert.Equal(t, "player2", roster[0].PlayerID)
+}
+
+// TestActiveMatches tests which room matches run on the simulation clock
+func TestActiveMatches(t *testing.T) {
+	manager := NewRoomManager()
+	player1 := &Player{ID: "player1", SendChan: make(chan []byte, 10)}
+	player2 := &Player{ID: "player2", SendChan: make(chan []byte, 10)}
+	manager.AddPlayer(player1)
+	room := manager.AddPlayer(player2)
+	require.NotNil(t, room)
+
+	assert.Empty(t, manager.ActiveMatches(), "a match that has not started has no clock")
+
+	room.Match.Start()
+	assert.Equal(t, []*Match{room.Match}, manager.ActiveMatches())
+
+	room.RemovePlayer(player1.ID)
+	room.RemovePlayer(player2.ID)
+	assert.Empty(t, manager.ActiveMatches(), "an empty room's match clock stops")
+}
+
 // TestGetAllRooms tests retrieving all active rooms from RoomManager
 func TestGetAllRooms(t *testing.T) {
 	t.Run("returns empty slice when no rooms exist", func(t *testing.T) {
@@ -654,3 +798,20 @@ func TestGetAllRooms(t *testing.T) {
 		assert.Contains(t, roomIDs, room2.ID)
 	})
 }
+
+func TestRoomManagerPlayerCountCoversQueuesAndRooms(t *testing.T) {
+	manager := NewRoomManager()
+	manager.SetCapacityLimits(CapacityLimits{MaxPlayers: 3})
+	flow := manager.SessionFlow()
+
+	flow.HandleHello(newSessionFlowPlayer("searching"), map[string]any{"mode": "public"})
+	flow.HandleHello(newSessionFlowPlayer("host"), map[string]any{"mode": "code", "code": "COUNT"})
+	flow.HandleHello(newSessionFlowPlayer("guest"), map[string]any{"mode": "code", "code": "COUNT"})
+	flow.HandleHello(newSessionFlowPlayer("overflow"), map[string]any{"mode": "public"})
+	assert.Equal(t, 4, manager.PlayerCount())
+
+	for _, id := range []string{"searching", "host", "guest", "overflow"} {
+		manager.RemovePlayer(id)
+	}
+	assert.Zero(t, manager.PlayerCount())
+}
@@ -0,0 +1,128 @@
+package game
+
+import (
+	"log"
+	"math/rand"
+	"sync"
+)
+
+// maxRoomSeed keeps seeds within the range a JSON number can carry exactly,
+// so a seed copied out of logs or match metadata round-trips unchanged.
+const maxRoomSeed = 1<<53 - 1
+
+// RandomSource is the slice of math/rand that randomized gameplay rules use.
+// Passing a room's RoomRNG makes those rules reproducible from the seed.
+type RandomSource interface {
+	Float64() float64
+	Intn(n int) int
+}
+
+// RoomRNG is a seeded random source owned by one room. All randomness for
+// that room's match (crate rolls, spreads, bot decisions) should draw from it
+// so a match can be replayed from its seed.
+type RoomRNG struct {
+	seed int64
+	rng  *rand.Rand
+	mu   sync.Mutex // rand.Rand is not safe for concurrent use
+}
+
+// NewRoomRNG creates a random source seeded with seed.
+func NewRoomRNG(seed int64) *RoomRNG {
+	return &RoomRNG{
+		seed: seed,
+		rng:  rand.New(rand.NewSource(seed)),
+	}
+}
+
+// NewRoomSeed picks a fresh seed for a new room.
+func NewRoomSeed() int64 {
+	return rand.Int63n(maxRoomSeed) + 1
+}
+
+// Seed returns the seed the source was created with.
+func (r *RoomRNG) Seed() int64 {
+	return r.seed
+}
+
+func (r *RoomRNG) Float64() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rng.Float64()
+}
+
+func (r *RoomRNG) Intn(n int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rng.Intn(n)
+}
+
+// defaultRoomSeed seeds the source shared by callers without a room, so even
+// their rolls repeat from run to run.
+const defaultRoomSeed = 1
+
+var defaultRandomSource = NewRoomRNG(defaultRoomSeed)
+
+func randomSourceOrDefault(source RandomSource) RandomSource {
+	if source == nil {
+		return defaultRandomSource
+	}
+	return source
+}
+
+// RandomSource returns the room's random source.
+func (r *Room) RandomSource() *RoomRNG {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.RNG
+}
+
+// RandomSourceForPlayer returns the random source of the player's room, or
+// nil if the player is in none.
+func (rm *RoomManager) RandomSourceForPlayer(playerID string) RandomSource {
+	room := rm.GetRoomByPlayerID(playerID)
+	if room == nil {
+		return nil
+	}
+	return room.RandomSource()
+}
+
+// randomSourceFor returns the random source of the player's room, or nil
+func (gs *GameServer) randomSourceFor(playerID string) RandomSource {
+	if gs.randomSource == nil {
+		return nil
+	}
+	return gs.randomSource(playerID)
+}
+
+// Reseed replaces the room's random source, recording the new seed on the
+// match. Used to replay a match from a seed taken from logs or a bug report.
+func (r *Room) Reseed(seed int64) {
+	r.mu.Lock()
+	r.RNG = NewRoomRNG(seed)
+	r.mu.Unlock()
+
+	r.Match.SetSeed(seed)
+}
+
+// newRoomLocked creates a room using the manager's map, seed, team and
+// gameplay hook settings.
+// Called with rm.mu held.
+func (rm *RoomManager) newRoomLocked(kind RoomKind, code string) *Room {
+	room := NewTypedRoom(kind, code, rm.defaultMapID)
+	if rm.fixedSeed != 0 {
+		room.Reseed(rm.fixedSeed)
+	}
+	room.Teams = rm.settings.Teams
+	rm.attachGameplayHooksLocked(room)
+	log.Printf("Room %s created (seed %d)", room.ID, room.RNG.Seed())
+	return room
+}
+
+// SetFixedRoomSeed makes every room created afterwards use seed, so a
+// reported match can be reproduced. Zero restores random seeds.
+func (rm *RoomManager) SetFixedRoomSeed(seed int64) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.fixedSeed = seed
+}
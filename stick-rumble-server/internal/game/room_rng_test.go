@@ -0,0 +1,86 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomRNGSameSeedSameSequence(t *testing.T) {
+	first := NewRoomRNG(42)
+	second := NewRoomRNG(42)
+
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, first.Float64(), second.Float64())
+		assert.Equal(t, first.Intn(100), second.Intn(100))
+	}
+	assert.Equal(t, int64(42), first.Seed())
+}
+
+func TestNewTypedRoomRecordsSeedOnMatch(t *testing.T) {
+	room := NewTypedRoom(RoomKindPublic, "")
+
+	require.NotNil(t, room.RNG)
+	assert.NotZero(t, room.RNG.Seed())
+	assert.Equal(t, room.RNG.Seed(), room.Match.GetSeed())
+	assert.LessOrEqual(t, room.RNG.Seed(), int64(maxRoomSeed))
+}
+
+func TestFixedRoomSeedAppliesToNewRooms(t *testing.T) {
+	manager := NewRoomManager()
+	manager.SetFixedRoomSeed(1234)
+	flow := manager.SessionFlow()
+
+	flow.HandleHello(newSessionFlowPlayer("player-1"), map[string]any{"mode": "public"})
+	public := flow.HandleHello(newSessionFlowPlayer("player-2"), map[string]any{"mode": "public"})
+	code := flow.HandleHello(newSessionFlowPlayer("player-3"), map[string]any{"mode": "code", "code": "SEEDED"})
+
+	require.NotNil(t, public.Room)
+	require.NotNil(t, code.Room)
+	assert.Equal(t, int64(1234), public.Room.Match.GetSeed())
+	assert.Equal(t, int64(1234), code.Room.RNG.Seed())
+}
+
+func TestSpreadIsReproducibleFromRoomSeed(t *testing.T) {
+	fire := func(seed int64) []float64 {
+		return CalculateShotgunPelletAnglesFrom(NewRoomRNG(seed), 0, 15)
+	}
+
+	assert.Equal(t, fire(7), fire(7))
+	assert.NotEqual(t, fire(7), fire(8))
+}
+
+func TestGameServerDrawsPelletSpreadFromRoomSource(t *testing.T) {
+	fire := func(seed int64) []Vector2 {
+		rng := NewRoomRNG(seed)
+		gs := NewGameServerWithConfig(GameServerConfig{
+			Clock:        NewManualClock(time.UnixMilli(1704067200000)),
+			RandomSource: func(string) RandomSource { return rng },
+		})
+		gs.AddPlayer("shooter")
+		gs.SetWeaponState("shooter", NewWeaponState(NewShotgun()))
+
+		result := gs.PlayerShoot("shooter", 0, 0)
+		require.True(t, result.Success)
+		velocities := make([]Vector2, 0, len(result.Pellets))
+		for _, pellet := range result.Pellets {
+			velocities = append(velocities, pellet.Velocity)
+		}
+		return velocities
+	}
+
+	assert.Equal(t, fire(7), fire(7), "the same room seed fires the same spread")
+	assert.NotEqual(t, fire(7), fire(8))
+}
+
+func TestRandomSourceForPlayerIsTheirRoomsSource(t *testing.T) {
+	manager := NewRoomManager()
+	manager.AddPlayer(newSessionFlowPlayer("player-1"))
+	room := manager.AddPlayer(newSessionFlowPlayer("player-2"))
+	require.NotNil(t, room)
+
+	assert.Same(t, room.RNG, manager.RandomSourceForPlayer("player-1"))
+	assert.Nil(t, manager.RandomSourceForPlayer("nobody"), "a player outside any room gets the fixed-seed fallback")
+}
@@ -1,5 +1,7 @@
 package game
 
+import "time"
+
 type RoomSessionActivation struct {
 	Player *Player
 	Room   *Room
@@ -11,24 +13,38 @@ type RoomSessionPublication struct {
 	State  SessionStatusState
 }
 
+// RoomSessionJoin records a player entering a room that already had members,
+// so the existing roster can be told about the newcomer.
+type RoomSessionJoin struct {
+	Player *Player
+	Room   *Room
+}
+
 type RoomSessionRejectionKind string
 
 const (
 	RoomSessionRejectionBadRoomCode  RoomSessionRejectionKind = "bad_room_code"
 	RoomSessionRejectionRoomFull     RoomSessionRejectionKind = "room_full"
 	RoomSessionRejectionInvalidHello RoomSessionRejectionKind = "invalid_hello"
+	RoomSessionRejectionAtCapacity   RoomSessionRejectionKind = "at_capacity"
+	RoomSessionRejectionVoteKicked   RoomSessionRejectionKind = "vote_kicked"
 )
 
 type RoomSessionRejection struct {
-	Kind   RoomSessionRejectionKind
-	Reason string
-	Code   string
+	Kind          RoomSessionRejectionKind
+	Reason        string
+	Code          string
+	QueuePosition int           // Set when the player was queued for capacity
+	RedirectURL   string        // Set when the player should try another instance
+	RetryAfter    time.Duration // Set when the player was vote-kicked from the room
 }
 
 type RoomSessionResult struct {
 	Room         *Room
 	Publications []RoomSessionPublication
 	Activations  []RoomSessionActivation
+	Joins        []RoomSessionJoin
+	ReadyChecks  []*Room
 	LeftSession  bool
 	Rejection    *RoomSessionRejection
 }
@@ -47,12 +63,12 @@ func (f *RoomSessionFlow) HandleHello(player *Player, data map[string]any) RoomS
 		player.DisplayName = SanitizeDisplayName(rawDisplayName)
 	}
 
+	var code string
 	mode, _ := data["mode"].(string)
 	switch mode {
-	case string(RoomKindPublic):
-		return f.joinPublic(player)
+	case string(RoomKindPublic), string(RoomKindPractice):
 	case string(RoomKindCode):
-		code, reason, normalized := NormalizeRoomCode(data["code"])
+		normalizedCode, reason, normalized := NormalizeRoomCode(data["code"])
 		if !normalized {
 			return RoomSessionResult{
 				Rejection: &RoomSessionRejection{
@@ -61,19 +77,36 @@ func (f *RoomSessionFlow) HandleHello(player *Player, data map[string]any) RoomS
 				},
 			}
 		}
-		return f.joinCode(player, code)
+		code = normalizedCode
 	default:
 		return RoomSessionResult{
 			Rejection: &RoomSessionRejection{Kind: RoomSessionRejectionInvalidHello},
 		}
 	}
-}
 
-func (f *RoomSessionFlow) joinPublic(player *Player) RoomSessionResult {
 	rm := f.roomManager
 	rm.mu.Lock()
 	defer rm.mu.Unlock()
 
+	if rejection := rm.capacityRejectionLocked(player, RoomKind(mode), code); rejection != nil {
+		return RoomSessionResult{Rejection: rejection}
+	}
+	return f.joinLocked(player, RoomKind(mode), code)
+}
+
+func (f *RoomSessionFlow) joinLocked(player *Player, mode RoomKind, code string) RoomSessionResult {
+	switch mode {
+	case RoomKindCode:
+		return f.joinCodeLocked(player, code)
+	case RoomKindPractice:
+		return f.joinPracticeLocked(player)
+	}
+	return f.joinPublicLocked(player)
+}
+
+// joinPublicLocked is called with rm.mu held.
+func (f *RoomSessionFlow) joinPublicLocked(player *Player) RoomSessionResult {
+	rm := f.roomManager
 	for _, room := range rm.rooms {
 		if room.Kind != RoomKindPublic || room.PlayerCount() != 1 || room.Match.IsEnded() {
 			continue
@@ -83,16 +116,20 @@ func (f *RoomSessionFlow) joinPublic(player *Player) RoomSessionResult {
 		}
 		rm.playerToRoom[player.ID] = room.ID
 		room.Match.RegisterPlayer(player.ID)
-		if room.PlayerCount() >= MinPlayersToStart && !room.Match.IsStarted() {
-			room.Match.Start()
-		}
-		return RoomSessionResult{
+		rm.queuePlayerLocked(player, room)
+		result := RoomSessionResult{
 			Room:         room,
 			Publications: sessionPublicationsForRoom(room, SessionStatusMatchReady),
 			Activations:  sessionActivationsForRoom(room),
+			Joins:        []RoomSessionJoin{{Player: player, Room: room}},
+		}
+		if room.PlayerCount() >= MinPlayersToStart && !room.Match.IsStarted() && rm.beginMatchOrReadyCheck(room) {
+			result.ReadyChecks = []*Room{room}
 		}
+		return result
 	}
 
+	rm.queuePlayerLocked(player, nil)
 	rm.waitingPlayers = append(rm.waitingPlayers, player)
 	result := RoomSessionResult{
 		Publications: []RoomSessionPublication{{
@@ -100,41 +137,50 @@ func (f *RoomSessionFlow) joinPublic(player *Player) RoomSessionResult {
 			State:  SessionStatusSearchingForMatch,
 		}},
 	}
-	if len(rm.waitingPlayers) < MinPlayersToStart {
+	minHumans := rm.settings.MinHumanPlayers
+	if len(rm.waitingPlayers) < minHumans {
 		return result
 	}
 
-	room := NewTypedRoom(RoomKindPublic, "", rm.defaultMapID)
-	player1 := rm.waitingPlayers[0]
-	player2 := rm.waitingPlayers[1]
-	rm.waitingPlayers = rm.waitingPlayers[2:]
-
-	_ = room.AddPlayer(player1)
-	_ = room.AddPlayer(player2)
-	room.Match.RegisterPlayer(player1.ID)
-	room.Match.RegisterPlayer(player2.ID)
-	room.Match.Start()
+	room := rm.newRoomLocked(RoomKindPublic, "")
+	queued := rm.waitingPlayers[:minHumans]
+	rm.waitingPlayers = rm.waitingPlayers[minHumans:]
 
+	for _, queuedPlayer := range queued {
+		_ = room.AddPlayer(queuedPlayer)
+		room.Match.RegisterPlayer(queuedPlayer.ID)
+		rm.playerToRoom[queuedPlayer.ID] = room.ID
+	}
 	rm.rooms[room.ID] = room
-	rm.playerToRoom[player1.ID] = room.ID
-	rm.playerToRoom[player2.ID] = room.ID
 
-	return RoomSessionResult{
+	result = RoomSessionResult{
 		Room:         room,
 		Publications: sessionPublicationsForRoom(room, SessionStatusMatchReady),
 		Activations:  sessionActivationsForRoom(room),
 	}
+	if rm.beginMatchOrReadyCheck(room) {
+		result.ReadyChecks = []*Room{room}
+	}
+	return result
 }
 
-func (f *RoomSessionFlow) joinCode(player *Player, normalizedCode string) RoomSessionResult {
+// joinCodeLocked is called with rm.mu held.
+func (f *RoomSessionFlow) joinCodeLocked(player *Player, normalizedCode string) RoomSessionResult {
 	rm := f.roomManager
-	rm.mu.Lock()
-	defer rm.mu.Unlock()
-
 	if existingRoomID, ok := rm.codeIndex[normalizedCode]; ok {
 		if existingRoom, exists := rm.rooms[existingRoomID]; exists {
+			blockedUntil, blocked := existingRoom.KickBlockedUntil(player, time.Now())
 			if existingRoom.Match.IsEnded() {
 				delete(rm.codeIndex, normalizedCode)
+			} else if blocked {
+				return RoomSessionResult{
+					Room: existingRoom,
+					Rejection: &RoomSessionRejection{
+						Kind:       RoomSessionRejectionVoteKicked,
+						Code:       normalizedCode,
+						RetryAfter: time.Until(blockedUntil),
+					},
+				}
 			} else if existingRoom.PlayerCount() >= existingRoom.MaxPlayers {
 				return RoomSessionResult{
 					Room: existingRoom,
@@ -155,16 +201,22 @@ func (f *RoomSessionFlow) joinCode(player *Player, normalizedCode string) RoomSe
 				}
 				rm.playerToRoom[player.ID] = existingRoom.ID
 				existingRoom.Match.RegisterPlayer(player.ID)
-				if existingRoom.PlayerCount() >= MinPlayersToStart && !existingRoom.Match.IsStarted() {
-					existingRoom.Match.Start()
-					return RoomSessionResult{
+				rm.queuePlayerLocked(player, existingRoom)
+				joins := []RoomSessionJoin{{Player: player, Room: existingRoom}}
+				if existingRoom.PlayerCount() >= rm.settings.MinHumanPlayers && !existingRoom.Match.IsStarted() && !existingRoom.InReadyCheck() {
+					result := RoomSessionResult{
 						Room:         existingRoom,
 						Publications: sessionPublicationsForRoom(existingRoom, SessionStatusMatchReady),
 						Activations:  sessionActivationsForRoom(existingRoom),
+						Joins:        joins,
+					}
+					if rm.beginMatchOrReadyCheck(existingRoom) {
+						result.ReadyChecks = []*Room{existingRoom}
 					}
+					return result
 				}
-				if existingRoom.Match.IsStarted() {
-					return RoomSessionResult{
+				if existingRoom.Match.IsStarted() || existingRoom.InReadyCheck() {
+					result := RoomSessionResult{
 						Room: existingRoom,
 						Publications: []RoomSessionPublication{{
 							Player: player,
@@ -175,7 +227,12 @@ func (f *RoomSessionFlow) joinCode(player *Player, normalizedCode string) RoomSe
 							Player: player,
 							Room:   existingRoom,
 						}},
+						Joins: joins,
 					}
+					if existingRoom.InReadyCheck() {
+						result.ReadyChecks = []*Room{existingRoom}
+					}
+					return result
 				}
 				return RoomSessionResult{
 					Room: existingRoom,
@@ -184,12 +241,14 @@ func (f *RoomSessionFlow) joinCode(player *Player, normalizedCode string) RoomSe
 						Room:   existingRoom,
 						State:  SessionStatusWaitingForPlayers,
 					}},
+					Joins: joins,
 				}
 			}
 		}
 	}
 
-	room := NewTypedRoom(RoomKindCode, normalizedCode, rm.defaultMapID)
+	room := rm.newRoomLocked(RoomKindCode, normalizedCode)
+	rm.queuePlayerLocked(player, nil)
 	_ = room.AddPlayer(player)
 	room.Match.RegisterPlayer(player.ID)
 	rm.rooms[room.ID] = room
@@ -211,6 +270,8 @@ func (f *RoomSessionFlow) LeaveSession(playerID string) RoomSessionResult {
 	rm.mu.Lock()
 	defer rm.mu.Unlock()
 
+	rm.metrics.Abandoned(playerID, time.Now())
+
 	for i, player := range rm.waitingPlayers {
 		if player.ID != playerID {
 			continue
@@ -218,6 +279,9 @@ func (f *RoomSessionFlow) LeaveSession(playerID string) RoomSessionResult {
 		rm.waitingPlayers = append(rm.waitingPlayers[:i], rm.waitingPlayers[i+1:]...)
 		return RoomSessionResult{LeftSession: true}
 	}
+	if rm.removeFromCapacityQueueLocked(playerID) {
+		return RoomSessionResult{LeftSession: true}
+	}
 
 	roomID, exists := rm.playerToRoom[playerID]
 	if !exists {
@@ -225,7 +289,7 @@ func (f *RoomSessionFlow) LeaveSession(playerID string) RoomSessionResult {
 	}
 
 	room, exists := rm.rooms[roomID]
-	if !exists || room.Match.IsStarted() {
+	if !exists || room.Match.IsStarted() || room.InReadyCheck() {
 		return RoomSessionResult{}
 	}
 
@@ -248,6 +312,17 @@ func (f *RoomSessionFlow) LeaveSession(playerID string) RoomSessionResult {
 	}
 }
 
+// queuePlayerLocked puts a player into matchmaking and starts timing its
+// wait. A player who joined a room whose match is already running is matched
+// straight away. It is called with rm.mu held.
+func (rm *RoomManager) queuePlayerLocked(player *Player, room *Room) {
+	player.QueuedAt = time.Now()
+	rm.metrics.Queued(player.ID, player.QueuedAt)
+	if room != nil && room.Match.IsStarted() {
+		rm.metrics.Matched(player.ID, room.ID, player.QueuedAt)
+	}
+}
+
 func sessionPublicationsForRoom(room *Room, state SessionStatusState) []RoomSessionPublication {
 	players := room.GetPlayers()
 	publications := make([]RoomSessionPublication, 0, len(players))
@@ -54,7 +54,9 @@ func TestRoomSessionFlowPublicHelloTransitionsToSearchingThenMatchReady(t *testi
 	})
 	require.Nil(t, second.Rejection)
 	require.NotNil(t, second.Room)
-	assert.True(t, second.Room.Match.IsStarted())
+	assert.False(t, second.Room.Match.IsStarted(), "match waits for the ready check")
+	assert.True(t, second.Room.InReadyCheck())
+	assert.Equal(t, []*Room{second.Room}, second.ReadyChecks)
 	assert.ElementsMatch(t, []string{player1.ID, player2.ID}, activationIDs(second.Activations))
 	assert.Equal(t, []SessionStatusState{SessionStatusMatchReady}, publicationStatesForPlayer(second.Publications, player1.ID))
 	assert.Equal(t, []SessionStatusState{SessionStatusMatchReady}, publicationStatesForPlayer(second.Publications, player2.ID))
@@ -89,7 +91,9 @@ func TestRoomSessionFlowCodeHelloTransitionsToWaitingThenMatchReady(t *testing.T
 	})
 	require.Nil(t, second.Rejection)
 	require.NotNil(t, second.Room)
-	assert.True(t, second.Room.Match.IsStarted())
+	assert.False(t, second.Room.Match.IsStarted(), "match waits for the ready check")
+	assert.True(t, second.Room.InReadyCheck())
+	assert.Equal(t, []*Room{second.Room}, second.ReadyChecks)
 	assert.ElementsMatch(t, []string{player1.ID, player2.ID}, activationIDs(second.Activations))
 	assert.Equal(t, []SessionStatusState{SessionStatusMatchReady}, publicationStatesForPlayer(second.Publications, player1.ID))
 	assert.Equal(t, []SessionStatusState{SessionStatusMatchReady}, publicationStatesForPlayer(second.Publications, player2.ID))
@@ -203,10 +207,39 @@ func TestRoomSessionFlowPublicHelloCanJoinExistingSinglePlayerRoom(t *testing.T)
 	require.Nil(t, result.Rejection)
 	require.NotNil(t, result.Room)
 	assert.Equal(t, room.ID, result.Room.ID)
-	assert.True(t, result.Room.Match.IsStarted())
+	assert.True(t, result.Room.InReadyCheck())
+	assert.Equal(t, []*Room{room}, result.ReadyChecks)
 	assert.ElementsMatch(t, []string{existingPlayer.ID, joiningPlayer.ID}, activationIDs(result.Activations))
 	assert.Equal(t, []SessionStatusState{SessionStatusMatchReady}, publicationStatesForPlayer(result.Publications, existingPlayer.ID))
 	assert.Equal(t, []SessionStatusState{SessionStatusMatchReady}, publicationStatesForPlayer(result.Publications, joiningPlayer.ID))
+	require.Len(t, result.Joins, 1)
+	assert.Equal(t, joiningPlayer.ID, result.Joins[0].Player.ID)
+	assert.Equal(t, room.ID, result.Joins[0].Room.ID)
+}
+
+func TestRoomSessionFlowJoinsOnlyReportedForExistingRosters(t *testing.T) {
+	manager := NewRoomManager()
+	flow := manager.SessionFlow()
+	player1 := newSessionFlowPlayer("player-1")
+	player2 := newSessionFlowPlayer("player-2")
+	player3 := newSessionFlowPlayer("player-3")
+
+	first := flow.HandleHello(player1, map[string]any{"mode": "public"})
+	assert.Empty(t, first.Joins)
+
+	// A room formed from two queued players has no prior roster to notify.
+	second := flow.HandleHello(player2, map[string]any{"mode": "public"})
+	require.NotNil(t, second.Room)
+	assert.Empty(t, second.Joins)
+
+	codeOwner := flow.HandleHello(player3, map[string]any{"mode": "code", "code": "JOINS"})
+	assert.Empty(t, codeOwner.Joins)
+
+	player4 := newSessionFlowPlayer("player-4")
+	codeJoiner := flow.HandleHello(player4, map[string]any{"mode": "code", "code": "JOINS"})
+	require.Len(t, codeJoiner.Joins, 1)
+	assert.Equal(t, player4.ID, codeJoiner.Joins[0].Player.ID)
+	assert.Equal(t, codeOwner.Room.ID, codeJoiner.Joins[0].Room.ID)
 }
 
 func TestRoomSessionFlowPublicHelloDoesNotReuseCodeRoom(t *testing.T) {
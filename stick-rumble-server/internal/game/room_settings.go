@@ -0,0 +1,152 @@
+package game
+
+import (
+	"log"
+	"time"
+)
+
+// RoomSettings controls when rooms form and whether bots top them up.
+type RoomSettings struct {
+	MinHumanPlayers int           // Humans needed before a room forms or starts on its own
+	BotFillAfter    time.Duration // How long humans wait before bots fill in; zero disables the timer
+	BotFillTarget   int           // Roster size bots top a stalled room up to
+	Teams           bool          // Split every new room's players between team alpha and team bravo
+}
+
+// DefaultRoomSettings forms rooms at the classic two-player minimum with the
+// bot fill timer disabled.
+func DefaultRoomSettings() RoomSettings {
+	return RoomSettings{
+		MinHumanPlayers: MinPlayersToStart,
+		BotFillTarget:   MinPlayersToStart,
+	}
+}
+
+// normalized clamps settings into the range a room can actually satisfy.
+func (s RoomSettings) normalized(maxPlayers int) RoomSettings {
+	if s.MinHumanPlayers < MinPlayersToStart {
+		s.MinHumanPlayers = MinPlayersToStart
+	}
+	if s.MinHumanPlayers > maxPlayers {
+		s.MinHumanPlayers = maxPlayers
+	}
+	if s.BotFillTarget < MinPlayersToStart {
+		s.BotFillTarget = MinPlayersToStart
+	}
+	if s.BotFillTarget > maxPlayers {
+		s.BotFillTarget = maxPlayers
+	}
+	return s
+}
+
+// RoomBotFiller supplies bot players when a stalled room is topped up.
+// Returned players are added to the room and activated like humans.
+type RoomBotFiller interface {
+	FillBots(room *Room, count int) []*Player
+}
+
+func (rm *RoomManager) SetRoomSettings(settings RoomSettings) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.settings = settings.normalized(defaultRoomMaxPlayers)
+}
+
+func (rm *RoomManager) RoomSettings() RoomSettings {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	return rm.settings
+}
+
+func (rm *RoomManager) SetBotFiller(filler RoomBotFiller) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.botFiller = filler
+}
+
+// FillStalledRooms starts matches for humans who have waited longer than the
+// bot fill timer. Queued public players are placed in a fresh room, named
+// rooms short of the human minimum are started as they are, and any
+// configured bot filler tops each room up before the match starts.
+func (f *RoomSessionFlow) FillStalledRooms(now time.Time) []RoomSessionResult {
+	rm := f.roomManager
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	if rm.settings.BotFillAfter <= 0 {
+		return nil
+	}
+
+	results := make([]RoomSessionResult, 0)
+	if len(rm.waitingPlayers) > 0 && now.Sub(rm.waitingPlayers[0].QueuedAt) >= rm.settings.BotFillAfter {
+		room := rm.newRoomLocked(RoomKindPublic, "")
+		queued := rm.waitingPlayers
+		if len(queued) > room.MaxPlayers {
+			queued = queued[:room.MaxPlayers]
+		}
+		rm.waitingPlayers = rm.waitingPlayers[len(queued):]
+
+		for _, player := range queued {
+			_ = room.AddPlayer(player)
+			room.Match.RegisterPlayer(player.ID)
+			rm.playerToRoom[player.ID] = room.ID
+		}
+		rm.rooms[room.ID] = room
+		results = append(results, rm.startStalledRoomLocked(room))
+	}
+
+	for _, room := range rm.rooms {
+		if room.Kind != RoomKindCode || room.Match.IsStarted() || room.Match.IsEnded() || room.InReadyCheck() {
+			continue
+		}
+		if room.IsEmpty() || room.PlayerCount() >= rm.settings.MinHumanPlayers {
+			continue
+		}
+		if now.Sub(earliestQueuedAt(room.GetPlayers())) < rm.settings.BotFillAfter {
+			continue
+		}
+		results = append(results, rm.startStalledRoomLocked(room))
+	}
+
+	return results
+}
+
+// startStalledRoomLocked tops a room up with bots and starts its match
+// without a ready check; the humans have already waited long enough.
+func (rm *RoomManager) startStalledRoomLocked(room *Room) RoomSessionResult {
+	bots := 0
+	if rm.botFiller != nil {
+		missing := rm.settings.BotFillTarget - room.PlayerCount()
+		if missing > 0 {
+			for _, bot := range rm.botFiller.FillBots(room, missing) {
+				if err := room.AddPlayer(bot); err != nil {
+					break
+				}
+				room.Match.RegisterPlayer(bot.ID)
+				rm.playerToRoom[bot.ID] = room.ID
+				bots++
+			}
+		}
+	}
+
+	log.Printf("Bot fill timer expired in room %s, starting match with %d players", room.ID, room.PlayerCount())
+	rm.startMatchLocked(room, bots)
+
+	return RoomSessionResult{
+		Room:         room,
+		Publications: sessionPublicationsForRoom(room, SessionStatusMatchReady),
+		Activations:  sessionActivationsForRoom(room),
+	}
+}
+
+func earliestQueuedAt(players []*Player) time.Time {
+	var earliest time.Time
+	for _, player := range players {
+		if earliest.IsZero() || player.QueuedAt.Before(earliest) {
+			earliest = player.QueuedAt
+		}
+	}
+	return earliest
+}
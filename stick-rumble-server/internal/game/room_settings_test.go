@@ -0,0 +1,117 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubBotFiller struct {
+	requested []int
+}
+
+func (f *stubBotFiller) FillBots(room *Room, count int) []*Player {
+	f.requested = append(f.requested, count)
+	bots := make([]*Player, 0, count)
+	for i := 0; i < count; i++ {
+		bots = append(bots, newSessionFlowPlayer(room.ID+"-bot-"+string(rune('a'+i))))
+	}
+	return bots
+}
+
+func TestRoomSettingsNormalizeToPlayableRange(t *testing.T) {
+	manager := NewRoomManager()
+
+	manager.SetRoomSettings(RoomSettings{MinHumanPlayers: 1, BotFillTarget: 20})
+	settings := manager.RoomSettings()
+	assert.Equal(t, MinPlayersToStart, settings.MinHumanPlayers)
+	assert.Equal(t, defaultRoomMaxPlayers, settings.BotFillTarget)
+
+	manager.SetRoomSettings(RoomSettings{MinHumanPlayers: 12})
+	assert.Equal(t, defaultRoomMaxPlayers, manager.RoomSettings().MinHumanPlayers)
+}
+
+func TestPublicQueueWaitsForMinimumHumans(t *testing.T) {
+	manager := NewRoomManager()
+	manager.SetRoomSettings(RoomSettings{MinHumanPlayers: 3})
+	flow := manager.SessionFlow()
+
+	first := flow.HandleHello(newSessionFlowPlayer("player-1"), map[string]any{"mode": "public"})
+	second := flow.HandleHello(newSessionFlowPlayer("player-2"), map[string]any{"mode": "public"})
+	assert.Nil(t, first.Room)
+	assert.Nil(t, second.Room, "two humans are not enough when three are required")
+
+	third := flow.HandleHello(newSessionFlowPlayer("player-3"), map[string]any{"mode": "public"})
+	require.NotNil(t, third.Room)
+	assert.Equal(t, 3, third.Room.PlayerCount())
+	assert.ElementsMatch(t, []string{"player-1", "player-2", "player-3"}, activationIDs(third.Activations))
+	assert.Empty(t, manager.waitingPlayers)
+}
+
+func TestCodeRoomWaitsForMinimumHumans(t *testing.T) {
+	manager := NewRoomManager()
+	manager.SetRoomSettings(RoomSettings{MinHumanPlayers: 3})
+	flow := manager.SessionFlow()
+
+	flow.HandleHello(newSessionFlowPlayer("host"), map[string]any{"mode": "code", "code": "TRIO"})
+	second := flow.HandleHello(newSessionFlowPlayer("guest"), map[string]any{"mode": "code", "code": "TRIO"})
+	assert.False(t, second.Room.InReadyCheck())
+	assert.Equal(t, []SessionStatusState{SessionStatusWaitingForPlayers}, publicationStatesForPlayer(second.Publications, "guest"))
+
+	third := flow.HandleHello(newSessionFlowPlayer("third"), map[string]any{"mode": "code", "code": "TRIO"})
+	assert.True(t, third.Room.InReadyCheck())
+	assert.Len(t, third.Activations, 3)
+}
+
+func TestFillStalledRoomsDisabledByDefault(t *testing.T) {
+	manager := NewRoomManager()
+	flow := manager.SessionFlow()
+	flow.HandleHello(newSessionFlowPlayer("player-1"), map[string]any{"mode": "public"})
+
+	assert.Empty(t, flow.FillStalledRooms(time.Now().Add(time.Hour)))
+	assert.Len(t, manager.waitingPlayers, 1)
+}
+
+func TestFillStalledRoomsStartsQueuedPublicPlayersWithBots(t *testing.T) {
+	manager := NewRoomManager()
+	manager.SetRoomSettings(RoomSettings{MinHumanPlayers: 2, BotFillAfter: 30 * time.Second, BotFillTarget: 4})
+	filler := &stubBotFiller{}
+	manager.SetBotFiller(filler)
+	flow := manager.SessionFlow()
+
+	player := newSessionFlowPlayer("lonely")
+	flow.HandleHello(player, map[string]any{"mode": "public"})
+
+	assert.Empty(t, flow.FillStalledRooms(time.Now().Add(10*time.Second)), "timer has not expired yet")
+
+	results := flow.FillStalledRooms(time.Now().Add(31 * time.Second))
+	require.Len(t, results, 1)
+	room := results[0].Room
+	require.NotNil(t, room)
+	assert.True(t, room.Match.IsStarted(), "stalled rooms start without a ready check")
+	assert.Equal(t, 4, room.PlayerCount())
+	assert.Equal(t, []int{3}, filler.requested)
+	assert.Len(t, results[0].Activations, 4)
+	assert.Equal(t, []SessionStatusState{SessionStatusMatchReady}, publicationStatesForPlayer(results[0].Publications, player.ID))
+	assert.Empty(t, manager.waitingPlayers)
+	assert.Equal(t, room, manager.GetRoomByPlayerID(player.ID))
+}
+
+func TestFillStalledRoomsStartsUnderfilledCodeRoomWithoutFiller(t *testing.T) {
+	manager := NewRoomManager()
+	manager.SetRoomSettings(RoomSettings{MinHumanPlayers: 3, BotFillAfter: 30 * time.Second})
+	flow := manager.SessionFlow()
+
+	host := flow.HandleHello(newSessionFlowPlayer("host"), map[string]any{"mode": "code", "code": "SLOW"})
+	flow.HandleHello(newSessionFlowPlayer("guest"), map[string]any{"mode": "code", "code": "SLOW"})
+
+	results := flow.FillStalledRooms(time.Now().Add(time.Minute))
+	require.Len(t, results, 1)
+	assert.Equal(t, host.Room, results[0].Room)
+	assert.True(t, host.Room.Match.IsStarted())
+	assert.Equal(t, 2, host.Room.PlayerCount())
+
+	assert.Empty(t, flow.FillStalledRooms(time.Now().Add(2*time.Minute)), "started rooms are not filled again")
+}
@@ -0,0 +1,120 @@
+package game
+
+import (
+	"sync"
+	"time"
+)
+
+// ShieldCrate represents a shield pickup point on the map
+type ShieldCrate struct {
+	ID          string
+	Position    Vector2
+	IsAvailable bool
+	RespawnTime time.Time
+}
+
+// ShieldCrateManager manages all shield crates in the game. Unlike weapon
+// crates, shield crates are picked up by walking over them.
+type ShieldCrateManager struct {
+	crates map[string]*ShieldCrate
+	mu     sync.RWMutex
+}
+
+// NewShieldCrateManager creates a shield crate manager from the map's shield spawns
+func NewShieldCrateManager(mapConfigs ...MapConfig) *ShieldCrateManager {
+	mapConfig := resolveMapConfig(mapConfigs...)
+	manager := &ShieldCrateManager{
+		crates: make(map[string]*ShieldCrate, len(mapConfig.ShieldSpawns)),
+	}
+	for _, spawn := range mapConfig.ShieldSpawns {
+		manager.crates[spawn.ID] = &ShieldCrate{
+			ID:          spawn.ID,
+			Position:    Vector2{X: spawn.X, Y: spawn.Y},
+			IsAvailable: true,
+		}
+	}
+	return manager
+}
+
+// PickupCrate attempts to pick up a shield crate
+// Returns true if pickup was successful, false if crate doesn't exist or is unavailable
+func (scm *ShieldCrateManager) PickupCrate(crateID string) bool {
+	scm.mu.Lock()
+	defer scm.mu.Unlock()
+
+	crate, exists := scm.crates[crateID]
+	if !exists || !crate.IsAvailable {
+		return false
+	}
+
+	crate.IsAvailable = false
+	crate.RespawnTime = time.Now().Add(ShieldRespawnDelay * time.Second)
+	return true
+}
+
+// CrateInRange returns the ID of an available crate within ShieldPickupRadius
+// of position, or "" if there is none
+func (scm *ShieldCrateManager) CrateInRange(position Vector2) string {
+	scm.mu.RLock()
+	defer scm.mu.RUnlock()
+
+	for id, crate := range scm.crates {
+		if crate.IsAvailable && distance(position, crate.Position) <= ShieldPickupRadius {
+			return id
+		}
+	}
+	return ""
+}
+
+// UpdateRespawns checks for crates that should respawn and makes them available again
+// Returns a slice of crate IDs that respawned
+func (scm *ShieldCrateManager) UpdateRespawns() []string {
+	scm.mu.Lock()
+	defer scm.mu.Unlock()
+
+	respawned := make([]string, 0)
+	now := time.Now()
+
+	for id, crate := range scm.crates {
+		if !crate.IsAvailable && now.After(crate.RespawnTime) {
+			crate.IsAvailable = true
+			respawned = append(respawned, id)
+		}
+	}
+
+	return respawned
+}
+
+// DelayRespawns pushes every pending respawn back by d so time spent
+// suspended does not count toward crate respawn timers
+func (scm *ShieldCrateManager) DelayRespawns(d time.Duration) {
+	scm.mu.Lock()
+	defer scm.mu.Unlock()
+
+	for _, crate := range scm.crates {
+		if !crate.IsAvailable {
+			crate.RespawnTime = crate.RespawnTime.Add(d)
+		}
+	}
+}
+
+// GetCrate returns a shield crate by ID
+// Returns nil if crate doesn't exist
+func (scm *ShieldCrateManager) GetCrate(crateID string) *ShieldCrate {
+	scm.mu.RLock()
+	defer scm.mu.RUnlock()
+
+	return scm.crates[crateID]
+}
+
+// GetAllCrates returns a copy of all shield crates
+func (scm *ShieldCrateManager) GetAllCrates() map[string]*ShieldCrate {
+	scm.mu.RLock()
+	defer scm.mu.RUnlock()
+
+	crates := make(map[string]*ShieldCrate, len(scm.crates))
+	for id, crate := range scm.crates {
+		crates[id] = crate
+	}
+	return crates
+}
@@ -0,0 +1,43 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewShieldCrateManager_UsesMapShieldSpawns(t *testing.T) {
+	mapConfig := MustDefaultMapConfig()
+	require.NotEmpty(t, mapConfig.ShieldSpawns)
+
+	manager := NewShieldCrateManager(mapConfig)
+	crates := manager.GetAllCrates()
+	require.Len(t, crates, len(mapConfig.ShieldSpawns))
+	for _, spawn := range mapConfig.ShieldSpawns {
+		crate := crates[spawn.ID]
+		require.NotNil(t, crate, "missing crate for spawn %q", spawn.ID)
+		assert.Equal(t, Vector2{X: spawn.X, Y: spawn.Y}, crate.Position)
+		assert.True(t, crate.IsAvailable)
+	}
+}
+
+func TestShieldCrateManager_PickupAndRespawn(t *testing.T) {
+	manager := NewShieldCrateManager()
+	spawn := MustDefaultMapConfig().ShieldSpawns[0]
+	onCrate := Vector2{X: spawn.X + ShieldPickupRadius/2, Y: spawn.Y}
+
+	crateID := manager.CrateInRange(onCrate)
+	require.Equal(t, spawn.ID, crateID)
+	assert.Empty(t, manager.CrateInRange(Vector2{X: spawn.X + ShieldPickupRadius*2, Y: spawn.Y}))
+
+	require.True(t, manager.PickupCrate(crateID))
+	assert.False(t, manager.PickupCrate(crateID), "a taken crate cannot be picked up again")
+	assert.Empty(t, manager.CrateInRange(onCrate), "a taken crate is not in range of anyone")
+	assert.Empty(t, manager.UpdateRespawns())
+
+	manager.GetCrate(crateID).RespawnTime = time.Now().Add(-time.Second)
+	assert.Equal(t, []string{crateID}, manager.UpdateRespawns())
+	assert.True(t, manager.GetCrate(crateID).IsAvailable)
+}
@@ -0,0 +1,212 @@
+package game
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Spawn safety tuning. A hit marks its surroundings as unsafe for a while, so
+// a respawn does not drop a player into a fight that is still going on.
+const (
+	SpawnCombatMemory  = 4 * time.Second // How long a hit counts as recent combat
+	SpawnCombatRadius  = 400.0           // Pixels around a hit where spawning is penalized
+	SpawnCombatPenalty = 400.0           // Score a fresh hit right on a spawn point costs, in pixels of enemy distance
+
+	// SpawnChoiceTolerance is how much less safe than the safest spawn point a
+	// point a dead player picks may be, in the same pixels as its score
+	SpawnChoiceTolerance = 200.0
+
+	// SpawnOptionsInterval is how often dead players are sent the spawn
+	// points they can pick from
+	SpawnOptionsInterval = 500 * time.Millisecond
+)
+
+// SpawnOption is a spawn point offered to a dead player
+type SpawnOption struct {
+	Index    int     // Position in map order, which the player picks it by
+	Position Vector2 // Where the player would spawn
+	Danger   float64 // How much lower it scores than the safest point; 0 for the safest
+	Allowed  bool    // Whether Danger is within SpawnChoiceTolerance
+}
+
+// combatActivity is where and when a player was hit
+type combatActivity struct {
+	position Vector2
+	at       time.Time
+}
+
+// SpawnManager keeps the selected map's valid spawn points and picks the
+// safest one for each spawn. A point scores its distance to the nearest
+// living enemy, less a penalty for combat near it in the last
+// SpawnCombatMemory.
+type SpawnManager struct {
+	points   []Vector2
+	fallback Vector2 // Map center, used when the map has no valid spawn point
+	combat   []combatActivity
+	clock    Clock
+	mu       sync.Mutex
+}
+
+// NewSpawnManager creates a spawn manager for the map's authored spawn points
+func NewSpawnManager(mapConfig MapConfig, clock Clock) *SpawnManager {
+	sm := &SpawnManager{clock: clock}
+	sm.SetMap(mapConfig)
+	return sm
+}
+
+// SetMap replaces the spawn points with the map's valid ones and forgets
+// recorded combat
+func (sm *SpawnManager) SetMap(mapConfig MapConfig) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	sm.points = validSpawnPoints(mapConfig)
+	sm.fallback = Vector2{X: mapConfig.Width / 2, Y: mapConfig.Height / 2}
+	sm.combat = nil
+}
+
+// SpawnPoints returns the valid spawn points in map order
+func (sm *SpawnManager) SpawnPoints() []Vector2 {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	return append([]Vector2(nil), sm.points...)
+}
+
+// RecordCombat notes a hit at position
+func (sm *SpawnManager) RecordCombat(position Vector2) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	sm.pruneCombatLocked(sm.clock.Now())
+	sm.combat = append(sm.combat, combatActivity{position: position, at: sm.clock.Now()})
+}
+
+// Score rates point as a spawn given the living enemies' positions. Higher is
+// safer.
+func (sm *SpawnManager) Score(point Vector2, enemyPositions []Vector2) float64 {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	now := sm.clock.Now()
+	sm.pruneCombatLocked(now)
+	return sm.scoreLocked(point, enemyPositions, now)
+}
+
+// SelectSpawn returns the highest-scoring spawn point, the first in map order
+// on a tie. Without spawn points it returns the map center.
+func (sm *SpawnManager) SelectSpawn(enemyPositions []Vector2) Vector2 {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if len(sm.points) == 0 {
+		return sm.fallback
+	}
+
+	now := sm.clock.Now()
+	sm.pruneCombatLocked(now)
+
+	best := sm.points[0]
+	bestScore := math.Inf(-1)
+	for _, point := range sm.points {
+		if score := sm.scoreLocked(point, enemyPositions, now); score > bestScore {
+			bestScore = score
+			best = point
+		}
+	}
+	return best
+}
+
+// Options rates every spawn point given the living enemies' positions, in
+// map order. A point may be picked while it scores within
+// SpawnChoiceTolerance of the point SelectSpawn would pick.
+func (sm *SpawnManager) Options(enemyPositions []Vector2) []SpawnOption {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	now := sm.clock.Now()
+	sm.pruneCombatLocked(now)
+
+	options := make([]SpawnOption, len(sm.points))
+	bestScore := math.Inf(-1)
+	for i, point := range sm.points {
+		score := sm.scoreLocked(point, enemyPositions, now)
+		options[i] = SpawnOption{Index: i, Position: point, Danger: score}
+		bestScore = math.Max(bestScore, score)
+	}
+	for i := range options {
+		options[i].Danger = bestScore - options[i].Danger
+		options[i].Allowed = options[i].Danger <= SpawnChoiceTolerance
+	}
+	return options
+}
+
+// ChosenSpawn returns the spawn point at index if a player may pick it given
+// the living enemies' positions
+func (sm *SpawnManager) ChosenSpawn(index int, enemyPositions []Vector2) (Vector2, bool) {
+	options := sm.Options(enemyPositions)
+	if index < 0 || index >= len(options) || !options[index].Allowed {
+		return Vector2{}, false
+	}
+	return options[index].Position, true
+}
+
+func (sm *SpawnManager) scoreLocked(point Vector2, enemyPositions []Vector2, now time.Time) float64 {
+	// With no living enemies every point is equally far from them
+	score := 0.0
+	if len(enemyPositions) > 0 {
+		score = math.MaxFloat64
+		for _, enemyPos := range enemyPositions {
+			score = math.Min(score, distance(point, enemyPos))
+		}
+	}
+
+	// Each recent hit nearby costs more the closer and fresher it is
+	for _, activity := range sm.combat {
+		dist := distance(point, activity.position)
+		if dist >= SpawnCombatRadius {
+			continue
+		}
+		freshness := 1 - float64(now.Sub(activity.at))/float64(SpawnCombatMemory)
+		score -= SpawnCombatPenalty * (1 - dist/SpawnCombatRadius) * freshness
+	}
+	return score
+}
+
+func (sm *SpawnManager) pruneCombatLocked(now time.Time) {
+	kept := sm.combat[:0]
+	for _, activity := range sm.combat {
+		if now.Sub(activity.at) < SpawnCombatMemory {
+			kept = append(kept, activity)
+		}
+	}
+	sm.combat = kept
+}
+
+// validSpawnPoints returns the map's spawn points that lie inside its bounds
+// and outside movement-blocking obstacles
+func validSpawnPoints(mapConfig MapConfig) []Vector2 {
+	blockingObstacles := movementBlockingObstacles(mapConfig)
+	points := make([]Vector2, 0, len(mapConfig.SpawnPoints))
+
+	for _, spawnPoint := range mapConfig.SpawnPoints {
+		if !pointWithinBounds(spawnPoint.X, spawnPoint.Y, mapConfig) {
+			continue
+		}
+
+		blocked := false
+		for _, obstacle := range blockingObstacles {
+			if pointInsideRect(spawnPoint.X, spawnPoint.Y, rectFromObstacle(obstacle)) {
+				blocked = true
+				break
+			}
+		}
+		if blocked {
+			continue
+		}
+
+		points = append(points, Vector2{X: spawnPoint.X, Y: spawnPoint.Y})
+	}
+
+	return points
+}
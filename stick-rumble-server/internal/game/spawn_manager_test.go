@@ -0,0 +1,125 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func spawnTestMapConfig() MapConfig {
+	mapConfig := openTestMapConfig()
+	mapConfig.SpawnPoints = []MapSpawnPoint{
+		{ID: "west", X: 200, Y: 540},
+		{ID: "east", X: 1720, Y: 540},
+		{ID: "blocked", X: 960, Y: 540},
+		{ID: "outside", X: -50, Y: 540},
+	}
+	mapConfig.Obstacles = []MapObstacle{
+		{ID: "pillar", X: 940, Y: 520, Width: 40, Height: 40, BlocksMovement: true},
+	}
+	return mapConfig
+}
+
+func TestSpawnManagerKeepsOnlyValidSpawnPoints(t *testing.T) {
+	sm := NewSpawnManager(spawnTestMapConfig(), &RealClock{})
+	assert.Equal(t, []Vector2{{X: 200, Y: 540}, {X: 1720, Y: 540}}, sm.SpawnPoints())
+
+	sm.SetMap(openTestMapConfig())
+	assert.Empty(t, sm.SpawnPoints())
+	assert.Equal(t, Vector2{X: ArenaWidth / 2, Y: ArenaHeight / 2}, sm.SelectSpawn(nil))
+}
+
+func TestSpawnManagerPicksPointFarthestFromEnemies(t *testing.T) {
+	sm := NewSpawnManager(spawnTestMapConfig(), &RealClock{})
+
+	assert.Equal(t, Vector2{X: 200, Y: 540}, sm.SelectSpawn(nil), "ties go to the first point")
+	assert.Equal(t, Vector2{X: 1720, Y: 540}, sm.SelectSpawn([]Vector2{{X: 400, Y: 540}}))
+}
+
+func TestSpawnManagerAvoidsRecentCombat(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	sm := NewSpawnManager(spawnTestMapConfig(), clock)
+	enemies := []Vector2{{X: 860, Y: 540}}
+
+	assert.Equal(t, Vector2{X: 1720, Y: 540}, sm.SelectSpawn(enemies))
+
+	sm.RecordCombat(Vector2{X: 1700, Y: 540})
+	assert.Less(t, sm.Score(Vector2{X: 1720, Y: 540}, enemies), sm.Score(Vector2{X: 200, Y: 540}, enemies))
+	assert.Equal(t, Vector2{X: 200, Y: 540}, sm.SelectSpawn(enemies))
+
+	clock.Advance(SpawnCombatMemory)
+	assert.Equal(t, Vector2{X: 1720, Y: 540}, sm.SelectSpawn(enemies), "old combat no longer counts")
+}
+
+func TestProjectileHitRecordsCombatForSpawnSafety(t *testing.T) {
+	gs := NewGameServer(func([]PlayerStateSnapshot) {})
+	setWorldMapConfig(gs.world, spawnTestMapConfig())
+	attacker := gs.AddPlayer("attacker")
+	victim := gs.AddPlayer("victim")
+	victim.SetPosition(Vector2{X: 1700, Y: 540})
+	enemies := []Vector2{attacker.GetPosition()}
+	before := gs.world.Spawns().Score(Vector2{X: 1720, Y: 540}, enemies)
+
+	_, ok := gs.ProcessProjectileHit(HitEvent{ProjectileID: "projectile-1", AttackerID: attacker.ID, VictimID: victim.ID})
+	require.True(t, ok)
+
+	assert.Less(t, gs.world.Spawns().Score(Vector2{X: 1720, Y: 540}, enemies), before)
+}
+
+func TestSpawnManagerOptionsRateDangerAgainstSafestPoint(t *testing.T) {
+	sm := NewSpawnManager(spawnTestMapConfig(), &RealClock{})
+
+	assert.Equal(t, []SpawnOption{
+		{Index: 0, Position: Vector2{X: 200, Y: 540}, Danger: 0, Allowed: true},
+		{Index: 1, Position: Vector2{X: 1720, Y: 540}, Danger: 0, Allowed: true},
+	}, sm.Options(nil), "without enemies every point is as safe")
+
+	enemies := []Vector2{{X: 400, Y: 540}}
+	options := sm.Options(enemies)
+	require.Len(t, options, 2)
+	assert.InDelta(t, 1120.0, options[0].Danger, 0.001)
+	assert.False(t, options[0].Allowed)
+	assert.Zero(t, options[1].Danger)
+	assert.True(t, options[1].Allowed)
+
+	_, allowed := sm.ChosenSpawn(0, enemies)
+	assert.False(t, allowed, "a point next to an enemy cannot be picked")
+	_, allowed = sm.ChosenSpawn(2, enemies)
+	assert.False(t, allowed)
+	point, allowed := sm.ChosenSpawn(1, enemies)
+	assert.True(t, allowed)
+	assert.Equal(t, Vector2{X: 1720, Y: 540}, point)
+}
+
+func TestGameServerRespawnsAtChosenSpawn(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	gs := NewGameServerWithClock(nil, clock)
+	setWorldMapConfig(gs.world, spawnTestMapConfig())
+	gs.AddPlayer("victim")
+	enemy := gs.AddPlayer("enemy")
+	enemy.SetPosition(Vector2{X: 400, Y: 540})
+	gs.MarkPlayerDead("victim")
+
+	assert.False(t, gs.ChooseSpawn("victim", 0), "the west point is next to the enemy")
+	assert.False(t, gs.ChooseSpawn("enemy", 1), "living players cannot pick a spawn")
+
+	enemy.SetPosition(Vector2{X: 960, Y: 200})
+	require.True(t, gs.ChooseSpawn("victim", 0))
+	require.True(t, gs.RequestRespawn("victim"))
+	clock.Advance(time.Duration(RespawnDelay*1000+100) * time.Millisecond)
+	gs.checkRespawns()
+	victim, _ := gs.GetPlayerState("victim")
+	assert.Nil(t, victim.DeathTime)
+	assert.Equal(t, Vector2{X: 200, Y: 540}, victim.Position)
+
+	gs.MarkPlayerDead("victim")
+	require.True(t, gs.ChooseSpawn("victim", 0))
+	enemy.SetPosition(Vector2{X: 300, Y: 540})
+	require.True(t, gs.RequestRespawn("victim"))
+	clock.Advance(time.Duration(RespawnDelay*1000+100) * time.Millisecond)
+	gs.checkRespawns()
+	victim, _ = gs.GetPlayerState("victim")
+	assert.Equal(t, Vector2{X: 1720, Y: 540}, victim.Position, "a pick that turned unsafe falls back to the safest point")
+}
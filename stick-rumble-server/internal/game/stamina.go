@@ -0,0 +1,59 @@
+package game
+
+import "time"
+
+// DodgeRollConfig sets how long a dodge roll protects the player and what it
+// costs. Zero fields use the defaults.
+type DodgeRollConfig struct {
+	Invincibility time.Duration // I-frames from the start of a roll, at most the roll's duration
+	StaminaCost   float64       // Stamina a roll spends, at most MaxStamina
+}
+
+func (c DodgeRollConfig) withDefaults() DodgeRollConfig {
+	if c.Invincibility <= 0 {
+		c.Invincibility = time.Duration(DodgeRollInvincibilityDuration * float64(time.Second))
+	}
+	c.Invincibility = min(c.Invincibility, time.Duration(DodgeRollDuration*float64(time.Second)))
+	if c.StaminaCost <= 0 {
+		c.StaminaCost = DodgeRollStaminaCost
+	}
+	c.StaminaCost = min(c.StaminaCost, MaxStamina)
+	return c
+}
+
+// setDodgeRoll applies the game's dodge roll settings to the player (thread-safe)
+func (p *PlayerState) setDodgeRoll(config DodgeRollConfig) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.dodgeRoll = config.withDefaults()
+}
+
+// Stamina returns the player's current stamina (thread-safe)
+func (p *PlayerState) Stamina() float64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.stamina
+}
+
+// spendStaminaLocked takes amount from the player's stamina and restarts the
+// regeneration delay. The caller holds p.mu.
+func (p *PlayerState) spendStaminaLocked(amount float64, now time.Time) {
+	p.stamina = max(p.stamina-amount, 0)
+	p.lastStaminaSpend = now
+}
+
+// ApplyStaminaRegeneration restores stamina at StaminaRegenerationRate once
+// StaminaRegenerationDelay has passed since it was last spent. Stamina does
+// not come back while the player is dead or rolling. (thread-safe)
+func (p *PlayerState) ApplyStaminaRegeneration(now time.Time, deltaTime float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.DeathTime != nil || p.rollState.IsRolling || p.stamina >= MaxStamina {
+		return
+	}
+	if now.Sub(p.lastStaminaSpend).Seconds() < StaminaRegenerationDelay {
+		return
+	}
+	p.stamina = min(p.stamina+StaminaRegenerationRate*deltaTime, MaxStamina)
+}
@@ -0,0 +1,79 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDodgeRollSpendsStamina(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	gs := NewGameServerWithConfig(GameServerConfig{Clock: clock, DodgeRoll: DodgeRollConfig{StaminaCost: 60}})
+	player := gs.AddPlayerAs("scout", ClassScout) // Two roll charges, so only stamina stops the second roll
+	require.Equal(t, MaxStamina, player.Stamina())
+
+	require.True(t, player.CanDodgeRoll())
+	player.StartDodgeRoll(Vector2{X: 1})
+	assert.Equal(t, 40.0, player.Stamina())
+	assert.Equal(t, 40, player.Snapshot().Stamina)
+
+	// No regeneration while rolling, nor within the delay after the roll
+	gs.updateStaminaRegeneration(1)
+	player.EndDodgeRoll()
+	gs.updateStaminaRegeneration(1)
+	assert.Equal(t, 40.0, player.Stamina())
+	assert.False(t, player.CanDodgeRoll(), "a charge is left but not the stamina")
+
+	clock.Advance(time.Duration(StaminaRegenerationDelay * float64(time.Second)))
+	gs.updateStaminaRegeneration(0.5)
+	assert.Equal(t, 40+StaminaRegenerationRate*0.5, player.Stamina())
+	assert.False(t, player.CanDodgeRoll())
+	gs.updateStaminaRegeneration(0.5)
+	assert.True(t, player.CanDodgeRoll())
+	gs.updateStaminaRegeneration(10)
+	assert.Equal(t, MaxStamina, player.Stamina(), "capped at full")
+}
+
+func TestStaminaRefillsOnRespawn(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	gs := NewGameServerWithClock(nil, clock)
+	player := gs.AddPlayer("p1")
+	player.StartDodgeRoll(Vector2{X: 1})
+	player.EndDodgeRoll()
+	player.MarkDead()
+
+	clock.Advance(time.Minute)
+	gs.updateStaminaRegeneration(10)
+	assert.Equal(t, MaxStamina-DodgeRollStaminaCost, player.Stamina(), "the dead do not regenerate")
+
+	player.Respawn(Vector2{X: 100, Y: 100})
+	assert.Equal(t, MaxStamina, player.Stamina())
+}
+
+func TestDodgeRollInvincibilityIsConfigurable(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	gs := NewGameServerWithConfig(GameServerConfig{Clock: clock, DodgeRoll: DodgeRollConfig{Invincibility: 300 * time.Millisecond}})
+	player := gs.AddPlayer("roller")
+	player.StartDodgeRoll(Vector2{X: 1})
+
+	clock.Advance(250 * time.Millisecond)
+	assert.True(t, player.IsInvincibleFromRoll(), "past the default window, inside the configured one")
+	proj := &Projectile{ID: "proj-1", OwnerID: "shooter", Position: player.GetPosition(), Active: true}
+	assert.False(t, NewPhysics(openTestMapConfig()).CheckProjectilePlayerCollision(proj, player), "hit detection skips the roller")
+
+	clock.Advance(50 * time.Millisecond)
+	assert.False(t, player.IsInvincibleFromRoll())
+	assert.True(t, NewPhysics(openTestMapConfig()).CheckProjectilePlayerCollision(proj, player))
+}
+
+func TestDodgeRollConfigDefaults(t *testing.T) {
+	config := DodgeRollConfig{}.withDefaults()
+	assert.Equal(t, 200*time.Millisecond, config.Invincibility)
+	assert.Equal(t, DodgeRollStaminaCost, config.StaminaCost)
+
+	config = DodgeRollConfig{Invincibility: time.Second, StaminaCost: 500}.withDefaults()
+	assert.Equal(t, 400*time.Millisecond, config.Invincibility, "no longer than the roll")
+	assert.Equal(t, MaxStamina, config.StaminaCost)
+}
@@ -0,0 +1,63 @@
+package game
+
+// Semantic team identifiers. Teams are never named after colors; clients pick
+// a color from TeamPalette by the team's palette index, so colorblind modes
+// can remap colors without guessing what "red" meant.
+const (
+	TeamAlpha = "alpha"
+	TeamBravo = "bravo"
+)
+
+// TeamPalette is the colorblind-safe (Okabe-Ito) team palette shared with
+// clients. Messages carry indexes into it rather than colors.
+var TeamPalette = []string{"#E69F00", "#56B4E9", "#009E73", "#F0E442", "#0072B2", "#D55E00", "#CC79A7"}
+
+// teamPaletteIndex maps each team to its palette entry. Alpha and Bravo use
+// orange and blue, which stay distinct under every common color vision
+// deficiency.
+var teamPaletteIndex = map[string]int{
+	TeamAlpha: 0,
+	TeamBravo: 4,
+}
+
+// Teams lists the teams of a team room, in the order new players fill them
+var Teams = []string{TeamAlpha, TeamBravo}
+
+// smallestTeamLocked returns the team with the fewest players in the room,
+// the first in Teams on a tie.
+// Called with r.mu held.
+func (r *Room) smallestTeamLocked() string {
+	counts := make(map[string]int, len(Teams))
+	for _, player := range r.Players {
+		counts[player.Team]++
+	}
+	smallest := Teams[0]
+	for _, team := range Teams[1:] {
+		if counts[team] < counts[smallest] {
+			smallest = team
+		}
+	}
+	return smallest
+}
+
+// SetAllChat turns the room's all-chat on or off. With it off, players'
+// chat reaches only their own team.
+func (r *Room) SetAllChat(enabled bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.allChatOff = !enabled
+}
+
+// AllChat reports whether chat can reach the whole room; it is on by default
+func (r *Room) AllChat() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return !r.allChatOff
+}
+
+// TeamPaletteIndex returns the palette index for a team, or false for free-for-all
+// players and unknown teams.
+func TeamPaletteIndex(team string) (int, bool) {
+	index, ok := teamPaletteIndex[team]
+	return index, ok
+}
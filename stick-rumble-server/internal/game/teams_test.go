@@ -0,0 +1,66 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamPaletteIndex(t *testing.T) {
+	alpha, ok := TeamPaletteIndex(TeamAlpha)
+	assert.True(t, ok)
+	bravo, ok := TeamPaletteIndex(TeamBravo)
+	assert.True(t, ok)
+
+	assert.NotEqual(t, alpha, bravo, "teams need distinct colors")
+	assert.Less(t, alpha, len(TeamPalette))
+	assert.Less(t, bravo, len(TeamPalette))
+
+	_, ok = TeamPaletteIndex("")
+	assert.False(t, ok, "free-for-all players have no team color")
+	_, ok = TeamPaletteIndex("red")
+	assert.False(t, ok)
+}
+
+func TestTeamRoomSplitsPlayersBetweenTeams(t *testing.T) {
+	room := NewRoom()
+	room.Teams = true
+
+	party := &Player{ID: "party", Team: TeamBravo, SendChan: make(chan []byte, 1)}
+	require.NoError(t, room.AddPlayer(party))
+	var joined []string
+	for _, id := range []string{"p1", "p2", "p3"} {
+		player := &Player{ID: id, SendChan: make(chan []byte, 1)}
+		require.NoError(t, room.AddPlayer(player))
+		joined = append(joined, player.Team)
+	}
+
+	assert.Equal(t, TeamBravo, party.Team, "a player keeps the team they brought")
+	assert.Equal(t, []string{TeamAlpha, TeamAlpha, TeamBravo}, joined, "each player joins the smaller team, alpha on a tie")
+}
+
+func TestFreeForAllRoomAssignsNoTeams(t *testing.T) {
+	room := NewRoom()
+	player := &Player{ID: "p1", SendChan: make(chan []byte, 1)}
+	require.NoError(t, room.AddPlayer(player))
+	assert.Empty(t, player.Team)
+}
+
+func TestRoomManagerCreatesTeamRoomsFromSettings(t *testing.T) {
+	manager := NewRoomManager()
+	settings := DefaultRoomSettings()
+	settings.Teams = true
+	manager.SetRoomSettings(settings)
+
+	first := newSessionFlowPlayer("p1")
+	second := newSessionFlowPlayer("p2")
+	room, ok := manager.AddCodePlayer(first, "TEAMS")
+	require.True(t, ok)
+	_, ok = manager.AddCodePlayer(second, "TEAMS")
+	require.True(t, ok)
+
+	assert.True(t, room.Teams)
+	assert.Equal(t, TeamAlpha, first.Team)
+	assert.Equal(t, TeamBravo, second.Team)
+}
@@ -0,0 +1,33 @@
+package game
+
+import "time"
+
+// TeleportReason says why a player's position jumped instead of moving, so
+// clients snap the player there rather than interpolating across the map
+type TeleportReason string
+
+const (
+	TeleportRespawn   TeleportReason = "respawn"    // Placed at a spawn point on respawn
+	TeleportForceSync TeleportReason = "force_sync" // Moved by the server, e.g. out of position quarantine
+)
+
+// TeleportFlagDuration is how long a teleport stays flagged in player
+// snapshots: long enough for every client to get it in at least one 20 Hz
+// broadcast
+const TeleportFlagDuration = 100 * time.Millisecond
+
+// markTeleportLocked flags the player's current position as a jump.
+// Callers hold p.mu for writing.
+func (p *PlayerState) markTeleportLocked(reason TeleportReason) {
+	p.teleport = reason
+	p.teleportedAt = p.clock.Now()
+}
+
+// teleportFlagLocked returns the reason for a teleport still within
+// TeleportFlagDuration, or an empty reason. Callers hold p.mu.
+func (p *PlayerState) teleportFlagLocked() TeleportReason {
+	if p.teleport == "" || p.clock.Since(p.teleportedAt) >= TeleportFlagDuration {
+		return ""
+	}
+	return p.teleport
+}
@@ -0,0 +1,41 @@
+package game
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRespawnFlagsTeleportForFlagDuration(t *testing.T) {
+	clock := NewManualClock(guardStart)
+	player := NewPlayerStateWithClock("p1", clock)
+	assert.Empty(t, player.Snapshot().Teleport)
+
+	player.Respawn(Vector2{X: 500, Y: 500})
+	assert.Equal(t, TeleportRespawn, player.Snapshot().Teleport)
+
+	clock.Advance(TeleportFlagDuration - time.Millisecond)
+	assert.Equal(t, TeleportRespawn, player.Snapshot().Teleport)
+
+	clock.Advance(time.Millisecond)
+	assert.Empty(t, player.Snapshot().Teleport, "the flag expires once every client has seen it")
+}
+
+func TestQuarantineReleaseFlagsForceSync(t *testing.T) {
+	clock := NewManualClock(guardStart)
+	player := NewPlayerStateWithClock("p1", clock)
+	player.SetPosition(Vector2{X: math.NaN(), Y: 100})
+
+	assert.True(t, player.releaseQuarantine(Vector2{X: 300, Y: 300}))
+	assert.Equal(t, TeleportForceSync, player.Snapshot().Teleport)
+}
+
+func TestOrdinaryMovesAreNotTeleports(t *testing.T) {
+	player := NewPlayerStateWithClock("p1", NewManualClock(guardStart))
+
+	player.SetPosition(Vector2{X: 900, Y: 900})
+
+	assert.Empty(t, player.Snapshot().Teleport)
+}
@@ -0,0 +1,147 @@
+package game
+
+import "os"
+
+// Tunables is the snapshot of gameplay constants the running server uses,
+// published so clients and QA can check them against their own values.
+// Durations are in seconds unless the field name says otherwise.
+type Tunables struct {
+	Movement   MovementTunables        `json:"movement"`
+	Arena      ArenaTunables           `json:"arena"`
+	Network    NetworkTunables         `json:"network"`
+	Player     PlayerTunables          `json:"player"`
+	Respawn    RespawnTunables         `json:"respawn"`
+	Regen      RegenTunables           `json:"healthRegeneration"`
+	DodgeRoll  DodgeRollTunables       `json:"dodgeRoll"`
+	Stamina    StaminaTunables         `json:"stamina"`
+	Pickups    PickupTunables          `json:"weaponPickups"`
+	Projectile ProjectileTunables      `json:"projectile"`
+	Shotgun    ShotgunTunables         `json:"shotgun"`
+	Match      MatchTunables           `json:"match"`
+	Weapons    map[string]WeaponConfig `json:"weapons"`
+	Classes    []PlayerClass           `json:"classes"`
+}
+
+type MovementTunables struct {
+	Speed                  float64 `json:"speed"`
+	SprintSpeed            float64 `json:"sprintSpeed"`
+	SprintSpreadMultiplier float64 `json:"sprintSpreadMultiplier"`
+	Acceleration           float64 `json:"acceleration"`
+	Deceleration           float64 `json:"deceleration"`
+}
+
+type ArenaTunables struct {
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+type NetworkTunables struct {
+	ServerTickRate   int `json:"serverTickRate"`
+	ClientUpdateRate int `json:"clientUpdateRate"`
+}
+
+type PlayerTunables struct {
+	Width     float64 `json:"width"`
+	Height    float64 `json:"height"`
+	MaxHealth int     `json:"maxHealth"`
+}
+
+type RespawnTunables struct {
+	Delay                   float64 `json:"delay"`
+	AutoDelay               float64 `json:"autoDelay"`
+	InvulnerabilityDuration float64 `json:"invulnerabilityDuration"`
+}
+
+type RegenTunables struct {
+	Delay         float64 `json:"delay"`
+	RatePerSecond float64 `json:"ratePerSecond"`
+}
+
+type DodgeRollTunables struct {
+	Duration              float64 `json:"duration"`
+	Distance              float64 `json:"distance"`
+	Cooldown              float64 `json:"cooldown"`
+	InvincibilityDuration float64 `json:"invincibilityDuration"`
+	StaminaCost           float64 `json:"staminaCost"`
+}
+
+type StaminaTunables struct {
+	Max           float64 `json:"max"`
+	Delay         float64 `json:"delay"`
+	RatePerSecond float64 `json:"ratePerSecond"`
+}
+
+type PickupTunables struct {
+	RespawnDelay float64 `json:"respawnDelay"`
+	Radius       float64 `json:"radius"`
+}
+
+type ProjectileTunables struct {
+	MaxLifetimeMs int64   `json:"maxLifetimeMs"`
+	MaxRange      float64 `json:"maxRange"`
+}
+
+type ShotgunTunables struct {
+	PelletCount  int     `json:"pelletCount"`
+	PelletDamage float64 `json:"pelletDamage"`
+}
+
+type MatchTunables struct {
+	KillTarget       int  `json:"killTarget"`
+	TimeLimitSeconds int  `json:"timeLimitSeconds"`
+	KillXPReward     int  `json:"killXpReward"`
+	AssistWindow     int  `json:"assistWindowSeconds"`
+	AssistXPReward   int  `json:"assistXpReward"`
+	TestMode         bool `json:"testMode"`
+}
+
+// CurrentTunables returns the constants in effect, including the weapon
+// configs loaded from weapon-configs.json and the TEST_MODE match overrides.
+func CurrentTunables() Tunables {
+	match := NewMatch()
+	testMode := testModeEnabled()
+	if testMode {
+		match.SetTestMode()
+	}
+
+	return Tunables{
+		Movement: MovementTunables{
+			Speed:                  MovementSpeed,
+			SprintSpeed:            SprintSpeed,
+			SprintSpreadMultiplier: SprintSpreadMultiplier,
+			Acceleration:           Acceleration,
+			Deceleration:           Deceleration,
+		},
+		Arena:   ArenaTunables{Width: ArenaWidth, Height: ArenaHeight},
+		Network: NetworkTunables{ServerTickRate: ServerTickRate, ClientUpdateRate: ClientUpdateRate},
+		Player:  PlayerTunables{Width: PlayerWidth, Height: PlayerHeight, MaxHealth: PlayerMaxHealth},
+		Respawn: RespawnTunables{Delay: RespawnDelay, AutoDelay: AutoRespawnDelay, InvulnerabilityDuration: SpawnInvulnerabilityDuration},
+		Regen:   RegenTunables{Delay: HealthRegenerationDelay, RatePerSecond: HealthRegenerationRate},
+		DodgeRoll: DodgeRollTunables{
+			Duration:              DodgeRollDuration,
+			Distance:              DodgeRollDistance,
+			Cooldown:              DodgeRollCooldown,
+			InvincibilityDuration: DodgeRollInvincibilityDuration,
+			StaminaCost:           DodgeRollStaminaCost,
+		},
+		Stamina:    StaminaTunables{Max: MaxStamina, Delay: StaminaRegenerationDelay, RatePerSecond: StaminaRegenerationRate},
+		Pickups:    PickupTunables{RespawnDelay: WeaponRespawnDelay, Radius: WeaponPickupRadius},
+		Projectile: ProjectileTunables{MaxLifetimeMs: ProjectileMaxLifetime.Milliseconds(), MaxRange: ProjectileMaxRange},
+		Shotgun:    ShotgunTunables{PelletCount: ShotgunPelletCount, PelletDamage: ShotgunPelletDamage},
+		Match: MatchTunables{
+			KillTarget:       match.Config.KillTarget,
+			TimeLimitSeconds: match.Config.TimeLimitSeconds,
+			KillXPReward:     KillXPReward,
+			AssistWindow:     AssistWindowSeconds,
+			AssistXPReward:   AssistXPReward,
+			TestMode:         testMode,
+		},
+		Weapons: WeaponDefinitions(),
+		Classes: PlayerClasses(),
+	}
+}
+
+// testModeEnabled reports whether TEST_MODE shortens matches for testing
+func testModeEnabled() bool {
+	return os.Getenv("TEST_MODE") == "true"
+}
@@ -0,0 +1,44 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurrentTunablesReportsEffectiveValues(t *testing.T) {
+	tunables := CurrentTunables()
+
+	assert.Equal(t, SprintSpeed, tunables.Movement.SprintSpeed)
+	assert.Equal(t, PlayerMaxHealth, tunables.Player.MaxHealth)
+	assert.Equal(t, DodgeRollStaminaCost, tunables.DodgeRoll.StaminaCost)
+	assert.Equal(t, MaxStamina, tunables.Stamina.Max)
+	assert.Equal(t, int64(1000), tunables.Projectile.MaxLifetimeMs)
+	assert.Equal(t, 20, tunables.Match.KillTarget)
+	assert.Equal(t, AssistWindowSeconds, tunables.Match.AssistWindow)
+	assert.Equal(t, AssistXPReward, tunables.Match.AssistXPReward)
+	assert.False(t, tunables.Match.TestMode)
+
+	pistol, ok := tunables.Weapons["Pistol"]
+	require.True(t, ok)
+	assert.Equal(t, getWeaponConfig("Pistol").Damage, pistol.Damage)
+}
+
+func TestCurrentTunablesAppliesTestMode(t *testing.T) {
+	t.Setenv("TEST_MODE", "true")
+
+	tunables := CurrentTunables()
+	assert.True(t, tunables.Match.TestMode)
+	assert.Equal(t, 2, tunables.Match.KillTarget)
+	assert.Equal(t, 10, tunables.Match.TimeLimitSeconds)
+}
+
+func TestCurrentTunablesCopiesWeaponConfigs(t *testing.T) {
+	tunables := CurrentTunables()
+	pistol := tunables.Weapons["Pistol"]
+	pistol.Damage = 999
+	tunables.Weapons["Pistol"] = pistol
+
+	assert.NotEqual(t, 999, getWeaponConfig("Pistol").Damage)
+}
@@ -0,0 +1,148 @@
+package game
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// UltimateMaxCharge is a full ultimate meter
+	UltimateMaxCharge = 100.0
+
+	// UltimateChargePerDamage is meter gained per point of damage dealt;
+	// 200 damage fills it
+	UltimateChargePerDamage = 0.5
+
+	// UltimateChargePerKill is meter gained for a kill, the objective of the
+	// default mode; modes with other objectives call ChargeUltimate
+	UltimateChargePerKill = 15.0
+
+	// DefaultUltimate names the ultimate every player has unless a gameplay
+	// hook swaps it
+	DefaultUltimate = "overdrive"
+)
+
+// Ultimate failure reasons
+const (
+	UltimateFailedNoPlayer   = "no_player"
+	UltimateFailedDead       = "dead"
+	UltimateFailedNotCharged = "not_charged"
+	UltimateFailedActive     = "active"
+)
+
+// UltimateEffect is what spending a full meter does
+type UltimateEffect struct {
+	Name             string
+	Heal             int           // Health restored at once, capped at max health
+	DamageMultiplier float64       // Outgoing damage multiplier while active
+	Duration         time.Duration // How long the multiplier lasts
+}
+
+// DefaultUltimateEffect returns the overdrive ultimate: a heal and a damage
+// boost for a few seconds
+func DefaultUltimateEffect() UltimateEffect {
+	return UltimateEffect{
+		Name:             DefaultUltimate,
+		Heal:             50,
+		DamageMultiplier: 1.5,
+		Duration:         6 * time.Second,
+	}
+}
+
+// UltimateResult contains the result of an ultimate activation
+type UltimateResult struct {
+	Success   bool
+	Reason    string
+	Effect    UltimateEffect
+	NewHealth int
+}
+
+// AddUltimateCharge fills the ultimate meter, capped at full. The meter does
+// not charge while an ultimate is active, so ultimates cannot be chained.
+func (p *PlayerState) AddUltimateCharge(amount float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if amount <= 0 || p.DeathTime != nil || p.ultimateActiveLocked() {
+		return
+	}
+	p.ultimateCharge = math.Min(p.ultimateCharge+amount, UltimateMaxCharge)
+}
+
+// UltimateCharge returns the ultimate meter (thread-safe)
+func (p *PlayerState) UltimateCharge() float64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.ultimateCharge
+}
+
+func (p *PlayerState) ultimateActiveLocked() bool {
+	return p.clock.Now().Before(p.ultimateEndsAt)
+}
+
+// activateUltimate spends a full meter on effect
+func (p *PlayerState) activateUltimate(effect UltimateEffect) (int, string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch {
+	case p.DeathTime != nil:
+		return p.Health, UltimateFailedDead
+	case p.ultimateActiveLocked():
+		return p.Health, UltimateFailedActive
+	case p.ultimateCharge < UltimateMaxCharge:
+		return p.Health, UltimateFailedNotCharged
+	}
+
+	p.ultimateCharge = 0
+	p.ultimateEffect = effect
+	p.ultimateEndsAt = p.clock.Now().Add(effect.Duration)
+	p.Health = min(p.Health+max(effect.Heal, 0), p.class.MaxHealth)
+	return p.Health, ""
+}
+
+// ultimateDamageMultiplier returns the outgoing damage multiplier of an
+// active ultimate, or 1
+func (p *PlayerState) ultimateDamageMultiplier() float64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if !p.ultimateActiveLocked() || p.ultimateEffect.DamageMultiplier <= 0 {
+		return 1
+	}
+	return p.ultimateEffect.DamageMultiplier
+}
+
+// ActivateUltimate spends a player's full meter on their ultimate. The room's
+// gameplay hooks choose the effect, starting from DefaultUltimateEffect.
+func (gs *GameServer) ActivateUltimate(playerID string) UltimateResult {
+	gs.recordAction(Action{Kind: ActionUltimate, PlayerID: playerID})
+	player, exists := gs.world.GetPlayer(playerID)
+	if !exists {
+		return UltimateResult{Reason: UltimateFailedNoPlayer}
+	}
+
+	effect := gs.hooksFor(playerID).Ultimate(UltimateEvent{PlayerID: playerID, Effect: DefaultUltimateEffect()})
+	newHealth, reason := player.activateUltimate(effect)
+	if reason != "" {
+		return UltimateResult{Reason: reason, NewHealth: newHealth}
+	}
+	return UltimateResult{Success: true, Effect: effect, NewHealth: newHealth}
+}
+
+// ChargeUltimate fills a player's ultimate meter, for damage, kills and any
+// objective a mode rewards
+func (gs *GameServer) ChargeUltimate(playerID string, amount float64) {
+	if player, exists := gs.world.GetPlayer(playerID); exists {
+		player.AddUltimateCharge(amount)
+	}
+}
+
+// outgoingDamage scales an attack by the attacker's active ultimate, then
+// runs the room's damage hooks
+func (gs *GameServer) outgoingDamage(event DamageEvent) int {
+	if attacker, exists := gs.world.GetPlayer(event.AttackerID); exists {
+		event.Damage = int(math.Round(float64(event.Damage) * attacker.ultimateDamageMultiplier()))
+	}
+	return gs.hooksFor(event.AttackerID).Damage(event)
+}
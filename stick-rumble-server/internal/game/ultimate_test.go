@@ -0,0 +1,109 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// shortUltimateHook swaps the default ultimate for a pure damage burst
+type shortUltimateHook struct {
+	BaseGameplayHook
+}
+
+func (shortUltimateHook) ModifyUltimate(event UltimateEvent) UltimateEffect {
+	return UltimateEffect{Name: "berserk", Heal: -10, DamageMultiplier: 2, Duration: time.Second}
+}
+
+func TestUltimateChargeIsCappedAndPausedWhileDead(t *testing.T) {
+	player := NewPlayerState("p1")
+
+	player.AddUltimateCharge(80)
+	player.AddUltimateCharge(-20)
+	assert.Equal(t, 80.0, player.UltimateCharge())
+
+	player.AddUltimateCharge(80)
+	assert.Equal(t, UltimateMaxCharge, player.UltimateCharge())
+	assert.Equal(t, 100, player.Snapshot().UltimateCharge)
+
+	player.ultimateCharge = 0
+	player.MarkDead()
+	player.AddUltimateCharge(50)
+	assert.Zero(t, player.UltimateCharge())
+}
+
+func TestActivateUltimateNeedsFullMeter(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	gs := NewGameServerWithClock(nil, clock)
+	player := gs.AddPlayer("p1")
+
+	assert.Equal(t, UltimateFailedNoPlayer, gs.ActivateUltimate("ghost").Reason)
+	assert.Equal(t, UltimateFailedNotCharged, gs.ActivateUltimate("p1").Reason)
+
+	player.TakeDamage(70)
+	gs.ChargeUltimate("p1", UltimateMaxCharge)
+	result := gs.ActivateUltimate("p1")
+	require.True(t, result.Success)
+	assert.Equal(t, DefaultUltimateEffect(), result.Effect)
+	assert.Equal(t, PlayerMaxHealth-70+50, result.NewHealth)
+	assert.Zero(t, player.UltimateCharge(), "activation spends the meter")
+	assert.True(t, player.Snapshot().UltimateActive)
+
+	// The meter does not refill while the effect runs
+	gs.ChargeUltimate("p1", UltimateMaxCharge)
+	assert.Zero(t, player.UltimateCharge())
+	assert.Equal(t, UltimateFailedActive, gs.ActivateUltimate("p1").Reason)
+
+	clock.Advance(DefaultUltimateEffect().Duration)
+	assert.False(t, player.Snapshot().UltimateActive)
+}
+
+func TestActiveUltimateBoostsDamageAndDamageChargesMeter(t *testing.T) {
+	gs := NewGameServerWithClock(nil, NewManualClock(time.Now()))
+	attacker := gs.AddPlayer("attacker")
+	gs.AddPlayer("victim")
+	baseDamage := gs.GetWeaponState(attacker.ID).Weapon.Damage
+
+	outcome, ok := gs.ProcessProjectileHit(HitEvent{ProjectileID: "projectile-1", AttackerID: attacker.ID, VictimID: "victim"})
+	require.True(t, ok)
+	assert.Equal(t, baseDamage, outcome.Damage)
+	assert.Equal(t, float64(baseDamage)*UltimateChargePerDamage, attacker.UltimateCharge())
+
+	gs.ChargeUltimate(attacker.ID, UltimateMaxCharge)
+	require.True(t, gs.ActivateUltimate(attacker.ID).Success)
+
+	outcome, ok = gs.ProcessProjectileHit(HitEvent{ProjectileID: "projectile-2", AttackerID: attacker.ID, VictimID: "victim"})
+	require.True(t, ok)
+	assert.Equal(t, int(float64(baseDamage)*1.5+0.5), outcome.Damage)
+}
+
+func TestDeathEndsUltimate(t *testing.T) {
+	gs := NewGameServerWithClock(nil, NewManualClock(time.Now()))
+	player := gs.AddPlayer("p1")
+	gs.ChargeUltimate("p1", UltimateMaxCharge)
+	require.True(t, gs.ActivateUltimate("p1").Success)
+
+	player.MarkDead()
+	assert.False(t, player.Snapshot().UltimateActive)
+	assert.Equal(t, 1.0, player.ultimateDamageMultiplier())
+	assert.Equal(t, UltimateFailedDead, gs.ActivateUltimate("p1").Reason)
+}
+
+func TestUltimateFollowsRoomHooks(t *testing.T) {
+	hooks := NewGameplayHooks()
+	hooks.Register(shortUltimateHook{})
+	gs := newHookedGameServer(hooks)
+	player := gs.AddPlayer("p1")
+	player.TakeDamage(30)
+	gs.ChargeUltimate("p1", UltimateMaxCharge)
+
+	result := gs.ActivateUltimate("p1")
+
+	require.True(t, result.Success)
+	assert.Equal(t, "berserk", result.Effect.Name)
+	assert.Zero(t, result.Effect.Heal, "heals are clamped at zero")
+	assert.Equal(t, PlayerMaxHealth-30, result.NewHealth)
+	assert.Equal(t, 2.0, player.ultimateDamageMultiplier())
+}
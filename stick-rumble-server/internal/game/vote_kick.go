@@ -0,0 +1,179 @@
+package game
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+const (
+	// DefaultVoteKickFraction is the share of a room's other players whose
+	// votes remove a player
+	DefaultVoteKickFraction = 0.6
+	// DefaultVoteKickWindow is how long a vote-kick stays open after its
+	// first vote
+	DefaultVoteKickWindow = 30 * time.Second
+	// DefaultVoteKickBlock is how long a vote-kicked player may not rejoin
+	// the room
+	DefaultVoteKickBlock = 5 * time.Minute
+	// MinVoteKickVoters is the fewest votes that remove a player, so a room
+	// needs at least that many other players before it can vote anyone out
+	MinVoteKickVoters = 2
+)
+
+// ErrVoteKickBlocked is returned when a vote-kicked player tries to rejoin
+// the room before their block runs out
+var ErrVoteKickBlocked = errors.New("vote-kicked from this room")
+
+// VoteKickConfig sets how much of a room must agree to remove one of its
+// players and for how long. Zero fields use the defaults.
+type VoteKickConfig struct {
+	Fraction float64       // Share of the other players whose votes remove the target, at most 1
+	Window   time.Duration // How long a vote stays open after its first vote
+	BlockFor time.Duration // How long a removed player may not rejoin the room
+}
+
+func (c VoteKickConfig) withDefaults() VoteKickConfig {
+	if c.Fraction <= 0 {
+		c.Fraction = DefaultVoteKickFraction
+	}
+	c.Fraction = min(c.Fraction, 1)
+	if c.Window <= 0 {
+		c.Window = DefaultVoteKickWindow
+	}
+	if c.BlockFor <= 0 {
+		c.BlockFor = DefaultVoteKickBlock
+	}
+	return c
+}
+
+// VoteKickResult is where a vote against a player stands after a vote
+type VoteKickResult struct {
+	TargetID  string
+	Votes     int       // Votes from players still in the room
+	Needed    int       // Votes that remove the target
+	ExpiresAt time.Time // When the vote lapses unless it passes first
+	Passed    bool      // The target must be removed; they are already blocked from rejoining
+	Reason    string    // Why the vote was refused; empty when it counted
+}
+
+// kickVote is an open vote to remove one player
+type kickVote struct {
+	voters    map[string]bool
+	expiresAt time.Time
+}
+
+// kickBlock keeps a vote-kicked player out of the room until it runs out. It
+// matches the player's ID and, so a fresh connection cannot walk straight
+// back in, the address they connected from.
+type kickBlock struct {
+	playerID string
+	address  string
+	until    time.Time
+}
+
+// VoteKick records voterID's vote to remove targetID from the room. Players
+// for whom abstains returns true, such as bots, neither vote nor count toward
+// the votes needed. A vote opens with its first vote and lapses after the
+// configured window; once it passes, the target is blocked from rejoining and
+// the caller removes them.
+func (r *Room) VoteKick(voterID, targetID string, now time.Time, config VoteKickConfig, abstains func(playerID string) bool) VoteKickResult {
+	config = config.withDefaults()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := VoteKickResult{TargetID: targetID}
+	var voter, target *Player
+	electorate := 0
+	for _, player := range r.Players {
+		switch {
+		case player.ID == targetID:
+			target = player
+		case abstains(player.ID):
+		default:
+			electorate++
+		}
+		if player.ID == voterID {
+			voter = player
+		}
+	}
+	switch {
+	case voter == nil || abstains(voterID):
+		result.Reason = "not_in_room"
+		return result
+	case target == nil || abstains(targetID):
+		result.Reason = "invalid_target"
+		return result
+	case voter == target:
+		result.Reason = "self_vote"
+		return result
+	case electorate < MinVoteKickVoters:
+		result.Reason = "too_few_players"
+		return result
+	}
+
+	for id, vote := range r.kickVotes {
+		if !now.Before(vote.expiresAt) {
+			delete(r.kickVotes, id)
+		}
+	}
+	if r.kickVotes == nil {
+		r.kickVotes = make(map[string]*kickVote)
+	}
+	vote, open := r.kickVotes[targetID]
+	if !open {
+		vote = &kickVote{voters: make(map[string]bool), expiresAt: now.Add(config.Window)}
+		r.kickVotes[targetID] = vote
+	}
+	if vote.voters[voterID] {
+		result.Reason = "already_voted"
+		return result
+	}
+	vote.voters[voterID] = true
+
+	for _, player := range r.Players {
+		if vote.voters[player.ID] {
+			result.Votes++
+		}
+	}
+	result.Needed = max(int(math.Ceil(config.Fraction*float64(electorate))), MinVoteKickVoters)
+	result.ExpiresAt = vote.expiresAt
+	if result.Votes >= result.Needed {
+		result.Passed = true
+		delete(r.kickVotes, targetID)
+		r.kickBlocks = append(r.kickBlocks, kickBlock{
+			playerID: target.ID,
+			address:  target.Address,
+			until:    now.Add(config.BlockFor),
+		})
+	}
+	return result
+}
+
+// KickBlockedUntil reports whether player was vote-kicked from the room and
+// may not rejoin it before the returned time
+func (r *Room) KickBlockedUntil(player *Player, now time.Time) (time.Time, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.kickBlockedUntilLocked(player, now)
+}
+
+// kickBlockedUntilLocked drops expired blocks and returns the one that
+// matches player. The caller holds r.mu.
+func (r *Room) kickBlockedUntilLocked(player *Player, now time.Time) (time.Time, bool) {
+	active := r.kickBlocks[:0]
+	var until time.Time
+	for _, block := range r.kickBlocks {
+		if !now.Before(block.until) {
+			continue
+		}
+		active = append(active, block)
+		matches := block.playerID == player.ID || (block.address != "" && block.address == player.Address)
+		if matches && block.until.After(until) {
+			until = block.until
+		}
+	}
+	r.kickBlocks = active
+	return until, !until.IsZero()
+}
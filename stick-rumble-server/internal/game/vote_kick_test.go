@@ -0,0 +1,134 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newVoteKickRoom fills a room with the given players; IDs starting with
+// "bot" abstain from votes
+func newVoteKickRoom(t *testing.T, ids ...string) *Room {
+	t.Helper()
+	room := NewRoom()
+	for _, id := range ids {
+		player := newSessionFlowPlayer(id)
+		player.Address = "10.0.0." + id
+		require.NoError(t, room.AddPlayer(player))
+	}
+	return room
+}
+
+func isTestBot(playerID string) bool {
+	return len(playerID) >= 3 && playerID[:3] == "bot"
+}
+
+func TestVoteKickPassesWithEnoughOfTheRoom(t *testing.T) {
+	room := newVoteKickRoom(t, "a", "b", "c", "d", "bot1")
+	now := time.Now()
+
+	result := room.VoteKick("a", "d", now, VoteKickConfig{}, isTestBot)
+	require.Empty(t, result.Reason)
+	assert.Equal(t, 1, result.Votes)
+	assert.Equal(t, 2, result.Needed, "60% of the three other humans, rounded up")
+	assert.Equal(t, now.Add(DefaultVoteKickWindow), result.ExpiresAt)
+	assert.False(t, result.Passed)
+
+	assert.Equal(t, "already_voted", room.VoteKick("a", "d", now, VoteKickConfig{}, isTestBot).Reason)
+
+	result = room.VoteKick("b", "d", now.Add(time.Second), VoteKickConfig{}, isTestBot)
+	assert.Equal(t, 2, result.Votes)
+	assert.True(t, result.Passed)
+	assert.Equal(t, now.Add(DefaultVoteKickWindow), result.ExpiresAt, "the window runs from the first vote")
+
+	// The vote passed, so a later vote starts over
+	result = room.VoteKick("c", "d", now.Add(time.Second), VoteKickConfig{}, isTestBot)
+	assert.Equal(t, 1, result.Votes)
+}
+
+func TestVoteKickRefusals(t *testing.T) {
+	room := newVoteKickRoom(t, "a", "b", "bot1")
+	now := time.Now()
+
+	assert.Equal(t, "not_in_room", room.VoteKick("x", "a", now, VoteKickConfig{}, isTestBot).Reason)
+	assert.Equal(t, "not_in_room", room.VoteKick("bot1", "a", now, VoteKickConfig{}, isTestBot).Reason)
+	assert.Equal(t, "invalid_target", room.VoteKick("a", "x", now, VoteKickConfig{}, isTestBot).Reason)
+	assert.Equal(t, "invalid_target", room.VoteKick("a", "bot1", now, VoteKickConfig{}, isTestBot).Reason)
+	assert.Equal(t, "self_vote", room.VoteKick("a", "a", now, VoteKickConfig{}, isTestBot).Reason)
+	assert.Equal(t, "too_few_players", room.VoteKick("a", "b", now, VoteKickConfig{}, isTestBot).Reason,
+		"one player cannot vote another out")
+}
+
+func TestVoteKickLapsesAfterTheWindow(t *testing.T) {
+	room := newVoteKickRoom(t, "a", "b", "c")
+	config := VoteKickConfig{Fraction: 1, Window: 10 * time.Second}
+	now := time.Now()
+
+	require.Equal(t, 1, room.VoteKick("a", "c", now, config, isTestBot).Votes)
+	result := room.VoteKick("b", "c", now.Add(10*time.Second), config, isTestBot)
+	assert.Equal(t, 1, result.Votes, "the first vote lapsed")
+	assert.False(t, result.Passed)
+
+	// Votes from players who left stop counting
+	require.True(t, room.RemovePlayer("b"))
+	require.NoError(t, room.AddPlayer(newSessionFlowPlayer("e")))
+	result = room.VoteKick("a", "c", now.Add(11*time.Second), config, isTestBot)
+	assert.Equal(t, 1, result.Votes)
+	assert.Equal(t, 2, result.Needed)
+}
+
+func TestVoteKickedPlayerIsBlockedFromTheRoom(t *testing.T) {
+	room := newVoteKickRoom(t, "a", "b", "c")
+	now := time.Now()
+	config := VoteKickConfig{BlockFor: time.Minute}
+	target := room.GetPlayer("c")
+
+	room.VoteKick("a", "c", now, config, isTestBot)
+	require.True(t, room.VoteKick("b", "c", now, config, isTestBot).Passed)
+	require.True(t, room.RemovePlayer("c"))
+
+	assert.ErrorIs(t, room.AddPlayer(target), ErrVoteKickBlocked)
+	sameHost := newSessionFlowPlayer("c-again")
+	sameHost.Address = target.Address
+	assert.ErrorIs(t, room.AddPlayer(sameHost), ErrVoteKickBlocked, "a fresh connection from the same host")
+	assert.NoError(t, room.AddPlayer(newSessionFlowPlayer("d")))
+
+	until, blocked := room.KickBlockedUntil(target, now.Add(59*time.Second))
+	assert.True(t, blocked)
+	assert.Equal(t, now.Add(time.Minute), until)
+	_, blocked = room.KickBlockedUntil(target, now.Add(time.Minute))
+	assert.False(t, blocked, "the block runs out")
+}
+
+func TestRoomSessionFlowRejectsVoteKickedPlayer(t *testing.T) {
+	manager := NewRoomManager()
+	flow := manager.SessionFlow()
+	hello := map[string]any{"displayName": "Crew", "mode": "code", "code": "CREW"}
+
+	players := []*Player{newSessionFlowPlayer("a"), newSessionFlowPlayer("b"), newSessionFlowPlayer("c")}
+	for _, player := range players {
+		require.Nil(t, flow.HandleHello(player, hello).Rejection)
+	}
+	room := manager.GetRoomByPlayerID("c")
+	require.NotNil(t, room)
+	room.VoteKick("a", "c", time.Now(), VoteKickConfig{}, isTestBot)
+	require.True(t, room.VoteKick("b", "c", time.Now(), VoteKickConfig{}, isTestBot).Passed)
+	manager.RemovePlayer("c")
+
+	rejected := flow.HandleHello(players[2], hello)
+	require.NotNil(t, rejected.Rejection)
+	assert.Equal(t, RoomSessionRejectionVoteKicked, rejected.Rejection.Kind)
+	assert.Equal(t, "CREW", rejected.Rejection.Code)
+	assert.InDelta(t, DefaultVoteKickBlock.Seconds(), rejected.Rejection.RetryAfter.Seconds(), 1)
+	assert.Nil(t, manager.GetRoomByPlayerID("c"))
+}
+
+func TestVoteKickConfigDefaults(t *testing.T) {
+	config := VoteKickConfig{}.withDefaults()
+	assert.Equal(t, DefaultVoteKickFraction, config.Fraction)
+	assert.Equal(t, DefaultVoteKickWindow, config.Window)
+	assert.Equal(t, DefaultVoteKickBlock, config.BlockFor)
+	assert.Equal(t, 1.0, VoteKickConfig{Fraction: 3}.withDefaults().Fraction)
+}
@@ -1,6 +1,7 @@
 package game
 
 import (
+	"sync"
 	"time"
 )
 
@@ -46,11 +47,46 @@ type Weapon struct {
 	ReloadTime        time.Duration  // Time to reload (0 for melee)
 	ProjectileSpeed   float64        // Projectile speed in px/s (0 for melee)
 	Range             float64        // Maximum range in pixels (for melee and ranged)
-	ArcDegrees        float64        // Swing arc in degrees (for melee, 0 for ranged)
-	KnockbackDistance float64        // Knockback distance in pixels (Bat only)
+	ArcDegrees        float64        // Swing arc in degrees for melee, pellet spread cone for ranged (0 for single-projectile weapons)
+	KnockbackDistance float64        // Knockback distance in pixels (Bat swing, or full-strength explosion push)
 	Recoil            *RecoilPattern // Recoil pattern (nil for no recoil)
 	SpreadDegrees     float64        // Movement spread in degrees (+/- while moving, 0 for stationary)
 	IsHitscan         bool           // Story 4.5: Instant-hit weapon (lag compensated) vs projectile
+	SplashRadius      float64        // Explosion radius in pixels for explosive projectiles (0 for weapons that do not explode)
+	HitImpulse        float64        // Velocity in px/s a ranged hit adds to its victim along the shot (0 for none)
+	ComboWindow       time.Duration  // Melee: after a landed swing, how long the next landed swing finishes a combo (0 for no combo)
+	ComboMultiplier   float64        // Melee: damage multiplier of a combo's finishing hit
+}
+
+// WeaponStats are the balance stats of a weapon sent to its holder in
+// weapon:state, so clients show what the server's weapon definitions say
+type WeaponStats struct {
+	Damage          int     `json:"damage"`
+	FireRate        float64 `json:"fireRate"`
+	MagazineSize    int     `json:"magazineSize"`
+	ReloadTimeMs    int64   `json:"reloadTimeMs"`
+	ProjectileSpeed float64 `json:"projectileSpeed"`
+	Range           float64 `json:"range"`
+	SpreadDegrees   float64 `json:"spreadDegrees"`
+	SplashRadius    float64 `json:"splashRadius,omitempty"`
+	ComboWindowMs   int64   `json:"comboWindowMs,omitempty"`
+	ComboMultiplier float64 `json:"comboMultiplier,omitempty"`
+}
+
+// Stats returns the weapon's balance stats
+func (w *Weapon) Stats() *WeaponStats {
+	return &WeaponStats{
+		Damage:          w.Damage,
+		FireRate:        w.FireRate,
+		MagazineSize:    w.MagazineSize,
+		ReloadTimeMs:    w.ReloadTime.Milliseconds(),
+		ProjectileSpeed: w.ProjectileSpeed,
+		Range:           w.Range,
+		SpreadDegrees:   w.SpreadDegrees,
+		SplashRadius:    w.SplashRadius,
+		ComboWindowMs:   w.ComboWindow.Milliseconds(),
+		ComboMultiplier: w.ComboMultiplier,
+	}
 }
 
 // IsMelee returns true if this is a melee weapon
@@ -58,8 +94,25 @@ func (w *Weapon) IsMelee() bool {
 	return w.MagazineSize == 0 && w.ProjectileSpeed == 0
 }
 
+// FiresPellets returns true for ranged weapons that fire a spread of pellets
+// across ArcDegrees instead of a single projectile
+func (w *Weapon) FiresPellets() bool {
+	return !w.IsMelee() && w.ArcDegrees > 0
+}
+
+// IsExplosive returns true for ranged weapons whose projectiles explode on
+// impact, damaging every player within SplashRadius
+func (w *Weapon) IsExplosive() bool {
+	return !w.IsMelee() && w.SplashRadius > 0
+}
+
 // NewPistol creates a new Pistol weapon instance
+// Stats loaded from weapon-configs.json or hardcoded defaults
 func NewPistol() *Weapon {
+	if config := getWeaponConfig("Pistol"); config != nil {
+		return config.ToWeapon()
+	}
+
 	return &Weapon{
 		Name:              "Pistol",
 		Damage:            PistolDamage,
@@ -80,9 +133,11 @@ type WeaponState struct {
 	Weapon          *Weapon
 	CurrentAmmo     int
 	IsReloading     bool
-	LastShotTime    time.Time
 	ReloadStartTime time.Time
-	clock           Clock // Clock for time operations (injectable for testing)
+	clock           Clock           // Clock for time operations (injectable for testing)
+	cooldowns       PlayerCooldowns // Fire interval; the holder's cooldowns once equipped
+	comboHitAt      time.Time       // When the swing that opened a melee combo landed (zero if none is open)
+	mu              sync.Mutex      // Guards ammo and reload state; the tick loop completes reloads that handlers start
 }
 
 // NewWeaponState creates a new weapon state with full ammo and real clock
@@ -97,6 +152,7 @@ func NewWeaponStateWithClock(weapon *Weapon, clock Clock) *WeaponState {
 		CurrentAmmo: weapon.MagazineSize,
 		IsReloading: false,
 		clock:       clock,
+		cooldowns:   NewCooldownManager(clock).For(""),
 	}
 }
 
@@ -105,55 +161,83 @@ func (ws *WeaponState) CanShoot() bool {
 	// Melee weapons bypass ammo and reload checks
 	isMelee := ws.Weapon.IsMelee()
 
+	ws.mu.Lock()
+	reloading, empty := ws.IsReloading, ws.CurrentAmmo <= 0
+	ws.mu.Unlock()
+
 	// Cannot shoot while reloading (ranged only)
-	if !isMelee && ws.IsReloading {
+	if !isMelee && reloading {
 		return false
 	}
 
 	// Cannot shoot with empty magazine (ranged only)
-	if !isMelee && ws.CurrentAmmo <= 0 {
+	if !isMelee && empty {
 		return false
 	}
 
 	// Check fire rate cooldown (both melee and ranged)
-	if !ws.LastShotTime.IsZero() {
-		cooldown := time.Duration(float64(time.Second) / ws.Weapon.FireRate)
-		if ws.clock.Since(ws.LastShotTime) < cooldown {
-			return false
-		}
+	return ws.cooldowns.Ready(ws.cooldownAbility())
+}
+
+// cooldownAbility is the cooldown the weapon's fire interval runs on
+func (ws *WeaponState) cooldownAbility() string {
+	if ws.Weapon.IsMelee() {
+		return CooldownMelee
 	}
+	return CooldownShoot
+}
 
-	return true
+// equip moves the weapon's fire interval onto its holder's cooldowns. A newly
+// equipped weapon is ready to fire, as before cooldowns were shared.
+func (ws *WeaponState) equip(cooldowns PlayerCooldowns) {
+	ws.cooldowns = cooldowns
+	cooldowns.Reset(CooldownShoot)
+	cooldowns.Reset(CooldownMelee)
 }
 
 // RecordShot records that a shot was fired (or swing for melee), decrements ammo for ranged weapons
 func (ws *WeaponState) RecordShot() {
 	// Only decrement ammo for ranged weapons
+	ws.mu.Lock()
 	if !ws.Weapon.IsMelee() && ws.CurrentAmmo > 0 {
 		ws.CurrentAmmo--
 	}
-	ws.LastShotTime = ws.clock.Now()
+	ws.mu.Unlock()
+	ws.cooldowns.Start(ws.cooldownAbility(), time.Duration(float64(time.Second)/ws.Weapon.FireRate))
 }
 
 // StartReload begins the reload process
-func (ws *WeaponState) StartReload() {
-	// Don't reload if already reloading
-	if ws.IsReloading {
-		return
-	}
+// Returns true if the weapon is reloading afterwards, false if its magazine is full
+func (ws *WeaponState) StartReload() bool {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
 
 	// Don't reload if magazine is full
 	if ws.CurrentAmmo >= ws.Weapon.MagazineSize {
-		return
+		return false
 	}
 
-	ws.IsReloading = true
-	ws.ReloadStartTime = ws.clock.Now()
+	// Don't restart a reload already in progress
+	if !ws.IsReloading {
+		ws.IsReloading = true
+		ws.ReloadStartTime = ws.clock.Now()
+	}
+	return true
+}
+
+// Reloading reports whether a reload is in progress
+func (ws *WeaponState) Reloading() bool {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	return ws.IsReloading
 }
 
 // CheckReloadComplete checks if reload is done and refills ammo if so
 // Returns true if reload just completed
 func (ws *WeaponState) CheckReloadComplete() bool {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
 	if !ws.IsReloading {
 		return false
 	}
@@ -170,6 +254,9 @@ func (ws *WeaponState) CheckReloadComplete() bool {
 // CancelReload cancels an in-progress reload
 // Used when switching weapons or picking up a new weapon
 func (ws *WeaponState) CancelReload() {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
 	if !ws.IsReloading {
 		return
 	}
@@ -180,11 +267,15 @@ func (ws *WeaponState) CancelReload() {
 
 // IsEmpty returns true if the magazine is empty
 func (ws *WeaponState) IsEmpty() bool {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
 	return ws.CurrentAmmo <= 0
 }
 
 // GetAmmoInfo returns current and max ammo
 func (ws *WeaponState) GetAmmoInfo() (current, max int) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
 	return ws.CurrentAmmo, ws.Weapon.MagazineSize
 }
 
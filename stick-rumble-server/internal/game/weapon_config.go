@@ -37,6 +37,10 @@ type WeaponConfig struct {
 	Recoil            *RecoilConfig `json:"recoil"`
 	SpreadDegrees     float64       `json:"spreadDegrees"`
 	IsHitscan         bool          `json:"isHitscan"` // Story 4.5: Lag compensation for instant-hit weapons
+	SplashRadius      float64       `json:"splashRadius"`
+	HitImpulse        float64       `json:"hitImpulse"`
+	ComboWindowMs     int           `json:"comboWindowMs"`
+	ComboMultiplier   float64       `json:"comboMultiplier"`
 	Visuals           WeaponVisuals `json:"visuals"`
 }
 
@@ -60,6 +64,10 @@ func (wc *WeaponConfig) ToWeapon() *Weapon {
 		KnockbackDistance: wc.KnockbackDistance,
 		SpreadDegrees:     wc.SpreadDegrees,
 		IsHitscan:         wc.IsHitscan,
+		SplashRadius:      wc.SplashRadius,
+		HitImpulse:        wc.HitImpulse,
+		ComboWindow:       time.Duration(wc.ComboWindowMs) * time.Millisecond,
+		ComboMultiplier:   wc.ComboMultiplier,
 	}
 
 	// Convert recoil config if present
@@ -75,7 +83,8 @@ func (wc *WeaponConfig) ToWeapon() *Weapon {
 	return weapon
 }
 
-// LoadWeaponConfigs loads weapon configurations from a JSON file
+// LoadWeaponConfigs loads weapon configurations from a JSON file, rejecting
+// any that fail ValidateWeaponConfig
 func LoadWeaponConfigs(configPath string) (map[string]*WeaponConfig, error) {
 	data, err := os.ReadFile(configPath)
 	if err != nil {
@@ -91,6 +100,12 @@ func LoadWeaponConfigs(configPath string) (map[string]*WeaponConfig, error) {
 	configs := make(map[string]*WeaponConfig)
 	for name, config := range configFile.Weapons {
 		configCopy := config // Create copy to get stable pointer
+		if err := ValidateWeaponConfig(&configCopy); err != nil {
+			return nil, fmt.Errorf("invalid weapon config %q: %w", name, err)
+		}
+		if configCopy.Name != name {
+			return nil, fmt.Errorf("invalid weapon config %q: name %q does not match its key", name, configCopy.Name)
+		}
 		configs[name] = &configCopy
 	}
 
@@ -132,6 +147,32 @@ func ValidateWeaponConfig(config *WeaponConfig) error {
 		return fmt.Errorf("ranged weapon must have positive projectile speed")
 	}
 
+	if config.SplashRadius < 0 {
+		return fmt.Errorf("weapon splash radius cannot be negative, got %f", config.SplashRadius)
+	}
+	if config.SplashRadius > 0 && config.ProjectileSpeed <= 0 {
+		return fmt.Errorf("explosive weapon must have positive projectile speed")
+	}
+	if config.HitImpulse < 0 {
+		return fmt.Errorf("weapon hit impulse cannot be negative, got %f", config.HitImpulse)
+	}
+
+	// Validate melee combos
+	if config.ComboWindowMs < 0 {
+		return fmt.Errorf("weapon combo window cannot be negative, got %d", config.ComboWindowMs)
+	}
+	if config.ComboWindowMs > 0 {
+		if config.MagazineSize > 0 || config.ProjectileSpeed > 0 {
+			return fmt.Errorf("only melee weapons can combo")
+		}
+		if swingMs := 1000 / config.FireRate; float64(config.ComboWindowMs) <= swingMs {
+			return fmt.Errorf("weapon combo window %dms must outlast the %.0fms swing cooldown", config.ComboWindowMs, swingMs)
+		}
+		if config.ComboMultiplier < 1 {
+			return fmt.Errorf("weapon combo multiplier must be at least 1, got %f", config.ComboMultiplier)
+		}
+	}
+
 	// Validate recoil if present
 	if config.Recoil != nil {
 		if config.Recoil.RecoveryTime <= 0 {
@@ -183,6 +224,8 @@ func getHardcodedWeaponConfigs() map[string]*WeaponConfig {
 			KnockbackDistance: 40,
 			Recoil:            nil,
 			SpreadDegrees:     0,
+			ComboWindowMs:     800,
+			ComboMultiplier:   1.5,
 		},
 		"Katana": {
 			Name:              "Katana",
@@ -196,6 +239,23 @@ func getHardcodedWeaponConfigs() map[string]*WeaponConfig {
 			KnockbackDistance: 0,
 			Recoil:            nil,
 			SpreadDegrees:     0,
+			ComboWindowMs:     1100,
+			ComboMultiplier:   1.3,
+		},
+		"Fists": {
+			Name:              "Fists",
+			Damage:            15,
+			FireRate:          3.0,
+			MagazineSize:      0,
+			ReloadTimeMs:      0,
+			ProjectileSpeed:   0,
+			Range:             60,
+			ArcDegrees:        100,
+			KnockbackDistance: 0,
+			Recoil:            nil,
+			SpreadDegrees:     0,
+			ComboWindowMs:     600,
+			ComboMultiplier:   2.0,
 		},
 		"Uzi": {
 			Name:            "Uzi",
@@ -243,6 +303,21 @@ func getHardcodedWeaponConfigs() map[string]*WeaponConfig {
 			KnockbackDistance: 0,
 			Recoil:            nil,
 			SpreadDegrees:     0,
+			HitImpulse:        75,
+		},
+		"RocketLauncher": {
+			Name:              "RocketLauncher",
+			Damage:            RocketLauncherDamage,
+			FireRate:          0.8,
+			MagazineSize:      3,
+			ReloadTimeMs:      3000,
+			ProjectileSpeed:   600.0,
+			Range:             600,
+			ArcDegrees:        0,
+			KnockbackDistance: RocketLauncherKnockback,
+			Recoil:            nil,
+			SpreadDegrees:     0,
+			SplashRadius:      RocketLauncherSplashRadius,
 		},
 	}
 }
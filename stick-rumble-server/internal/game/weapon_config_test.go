@@ -104,7 +104,7 @@ func TestLoadWeaponConfigs_AllWeapons(t *testing.T) {
 		t.Fatalf("LoadWeaponConfigs failed: %v", err)
 	}
 
-	expectedWeapons := []string{"Pistol", "Bat", "Katana", "Uzi", "AK47", "Shotgun"}
+	expectedWeapons := []string{"Pistol", "Bat", "Katana", "Fists", "Uzi", "AK47", "Shotgun", "RocketLauncher"}
 	for _, weaponName := range expectedWeapons {
 		if configs[weaponName] == nil {
 			t.Errorf("Expected weapon '%s' to be in configs", weaponName)
@@ -400,6 +400,27 @@ func TestValidateWeaponConfig_InvalidRecoilMaxAccumulation(t *testing.T) {
 	}
 }
 
+func TestValidateWeaponConfig_InvalidCombo(t *testing.T) {
+	valid := WeaponConfig{Name: "Fists", Damage: 15, FireRate: 3.0, Range: 60, ComboWindowMs: 600, ComboMultiplier: 2.0}
+	if err := ValidateWeaponConfig(&valid); err != nil {
+		t.Fatalf("Expected valid combo config, got error: %v", err)
+	}
+
+	tests := map[string]func(*WeaponConfig){
+		"negative window":          func(c *WeaponConfig) { c.ComboWindowMs = -1 },
+		"window inside cooldown":   func(c *WeaponConfig) { c.ComboWindowMs = 300 },
+		"multiplier below 1":       func(c *WeaponConfig) { c.ComboMultiplier = 0.5 },
+		"ranged weapon with combo": func(c *WeaponConfig) { c.MagazineSize, c.ProjectileSpeed = 15, 800 },
+	}
+	for name, breakConfig := range tests {
+		config := valid
+		breakConfig(&config)
+		if err := ValidateWeaponConfig(&config); err == nil {
+			t.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
+
 func TestLoadWeaponConfigsOrDefault_Success(t *testing.T) {
 	// Create temporary config file
 	tmpDir := t.TempDir()
@@ -440,7 +461,7 @@ func TestLoadWeaponConfigsOrDefault_FallbackToHardcoded(t *testing.T) {
 	}
 
 	// Should have hardcoded weapons
-	expectedWeapons := []string{"Pistol", "Bat", "Katana", "Uzi", "AK47", "Shotgun"}
+	expectedWeapons := []string{"Pistol", "Bat", "Katana", "Fists", "Uzi", "AK47", "Shotgun", "RocketLauncher"}
 	for _, weaponName := range expectedWeapons {
 		if configs[weaponName] == nil {
 			t.Errorf("Expected hardcoded weapon '%s' to exist in fallback", weaponName)
@@ -2,6 +2,7 @@ package game
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 )
@@ -19,6 +20,7 @@ type WeaponCrate struct {
 type WeaponCrateManager struct {
 	mapConfig MapConfig
 	crates    map[string]*WeaponCrate
+	spawned   int // Crates added at runtime by SpawnCrate, used for their IDs
 	mu        sync.RWMutex
 }
 
@@ -85,6 +87,44 @@ func (wcm *WeaponCrateManager) UpdateRespawns() []string {
 	return respawned
 }
 
+// DelayRespawns pushes every pending respawn back by d so time spent
+// suspended does not count toward crate respawn timers
+func (wcm *WeaponCrateManager) DelayRespawns(d time.Duration) {
+	wcm.mu.Lock()
+	defer wcm.mu.Unlock()
+
+	for _, crate := range wcm.crates {
+		if !crate.IsAvailable {
+			crate.RespawnTime = crate.RespawnTime.Add(d)
+		}
+	}
+}
+
+// SpawnCrate adds a new available crate at position, for custom modes that
+// place extra weapons at runtime. The weapon type must be a known weapon and
+// the position must be inside the map.
+func (wcm *WeaponCrateManager) SpawnCrate(weaponType string, position Vector2) (*WeaponCrate, error) {
+	if _, err := CreateWeaponByType(weaponType); err != nil {
+		return nil, err
+	}
+	if position.X < 0 || position.Y < 0 || position.X > wcm.mapConfig.Width || position.Y > wcm.mapConfig.Height {
+		return nil, fmt.Errorf("crate position (%.0f, %.0f) is outside the map", position.X, position.Y)
+	}
+
+	wcm.mu.Lock()
+	defer wcm.mu.Unlock()
+
+	wcm.spawned++
+	crate := &WeaponCrate{
+		ID:          fmt.Sprintf("spawned_%d", wcm.spawned),
+		Position:    position,
+		WeaponType:  strings.ToLower(weaponType),
+		IsAvailable: true,
+	}
+	wcm.crates[crate.ID] = crate
+	return crate, nil
+}
+
 // GetCrate returns a weapon crate by ID
 // Returns nil if crate doesn't exist
 func (wcm *WeaponCrateManager) GetCrate(crateID string) *WeaponCrate {
@@ -319,3 +319,57 @@ func TestWeaponCrateManager_ConcurrentAccess(t *testing.T) {
 
 	// If we get here without race conditions or panics, test passes
 }
+
+func TestWeaponCrateManager_DelayRespawns(t *testing.T) {
+	manager := NewWeaponCrateManager()
+
+	var pickedID, availableID string
+	for id := range manager.GetAllCrates() {
+		if pickedID == "" {
+			pickedID = id
+		} else {
+			availableID = id
+			break
+		}
+	}
+	if !manager.PickupCrate(pickedID) {
+		t.Fatalf("PickupCrate(%q) failed", pickedID)
+	}
+
+	picked := manager.GetCrate(pickedID)
+	original := picked.RespawnTime
+	manager.DelayRespawns(5 * time.Second)
+
+	if got := picked.RespawnTime.Sub(original); got != 5*time.Second {
+		t.Errorf("pending respawn delayed by %v, want 5s", got)
+	}
+	if availableID != "" && !manager.GetCrate(availableID).RespawnTime.IsZero() {
+		t.Errorf("available crate %q should not gain a respawn time", availableID)
+	}
+}
+
+func TestWeaponCrateManager_SpawnCrate(t *testing.T) {
+	manager := NewWeaponCrateManager()
+	before := len(manager.GetAllCrates())
+
+	crate, err := manager.SpawnCrate("Shotgun", Vector2{X: 100, Y: 200})
+	if err != nil {
+		t.Fatalf("SpawnCrate() returned error: %v", err)
+	}
+	if crate.ID != "spawned_1" || crate.WeaponType != "shotgun" || !crate.IsAvailable {
+		t.Errorf("Unexpected spawned crate: %+v", crate)
+	}
+	if manager.GetCrate(crate.ID) == nil {
+		t.Error("Spawned crate should be retrievable by ID")
+	}
+	if len(manager.GetAllCrates()) != before+1 {
+		t.Errorf("Expected %d crates after spawn, got %d", before+1, len(manager.GetAllCrates()))
+	}
+
+	if _, err := manager.SpawnCrate("rocket", Vector2{X: 100, Y: 200}); err == nil {
+		t.Error("Expected error for unknown weapon type")
+	}
+	if _, err := manager.SpawnCrate("uzi", Vector2{X: -1, Y: 200}); err == nil {
+		t.Error("Expected error for position outside the map")
+	}
+}
@@ -2,7 +2,6 @@ package game
 
 import (
 	"fmt"
-	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -12,23 +11,79 @@ var (
 	// Global weapon configs loaded once at startup
 	weaponConfigs     map[string]*WeaponConfig
 	weaponConfigsOnce sync.Once
+	weaponConfigsMu   sync.RWMutex
 )
 
+// defaultWeaponConfigPaths are searched for weapon-configs.json when no
+// config file is set, from the server, package and repo root directories
+var defaultWeaponConfigPaths = []string{
+	"weapon-configs.json",
+	"../weapon-configs.json",
+	"../../weapon-configs.json",
+	"../../../weapon-configs.json",
+}
+
 // initWeaponConfigs initializes weapon configs from JSON file or falls back to hardcoded values
 func initWeaponConfigs() {
 	weaponConfigsOnce.Do(func() {
-		// Try to load from file at project root
-		configPath := filepath.Join("..", "..", "weapon-configs.json")
-		weaponConfigs = LoadWeaponConfigsOrDefault(configPath)
+		configs := getHardcodedWeaponConfigs()
+		for _, path := range defaultWeaponConfigPaths {
+			if loaded, err := LoadWeaponConfigs(path); err == nil {
+				configs = loaded
+				break
+			}
+		}
+		weaponConfigs = configs
 	})
 }
 
 // getWeaponConfig returns the weapon config for a given weapon name
 func getWeaponConfig(name string) *WeaponConfig {
 	initWeaponConfigs()
+	weaponConfigsMu.RLock()
+	defer weaponConfigsMu.RUnlock()
 	return weaponConfigs[name]
 }
 
+// WeaponDefinitions returns a copy of the weapon configs in effect, keyed by
+// weapon name
+func WeaponDefinitions() map[string]WeaponConfig {
+	initWeaponConfigs()
+	weaponConfigsMu.RLock()
+	defer weaponConfigsMu.RUnlock()
+
+	definitions := make(map[string]WeaponConfig, len(weaponConfigs))
+	for name, config := range weaponConfigs {
+		definitions[name] = *config
+	}
+	return definitions
+}
+
+// UseWeaponConfigFile replaces the weapon definitions with those in path, so
+// operators can tune balance without recompiling. Weapons the file leaves out
+// keep their built-in stats. Called once at startup, before any weapon is
+// created; a file that fails to load or validate changes nothing.
+func UseWeaponConfigFile(path string) error {
+	loaded, err := LoadWeaponConfigs(path)
+	if err != nil {
+		return err
+	}
+
+	configs := getHardcodedWeaponConfigs()
+	for name, config := range loaded {
+		if _, known := configs[name]; !known {
+			return fmt.Errorf("weapon config %s: unknown weapon %q", path, name)
+		}
+		configs[name] = config
+	}
+
+	initWeaponConfigs()
+	weaponConfigsMu.Lock()
+	defer weaponConfigsMu.Unlock()
+	weaponConfigs = configs
+	return nil
+}
+
 // NewBat creates a new Bat weapon instance
 // Stats loaded from weapon-configs.json or hardcoded defaults
 func NewBat() *Weapon {
@@ -50,6 +105,8 @@ func NewBat() *Weapon {
 		KnockbackDistance: 40,
 		Recoil:            nil,
 		SpreadDegrees:     0,
+		ComboWindow:       800 * time.Millisecond,
+		ComboMultiplier:   1.5,
 	}
 }
 
@@ -74,6 +131,34 @@ func NewKatana() *Weapon {
 		KnockbackDistance: 0,
 		Recoil:            nil,
 		SpreadDegrees:     0,
+		ComboWindow:       1100 * time.Millisecond,
+		ComboMultiplier:   1.3,
+	}
+}
+
+// NewFists creates a new Fists weapon instance
+// Stats loaded from weapon-configs.json or hardcoded defaults
+func NewFists() *Weapon {
+	config := getWeaponConfig("Fists")
+	if config != nil {
+		return config.ToWeapon()
+	}
+
+	// Fallback to hardcoded values if config not found
+	return &Weapon{
+		Name:              "Fists",
+		Damage:            15,
+		FireRate:          3.0,
+		MagazineSize:      0,
+		ReloadTime:        0,
+		ProjectileSpeed:   0,
+		Range:             60,
+		ArcDegrees:        100,
+		KnockbackDistance: 0,
+		Recoil:            nil,
+		SpreadDegrees:     0,
+		ComboWindow:       600 * time.Millisecond,
+		ComboMultiplier:   2.0,
 	}
 }
 
@@ -156,6 +241,32 @@ func NewShotgun() *Weapon {
 		KnockbackDistance: 0,
 		Recoil:            nil,
 		SpreadDegrees:     0,
+		HitImpulse:        75,
+	}
+}
+
+// NewRocketLauncher creates a new RocketLauncher weapon instance
+// Stats loaded from weapon-configs.json or hardcoded defaults
+func NewRocketLauncher() *Weapon {
+	config := getWeaponConfig("RocketLauncher")
+	if config != nil {
+		return config.ToWeapon()
+	}
+
+	// Fallback to hardcoded values if config not found
+	return &Weapon{
+		Name:              "RocketLauncher",
+		Damage:            RocketLauncherDamage,
+		FireRate:          0.8,
+		MagazineSize:      3,
+		ReloadTime:        3000 * time.Millisecond,
+		ProjectileSpeed:   600.0,
+		Range:             600,
+		ArcDegrees:        0,
+		KnockbackDistance: RocketLauncherKnockback,
+		Recoil:            nil,
+		SpreadDegrees:     0,
+		SplashRadius:      RocketLauncherSplashRadius,
 	}
 }
 
@@ -168,6 +279,8 @@ func CreateWeaponByType(weaponType string) (*Weapon, error) {
 		return NewBat(), nil
 	case "katana":
 		return NewKatana(), nil
+	case "fists":
+		return NewFists(), nil
 	case "uzi":
 		return NewUzi(), nil
 	case "ak47":
@@ -176,6 +289,8 @@ func CreateWeaponByType(weaponType string) (*Weapon, error) {
 		return NewShotgun(), nil
 	case "pistol":
 		return NewPistol(), nil
+	case "rocketlauncher":
+		return NewRocketLauncher(), nil
 	default:
 		return nil, fmt.Errorf("invalid weapon type: %s", weaponType)
 	}
@@ -1,6 +1,9 @@
 package game
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 )
@@ -169,6 +172,54 @@ func TestNewShotgun(t *testing.T) {
 	if shotgun.ProjectileSpeed != 800.0 {
 		t.Errorf("Expected projectile speed 800.0, got %f", shotgun.ProjectileSpeed)
 	}
+	if shotgun.HitImpulse != 75 {
+		t.Errorf("Expected hit impulse 75, got %f", shotgun.HitImpulse)
+	}
+}
+
+func TestNewRocketLauncher(t *testing.T) {
+	launcher := NewRocketLauncher()
+
+	if launcher == nil {
+		t.Fatal("NewRocketLauncher() returned nil")
+	}
+
+	if launcher.Name != "RocketLauncher" {
+		t.Errorf("Expected name 'RocketLauncher', got '%s'", launcher.Name)
+	}
+	if launcher.Damage != RocketLauncherDamage {
+		t.Errorf("Expected damage %d, got %d", RocketLauncherDamage, launcher.Damage)
+	}
+	if launcher.MagazineSize != 3 {
+		t.Errorf("Expected magazine size 3, got %d", launcher.MagazineSize)
+	}
+	if launcher.SplashRadius != RocketLauncherSplashRadius {
+		t.Errorf("Expected splash radius %f, got %f", RocketLauncherSplashRadius, launcher.SplashRadius)
+	}
+	if !launcher.IsExplosive() || launcher.IsMelee() {
+		t.Error("RocketLauncher should be an explosive ranged weapon")
+	}
+}
+
+func TestNewFists(t *testing.T) {
+	fists := NewFists()
+
+	if fists == nil {
+		t.Fatal("NewFists() returned nil")
+	}
+
+	if fists.Name != "Fists" {
+		t.Errorf("Expected name 'Fists', got '%s'", fists.Name)
+	}
+	if !fists.IsMelee() {
+		t.Error("Fists should be a melee weapon")
+	}
+	if fists.Range >= NewBat().Range || fists.ArcDegrees <= NewBat().ArcDegrees {
+		t.Errorf("Fists should reach less than the bat over a wider arc, got range %f and arc %f", fists.Range, fists.ArcDegrees)
+	}
+	if fists.ComboWindow != 600*time.Millisecond || fists.ComboMultiplier != 2.0 {
+		t.Errorf("Expected a 600ms combo window at 2x, got %v at %fx", fists.ComboWindow, fists.ComboMultiplier)
+	}
 }
 
 func TestCreateWeaponByType_AllValidTypes(t *testing.T) {
@@ -182,6 +233,8 @@ func TestCreateWeaponByType_AllValidTypes(t *testing.T) {
 		{"ak47", "AK47"},
 		{"shotgun", "Shotgun"},
 		{"pistol", "Pistol"},
+		{"rocketlauncher", "RocketLauncher"},
+		{"fists", "Fists"},
 	}
 
 	for _, tt := range tests {
@@ -250,3 +303,85 @@ func TestCreateWeaponByType_CaseInsensitive(t *testing.T) {
 		})
 	}
 }
+
+// restoreWeaponDefinitions puts back the weapon definitions in effect before a
+// test replaces them
+func restoreWeaponDefinitions(t *testing.T) {
+	initWeaponConfigs()
+	weaponConfigsMu.RLock()
+	saved := weaponConfigs
+	weaponConfigsMu.RUnlock()
+
+	t.Cleanup(func() {
+		weaponConfigsMu.Lock()
+		weaponConfigs = saved
+		weaponConfigsMu.Unlock()
+	})
+}
+
+func writeWeaponConfigFile(t *testing.T, weapons map[string]WeaponConfig) string {
+	t.Helper()
+	data, err := json.Marshal(WeaponConfigFile{Version: "1.0.0", Weapons: weapons})
+	if err != nil {
+		t.Fatalf("Failed to marshal config: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "weapons.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestUseWeaponConfigFile_TunesWeapons(t *testing.T) {
+	restoreWeaponDefinitions(t)
+
+	path := writeWeaponConfigFile(t, map[string]WeaponConfig{
+		"Pistol": {Name: "Pistol", Damage: 30, FireRate: 4.0, MagazineSize: 12, ReloadTimeMs: 1200, ProjectileSpeed: 900, Range: 800},
+	})
+	if err := UseWeaponConfigFile(path); err != nil {
+		t.Fatalf("UseWeaponConfigFile failed: %v", err)
+	}
+
+	pistol := NewPistol()
+	if pistol.Damage != 30 || pistol.MagazineSize != 12 || pistol.ReloadTime != 1200*time.Millisecond {
+		t.Errorf("Pistol should use the configured stats, got %+v", pistol)
+	}
+	if NewUzi().Damage != 8 {
+		t.Errorf("Weapons the file leaves out should keep their defaults, got Uzi damage %d", NewUzi().Damage)
+	}
+
+	gs := NewGameServer(nil)
+	gs.AddPlayer("p1")
+	ws := gs.GetWeaponState("p1")
+	if ws.Weapon.Damage != 30 || ws.CurrentAmmo != 12 {
+		t.Errorf("A new player's weapon should use the configured stats, got damage %d ammo %d", ws.Weapon.Damage, ws.CurrentAmmo)
+	}
+	if got := WeaponDefinitions()["Pistol"].Damage; got != 30 {
+		t.Errorf("WeaponDefinitions should report the configured damage, got %d", got)
+	}
+}
+
+func TestUseWeaponConfigFile_RejectsBadFiles(t *testing.T) {
+	restoreWeaponDefinitions(t)
+	before := NewPistol().Damage
+
+	tests := map[string]map[string]WeaponConfig{
+		"unknown weapon": {"Railgun": {Name: "Railgun", Damage: 100, FireRate: 1, MagazineSize: 1, ProjectileSpeed: 2000, Range: 1000}},
+		"invalid stats":  {"Pistol": {Name: "Pistol", Damage: 0, FireRate: 3, MagazineSize: 15, ProjectileSpeed: 800, Range: 800}},
+		"mismatched key": {"Pistol": {Name: "Uzi", Damage: 8, FireRate: 10, MagazineSize: 30, ProjectileSpeed: 800, Range: 600}},
+	}
+	for name, weapons := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := UseWeaponConfigFile(writeWeaponConfigFile(t, weapons)); err == nil {
+				t.Fatal("Expected an error")
+			}
+		})
+	}
+
+	if err := UseWeaponConfigFile("/nonexistent/weapons.json"); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+	if NewPistol().Damage != before {
+		t.Errorf("A rejected file should change nothing, Pistol damage went from %d to %d", before, NewPistol().Damage)
+	}
+}
@@ -16,6 +16,10 @@ func getWeaponBarrelLength(weaponType string) float64 {
 		return 50.5
 	case "Katana", "katana":
 		return 65
+	case "RocketLauncher", "rocketlauncher":
+		return 55
+	case "Fists", "fists":
+		return 20
 	case "Pistol", "pistol":
 		fallthrough
 	default:
@@ -0,0 +1,113 @@
+package game
+
+import "sort"
+
+// Weapon kinds reported by WeaponInspections
+const (
+	WeaponKindMelee     = "melee"
+	WeaponKindRanged    = "ranged"
+	WeaponKindPellet    = "pellet"
+	WeaponKindExplosive = "explosive"
+)
+
+// Damage falloff kinds reported by WeaponInspections
+const (
+	FalloffNone   = "none"   // Full damage anywhere within range
+	FalloffSplash = "splash" // Explosion damage shrinks from the center to the edge
+)
+
+// WeaponInspection is one weapon's balance sheet as the server applies it,
+// published so wikis and clients can read stats instead of copying them.
+// Durations are in milliseconds, distances in pixels and angles in degrees.
+type WeaponInspection struct {
+	Name              string        `json:"name"`
+	Kind              string        `json:"kind"`
+	Damage            int           `json:"damage"`            // Per swing, bullet or explosion center; a pellet weapon's whole volley
+	Pellets           int           `json:"pellets,omitempty"` // Projectiles per shot for pellet weapons
+	PelletDamage      []int         `json:"pelletDamage,omitempty"`
+	FireRate          float64       `json:"fireRate"`        // Shots or swings per second
+	DamagePerSecond   float64       `json:"damagePerSecond"` // Damage times fire rate, ignoring reloads
+	MagazineSize      int           `json:"magazineSize"`    // 0 for melee
+	ReloadTimeMs      int           `json:"reloadTimeMs"`
+	ProjectileSpeed   float64       `json:"projectileSpeed"` // px/s; 0 for melee
+	IsHitscan         bool          `json:"isHitscan"`
+	Range             float64       `json:"range"`
+	ArcDegrees        float64       `json:"arcDegrees"` // Melee swing arc or pellet cone
+	Spread            WeaponSpread  `json:"spread"`
+	Recoil            *RecoilConfig `json:"recoil"`
+	Falloff           WeaponFalloff `json:"falloff"`
+	SplashRadius      float64       `json:"splashRadius"`
+	KnockbackDistance float64       `json:"knockbackDistance"`
+	HitImpulse        float64       `json:"hitImpulse"` // px/s added to the victim along the shot
+}
+
+// WeaponSpread is the random aim error a weapon adds while its holder moves,
+// up to the given degrees either side of the aim
+type WeaponSpread struct {
+	MovingDegrees    float64 `json:"movingDegrees"`
+	SprintingDegrees float64 `json:"sprintingDegrees"`
+}
+
+// WeaponFalloff describes how a weapon's damage shrinks with distance
+type WeaponFalloff struct {
+	Kind           string  `json:"kind"`
+	EdgeMultiplier float64 `json:"edgeMultiplier,omitempty"` // Share of damage at the edge of a splash
+}
+
+// WeaponInspections returns the balance sheet of every weapon in effect,
+// sorted by name. Stats come from the same definitions weapons are created
+// from, so they include any weapon config file loaded at startup.
+func WeaponInspections() []WeaponInspection {
+	definitions := WeaponDefinitions()
+	inspections := make([]WeaponInspection, 0, len(definitions))
+	for _, config := range definitions {
+		inspections = append(inspections, inspectWeapon(config))
+	}
+	sort.Slice(inspections, func(i, j int) bool {
+		return inspections[i].Name < inspections[j].Name
+	})
+	return inspections
+}
+
+func inspectWeapon(config WeaponConfig) WeaponInspection {
+	weapon := config.ToWeapon()
+	inspection := WeaponInspection{
+		Name:            config.Name,
+		Kind:            WeaponKindRanged,
+		Damage:          config.Damage,
+		FireRate:        config.FireRate,
+		DamagePerSecond: float64(config.Damage) * config.FireRate,
+		MagazineSize:    config.MagazineSize,
+		ReloadTimeMs:    config.ReloadTimeMs,
+		ProjectileSpeed: config.ProjectileSpeed,
+		IsHitscan:       config.IsHitscan,
+		Range:           config.Range,
+		ArcDegrees:      config.ArcDegrees,
+		Spread: WeaponSpread{
+			MovingDegrees:    config.SpreadDegrees,
+			SprintingDegrees: config.SpreadDegrees * SprintSpreadMultiplier,
+		},
+		Falloff:           WeaponFalloff{Kind: FalloffNone},
+		SplashRadius:      config.SplashRadius,
+		KnockbackDistance: config.KnockbackDistance,
+		HitImpulse:        config.HitImpulse,
+	}
+
+	if config.Recoil != nil {
+		recoil := *config.Recoil
+		inspection.Recoil = &recoil
+	}
+
+	switch {
+	case weapon.IsMelee():
+		inspection.Kind = WeaponKindMelee
+	case weapon.FiresPellets():
+		inspection.Kind = WeaponKindPellet
+		inspection.Pellets = ShotgunPelletCount
+		inspection.PelletDamage = ShotgunPelletDamages(config.Damage)
+	case weapon.IsExplosive():
+		inspection.Kind = WeaponKindExplosive
+		inspection.Falloff = WeaponFalloff{Kind: FalloffSplash, EdgeMultiplier: ExplosionEdgeFalloff}
+	}
+	return inspection
+}
@@ -0,0 +1,60 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func inspectionByName(t *testing.T, inspections []WeaponInspection, name string) WeaponInspection {
+	t.Helper()
+	for _, inspection := range inspections {
+		if inspection.Name == name {
+			return inspection
+		}
+	}
+	require.Failf(t, "weapon not inspected", "no inspection for %s", name)
+	return WeaponInspection{}
+}
+
+func TestWeaponInspectionsListEveryWeaponByName(t *testing.T) {
+	inspections := WeaponInspections()
+
+	require.Len(t, inspections, len(WeaponDefinitions()))
+	for i := 1; i < len(inspections); i++ {
+		assert.Less(t, inspections[i-1].Name, inspections[i].Name)
+	}
+
+	ak47 := inspectionByName(t, inspections, "AK47")
+	assert.Equal(t, WeaponKindRanged, ak47.Kind)
+	assert.Equal(t, 120.0, ak47.DamagePerSecond)
+	assert.Equal(t, WeaponSpread{MovingDegrees: 3, SprintingDegrees: 4.5}, ak47.Spread)
+	require.NotNil(t, ak47.Recoil)
+	assert.Equal(t, 3.0, ak47.Recoil.HorizontalPerShot)
+	assert.Equal(t, WeaponFalloff{Kind: FalloffNone}, ak47.Falloff)
+
+	assert.Equal(t, WeaponKindMelee, inspectionByName(t, inspections, "Bat").Kind)
+
+	shotgun := inspectionByName(t, inspections, "Shotgun")
+	assert.Equal(t, WeaponKindPellet, shotgun.Kind)
+	assert.Equal(t, ShotgunPelletCount, shotgun.Pellets)
+	assert.Equal(t, ShotgunPelletDamages(shotgun.Damage), shotgun.PelletDamage)
+
+	rocket := inspectionByName(t, inspections, "RocketLauncher")
+	assert.Equal(t, WeaponKindExplosive, rocket.Kind)
+	assert.Equal(t, WeaponFalloff{Kind: FalloffSplash, EdgeMultiplier: ExplosionEdgeFalloff}, rocket.Falloff)
+}
+
+func TestWeaponInspectionsFollowLoadedConfig(t *testing.T) {
+	restoreWeaponDefinitions(t)
+	path := writeWeaponConfigFile(t, map[string]WeaponConfig{
+		"Pistol": {Name: "Pistol", Damage: 30, FireRate: 4.0, MagazineSize: 12, ReloadTimeMs: 1200, ProjectileSpeed: 900, Range: 800},
+	})
+	require.NoError(t, UseWeaponConfigFile(path))
+
+	pistol := inspectionByName(t, WeaponInspections(), "Pistol")
+	assert.Equal(t, 30, pistol.Damage)
+	assert.Equal(t, 120.0, pistol.DamagePerSecond)
+	assert.Equal(t, 1200, pistol.ReloadTimeMs)
+}
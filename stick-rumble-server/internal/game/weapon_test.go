@@ -107,8 +107,8 @@ func TestWeaponState_RecordShot(t *testing.T) {
 		t.Errorf("expected ammo %d, got %d", initialAmmo-1, state.CurrentAmmo)
 	}
 
-	if state.LastShotTime.IsZero() {
-		t.Error("last shot time should be set after shooting")
+	if state.cooldowns.Ready(CooldownShoot) {
+		t.Error("fire cooldown should start after shooting")
 	}
 }
 
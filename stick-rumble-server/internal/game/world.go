@@ -2,7 +2,6 @@ package game
 
 import (
 	"math"
-	"math/rand"
 	"sync"
 )
 
@@ -11,9 +10,9 @@ type World struct {
 	mapConfig MapConfig
 	players   map[string]*PlayerState
 	clock     Clock
-	rng       *rand.Rand // Random number generator for deterministic spawn tie-breaking (protected by rngMu)
+	cooldowns *CooldownManager // Ability cooldowns of every player in the world
+	spawns    *SpawnManager    // Picks the safest spawn point for each spawn
 	mu        sync.RWMutex
-	rngMu     sync.Mutex // Protects rng access (rand.Rand is not thread-safe)
 }
 
 // NewWorld creates a new game world with a real clock
@@ -29,7 +28,8 @@ func NewWorldWithClock(clock Clock, mapConfigs ...MapConfig) *World {
 		mapConfig: mapConfig,
 		players:   make(map[string]*PlayerState),
 		clock:     clock,
-		rng:       rand.New(rand.NewSource(rand.Int63())), // Use a random seed by default
+		cooldowns: NewCooldownManager(clock),
+		spawns:    NewSpawnManager(mapConfig, clock),
 	}
 }
 
@@ -39,6 +39,8 @@ func (w *World) AddPlayer(playerID string) *PlayerState {
 	defer w.mu.Unlock()
 
 	player := NewPlayerStateWithClock(playerID, w.clock)
+	player.cooldowns = w.cooldowns.For(playerID)
+	player.bounds = Vector2{X: w.mapConfig.Width, Y: w.mapConfig.Height}
 
 	// Get a balanced spawn point away from other players
 	// Note: We can't call GetBalancedSpawnPoint here (would deadlock due to mutex)
@@ -50,18 +52,22 @@ func (w *World) AddPlayer(playerID string) *PlayerState {
 	return player
 }
 
-// getBalancedSpawnPointLocked finds a spawn point furthest from all living enemy players
+// getBalancedSpawnPointLocked finds the safest spawn point away from all living enemy players
 // MUST be called with w.mu already held (locked)
 func (w *World) getBalancedSpawnPointLocked(excludePlayerID string) Vector2 {
-	// Collect positions of all living enemy players
+	return w.spawns.SelectSpawn(w.enemyPositionsLocked(excludePlayerID))
+}
+
+// enemyPositionsLocked returns the positions of all living players other than
+// excludePlayerID. MUST be called with w.mu held
+func (w *World) enemyPositionsLocked(excludePlayerID string) []Vector2 {
 	enemyPositions := make([]Vector2, 0)
 	for id, player := range w.players {
 		if id != excludePlayerID && !player.IsDead() {
 			enemyPositions = append(enemyPositions, player.GetPosition())
 		}
 	}
-
-	return w.selectBestSpawnPoint(enemyPositions)
+	return enemyPositions
 }
 
 // RemovePlayer removes a player from the world
@@ -69,6 +75,7 @@ func (w *World) RemovePlayer(playerID string) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 	delete(w.players, playerID)
+	w.cooldowns.RemovePlayer(playerID)
 }
 
 // GetPlayer retrieves a player by ID
@@ -110,89 +117,40 @@ func (w *World) UpdatePlayerInput(playerID string, input InputState) bool {
 	return true
 }
 
-// SetRandSource sets a custom random source for spawn point generation (for testing)
-func (w *World) SetRandSource(source rand.Source) {
-	w.rngMu.Lock()
-	defer w.rngMu.Unlock()
-	w.rng = rand.New(source)
-}
-
-// GetBalancedSpawnPoint finds a spawn point furthest from all living enemy players
-// Returns the center position if no enemies are present
+// GetBalancedSpawnPoint finds the safest spawn point away from all living enemy players,
+// avoiding recent combat. Returns the center position if the map has no valid spawn point
 func (w *World) GetBalancedSpawnPoint(excludePlayerID string) Vector2 {
 	w.mu.RLock()
 	defer w.mu.RUnlock()
 
-	// Collect positions of all living enemy players
-	enemyPositions := make([]Vector2, 0)
-	for id, player := range w.players {
-		if id != excludePlayerID && !player.IsDead() {
-			enemyPositions = append(enemyPositions, player.GetPosition())
-		}
-	}
-
-	return w.selectBestSpawnPoint(enemyPositions)
+	return w.spawns.SelectSpawn(w.enemyPositionsLocked(excludePlayerID))
 }
 
-func (w *World) GetMapConfig() MapConfig {
-	return w.mapConfig
-}
-
-func (w *World) selectBestSpawnPoint(enemyPositions []Vector2) Vector2 {
-	candidates := w.validSpawnCandidates()
-	if len(candidates) == 0 {
-		return Vector2{X: w.mapConfig.Width / 2, Y: w.mapConfig.Height / 2}
-	}
-
-	if len(enemyPositions) == 0 {
-		return candidates[0]
-	}
-
-	bestSpawn := candidates[0]
-	bestScore := -1.0
-
-	for _, candidate := range candidates {
-		score := math.MaxFloat64
-		for _, enemyPos := range enemyPositions {
-			dist := distance(candidate, enemyPos)
-			if dist < score {
-				score = dist
-			}
-		}
-
-		if score > bestScore {
-			bestScore = score
-			bestSpawn = candidate
-		}
-	}
+// SpawnOptions rates every spawn point for the player, as automatic
+// selection would for their respawn
+func (w *World) SpawnOptions(playerID string) []SpawnOption {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
 
-	return bestSpawn
+	return w.spawns.Options(w.enemyPositionsLocked(playerID))
 }
 
-func (w *World) validSpawnCandidates() []Vector2 {
-	blockingObstacles := movementBlockingObstacles(w.mapConfig)
-	candidates := make([]Vector2, 0, len(w.mapConfig.SpawnPoints))
-
-	for _, spawnPoint := range w.mapConfig.SpawnPoints {
-		if !pointWithinBounds(spawnPoint.X, spawnPoint.Y, w.mapConfig) {
-			continue
-		}
+// ChosenSpawnPoint returns the spawn point at index if the player may respawn
+// there right now
+func (w *World) ChosenSpawnPoint(playerID string, index int) (Vector2, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
 
-		blocked := false
-		for _, obstacle := range blockingObstacles {
-			if pointInsideRect(spawnPoint.X, spawnPoint.Y, rectFromObstacle(obstacle)) {
-				blocked = true
-				break
-			}
-		}
-		if blocked {
-			continue
-		}
+	return w.spawns.ChosenSpawn(index, w.enemyPositionsLocked(playerID))
+}
 
-		candidates = append(candidates, Vector2{X: spawnPoint.X, Y: spawnPoint.Y})
-	}
+func (w *World) GetMapConfig() MapConfig {
+	return w.mapConfig
+}
 
-	return candidates
+// Spawns returns the world's spawn manager
+func (w *World) Spawns() *SpawnManager {
+	return w.spawns
 }
 
 func resolveMapConfig(mapConfigs ...MapConfig) MapConfig {
@@ -0,0 +1,581 @@
+package network
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+// AdminMatchEndReason is the match:ended reason when an operator ends a match
+const AdminMatchEndReason = "admin_ended"
+
+// adminRoom is one room in GET /admin/rooms
+type adminRoom struct {
+	ID               string           `json:"id"`
+	Kind             string           `json:"kind"`
+	Code             string           `json:"code,omitempty"`
+	MapID            string           `json:"mapId"`
+	PlayerIDs        []string         `json:"playerIds"`
+	MaxPlayers       int              `json:"maxPlayers"`
+	MatchState       string           `json:"matchState"`
+	RemainingSeconds int              `json:"remainingSeconds"`
+	AimTurnRate      float64          `json:"aimTurnRate"` // 0 when the room uses the server's
+	AllChat          bool             `json:"allChat"`     // False when chat reaches only the sender's team
+	CreatedAt        time.Time        `json:"createdAt"`
+	History          adminRoomHistory `json:"history"`
+}
+
+// adminRoomHistory is how much per-match history a room is holding in memory
+type adminRoomHistory struct {
+	Match    game.MatchHistorySizes `json:"match"`
+	Timeline *TimelineSizes         `json:"timeline,omitempty"` // Omitted without a timeline of the running match
+}
+
+// adminAimTurnRate is the body of PUT /admin/rooms/{roomID}/aim-turn-rate
+type adminAimTurnRate struct {
+	RadiansPerSecond float64 `json:"radiansPerSecond"`
+}
+
+// adminAllChat is the body of PUT /admin/rooms/{roomID}/all-chat
+type adminAllChat struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// adminPlayer is one player in GET /admin/players. Stats is nil until the
+// player is in the game world.
+type adminPlayer struct {
+	ID                       string              `json:"id"`
+	DisplayName              string              `json:"displayName"`
+	RoomID                   string              `json:"roomId"`
+	Team                     string              `json:"team,omitempty"`
+	RTTMs                    int64               `json:"rttMs"`
+	MovementFlagged          bool                `json:"movementFlagged"`
+	InputTimestampViolations int                 `json:"inputTimestampViolations"`
+	Outbound                 *game.OutboundStats `json:"outbound,omitempty"` // Omitted for players without an outbound queue
+	Stats                    *adminPlayerStats   `json:"stats,omitempty"`
+}
+
+type adminPlayerStats struct {
+	Health         int          `json:"health"`
+	Class          string       `json:"class,omitempty"`
+	Alive          bool         `json:"alive"`
+	Kills          int          `json:"kills"`
+	Deaths         int          `json:"deaths"`
+	XP             int          `json:"xp"`
+	Weapon         string       `json:"weapon"`
+	Position       game.Vector2 `json:"position"`
+	UltimateCharge int          `json:"ultimateCharge"`
+	Quarantined    bool         `json:"quarantined"` // Frozen after a non-finite position or velocity
+}
+
+// adminPlayerDetail is GET /admin/players/{playerID}
+type adminPlayerDetail struct {
+	adminPlayer
+	Cooldowns []adminCooldown `json:"cooldowns"`
+	Chaos     *adminChaos     `json:"chaos,omitempty"`
+}
+
+type adminCooldown struct {
+	PlayerID    string `json:"playerId"`
+	Ability     string `json:"ability"`
+	RemainingMs int64  `json:"remainingMs"`
+}
+
+type adminChaos struct {
+	Config ChaosConfig `json:"config"`
+	Stats  ChaosStats  `json:"stats"`
+}
+
+type adminRecording struct {
+	Kind      RecordingTargetKind `json:"kind"`
+	ID        string              `json:"id"`
+	Path      string              `json:"path"`
+	StartedAt time.Time           `json:"startedAt"`
+	Records   int                 `json:"records"`
+}
+
+type adminKick struct {
+	PlayerID string `json:"playerId"`
+	Kicked   bool   `json:"kicked"`
+}
+
+type adminBan struct {
+	Ban
+	Kicked bool `json:"kicked"`
+}
+
+// AdminHandler serves the operator API under /admin/ for the shared global
+// handler. Every request must carry "Authorization: Bearer <token>".
+func AdminHandler(token string) http.Handler {
+	return getGlobalHandler().AdminHandler(token)
+}
+
+// AdminHandler serves the operator API: live rooms and players, matchmaking
+// funnel stats, outbound message drops, force-ending matches, room aim turn
+// rates and all-chat, kicks and bans, name histories, and the debugging tools (session
+// recordings, connection chaos, forced host restart drills, cosmetic grants,
+// cooldowns, hot-path log sampling). Every request must carry
+// "Authorization: Bearer <token>"; an empty token rejects every request.
+func (h *WebSocketHandler) AdminHandler(token string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/rooms", h.adminListRooms)
+	mux.HandleFunc("POST /admin/rooms/{roomID}/end", h.adminEndMatch)
+	mux.HandleFunc("PUT /admin/rooms/{roomID}/aim-turn-rate", h.adminSetAimTurnRate)
+	mux.HandleFunc("PUT /admin/rooms/{roomID}/all-chat", h.adminSetAllChat)
+	mux.HandleFunc("POST /admin/rooms/{roomID}/drill", h.adminChaosDrill)
+	mux.HandleFunc("GET /admin/matchmaking", h.adminMatchmakingStats)
+	mux.HandleFunc("GET /admin/outbound", h.adminOutboundDrops)
+	mux.HandleFunc("GET /admin/players", h.adminListPlayers)
+	mux.HandleFunc("GET /admin/players/{playerID}", h.adminGetPlayer)
+	mux.HandleFunc("GET /admin/players/{playerID}/names", h.adminGetNameHistory)
+	mux.HandleFunc("POST /admin/kick/{playerID}", h.adminKick)
+	mux.HandleFunc("GET /admin/bans", h.adminListBans)
+	mux.HandleFunc("GET /admin/bans/{reference}", h.adminGetBan)
+	mux.HandleFunc("POST /admin/ban/{playerID}", h.adminBan)
+	mux.HandleFunc("DELETE /admin/ban/{playerID}", h.adminUnban)
+	mux.HandleFunc("GET /admin/cooldowns", h.adminListCooldowns)
+	mux.HandleFunc("GET /admin/recordings", h.adminListRecordings)
+	mux.HandleFunc("POST /admin/recordings/{kind}/{id}", h.adminStartRecording)
+	mux.HandleFunc("DELETE /admin/recordings/{kind}/{id}", h.adminStopRecording)
+	mux.HandleFunc("PUT /admin/chaos/{playerID}", h.adminSetChaos)
+	mux.HandleFunc("DELETE /admin/chaos/{playerID}", h.adminClearChaos)
+	mux.HandleFunc("POST /admin/cosmetics/{playerID}/{effectID}", h.adminGrantCosmetic)
+	mux.HandleFunc("GET /admin/log-sampling", h.adminListLogSampling)
+	mux.HandleFunc("PUT /admin/log-sampling/{logger}", h.adminSetLogSampling)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !adminAuthorized(r, token) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="stick-rumble-admin"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// adminAuthorized compares the request's bearer token with token in constant
+// time
+func adminAuthorized(r *http.Request, token string) bool {
+	scheme, value, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if token == "" || !ok || !strings.EqualFold(scheme, "Bearer") {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(strings.TrimSpace(value)), []byte(token)) == 1
+}
+
+func (h *WebSocketHandler) adminListRooms(w http.ResponseWriter, r *http.Request) {
+	rooms := make([]adminRoom, 0)
+	for _, room := range h.roomManager.GetAllRooms() {
+		playerIDs := make([]string, 0, room.PlayerCount())
+		for _, player := range room.GetPlayers() {
+			playerIDs = append(playerIDs, player.ID)
+		}
+		rooms = append(rooms, adminRoom{
+			ID:               room.ID,
+			Kind:             string(room.Kind),
+			Code:             room.Code,
+			MapID:            room.MapID,
+			PlayerIDs:        playerIDs,
+			MaxPlayers:       room.MaxPlayers,
+			MatchState:       string(room.Match.GetState()),
+			RemainingSeconds: room.Match.GetRemainingSeconds(),
+			AimTurnRate:      room.AimTurnRate(),
+			AllChat:          room.AllChat(),
+			CreatedAt:        room.CreatedAt,
+			History:          h.roomHistory(room),
+		})
+	}
+	sort.Slice(rooms, func(i, j int) bool { return rooms[i].CreatedAt.Before(rooms[j].CreatedAt) })
+	writeAdminJSON(w, http.StatusOK, rooms)
+}
+
+// roomHistory measures what the room's match and its timeline are holding
+func (h *WebSocketHandler) roomHistory(room *game.Room) adminRoomHistory {
+	history := adminRoomHistory{Match: room.Match.HistorySizes()}
+	if h.timelines != nil {
+		if sizes, ok := h.timelines.sizes(room.ID); ok {
+			history.Timeline = &sizes
+		}
+	}
+	return history
+}
+
+// adminEndMatch ends a room's running match on the next match tick, which
+// announces match:ended like any other end
+func (h *WebSocketHandler) adminEndMatch(w http.ResponseWriter, r *http.Request) {
+	room := h.roomManager.GetRoom(r.PathValue("roomID"))
+	if room == nil {
+		http.Error(w, "room not found", http.StatusNotFound)
+		return
+	}
+	if !room.Match.IsStarted() {
+		http.Error(w, "match is not running", http.StatusConflict)
+		return
+	}
+
+	room.Match.RequestEnd(AdminMatchEndReason)
+	log.Printf("Admin ended match in room %s", room.ID)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// adminChaosDrill runs a forced host restart drill in a room and answers with
+// its report once the clients have resynced or the drill timed out
+func (h *WebSocketHandler) adminChaosDrill(w http.ResponseWriter, r *http.Request) {
+	report, err := h.RunChaosDrill(r.PathValue("roomID"), h.drillTimeout)
+	switch {
+	case errors.Is(err, ErrDrillRoomAbsent):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		writeAdminJSON(w, http.StatusOK, report)
+	}
+}
+
+// adminSetAimTurnRate sets a room's aim turn rate cap for competitive rule
+// sets; 0 restores the server default
+func (h *WebSocketHandler) adminSetAimTurnRate(w http.ResponseWriter, r *http.Request) {
+	room := h.roomManager.GetRoom(r.PathValue("roomID"))
+	if room == nil {
+		http.Error(w, "room not found", http.StatusNotFound)
+		return
+	}
+
+	var body adminAimTurnRate
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&body); err != nil {
+		http.Error(w, "invalid aim turn rate: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if body.RadiansPerSecond < 0 || math.IsNaN(body.RadiansPerSecond) || math.IsInf(body.RadiansPerSecond, 0) {
+		http.Error(w, "radiansPerSecond must be a finite number, 0 or more", http.StatusBadRequest)
+		return
+	}
+
+	room.SetAimTurnRate(body.RadiansPerSecond)
+	log.Printf("Admin set aim turn rate in room %s to %.1f rad/s", room.ID, body.RadiansPerSecond)
+	writeAdminJSON(w, http.StatusOK, adminAimTurnRate{RadiansPerSecond: room.AimTurnRate()})
+}
+
+// adminSetAllChat turns a room's all-chat on or off; with it off, players
+// chat only with their team
+func (h *WebSocketHandler) adminSetAllChat(w http.ResponseWriter, r *http.Request) {
+	room := h.roomManager.GetRoom(r.PathValue("roomID"))
+	if room == nil {
+		http.Error(w, "room not found", http.StatusNotFound)
+		return
+	}
+
+	var body adminAllChat
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&body); err != nil {
+		http.Error(w, "invalid all-chat setting: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if body.Enabled == nil {
+		http.Error(w, "enabled is required", http.StatusBadRequest)
+		return
+	}
+
+	room.SetAllChat(*body.Enabled)
+	state := "on"
+	if !*body.Enabled {
+		state = "off"
+	}
+	log.Printf("Admin turned all-chat %s in room %s", state, room.ID)
+	enabled := room.AllChat()
+	writeAdminJSON(w, http.StatusOK, adminAllChat{Enabled: &enabled})
+}
+
+// adminMatchmakingStats reports the matchmaking funnel: time to match,
+// abandonment, bot fill and rematch acceptance
+func (h *WebSocketHandler) adminMatchmakingStats(w http.ResponseWriter, r *http.Request) {
+	writeAdminJSON(w, http.StatusOK, h.roomManager.MatchmakingStats())
+}
+
+// adminOutboundDrops returns the messages dropped from every player's
+// outbound queue since start, by priority
+func (h *WebSocketHandler) adminOutboundDrops(w http.ResponseWriter, r *http.Request) {
+	writeAdminJSON(w, http.StatusOK, game.OutboundDropTotals())
+}
+
+func (h *WebSocketHandler) adminListPlayers(w http.ResponseWriter, r *http.Request) {
+	players := make([]adminPlayer, 0)
+	for _, room := range h.roomManager.GetAllRooms() {
+		for _, player := range room.GetPlayers() {
+			players = append(players, h.adminPlayerView(room, player))
+		}
+	}
+	sort.Slice(players, func(i, j int) bool { return players[i].ID < players[j].ID })
+	writeAdminJSON(w, http.StatusOK, players)
+}
+
+func (h *WebSocketHandler) adminGetPlayer(w http.ResponseWriter, r *http.Request) {
+	playerID := r.PathValue("playerID")
+	room := h.roomManager.GetRoomByPlayerID(playerID)
+	var player *game.Player
+	if room != nil {
+		player = room.GetPlayer(playerID)
+	}
+	if player == nil {
+		http.Error(w, "player not found", http.StatusNotFound)
+		return
+	}
+
+	detail := adminPlayerDetail{
+		adminPlayer: h.adminPlayerView(room, player),
+		Cooldowns:   make([]adminCooldown, 0),
+	}
+	for _, cooldown := range h.adminCooldowns() {
+		if cooldown.PlayerID == playerID {
+			detail.Cooldowns = append(detail.Cooldowns, cooldown)
+		}
+	}
+	if config, stats, ok := h.ConnectionChaos(playerID); ok {
+		detail.Chaos = &adminChaos{Config: config, Stats: stats}
+	}
+	writeAdminJSON(w, http.StatusOK, detail)
+}
+
+// adminGetNameHistory lists the display names an account has used, oldest
+// first, including after the player has left
+func (h *WebSocketHandler) adminGetNameHistory(w http.ResponseWriter, r *http.Request) {
+	names := h.names.names(r.PathValue("playerID"))
+	if len(names) == 0 {
+		http.Error(w, "no name history for player", http.StatusNotFound)
+		return
+	}
+	writeAdminJSON(w, http.StatusOK, names)
+}
+
+func (h *WebSocketHandler) adminPlayerView(room *game.Room, player *game.Player) adminPlayer {
+	view := adminPlayer{
+		ID:                       player.ID,
+		DisplayName:              player.DisplayName,
+		RoomID:                   room.ID,
+		Team:                     player.Team,
+		RTTMs:                    player.PingTracker.GetRTT(),
+		MovementFlagged:          h.gameServer.IsMovementFlagged(player.ID),
+		InputTimestampViolations: h.gameServer.InputTimestampViolations(player.ID),
+	}
+	if player.Outbox != nil {
+		stats := player.Outbox.Stats()
+		view.Outbound = &stats
+	}
+	if state, ok := h.gameServer.GetPlayerState(player.ID); ok {
+		view.Stats = &adminPlayerStats{
+			Health:         state.Health,
+			Class:          state.Class,
+			Alive:          state.DeathTime == nil,
+			Kills:          state.Kills,
+			Deaths:         state.Deaths,
+			XP:             state.XP,
+			Weapon:         state.WeaponType,
+			Position:       state.Position,
+			UltimateCharge: state.UltimateCharge,
+		}
+		if worldPlayer, exists := h.gameServer.GetWorld().GetPlayer(player.ID); exists {
+			view.Stats.Quarantined = worldPlayer.IsQuarantined()
+		}
+	}
+	return view
+}
+
+func (h *WebSocketHandler) adminKick(w http.ResponseWriter, r *http.Request) {
+	playerID := r.PathValue("playerID")
+	if !h.kickPlayer(playerID, kickedReason(adminReason(r, "kicked by admin"))) {
+		http.Error(w, "player not connected", http.StatusNotFound)
+		return
+	}
+	writeAdminJSON(w, http.StatusOK, adminKick{PlayerID: playerID, Kicked: true})
+}
+
+func (h *WebSocketHandler) adminListBans(w http.ResponseWriter, r *http.Request) {
+	writeAdminJSON(w, http.StatusOK, h.bans.list())
+}
+
+// adminGetBan looks up a ban by the appeal reference the player was shown,
+// including bans since lifted
+func (h *WebSocketHandler) adminGetBan(w http.ResponseWriter, r *http.Request) {
+	ban, ok := h.bans.lookup(r.PathValue("reference"))
+	if !ok {
+		http.Error(w, "no ban with that reference", http.StatusNotFound)
+		return
+	}
+	writeAdminJSON(w, http.StatusOK, ban)
+}
+
+// adminBan bars a player from reconnecting and kicks them if connected, with
+// the appeal reference in the close reason
+func (h *WebSocketHandler) adminBan(w http.ResponseWriter, r *http.Request) {
+	playerID := r.PathValue("playerID")
+	ban := h.bans.ban(playerID, adminReason(r, "banned by admin"), h.banEvidence(playerID))
+	kicked := h.kickPlayer(ban.PlayerID, bannedReason(ban))
+	writeAdminJSON(w, http.StatusOK, adminBan{Ban: ban, Kicked: kicked})
+}
+
+func (h *WebSocketHandler) adminUnban(w http.ResponseWriter, r *http.Request) {
+	if !h.bans.unban(r.PathValue("playerID")) {
+		http.Error(w, "player not banned", http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *WebSocketHandler) adminListCooldowns(w http.ResponseWriter, r *http.Request) {
+	writeAdminJSON(w, http.StatusOK, h.adminCooldowns())
+}
+
+func (h *WebSocketHandler) adminCooldowns() []adminCooldown {
+	entries := h.gameServer.DumpCooldowns()
+	cooldowns := make([]adminCooldown, 0, len(entries))
+	for _, entry := range entries {
+		cooldowns = append(cooldowns, adminCooldown{
+			PlayerID:    entry.PlayerID,
+			Ability:     entry.Ability,
+			RemainingMs: entry.Remaining.Milliseconds(),
+		})
+	}
+	return cooldowns
+}
+
+func (h *WebSocketHandler) adminListRecordings(w http.ResponseWriter, r *http.Request) {
+	recordings := make([]adminRecording, 0)
+	for _, info := range h.ActiveSessionRecordings() {
+		recordings = append(recordings, newAdminRecording(info))
+	}
+	writeAdminJSON(w, http.StatusOK, recordings)
+}
+
+func (h *WebSocketHandler) adminStartRecording(w http.ResponseWriter, r *http.Request) {
+	info, err := h.StartSessionRecording(adminRecordingTarget(r))
+	if err != nil {
+		writeAdminError(w, err)
+		return
+	}
+	writeAdminJSON(w, http.StatusCreated, newAdminRecording(info))
+}
+
+func (h *WebSocketHandler) adminStopRecording(w http.ResponseWriter, r *http.Request) {
+	info, err := h.StopSessionRecording(adminRecordingTarget(r))
+	if err != nil {
+		writeAdminError(w, err)
+		return
+	}
+	writeAdminJSON(w, http.StatusOK, newAdminRecording(info))
+}
+
+func adminRecordingTarget(r *http.Request) RecordingTarget {
+	return RecordingTarget{Kind: RecordingTargetKind(r.PathValue("kind")), ID: r.PathValue("id")}
+}
+
+func newAdminRecording(info SessionRecordingInfo) adminRecording {
+	return adminRecording{
+		Kind:      info.Target.Kind,
+		ID:        info.Target.ID,
+		Path:      info.Path,
+		StartedAt: info.StartedAt,
+		Records:   info.Records,
+	}
+}
+
+func (h *WebSocketHandler) adminSetChaos(w http.ResponseWriter, r *http.Request) {
+	var config ChaosConfig
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		http.Error(w, "invalid chaos config: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := config.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := h.SetConnectionChaos(r.PathValue("playerID"), config); err != nil {
+		writeAdminError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *WebSocketHandler) adminClearChaos(w http.ResponseWriter, r *http.Request) {
+	h.ClearConnectionChaos(r.PathValue("playerID"))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *WebSocketHandler) adminGrantCosmetic(w http.ResponseWriter, r *http.Request) {
+	if err := h.gameServer.GetCosmeticInventory().Grant(r.PathValue("playerID"), r.PathValue("effectID")); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *WebSocketHandler) adminListLogSampling(w http.ResponseWriter, r *http.Request) {
+	writeAdminJSON(w, http.StatusOK, game.HotPathLogSampling())
+}
+
+func (h *WebSocketHandler) adminSetLogSampling(w http.ResponseWriter, r *http.Request) {
+	var sampling game.LogSampling
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&sampling); err != nil {
+		http.Error(w, "invalid log sampling: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := sampling.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	status, err := game.SetHotPathLogSampling(r.PathValue("logger"), sampling)
+	if err != nil {
+		writeAdminError(w, err)
+		return
+	}
+	log.Printf("Admin set %s log sampling to every=%d maxPerSecond=%d", status.Name, sampling.Every, sampling.MaxPerSecond)
+	writeAdminJSON(w, http.StatusOK, status)
+}
+
+// adminReason returns the request's reason query parameter, or fallback
+func adminReason(r *http.Request, fallback string) string {
+	if reason := strings.TrimSpace(r.URL.Query().Get("reason")); reason != "" {
+		return reason
+	}
+	return fallback
+}
+
+// writeAdminError maps the errors of the admin-controlled tools to statuses
+func writeAdminError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrRecordingTargetInvalid):
+		status = http.StatusBadRequest
+	case errors.Is(err, ErrRecordingAlreadyActive):
+		status = http.StatusConflict
+	case errors.Is(err, ErrRecordingNotActive):
+		status = http.StatusNotFound
+	case errors.Is(err, ErrChaosDisabled):
+		status = http.StatusForbidden
+	case errors.Is(err, game.ErrUnknownLogger):
+		status = http.StatusNotFound
+	}
+	http.Error(w, err.Error(), status)
+}
+
+func writeAdminJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		log.Printf("Failed to encode admin response: %v", err)
+	}
+}
@@ -0,0 +1,430 @@
+package network
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gorilla/websocket"
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAdminToken = "admin-secret"
+
+// adminClient calls a test server's admin API
+type adminClient struct {
+	t      *testing.T
+	server *httptest.Server
+}
+
+func newAdminClient(t *testing.T, ts *testServer) *adminClient {
+	server := httptest.NewServer(ts.handler.AdminHandler(testAdminToken))
+	t.Cleanup(server.Close)
+	return &adminClient{t: t, server: server}
+}
+
+// do sends an authorized request and returns the status and body
+func (c *adminClient) do(method, path, body string) (int, []byte) {
+	c.t.Helper()
+	req, err := http.NewRequest(method, c.server.URL+path, strings.NewReader(body))
+	require.NoError(c.t, err)
+	req.Header.Set("Authorization", "Bearer "+testAdminToken)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(c.t, err)
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(c.t, err)
+	return resp.StatusCode, data
+}
+
+// getJSON sends an authorized GET and decodes the 200 response into value
+func (c *adminClient) getJSON(path string, value any) {
+	c.t.Helper()
+	status, body := c.do(http.MethodGet, path, "")
+	require.Equal(c.t, http.StatusOK, status, string(body))
+	require.NoError(c.t, json.Unmarshal(body, value))
+}
+
+// joinCodeRoom connects two players to a code room and returns their
+// connections, IDs and the room ID once the match is ready
+func joinCodeRoom(t *testing.T, ts *testServer, code string) ([]*websocket.Conn, []string, string) {
+	conns := []*websocket.Conn{ts.connectRawClient(t), ts.connectRawClient(t)}
+	ids := make([]string, 0, len(conns))
+	roomID := ""
+	for i, conn := range conns {
+		sendHelloMessage(t, conn, []string{"Alpha", "Bravo"}[i], "code", code)
+	}
+	for _, conn := range conns {
+		_, status, err := readSessionStatus(t, conn, "match_ready", 2*time.Second)
+		require.NoError(t, err)
+		ids = append(ids, status["playerId"].(string))
+		roomID = status["roomId"].(string)
+	}
+	return conns, ids, roomID
+}
+
+func TestAdminAPIRequiresToken(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	for _, token := range []string{"", testAdminToken} {
+		server := httptest.NewServer(ts.handler.AdminHandler(token))
+		for _, header := range []string{"", "Bearer wrong", "Basic " + testAdminToken} {
+			req, err := http.NewRequest(http.MethodGet, server.URL+"/admin/rooms", nil)
+			require.NoError(t, err)
+			if header != "" {
+				req.Header.Set("Authorization", header)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "token %q, header %q", token, header)
+		}
+		server.Close()
+	}
+}
+
+func TestAdminAPIListsRoomsAndPlayers(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	admin := newAdminClient(t, ts)
+
+	conns, ids, roomID := joinCodeRoom(t, ts, "LIST")
+	for _, conn := range conns {
+		defer conn.Close()
+	}
+
+	var rooms []adminRoom
+	admin.getJSON("/admin/rooms", &rooms)
+	require.Len(t, rooms, 1)
+	assert.Equal(t, roomID, rooms[0].ID)
+	assert.Equal(t, "LIST", rooms[0].Code)
+	assert.ElementsMatch(t, ids, rooms[0].PlayerIDs)
+	assert.Equal(t, string(game.MatchStateWaiting), rooms[0].MatchState, "the match waits for the ready check")
+	assert.Equal(t, 2, rooms[0].History.Match.ScoredPlayers)
+	assert.Nil(t, rooms[0].History.Timeline, "no timeline is kept without a timeline directory")
+
+	var players []adminPlayer
+	admin.getJSON("/admin/players", &players)
+	require.Len(t, players, 2)
+	for _, player := range players {
+		assert.Equal(t, roomID, player.RoomID)
+		require.NotNil(t, player.Stats)
+		assert.Equal(t, game.PlayerMaxHealth, player.Stats.Health)
+		assert.True(t, player.Stats.Alive)
+		require.NotNil(t, player.Outbound)
+		assert.Zero(t, player.Outbound.Dropped)
+	}
+
+	var drops game.OutboundDrops
+	admin.getJSON("/admin/outbound", &drops)
+
+	var detail adminPlayerDetail
+	admin.getJSON("/admin/players/"+ids[0], &detail)
+	assert.Equal(t, ids[0], detail.ID)
+	assert.Empty(t, detail.Cooldowns)
+
+	status, _ := admin.do(http.MethodGet, "/admin/players/nobody", "")
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestAdminAPIEndsMatch(t *testing.T) {
+	ts := newTestServerWithConfig(50 * time.Millisecond)
+	defer ts.Close()
+	admin := newAdminClient(t, ts)
+
+	conns, _, roomID := joinCodeRoom(t, ts, "ENDIT")
+	for _, conn := range conns {
+		defer conn.Close()
+	}
+
+	status, _ := admin.do(http.MethodPost, "/admin/rooms/missing/end", "")
+	assert.Equal(t, http.StatusNotFound, status)
+
+	status, _ = admin.do(http.MethodPost, "/admin/rooms/"+roomID+"/end", "")
+	assert.Equal(t, http.StatusConflict, status, "the match has not started")
+
+	for _, conn := range conns {
+		sendReadyMessage(t, conn, true)
+	}
+	require.Eventually(t, func() bool {
+		return ts.handler.roomManager.GetRoom(roomID).Match.IsStarted()
+	}, 2*time.Second, 10*time.Millisecond)
+
+	status, _ = admin.do(http.MethodPost, "/admin/rooms/"+roomID+"/end", "")
+	require.Equal(t, http.StatusAccepted, status)
+
+	msg, err := readMessageOfType(t, conns[0], "match:ended", 2*time.Second)
+	require.NoError(t, err)
+	data := msg.Data.(map[string]interface{})
+	assert.Equal(t, AdminMatchEndReason, data["reason"])
+
+	status, _ = admin.do(http.MethodPost, "/admin/rooms/"+roomID+"/end", "")
+	assert.Equal(t, http.StatusConflict, status, "an ended match cannot be ended again")
+}
+
+func TestAdminAPISetsRoomAimTurnRate(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	admin := newAdminClient(t, ts)
+
+	conns, playerIDs, roomID := joinCodeRoom(t, ts, "AIMCAP")
+	for _, conn := range conns {
+		defer conn.Close()
+	}
+
+	status, _ := admin.do(http.MethodPut, "/admin/rooms/missing/aim-turn-rate", `{"radiansPerSecond":30}`)
+	assert.Equal(t, http.StatusNotFound, status)
+	status, _ = admin.do(http.MethodPut, "/admin/rooms/"+roomID+"/aim-turn-rate", `{"radiansPerSecond":-1}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status, body := admin.do(http.MethodPut, "/admin/rooms/"+roomID+"/aim-turn-rate", `{"radiansPerSecond":30}`)
+	require.Equal(t, http.StatusOK, status, string(body))
+	assert.Equal(t, 30.0, ts.handler.roomManager.AimTurnRateForPlayer(playerIDs[0]))
+
+	var rooms []adminRoom
+	admin.getJSON("/admin/rooms", &rooms)
+	require.Len(t, rooms, 1)
+	assert.Equal(t, 30.0, rooms[0].AimTurnRate)
+}
+
+func TestAdminAPITogglesRoomAllChat(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	admin := newAdminClient(t, ts)
+
+	conns, _, roomID := joinCodeRoom(t, ts, "TEAMTALK")
+	for _, conn := range conns {
+		defer conn.Close()
+	}
+
+	var rooms []adminRoom
+	admin.getJSON("/admin/rooms", &rooms)
+	require.Len(t, rooms, 1)
+	assert.True(t, rooms[0].AllChat, "all-chat is on by default")
+
+	status, _ := admin.do(http.MethodPut, "/admin/rooms/missing/all-chat", `{"enabled":false}`)
+	assert.Equal(t, http.StatusNotFound, status)
+	status, _ = admin.do(http.MethodPut, "/admin/rooms/"+roomID+"/all-chat", `{}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status, body := admin.do(http.MethodPut, "/admin/rooms/"+roomID+"/all-chat", `{"enabled":false}`)
+	require.Equal(t, http.StatusOK, status, string(body))
+	assert.JSONEq(t, `{"enabled":false}`, string(body))
+	assert.False(t, ts.handler.roomManager.GetRoom(roomID).AllChat())
+
+	admin.getJSON("/admin/rooms", &rooms)
+	assert.False(t, rooms[0].AllChat)
+}
+
+func TestAdminAPIReportsMatchmakingFunnel(t *testing.T) {
+	ts := newTestServerWithConfig(50 * time.Millisecond)
+	defer ts.Close()
+	admin := newAdminClient(t, ts)
+
+	conns, _, roomID := joinCodeRoom(t, ts, "FUNNEL")
+	for _, conn := range conns {
+		defer conn.Close()
+	}
+	for _, conn := range conns {
+		sendReadyMessage(t, conn, true)
+	}
+	require.Eventually(t, func() bool {
+		return ts.handler.roomManager.GetRoom(roomID).Match.IsStarted()
+	}, 2*time.Second, 10*time.Millisecond)
+
+	status, _ := admin.do(http.MethodPost, "/admin/rooms/"+roomID+"/end", "")
+	require.Equal(t, http.StatusAccepted, status)
+	_, err := readMessageOfType(t, conns[0], "match:ended", 2*time.Second)
+	require.NoError(t, err)
+
+	var stats game.MatchmakingStats
+	require.Eventually(t, func() bool {
+		admin.getJSON("/admin/matchmaking", &stats)
+		return stats.RematchOffers == 2
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 2, stats.Matched)
+	assert.Equal(t, 1, stats.MatchesStarted)
+	assert.Zero(t, stats.BotFilledMatches)
+	assert.Equal(t, 2, stats.TimeToMatch.Count)
+}
+
+// readCloseReason reads from conn until the server closes it and returns the
+// close frame's code and text
+func readCloseReason(t *testing.T, conn *websocket.Conn) disconnectReason {
+	t.Helper()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			var closeErr *websocket.CloseError
+			require.ErrorAs(t, err, &closeErr)
+			return disconnectReason{code: CloseCode(closeErr.Code), text: closeErr.Text}
+		}
+	}
+}
+
+func TestAdminAPIKicksAndBansPlayers(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	ts.handler.recorder = newSessionRecorder(t.TempDir(), time.Now)
+	admin := newAdminClient(t, ts)
+
+	conns, ids, roomID := joinCodeRoom(t, ts, "BANS")
+	for _, conn := range conns {
+		defer conn.Close()
+	}
+
+	status, _ := admin.do(http.MethodPost, "/admin/kick/"+ids[0]+"?reason=spam", "")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, kickedReason("spam"), readCloseReason(t, conns[0]))
+	require.Eventually(t, func() bool {
+		return ts.handler.roomManager.GetRoomByPlayerID(ids[0]) == nil
+	}, 2*time.Second, 10*time.Millisecond, "a kicked player is removed")
+
+	status, _ = admin.do(http.MethodPost, "/admin/kick/"+ids[0], "")
+	assert.Equal(t, http.StatusNotFound, status)
+
+	status, _ = admin.do(http.MethodPost, "/admin/recordings/room/"+roomID, "")
+	require.Equal(t, http.StatusCreated, status)
+	status, body := admin.do(http.MethodPost, "/admin/ban/"+ids[1]+"?reason=aimbot", "")
+	require.Equal(t, http.StatusOK, status)
+	var ban adminBan
+	require.NoError(t, json.Unmarshal(body, &ban))
+	assert.True(t, ban.Kicked)
+	assert.Equal(t, "127.0.0.1", ban.Address)
+	assert.Equal(t, "aimbot", ban.Reason)
+	assert.Regexp(t, `^BAN-[2-9A-HJ-NP-Z]{4}-[2-9A-HJ-NP-Z]{4}$`, ban.Reference)
+	require.Len(t, ban.Evidence.Recordings, 1, "the room recording is the replay slice to review")
+	assert.Equal(t, RecordingTarget{Kind: RecordingTargetRoom, ID: roomID}, ban.Evidence.Recordings[0].Target)
+	assert.Equal(t, disconnectReason{code: CloseBanned, text: "banned, appeal ref " + ban.Reference + ": aimbot"}, readCloseReason(t, conns[1]))
+
+	// Test clients all connect from loopback, so the address ban bars them
+	_, resp, err := websocket.DefaultDialer.Dial(ts.wsURL(), nil)
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	rejection, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(rejection), ban.Reference)
+
+	var bans []Ban
+	admin.getJSON("/admin/bans", &bans)
+	require.Len(t, bans, 1)
+	assert.Equal(t, ids[1], bans[0].PlayerID)
+
+	status, _ = admin.do(http.MethodDelete, "/admin/ban/"+ids[1], "")
+	require.Equal(t, http.StatusNoContent, status)
+	conn := ts.connectRawClient(t)
+	conn.Close()
+
+	status, _ = admin.do(http.MethodDelete, "/admin/ban/"+ids[1], "")
+	assert.Equal(t, http.StatusNotFound, status)
+
+	// The reference still resolves after the ban is lifted
+	var appealed Ban
+	admin.getJSON("/admin/bans/"+ban.Reference, &appealed)
+	assert.Equal(t, ids[1], appealed.PlayerID)
+	assert.Equal(t, ban.Evidence, appealed.Evidence)
+	assert.NotNil(t, appealed.LiftedAt)
+	status, _ = admin.do(http.MethodGet, "/admin/bans/BAN-2222-2222", "")
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestBanCloseReasonFitsCloseFrame(t *testing.T) {
+	ban := Ban{Reference: "BAN-7KQ2-M9XD", Reason: strings.Repeat("é", 100)}
+	reason := banCloseReason(ban)
+
+	assert.LessOrEqual(t, len(reason), maxCloseReasonBytes)
+	assert.True(t, strings.HasPrefix(reason, "banned, appeal ref BAN-7KQ2-M9XD: "), "the reference survives truncation")
+	assert.True(t, utf8.ValidString(reason))
+	assert.Equal(t, "banned, appeal ref BAN-7KQ2-M9XD", banCloseReason(Ban{Reference: "BAN-7KQ2-M9XD"}))
+}
+
+func TestAdminAPIExposesDebugTools(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	ts.handler.recorder = newSessionRecorder(t.TempDir(), time.Now)
+	admin := newAdminClient(t, ts)
+
+	conns, ids, roomID := joinCodeRoom(t, ts, "TOOLS")
+	for _, conn := range conns {
+		defer conn.Close()
+	}
+
+	// Session recordings
+	status, body := admin.do(http.MethodPost, "/admin/recordings/room/"+roomID, "")
+	require.Equal(t, http.StatusCreated, status, string(body))
+	status, _ = admin.do(http.MethodPost, "/admin/recordings/room/"+roomID, "")
+	assert.Equal(t, http.StatusConflict, status)
+	var recordings []adminRecording
+	admin.getJSON("/admin/recordings", &recordings)
+	require.Len(t, recordings, 1)
+	assert.Equal(t, roomID, recordings[0].ID)
+	status, _ = admin.do(http.MethodDelete, "/admin/recordings/room/"+roomID, "")
+	assert.Equal(t, http.StatusOK, status)
+	status, _ = admin.do(http.MethodPost, "/admin/recordings/server/"+roomID, "")
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	// Connection chaos
+	status, _ = admin.do(http.MethodPut, "/admin/chaos/"+ids[0], `{"dropPercent": 150}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = admin.do(http.MethodPut, "/admin/chaos/"+ids[0], `{"delayPercent": 10, "delayMs": 100}`)
+	require.Equal(t, http.StatusNoContent, status)
+	var detail adminPlayerDetail
+	admin.getJSON("/admin/players/"+ids[0], &detail)
+	require.NotNil(t, detail.Chaos)
+	assert.Equal(t, 100, detail.Chaos.Config.DelayMs)
+	status, _ = admin.do(http.MethodDelete, "/admin/chaos/"+ids[0], "")
+	require.Equal(t, http.StatusNoContent, status)
+	_, _, chaotic := ts.handler.ConnectionChaos(ids[0])
+	assert.False(t, chaotic)
+
+	// Cosmetic grants
+	status, _ = admin.do(http.MethodPost, "/admin/cosmetics/"+ids[0]+"/rainbow", "")
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = admin.do(http.MethodPost, "/admin/cosmetics/"+ids[0]+"/"+game.TrailEffectNeon, "")
+	require.Equal(t, http.StatusNoContent, status)
+	assert.True(t, ts.handler.gameServer.GetCosmeticInventory().Owns(ids[0], game.TrailEffectNeon))
+
+	// Cooldowns
+	require.True(t, ts.handler.gameServer.PlayerShoot(ids[0], 0, time.Now().UnixMilli()).Success)
+	var cooldowns []adminCooldown
+	admin.getJSON("/admin/cooldowns", &cooldowns)
+	require.Len(t, cooldowns, 1)
+	assert.Equal(t, ids[0], cooldowns[0].PlayerID)
+	assert.Equal(t, game.CooldownShoot, cooldowns[0].Ability)
+	assert.Positive(t, cooldowns[0].RemainingMs)
+}
+
+func TestAdminAPISetsHotPathLogSampling(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	admin := newAdminClient(t, ts)
+	t.Cleanup(func() { inputLog.SetSampling(game.LogSampling{}) })
+
+	var loggers []game.LogSamplingStatus
+	admin.getJSON("/admin/log-sampling", &loggers)
+	names := make([]string, 0, len(loggers))
+	for _, logger := range loggers {
+		names = append(names, logger.Name)
+	}
+	assert.Subset(t, names, []string{"broadcast", "input", "room_send"})
+
+	status, body := admin.do(http.MethodPut, "/admin/log-sampling/input", `{"every":10,"maxPerSecond":5}`)
+	require.Equal(t, http.StatusOK, status, string(body))
+	assert.Equal(t, game.LogSampling{Every: 10, MaxPerSecond: 5}, inputLog.Status().Sampling)
+
+	status, _ = admin.do(http.MethodPut, "/admin/log-sampling/nope", `{"every":2}`)
+	assert.Equal(t, http.StatusNotFound, status)
+	status, _ = admin.do(http.MethodPut, "/admin/log-sampling/input", `{"every":-1}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = admin.do(http.MethodPut, "/admin/log-sampling/input", `{"rate":1}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
@@ -0,0 +1,161 @@
+package network
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxUserIDLength bounds the subject claim, which becomes the player ID sent
+// to every client in the room
+const maxUserIDLength = 128
+
+var (
+	errMissingToken = errors.New("missing bearer token")
+	errInvalidToken = errors.New("invalid bearer token")
+	errExpiredToken = errors.New("bearer token expired")
+)
+
+// tokenClaims are the JWT claims the server reads; anything else is ignored
+type tokenClaims struct {
+	Subject   string `json:"sub"`
+	ExpiresAt *int64 `json:"exp,omitempty"`
+	NotBefore *int64 `json:"nbf,omitempty"`
+}
+
+// tokenAuthenticator verifies HS256 JWT bearer tokens on the /ws upgrade and
+// tracks which user IDs have a player, so one account cannot play as two
+// players at once. With no secret it is disabled and players get random IDs.
+type tokenAuthenticator struct {
+	secret []byte
+	now    func() time.Time
+	users  map[string]chan struct{} // user ID -> closed when its player (live or parked) is released
+	mu     sync.Mutex
+}
+
+func newTokenAuthenticator(secret string, now func() time.Time) *tokenAuthenticator {
+	return &tokenAuthenticator{
+		secret: []byte(secret),
+		now:    now,
+		users:  make(map[string]chan struct{}),
+	}
+}
+
+// enabled reports whether connections must present a token
+func (a *tokenAuthenticator) enabled() bool {
+	return len(a.secret) > 0
+}
+
+// authenticate returns the verified user ID of the token in r's Authorization
+// header or, for browsers that cannot set headers on a WebSocket, its token
+// query parameter
+func (a *tokenAuthenticator) authenticate(r *http.Request) (string, error) {
+	token := r.URL.Query().Get("token")
+	if header := r.Header.Get("Authorization"); header != "" {
+		scheme, value, ok := strings.Cut(header, " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") {
+			return "", errInvalidToken
+		}
+		token = strings.TrimSpace(value)
+	}
+	if token == "" {
+		return "", errMissingToken
+	}
+	return a.verify(token)
+}
+
+// verify checks token's signature and time claims and returns its subject
+func (a *tokenAuthenticator) verify(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", errInvalidToken
+	}
+
+	var header struct {
+		Algorithm string `json:"alg"`
+	}
+	if err := decodeTokenPart(parts[0], &header); err != nil || header.Algorithm != "HS256" {
+		return "", errInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", errInvalidToken
+	}
+	mac := hmac.New(sha256.New, a.secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return "", errInvalidToken
+	}
+
+	var claims tokenClaims
+	if err := decodeTokenPart(parts[1], &claims); err != nil {
+		return "", errInvalidToken
+	}
+	if claims.Subject == "" || len(claims.Subject) > maxUserIDLength {
+		return "", errInvalidToken
+	}
+	now := a.now().Unix()
+	if claims.ExpiresAt != nil && now >= *claims.ExpiresAt {
+		return "", errExpiredToken
+	}
+	if claims.NotBefore != nil && now < *claims.NotBefore {
+		return "", errInvalidToken
+	}
+	return claims.Subject, nil
+}
+
+func decodeTokenPart(part string, value any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, value)
+}
+
+// claim reserves userID for a new player; false if the user already has one
+func (a *tokenAuthenticator) claim(userID string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, taken := a.users[userID]; taken {
+		return false
+	}
+	a.users[userID] = make(chan struct{})
+	return true
+}
+
+// claimAfterRelease waits up to timeout for the user's current player to be
+// released, then claims userID. False if it is still held or another
+// connection claimed it first.
+func (a *tokenAuthenticator) claimAfterRelease(userID string, timeout time.Duration) bool {
+	a.mu.Lock()
+	released, taken := a.users[userID]
+	a.mu.Unlock()
+
+	if taken {
+		select {
+		case <-released:
+		case <-time.After(timeout):
+			return false
+		}
+	}
+	return a.claim(userID)
+}
+
+// release frees userID once its player is removed for good
+func (a *tokenAuthenticator) release(userID string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if released, taken := a.users[userID]; taken {
+		close(released)
+		delete(a.users, userID)
+	}
+}
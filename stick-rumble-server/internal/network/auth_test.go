@@ -0,0 +1,267 @@
+package network
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAuthSecret = "test-secret"
+
+var authTestNow = time.Unix(1_800_000_000, 0)
+
+// signToken builds an HS256 JWT over claims
+func signToken(t *testing.T, secret string, claims map[string]any) string {
+	t.Helper()
+	header, err := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func newTestAuthenticator() *tokenAuthenticator {
+	return newTokenAuthenticator(testAuthSecret, func() time.Time { return authTestNow })
+}
+
+func TestTokenAuthenticatorVerify(t *testing.T) {
+	auth := newTestAuthenticator()
+	valid := signToken(t, testAuthSecret, map[string]any{"sub": "user-42", "exp": authTestNow.Add(time.Hour).Unix()})
+
+	userID, err := auth.verify(valid)
+	require.NoError(t, err)
+	assert.Equal(t, "user-42", userID)
+
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"user-42"}`)) + "."
+
+	for name, token := range map[string]string{
+		"wrong secret":  signToken(t, "other-secret", map[string]any{"sub": "user-42"}),
+		"alg none":      unsigned,
+		"no subject":    signToken(t, testAuthSecret, map[string]any{"exp": authTestNow.Add(time.Hour).Unix()}),
+		"not yet valid": signToken(t, testAuthSecret, map[string]any{"sub": "user-42", "nbf": authTestNow.Add(time.Minute).Unix()}),
+		"malformed":     "not-a-jwt",
+		"tampered":      valid[:len(valid)-2] + "xx",
+	} {
+		_, err := auth.verify(token)
+		assert.ErrorIs(t, err, errInvalidToken, name)
+	}
+
+	_, err = auth.verify(signToken(t, testAuthSecret, map[string]any{"sub": "user-42", "exp": authTestNow.Unix()}))
+	assert.ErrorIs(t, err, errExpiredToken)
+}
+
+func TestTokenAuthenticatorReadsHeaderOrQuery(t *testing.T) {
+	auth := newTestAuthenticator()
+	token := signToken(t, testAuthSecret, map[string]any{"sub": "user-42"})
+
+	fromHeader := httptest.NewRequest(http.MethodGet, "/ws", nil)
+	fromHeader.Header.Set("Authorization", "Bearer "+token)
+	userID, err := auth.authenticate(fromHeader)
+	require.NoError(t, err)
+	assert.Equal(t, "user-42", userID)
+
+	userID, err = auth.authenticate(httptest.NewRequest(http.MethodGet, "/ws?token="+token, nil))
+	require.NoError(t, err)
+	assert.Equal(t, "user-42", userID)
+
+	_, err = auth.authenticate(httptest.NewRequest(http.MethodGet, "/ws", nil))
+	assert.ErrorIs(t, err, errMissingToken)
+
+	basic := httptest.NewRequest(http.MethodGet, "/ws", nil)
+	basic.Header.Set("Authorization", "Basic dXNlcjpwYXNz")
+	_, err = auth.authenticate(basic)
+	assert.ErrorIs(t, err, errInvalidToken)
+}
+
+func TestTokenAuthenticatorClaimsOnePlayerPerUser(t *testing.T) {
+	auth := newTestAuthenticator()
+
+	assert.True(t, auth.claim("user-42"))
+	assert.False(t, auth.claim("user-42"))
+	auth.release("user-42")
+	assert.True(t, auth.claim("user-42"))
+}
+
+func TestTokenAuthenticatorClaimAfterRelease(t *testing.T) {
+	auth := newTestAuthenticator()
+
+	assert.True(t, auth.claimAfterRelease("user-42", time.Second), "an unheld user is claimed at once")
+	assert.False(t, auth.claimAfterRelease("user-42", 10*time.Millisecond), "the holder never released")
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		auth.release("user-42")
+	}()
+	assert.True(t, auth.claimAfterRelease("user-42", time.Second))
+}
+
+func TestHandleWebSocketRequiresTokenWhenAuthEnabled(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	ts.setAuthSecret(testAuthSecret)
+
+	_, resp, err := websocket.DefaultDialer.Dial(ts.wsURL(), nil)
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	token := signToken(t, testAuthSecret, map[string]any{"sub": "user-42", "exp": time.Now().Add(time.Hour).Unix()})
+	header := http.Header{"Authorization": []string{"Bearer " + token}}
+	conn, _, err := websocket.DefaultDialer.Dial(ts.wsURL(), header)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	sendHelloMessage(t, conn, "Blip", "code", "AUTH")
+	_, status, err := readSessionStatus(t, conn, "waiting_for_players", 2*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "user-42", status["playerId"], "the verified user ID is the player ID")
+}
+
+// expectSessionReplaced reads conn until session:replaced, then expects the
+// connection to be closed with the replacement reason
+func expectSessionReplaced(t *testing.T, conn *websocket.Conn) {
+	t.Helper()
+	_, err := readMessageOfType(t, conn, "session:replaced", 2*time.Second)
+	require.NoError(t, err)
+
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			var closeErr *websocket.CloseError
+			require.ErrorAs(t, err, &closeErr)
+			assert.Equal(t, int(CloseSessionReplaced), closeErr.Code)
+			assert.Equal(t, sessionReplacedReason.text, closeErr.Text)
+			return
+		}
+	}
+}
+
+func TestHandleWebSocketNewConnectionReplacesOlderSession(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	ts.setAuthSecret(testAuthSecret)
+
+	url := ts.wsURL() + "?token=" + signToken(t, testAuthSecret, map[string]any{"sub": "user-42"})
+	first, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	defer first.Close()
+	readServerHello(t, first)
+	sendHelloMessage(t, first, "Blip", "code", "AUTH")
+	_, _, err = readSessionStatus(t, first, "waiting_for_players", 2*time.Second)
+	require.NoError(t, err)
+
+	second, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	defer second.Close()
+
+	expectSessionReplaced(t, first)
+
+	// The new connection takes over the same player and room
+	_, resumed := readServerHello(t, second)
+	assert.True(t, resumed)
+	_, status, err := readSessionStatus(t, second, "waiting_for_players", 2*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "user-42", status["playerId"])
+	assert.Equal(t, "AUTH", status["code"])
+}
+
+func TestHandleWebSocketNewConnectionStartsFreshWithoutResume(t *testing.T) {
+	ts := newTestServer(withResumeGrace(0))
+	defer ts.Close()
+	ts.setAuthSecret(testAuthSecret)
+
+	url := ts.wsURL() + "?token=" + signToken(t, testAuthSecret, map[string]any{"sub": "user-42"})
+	first, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	defer first.Close()
+	readServerHello(t, first)
+	sendHelloMessage(t, first, "Blip", "code", "AUTH")
+	_, _, err = readSessionStatus(t, first, "waiting_for_players", 2*time.Second)
+	require.NoError(t, err)
+
+	second, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	defer second.Close()
+
+	expectSessionReplaced(t, first)
+
+	// The old player is removed and the new connection gets its own
+	_, resumed := readServerHello(t, second)
+	assert.False(t, resumed)
+	sendHelloMessage(t, second, "Blip", "code", "AUTH")
+	_, status, err := readSessionStatus(t, second, "waiting_for_players", 2*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "user-42", status["playerId"])
+}
+
+// sendSequencedInput sends an input:state holding right with the given sequence
+func sendSequencedInput(t *testing.T, conn *websocket.Conn, sequence int) {
+	t.Helper()
+	sendMessage(t, conn, Message{
+		Type:      "input:state",
+		Timestamp: time.Now().UnixMilli(),
+		Data: map[string]interface{}{
+			"up":          false,
+			"down":        false,
+			"left":        false,
+			"right":       true,
+			"aimAngle":    0.0,
+			"isSprinting": false,
+			"sequence":    sequence,
+		},
+	})
+}
+
+func TestHandleWebSocketReplacingConnectionRestartsInputSequence(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	ts.setAuthSecret(testAuthSecret)
+
+	url := ts.wsURL() + "?token=" + signToken(t, testAuthSecret, map[string]any{"sub": "user-42"})
+	first, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	defer first.Close()
+	readServerHello(t, first)
+	sendHelloMessage(t, first, "Blip", "code", "AUTH")
+	other, _, err := websocket.DefaultDialer.Dial(ts.wsURL()+"?token="+signToken(t, testAuthSecret, map[string]any{"sub": "user-7"}), nil)
+	require.NoError(t, err)
+	defer other.Close()
+	sendHelloMessage(t, other, "Steady", "code", "AUTH")
+	_, _, err = readSessionStatus(t, first, "match_ready", 2*time.Second)
+	require.NoError(t, err)
+
+	player, exists := ts.handler.gameServer.GetWorld().GetPlayer("user-42")
+	require.True(t, exists)
+	sendSequencedInput(t, first, 400)
+	require.Eventually(t, func() bool { return player.GetInputSequence() == 400 }, 2*time.Second, 10*time.Millisecond)
+
+	second, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	defer second.Close()
+	expectSessionReplaced(t, first)
+	readServerHello(t, second)
+	_, _, err = readSessionStatus(t, second, "match_ready", 2*time.Second)
+	require.NoError(t, err)
+	assert.False(t, player.GetInput().Right, "the old connection's keys are released")
+
+	// A fresh tab numbers its inputs from the start again
+	start := player.GetPosition()
+	sendSequencedInput(t, second, 1)
+	require.Eventually(t, func() bool {
+		return player.GetInputSequence() == 1 && player.GetPosition().X > start.X
+	}, 2*time.Second, 10*time.Millisecond, "the replacing connection's inputs move the player")
+}
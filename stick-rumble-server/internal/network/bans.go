@@ -0,0 +1,329 @@
+package network
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// banFileName is the JSON-lines file bans are appended to
+const banFileName = "bans.jsonl"
+
+// Ban is a player barred from reconnecting. Players without an authenticated
+// user ID get a new ID on every connection, so the ban also covers the
+// address they were connected from.
+type Ban struct {
+	Reference string      `json:"reference"` // Appeal reference code shown to the player
+	PlayerID  string      `json:"playerId"`
+	Address   string      `json:"address,omitempty"`
+	Reason    string      `json:"reason,omitempty"`
+	BannedAt  time.Time   `json:"bannedAt"`
+	LiftedAt  *time.Time  `json:"liftedAt,omitempty"`
+	Evidence  BanEvidence `json:"evidence"`
+}
+
+// BanEvidence is what a ban was based on, captured when it is applied so an
+// appeal can be reviewed after the player and its room are gone
+type BanEvidence struct {
+	MovementFlagged bool           `json:"movementFlagged"`
+	Recordings      []BanRecording `json:"recordings,omitempty"`
+}
+
+// BanRecording is a session recording that was watching the player or its
+// room when the ban was applied. The slice to review runs from StartedAt to
+// the ban's BannedAt.
+type BanRecording struct {
+	Target    RecordingTarget `json:"target"`
+	Path      string          `json:"path"`
+	StartedAt time.Time       `json:"startedAt"`
+}
+
+// banReferenceAlphabet leaves out 0/O and 1/I so a code read out to support
+// is unambiguous
+const banReferenceAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+
+// banStore persists bans, lifted or not
+type banStore interface {
+	SaveBan(ban Ban) error
+	LoadBans() ([]Ban, error)
+}
+
+// fileBanStore appends a ban as a line of bans.jsonl in its directory each
+// time it is issued or lifted; the last line for a reference is its current
+// state. Bans are rare, so the file is opened per write rather than held open.
+type fileBanStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+func newFileBanStore(dir string) *fileBanStore {
+	return &fileBanStore{dir: dir}
+}
+
+func (s *fileBanStore) SaveBan(ban Ban) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("create ban directory: %w", err)
+	}
+	file, err := os.OpenFile(filepath.Join(s.dir, banFileName), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open ban file: %w", err)
+	}
+	if err := json.NewEncoder(file).Encode(ban); err != nil {
+		file.Close()
+		return fmt.Errorf("write ban: %w", err)
+	}
+	return file.Close()
+}
+
+// LoadBans returns every line of bans.jsonl, oldest first. A missing file
+// has no bans.
+func (s *fileBanStore) LoadBans() ([]Ban, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.Open(filepath.Join(s.dir, banFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open ban file: %w", err)
+	}
+	defer file.Close()
+
+	var bans []Ban
+	decoder := json.NewDecoder(file)
+	for {
+		var ban Ban
+		if err := decoder.Decode(&ban); errors.Is(err, io.EOF) {
+			return bans, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("read ban: %w", err)
+		}
+		bans = append(bans, ban)
+	}
+}
+
+// banList holds the bans and the address each connected or parked player
+// came from. With a store, bans outlive the process; without one they are
+// kept in memory only. Every ban issued stays in the history after it is
+// lifted, so its reference still resolves for an appeal.
+type banList struct {
+	bans      map[string]Ban    // player ID -> ban
+	history   map[string]Ban    // reference -> every ban issued
+	addresses map[string]string // player ID -> remote address while connected or parked
+	store     banStore          // Saves every ban issued or lifted; nil keeps them in memory
+	now       func() time.Time
+	mu        sync.Mutex
+}
+
+func newBanList(now func() time.Time) *banList {
+	return &banList{
+		bans:      make(map[string]Ban),
+		history:   make(map[string]Ban),
+		addresses: make(map[string]string),
+		now:       now,
+	}
+}
+
+// restore loads the bans saved in store, then saves every ban issued or lifted
+// to it
+func (b *banList) restore(store banStore) error {
+	saved, err := store.LoadBans()
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, entry := range saved {
+		b.history[entry.Reference] = entry
+		if entry.LiftedAt == nil {
+			b.bans[entry.PlayerID] = entry
+		} else if current, ok := b.bans[entry.PlayerID]; ok && current.Reference == entry.Reference {
+			delete(b.bans, entry.PlayerID)
+		}
+	}
+	b.store = store
+	return nil
+}
+
+// saveLocked hands a ban just issued or lifted to the store, if there is one.
+// The ban stands either way; a failed write is logged.
+// Called with b.mu held.
+func (b *banList) saveLocked(entry Ban) {
+	if b.store == nil {
+		return
+	}
+	if err := b.store.SaveBan(entry); err != nil {
+		log.Printf("Error saving ban %s: %v", entry.Reference, err)
+	}
+}
+
+// newBanReference returns a code like BAN-7KQ2-M9XD (40 random bits)
+func newBanReference() string {
+	var random [8]byte
+	if _, err := rand.Read(random[:]); err != nil {
+		panic("ban reference: " + err.Error())
+	}
+	code := []byte("BAN-XXXX-XXXX")
+	for i, j := 0, 4; i < len(random); i, j = i+1, j+1 {
+		if code[j] == '-' {
+			j++
+		}
+		code[j] = banReferenceAlphabet[int(random[i])%len(banReferenceAlphabet)]
+	}
+	return string(code)
+}
+
+// banCloseReason is the text a banned player is shown, leading with the
+// appeal reference so it survives the close frame's length limit
+func banCloseReason(ban Ban) string {
+	text := "banned, appeal ref " + ban.Reference
+	if ban.Reason != "" {
+		text += ": " + ban.Reason
+	}
+	return closeReasonText(text)
+}
+
+// remoteHost returns the IP of a request's remote address
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// connect remembers where a player is connected from
+func (b *banList) connect(playerID, address string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.addresses[playerID] = address
+}
+
+// disconnect forgets a player's address once the player is removed for good
+func (b *banList) disconnect(playerID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.addresses, playerID)
+}
+
+// ban bars a player ID and, if the player is connected or parked, its
+// address, under a new appeal reference
+func (b *banList) ban(playerID, reason string, evidence BanEvidence) Ban {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var reference string
+	for {
+		reference = newBanReference()
+		if _, taken := b.history[reference]; !taken {
+			break
+		}
+	}
+
+	entry := Ban{
+		Reference: reference,
+		PlayerID:  playerID,
+		Address:   b.addresses[playerID],
+		Reason:    reason,
+		BannedAt:  b.now(),
+		Evidence:  evidence,
+	}
+	b.bans[playerID] = entry
+	b.history[reference] = entry
+	b.saveLocked(entry)
+	return entry
+}
+
+// unban lifts a player's ban; false if there was none
+func (b *banList) unban(playerID string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entry, ok := b.bans[playerID]
+	if !ok {
+		return false
+	}
+	delete(b.bans, playerID)
+	liftedAt := b.now()
+	entry.LiftedAt = &liftedAt
+	b.history[entry.Reference] = entry
+	b.saveLocked(entry)
+	return true
+}
+
+// lookup returns the ban issued under an appeal reference, lifted or not
+func (b *banList) lookup(reference string) (Ban, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entry, ok := b.history[reference]
+	return entry, ok
+}
+
+// banned returns the ban barring a connection with this user ID (empty when
+// auth is off) from this address, if any
+func (b *banList) banned(userID, address string) (Ban, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if entry, ok := b.bans[userID]; ok && userID != "" {
+		return entry, true
+	}
+	for _, entry := range b.bans {
+		if entry.Address != "" && entry.Address == address {
+			return entry, true
+		}
+	}
+	return Ban{}, false
+}
+
+// list returns the bans, oldest first
+func (b *banList) list() []Ban {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	bans := make([]Ban, 0, len(b.bans))
+	for _, entry := range b.bans {
+		bans = append(bans, entry)
+	}
+	sort.Slice(bans, func(i, j int) bool {
+		if !bans[i].BannedAt.Equal(bans[j].BannedAt) {
+			return bans[i].BannedAt.Before(bans[j].BannedAt)
+		}
+		return bans[i].PlayerID < bans[j].PlayerID
+	})
+	return bans
+}
+
+// banEvidence gathers what a ban is based on: the movement guard's verdict
+// and the session recordings watching the player or its room
+func (h *WebSocketHandler) banEvidence(playerID string) BanEvidence {
+	evidence := BanEvidence{MovementFlagged: h.gameServer.IsMovementFlagged(playerID)}
+
+	roomTarget := RecordingTarget{}
+	if room := h.roomManager.GetRoomByPlayerID(playerID); room != nil {
+		roomTarget = RecordingTarget{Kind: RecordingTargetRoom, ID: room.ID}
+	}
+	for _, info := range h.recorder.list() {
+		if info.Target == (RecordingTarget{Kind: RecordingTargetPlayer, ID: playerID}) || (roomTarget.ID != "" && info.Target == roomTarget) {
+			evidence.Recordings = append(evidence.Recordings, BanRecording{Target: info.Target, Path: info.Path, StartedAt: info.StartedAt})
+		}
+	}
+	return evidence
+}
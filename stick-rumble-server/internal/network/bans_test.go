@@ -0,0 +1,58 @@
+package network
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBanListReloadsSavedBans(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "bans")
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	bans := newBanList(clock)
+	require.NoError(t, bans.restore(newFileBanStore(dir)))
+	bans.connect("cheater", "203.0.113.7")
+	kept := bans.ban("cheater", "speed hack", BanEvidence{MovementFlagged: true})
+	lifted := bans.ban("appealed", "mistake", BanEvidence{})
+	require.True(t, bans.unban("appealed"))
+
+	// A restarted server loads the same bans
+	reloaded := newBanList(clock)
+	require.NoError(t, reloaded.restore(newFileBanStore(dir)))
+
+	assert.Equal(t, []Ban{kept}, reloaded.list(), "only the standing ban bars players")
+	ban, banned := reloaded.banned("", "203.0.113.7")
+	assert.True(t, banned, "the banned address stays barred")
+	assert.Equal(t, kept.Reference, ban.Reference)
+
+	appeal, ok := reloaded.lookup(lifted.Reference)
+	require.True(t, ok, "a lifted ban's reference still resolves")
+	require.NotNil(t, appeal.LiftedAt)
+	assert.True(t, appeal.LiftedAt.Equal(now))
+
+	// Bans made after reloading are saved too
+	reloaded.ban("griefer", "", BanEvidence{})
+	again := newBanList(clock)
+	require.NoError(t, again.restore(newFileBanStore(dir)))
+	assert.Len(t, again.list(), 2)
+}
+
+func TestFileBanStoreWithoutFileHasNoBans(t *testing.T) {
+	bans, err := newFileBanStore(t.TempDir()).LoadBans()
+	require.NoError(t, err)
+	assert.Empty(t, bans)
+}
+
+func TestFileBanStoreRejectsCorruptFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, banFileName), []byte("{not json\n"), 0o644))
+
+	bans := newBanList(time.Now)
+	assert.Error(t, bans.restore(newFileBanStore(dir)))
+}
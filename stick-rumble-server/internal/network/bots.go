@@ -0,0 +1,77 @@
+package network
+
+import (
+	"context"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+// botThinkInterval is how often bots decide what to do, matching the rate
+// clients send input
+const botThinkInterval = time.Duration(game.ClientUpdateInterval) * time.Millisecond
+
+// botLoop runs the bots at botThinkInterval
+func (h *WebSocketHandler) botLoop(ctx context.Context) {
+	ticker := time.NewTicker(botThinkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.stepBots()
+		}
+	}
+}
+
+// stepBots applies each bot's decision through the same handlers as a human
+// client's messages. A bot whose match has ended, or whose room has no humans
+// left, leaves its room.
+func (h *WebSocketHandler) stepBots() {
+	for _, player := range h.bots.Players() {
+		room := h.roomManager.GetRoomByPlayerID(player.ID)
+		if room == nil || room.Match.IsEnded() || !h.hasHumans(room) {
+			h.releasePlayer(player)
+			h.bots.Remove(player.ID)
+			continue
+		}
+
+		action, ok := h.bots.Think(player.ID)
+		if !ok {
+			continue
+		}
+		if action.Respawn {
+			h.handlePlayerRespawnRequest(player.ID)
+		}
+
+		h.handleInputState(player.ID, map[string]interface{}{
+			"up":          action.Input.Up,
+			"down":        action.Input.Down,
+			"left":        action.Input.Left,
+			"right":       action.Input.Right,
+			"aimAngle":    action.Input.AimAngle,
+			"isSprinting": action.Input.IsSprinting,
+		}, 0)
+		if action.Reload {
+			h.handlePlayerReload(player.ID)
+		}
+		if action.Shoot {
+			h.handlePlayerShoot(player.ID, map[string]interface{}{
+				"aimAngle":        action.Input.AimAngle,
+				"clientTimestamp": float64(time.Now().UnixMilli()),
+			})
+		}
+	}
+}
+
+// hasHumans reports whether any player in room is not a bot
+func (h *WebSocketHandler) hasHumans(room *game.Room) bool {
+	for _, player := range room.GetPlayers() {
+		if !h.bots.IsBot(player.ID) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBotFillsStalledRoomAndLeavesWhenMatchEnds(t *testing.T) {
+	ts := newTestServerWithConfig(50 * time.Millisecond)
+	defer ts.Close()
+	ts.handler.roomManager.SetRoomSettings(game.RoomSettings{
+		MinHumanPlayers: 2,
+		BotFillAfter:    100 * time.Millisecond,
+		BotFillTarget:   2,
+	})
+
+	conn := ts.connectClient(t)
+	defer conn.Close()
+	_, _, err := readSessionStatus(t, conn, "match_ready", 2*time.Second)
+	require.NoError(t, err, "the bot fill timer starts the match")
+
+	bots := ts.handler.bots.Players()
+	require.Len(t, bots, 1)
+	botID := bots[0].ID
+	room := ts.handler.roomManager.GetRoomByPlayerID(botID)
+	require.NotNil(t, room)
+	assert.Equal(t, 2, room.PlayerCount())
+
+	// The bot's input reaches the game world like a client's
+	require.Eventually(t, func() bool {
+		state, exists := ts.handler.gameServer.GetPlayerState(botID)
+		return exists && state.AimAngle != 0
+	}, 2*time.Second, 10*time.Millisecond)
+
+	room.Match.EndMatch("test")
+	require.Eventually(t, func() bool {
+		return len(ts.handler.bots.Players()) == 0
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Nil(t, ts.handler.roomManager.GetRoomByPlayerID(botID))
+	_, exists := ts.handler.gameServer.GetPlayerState(botID)
+	assert.False(t, exists)
+}
@@ -4,11 +4,103 @@ import (
 	"encoding/json"
 	"log"
 	"math"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/mtomcal/stick-rumble-server/internal/game"
 )
 
+// broadcastBuffers holds the slices and maps broadcastPlayerStates reuses
+// from one 20Hz broadcast to the next instead of allocating them every time
+type broadcastBuffers struct {
+	mu          sync.Mutex
+	roomIndices map[string][]int // roomID -> indices into the broadcast's player states
+	waiting     []int
+	roomPlayers []game.PlayerStateSnapshot
+	frame       broadcastFrame
+	entities    entityTracker // What each room's previous frame carried
+}
+
+// broadcastFrame is what every client's snapshot or delta shares in one
+// room's broadcast, gathered once per room rather than once per client
+type broadcastFrame struct {
+	tick                  uint64 // Simulation tick the states were taken after
+	players               []game.PlayerStateSnapshot
+	projectiles           []game.ProjectileSnapshot
+	lastProcessedSequence map[string]uint64
+	correctedPlayers      []string
+}
+
+// projectileStateData is a projectile in state:snapshot and state:delta
+type projectileStateData struct {
+	ID          string       `json:"id"`
+	OwnerID     string       `json:"ownerId"`
+	Position    game.Vector2 `json:"position"`
+	Velocity    game.Vector2 `json:"velocity"`
+	LastUpdated uint64       `json:"lastUpdated"` // Projectiles move every tick, so always the frame's tick
+}
+
+// weaponCrateStateData is a weapon crate in state:snapshot
+type weaponCrateStateData struct {
+	ID          string       `json:"id"`
+	Position    game.Vector2 `json:"position"`
+	WeaponType  string       `json:"weaponType"`
+	IsAvailable bool         `json:"isAvailable"`
+}
+
+type projectileSpawnData struct {
+	ID         string                 `json:"id"`
+	OwnerID    string                 `json:"ownerId"`
+	WeaponType string                 `json:"weaponType"`
+	Position   game.Vector2           `json:"position"`
+	Velocity   game.Vector2           `json:"velocity"`
+	EffectID   string                 `json:"effectId,omitempty"`
+	Pellets    []projectilePelletData `json:"pellets,omitempty"`
+}
+
+// projectilePelletData is one pellet of a spread shot in projectile:spawn; the
+// pellets share the shot's owner, weapon, position and effect
+type projectilePelletData struct {
+	ID       string       `json:"id"`
+	Velocity game.Vector2 `json:"velocity"`
+}
+
+// projectileExplodeData is an explosive projectile blowing up in
+// projectile:explode
+type projectileExplodeData struct {
+	ProjectileID string       `json:"projectileId"`
+	OwnerID      string       `json:"ownerId"`
+	WeaponType   string       `json:"weaponType"`
+	Position     game.Vector2 `json:"position"`
+	Radius       float64      `json:"radius"`
+}
+
+// projectileStates converts projectile snapshots to their state message form
+func projectileStates(projectiles []game.ProjectileSnapshot, tick uint64) []projectileStateData {
+	data := make([]projectileStateData, len(projectiles))
+	for i, proj := range projectiles {
+		data[i] = projectileStateData{ID: proj.ID, OwnerID: proj.OwnerID, Position: proj.Position, Velocity: proj.Velocity, LastUpdated: tick}
+	}
+	return data
+}
+
+// sortedIDs returns a map's keys in order, so lists built from the game's
+// crate and pack maps go out in the same order every time
+func sortedIDs[V any](byID map[string]V) []string {
+	ids := make([]string, 0, len(byID))
+	for id := range byID {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// broadcastLog samples the errors of the 20 Hz state broadcast, which repeat
+// every update until their cause is fixed
+var broadcastLog = game.HotPathLogger("broadcast")
+
 // broadcastPlayerStates sends player position updates to all players using delta compression
 func (h *WebSocketHandler) broadcastPlayerStates(playerStates []game.PlayerStateSnapshot) {
 	if len(playerStates) == 0 {
@@ -20,144 +112,176 @@ func (h *WebSocketHandler) broadcastPlayerStates(playerStates []game.PlayerState
 		state := &playerStates[i]
 		if math.IsNaN(state.Position.X) || math.IsNaN(state.Position.Y) ||
 			math.IsInf(state.Position.X, 0) || math.IsInf(state.Position.Y, 0) {
-			log.Printf("ERROR: Player %s has invalid position: %+v", state.ID, state.Position)
+			broadcastLog.Printf("ERROR: Player %s has invalid position: %+v", state.ID, state.Position)
 		}
 		if math.IsNaN(state.Velocity.X) || math.IsNaN(state.Velocity.Y) ||
 			math.IsInf(state.Velocity.X, 0) || math.IsInf(state.Velocity.Y, 0) {
-			log.Printf("ERROR: Player %s has invalid velocity: %+v", state.ID, state.Velocity)
+			broadcastLog.Printf("ERROR: Player %s has invalid velocity: %+v", state.ID, state.Velocity)
 		}
 		if math.IsNaN(state.AimAngle) || math.IsInf(state.AimAngle, 0) {
-			log.Printf("ERROR: Player %s has invalid aimAngle: %v", state.ID, state.AimAngle)
+			broadcastLog.Printf("ERROR: Player %s has invalid aimAngle: %v", state.ID, state.AimAngle)
 			// Sanitize aim angle to prevent JSON marshal error
 			state.AimAngle = 0
 		}
 	}
 
+	buffers := &h.broadcastBuffers
+	buffers.mu.Lock()
+	defer buffers.mu.Unlock()
+
 	// Group player state indices by room to avoid broadcasting cross-room player data
 	// Using indices to avoid copying PlayerState which contains a mutex
-	roomPlayerIndices := make(map[string][]int)
-	waitingPlayerIndices := make([]int, 0)
+	if buffers.roomIndices == nil {
+		buffers.roomIndices = make(map[string][]int)
+	}
+	for roomID, indices := range buffers.roomIndices {
+		buffers.roomIndices[roomID] = indices[:0]
+	}
+	buffers.waiting = buffers.waiting[:0]
 
 	for i := range playerStates {
 		room := h.roomManager.GetRoomByPlayerID(playerStates[i].ID)
 		if room != nil {
-			roomPlayerIndices[room.ID] = append(roomPlayerIndices[room.ID], i)
+			buffers.roomIndices[room.ID] = append(buffers.roomIndices[room.ID], i)
 		} else {
 			// Player is waiting (not in a room yet)
-			waitingPlayerIndices = append(waitingPlayerIndices, i)
+			buffers.waiting = append(buffers.waiting, i)
 		}
 	}
 
+	// The tick and projectiles are world-wide, so every room's frame shares them
+	buffers.frame.tick = h.gameServer.Tick()
+	buffers.frame.projectiles = h.gameServer.GetActiveProjectiles()
+
 	// Broadcast to each room with delta compression (per-client basis)
-	for roomID, indices := range roomPlayerIndices {
-		// Build player slice for this room only
-		roomPlayers := make([]game.PlayerStateSnapshot, len(indices))
-		for j, idx := range indices {
-			roomPlayers[j] = playerStates[idx]
+	for roomID, indices := range buffers.roomIndices {
+		if len(indices) == 0 {
+			// The room had no states this time; forget it so the map stays small
+			delete(buffers.roomIndices, roomID)
+			continue
+		}
+		room := h.roomManager.GetRoom(roomID)
+		if room == nil {
+			continue
 		}
 
-		// Get room and broadcast to each player individually with delta compression
-		rooms := h.roomManager.GetAllRooms()
-		for _, room := range rooms {
-			if room.ID == roomID {
-				// Broadcast to each player in the room with per-client delta compression
-				for _, player := range room.GetPlayers() {
-					h.broadcastPlayerStatesToClient(player.ID, roomPlayers)
-				}
-				break
+		// Build player slice for this room only
+		buffers.roomPlayers = buffers.roomPlayers[:0]
+		for _, idx := range indices {
+			buffers.roomPlayers = append(buffers.roomPlayers, playerStates[idx])
+		}
+		h.fillBroadcastFrame(&buffers.frame, buffers.roomPlayers)
+		if removed := buffers.entities.update(roomID, &buffers.frame); len(removed) > 0 {
+			// Sent ahead of the state, so clients drop the entities before
+			// they would interpolate them
+			data := entityRemovedData{Tick: buffers.frame.tick, Entities: removed}
+			if err := h.publication.BroadcastEntityRemoved(room, data); err != nil {
+				broadcastLog.Printf("Error broadcasting entity:removed to room %s: %v", roomID, err)
 			}
 		}
+		if h.replays != nil {
+			h.replays.recordState(room, &buffers.frame)
+		}
+		if h.timelines != nil {
+			h.timelines.track(room)
+		}
+
+		// Broadcast to each player in the room with per-client delta compression
+		for _, player := range room.GetPlayers() {
+			h.broadcastPlayerStatesToClient(player.ID, &buffers.frame)
+		}
 	}
 
 	// Send to waiting players (each waiting player only sees their own state)
-	for _, idx := range waitingPlayerIndices {
-		// Create slice with single state
-		singlePlayerState := make([]game.PlayerStateSnapshot, 1)
-		singlePlayerState[0] = playerStates[idx]
-		h.broadcastPlayerStatesToClient(playerStates[idx].ID, singlePlayerState)
+	for _, idx := range buffers.waiting {
+		buffers.roomPlayers = append(buffers.roomPlayers[:0], playerStates[idx])
+		h.fillBroadcastFrame(&buffers.frame, buffers.roomPlayers)
+		h.broadcastPlayerStatesToClient(playerStates[idx].ID, &buffers.frame)
 	}
-}
-
-// broadcastPlayerStatesToClient sends player states to a specific client using delta compression
-func (h *WebSocketHandler) broadcastPlayerStatesToClient(clientID string, playerStates []game.PlayerStateSnapshot) {
-	// Check if we should send a full snapshot or a delta
-	shouldSnapshot := h.deltaTracker.ShouldSendSnapshot(clientID)
 
-	if shouldSnapshot {
-		// Send full snapshot
-		h.sendSnapshot(clientID, playerStates)
-		h.deltaTracker.UpdateLastSnapshot(clientID)
-		h.deltaTracker.UpdatePlayerState(clientID, playerStates)
-	} else {
-		// Send delta
-		h.sendDelta(clientID, playerStates)
-		h.deltaTracker.UpdatePlayerState(clientID, playerStates)
+	buffers.entities.closeRemovedRooms(h.roomManager)
+	if h.replays != nil {
+		h.replays.closeRemovedRooms(h.roomManager)
+	}
+	if h.timelines != nil {
+		h.timelines.closeRemovedRooms(h.roomManager)
 	}
 }
 
-// sendSnapshot sends a full state snapshot to a client
-func (h *WebSocketHandler) sendSnapshot(clientID string, playerStates []game.PlayerStateSnapshot) {
-	// Get active projectiles
-	projectiles := h.gameServer.GetActiveProjectiles()
-
-	// Get weapon crates
-	weaponCrates := h.gameServer.GetWeaponCrateManager().GetAllCrates()
-
-	// Build projectile snapshot data
-	projectileSnapshots := make([]map[string]interface{}, len(projectiles))
-	for i, proj := range projectiles {
-		projectileSnapshots[i] = map[string]interface{}{
-			"id":       proj.ID,
-			"ownerId":  proj.OwnerID,
-			"position": proj.Position,
-			"velocity": proj.Velocity,
-		}
-	}
-
-	// Build weapon crate snapshot data
-	crateSnapshots := make([]map[string]interface{}, 0, len(weaponCrates))
-	for _, crate := range weaponCrates {
-		crateSnapshots = append(crateSnapshots, map[string]interface{}{
-			"id":          crate.ID,
-			"position":    crate.Position,
-			"weaponType":  crate.WeaponType,
-			"isAvailable": crate.IsAvailable,
-		})
-	}
+// fillBroadcastFrame gathers the reconciliation data for a room's players
+// into frame, reusing its map and slice
+func (h *WebSocketHandler) fillBroadcastFrame(frame *broadcastFrame, playerStates []game.PlayerStateSnapshot) {
+	frame.players = playerStates
 
 	// Build lastProcessedSequence and correctedPlayers for reconciliation (Story 4.2)
-	lastProcessedSequence := make(map[string]interface{})
-	correctedPlayers := make([]string, 0)
+	if frame.lastProcessedSequence == nil {
+		frame.lastProcessedSequence = make(map[string]uint64, len(playerStates))
+	}
+	clear(frame.lastProcessedSequence)
+	frame.correctedPlayers = frame.correctedPlayers[:0]
 
 	for _, state := range playerStates {
 		if player, exists := h.gameServer.GetWorld().GetPlayer(state.ID); exists {
-			seq := player.GetInputSequence()
-			lastProcessedSequence[state.ID] = float64(seq)
+			frame.lastProcessedSequence[state.ID] = player.GetInputSequence()
 
 			// Check if this player needs correction (recent correction in stats)
 			stats := player.GetCorrectionStats()
 			if !stats.LastCorrectionAt.IsZero() && time.Since(stats.LastCorrectionAt) < 100*time.Millisecond {
-				correctedPlayers = append(correctedPlayers, state.ID)
+				frame.correctedPlayers = append(frame.correctedPlayers, state.ID)
 			}
 		}
 	}
+}
+
+// broadcastPlayerStatesToClient sends a room's frame to a specific client using delta compression
+func (h *WebSocketHandler) broadcastPlayerStatesToClient(clientID string, frame *broadcastFrame) {
+	// Check if we should send a full snapshot or a delta
+	shouldSnapshot := h.deltaTracker.ShouldSendSnapshot(clientID)
+
+	if shouldSnapshot {
+		// Send full snapshot
+		h.sendSnapshot(clientID, frame)
+		h.deltaTracker.UpdateLastSnapshot(clientID)
+		h.deltaTracker.UpdatePlayerState(clientID, frame.players)
+	} else if h.sendDelta(clientID, frame) {
+		// Send delta
+		h.deltaTracker.UpdatePlayerState(clientID, frame.players)
+	}
+}
+
+// sendSnapshot sends a full state snapshot to a client
+func (h *WebSocketHandler) sendSnapshot(clientID string, frame *broadcastFrame) {
+	// Build weapon crate snapshot data
+	weaponCrates := h.gameServer.GetWeaponCrateManager().GetAllCrates()
+	crates := make([]weaponCrateStateData, 0, len(weaponCrates))
+	for _, id := range sortedIDs(weaponCrates) {
+		crate := weaponCrates[id]
+		crates = append(crates, weaponCrateStateData{
+			ID:          crate.ID,
+			Position:    crate.Position,
+			WeaponType:  crate.WeaponType,
+			IsAvailable: crate.IsAvailable,
+		})
+	}
 
 	// Create state:snapshot message data
 	data := map[string]interface{}{
-		"players":               playerStates,
-		"projectiles":           projectileSnapshots,
-		"weaponCrates":          crateSnapshots,
-		"lastProcessedSequence": lastProcessedSequence,
+		"seq":                   h.deltaTracker.NextSequence(clientID),
+		"tick":                  frame.tick,
+		"players":               frame.players,
+		"projectiles":           projectileStates(frame.projectiles, frame.tick),
+		"weaponCrates":          crates,
+		"lastProcessedSequence": frame.lastProcessedSequence,
 	}
 
 	// Only include correctedPlayers if there are any
-	if len(correctedPlayers) > 0 {
-		data["correctedPlayers"] = correctedPlayers
+	if len(frame.correctedPlayers) > 0 {
+		data["correctedPlayers"] = frame.correctedPlayers
 	}
 
 	// Validate outgoing message schema (development mode only)
 	if err := h.validateOutgoingMessage("state:snapshot", data); err != nil {
-		log.Printf("Schema validation failed for state:snapshot: %v", err)
+		broadcastLog.Printf("Schema validation failed for state:snapshot: %v", err)
 	}
 
 	message := Message{
@@ -168,78 +292,55 @@ func (h *WebSocketHandler) sendSnapshot(clientID string, playerStates []game.Pla
 
 	msgBytes, err := json.Marshal(message)
 	if err != nil {
-		log.Printf("Error marshaling state:snapshot message: %v", err)
+		broadcastLog.Printf("Error marshaling state:snapshot message: %v", err)
 		return
 	}
 
 	// Send to client
 	h.roomManager.SendToPlayer(clientID, msgBytes)
-}
-
-// sendDelta sends only changed state to a client
-func (h *WebSocketHandler) sendDelta(clientID string, playerStates []game.PlayerStateSnapshot) {
-	// Compute player delta
-	playerDelta := h.deltaTracker.ComputePlayerDelta(clientID, playerStates)
 
-	// Compute projectile delta
-	projectiles := h.gameServer.GetActiveProjectiles()
-	projectilesAdded, projectilesRemoved := h.deltaTracker.ComputeProjectileDelta(clientID, projectiles)
-
-	// Build lastProcessedSequence and correctedPlayers for reconciliation (Story 4.2)
-	lastProcessedSequence := make(map[string]interface{})
-	correctedPlayers := make([]string, 0)
+	// Update projectile state tracking
+	h.deltaTracker.UpdateProjectileState(clientID, frame.projectiles)
+}
 
-	for _, state := range playerStates {
-		if player, exists := h.gameServer.GetWorld().GetPlayer(state.ID); exists {
-			seq := player.GetInputSequence()
-			lastProcessedSequence[state.ID] = float64(seq)
-
-			// Check if this player needs correction (recent correction in stats)
-			stats := player.GetCorrectionStats()
-			if !stats.LastCorrectionAt.IsZero() && time.Since(stats.LastCorrectionAt) < 100*time.Millisecond {
-				correctedPlayers = append(correctedPlayers, state.ID)
-			}
-		}
-	}
+// sendDelta sends only the state that changed since the client's baseline.
+// Returns false if nothing changed and no message was sent.
+func (h *WebSocketHandler) sendDelta(clientID string, frame *broadcastFrame) bool {
+	// Compute player and projectile deltas
+	playerDelta := h.deltaTracker.ComputePlayerDelta(clientID, frame.players)
+	projectilesAdded, projectilesRemoved := h.deltaTracker.ComputeProjectileDelta(clientID, frame.projectiles)
 
 	// If nothing changed, don't send a message
 	if len(playerDelta) == 0 && len(projectilesAdded) == 0 && len(projectilesRemoved) == 0 {
-		return
+		return false
 	}
 
 	// Build delta message data
-	data := make(map[string]interface{})
+	data := map[string]interface{}{
+		"tick":                  frame.tick,
+		"lastProcessedSequence": frame.lastProcessedSequence,
+	}
+	if baseSeq := h.deltaTracker.BaseSequence(clientID); baseSeq > 0 {
+		data["baseSeq"] = baseSeq
+	}
+	data["seq"] = h.deltaTracker.NextSequence(clientID)
 
 	if len(playerDelta) > 0 {
 		data["players"] = playerDelta
 	}
-
 	if len(projectilesAdded) > 0 {
-		projSnapshots := make([]map[string]interface{}, len(projectilesAdded))
-		for i, proj := range projectilesAdded {
-			projSnapshots[i] = map[string]interface{}{
-				"id":       proj.ID,
-				"ownerId":  proj.OwnerID,
-				"position": proj.Position,
-				"velocity": proj.Velocity,
-			}
-		}
-		data["projectilesAdded"] = projSnapshots
+		data["projectilesAdded"] = projectileStates(projectilesAdded, frame.tick)
 	}
-
 	if len(projectilesRemoved) > 0 {
 		data["projectilesRemoved"] = projectilesRemoved
 	}
-
-	// Add reconciliation data
-	data["lastProcessedSequence"] = lastProcessedSequence
-	if len(correctedPlayers) > 0 {
-		data["correctedPlayers"] = correctedPlayers
+	if len(frame.correctedPlayers) > 0 {
+		data["correctedPlayers"] = frame.correctedPlayers
 	}
 
 	// Validate outgoing message schema (development mode only)
 	if err := h.validateOutgoingMessage("state:delta", data); err != nil {
-		log.Printf("Schema validation failed for state:delta: %v", err)
+		broadcastLog.Printf("Schema validation failed for state:delta: %v", err)
 	}
 
 	message := Message{
@@ -250,29 +351,36 @@ func (h *WebSocketHandler) sendDelta(clientID string, playerStates []game.Player
 
 	msgBytes, err := json.Marshal(message)
 	if err != nil {
-		log.Printf("Error marshaling state:delta message: %v", err)
-		return
+		broadcastLog.Printf("Error marshaling state:delta message: %v", err)
+		return false
 	}
 
 	// Send to client
 	h.roomManager.SendToPlayer(clientID, msgBytes)
 
 	// Update projectile state tracking
-	h.deltaTracker.UpdateProjectileState(clientID, projectiles)
+	h.deltaTracker.UpdateProjectileState(clientID, frame.projectiles)
+	return true
 }
 
-// broadcastProjectileSpawn sends projectile spawn event to all clients
-func (h *WebSocketHandler) broadcastProjectileSpawn(proj *game.Projectile) {
+// broadcastProjectileSpawn sends projectile spawn event to all clients. The
+// pellets of a spread shot go out in the same message.
+func (h *WebSocketHandler) broadcastProjectileSpawn(proj *game.Projectile, pellets []game.Projectile) {
 	if proj == nil {
 		return
 	}
 
 	// Create projectile:spawn message data
-	data := map[string]interface{}{
-		"id":       proj.ID,
-		"ownerId":  proj.OwnerID,
-		"position": proj.Position,
-		"velocity": proj.Velocity,
+	data := projectileSpawnData{
+		ID:         proj.ID,
+		OwnerID:    proj.OwnerID,
+		WeaponType: proj.WeaponType,
+		Position:   proj.Position,
+		Velocity:   proj.Velocity,
+		EffectID:   proj.EffectID,
+	}
+	for _, pellet := range pellets {
+		data.Pellets = append(data.Pellets, projectilePelletData{ID: pellet.ID, Velocity: pellet.Velocity})
 	}
 
 	// Validate outgoing message schema (development mode only)
@@ -296,11 +404,58 @@ func (h *WebSocketHandler) broadcastProjectileSpawn(proj *game.Projectile) {
 	h.roomManager.BroadcastToAll(msgBytes)
 }
 
+// broadcastProjectileExplode tells clients where an explosive projectile blew
+// up and how far the blast reached. It goes to everyone projectile:spawn went
+// to, so every client can replace the rocket with the explosion.
+func (h *WebSocketHandler) broadcastProjectileExplode(event game.ProjectileExplodedEvent) {
+	data := projectileExplodeData{
+		ProjectileID: event.ProjectileID,
+		OwnerID:      event.OwnerID,
+		WeaponType:   event.WeaponType,
+		Position:     event.Position,
+		Radius:       event.Radius,
+	}
+
+	// Validate outgoing message schema (development mode only)
+	if err := h.validateOutgoingMessage("projectile:explode", data); err != nil {
+		log.Printf("Schema validation failed for projectile:explode: %v", err)
+	}
+
+	message := Message{
+		Type:      "projectile:explode",
+		Timestamp: time.Now().UnixMilli(),
+		Data:      data,
+	}
+
+	msgBytes, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("Error marshaling projectile:explode message: %v", err)
+		return
+	}
+
+	h.roomManager.BroadcastToAll(msgBytes)
+}
+
 // emitMatchTimers evaluates authoritative room timer state and publishes resulting events.
 func (h *WebSocketHandler) emitMatchTimers() {
+	now := time.Now()
+	h.runScriptActions()
+	for _, result := range h.sessionFlow.FillStalledRooms(now) {
+		h.applySessionResult(result)
+	}
+	h.roomManager.TickReadyChecks(now)
+	for _, result := range h.sessionFlow.TickRematchVotes(now) {
+		h.applyRematchResult(result)
+	}
+	h.admitQueuedPlayers()
+
 	rooms := h.roomManager.GetAllRooms()
 
 	for _, room := range rooms {
+		// Rooms kept alive with nobody connected have no one to update
+		if room.IsEmpty() {
+			continue
+		}
 		h.matchEvents.EmitRoomTick(room.ID, room.Match, h.gameServer.GetWorld())
 	}
 }
@@ -346,14 +501,22 @@ func (h *WebSocketHandler) sendWeaponState(playerID string) {
 	}
 
 	current, max := ws.GetAmmoInfo()
+	stamina := 0
+	if player, exists := h.gameServer.GetWorld().GetPlayer(playerID); exists {
+		stamina = int(player.Stamina())
+	}
 
 	if err := h.publication.SendWeaponState(playerID, weaponStateData{
 		CurrentAmmo: current,
 		MaxAmmo:     max,
-		IsReloading: ws.IsReloading,
+		IsReloading: ws.Reloading(),
 		CanShoot:    ws.CanShoot(),
 		WeaponType:  ws.Weapon.Name,
 		IsMelee:     ws.Weapon.IsMelee(),
+		Abilities:   h.gameServer.AbilityStates(playerID),
+		Stats:       ws.Weapon.Stats(),
+		Stamina:     stamina,
+		MaxStamina:  game.MaxStamina,
 	}); err != nil {
 		log.Printf("Error building weapon:state message: %v", err)
 	}
@@ -388,10 +551,8 @@ func (h *WebSocketHandler) sendShootFailed(playerID string, reason string) {
 	if room != nil {
 		player := room.GetPlayer(playerID)
 		if player != nil {
-			select {
-			case player.SendChan <- msgBytes:
-			default:
-				log.Printf("Failed to send shoot:failed to player %s (channel full)", playerID)
+			if err := player.Send(msgBytes); err != nil {
+				log.Printf("Failed to send shoot:failed to player %s (%v)", playerID, err)
 			}
 		}
 	} else {
@@ -411,16 +572,12 @@ func (h *WebSocketHandler) broadcastMatchEnded(room *game.Room, world *game.Worl
 	winners := room.Match.GetWinnerSummaries(world)
 	finalScores := room.Match.GetFinalScores(world)
 
-	if err := h.publication.BroadcastMatchEnded(room, matchEndedData{
+	h.publishMatchEnded(room, matchEndedData{
 		Winners:     winners,
 		FinalScores: finalScores,
 		Reason:      room.Match.EndReason,
-	}); err != nil {
-		log.Printf("Error building match:ended message: %v", err)
-		return
-	}
-
-	log.Printf("Match ended in room %s - reason: %s, winners: %v", room.ID, room.Match.EndReason, winners)
+		Scoreboard:  room.Match.MatchStats(world),
+	})
 }
 
 func (h *WebSocketHandler) broadcastMatchEndedEvent(event game.MatchEndedEvent) {
@@ -429,16 +586,45 @@ func (h *WebSocketHandler) broadcastMatchEndedEvent(event game.MatchEndedEvent)
 		return
 	}
 
-	if err := h.publication.BroadcastMatchEnded(room, matchEndedData{
+	h.publishMatchEnded(room, matchEndedData{
 		Winners:     event.Winners,
 		FinalScores: event.FinalScores,
 		Reason:      event.Reason,
-	}); err != nil {
+		Scoreboard:  event.Scoreboard,
+	})
+}
+
+// publishMatchEnded adds the match's setup and the server build to a result,
+// so it can be reproduced, then announces it to the room and saves its record
+func (h *WebSocketHandler) publishMatchEnded(room *game.Room, data matchEndedData) {
+	setup := room.MatchSetup()
+	data.Settings = setup.Settings
+	data.MapID = setup.MapID
+	data.Mutators = setup.Mutators
+	data.Seed = setup.Seed
+	data.Build = h.buildInfo()
+
+	if err := h.publication.BroadcastMatchEnded(room, data); err != nil {
 		log.Printf("Error building match:ended message: %v", err)
 		return
 	}
 
-	log.Printf("Match ended in room %s - reason: %s, winners: %v", event.RoomID, event.Reason, event.Winners)
+	log.Printf("Match ended in room %s - reason: %s, winners: %v, seed: %d", room.ID, data.Reason, data.Winners, data.Seed)
+	if h.replays != nil {
+		h.replays.finish(room)
+	}
+	if h.timelines != nil {
+		h.timelines.finish(room, data)
+	}
+	if h.matchRecords != nil {
+		if err := h.matchRecords.SaveMatchRecord(newMatchRecord(room, data, time.Now())); err != nil {
+			log.Printf("Error saving match record for room %s: %v", room.ID, err)
+		}
+	}
+	if h.leaderboard != nil {
+		h.leaderboard.requestRefresh()
+	}
+	h.roomManager.RecordMatchEnded(room)
 }
 
 // broadcastWeaponPickup broadcasts weapon pickup event to all clients
@@ -509,7 +695,8 @@ func (h *WebSocketHandler) sendWeaponSpawns(playerID string) {
 
 	// Build crates array for the message
 	crates := make([]map[string]interface{}, 0, len(allCrates))
-	for _, crate := range allCrates {
+	for _, id := range sortedIDs(allCrates) {
+		crate := allCrates[id]
 		crateData := map[string]interface{}{
 			"id":          crate.ID,
 			"position":    map[string]interface{}{"x": crate.Position.X, "y": crate.Position.Y},
@@ -547,11 +734,8 @@ func (h *WebSocketHandler) sendWeaponSpawns(playerID string) {
 	if room != nil {
 		player := room.GetPlayer(playerID)
 		if player != nil {
-			select {
-			case player.SendChan <- msgBytes:
-				// Message sent successfully
-			default:
-				log.Printf("Failed to send weapon:spawned to player %s (channel full)", playerID)
+			if err := player.Send(msgBytes); err != nil {
+				log.Printf("Failed to send weapon:spawned to player %s (%v)", playerID, err)
 			}
 		}
 	} else {
@@ -560,6 +744,24 @@ func (h *WebSocketHandler) sendWeaponSpawns(playerID string) {
 	}
 }
 
+// sendWorldSync sends a player the authoritative scores of its room's match
+// once it has started; before then there are no scores to miss
+func (h *WebSocketHandler) sendWorldSync(player *game.Player, room *game.Room) {
+	if room == nil || room.Match == nil || room.Match.GetState() == game.MatchStateWaiting {
+		return
+	}
+	if err := h.publication.SendWorldSync(player, room.Match, h.gameServer.GetWorld()); err != nil {
+		log.Printf("Error sending world:sync to player %s: %v", player.ID, err)
+	}
+}
+
+// sendMapLoad sends a player the map geometry of the game world
+func (h *WebSocketHandler) sendMapLoad(player *game.Player) {
+	if err := h.publication.SendMapLoad(player, h.gameServer.GetWorld().GetMapConfig()); err != nil {
+		log.Printf("Error sending map:load to player %s: %v", player.ID, err)
+	}
+}
+
 // broadcastRollStart broadcasts roll start event to all players in the room
 func (h *WebSocketHandler) broadcastRollStart(playerID string, direction game.Vector2, rollStartTime time.Time) {
 	// Create roll:start message data
@@ -593,14 +795,18 @@ func (h *WebSocketHandler) broadcastRollStart(playerID string, direction game.Ve
 	}
 }
 
-// broadcastMeleeHit broadcasts melee hit event to all players in the room
-func (h *WebSocketHandler) broadcastMeleeHit(attackerID string, victimIDs []string, knockbackApplied bool) {
+// broadcastMeleeHit broadcasts melee hit event to all players in the room.
+// combo is the hit of the weapon's combo the swing landed as, 0 for a miss.
+func (h *WebSocketHandler) broadcastMeleeHit(attackerID string, victimIDs []string, knockbackApplied bool, combo int) {
 	// Create melee:hit message data
 	data := map[string]interface{}{
 		"attackerId":       attackerID,
 		"victims":          victimIDs,
 		"knockbackApplied": knockbackApplied,
 	}
+	if combo > 0 {
+		data["combo"] = combo
+	}
 
 	// Validate outgoing message schema (development mode only)
 	if err := h.validateOutgoingMessage("melee:hit", data); err != nil {
@@ -622,7 +828,7 @@ func (h *WebSocketHandler) broadcastMeleeHit(attackerID string, victimIDs []stri
 	// Broadcast to all players in the room
 	room := h.roomManager.GetRoomByPlayerID(attackerID)
 	if room != nil {
-		room.Broadcast(msgBytes, "")
+		room.BroadcastType("melee:hit", msgBytes, "")
 	}
 }
 
@@ -630,34 +836,31 @@ func (h *WebSocketHandler) broadcastMeleeHit(attackerID string, victimIDs []stri
 func (h *WebSocketHandler) broadcastPlayerDamaged(attackerID, victimID string, damage, newHealth int) {
 	room := h.roomManager.GetRoomByPlayerID(victimID)
 	if room != nil {
+		newShield := 0
+		if victim, exists := h.gameServer.GetWorld().GetPlayer(victimID); exists {
+			newShield = victim.GetShield()
+		}
 		if err := h.publication.BroadcastPlayerDamaged(room, playerDamagedData{
 			VictimID:     victimID,
 			AttackerID:   attackerID,
 			Damage:       damage,
 			NewHealth:    newHealth,
+			NewShield:    newShield,
 			ProjectileID: "melee",
 		}); err != nil {
 			log.Printf("Error building player:damaged message: %v", err)
 		}
+		if room.Match != nil {
+			room.Match.RecordDamage(attackerID, victimID, damage)
+		}
 	}
 }
 
 // processMeleeKill handles death processing for melee kills
 func (h *WebSocketHandler) processMeleeKill(attackerID, victimID string) {
-	// Mark player as dead
-	h.gameServer.MarkPlayerDead(victimID)
-
-	// Get the actual player pointers to update stats
+	// Mark player as dead and credit the kill
+	streak := h.gameServer.CreditMeleeKill(attackerID, victimID)
 	attacker, attackerExists := h.gameServer.GetWorld().GetPlayer(attackerID)
-	if attackerExists && attacker != nil {
-		attacker.IncrementKills()
-		attacker.AddXP(game.KillXPReward)
-	}
-
-	victim, victimExists := h.gameServer.GetWorld().GetPlayer(victimID)
-	if victimExists && victim != nil {
-		victim.IncrementDeaths()
-	}
 
 	room := h.roomManager.GetRoomByPlayerID(victimID)
 	if room != nil {
@@ -682,9 +885,10 @@ func (h *WebSocketHandler) processMeleeKill(attackerID, victimID string) {
 			log.Printf("Error building player:kill_credit message: %v", err)
 			return
 		}
+		h.broadcastKillstreak(room, attackerID, streak)
 
-		// Track kill in match and check win conditions
-		room.Match.AddKill(attackerID)
+		// Track kill and assists in match and check win conditions
+		h.recordKill(room, attackerID, victimID)
 
 		// Check if kill target reached
 		if room.Match.CheckKillTarget() {
@@ -695,6 +899,55 @@ func (h *WebSocketHandler) processMeleeKill(attackerID, victimID string) {
 	}
 }
 
+// broadcastKillstreak announces a kill that extended the killer's streak so
+// clients can show a banner
+func (h *WebSocketHandler) broadcastKillstreak(room *game.Room, killerID string, streak game.KillStreak) {
+	if !streak.Announced() {
+		return
+	}
+	if err := h.publication.BroadcastPlayerKillstreak(room, playerKillstreakData{
+		PlayerID:  killerID,
+		Streak:    streak.Streak,
+		MultiKill: streak.MultiKill,
+		BonusXP:   streak.BonusXP,
+	}); err != nil {
+		log.Printf("Error building player:killstreak message: %v", err)
+	}
+}
+
+// recordKill tracks the kill and its assists in the match, and the killer's
+// weapon and time to kill in the match timeline, then gives each
+// assisting player assist XP and announces it with player:assist_credit. In a
+// practice room the kill also counts toward the bots' difficulty.
+func (h *WebSocketHandler) recordKill(room *game.Room, killerID, victimID string) {
+	h.recordPracticeFight(room, killerID, victimID)
+	if h.timelines != nil {
+		// Read before RecordKill starts the victim's next life
+		timeToKill, _ := room.Match.TimeToKill(killerID, victimID)
+		weaponType := ""
+		if killer, ok := h.gameServer.GetPlayerState(killerID); ok {
+			weaponType = strings.ToLower(killer.WeaponType)
+		}
+		h.timelines.recordKillWeapon(room, killerID, victimID, weaponType, timeToKill)
+	}
+	for _, assist := range room.Match.RecordKill(killerID, victimID) {
+		xp, credited := h.gameServer.CreditAssist(assist.PlayerID)
+		if !credited {
+			continue
+		}
+		if err := h.publication.BroadcastPlayerAssistCredit(room, playerAssistCreditData{
+			PlayerID:      assist.PlayerID,
+			VictimID:      victimID,
+			KillerID:      killerID,
+			Damage:        assist.Damage,
+			PlayerAssists: assist.Assists,
+			PlayerXP:      xp,
+		}); err != nil {
+			log.Printf("Error building player:assist_credit message: %v", err)
+		}
+	}
+}
+
 // broadcastRollEnd broadcasts roll end event to all players in the room
 func (h *WebSocketHandler) broadcastRollEnd(playerID string, reason string) {
 	// Create roll:end message data
@@ -1,6 +1,10 @@
 package network
 
 import (
+	"fmt"
+	"io"
+	"log"
+	"os"
 	"testing"
 	"time"
 
@@ -154,6 +158,73 @@ func TestBroadcastProjectileSpawn(t *testing.T) {
 	conn2.Close()
 }
 
+func TestBroadcastProjectileSpawnBatchesShotgunPellets(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn1, conn2 := ts.connectTwoClients(t)
+	defer conn1.Close()
+	defer conn2.Close()
+
+	player1ID := consumeRoomJoinedAndGetPlayerID(t, conn1)
+	_ = consumeRoomJoinedAndGetPlayerID(t, conn2)
+	ts.handler.gameServer.SetWeaponState(player1ID, game.NewWeaponState(game.NewShotgun()))
+
+	sendShootMessage(t, conn1, 0)
+
+	msg, err := readMessageOfType(t, conn2, "projectile:spawn", 2*time.Second)
+	require.NoError(t, err, "Should receive projectile:spawn")
+	data := msg.Data.(map[string]interface{})
+	assert.Equal(t, "Shotgun", data["weaponType"])
+
+	pellets, ok := data["pellets"].([]interface{})
+	require.True(t, ok, "a shotgun shot carries its pellets")
+	require.Len(t, pellets, game.ShotgunPelletCount)
+	assert.Equal(t, data["id"], pellets[0].(map[string]interface{})["id"], "the first pellet is the top-level projectile")
+
+	ids := make(map[interface{}]bool)
+	for _, pellet := range pellets {
+		ids[pellet.(map[string]interface{})["id"]] = true
+	}
+	assert.Len(t, ids, game.ShotgunPelletCount, "every pellet has its own id")
+
+	_, err = readMessageOfType(t, conn2, "projectile:spawn", 300*time.Millisecond)
+	assert.Error(t, err, "one shot is one projectile:spawn")
+}
+
+func TestBroadcastProjectileSpawnCarriesOwnedTrailEffect(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn1, conn2 := ts.connectTwoClients(t)
+
+	player1ID := consumeRoomJoinedAndGetPlayerID(t, conn1)
+	_ = consumeRoomJoinedAndGetPlayerID(t, conn2)
+
+	// Unowned and unknown effects are rejected without firing
+	sendShootMessageWithEffect(t, conn1, 1.57, game.TrailEffectEmber)
+	msg, err := readMessageOfType(t, conn1, "shoot:failed", 2*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, game.ShootFailedEffectNotOwned, msg.Data.(map[string]interface{})["reason"])
+
+	sendShootMessageWithEffect(t, conn1, 1.57, "rainbow")
+	msg, err = readMessageOfType(t, conn1, "shoot:failed", 2*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, game.ShootFailedUnknownEffect, msg.Data.(map[string]interface{})["reason"])
+
+	require.NoError(t, ts.handler.gameServer.GetCosmeticInventory().Grant(player1ID, game.TrailEffectEmber))
+	sendShootMessageWithEffect(t, conn1, 1.57, game.TrailEffectEmber)
+
+	msg, err = readMessageOfType(t, conn2, "projectile:spawn", 2*time.Second)
+	require.NoError(t, err, "Should receive projectile:spawn")
+	data := msg.Data.(map[string]interface{})
+	assert.Equal(t, player1ID, data["ownerId"])
+	assert.Equal(t, game.TrailEffectEmber, data["effectId"])
+
+	conn1.Close()
+	conn2.Close()
+}
+
 // TestBroadcastProjectileDestroy is removed - projectile destruction is tested through shooting integration
 
 func TestBroadcastPlayerDamaged(t *testing.T) {
@@ -298,6 +369,18 @@ func TestBroadcastWeaponState(t *testing.T) {
 	assert.NotNil(t, data["weaponType"])
 	assert.NotNil(t, data["isMelee"])
 
+	abilities, ok := data["abilities"].([]interface{})
+	require.True(t, ok, "weapon:state carries ability cooldowns")
+	require.Len(t, abilities, 2)
+	roll := abilities[1].(map[string]interface{})
+	assert.Equal(t, game.CooldownRoll, roll["ability"])
+	assert.Equal(t, 1.0, roll["charges"])
+
+	stats, ok := data["stats"].(map[string]interface{})
+	require.True(t, ok, "weapon:state carries the weapon's balance stats")
+	assert.Equal(t, float64(game.NewPistol().Damage), stats["damage"])
+	assert.Equal(t, float64(game.NewPistol().ReloadTime.Milliseconds()), stats["reloadTimeMs"])
+
 	currentAmmo, ok := data["currentAmmo"].(float64)
 	require.True(t, ok)
 	assert.GreaterOrEqual(t, currentAmmo, 0.0)
@@ -601,3 +684,116 @@ func TestSendWeaponSpawnsMessage(t *testing.T) {
 	require.True(t, ok)
 	assert.NotNil(t, data["crates"], "Should have crates field")
 }
+
+func TestLateJoinerReceivesWorldSync(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn1 := ts.connectRawClient(t)
+	defer conn1.Close()
+	conn2 := ts.connectRawClient(t)
+	defer conn2.Close()
+	sendHelloMessage(t, conn1, "Alice", "code", "LATE")
+	sendHelloMessage(t, conn2, "Bob", "code", "LATE")
+	_, status1, err := readSessionStatus(t, conn1, "match_ready", 2*time.Second)
+	require.NoError(t, err)
+	_, status2, err := readSessionStatus(t, conn2, "match_ready", 2*time.Second)
+	require.NoError(t, err)
+	player1ID := status1["playerId"].(string)
+	player2ID := status2["playerId"].(string)
+
+	room := ts.handler.roomManager.GetRoomByPlayerID(player1ID)
+	require.NotNil(t, room)
+	room.Match.Start()
+
+	ts.handler.gameServer.DamagePlayer(player2ID, game.PlayerMaxHealth)
+	ts.handler.onHit(game.HitEvent{
+		VictimID:     player2ID,
+		AttackerID:   player1ID,
+		ProjectileID: "killing-blow",
+	})
+	_, err = readMessageOfType(t, conn1, "player:kill_credit", 2*time.Second)
+	require.NoError(t, err)
+
+	// A player joining now missed the kill events
+	conn3 := ts.connectRawClient(t)
+	defer conn3.Close()
+	sendHelloMessage(t, conn3, "Carol", "code", "LATE")
+	msg, err := readMessageOfType(t, conn3, "world:sync", 2*time.Second)
+	require.NoError(t, err, "a late joiner should receive world:sync")
+
+	data, ok := msg.Data.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "active", data["matchState"])
+	playerKills, ok := data["playerKills"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, 1.0, playerKills[player1ID])
+	assert.Equal(t, 0.0, playerKills[player2ID])
+
+	scoreboard, ok := data["scoreboard"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, scoreboard, 3)
+	leader := scoreboard[0].(map[string]interface{})
+	assert.Equal(t, player1ID, leader["playerId"])
+	assert.Equal(t, "Alice", leader["displayName"])
+	assert.Equal(t, 1.0, leader["kills"])
+}
+
+func TestMatchStartSendsMapLoad(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn1, conn2 := ts.connectTwoClients(t)
+	defer conn1.Close()
+	defer conn2.Close()
+
+	msg, err := readMessageOfType(t, conn1, "map:load", 2*time.Second)
+	require.NoError(t, err, "Should receive map:load")
+
+	data, ok := msg.Data.(map[string]interface{})
+	require.True(t, ok)
+	mapConfig := game.MustDefaultMapConfig()
+	assert.Equal(t, mapConfig.ID, data["mapId"])
+	assert.Equal(t, mapConfig.Width, data["width"])
+	assert.Equal(t, mapConfig.Height, data["height"])
+
+	obstacles, ok := data["obstacles"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, obstacles, len(mapConfig.Obstacles))
+	first := obstacles[0].(map[string]interface{})
+	assert.Equal(t, mapConfig.Obstacles[0].ID, first["id"])
+	assert.Equal(t, mapConfig.Obstacles[0].BlocksProjectiles, first["blocksProjectiles"])
+	assert.NotContains(t, data, "visualAcceptanceViewpoints", "authoring-only data stays on the server")
+}
+
+// BenchmarkBroadcastPlayerStates broadcasts one 20Hz update to a room of
+// eight players with projectiles in flight
+func BenchmarkBroadcastPlayerStates(b *testing.B) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	handler := NewWebSocketHandler()
+	players := make([]*game.Player, 8)
+	for i := range players {
+		players[i] = game.NewPlayer(fmt.Sprintf("bench-%d", i), make(chan []byte, 64))
+		handler.roomManager.AddCodePlayer(players[i], "BENCH")
+		handler.gameServer.AddPlayer(players[i].ID)
+		handler.gameServer.PlayerShoot(players[i].ID, float64(i), time.Now().UnixMilli())
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		states := handler.gameServer.GetAllPlayerStates()
+		// Move everyone so each client gets a delta
+		for j := range states {
+			states[j].Position.X += float64(i%2) + 1
+		}
+		handler.broadcastPlayerStates(states)
+
+		for _, player := range players {
+			for len(player.SendChan) > 0 {
+				<-player.SendChan
+			}
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const (
+	// maxChaosDelay bounds the delay chaos may add to a single message
+	maxChaosDelay = 5 * time.Second
+
+	// chaosReorderHold is how long a message held back for reordering waits
+	// for a later message to overtake it before it is sent anyway
+	chaosReorderHold = 250 * time.Millisecond
+)
+
+// ErrChaosDisabled is returned when chaos is configured on a production server.
+var ErrChaosDisabled = errors.New("chaos injection is only available outside production")
+
+// ChaosConfig describes the faults injected into one connection's outgoing
+// messages. Each percentage is rolled independently per message.
+type ChaosConfig struct {
+	DropPercent    int `json:"dropPercent"`    // Messages silently dropped
+	DelayPercent   int `json:"delayPercent"`   // Messages sent late
+	DelayMs        int `json:"delayMs"`        // Added delay for delayed messages
+	ReorderPercent int `json:"reorderPercent"` // Messages held back until the next one has been sent
+}
+
+// Validate reports whether every percentage is within 0-100 and the delay is
+// within maxChaosDelay.
+func (c ChaosConfig) Validate() error {
+	for name, percent := range map[string]int{
+		"dropPercent":    c.DropPercent,
+		"delayPercent":   c.DelayPercent,
+		"reorderPercent": c.ReorderPercent,
+	} {
+		if percent < 0 || percent > 100 {
+			return fmt.Errorf("%s must be between 0 and 100, got %d", name, percent)
+		}
+	}
+	if c.DelayMs < 0 || time.Duration(c.DelayMs)*time.Millisecond > maxChaosDelay {
+		return fmt.Errorf("delayMs must be between 0 and %d, got %d", maxChaosDelay.Milliseconds(), c.DelayMs)
+	}
+	return nil
+}
+
+// ChaosStats counts the faults injected into a connection so far.
+type ChaosStats struct {
+	Dropped   int `json:"dropped"`
+	Delayed   int `json:"delayed"`
+	Reordered int `json:"reordered"`
+}
+
+type connectionChaos struct {
+	config   ChaosConfig
+	stats    ChaosStats
+	held     []byte
+	holdDone *time.Timer
+}
+
+// chaosInjector applies per-connection ChaosConfigs to outgoing frames. Unlike
+// the process-wide NetworkSimulator it is toggled at runtime for individual
+// players, and it is refused entirely on production servers.
+type chaosInjector struct {
+	enabled     bool
+	rng         *rand.Rand
+	connections map[string]*connectionChaos
+	mu          sync.Mutex
+}
+
+func newChaosInjector(enabled bool, rng *rand.Rand) *chaosInjector {
+	return &chaosInjector{
+		enabled:     enabled,
+		rng:         rng,
+		connections: make(map[string]*connectionChaos),
+	}
+}
+
+// set starts or replaces chaos on a player's connection
+func (c *chaosInjector) set(playerID string, config ChaosConfig) error {
+	if !c.enabled {
+		return ErrChaosDisabled
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if existing, ok := c.connections[playerID]; ok {
+		existing.config = config
+		return nil
+	}
+	c.connections[playerID] = &connectionChaos{config: config}
+	return nil
+}
+
+// clear stops chaos on a player's connection; a message held for reordering
+// is dropped
+func (c *chaosInjector) clear(playerID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if existing, ok := c.connections[playerID]; ok && existing.holdDone != nil {
+		existing.holdDone.Stop()
+	}
+	delete(c.connections, playerID)
+}
+
+// get returns a player's chaos config and the faults injected so far
+func (c *chaosInjector) get(playerID string) (ChaosConfig, ChaosStats, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	existing, ok := c.connections[playerID]
+	if !ok {
+		return ChaosConfig{}, ChaosStats{}, false
+	}
+	return existing.config, existing.stats, true
+}
+
+// deliver passes frame to write, first applying the player's chaos config.
+// Delayed and reordered frames are written from another goroutine, so write
+// must be safe for concurrent use.
+func (c *chaosInjector) deliver(playerID string, frame []byte, write func([]byte)) {
+	c.mu.Lock()
+	conn, ok := c.connections[playerID]
+	if !ok {
+		c.mu.Unlock()
+		write(frame)
+		return
+	}
+
+	if c.rng.Intn(100) < conn.config.DropPercent {
+		conn.stats.Dropped++
+		c.mu.Unlock()
+		return
+	}
+
+	var delay time.Duration
+	if c.rng.Intn(100) < conn.config.DelayPercent {
+		delay = time.Duration(conn.config.DelayMs) * time.Millisecond
+		conn.stats.Delayed++
+	}
+
+	if conn.held == nil && c.rng.Intn(100) < conn.config.ReorderPercent {
+		conn.stats.Reordered++
+		conn.held = frame
+		conn.holdDone = time.AfterFunc(chaosReorderHold, func() {
+			c.mu.Lock()
+			held := conn.held
+			conn.held = nil
+			c.mu.Unlock()
+			if held != nil {
+				write(held)
+			}
+		})
+		c.mu.Unlock()
+		return
+	}
+
+	held := conn.held
+	conn.held = nil
+	if conn.holdDone != nil {
+		conn.holdDone.Stop()
+		conn.holdDone = nil
+	}
+	c.mu.Unlock()
+
+	send := func() {
+		write(frame)
+		if held != nil {
+			write(held)
+		}
+	}
+	if delay > 0 {
+		time.AfterFunc(delay, send)
+		return
+	}
+	send()
+}
+
+// SetConnectionChaos injects drops, delays and reordering into a player's
+// outgoing messages until ClearConnectionChaos or the player disconnects.
+// Returns ErrChaosDisabled when GO_ENV is production.
+func (h *WebSocketHandler) SetConnectionChaos(playerID string, config ChaosConfig) error {
+	return h.chaos.set(playerID, config)
+}
+
+// ClearConnectionChaos stops chaos on a player's connection
+func (h *WebSocketHandler) ClearConnectionChaos(playerID string) {
+	h.chaos.clear(playerID)
+}
+
+// ConnectionChaos returns the chaos config on a player's connection and the
+// faults injected so far
+func (h *WebSocketHandler) ConnectionChaos(playerID string) (ChaosConfig, ChaosStats, bool) {
+	return h.chaos.get(playerID)
+}
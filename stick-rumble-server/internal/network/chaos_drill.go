@@ -0,0 +1,239 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	// chaosDrillTimeout bounds how long a drill waits for the room's clients
+	// to resume and resync. It stays under the HTTP write timeout, since the
+	// admin request waits for the report.
+	chaosDrillTimeout = 10 * time.Second
+
+	// chaosDrillPollInterval is how often a drill checks on the clients
+	chaosDrillPollInterval = 50 * time.Millisecond
+)
+
+var (
+	ErrDrillResumeOff  = errors.New("session resume is off, so a drill would remove every player")
+	ErrDrillRunning    = errors.New("a drill is already running in this room")
+	ErrDrillNoClients  = errors.New("room has no connected players")
+	ErrDrillRoomAbsent = errors.New("room not found")
+)
+
+// ChaosDrillReport is the outcome of a forced host restart drill in one room
+type ChaosDrillReport struct {
+	RoomID     string             `json:"roomId"`
+	StartedAt  time.Time          `json:"startedAt"`
+	DurationMs int64              `json:"durationMs"`
+	Resynced   bool               `json:"resynced"` // Every dropped client came back with its state intact
+	Players    []ChaosDrillPlayer `json:"players"`
+}
+
+// ChaosDrillPlayer is how one dropped client came through a drill
+type ChaosDrillPlayer struct {
+	PlayerID string           `json:"playerId"`
+	Resumed  bool             `json:"resumed"`
+	ResumeMs int64            `json:"resumeMs,omitempty"` // From the drop to the resumed connection
+	Snapshot bool             `json:"snapshot"`           // Sent a full state:snapshot after resuming
+	Before   chaosDrillState  `json:"before"`
+	After    *chaosDrillState `json:"after,omitempty"` // Omitted if the player is gone
+	Problems []string         `json:"problems"`
+}
+
+// chaosDrillState is the part of a player's state a restart must not lose
+type chaosDrillState struct {
+	RoomID  string `json:"roomId"`
+	Team    string `json:"team,omitempty"`
+	MatchID string `json:"matchId,omitempty"`
+	InGame  bool   `json:"inGame"` // Has a player in the game world
+	Kills   int    `json:"kills"`
+	XP      int    `json:"xp"`
+}
+
+// chaosDrills keeps two drills from running in one room at once
+type chaosDrills struct {
+	rooms map[string]bool
+	mu    sync.Mutex
+}
+
+func newChaosDrills() *chaosDrills {
+	return &chaosDrills{rooms: make(map[string]bool)}
+}
+
+func (d *chaosDrills) begin(roomID string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.rooms[roomID] {
+		return false
+	}
+	d.rooms[roomID] = true
+	return true
+}
+
+func (d *chaosDrills) end(roomID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.rooms, roomID)
+}
+
+// drillState captures a player's state, or returns nil if it left the room
+func (h *WebSocketHandler) drillState(playerID string) *chaosDrillState {
+	room := h.roomManager.GetRoomByPlayerID(playerID)
+	if room == nil {
+		return nil
+	}
+	state := &chaosDrillState{RoomID: room.ID, MatchID: room.Match.GetID()}
+	if player := room.GetPlayer(playerID); player != nil {
+		state.Team = player.Team
+	}
+	if snapshot, ok := h.gameServer.GetPlayerState(playerID); ok {
+		state.InGame = true
+		state.Kills = snapshot.Kills
+		state.XP = snapshot.XP
+	}
+	return state
+}
+
+// RunChaosDrill rehearses a host crash in a room: it snapshots every connected
+// player's state, drops their connections the way a crashed host would, and
+// waits for the clients to come back through session resume. The report says
+// whether each one resumed, was sent a full snapshot and kept its room, team,
+// match, kills and XP. Bots have no connection and are left alone.
+func (h *WebSocketHandler) RunChaosDrill(roomID string, timeout time.Duration) (ChaosDrillReport, error) {
+	room := h.roomManager.GetRoom(roomID)
+	if room == nil {
+		return ChaosDrillReport{}, ErrDrillRoomAbsent
+	}
+	if !h.resumer.enabled() {
+		return ChaosDrillReport{}, ErrDrillResumeOff
+	}
+	if !h.drills.begin(roomID) {
+		return ChaosDrillReport{}, ErrDrillRunning
+	}
+	defer h.drills.end(roomID)
+
+	report := ChaosDrillReport{RoomID: roomID, StartedAt: time.Now(), Players: []ChaosDrillPlayer{}}
+	type dropped struct {
+		result    *ChaosDrillPlayer
+		parked    <-chan struct{}
+		droppedAt time.Time
+	}
+	var players []*dropped
+	for _, player := range room.GetPlayers() {
+		before := h.drillState(player.ID)
+		if before == nil {
+			continue
+		}
+		droppedAt := time.Now()
+		parked, ok := h.resumer.drop(player.ID)
+		if !ok {
+			continue
+		}
+		players = append(players, &dropped{
+			result:    &ChaosDrillPlayer{PlayerID: player.ID, Before: *before, Problems: []string{}},
+			parked:    parked,
+			droppedAt: droppedAt,
+		})
+	}
+	if len(players) == 0 {
+		return ChaosDrillReport{}, ErrDrillNoClients
+	}
+	log.Printf("Chaos drill in room %s dropped %d connections", roomID, len(players))
+
+	deadline := time.After(timeout)
+	for _, player := range players {
+		select {
+		case <-player.parked:
+		case <-deadline:
+			player.result.Problems = append(player.result.Problems, "connection was not parked")
+		}
+	}
+
+	// Wait for every client to resume and, if it is in the game, to be sent
+	// a full snapshot
+	ticker := time.NewTicker(chaosDrillPollInterval)
+	defer ticker.Stop()
+	waiting := len(players)
+	for waiting > 0 {
+		waiting = 0
+		for _, player := range players {
+			result := player.result
+			if !result.Resumed {
+				if boundAt, ok := h.resumer.connectedSince(result.PlayerID); ok && boundAt.After(player.droppedAt) {
+					result.Resumed = true
+					result.ResumeMs = boundAt.Sub(player.droppedAt).Milliseconds()
+				}
+			}
+			if result.Resumed && !result.Snapshot {
+				boundAt, _ := h.resumer.connectedSince(result.PlayerID)
+				_, inGame := h.gameServer.GetPlayerState(result.PlayerID)
+				result.Snapshot = inGame && h.deltaTracker.SnapshotSentSince(result.PlayerID, boundAt)
+			}
+			if !result.Resumed || (result.Before.InGame && !result.Snapshot) {
+				waiting++
+			}
+		}
+		if waiting == 0 {
+			break
+		}
+		select {
+		case <-ticker.C:
+		case <-deadline:
+			waiting = 0
+		}
+	}
+
+	report.Resynced = true
+	for _, player := range players {
+		result := player.result
+		result.After = h.drillState(result.PlayerID)
+		result.Problems = append(result.Problems, drillProblems(*result)...)
+		if len(result.Problems) > 0 {
+			report.Resynced = false
+		}
+		report.Players = append(report.Players, *result)
+	}
+	report.DurationMs = time.Since(report.StartedAt).Milliseconds()
+	log.Printf("Chaos drill in room %s finished in %dms, resynced %t", roomID, report.DurationMs, report.Resynced)
+	return report, nil
+}
+
+// drillProblems lists what a player did not get back after a drill
+func drillProblems(result ChaosDrillPlayer) []string {
+	problems := []string{}
+	if !result.Resumed {
+		problems = append(problems, "client did not resume")
+	} else if result.Before.InGame && !result.Snapshot {
+		problems = append(problems, "no full snapshot after resuming")
+	}
+
+	before, after := result.Before, result.After
+	if after == nil {
+		return append(problems, "player left the room")
+	}
+	if after.RoomID != before.RoomID {
+		problems = append(problems, fmt.Sprintf("moved from room %s to %s", before.RoomID, after.RoomID))
+	}
+	if after.Team != before.Team {
+		problems = append(problems, fmt.Sprintf("team changed from %q to %q", before.Team, after.Team))
+	}
+	if after.MatchID != before.MatchID {
+		problems = append(problems, "match changed")
+	}
+	if before.InGame && !after.InGame {
+		problems = append(problems, "player left the game world")
+	}
+	// Kills and XP can grow while other players keep fighting, never shrink
+	if after.Kills < before.Kills {
+		problems = append(problems, fmt.Sprintf("kills went from %d to %d", before.Kills, after.Kills))
+	}
+	if after.XP < before.XP {
+		problems = append(problems, fmt.Sprintf("XP went from %d to %d", before.XP, after.XP))
+	}
+	return problems
+}
@@ -0,0 +1,150 @@
+package network
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// joinDrillRoom connects two players to a code room and returns their
+// connections, session tokens and the room ID once the match is ready
+func joinDrillRoom(t *testing.T, ts *testServer, code string) ([]*websocket.Conn, []string, string) {
+	t.Helper()
+	conns := []*websocket.Conn{ts.connectRawClient(t), ts.connectRawClient(t)}
+	tokens := make([]string, 0, len(conns))
+	for i, conn := range conns {
+		token, _ := readServerHello(t, conn)
+		tokens = append(tokens, token)
+		sendHelloMessage(t, conn, []string{"Alpha", "Bravo"}[i], "code", code)
+	}
+	roomID := ""
+	for _, conn := range conns {
+		_, status, err := readSessionStatus(t, conn, "match_ready", 2*time.Second)
+		require.NoError(t, err)
+		roomID = status["roomId"].(string)
+	}
+	return conns, tokens, roomID
+}
+
+// startDrill sends the drill request in the background, since it answers
+// only once the clients have resumed
+func startDrill(admin *adminClient, roomID string) <-chan ChaosDrillReport {
+	reports := make(chan ChaosDrillReport, 1)
+	go func() {
+		status, body := admin.do(http.MethodPost, "/admin/rooms/"+roomID+"/drill", "")
+		var report ChaosDrillReport
+		if status == http.StatusOK && json.Unmarshal(body, &report) == nil {
+			reports <- report
+		}
+		close(reports)
+	}()
+	return reports
+}
+
+// waitForDrop reads from conn until the drill closes it
+func waitForDrop(t *testing.T, conn *websocket.Conn) {
+	t.Helper()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			netErr, ok := err.(interface{ Timeout() bool })
+			require.False(t, ok && netErr.Timeout(), "the drill should drop the connection")
+			return
+		}
+	}
+}
+
+func TestChaosDrillReportsResyncedClients(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	admin := newAdminClient(t, ts)
+
+	conns, tokens, roomID := joinDrillRoom(t, ts, "DRILL")
+	reports := startDrill(admin, roomID)
+	for i, conn := range conns {
+		waitForDrop(t, conn)
+		resumed := dialResume(t, ts, tokens[i])
+		defer resumed.Close()
+		_, ok := readServerHello(t, resumed)
+		assert.True(t, ok)
+	}
+
+	report, ok := <-reports
+	require.True(t, ok, "the drill should answer with a report")
+	assert.Equal(t, roomID, report.RoomID)
+	assert.True(t, report.Resynced, "%+v", report.Players)
+	require.Len(t, report.Players, 2)
+	for _, player := range report.Players {
+		assert.True(t, player.Resumed)
+		assert.True(t, player.Snapshot, "a resumed player is sent a full snapshot")
+		assert.Empty(t, player.Problems)
+		require.NotNil(t, player.After)
+		assert.Equal(t, player.Before, *player.After)
+	}
+}
+
+func TestChaosDrillReportsClientsThatDoNotResume(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	ts.handler.drillTimeout = 300 * time.Millisecond
+	admin := newAdminClient(t, ts)
+
+	conns, tokens, roomID := joinDrillRoom(t, ts, "STRAY")
+	reports := startDrill(admin, roomID)
+	waitForDrop(t, conns[0])
+	waitForDrop(t, conns[1])
+	resumed := dialResume(t, ts, tokens[0])
+	defer resumed.Close()
+
+	report, ok := <-reports
+	require.True(t, ok, "the drill should answer with a report")
+	assert.False(t, report.Resynced)
+	require.Len(t, report.Players, 2)
+	problems := 0
+	for _, player := range report.Players {
+		if !player.Resumed {
+			problems++
+			assert.Contains(t, player.Problems, "client did not resume")
+			require.NotNil(t, player.After, "a parked player keeps its room")
+		}
+	}
+	assert.Equal(t, 1, problems)
+}
+
+func TestChaosDrillRejectsUnsafeRequests(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	admin := newAdminClient(t, ts)
+
+	status, _ := admin.do(http.MethodPost, "/admin/rooms/missing/drill", "")
+	assert.Equal(t, http.StatusNotFound, status)
+
+	conns, _, roomID := joinDrillRoom(t, ts, "NODRILL")
+	for _, conn := range conns {
+		defer conn.Close()
+	}
+
+	require.True(t, ts.handler.drills.begin(roomID))
+	status, body := admin.do(http.MethodPost, "/admin/rooms/"+roomID+"/drill", "")
+	assert.Equal(t, http.StatusConflict, status, "one drill per room at a time: %s", body)
+	ts.handler.drills.end(roomID)
+}
+
+func TestChaosDrillNeedsSessionResume(t *testing.T) {
+	ts := newTestServer(withResumeGrace(0))
+	defer ts.Close()
+	admin := newAdminClient(t, ts)
+
+	conns, _, roomID := joinDrillRoom(t, ts, "NOPARK")
+	for _, conn := range conns {
+		defer conn.Close()
+	}
+
+	status, body := admin.do(http.MethodPost, "/admin/rooms/"+roomID+"/drill", "")
+	assert.Equal(t, http.StatusConflict, status, "without session resume a drill would remove every player: %s", body)
+}
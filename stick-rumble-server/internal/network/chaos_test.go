@@ -0,0 +1,145 @@
+package network
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// frameLog collects frames written by a chaosInjector
+type frameLog struct {
+	frames []string
+	mu     sync.Mutex
+}
+
+func (l *frameLog) write(frame []byte) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.frames = append(l.frames, string(frame))
+}
+
+func (l *frameLog) snapshot() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.frames...)
+}
+
+func newTestChaosInjector() *chaosInjector {
+	return newChaosInjector(true, rand.New(rand.NewSource(1)))
+}
+
+func TestChaosConfigValidate(t *testing.T) {
+	assert.NoError(t, ChaosConfig{DropPercent: 100, DelayPercent: 50, DelayMs: 5000, ReorderPercent: 0}.Validate())
+	assert.Error(t, ChaosConfig{DropPercent: 101}.Validate())
+	assert.Error(t, ChaosConfig{ReorderPercent: -1}.Validate())
+	assert.Error(t, ChaosConfig{DelayMs: 5001}.Validate())
+}
+
+func TestChaosInjectorPassesThroughUnconfiguredPlayers(t *testing.T) {
+	chaos := newTestChaosInjector()
+	require.NoError(t, chaos.set("p1", ChaosConfig{DropPercent: 100}))
+
+	var log frameLog
+	chaos.deliver("p2", []byte("a"), log.write)
+	assert.Equal(t, []string{"a"}, log.snapshot())
+}
+
+func TestChaosInjectorDropsMessages(t *testing.T) {
+	chaos := newTestChaosInjector()
+	require.NoError(t, chaos.set("p1", ChaosConfig{DropPercent: 100}))
+
+	var log frameLog
+	for i := 0; i < 10; i++ {
+		chaos.deliver("p1", []byte("a"), log.write)
+	}
+	assert.Empty(t, log.snapshot())
+
+	_, stats, ok := chaos.get("p1")
+	require.True(t, ok)
+	assert.Equal(t, 10, stats.Dropped)
+}
+
+func TestChaosInjectorReordersMessages(t *testing.T) {
+	chaos := newTestChaosInjector()
+	require.NoError(t, chaos.set("p1", ChaosConfig{ReorderPercent: 100}))
+
+	var log frameLog
+	for _, frame := range []string{"1", "2", "3", "4"} {
+		chaos.deliver("p1", []byte(frame), log.write)
+	}
+	assert.Equal(t, []string{"2", "1", "4", "3"}, log.snapshot())
+}
+
+func TestChaosInjectorFlushesHeldMessage(t *testing.T) {
+	chaos := newTestChaosInjector()
+	require.NoError(t, chaos.set("p1", ChaosConfig{ReorderPercent: 100}))
+
+	var log frameLog
+	chaos.deliver("p1", []byte("only"), log.write)
+	assert.Empty(t, log.snapshot())
+	assert.Eventually(t, func() bool {
+		return len(log.snapshot()) == 1
+	}, time.Second, 10*time.Millisecond, "a held message is sent when nothing overtakes it")
+}
+
+func TestChaosInjectorDelaysMessages(t *testing.T) {
+	chaos := newTestChaosInjector()
+	require.NoError(t, chaos.set("p1", ChaosConfig{DelayPercent: 100, DelayMs: 50}))
+
+	var log frameLog
+	start := time.Now()
+	chaos.deliver("p1", []byte("late"), log.write)
+	assert.Empty(t, log.snapshot())
+	assert.Eventually(t, func() bool {
+		return len(log.snapshot()) == 1
+	}, time.Second, 5*time.Millisecond)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestChaosInjectorClear(t *testing.T) {
+	chaos := newTestChaosInjector()
+	require.NoError(t, chaos.set("p1", ChaosConfig{DropPercent: 100}))
+	chaos.clear("p1")
+
+	_, _, ok := chaos.get("p1")
+	assert.False(t, ok)
+
+	var log frameLog
+	chaos.deliver("p1", []byte("a"), log.write)
+	assert.Equal(t, []string{"a"}, log.snapshot())
+}
+
+func TestChaosInjectorDisabledInProduction(t *testing.T) {
+	chaos := newChaosInjector(false, rand.New(rand.NewSource(1)))
+	assert.ErrorIs(t, chaos.set("p1", ChaosConfig{DropPercent: 100}), ErrChaosDisabled)
+}
+
+func TestConnectionChaosAppliesToOnePlayer(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn := ts.connectRawClient(t)
+	defer conn.Close()
+	sendHelloMessage(t, conn, "Chaos", "code", "CHAOS")
+
+	_, status, err := readSessionStatus(t, conn, "waiting_for_players", 2*time.Second)
+	require.NoError(t, err)
+	playerID, _ := status["playerId"].(string)
+	require.NotEmpty(t, playerID)
+
+	require.NoError(t, ts.handler.SetConnectionChaos(playerID, ChaosConfig{DropPercent: 100}))
+	sendMessage(t, conn, Message{Type: "room:roster_request", Timestamp: time.Now().UnixMilli()})
+	assert.Eventually(t, func() bool {
+		_, stats, ok := ts.handler.ConnectionChaos(playerID)
+		return ok && stats.Dropped > 0
+	}, 2*time.Second, 10*time.Millisecond, "the roster reply should be dropped")
+
+	ts.handler.ClearConnectionChaos(playerID)
+	sendMessage(t, conn, Message{Type: "room:roster_request", Timestamp: time.Now().UnixMilli()})
+	_, err = readMessageOfType(t, conn, "room:roster", 2*time.Second)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,167 @@
+package network
+
+import (
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+// Chat limits. A player may send chatBurst messages in any chatWindow; more
+// are dropped until the oldest one in the window ages out.
+const (
+	maxChatMessageLength = 200 // Characters after trimming
+	chatBurst            = 3
+	chatWindow           = time.Second
+)
+
+// Chat channels. All-chat reaches the whole room; team chat reaches the
+// sender's team. A room with all-chat turned off sends every message to the
+// sender's team.
+const (
+	chatChannelAll  = "all"
+	chatChannelTeam = "team"
+)
+
+// ProfanityFilter cleans chat text before it is broadcast. The handler uses a
+// word list by default; SetChatFilter swaps in another filter, such as one
+// backed by a moderation service.
+type ProfanityFilter interface {
+	Filter(text string) string
+}
+
+// defaultBlockedWords is the built-in word list for chat
+var defaultBlockedWords = []string{"fuck", "shit", "bitch", "cunt", "asshole", "bastard", "dick", "slut", "whore"}
+
+// wordListFilter masks whole words from a list, ignoring case, with one
+// asterisk per letter
+type wordListFilter struct {
+	blocked map[string]bool
+}
+
+// NewWordListFilter creates a filter that masks every word in words
+func NewWordListFilter(words []string) ProfanityFilter {
+	blocked := make(map[string]bool, len(words))
+	for _, word := range words {
+		blocked[strings.ToLower(word)] = true
+	}
+	return &wordListFilter{blocked: blocked}
+}
+
+func (f *wordListFilter) Filter(text string) string {
+	var out strings.Builder
+	var word []rune
+	flush := func() {
+		if f.blocked[strings.ToLower(string(word))] {
+			out.WriteString(strings.Repeat("*", len(word)))
+		} else {
+			out.WriteString(string(word))
+		}
+		word = word[:0]
+	}
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			word = append(word, r)
+			continue
+		}
+		flush()
+		out.WriteRune(r)
+	}
+	flush()
+	return out.String()
+}
+
+// chatRelay checks chat messages before they reach a room: it cleans the
+// text and holds each player to the chat flood limit
+type chatRelay struct {
+	filter ProfanityFilter
+	sent   map[string][]time.Time // player ID -> send times within the chat window, oldest first
+	now    func() time.Time
+	mu     sync.Mutex
+}
+
+func newChatRelay(filter ProfanityFilter, now func() time.Time) *chatRelay {
+	return &chatRelay{
+		filter: filter,
+		sent:   make(map[string][]time.Time),
+		now:    now,
+	}
+}
+
+// setFilter replaces the profanity filter
+func (c *chatRelay) setFilter(filter ProfanityFilter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.filter = filter
+}
+
+// prepare returns the text to broadcast for a player's chat message, or false
+// if the text is empty once cleaned or the player is over the flood limit.
+// Only messages that pass count toward the limit. Send times that left the
+// window are dropped as it goes, so players who left are not kept.
+func (c *chatRelay) prepare(playerID, text string) (string, bool) {
+	text = sanitizeChatText(text)
+	if text == "" {
+		return "", false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for id, times := range c.sent {
+		for len(times) > 0 && now.Sub(times[0]) >= chatWindow {
+			times = times[1:]
+		}
+		if len(times) == 0 {
+			delete(c.sent, id)
+		} else {
+			c.sent[id] = times
+		}
+	}
+	if len(c.sent[playerID]) >= chatBurst {
+		return "", false
+	}
+	c.sent[playerID] = append(c.sent[playerID], now)
+
+	if c.filter != nil {
+		text = c.filter.Filter(text)
+	}
+	return text, true
+}
+
+// sanitizeChatText turns control characters, line breaks included, into
+// spaces, so they cannot glue words past the filter, then trims the text and
+// cuts it to maxChatMessageLength characters
+func sanitizeChatText(text string) string {
+	text = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, text))
+	if runes := []rune(text); len(runes) > maxChatMessageLength {
+		text = strings.TrimSpace(string(runes[:maxChatMessageLength]))
+	}
+	return text
+}
+
+// chatChannel returns the channel a player's chat message goes out on: the
+// one requested, all-chat by default, narrowed to team chat when the room
+// has all-chat off. Returns false when the message has no one to reach
+// because the player has no team.
+func chatChannel(room *game.Room, player *game.Player, requested string) (string, bool) {
+	channel := requested
+	if channel == "" {
+		channel = chatChannelAll
+	}
+	if channel == chatChannelAll && !room.AllChat() {
+		channel = chatChannelTeam
+	}
+	if channel == chatChannelTeam && player.Team == "" {
+		return "", false
+	}
+	return channel, true
+}
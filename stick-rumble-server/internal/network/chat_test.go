@@ -0,0 +1,136 @@
+package network
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWordListFilterMasksWholeWords(t *testing.T) {
+	filter := NewWordListFilter([]string{"darn", "heck"})
+
+	assert.Equal(t, "well **** it", filter.Filter("well darn it"))
+	assert.Equal(t, "****! ****?", filter.Filter("DARN! Heck?"), "case and punctuation do not hide a word")
+	assert.Equal(t, "darned heckler", filter.Filter("darned heckler"), "only whole words are masked")
+	assert.Equal(t, "gg wp", NewWordListFilter(defaultBlockedWords).Filter("gg wp"))
+}
+
+func TestChatRelayFloodLimit(t *testing.T) {
+	now := time.Unix(0, 0)
+	relay := newChatRelay(nil, func() time.Time { return now })
+
+	for i := 0; i < chatBurst; i++ {
+		_, ok := relay.prepare("p1", "hi")
+		assert.True(t, ok, "message %d of the burst", i+1)
+	}
+	_, ok := relay.prepare("p1", "hi")
+	assert.False(t, ok, "past the burst")
+	_, ok = relay.prepare("p2", "hi")
+	assert.True(t, ok, "limits are per player")
+
+	now = now.Add(chatWindow)
+	_, ok = relay.prepare("p1", "hi")
+	assert.True(t, ok, "the window moved on")
+}
+
+func TestChatRelayCleansText(t *testing.T) {
+	relay := newChatRelay(NewWordListFilter([]string{"darn"}), time.Now)
+
+	text, ok := relay.prepare("p1", "  darn\nit\x00  ")
+	require.True(t, ok)
+	assert.Equal(t, "**** it", text, "a line break does not join words")
+
+	_, ok = relay.prepare("p1", " \n\t ")
+	assert.False(t, ok, "nothing left to send")
+
+	text, _ = relay.prepare("p2", strings.Repeat("é", maxChatMessageLength+10))
+	assert.Len(t, []rune(text), maxChatMessageLength)
+}
+
+func TestChatMessageReachesTheRoomExceptMuters(t *testing.T) {
+	withSchemaValidation(t)
+	f := newGoldenFixture(t)
+	f.handler.SetChatFilter(NewWordListFilter([]string{"darn"}))
+
+	f.handler.handleChatMessage(f.sender, map[string]interface{}{"text": "darn, nice shot"})
+	var msg Message
+	require.NoError(t, json.Unmarshal(f.received(t, "chat:posted"), &msg))
+	assert.Equal(t, map[string]interface{}{
+		"playerId":    "player-a",
+		"displayName": "Alpha",
+		"text":        "****, nice shot",
+		"channel":     "all",
+	}, msg.Data)
+
+	f.handler.handleChatMute(f.receiver, map[string]interface{}{"playerId": "player-a", "muted": true})
+	f.handler.handleChatMessage(f.sender, map[string]interface{}{"text": "hello?"})
+	assert.Empty(t, f.receiver.SendChan, "muted")
+
+	f.handler.handleChatMute(f.receiver, map[string]interface{}{"playerId": "player-a", "muted": false})
+	f.handler.handleChatMessage(f.sender, map[string]interface{}{"text": "hello?"})
+	assert.Len(t, f.receiver.SendChan, 1, "unmuted")
+}
+
+func TestTeamChatReachesOnlyTheSendersTeam(t *testing.T) {
+	withSchemaValidation(t)
+	f := newGoldenFixture(t)
+	f.sender.Team = game.TeamAlpha
+	f.receiver.Team = game.TeamBravo
+	teammate := game.NewPlayer("player-c", make(chan []byte, 64))
+	teammate.Team = game.TeamAlpha
+	require.NoError(t, f.room.AddPlayer(teammate))
+	f.drain()
+
+	f.handler.handleChatMessage(f.sender, map[string]interface{}{"text": "push B", "channel": "team"})
+	assert.Empty(t, f.receiver.SendChan, "the other team does not see team chat")
+	require.Len(t, teammate.SendChan, 1)
+	var msg Message
+	require.NoError(t, json.Unmarshal(<-teammate.SendChan, &msg))
+	assert.Equal(t, "team", msg.Data.(map[string]interface{})["channel"])
+
+	f.handler.handleChatMessage(f.sender, map[string]interface{}{"text": "gg", "channel": "all"})
+	assert.Len(t, f.receiver.SendChan, 1, "all-chat reaches both teams")
+	assert.Len(t, teammate.SendChan, 1)
+}
+
+func TestChatStaysWithinTheTeamWhenAllChatIsOff(t *testing.T) {
+	withSchemaValidation(t)
+	f := newGoldenFixture(t)
+	f.room.SetAllChat(false)
+	for len(f.sender.SendChan) > 0 {
+		<-f.sender.SendChan
+	}
+
+	f.handler.handleChatMessage(f.sender, map[string]interface{}{"text": "anyone?"})
+	assert.Empty(t, f.receiver.SendChan, "a player without a team has no one to chat with")
+	assert.Empty(t, f.sender.SendChan)
+
+	f.sender.Team = game.TeamAlpha
+	f.receiver.Team = game.TeamBravo
+	f.handler.handleChatMessage(f.sender, map[string]interface{}{"text": "gg", "channel": "all"})
+	assert.Empty(t, f.receiver.SendChan, "all-chat is off")
+	require.Len(t, f.sender.SendChan, 1)
+	var msg Message
+	require.NoError(t, json.Unmarshal(<-f.sender.SendChan, &msg))
+	assert.Equal(t, "team", msg.Data.(map[string]interface{})["channel"], "the message went out on team chat")
+}
+
+func TestChatMuteNeedsAnotherPlayerInTheRoom(t *testing.T) {
+	withSchemaValidation(t)
+	f := newGoldenFixture(t)
+
+	f.handler.handleChatMute(f.receiver, map[string]interface{}{"playerId": "player-b", "muted": true})
+	f.handler.handleChatMute(f.receiver, map[string]interface{}{"playerId": "stranger", "muted": true})
+	f.handler.handleChatMute(f.receiver, map[string]interface{}{"playerId": "player-a"})
+	assert.False(t, f.receiver.ChatMutes.Mutes("player-b"))
+	assert.False(t, f.receiver.ChatMutes.Mutes("stranger"))
+	assert.False(t, f.receiver.ChatMutes.Mutes("player-a"), "a mute without muted is rejected")
+
+	f.handler.handleChatMessage(f.receiver, map[string]interface{}{"text": strings.Repeat("a", maxChatMessageLength+1)})
+	assert.Empty(t, f.receiver.SendChan, "over-long messages fail validation")
+}
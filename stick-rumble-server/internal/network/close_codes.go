@@ -0,0 +1,96 @@
+package network
+
+import (
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// CloseCode is a WebSocket close code the server ends a connection with.
+// Codes 4000-4999 are private to the application (RFC 6455 section 7.4.2);
+// each names why the client was disconnected, so it can show the right
+// dialog and decide whether to reconnect.
+type CloseCode int
+
+const (
+	CloseKicked           CloseCode = 4000 // An admin or the anti-cheat removed the player
+	CloseBanned           CloseCode = 4001 // The player or address is banned; the text carries the appeal reference
+	CloseIdle             CloseCode = 4002 // The connection stopped answering net:ping
+	CloseServerShutdown   CloseCode = 4003 // The server drained for shutdown
+	CloseProtocolError    CloseCode = 4004 // The client kept sending frames the server cannot read
+	CloseSessionReplaced  CloseCode = 4005 // The account connected again elsewhere
+	CloseAlreadyConnected CloseCode = 4006 // The account is connected and its session could not be taken over
+)
+
+// Reason is the code's name in specs and the client's CLOSE_CODES
+func (c CloseCode) Reason() string {
+	switch c {
+	case CloseKicked:
+		return "kicked"
+	case CloseBanned:
+		return "banned"
+	case CloseIdle:
+		return "idle"
+	case CloseServerShutdown:
+		return "server_shutdown"
+	case CloseProtocolError:
+		return "protocol_error"
+	case CloseSessionReplaced:
+		return "session_replaced"
+	case CloseAlreadyConnected:
+		return "already_connected"
+	default:
+		return ""
+	}
+}
+
+// Reconnects reports whether a client closed with c should reconnect on its
+// own: an idle connection may resume its session, and a drained server's
+// clients may join another instance. The rest need the player to act.
+func (c CloseCode) Reconnects() bool {
+	return c == CloseIdle || c == CloseServerShutdown
+}
+
+// disconnectReason is why the server closes a connection: the close code and
+// the text the player is shown. The zero value closes without a close frame,
+// the way a lost link looks, so the player is parked for resume.
+type disconnectReason struct {
+	code CloseCode
+	text string
+}
+
+var (
+	// heartbeatTimeoutReason closes a connection that stopped answering net:ping
+	heartbeatTimeoutReason = disconnectReason{code: CloseIdle, text: "heartbeat timeout"}
+	// serverShutdownReason closes every connection at the end of a drain
+	serverShutdownReason = disconnectReason{code: CloseServerShutdown, text: "server shutting down"}
+	// sessionReplacedReason closes a connection whose account connected
+	// again; the client is sent session:replaced first
+	sessionReplacedReason = disconnectReason{code: CloseSessionReplaced, text: "session replaced"}
+	// alreadyConnectedReason turns away a second connection for an account
+	// whose session could not be taken over in time
+	alreadyConnectedReason = disconnectReason{code: CloseAlreadyConnected, text: "user already connected"}
+	// protocolErrorReason closes a connection after maxMalformedFrames
+	// frames in a row could not be read
+	protocolErrorReason = disconnectReason{code: CloseProtocolError, text: "malformed messages"}
+)
+
+// kickedReason and bannedReason carry the text an admin or the anti-cheat gave
+func kickedReason(text string) disconnectReason {
+	return disconnectReason{code: CloseKicked, text: text}
+}
+
+func bannedReason(ban Ban) disconnectReason {
+	return disconnectReason{code: CloseBanned, text: banCloseReason(ban)}
+}
+
+// maxMalformedFrames is how many frames in a row that fail to decode or parse
+// a connection may send before it is closed with CloseProtocolError
+const maxMalformedFrames = 10
+
+// writeClose sends reason's close frame, with the text cut to fit
+func writeClose(conn *websocket.Conn, reason disconnectReason) error {
+	return conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(int(reason.code), closeReasonText(reason.text)),
+		time.Now().Add(time.Second))
+}
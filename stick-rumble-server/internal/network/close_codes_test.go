@@ -0,0 +1,52 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloseCodeReasons(t *testing.T) {
+	codes := map[CloseCode]bool{
+		CloseKicked:           false,
+		CloseBanned:           false,
+		CloseIdle:             true,
+		CloseServerShutdown:   true,
+		CloseProtocolError:    false,
+		CloseSessionReplaced:  false,
+		CloseAlreadyConnected: false,
+	}
+	reasons := make(map[string]bool)
+	for code, reconnects := range codes {
+		assert.GreaterOrEqual(t, int(code), 4000, "application codes start at 4000")
+		assert.NotEmpty(t, code.Reason())
+		reasons[code.Reason()] = true
+		assert.Equal(t, reconnects, code.Reconnects(), code.Reason())
+	}
+	assert.Len(t, reasons, len(codes), "every code has its own reason")
+	assert.Empty(t, CloseCode(websocket.CloseNormalClosure).Reason())
+}
+
+func TestMalformedMessagesCloseWithProtocolError(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn := ts.connectRawClient(t)
+	defer conn.Close()
+	sendHelloMessage(t, conn, "Garbler", "code", "GARB")
+	_, status, err := readSessionStatus(t, conn, "waiting_for_players", 2*time.Second)
+	require.NoError(t, err)
+	playerID := status["playerId"].(string)
+
+	for i := 0; i < maxMalformedFrames; i++ {
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("not json")))
+	}
+
+	assert.Equal(t, protocolErrorReason, readCloseReason(t, conn))
+	require.Eventually(t, func() bool {
+		return ts.handler.roomManager.GetRoomByPlayerID(playerID) == nil
+	}, 2*time.Second, 10*time.Millisecond, "the player is removed rather than parked")
+}
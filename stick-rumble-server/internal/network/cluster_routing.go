@@ -0,0 +1,224 @@
+package network
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mtomcal/stick-rumble-server/internal/cluster"
+	"github.com/mtomcal/stick-rumble-server/internal/config"
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+const (
+	// clusterHeartbeatInterval is how often this node publishes its load and
+	// room codes and reads the other nodes'
+	clusterHeartbeatInterval = 2 * time.Second
+	// clusterNodeTTL is how long a node stays registered without a heartbeat
+	clusterNodeTTL = 3 * clusterHeartbeatInterval
+	// clusterRequestTimeout bounds a registry call made while handling a hello
+	clusterRequestTimeout = time.Second
+)
+
+// room:redirect reasons
+const (
+	RedirectReasonRoomCode    = "room_code"
+	RedirectReasonLeastLoaded = "least_loaded"
+)
+
+// clusterRegistry is where nodes share their load and room codes;
+// *cluster.Registry keeps it in Redis
+type clusterRegistry interface {
+	Register(ctx context.Context, node cluster.Node, codes []string) error
+	Nodes(ctx context.Context) ([]cluster.Node, error)
+	ClaimRoom(ctx context.Context, code, nodeID string) (string, error)
+	Deregister(ctx context.Context, nodeID string, codes []string) error
+}
+
+// clusterRouter sends players joining this node to the node that should host
+// them: the one holding their named room, or the least-loaded one for public
+// matchmaking
+type clusterRouter struct {
+	registry clusterRegistry
+	nodeID   string
+	address  string // WebSocket URL other nodes redirect players to
+
+	mu    sync.Mutex
+	peers []cluster.Node // Other live nodes as of the last heartbeat
+}
+
+func newClusterRouter(registry clusterRegistry, nodeID, address string) *clusterRouter {
+	return &clusterRouter{registry: registry, nodeID: nodeID, address: address}
+}
+
+// newClusterFromConfig connects to the Redis cluster registry. Without an
+// advertised address other nodes could not send players here, so the node
+// stays out of the cluster.
+func newClusterFromConfig(runtimeConfig config.RuntimeConfig) *clusterRouter {
+	if runtimeConfig.ClusterAdvertiseURL == "" {
+		log.Printf("REDIS_ADDR is set without CLUSTER_ADVERTISE_URL, not joining the cluster")
+		return nil
+	}
+	nodeID := runtimeConfig.ClusterNodeID
+	if nodeID == "" {
+		nodeID = uuid.NewString()
+	}
+
+	log.Printf("Joining cluster at %s as node %s (%s)", runtimeConfig.RedisAddr, nodeID, runtimeConfig.ClusterAdvertiseURL)
+	client := cluster.NewRedisClient(runtimeConfig.RedisAddr, runtimeConfig.RedisPassword)
+	return newClusterRouter(cluster.NewRegistry(client, clusterNodeTTL), nodeID, runtimeConfig.ClusterAdvertiseURL)
+}
+
+// clusterLoop keeps this node registered until ctx is done, then deregisters
+// it so its room codes are free at once
+func (h *WebSocketHandler) clusterLoop(ctx context.Context) {
+	ticker := time.NewTicker(clusterHeartbeatInterval)
+	defer ticker.Stop()
+
+	h.clusterHeartbeat(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			deregisterCtx, cancel := context.WithTimeout(context.Background(), clusterRequestTimeout)
+			defer cancel()
+			if err := h.cluster.registry.Deregister(deregisterCtx, h.cluster.nodeID, h.localRoomCodes()); err != nil {
+				log.Printf("Error leaving cluster: %v", err)
+			}
+			return
+		case <-ticker.C:
+			h.clusterHeartbeat(ctx)
+		}
+	}
+}
+
+// clusterHeartbeat publishes this node's load and room codes and refreshes
+// the view of the other nodes. On failure the previous view is kept.
+func (h *WebSocketHandler) clusterHeartbeat(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, clusterHeartbeatInterval)
+	defer cancel()
+
+	router := h.cluster
+	node := cluster.Node{
+		ID:      router.nodeID,
+		Address: router.address,
+		Players: h.roomManager.PlayerCount(),
+		Rooms:   len(h.roomManager.GetAllRooms()),
+	}
+	if err := router.registry.Register(ctx, node, h.localRoomCodes()); err != nil {
+		log.Printf("Error registering with cluster: %v", err)
+		return
+	}
+
+	nodes, err := router.registry.Nodes(ctx)
+	if err != nil {
+		log.Printf("Error reading cluster nodes: %v", err)
+		return
+	}
+	peers := make([]cluster.Node, 0, len(nodes))
+	for _, peer := range nodes {
+		if peer.ID != router.nodeID {
+			peers = append(peers, peer)
+		}
+	}
+
+	router.mu.Lock()
+	router.peers = peers
+	router.mu.Unlock()
+}
+
+// localRoomCodes returns the codes of the named rooms hosted here
+func (h *WebSocketHandler) localRoomCodes() []string {
+	var codes []string
+	for _, room := range h.roomManager.GetAllRooms() {
+		if room.Kind == game.RoomKindCode && room.Code != "" {
+			codes = append(codes, room.Code)
+		}
+	}
+	return codes
+}
+
+// clusterRedirect decides whether a hello belongs on another node and
+// returns that node's address and the reason, or "" to handle it here.
+// Registry failures keep the player here.
+func (h *WebSocketHandler) clusterRedirect(hello map[string]any) (string, string) {
+	if h.cluster == nil {
+		return "", ""
+	}
+	// A player already redirected once stays, even if loads or the room code's
+	// holder changed on the way, so it cannot bounce between nodes
+	if redirected, _ := hello["redirected"].(bool); redirected {
+		return "", ""
+	}
+
+	mode, _ := hello["mode"].(string)
+	switch mode {
+	case string(game.RoomKindCode):
+		code, _, ok := game.NormalizeRoomCode(hello["code"])
+		if !ok {
+			return "", ""
+		}
+		return h.cluster.roomCodeRedirect(code), RedirectReasonRoomCode
+	case string(game.RoomKindPublic):
+		// A partner waiting here makes a match sooner than any other node
+		if h.roomManager.HasWaitingPublicPlayer() {
+			return "", ""
+		}
+		return h.cluster.leastLoadedRedirect(h.roomManager.PlayerCount()), RedirectReasonLeastLoaded
+	}
+	return "", ""
+}
+
+// roomCodeRedirect claims a room code for this node, or returns the address
+// of the live node already holding it
+func (r *clusterRouter) roomCodeRedirect(code string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), clusterRequestTimeout)
+	defer cancel()
+
+	holder, err := r.registry.ClaimRoom(ctx, code, r.nodeID)
+	if err != nil {
+		log.Printf("Error claiming room code %s: %v", code, err)
+		return ""
+	}
+	if holder == r.nodeID {
+		return ""
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, peer := range r.peers {
+		if peer.ID == holder {
+			return peer.Address
+		}
+	}
+	return ""
+}
+
+// leastLoadedRedirect returns the address of the node with the fewest
+// players if it has fewer than the players here. Peers are in ID order, so
+// every node breaks ties the same way and waiting players meet on one node.
+func (r *clusterRouter) leastLoadedRedirect(localPlayers int) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	best := -1
+	for i, peer := range r.peers {
+		if peer.Players >= localPlayers {
+			continue
+		}
+		if best < 0 || peer.Players < r.peers[best].Players {
+			best = i
+		}
+	}
+	if best < 0 {
+		return ""
+	}
+	return r.peers[best].Address
+}
+
+func (h *WebSocketHandler) sendRoomRedirect(player *game.Player, address, reason string) {
+	if err := h.publication.SendRoomRedirect(player, address, reason); err != nil {
+		log.Printf("Error building room:redirect message: %v", err)
+	}
+}
@@ -0,0 +1,211 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/cluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryClusterRegistry is a clusterRegistry for one test process
+type memoryClusterRegistry struct {
+	mu      sync.Mutex
+	nodes   map[string]cluster.Node
+	holders map[string]string // room code -> node ID
+	err     error
+}
+
+func newMemoryClusterRegistry(nodes ...cluster.Node) *memoryClusterRegistry {
+	registry := &memoryClusterRegistry{nodes: make(map[string]cluster.Node), holders: make(map[string]string)}
+	for _, node := range nodes {
+		registry.nodes[node.ID] = node
+	}
+	return registry
+}
+
+func (r *memoryClusterRegistry) Register(_ context.Context, node cluster.Node, codes []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	r.nodes[node.ID] = node
+	for _, code := range codes {
+		if _, held := r.holders[code]; !held {
+			r.holders[code] = node.ID
+		}
+	}
+	return nil
+}
+
+func (r *memoryClusterRegistry) Nodes(context.Context) ([]cluster.Node, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	nodes := make([]cluster.Node, 0, len(r.nodes))
+	for _, id := range []string{"node-a", "node-b", "node-c"} {
+		if node, ok := r.nodes[id]; ok {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes, nil
+}
+
+func (r *memoryClusterRegistry) ClaimRoom(_ context.Context, code, nodeID string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return "", r.err
+	}
+	if holder, held := r.holders[code]; held {
+		return holder, nil
+	}
+	r.holders[code] = nodeID
+	return nodeID, nil
+}
+
+func (r *memoryClusterRegistry) Deregister(_ context.Context, nodeID string, codes []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.nodes, nodeID)
+	for _, code := range codes {
+		if r.holders[code] == nodeID {
+			delete(r.holders, code)
+		}
+	}
+	return nil
+}
+
+func (r *memoryClusterRegistry) setPlayers(nodeID string, players int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	node := r.nodes[nodeID]
+	node.Players = players
+	r.nodes[nodeID] = node
+}
+
+func TestClusterRedirectsNamedRoomToItsNode(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	registry := newMemoryClusterRegistry(cluster.Node{ID: "node-b", Address: "wss://b.example.com/ws"})
+	registry.holders["ELSEWHERE"] = "node-b"
+	ts.handler.cluster = newClusterRouter(registry, "node-a", "wss://a.example.com/ws")
+	ts.handler.clusterHeartbeat(context.Background())
+
+	conn := ts.connectRawClient(t)
+	defer conn.Close()
+	sendHelloMessage(t, conn, "Alpha", "code", "elsewhere")
+	msg, err := readMessageOfType(t, conn, "room:redirect", 2*time.Second)
+	require.NoError(t, err)
+	data := msg.Data.(map[string]interface{})
+	assert.Equal(t, "wss://b.example.com/ws", data["address"])
+	assert.Equal(t, RedirectReasonRoomCode, data["reason"])
+
+	conns, _, _ := joinCodeRoom(t, ts, "HERE")
+	for _, conn := range conns {
+		defer conn.Close()
+	}
+	assert.Equal(t, "node-a", registry.holders["HERE"], "a new named room is claimed for this node")
+
+	ts.handler.clusterHeartbeat(context.Background())
+	assert.Equal(t, 2, registry.nodes["node-a"].Players, "heartbeats publish this node's load")
+	assert.Equal(t, 1, registry.nodes["node-a"].Rooms)
+}
+
+func TestClusterRedirectsPublicPlayersToLeastLoadedNode(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	registry := newMemoryClusterRegistry(
+		cluster.Node{ID: "node-b", Address: "wss://b.example.com/ws", Players: 3},
+		cluster.Node{ID: "node-c", Address: "wss://c.example.com/ws", Players: 1},
+	)
+	ts.handler.cluster = newClusterRouter(registry, "node-a", "wss://a.example.com/ws")
+	ts.handler.clusterHeartbeat(context.Background())
+
+	first := ts.connectRawClient(t)
+	defer first.Close()
+	sendHelloMessage(t, first, "Alpha", "public", "")
+	_, _, err := readSessionStatus(t, first, "searching_for_match", 2*time.Second)
+	require.NoError(t, err, "an empty node is the least loaded")
+
+	registry.setPlayers("node-c", 0)
+	ts.handler.clusterHeartbeat(context.Background())
+	second := ts.connectRawClient(t)
+	defer second.Close()
+	sendHelloMessage(t, second, "Bravo", "public", "")
+	_, _, err = readSessionStatus(t, second, "match_ready", 2*time.Second)
+	require.NoError(t, err, "a player waiting here is matched here")
+
+	third := ts.connectRawClient(t)
+	defer third.Close()
+	sendHelloMessage(t, third, "Charlie", "public", "")
+	msg, err := readMessageOfType(t, third, "room:redirect", 2*time.Second)
+	require.NoError(t, err)
+	data := msg.Data.(map[string]interface{})
+	assert.Equal(t, "wss://c.example.com/ws", data["address"])
+	assert.Equal(t, RedirectReasonLeastLoaded, data["reason"])
+}
+
+func TestClusterServesRedirectedHelloHere(t *testing.T) {
+	withSchemaValidation(t)
+	ts := newTestServer()
+	defer ts.Close()
+
+	registry := newMemoryClusterRegistry(cluster.Node{ID: "node-b", Address: "wss://b.example.com/ws"})
+	registry.holders["ELSEWHERE"] = "node-b"
+	ts.handler.cluster = newClusterRouter(registry, "node-a", "wss://a.example.com/ws")
+	ts.handler.clusterHeartbeat(context.Background())
+
+	// node-b has fewer players and holds the code, but the player was
+	// already sent here once
+	public := ts.connectRawClient(t)
+	defer public.Close()
+	sendMessage(t, public, Message{Type: "player:hello", Timestamp: time.Now().UnixMilli(),
+		Data: map[string]interface{}{"displayName": "Alpha", "mode": "public", "redirected": true}})
+	_, _, err := readSessionStatus(t, public, "searching_for_match", 2*time.Second)
+	require.NoError(t, err, "a redirected public hello is matched here")
+
+	named := ts.connectRawClient(t)
+	defer named.Close()
+	sendMessage(t, named, Message{Type: "player:hello", Timestamp: time.Now().UnixMilli(),
+		Data: map[string]interface{}{"displayName": "Bravo", "mode": "code", "code": "elsewhere", "redirected": true}})
+	_, _, err = readSessionStatus(t, named, "waiting_for_players", 2*time.Second)
+	require.NoError(t, err, "a redirected named-room hello joins here")
+}
+
+func TestClusterRegistryFailureKeepsPlayersHere(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	registry := newMemoryClusterRegistry()
+	registry.err = errors.New("redis down")
+	ts.handler.cluster = newClusterRouter(registry, "node-a", "wss://a.example.com/ws")
+
+	conns, _, _ := joinCodeRoom(t, ts, "FALLBACK")
+	for _, conn := range conns {
+		conn.Close()
+	}
+}
+
+func TestClusterLoopDeregistersOnStop(t *testing.T) {
+	handler := NewWebSocketHandler()
+	registry := newMemoryClusterRegistry()
+	handler.cluster = newClusterRouter(registry, "node-a", "wss://a.example.com/ws")
+
+	handler.Start(context.Background())
+	require.Eventually(t, func() bool {
+		nodes, _ := registry.Nodes(context.Background())
+		return len(nodes) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+
+	handler.Stop()
+	nodes, err := registry.Nodes(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, nodes)
+}
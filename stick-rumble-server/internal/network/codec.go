@@ -0,0 +1,175 @@
+package network
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Wire protocol names, accepted as the ?protocol= query parameter or as a
+// WebSocket subprotocol on /ws
+const (
+	protocolJSON    = "json"
+	protocolMsgpack = "msgpack"
+)
+
+// Codec converts between a connection's wire frames and the JSON messages the
+// handler builds, validates and records. Messages stay JSON inside the server
+// so schema validation, session recording and broadcast fan-out are unchanged;
+// a codec only changes the bytes on the wire.
+type Codec interface {
+	// Name returns the protocol name the client negotiated
+	Name() string
+	// FrameType returns the WebSocket frame type used for outgoing messages
+	FrameType() int
+	// Encode converts a JSON message into a wire frame
+	Encode(jsonMessage []byte) ([]byte, error)
+	// Decode converts a wire frame from the client into a JSON message
+	Decode(frame []byte) ([]byte, error)
+}
+
+// jsonCodec sends messages as JSON text frames, the default protocol
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string   { return protocolJSON }
+func (jsonCodec) FrameType() int { return websocket.TextMessage }
+
+func (jsonCodec) Encode(jsonMessage []byte) ([]byte, error) {
+	return jsonMessage, nil
+}
+
+func (jsonCodec) Decode(frame []byte) ([]byte, error) {
+	return frame, nil
+}
+
+// msgpackCodec sends messages as MessagePack binary frames with the same
+// structure as the JSON messages. Whole-number floats are sent as integers,
+// which keeps the 20Hz state:snapshot and state:delta broadcasts small.
+type msgpackCodec struct{}
+
+func (msgpackCodec) Name() string   { return protocolMsgpack }
+func (msgpackCodec) FrameType() int { return websocket.BinaryMessage }
+
+// Encode converts a message once however many connections send it: a
+// broadcast hands every recipient the same message slice, and the frame built
+// for the first is reused for the rest.
+func (msgpackCodec) Encode(jsonMessage []byte) ([]byte, error) {
+	if frame, ok := msgpackFrames.get(jsonMessage); ok {
+		return frame, nil
+	}
+	frame, err := encodeMsgpack(jsonMessage)
+	if err != nil {
+		return nil, err
+	}
+	msgpackFrames.put(jsonMessage, frame)
+	return frame, nil
+}
+
+func encodeMsgpack(jsonMessage []byte) ([]byte, error) {
+	var value any
+	if err := json.Unmarshal(jsonMessage, &value); err != nil {
+		return nil, fmt.Errorf("decode JSON message: %w", err)
+	}
+
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.UseCompactInts(true)
+	enc.UseCompactFloats(true)
+	if err := enc.Encode(value); err != nil {
+		return nil, fmt.Errorf("encode msgpack message: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (msgpackCodec) Decode(frame []byte) ([]byte, error) {
+	var value any
+	if err := msgpack.Unmarshal(frame, &value); err != nil {
+		return nil, fmt.Errorf("decode msgpack message: %w", err)
+	}
+	return json.Marshal(value)
+}
+
+// msgpackFramesSize is how many recent messages keep their msgpack frames,
+// comfortably more than a tick's broadcasts across every room
+const msgpackFramesSize = 256
+
+var msgpackFrames = newFrameCache(msgpackFramesSize)
+
+// frameCache maps recently encoded messages to their frames. Messages are
+// keyed by the slice itself (its first byte and length), not its contents, so
+// a lookup costs nothing; holding the message keeps the key from being reused.
+type frameCache struct {
+	mu      sync.Mutex
+	entries []cachedFrame // Ring of the most recent messages
+	index   map[*byte]int
+	next    int
+}
+
+type cachedFrame struct {
+	message []byte
+	frame   []byte
+}
+
+func newFrameCache(size int) *frameCache {
+	return &frameCache{
+		entries: make([]cachedFrame, size),
+		index:   make(map[*byte]int, size),
+	}
+}
+
+func (c *frameCache) get(message []byte) ([]byte, bool) {
+	if len(message) == 0 {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i, ok := c.index[&message[0]]
+	if !ok || len(c.entries[i].message) != len(message) {
+		return nil, false
+	}
+	return c.entries[i].frame, true
+}
+
+func (c *frameCache) put(message, frame []byte) {
+	if len(message) == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if old := c.entries[c.next].message; len(old) > 0 {
+		delete(c.index, &old[0])
+	}
+	c.entries[c.next] = cachedFrame{message: message, frame: frame}
+	c.index[&message[0]] = c.next
+	c.next = (c.next + 1) % len(c.entries)
+}
+
+// codecSubprotocols lists the subprotocols the upgrader may select, in the
+// server's order of preference
+var codecSubprotocols = []string{protocolMsgpack, protocolJSON}
+
+// negotiateCodec picks the codec for a connection. An explicit ?protocol=
+// query parameter wins; otherwise the subprotocol the upgrader selected is
+// used, and connections that selected none get JSON. Called with an empty
+// subprotocol before the upgrade, it checks the query parameter.
+func negotiateCodec(query, subprotocol string) (Codec, error) {
+	protocol := query
+	if protocol == "" {
+		protocol = subprotocol
+	}
+
+	switch protocol {
+	case "", protocolJSON:
+		return jsonCodec{}, nil
+	case protocolMsgpack:
+		return msgpackCodec{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported protocol %q", protocol)
+	}
+}
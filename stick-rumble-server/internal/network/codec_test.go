@@ -0,0 +1,198 @@
+package network
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+const snapshotJSON = `{"type":"state:snapshot","timestamp":1704067200000,"data":{"players":[{"id":"p1","position":{"x":100,"y":250.5},"health":100,"isDead":false}]}}`
+
+func TestCodecsRoundTripMessages(t *testing.T) {
+	for _, codec := range []Codec{jsonCodec{}, msgpackCodec{}} {
+		t.Run(codec.Name(), func(t *testing.T) {
+			frame, err := codec.Encode([]byte(snapshotJSON))
+			require.NoError(t, err)
+
+			decoded, err := codec.Decode(frame)
+			require.NoError(t, err)
+			assert.JSONEq(t, snapshotJSON, string(decoded))
+		})
+	}
+}
+
+func TestMsgpackCodecIsSmallerThanJSON(t *testing.T) {
+	frame, err := msgpackCodec{}.Encode([]byte(snapshotJSON))
+	require.NoError(t, err)
+	assert.Less(t, len(frame), len(snapshotJSON))
+
+	var value map[string]any
+	require.NoError(t, msgpack.Unmarshal(frame, &value))
+	assert.Equal(t, "state:snapshot", value["type"])
+}
+
+func TestMsgpackCodecRejectsMalformedFrames(t *testing.T) {
+	_, err := msgpackCodec{}.Decode([]byte{0xc1})
+	assert.Error(t, err)
+
+	_, err = msgpackCodec{}.Encode([]byte("{not json"))
+	assert.Error(t, err)
+}
+
+func TestMsgpackCodecEncodesABroadcastOnce(t *testing.T) {
+	message := []byte(snapshotJSON)
+	first, err := msgpackCodec{}.Encode(message)
+	require.NoError(t, err)
+	second, err := msgpackCodec{}.Encode(message)
+	require.NoError(t, err)
+	assert.Same(t, &first[0], &second[0], "every recipient of the same message shares its frame")
+
+	copied, err := msgpackCodec{}.Encode([]byte(snapshotJSON))
+	require.NoError(t, err)
+	decodedFirst, err := msgpackCodec{}.Decode(first)
+	require.NoError(t, err)
+	decodedCopy, err := msgpackCodec{}.Decode(copied)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(decodedFirst), string(decodedCopy))
+	assert.NotSame(t, &first[0], &copied[0], "a different message is encoded afresh")
+}
+
+func TestFrameCacheForgetsOldestMessages(t *testing.T) {
+	cache := newFrameCache(2)
+	messages := [][]byte{[]byte("a"), []byte("b"), []byte("c")}
+	for _, message := range messages {
+		cache.put(message, append([]byte("frame-"), message...))
+	}
+
+	_, ok := cache.get(messages[0])
+	assert.False(t, ok, "the oldest message was evicted")
+	frame, ok := cache.get(messages[2])
+	require.True(t, ok)
+	assert.Equal(t, "frame-c", string(frame))
+	_, ok = cache.get(messages[1][:0])
+	assert.False(t, ok)
+}
+
+func TestNegotiateCodec(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		subprotocol string
+		want        string
+		wantErr     bool
+	}{
+		{name: "default is JSON", want: protocolJSON},
+		{name: "query parameter", query: protocolMsgpack, want: protocolMsgpack},
+		{name: "selected subprotocol", subprotocol: protocolMsgpack, want: protocolMsgpack},
+		{name: "query wins over subprotocol", query: protocolJSON, subprotocol: protocolMsgpack, want: protocolJSON},
+		{name: "unknown query parameter", query: "protobuf", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			codec, err := negotiateCodec(tt.query, tt.subprotocol)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, codec.Name())
+		})
+	}
+}
+
+// readMsgpackMessageOfType reads binary frames until a message of msgType arrives
+func readMsgpackMessageOfType(t *testing.T, conn *websocket.Conn, msgType string, timeout time.Duration) map[string]any {
+	t.Helper()
+
+	conn.SetReadDeadline(time.Now().Add(timeout))
+	defer conn.SetReadDeadline(time.Time{})
+	for {
+		frameType, frame, err := conn.ReadMessage()
+		require.NoError(t, err, "Should receive %s", msgType)
+		require.Equal(t, websocket.BinaryMessage, frameType)
+
+		var msg map[string]any
+		require.NoError(t, msgpack.Unmarshal(frame, &msg))
+		if msg["type"] == msgType {
+			return msg
+		}
+	}
+}
+
+func TestWebSocketMsgpackProtocol(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	dialer := websocket.Dialer{Subprotocols: []string{protocolMsgpack}}
+	conn, resp, err := dialer.Dial(ts.wsURL(), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	assert.Equal(t, protocolMsgpack, resp.Header.Get("Sec-WebSocket-Protocol"))
+
+	hello, err := msgpack.Marshal(map[string]any{
+		"type":      "player:hello",
+		"timestamp": time.Now().UnixMilli(),
+		"data":      map[string]any{"displayName": "Packed", "mode": "public"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, conn.WriteMessage(websocket.BinaryMessage, hello))
+
+	// A JSON client joins the same room so the match starts
+	jsonConn := ts.connectClient(t)
+	defer jsonConn.Close()
+
+	status := readMsgpackMessageOfType(t, conn, "session:status", 2*time.Second)
+	data, ok := status["data"].(map[string]any)
+	require.True(t, ok)
+	assert.NotEmpty(t, data["playerId"])
+
+	msg, err := readMessageOfType(t, jsonConn, "session:status", 2*time.Second)
+	require.NoError(t, err, "JSON clients keep receiving text frames")
+	assert.Equal(t, "session:status", msg.Type)
+}
+
+func TestWebSocketCodecFollowsSelectedSubprotocol(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	// The upgrader prefers msgpack whatever order the client offers them in
+	dialer := websocket.Dialer{Subprotocols: []string{protocolJSON, protocolMsgpack}}
+	conn, resp, err := dialer.Dial(ts.wsURL(), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	require.Equal(t, protocolMsgpack, resp.Header.Get("Sec-WebSocket-Protocol"))
+
+	hello := readMsgpackMessageOfType(t, conn, "server:hello", 2*time.Second)
+	assert.Equal(t, "server:hello", hello["type"])
+}
+
+func TestWebSocketRejectsUnknownProtocol(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	_, resp, err := websocket.DefaultDialer.Dial(ts.wsURL()+"?protocol=protobuf", nil)
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestWebSocketJSONIsDefaultProtocol(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn := ts.connectClient(t)
+	defer conn.Close()
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	frameType, frame, err := conn.ReadMessage()
+	require.NoError(t, err)
+	assert.Equal(t, websocket.TextMessage, frameType)
+	assert.True(t, json.Valid(frame))
+}
@@ -106,7 +106,7 @@ func TestBroadcastProjectileSpawnWithValidation(t *testing.T) {
 	}
 
 	require.NotPanics(t, func() {
-		ts.handler.broadcastProjectileSpawn(proj)
+		ts.handler.broadcastProjectileSpawn(proj, nil)
 	})
 
 	msg, err := readMessageOfType(t, conn1, "projectile:spawn", 2*time.Second)
@@ -195,6 +195,8 @@ func TestBroadcastMatchEndedWithValidation(t *testing.T) {
 
 	room := ts.handler.roomManager.GetRoomByPlayerID(player1ID)
 	require.NotNil(t, room)
+	room.Match.RecordShots(player1ID, 4)
+	room.Match.RecordShotHit(player1ID, "someone")
 	room.Match.EndMatch("test_reason")
 
 	require.NotPanics(t, func() {
@@ -204,6 +206,16 @@ func TestBroadcastMatchEndedWithValidation(t *testing.T) {
 	msg, err := readMessageOfType(t, conn1, "match:ended", 2*time.Second)
 	require.NoError(t, err)
 	assert.Equal(t, "match:ended", msg.Type)
+
+	scoreboard := msg.Data.(map[string]any)["scoreboard"].([]any)
+	require.Len(t, scoreboard, 2)
+	for _, row := range scoreboard {
+		line := row.(map[string]any)
+		if line["playerId"] == player1ID {
+			assert.Equal(t, float64(4), line["shotsFired"])
+			assert.Equal(t, 0.25, line["accuracy"])
+		}
+	}
 }
 
 // TestBroadcastWeaponPickupWithValidation tests broadcastWeaponPickup with validation enabled
@@ -315,7 +327,7 @@ func TestBroadcastMeleeHitWithValidation(t *testing.T) {
 	player2ID := consumeRoomJoinedAndGetPlayerID(t, conn2)
 
 	require.NotPanics(t, func() {
-		ts.handler.broadcastMeleeHit(player1ID, []string{player2ID}, true)
+		ts.handler.broadcastMeleeHit(player1ID, []string{player2ID}, true, 1)
 	})
 
 	msg, err := readMessageOfType(t, conn1, "melee:hit", 2*time.Second)
@@ -390,6 +402,125 @@ func TestOnHitWithValidation(t *testing.T) {
 	msg, err := readMessageOfType(t, conn2, "player:damaged", 2*time.Second)
 	require.NoError(t, err)
 	assert.Equal(t, "player:damaged", msg.Type)
+	assert.NotContains(t, msg.Data.(map[string]any), "impulse")
+}
+
+// TestOnHitImpulseWithValidation tests that a heavy weapon's push reaches
+// player:damaged and passes the schema
+func TestOnHitImpulseWithValidation(t *testing.T) {
+	withSchemaValidation(t)
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn1, conn2 := ts.connectTwoClients(t)
+	defer conn1.Close()
+	defer conn2.Close()
+
+	player1ID := consumeRoomJoinedAndGetPlayerID(t, conn1)
+	player2ID := consumeRoomJoinedAndGetPlayerID(t, conn2)
+
+	ts.handler.gameServer.SetWeaponState(player1ID, game.NewWeaponState(game.NewShotgun()))
+	ts.handler.onHit(game.HitEvent{
+		VictimID:     player2ID,
+		AttackerID:   player1ID,
+		ProjectileID: "pellet-1",
+		Damage:       7,
+		Direction:    game.Vector2{X: 1, Y: 0},
+	})
+
+	msg, err := readMessageOfType(t, conn2, "player:damaged", 2*time.Second)
+	require.NoError(t, err)
+	data := msg.Data.(map[string]any)
+	require.Contains(t, data, "impulse")
+	impulse := data["impulse"].(map[string]any)
+	assert.Equal(t, 75.0, impulse["x"])
+	assert.Equal(t, 0.0, impulse["y"])
+}
+
+func TestShieldMessagesWithValidation(t *testing.T) {
+	withSchemaValidation(t)
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn1, conn2 := ts.connectTwoClients(t)
+	defer conn1.Close()
+	defer conn2.Close()
+
+	player1ID := consumeRoomJoinedAndGetPlayerID(t, conn1)
+	player2ID := consumeRoomJoinedAndGetPlayerID(t, conn2)
+
+	ts.handler.sendShieldSpawns(player2ID)
+	msg, err := readMessageOfType(t, conn2, "shield:spawned", 2*time.Second)
+	require.NoError(t, err)
+	crates := msg.Data.(map[string]any)["crates"].([]any)
+	assert.Len(t, crates, len(game.MustDefaultMapConfig().ShieldSpawns))
+
+	ts.handler.HandleGameLoopEvent(game.ShieldPickedUpEvent{
+		PlayerID:    player2ID,
+		CrateID:     "shield_west_flank",
+		Shield:      game.ShieldPickupAmount,
+		RespawnTime: time.Now().Add(game.ShieldRespawnDelay * time.Second),
+	})
+	msg, err = readMessageOfType(t, conn2, "shield:pickup_confirmed", 2*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, float64(game.ShieldPickupAmount), msg.Data.(map[string]any)["shield"])
+
+	victim, ok := ts.handler.gameServer.GetWorld().GetPlayer(player2ID)
+	require.True(t, ok)
+	victim.AddShield(game.ShieldPickupAmount)
+	ts.handler.onHit(game.HitEvent{
+		VictimID:     player2ID,
+		AttackerID:   player1ID,
+		ProjectileID: "proj-shielded",
+	})
+
+	msg, err = readMessageOfType(t, conn2, "player:damaged", 2*time.Second)
+	require.NoError(t, err)
+	data := msg.Data.(map[string]any)
+	assert.Equal(t, float64(game.PlayerMaxHealth), data["newHealth"], "the shield absorbed the whole hit")
+	assert.Less(t, data["newShield"].(float64), float64(game.ShieldPickupAmount))
+}
+
+func TestHealthPackMessagesWithValidation(t *testing.T) {
+	withSchemaValidation(t)
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn1, conn2 := ts.connectTwoClients(t)
+	defer conn1.Close()
+	defer conn2.Close()
+
+	consumeRoomJoinedAndGetPlayerID(t, conn1)
+	player2ID := consumeRoomJoinedAndGetPlayerID(t, conn2)
+
+	ts.handler.sendHealthSpawns(player2ID)
+	msg, err := readMessageOfType(t, conn2, "health:spawned", 2*time.Second)
+	require.NoError(t, err)
+	packs := msg.Data.(map[string]any)["packs"].([]any)
+	assert.Len(t, packs, len(game.MustDefaultMapConfig().HealthSpawns))
+
+	ts.handler.HandleGameLoopEvent(game.HealthPackPickedUpEvent{
+		PlayerID:    player2ID,
+		PackID:      "health_north_east",
+		Healed:      40,
+		NewHealth:   game.PlayerMaxHealth,
+		RespawnTime: time.Now().Add(game.HealthPackRespawnDelay * time.Second),
+	})
+	msg, err = readMessageOfType(t, conn2, "health:pickup_confirmed", 2*time.Second)
+	require.NoError(t, err)
+	data := msg.Data.(map[string]any)
+	assert.Equal(t, float64(40), data["healed"])
+	assert.Equal(t, float64(game.PlayerMaxHealth), data["newHealth"])
+
+	ts.handler.HandleGameLoopEvent(game.HealthPackRespawnedEvent{
+		PackID:   "health_north_east",
+		Position: game.Vector2{X: 1320, Y: 180},
+	})
+	msg, err = readMessageOfType(t, conn2, "health:spawned", 2*time.Second)
+	require.NoError(t, err)
+	packs = msg.Data.(map[string]any)["packs"].([]any)
+	require.Len(t, packs, 1)
+	assert.Equal(t, "health_north_east", packs[0].(map[string]any)["id"])
 }
 
 // TestOnHitDeathWithValidation tests the full death chain with validation enabled
@@ -470,6 +601,63 @@ func TestProcessMeleeKillWithValidation(t *testing.T) {
 	assert.Equal(t, "player:death", msg.Type)
 }
 
+func TestKillstreakBroadcastWithValidation(t *testing.T) {
+	withSchemaValidation(t)
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn1, conn2 := ts.connectTwoClients(t)
+	defer conn1.Close()
+	defer conn2.Close()
+
+	player1ID := consumeRoomJoinedAndGetPlayerID(t, conn1)
+	player2ID := consumeRoomJoinedAndGetPlayerID(t, conn2)
+
+	ts.handler.processMeleeKill(player1ID, player2ID)
+	_, err := readMessageOfType(t, conn2, "player:kill_credit", 2*time.Second)
+	require.NoError(t, err)
+
+	ts.handler.processMeleeKill(player1ID, player2ID)
+	msg, err := readMessageOfType(t, conn2, "player:killstreak", 2*time.Second)
+	require.NoError(t, err, "the second kill without dying starts a streak")
+	data := msg.Data.(map[string]any)
+	assert.Equal(t, player1ID, data["playerId"])
+	assert.Equal(t, float64(2), data["streak"])
+	assert.Equal(t, float64(2), data["multiKill"])
+	assert.Equal(t, float64(game.KillStreakBonusXPStep+game.MultiKillBonusXPStep), data["bonusXp"])
+}
+
+func TestAssistCreditBroadcastWithValidation(t *testing.T) {
+	withSchemaValidation(t)
+	ts := newTestServer()
+	defer ts.Close()
+
+	conns, ids, _ := joinCodeRoom(t, ts, "ASSIST")
+	conn3 := ts.connectRawClient(t)
+	conns = append(conns, conn3)
+	for _, conn := range conns {
+		defer conn.Close()
+	}
+	sendHelloMessage(t, conn3, "Charlie", "code", "ASSIST")
+	_, status, err := readSessionStatus(t, conn3, "match_ready", 2*time.Second)
+	require.NoError(t, err)
+	player1ID, player2ID, player3ID := ids[0], ids[1], status["playerId"].(string)
+	conn2 := conns[1]
+
+	ts.handler.broadcastPlayerDamaged(player3ID, player2ID, 40, 60)
+	ts.handler.processMeleeKill(player1ID, player2ID)
+
+	msg, err := readMessageOfType(t, conn2, "player:assist_credit", 2*time.Second)
+	require.NoError(t, err)
+	data := msg.Data.(map[string]any)
+	assert.Equal(t, player3ID, data["playerId"])
+	assert.Equal(t, player2ID, data["victimId"])
+	assert.Equal(t, player1ID, data["killerId"])
+	assert.Equal(t, float64(40), data["damage"])
+	assert.Equal(t, float64(1), data["playerAssists"])
+	assert.Equal(t, float64(game.AssistXPReward), data["playerXP"])
+}
+
 // ==========================
 // Global HandleWebSocket function test (line 352)
 // ==========================
@@ -650,3 +838,51 @@ func TestInvalidJSONMessage(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "test", msg.Type)
 }
+
+// TestReadyStateWithValidation tests that the opening room:ready_state, with
+// no one ready yet, passes outgoing validation
+func TestReadyStateWithValidation(t *testing.T) {
+	withSchemaValidation(t)
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn1, conn2 := ts.connectTwoClients(t)
+	defer conn1.Close()
+	defer conn2.Close()
+
+	msg, err := readMessageOfType(t, conn1, "room:ready_state", 2*time.Second)
+	require.NoError(t, err, "The empty ready state should not be dropped by validation")
+	data, ok := msg.Data.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, float64(0), data["readyCount"])
+	assert.Equal(t, []interface{}{}, data["readyPlayerIds"])
+}
+
+// TestWorldSyncAfterRematchWithValidation tests that the world:sync sent when
+// a rematch restarts the match passes outgoing validation
+func TestWorldSyncAfterRematchWithValidation(t *testing.T) {
+	withSchemaValidation(t)
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn1, conn2 := ts.connectTwoClients(t)
+	defer conn1.Close()
+	defer conn2.Close()
+
+	player1ID := consumeRoomJoinedAndGetPlayerID(t, conn1)
+	_ = consumeRoomJoinedAndGetPlayerID(t, conn2)
+
+	room := ts.handler.roomManager.GetRoomByPlayerID(player1ID)
+	require.NotNil(t, room)
+	room.Match.Start()
+	room.Match.EndMatch("kill_target")
+	ts.handler.broadcastMatchEnded(room, ts.handler.gameServer.GetWorld())
+
+	sendRematchVoteMessage(t, conn1, true)
+	sendRematchVoteMessage(t, conn2, true)
+	msg, err := readMessageOfType(t, conn1, "world:sync", 2*time.Second)
+	require.NoError(t, err, "world:sync should not be dropped by validation")
+	data, ok := msg.Data.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "active", data["matchState"])
+}
@@ -75,11 +75,16 @@ func TestBroadcastMatchEndedNilMatch(t *testing.T) {
 	world := ts.handler.gameServer.GetWorld()
 	require.NotNil(t, world, "World should exist")
 
-	// Set match to nil
-	room.Match = nil
+	// A room without a match holding the same players; clearing the live
+	// room's match would race with the match timer
+	detached := game.NewTypedRoom(room.Kind, room.Code)
+	detached.Match = nil
+	for _, player := range room.GetPlayers() {
+		require.NoError(t, detached.AddPlayer(player))
+	}
 
 	// Call broadcastMatchEnded - should not panic
-	ts.handler.broadcastMatchEnded(room, world)
+	ts.handler.broadcastMatchEnded(detached, world)
 
 	// Should not receive any message (function returns early)
 	_, err := readMessageOfType(t, conn1, "match:ended", 500*time.Millisecond)
@@ -416,7 +421,7 @@ func TestHandleInputStateAfterMatchEnded(t *testing.T) {
 
 	// Call handleInputState directly - should return early without errors
 	require.NotPanics(t, func() {
-		ts.handler.handleInputState(player1ID, inputData)
+		ts.handler.handleInputState(player1ID, inputData, 0)
 	}, "handleInputState should silently ignore input after match ends")
 
 	// Verify input was NOT updated (early return on match ended)
@@ -460,3 +465,22 @@ func TestBroadcastMatchTimersNilRoom(t *testing.T) {
 }
 
 // Note: Global HandleWebSocket function is a simple one-line wrapper and is covered by integration tests
+
+// TestHandlerStopEndsLoopsWithoutCancel verifies Stop alone shuts down every
+// loop Start launched
+func TestHandlerStopEndsLoopsWithoutCancel(t *testing.T) {
+	handler := NewWebSocketHandlerWithConfig(10 * time.Millisecond)
+	handler.Start(context.Background())
+
+	stopped := make(chan struct{})
+	go func() {
+		handler.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Stop() should end the timer, sweep and game loops")
+	}
+}
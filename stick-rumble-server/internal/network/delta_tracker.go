@@ -20,14 +20,66 @@ const (
 
 	// RotationDeltaThreshold defines minimum rotation change to include in delta (radians)
 	RotationDeltaThreshold = 0.01
+
+	// MaxAckLag is how many state messages a client that acknowledges state
+	// may fall behind before it gets a full snapshot instead of a delta
+	// (10 messages = 500ms at 20Hz)
+	MaxAckLag = 10
 )
 
-// ClientState tracks the last sent state for a single client
+// ClientState tracks the baseline deltas are computed against for a single
+// client. Until the client sends state:ack the baseline is the last state
+// sent; once it acknowledges, the baseline is the last state it acknowledged.
 type ClientState struct {
 	LastSnapshot       time.Time
-	LastPlayerStates   map[string]game.PlayerStateSnapshot // playerID -> last sent state
+	LastPlayerStates   map[string]game.PlayerStateSnapshot // playerID -> baseline state
 	LastProjectileIDs  map[string]bool                     // projectileID -> exists
 	LastWeaponCrateIDs map[string]bool                     // crateID -> exists
+	Sequence           uint64                              // Sequence of the last state message sent
+	AckedSequence      uint64                              // Baseline sequence; 0 until the client acknowledges
+	frames             map[uint64]*sentFrame               // Recent unacknowledged state messages by sequence
+}
+
+// sentFrame is the state a client holds after applying one state message
+type sentFrame struct {
+	playerStates  map[string]game.PlayerStateSnapshot
+	projectileIDs map[string]bool
+}
+
+// newClientState creates tracking state for a client's first message
+func newClientState() *ClientState {
+	return &ClientState{
+		LastSnapshot:       time.Now(),
+		LastPlayerStates:   make(map[string]game.PlayerStateSnapshot),
+		LastProjectileIDs:  make(map[string]bool),
+		LastWeaponCrateIDs: make(map[string]bool),
+		frames:             make(map[uint64]*sentFrame),
+	}
+}
+
+// acking reports whether the client acknowledges state
+func (cs *ClientState) acking() bool {
+	return cs.AckedSequence > 0
+}
+
+// currentFrame returns the frame of the last message sent, starting it from
+// the baseline the first time
+func (cs *ClientState) currentFrame() *sentFrame {
+	frame, exists := cs.frames[cs.Sequence]
+	if !exists {
+		frame = &sentFrame{
+			playerStates:  make(map[string]game.PlayerStateSnapshot, len(cs.LastPlayerStates)),
+			projectileIDs: make(map[string]bool, len(cs.LastProjectileIDs)),
+		}
+		for id, state := range cs.LastPlayerStates {
+			frame.playerStates[id] = state
+		}
+		for id := range cs.LastProjectileIDs {
+			frame.projectileIDs[id] = true
+		}
+		cs.frames[cs.Sequence] = frame
+	}
+	return frame
 }
 
 // DeltaTracker tracks last sent state per client for delta compression
@@ -54,26 +106,104 @@ func (dt *DeltaTracker) ShouldSendSnapshot(clientID string) bool {
 		return true
 	}
 
+	// An acknowledging client's baseline cannot drift; it only needs a
+	// snapshot once its acknowledgements fall too far behind
+	if clientState.acking() {
+		return clientState.Sequence-clientState.AckedSequence > MaxAckLag
+	}
+
 	// Check if snapshot interval elapsed
 	return time.Since(clientState.LastSnapshot) >= SnapshotInterval
 }
 
-// UpdateLastSnapshot updates the last snapshot time for a client
+// UpdateLastSnapshot updates the last snapshot time for a client. A client
+// whose acknowledgements fell behind goes back to using the last sent state
+// as its baseline until it acknowledges again.
 func (dt *DeltaTracker) UpdateLastSnapshot(clientID string) {
 	dt.mu.Lock()
 	defer dt.mu.Unlock()
 
+	clientState := dt.clientStateLocked(clientID)
+	clientState.LastSnapshot = time.Now()
+	clientState.AckedSequence = 0
+}
+
+// SnapshotSentSince reports whether the client was sent a full snapshot at or
+// after since
+func (dt *DeltaTracker) SnapshotSentSince(clientID string, since time.Time) bool {
+	dt.mu.RLock()
+	defer dt.mu.RUnlock()
+
+	clientState, exists := dt.lastSentStates[clientID]
+	return exists && !clientState.LastSnapshot.Before(since)
+}
+
+// NextSequence numbers the next state message sent to a client
+func (dt *DeltaTracker) NextSequence(clientID string) uint64 {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	clientState := dt.clientStateLocked(clientID)
+	clientState.Sequence++
+
+	// Forget frames too old to be acknowledged into a baseline
+	for seq := range clientState.frames {
+		if clientState.Sequence-seq > MaxAckLag {
+			delete(clientState.frames, seq)
+		}
+	}
+	return clientState.Sequence
+}
+
+// BaseSequence returns the sequence deltas to a client are computed against,
+// or 0 if the client does not acknowledge state
+func (dt *DeltaTracker) BaseSequence(clientID string) uint64 {
+	dt.mu.RLock()
+	defer dt.mu.RUnlock()
+
+	if clientState, exists := dt.lastSentStates[clientID]; exists {
+		return clientState.AckedSequence
+	}
+	return 0
+}
+
+// Acknowledge records that a client applied the state message with the given
+// sequence, making that state its baseline. Acknowledgements older than the
+// current baseline, for messages never sent, or for frames already forgotten
+// are ignored. Returns true if the baseline moved.
+func (dt *DeltaTracker) Acknowledge(clientID string, seq uint64) bool {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
 	clientState, exists := dt.lastSentStates[clientID]
+	if !exists || seq <= clientState.AckedSequence || seq > clientState.Sequence {
+		return false
+	}
+	frame, exists := clientState.frames[seq]
 	if !exists {
-		clientState = &ClientState{
-			LastPlayerStates:   make(map[string]game.PlayerStateSnapshot),
-			LastProjectileIDs:  make(map[string]bool),
-			LastWeaponCrateIDs: make(map[string]bool),
+		return false
+	}
+
+	clientState.LastPlayerStates = frame.playerStates
+	clientState.LastProjectileIDs = frame.projectileIDs
+	clientState.AckedSequence = seq
+	for frameSeq := range clientState.frames {
+		if frameSeq <= seq {
+			delete(clientState.frames, frameSeq)
 		}
-		dt.lastSentStates[clientID] = clientState
 	}
+	return true
+}
 
-	clientState.LastSnapshot = time.Now()
+// clientStateLocked returns a client's tracking state, creating it if needed.
+// Callers hold dt.mu for writing.
+func (dt *DeltaTracker) clientStateLocked(clientID string) *ClientState {
+	clientState, exists := dt.lastSentStates[clientID]
+	if !exists {
+		clientState = newClientState()
+		dt.lastSentStates[clientID] = clientState
+	}
+	return clientState
 }
 
 // ComputePlayerDelta computes the delta between current and last sent player states
@@ -137,8 +267,12 @@ func stateChanged(current, last game.PlayerStateSnapshot) bool {
 		return true
 	}
 
-	// Check health change
-	if current.Health != last.Health {
+	// Check health, shield, stamina and class changes
+	if current.Health != last.Health ||
+		current.Shield != last.Shield ||
+		current.Stamina != last.Stamina ||
+		current.MaxHealth != last.MaxHealth ||
+		current.Class != last.Class {
 		return true
 	}
 
@@ -159,28 +293,34 @@ func stateChanged(current, last game.PlayerStateSnapshot) bool {
 		return true
 	}
 
+	// Check ultimate meter changes
+	if current.UltimateCharge != last.UltimateCharge ||
+		current.UltimateActive != last.UltimateActive {
+		return true
+	}
+
+	// A teleport flag always goes out, even for a jump to a nearby point
+	if current.Teleport != last.Teleport {
+		return true
+	}
+
 	return false
 }
 
-// UpdatePlayerState updates the last sent player state for a client
+// UpdatePlayerState records the player states sent to a client
 func (dt *DeltaTracker) UpdatePlayerState(clientID string, states []game.PlayerStateSnapshot) {
 	dt.mu.Lock()
 	defer dt.mu.Unlock()
 
-	clientState, exists := dt.lastSentStates[clientID]
-	if !exists {
-		clientState = &ClientState{
-			LastSnapshot:       time.Now(),
-			LastPlayerStates:   make(map[string]game.PlayerStateSnapshot),
-			LastProjectileIDs:  make(map[string]bool),
-			LastWeaponCrateIDs: make(map[string]bool),
-		}
-		dt.lastSentStates[clientID] = clientState
-	}
+	clientState := dt.clientStateLocked(clientID)
+	frame := clientState.currentFrame()
 
-	// Update player states
+	// Update player states; an acknowledging client's baseline waits for its ack
 	for _, state := range states {
-		clientState.LastPlayerStates[state.ID] = state
+		frame.playerStates[state.ID] = state
+		if !clientState.acking() {
+			clientState.LastPlayerStates[state.ID] = state
+		}
 	}
 }
 
@@ -218,26 +358,24 @@ func (dt *DeltaTracker) ComputeProjectileDelta(clientID string, currentProjectil
 	return added, removed
 }
 
-// UpdateProjectileState updates the last sent projectile state for a client
+// UpdateProjectileState records the projectiles sent to a client
 func (dt *DeltaTracker) UpdateProjectileState(clientID string, projectiles []game.ProjectileSnapshot) {
 	dt.mu.Lock()
 	defer dt.mu.Unlock()
 
-	clientState, exists := dt.lastSentStates[clientID]
-	if !exists {
-		clientState = &ClientState{
-			LastSnapshot:       time.Now(),
-			LastPlayerStates:   make(map[string]game.PlayerStateSnapshot),
-			LastProjectileIDs:  make(map[string]bool),
-			LastWeaponCrateIDs: make(map[string]bool),
-		}
-		dt.lastSentStates[clientID] = clientState
-	}
+	clientState := dt.clientStateLocked(clientID)
+	frame := clientState.currentFrame()
 
 	// Reset and rebuild projectile set
-	clientState.LastProjectileIDs = make(map[string]bool)
+	frame.projectileIDs = make(map[string]bool, len(projectiles))
 	for _, proj := range projectiles {
-		clientState.LastProjectileIDs[proj.ID] = true
+		frame.projectileIDs[proj.ID] = true
+	}
+	if !clientState.acking() {
+		clientState.LastProjectileIDs = make(map[string]bool, len(projectiles))
+		for id := range frame.projectileIDs {
+			clientState.LastProjectileIDs[id] = true
+		}
 	}
 }
 
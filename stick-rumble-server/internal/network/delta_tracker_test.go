@@ -201,6 +201,24 @@ func TestDeltaTracker_WeaponTypeChange(t *testing.T) {
 	}
 }
 
+func TestDeltaTracker_TeleportFlag(t *testing.T) {
+	tracker := NewDeltaTracker()
+	playerID := "player1"
+
+	tracker.UpdatePlayerState(playerID, []game.PlayerStateSnapshot{
+		{ID: "player1", Position: game.Vector2{X: 100, Y: 100}, Health: 100},
+	})
+
+	// A respawn right next to where the player died is still a teleport
+	respawned := []game.PlayerStateSnapshot{
+		{ID: "player1", Position: game.Vector2{X: 100.5, Y: 100}, Health: 100, Teleport: game.TeleportRespawn},
+	}
+	delta := tracker.ComputePlayerDelta(playerID, respawned)
+	if len(delta) != 1 || delta[0].Teleport != game.TeleportRespawn {
+		t.Fatalf("Expected delta with the respawn teleport flag, got %+v", delta)
+	}
+}
+
 // TestDeltaTracker_ProjectileDelta tests projectile delta calculation
 func TestDeltaTracker_ProjectileDelta(t *testing.T) {
 	tracker := NewDeltaTracker()
@@ -464,3 +482,95 @@ func TestDeltaTracker_StatsChange(t *testing.T) {
 		t.Errorf("Expected XP 100, got %d", delta[0].XP)
 	}
 }
+
+// sendTrackedState records one sequenced state message to a client
+func sendTrackedState(tracker *DeltaTracker, clientID string, states []game.PlayerStateSnapshot, projectiles []game.ProjectileSnapshot) uint64 {
+	seq := tracker.NextSequence(clientID)
+	tracker.UpdatePlayerState(clientID, states)
+	tracker.UpdateProjectileState(clientID, projectiles)
+	return seq
+}
+
+// TestDeltaTracker_AckedBaseline tests that deltas are computed against the
+// last acknowledged state once a client acknowledges
+func TestDeltaTracker_AckedBaseline(t *testing.T) {
+	tracker := NewDeltaTracker()
+	clientID := "player1"
+	at := func(x float64) []game.PlayerStateSnapshot {
+		return []game.PlayerStateSnapshot{{ID: "player1", Position: game.Vector2{X: x, Y: 100}, Health: 100}}
+	}
+	proj := []game.ProjectileSnapshot{{ID: "proj1", OwnerID: "player1"}}
+
+	first := sendTrackedState(tracker, clientID, at(100), nil)
+	tracker.UpdateLastSnapshot(clientID)
+	if !tracker.Acknowledge(clientID, first) {
+		t.Fatal("Expected the first acknowledgement to set the baseline")
+	}
+	if tracker.BaseSequence(clientID) != first {
+		t.Errorf("Expected base sequence %d, got %d", first, tracker.BaseSequence(clientID))
+	}
+
+	// Unacknowledged messages do not move the baseline
+	sendTrackedState(tracker, clientID, at(200), proj)
+	third := sendTrackedState(tracker, clientID, at(200), proj)
+	if delta := tracker.ComputePlayerDelta(clientID, at(200)); len(delta) != 1 {
+		t.Errorf("Expected the move to repeat until acknowledged, got %d players", len(delta))
+	}
+	if added, _ := tracker.ComputeProjectileDelta(clientID, proj); len(added) != 1 {
+		t.Errorf("Expected the projectile to repeat until acknowledged, got %d", len(added))
+	}
+
+	// Stale, future and forgotten acknowledgements are ignored
+	if tracker.Acknowledge(clientID, first) || tracker.Acknowledge(clientID, third+1) || tracker.Acknowledge("ghost", 1) {
+		t.Error("Expected stale, future and unknown acknowledgements to be ignored")
+	}
+
+	if !tracker.Acknowledge(clientID, third) {
+		t.Fatal("Expected the acknowledgement to move the baseline")
+	}
+	if delta := tracker.ComputePlayerDelta(clientID, at(200)); len(delta) != 0 {
+		t.Errorf("Expected no delta against the acknowledged state, got %d players", len(delta))
+	}
+	if added, removed := tracker.ComputeProjectileDelta(clientID, nil); len(added) != 0 || len(removed) != 1 {
+		t.Errorf("Expected only the projectile removal, got %d added and %d removed", len(added), len(removed))
+	}
+}
+
+// TestDeltaTracker_AckLagFallsBackToSnapshot tests that a client whose
+// acknowledgements fall behind gets a full snapshot
+func TestDeltaTracker_AckLagFallsBackToSnapshot(t *testing.T) {
+	tracker := NewDeltaTracker()
+	clientID := "player1"
+	states := []game.PlayerStateSnapshot{{ID: "player1", Health: 100}}
+
+	seq := sendTrackedState(tracker, clientID, states, nil)
+	tracker.UpdateLastSnapshot(clientID)
+	tracker.Acknowledge(clientID, seq)
+
+	// An acknowledging client needs no periodic snapshot
+	tracker.lastSentStates[clientID].LastSnapshot = time.Now().Add(-SnapshotInterval - 10*time.Millisecond)
+	for i := 0; i < MaxAckLag; i++ {
+		if tracker.ShouldSendSnapshot(clientID) {
+			t.Fatalf("Expected deltas while %d messages behind", i)
+		}
+		sendTrackedState(tracker, clientID, states, nil)
+	}
+
+	sendTrackedState(tracker, clientID, states, nil)
+	if !tracker.ShouldSendSnapshot(clientID) {
+		t.Fatal("Expected a snapshot once acknowledgements lag too far")
+	}
+	if frames := len(tracker.lastSentStates[clientID].frames); frames > MaxAckLag+1 {
+		t.Errorf("Expected frames older than the lag limit to be forgotten, have %d", frames)
+	}
+
+	// The snapshot resets the baseline to the last sent state until the next ack
+	sendTrackedState(tracker, clientID, states, nil)
+	tracker.UpdateLastSnapshot(clientID)
+	if tracker.BaseSequence(clientID) != 0 {
+		t.Errorf("Expected no base sequence after the fallback snapshot, got %d", tracker.BaseSequence(clientID))
+	}
+	if tracker.ShouldSendSnapshot(clientID) {
+		t.Error("Expected deltas right after the fallback snapshot")
+	}
+}
@@ -0,0 +1,41 @@
+package network
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayerEmoteReachesTheRoom(t *testing.T) {
+	withSchemaValidation(t)
+	f := newGoldenFixture(t)
+	f.handler.gameServer.AddPlayer("player-a")
+	f.drain()
+
+	f.handler.handlePlayerEmote(f.sender, map[string]interface{}{"emote": "gg"})
+
+	var msg Message
+	require.NoError(t, json.Unmarshal(f.received(t, "player:emoted"), &msg))
+	assert.Equal(t, map[string]interface{}{"playerId": "player-a", "emote": "gg"}, msg.Data)
+}
+
+func TestPlayerEmoteRejectsUnknownIDsAndFloods(t *testing.T) {
+	withSchemaValidation(t)
+	f := newGoldenFixture(t)
+	f.handler.gameServer.AddPlayer("player-b")
+	emote := func(id string) {
+		f.handler.handlePlayerEmote(f.receiver, map[string]interface{}{"emote": id})
+	}
+
+	emote("you are all terrible")
+	f.handler.handlePlayerEmote(f.receiver, map[string]interface{}{})
+	assert.Empty(t, f.receiver.SendChan, "free text never reaches the room")
+
+	for i := 0; i < game.EmoteCharges+2; i++ {
+		emote("wave")
+	}
+	assert.Len(t, f.receiver.SendChan, game.EmoteCharges, "emotes past the burst are dropped")
+}
@@ -0,0 +1,111 @@
+package network
+
+import (
+	"sort"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+// Kinds of entity in entity:removed
+const (
+	entityKindPlayer     = "player"
+	entityKindProjectile = "projectile"
+)
+
+// removedEntityData is one entity in entity:removed
+type removedEntityData struct {
+	Kind string `json:"kind"`
+	ID   string `json:"id"`
+}
+
+// entityRemovedData tells a room's clients which entities its state stopped
+// carrying, so they can drop them instead of waiting for them to time out
+type entityRemovedData struct {
+	Tick     uint64              `json:"tick"`
+	Entities []removedEntityData `json:"entities"`
+}
+
+// stampedPlayer is a player's state as of the tick it last changed beyond
+// the delta thresholds
+type stampedPlayer struct {
+	state game.PlayerStateSnapshot
+	tick  uint64
+	frame uint64 // The room frame that last carried the player
+}
+
+// roomEntities is what one room's state broadcast carried, by the frame that
+// last carried each entity
+type roomEntities struct {
+	frame       uint64
+	players     map[string]stampedPlayer
+	projectiles map[string]uint64
+}
+
+// entityTracker follows the entities in each room's state broadcast from one
+// frame to the next. It stamps players with the tick their state last
+// changed and finds the entities a frame no longer carries. It is only used
+// by broadcastPlayerStates, under the broadcast buffers' lock.
+type entityTracker struct {
+	rooms map[string]*roomEntities
+}
+
+// update stamps frame's players with LastUpdated and returns the players and
+// projectiles the room's previous frame carried that this one does not,
+// players first, each kind in ID order
+func (t *entityTracker) update(roomID string, frame *broadcastFrame) []removedEntityData {
+	if t.rooms == nil {
+		t.rooms = make(map[string]*roomEntities)
+	}
+	room, ok := t.rooms[roomID]
+	if !ok {
+		room = &roomEntities{players: make(map[string]stampedPlayer), projectiles: make(map[string]uint64)}
+		t.rooms[roomID] = room
+	}
+	room.frame++
+
+	for i := range frame.players {
+		state := &frame.players[i]
+		stamped, seen := room.players[state.ID]
+		if !seen || stateChanged(*state, stamped.state) {
+			stamped.state = *state
+			stamped.tick = frame.tick
+		}
+		stamped.frame = room.frame
+		room.players[state.ID] = stamped
+		state.LastUpdated = stamped.tick
+	}
+	for _, proj := range frame.projectiles {
+		room.projectiles[proj.ID] = room.frame
+	}
+
+	var removed []removedEntityData
+	for id, stamped := range room.players {
+		if stamped.frame != room.frame {
+			delete(room.players, id)
+			removed = append(removed, removedEntityData{Kind: entityKindPlayer, ID: id})
+		}
+	}
+	for id, lastFrame := range room.projectiles {
+		if lastFrame != room.frame {
+			delete(room.projectiles, id)
+			removed = append(removed, removedEntityData{Kind: entityKindProjectile, ID: id})
+		}
+	}
+	// "player" sorts before "projectile"
+	sort.Slice(removed, func(i, j int) bool {
+		if removed[i].Kind != removed[j].Kind {
+			return removed[i].Kind < removed[j].Kind
+		}
+		return removed[i].ID < removed[j].ID
+	})
+	return removed
+}
+
+// closeRemovedRooms forgets the rooms that no longer exist
+func (t *entityTracker) closeRemovedRooms(rooms *game.RoomManager) {
+	for roomID := range t.rooms {
+		if rooms.GetRoom(roomID) == nil {
+			delete(t.rooms, roomID)
+		}
+	}
+}
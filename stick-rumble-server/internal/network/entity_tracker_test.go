@@ -0,0 +1,98 @@
+package network
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func trackerFrame(tick uint64, players []game.PlayerStateSnapshot, projectileIDs ...string) *broadcastFrame {
+	frame := &broadcastFrame{tick: tick, players: players}
+	for _, id := range projectileIDs {
+		frame.projectiles = append(frame.projectiles, game.ProjectileSnapshot{ID: id})
+	}
+	return frame
+}
+
+func TestEntityTrackerStampsLastChange(t *testing.T) {
+	var tracker entityTracker
+	players := func(x float64) []game.PlayerStateSnapshot {
+		return []game.PlayerStateSnapshot{{ID: "p1", Position: game.Vector2{X: x}}, {ID: "p2"}}
+	}
+
+	frame := trackerFrame(10, players(0))
+	assert.Empty(t, tracker.update("room", frame))
+	assert.Equal(t, uint64(10), frame.players[0].LastUpdated)
+
+	// p1 moves below the delta threshold, then past it
+	frame = trackerFrame(13, players(PositionDeltaThreshold/2))
+	tracker.update("room", frame)
+	assert.Equal(t, uint64(10), frame.players[0].LastUpdated)
+	frame = trackerFrame(16, players(PositionDeltaThreshold*2))
+	tracker.update("room", frame)
+	assert.Equal(t, uint64(16), frame.players[0].LastUpdated)
+	assert.Equal(t, uint64(10), frame.players[1].LastUpdated, "p2 never changed")
+}
+
+func TestEntityTrackerReportsRemovedEntities(t *testing.T) {
+	var tracker entityTracker
+	both := []game.PlayerStateSnapshot{{ID: "p1"}, {ID: "p2"}}
+	assert.Empty(t, tracker.update("room", trackerFrame(1, both, "proj-2", "proj-1")))
+	assert.Empty(t, tracker.update("other", trackerFrame(1, []game.PlayerStateSnapshot{{ID: "p3"}})))
+
+	removed := tracker.update("room", trackerFrame(2, []game.PlayerStateSnapshot{{ID: "p1"}}))
+	assert.Equal(t, []removedEntityData{
+		{Kind: entityKindPlayer, ID: "p2"},
+		{Kind: entityKindProjectile, ID: "proj-1"},
+		{Kind: entityKindProjectile, ID: "proj-2"},
+	}, removed)
+	assert.Empty(t, tracker.update("room", trackerFrame(3, []game.PlayerStateSnapshot{{ID: "p1"}})), "each removal is reported once")
+
+	// A player who comes back is new again
+	frame := trackerFrame(4, both)
+	assert.Empty(t, tracker.update("room", frame))
+	assert.Equal(t, uint64(4), frame.players[1].LastUpdated)
+
+	rooms := game.NewRoomManager()
+	tracker.closeRemovedRooms(rooms)
+	assert.Empty(t, tracker.rooms)
+}
+
+func TestBroadcastSendsEntityRemovedBeforeState(t *testing.T) {
+	handler := NewWebSocketHandler()
+	stayer := game.NewPlayer("stayer", make(chan []byte, 64))
+	leaver := game.NewPlayer("leaver", make(chan []byte, 64))
+	for _, player := range []*game.Player{stayer, leaver} {
+		handler.roomManager.AddCodePlayer(player, "GCTEST")
+		handler.gameServer.AddPlayer(player.ID)
+	}
+	handler.broadcastPlayerStates(handler.gameServer.GetAllPlayerStates())
+
+	handler.roomManager.RemovePlayer(leaver.ID)
+	handler.gameServer.RemovePlayer(leaver.ID)
+	stayer.Outbox.Drain()
+	states := handler.gameServer.GetAllPlayerStates()
+	require.Len(t, states, 1)
+	states[0].Position.X += 10 // So the stayer gets a delta
+	handler.broadcastPlayerStates(states)
+
+	var types []string
+	var removed entityRemovedData
+	for len(stayer.SendChan) > 0 {
+		var msg struct {
+			Type string          `json:"type"`
+			Data json.RawMessage `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(<-stayer.SendChan, &msg))
+		types = append(types, msg.Type)
+		if msg.Type == "entity:removed" {
+			require.NoError(t, json.Unmarshal(msg.Data, &removed))
+		}
+	}
+	assert.Equal(t, []string{"entity:removed", "state:delta"}, types, "sent just ahead of the state")
+	assert.Equal(t, []removedEntityData{{Kind: entityKindPlayer, ID: leaver.ID}}, removed.Entities)
+	assert.Equal(t, handler.gameServer.Tick(), removed.Tick)
+}
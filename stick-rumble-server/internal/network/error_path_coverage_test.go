@@ -134,7 +134,7 @@ func TestBroadcastProjectileSpawnNilProjectile(t *testing.T) {
 
 	// Should not panic when broadcasting nil projectile - function returns early
 	require.NotPanics(t, func() {
-		handler.broadcastProjectileSpawn(nil)
+		handler.broadcastProjectileSpawn(nil, nil)
 	}, "Should handle nil projectile without panic")
 
 	// Verify early return: no broadcasts should occur
@@ -234,7 +234,7 @@ func TestBroadcastMeleeHitPlayerNotInRoom(t *testing.T) {
 	// Call with attacker not in any room
 	victimIDs := []string{"victim-id"}
 	require.NotPanics(t, func() {
-		handler.broadcastMeleeHit("orphan-attacker", victimIDs, true)
+		handler.broadcastMeleeHit("orphan-attacker", victimIDs, true, 1)
 	}, "Should handle attacker not in room without panic")
 
 	// Verify early return: attacker not in any room
@@ -296,7 +296,7 @@ func TestHandleInputStateSchemaValidationFail(t *testing.T) {
 
 	// Call handleInputState - should handle validation failure gracefully
 	require.NotPanics(t, func() {
-		ts.handler.handleInputState(player1ID, invalidData)
+		ts.handler.handleInputState(player1ID, invalidData, 0)
 	}, "Should handle schema validation failure gracefully")
 
 	// Verify input was NOT updated (early return on validation failure)
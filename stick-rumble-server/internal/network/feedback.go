@@ -0,0 +1,121 @@
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/mtomcal/stick-rumble-server/internal/buildinfo"
+)
+
+// feedbackFileName is the JSON-lines file feedback is appended to
+const feedbackFileName = "feedback.jsonl"
+
+// FeedbackEntry is one feedback:submit, stored with the match it was sent
+// from and the server build that ran it, so designers can line ratings up
+// with what was being played
+type FeedbackEntry struct {
+	PlayerID    string         `json:"playerId"`
+	DisplayName string         `json:"displayName"`
+	RoomID      string         `json:"roomId,omitempty"`
+	MatchID     string         `json:"matchId,omitempty"` // Empty when the player's match had not started
+	Rating      int            `json:"rating"`
+	Text        string         `json:"text,omitempty"`
+	Build       buildinfo.Info `json:"build"`
+	SubmittedAt time.Time      `json:"submittedAt"`
+}
+
+// feedbackStore persists playtest feedback
+type feedbackStore interface {
+	SaveFeedback(entry FeedbackEntry) error
+}
+
+// fileFeedbackStore appends each entry as a line of feedback.jsonl in its
+// directory. Feedback is rare, so the file is opened per entry rather than
+// held open.
+type fileFeedbackStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+func newFileFeedbackStore(dir string) *fileFeedbackStore {
+	if dir == "" {
+		dir = "feedback"
+	}
+	return &fileFeedbackStore{dir: dir}
+}
+
+func (s *fileFeedbackStore) SaveFeedback(entry FeedbackEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("create feedback directory: %w", err)
+	}
+	file, err := os.OpenFile(filepath.Join(s.dir, feedbackFileName), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open feedback file: %w", err)
+	}
+	if err := json.NewEncoder(file).Encode(entry); err != nil {
+		file.Close()
+		return fmt.Errorf("write feedback: %w", err)
+	}
+	return file.Close()
+}
+
+// feedbackCollector accepts at most one feedback:submit per player per
+// cooldown and hands it to the store
+type feedbackCollector struct {
+	store    feedbackStore
+	cooldown time.Duration
+	last     map[string]time.Time // player ID -> last accepted submission within the cooldown
+	now      func() time.Time
+	mu       sync.Mutex
+}
+
+func newFeedbackCollector(store feedbackStore, cooldown time.Duration, now func() time.Time) *feedbackCollector {
+	return &feedbackCollector{
+		store:    store,
+		cooldown: cooldown,
+		last:     make(map[string]time.Time),
+		now:      now,
+	}
+}
+
+// allow reports whether the player may submit feedback now, and if so starts
+// its cooldown. Expired cooldowns are dropped as it goes, so players who left
+// are not kept.
+func (c *feedbackCollector) allow(playerID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for id, at := range c.last {
+		if now.Sub(at) >= c.cooldown {
+			delete(c.last, id)
+		}
+	}
+	if _, limited := c.last[playerID]; limited {
+		return false
+	}
+	if c.cooldown > 0 {
+		c.last[playerID] = now
+	}
+	return true
+}
+
+// sanitizeFeedbackText trims the text and drops control characters other
+// than line breaks
+func sanitizeFeedbackText(text string) string {
+	return strings.TrimSpace(strings.Map(func(r rune) rune {
+		if r != '\n' && unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, text))
+}
@@ -0,0 +1,121 @@
+package network
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/mtomcal/stick-rumble-server/internal/buildinfo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryFeedbackStore struct {
+	entries []FeedbackEntry
+	mu      sync.Mutex
+}
+
+func (s *memoryFeedbackStore) SaveFeedback(entry FeedbackEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func (s *memoryFeedbackStore) saved() []FeedbackEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]FeedbackEntry{}, s.entries...)
+}
+
+func sendFeedbackMessage(t *testing.T, conn *websocket.Conn, data map[string]interface{}) {
+	sendMessage(t, conn, Message{
+		Type:      "feedback:submit",
+		Timestamp: time.Now().UnixMilli(),
+		Data:      data,
+	})
+}
+
+func TestFileFeedbackStoreAppendsJSONLines(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "feedback")
+	store := newFileFeedbackStore(dir)
+
+	require.NoError(t, store.SaveFeedback(FeedbackEntry{PlayerID: "p1", Rating: 4, Text: "Fun"}))
+	require.NoError(t, store.SaveFeedback(FeedbackEntry{PlayerID: "p2", Rating: 2}))
+
+	file, err := os.Open(filepath.Join(dir, feedbackFileName))
+	require.NoError(t, err)
+	defer file.Close()
+
+	var entries []FeedbackEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry FeedbackEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	require.Len(t, entries, 2)
+	assert.Equal(t, "Fun", entries[0].Text)
+	assert.Equal(t, "p2", entries[1].PlayerID)
+}
+
+func TestFeedbackCollectorEnforcesCooldown(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	collector := newFeedbackCollector(&memoryFeedbackStore{}, time.Minute, func() time.Time { return now })
+
+	assert.True(t, collector.allow("p1"))
+	assert.False(t, collector.allow("p1"))
+	assert.True(t, collector.allow("p2"), "the cooldown is per player")
+
+	now = now.Add(time.Minute)
+	assert.True(t, collector.allow("p1"))
+}
+
+func TestSanitizeFeedbackText(t *testing.T) {
+	assert.Equal(t, "Too much\nrecoil", sanitizeFeedbackText("  Too\x07 much\nrecoil\t "))
+}
+
+func TestFeedbackSubmitStoresMatchAndBuild(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	store := &memoryFeedbackStore{}
+	ts.handler.feedback = newFeedbackCollector(store, time.Minute, time.Now)
+
+	conns, ids, roomID := joinCodeRoom(t, ts, "FEEDBACK")
+	for _, conn := range conns {
+		defer conn.Close()
+	}
+
+	// Code rooms wait for a ready check before their match starts
+	match := ts.handler.roomManager.GetRoom(roomID).Match
+	match.Start()
+
+	sendFeedbackMessage(t, conns[0], map[string]interface{}{"rating": 4, "text": "  Shotgun feels strong\x00 "})
+	require.Eventually(t, func() bool { return len(store.saved()) == 1 }, 2*time.Second, 10*time.Millisecond)
+
+	entry := store.saved()[0]
+	assert.Equal(t, ids[0], entry.PlayerID)
+	assert.Equal(t, "Alpha", entry.DisplayName)
+	assert.Equal(t, roomID, entry.RoomID)
+	assert.NotEmpty(t, entry.MatchID)
+	assert.Equal(t, match.GetID(), entry.MatchID)
+	assert.Equal(t, 4, entry.Rating)
+	assert.Equal(t, "Shotgun feels strong", entry.Text)
+	assert.Equal(t, buildinfo.Get(), entry.Build)
+
+	// A second submission within the cooldown and an invalid rating are dropped
+	sendFeedbackMessage(t, conns[0], map[string]interface{}{"rating": 5})
+	sendFeedbackMessage(t, conns[1], map[string]interface{}{"rating": 9})
+	sendMessage(t, conns[0], Message{Type: "room:roster_request", Timestamp: time.Now().UnixMilli()})
+	sendMessage(t, conns[1], Message{Type: "room:roster_request", Timestamp: time.Now().UnixMilli()})
+	for _, conn := range conns {
+		_, err := readMessageOfType(t, conn, "room:roster", 2*time.Second)
+		require.NoError(t, err)
+	}
+	assert.Len(t, store.saved(), 1)
+}
@@ -32,10 +32,10 @@ func TestBroadcastMatchTimersTimeLimitReached(t *testing.T) {
 	require.NotNil(t, room, "Room should exist")
 	require.NotNil(t, room.Match, "Match should exist")
 
-	// Start the match and then set start time to past so time limit is reached
+	// Start the match and then run its clock past the time limit
 	// Match duration is 420 seconds (7 minutes)
 	room.Match.Start()
-	room.Match.StartTime = time.Now().Add(-421 * time.Second)
+	room.Match.AdvanceTicks(421 * 1000 / game.ServerTickInterval)
 
 	// Call broadcastMatchTimers - should trigger time limit check
 	ts.handler.broadcastMatchTimers()
@@ -307,7 +307,7 @@ func TestHandleInputStateUpdatePlayerInputFail(t *testing.T) {
 	// Call handleInputState with non-existent player
 	// Should trigger line 38-41 (UpdatePlayerInput fails)
 	require.NotPanics(t, func() {
-		handler.handleInputState("non-existent-player", inputData)
+		handler.handleInputState("non-existent-player", inputData, 0)
 	}, "Should handle UpdatePlayerInput failure gracefully")
 
 	// Verify player doesn't exist
@@ -341,7 +341,7 @@ func TestBroadcastProjectileSpawnSchemaValidationError(t *testing.T) {
 	// Call broadcastProjectileSpawn
 	// Schema validation errors are logged but don't prevent broadcast
 	require.NotPanics(t, func() {
-		ts.handler.broadcastProjectileSpawn(projectile)
+		ts.handler.broadcastProjectileSpawn(projectile, nil)
 	}, "Should handle schema validation gracefully")
 
 	// Should receive projectile:spawn message (broadcast continues even if validation logs error)
@@ -512,7 +512,7 @@ func TestBroadcastMeleeHitSchemaValidation(t *testing.T) {
 	// Call broadcastMeleeHit
 	victimIDs := []string{player2ID}
 	require.NotPanics(t, func() {
-		ts.handler.broadcastMeleeHit(player1ID, victimIDs, true)
+		ts.handler.broadcastMeleeHit(player1ID, victimIDs, true, 1)
 	}, "Should handle broadcast gracefully")
 
 	// Should receive melee:hit message
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/mtomcal/stick-rumble-server/internal/game"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -186,3 +187,34 @@ func TestHandleGameLoopEvent_StateOutcomeRoutes(t *testing.T) {
 	assert.Equal(t, "crate-1", weaponRespawnData["crateId"])
 	assert.Equal(t, "Shotgun", weaponRespawnData["weaponType"])
 }
+
+func TestHandleGameLoopEvent_ProjectileExplodedBroadcastsToAll(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn1, conn2 := ts.connectTwoClients(t)
+	defer conn1.Close()
+	defer conn2.Close()
+
+	player1ID := consumeRoomJoinedAndGetPlayerID(t, conn1)
+	_ = consumeRoomJoinedAndGetPlayerID(t, conn2)
+
+	ts.handler.HandleGameLoopEvent(game.ProjectileExplodedEvent{
+		ProjectileID: "rocket-1",
+		OwnerID:      player1ID,
+		WeaponType:   "RocketLauncher",
+		Position:     game.Vector2{X: 400, Y: 300},
+		Radius:       game.RocketLauncherSplashRadius,
+	})
+
+	for _, conn := range []*websocket.Conn{conn1, conn2} {
+		explodeMsg, err := readMessageOfType(t, conn, "projectile:explode", 2*time.Second)
+		require.NoError(t, err)
+		explodeData, ok := explodeMsg.Data.(map[string]interface{})
+		require.True(t, ok)
+		assert.Equal(t, "rocket-1", explodeData["projectileId"])
+		assert.Equal(t, player1ID, explodeData["ownerId"])
+		assert.Equal(t, "RocketLauncher", explodeData["weaponType"])
+		assert.Equal(t, game.RocketLauncherSplashRadius, explodeData["radius"])
+	}
+}
@@ -2,7 +2,9 @@ package network
 
 import (
 	"testing"
+	"time"
 
+	"github.com/mtomcal/stick-rumble-server/internal/game"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -12,6 +14,15 @@ import (
 // Tests for handleInputState function to cover lines 27-41
 // ==========================
 
+// waitForAppliedAim waits for the game loop to apply a queued input aiming at
+// aimAngle; inputs are applied on the tick after they are queued
+func waitForAppliedAim(t *testing.T, player *game.PlayerState, aimAngle float64) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		return player.GetInput().AimAngle == aimAngle
+	}, time.Second, time.Millisecond)
+}
+
 // TestHandleInputStateSuccess tests successful input state update
 func TestHandleInputStateSuccess(t *testing.T) {
 	ts := newTestServer()
@@ -43,11 +54,11 @@ func TestHandleInputStateSuccess(t *testing.T) {
 
 	// Call handleInputState directly - should update input
 	require.NotPanics(t, func() {
-		ts.handler.handleInputState(player1ID, inputData)
+		ts.handler.handleInputState(player1ID, inputData, 0)
 	}, "handleInputState should process valid input without panic")
 
-	// Verify input was updated (covers lines 27-41)
-	player, _ = world.GetPlayer(player1ID)
+	// Verify input was applied on a following tick (covers lines 27-41)
+	waitForAppliedAim(t, player, 1.57)
 	currentInput := player.GetInput()
 
 	// Input should be different from initial
@@ -77,7 +88,7 @@ func TestHandleInputStateNonExistentPlayer(t *testing.T) {
 	// Call handleInputState with non-existent player
 	// Should trigger line 38-41 (UpdatePlayerInput returns false)
 	require.NotPanics(t, func() {
-		handler.handleInputState("non-existent-player-id", inputData)
+		handler.handleInputState("non-existent-player-id", inputData, 0)
 	}, "Should handle non-existent player gracefully")
 
 	// Verify player doesn't exist
@@ -126,7 +137,7 @@ func TestHandleInputStateMatchEnded(t *testing.T) {
 
 	// Call handleInputState directly - should return early without updating
 	require.NotPanics(t, func() {
-		ts.handler.handleInputState(player1ID, inputData)
+		ts.handler.handleInputState(player1ID, inputData, 0)
 	}, "handleInputState should silently ignore input after match ends")
 
 	// Verify input was NOT updated (early return on match ended, line 17)
@@ -165,7 +176,7 @@ func TestHandleInputStateSchemaValidationError(t *testing.T) {
 
 	// Call handleInputState - should handle validation failure gracefully (line 21-24)
 	require.NotPanics(t, func() {
-		ts.handler.handleInputState(player1ID, invalidData)
+		ts.handler.handleInputState(player1ID, invalidData, 0)
 	}, "Should handle schema validation failure gracefully")
 
 	// Verify input was NOT updated (early return on validation failure)
@@ -201,8 +212,8 @@ func TestHandleInputStateWithDifferentInputs(t *testing.T) {
 		"isSprinting": false,
 		"sequence":    1,
 	}
-	ts.handler.handleInputState(player1ID, inputData1)
-	player, _ = world.GetPlayer(player1ID)
+	ts.handler.handleInputState(player1ID, inputData1, 0)
+	waitForAppliedAim(t, player, 3.14)
 	input1 := player.GetInput()
 	assert.True(t, input1.Up)
 	assert.True(t, input1.Down)
@@ -216,18 +227,18 @@ func TestHandleInputStateWithDifferentInputs(t *testing.T) {
 		"down":        false,
 		"left":        false,
 		"right":       true,
-		"aimAngle":    0.785, // 45 degrees
+		"aimAngle":    2.356, // 135 degrees, within one tick's aim turn
 		"isSprinting": false,
 		"sequence":    2,
 	}
-	ts.handler.handleInputState(player1ID, inputData2)
-	player, _ = world.GetPlayer(player1ID)
+	ts.handler.handleInputState(player1ID, inputData2, 0)
+	waitForAppliedAim(t, player, 2.356)
 	input2 := player.GetInput()
 	assert.True(t, input2.Up)
 	assert.False(t, input2.Down)
 	assert.False(t, input2.Left)
 	assert.True(t, input2.Right)
-	assert.Equal(t, 0.785, input2.AimAngle)
+	assert.Equal(t, 2.356, input2.AimAngle)
 
 	// Test Case 3: Stationary with aim
 	inputData3 := map[string]interface{}{
@@ -235,16 +246,53 @@ func TestHandleInputStateWithDifferentInputs(t *testing.T) {
 		"down":        false,
 		"left":        false,
 		"right":       false,
-		"aimAngle":    6.28, // 360 degrees
+		"aimAngle":    2.5, // Inside what is left of the tick's aim turn
 		"isSprinting": false,
 		"sequence":    3,
 	}
-	ts.handler.handleInputState(player1ID, inputData3)
-	player, _ = world.GetPlayer(player1ID)
+	ts.handler.handleInputState(player1ID, inputData3, 0)
+	waitForAppliedAim(t, player, 2.5)
 	input3 := player.GetInput()
 	assert.False(t, input3.Up)
 	assert.False(t, input3.Down)
 	assert.False(t, input3.Left)
 	assert.False(t, input3.Right)
-	assert.Equal(t, 6.28, input3.AimAngle)
+	assert.Equal(t, 2.5, input3.AimAngle)
+}
+
+// TestInputStateWithBackwardsTimestampIsDropped covers replayed inputs
+func TestInputStateWithBackwardsTimestampIsDropped(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn1, conn2 := ts.connectTwoClients(t)
+	defer conn1.Close()
+	defer conn2.Close()
+
+	player1ID := consumeRoomJoinedAndGetPlayerID(t, conn1)
+	_ = consumeRoomJoinedAndGetPlayerID(t, conn2)
+
+	sendInputState(t, conn1, true, false, false, false)
+	player, exists := ts.handler.gameServer.GetWorld().GetPlayer(player1ID)
+	require.True(t, exists)
+	require.Eventually(t, func() bool { return player.GetInput().Up }, time.Second, 10*time.Millisecond)
+
+	sendMessage(t, conn1, Message{
+		Type:      "input:state",
+		Timestamp: time.Now().Add(-time.Minute).UnixMilli(),
+		Data: map[string]interface{}{
+			"up":          false,
+			"down":        true,
+			"left":        false,
+			"right":       false,
+			"aimAngle":    0.0,
+			"isSprinting": false,
+		},
+	})
+
+	require.Eventually(t, func() bool {
+		return ts.handler.gameServer.InputTimestampViolations(player1ID) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.True(t, player.GetInput().Up, "the replayed input was not applied")
+	assert.False(t, player.GetInput().Down)
 }
@@ -0,0 +1,110 @@
+package network
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+// broadcastHealthPickup broadcasts a health pack pickup to all clients
+func (h *WebSocketHandler) broadcastHealthPickup(event game.HealthPackPickedUpEvent) {
+	data := map[string]interface{}{
+		"playerId":        event.PlayerID,
+		"packId":          event.PackID,
+		"healed":          event.Healed,
+		"newHealth":       event.NewHealth,
+		"nextRespawnTime": event.RespawnTime.Unix(),
+	}
+
+	// Validate outgoing message schema (development mode only)
+	if err := h.validateOutgoingMessage("health:pickup_confirmed", data); err != nil {
+		log.Printf("Schema validation failed for health:pickup_confirmed: %v", err)
+	}
+
+	message := Message{
+		Type:      "health:pickup_confirmed",
+		Timestamp: time.Now().UnixMilli(),
+		Data:      data,
+	}
+
+	msgBytes, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("Error marshaling health:pickup_confirmed message: %v", err)
+		return
+	}
+
+	h.roomManager.BroadcastToAll(msgBytes)
+	log.Printf("Player %s picked up health pack %s (+%d HP)", event.PlayerID, event.PackID, event.Healed)
+}
+
+// broadcastHealthRespawn tells all clients a health pack is available again
+// with a health:spawned listing only that pack
+func (h *WebSocketHandler) broadcastHealthRespawn(event game.HealthPackRespawnedEvent) {
+	msgBytes, err := h.healthSpawnedMessage([]*game.HealthPack{{
+		ID:          event.PackID,
+		Position:    event.Position,
+		IsAvailable: true,
+	}})
+	if err != nil {
+		log.Printf("Error marshaling health:spawned message: %v", err)
+		return
+	}
+
+	h.roomManager.BroadcastToAll(msgBytes)
+}
+
+// sendHealthSpawns sends every health pack's state to a specific player
+func (h *WebSocketHandler) sendHealthSpawns(playerID string) {
+	allPacks := h.gameServer.GetHealthPackManager().GetAllPacks()
+	packs := make([]*game.HealthPack, 0, len(allPacks))
+	for _, id := range sortedIDs(allPacks) {
+		packs = append(packs, allPacks[id])
+	}
+
+	msgBytes, err := h.healthSpawnedMessage(packs)
+	if err != nil {
+		log.Printf("Error marshaling health:spawned message: %v", err)
+		return
+	}
+
+	room := h.roomManager.GetRoomByPlayerID(playerID)
+	if room != nil {
+		player := room.GetPlayer(playerID)
+		if player != nil {
+			if err := player.Send(msgBytes); err != nil {
+				log.Printf("Failed to send health:spawned to player %s (%v)", playerID, err)
+			}
+		}
+	} else {
+		h.roomManager.SendToWaitingPlayer(playerID, msgBytes)
+	}
+}
+
+// healthSpawnedMessage builds a health:spawned message listing packs
+func (h *WebSocketHandler) healthSpawnedMessage(packs []*game.HealthPack) ([]byte, error) {
+	packData := make([]map[string]interface{}, 0, len(packs))
+	for _, pack := range packs {
+		packData = append(packData, map[string]interface{}{
+			"id":          pack.ID,
+			"position":    map[string]interface{}{"x": pack.Position.X, "y": pack.Position.Y},
+			"isAvailable": pack.IsAvailable,
+		})
+	}
+
+	data := map[string]interface{}{
+		"packs": packData,
+	}
+
+	// Validate outgoing message schema (development mode only)
+	if err := h.validateOutgoingMessage("health:spawned", data); err != nil {
+		log.Printf("Schema validation failed for health:spawned: %v", err)
+	}
+
+	return json.Marshal(Message{
+		Type:      "health:spawned",
+		Timestamp: time.Now().UnixMilli(),
+		Data:      data,
+	})
+}
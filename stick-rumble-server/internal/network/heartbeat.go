@@ -0,0 +1,107 @@
+package network
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+const (
+	// defaultHeartbeatInterval is how often each connection is sent a net:ping
+	defaultHeartbeatInterval = 2 * time.Second
+
+	// defaultHeartbeatMissLimit is how many net:pings in a row may go
+	// unanswered before the connection is closed
+	defaultHeartbeatMissLimit = 3
+)
+
+// heartbeat tracks the application-level pings of one connection. Only the
+// latest ping is outstanding: sending the next one while it is unanswered
+// counts as a miss, and a late pong to it no longer counts.
+//
+// Unlike WebSocket control pings, which the browser answers on its own, a
+// net:ping is answered by the client's code, so a frozen tab or hung client
+// misses it and its ghost player does not linger in a room.
+type heartbeat struct {
+	nextID    int
+	pendingID int       // 0 when the latest ping was answered
+	sentAt    time.Time // When pendingID was sent
+	missed    int       // Pings in a row that went unanswered
+	mu        sync.Mutex
+}
+
+// ping starts the next ping and returns its ID along with how many pings in a
+// row have now gone unanswered
+func (hb *heartbeat) ping(now time.Time) (id, missed int) {
+	hb.mu.Lock()
+	defer hb.mu.Unlock()
+
+	if hb.pendingID != 0 {
+		hb.missed++
+	}
+	hb.nextID++
+	hb.pendingID = hb.nextID
+	hb.sentAt = now
+	return hb.pendingID, hb.missed
+}
+
+// pong answers the outstanding ping and returns its round-trip time; ok is
+// false when id is not the outstanding ping
+func (hb *heartbeat) pong(id int, now time.Time) (rtt time.Duration, ok bool) {
+	hb.mu.Lock()
+	defer hb.mu.Unlock()
+
+	if id == 0 || id != hb.pendingID {
+		return 0, false
+	}
+	hb.pendingID = 0
+	hb.missed = 0
+	return now.Sub(hb.sentAt), true
+}
+
+// runHeartbeat pings player's connection every heartbeat interval until done
+// closes, closing the connection once the miss limit is reached.
+//
+// Pings go through the player's send channel like any other message, so the
+// measured round trip includes send queueing and any simulated latency.
+func (h *WebSocketHandler) runHeartbeat(player *game.Player, hb *heartbeat, closeConn func(reason disconnectReason), done <-chan struct{}) {
+	ticker := time.NewTicker(h.heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			now := time.Now()
+			id, missed := hb.ping(now)
+			if missed >= h.heartbeatMissLimit {
+				log.Printf("Closing connection of %s: %d heartbeats unanswered", player.ID, missed)
+				closeConn(heartbeatTimeoutReason)
+				return
+			}
+			if err := h.publication.SendNetPing(player, id, now); err != nil {
+				log.Printf("Error sending net:ping to %s: %v", player.ID, err)
+			}
+		}
+	}
+}
+
+// handleNetPong records the round-trip time of an answered net:ping; the
+// player's smoothed RTT feeds lag compensation
+func (h *WebSocketHandler) handleNetPong(player *game.Player, hb *heartbeat, data any) {
+	if err := h.validator.Validate("net-pong-data", data); err != nil {
+		log.Printf("Schema validation failed for net:pong from %s: %v", player.ID, err)
+		return
+	}
+
+	id := int(data.(map[string]interface{})["id"].(float64))
+	rtt, ok := hb.pong(id, time.Now())
+	if !ok {
+		return
+	}
+	player.PingTracker.RecordRTT(rtt)
+	log.Printf("Player %s RTT: %dms (avg: %dms)", player.ID, rtt.Milliseconds(), player.PingTracker.GetRTT())
+}
@@ -0,0 +1,76 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeartbeatCountsUnansweredPings(t *testing.T) {
+	hb := &heartbeat{}
+	start := time.Now()
+
+	id, missed := hb.ping(start)
+	assert.Equal(t, 1, id)
+	assert.Zero(t, missed)
+
+	id, missed = hb.ping(start.Add(time.Second))
+	assert.Equal(t, 2, id)
+	assert.Equal(t, 1, missed, "the first ping went unanswered")
+
+	_, ok := hb.pong(1, start.Add(1100*time.Millisecond))
+	assert.False(t, ok, "a late pong to an earlier ping does not count")
+
+	rtt, ok := hb.pong(2, start.Add(1040*time.Millisecond))
+	require.True(t, ok)
+	assert.Equal(t, 40*time.Millisecond, rtt)
+
+	_, ok = hb.pong(2, start.Add(1050*time.Millisecond))
+	assert.False(t, ok, "a ping is answered once")
+
+	_, missed = hb.ping(start.Add(2 * time.Second))
+	assert.Zero(t, missed, "the pong reset the miss count")
+}
+
+func TestNetPongRecordsPlayerRTT(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	ts.handler.heartbeatInterval = 20 * time.Millisecond
+
+	conn := ts.connectRawClient(t)
+	defer conn.Close()
+	sendHelloMessage(t, conn, "Pinger", "code", "BEAT")
+	_, status, err := readSessionStatus(t, conn, "waiting_for_players", 2*time.Second)
+	require.NoError(t, err)
+	playerID := status["playerId"].(string)
+
+	ping, err := readMessageOfType(t, conn, "net:ping", 2*time.Second)
+	require.NoError(t, err)
+	sendMessage(t, conn, Message{Type: "net:pong", Timestamp: time.Now().UnixMilli(), Data: ping.Data})
+
+	player := ts.handler.roomManager.GetRoomByPlayerID(playerID).GetPlayer(playerID)
+	require.Eventually(t, func() bool {
+		return player.PingTracker.GetMeasurementCount() == 1
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestHeartbeatClosesUnresponsiveConnection(t *testing.T) {
+	ts := newTestServer(withResumeGrace(0))
+	defer ts.Close()
+	ts.handler.heartbeatInterval = 20 * time.Millisecond
+	ts.handler.heartbeatMissLimit = 2
+
+	conn := ts.connectRawClient(t)
+	defer conn.Close()
+	sendHelloMessage(t, conn, "Ghost", "code", "GHST")
+	_, status, err := readSessionStatus(t, conn, "waiting_for_players", 2*time.Second)
+	require.NoError(t, err)
+	playerID := status["playerId"].(string)
+
+	assert.Equal(t, heartbeatTimeoutReason, readCloseReason(t, conn))
+	require.Eventually(t, func() bool {
+		return ts.handler.roomManager.GetRoomByPlayerID(playerID) == nil
+	}, 2*time.Second, 10*time.Millisecond, "the ghost player leaves its room")
+}
@@ -15,7 +15,7 @@ import (
 // TestFullGameplayFlow tests a complete gameplay scenario from connection to disconnect
 // SKIPPED: weapon:spawned data population issue tracked in stick-rumble-47x
 func SkipTestFullGameplayFlow(t *testing.T) {
-	ts := newTestServer()
+	ts := newTestServer(withResumeGrace(100 * time.Millisecond)) // player:left follows the grace period
 	defer ts.Close()
 
 	// 1. Connect two players
@@ -152,7 +152,7 @@ func TestMatchTimerAndEnding(t *testing.T) {
 
 // TestReconnectionScenario tests disconnection and reconnection handling
 func TestReconnectionScenario(t *testing.T) {
-	ts := newTestServer()
+	ts := newTestServer(withResumeGrace(100 * time.Millisecond)) // player:left follows the grace period
 	defer ts.Close()
 
 	// Connect two players
@@ -431,6 +431,41 @@ func TestDeltaCompressionDeltaBetweenSnapshots(t *testing.T) {
 	assert.True(t, foundDelta, "Should receive state:delta message when player moves")
 }
 
+// TestDeltaCompressionAckedBaseline verifies deltas name the acknowledged
+// baseline and that a client that stops acknowledging gets a snapshot
+func TestDeltaCompressionAckedBaseline(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn1, conn2 := ts.connectTwoClients(t)
+	defer conn1.Close()
+	defer conn2.Close()
+
+	_ = consumeRoomJoinedAndGetPlayerID(t, conn1)
+	player2ID := consumeRoomJoinedAndGetPlayerID(t, conn2)
+
+	msg, err := readMessageOfType(t, conn2, "state:snapshot", 2*time.Second)
+	require.NoError(t, err)
+	seq := msg.Data.(map[string]interface{})["seq"].(float64)
+	require.Positive(t, seq)
+
+	sendMessage(t, conn2, Message{Type: "state:ack", Timestamp: time.Now().UnixMilli(), Data: map[string]any{"seq": seq}})
+	require.Eventually(t, func() bool {
+		return ts.handler.deltaTracker.BaseSequence(player2ID) == uint64(seq)
+	}, 2*time.Second, 10*time.Millisecond)
+
+	// Player 1 keeps moving, so player 2 keeps getting deltas against its ack
+	sendInputState(t, conn1, true, false, false, false)
+	msg, err = readMessageOfType(t, conn2, "state:delta", 2*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, seq, msg.Data.(map[string]interface{})["baseSeq"])
+
+	// Without further acks the server falls back to a full snapshot
+	msg, err = readMessageOfType(t, conn2, "state:snapshot", 2*time.Second)
+	require.NoError(t, err)
+	assert.Greater(t, msg.Data.(map[string]interface{})["seq"].(float64), seq+MaxAckLag)
+}
+
 // TestDeltaCompressionEmptyDelta verifies no message sent when nothing changes
 func TestDeltaCompressionEmptyDelta(t *testing.T) {
 	ts := newTestServer()
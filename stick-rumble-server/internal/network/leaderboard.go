@@ -0,0 +1,355 @@
+package network
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultLeaderboardPageSize is how many players GET /leaderboard
+	// returns without a limit
+	defaultLeaderboardPageSize = 50
+	// maxLeaderboardPageSize caps the limit a client may ask for
+	maxLeaderboardPageSize = 100
+	// leaderboardUpdateTop is how many players leaderboard:update carries
+	leaderboardUpdateTop = 10
+)
+
+// Orderings of the leaderboard
+const (
+	LeaderboardByXP   = "xp"
+	LeaderboardByKD   = "kd"
+	LeaderboardByWins = "wins"
+)
+
+// Leaderboard ranks every player in the match records. Served at GET
+// /leaderboard.
+type Leaderboard struct {
+	GeneratedAt time.Time           `json:"generatedAt"`
+	Matches     int                 `json:"matches"` // Match records read
+	Players     []LeaderboardPlayer `json:"players"` // In XP rank order
+}
+
+// LeaderboardPlayer is one player's totals over the recorded matches and
+// their place in each ordering. Ranks start at 1 and are never shared: ties
+// are broken by the next stat and then the player ID.
+type LeaderboardPlayer struct {
+	PlayerID    string  `json:"playerId"`
+	DisplayName string  `json:"displayName"` // Name in their latest recorded match
+	XP          int     `json:"xp"`
+	Kills       int     `json:"kills"`
+	Deaths      int     `json:"deaths"`
+	KD          float64 `json:"kd"` // Kills over deaths, counting no deaths as one
+	Wins        int     `json:"wins"`
+	Matches     int     `json:"matches"`
+	XPRank      int     `json:"xpRank"`   // By XP, then wins
+	KDRank      int     `json:"kdRank"`   // By K/D, then kills
+	WinsRank    int     `json:"winsRank"` // By wins, then XP
+}
+
+// rank returns the player's place in the ordering
+func (p LeaderboardPlayer) rank(by string) int {
+	switch by {
+	case LeaderboardByKD:
+		return p.KDRank
+	case LeaderboardByWins:
+		return p.WinsRank
+	default:
+		return p.XPRank
+	}
+}
+
+// matchRecordReader walks the persisted match records, oldest first
+type matchRecordReader interface {
+	eachMatchRecord(visit func(MatchRecord)) error
+}
+
+// leaderboardStore keeps the latest leaderboard so a restarted server can
+// serve it before its first recomputation. Load returns nil when none was
+// saved.
+type leaderboardStore interface {
+	SaveLeaderboard(board Leaderboard) error
+	LoadLeaderboard() (*Leaderboard, error)
+}
+
+// leaderboardRanker recomputes the leaderboard from the match records when a
+// match ends and on a schedule, and pushes the top of it to every connected
+// player when it changes
+type leaderboardRanker struct {
+	records matchRecordReader
+	store   leaderboardStore
+	now     func() time.Time
+
+	refreshes chan struct{}            // Match ends asking for a recomputation; holds at most one
+	lastTop   []leaderboardUpdateEntry // Only touched by the leaderboard loop
+
+	mu    sync.RWMutex
+	board *Leaderboard // nil until loaded or first computed
+}
+
+func newLeaderboardRanker(records matchRecordReader, store leaderboardStore, now func() time.Time) *leaderboardRanker {
+	return &leaderboardRanker{records: records, store: store, now: now, refreshes: make(chan struct{}, 1)}
+}
+
+// requestRefresh asks the leaderboard loop to recompute soon. Requests made
+// while one is pending are merged into it.
+func (l *leaderboardRanker) requestRefresh() {
+	select {
+	case l.refreshes <- struct{}{}:
+	default:
+	}
+}
+
+// leaderboardLoop serves the stored leaderboard, recomputes it at start and
+// then whenever a match ends or interval passes (interval 0 only recomputes
+// on match ends), until ctx is done
+func (h *WebSocketHandler) leaderboardLoop(ctx context.Context, interval time.Duration) {
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	h.leaderboard.load()
+	h.refreshLeaderboard()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick:
+			h.refreshLeaderboard()
+		case <-h.leaderboard.refreshes:
+			h.refreshLeaderboard()
+		}
+	}
+}
+
+// load serves the stored leaderboard until the first recomputation
+func (l *leaderboardRanker) load() {
+	board, err := l.store.LoadLeaderboard()
+	if err != nil {
+		log.Printf("Error loading the stored leaderboard: %v", err)
+		return
+	}
+	if board != nil {
+		l.mu.Lock()
+		l.board = board
+		l.mu.Unlock()
+	}
+}
+
+// refreshLeaderboard recomputes and stores the leaderboard, then sends
+// leaderboard:update to every connected player if its top changed
+func (h *WebSocketHandler) refreshLeaderboard() {
+	board, err := h.leaderboard.refresh()
+	if err != nil {
+		log.Printf("Error computing the leaderboard: %v", err)
+		return
+	}
+
+	data := newLeaderboardUpdateData(board)
+	if slices.Equal(data.Top, h.leaderboard.lastTop) {
+		return
+	}
+	h.leaderboard.lastTop = data.Top
+	if err := h.publication.SendLeaderboardUpdate(h.resumer.players(), data); err != nil {
+		log.Printf("Error sending leaderboard:update: %v", err)
+	}
+}
+
+// refresh ranks the match records into a new leaderboard and stores it
+func (l *leaderboardRanker) refresh() (Leaderboard, error) {
+	totals := make(map[string]*PlayerHistory)
+	matches := 0
+	err := l.records.eachMatchRecord(func(record MatchRecord) {
+		matches++
+		for _, line := range record.Scoreboard {
+			history, ok := totals[line.PlayerID]
+			if !ok {
+				history = &PlayerHistory{}
+				totals[line.PlayerID] = history
+			}
+			history.add(record, line)
+		}
+	})
+	if err != nil {
+		return Leaderboard{}, err
+	}
+
+	board := Leaderboard{GeneratedAt: l.now(), Matches: matches, Players: rankLeaderboard(totals)}
+	if err := l.store.SaveLeaderboard(board); err != nil {
+		log.Printf("Error storing the leaderboard: %v", err)
+	}
+
+	l.mu.Lock()
+	l.board = &board
+	l.mu.Unlock()
+	return board, nil
+}
+
+// current returns the latest leaderboard, or nil before the first
+func (l *leaderboardRanker) current() *Leaderboard {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.board
+}
+
+// rankLeaderboard places every player in each ordering and returns them in
+// XP rank order
+func rankLeaderboard(totals map[string]*PlayerHistory) []LeaderboardPlayer {
+	players := make([]LeaderboardPlayer, 0, len(totals))
+	for playerID, history := range totals {
+		players = append(players, LeaderboardPlayer{
+			PlayerID:    playerID,
+			DisplayName: history.DisplayName,
+			XP:          history.XP,
+			Kills:       history.Kills,
+			Deaths:      history.Deaths,
+			KD:          float64(history.Kills) / float64(max(history.Deaths, 1)),
+			Wins:        history.Wins,
+			Matches:     history.Matches,
+		})
+	}
+
+	orderings := []struct {
+		compare func(a, b LeaderboardPlayer) int
+		set     func(p *LeaderboardPlayer, rank int)
+	}{
+		{
+			compare: func(a, b LeaderboardPlayer) int {
+				return cmp.Or(cmp.Compare(b.KD, a.KD), cmp.Compare(b.Kills, a.Kills))
+			},
+			set: func(p *LeaderboardPlayer, rank int) { p.KDRank = rank },
+		},
+		{
+			compare: func(a, b LeaderboardPlayer) int {
+				return cmp.Or(cmp.Compare(b.Wins, a.Wins), cmp.Compare(b.XP, a.XP))
+			},
+			set: func(p *LeaderboardPlayer, rank int) { p.WinsRank = rank },
+		},
+		{
+			compare: func(a, b LeaderboardPlayer) int {
+				return cmp.Or(cmp.Compare(b.XP, a.XP), cmp.Compare(b.Wins, a.Wins))
+			},
+			set: func(p *LeaderboardPlayer, rank int) { p.XPRank = rank },
+		},
+	}
+	// XP goes last so the players are left in its order
+	for _, ordering := range orderings {
+		slices.SortFunc(players, func(a, b LeaderboardPlayer) int {
+			return cmp.Or(ordering.compare(a, b), cmp.Compare(a.PlayerID, b.PlayerID))
+		})
+		for i := range players {
+			ordering.set(&players[i], i+1)
+		}
+	}
+	return players
+}
+
+// leaderboardPage is the body of GET /leaderboard
+type leaderboardPage struct {
+	GeneratedAt time.Time           `json:"generatedAt"`
+	Matches     int                 `json:"matches"`
+	Sort        string              `json:"sort"`
+	Total       int                 `json:"total"` // Ranked players
+	Offset      int                 `json:"offset"`
+	Players     []LeaderboardPlayer `json:"players"`
+}
+
+// HandleLeaderboard serves GET /leaderboard for the shared global handler
+func HandleLeaderboard(w http.ResponseWriter, r *http.Request) {
+	getGlobalHandler().HandleLeaderboard(w, r)
+}
+
+// HandleLeaderboard serves one page of the latest leaderboard. ?sort= picks
+// the ordering (xp, kd or wins; xp by default), ?offset= and ?limit= the
+// page. 404 while match records are off, 503 until the first ranking.
+func (h *WebSocketHandler) HandleLeaderboard(w http.ResponseWriter, r *http.Request) {
+	if h.leaderboard == nil {
+		http.Error(w, "leaderboard not enabled", http.StatusNotFound)
+		return
+	}
+
+	query := r.URL.Query()
+	sortBy := query.Get("sort")
+	switch sortBy {
+	case "":
+		sortBy = LeaderboardByXP
+	case LeaderboardByXP, LeaderboardByKD, LeaderboardByWins:
+	default:
+		http.Error(w, "sort must be xp, kd or wins", http.StatusBadRequest)
+		return
+	}
+	offset, ok := queryInt(query.Get("offset"), 0, 0)
+	if !ok {
+		http.Error(w, "offset must be a non-negative integer", http.StatusBadRequest)
+		return
+	}
+	limit, ok := queryInt(query.Get("limit"), defaultLeaderboardPageSize, 1)
+	if !ok {
+		http.Error(w, "limit must be a positive integer", http.StatusBadRequest)
+		return
+	}
+	limit = min(limit, maxLeaderboardPageSize)
+
+	board := h.leaderboard.current()
+	if board == nil {
+		http.Error(w, "leaderboard not ready", http.StatusServiceUnavailable)
+		return
+	}
+
+	players := slices.Clone(board.Players)
+	slices.SortFunc(players, func(a, b LeaderboardPlayer) int { return cmp.Compare(a.rank(sortBy), b.rank(sortBy)) })
+	start := min(offset, len(players))
+	end := min(start+limit, len(players))
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(leaderboardPage{
+		GeneratedAt: board.GeneratedAt,
+		Matches:     board.Matches,
+		Sort:        sortBy,
+		Total:       len(players),
+		Offset:      offset,
+		Players:     players[start:end],
+	}); err != nil {
+		log.Printf("Error writing leaderboard: %v", err)
+	}
+}
+
+// queryInt parses an optional integer query parameter of at least minimum,
+// returning fallback when it is blank
+func queryInt(raw string, fallback, minimum int) (int, bool) {
+	if raw == "" {
+		return fallback, true
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < minimum {
+		return 0, false
+	}
+	return value, true
+}
+
+// newLeaderboardUpdateData takes the top of the XP ordering for
+// leaderboard:update
+func newLeaderboardUpdateData(board Leaderboard) leaderboardUpdateData {
+	top := make([]leaderboardUpdateEntry, 0, leaderboardUpdateTop)
+	for _, player := range board.Players[:min(len(board.Players), leaderboardUpdateTop)] {
+		top = append(top, leaderboardUpdateEntry{
+			Rank:        player.XPRank,
+			PlayerID:    player.PlayerID,
+			DisplayName: player.DisplayName,
+			XP:          player.XP,
+			KD:          player.KD,
+			Wins:        player.Wins,
+		})
+	}
+	return leaderboardUpdateData{Matches: board.Matches, Total: len(board.Players), Top: top}
+}
@@ -0,0 +1,162 @@
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// saveLeaderboardMatch records a match with one scoreboard line per player;
+// the first player wins
+func saveLeaderboardMatch(t *testing.T, store *fileMatchRecordStore, lines ...game.PlayerMatchStats) {
+	t.Helper()
+	require.NoError(t, store.SaveMatchRecord(MatchRecord{
+		Winners:    []game.WinnerSummary{{PlayerID: lines[0].PlayerID}},
+		Scoreboard: lines,
+		EndedAt:    time.Now(),
+	}))
+}
+
+func getLeaderboard(h *WebSocketHandler, query string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/leaderboard"+query, nil)
+	rec := httptest.NewRecorder()
+	h.HandleLeaderboard(rec, req)
+	return rec
+}
+
+func TestLeaderboardRanksEachOrdering(t *testing.T) {
+	store := newFileMatchRecordStore(t.TempDir())
+	saveLeaderboardMatch(t, store,
+		game.PlayerMatchStats{PlayerID: "grinder", DisplayName: "Grinder", XP: 500, Kills: 5, Deaths: 5},
+		game.PlayerMatchStats{PlayerID: "sniper", DisplayName: "Sniper", XP: 300, Kills: 6, Deaths: 1},
+		game.PlayerMatchStats{PlayerID: "rookie", DisplayName: "Rookie", XP: 100, Kills: 1, Deaths: 0},
+	)
+	saveLeaderboardMatch(t, store,
+		game.PlayerMatchStats{PlayerID: "rookie", DisplayName: "Rook", XP: 100, Kills: 1, Deaths: 3},
+		game.PlayerMatchStats{PlayerID: "grinder", DisplayName: "Grinder", XP: 400, Kills: 4, Deaths: 4},
+	)
+	ranker := newLeaderboardRanker(store, store, func() time.Time { return goldenTime })
+
+	board, err := ranker.refresh()
+	require.NoError(t, err)
+	assert.Equal(t, goldenTime, board.GeneratedAt)
+	assert.Equal(t, 2, board.Matches)
+	require.Len(t, board.Players, 3)
+
+	grinder, sniper, rookie := board.Players[0], board.Players[1], board.Players[2]
+	assert.Equal(t, LeaderboardPlayer{
+		PlayerID: "grinder", DisplayName: "Grinder", XP: 900, Kills: 9, Deaths: 9, KD: 1, Wins: 1, Matches: 2,
+		XPRank: 1, KDRank: 2, WinsRank: 1,
+	}, grinder)
+	assert.Equal(t, "sniper", sniper.PlayerID)
+	assert.Equal(t, 6.0, sniper.KD)
+	assert.Equal(t, []int{2, 1, 3}, []int{sniper.XPRank, sniper.KDRank, sniper.WinsRank}, "no wins ranks last; ties fall back to XP")
+	assert.Equal(t, "Rook", rookie.DisplayName, "the name from their latest match")
+	assert.Equal(t, []int{3, 3, 2}, []int{rookie.XPRank, rookie.KDRank, rookie.WinsRank})
+
+	stored, err := store.LoadLeaderboard()
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, board.Players, stored.Players, "the leaderboard is kept for the next start")
+}
+
+func TestLeaderboardPages(t *testing.T) {
+	f := newGoldenFixture(t)
+	assert.Equal(t, http.StatusNotFound, getLeaderboard(f.handler, "").Code, "off without match records")
+
+	store := newFileMatchRecordStore(t.TempDir())
+	f.handler.leaderboard = newLeaderboardRanker(store, store, time.Now)
+	assert.Equal(t, http.StatusServiceUnavailable, getLeaderboard(f.handler, "").Code, "not ranked yet")
+
+	saveLeaderboardMatch(t, store,
+		game.PlayerMatchStats{PlayerID: "a", XP: 300, Kills: 1, Deaths: 1},
+		game.PlayerMatchStats{PlayerID: "b", XP: 200, Kills: 4, Deaths: 1},
+		game.PlayerMatchStats{PlayerID: "c", XP: 100, Kills: 2, Deaths: 1},
+	)
+	_, err := f.handler.leaderboard.refresh()
+	require.NoError(t, err)
+
+	rec := getLeaderboard(f.handler, "?sort=kd&offset=1&limit=1")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var page leaderboardPage
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+	assert.Equal(t, LeaderboardByKD, page.Sort)
+	assert.Equal(t, 3, page.Total)
+	assert.Equal(t, 1, page.Offset)
+	require.Len(t, page.Players, 1)
+	assert.Equal(t, "c", page.Players[0].PlayerID)
+
+	page = leaderboardPage{}
+	rec = getLeaderboard(f.handler, "?offset=5")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+	assert.Equal(t, LeaderboardByXP, page.Sort)
+	assert.Empty(t, page.Players, "past the end")
+
+	for _, query := range []string{"?sort=deaths", "?offset=-1", "?limit=0", "?limit=many"} {
+		assert.Equal(t, http.StatusBadRequest, getLeaderboard(f.handler, query).Code, query)
+	}
+}
+
+func TestLeaderboardPushesUpdatesAfterMatchEnd(t *testing.T) {
+	f := newGoldenFixture(t)
+	store := newFileMatchRecordStore(t.TempDir())
+	f.handler.matchRecords = store
+	f.handler.leaderboard = newLeaderboardRanker(store, store, time.Now)
+	f.handler.resumer.issue(f.receiver, func(disconnectReason) {})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f.handler.leaderboardLoop(ctx, 0)
+	}()
+
+	require.Eventually(t, func() bool { return f.handler.leaderboard.current() != nil }, 2*time.Second, 10*time.Millisecond)
+	f.drain()
+
+	f.room.Match.Start()
+	f.handler.broadcastMatchEndedEvent(game.MatchEndedEvent{
+		RoomID:     f.room.ID,
+		Reason:     "kill_target",
+		Winners:    []game.WinnerSummary{{PlayerID: "player-a", DisplayName: "Alpha"}},
+		Scoreboard: []game.PlayerMatchStats{{PlayerID: "player-a", DisplayName: "Alpha", XP: 250, Kills: 3, Deaths: 1}},
+	})
+
+	var update leaderboardUpdateData
+	require.Eventually(t, func() bool {
+		select {
+		case msgBytes := <-f.receiver.SendChan:
+			var msg struct {
+				Type string                `json:"type"`
+				Data leaderboardUpdateData `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(msgBytes, &msg))
+			update = msg.Data
+			return msg.Type == "leaderboard:update"
+		default:
+			return false
+		}
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 1, update.Matches)
+	assert.Equal(t, []leaderboardUpdateEntry{{Rank: 1, PlayerID: "player-a", DisplayName: "Alpha", XP: 250, KD: 3, Wins: 1}}, update.Top)
+
+	// A recomputation that leaves the top as it was sends nothing
+	f.handler.refreshLeaderboard()
+	select {
+	case msgBytes := <-f.receiver.SendChan:
+		t.Fatalf("unexpected message %s", msgBytes)
+	default:
+	}
+
+	cancel()
+	<-done
+}
@@ -0,0 +1,71 @@
+package network
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const leakCheckTimeout = 5 * time.Second
+
+// leakCheckIgnoredFrames marks goroutines that outlive tests on purpose.
+var leakCheckIgnoredFrames = []string{
+	"testing.(*T).Run",
+	"testing.(*M).",
+	"testing.runTests",
+	"runtime.goexit0",
+	"os/signal.signal_recv",
+}
+
+// leakedGoroutines returns the stacks of goroutines still running code from
+// this module, excluding the caller's own goroutine.
+func leakedGoroutines() []string {
+	buf := make([]byte, 1<<20)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	stacks := strings.Split(string(buf), "\n\n")
+	leaked := make([]string, 0)
+	for _, stack := range stacks[1:] {
+		if !strings.Contains(stack, "github.com/mtomcal/stick-rumble-server/") {
+			continue
+		}
+		if isIgnoredGoroutine(stack) {
+			continue
+		}
+		leaked = append(leaked, stack)
+	}
+	return leaked
+}
+
+func isIgnoredGoroutine(stack string) bool {
+	for _, frame := range leakCheckIgnoredFrames {
+		if strings.Contains(stack, frame) {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyNoGoroutineLeaks waits for module goroutines to exit, in the spirit of
+// goleak.VerifyTestMain, and describes any that never do.
+func verifyNoGoroutineLeaks() error {
+	deadline := time.Now().Add(leakCheckTimeout)
+	for {
+		leaked := leakedGoroutines()
+		if len(leaked) == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("found %d leaked goroutine(s):\n\n%s", len(leaked), strings.Join(leaked, "\n\n"))
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
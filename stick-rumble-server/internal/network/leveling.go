@@ -0,0 +1,42 @@
+package network
+
+import (
+	"log"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+// sendLevelUp tells a player they reached a new level
+func (h *WebSocketHandler) sendLevelUp(event game.PlayerLeveledUpEvent) {
+	unlocks := event.Unlocks
+	if unlocks == nil {
+		unlocks = []string{}
+	}
+	err := h.publication.SendPlayerLevelUp(playerLevelUpData{
+		PlayerID:      event.PlayerID,
+		Level:         event.Level,
+		PreviousLevel: event.PreviousLevel,
+		XP:            event.XP,
+		NextLevelXP:   event.NextLevelXP,
+		Unlocks:       unlocks,
+	})
+	if err != nil {
+		log.Printf("Error sending player:level_up to %s: %v", event.PlayerID, err)
+	}
+}
+
+// recordedXP is the XP a player earned in the recorded matches, which counts
+// toward their level when they join a game. It reads the whole record file,
+// once per player joining; 0 while match records are off or unreadable.
+func (h *WebSocketHandler) recordedXP(playerID string) int {
+	reader, ok := h.matchRecords.(playerHistoryReader)
+	if !ok {
+		return 0
+	}
+	history, _, err := reader.PlayerHistory(playerID)
+	if err != nil {
+		log.Printf("Error reading match history of %s for their level: %v", playerID, err)
+		return 0
+	}
+	return history.XP
+}
@@ -0,0 +1,52 @@
+package network
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevelUpIsSentToThePlayer(t *testing.T) {
+	f := newGoldenFixture(t)
+	f.handler.gameServer.AddPlayer("player-b")
+
+	for range game.DefaultLevelFirstXP / game.AssistXPReward {
+		f.handler.gameServer.CreditAssist("player-b")
+	}
+
+	var msg struct {
+		Data playerLevelUpData `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(f.received(t, "player:level_up"), &msg))
+	assert.Equal(t, playerLevelUpData{
+		PlayerID:      "player-b",
+		Level:         2,
+		PreviousLevel: 1,
+		XP:            game.DefaultLevelFirstXP,
+		NextLevelXP:   game.LevelCurve{}.LevelXP(3),
+		Unlocks:       []string{},
+	}, msg.Data)
+}
+
+func TestRecordedMatchesCountTowardTheLevel(t *testing.T) {
+	f := newGoldenFixture(t)
+	store := newFileMatchRecordStore(t.TempDir())
+	f.handler.matchRecords = store
+	require.NoError(t, store.SaveMatchRecord(MatchRecord{
+		Scoreboard: []game.PlayerMatchStats{{PlayerID: "player-b", XP: game.DefaultLevelFirstXP}},
+		EndedAt:    time.Now(),
+	}))
+
+	f.handler.sessionRuntime.ActivatePlayers([]game.RoomSessionActivation{{Player: f.receiver, Room: f.room}})
+
+	state, ok := f.handler.gameServer.GetPlayerState("player-b")
+	require.True(t, ok)
+	assert.Zero(t, state.XP, "this session has earned nothing yet")
+	player, _ := f.handler.gameServer.GetWorld().GetPlayer("player-b")
+	require.NotNil(t, player)
+	assert.Equal(t, 2, player.Level())
+}
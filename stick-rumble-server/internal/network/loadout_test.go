@@ -0,0 +1,62 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlePlayerLoadout_AppliesClassAtMatchStart(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	heavy := ts.connectRawClient(t)
+	defer heavy.Close()
+	sendHelloMessage(t, heavy, "Tank", "code", "CLASS")
+	_, status, err := readSessionStatus(t, heavy, "waiting_for_players", 2*time.Second)
+	require.NoError(t, err)
+	heavyID := status["playerId"].(string)
+
+	// Unknown classes are rejected by the schema and leave the choice alone
+	sendMessage(t, heavy, Message{Type: "player:loadout", Timestamp: time.Now().UnixMilli(), Data: map[string]any{"class": "medic"}})
+	sendMessage(t, heavy, Message{Type: "player:loadout", Timestamp: time.Now().UnixMilli(), Data: map[string]any{"class": game.ClassHeavy}})
+
+	other := ts.connectRawClient(t)
+	defer other.Close()
+	sendHelloMessage(t, other, "Other", "code", "CLASS")
+	_, _, err = readSessionStatus(t, other, "match_ready", 2*time.Second)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		state, exists := ts.handler.gameServer.GetPlayerState(heavyID)
+		return exists && state.Class == game.ClassHeavy
+	}, 2*time.Second, 10*time.Millisecond)
+	state, _ := ts.handler.gameServer.GetPlayerState(heavyID)
+	assert.Equal(t, 150, state.Health)
+	assert.Equal(t, 150, state.MaxHealth)
+	assert.Equal(t, "Shotgun", state.WeaponType)
+}
+
+func TestHandlePlayerLoadout_InGameWaitsForRespawn(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn1, conn2 := ts.connectTwoClients(t)
+	defer conn1.Close()
+	defer conn2.Close()
+
+	player1ID := consumeRoomJoinedAndGetPlayerID(t, conn1)
+	_ = consumeRoomJoinedAndGetPlayerID(t, conn2)
+
+	player := ts.handler.roomManager.GetRoomByPlayerID(player1ID).GetPlayer(player1ID)
+	require.NotNil(t, player)
+	ts.handler.handlePlayerLoadout(player, map[string]any{"class": game.ClassScout})
+
+	assert.Equal(t, game.ClassScout, player.Loadout.Class())
+	state, _ := ts.handler.gameServer.GetPlayerState(player1ID)
+	assert.Empty(t, state.Class, "the current life keeps its stats")
+	assert.Equal(t, game.PlayerMaxHealth, state.MaxHealth)
+}
@@ -0,0 +1,104 @@
+package network
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+// lobbySandboxInterval is how often lobby sandboxes step and send their state
+const lobbySandboxInterval = time.Duration(game.ClientUpdateInterval) * time.Millisecond
+
+// lobbySandboxes holds the solo sandbox of every player waiting in the
+// matchmaking queue
+type lobbySandboxes struct {
+	byPlayer map[string]*game.LobbySandbox
+	clock    game.Clock
+	mu       sync.Mutex
+}
+
+func newLobbySandboxes(clock game.Clock) *lobbySandboxes {
+	return &lobbySandboxes{
+		byPlayer: make(map[string]*game.LobbySandbox),
+		clock:    clock,
+	}
+}
+
+// open gives the player a fresh sandbox unless it already has one
+func (s *lobbySandboxes) open(playerID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.byPlayer[playerID]; !exists {
+		s.byPlayer[playerID] = game.NewLobbySandbox(playerID, s.clock)
+	}
+}
+
+// close discards the player's sandbox
+func (s *lobbySandboxes) close(playerID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.byPlayer, playerID)
+}
+
+// get returns the player's sandbox, or nil if it is not waiting
+func (s *lobbySandboxes) get(playerID string) *game.LobbySandbox {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.byPlayer[playerID]
+}
+
+// all returns every open sandbox in player ID order
+func (s *lobbySandboxes) all() []*game.LobbySandbox {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	playerIDs := sortedIDs(s.byPlayer)
+	sandboxes := make([]*game.LobbySandbox, 0, len(playerIDs))
+	for _, playerID := range playerIDs {
+		sandboxes = append(sandboxes, s.byPlayer[playerID])
+	}
+	return sandboxes
+}
+
+// syncLobbySandboxes opens a sandbox for each player a session result leaves
+// searching for a match and discards the sandboxes of players it activates
+func (h *WebSocketHandler) syncLobbySandboxes(result game.RoomSessionResult) {
+	for _, publication := range result.Publications {
+		if publication.State == game.SessionStatusSearchingForMatch {
+			h.sandboxes.open(publication.Player.ID)
+		}
+	}
+	for _, activation := range result.Activations {
+		h.sandboxes.close(activation.Player.ID)
+	}
+}
+
+// lobbySandboxLoop steps the lobby sandboxes at the client update rate
+func (h *WebSocketHandler) lobbySandboxLoop(ctx context.Context) {
+	ticker := time.NewTicker(lobbySandboxInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.stepLobbySandboxes(lobbySandboxInterval.Seconds())
+		}
+	}
+}
+
+// stepLobbySandboxes advances every sandbox and sends each waiting player its
+// state. A sandbox whose player is gone is discarded.
+func (h *WebSocketHandler) stepLobbySandboxes(deltaTime float64) {
+	for _, sandbox := range h.sandboxes.all() {
+		state := sandbox.Step(deltaTime)
+		if err := h.publication.SendLobbySandboxState(sandbox.PlayerID, state); err != nil {
+			log.Printf("Discarding lobby sandbox of %s: %v", sandbox.PlayerID, err)
+			h.sandboxes.close(sandbox.PlayerID)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitingPlayerGetsLobbySandboxUntilRoomForms(t *testing.T) {
+	withSchemaValidation(t)
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn1 := ts.connectClient(t)
+	defer conn1.Close()
+	msg, err := readMessageOfType(t, conn1, "lobby:sandbox_state", 2*time.Second)
+	require.NoError(t, err, "a waiting player gets a sandbox")
+	data := msg.Data.(map[string]interface{})
+	startY := data["player"].(map[string]interface{})["position"].(map[string]interface{})["y"].(float64)
+
+	// Input moves the player in its sandbox
+	sendInputState(t, conn1, false, true, false, false)
+	require.Eventually(t, func() bool {
+		msg, err := readMessageOfType(t, conn1, "lobby:sandbox_state", time.Second)
+		if err != nil {
+			return false
+		}
+		position := msg.Data.(map[string]interface{})["player"].(map[string]interface{})["position"].(map[string]interface{})
+		return position["y"].(float64) > startY
+	}, 2*time.Second, 10*time.Millisecond)
+
+	conn2 := ts.connectClient(t)
+	defer conn2.Close()
+	_, _, err = readSessionStatus(t, conn1, "match_ready", 2*time.Second)
+	require.NoError(t, err)
+
+	assert.Empty(t, ts.handler.sandboxes.all(), "sandboxes are discarded once the room forms")
+}
+
+func TestLeavingQueueDiscardsLobbySandbox(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn := ts.connectClient(t)
+	defer conn.Close()
+	_, err := readMessageOfType(t, conn, "lobby:sandbox_state", 2*time.Second)
+	require.NoError(t, err)
+
+	sendMessage(t, conn, Message{Type: "session:leave", Timestamp: time.Now().UnixMilli()})
+	require.Eventually(t, func() bool { return len(ts.handler.sandboxes.all()) == 0 }, 2*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,34 @@
+package network
+
+import (
+	"log"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+// updateMatchPause pauses a room's match clock while most of its players are
+// parked waiting to resume, as after a network blip that drops the whole
+// room, and restarts it once they are back or their grace period runs out
+func (h *WebSocketHandler) updateMatchPause(room *game.Room) {
+	if room == nil || room.Match == nil {
+		return
+	}
+
+	players := room.GetPlayers()
+	parked := 0
+	for _, player := range players {
+		if h.resumer.parked(player.ID) {
+			parked++
+		}
+	}
+
+	if parked > 0 && parked*2 > len(players) {
+		if room.Match.Pause() {
+			log.Printf("Match clock paused in room %s: %d of %d players reconnecting", room.ID, parked, len(players))
+		}
+		return
+	}
+	if room.Match.Resume() {
+		log.Printf("Match clock resumed in room %s", room.ID)
+	}
+}
@@ -0,0 +1,37 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchClockPausesWhileMostOfTheRoomIsReconnecting(t *testing.T) {
+	handler := NewWebSocketHandler()
+	handler.resumer = newSessionResumer(time.Minute)
+
+	room := game.NewRoom()
+	tokens := make([]string, 0, 3)
+	for _, id := range []string{"p1", "p2", "p3"} {
+		player := game.NewPlayer(id, make(chan []byte, 1))
+		require.NoError(t, room.AddPlayer(player))
+		tokens = append(tokens, handler.resumer.issue(player, func(disconnectReason) {}))
+	}
+	room.Match.Start()
+
+	handler.resumer.park(tokens[0], func() {})
+	handler.updateMatchPause(room)
+	assert.False(t, room.Match.IsPaused(), "one dropped player does not stop the clock")
+
+	handler.resumer.park(tokens[1], func() {})
+	handler.updateMatchPause(room)
+	assert.True(t, room.Match.IsPaused(), "the clock stops while most of the room is reconnecting")
+
+	_, _, ok := handler.resumer.resume(tokens[1], func(disconnectReason) {})
+	require.True(t, ok)
+	handler.updateMatchPause(room)
+	assert.False(t, room.Match.IsPaused(), "the clock restarts once the room is back")
+}
@@ -0,0 +1,125 @@
+package network
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+const (
+	// defaultMatchHistoryLimit is how many matches GET /matches returns
+	// without a limit
+	defaultMatchHistoryLimit = 20
+	// maxMatchHistoryLimit caps the limit a client may ask for
+	maxMatchHistoryLimit = 100
+)
+
+// MatchSummary is one finished match as listed in a match history
+type MatchSummary struct {
+	MatchID    string               `json:"matchId"`
+	RoomID     string               `json:"roomId"`
+	MapID      string               `json:"mapId"`
+	Reason     string               `json:"reason"`
+	Winners    []game.WinnerSummary `json:"winners"`
+	Players    []game.PlayerScore   `json:"players"` // Final scores, kills and deaths of everyone who finished
+	DurationMs int64                `json:"durationMs"`
+	EndedAt    time.Time            `json:"endedAt"`
+}
+
+// matchHistoryReader lists recorded matches newest first, only those playerID
+// finished when it is set
+type matchHistoryReader interface {
+	RecentMatches(playerID string, limit int) ([]MatchSummary, error)
+}
+
+// RecentMatches keeps the last limit matching records while scanning and
+// returns them newest first
+func (s *fileMatchRecordStore) RecentMatches(playerID string, limit int) ([]MatchSummary, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	matches := make([]MatchSummary, 0, limit)
+	err := s.eachMatchRecordLocked(func(record MatchRecord) {
+		if limit <= 0 || (playerID != "" && !recordHasPlayer(record, playerID)) {
+			return
+		}
+		if len(matches) == limit {
+			matches = append(matches[:0], matches[1:]...)
+		}
+		matches = append(matches, newMatchSummary(record))
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(matches)
+	return matches, nil
+}
+
+// recordHasPlayer reports whether playerID finished the recorded match
+func recordHasPlayer(record MatchRecord, playerID string) bool {
+	for _, score := range record.FinalScores {
+		if score.PlayerID == playerID {
+			return true
+		}
+	}
+	return false
+}
+
+func newMatchSummary(record MatchRecord) MatchSummary {
+	return MatchSummary{
+		MatchID:    record.MatchID,
+		RoomID:     record.RoomID,
+		MapID:      record.MapID,
+		Reason:     record.Reason,
+		Winners:    record.Winners,
+		Players:    record.FinalScores,
+		DurationMs: record.DurationMs,
+		EndedAt:    record.EndedAt,
+	}
+}
+
+// HandleMatchHistory serves match history from the shared global handler
+func HandleMatchHistory(w http.ResponseWriter, r *http.Request) {
+	getGlobalHandler().HandleMatchHistory(w, r)
+}
+
+// HandleMatchHistory lists recent recorded matches, newest first, for match
+// history screens. ?playerId= keeps the matches that player finished and
+// ?limit= sets how many are returned. 404 while match records are off.
+func (h *WebSocketHandler) HandleMatchHistory(w http.ResponseWriter, r *http.Request) {
+	reader, ok := h.matchRecords.(matchHistoryReader)
+	if !ok {
+		http.Error(w, "match history not kept", http.StatusNotFound)
+		return
+	}
+
+	query := r.URL.Query()
+	limit := defaultMatchHistoryLimit
+	if raw := query.Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 {
+			http.Error(w, "limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		limit = min(parsed, maxMatchHistoryLimit)
+	}
+
+	matches, err := reader.RecentMatches(query.Get("playerId"), limit)
+	if err != nil {
+		log.Printf("Error reading match history: %v", err)
+		http.Error(w, "match history unavailable", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(struct {
+		Matches []MatchSummary `json:"matches"`
+	}{matches}); err != nil {
+		log.Printf("Failed to encode %s response: %v", r.URL.Path, err)
+	}
+}
@@ -0,0 +1,93 @@
+package network
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getMatchHistory(h *WebSocketHandler, query string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/matches"+query, nil)
+	rec := httptest.NewRecorder()
+	h.HandleMatchHistory(rec, req)
+	return rec
+}
+
+func decodeMatchHistory(t *testing.T, rec *httptest.ResponseRecorder) []MatchSummary {
+	t.Helper()
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var body struct {
+		Matches []MatchSummary `json:"matches"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	return body.Matches
+}
+
+func TestMatchHistoryListsRecentMatchesOfAPlayer(t *testing.T) {
+	f := newGoldenFixture(t)
+	store := newFileMatchRecordStore(t.TempDir())
+	f.handler.matchRecords = store
+
+	assert.Empty(t, decodeMatchHistory(t, getMatchHistory(f.handler, "")), "nothing recorded yet")
+
+	endedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	for i, players := range [][]string{{"alpha", "bravo"}, {"bravo", "charlie"}, {"alpha", "charlie"}} {
+		var scores []game.PlayerScore
+		for _, id := range players {
+			scores = append(scores, game.PlayerScore{PlayerID: id, Kills: i})
+		}
+		require.NoError(t, store.SaveMatchRecord(MatchRecord{
+			MatchID:     "m" + strconv.Itoa(i+1),
+			Reason:      "kill_target",
+			Winners:     []game.WinnerSummary{{PlayerID: players[0]}},
+			FinalScores: scores,
+			DurationMs:  int64(60000 * (i + 1)),
+			EndedAt:     endedAt.Add(time.Duration(i) * time.Hour),
+		}))
+	}
+
+	matches := decodeMatchHistory(t, getMatchHistory(f.handler, "?playerId=alpha"))
+	require.Len(t, matches, 2)
+	assert.Equal(t, "m3", matches[0].MatchID, "newest first")
+	assert.Equal(t, "m1", matches[1].MatchID)
+	assert.Equal(t, "kill_target", matches[0].Reason)
+	assert.Equal(t, int64(180000), matches[0].DurationMs)
+	assert.Equal(t, "alpha", matches[0].Winners[0].PlayerID)
+	assert.Equal(t, []game.PlayerScore{{PlayerID: "alpha", Kills: 2}, {PlayerID: "charlie", Kills: 2}}, matches[0].Players)
+	assert.Equal(t, endedAt.Add(2*time.Hour), matches[0].EndedAt.UTC())
+
+	matches = decodeMatchHistory(t, getMatchHistory(f.handler, "?limit=2"))
+	require.Len(t, matches, 2, "every player's matches without playerId")
+	assert.Equal(t, []string{"m3", "m2"}, []string{matches[0].MatchID, matches[1].MatchID})
+
+	assert.Empty(t, decodeMatchHistory(t, getMatchHistory(f.handler, "?playerId=nobody")))
+	assert.Equal(t, http.StatusBadRequest, getMatchHistory(f.handler, "?limit=0").Code)
+	assert.Equal(t, http.StatusBadRequest, getMatchHistory(f.handler, "?limit=ten").Code)
+}
+
+func TestMatchHistoryIsNotFoundWithoutRecords(t *testing.T) {
+	f := newGoldenFixture(t)
+	assert.Equal(t, http.StatusNotFound, getMatchHistory(f.handler, "?playerId=alpha").Code)
+}
+
+func TestMatchRecordKeepsDuration(t *testing.T) {
+	f := newGoldenFixture(t)
+	dir := t.TempDir()
+	f.handler.matchRecords = newFileMatchRecordStore(dir)
+	f.room.Match.Start()
+	f.room.Match.AdvanceTicks(40)
+
+	f.handler.broadcastMatchEndedEvent(game.MatchEndedEvent{RoomID: f.room.ID, Reason: "time_limit"})
+
+	records := readMatchRecords(t, dir)
+	require.Len(t, records, 1)
+	assert.Equal(t, f.room.Match.Elapsed().Milliseconds(), records[0].DurationMs)
+	assert.Positive(t, records[0].DurationMs)
+}
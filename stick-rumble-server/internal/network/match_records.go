@@ -0,0 +1,240 @@
+package network
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/buildinfo"
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+// matchRecordFileName is the JSON-lines file match records are appended to
+const matchRecordFileName = "matches.jsonl"
+
+// leaderboardFileName is the file the latest leaderboard is kept in, next to
+// the match records it ranks
+const leaderboardFileName = "leaderboard.json"
+
+// MatchRecord is one finished match: its result as announced in match:ended,
+// plus the setup and server build it ran with, so the result can be audited
+// and the match replayed from its seed
+type MatchRecord struct {
+	MatchID     string                  `json:"matchId"`
+	RoomID      string                  `json:"roomId"`
+	Reason      string                  `json:"reason"`
+	Winners     []game.WinnerSummary    `json:"winners"`
+	FinalScores []game.PlayerScore      `json:"finalScores"`
+	Scoreboard  []game.PlayerMatchStats `json:"scoreboard"`
+	Settings    game.MatchSettings      `json:"settings"`
+	MapID       string                  `json:"mapId"`
+	Mutators    []string                `json:"mutators"`
+	Seed        int64                   `json:"seed"`
+	Build       buildinfo.Info          `json:"build"`
+	DurationMs  int64                   `json:"durationMs"` // Match time played, 0 in records written before it was kept
+	EndedAt     time.Time               `json:"endedAt"`
+}
+
+// matchRecordStore persists finished matches
+type matchRecordStore interface {
+	SaveMatchRecord(record MatchRecord) error
+}
+
+// PlayerHistory is one player's totals over the recorded matches they
+// finished
+type PlayerHistory struct {
+	DisplayName    string    `json:"displayName"` // Name in their latest recorded match
+	Matches        int       `json:"matches"`
+	Wins           int       `json:"wins"`
+	Kills          int       `json:"kills"`
+	Deaths         int       `json:"deaths"`
+	Assists        int       `json:"assists"`
+	XP             int       `json:"xp"`
+	ShotsFired     int       `json:"shotsFired"`
+	ShotsHit       int       `json:"shotsHit"`
+	Accuracy       float64   `json:"accuracy"` // ShotsHit / ShotsFired, at most 1; 0 without shots
+	DamageDealt    int       `json:"damageDealt"`
+	BestKillStreak int       `json:"bestKillStreak"`
+	LastPlayedAt   time.Time `json:"lastPlayedAt"`
+}
+
+// playerHistoryReader totals a player's persisted matches; it reports false
+// when none were recorded
+type playerHistoryReader interface {
+	PlayerHistory(playerID string) (PlayerHistory, bool, error)
+}
+
+// fileMatchRecordStore appends each record as a line of matches.jsonl in its
+// directory. Matches end minutes apart, so the file is opened per record
+// rather than held open.
+type fileMatchRecordStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+func newFileMatchRecordStore(dir string) *fileMatchRecordStore {
+	return &fileMatchRecordStore{dir: dir}
+}
+
+func (s *fileMatchRecordStore) SaveMatchRecord(record MatchRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("create match record directory: %w", err)
+	}
+	file, err := os.OpenFile(filepath.Join(s.dir, matchRecordFileName), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open match record file: %w", err)
+	}
+	if err := json.NewEncoder(file).Encode(record); err != nil {
+		file.Close()
+		return fmt.Errorf("write match record: %w", err)
+	}
+	return file.Close()
+}
+
+// eachMatchRecordLocked hands visit every record in matches.jsonl, oldest
+// first. A missing file has no records. Lookups are rare next to match
+// traffic, so the file is scanned per call rather than indexed. The caller
+// holds s.mu.
+func (s *fileMatchRecordStore) eachMatchRecordLocked(visit func(MatchRecord)) error {
+	file, err := os.Open(filepath.Join(s.dir, matchRecordFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("open match record file: %w", err)
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	for {
+		var record MatchRecord
+		if err := decoder.Decode(&record); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("read match record: %w", err)
+		}
+		visit(record)
+	}
+}
+
+// eachMatchRecord hands visit every record in matches.jsonl, oldest first
+func (s *fileMatchRecordStore) eachMatchRecord(visit func(MatchRecord)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.eachMatchRecordLocked(visit)
+}
+
+// SaveLeaderboard replaces leaderboard.json with board. It is written to a
+// temporary file first so a crash never leaves half a leaderboard.
+func (s *fileMatchRecordStore) SaveLeaderboard(board Leaderboard) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("create match record directory: %w", err)
+	}
+	file, err := os.CreateTemp(s.dir, leaderboardFileName+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create leaderboard file: %w", err)
+	}
+	if err := json.NewEncoder(file).Encode(board); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return fmt.Errorf("write leaderboard: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return fmt.Errorf("write leaderboard: %w", err)
+	}
+	if err := os.Rename(file.Name(), filepath.Join(s.dir, leaderboardFileName)); err != nil {
+		os.Remove(file.Name())
+		return fmt.Errorf("replace leaderboard file: %w", err)
+	}
+	return nil
+}
+
+// LoadLeaderboard reads leaderboard.json, or returns nil when there is none
+func (s *fileMatchRecordStore) LoadLeaderboard() (*Leaderboard, error) {
+	raw, err := os.ReadFile(filepath.Join(s.dir, leaderboardFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read leaderboard file: %w", err)
+	}
+	var board Leaderboard
+	if err := json.Unmarshal(raw, &board); err != nil {
+		return nil, fmt.Errorf("decode leaderboard file: %w", err)
+	}
+	return &board, nil
+}
+
+// PlayerHistory totals the lines of playerID's recorded scoreboards
+func (s *fileMatchRecordStore) PlayerHistory(playerID string) (PlayerHistory, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var history PlayerHistory
+	err := s.eachMatchRecordLocked(func(record MatchRecord) {
+		for _, line := range record.Scoreboard {
+			if line.PlayerID == playerID {
+				history.add(record, line)
+			}
+		}
+	})
+	if err != nil {
+		return PlayerHistory{}, false, err
+	}
+	if history.ShotsFired > 0 {
+		history.Accuracy = math.Min(float64(history.ShotsHit)/float64(history.ShotsFired), 1)
+	}
+	return history, history.Matches > 0, nil
+}
+
+// add counts one recorded match's scoreboard line
+func (h *PlayerHistory) add(record MatchRecord, line game.PlayerMatchStats) {
+	h.Matches++
+	for _, winner := range record.Winners {
+		if winner.PlayerID == line.PlayerID {
+			h.Wins++
+			break
+		}
+	}
+	h.Kills += line.Kills
+	h.Deaths += line.Deaths
+	h.Assists += line.Assists
+	h.XP += line.XP
+	h.ShotsFired += line.ShotsFired
+	h.ShotsHit += line.ShotsHit
+	h.DamageDealt += line.DamageDealt
+	h.BestKillStreak = max(h.BestKillStreak, line.BestKillStreak)
+	if !record.EndedAt.Before(h.LastPlayedAt) {
+		h.LastPlayedAt = record.EndedAt
+		h.DisplayName = line.DisplayName
+	}
+}
+
+// newMatchRecord builds the record of a match whose match:ended payload is data
+func newMatchRecord(room *game.Room, data matchEndedData, endedAt time.Time) MatchRecord {
+	return MatchRecord{
+		MatchID:     room.Match.GetID(),
+		RoomID:      room.ID,
+		Reason:      data.Reason,
+		Winners:     data.Winners,
+		FinalScores: data.FinalScores,
+		Scoreboard:  data.Scoreboard,
+		Settings:    data.Settings,
+		MapID:       data.MapID,
+		Mutators:    data.Mutators,
+		Seed:        data.Seed,
+		Build:       data.Build,
+		DurationMs:  room.Match.Elapsed().Milliseconds(),
+		EndedAt:     endedAt,
+	}
+}
@@ -0,0 +1,76 @@
+package network
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mtomcal/stick-rumble-server/internal/buildinfo"
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readMatchRecords(t *testing.T, dir string) []MatchRecord {
+	t.Helper()
+
+	file, err := os.Open(filepath.Join(dir, matchRecordFileName))
+	require.NoError(t, err)
+	defer file.Close()
+
+	var records []MatchRecord
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record MatchRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestFileMatchRecordStoreAppendsJSONLines(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "matches")
+	store := newFileMatchRecordStore(dir)
+
+	require.NoError(t, store.SaveMatchRecord(MatchRecord{MatchID: "m1", Seed: 7}))
+	require.NoError(t, store.SaveMatchRecord(MatchRecord{MatchID: "m2", Seed: 8}))
+
+	records := readMatchRecords(t, dir)
+	require.Len(t, records, 2)
+	assert.Equal(t, "m1", records[0].MatchID)
+	assert.Equal(t, int64(8), records[1].Seed)
+}
+
+func TestMatchEndedRecordsSetupAndBuild(t *testing.T) {
+	f := newGoldenFixture(t)
+	dir := t.TempDir()
+	f.handler.matchRecords = newFileMatchRecordStore(dir)
+	build := buildinfo.Info{Commit: "abc1234", BuildTime: "2026-10-16T00:00:00Z", GoVersion: "go1.25.0"}
+	f.handler.buildInfo = func() buildinfo.Info { return build }
+	f.room.Reseed(99)
+	f.room.Match.Start()
+
+	f.handler.broadcastMatchEndedEvent(game.MatchEndedEvent{
+		RoomID:  f.room.ID,
+		Reason:  "time_limit",
+		Winners: []game.WinnerSummary{{PlayerID: "player-a", DisplayName: "Alpha"}},
+	})
+
+	var msg Message
+	require.NoError(t, json.Unmarshal(f.received(t, "match:ended"), &msg))
+	data := msg.Data.(map[string]interface{})
+	assert.Equal(t, float64(99), data["seed"])
+	assert.Equal(t, game.DefaultMapID, data["mapId"])
+	assert.Equal(t, "abc1234", data["build"].(map[string]interface{})["commit"])
+
+	records := readMatchRecords(t, dir)
+	require.Len(t, records, 1)
+	assert.Equal(t, f.room.Match.GetID(), records[0].MatchID)
+	assert.Equal(t, f.room.ID, records[0].RoomID)
+	assert.Equal(t, "time_limit", records[0].Reason)
+	assert.Equal(t, int64(99), records[0].Seed)
+	assert.Equal(t, "code", records[0].Settings.RoomKind)
+	assert.Equal(t, build, records[0].Build)
+}
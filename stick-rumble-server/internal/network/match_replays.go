@@ -0,0 +1,365 @@
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/mtomcal/stick-rumble-server/internal/replay"
+)
+
+// matchReplayEventTypes are the room broadcasts a replay keeps between its
+// states: shots, hits, deaths, pickups and the match result
+var matchReplayEventTypes = map[string]bool{
+	"projectile:spawn":        true,
+	"projectile:explode":      true,
+	"player:damaged":          true,
+	"melee:hit":               true,
+	"player:death":            true,
+	"player:kill_credit":      true,
+	"player:respawn":          true,
+	"weapon:pickup_confirmed": true,
+	"shield:pickup_confirmed": true,
+	"health:pickup_confirmed": true,
+	"match:ended":             true,
+}
+
+// matchReplayTap names the recorder's broadcast tap on a room
+const matchReplayTap = "match_replay"
+
+// matchReplayLog samples replay write failures, which repeat on every state
+// broadcast until the disk recovers
+var matchReplayLog = game.HotPathLogger("match_replay")
+
+// matchReplay is the replay file of one room's match
+type matchReplay struct {
+	roomID  string
+	matchID string
+	path    string
+	file    *os.File
+	writer  *replay.Writer
+	mu      sync.Mutex
+}
+
+// matchReplayRecorder writes a replay file for every match while a replay
+// directory is configured. A room's replay starts with the first state
+// broadcast of its match and ends with match:ended; events reach it through
+// the room's broadcast tap, and simulation ticks and player actions through
+// the game server's action recorder.
+type matchReplayRecorder struct {
+	dir     string
+	tick    func() uint64
+	now     func() time.Time
+	world   func() *game.World
+	replays map[string]*matchReplay // room ID -> replay of its current match
+	players map[string]*matchReplay // player ID -> replay its actions go to
+	mu      sync.Mutex
+}
+
+func newMatchReplayRecorder(dir string, tick func() uint64, now func() time.Time, world func() *game.World) *matchReplayRecorder {
+	return &matchReplayRecorder{
+		dir:     dir,
+		tick:    tick,
+		now:     now,
+		world:   world,
+		replays: make(map[string]*matchReplay),
+		players: make(map[string]*matchReplay),
+	}
+}
+
+// recordState appends a room's state broadcast to the replay of its match,
+// starting the replay on the match's first broadcast
+func (r *matchReplayRecorder) recordState(room *game.Room, frame *broadcastFrame) {
+	if !room.Match.IsStarted() || room.Match.IsEnded() {
+		return
+	}
+
+	// Projectiles are world-wide; the room's are the ones its players fired
+	inRoom := make(map[string]bool, len(frame.players))
+	for _, player := range frame.players {
+		inRoom[player.ID] = true
+	}
+	projectiles := make([]game.ProjectileSnapshot, 0, len(frame.projectiles))
+	for _, proj := range frame.projectiles {
+		if inRoom[proj.OwnerID] {
+			projectiles = append(projectiles, proj)
+		}
+	}
+	state := replay.State{Players: frame.players, Projectiles: projectiles}
+
+	rec, started := r.replayFor(room, frame.tick, state)
+	if rec == nil || started {
+		return
+	}
+	r.followNewPlayers(rec, state.Players)
+	rec.write(func(w *replay.Writer) error {
+		return w.WriteState(frame.tick, r.now(), state)
+	})
+}
+
+// followNewPlayers routes the actions of players who joined the room after
+// its replay started to the replay, which adds them where they stand now
+func (r *matchReplayRecorder) followNewPlayers(rec *matchReplay, players []game.PlayerStateSnapshot) {
+	var joined []game.PlayerStateSnapshot
+	r.mu.Lock()
+	for _, player := range players {
+		if r.players[player.ID] != rec {
+			r.players[player.ID] = rec
+			joined = append(joined, player)
+		}
+	}
+	r.mu.Unlock()
+
+	for _, player := range joined {
+		position := player.Position
+		rec.write(func(w *replay.Writer) error {
+			return w.WriteAction(r.tick(), game.Action{Kind: game.ActionJoin, PlayerID: player.ID, At: r.now().UnixNano(), Class: player.Class, Position: &position})
+		})
+	}
+}
+
+// RecordTick appends a simulation tick to every replay in progress
+func (r *matchReplayRecorder) RecordTick(record game.TickRecord) {
+	r.mu.Lock()
+	replays := make([]*matchReplay, 0, len(r.replays))
+	for _, rec := range r.replays {
+		replays = append(replays, rec)
+	}
+	r.mu.Unlock()
+
+	for _, rec := range replays {
+		inputs := make([]game.PlayerInput, 0, len(record.Inputs))
+		for _, input := range record.Inputs {
+			if r.replayOf(input.PlayerID) == rec {
+				inputs = append(inputs, input)
+			}
+		}
+		roomRecord := record
+		roomRecord.Inputs = inputs
+		rec.write(func(w *replay.Writer) error {
+			return w.WriteTick(roomRecord)
+		})
+	}
+}
+
+// RecordAction appends a player's action to the replay of its room's match
+func (r *matchReplayRecorder) RecordAction(action game.Action) {
+	rec := r.replayOf(action.PlayerID)
+	if rec == nil {
+		return
+	}
+	if action.Kind == game.ActionLeave {
+		r.mu.Lock()
+		delete(r.players, action.PlayerID)
+		r.mu.Unlock()
+	}
+	rec.write(func(w *replay.Writer) error {
+		return w.WriteAction(r.tick(), action)
+	})
+}
+
+func (r *matchReplayRecorder) replayOf(playerID string) *matchReplay {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.players[playerID]
+}
+
+// replayFor returns the replay of the room's current match. If the match has
+// none yet it opens one, starting with state and the input each of the
+// room's players is holding, and reports true. Returns nil if the file cannot
+// be created.
+func (r *matchReplayRecorder) replayFor(room *game.Room, tick uint64, state replay.State) (*matchReplay, bool) {
+	setup := room.MatchSetup()
+
+	r.mu.Lock()
+	rec, exists := r.replays[room.ID]
+	if exists && rec.matchID == setup.MatchID {
+		r.mu.Unlock()
+		return rec, false
+	}
+	delete(r.replays, room.ID)
+	r.forgetPlayersLocked(rec)
+	r.mu.Unlock()
+
+	// A rematch replaced the match without it ending through publishMatchEnded
+	if exists {
+		rec.close()
+	}
+
+	rec, err := r.open(room.ID, setup)
+	if err != nil {
+		matchReplayLog.Printf("Error starting match replay for room %s: %v", room.ID, err)
+		return nil, false
+	}
+	rec.write(func(w *replay.Writer) error {
+		if err := w.WriteState(tick, r.now(), state); err != nil {
+			return err
+		}
+		return r.writeHeldInputs(w, tick, state.Players)
+	})
+
+	r.mu.Lock()
+	r.replays[room.ID] = rec
+	for _, player := range state.Players {
+		r.players[player.ID] = rec
+	}
+	r.mu.Unlock()
+
+	room.SetBroadcastTap(matchReplayTap, func(message []byte) {
+		r.recordEvent(rec, message)
+	})
+	log.Printf("Match replay started for room %s: %s", room.ID, rec.path)
+	return rec, true
+}
+
+// writeHeldInputs records the input each player is holding as a replay
+// starts; later inputs come with the ticks that apply them
+func (r *matchReplayRecorder) writeHeldInputs(w *replay.Writer, tick uint64, players []game.PlayerStateSnapshot) error {
+	world := r.world()
+	for _, snapshot := range players {
+		player, exists := world.GetPlayer(snapshot.ID)
+		if !exists {
+			continue
+		}
+		sequence := player.GetInputSequence()
+		input := game.QueuedInput{Input: player.GetInput(), Sequence: sequence, HasSequence: sequence > 0}
+		if err := w.WriteAction(tick, game.Action{Kind: game.ActionInput, PlayerID: snapshot.ID, At: r.now().UnixNano(), Input: &input}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forgetPlayersLocked stops routing actions to rec. It is called with r.mu held.
+func (r *matchReplayRecorder) forgetPlayersLocked(rec *matchReplay) {
+	if rec == nil {
+		return
+	}
+	for playerID, routed := range r.players {
+		if routed == rec {
+			delete(r.players, playerID)
+		}
+	}
+}
+
+func (r *matchReplayRecorder) open(roomID string, setup game.MatchSetup) (*matchReplay, error) {
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create replay directory: %w", err)
+	}
+
+	path := filepath.Join(r.dir, fmt.Sprintf("%s-%s%s", roomID, setup.MatchID, replay.FileExtension))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("create replay file: %w", err)
+	}
+
+	writer, err := replay.NewWriter(file, replay.Header{
+		RoomID:    roomID,
+		MatchID:   setup.MatchID,
+		MapID:     setup.MapID,
+		Seed:      setup.Seed,
+		StartedAt: r.now().UnixMilli(),
+	})
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return &matchReplay{roomID: roomID, matchID: setup.MatchID, path: path, file: file, writer: writer}, nil
+}
+
+// recordEvent appends a room broadcast to the replay if it is a key event.
+// It runs inside the room's broadcast, so it must not touch the room.
+func (r *matchReplayRecorder) recordEvent(rec *matchReplay, message []byte) {
+	var envelope struct {
+		Type string          `json:"type"`
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(message, &envelope); err != nil || !matchReplayEventTypes[envelope.Type] {
+		return
+	}
+
+	rec.write(func(w *replay.Writer) error {
+		return w.WriteEvent(r.tick(), r.now(), envelope.Type, envelope.Data)
+	})
+}
+
+// finish closes the replay of a room whose match just ended
+func (r *matchReplayRecorder) finish(room *game.Room) {
+	r.mu.Lock()
+	rec, exists := r.replays[room.ID]
+	delete(r.replays, room.ID)
+	r.forgetPlayersLocked(rec)
+	r.mu.Unlock()
+
+	if !exists {
+		return
+	}
+	room.SetBroadcastTap(matchReplayTap, nil)
+	rec.close()
+}
+
+// closeRemovedRooms closes the replays of rooms that were removed before
+// their match ended
+func (r *matchReplayRecorder) closeRemovedRooms(rooms *game.RoomManager) {
+	r.mu.Lock()
+	var removed []*matchReplay
+	for roomID, rec := range r.replays {
+		if rooms.GetRoom(roomID) == nil {
+			removed = append(removed, rec)
+			delete(r.replays, roomID)
+			r.forgetPlayersLocked(rec)
+		}
+	}
+	r.mu.Unlock()
+
+	for _, rec := range removed {
+		rec.close()
+	}
+}
+
+func (r *matchReplayRecorder) stopAll() {
+	r.mu.Lock()
+	replays := r.replays
+	r.replays = make(map[string]*matchReplay)
+	r.players = make(map[string]*matchReplay)
+	r.mu.Unlock()
+
+	for _, rec := range replays {
+		rec.close()
+	}
+}
+
+func (m *matchReplay) write(write func(w *replay.Writer) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.writer == nil {
+		return
+	}
+	if err := write(m.writer); err != nil {
+		matchReplayLog.Printf("Match replay write failed for room %s: %v", m.roomID, err)
+	}
+}
+
+func (m *matchReplay) close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.writer == nil {
+		return
+	}
+	entries := m.writer.Entries()
+	if err := m.writer.Close(); err != nil {
+		log.Printf("Match replay flush failed for %s: %v", m.path, err)
+	}
+	if err := m.file.Close(); err != nil {
+		log.Printf("Match replay close failed for %s: %v", m.path, err)
+	}
+	m.writer = nil
+	log.Printf("Match replay saved for room %s: %s (%d entries)", m.roomID, m.path, entries)
+}
@@ -0,0 +1,148 @@
+package network
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/mtomcal/stick-rumble-server/internal/replay"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readReplayEntries(t *testing.T, path string) (replay.Header, []replay.Entry) {
+	t.Helper()
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	reader, err := replay.NewReader(file)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	var entries []replay.Entry
+	for {
+		entry, err := reader.Next()
+		if err == io.EOF {
+			return reader.Header, entries
+		}
+		require.NoError(t, err)
+		entries = append(entries, entry)
+	}
+}
+
+func TestMatchReplayRecordsStatesAndKeyEvents(t *testing.T) {
+	f := newGoldenFixture(t)
+	dir := t.TempDir()
+	f.handler.replays = newMatchReplayRecorder(dir, func() uint64 { return 7 }, func() time.Time { return goldenTime }, f.handler.gameServer.GetWorld)
+	states := []game.PlayerStateSnapshot{
+		{ID: "player-a", Position: game.Vector2{X: 100, Y: 200}, Health: 100},
+		{ID: "player-b", Position: game.Vector2{X: 300, Y: 400}, Health: 100},
+	}
+
+	f.handler.broadcastPlayerStates(states)
+	matches, err := filepath.Glob(filepath.Join(dir, "*"+replay.FileExtension))
+	require.NoError(t, err)
+	assert.Empty(t, matches, "nothing is recorded before the match starts")
+
+	f.room.Reseed(5)
+	f.room.Match.Start()
+	f.handler.broadcastPlayerStates(states)
+	require.NoError(t, f.handler.publication.BroadcastPlayerDeath(f.room, playerDeathData{VictimID: "player-b", AttackerID: "player-a"}))
+	f.handler.broadcastMatchTimerEvent(game.MatchTimerUpdatedEvent{RoomID: f.room.ID, RemainingSeconds: 30})
+	f.handler.broadcastMatchEndedEvent(game.MatchEndedEvent{RoomID: f.room.ID, Reason: "time_limit"})
+	require.NoError(t, f.handler.publication.BroadcastPlayerDeath(f.room, playerDeathData{VictimID: "player-a", AttackerID: "player-b"}))
+
+	path := filepath.Join(dir, f.room.ID+"-"+f.room.Match.GetID()+replay.FileExtension)
+	header, entries := readReplayEntries(t, path)
+	assert.Equal(t, f.room.ID, header.RoomID)
+	assert.Equal(t, f.room.Match.GetID(), header.MatchID)
+	assert.Equal(t, game.DefaultMapID, header.MapID)
+	assert.Equal(t, int64(5), header.Seed)
+	assert.Equal(t, goldenTime.UnixMilli(), header.StartedAt)
+
+	require.Len(t, entries, 3, "the timer is not a key event, and the replay ends with the match")
+	assert.Equal(t, replay.KindState, entries[0].Kind)
+	assert.Equal(t, f.handler.gameServer.Tick(), entries[0].Tick, "a state carries its broadcast's tick")
+	state, err := entries[0].State()
+	require.NoError(t, err)
+	require.Len(t, state.Players, 2)
+	assert.Equal(t, game.Vector2{X: 300, Y: 400}, state.Players[1].Position)
+
+	assert.Equal(t, "player:death", entries[1].Type)
+	assert.Equal(t, uint64(7), entries[1].Tick)
+	assert.JSONEq(t, `{"victimId":"player-b","attackerId":"player-a"}`, string(entries[1].Data))
+	assert.Equal(t, "match:ended", entries[2].Type)
+}
+
+func TestMatchReplayClosesWhenRoomIsRemoved(t *testing.T) {
+	f := newGoldenFixture(t)
+	dir := t.TempDir()
+	f.handler.replays = newMatchReplayRecorder(dir, func() uint64 { return 1 }, func() time.Time { return goldenTime }, f.handler.gameServer.GetWorld)
+	f.room.Match.Start()
+	f.handler.broadcastPlayerStates([]game.PlayerStateSnapshot{{ID: "player-a"}})
+
+	f.handler.roomManager.RemovePlayer("player-a")
+	f.handler.roomManager.RemovePlayer("player-b")
+	f.handler.broadcastPlayerStates([]game.PlayerStateSnapshot{{ID: "player-a"}})
+
+	_, entries := readReplayEntries(t, filepath.Join(dir, f.room.ID+"-"+f.room.Match.GetID()+replay.FileExtension))
+	assert.Len(t, entries, 1, "the replay is flushed once its room is gone")
+}
+
+func TestMatchReplayRecordsTicksAndActions(t *testing.T) {
+	f := newGoldenFixture(t)
+	dir := t.TempDir()
+	f.handler.replays = newMatchReplayRecorder(dir, func() uint64 { return 3 }, func() time.Time { return goldenTime }, f.handler.gameServer.GetWorld)
+	f.handler.gameServer.SetActionRecorder(f.handler.replays)
+	f.handler.gameServer.AddPlayer("player-a")
+	f.handler.gameServer.AddPlayer("outsider")
+	f.handler.gameServer.QueuePlayerInput("player-a", game.QueuedInput{Input: game.InputState{Right: true}, Sequence: 4, HasSequence: true})
+	f.handler.gameServer.Step(goldenTime, 1.0/60.0)
+
+	f.room.Match.Start()
+	f.handler.broadcastPlayerStates([]game.PlayerStateSnapshot{{ID: "player-a"}})
+	f.handler.gameServer.QueuePlayerInput("player-a", game.QueuedInput{Input: game.InputState{Up: true}, Sequence: 5, HasSequence: true})
+	f.handler.gameServer.QueuePlayerInput("outsider", game.QueuedInput{Input: game.InputState{Down: true}})
+	f.handler.gameServer.Step(goldenTime.Add(time.Second/60), 1.0/60.0)
+	f.handler.gameServer.PlayerReload("player-a")
+	f.handler.gameServer.PlayerReload("outsider")
+	f.handler.broadcastPlayerStates([]game.PlayerStateSnapshot{{ID: "player-a"}, {ID: "player-b", Class: "tank", Position: game.Vector2{X: 50, Y: 60}}})
+	f.handler.broadcastMatchEndedEvent(game.MatchEndedEvent{RoomID: f.room.ID, Reason: "time_limit"})
+
+	_, entries := readReplayEntries(t, filepath.Join(dir, f.room.ID+"-"+f.room.Match.GetID()+replay.FileExtension))
+	require.Len(t, entries, 7)
+	assert.Equal(t, replay.KindState, entries[0].Kind)
+
+	held, err := entries[1].Action()
+	require.NoError(t, err)
+	assert.Equal(t, game.ActionInput, held.Kind, "a replay starts with the input each player holds")
+	require.NotNil(t, held.Input)
+	assert.True(t, held.Input.Input.Right)
+	assert.Equal(t, uint64(4), held.Input.Sequence)
+
+	tick, err := entries[2].TickRecord()
+	require.NoError(t, err)
+	require.Len(t, tick.Inputs, 1, "only the room's inputs go to its replay")
+	assert.Equal(t, "player-a", tick.Inputs[0].PlayerID)
+	assert.Equal(t, goldenTime.Add(time.Second/60).UnixNano(), tick.Now)
+
+	reload, err := entries[3].Action()
+	require.NoError(t, err)
+	assert.Equal(t, game.ActionReload, reload.Kind)
+	assert.Equal(t, "player-a", reload.PlayerID)
+
+	joined, err := entries[4].Action()
+	require.NoError(t, err)
+	assert.Equal(t, game.ActionJoin, joined.Kind, "a player who shows up mid-match joins the replay")
+	assert.Equal(t, "player-b", joined.PlayerID)
+	assert.Equal(t, "tank", joined.Class)
+	assert.Equal(t, &game.Vector2{X: 50, Y: 60}, joined.Position)
+
+	assert.Equal(t, replay.KindState, entries[5].Kind)
+	assert.Equal(t, "match:ended", entries[6].Type)
+}
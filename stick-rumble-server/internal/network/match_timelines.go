@@ -0,0 +1,369 @@
+package network
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+// matchTimelineFileExtension is the extension timeline files are written with
+const matchTimelineFileExtension = ".timeline.json.gz"
+
+// matchTimelineTap names the recorder's broadcast tap on a room
+const matchTimelineTap = "match_timeline"
+
+// Caps on one match's timeline, so a multi-hour match can't grow it without
+// bound. Events past maxTimelineEvents are dropped and counted; score samples
+// past maxTimelineScores are thinned to every other one, which keeps the
+// graph's shape at a lower resolution.
+const (
+	maxTimelineEvents = 5000
+	maxTimelineScores = 1000
+)
+
+// Timeline event kinds
+const (
+	TimelineKill       = "kill"
+	TimelinePickup     = "pickup"
+	TimelineKillstreak = "killstreak"
+)
+
+// MatchTimeline is the post-game summary of one match: its major events and
+// every score change, small enough for a client to draw a graph from without
+// downloading the replay
+type MatchTimeline struct {
+	MatchID     string             `json:"matchId"`
+	RoomID      string             `json:"roomId"`
+	MapID       string             `json:"mapId"`
+	Reason      string             `json:"reason"`
+	StartedAt   time.Time          `json:"startedAt"`
+	DurationMs  int64              `json:"durationMs"`
+	FinalScores []game.PlayerScore `json:"finalScores"`
+	Events      []TimelineEvent    `json:"events"`
+	Scores      []ScoreSample      `json:"scores"`
+
+	// DroppedEvents counts events past maxTimelineEvents, omitted if none
+	DroppedEvents int `json:"droppedEvents,omitempty"`
+}
+
+// TimelineEvent is one kill, pickup or killstreak
+type TimelineEvent struct {
+	T        int64  `json:"t"` // Match time in ms
+	Kind     string `json:"kind"`
+	PlayerID string `json:"playerId"`
+	TargetID string `json:"targetId,omitempty"` // Victim of a kill
+	Item     string `json:"item,omitempty"`     // A pickup's weapon type, "shield" or "health"; a kill's weapon type
+	Streak   int    `json:"streak,omitempty"`
+
+	// TimeToKillMs is the match time from the killer's first hit on the
+	// victim's life to the kill, omitted if the killer never hit it
+	TimeToKillMs int64 `json:"timeToKillMs,omitempty"`
+}
+
+// ScoreSample is the kill count of every player who has scored, taken after
+// each kill
+type ScoreSample struct {
+	T     int64          `json:"t"` // Match time in ms
+	Kills map[string]int `json:"kills"`
+}
+
+// TimelineSizes is how much of a running match's timeline is held in memory
+type TimelineSizes struct {
+	Events        int `json:"events"`
+	Scores        int `json:"scores"`
+	DroppedEvents int `json:"droppedEvents"`
+}
+
+// matchTimeline collects the timeline of one room's running match
+type matchTimeline struct {
+	match         *game.Match
+	startedAt     time.Time
+	events        []TimelineEvent
+	scores        []ScoreSample
+	kills         map[string]int
+	droppedEvents int
+	mu            sync.Mutex
+}
+
+// matchTimelineRecorder keeps a timeline of every match while a timeline
+// directory is configured and writes it out when the match ends. Events reach
+// it through the room's broadcast tap, like the replay recorder's.
+type matchTimelineRecorder struct {
+	dir       string
+	now       func() time.Time
+	timelines map[string]*matchTimeline // room ID -> timeline of its current match
+	mu        sync.Mutex
+}
+
+func newMatchTimelineRecorder(dir string, now func() time.Time) *matchTimelineRecorder {
+	return &matchTimelineRecorder{
+		dir:       dir,
+		now:       now,
+		timelines: make(map[string]*matchTimeline),
+	}
+}
+
+// track starts the timeline of the room's match on its first state broadcast
+func (r *matchTimelineRecorder) track(room *game.Room) {
+	if !room.Match.IsStarted() || room.Match.IsEnded() {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if current, exists := r.timelines[room.ID]; exists && current.match == room.Match {
+		return
+	}
+
+	timeline := &matchTimeline{match: room.Match, startedAt: r.now(), kills: make(map[string]int)}
+	r.timelines[room.ID] = timeline
+	room.SetBroadcastTap(matchTimelineTap, timeline.record)
+}
+
+// record adds a room broadcast to the timeline if it is a major event. It
+// runs inside the room's broadcast, so it must not touch the room.
+func (t *matchTimeline) record(message []byte) {
+	var envelope struct {
+		Type string `json:"type"`
+		Data struct {
+			PlayerID    string `json:"playerId"`
+			KillerID    string `json:"killerId"`
+			VictimID    string `json:"victimId"`
+			KillerKills int    `json:"killerKills"`
+			WeaponType  string `json:"weaponType"`
+			Streak      int    `json:"streak"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(message, &envelope); err != nil {
+		return
+	}
+	data := envelope.Data
+
+	var event TimelineEvent
+	switch envelope.Type {
+	case "player:kill_credit":
+		event = TimelineEvent{Kind: TimelineKill, PlayerID: data.KillerID, TargetID: data.VictimID}
+	case "player:killstreak":
+		event = TimelineEvent{Kind: TimelineKillstreak, PlayerID: data.PlayerID, Streak: data.Streak}
+	case "weapon:pickup_confirmed":
+		event = TimelineEvent{Kind: TimelinePickup, PlayerID: data.PlayerID, Item: data.WeaponType}
+	case "shield:pickup_confirmed":
+		event = TimelineEvent{Kind: TimelinePickup, PlayerID: data.PlayerID, Item: "shield"}
+	case "health:pickup_confirmed":
+		event = TimelineEvent{Kind: TimelinePickup, PlayerID: data.PlayerID, Item: "health"}
+	default:
+		return
+	}
+	event.T = t.match.Elapsed().Milliseconds()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if event.Kind == TimelineKill {
+		t.kills[data.KillerID] = data.KillerKills
+		if len(t.scores) >= maxTimelineScores {
+			t.scores = thinScoreSamples(t.scores)
+		}
+		t.scores = append(t.scores, ScoreSample{T: event.T, Kills: maps.Clone(t.kills)})
+	}
+	if len(t.events) >= maxTimelineEvents {
+		if t.droppedEvents == 0 {
+			log.Printf("Match %s timeline reached %d events; dropping the rest", t.match.GetID(), maxTimelineEvents)
+		}
+		t.droppedEvents++
+		return
+	}
+	t.events = append(t.events, event)
+}
+
+// thinScoreSamples keeps every other sample, and always the latest
+func thinScoreSamples(scores []ScoreSample) []ScoreSample {
+	last := len(scores) - 1
+	thinned := scores[:0]
+	for i := 0; i <= last; i++ {
+		if i%2 == last%2 {
+			thinned = append(thinned, scores[i])
+		}
+	}
+	clear(scores[len(thinned):])
+	return thinned
+}
+
+// sizes returns how much of the room's running match timeline is held, false
+// if none is being kept
+func (r *matchTimelineRecorder) sizes(roomID string) (TimelineSizes, bool) {
+	r.mu.Lock()
+	timeline, exists := r.timelines[roomID]
+	r.mu.Unlock()
+	if !exists {
+		return TimelineSizes{}, false
+	}
+
+	timeline.mu.Lock()
+	defer timeline.mu.Unlock()
+	return TimelineSizes{Events: len(timeline.events), Scores: len(timeline.scores), DroppedEvents: timeline.droppedEvents}, true
+}
+
+// recordKillWeapon fills in the weapon and time to kill of the killer's
+// latest kill of victimID. The kill's player:kill_credit has already added
+// the event through the tap, but does not carry either.
+func (r *matchTimelineRecorder) recordKillWeapon(room *game.Room, killerID, victimID, weaponType string, timeToKill time.Duration) {
+	r.mu.Lock()
+	timeline, exists := r.timelines[room.ID]
+	r.mu.Unlock()
+	if !exists {
+		return
+	}
+
+	timeline.mu.Lock()
+	defer timeline.mu.Unlock()
+	for i := len(timeline.events) - 1; i >= 0; i-- {
+		event := &timeline.events[i]
+		if event.Kind == TimelineKill && event.PlayerID == killerID && event.TargetID == victimID {
+			event.Item = weaponType
+			event.TimeToKillMs = timeToKill.Milliseconds()
+			return
+		}
+	}
+}
+
+// finish writes out the timeline of a room whose match just ended with data
+func (r *matchTimelineRecorder) finish(room *game.Room, data matchEndedData) {
+	r.mu.Lock()
+	timeline, exists := r.timelines[room.ID]
+	delete(r.timelines, room.ID)
+	r.mu.Unlock()
+
+	if !exists {
+		return
+	}
+	room.SetBroadcastTap(matchTimelineTap, nil)
+
+	// Pickups are announced to every room; keep the ones of this match's players
+	inMatch := make(map[string]bool, len(data.FinalScores))
+	for _, score := range data.FinalScores {
+		inMatch[score.PlayerID] = true
+	}
+
+	timeline.mu.Lock()
+	result := MatchTimeline{
+		MatchID:     timeline.match.GetID(),
+		RoomID:      room.ID,
+		MapID:       data.MapID,
+		Reason:      data.Reason,
+		StartedAt:   timeline.startedAt,
+		DurationMs:  timeline.match.Elapsed().Milliseconds(),
+		FinalScores: data.FinalScores,
+		Events:      make([]TimelineEvent, 0, len(timeline.events)),
+		Scores:      append([]ScoreSample{}, timeline.scores...),
+
+		DroppedEvents: timeline.droppedEvents,
+	}
+	for _, event := range timeline.events {
+		if inMatch[event.PlayerID] {
+			result.Events = append(result.Events, event)
+		}
+	}
+	timeline.mu.Unlock()
+
+	if err := r.save(result); err != nil {
+		log.Printf("Error saving match timeline for room %s: %v", room.ID, err)
+	}
+}
+
+func (r *matchTimelineRecorder) save(timeline MatchTimeline) error {
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return fmt.Errorf("create timeline directory: %w", err)
+	}
+	file, err := os.Create(r.path(timeline.MatchID))
+	if err != nil {
+		return fmt.Errorf("create timeline file: %w", err)
+	}
+
+	gz := gzip.NewWriter(file)
+	if err := json.NewEncoder(gz).Encode(timeline); err != nil {
+		file.Close()
+		return fmt.Errorf("write timeline: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		file.Close()
+		return fmt.Errorf("write timeline: %w", err)
+	}
+	return file.Close()
+}
+
+func (r *matchTimelineRecorder) path(matchID string) string {
+	return filepath.Join(r.dir, matchID+matchTimelineFileExtension)
+}
+
+// closeRemovedRooms drops the timelines of rooms removed before their match
+// ended; with no result they are not worth keeping
+func (r *matchTimelineRecorder) closeRemovedRooms(rooms *game.RoomManager) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for roomID := range r.timelines {
+		if rooms.GetRoom(roomID) == nil {
+			delete(r.timelines, roomID)
+		}
+	}
+}
+
+// HandleMatchTimeline serves GET /matches/{matchID}/timeline for the shared
+// global handler
+func HandleMatchTimeline(w http.ResponseWriter, r *http.Request) {
+	getGlobalHandler().HandleMatchTimeline(w, r)
+}
+
+// HandleMatchTimeline serves a finished match's timeline as JSON, compressed
+// as stored when the client accepts gzip. 404 if the match has none.
+func (h *WebSocketHandler) HandleMatchTimeline(w http.ResponseWriter, r *http.Request) {
+	matchID := r.PathValue("matchID")
+	if h.timelines == nil || uuid.Validate(matchID) != nil {
+		http.Error(w, "timeline not found", http.StatusNotFound)
+		return
+	}
+
+	file, err := os.Open(h.timelines.path(matchID))
+	if errors.Is(err, os.ErrNotExist) {
+		http.Error(w, "timeline not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("Error opening match timeline %s: %v", matchID, err)
+		http.Error(w, "timeline unavailable", http.StatusInternalServerError)
+		return
+	}
+	defer file.Close()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Vary", "Accept-Encoding")
+	var body io.Reader = file
+	if strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+		w.Header().Set("Content-Encoding", "gzip")
+	} else {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			log.Printf("Error reading match timeline %s: %v", matchID, err)
+			http.Error(w, "timeline unavailable", http.StatusInternalServerError)
+			return
+		}
+		defer gz.Close()
+		body = gz
+	}
+	if _, err := io.Copy(w, body); err != nil {
+		log.Printf("Error sending match timeline %s: %v", matchID, err)
+	}
+}
@@ -0,0 +1,130 @@
+package network
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getMatchTimeline(h *WebSocketHandler, matchID, acceptEncoding string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/matches/"+matchID+"/timeline", nil)
+	req.SetPathValue("matchID", matchID)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	rec := httptest.NewRecorder()
+	h.HandleMatchTimeline(rec, req)
+	return rec
+}
+
+func TestMatchTimelineRecordsMajorEvents(t *testing.T) {
+	f := newGoldenFixture(t)
+	f.handler.timelines = newMatchTimelineRecorder(t.TempDir(), func() time.Time { return goldenTime })
+	f.room.Match.Start()
+	f.handler.broadcastPlayerStates([]game.PlayerStateSnapshot{{ID: "player-a"}, {ID: "player-b"}})
+
+	require.NoError(t, f.handler.publication.BroadcastPlayerKillCredit(f.room, playerKillCreditData{KillerID: "player-a", VictimID: "player-b", KillerKills: 1}))
+	f.handler.timelines.recordKillWeapon(f.room, "player-a", "player-b", "ak47", 1200*time.Millisecond)
+	require.NoError(t, f.handler.publication.BroadcastPlayerKillstreak(f.room, playerKillstreakData{PlayerID: "player-a", Streak: 3}))
+	f.handler.broadcastShieldPickup(game.ShieldPickedUpEvent{PlayerID: "player-b", CrateID: "shield-1", Shield: 50, RespawnTime: goldenTime})
+	f.handler.broadcastWeaponPickup("player-elsewhere", "crate-1", "AK47", goldenTime)
+	require.NoError(t, f.handler.publication.BroadcastPlayerKillCredit(f.room, playerKillCreditData{KillerID: "player-b", VictimID: "player-a", KillerKills: 1}))
+	f.handler.broadcastMatchEndedEvent(game.MatchEndedEvent{
+		RoomID: f.room.ID,
+		Reason: "time_limit",
+		FinalScores: []game.PlayerScore{
+			{PlayerID: "player-a", DisplayName: "Alpha", Kills: 1, Deaths: 1},
+			{PlayerID: "player-b", DisplayName: "Bravo", Kills: 1, Deaths: 1},
+		},
+	})
+	require.NoError(t, f.handler.publication.BroadcastPlayerKillCredit(f.room, playerKillCreditData{KillerID: "player-a", VictimID: "player-b", KillerKills: 2}))
+
+	rec := getMatchTimeline(f.handler, f.room.Match.GetID(), "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+	var timeline MatchTimeline
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &timeline))
+
+	assert.Equal(t, f.room.Match.GetID(), timeline.MatchID)
+	assert.Equal(t, "time_limit", timeline.Reason)
+	assert.Equal(t, goldenTime, timeline.StartedAt.UTC())
+	assert.Len(t, timeline.FinalScores, 2)
+	require.Len(t, timeline.Events, 4, "pickups outside the match are dropped and nothing is kept after the end")
+	assert.Equal(t, TimelineEvent{Kind: TimelineKill, PlayerID: "player-a", TargetID: "player-b", Item: "ak47", TimeToKillMs: 1200}, timeline.Events[0])
+	assert.Equal(t, TimelineEvent{Kind: TimelineKillstreak, PlayerID: "player-a", Streak: 3}, timeline.Events[1])
+	assert.Equal(t, TimelineEvent{Kind: TimelinePickup, PlayerID: "player-b", Item: "shield"}, timeline.Events[2])
+	require.Len(t, timeline.Scores, 2)
+	assert.Equal(t, map[string]int{"player-a": 1}, timeline.Scores[0].Kills)
+	assert.Equal(t, map[string]int{"player-a": 1, "player-b": 1}, timeline.Scores[1].Kills)
+
+	rec = getMatchTimeline(f.handler, f.room.Match.GetID(), "gzip, deflate")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"), "served compressed as stored")
+	gz, err := gzip.NewReader(rec.Body)
+	require.NoError(t, err)
+	var compressed MatchTimeline
+	require.NoError(t, json.NewDecoder(gz).Decode(&compressed))
+	assert.Equal(t, timeline.Events, compressed.Events)
+}
+
+func TestMatchTimelineNotFound(t *testing.T) {
+	f := newGoldenFixture(t)
+	matchID := "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+	assert.Equal(t, http.StatusNotFound, getMatchTimeline(f.handler, matchID, "").Code, "timelines are off")
+
+	f.handler.timelines = newMatchTimelineRecorder(t.TempDir(), time.Now)
+	assert.Equal(t, http.StatusNotFound, getMatchTimeline(f.handler, matchID, "").Code)
+	assert.Equal(t, http.StatusNotFound, getMatchTimeline(f.handler, "..%2F..%2Fetc", "").Code)
+}
+
+func TestMatchTimelineCapsEventsAndThinsScores(t *testing.T) {
+	f := newGoldenFixture(t)
+	f.handler.timelines = newMatchTimelineRecorder(t.TempDir(), func() time.Time { return goldenTime })
+	f.room.Match.Start()
+	f.handler.timelines.track(f.room)
+
+	kill := func(kills int) []byte {
+		message, err := json.Marshal(Message{Type: "player:kill_credit", Data: playerKillCreditData{KillerID: "player-a", VictimID: "player-b", KillerKills: kills}})
+		require.NoError(t, err)
+		return message
+	}
+	timeline := f.handler.timelines.timelines[f.room.ID]
+	require.NotNil(t, timeline)
+	for kills := 1; kills <= maxTimelineEvents+10; kills++ {
+		timeline.record(kill(kills))
+	}
+
+	sizes, ok := f.handler.timelines.sizes(f.room.ID)
+	require.True(t, ok)
+	assert.Equal(t, maxTimelineEvents, sizes.Events)
+	assert.Equal(t, 10, sizes.DroppedEvents)
+	assert.LessOrEqual(t, sizes.Scores, maxTimelineScores)
+	assert.Equal(t, maxTimelineEvents+10, timeline.scores[len(timeline.scores)-1].Kills["player-a"], "the latest score is kept")
+
+	f.handler.broadcastMatchEndedEvent(game.MatchEndedEvent{
+		RoomID:      f.room.ID,
+		Reason:      "time_limit",
+		FinalScores: []game.PlayerScore{{PlayerID: "player-a"}, {PlayerID: "player-b"}},
+	})
+	rec := getMatchTimeline(f.handler, f.room.Match.GetID(), "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var saved MatchTimeline
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &saved))
+	assert.Len(t, saved.Events, maxTimelineEvents)
+	assert.Equal(t, 10, saved.DroppedEvents)
+}
+
+func TestThinScoreSamplesKeepsLatest(t *testing.T) {
+	scores := []ScoreSample{{T: 1}, {T: 2}, {T: 3}, {T: 4}, {T: 5}}
+	assert.Equal(t, []ScoreSample{{T: 1}, {T: 3}, {T: 5}}, thinScoreSamples(scores))
+
+	scores = []ScoreSample{{T: 1}, {T: 2}, {T: 3}, {T: 4}}
+	assert.Equal(t, []ScoreSample{{T: 2}, {T: 4}}, thinScoreSamples(scores))
+}
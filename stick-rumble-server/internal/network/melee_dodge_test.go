@@ -103,6 +103,38 @@ func TestHandlePlayerMeleeAttack_NoVictims(t *testing.T) {
 	assert.Empty(t, victims, "Should have empty victim list")
 }
 
+func TestHandlePlayerMeleeAttack_InvulnerableVictimSendsHitRejected(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn1, conn2 := ts.connectTwoClients(t)
+	defer conn1.Close()
+	defer conn2.Close()
+
+	player1ID := consumeRoomJoinedAndGetPlayerID(t, conn1)
+	player2ID := consumeRoomJoinedAndGetPlayerID(t, conn2)
+	ts.handler.gameServer.SetWeaponState(player1ID, game.NewWeaponState(game.NewBat()))
+
+	world := ts.handler.gameServer.GetWorld()
+	attacker, exists := world.GetPlayer(player1ID)
+	require.True(t, exists)
+	victim, exists := world.GetPlayer(player2ID)
+	require.True(t, exists)
+	attacker.SetPosition(game.Vector2{X: 100, Y: 100})
+	victim.Respawn(game.Vector2{X: 150, Y: 100}) // Spawn protection
+
+	ts.handler.handlePlayerMeleeAttack(player1ID, map[string]interface{}{"aimAngle": 0.0})
+
+	msg, err := readMessageOfType(t, conn1, "hit:rejected", 2*time.Second)
+	require.NoError(t, err, "the attacker learns why its swing did not land")
+	assert.Equal(t, map[string]interface{}{
+		"attack":   "melee",
+		"code":     game.HitRejectedInvulnerable,
+		"victimId": player2ID,
+	}, msg.Data)
+	assert.Equal(t, game.PlayerMaxHealth, victim.Snapshot().Health)
+}
+
 func TestHandlePlayerMeleeAttack_InvalidData(t *testing.T) {
 	ts := newTestServer()
 	defer ts.Close()
@@ -140,7 +172,7 @@ func TestBroadcastMeleeHit(t *testing.T) {
 
 	// Broadcast melee hit
 	victimIDs := []string{player2ID}
-	ts.handler.broadcastMeleeHit(player1ID, victimIDs, true)
+	ts.handler.broadcastMeleeHit(player1ID, victimIDs, true, 1)
 
 	// Both players should receive melee:hit
 	msg, err := readMessageOfType(t, conn1, "melee:hit", 2*time.Second)
@@ -156,6 +188,43 @@ func TestBroadcastMeleeHit(t *testing.T) {
 	assert.Equal(t, player2ID, victims[0])
 }
 
+func TestBroadcastMeleeHitSkipsOptedOutPlayers(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn1, conn2 := ts.connectTwoClients(t)
+	defer conn1.Close()
+	defer conn2.Close()
+
+	player1ID := consumeRoomJoinedAndGetPlayerID(t, conn1)
+	player2ID := consumeRoomJoinedAndGetPlayerID(t, conn2)
+
+	sendMessage(t, conn2, Message{
+		Type:      "player:preferences",
+		Timestamp: time.Now().UnixMilli(),
+		Data:      map[string]interface{}{"optOut": []string{"melee:hit"}},
+	})
+	player2 := ts.handler.roomManager.GetRoomByPlayerID(player2ID).GetPlayer(player2ID)
+	require.Eventually(t, func() bool {
+		return !player2.Broadcasts.Allows("melee:hit")
+	}, 2*time.Second, 10*time.Millisecond)
+
+	ts.handler.broadcastMeleeHit(player1ID, []string{player2ID}, false, 1)
+	ts.handler.broadcastPlayerDamaged(player1ID, player2ID, 30, 70)
+
+	_, err := readMessageOfType(t, conn1, "melee:hit", 2*time.Second)
+	require.NoError(t, err, "Players who did not opt out still receive melee:hit")
+
+	for {
+		msg, err := readMessage(t, conn2, 2*time.Second)
+		require.NoError(t, err, "Should receive player:damaged")
+		require.NotEqual(t, "melee:hit", msg.Type, "Opted-out player should not receive melee:hit")
+		if msg.Type == "player:damaged" {
+			break
+		}
+	}
+}
+
 func TestBroadcastPlayerDamaged_MeleeVersion(t *testing.T) {
 	ts := newTestServer()
 	defer ts.Close()
@@ -3,10 +3,19 @@ package network
 import (
 	"log"
 	"math"
+	"time"
+	"unicode/utf8"
 
+	"github.com/mtomcal/stick-rumble-server/internal/buildinfo"
 	"github.com/mtomcal/stick-rumble-server/internal/game"
 )
 
+func (h *WebSocketHandler) sendServerHello(player *game.Player, sessionToken string, resumed bool) {
+	if err := h.publication.SendServerHello(player, buildinfo.Get(), sessionToken, resumed); err != nil {
+		log.Printf("Error building server:hello message: %v", err)
+	}
+}
+
 func (h *WebSocketHandler) sendNoHelloError(player *game.Player, offendingType string) {
 	if err := h.publication.SendNoHelloError(player, offendingType); err != nil {
 		log.Printf("Error building error:no_hello message: %v", err)
@@ -25,8 +34,218 @@ func (h *WebSocketHandler) sendRoomFullError(player *game.Player, code string) {
 	}
 }
 
+func (h *WebSocketHandler) sendRoomBlockedError(player *game.Player, code string, retryAfter time.Duration) {
+	if err := h.publication.SendRoomBlockedError(player, code, retryAfter); err != nil {
+		log.Printf("Error building error:room_blocked message: %v", err)
+	}
+}
+
+func (h *WebSocketHandler) sendPayloadRejected(player *game.Player, offendingType string, rejection payloadRejection) {
+	if err := h.publication.SendPayloadRejected(player, offendingType, rejection); err != nil {
+		log.Printf("Error building error:payload_rejected message: %v", err)
+	}
+}
+
+// writeSessionReplaced tells a connection its account connected again
+// elsewhere. It writes straight to the connection because the player's send
+// channel is about to move to the new one.
+func (h *WebSocketHandler) writeSessionReplaced(codec Codec, writeFrame func([]byte) error) {
+	msgBytes, err := h.buildOutgoingMessage("session:replaced", sessionReplacedData{})
+	if err != nil {
+		log.Printf("Error building session:replaced message: %v", err)
+		return
+	}
+	frame, err := codec.Encode(msgBytes)
+	if err != nil {
+		log.Printf("Error encoding session:replaced message: %v", err)
+		return
+	}
+	if err := writeFrame(frame); err != nil {
+		log.Printf("Error writing session:replaced message: %v", err)
+	}
+}
+
+func (h *WebSocketHandler) sendCapacityNotice(player *game.Player, queuePosition int, redirectURL string) {
+	if err := h.publication.SendCapacityNotice(player, queuePosition, redirectURL); err != nil {
+		log.Printf("Error building session:capacity message: %v", err)
+	}
+}
+
+// handleRoomRosterRequest replies with the requester's current room roster
+func (h *WebSocketHandler) handleRoomRosterRequest(player *game.Player) {
+	room := h.roomManager.GetRoomByPlayerID(player.ID)
+	if err := h.publication.SendRoomRoster(player, room); err != nil {
+		log.Printf("Error sending room:roster to %s: %v", player.ID, err)
+	}
+}
+
+// handlePlayerReady records a ready-check vote for the player's room
+func (h *WebSocketHandler) handlePlayerReady(playerID string, data any) {
+	if err := h.validator.Validate("player-ready-data", data); err != nil {
+		log.Printf("Schema validation failed for player:ready from %s: %v", playerID, err)
+		return
+	}
+
+	ready := data.(map[string]interface{})["ready"].(bool)
+	if !h.roomManager.SetPlayerReady(playerID, ready) {
+		log.Printf("Ignoring player:ready from %s: no ready check in progress", playerID)
+	}
+}
+
+// handlePartyStayTogether records a vote to re-queue with the same group after
+// match:ended and moves the group into its next room once everyone is in
+func (h *WebSocketHandler) handlePartyStayTogether(player *game.Player, data any) {
+	if err := h.validator.Validate("party-stay-together-data", data); err != nil {
+		log.Printf("Schema validation failed for party:stay_together from %s: %v", player.ID, err)
+		return
+	}
+
+	stay := data.(map[string]interface{})["stay"].(bool)
+	result, ok := h.sessionFlow.StayTogether(player.ID, stay)
+	if !ok {
+		log.Printf("Ignoring party:stay_together from %s: match has not ended", player.ID)
+		return
+	}
+	h.applyPartyResult(result)
+}
+
+// handleMatchRematchVote records a rematch vote after match:ended and restarts
+// or dissolves the room once the vote is decided
+func (h *WebSocketHandler) handleMatchRematchVote(player *game.Player, data any) {
+	if err := h.validator.Validate("match-rematch-vote-data", data); err != nil {
+		log.Printf("Schema validation failed for match:rematch_vote from %s: %v", player.ID, err)
+		return
+	}
+
+	vote := data.(map[string]interface{})["vote"].(bool)
+	result, ok := h.sessionFlow.VoteRematch(player.ID, vote)
+	if !ok {
+		log.Printf("Ignoring match:rematch_vote from %s: no rematch vote in progress", player.ID)
+		return
+	}
+	h.applyRematchResult(result)
+}
+
+// handlePlayerPreferences replaces the broadcast types the player opted out of
+func (h *WebSocketHandler) handlePlayerPreferences(player *game.Player, data any) {
+	if err := h.validator.Validate("player-preferences-data", data); err != nil {
+		log.Printf("Schema validation failed for player:preferences from %s: %v", player.ID, err)
+		return
+	}
+
+	rawOptOut := data.(map[string]interface{})["optOut"].([]interface{})
+	optOut := make([]string, 0, len(rawOptOut))
+	for _, messageType := range rawOptOut {
+		optOut = append(optOut, messageType.(string))
+	}
+	if err := player.Broadcasts.SetOptOut(optOut); err != nil {
+		log.Printf("Ignoring player:preferences from %s: %v", player.ID, err)
+	}
+}
+
+// handlePlayerLoadout records the player's class choice. It applies when the
+// player next spawns: at the start of its next match or on its next respawn.
+func (h *WebSocketHandler) handlePlayerLoadout(player *game.Player, data any) {
+	if err := h.validator.Validate("player-loadout-data", data); err != nil {
+		log.Printf("Schema validation failed for player:loadout from %s: %v", player.ID, err)
+		return
+	}
+
+	className := data.(map[string]interface{})["class"].(string)
+	if err := player.Loadout.SetClass(className); err != nil {
+		log.Printf("Ignoring player:loadout from %s: %v", player.ID, err)
+		return
+	}
+	if _, inGame := h.gameServer.GetPlayerState(player.ID); inGame {
+		if err := h.gameServer.SetPlayerClass(player.ID, className); err != nil {
+			log.Printf("Error setting class for %s: %v", player.ID, err)
+		}
+	}
+}
+
+// RenameFailedCooldown is the rename:failed reason while the player's last
+// name change is within the name change cooldown
+const RenameFailedCooldown = "cooldown"
+
+// handlePlayerRename changes the player's display name, at most once per name
+// change cooldown, and tells its room
+func (h *WebSocketHandler) handlePlayerRename(player *game.Player, data any) {
+	if err := h.validator.Validate("player-rename-data", data); err != nil {
+		log.Printf("Schema validation failed for player:rename from %s: %v", player.ID, err)
+		return
+	}
+
+	name := game.SanitizeDisplayName(data.(map[string]interface{})["displayName"])
+	if wait := h.names.change(player.ID, name); wait > 0 {
+		if err := h.publication.SendRenameFailed(player.ID, renameFailedData{
+			Reason:       RenameFailedCooldown,
+			RetryAfterMs: wait.Milliseconds(),
+		}); err != nil {
+			log.Printf("Error building rename:failed message: %v", err)
+		}
+		return
+	}
+
+	room, ok := h.roomManager.SetPlayerDisplayName(player.ID, name)
+	if !ok {
+		return
+	}
+	h.gameServer.SetPlayerDisplayName(player.ID, name)
+	if err := h.publication.PublishPlayerRenamed(room, playerRenamedData{PlayerID: player.ID, DisplayName: name}); err != nil {
+		log.Printf("Error building player:renamed message: %v", err)
+	}
+}
+
+// handleFeedbackSubmit stores a player's playtest feedback with its current
+// match and the server build. Submissions within the feedback cooldown of the
+// player's last one are dropped.
+func (h *WebSocketHandler) handleFeedbackSubmit(player *game.Player, data any) {
+	if err := h.validator.Validate("feedback-submit-data", data); err != nil {
+		log.Printf("Schema validation failed for feedback:submit from %s: %v", player.ID, err)
+		return
+	}
+	if !h.feedback.allow(player.ID) {
+		log.Printf("Dropped feedback:submit from %s: within feedback cooldown", player.ID)
+		return
+	}
+
+	dataMap := data.(map[string]interface{})
+	text, _ := dataMap["text"].(string)
+	entry := FeedbackEntry{
+		PlayerID:    player.ID,
+		DisplayName: player.DisplayName,
+		Rating:      int(dataMap["rating"].(float64)),
+		Text:        sanitizeFeedbackText(text),
+		Build:       buildinfo.Get(),
+		SubmittedAt: h.feedback.now(),
+	}
+	if room := h.roomManager.GetRoomByPlayerID(player.ID); room != nil {
+		entry.RoomID = room.ID
+		entry.MatchID = room.Match.GetID()
+	}
+	if err := h.feedback.store.SaveFeedback(entry); err != nil {
+		log.Printf("Error saving feedback from %s: %v", player.ID, err)
+	}
+}
+
+// handleStateAck records the last state:snapshot or state:delta the client
+// applied, so later deltas are computed against it
+func (h *WebSocketHandler) handleStateAck(playerID string, data any) {
+	if err := h.validator.Validate("state-ack-data", data); err != nil {
+		log.Printf("Schema validation failed for state:ack from %s: %v", playerID, err)
+		return
+	}
+
+	seq := uint64(data.(map[string]interface{})["seq"].(float64))
+	h.deltaTracker.Acknowledge(playerID, seq)
+}
+
+// inputLog samples input:state handling errors; clients send input up to 60
+// times a second
+var inputLog = game.HotPathLogger("input")
+
 // handleInputState processes player input state updates
-func (h *WebSocketHandler) handleInputState(playerID string, data any) {
+func (h *WebSocketHandler) handleInputState(playerID string, data any, clientTime int64) {
 	// Check if player's match has ended - reject input if so
 	room := h.roomManager.GetRoomByPlayerID(playerID)
 	if room != nil && room.Match.IsEnded() {
@@ -36,7 +255,7 @@ func (h *WebSocketHandler) handleInputState(playerID string, data any) {
 
 	// Validate data against JSON schema
 	if err := h.validator.Validate("input-state-data", data); err != nil {
-		log.Printf("Schema validation failed for input:state from %s: %v", playerID, err)
+		inputLog.Printf("Schema validation failed for input:state from %s: %v", playerID, err)
 		return
 	}
 
@@ -52,16 +271,22 @@ func (h *WebSocketHandler) handleInputState(playerID string, data any) {
 		IsSprinting: dataMap["isSprinting"].(bool),
 	}
 
-	// Extract sequence number for client-side prediction reconciliation
-	var sequence uint64
-	if seqFloat, ok := dataMap["sequence"].(float64); ok {
-		sequence = uint64(seqFloat)
+	// A player waiting for a match moves in its lobby sandbox instead
+	if sandbox := h.sandboxes.get(playerID); sandbox != nil {
+		sandbox.SetInput(input)
+		return
 	}
 
-	// Update game server with input and sequence
-	success := h.gameServer.UpdatePlayerInputWithSequence(playerID, input, sequence)
-	if !success {
-		log.Printf("Failed to update input for player %s", playerID)
+	// Clients doing client-side prediction number their inputs; others omit the
+	// sequence and keep their last acknowledged one. The game loop applies
+	// queued inputs one per tick.
+	queued := game.QueuedInput{Input: input, ClientTime: clientTime}
+	if seqFloat, ok := dataMap["sequence"].(float64); ok {
+		queued.Sequence = uint64(seqFloat)
+		queued.HasSequence = true
+	}
+	if !h.gameServer.QueuePlayerInput(playerID, queued) {
+		inputLog.Printf("Failed to update input for player %s", playerID)
 	}
 }
 
@@ -77,13 +302,21 @@ func (h *WebSocketHandler) handlePlayerShoot(playerID string, data any) {
 	dataMap := data.(map[string]interface{})
 	aimAngle := dataMap["aimAngle"].(float64)
 	clientTimestamp := int64(dataMap["clientTimestamp"].(float64)) // Convert from float64 to int64
+	effectID, _ := dataMap["effectId"].(string)
+
+	// A player waiting for a match shoots in its lobby sandbox instead
+	if sandbox := h.sandboxes.get(playerID); sandbox != nil {
+		sandbox.Shoot(aimAngle)
+		return
+	}
 
 	// Attempt to shoot with client timestamp for lag compensation
-	result := h.gameServer.PlayerShoot(playerID, aimAngle, clientTimestamp)
+	result := h.gameServer.PlayerShootWithEffect(playerID, aimAngle, clientTimestamp, effectID)
 
 	if result.Success {
 		// Broadcast projectile spawn to all players
-		h.broadcastProjectileSpawn(result.Projectile)
+		h.broadcastProjectileSpawn(result.Projectile, result.Pellets)
+		h.recordShots(playerID, result)
 
 		// Send weapon state update to the shooter
 		h.sendWeaponState(playerID)
@@ -91,6 +324,32 @@ func (h *WebSocketHandler) handlePlayerShoot(playerID string, data any) {
 		// Send failure reason to player (for empty click sound, etc.)
 		h.sendShootFailed(playerID, result.Reason)
 	}
+	h.sendHitRejected(playerID, "shoot", result.Rejection)
+}
+
+// recordShots counts a successful shot's pellets, or its one projectile or
+// hitscan ray, toward the shooter's match accuracy
+func (h *WebSocketHandler) recordShots(playerID string, result game.ShootResult) {
+	room := h.roomManager.GetRoomByPlayerID(playerID)
+	if room == nil || room.Match == nil {
+		return
+	}
+	room.Match.RecordShots(playerID, max(len(result.Pellets), 1))
+}
+
+// sendHitRejected tells an attacker why its attack hit nobody, so the client
+// can take back hit feedback it played early
+func (h *WebSocketHandler) sendHitRejected(playerID, attack string, rejection *game.HitRejection) {
+	if rejection == nil {
+		return
+	}
+	if err := h.publication.SendHitRejected(playerID, hitRejectedData{
+		Attack:   attack,
+		Code:     rejection.Code,
+		VictimID: rejection.VictimID,
+	}); err != nil {
+		log.Printf("Error building hit:rejected message: %v", err)
+	}
 }
 
 // handlePlayerReload processes player reload messages
@@ -122,16 +381,26 @@ func (h *WebSocketHandler) onHit(hit game.HitEvent) {
 func (h *WebSocketHandler) publishProjectileHitOutcome(outcome game.ProjectileHitOutcome) {
 	room := h.roomManager.GetRoomByPlayerID(outcome.Hit.VictimID)
 	if room != nil {
-		if err := h.publication.BroadcastPlayerDamaged(room, playerDamagedData{
+		damaged := playerDamagedData{
 			VictimID:     outcome.Hit.VictimID,
 			AttackerID:   outcome.Hit.AttackerID,
 			Damage:       outcome.Damage,
 			NewHealth:    outcome.NewHealth,
+			NewShield:    outcome.NewShield,
 			ProjectileID: outcome.Hit.ProjectileID,
-		}); err != nil {
+		}
+		if outcome.Impulse != (game.Vector2{}) {
+			impulse := outcome.Impulse
+			damaged.Impulse = &impulse
+		}
+		if err := h.publication.BroadcastPlayerDamaged(room, damaged); err != nil {
 			log.Printf("Error building player:damaged message: %v", err)
 			return
 		}
+		if room.Match != nil {
+			room.Match.RecordDamage(outcome.Hit.AttackerID, outcome.Hit.VictimID, outcome.Damage)
+			room.Match.RecordShotHit(outcome.Hit.AttackerID, outcome.Hit.VictimID)
+		}
 	}
 
 	if err := h.publication.SendHitConfirmed(outcome.Hit.AttackerID, hitConfirmedData{
@@ -163,9 +432,10 @@ func (h *WebSocketHandler) publishProjectileHitOutcome(outcome game.ProjectileHi
 				log.Printf("Error building player:kill_credit message: %v", err)
 				return
 			}
+			h.broadcastKillstreak(room, outcome.Hit.AttackerID, outcome.KillStreak)
 
-			// Track kill in match and check win conditions
-			room.Match.AddKill(outcome.Hit.AttackerID)
+			// Track kill and assists in match and check win conditions
+			h.recordKill(room, outcome.Hit.AttackerID, outcome.Hit.VictimID)
 
 			// Check if kill target reached
 			if room.Match.CheckKillTarget() {
@@ -176,6 +446,7 @@ func (h *WebSocketHandler) publishProjectileHitOutcome(outcome game.ProjectileHi
 					Reason:      room.Match.EndReason,
 					Winners:     room.Match.GetWinnerSummaries(h.gameServer.GetWorld()),
 					FinalScores: room.Match.GetFinalScores(h.gameServer.GetWorld()),
+					Scoreboard:  room.Match.MatchStats(h.gameServer.GetWorld()),
 				})
 			}
 		}
@@ -186,17 +457,21 @@ func (h *WebSocketHandler) publishProjectileHitOutcome(outcome game.ProjectileHi
 func (h *WebSocketHandler) onRespawn(playerID string, position game.Vector2) {
 	room := h.roomManager.GetRoomByPlayerID(playerID)
 	if room != nil {
+		health := game.PlayerMaxHealth
+		if state, exists := h.gameServer.GetPlayerState(playerID); exists {
+			health = state.MaxHealth // Respawns are at the class's full health
+		}
 		if err := h.publication.BroadcastPlayerRespawn(room, playerRespawnData{
 			PlayerID: playerID,
 			Position: position,
-			Health:   game.PlayerMaxHealth,
+			Health:   health,
 		}); err != nil {
 			log.Printf("Error building player:respawn message: %v", err)
 			return
 		}
 	}
 
-	// The respawning player's weapon state is reset server-side to the default pistol.
+	// The respawning player's weapon state is reset server-side to the class's starting weapon.
 	// Resend the authoritative weapon state immediately so local firing rules and visuals
 	// do not lag behind the respawn broadcast.
 	h.sendWeaponState(playerID)
@@ -247,32 +522,24 @@ func (h *WebSocketHandler) handleWeaponPickup(playerID string, data any) {
 		return
 	}
 
-	// All validation passed - perform pickup
-	// 1. Mark crate as picked up
-	success := h.gameServer.GetWeaponCrateManager().PickupCrate(crateID)
-	if !success {
-		log.Printf("Failed to pick up crate %s (race condition)", crateID)
+	// Room gameplay hooks may forbid the pickup (custom modes)
+	if !h.gameServer.AllowWeaponPickup(playerID, crate) {
+		log.Printf("Player %s pickup of crate %s blocked by room rules", playerID, crateID)
 		return
 	}
 
-	// 2. Create new weapon for player
-	newWeapon, err := game.CreateWeaponByType(crate.WeaponType)
-	if err != nil {
-		log.Printf("Failed to create weapon %s: %v", crate.WeaponType, err)
-		// Return crate to available state
-		crate.IsAvailable = true
+	// All validation passed - take the crate's weapon in place of the player's
+	if !h.gameServer.PickUpWeapon(playerID, crateID) {
+		log.Printf("Failed to pick up crate %s (race condition)", crateID)
 		return
 	}
 
-	// 3. Replace player's weapon
-	h.gameServer.SetWeaponState(playerID, game.NewWeaponState(newWeapon))
-
-	// 4. Call pickup callback to broadcast to clients
+	// Call pickup callback to broadcast to clients
 	if h.gameServer.GetWeaponCrateManager().GetCrate(crateID) != nil {
 		updatedCrate := h.gameServer.GetWeaponCrateManager().GetCrate(crateID)
 		h.broadcastWeaponPickup(playerID, crateID, crate.WeaponType, updatedCrate.RespawnTime)
 
-		// 5. Send updated weapon state to picker
+		// Send updated weapon state to picker
 		h.sendWeaponState(playerID)
 	}
 
@@ -289,23 +556,66 @@ func (h *WebSocketHandler) HandleGameLoopEvent(event game.GameLoopEvent) {
 	switch typed := event.(type) {
 	case game.ProjectileHitResolvedEvent:
 		h.publishProjectileHitOutcome(typed.Outcome)
+	case game.ProjectileExplodedEvent:
+		h.broadcastProjectileExplode(typed)
 	case game.ReloadCompletedEvent:
 		h.onReloadComplete(typed.PlayerID)
 	case game.PlayerRespawnedEvent:
 		h.onRespawn(typed.PlayerID, typed.Position)
 	case game.RollEndedEvent:
 		h.broadcastRollEnd(typed.PlayerID, typed.Reason)
+		h.sendWeaponState(typed.PlayerID) // The roll's cooldown starts when it ends
 	case game.WeaponCrateRespawnedEvent:
 		h.broadcastWeaponRespawn(&game.WeaponCrate{
 			ID:         typed.CrateID,
 			WeaponType: typed.WeaponType,
 			Position:   typed.Position,
 		})
+	case game.ShieldPickedUpEvent:
+		h.broadcastShieldPickup(typed)
+	case game.ShieldCrateRespawnedEvent:
+		h.broadcastShieldRespawn(typed)
+	case game.HealthPackPickedUpEvent:
+		h.broadcastHealthPickup(typed)
+	case game.HealthPackRespawnedEvent:
+		h.broadcastHealthRespawn(typed)
 	case game.MatchTimerUpdatedEvent:
 		h.broadcastMatchTimerEvent(typed)
 	case game.MatchEndedEvent:
 		h.broadcastMatchEndedEvent(typed)
+	case game.PlayerLeveledUpEvent:
+		h.sendLevelUp(typed)
+	case game.MovementViolationEvent:
+		if typed.Kick {
+			h.kickPlayer(typed.PlayerID, kickedReason("movement violations"))
+		}
+	}
+}
+
+// kickPlayer closes a player's connection with reason's close code and text and
+// revokes its session token, so the player is removed rather than parked for a
+// resuming client
+func (h *WebSocketHandler) kickPlayer(playerID string, reason disconnectReason) bool {
+	if !h.resumer.revoke(playerID, reason) {
+		return false
+	}
+	log.Printf("Kicked player %s (%s): %s", playerID, reason.code.Reason(), reason.text)
+	return true
+}
+
+// maxCloseReasonBytes is the most text a WebSocket close frame can carry
+const maxCloseReasonBytes = 123
+
+// closeReasonText cuts reason to fit a close frame without splitting a character
+func closeReasonText(reason string) string {
+	if len(reason) <= maxCloseReasonBytes {
+		return reason
+	}
+	cut := maxCloseReasonBytes
+	for cut > 0 && !utf8.RuneStart(reason[cut]) {
+		cut--
 	}
+	return reason[:cut]
 }
 
 // handlePlayerMeleeAttack processes player melee attack messages
@@ -323,6 +633,7 @@ func (h *WebSocketHandler) handlePlayerMeleeAttack(playerID string, data any) {
 	// Attempt melee attack
 	result := h.gameServer.PlayerMeleeAttack(playerID, aimAngle)
 
+	h.sendHitRejected(playerID, "melee", result.Rejection)
 	if !result.Success {
 		log.Printf("Melee attack failed for player %s: %s", playerID, result.Reason)
 		return
@@ -335,17 +646,12 @@ func (h *WebSocketHandler) handlePlayerMeleeAttack(playerID string, data any) {
 	}
 
 	// Broadcast melee:hit to all players (even if no victims - for swing animation)
-	h.broadcastMeleeHit(playerID, victimIDs, result.KnockbackApplied)
+	h.broadcastMeleeHit(playerID, victimIDs, result.KnockbackApplied, result.Combo)
 
 	// Process damage events for each victim
-	for _, victim := range result.HitPlayers {
-		// Get weapon to determine damage
-		ws := h.gameServer.GetWeaponState(playerID)
-		if ws == nil {
-			continue
-		}
-
-		damage := ws.Weapon.Damage
+	for i, victim := range result.HitPlayers {
+		// Damage as dealt, after any room gameplay hooks
+		damage := result.Damages[i]
 
 		// Broadcast player:damaged
 		h.broadcastPlayerDamaged(playerID, victim.ID, damage, victim.Health)
@@ -411,10 +717,180 @@ func (h *WebSocketHandler) handlePlayerDodgeRoll(playerID string) {
 	}
 
 	// Start the dodge roll
-	playerState.StartDodgeRoll(direction)
+	h.gameServer.StartDodgeRoll(playerID, direction)
 
 	// Broadcast roll:start to all players in the room
 	h.broadcastRollStart(playerID, direction, playerState.GetRollState().RollStartTime)
 
 	log.Printf("Player %s started dodge roll", playerID)
 }
+
+// handlePlayerRespawnRequest records a dead player's request to respawn; the
+// game loop respawns them once the respawn delay has passed
+func (h *WebSocketHandler) handlePlayerRespawnRequest(playerID string) {
+	if !h.gameServer.RequestRespawn(playerID) {
+		log.Printf("Player %s cannot request a respawn: not dead", playerID)
+	}
+}
+
+// handlePlayerPing routes a tactical ping to the player's team. Pings are
+// not stored; a ping that fails validation or the rate limit is dropped.
+func (h *WebSocketHandler) handlePlayerPing(player *game.Player, data any) {
+	if err := h.validator.Validate("player-ping-data", data); err != nil {
+		log.Printf("Schema validation failed for player:ping from %s: %v", player.ID, err)
+		return
+	}
+
+	dataMap := data.(map[string]interface{})
+	pingType := dataMap["type"].(string)
+	positionMap := dataMap["position"].(map[string]interface{})
+	position := game.Vector2{X: positionMap["x"].(float64), Y: positionMap["y"].(float64)}
+
+	room := h.roomManager.GetRoomByPlayerID(player.ID)
+	if room == nil {
+		return
+	}
+	if result := h.gameServer.Ping(player.ID, pingType, position); !result.Success {
+		log.Printf("Player %s cannot ping: %s", player.ID, result.Reason)
+		return
+	}
+	if err := h.publication.BroadcastTeamPing(room, player.ID, pingType, position); err != nil {
+		log.Printf("Error building team:ping message: %v", err)
+	}
+}
+
+// handlePlayerEmote shows a player's emote or quick-chat line to their room.
+// An emote that fails validation or the rate limit is dropped.
+func (h *WebSocketHandler) handlePlayerEmote(player *game.Player, data any) {
+	if err := h.validator.Validate("player-emote-data", data); err != nil {
+		log.Printf("Schema validation failed for player:emote from %s: %v", player.ID, err)
+		return
+	}
+
+	emote := data.(map[string]interface{})["emote"].(string)
+
+	room := h.roomManager.GetRoomByPlayerID(player.ID)
+	if room == nil {
+		return
+	}
+	if result := h.gameServer.Emote(player.ID, emote); !result.Success {
+		log.Printf("Player %s cannot emote: %s", player.ID, result.Reason)
+		return
+	}
+	if err := h.publication.BroadcastPlayerEmoted(room, playerEmotedData{PlayerID: player.ID, Emote: emote}); err != nil {
+		log.Printf("Error building player:emoted message: %v", err)
+	}
+}
+
+// handleChatMessage broadcasts a player's chat message to their room, or to
+// their team on the team channel, cleaned by the profanity filter. Players who
+// muted the sender do not get it. A message that fails validation or the
+// flood limit, or is for a team the player is not on, is dropped.
+func (h *WebSocketHandler) handleChatMessage(player *game.Player, data any) {
+	if err := h.validator.Validate("chat-message-data", data); err != nil {
+		log.Printf("Schema validation failed for chat:message from %s: %v", player.ID, err)
+		return
+	}
+
+	room := h.roomManager.GetRoomByPlayerID(player.ID)
+	if room == nil {
+		return
+	}
+	dataMap := data.(map[string]interface{})
+	requested, _ := dataMap["channel"].(string)
+	channel, ok := chatChannel(room, player, requested)
+	if !ok {
+		log.Printf("Dropped chat:message from %s: team chat without a team", player.ID)
+		return
+	}
+	text, ok := h.chat.prepare(player.ID, dataMap["text"].(string))
+	if !ok {
+		log.Printf("Dropped chat:message from %s: empty or over the flood limit", player.ID)
+		return
+	}
+	if err := h.publication.BroadcastChatPosted(room, chatPostedData{
+		PlayerID:    player.ID,
+		DisplayName: player.DisplayName,
+		Text:        text,
+		Channel:     channel,
+	}); err != nil {
+		log.Printf("Error building chat:posted message: %v", err)
+	}
+}
+
+// handleChatMute mutes or unmutes another player in the same room for this
+// player only. Nobody is told.
+func (h *WebSocketHandler) handleChatMute(player *game.Player, data any) {
+	if err := h.validator.Validate("chat-mute-data", data); err != nil {
+		log.Printf("Schema validation failed for chat:mute from %s: %v", player.ID, err)
+		return
+	}
+
+	dataMap := data.(map[string]interface{})
+	targetID := dataMap["playerId"].(string)
+	room := h.roomManager.GetRoomByPlayerID(player.ID)
+	if targetID == player.ID || room == nil || room.GetPlayer(targetID) == nil {
+		log.Printf("Player %s cannot mute %s: not another player in their room", player.ID, targetID)
+		return
+	}
+	player.ChatMutes.Set(targetID, dataMap["muted"].(bool))
+}
+
+// handleRoomVoteKick counts a player's vote to kick another player from their
+// room and tells the room how the vote stands. Once enough of the room agrees,
+// the target is kicked and kept out of the room for a while. Bots neither vote
+// nor can be voted out.
+func (h *WebSocketHandler) handleRoomVoteKick(player *game.Player, data any) {
+	if err := h.validator.Validate("room-votekick-data", data); err != nil {
+		log.Printf("Schema validation failed for room:votekick from %s: %v", player.ID, err)
+		return
+	}
+
+	targetID := data.(map[string]interface{})["playerId"].(string)
+	room := h.roomManager.GetRoomByPlayerID(player.ID)
+	if room == nil {
+		return
+	}
+	now := time.Now()
+	result := room.VoteKick(player.ID, targetID, now, h.voteKick, h.bots.IsBot)
+	if result.Reason != "" {
+		log.Printf("Player %s cannot vote to kick %s: %s", player.ID, targetID, result.Reason)
+		return
+	}
+	if err := h.publication.BroadcastVoteKickProgress(room, voteKickProgressData{
+		TargetID:    targetID,
+		VoterID:     player.ID,
+		Votes:       result.Votes,
+		Needed:      result.Needed,
+		ExpiresInMs: result.ExpiresAt.Sub(now).Milliseconds(),
+		Passed:      result.Passed,
+	}); err != nil {
+		log.Printf("Error building room:votekick_progress message: %v", err)
+	}
+	if result.Passed {
+		h.kickPlayer(targetID, kickedReason("vote-kicked by the room"))
+	}
+}
+
+// handlePlayerUltimate processes player ultimate activation requests
+func (h *WebSocketHandler) handlePlayerUltimate(playerID string) {
+	result := h.gameServer.ActivateUltimate(playerID)
+	if !result.Success {
+		log.Printf("Player %s cannot activate ultimate: %s", playerID, result.Reason)
+		return
+	}
+
+	room := h.roomManager.GetRoomByPlayerID(playerID)
+	if room == nil {
+		return
+	}
+	if err := h.publication.BroadcastUltimateActivated(room, ultimateActivatedData{
+		PlayerID:         playerID,
+		Ultimate:         result.Effect.Name,
+		DurationMs:       result.Effect.Duration.Milliseconds(),
+		DamageMultiplier: result.Effect.DamageMultiplier,
+		NewHealth:        result.NewHealth,
+	}); err != nil {
+		log.Printf("Error building ultimate:activated message: %v", err)
+	}
+}
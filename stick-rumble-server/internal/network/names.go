@@ -0,0 +1,94 @@
+package network
+
+import (
+	"sync"
+	"time"
+)
+
+// NameChange is one display name an account has used
+type NameChange struct {
+	Name      string    `json:"name"`
+	ChangedAt time.Time `json:"changedAt"`
+}
+
+// nameRegistry keeps each account's display name history and enforces a
+// cooldown between changes, so a player cannot shed a name others have
+// reported by renaming over and over. The first change after an account's
+// initial name is free; joining with a different name counts as a change. Histories are kept in memory only; without auth every connection
+// is a new account, so those are dropped when the player is removed for good.
+type nameRegistry struct {
+	cooldown time.Duration
+	history  map[string][]NameChange // player ID -> names, oldest first
+	now      func() time.Time
+	mu       sync.Mutex
+}
+
+func newNameRegistry(cooldown time.Duration, now func() time.Time) *nameRegistry {
+	return &nameRegistry{
+		cooldown: cooldown,
+		history:  make(map[string][]NameChange),
+		now:      now,
+	}
+}
+
+// joinName returns the name a player joins with: the requested name, unless
+// the account changed names within the cooldown, in which case it keeps its
+// current one
+func (n *nameRegistry) joinName(playerID, requested string) string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.retryAfterLocked(playerID, requested) > 0 {
+		return n.currentLocked(playerID)
+	}
+	return requested
+}
+
+// change records name as the player's display name. It returns how long the
+// player must wait instead if the last change was within the cooldown.
+// Keeping the current name is not a change.
+func (n *nameRegistry) change(playerID, name string) time.Duration {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if wait := n.retryAfterLocked(playerID, name); wait > 0 {
+		return wait
+	}
+	if n.currentLocked(playerID) != name {
+		n.history[playerID] = append(n.history[playerID], NameChange{Name: name, ChangedAt: n.now()})
+	}
+	return 0
+}
+
+// retryAfterLocked returns how long until the player may switch to name; zero
+// if it may now. Only renames start the cooldown, not the initial name.
+// Callers hold n.mu.
+func (n *nameRegistry) retryAfterLocked(playerID, name string) time.Duration {
+	names := n.history[playerID]
+	if len(names) <= 1 || names[len(names)-1].Name == name {
+		return 0
+	}
+	return max(names[len(names)-1].ChangedAt.Add(n.cooldown).Sub(n.now()), 0)
+}
+
+func (n *nameRegistry) currentLocked(playerID string) string {
+	names := n.history[playerID]
+	if len(names) == 0 {
+		return ""
+	}
+	return names[len(names)-1].Name
+}
+
+// names returns a copy of the player's name history, oldest first
+func (n *nameRegistry) names(playerID string) []NameChange {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]NameChange{}, n.history[playerID]...)
+}
+
+// forget drops a player's history
+func (n *nameRegistry) forget(playerID string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.history, playerID)
+}
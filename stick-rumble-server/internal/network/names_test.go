@@ -0,0 +1,113 @@
+package network
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sendRenameMessage(t *testing.T, conn *websocket.Conn, displayName string) {
+	sendMessage(t, conn, Message{
+		Type:      "player:rename",
+		Timestamp: time.Now().UnixMilli(),
+		Data:      map[string]interface{}{"displayName": displayName},
+	})
+}
+
+func TestNameRegistryEnforcesCooldown(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	names := newNameRegistry(10*time.Minute, func() time.Time { return now })
+
+	assert.Equal(t, "Alpha", names.joinName("p1", "Alpha"), "a new account takes any name")
+	assert.Zero(t, names.change("p1", "Alpha"))
+	assert.Zero(t, names.change("p1", "Bravo"), "the first rename is free")
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, 9*time.Minute, names.change("p1", "Charlie"))
+	assert.Zero(t, names.change("p1", "Bravo"), "keeping the name is not a change")
+	assert.Equal(t, "Bravo", names.joinName("p1", "Charlie"), "rejoining cannot skip the cooldown")
+
+	now = now.Add(9 * time.Minute)
+	assert.Equal(t, "Charlie", names.joinName("p1", "Charlie"))
+	assert.Zero(t, names.change("p1", "Charlie"))
+	assert.Equal(t, []NameChange{
+		{Name: "Alpha", ChangedAt: now.Add(-10 * time.Minute)},
+		{Name: "Bravo", ChangedAt: now.Add(-10 * time.Minute)},
+		{Name: "Charlie", ChangedAt: now},
+	}, names.names("p1"))
+
+	names.forget("p1")
+	assert.Empty(t, names.names("p1"))
+}
+
+func TestPlayerRenameNotifiesRoomAndEnforcesCooldown(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	admin := newAdminClient(t, ts)
+
+	conns, ids, _ := joinCodeRoom(t, ts, "RENAME")
+	for _, conn := range conns {
+		defer conn.Close()
+	}
+
+	sendRenameMessage(t, conns[0], "  Charlie  ")
+	for _, conn := range conns {
+		msg, err := readMessageOfType(t, conn, "player:renamed", 2*time.Second)
+		require.NoError(t, err)
+		data := msg.Data.(map[string]interface{})
+		assert.Equal(t, ids[0], data["playerId"])
+		assert.Equal(t, "Charlie", data["displayName"], "names are sanitized")
+	}
+	assert.Equal(t, "Charlie", ts.handler.roomManager.GetRoomByPlayerID(ids[0]).GetPlayer(ids[0]).DisplayName)
+
+	sendRenameMessage(t, conns[0], "Delta")
+	msg, err := readMessageOfType(t, conns[0], "rename:failed", 2*time.Second)
+	require.NoError(t, err)
+	data := msg.Data.(map[string]interface{})
+	assert.Equal(t, RenameFailedCooldown, data["reason"])
+	assert.Greater(t, data["retryAfterMs"], 0.0)
+
+	var history []NameChange
+	admin.getJSON("/admin/players/"+ids[0]+"/names", &history)
+	require.Len(t, history, 2)
+	assert.Equal(t, "Alpha", history[0].Name)
+	assert.Equal(t, "Charlie", history[1].Name)
+
+	status, _ := admin.do(http.MethodGet, "/admin/players/nobody/names", "")
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestNameHistoryOutlivesAuthenticatedConnection(t *testing.T) {
+	ts := newTestServer(withResumeGrace(0))
+	defer ts.Close()
+	ts.setAuthSecret(testAuthSecret)
+
+	url := ts.wsURL() + "?token=" + signToken(t, testAuthSecret, map[string]any{"sub": "user-7"})
+	connect := func(displayName string) *websocket.Conn {
+		conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+		require.NoError(t, err)
+		sendHelloMessage(t, conn, displayName, "code", "NAMES")
+		_, _, err = readSessionStatus(t, conn, "waiting_for_players", 2*time.Second)
+		require.NoError(t, err)
+		return conn
+	}
+
+	conn := connect("Alpha")
+	sendRenameMessage(t, conn, "Bravo")
+	_, err := readMessageOfType(t, conn, "player:renamed", 2*time.Second)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+	require.Eventually(t, func() bool {
+		return ts.handler.roomManager.GetRoomByPlayerID("user-7") == nil
+	}, 2*time.Second, 20*time.Millisecond)
+
+	// Reconnecting under a new name within the cooldown keeps the old one
+	conn = connect("Charlie")
+	defer conn.Close()
+	assert.Equal(t, "Bravo", ts.handler.roomManager.GetRoomByPlayerID("user-7").GetPlayer("user-7").DisplayName)
+	assert.Len(t, ts.handler.names.names("user-7"), 2)
+}
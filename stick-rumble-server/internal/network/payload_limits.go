@@ -0,0 +1,92 @@
+package network
+
+// Client payload limits. Frames past maxClientFrameBytes never reach the
+// handler: the connection is closed with 1009 (message too big). Smaller
+// frames that still break a limit below are dropped with an
+// error:payload_rejected so a misbehaving client learns why it was ignored.
+const (
+	// maxClientFrameBytes is the WebSocket read limit for a single frame
+	maxClientFrameBytes = 64 * 1024
+
+	// maxClientMessageBytes is the largest message the handler will decode
+	maxClientMessageBytes = 4096
+
+	// maxClientTypeLength bounds the message type, which is echoed back as
+	// offendingType
+	maxClientTypeLength = 64
+
+	// maxClientStringLength bounds any string field without its own limit
+	maxClientStringLength = 128
+)
+
+// Payload rejection reasons sent in error:payload_rejected
+const (
+	payloadRejectedTooLarge     = "message_too_large"
+	payloadRejectedFieldTooLong = "field_too_long"
+)
+
+// maxClientFieldLengths bounds known string fields by name, in bytes. Limits
+// apply before sanitization, so they leave room for whitespace and mixed case
+// the server strips later.
+var maxClientFieldLengths = map[string]int{
+	"displayName": 64, // Sanitized down to game.MaxDisplayNameLen runes
+	"code":        32, // Normalized down to game.MaxRoomCodeLen characters
+	"crateId":     64,
+	"effectId":    64,
+	"text":        2048, // Feedback text, at most 500 characters; chat text, at most 200
+}
+
+// payloadRejection describes why a client message was dropped
+type payloadRejection struct {
+	Reason string
+	Field  string // Empty when the whole message was too large
+	Limit  int
+}
+
+// checkClientFrameSize rejects a frame too large to decode
+func checkClientFrameSize(frame []byte) (payloadRejection, bool) {
+	if len(frame) > maxClientMessageBytes {
+		return payloadRejection{Reason: payloadRejectedTooLarge, Limit: maxClientMessageBytes}, false
+	}
+	return payloadRejection{}, true
+}
+
+// checkClientFields rejects a decoded message with an oversized type or
+// string field anywhere in its data
+func checkClientFields(msg Message) (payloadRejection, bool) {
+	if len(msg.Type) > maxClientTypeLength {
+		return payloadRejection{Reason: payloadRejectedFieldTooLong, Field: "type", Limit: maxClientTypeLength}, false
+	}
+	return checkClientValue("data", msg.Data)
+}
+
+// checkClientValue walks value and checks every string against the limit for
+// the field that holds it; strings inside arrays use the array's field name
+func checkClientValue(field string, value any) (payloadRejection, bool) {
+	switch v := value.(type) {
+	case string:
+		limit, known := maxClientFieldLengths[field]
+		if !known {
+			limit = maxClientStringLength
+		}
+		if len(v) > limit {
+			return payloadRejection{Reason: payloadRejectedFieldTooLong, Field: field, Limit: limit}, false
+		}
+	case map[string]any:
+		for key, nested := range v {
+			if len(key) > maxClientStringLength {
+				return payloadRejection{Reason: payloadRejectedFieldTooLong, Field: field, Limit: maxClientStringLength}, false
+			}
+			if rejection, ok := checkClientValue(key, nested); !ok {
+				return rejection, false
+			}
+		}
+	case []any:
+		for _, nested := range v {
+			if rejection, ok := checkClientValue(field, nested); !ok {
+				return rejection, false
+			}
+		}
+	}
+	return payloadRejection{}, true
+}
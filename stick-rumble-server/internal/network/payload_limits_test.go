@@ -0,0 +1,117 @@
+package network
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckClientFrameSize(t *testing.T) {
+	_, ok := checkClientFrameSize(make([]byte, maxClientMessageBytes))
+	assert.True(t, ok)
+
+	rejection, ok := checkClientFrameSize(make([]byte, maxClientMessageBytes+1))
+	assert.False(t, ok)
+	assert.Equal(t, payloadRejection{Reason: payloadRejectedTooLarge, Limit: maxClientMessageBytes}, rejection)
+}
+
+func TestCheckClientFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      Message
+		expected payloadRejection
+		ok       bool
+	}{
+		{
+			name: "accepts fields within their limits",
+			msg: Message{Type: "player:hello", Data: map[string]any{
+				"displayName": strings.Repeat("a", 64),
+				"mode":        "code",
+				"code":        strings.Repeat("b", 32),
+			}},
+			ok: true,
+		},
+		{
+			name:     "rejects an oversized type",
+			msg:      Message{Type: strings.Repeat("t", maxClientTypeLength+1)},
+			expected: payloadRejection{Reason: payloadRejectedFieldTooLong, Field: "type", Limit: maxClientTypeLength},
+		},
+		{
+			name:     "rejects a field past its own limit",
+			msg:      Message{Type: "player:rename", Data: map[string]any{"displayName": strings.Repeat("a", 65)}},
+			expected: payloadRejection{Reason: payloadRejectedFieldTooLong, Field: "displayName", Limit: 64},
+		},
+		{
+			name:     "rejects an unknown field past the default limit",
+			msg:      Message{Type: "chat:send", Data: map[string]any{"message": strings.Repeat("a", maxClientStringLength+1)}},
+			expected: payloadRejection{Reason: payloadRejectedFieldTooLong, Field: "message", Limit: maxClientStringLength},
+		},
+		{
+			name: "checks strings inside arrays against the array's field",
+			msg: Message{Type: "player:preferences", Data: map[string]any{
+				"optOut": []any{"melee:hit", strings.Repeat("a", maxClientStringLength+1)},
+			}},
+			expected: payloadRejection{Reason: payloadRejectedFieldTooLong, Field: "optOut", Limit: maxClientStringLength},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rejection, ok := checkClientFields(tt.msg)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, rejection)
+		})
+	}
+}
+
+func TestOversizedMessagesAreRejected(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn := ts.connectClient(t)
+	defer conn.Close()
+
+	t.Run("field too long", func(t *testing.T) {
+		sendMessage(t, conn, Message{
+			Type:      "player:rename",
+			Timestamp: time.Now().UnixMilli(),
+			Data:      map[string]any{"displayName": strings.Repeat("a", 65)},
+		})
+
+		msg, err := readMessageOfType(t, conn, "error:payload_rejected", 2*time.Second)
+		require.NoError(t, err)
+		data := msg.Data.(map[string]any)
+		assert.Equal(t, payloadRejectedFieldTooLong, data["reason"])
+		assert.Equal(t, "player:rename", data["offendingType"])
+		assert.Equal(t, "displayName", data["field"])
+		assert.Equal(t, float64(64), data["limit"])
+	})
+
+	t.Run("message too large", func(t *testing.T) {
+		frame := `{"type":"input:state","timestamp":0,"data":{"pad":"` + strings.Repeat("a", maxClientMessageBytes) + `"}}`
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(frame)))
+
+		msg, err := readMessageOfType(t, conn, "error:payload_rejected", 2*time.Second)
+		require.NoError(t, err)
+		data := msg.Data.(map[string]any)
+		assert.Equal(t, payloadRejectedTooLarge, data["reason"])
+		assert.NotContains(t, data, "offendingType")
+		assert.Equal(t, float64(maxClientMessageBytes), data["limit"])
+	})
+
+	t.Run("frame past the read limit closes the connection", func(t *testing.T) {
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, make([]byte, maxClientFrameBytes+1)))
+
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				assert.True(t, websocket.IsCloseError(err, websocket.CloseMessageTooBig), "unexpected error: %v", err)
+				return
+			}
+		}
+	})
+}
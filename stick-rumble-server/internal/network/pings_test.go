@@ -0,0 +1,64 @@
+package network
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayerPingReachesOnlyTeammates(t *testing.T) {
+	withSchemaValidation(t)
+	f := newGoldenFixture(t)
+	bystander := game.NewPlayer("player-c", make(chan []byte, 64))
+	_, ok := f.handler.roomManager.AddCodePlayer(bystander, "GOLDEN")
+	require.True(t, ok)
+	for _, id := range []string{"player-a", "player-b", "player-c"} {
+		f.handler.gameServer.AddPlayer(id)
+	}
+	f.sender.Team = game.TeamAlpha
+	f.receiver.Team = game.TeamAlpha
+	bystander.Team = game.TeamBravo
+	f.drain()
+	for len(bystander.SendChan) > 0 {
+		<-bystander.SendChan
+	}
+
+	f.handler.handlePlayerPing(f.sender, map[string]interface{}{
+		"type":     "enemy",
+		"position": map[string]interface{}{"x": 640.0, "y": 360.0},
+	})
+
+	var msg Message
+	require.NoError(t, json.Unmarshal(f.received(t, "team:ping"), &msg))
+	assert.Equal(t, map[string]interface{}{
+		"playerId": "player-a",
+		"type":     "enemy",
+		"position": map[string]interface{}{"x": 640.0, "y": 360.0},
+	}, msg.Data)
+	assert.Empty(t, bystander.SendChan, "the other team never sees the ping")
+}
+
+func TestPlayerPingRejectsOutOfBoundsAndFloods(t *testing.T) {
+	withSchemaValidation(t)
+	f := newGoldenFixture(t)
+	f.handler.gameServer.AddPlayer("player-b")
+	ping := func(x float64) {
+		f.handler.handlePlayerPing(f.receiver, map[string]interface{}{
+			"type":     "go",
+			"position": map[string]interface{}{"x": x, "y": 100.0},
+		})
+	}
+
+	ping(-50)
+	ping(100)
+	f.handler.handlePlayerPing(f.receiver, map[string]interface{}{"type": "go"})
+	assert.Len(t, f.receiver.SendChan, 1, "only the ping inside the map is routed")
+
+	for i := 0; i < game.PingCharges+2; i++ {
+		ping(100)
+	}
+	assert.Len(t, f.receiver.SendChan, game.PingCharges, "pings past the burst are dropped")
+}
@@ -0,0 +1,82 @@
+package network
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// playerStats is the body of GET /players/{playerID}/stats
+type playerStats struct {
+	PlayerID    string           `json:"playerId"`
+	DisplayName string           `json:"displayName"`
+	Live        *livePlayerStats `json:"live,omitempty"`    // Omitted when the player is not on this server
+	History     *PlayerHistory   `json:"history,omitempty"` // Only with ?history=true while match records are kept
+}
+
+// livePlayerStats is a connected player's current match, from their game
+// state snapshot and their room's match
+type livePlayerStats struct {
+	RoomID     string  `json:"roomId,omitempty"` // Omitted while the player is in no room
+	Kills      int     `json:"kills"`
+	Deaths     int     `json:"deaths"`
+	XP         int     `json:"xp"`
+	ShotsFired int     `json:"shotsFired"`
+	ShotsHit   int     `json:"shotsHit"`
+	Accuracy   float64 `json:"accuracy"` // ShotsHit / ShotsFired, at most 1; 0 without shots
+}
+
+// HandlePlayerStats serves player stats from the shared global handler
+func HandlePlayerStats(w http.ResponseWriter, r *http.Request) {
+	getGlobalHandler().HandlePlayerStats(w, r)
+}
+
+// HandlePlayerStats serves a player's live stats and, with ?history=true,
+// their totals over the persisted match records. 404 when the server knows
+// nothing about the player.
+func (h *WebSocketHandler) HandlePlayerStats(w http.ResponseWriter, r *http.Request) {
+	playerID := r.PathValue("playerID")
+	stats := playerStats{PlayerID: playerID}
+
+	room := h.roomManager.GetRoomByPlayerID(playerID)
+	snapshot, inGame := h.gameServer.GetPlayerState(playerID)
+	if inGame || room != nil {
+		live := &livePlayerStats{Kills: snapshot.Kills, Deaths: snapshot.Deaths, XP: snapshot.XP}
+		stats.DisplayName = snapshot.DisplayName
+		if room != nil {
+			live.RoomID = room.ID
+			live.ShotsFired, live.ShotsHit, live.Accuracy = room.Match.ShotStats(playerID)
+			if player := room.GetPlayer(playerID); player != nil {
+				stats.DisplayName = player.DisplayName
+			}
+		}
+		stats.Live = live
+	}
+
+	if includeHistory, _ := strconv.ParseBool(r.URL.Query().Get("history")); includeHistory {
+		if reader, ok := h.matchRecords.(playerHistoryReader); ok {
+			history, found, err := reader.PlayerHistory(playerID)
+			if err != nil {
+				log.Printf("Error reading match history of %s: %v", playerID, err)
+				http.Error(w, "match history unavailable", http.StatusInternalServerError)
+				return
+			}
+			if found {
+				stats.History = &history
+				if stats.DisplayName == "" {
+					stats.DisplayName = history.DisplayName
+				}
+			}
+		}
+	}
+
+	if stats.Live == nil && stats.History == nil {
+		http.Error(w, "player not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(stats); err != nil {
+		log.Printf("Failed to encode %s response: %v", r.URL.Path, err)
+	}
+}
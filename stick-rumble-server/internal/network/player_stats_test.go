@@ -0,0 +1,118 @@
+package network
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getPlayerStats(h *WebSocketHandler, playerID, query string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/players/"+playerID+"/stats"+query, nil)
+	req.SetPathValue("playerID", playerID)
+	rec := httptest.NewRecorder()
+	h.HandlePlayerStats(rec, req)
+	return rec
+}
+
+func TestPlayerStatsReportsLiveMatch(t *testing.T) {
+	f := newGoldenFixture(t)
+	state := f.handler.gameServer.AddPlayer("player-a")
+	state.IncrementKills()
+	state.IncrementKills()
+	state.IncrementDeaths()
+	state.AddXP(150)
+	f.room.Match.RecordShots("player-a", 4)
+	f.room.Match.RecordShotHit("player-a", "player-b")
+
+	rec := getPlayerStats(f.handler, "player-a", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var stats playerStats
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &stats))
+
+	assert.Equal(t, "player-a", stats.PlayerID)
+	assert.Equal(t, "Alpha", stats.DisplayName)
+	require.NotNil(t, stats.Live)
+	assert.Equal(t, livePlayerStats{RoomID: f.room.ID, Kills: 2, Deaths: 1, XP: 150, ShotsFired: 4, ShotsHit: 1, Accuracy: 0.25}, *stats.Live)
+	assert.Nil(t, stats.History, "history is only read when asked for")
+
+	// In the room but not yet spawned into the game
+	rec = getPlayerStats(f.handler, "player-b", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &stats))
+	assert.Equal(t, "Bravo", stats.DisplayName)
+	assert.Equal(t, livePlayerStats{RoomID: f.room.ID}, *stats.Live)
+}
+
+func TestPlayerStatsUnknownPlayerIsNotFound(t *testing.T) {
+	f := newGoldenFixture(t)
+
+	assert.Equal(t, http.StatusNotFound, getPlayerStats(f.handler, "nobody", "").Code)
+	assert.Equal(t, http.StatusNotFound, getPlayerStats(f.handler, "nobody", "?history=true").Code,
+		"history is ignored without match records")
+}
+
+func TestPlayerStatsIncludesRecordedHistory(t *testing.T) {
+	f := newGoldenFixture(t)
+	store := newFileMatchRecordStore(t.TempDir())
+	f.handler.matchRecords = store
+
+	assert.Equal(t, http.StatusNotFound, getPlayerStats(f.handler, "veteran", "?history=true").Code,
+		"nothing is recorded yet")
+
+	first := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, store.SaveMatchRecord(MatchRecord{
+		MatchID: "m1",
+		Winners: []game.WinnerSummary{{PlayerID: "veteran"}},
+		Scoreboard: []game.PlayerMatchStats{
+			{PlayerID: "veteran", DisplayName: "Old", Kills: 5, Deaths: 1, Assists: 2, XP: 300, ShotsFired: 10, ShotsHit: 6, DamageDealt: 400, BestKillStreak: 4},
+			{PlayerID: "rookie", Kills: 1},
+		},
+		EndedAt: first,
+	}))
+	require.NoError(t, store.SaveMatchRecord(MatchRecord{
+		MatchID: "m2",
+		Winners: []game.WinnerSummary{{PlayerID: "rookie"}},
+		Scoreboard: []game.PlayerMatchStats{
+			{PlayerID: "veteran", DisplayName: "Vet", Kills: 3, Deaths: 4, XP: 100, ShotsFired: 10, ShotsHit: 2, DamageDealt: 150, BestKillStreak: 2},
+		},
+		EndedAt: first.Add(time.Hour),
+	}))
+
+	rec := getPlayerStats(f.handler, "veteran", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code, "offline players have no live stats")
+
+	rec = getPlayerStats(f.handler, "veteran", "?history=true")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var stats playerStats
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &stats))
+
+	assert.Nil(t, stats.Live)
+	assert.Equal(t, "Vet", stats.DisplayName, "the name from their latest match")
+	require.NotNil(t, stats.History)
+	history := *stats.History
+	assert.Equal(t, 2, history.Matches)
+	assert.Equal(t, 1, history.Wins)
+	assert.Equal(t, 8, history.Kills)
+	assert.Equal(t, 5, history.Deaths)
+	assert.Equal(t, 2, history.Assists)
+	assert.Equal(t, 400, history.XP)
+	assert.Equal(t, 0.4, history.Accuracy)
+	assert.Equal(t, 550, history.DamageDealt)
+	assert.Equal(t, 4, history.BestKillStreak)
+	assert.Equal(t, first.Add(time.Hour), history.LastPlayedAt.UTC())
+
+	// A live player without recorded matches still gets their live stats
+	stats = playerStats{}
+	rec = getPlayerStats(f.handler, "player-b", "?history=1")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &stats))
+	assert.NotNil(t, stats.Live)
+	assert.Nil(t, stats.History)
+}
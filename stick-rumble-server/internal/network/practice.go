@@ -0,0 +1,40 @@
+package network
+
+import (
+	"log"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+// recordPracticeFight counts a kill between the player and a bot toward a
+// practice room's adaptive difficulty, telling the room when the difficulty
+// changes. Kills in other rooms, and bots killing each other, are ignored.
+func (h *WebSocketHandler) recordPracticeFight(room *game.Room, killerID, victimID string) {
+	if room.Practice == nil {
+		return
+	}
+
+	killerIsBot, victimIsBot := h.bots.IsBot(killerID), h.bots.IsBot(victimID)
+	var changed bool
+	switch {
+	case victimIsBot && !killerIsBot:
+		changed = room.Practice.RecordKill()
+	case killerIsBot && !victimIsBot:
+		changed = room.Practice.RecordDeath()
+	default:
+		return
+	}
+	if !changed {
+		return
+	}
+
+	log.Printf("Practice room %s bot difficulty changed to %s", room.ID, room.Practice.Difficulty().Name)
+	h.sendPracticeStatus(room)
+}
+
+// sendPracticeStatus sends practice:status to a practice room
+func (h *WebSocketHandler) sendPracticeStatus(room *game.Room) {
+	if err := h.publication.BroadcastPracticeStatus(room); err != nil {
+		log.Printf("Error building practice:status message: %v", err)
+	}
+}
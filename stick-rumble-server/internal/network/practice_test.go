@@ -0,0 +1,73 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPracticeHelloStartsMatchWithBots(t *testing.T) {
+	ts := newTestServer(withResumeGrace(0))
+	defer ts.Close()
+
+	conn := ts.connectRawClient(t)
+	sendHelloMessage(t, conn, "Solo", "practice", "")
+
+	_, data, err := readSessionStatus(t, conn, "match_ready", 2*time.Second)
+	require.NoError(t, err, "practice starts without a second player")
+	assert.Equal(t, "practice", data["joinMode"])
+	assert.Equal(t, float64(1+game.PracticeBotCount), data["rosterSize"])
+
+	msg, err := readMessageOfType(t, conn, "practice:status", 2*time.Second)
+	require.NoError(t, err)
+	status := msg.Data.(map[string]interface{})
+	assert.Equal(t, "normal", status["difficulty"])
+
+	bots := ts.handler.bots.Players()
+	require.Len(t, bots, game.PracticeBotCount)
+	room := ts.handler.roomManager.GetRoomByPlayerID(bots[0].ID)
+	require.NotNil(t, room)
+	assert.Equal(t, game.RoomKindPractice, room.Kind)
+
+	// The bots leave once the player has gone
+	conn.Close()
+	require.Eventually(t, func() bool {
+		return len(ts.handler.bots.Players()) == 0
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestPracticeKillsAgainstBotsRaiseDifficulty(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn := ts.connectRawClient(t)
+	defer conn.Close()
+	sendHelloMessage(t, conn, "Solo", "practice", "")
+	_, err := readMessageOfType(t, conn, "practice:status", 2*time.Second)
+	require.NoError(t, err)
+
+	bots := ts.handler.bots.Players()
+	require.NotEmpty(t, bots)
+	room := ts.handler.roomManager.GetRoomByPlayerID(bots[0].ID)
+	require.NotNil(t, room)
+	var playerID string
+	for _, player := range room.GetPlayers() {
+		if !ts.handler.bots.IsBot(player.ID) {
+			playerID = player.ID
+		}
+	}
+
+	// Bots killing each other do not count
+	ts.handler.recordPracticeFight(room, bots[0].ID, bots[1].ID)
+	for range game.BotDifficultyMinEvents {
+		ts.handler.recordPracticeFight(room, playerID, bots[0].ID)
+	}
+	assert.Equal(t, "hard", room.Practice.Difficulty().Name)
+
+	msg, err := readMessageOfType(t, conn, "practice:status", 2*time.Second)
+	require.NoError(t, err, "the player is told the difficulty changed")
+	assert.Equal(t, "hard", msg.Data.(map[string]interface{})["difficulty"])
+}
@@ -1,8 +1,11 @@
 package network
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/mtomcal/stick-rumble-server/internal/buildinfo"
 	"github.com/mtomcal/stick-rumble-server/internal/game"
 )
 
@@ -31,10 +34,100 @@ type playerLeftData struct {
 	PlayerID string `json:"playerId"`
 }
 
+type playerJoinedData struct {
+	PlayerID    string    `json:"playerId"`
+	DisplayName string    `json:"displayName"`
+	Team        *teamData `json:"team,omitempty"`
+	RosterSize  int       `json:"rosterSize"`
+}
+
+type teamData struct {
+	ID           string `json:"id"`
+	PaletteIndex int    `json:"paletteIndex"`
+}
+
+type rosterPlayerData struct {
+	PlayerID    string    `json:"playerId"`
+	DisplayName string    `json:"displayName"`
+	Team        *teamData `json:"team,omitempty"`
+	Ready       bool      `json:"ready"`
+}
+
+// teamDataFor describes a team for team-related messages, or nil for
+// free-for-all players
+func teamDataFor(team string) *teamData {
+	paletteIndex, ok := game.TeamPaletteIndex(team)
+	if !ok {
+		return nil
+	}
+	return &teamData{ID: team, PaletteIndex: paletteIndex}
+}
+
+type roomRosterData struct {
+	RoomID  string             `json:"roomId,omitempty"`
+	Code    string             `json:"code,omitempty"`
+	Players []rosterPlayerData `json:"players"`
+}
+
+type roomReadyStateData struct {
+	RoomID           string   `json:"roomId"`
+	ReadyPlayerIDs   []string `json:"readyPlayerIds"`
+	ReadyCount       int      `json:"readyCount"`
+	RequiredCount    int      `json:"requiredCount"`
+	TotalPlayers     int      `json:"totalPlayers"`
+	RemainingSeconds int      `json:"remainingSeconds"`
+	Active           bool     `json:"active"`
+	Started          bool     `json:"started"`
+}
+
+type partyStateData struct {
+	RoomID           string   `json:"roomId"`
+	StayingPlayerIDs []string `json:"stayingPlayerIds"`
+	TotalPlayers     int      `json:"totalPlayers"`
+}
+
+type matchRestartedData struct {
+	RoomID    string   `json:"roomId"`
+	PlayerIDs []string `json:"playerIds"`
+}
+
+type sessionCapacityData struct {
+	QueuePosition int    `json:"queuePosition,omitempty"`
+	RedirectURL   string `json:"redirectUrl,omitempty"`
+}
+
+type roomRedirectData struct {
+	Address string `json:"address"`
+	Reason  string `json:"reason"`
+}
+
+type serverHelloData struct {
+	Commit       string `json:"commit"`
+	BuildTime    string `json:"buildTime"`
+	GoVersion    string `json:"goVersion"`
+	SessionToken string `json:"sessionToken"`
+	Resumed      bool   `json:"resumed"`
+}
+
+type netPingData struct {
+	ID         int   `json:"id"`
+	ServerTime int64 `json:"serverTime"`
+}
+
+// sessionReplacedData has no fields; the message type says it all
+type sessionReplacedData struct{}
+
 type errorNoHelloData struct {
 	OffendingType string `json:"offendingType"`
 }
 
+type errorPayloadRejectedData struct {
+	Reason        string `json:"reason"`
+	OffendingType string `json:"offendingType,omitempty"`
+	Field         string `json:"field,omitempty"`
+	Limit         int    `json:"limit"`
+}
+
 type errorBadRoomCodeData struct {
 	Reason string `json:"reason"`
 }
@@ -43,12 +136,22 @@ type errorRoomFullData struct {
 	Code string `json:"code"`
 }
 
+type errorRoomBlockedData struct {
+	Code         string `json:"code"`
+	RetryAfterMs int64  `json:"retryAfterMs"`
+}
+
 type playerDamagedData struct {
 	VictimID     string `json:"victimId"`
 	AttackerID   string `json:"attackerId"`
 	Damage       int    `json:"damage"`
 	NewHealth    int    `json:"newHealth"`
+	NewShield    int    `json:"newShield"`
 	ProjectileID string `json:"projectileId"`
+
+	// Impulse is the velocity a heavy ranged hit added to the victim, omitted
+	// when the hit did not push
+	Impulse *game.Vector2 `json:"impulse,omitempty"`
 }
 
 type hitConfirmedData struct {
@@ -57,6 +160,75 @@ type hitConfirmedData struct {
 	ProjectileID string `json:"projectileId"`
 }
 
+type hitRejectedData struct {
+	Attack   string `json:"attack"`
+	Code     string `json:"code"`
+	VictimID string `json:"victimId,omitempty"`
+}
+
+type playerRenamedData struct {
+	PlayerID    string `json:"playerId"`
+	DisplayName string `json:"displayName"`
+}
+
+type renameFailedData struct {
+	Reason       string `json:"reason"`
+	RetryAfterMs int64  `json:"retryAfterMs"`
+}
+
+type ultimateActivatedData struct {
+	PlayerID         string  `json:"playerId"`
+	Ultimate         string  `json:"ultimate"`
+	DurationMs       int64   `json:"durationMs"`
+	DamageMultiplier float64 `json:"damageMultiplier"`
+	NewHealth        int     `json:"newHealth"`
+}
+
+type playerEmotedData struct {
+	PlayerID string `json:"playerId"`
+	Emote    string `json:"emote"`
+}
+
+type chatPostedData struct {
+	PlayerID    string `json:"playerId"`
+	DisplayName string `json:"displayName"`
+	Text        string `json:"text"`
+	Channel     string `json:"channel"` // chatChannelAll or chatChannelTeam
+}
+
+type voteKickProgressData struct {
+	TargetID    string `json:"targetId"`
+	VoterID     string `json:"voterId"`
+	Votes       int    `json:"votes"`
+	Needed      int    `json:"needed"`
+	ExpiresInMs int64  `json:"expiresInMs"`
+	Passed      bool   `json:"passed"`
+}
+
+type playerLevelUpData struct {
+	PlayerID      string   `json:"playerId"`
+	Level         int      `json:"level"`
+	PreviousLevel int      `json:"previousLevel"`
+	XP            int      `json:"xp"`
+	NextLevelXP   int      `json:"nextLevelXp"`
+	Unlocks       []string `json:"unlocks"`
+}
+
+type leaderboardUpdateData struct {
+	Matches int                      `json:"matches"`
+	Total   int                      `json:"total"`
+	Top     []leaderboardUpdateEntry `json:"top"`
+}
+
+type leaderboardUpdateEntry struct {
+	Rank        int     `json:"rank"`
+	PlayerID    string  `json:"playerId"`
+	DisplayName string  `json:"displayName"`
+	XP          int     `json:"xp"`
+	KD          float64 `json:"kd"`
+	Wins        int     `json:"wins"`
+}
+
 type playerDeathData struct {
 	VictimID   string `json:"victimId"`
 	AttackerID string `json:"attackerId"`
@@ -69,6 +241,22 @@ type playerKillCreditData struct {
 	KillerXP    int    `json:"killerXP"`
 }
 
+type playerKillstreakData struct {
+	PlayerID  string `json:"playerId"`
+	Streak    int    `json:"streak"`
+	MultiKill int    `json:"multiKill"`
+	BonusXP   int    `json:"bonusXp"`
+}
+
+type playerAssistCreditData struct {
+	PlayerID      string `json:"playerId"`
+	VictimID      string `json:"victimId"`
+	KillerID      string `json:"killerId"`
+	Damage        int    `json:"damage"`
+	PlayerAssists int    `json:"playerAssists"`
+	PlayerXP      int    `json:"playerXP"`
+}
+
 type playerRespawnData struct {
 	PlayerID string       `json:"playerId"`
 	Position game.Vector2 `json:"position"`
@@ -76,18 +264,44 @@ type playerRespawnData struct {
 }
 
 type weaponStateData struct {
-	CurrentAmmo int    `json:"currentAmmo"`
-	MaxAmmo     int    `json:"maxAmmo"`
-	IsReloading bool   `json:"isReloading"`
-	CanShoot    bool   `json:"canShoot"`
-	WeaponType  string `json:"weaponType"`
-	IsMelee     bool   `json:"isMelee"`
+	CurrentAmmo int                 `json:"currentAmmo"`
+	MaxAmmo     int                 `json:"maxAmmo"`
+	IsReloading bool                `json:"isReloading"`
+	CanShoot    bool                `json:"canShoot"`
+	WeaponType  string              `json:"weaponType"`
+	IsMelee     bool                `json:"isMelee"`
+	Abilities   []game.AbilityState `json:"abilities,omitempty"`
+	Stats       *game.WeaponStats   `json:"stats,omitempty"`
+	Stamina     int                 `json:"stamina"`    // Stamina left for dodge rolls
+	MaxStamina  int                 `json:"maxStamina"` // Full stamina
 }
 
 type matchEndedData struct {
-	Winners     []game.WinnerSummary `json:"winners"`
-	FinalScores []game.PlayerScore   `json:"finalScores"`
-	Reason      string               `json:"reason"`
+	Winners     []game.WinnerSummary    `json:"winners"`
+	FinalScores []game.PlayerScore      `json:"finalScores"`
+	Reason      string                  `json:"reason"`
+	Scoreboard  []game.PlayerMatchStats `json:"scoreboard"`
+	Settings    game.MatchSettings      `json:"settings"`
+	MapID       string                  `json:"mapId"`
+	Mutators    []string                `json:"mutators"`
+	Seed        int64                   `json:"seed"`
+	Build       buildinfo.Info          `json:"build"`
+}
+
+type worldSyncData struct {
+	MatchState       string                 `json:"matchState"`
+	RemainingSeconds int                    `json:"remainingSeconds"`
+	KillTarget       int                    `json:"killTarget"`
+	PlayerKills      map[string]int         `json:"playerKills"`
+	Scoreboard       []game.ScoreboardEntry `json:"scoreboard"`
+}
+
+type mapLoadData struct {
+	MapID     string             `json:"mapId"`
+	Name      string             `json:"name"`
+	Width     float64            `json:"width"`
+	Height    float64            `json:"height"`
+	Obstacles []game.MapObstacle `json:"obstacles"`
 }
 
 func newServerToClientPublication(builder outgoingEnvelopeBuilder, roomManager *game.RoomManager) *serverToClientPublication {
@@ -116,6 +330,164 @@ func (p *serverToClientPublication) PublishPlayerLeft(room *game.Room, playerID
 	return nil
 }
 
+func (p *serverToClientPublication) PublishPlayerJoined(room *game.Room, player *game.Player) error {
+	msgBytes, err := p.builder.Build("player:joined", playerJoinedData{
+		PlayerID:    player.ID,
+		DisplayName: player.DisplayName,
+		Team:        teamDataFor(player.Team),
+		RosterSize:  room.PlayerCount(),
+	})
+	if err != nil {
+		return err
+	}
+
+	room.Broadcast(msgBytes, player.ID)
+	return nil
+}
+
+func (p *serverToClientPublication) PublishReadyState(room *game.Room, state game.ReadyCheckState) error {
+	return p.broadcastToRoom(room, "room:ready_state", roomReadyStateData{
+		RoomID:           state.RoomID,
+		ReadyPlayerIDs:   state.ReadyPlayerIDs,
+		ReadyCount:       state.ReadyCount,
+		RequiredCount:    state.RequiredCount,
+		TotalPlayers:     state.TotalPlayers,
+		RemainingSeconds: state.RemainingSeconds,
+		Active:           state.Active,
+		Started:          state.Started,
+	})
+}
+
+func (p *serverToClientPublication) PublishPartyState(room *game.Room, state game.PartyState) error {
+	return p.broadcastToRoom(room, "party:state", partyStateData{
+		RoomID:           state.RoomID,
+		StayingPlayerIDs: state.StayingPlayerIDs,
+		TotalPlayers:     state.TotalPlayers,
+	})
+}
+
+// SendRoomRoster replies to a roster request. A player still queued for a
+// public match has no room yet, so their roster contains only themselves.
+func (p *serverToClientPublication) SendRoomRoster(player *game.Player, room *game.Room) error {
+	data := roomRosterData{}
+	var roster []game.RosterEntry
+	if room != nil {
+		data.RoomID = room.ID
+		if room.Kind == game.RoomKindCode {
+			data.Code = room.Code
+		}
+		roster = room.Roster()
+	} else {
+		roster = []game.RosterEntry{{
+			PlayerID:    player.ID,
+			DisplayName: player.DisplayName,
+			Team:        player.Team,
+			Ready:       player.Ready,
+		}}
+	}
+
+	data.Players = make([]rosterPlayerData, 0, len(roster))
+	for _, entry := range roster {
+		data.Players = append(data.Players, rosterPlayerData{
+			PlayerID:    entry.PlayerID,
+			DisplayName: entry.DisplayName,
+			Team:        teamDataFor(entry.Team),
+			Ready:       entry.Ready,
+		})
+	}
+
+	msgBytes, err := p.builder.Build("room:roster", data)
+	if err != nil {
+		return err
+	}
+
+	return p.sendDirect(player, msgBytes)
+}
+
+// SendCapacityNotice tells a player turned away at capacity either their
+// queue position or which instance to try instead.
+func (p *serverToClientPublication) SendCapacityNotice(player *game.Player, queuePosition int, redirectURL string) error {
+	msgBytes, err := p.builder.Build("session:capacity", sessionCapacityData{
+		QueuePosition: queuePosition,
+		RedirectURL:   redirectURL,
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.sendDirect(player, msgBytes)
+}
+
+// SendRoomRedirect tells a player to join through another server instance
+func (p *serverToClientPublication) SendRoomRedirect(player *game.Player, address, reason string) error {
+	msgBytes, err := p.builder.Build("room:redirect", roomRedirectData{
+		Address: address,
+		Reason:  reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.sendDirect(player, msgBytes)
+}
+
+// SendServerShutdown warns a player that the server is draining and how long
+// running matches have left
+func (p *serverToClientPublication) SendServerShutdown(player *game.Player, countdown time.Duration) error {
+	msgBytes, err := p.builder.Build("server:shutdown", map[string]interface{}{
+		"countdownMs": countdown.Milliseconds(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.sendDirect(player, msgBytes)
+}
+
+// SendServerHello tells a newly connected client which server build it reached
+// and the session token it can resume with after a disconnect
+func (p *serverToClientPublication) SendServerHello(player *game.Player, info buildinfo.Info, sessionToken string, resumed bool) error {
+	msgBytes, err := p.builder.Build("server:hello", serverHelloData{
+		Commit:       info.Commit,
+		BuildTime:    info.BuildTime,
+		GoVersion:    info.GoVersion,
+		SessionToken: sessionToken,
+		Resumed:      resumed,
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.sendDirect(player, msgBytes)
+}
+
+// SendNetPing sends a player's connection the next heartbeat ping
+func (p *serverToClientPublication) SendNetPing(player *game.Player, id int, sentAt time.Time) error {
+	msgBytes, err := p.builder.Build("net:ping", netPingData{ID: id, ServerTime: sentAt.UnixMilli()})
+	if err != nil {
+		return err
+	}
+
+	return p.sendDirect(player, msgBytes)
+}
+
+// SendTimeSync answers a player's clock sync request. Built as a map: the
+// tick is 0 until the tick loop first runs, and a zero struct field would
+// fail the required-field check
+func (p *serverToClientPublication) SendTimeSync(player *game.Player, clientTime float64, receivedAt, transmittedAt time.Time, tick uint64) error {
+	msgBytes, err := p.builder.Build("time:sync", map[string]interface{}{
+		"clientTime":         clientTime,
+		"serverReceiveTime":  receivedAt.UnixMilli(),
+		"serverTransmitTime": transmittedAt.UnixMilli(),
+		"tick":               tick,
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.sendDirect(player, msgBytes)
+}
+
 func (p *serverToClientPublication) SendNoHelloError(player *game.Player, offendingType string) error {
 	msgBytes, err := p.builder.Build("error:no_hello", errorNoHelloData{OffendingType: offendingType})
 	if err != nil {
@@ -125,6 +497,20 @@ func (p *serverToClientPublication) SendNoHelloError(player *game.Player, offend
 	return p.sendDirect(player, msgBytes)
 }
 
+func (p *serverToClientPublication) SendPayloadRejected(player *game.Player, offendingType string, rejection payloadRejection) error {
+	msgBytes, err := p.builder.Build("error:payload_rejected", errorPayloadRejectedData{
+		Reason:        rejection.Reason,
+		OffendingType: offendingType,
+		Field:         rejection.Field,
+		Limit:         rejection.Limit,
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.sendDirect(player, msgBytes)
+}
+
 func (p *serverToClientPublication) SendBadRoomCodeError(player *game.Player, reason string) error {
 	msgBytes, err := p.builder.Build("error:bad_room_code", errorBadRoomCodeData{Reason: reason})
 	if err != nil {
@@ -143,6 +529,20 @@ func (p *serverToClientPublication) SendRoomFullError(player *game.Player, code
 	return p.sendDirect(player, msgBytes)
 }
 
+// SendRoomBlockedError tells a vote-kicked player they cannot rejoin the room
+// with code yet
+func (p *serverToClientPublication) SendRoomBlockedError(player *game.Player, code string, retryAfter time.Duration) error {
+	msgBytes, err := p.builder.Build("error:room_blocked", errorRoomBlockedData{
+		Code:         code,
+		RetryAfterMs: retryAfter.Milliseconds(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.sendDirect(player, msgBytes)
+}
+
 func (p *serverToClientPublication) BroadcastPlayerDamaged(room *game.Room, data playerDamagedData) error {
 	return p.broadcastToRoom(room, "player:damaged", data)
 }
@@ -151,6 +551,90 @@ func (p *serverToClientPublication) SendHitConfirmed(playerID string, data hitCo
 	return p.sendToPlayerID(playerID, "hit:confirmed", data)
 }
 
+func (p *serverToClientPublication) SendHitRejected(playerID string, data hitRejectedData) error {
+	return p.sendToPlayerID(playerID, "hit:rejected", data)
+}
+
+// PublishPlayerRenamed tells the player's room about its new display name, or
+// just the player while it waits for a room
+func (p *serverToClientPublication) PublishPlayerRenamed(room *game.Room, data playerRenamedData) error {
+	if room == nil {
+		return p.sendToPlayerID(data.PlayerID, "player:renamed", data)
+	}
+	return p.broadcastToRoom(room, "player:renamed", data)
+}
+
+func (p *serverToClientPublication) SendRenameFailed(playerID string, data renameFailedData) error {
+	return p.sendToPlayerID(playerID, "rename:failed", data)
+}
+
+func (p *serverToClientPublication) BroadcastUltimateActivated(room *game.Room, data ultimateActivatedData) error {
+	return p.broadcastToRoom(room, "ultimate:activated", data)
+}
+
+// BroadcastPlayerEmoted shows a player's emote or quick-chat line to room.
+// Players who opted out of player:emoted skip it.
+func (p *serverToClientPublication) BroadcastPlayerEmoted(room *game.Room, data playerEmotedData) error {
+	return p.broadcastToRoom(room, "player:emoted", data)
+}
+
+// BroadcastChatPosted sends a chat message to room, or to its sender's team
+// on the team channel, skipping players who muted its sender
+func (p *serverToClientPublication) BroadcastChatPosted(room *game.Room, data chatPostedData) error {
+	msgBytes, err := p.builder.Build("chat:posted", data)
+	if err != nil {
+		return err
+	}
+
+	room.BroadcastChat(msgBytes, data.PlayerID, data.Channel == chatChannelTeam)
+	return nil
+}
+
+// BroadcastVoteKickProgress tells room how a vote to kick one of its players
+// stands, the target included
+func (p *serverToClientPublication) BroadcastVoteKickProgress(room *game.Room, data voteKickProgressData) error {
+	return p.broadcastToRoom(room, "room:votekick_progress", data)
+}
+
+// SendPlayerLevelUp tells a player the level their XP just reached and what
+// it unlocked
+func (p *serverToClientPublication) SendPlayerLevelUp(data playerLevelUpData) error {
+	return p.sendToPlayerID(data.PlayerID, "player:level_up", data)
+}
+
+// SendLeaderboardUpdate sends the top of the recomputed leaderboard to every
+// given player, building the message once. Players it could not reach are
+// skipped and reported in the joined error.
+func (p *serverToClientPublication) SendLeaderboardUpdate(players []*game.Player, data leaderboardUpdateData) error {
+	msgBytes, err := p.builder.Build("leaderboard:update", data)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, player := range players {
+		if err := p.sendDirect(player, msgBytes); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// BroadcastTeamPing routes a player's tactical ping to their team in room
+func (p *serverToClientPublication) BroadcastTeamPing(room *game.Room, playerID, pingType string, position game.Vector2) error {
+	msgBytes, err := p.builder.Build("team:ping", map[string]interface{}{
+		"playerId": playerID,
+		"type":     pingType,
+		"position": map[string]interface{}{"x": position.X, "y": position.Y},
+	})
+	if err != nil {
+		return err
+	}
+
+	room.BroadcastTeam("team:ping", msgBytes, playerID)
+	return nil
+}
+
 func (p *serverToClientPublication) BroadcastPlayerDeath(room *game.Room, data playerDeathData) error {
 	return p.broadcastToRoom(room, "player:death", data)
 }
@@ -159,6 +643,18 @@ func (p *serverToClientPublication) BroadcastPlayerKillCredit(room *game.Room, d
 	return p.broadcastToRoom(room, "player:kill_credit", data)
 }
 
+func (p *serverToClientPublication) BroadcastPlayerKillstreak(room *game.Room, data playerKillstreakData) error {
+	return p.broadcastToRoom(room, "player:killstreak", data)
+}
+
+func (p *serverToClientPublication) BroadcastPlayerAssistCredit(room *game.Room, data playerAssistCreditData) error {
+	return p.broadcastToRoom(room, "player:assist_credit", data)
+}
+
+func (p *serverToClientPublication) BroadcastEntityRemoved(room *game.Room, data entityRemovedData) error {
+	return p.broadcastToRoom(room, "entity:removed", data)
+}
+
 func (p *serverToClientPublication) BroadcastPlayerRespawn(room *game.Room, data playerRespawnData) error {
 	return p.broadcastToRoom(room, "player:respawn", data)
 }
@@ -167,10 +663,139 @@ func (p *serverToClientPublication) SendWeaponState(playerID string, data weapon
 	return p.sendToPlayerID(playerID, "weapon:state", data)
 }
 
+// SendLobbySandboxState sends a waiting player the state of its lobby sandbox
+func (p *serverToClientPublication) SendLobbySandboxState(playerID string, state game.LobbySandboxState) error {
+	projectiles := make([]map[string]interface{}, 0, len(state.Projectiles))
+	for _, projectile := range state.Projectiles {
+		projectiles = append(projectiles, map[string]interface{}{
+			"id":       projectile.ID,
+			"position": map[string]interface{}{"x": projectile.Position.X, "y": projectile.Position.Y},
+			"velocity": map[string]interface{}{"x": projectile.Velocity.X, "y": projectile.Velocity.Y},
+		})
+	}
+	return p.sendToPlayerID(playerID, "lobby:sandbox_state", map[string]interface{}{
+		"width":  game.LobbySandboxWidth,
+		"height": game.LobbySandboxHeight,
+		"player": map[string]interface{}{
+			"position": map[string]interface{}{"x": state.Player.Position.X, "y": state.Player.Position.Y},
+			"velocity": map[string]interface{}{"x": state.Player.Velocity.X, "y": state.Player.Velocity.Y},
+			"aimAngle": state.Player.AimAngle,
+		},
+		"dummy": map[string]interface{}{
+			"position": map[string]interface{}{"x": state.Dummy.Position.X, "y": state.Dummy.Position.Y},
+			"health":   state.Dummy.Health,
+		},
+		"projectiles": projectiles,
+		"hits":        state.Hits,
+		"knockdowns":  state.Knockdowns,
+	})
+}
+
+// SendQueueStatus tells a player in the matchmaking queue its position and
+// estimated wait
+func (p *serverToClientPublication) SendQueueStatus(status game.QueueStatus) error {
+	data := map[string]interface{}{
+		"position":      status.Position,
+		"playersOnline": status.PlayersOnline,
+	}
+	if status.WaitKnown {
+		data["estimatedWaitMs"] = status.EstimatedWait.Milliseconds()
+	}
+
+	msgBytes, err := p.builder.Build("queue:status", data)
+	if err != nil {
+		return err
+	}
+
+	return p.sendDirect(status.Player, msgBytes)
+}
+
+// SendSpawnOptions tells a dead player where they can respawn and how
+// dangerous each spawn point is
+func (p *serverToClientPublication) SendSpawnOptions(player *game.Player, options []game.SpawnOption) error {
+	points := make([]map[string]interface{}, 0, len(options))
+	for _, option := range options {
+		points = append(points, map[string]interface{}{
+			"index":    option.Index,
+			"position": map[string]interface{}{"x": option.Position.X, "y": option.Position.Y},
+			"danger":   option.Danger,
+			"allowed":  option.Allowed,
+		})
+	}
+
+	msgBytes, err := p.builder.Build("spawn:options", map[string]interface{}{"points": points})
+	if err != nil {
+		return err
+	}
+
+	return p.sendDirect(player, msgBytes)
+}
+
+// BroadcastPracticeStatus tells a practice room how hard its bots play and
+// how its player is doing against them
+func (p *serverToClientPublication) BroadcastPracticeStatus(room *game.Room) error {
+	difficulty := room.Practice.Difficulty()
+	return p.broadcastToRoom(room, "practice:status", map[string]interface{}{
+		"difficulty":   difficulty.Name,
+		"accuracy":     difficulty.Accuracy,
+		"reactionTime": difficulty.ReactionTime,
+		"kd":           room.Practice.KD(),
+	})
+}
+
+// BroadcastMatchRestarted tells a room that its rematch vote passed and the
+// match started again with players
+func (p *serverToClientPublication) BroadcastMatchRestarted(room *game.Room, players []*game.Player) error {
+	data := matchRestartedData{RoomID: room.ID, PlayerIDs: make([]string, 0, len(players))}
+	for _, player := range players {
+		data.PlayerIDs = append(data.PlayerIDs, player.ID)
+	}
+	return p.broadcastToRoom(room, "match:restarted", data)
+}
+
 func (p *serverToClientPublication) BroadcastMatchEnded(room *game.Room, data matchEndedData) error {
 	return p.broadcastToRoom(room, "match:ended", data)
 }
 
+// SendWorldSync sends a player the authoritative score state of its room's
+// match, so a late joiner or resumed session does not depend on the kill
+// events it missed
+func (p *serverToClientPublication) SendWorldSync(player *game.Player, match *game.Match, world *game.World) error {
+	msgBytes, err := p.builder.Build("world:sync", worldSyncData{
+		MatchState:       string(match.GetState()),
+		RemainingSeconds: match.GetRemainingSeconds(),
+		KillTarget:       match.Config.KillTarget,
+		PlayerKills:      match.KillMap(),
+		Scoreboard:       match.Scoreboard(world),
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.sendDirect(player, msgBytes)
+}
+
+// SendMapLoad sends a player the geometry of the map the server collides
+// players and projectiles against
+func (p *serverToClientPublication) SendMapLoad(player *game.Player, mapConfig game.MapConfig) error {
+	obstacles := mapConfig.Obstacles
+	if obstacles == nil {
+		obstacles = []game.MapObstacle{}
+	}
+	msgBytes, err := p.builder.Build("map:load", mapLoadData{
+		MapID:     mapConfig.ID,
+		Name:      mapConfig.Name,
+		Width:     mapConfig.Width,
+		Height:    mapConfig.Height,
+		Obstacles: obstacles,
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.sendDirect(player, msgBytes)
+}
+
 func (p *serverToClientPublication) buildSessionStatusData(player *game.Player, room *game.Room, state game.SessionStatusState) sessionStatusData {
 	data := sessionStatusData{
 		State:       string(state),
@@ -179,6 +804,9 @@ func (p *serverToClientPublication) buildSessionStatusData(player *game.Player,
 		JoinMode:    string(game.RoomKindPublic),
 		MinPlayers:  game.MinPlayersToStart,
 	}
+	if p.roomManager != nil {
+		data.MinPlayers = p.roomManager.RoomSettings().MinHumanPlayers
+	}
 
 	if room == nil {
 		return data
@@ -198,19 +826,11 @@ func (p *serverToClientPublication) buildSessionStatusData(player *game.Player,
 	return data
 }
 
-func (p *serverToClientPublication) sendDirect(player *game.Player, msgBytes []byte) (err error) {
-	defer func() {
-		if rec := recover(); rec != nil {
-			err = fmt.Errorf("send direct to player %s: channel closed", player.ID)
-		}
-	}()
-
-	select {
-	case player.SendChan <- msgBytes:
-		return nil
-	default:
-		return fmt.Errorf("send direct to player %s: channel full", player.ID)
+func (p *serverToClientPublication) sendDirect(player *game.Player, msgBytes []byte) error {
+	if err := player.Send(msgBytes); err != nil {
+		return fmt.Errorf("send direct to player %s: %w", player.ID, err)
 	}
+	return nil
 }
 
 func (p *serverToClientPublication) sendToPlayerID(playerID, messageType string, data any) error {
@@ -232,6 +852,6 @@ func (p *serverToClientPublication) broadcastToRoom(room *game.Room, messageType
 		return err
 	}
 
-	room.Broadcast(msgBytes, "")
+	room.BroadcastType(messageType, msgBytes, "")
 	return nil
 }
@@ -190,6 +190,135 @@ func TestServerToClientPublicationPublishesPlayerLeftAndDirectErrors(t *testing.
 	}
 }
 
+func TestServerToClientPublicationPublishesPlayerJoinedToExistingRoster(t *testing.T) {
+	builder := &stubEnvelopeBuilder{timestamp: 6060}
+	publication := newServerToClientPublication(builder, game.NewRoomManager())
+
+	existing := game.NewPlayer("existing", make(chan []byte, 2))
+	joiner := game.NewPlayer("joiner", make(chan []byte, 2))
+	joiner.DisplayName = "Newcomer"
+	room := game.NewTypedRoom(game.RoomKindCode, "JOIN")
+	room.Teams = true
+	require.NoError(t, room.AddPlayer(existing))
+	require.NoError(t, room.AddPlayer(joiner))
+
+	require.NoError(t, publication.PublishPlayerJoined(room, joiner))
+
+	var msg Message
+	select {
+	case msgBytes := <-existing.SendChan:
+		require.NoError(t, json.Unmarshal(msgBytes, &msg))
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for player:joined")
+	}
+
+	assert.Equal(t, "player:joined", msg.Type)
+	data, ok := msg.Data.(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, joiner.ID, data["playerId"])
+	assert.Equal(t, "Newcomer", data["displayName"])
+	assert.Equal(t, float64(2), data["rosterSize"])
+	assert.Equal(t, map[string]any{"id": game.TeamBravo, "paletteIndex": float64(4)}, data["team"])
+	assert.Empty(t, joiner.SendChan, "joining player should not receive their own player:joined")
+}
+
+func TestServerToClientPublicationSendsRoomRoster(t *testing.T) {
+	builder := &stubEnvelopeBuilder{timestamp: 7070}
+	publication := newServerToClientPublication(builder, game.NewRoomManager())
+
+	requester := game.NewPlayer("requester", make(chan []byte, 2))
+	other := game.NewPlayer("other", make(chan []byte, 2))
+	other.DisplayName = "Other"
+	other.Ready = true
+	other.Team = game.TeamBravo
+	room := game.NewTypedRoom(game.RoomKindCode, "ROSTER")
+	require.NoError(t, room.AddPlayer(requester))
+	require.NoError(t, room.AddPlayer(other))
+
+	require.NoError(t, publication.SendRoomRoster(requester, room))
+	require.Len(t, builder.buildCalls, 1)
+	assert.Equal(t, "room:roster", builder.buildCalls[0].messageType)
+	data, ok := builder.buildCalls[0].data.(roomRosterData)
+	require.True(t, ok)
+	assert.Equal(t, room.ID, data.RoomID)
+	assert.Equal(t, "ROSTER", data.Code)
+	assert.Equal(t, []rosterPlayerData{
+		{PlayerID: "requester", DisplayName: game.FallbackDisplayName},
+		{PlayerID: "other", DisplayName: "Other", Team: &teamData{ID: game.TeamBravo, PaletteIndex: 4}, Ready: true},
+	}, data.Players)
+	assert.Len(t, requester.SendChan, 1)
+	assert.Empty(t, other.SendChan, "roster replies go only to the requester")
+
+	queued := game.NewPlayer("queued", make(chan []byte, 1))
+	require.NoError(t, publication.SendRoomRoster(queued, nil))
+	queuedData, ok := builder.buildCalls[1].data.(roomRosterData)
+	require.True(t, ok)
+	assert.Empty(t, queuedData.RoomID)
+	assert.Equal(t, []rosterPlayerData{{PlayerID: "queued", DisplayName: game.FallbackDisplayName}}, queuedData.Players)
+}
+
+func TestServerToClientPublicationPublishesReadyState(t *testing.T) {
+	builder := &stubEnvelopeBuilder{timestamp: 8080}
+	publication := newServerToClientPublication(builder, game.NewRoomManager())
+
+	player1 := game.NewPlayer("player-1", make(chan []byte, 1))
+	player2 := game.NewPlayer("player-2", make(chan []byte, 1))
+	room := game.NewTypedRoom(game.RoomKindPublic, "")
+	require.NoError(t, room.AddPlayer(player1))
+	require.NoError(t, room.AddPlayer(player2))
+
+	require.NoError(t, publication.PublishReadyState(room, game.ReadyCheckState{
+		RoomID:           room.ID,
+		ReadyPlayerIDs:   []string{player1.ID},
+		ReadyCount:       1,
+		RequiredCount:    2,
+		TotalPlayers:     2,
+		RemainingSeconds: 9,
+		Active:           true,
+	}))
+
+	require.Len(t, builder.buildCalls, 1)
+	assert.Equal(t, "room:ready_state", builder.buildCalls[0].messageType)
+	assert.Equal(t, roomReadyStateData{
+		RoomID:           room.ID,
+		ReadyPlayerIDs:   []string{player1.ID},
+		ReadyCount:       1,
+		RequiredCount:    2,
+		TotalPlayers:     2,
+		RemainingSeconds: 9,
+		Active:           true,
+	}, builder.buildCalls[0].data)
+	assert.Len(t, player1.SendChan, 1)
+	assert.Len(t, player2.SendChan, 1)
+}
+
+func TestServerToClientPublicationPublishesPartyState(t *testing.T) {
+	builder := &stubEnvelopeBuilder{timestamp: 8080}
+	publication := newServerToClientPublication(builder, game.NewRoomManager())
+
+	player1 := game.NewPlayer("player-1", make(chan []byte, 1))
+	player2 := game.NewPlayer("player-2", make(chan []byte, 1))
+	room := game.NewTypedRoom(game.RoomKindCode, "PARTY")
+	require.NoError(t, room.AddPlayer(player1))
+	require.NoError(t, room.AddPlayer(player2))
+
+	require.NoError(t, publication.PublishPartyState(room, game.PartyState{
+		RoomID:           room.ID,
+		StayingPlayerIDs: []string{player2.ID},
+		TotalPlayers:     2,
+	}))
+
+	require.Len(t, builder.buildCalls, 1)
+	assert.Equal(t, "party:state", builder.buildCalls[0].messageType)
+	assert.Equal(t, partyStateData{
+		RoomID:           room.ID,
+		StayingPlayerIDs: []string{player2.ID},
+		TotalPlayers:     2,
+	}, builder.buildCalls[0].data)
+	assert.Len(t, player1.SendChan, 1)
+	assert.Len(t, player2.SendChan, 1)
+}
+
 func TestServerToClientPublicationPublishesGameplayEvents(t *testing.T) {
 	builder := &stubEnvelopeBuilder{timestamp: 8080}
 	roomManager := game.NewRoomManager()
@@ -278,3 +407,18 @@ func TestServerToClientPublicationReturnsBuilderErrors(t *testing.T) {
 	default:
 	}
 }
+
+func TestServerToClientPublicationSendsCapacityNotice(t *testing.T) {
+	builder := &stubEnvelopeBuilder{timestamp: 8080}
+	publication := newServerToClientPublication(builder, game.NewRoomManager())
+	player := game.NewPlayer("overflow", make(chan []byte, 2))
+
+	require.NoError(t, publication.SendCapacityNotice(player, 3, ""))
+	require.NoError(t, publication.SendCapacityNotice(player, 0, "wss://eu-2.example/ws"))
+
+	require.Len(t, builder.buildCalls, 2)
+	assert.Equal(t, "session:capacity", builder.buildCalls[0].messageType)
+	assert.Equal(t, sessionCapacityData{QueuePosition: 3}, builder.buildCalls[0].data)
+	assert.Equal(t, sessionCapacityData{RedirectURL: "wss://eu-2.example/ws"}, builder.buildCalls[1].data)
+	assert.Len(t, player.SendChan, 2)
+}
@@ -0,0 +1,35 @@
+package network
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+// queueStatusLoop tells queued players where they stand every
+// game.QueueStatusInterval
+func (h *WebSocketHandler) queueStatusLoop(ctx context.Context) {
+	ticker := time.NewTicker(game.QueueStatusInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.sendQueueStatuses()
+		}
+	}
+}
+
+// sendQueueStatuses sends queue:status to every player in the public
+// matchmaking queue
+func (h *WebSocketHandler) sendQueueStatuses() {
+	for _, status := range h.roomManager.QueueStatuses(time.Now()) {
+		if err := h.publication.SendQueueStatus(status); err != nil {
+			log.Printf("Failed to send queue status to %s: %v", status.Player.ID, err)
+		}
+	}
+}
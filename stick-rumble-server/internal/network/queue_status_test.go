@@ -0,0 +1,28 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueuedPlayersReceiveQueueStatus(t *testing.T) {
+	withSchemaValidation(t)
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn := ts.connectClient(t)
+	defer conn.Close()
+	_, _, err := readSessionStatus(t, conn, "searching_for_match", 2*time.Second)
+	require.NoError(t, err)
+
+	ts.handler.sendQueueStatuses()
+	msg, err := readMessageOfType(t, conn, "queue:status", 2*time.Second)
+	require.NoError(t, err)
+	data := msg.Data.(map[string]interface{})
+	assert.Equal(t, float64(1), data["position"])
+	assert.Equal(t, float64(1), data["playersOnline"])
+	assert.NotContains(t, data, "estimatedWaitMs", "a fresh server has no fill rate to estimate from")
+}
@@ -0,0 +1,65 @@
+package network
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+// roomListing is one room in GET /rooms
+type roomListing struct {
+	ID             string    `json:"id"`
+	Mode           string    `json:"mode"`           // Join mode of player:hello: "public" or "code"
+	Code           string    `json:"code,omitempty"` // Named rooms only
+	MapID          string    `json:"mapId"`
+	Players        int       `json:"players"`
+	MaxPlayers     int       `json:"maxPlayers"`
+	MatchState     string    `json:"matchState"`
+	ElapsedSeconds int       `json:"elapsedSeconds"` // Simulation time the match has run
+	Joinable       bool      `json:"joinable"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// HandleRoomList serves the room browser of the shared global handler
+func HandleRoomList(w http.ResponseWriter, r *http.Request) {
+	getGlobalHandler().HandleRoomList(w, r)
+}
+
+// HandleRoomList serves the public and named rooms as JSON, oldest first, for
+// a lobby's server browser. Practice rooms are solo and are not listed.
+func (h *WebSocketHandler) HandleRoomList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	rooms := make([]roomListing, 0)
+	for _, room := range h.roomManager.GetAllRooms() {
+		if room.Kind == game.RoomKindPractice {
+			continue
+		}
+		rooms = append(rooms, roomListing{
+			ID:             room.ID,
+			Mode:           string(room.Kind),
+			Code:           room.Code,
+			MapID:          room.MapID,
+			Players:        room.PlayerCount(),
+			MaxPlayers:     room.MaxPlayers,
+			MatchState:     string(room.Match.GetState()),
+			ElapsedSeconds: int(room.Match.Elapsed().Seconds()),
+			Joinable:       room.AcceptsJoins(),
+			CreatedAt:      room.CreatedAt,
+		})
+	}
+	sort.Slice(rooms, func(i, j int) bool { return rooms[i].CreatedAt.Before(rooms[j].CreatedAt) })
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(rooms); err != nil {
+		log.Printf("Failed to encode %s response: %v", r.URL.Path, err)
+	}
+}
@@ -0,0 +1,66 @@
+package network
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func listRooms(t *testing.T, ts *testServer) []roomListing {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	ts.handler.HandleRoomList(rec, httptest.NewRequest(http.MethodGet, "/rooms", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var rooms []roomListing
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rooms))
+	return rooms
+}
+
+func TestRoomListShowsOpenRooms(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	assert.Empty(t, listRooms(t, ts))
+
+	conns, _, roomID := joinCodeRoom(t, ts, "BROWSE")
+	for _, conn := range conns {
+		defer conn.Close()
+	}
+	solo := ts.connectRawClient(t)
+	defer solo.Close()
+	sendHelloMessage(t, solo, "Solo", "practice", "")
+	_, _, err := readSessionStatus(t, solo, "match_ready", 2*time.Second)
+	require.NoError(t, err)
+
+	rooms := listRooms(t, ts)
+	require.Len(t, rooms, 1, "practice rooms are not listed")
+	assert.Equal(t, roomID, rooms[0].ID)
+	assert.Equal(t, "code", rooms[0].Mode)
+	assert.Equal(t, "BROWSE", rooms[0].Code)
+	assert.Equal(t, game.DefaultMapID, rooms[0].MapID)
+	assert.Equal(t, 2, rooms[0].Players)
+	assert.Equal(t, string(game.MatchStateWaiting), rooms[0].MatchState)
+	assert.Zero(t, rooms[0].ElapsedSeconds)
+	assert.True(t, rooms[0].Joinable, "a named room takes players until it is full")
+
+	room := ts.handler.roomManager.GetRoom(roomID)
+	room.Match.EndMatch("test")
+	assert.False(t, listRooms(t, ts)[0].Joinable, "an ended match takes nobody")
+}
+
+func TestRoomListRejectsOtherMethods(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	rec := httptest.NewRecorder()
+	ts.handler.HandleRoomList(rec, httptest.NewRequest(http.MethodPost, "/rooms", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, http.MethodGet, rec.Header().Get("Allow"))
+}
@@ -0,0 +1,88 @@
+package network
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/mtomcal/stick-rumble-server/internal/scripting"
+)
+
+// roomScriptHost carries out a custom mode script's actions for one room.
+// Scripts run inside gameplay hooks and room creation, where the game loop or
+// room manager may hold locks, so anything that broadcasts is queued and
+// applied on the next match tick.
+type roomScriptHost struct {
+	handler *WebSocketHandler
+	room    *game.Room
+}
+
+func (h *WebSocketHandler) roomScriptHost(room *game.Room) scripting.Host {
+	return &roomScriptHost{handler: h, room: room}
+}
+
+func (s *roomScriptHost) SpawnWeaponCrate(weaponType string, position game.Vector2) (string, error) {
+	crate, err := s.handler.gameServer.GetWeaponCrateManager().SpawnCrate(weaponType, position)
+	if err != nil {
+		return "", err
+	}
+
+	spawned := *crate
+	s.handler.queueScriptAction(func() {
+		s.handler.broadcastSpawnedCrate(&spawned)
+	})
+	return crate.ID, nil
+}
+
+func (s *roomScriptHost) EndMatch(reason string) {
+	s.room.Match.RequestEnd(reason)
+}
+
+func (h *WebSocketHandler) queueScriptAction(action func()) {
+	h.scriptActionsMu.Lock()
+	defer h.scriptActionsMu.Unlock()
+
+	h.scriptActions = append(h.scriptActions, action)
+}
+
+// runScriptActions applies queued script actions; called from the match tick.
+func (h *WebSocketHandler) runScriptActions() {
+	h.scriptActionsMu.Lock()
+	actions := h.scriptActions
+	h.scriptActions = nil
+	h.scriptActionsMu.Unlock()
+
+	for _, action := range actions {
+		action()
+	}
+}
+
+// broadcastSpawnedCrate announces a crate added at runtime. Crates are shared
+// by every room, so all connected players learn about it.
+func (h *WebSocketHandler) broadcastSpawnedCrate(crate *game.WeaponCrate) {
+	data := map[string]interface{}{
+		"crates": []map[string]interface{}{{
+			"id":          crate.ID,
+			"position":    map[string]interface{}{"x": crate.Position.X, "y": crate.Position.Y},
+			"weaponType":  crate.WeaponType,
+			"isAvailable": crate.IsAvailable,
+		}},
+	}
+
+	if err := h.validateOutgoingMessage("weapon:spawned", data); err != nil {
+		log.Printf("Schema validation failed for weapon:spawned: %v", err)
+	}
+
+	msgBytes, err := json.Marshal(Message{
+		Type:      "weapon:spawned",
+		Timestamp: time.Now().UnixMilli(),
+		Data:      data,
+	})
+	if err != nil {
+		log.Printf("Error marshaling weapon:spawned message: %v", err)
+		return
+	}
+
+	h.roomManager.BroadcastToAll(msgBytes)
+}
@@ -0,0 +1,333 @@
+package network
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Session recordings capture every message a watched player or room sends and
+// receives, for anti-cheat review. They are toggled at runtime per target and
+// run independently of any global recording setting.
+const (
+	sessionRecordingFormat  = "stick-rumble-session-recording"
+	sessionRecordingVersion = 1
+
+	RecordingTargetPlayer RecordingTargetKind = "player"
+	RecordingTargetRoom   RecordingTargetKind = "room"
+)
+
+var (
+	ErrRecordingTargetInvalid = errors.New("recording target must be a player or room with an alphanumeric ID")
+	ErrRecordingAlreadyActive = errors.New("recording already active for target")
+	ErrRecordingNotActive     = errors.New("no active recording for target")
+	ErrRecordingFormat        = errors.New("not a session recording")
+
+	recordingIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+)
+
+type RecordingTargetKind string
+
+// RecordingTarget names the player or room a session recording watches.
+type RecordingTarget struct {
+	Kind RecordingTargetKind `json:"kind"`
+	ID   string              `json:"id"`
+}
+
+// SessionRecordingInfo describes an active session recording.
+type SessionRecordingInfo struct {
+	Target    RecordingTarget
+	Path      string
+	StartedAt time.Time
+	Records   int
+}
+
+// sessionRecordingHeader is the first line of every recording file.
+type sessionRecordingHeader struct {
+	Format    string          `json:"format"`
+	Version   int             `json:"version"`
+	Target    RecordingTarget `json:"target"`
+	StartedAt int64           `json:"startedAt"`
+}
+
+// SessionRecordingEntry is one recorded message. Direction is "in" for
+// client-to-server input and "out" for server-to-client events.
+type SessionRecordingEntry struct {
+	Timestamp int64           `json:"t"`
+	Direction string          `json:"dir"`
+	PlayerID  string          `json:"playerId"`
+	RoomID    string          `json:"roomId,omitempty"`
+	Message   json.RawMessage `json:"message"`
+}
+
+type sessionRecording struct {
+	info    SessionRecordingInfo
+	file    *os.File
+	encoder *json.Encoder
+	mu      sync.Mutex
+}
+
+// sessionRecorder holds the active recordings. The active counter lets the
+// per-message hooks skip all lookups while nothing is being recorded.
+type sessionRecorder struct {
+	dir        string
+	now        func() time.Time
+	active     atomic.Int32
+	recordings map[RecordingTarget]*sessionRecording
+	mu         sync.RWMutex
+}
+
+func newSessionRecorder(dir string, now func() time.Time) *sessionRecorder {
+	if dir == "" {
+		dir = "recordings"
+	}
+	return &sessionRecorder{
+		dir:        dir,
+		now:        now,
+		recordings: make(map[RecordingTarget]*sessionRecording),
+	}
+}
+
+func (r *sessionRecorder) enabled() bool {
+	return r.active.Load() > 0
+}
+
+func (r *sessionRecorder) hasRoomTargets() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for target := range r.recordings {
+		if target.Kind == RecordingTargetRoom {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *sessionRecorder) start(target RecordingTarget) (SessionRecordingInfo, error) {
+	if (target.Kind != RecordingTargetPlayer && target.Kind != RecordingTargetRoom) || !recordingIDPattern.MatchString(target.ID) {
+		return SessionRecordingInfo{}, ErrRecordingTargetInvalid
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.recordings[target]; exists {
+		return SessionRecordingInfo{}, ErrRecordingAlreadyActive
+	}
+
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return SessionRecordingInfo{}, fmt.Errorf("create recording directory: %w", err)
+	}
+
+	startedAt := r.now()
+	path := filepath.Join(r.dir, fmt.Sprintf("%s-%s-%d.jsonl", target.Kind, target.ID, startedAt.UnixMilli()))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return SessionRecordingInfo{}, fmt.Errorf("create recording file: %w", err)
+	}
+
+	recording := &sessionRecording{
+		info:    SessionRecordingInfo{Target: target, Path: path, StartedAt: startedAt},
+		file:    file,
+		encoder: json.NewEncoder(file),
+	}
+	header := sessionRecordingHeader{
+		Format:    sessionRecordingFormat,
+		Version:   sessionRecordingVersion,
+		Target:    target,
+		StartedAt: startedAt.UnixMilli(),
+	}
+	if err := recording.encoder.Encode(header); err != nil {
+		_ = file.Close()
+		return SessionRecordingInfo{}, fmt.Errorf("write recording header: %w", err)
+	}
+
+	r.recordings[target] = recording
+	r.active.Add(1)
+	log.Printf("Session recording started for %s %s: %s", target.Kind, target.ID, path)
+	return recording.info, nil
+}
+
+func (r *sessionRecorder) stop(target RecordingTarget) (SessionRecordingInfo, error) {
+	r.mu.Lock()
+	recording, exists := r.recordings[target]
+	if exists {
+		delete(r.recordings, target)
+		r.active.Add(-1)
+	}
+	r.mu.Unlock()
+
+	if !exists {
+		return SessionRecordingInfo{}, ErrRecordingNotActive
+	}
+
+	info := recording.close()
+	log.Printf("Session recording stopped for %s %s: %d records", target.Kind, target.ID, info.Records)
+	return info, nil
+}
+
+func (r *sessionRecorder) stopAll() {
+	r.mu.Lock()
+	recordings := r.recordings
+	r.recordings = make(map[RecordingTarget]*sessionRecording)
+	r.active.Store(0)
+	r.mu.Unlock()
+
+	for _, recording := range recordings {
+		recording.close()
+	}
+}
+
+func (r *sessionRecorder) list() []SessionRecordingInfo {
+	r.mu.RLock()
+	infos := make([]SessionRecordingInfo, 0, len(r.recordings))
+	for _, recording := range r.recordings {
+		recording.mu.Lock()
+		infos = append(infos, recording.info)
+		recording.mu.Unlock()
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].StartedAt.Before(infos[j].StartedAt)
+	})
+	return infos
+}
+
+// record appends message to every recording watching the player or its room.
+func (r *sessionRecorder) record(direction, playerID, roomID string, message []byte) {
+	if !json.Valid(message) {
+		return
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry := SessionRecordingEntry{
+		Timestamp: r.now().UnixMilli(),
+		Direction: direction,
+		PlayerID:  playerID,
+		RoomID:    roomID,
+		Message:   message,
+	}
+	if recording, ok := r.recordings[RecordingTarget{Kind: RecordingTargetPlayer, ID: playerID}]; ok {
+		recording.write(entry)
+	}
+	if roomID == "" {
+		return
+	}
+	if recording, ok := r.recordings[RecordingTarget{Kind: RecordingTargetRoom, ID: roomID}]; ok {
+		recording.write(entry)
+	}
+}
+
+func (s *sessionRecording) write(entry SessionRecordingEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return
+	}
+	if err := s.encoder.Encode(entry); err != nil {
+		log.Printf("Session recording write failed for %s %s: %v", s.info.Target.Kind, s.info.Target.ID, err)
+		return
+	}
+	s.info.Records++
+}
+
+func (s *sessionRecording) close() SessionRecordingInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file != nil {
+		if err := s.file.Close(); err != nil {
+			log.Printf("Session recording close failed for %s: %v", s.info.Path, err)
+		}
+		s.file = nil
+	}
+	return s.info
+}
+
+// recordSessionMessage is the per-message hook used by the connection read
+// and write loops.
+func (h *WebSocketHandler) recordSessionMessage(direction, playerID string, message []byte) {
+	if !h.recorder.enabled() {
+		return
+	}
+
+	roomID := ""
+	if h.recorder.hasRoomTargets() {
+		if room := h.roomManager.GetRoomByPlayerID(playerID); room != nil {
+			roomID = room.ID
+		}
+	}
+	h.recorder.record(direction, playerID, roomID, message)
+}
+
+// StartSessionRecording begins recording all input and events for a player
+// or room, returning where the recording is written.
+func (h *WebSocketHandler) StartSessionRecording(target RecordingTarget) (SessionRecordingInfo, error) {
+	return h.recorder.start(target)
+}
+
+// StopSessionRecording ends a recording and returns its final summary.
+func (h *WebSocketHandler) StopSessionRecording(target RecordingTarget) (SessionRecordingInfo, error) {
+	return h.recorder.stop(target)
+}
+
+// ActiveSessionRecordings lists recordings in progress, oldest first.
+func (h *WebSocketHandler) ActiveSessionRecordings() []SessionRecordingInfo {
+	return h.recorder.list()
+}
+
+// SessionRecordingReader reads back a recording file one entry at a time, for
+// offline tools such as cmd/replaytool.
+type SessionRecordingReader struct {
+	Target    RecordingTarget
+	StartedAt time.Time
+	decoder   *json.Decoder
+}
+
+// NewSessionRecordingReader reads and checks the recording header. Returns
+// ErrRecordingFormat if r does not start with one this build can read.
+func NewSessionRecordingReader(r io.Reader) (*SessionRecordingReader, error) {
+	decoder := json.NewDecoder(r)
+	var header sessionRecordingHeader
+	if err := decoder.Decode(&header); err != nil {
+		return nil, fmt.Errorf("%w: read header: %v", ErrRecordingFormat, err)
+	}
+	if header.Format != sessionRecordingFormat {
+		return nil, fmt.Errorf("%w: format %q", ErrRecordingFormat, header.Format)
+	}
+	if header.Version != sessionRecordingVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrRecordingFormat, header.Version)
+	}
+
+	return &SessionRecordingReader{
+		Target:    header.Target,
+		StartedAt: time.UnixMilli(header.StartedAt),
+		decoder:   decoder,
+	}, nil
+}
+
+// Next returns the next recorded message, or io.EOF after the last one
+func (r *SessionRecordingReader) Next() (SessionRecordingEntry, error) {
+	var entry SessionRecordingEntry
+	if err := r.decoder.Decode(&entry); err != nil {
+		if errors.Is(err, io.EOF) {
+			return SessionRecordingEntry{}, io.EOF
+		}
+		return SessionRecordingEntry{}, fmt.Errorf("read recording entry: %w", err)
+	}
+	return entry, nil
+}
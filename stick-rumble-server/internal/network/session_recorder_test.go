@@ -0,0 +1,155 @@
+package network
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readRecordingLines(t *testing.T, path string) (*SessionRecordingReader, []SessionRecordingEntry) {
+	t.Helper()
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	reader, err := NewSessionRecordingReader(file)
+	require.NoError(t, err, "recording should start with a header")
+
+	var entries []SessionRecordingEntry
+	for {
+		entry, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		entries = append(entries, entry)
+	}
+	return reader, entries
+}
+
+func TestSessionRecorderRecordsWatchedPlayerAndRoom(t *testing.T) {
+	recorder := newSessionRecorder(t.TempDir(), time.Now)
+	assert.False(t, recorder.enabled())
+
+	playerInfo, err := recorder.start(RecordingTarget{Kind: RecordingTargetPlayer, ID: "suspect"})
+	require.NoError(t, err)
+	roomInfo, err := recorder.start(RecordingTarget{Kind: RecordingTargetRoom, ID: "room-1"})
+	require.NoError(t, err)
+	assert.True(t, recorder.enabled())
+	assert.Len(t, recorder.list(), 2)
+
+	recorder.record("in", "suspect", "", []byte(`{"type":"input:state"}`))
+	recorder.record("out", "suspect", "room-1", []byte(`{"type":"match:timer"}`))
+	recorder.record("in", "bystander", "room-1", []byte(`{"type":"player:shoot"}`))
+	recorder.record("in", "stranger", "room-2", []byte(`{"type":"player:shoot"}`))
+	recorder.record("in", "suspect", "", []byte(`not json`))
+
+	playerInfo, err = recorder.stop(playerInfo.Target)
+	require.NoError(t, err)
+	roomInfo, err = recorder.stop(roomInfo.Target)
+	require.NoError(t, err)
+	assert.False(t, recorder.enabled())
+	assert.Equal(t, 2, playerInfo.Records)
+	assert.Equal(t, 2, roomInfo.Records)
+
+	reader, entries := readRecordingLines(t, playerInfo.Path)
+	assert.Equal(t, RecordingTarget{Kind: RecordingTargetPlayer, ID: "suspect"}, reader.Target)
+	assert.Equal(t, playerInfo.StartedAt.UnixMilli(), reader.StartedAt.UnixMilli())
+	require.Len(t, entries, 2)
+	assert.Equal(t, "in", entries[0].Direction)
+	assert.JSONEq(t, `{"type":"input:state"}`, string(entries[0].Message))
+
+	_, entries = readRecordingLines(t, roomInfo.Path)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "suspect", entries[0].PlayerID)
+	assert.Equal(t, "bystander", entries[1].PlayerID)
+}
+
+func TestSessionRecorderRejectsBadTargets(t *testing.T) {
+	recorder := newSessionRecorder(t.TempDir(), time.Now)
+
+	_, err := recorder.start(RecordingTarget{Kind: RecordingTargetPlayer, ID: "../etc"})
+	assert.ErrorIs(t, err, ErrRecordingTargetInvalid)
+	_, err = recorder.start(RecordingTarget{Kind: "match", ID: "abc"})
+	assert.ErrorIs(t, err, ErrRecordingTargetInvalid)
+
+	target := RecordingTarget{Kind: RecordingTargetRoom, ID: "abc"}
+	_, err = recorder.start(target)
+	require.NoError(t, err)
+	_, err = recorder.start(target)
+	assert.ErrorIs(t, err, ErrRecordingAlreadyActive)
+
+	recorder.stopAll()
+	_, err = recorder.stop(target)
+	assert.ErrorIs(t, err, ErrRecordingNotActive)
+}
+
+func TestSessionRecordingReaderRejectsOtherFiles(t *testing.T) {
+	for _, content := range []string{
+		``,
+		`{"type":"input:state"}`,
+		`{"format":"stick-rumble-session-recording","version":99}`,
+	} {
+		_, err := NewSessionRecordingReader(strings.NewReader(content))
+		assert.ErrorIs(t, err, ErrRecordingFormat, content)
+	}
+
+	reader, err := NewSessionRecordingReader(strings.NewReader(`{"format":"stick-rumble-session-recording","version":1}` + "\n" + `{"t":1,"dir":`))
+	require.NoError(t, err)
+	_, err = reader.Next()
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, io.EOF, "a truncated entry is not a clean end")
+}
+
+func TestSessionRecordingCapturesLiveRoomTraffic(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	ts.handler.recorder = newSessionRecorder(t.TempDir(), time.Now)
+
+	conn1 := ts.connectRawClient(t)
+	defer conn1.Close()
+	conn2 := ts.connectRawClient(t)
+	defer conn2.Close()
+	sendHelloMessage(t, conn1, "Alpha", "code", "WATCH")
+	sendHelloMessage(t, conn2, "Bravo", "code", "WATCH")
+
+	_, status, err := readSessionStatus(t, conn1, "match_ready", 2*time.Second)
+	require.NoError(t, err)
+	roomID, _ := status["roomId"].(string)
+	require.NotEmpty(t, roomID)
+
+	info, err := ts.handler.StartSessionRecording(RecordingTarget{Kind: RecordingTargetRoom, ID: roomID})
+	require.NoError(t, err)
+
+	sendMessage(t, conn1, Message{
+		Type:      "input:state",
+		Timestamp: time.Now().UnixMilli(),
+		Data:      map[string]any{"up": true, "down": false, "left": false, "right": false, "aimAngle": 0.0, "isSprinting": false},
+	})
+	sendMessage(t, conn1, Message{Type: "room:roster_request", Timestamp: time.Now().UnixMilli()})
+	_, err = readMessageOfType(t, conn1, "room:roster", 2*time.Second)
+	require.NoError(t, err)
+
+	info, err = ts.handler.StopSessionRecording(info.Target)
+	require.NoError(t, err)
+	assert.Empty(t, ts.handler.ActiveSessionRecordings())
+
+	_, entries := readRecordingLines(t, info.Path)
+	var sawInput, sawEvent bool
+	for _, entry := range entries {
+		assert.Equal(t, roomID, entry.RoomID)
+		var msg Message
+		require.NoError(t, json.Unmarshal(entry.Message, &msg))
+		sawInput = sawInput || (entry.Direction == "in" && msg.Type == "input:state")
+		sawEvent = sawEvent || (entry.Direction == "out" && msg.Type == "room:roster")
+	}
+	assert.True(t, sawInput, "input from a room member should be recorded")
+	assert.True(t, sawEvent, "events sent to room members should be recorded")
+}
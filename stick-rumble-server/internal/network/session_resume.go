@@ -0,0 +1,371 @@
+package network
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+// resumeTakeoverWait bounds how long a resuming client waits for the server to
+// release a connection it still considers open (e.g. before the pong deadline
+// notices a dropped WiFi link)
+const resumeTakeoverWait = 2 * time.Second
+
+// resumeSession ties a session token to the player it can re-bind to.
+type resumeSession struct {
+	player    *game.Player
+	closeConn func(reason disconnectReason) // Closes the live connection, sending reason's close frame if set; nil once released
+	boundAt   time.Time                     // When the live connection was bound to the player
+	released  chan struct{}                 // Closed when the connection is parked or forgotten
+	expiry    *time.Timer                   // Runs the deferred removal while parked
+	onExpire  func()                        // The deferred removal itself, for revoking a parked session
+}
+
+// sessionResumer issues session tokens and keeps disconnected players parked
+// for a grace period so a client that lost its connection can re-bind to its
+// existing player, room and game state instead of starting over.
+type sessionResumer struct {
+	grace    time.Duration
+	sessions map[string]*resumeSession // token -> session
+	mu       sync.Mutex
+}
+
+func newSessionResumer(grace time.Duration) *sessionResumer {
+	return &sessionResumer{
+		grace:    grace,
+		sessions: make(map[string]*resumeSession),
+	}
+}
+
+// enabled reports whether disconnected players are parked at all
+func (r *sessionResumer) enabled() bool {
+	return r.grace > 0
+}
+
+// issue registers a live connection for player and returns its session token
+func (r *sessionResumer) issue(player *game.Player, closeConn func(reason disconnectReason)) string {
+	token := newSessionToken()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sessions[token] = &resumeSession{
+		player:    player,
+		closeConn: closeConn,
+		boundAt:   time.Now(),
+		released:  make(chan struct{}),
+	}
+	return token
+}
+
+// park keeps a disconnected session resumable for the grace period; onExpire
+// runs if no client resumes it in time
+func (r *sessionResumer) park(token string, onExpire func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, ok := r.sessions[token]
+	if !ok {
+		go onExpire()
+		return
+	}
+
+	session.closeConn = nil
+	session.onExpire = onExpire
+	session.expiry = time.AfterFunc(r.grace, func() {
+		r.mu.Lock()
+		current, ok := r.sessions[token]
+		if ok && current == session {
+			delete(r.sessions, token)
+		}
+		r.mu.Unlock()
+
+		if ok && current == session {
+			onExpire()
+		}
+	})
+	close(session.released)
+}
+
+// forget drops a session whose connection ended without being parked
+func (r *sessionResumer) forget(token string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if session, ok := r.sessions[token]; ok {
+		delete(r.sessions, token)
+		close(session.released)
+	}
+}
+
+// revoke closes the live connection of a player's session with reason's close
+// frame and drops its token, so the disconnect removes the
+// player instead of parking it. A parked player is removed at once.
+func (r *sessionResumer) revoke(playerID string, reason disconnectReason) bool {
+	r.mu.Lock()
+	var remove func()
+	for token, session := range r.sessions {
+		if session.player.ID != playerID {
+			continue
+		}
+		if session.closeConn != nil {
+			closeConn := session.closeConn
+			remove = func() { closeConn(reason) }
+		} else if session.expiry != nil && session.expiry.Stop() {
+			remove = session.onExpire
+		}
+		if remove != nil {
+			delete(r.sessions, token)
+			break
+		}
+	}
+	r.mu.Unlock()
+
+	if remove == nil {
+		return false
+	}
+	remove()
+	return true
+}
+
+// drop closes a player's live connection without a close frame, the way a
+// lost link or a crashed host looks, keeping its token so the player is
+// parked. It returns a channel closed once the player is parked, or false if
+// the player has no live connection.
+func (r *sessionResumer) drop(playerID string) (<-chan struct{}, bool) {
+	r.mu.Lock()
+	var session *resumeSession
+	for _, candidate := range r.sessions {
+		if candidate.player.ID == playerID && candidate.closeConn != nil {
+			session = candidate
+			break
+		}
+	}
+	if session == nil {
+		r.mu.Unlock()
+		return nil, false
+	}
+	closeConn := session.closeConn
+	r.mu.Unlock()
+
+	closeConn(disconnectReason{})
+	return session.released, true
+}
+
+// connectedSince returns when a player's live connection was bound, or false
+// while it has none
+func (r *sessionResumer) connectedSince(playerID string) (time.Time, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, session := range r.sessions {
+		if session.player.ID == playerID && session.closeConn != nil {
+			return session.boundAt, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// playerID returns the ID of the player a token can resume
+func (r *sessionResumer) playerID(token string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, ok := r.sessions[token]
+	if !ok {
+		return "", false
+	}
+	return session.player.ID, true
+}
+
+// players returns the players of every session, connected or parked
+func (r *sessionResumer) players() []*game.Player {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	players := make([]*game.Player, 0, len(r.sessions))
+	for _, session := range r.sessions {
+		players = append(players, session.player)
+	}
+	return players
+}
+
+// resume re-binds a new connection to the player behind token and returns the
+// player and a fresh token; the old token is spent. A session whose connection
+// is still open is taken over: the old connection is closed and parked first.
+func (r *sessionResumer) resume(token string, closeConn func(reason disconnectReason)) (*game.Player, string, bool) {
+	return r.rebind(token, closeConn, disconnectReason{})
+}
+
+// replace re-binds a new connection to playerID's session like resume, for a
+// client that has no session token because it is another device or tab on the
+// same account. A live connection is closed with sessionReplacedReason.
+func (r *sessionResumer) replace(playerID string, closeConn func(reason disconnectReason)) (*game.Player, string, bool) {
+	r.mu.Lock()
+	token, found := "", false
+	for candidate, session := range r.sessions {
+		if session.player.ID == playerID {
+			token, found = candidate, true
+			break
+		}
+	}
+	r.mu.Unlock()
+
+	if !found {
+		return nil, "", false
+	}
+	return r.rebind(token, closeConn, sessionReplacedReason)
+}
+
+// rebind moves token's session to a new connection, closing a live one with
+// takeoverReason and waiting for it to be parked first
+func (r *sessionResumer) rebind(token string, closeConn func(reason disconnectReason), takeoverReason disconnectReason) (*game.Player, string, bool) {
+	r.mu.Lock()
+	session, ok := r.sessions[token]
+	if !ok {
+		r.mu.Unlock()
+		return nil, "", false
+	}
+	if session.closeConn != nil {
+		takeover := session.closeConn
+		r.mu.Unlock()
+
+		takeover(takeoverReason)
+		select {
+		case <-session.released:
+		case <-time.After(resumeTakeoverWait):
+			return nil, "", false
+		}
+		r.mu.Lock()
+	}
+	defer r.mu.Unlock()
+
+	if current, ok := r.sessions[token]; !ok || current != session || session.expiry == nil {
+		return nil, "", false
+	}
+	if !session.expiry.Stop() {
+		// The grace period ran out and the player is being removed
+		return nil, "", false
+	}
+	delete(r.sessions, token)
+
+	newToken := newSessionToken()
+	r.sessions[newToken] = &resumeSession{
+		player:    session.player,
+		closeConn: closeConn,
+		boundAt:   time.Now(),
+		released:  make(chan struct{}),
+	}
+	return session.player, newToken, true
+}
+
+// parked reports whether playerID is disconnected and waiting for a client to
+// resume it
+func (r *sessionResumer) parked(playerID string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, session := range r.sessions {
+		if session.player.ID == playerID && session.expiry != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// parkedPlayers returns the IDs of every parked player
+func (r *sessionResumer) parkedPlayers() map[string]bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	parked := make(map[string]bool)
+	for _, session := range r.sessions {
+		if session.expiry != nil {
+			parked[session.player.ID] = true
+		}
+	}
+	return parked
+}
+
+// newSessionToken returns 128 random bits, hex encoded
+func newSessionToken() string {
+	var token [16]byte
+	if _, err := rand.Read(token[:]); err != nil {
+		panic("session token: " + err.Error())
+	}
+	return hex.EncodeToString(token[:])
+}
+
+// idlePlayer stops a parked player's movement so it does not keep running on
+// its last input while nobody is controlling it
+func (h *WebSocketHandler) idlePlayer(playerID string) {
+	state, exists := h.gameServer.GetPlayerState(playerID)
+	if !exists {
+		return
+	}
+	h.gameServer.UpdatePlayerInput(playerID, game.InputState{AimAngle: state.AimAngle})
+}
+
+// dormantPlayers returns the players of rooms whose every player is parked:
+// rooms kept alive for reconnection that the tick loop can leave be until
+// someone resumes
+func (h *WebSocketHandler) dormantPlayers() map[string]bool {
+	parked := h.resumer.parkedPlayers()
+	dormant := make(map[string]bool)
+	if len(parked) == 0 {
+		return dormant
+	}
+
+	for _, room := range h.roomManager.GetAllRooms() {
+		players := room.GetPlayers()
+		if len(players) == 0 {
+			continue
+		}
+		connected := false
+		for _, player := range players {
+			if !parked[player.ID] {
+				connected = true
+				break
+			}
+		}
+		if !connected {
+			for _, player := range players {
+				dormant[player.ID] = true
+			}
+		}
+	}
+	return dormant
+}
+
+// resumeSessionState brings a resumed client back up to date: its session
+// status and, mid-match, the weapon crates, its own weapon state and the
+// scores it missed. The next broadcast sends it a full snapshot.
+func (h *WebSocketHandler) resumeSessionState(player *game.Player) {
+	room := h.roomManager.GetRoomByPlayerID(player.ID)
+	if room == nil {
+		return
+	}
+
+	h.updateMatchPause(room)
+
+	state := game.SessionStatusWaitingForPlayers
+	if _, active := h.gameServer.GetPlayerState(player.ID); active {
+		state = game.SessionStatusMatchReady
+	}
+	if err := h.publication.PublishSessionStatus(player, room, state); err != nil {
+		log.Printf("Error sending session:status to resumed player %s: %v", player.ID, err)
+	}
+
+	if state == game.SessionStatusMatchReady {
+		h.sendMapLoad(player)
+		h.sendWeaponSpawns(player.ID)
+		h.sendShieldSpawns(player.ID)
+		h.sendHealthSpawns(player.ID)
+		h.sendWeaponState(player.ID)
+		h.sendWorldSync(player, room)
+	}
+}
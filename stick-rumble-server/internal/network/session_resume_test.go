@@ -0,0 +1,338 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionResumerResumesParkedPlayer(t *testing.T) {
+	resumer := newSessionResumer(time.Minute)
+	player := game.NewPlayer("p1", make(chan []byte, 1))
+
+	token := resumer.issue(player, func(disconnectReason) {})
+	expired := make(chan struct{})
+	resumer.park(token, func() { close(expired) })
+
+	resumed, newToken, ok := resumer.resume(token, func(disconnectReason) {})
+	require.True(t, ok)
+	assert.Same(t, player, resumed)
+	assert.NotEqual(t, token, newToken, "tokens rotate on resume")
+
+	_, _, ok = resumer.resume(token, func(disconnectReason) {})
+	assert.False(t, ok, "a spent token cannot be reused")
+
+	select {
+	case <-expired:
+		t.Fatal("a resumed player must not be removed")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestSessionResumerExpiresParkedPlayer(t *testing.T) {
+	resumer := newSessionResumer(20 * time.Millisecond)
+	token := resumer.issue(game.NewPlayer("p1", make(chan []byte, 1)), func(disconnectReason) {})
+
+	expired := make(chan struct{})
+	resumer.park(token, func() { close(expired) })
+
+	select {
+	case <-expired:
+	case <-time.After(time.Second):
+		t.Fatal("grace period should expire")
+	}
+	_, _, ok := resumer.resume(token, func(disconnectReason) {})
+	assert.False(t, ok)
+}
+
+func TestSessionResumerRejectsForgottenAndUnknownTokens(t *testing.T) {
+	resumer := newSessionResumer(time.Minute)
+	token := resumer.issue(game.NewPlayer("p1", make(chan []byte, 1)), func(disconnectReason) {})
+	resumer.forget(token)
+
+	_, _, ok := resumer.resume(token, func(disconnectReason) {})
+	assert.False(t, ok)
+	_, _, ok = resumer.resume("not-a-token", func(disconnectReason) {})
+	assert.False(t, ok)
+}
+
+func TestSessionResumerTakesOverOpenConnection(t *testing.T) {
+	resumer := newSessionResumer(time.Minute)
+	player := game.NewPlayer("p1", make(chan []byte, 1))
+
+	var token string
+	token = resumer.issue(player, func(disconnectReason) {
+		// Closing the old connection ends its read loop, which parks it
+		go resumer.park(token, func() {})
+	})
+
+	resumed, _, ok := resumer.resume(token, func(disconnectReason) {})
+	require.True(t, ok)
+	assert.Same(t, player, resumed)
+}
+
+// dialResume reconnects with a session token
+func dialResume(t *testing.T, ts *testServer, token string) *websocket.Conn {
+	t.Helper()
+	conn, _, err := websocket.DefaultDialer.Dial(ts.wsURL()+"?resume="+token, nil)
+	require.NoError(t, err)
+	return conn
+}
+
+// readServerHello reads server:hello and returns its session token and resumed flag
+func readServerHello(t *testing.T, conn *websocket.Conn) (string, bool) {
+	t.Helper()
+	msg, err := readMessageOfType(t, conn, "server:hello", 2*time.Second)
+	require.NoError(t, err)
+	data, ok := msg.Data.(map[string]interface{})
+	require.True(t, ok)
+	token, _ := data["sessionToken"].(string)
+	require.NotEmpty(t, token)
+	resumed, _ := data["resumed"].(bool)
+	return token, resumed
+}
+
+func TestResumeRebindsToExistingPlayerAfterDisconnect(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn1 := ts.connectRawClient(t)
+	conn2 := ts.connectRawClient(t)
+	defer conn2.Close()
+	token, resumed := readServerHello(t, conn1)
+	assert.False(t, resumed)
+
+	sendHelloMessage(t, conn1, "Blip", "code", "RESUME")
+	sendHelloMessage(t, conn2, "Steady", "code", "RESUME")
+	_, status, err := readSessionStatus(t, conn1, "match_ready", 2*time.Second)
+	require.NoError(t, err)
+	playerID := status["playerId"].(string)
+	roomID := status["roomId"].(string)
+
+	require.NoError(t, conn1.Close())
+	require.Eventually(t, func() bool {
+		ts.handler.resumer.mu.Lock()
+		defer ts.handler.resumer.mu.Unlock()
+		session, ok := ts.handler.resumer.sessions[token]
+		return ok && session.expiry != nil
+	}, 2*time.Second, 10*time.Millisecond, "the disconnected player should be parked")
+
+	room := ts.handler.roomManager.GetRoomByPlayerID(playerID)
+	require.NotNil(t, room, "a parked player keeps its room")
+	_, exists := ts.handler.gameServer.GetPlayerState(playerID)
+	assert.True(t, exists, "a parked player keeps its game state")
+
+	resumedConn := dialResume(t, ts, token)
+	defer resumedConn.Close()
+	newToken, resumed := readServerHello(t, resumedConn)
+	assert.True(t, resumed)
+	assert.NotEqual(t, token, newToken)
+
+	_, status, err = readSessionStatus(t, resumedConn, "match_ready", 2*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, playerID, status["playerId"])
+	assert.Equal(t, roomID, status["roomId"])
+
+	_, err = readMessageOfType(t, resumedConn, "weapon:spawned", 2*time.Second)
+	assert.NoError(t, err, "a resumed player is sent the crate state")
+	_, err = readMessageOfType(t, resumedConn, "state:snapshot", 2*time.Second)
+	assert.NoError(t, err, "a resumed player gets a full snapshot")
+
+	// The resumed connection plays as the same player
+	sendMessage(t, resumedConn, Message{Type: "room:roster_request", Timestamp: time.Now().UnixMilli()})
+	_, err = readMessageOfType(t, resumedConn, "room:roster", 2*time.Second)
+	assert.NoError(t, err)
+}
+
+func TestResumedConnectionRestartsInputSequence(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn1 := ts.connectRawClient(t)
+	conn2 := ts.connectRawClient(t)
+	defer conn2.Close()
+	token, _ := readServerHello(t, conn1)
+	sendHelloMessage(t, conn1, "Blip", "code", "RESUME")
+	sendHelloMessage(t, conn2, "Steady", "code", "RESUME")
+	_, status, err := readSessionStatus(t, conn1, "match_ready", 2*time.Second)
+	require.NoError(t, err)
+	playerID := status["playerId"].(string)
+
+	player, exists := ts.handler.gameServer.GetWorld().GetPlayer(playerID)
+	require.True(t, exists)
+	sendSequencedInput(t, conn1, 300)
+	require.Eventually(t, func() bool { return player.GetInputSequence() == 300 }, 2*time.Second, 10*time.Millisecond)
+	require.NoError(t, conn1.Close())
+
+	resumedConn := dialResume(t, ts, token)
+	defer resumedConn.Close()
+	_, resumed := readServerHello(t, resumedConn)
+	require.True(t, resumed)
+	_, _, err = readSessionStatus(t, resumedConn, "match_ready", 2*time.Second)
+	require.NoError(t, err)
+
+	start := player.GetPosition()
+	sendSequencedInput(t, resumedConn, 1)
+	require.Eventually(t, func() bool {
+		return player.GetInputSequence() == 1 && player.GetPosition().X > start.X
+	}, 2*time.Second, 10*time.Millisecond, "the resumed connection's inputs move the player")
+}
+
+func TestResumeSendsAuthoritativeScores(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn1 := ts.connectRawClient(t)
+	conn2 := ts.connectRawClient(t)
+	defer conn2.Close()
+	token, _ := readServerHello(t, conn1)
+	sendHelloMessage(t, conn1, "Blip", "code", "RESYNC")
+	sendHelloMessage(t, conn2, "Steady", "code", "RESYNC")
+	_, status, err := readSessionStatus(t, conn1, "match_ready", 2*time.Second)
+	require.NoError(t, err)
+	_, otherStatus, err := readSessionStatus(t, conn2, "match_ready", 2*time.Second)
+	require.NoError(t, err)
+	playerID := status["playerId"].(string)
+	otherID := otherStatus["playerId"].(string)
+	room := ts.handler.roomManager.GetRoomByPlayerID(playerID)
+	require.NotNil(t, room)
+	room.Match.Start()
+
+	require.NoError(t, conn1.Close())
+	require.Eventually(t, func() bool {
+		ts.handler.resumer.mu.Lock()
+		defer ts.handler.resumer.mu.Unlock()
+		session, ok := ts.handler.resumer.sessions[token]
+		return ok && session.expiry != nil
+	}, 2*time.Second, 10*time.Millisecond, "the disconnected player should be parked")
+
+	// A kill scored while the player was away
+	room.Match.RecordKill(otherID, playerID)
+
+	resumedConn := dialResume(t, ts, token)
+	defer resumedConn.Close()
+	_, err = readMessageOfType(t, resumedConn, "map:load", 2*time.Second)
+	require.NoError(t, err, "a resumed player should receive map:load")
+	msg, err := readMessageOfType(t, resumedConn, "world:sync", 2*time.Second)
+	require.NoError(t, err, "a resumed player should receive world:sync")
+
+	data, ok := msg.Data.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, map[string]interface{}{playerID: 0.0, otherID: 1.0}, data["playerKills"])
+}
+
+func TestResumeTakesOverConnectionServerStillConsidersOpen(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn1 := ts.connectRawClient(t)
+	defer conn1.Close()
+	token, _ := readServerHello(t, conn1)
+	sendHelloMessage(t, conn1, "Blip", "code", "TAKEOVER")
+	_, status, err := readSessionStatus(t, conn1, "waiting_for_players", 2*time.Second)
+	require.NoError(t, err)
+
+	resumedConn := dialResume(t, ts, token)
+	defer resumedConn.Close()
+	_, resumed := readServerHello(t, resumedConn)
+	assert.True(t, resumed)
+
+	_, resumedStatus, err := readSessionStatus(t, resumedConn, "waiting_for_players", 2*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, status["playerId"], resumedStatus["playerId"])
+}
+
+func TestResumeWithUnknownTokenStartsFreshSession(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn := dialResume(t, ts, "expired-or-forged")
+	defer conn.Close()
+	_, resumed := readServerHello(t, conn)
+	assert.False(t, resumed)
+
+	sendInputState(t, conn, true, false, false, false)
+	_, err := readMessageOfType(t, conn, "error:no_hello", 2*time.Second)
+	assert.NoError(t, err, "a fresh session still needs player:hello")
+}
+
+func TestKickedPlayerIsRemovedInsteadOfParked(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn := ts.connectRawClient(t)
+	defer conn.Close()
+	token, _ := readServerHello(t, conn)
+	sendHelloMessage(t, conn, "Blip", "code", "KICK")
+	_, status, err := readSessionStatus(t, conn, "waiting_for_players", 2*time.Second)
+	require.NoError(t, err)
+	playerID := status["playerId"].(string)
+
+	ts.handler.HandleGameLoopEvent(game.MovementViolationEvent{PlayerID: playerID, Kick: true})
+
+	require.Eventually(t, func() bool {
+		return ts.handler.roomManager.GetRoomByPlayerID(playerID) == nil
+	}, 2*time.Second, 10*time.Millisecond, "a kicked player leaves its room at once")
+	_, exists := ts.handler.gameServer.GetPlayerState(playerID)
+	assert.False(t, exists)
+
+	resumedConn := dialResume(t, ts, token)
+	defer resumedConn.Close()
+	_, resumed := readServerHello(t, resumedConn)
+	assert.False(t, resumed, "a kicked player's session token is revoked")
+}
+
+func TestKickingParkedPlayerRemovesItAtOnce(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn := ts.connectRawClient(t)
+	token, _ := readServerHello(t, conn)
+	sendHelloMessage(t, conn, "Blip", "code", "PARKED")
+	_, status, err := readSessionStatus(t, conn, "waiting_for_players", 2*time.Second)
+	require.NoError(t, err)
+	playerID := status["playerId"].(string)
+
+	conn.Close()
+	require.Eventually(t, func() bool {
+		ts.handler.resumer.mu.Lock()
+		defer ts.handler.resumer.mu.Unlock()
+		session, ok := ts.handler.resumer.sessions[token]
+		return ok && session.expiry != nil
+	}, 2*time.Second, 10*time.Millisecond, "the player is parked")
+
+	require.True(t, ts.handler.kickPlayer(playerID, kickedReason("test")))
+	assert.Nil(t, ts.handler.roomManager.GetRoomByPlayerID(playerID), "a parked player is removed at once")
+
+	resumedConn := dialResume(t, ts, token)
+	defer resumedConn.Close()
+	_, resumed := readServerHello(t, resumedConn)
+	assert.False(t, resumed)
+}
+
+func TestDormantPlayersAreThoseOfFullyParkedRooms(t *testing.T) {
+	handler := NewWebSocketHandler()
+	handler.resumer = newSessionResumer(time.Minute)
+
+	tokens := make(map[string]string)
+	for _, join := range []struct{ id, code string }{{"a1", "ALPHA"}, {"a2", "ALPHA"}, {"b1", "BRAVO"}, {"b2", "BRAVO"}} {
+		player := game.NewPlayer(join.id, make(chan []byte, 8))
+		_, ok := handler.roomManager.AddCodePlayer(player, join.code)
+		require.True(t, ok)
+		tokens[join.id] = handler.resumer.issue(player, func(disconnectReason) {})
+	}
+	assert.Empty(t, handler.dormantPlayers())
+
+	handler.resumer.park(tokens["a1"], func() {})
+	handler.resumer.park(tokens["a2"], func() {})
+	handler.resumer.park(tokens["b1"], func() {})
+	assert.Equal(t, map[string]bool{"a1": true, "a2": true}, handler.dormantPlayers(), "a room with someone connected keeps running")
+
+	_, _, ok := handler.resumer.resume(tokens["a2"], func(disconnectReason) {})
+	require.True(t, ok)
+	assert.Empty(t, handler.dormantPlayers(), "the room wakes up with its first returning player")
+}
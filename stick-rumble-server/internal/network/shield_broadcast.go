@@ -0,0 +1,114 @@
+package network
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+// broadcastShieldPickup broadcasts a shield crate pickup to all clients
+func (h *WebSocketHandler) broadcastShieldPickup(event game.ShieldPickedUpEvent) {
+	data := map[string]interface{}{
+		"playerId":        event.PlayerID,
+		"crateId":         event.CrateID,
+		"shield":          event.Shield,
+		"nextRespawnTime": event.RespawnTime.Unix(),
+	}
+
+	// Validate outgoing message schema (development mode only)
+	if err := h.validateOutgoingMessage("shield:pickup_confirmed", data); err != nil {
+		log.Printf("Schema validation failed for shield:pickup_confirmed: %v", err)
+	}
+
+	message := Message{
+		Type:      "shield:pickup_confirmed",
+		Timestamp: time.Now().UnixMilli(),
+		Data:      data,
+	}
+
+	msgBytes, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("Error marshaling shield:pickup_confirmed message: %v", err)
+		return
+	}
+
+	h.roomManager.BroadcastToAll(msgBytes)
+	log.Printf("Player %s picked up shield crate %s (shield %d)", event.PlayerID, event.CrateID, event.Shield)
+}
+
+// broadcastShieldRespawn broadcasts a shield crate becoming available again
+func (h *WebSocketHandler) broadcastShieldRespawn(event game.ShieldCrateRespawnedEvent) {
+	data := map[string]interface{}{
+		"crateId":  event.CrateID,
+		"position": event.Position,
+	}
+
+	// Validate outgoing message schema (development mode only)
+	if err := h.validateOutgoingMessage("shield:respawned", data); err != nil {
+		log.Printf("Schema validation failed for shield:respawned: %v", err)
+	}
+
+	message := Message{
+		Type:      "shield:respawned",
+		Timestamp: time.Now().UnixMilli(),
+		Data:      data,
+	}
+
+	msgBytes, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("Error marshaling shield:respawned message: %v", err)
+		return
+	}
+
+	h.roomManager.BroadcastToAll(msgBytes)
+}
+
+// sendShieldSpawns sends initial shield crate state to a specific player
+func (h *WebSocketHandler) sendShieldSpawns(playerID string) {
+	allCrates := h.gameServer.GetShieldCrateManager().GetAllCrates()
+
+	crates := make([]map[string]interface{}, 0, len(allCrates))
+	for _, id := range sortedIDs(allCrates) {
+		crate := allCrates[id]
+		crates = append(crates, map[string]interface{}{
+			"id":          crate.ID,
+			"position":    map[string]interface{}{"x": crate.Position.X, "y": crate.Position.Y},
+			"isAvailable": crate.IsAvailable,
+		})
+	}
+
+	data := map[string]interface{}{
+		"crates": crates,
+	}
+
+	// Validate outgoing message schema (development mode only)
+	if err := h.validateOutgoingMessage("shield:spawned", data); err != nil {
+		log.Printf("Schema validation failed for shield:spawned: %v", err)
+	}
+
+	message := Message{
+		Type:      "shield:spawned",
+		Timestamp: time.Now().UnixMilli(),
+		Data:      data,
+	}
+
+	msgBytes, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("Error marshaling shield:spawned message: %v", err)
+		return
+	}
+
+	room := h.roomManager.GetRoomByPlayerID(playerID)
+	if room != nil {
+		player := room.GetPlayer(playerID)
+		if player != nil {
+			if err := player.Send(msgBytes); err != nil {
+				log.Printf("Failed to send shield:spawned to player %s (%v)", playerID, err)
+			}
+		}
+	} else {
+		h.roomManager.SendToWaitingPlayer(playerID, msgBytes)
+	}
+}
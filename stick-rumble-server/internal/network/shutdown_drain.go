@@ -0,0 +1,78 @@
+package network
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// ServerShutdownEndReason is the match:ended reason when a drain runs out
+// before a match finishes
+const ServerShutdownEndReason = "server_shutdown"
+
+const (
+	drainPollInterval = 100 * time.Millisecond
+	// drainEndWait bounds how long ended matches get to announce match:ended
+	drainEndWait = 2 * time.Second
+	// drainFlushDelay lets the final messages reach clients before their
+	// connections are closed
+	drainFlushDelay = 500 * time.Millisecond
+)
+
+// Drain gets the server ready to stop without cutting matches off mid-fight.
+// New connections are refused and every player is sent server:shutdown with
+// the countdown. Running matches may finish inside it; those still running
+// when it runs out, or when ctx is done, are ended with
+// ServerShutdownEndReason so players get the scoreboard. Then every
+// connection is closed. Stop should follow.
+func (h *WebSocketHandler) Drain(ctx context.Context, countdown time.Duration) {
+	if !h.draining.CompareAndSwap(false, true) {
+		return
+	}
+
+	players := h.resumer.players()
+	log.Printf("Draining for shutdown: %d players, %d running matches, %s countdown",
+		len(players), len(h.roomManager.ActiveMatches()), countdown)
+	for _, player := range players {
+		if err := h.publication.SendServerShutdown(player, countdown); err != nil {
+			log.Printf("Error sending server:shutdown to %s: %v", player.ID, err)
+		}
+	}
+
+	if !h.waitForMatchesToEnd(ctx, countdown) {
+		running := h.roomManager.ActiveMatches()
+		for _, match := range running {
+			match.RequestEnd(ServerShutdownEndReason)
+		}
+		log.Printf("Drain countdown over, ending %d running matches", len(running))
+		h.waitForMatchesToEnd(context.Background(), drainEndWait)
+	}
+
+	time.Sleep(drainFlushDelay)
+	for _, player := range h.resumer.players() {
+		h.resumer.revoke(player.ID, serverShutdownReason)
+	}
+	log.Println("Drain complete")
+}
+
+// waitForMatchesToEnd waits up to timeout for every running match to end.
+// Returns false if some are still running.
+func (h *WebSocketHandler) waitForMatchesToEnd(ctx context.Context, timeout time.Duration) bool {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if len(h.roomManager.ActiveMatches()) == 0 {
+			return true
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-deadline.C:
+			return false
+		case <-ticker.C:
+		}
+	}
+}
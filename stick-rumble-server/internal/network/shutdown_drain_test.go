@@ -0,0 +1,76 @@
+package network
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrainEndsRunningMatchesAndClosesConnections(t *testing.T) {
+	// A short timer interval keeps match:timer flowing between the reads
+	ts := newTestServerWithConfig(50 * time.Millisecond)
+	defer ts.Close()
+
+	conns, _, roomID := joinCodeRoom(t, ts, "DRAIN")
+	for _, conn := range conns {
+		defer conn.Close()
+		sendReadyMessage(t, conn, true)
+	}
+	room := ts.handler.roomManager.GetRoom(roomID)
+	require.Eventually(t, room.Match.IsStarted, 2*time.Second, 10*time.Millisecond)
+
+	drained := make(chan struct{})
+	go func() {
+		ts.handler.Drain(context.Background(), 200*time.Millisecond)
+		close(drained)
+	}()
+
+	msg, err := readMessageOfType(t, conns[0], "server:shutdown", 2*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, float64(200), msg.Data.(map[string]interface{})["countdownMs"])
+
+	msg, err = readMessageOfType(t, conns[0], "match:ended", 2*time.Second)
+	require.NoError(t, err, "the countdown runs out before the match does")
+	assert.Equal(t, ServerShutdownEndReason, msg.Data.(map[string]interface{})["reason"])
+
+	select {
+	case <-drained:
+	case <-time.After(5 * time.Second):
+		t.Fatal("drain did not finish")
+	}
+	for err == nil {
+		_, err = readMessage(t, conns[0], 2*time.Second)
+	}
+	var closeErr *websocket.CloseError
+	require.ErrorAs(t, err, &closeErr)
+	assert.Equal(t, int(CloseServerShutdown), closeErr.Code)
+	assert.Equal(t, serverShutdownReason.text, closeErr.Text)
+
+	_, resp, err := websocket.DefaultDialer.Dial(ts.wsURL(), nil)
+	require.Error(t, err, "new connections are refused while draining")
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
+
+func TestDrainWaitsForFinishedMatches(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn := ts.connectClient(t)
+	defer conn.Close()
+	_, err := readMessageOfType(t, conn, "session:status", 2*time.Second)
+	require.NoError(t, err)
+
+	start := time.Now()
+	ts.handler.Drain(context.Background(), time.Minute)
+	assert.Less(t, time.Since(start), 5*time.Second, "with no match running there is nothing to wait for")
+
+	msg, err := readMessageOfType(t, conn, "server:shutdown", 2*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, float64(time.Minute.Milliseconds()), msg.Data.(map[string]interface{})["countdownMs"])
+}
@@ -0,0 +1,182 @@
+package network
+
+import (
+	"math/rand"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/stretchr/testify/require"
+)
+
+// Soak mode is opt-in: set SOAK_DURATION (for example "2h") to run it.
+// SOAK_CLIENTS caps concurrent synthetic clients and SOAK_SEED replays a run.
+const (
+	soakDefaultClients     = 16
+	soakCheckpointInterval = 30 * time.Second
+	soakSettleTimeout      = 10 * time.Second
+	soakGoroutineSlack     = 2
+)
+
+var soakRoomCodes = []string{"SOAKA", "SOAKB", "SOAKC", "SOAKD"}
+
+// soakClient is a synthetic player whose incoming messages are drained in the
+// background so the server never blocks on a slow reader.
+type soakClient struct {
+	conn *websocket.Conn
+	mode string
+	code string
+	done chan struct{}
+}
+
+func dialSoakClient(t *testing.T, ts *testServer, rng *rand.Rand) *soakClient {
+	t.Helper()
+
+	conn := ts.connectRawClient(t)
+	client := &soakClient{conn: conn, mode: "public", done: make(chan struct{})}
+	if rng.Intn(2) == 0 {
+		client.mode = "code"
+		client.code = soakRoomCodes[rng.Intn(len(soakRoomCodes))]
+	}
+
+	go func() {
+		defer close(client.done)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	sendHelloMessage(t, conn, "Soak", client.mode, client.code)
+	return client
+}
+
+func (c *soakClient) close() {
+	_ = c.conn.Close()
+	<-c.done
+}
+
+func soakSettings(t *testing.T) (time.Duration, int, int64) {
+	t.Helper()
+
+	rawDuration := strings.TrimSpace(os.Getenv("SOAK_DURATION"))
+	if rawDuration == "" {
+		t.Skip("soak mode disabled; set SOAK_DURATION to run")
+	}
+	duration, err := time.ParseDuration(rawDuration)
+	require.NoError(t, err, "SOAK_DURATION must be a Go duration")
+
+	clients := soakDefaultClients
+	if raw := strings.TrimSpace(os.Getenv("SOAK_CLIENTS")); raw != "" {
+		clients, err = strconv.Atoi(raw)
+		require.NoError(t, err, "SOAK_CLIENTS must be an integer")
+		require.Positive(t, clients, "SOAK_CLIENTS must be positive")
+	}
+
+	seed := time.Now().UnixNano()
+	if raw := strings.TrimSpace(os.Getenv("SOAK_SEED")); raw != "" {
+		seed, err = strconv.ParseInt(raw, 10, 64)
+		require.NoError(t, err, "SOAK_SEED must be an integer")
+	}
+
+	return duration, clients, seed
+}
+
+// TestSoakSyntheticChurn connects, disconnects, reconnects and re-queues
+// synthetic clients for SOAK_DURATION, then checks that rooms, players and
+// goroutines all drain back to where they started.
+func TestSoakSyntheticChurn(t *testing.T) {
+	duration, maxClients, seed := soakSettings(t)
+	t.Logf("soak: duration=%s clients=%d seed=%d", duration, maxClients, seed)
+	rng := rand.New(rand.NewSource(seed))
+
+	// Closed clients never resume, so parking them for the resume grace would
+	// only count them as live players until it ran out
+	ts := newTestServerWithConfig(100*time.Millisecond, withResumeGrace(0))
+	defer ts.Close()
+	ts.handler.roomManager.SetReadyCheckConfig(game.ReadyCheckConfig{})
+
+	baselineGoroutines := runtime.NumGoroutine()
+	clients := make([]*soakClient, 0, maxClients)
+	deadline := time.Now().Add(duration)
+	nextCheckpoint := time.Now().Add(soakCheckpointInterval)
+	operations := 0
+
+	for time.Now().Before(deadline) {
+		switch action := rng.Intn(10); {
+		case len(clients) < maxClients && (action < 4 || len(clients) == 0):
+			clients = append(clients, dialSoakClient(t, ts, rng))
+		case action < 7:
+			i := rng.Intn(len(clients))
+			clients[i].close()
+			clients = append(clients[:i], clients[i+1:]...)
+		case action < 9:
+			i := rng.Intn(len(clients))
+			clients[i].close()
+			clients[i] = dialSoakClient(t, ts, rng)
+		default:
+			client := clients[rng.Intn(len(clients))]
+			sendMessage(t, client.conn, Message{Type: "session:leave", Timestamp: time.Now().UnixMilli()})
+			sendHelloMessage(t, client.conn, "Soak", client.mode, client.code)
+		}
+		operations++
+		time.Sleep(time.Duration(rng.Intn(20)) * time.Millisecond)
+
+		if time.Now().After(nextCheckpoint) {
+			tracked := ts.handler.roomManager.PlayerCount()
+			require.LessOrEqual(t, tracked, len(clients), "room manager tracks more players than live clients after %d operations", operations)
+			nextCheckpoint = time.Now().Add(soakCheckpointInterval)
+		}
+	}
+
+	for _, client := range clients {
+		client.close()
+	}
+	t.Logf("soak: %d operations", operations)
+
+	assertSoakDrained(t, ts, baselineGoroutines)
+}
+
+func assertSoakDrained(t *testing.T, ts *testServer, baselineGoroutines int) {
+	t.Helper()
+
+	roomManager := ts.handler.roomManager
+	world := ts.handler.gameServer.GetWorld()
+	require.Eventually(t, func() bool {
+		return roomManager.PlayerCount() == 0 && world.PlayerCount() == 0
+	}, soakSettleTimeout, 50*time.Millisecond, "players leaked: room manager=%d world=%d", roomManager.PlayerCount(), world.PlayerCount())
+
+	// Empty pre-match named rooms legitimately wait for TTL cleanup; anything
+	// else still registered is a leak.
+	for _, room := range roomManager.GetAllRooms() {
+		require.Equal(t, game.RoomKindCode, room.Kind, "public room %s leaked", room.ID)
+		require.True(t, room.IsEmpty(), "room %s still has players", room.ID)
+		require.NotNil(t, room.EmptySince, "room %s is not scheduled for cleanup", room.ID)
+		require.True(t, roomManager.RemoveRoomIfIdle(room.ID), "room %s could not be reaped", room.ID)
+	}
+	require.Empty(t, roomManager.GetAllRooms())
+
+	settled := func() bool { return runtime.NumGoroutine() <= baselineGoroutines+soakGoroutineSlack }
+	if !assertEventuallyTrue(settled, soakSettleTimeout) {
+		_ = pprof.Lookup("goroutine").WriteTo(os.Stderr, 1)
+		t.Fatalf("goroutines leaked: baseline=%d now=%d", baselineGoroutines, runtime.NumGoroutine())
+	}
+}
+
+func assertEventuallyTrue(condition func() bool, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if condition() {
+			return true
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return condition()
+}
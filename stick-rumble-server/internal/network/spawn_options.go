@@ -0,0 +1,62 @@
+package network
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+// spawnOptionsLoop shows dead players where they can respawn every
+// game.SpawnOptionsInterval, as enemies move and fights break out
+func (h *WebSocketHandler) spawnOptionsLoop(ctx context.Context) {
+	ticker := time.NewTicker(game.SpawnOptionsInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.sendSpawnOptions()
+		}
+	}
+}
+
+// sendSpawnOptions sends spawn:options to every dead player of a match in
+// progress. Bots respawn at the safest point and are skipped.
+func (h *WebSocketHandler) sendSpawnOptions() {
+	world := h.gameServer.GetWorld()
+	for _, room := range h.roomManager.GetAllRooms() {
+		if room.Match == nil || !room.Match.IsStarted() || room.Match.IsEnded() {
+			continue
+		}
+		for _, player := range room.GetPlayers() {
+			if h.bots.IsBot(player.ID) {
+				continue
+			}
+			state, exists := world.GetPlayer(player.ID)
+			if !exists || !state.IsDead() {
+				continue
+			}
+			if err := h.publication.SendSpawnOptions(player, world.SpawnOptions(player.ID)); err != nil {
+				log.Printf("Failed to send spawn options to %s: %v", player.ID, err)
+			}
+		}
+	}
+}
+
+// handlePlayerSpawnChoice records the spawn point a dead player picked from
+// spawn:options. A pick that automatic selection would not allow is ignored.
+func (h *WebSocketHandler) handlePlayerSpawnChoice(playerID string, data any) {
+	if err := h.validator.Validate("player-spawn-choice-data", data); err != nil {
+		log.Printf("Schema validation failed for player:spawn_choice from %s: %v", playerID, err)
+		return
+	}
+
+	index := int(data.(map[string]interface{})["index"].(float64))
+	if !h.gameServer.ChooseSpawn(playerID, index) {
+		log.Printf("Player %s cannot pick spawn point %d", playerID, index)
+	}
+}
@@ -0,0 +1,60 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadPlayerReceivesSpawnOptionsAndPicksOne(t *testing.T) {
+	withSchemaValidation(t)
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn1, conn2 := ts.connectTwoClients(t)
+	defer conn1.Close()
+	defer conn2.Close()
+
+	player1ID := consumeRoomJoinedAndGetPlayerID(t, conn1)
+	_ = consumeRoomJoinedAndGetPlayerID(t, conn2)
+	room := ts.handler.roomManager.GetRoomByPlayerID(player1ID)
+	require.NotNil(t, room)
+	room.Match.Start()
+
+	ts.handler.gameServer.MarkPlayerDead(player1ID)
+	ts.handler.sendSpawnOptions()
+
+	msg, err := readMessageOfType(t, conn1, "spawn:options", 2*time.Second)
+	require.NoError(t, err)
+	points := msg.Data.(map[string]interface{})["points"].([]interface{})
+	require.Len(t, points, len(ts.handler.gameServer.GetWorld().Spawns().SpawnPoints()))
+
+	allowed, blocked := -1, -1
+	for _, raw := range points {
+		point := raw.(map[string]interface{})
+		if point["allowed"].(bool) {
+			allowed = int(point["index"].(float64))
+		} else {
+			blocked = int(point["index"].(float64))
+		}
+	}
+	require.NotEqual(t, -1, allowed, "the safest point is always allowed")
+
+	player, exists := ts.handler.gameServer.GetWorld().GetPlayer(player1ID)
+	require.True(t, exists)
+	ts.handler.handlePlayerSpawnChoice(player1ID, map[string]interface{}{"index": -1.0})
+	_, chosen := player.SpawnChoice()
+	assert.False(t, chosen, "an invalid payload is ignored")
+	if blocked != -1 {
+		ts.handler.handlePlayerSpawnChoice(player1ID, map[string]interface{}{"index": float64(blocked)})
+		_, chosen = player.SpawnChoice()
+		assert.False(t, chosen, "a point automatic selection would not allow is ignored")
+	}
+
+	ts.handler.handlePlayerSpawnChoice(player1ID, map[string]interface{}{"index": float64(allowed)})
+	index, chosen := player.SpawnChoice()
+	assert.True(t, chosen)
+	assert.Equal(t, allowed, index)
+}
@@ -1,6 +1,7 @@
 package network
 
 import (
+	"fmt"
 	"os"
 	"testing"
 )
@@ -15,6 +16,15 @@ func TestMain(m *testing.M) {
 	// Run all tests
 	code := m.Run()
 
+	// Fail the package if any handler, game loop or connection goroutine
+	// outlived the tests that started it
+	if code == 0 {
+		if err := verifyNoGoroutineLeaks(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			code = 1
+		}
+	}
+
 	// Exit with test result code
 	os.Exit(code)
 }
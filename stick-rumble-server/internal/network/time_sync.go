@@ -0,0 +1,24 @@
+package network
+
+import (
+	"log"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+// handleTimeSyncRequest answers a clock sync request with the time it was
+// read off the connection, the time the reply is built and the current
+// simulation tick, so the client can estimate its offset to the server clock.
+// Accepted before player:hello: clients sync while they pick a mode.
+func (h *WebSocketHandler) handleTimeSyncRequest(player *game.Player, data any, receivedAt time.Time) {
+	if err := h.validator.Validate("time-sync-request-data", data); err != nil {
+		log.Printf("Schema validation failed for time:sync_request from %s: %v", player.ID, err)
+		return
+	}
+
+	clientTime := data.(map[string]interface{})["clientTime"].(float64)
+	if err := h.publication.SendTimeSync(player, clientTime, receivedAt, time.Now(), h.gameServer.Tick()); err != nil {
+		log.Printf("Error sending time:sync to %s: %v", player.ID, err)
+	}
+}
@@ -0,0 +1,37 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeSyncRequestAnsweredBeforeHello(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn := ts.connectRawClient(t)
+	defer conn.Close()
+
+	sent := time.Now()
+	sendMessage(t, conn, Message{
+		Type:      "time:sync_request",
+		Timestamp: sent.UnixMilli(),
+		Data:      map[string]interface{}{"clientTime": 12345.5},
+	})
+
+	msg, err := readMessageOfType(t, conn, "time:sync", 2*time.Second)
+	require.NoError(t, err)
+	data := msg.Data.(map[string]interface{})
+	assert.Equal(t, 12345.5, data["clientTime"], "the client's send time is echoed")
+	receivedAt := int64(data["serverReceiveTime"].(float64))
+	transmittedAt := int64(data["serverTransmitTime"].(float64))
+	assert.GreaterOrEqual(t, receivedAt, sent.UnixMilli())
+	assert.GreaterOrEqual(t, transmittedAt, receivedAt)
+	assert.Contains(t, data, "tick")
+
+	_, err = readMessageOfType(t, conn, "error:no_hello", 200*time.Millisecond)
+	assert.Error(t, err, "time sync does not need player:hello")
+}
@@ -0,0 +1,113 @@
+package network
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+// clientConn is a client connection as the session loop sees it. Every
+// transport carries the same codec frames, so serveClient does not know
+// whether a player connected over WebSocket or WebTransport.
+type clientConn interface {
+	// ReadFrame blocks until the client's next frame arrives
+	ReadFrame() ([]byte, error)
+	// WriteFrame sends a frame reliably and in order; safe for concurrent use
+	WriteFrame(frame []byte) error
+	// Close ends the connection, telling the client reason when its code is
+	// set; a zero reason drops the connection. Safe to call more than once.
+	Close(reason disconnectReason)
+	// KeepAlive starts the transport's liveness checks, which run until stop
+	// is closed
+	KeepAlive(playerID string, stop <-chan struct{})
+}
+
+// datagramConn is a clientConn that can also send frames unreliably, without
+// waiting behind a lost packet
+type datagramConn interface {
+	clientConn
+	// SendDatagram sends a frame that may be lost or reordered. It fails for a
+	// frame too large for one datagram.
+	SendDatagram(frame []byte) error
+}
+
+// frameWriter returns how to write a player's next outgoing message. A
+// state:delta goes as a datagram when the transport has them and the client
+// acknowledges state: a lost delta leaves the baseline where it was, and the
+// next delta is built against it. Everything else, full snapshots included,
+// goes on the reliable stream, as does a delta that does not fit a datagram.
+func (h *WebSocketHandler) frameWriter(conn clientConn, playerID string, msg []byte) func([]byte) error {
+	datagrams, ok := conn.(datagramConn)
+	if !ok || game.OutboundMessageType(msg) != "state:delta" || h.deltaTracker.BaseSequence(playerID) == 0 {
+		return conn.WriteFrame
+	}
+	return func(frame []byte) error {
+		if err := datagrams.SendDatagram(frame); err == nil {
+			return nil
+		}
+		return conn.WriteFrame(frame)
+	}
+}
+
+// webSocketConn carries a session over a gorilla WebSocket connection
+type webSocketConn struct {
+	conn      *websocket.Conn
+	frameType int
+
+	// Simulated and chaos-delayed frames are written from other goroutines;
+	// the connection allows only one writer at a time
+	writeMu sync.Mutex
+}
+
+func newWebSocketConn(conn *websocket.Conn, codec Codec) *webSocketConn {
+	conn.SetReadLimit(maxClientFrameBytes)
+	return &webSocketConn{conn: conn, frameType: codec.FrameType()}
+}
+
+func (c *webSocketConn) ReadFrame() ([]byte, error) {
+	_, frame, err := c.conn.ReadMessage()
+	return frame, err
+}
+
+func (c *webSocketConn) WriteFrame(frame []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.conn.WriteMessage(c.frameType, frame)
+}
+
+func (c *webSocketConn) Close(reason disconnectReason) {
+	if reason.code != 0 {
+		_ = writeClose(c.conn, reason)
+	}
+	_ = c.conn.Close()
+}
+
+// KeepAlive sends WebSocket control pings; each pong moves the read deadline,
+// so a connection that stops answering fails its next read
+func (c *webSocketConn) KeepAlive(playerID string, stop <-chan struct{}) {
+	_ = c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(appData string) error {
+		_ = c.conn.SetReadDeadline(time.Now().Add(pongWait))
+		return nil
+	})
+
+	go func() {
+		ticker := time.NewTicker(pingInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if err := c.conn.WriteControl(websocket.PingMessage, []byte{}, time.Now().Add(1*time.Second)); err != nil {
+					log.Printf("Ping error for %s: %v", playerID, err)
+					return
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,40 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlePlayerUltimate_BroadcastsActivation(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn1, conn2 := ts.connectTwoClients(t)
+	defer conn1.Close()
+	defer conn2.Close()
+
+	player1ID := consumeRoomJoinedAndGetPlayerID(t, conn1)
+	_ = consumeRoomJoinedAndGetPlayerID(t, conn2)
+
+	// An empty meter is ignored
+	ts.handler.handlePlayerUltimate(player1ID)
+
+	ts.handler.gameServer.ChargeUltimate(player1ID, game.UltimateMaxCharge)
+	sendMessage(t, conn1, Message{Type: "player:ultimate", Timestamp: time.Now().UnixMilli()})
+
+	// The other player sees the activation
+	msg, err := readMessageOfType(t, conn2, "ultimate:activated", 2*time.Second)
+	require.NoError(t, err, "Should receive ultimate:activated")
+
+	data, ok := msg.Data.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, player1ID, data["playerId"])
+	assert.Equal(t, game.DefaultUltimate, data["ultimate"])
+	assert.Equal(t, float64(game.DefaultUltimateEffect().Duration.Milliseconds()), data["durationMs"])
+	assert.Equal(t, 1.5, data["damageMultiplier"])
+	assert.Equal(t, float64(game.PlayerMaxHealth), data["newHealth"])
+}
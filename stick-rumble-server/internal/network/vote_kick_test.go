@@ -0,0 +1,71 @@
+package network
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVoteKickProgressReachesTheRoom(t *testing.T) {
+	withSchemaValidation(t)
+	f := newGoldenFixture(t)
+	third := game.NewPlayer("player-c", make(chan []byte, 64))
+	_, ok := f.handler.roomManager.AddCodePlayer(third, "GOLDEN")
+	require.True(t, ok)
+	f.drain()
+
+	f.handler.handleRoomVoteKick(f.sender, map[string]interface{}{"playerId": "player-c"})
+	var msg Message
+	require.NoError(t, json.Unmarshal(f.received(t, "room:votekick_progress"), &msg))
+	data := msg.Data.(map[string]interface{})
+	assert.Equal(t, "player-c", data["targetId"])
+	assert.Equal(t, "player-a", data["voterId"])
+	assert.Equal(t, float64(1), data["votes"])
+	assert.Equal(t, float64(2), data["needed"])
+	assert.Equal(t, false, data["passed"])
+	assert.NotEmpty(t, third.SendChan, "the target sees the vote too")
+
+	f.handler.handleRoomVoteKick(f.sender, map[string]interface{}{"playerId": "player-c"})
+	f.handler.handleRoomVoteKick(f.receiver, map[string]interface{}{"playerId": "player-b"})
+	f.handler.handleRoomVoteKick(f.receiver, map[string]interface{}{})
+	assert.Empty(t, f.receiver.SendChan, "repeat, self and malformed votes are dropped")
+}
+
+func TestVoteKickRemovesAndBlocksThePlayer(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conns, ids, _ := joinCodeRoom(t, ts, "VOTES")
+	for _, conn := range conns {
+		defer conn.Close()
+	}
+	target := ts.connectRawClient(t)
+	defer target.Close()
+	sendHelloMessage(t, target, "Charlie", "code", "VOTES")
+	_, status, err := readSessionStatus(t, target, "match_ready", 2*time.Second)
+	require.NoError(t, err)
+	targetID := status["playerId"].(string)
+
+	for _, conn := range conns {
+		sendMessage(t, conn, Message{Type: "room:votekick", Timestamp: time.Now().UnixMilli(), Data: map[string]interface{}{"playerId": targetID}})
+	}
+	assert.Equal(t, kickedReason("vote-kicked by the room"), readCloseReason(t, target))
+	require.Eventually(t, func() bool {
+		return ts.handler.roomManager.GetRoomByPlayerID(targetID) == nil
+	}, 2*time.Second, 10*time.Millisecond, "the kicked player leaves the room")
+	assert.NotNil(t, ts.handler.roomManager.GetRoomByPlayerID(ids[0]))
+
+	// Reconnecting from the same host does not get back in
+	again := ts.connectRawClient(t)
+	defer again.Close()
+	sendHelloMessage(t, again, "Charlie", "code", "VOTES")
+	msg, err := readMessageOfType(t, again, "error:room_blocked", 2*time.Second)
+	require.NoError(t, err)
+	data := msg.Data.(map[string]interface{})
+	assert.Equal(t, "VOTES", data["code"])
+	assert.Greater(t, data["retryAfterMs"], float64(0))
+}
@@ -0,0 +1,371 @@
+package network
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/balance"
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+// Elo-style ratings the weapon report sorts players into skill brackets by.
+// Every kill is a won duel for the killer against the victim.
+const (
+	weaponReportInitialRating = 1500.0
+	weaponReportRatingK       = 32.0   // Most rating one kill moves
+	weaponReportLowRating     = 1450.0 // Below this a player is in the low bracket
+	weaponReportHighRating    = 1550.0 // At or above this a player is in the high bracket
+	weaponReportSimSeed       = 1      // Seed of the report's balance simulation
+)
+
+// Skill brackets of real matches in the weapon report
+const (
+	SkillBracketLow  = "low"
+	SkillBracketMid  = "mid"
+	SkillBracketHigh = "high"
+)
+
+// WeaponReport is how each weapon fares in real matches, by the skill of the
+// players using it, and in the balance simulation, by bot difficulty. Served
+// at GET /weapons/report.
+type WeaponReport struct {
+	GeneratedAt     time.Time       `json:"generatedAt"`
+	Matches         int             `json:"matches"`         // Match timelines read
+	Brackets        []WeaponBracket `json:"brackets"`        // Real matches: low, mid and high
+	SimulationDuels int             `json:"simulationDuels"` // Per matchup and difficulty; 0 when the simulation is off
+	Simulation      []WeaponBracket `json:"simulation"`      // Simulated duels, one bracket per bot difficulty
+}
+
+// WeaponBracket is every weapon's numbers within one skill bracket
+type WeaponBracket struct {
+	Bracket string              `json:"bracket"`
+	Pickups int                 `json:"pickups"` // Weapon pickups in the bracket
+	Kills   int                 `json:"kills"`   // Kills with a known weapon in the bracket
+	Weapons []WeaponBracketStat `json:"weapons"` // Every weapon, sorted by name
+}
+
+// WeaponBracketStat is one weapon's pick rate, kill share and time to kill
+// within a bracket
+type WeaponBracketStat struct {
+	Weapon           string  `json:"weapon"`
+	Pickups          int     `json:"pickups"`
+	PickRate         float64 `json:"pickRate"` // Share of the bracket's weapon pickups
+	Kills            int     `json:"kills"`
+	KillShare        float64 `json:"killShare"`        // Share of the bracket's kills
+	MeanTimeToKillMs int64   `json:"meanTimeToKillMs"` // Over kills with a time to kill
+}
+
+// weaponTally counts one weapon within a bracket
+type weaponTally struct {
+	pickups    int
+	kills      int
+	timedKills int
+	killTime   time.Duration
+}
+
+// weaponReporter recomputes the weapon report in the background from the
+// match timelines on disk and, when simulation duels are configured, a
+// balance simulation at every bot difficulty
+type weaponReporter struct {
+	timelineDir string // "" without timelines
+	simDuels    int
+	now         func() time.Time
+
+	simulation []WeaponBracket // Only touched by the report loop; weapons do not change while the server runs, so it is simulated once
+
+	mu     sync.RWMutex
+	report *WeaponReport // nil until the first run finishes
+}
+
+func newWeaponReporter(timelineDir string, simDuels int, now func() time.Time) *weaponReporter {
+	return &weaponReporter{timelineDir: timelineDir, simDuels: simDuels, now: now}
+}
+
+// weaponReportLoop recomputes the weapon report at start and then every
+// interval until ctx is done
+func (h *WebSocketHandler) weaponReportLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	h.weaponReports.refresh(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.weaponReports.refresh(ctx)
+		}
+	}
+}
+
+// refresh aggregates the timelines on disk into a new report, running the
+// balance simulation first if it has not run yet
+func (r *weaponReporter) refresh(ctx context.Context) {
+	if r.simDuels > 0 && r.simulation == nil {
+		simulation, err := simulateWeaponBrackets(ctx, r.simDuels)
+		if err != nil {
+			log.Printf("Error simulating weapon balance: %v", err)
+		} else {
+			r.simulation = simulation
+		}
+	}
+	if ctx.Err() != nil {
+		return
+	}
+
+	var timelines []MatchTimeline
+	if r.timelineDir != "" {
+		var err error
+		if timelines, err = readMatchTimelines(r.timelineDir); err != nil {
+			log.Printf("Error reading match timelines for the weapon report: %v", err)
+			return
+		}
+	}
+
+	report := buildWeaponReport(timelines)
+	report.GeneratedAt = r.now()
+	if r.simulation != nil {
+		report.SimulationDuels = r.simDuels
+		report.Simulation = r.simulation
+	}
+
+	r.mu.Lock()
+	r.report = &report
+	r.mu.Unlock()
+}
+
+// current returns the latest report, or nil before the first
+func (r *weaponReporter) current() *WeaponReport {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.report
+}
+
+// readMatchTimelines reads every timeline in dir, oldest match first.
+// Unreadable files are logged and skipped.
+func readMatchTimelines(dir string) ([]MatchTimeline, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list timeline directory: %w", err)
+	}
+
+	var timelines []MatchTimeline
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), matchTimelineFileExtension) {
+			continue
+		}
+		timeline, err := readMatchTimeline(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			log.Printf("Skipping match timeline %s: %v", entry.Name(), err)
+			continue
+		}
+		timelines = append(timelines, timeline)
+	}
+
+	sort.SliceStable(timelines, func(i, j int) bool { return timelines[i].StartedAt.Before(timelines[j].StartedAt) })
+	return timelines, nil
+}
+
+func readMatchTimeline(path string) (MatchTimeline, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return MatchTimeline{}, err
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return MatchTimeline{}, err
+	}
+	defer gz.Close()
+
+	var timeline MatchTimeline
+	if err := json.NewDecoder(gz).Decode(&timeline); err != nil {
+		return MatchTimeline{}, err
+	}
+	return timeline, nil
+}
+
+// buildWeaponReport replays the timelines' kills in order to rate every
+// player, and counts each weapon pickup and kill in the bracket of the player
+// making it as rated just before. Player IDs only carry a rating across
+// matches when players sign in; otherwise each match starts everyone afresh.
+func buildWeaponReport(timelines []MatchTimeline) WeaponReport {
+	weapons := weaponNamesByType()
+	ratings := make(map[string]float64)
+	rating := func(playerID string) float64 {
+		if value, ok := ratings[playerID]; ok {
+			return value
+		}
+		return weaponReportInitialRating
+	}
+
+	brackets := []string{SkillBracketLow, SkillBracketMid, SkillBracketHigh}
+	tallies := make(map[string]map[string]*weaponTally, len(brackets))
+	for _, bracket := range brackets {
+		tallies[bracket] = make(map[string]*weaponTally)
+	}
+	tally := func(playerID, weapon string) *weaponTally {
+		bracket := tallies[skillBracket(rating(playerID))]
+		if bracket[weapon] == nil {
+			bracket[weapon] = &weaponTally{}
+		}
+		return bracket[weapon]
+	}
+
+	for _, timeline := range timelines {
+		for _, event := range timeline.Events {
+			weapon, known := weapons[event.Item]
+			switch event.Kind {
+			case TimelinePickup:
+				if known {
+					tally(event.PlayerID, weapon).pickups++
+				}
+			case TimelineKill:
+				if known {
+					counted := tally(event.PlayerID, weapon)
+					counted.kills++
+					if event.TimeToKillMs > 0 {
+						counted.timedKills++
+						counted.killTime += time.Duration(event.TimeToKillMs) * time.Millisecond
+					}
+				}
+				killer, victim := rating(event.PlayerID), rating(event.TargetID)
+				change := weaponReportRatingK * (1 - 1/(1+math.Pow(10, (victim-killer)/400)))
+				ratings[event.PlayerID] = killer + change
+				ratings[event.TargetID] = victim - change
+			}
+		}
+	}
+
+	report := WeaponReport{Matches: len(timelines), Brackets: make([]WeaponBracket, 0, len(brackets)), Simulation: []WeaponBracket{}}
+	for _, bracket := range brackets {
+		report.Brackets = append(report.Brackets, newWeaponBracket(bracket, tallies[bracket]))
+	}
+	return report
+}
+
+// skillBracket returns the bracket of a rating
+func skillBracket(rating float64) string {
+	switch {
+	case rating < weaponReportLowRating:
+		return SkillBracketLow
+	case rating >= weaponReportHighRating:
+		return SkillBracketHigh
+	default:
+		return SkillBracketMid
+	}
+}
+
+// newWeaponBracket turns one bracket's tallies into rates, listing every
+// weapon in effect
+func newWeaponBracket(name string, tallies map[string]*weaponTally) WeaponBracket {
+	bracket := WeaponBracket{Bracket: name, Weapons: []WeaponBracketStat{}}
+	for _, counted := range tallies {
+		bracket.Pickups += counted.pickups
+		bracket.Kills += counted.kills
+	}
+
+	for _, weapon := range balance.AllWeapons() {
+		stat := WeaponBracketStat{Weapon: weapon}
+		if counted := tallies[weapon]; counted != nil {
+			stat.Pickups = counted.pickups
+			stat.Kills = counted.kills
+			if bracket.Pickups > 0 {
+				stat.PickRate = float64(counted.pickups) / float64(bracket.Pickups)
+			}
+			if bracket.Kills > 0 {
+				stat.KillShare = float64(counted.kills) / float64(bracket.Kills)
+			}
+			if counted.timedKills > 0 {
+				stat.MeanTimeToKillMs = (counted.killTime / time.Duration(counted.timedKills)).Milliseconds()
+			}
+		}
+		bracket.Weapons = append(bracket.Weapons, stat)
+	}
+	return bracket
+}
+
+// weaponNamesByType maps the lowercase weapon types timelines record to the
+// weapons' own names
+func weaponNamesByType() map[string]string {
+	names := make(map[string]string)
+	for _, weapon := range balance.AllWeapons() {
+		names[strings.ToLower(weapon)] = weapon
+	}
+	return names
+}
+
+// simulateWeaponBrackets duels every pair of weapons duels times at each bot
+// difficulty, stopping early if ctx is done
+func simulateWeaponBrackets(ctx context.Context, duels int) ([]WeaponBracket, error) {
+	var brackets []WeaponBracket
+	for _, difficulty := range game.BotDifficulties() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		report, err := balance.Run(balance.Config{Duels: duels, Seed: weaponReportSimSeed, Difficulty: difficulty})
+		if err != nil {
+			return nil, err
+		}
+		brackets = append(brackets, simulationBracket(difficulty.Name, report))
+	}
+	return brackets, nil
+}
+
+// simulationBracket counts each duel won as a kill by the winner's weapon.
+// Duels have no pickups.
+func simulationBracket(name string, report balance.Report) WeaponBracket {
+	tallies := make(map[string]*weaponTally, len(report.Weapons))
+	for _, weapon := range report.Weapons {
+		tallies[weapon] = &weaponTally{}
+	}
+	for _, matchup := range report.Matchups {
+		winner, loser := tallies[matchup.Weapon], tallies[matchup.Opponent]
+		winner.kills += matchup.Wins
+		winner.timedKills += matchup.Wins
+		winner.killTime += time.Duration(matchup.Wins) * time.Duration(matchup.WinTimeToKillMs) * time.Millisecond
+		loser.kills += matchup.Losses
+		loser.timedKills += matchup.Losses
+		loser.killTime += time.Duration(matchup.Losses) * time.Duration(matchup.LossTimeToKillMs) * time.Millisecond
+	}
+	return newWeaponBracket(name, tallies)
+}
+
+// HandleWeaponReport serves GET /weapons/report for the shared global handler
+func HandleWeaponReport(w http.ResponseWriter, r *http.Request) {
+	getGlobalHandler().HandleWeaponReport(w, r)
+}
+
+// HandleWeaponReport serves the latest weapon report as JSON. 404 when the
+// report is off, 503 until its first run finishes.
+func (h *WebSocketHandler) HandleWeaponReport(w http.ResponseWriter, r *http.Request) {
+	if h.weaponReports == nil {
+		http.Error(w, "weapon report not enabled", http.StatusNotFound)
+		return
+	}
+	report := h.weaponReports.current()
+	if report == nil {
+		http.Error(w, "weapon report not ready", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		log.Printf("Error writing weapon report: %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/balance"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getWeaponReport(h *WebSocketHandler) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.HandleWeaponReport(rec, httptest.NewRequest(http.MethodGet, "/weapons/report", nil))
+	return rec
+}
+
+// weaponStat returns the named weapon's line of a bracket
+func weaponStat(t *testing.T, bracket WeaponBracket, weapon string) WeaponBracketStat {
+	t.Helper()
+	for _, stat := range bracket.Weapons {
+		if stat.Weapon == weapon {
+			return stat
+		}
+	}
+	t.Fatalf("bracket %s has no %s", bracket.Bracket, weapon)
+	return WeaponBracketStat{}
+}
+
+func TestWeaponReportBracketsBySkill(t *testing.T) {
+	dir := t.TempDir()
+	timelines := newMatchTimelineRecorder(dir, time.Now)
+	started := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	first := MatchTimeline{
+		MatchID:   "f0000000-0000-4000-8000-000000000001",
+		StartedAt: started,
+		Events: []TimelineEvent{
+			{Kind: TimelinePickup, PlayerID: "alpha", Item: "ak47"},
+			{Kind: TimelinePickup, PlayerID: "bravo", Item: "shield"},
+			{Kind: TimelinePickup, PlayerID: "bravo", Item: "bat"},
+		},
+	}
+	// Alpha's first four kills are rated mid; by the fifth alpha is high
+	for _, timeToKill := range []int64{1000, 1000, 1000, 1000, 500} {
+		first.Events = append(first.Events, TimelineEvent{Kind: TimelineKill, PlayerID: "alpha", TargetID: "bravo", Item: "ak47", TimeToKillMs: timeToKill})
+	}
+	second := MatchTimeline{
+		MatchID:   "a0000000-0000-4000-8000-000000000002", // Listed first, played second
+		StartedAt: started.Add(time.Hour),
+		Events: []TimelineEvent{
+			{Kind: TimelinePickup, PlayerID: "bravo", Item: "uzi"},
+			{Kind: TimelineKill, PlayerID: "bravo", TargetID: "alpha", Item: "bat", TimeToKillMs: 800},
+			{Kind: TimelineKill, PlayerID: "alpha", TargetID: "bravo"}, // Recorded before kill weapons were
+		},
+	}
+	require.NoError(t, timelines.save(first))
+	require.NoError(t, timelines.save(second))
+
+	handler := NewWebSocketHandler()
+	assert.Equal(t, http.StatusNotFound, getWeaponReport(handler).Code, "the report is off")
+
+	generatedAt := started.Add(2 * time.Hour)
+	handler.weaponReports = newWeaponReporter(dir, 0, func() time.Time { return generatedAt })
+	assert.Equal(t, http.StatusServiceUnavailable, getWeaponReport(handler).Code, "not computed yet")
+
+	handler.weaponReports.refresh(context.Background())
+	rec := getWeaponReport(handler)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var report WeaponReport
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+
+	assert.Equal(t, generatedAt, report.GeneratedAt.UTC())
+	assert.Equal(t, 2, report.Matches)
+	assert.Zero(t, report.SimulationDuels)
+	assert.Empty(t, report.Simulation)
+	require.Len(t, report.Brackets, 3)
+	low, mid, high := report.Brackets[0], report.Brackets[1], report.Brackets[2]
+	assert.Equal(t, []string{SkillBracketLow, SkillBracketMid, SkillBracketHigh}, []string{low.Bracket, mid.Bracket, high.Bracket})
+	assert.Len(t, mid.Weapons, len(balance.AllWeapons()), "every weapon is listed")
+
+	assert.Equal(t, 2, mid.Pickups, "shield pickups are not weapons")
+	assert.Equal(t, 4, mid.Kills)
+	assert.Equal(t, WeaponBracketStat{Weapon: "AK47", Pickups: 1, PickRate: 0.5, Kills: 4, KillShare: 1, MeanTimeToKillMs: 1000}, weaponStat(t, mid, "AK47"))
+	assert.Equal(t, WeaponBracketStat{Weapon: "Bat", Pickups: 1, PickRate: 0.5}, weaponStat(t, mid, "Bat"))
+
+	assert.Equal(t, WeaponBracketStat{Weapon: "AK47", Kills: 1, KillShare: 1, MeanTimeToKillMs: 500}, weaponStat(t, high, "AK47"))
+
+	assert.Equal(t, 1, low.Pickups, "bravo lost rating in the first match")
+	assert.Equal(t, 1, low.Kills, "a kill without a weapon only moves ratings")
+	assert.Equal(t, WeaponBracketStat{Weapon: "Uzi", Pickups: 1, PickRate: 1}, weaponStat(t, low, "Uzi"))
+	assert.Equal(t, WeaponBracketStat{Weapon: "Bat", Kills: 1, KillShare: 1, MeanTimeToKillMs: 800}, weaponStat(t, low, "Bat"))
+}
+
+func TestSimulationBracketCountsDuelWinsAsKills(t *testing.T) {
+	bracket := simulationBracket("hard", balance.Report{
+		Weapons: []string{"AK47", "Bat"},
+		Matchups: []balance.Matchup{
+			{Weapon: "AK47", Opponent: "AK47", Wins: 2, Losses: 2, WinTimeToKillMs: 1000, LossTimeToKillMs: 3000},
+			{Weapon: "AK47", Opponent: "Bat", Wins: 3, Losses: 1, Draws: 1, WinTimeToKillMs: 2000, LossTimeToKillMs: 4000},
+			{Weapon: "Bat", Opponent: "Bat", Wins: 1, Losses: 1, WinTimeToKillMs: 600, LossTimeToKillMs: 1800},
+		},
+	})
+
+	assert.Equal(t, "hard", bracket.Bracket)
+	assert.Zero(t, bracket.Pickups)
+	assert.Equal(t, 10, bracket.Kills)
+	assert.Equal(t, WeaponBracketStat{Weapon: "AK47", Kills: 7, KillShare: 0.7, MeanTimeToKillMs: 2000}, weaponStat(t, bracket, "AK47"))
+	assert.Equal(t, WeaponBracketStat{Weapon: "Bat", Kills: 3, KillShare: 0.3, MeanTimeToKillMs: 2133}, weaponStat(t, bracket, "Bat"))
+}
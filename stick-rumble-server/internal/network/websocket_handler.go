@@ -5,19 +5,25 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"math/rand"
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
+	"github.com/mtomcal/stick-rumble-server/internal/buildinfo"
 	"github.com/mtomcal/stick-rumble-server/internal/config"
 	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/mtomcal/stick-rumble-server/internal/game/bot"
+	"github.com/mtomcal/stick-rumble-server/internal/scripting"
 )
 
 var upgrader = websocket.Upgrader{
 	ReadBufferSize:  1024,
 	WriteBufferSize: 1024,
+	Subprotocols:    codecSubprotocols,
 	CheckOrigin: func(r *http.Request) bool {
 		return config.Load().AllowsOrigin(r.Header.Get("Origin"))
 	},
@@ -42,8 +48,41 @@ type WebSocketHandler struct {
 	outgoingValidator *SchemaValidator
 	outgoingMessages  *outgoingMessageBuilder
 	publication       *serverToClientPublication
-	networkSimulator  *NetworkSimulator // For artificial latency testing (Story 4.6)
-	deltaTracker      *DeltaTracker     // For delta compression (Story 4.4)
+	networkSimulator  *NetworkSimulator      // For artificial latency testing (Story 4.6)
+	deltaTracker      *DeltaTracker          // For delta compression (Story 4.4)
+	broadcastBuffers  broadcastBuffers       // Reused by broadcastPlayerStates
+	recorder          *sessionRecorder       // Targeted input+event recording for anti-cheat review
+	resumer           *sessionResumer        // Session tokens and parked players awaiting reconnect
+	chaos             *chaosInjector         // Per-connection fault injection for dev testing
+	drills            *chaosDrills           // Rooms with a forced host restart drill running
+	drillTimeout      time.Duration          // How long a drill waits for clients to resync
+	auth              *tokenAuthenticator    // Bearer token checks on /ws; off without a secret
+	bans              *banList               // Players and addresses barred by an admin
+	names             *nameRegistry          // Display name history and rename cooldowns per account
+	feedback          *feedbackCollector     // Rate limits playtest feedback and stores it
+	chat              *chatRelay             // Profanity filter and flood limit for room chat
+	voteKick          game.VoteKickConfig    // How much of a room must agree to kick a player, and for how long
+	matchRecords      matchRecordStore       // Persists finished matches; nil without a match record directory
+	replays           *matchReplayRecorder   // Writes every match to a replay file; nil without a replay directory
+	timelines         *matchTimelineRecorder // Writes every match's timeline; nil without a timeline directory
+	cluster           *clusterRouter         // Routes joins to other instances; nil without Redis
+	weaponReports     *weaponReporter        // Aggregates GET /weapons/report; nil when it has nothing to report
+	weaponReportEvery time.Duration          // How often the weapon report is recomputed
+	leaderboard       *leaderboardRanker     // Ranks the match records for GET /leaderboard; nil without them
+	leaderboardEvery  time.Duration          // How often the leaderboard is recomputed besides match ends; 0 only on match ends
+	sandboxes         *lobbySandboxes        // Solo practice worlds of players waiting for a match
+	bots              *bot.Controller        // Server-controlled players that fill stalled and practice rooms
+	scriptActions     []func()               // Mode script actions waiting for the next match tick
+	scriptActionsMu   sync.Mutex
+	stopLoops         context.CancelFunc // Cancels the loops Start began; nil before Start
+	loopsMu           sync.Mutex         // Guards stopLoops, and loops against Add during Wait
+	loops             sync.WaitGroup
+	draining          atomic.Bool // Set by Drain; /ws turns new connections away
+
+	heartbeatInterval  time.Duration // How often connections are sent net:ping
+	heartbeatMissLimit int           // Unanswered net:pings in a row that close a connection
+
+	buildInfo func() buildinfo.Info // Server build recorded with match results
 }
 
 type roomSessionRuntime interface {
@@ -53,16 +92,28 @@ type roomSessionRuntime interface {
 
 type gameSessionRuntime struct {
 	gameServer       *game.GameServer
+	sendMapLoad      func(player *game.Player)
 	sendWeaponSpawns func(playerID string)
+	sendShieldSpawns func(playerID string)
+	sendHealthSpawns func(playerID string)
+	sendWorldSync    func(player *game.Player, room *game.Room)
+	recordedXP       func(playerID string) int // XP from the player's recorded matches; nil counts none
 }
 
 func (r *gameSessionRuntime) ActivatePlayers(activations []game.RoomSessionActivation) {
 	for _, activation := range activations {
 		if _, exists := r.gameServer.GetPlayerState(activation.Player.ID); !exists {
-			r.gameServer.AddPlayer(activation.Player.ID)
+			r.gameServer.AddPlayerAs(activation.Player.ID, activation.Player.Loadout.Class())
+			if r.recordedXP != nil {
+				r.gameServer.SetRecordedXP(activation.Player.ID, r.recordedXP(activation.Player.ID))
+			}
 		}
 		r.gameServer.SetPlayerDisplayName(activation.Player.ID, activation.Player.DisplayName)
+		r.sendMapLoad(activation.Player)
 		r.sendWeaponSpawns(activation.Player.ID)
+		r.sendShieldSpawns(activation.Player.ID)
+		r.sendHealthSpawns(activation.Player.ID)
+		r.sendWorldSync(activation.Player, activation.Room)
 	}
 }
 
@@ -93,27 +144,118 @@ func NewWebSocketHandlerWithConfig(timerInterval time.Duration) *WebSocketHandle
 	networkSimulator := NewNetworkSimulator()
 
 	handler := &WebSocketHandler{
-		roomManager:       game.NewRoomManager(),
-		timerInterval:     timerInterval,
-		validator:         NewSchemaValidator(schemaLoader),
-		outgoingValidator: NewSchemaValidator(outgoingSchemaLoader),
-		networkSimulator:  networkSimulator,
-		deltaTracker:      NewDeltaTracker(),
+		roomManager:        game.NewRoomManager(),
+		timerInterval:      timerInterval,
+		validator:          NewSchemaValidator(schemaLoader),
+		outgoingValidator:  NewSchemaValidator(outgoingSchemaLoader),
+		networkSimulator:   networkSimulator,
+		deltaTracker:       NewDeltaTracker(),
+		heartbeatInterval:  defaultHeartbeatInterval,
+		heartbeatMissLimit: defaultHeartbeatMissLimit,
+		buildInfo:          buildinfo.Get,
 	}
 	handler.outgoingMessages = newOutgoingMessageBuilder(handler.outgoingValidator, time.Now)
 	handler.publication = newServerToClientPublication(handler.outgoingMessages, handler.roomManager)
 	handler.roomManager.SetPublisher(handler.publication)
+	runtimeConfig := config.Load()
+	handler.roomManager.SetRoomSettings(game.RoomSettings{
+		MinHumanPlayers: runtimeConfig.MinHumanPlayers,
+		BotFillAfter:    runtimeConfig.BotFillAfter,
+		BotFillTarget:   runtimeConfig.BotFillTarget,
+		Teams:           runtimeConfig.Teams,
+	})
+	handler.roomManager.SetCapacityLimits(game.CapacityLimits{
+		MaxRooms:    runtimeConfig.MaxRooms,
+		MaxPlayers:  runtimeConfig.MaxPlayers,
+		RedirectURL: runtimeConfig.RedirectURL,
+	})
+	handler.roomManager.SetFixedRoomSeed(runtimeConfig.RoomSeed)
+	handler.recorder = newSessionRecorder(runtimeConfig.RecordingDir, time.Now)
+	handler.resumer = newSessionResumer(runtimeConfig.ResumeGrace)
+	handler.drills = newChaosDrills()
+	handler.drillTimeout = chaosDrillTimeout
+	handler.auth = newTokenAuthenticator(runtimeConfig.AuthTokenSecret, time.Now)
+	handler.bans = newBanList(time.Now)
+	if runtimeConfig.BanDir != "" {
+		if err := handler.bans.restore(newFileBanStore(runtimeConfig.BanDir)); err != nil {
+			log.Printf("Error loading bans from %s, keeping bans in memory: %v", runtimeConfig.BanDir, err)
+		}
+	}
+	handler.names = newNameRegistry(runtimeConfig.NameChangeCooldown, time.Now)
+	handler.sandboxes = newLobbySandboxes(&game.RealClock{})
+	handler.chat = newChatRelay(NewWordListFilter(defaultBlockedWords), time.Now)
+	handler.feedback = newFeedbackCollector(newFileFeedbackStore(runtimeConfig.FeedbackDir), runtimeConfig.FeedbackCooldown, time.Now)
+	if runtimeConfig.MatchRecordDir != "" {
+		records := newFileMatchRecordStore(runtimeConfig.MatchRecordDir)
+		handler.matchRecords = records
+		handler.leaderboard = newLeaderboardRanker(records, records, time.Now)
+		handler.leaderboardEvery = runtimeConfig.LeaderboardInterval
+	}
+	if runtimeConfig.ReplayDir != "" {
+		handler.replays = newMatchReplayRecorder(runtimeConfig.ReplayDir, func() uint64 { return handler.gameServer.Tick() }, time.Now, func() *game.World { return handler.gameServer.GetWorld() })
+	}
+	if runtimeConfig.TimelineDir != "" {
+		handler.timelines = newMatchTimelineRecorder(runtimeConfig.TimelineDir, time.Now)
+	}
+	if runtimeConfig.WeaponReportInterval > 0 && (runtimeConfig.TimelineDir != "" || runtimeConfig.WeaponReportSimDuels > 0) {
+		handler.weaponReports = newWeaponReporter(runtimeConfig.TimelineDir, runtimeConfig.WeaponReportSimDuels, time.Now)
+		handler.weaponReportEvery = runtimeConfig.WeaponReportInterval
+	}
+	if runtimeConfig.RedisAddr != "" {
+		handler.cluster = newClusterFromConfig(runtimeConfig)
+	}
+	handler.chaos = newChaosInjector(runtimeConfig.GoEnv != "production", rand.New(rand.NewSource(time.Now().UnixNano())))
+	if runtimeConfig.ModeScriptsDir != "" {
+		library := scripting.NewLibrary(runtimeConfig.ModeScriptsDir)
+		handler.roomManager.AddGameplayHookFactory(library.HookFactory(handler.roomScriptHost))
+	}
 	handler.gameServer = game.NewGameServerWithConfig(game.GameServerConfig{
-		BroadcastFunc: handler.broadcastPlayerStates,
-		EventSink:     handler,
-		RTTProvider:   handler.getPlayerRTT,
+		BroadcastFunc:  handler.broadcastPlayerStates,
+		EventSink:      handler,
+		RTTProvider:    handler.getPlayerRTT,
+		GameplayHooks:  handler.roomManager.GameplayHooksForPlayer,
+		ActiveMatches:  handler.roomManager.ActiveMatches,
+		DormantPlayers: handler.dormantPlayers,
+		MovementGuard:  game.MovementGuardConfig{KickAfter: runtimeConfig.MovementKickAfter},
+		AimTurnRate:    handler.roomManager.AimTurnRateForPlayer,
+		RandomSource:   handler.roomManager.RandomSourceForPlayer,
+		DodgeRoll: game.DodgeRollConfig{
+			Invincibility: runtimeConfig.DodgeRollIFrames,
+			StaminaCost:   float64(runtimeConfig.DodgeRollStaminaCost),
+		},
+		Leveling: game.LevelCurve{
+			FirstLevelXP: runtimeConfig.LevelFirstXP,
+			Growth:       float64(runtimeConfig.LevelGrowthPercent) / 100,
+			MaxLevel:     runtimeConfig.LevelMax,
+			Unlocks:      runtimeConfig.LevelUnlocks,
+		},
 	})
+	if handler.replays != nil {
+		handler.gameServer.SetActionRecorder(handler.replays)
+	}
+	botDifficulty, ok := game.BotDifficultyByName(runtimeConfig.BotDifficulty)
+	if !ok {
+		log.Printf("Unknown bot difficulty %q, using %s", runtimeConfig.BotDifficulty, config.DefaultBotDifficulty)
+		botDifficulty, _ = game.BotDifficultyByName(config.DefaultBotDifficulty)
+	}
+	handler.voteKick = game.VoteKickConfig{
+		Fraction: float64(runtimeConfig.VoteKickPercent) / 100,
+		Window:   runtimeConfig.VoteKickWindow,
+		BlockFor: runtimeConfig.VoteKickBlock,
+	}
+	handler.bots = bot.NewController(handler.gameServer, botDifficulty, &game.RealClock{})
+	handler.roomManager.SetBotFiller(handler.bots)
 	handler.sessionFlow = handler.roomManager.SessionFlow()
 	handler.sessionRuntime = &gameSessionRuntime{
 		gameServer:       handler.gameServer,
+		sendMapLoad:      handler.sendMapLoad,
 		sendWeaponSpawns: handler.sendWeaponSpawns,
+		sendShieldSpawns: handler.sendShieldSpawns,
+		sendHealthSpawns: handler.sendHealthSpawns,
+		sendWorldSync:    handler.sendWorldSync,
+		recordedXP:       handler.recordedXP,
 	}
-	handler.matchEvents = game.NewMatchEventEmitter(&game.RealClock{}, handler)
+	handler.matchEvents = game.NewMatchEventEmitter(handler)
 
 	return handler
 }
@@ -155,16 +297,77 @@ func resetGlobalHandler() {
 	globalHandlerOnce = sync.Once{}
 }
 
-// Start starts the game server tick loop and match timer broadcasts
+// Start starts the game server tick loop, match timer broadcasts and lobby sandboxes
 func (h *WebSocketHandler) Start(ctx context.Context) {
+	h.loopsMu.Lock()
+	defer h.loopsMu.Unlock()
+
+	ctx, h.stopLoops = context.WithCancel(ctx)
 	h.gameServer.Start(ctx)
-	go h.matchTimerLoop(ctx)
-	go h.staleRoomSweepLoop(ctx)
+
+	h.loops.Add(6)
+	go func() {
+		defer h.loops.Done()
+		h.matchTimerLoop(ctx)
+	}()
+	go func() {
+		defer h.loops.Done()
+		h.staleRoomSweepLoop(ctx)
+	}()
+	go func() {
+		defer h.loops.Done()
+		h.lobbySandboxLoop(ctx)
+	}()
+	go func() {
+		defer h.loops.Done()
+		h.botLoop(ctx)
+	}()
+	go func() {
+		defer h.loops.Done()
+		h.queueStatusLoop(ctx)
+	}()
+	go func() {
+		defer h.loops.Done()
+		h.spawnOptionsLoop(ctx)
+	}()
+	if h.cluster != nil {
+		h.loops.Add(1)
+		go func() {
+			defer h.loops.Done()
+			h.clusterLoop(ctx)
+		}()
+	}
+	if h.weaponReports != nil {
+		h.loops.Add(1)
+		go func() {
+			defer h.loops.Done()
+			h.weaponReportLoop(ctx, h.weaponReportEvery)
+		}()
+	}
+	if h.leaderboard != nil {
+		h.loops.Add(1)
+		go func() {
+			defer h.loops.Done()
+			h.leaderboardLoop(ctx, h.leaderboardEvery)
+		}()
+	}
 }
 
-// Stop stops the game server
+// Stop stops the timer loops and the game server and waits for them to exit
 func (h *WebSocketHandler) Stop() {
+	// Held while waiting so a concurrent Start cannot add loops mid-wait
+	h.loopsMu.Lock()
+	if h.stopLoops != nil {
+		h.stopLoops()
+	}
+	h.loops.Wait()
 	h.gameServer.Stop()
+	h.loopsMu.Unlock()
+
+	h.recorder.stopAll()
+	if h.replays != nil {
+		h.replays.stopAll()
+	}
 }
 
 // StartGlobalHandler starts the global handler's game server
@@ -172,6 +375,11 @@ func StartGlobalHandler(ctx context.Context) {
 	getGlobalHandler().Start(ctx)
 }
 
+// DrainGlobalHandler drains the global handler before it is stopped
+func DrainGlobalHandler(ctx context.Context, countdown time.Duration) {
+	getGlobalHandler().Drain(ctx, countdown)
+}
+
 // StopGlobalHandler stops the global handler's game server
 func StopGlobalHandler() {
 	getGlobalHandler().Stop()
@@ -198,80 +406,208 @@ func (h *WebSocketHandler) buildOutgoingMessage(messageType string, data interfa
 
 // HandleWebSocket upgrades HTTP connection to WebSocket and manages message loop
 func (h *WebSocketHandler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
+	userID, address, ok := h.admitClient(w, r, "WebSocket")
+	if !ok {
+		return
+	}
+
 	// Upgrade HTTP connection to WebSocket
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		log.Println("WebSocket upgrade failed:", err)
 		return
 	}
-	defer conn.Close()
 
-	// Create player with unique ID
-	playerID := uuid.New().String()
-	// Buffer size 256: Allows burst messages while preventing memory exhaustion.
-	// If buffer fills (slow/unresponsive client), messages are dropped with log warning.
-	sendChan := make(chan []byte, 256)
-	player := game.NewPlayer(playerID, sendChan)
+	// The upgrader picks the subprotocol in the server's order, not the client's
+	codec, err := negotiateCodec(r.URL.Query().Get("protocol"), conn.Subprotocol())
+	if err != nil {
+		log.Printf("WebSocket protocol negotiation failed: %v", err)
+		_ = conn.Close()
+		return
+	}
 
-	log.Printf("Client connected: %s", playerID)
-	_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+	h.serveClient(newWebSocketConn(conn, codec), codec, r, userID, address)
+}
 
-	// Setup ping/pong for RTT measurement (Story 4.5: Lag compensation)
-	var pingMu sync.Mutex
-	var lastPingTime time.Time
+// admitClient runs the checks a connection must pass before it is upgraded,
+// on any transport, and writes the HTTP error for one that fails. It returns
+// the authenticated user ID, empty with auth off, and the client's address.
+func (h *WebSocketHandler) admitClient(w http.ResponseWriter, r *http.Request, transport string) (userID, address string, ok bool) {
+	if h.draining.Load() {
+		http.Error(w, serverShutdownReason.text, http.StatusServiceUnavailable)
+		return "", "", false
+	}
 
-	conn.SetPongHandler(func(appData string) error {
-		pingMu.Lock()
-		defer pingMu.Unlock()
+	var err error
+	if _, err = negotiateCodec(r.URL.Query().Get("protocol"), ""); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return "", "", false
+	}
 
-		// Calculate RTT and record it
-		if !lastPingTime.IsZero() {
-			rtt := time.Since(lastPingTime)
-			player.PingTracker.RecordRTT(rtt)
-			log.Printf("Player %s RTT: %dms (avg: %dms)", playerID, rtt.Milliseconds(), player.PingTracker.GetRTT())
+	// With auth on, the verified user ID becomes the player ID
+	if h.auth.enabled() {
+		userID, err = h.auth.authenticate(r)
+		if err != nil {
+			log.Printf("Rejected %s connection from %s: %v", transport, r.RemoteAddr, err)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="stick-rumble"`)
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return "", "", false
 		}
-		_ = conn.SetReadDeadline(time.Now().Add(pongWait))
-		return nil
-	})
+	}
+	address = remoteHost(r)
+	if ban, banned := h.bans.banned(userID, address); banned {
+		log.Printf("Rejected %s connection from banned %s (user %q, ref %s)", transport, address, userID, ban.Reference)
+		http.Error(w, banCloseReason(ban), http.StatusForbidden)
+		return "", "", false
+	}
+	return userID, address, true
+}
 
-	// Start goroutine to send periodic pings for RTT measurement
+// serveClient runs an admitted connection's session until it disconnects:
+// binding or resuming the player, writing its messages and handling the
+// client's. It is the same for every transport.
+func (h *WebSocketHandler) serveClient(conn clientConn, codec Codec, r *http.Request, userID, address string) {
+	defer conn.Close(disconnectReason{})
+
+	// Re-bind to a parked player when the client presents its session token;
+	// otherwise create a player with a unique ID
+	closeConn := func(reason disconnectReason) {
+		if reason == sessionReplacedReason {
+			// Written directly: the send channel moves to the new connection
+			h.writeSessionReplaced(codec, conn.WriteFrame)
+		}
+		conn.Close(reason)
+	}
+	var player *game.Player
+	var sessionToken string
+	resumed := false
+	if token := r.URL.Query().Get("resume"); token != "" && h.resumer.enabled() {
+		// An authenticated client may only resume its own player
+		if owner, ok := h.resumer.playerID(token); ok && (userID == "" || owner == userID) {
+			player, sessionToken, resumed = h.resumer.resume(token, closeConn)
+		}
+	}
+	if !resumed {
+		if userID != "" && !h.auth.claim(userID) {
+			// The newest connection wins: it takes over the account's player
+			// and the older one is told session:replaced and closed
+			player, sessionToken, resumed = h.resumer.replace(userID, closeConn)
+			if resumed {
+				log.Printf("Connection for user %s replaced the previous session", userID)
+			} else if !h.auth.claimAfterRelease(userID, resumeTakeoverWait) {
+				log.Printf("Rejected second connection for user %s", userID)
+				conn.Close(alreadyConnectedReason)
+				return
+			}
+		}
+	}
+	if !resumed {
+		id := uuid.New().String()
+		if userID != "" {
+			id = userID
+		}
+		// Buffer size 256: Allows burst messages while preventing memory exhaustion.
+		// Past it (slow/unresponsive client) the player's Outbox queues messages
+		// and drops stale state updates first (game/outbound_queue.go).
+		player = game.NewPlayer(id, make(chan []byte, 256))
+		sessionToken = h.resumer.issue(player, closeConn)
+	}
+	playerID := player.ID
+	sendChan := player.SendChan
+	h.bans.connect(playerID, address)
+	player.Address = address
+	if resumed {
+		// Whatever queued up while the player was away is stale; the client
+		// gets a fresh session status and full snapshot instead
+		player.Outbox.Drain()
+		h.deltaTracker.RemoveClient(playerID)
+		// The new connection numbers its inputs from the start
+		h.gameServer.ResetPlayerInput(playerID)
+	}
+
+	log.Printf("Client connected: %s (protocol %s, resumed %t)", playerID, codec.Name(), resumed)
+
+	// Transport keepalives detect a dead connection; net:ping (heartbeat.go)
+	// measures RTT
 	pingDone := make(chan struct{})
-	go func() {
-		ticker := time.NewTicker(pingInterval)
-		defer ticker.Stop()
+	defer close(pingDone) // Stop keepalive and heartbeat goroutines
+	conn.KeepAlive(playerID, pingDone)
+	hb := &heartbeat{}
+	go h.runHeartbeat(player, hb, closeConn, pingDone)
+
+	// Start goroutine to send messages to client
+	done := make(chan struct{})
+	stopWriter := make(chan struct{})
+	defer func() {
+		// Clean up on disconnect. Deferred so a panic while handling a
+		// message still releases the player and the writer goroutine.
+		if h.resumer.enabled() && player.HelloSeen && h.roomManager.GetRoomByPlayerID(playerID) != nil {
+			// Park the player for a resuming client: the writer stops but
+			// sendChan stays open for the next connection
+			close(stopWriter)
+			<-done
+			h.idlePlayer(playerID)
+			h.chaos.clear(playerID)
+			h.resumer.park(sessionToken, func() {
+				room := h.roomManager.GetRoomByPlayerID(playerID)
+				h.releasePlayer(player)
+				h.auth.release(playerID)
+				h.bans.disconnect(playerID)
+				h.forgetNames(playerID)
+				close(sendChan)
+				h.updateMatchPause(room)
+			})
+			h.updateMatchPause(h.roomManager.GetRoomByPlayerID(playerID))
+			return
+		}
+
+		h.resumer.forget(sessionToken)
+		defer func() {
+			close(sendChan)
+			<-done // Wait for send goroutine to finish
+		}()
+		h.releasePlayer(player)
+		h.auth.release(playerID)
+		h.bans.disconnect(playerID)
+		h.forgetNames(playerID)
+	}()
 
+	go func() {
+		defer close(done)
 		for {
+			var msg []byte
 			select {
-			case <-pingDone:
+			case <-stopWriter:
 				return
-			case <-ticker.C:
-				pingMu.Lock()
-				lastPingTime = time.Now()
-				pingMu.Unlock()
-
-				if err := conn.WriteControl(websocket.PingMessage, []byte{}, time.Now().Add(1*time.Second)); err != nil {
-					log.Printf("Ping error for %s: %v", playerID, err)
+			case next, ok := <-sendChan:
+				if !ok {
 					return
 				}
+				msg = next
+				player.Outbox.Refill()
 			}
-		}
-	}()
+			h.recordSessionMessage("out", playerID, msg)
 
-	// Start goroutine to send messages to client
-	done := make(chan struct{})
-	go func() {
-		defer close(done)
-		for msg := range sendChan {
-			// Capture msg for closure (Story 4.6: Network simulator)
-			msgToSend := msg
+			// Capture the encoded frame for closure (Story 4.6: Network simulator)
+			msgToSend, err := codec.Encode(msg)
+			if err != nil {
+				log.Printf("Encode error for %s: %v", playerID, err)
+				continue
+			}
+			writeFrame := h.frameWriter(conn, playerID, msg)
+			writeFrameLogged := func(frame []byte) {
+				if err := writeFrame(frame); err != nil {
+					log.Printf("Write error for %s: %v", playerID, err)
+				}
+			}
 			if h.networkSimulator.IsEnabled() {
 				h.networkSimulator.SimulateSend(func() {
-					if err := conn.WriteMessage(websocket.TextMessage, msgToSend); err != nil {
-						log.Printf("Write error for %s: %v", playerID, err)
-					}
+					h.chaos.deliver(playerID, msgToSend, writeFrameLogged)
 				})
+			} else if _, _, chaotic := h.chaos.get(playerID); chaotic {
+				h.chaos.deliver(playerID, msgToSend, writeFrameLogged)
 			} else {
-				if err := conn.WriteMessage(websocket.TextMessage, msgToSend); err != nil {
+				if err := writeFrame(msgToSend); err != nil {
 					log.Printf("Write error for %s: %v", playerID, err)
 					return
 				}
@@ -279,10 +615,19 @@ func (h *WebSocketHandler) HandleWebSocket(w http.ResponseWriter, r *http.Reques
 		}
 	}()
 
+	// Identify the server build and issue the session token before the
+	// client says hello
+	h.sendServerHello(player, sessionToken, resumed)
+	if resumed {
+		h.resumeSessionState(player)
+	}
+
 	// Message handling loop
+	malformed := 0 // Frames in a row that could not be decoded or parsed
 	for {
 		// Read message from client
-		_, messageBytes, err := conn.ReadMessage()
+		frame, err := conn.ReadFrame()
+		receivedAt := time.Now()
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				log.Printf("WebSocket error: %v", err)
@@ -292,19 +637,54 @@ func (h *WebSocketHandler) HandleWebSocket(w http.ResponseWriter, r *http.Reques
 			break
 		}
 
-		// Parse JSON message
+		if rejection, ok := checkClientFrameSize(frame); !ok {
+			log.Printf("Rejected %d-byte message from %s", len(frame), playerID)
+			h.sendPayloadRejected(player, "", rejection)
+			continue
+		}
+
+		messageBytes, err := codec.Decode(frame)
 		var msg Message
-		if err := json.Unmarshal(messageBytes, &msg); err != nil {
-			log.Printf("Failed to parse message: %v", err)
+		if err == nil {
+			// Parse JSON message
+			err = json.Unmarshal(messageBytes, &msg)
+		}
+		if err != nil {
+			log.Printf("Failed to read message from %s: %v", playerID, err)
+			if malformed++; malformed >= maxMalformedFrames {
+				// Removed rather than parked: resuming would not fix the client
+				h.kickPlayer(playerID, protocolErrorReason)
+				break
+			}
+			continue
+		}
+		malformed = 0
+
+		if rejection, ok := checkClientFields(msg); !ok {
+			log.Printf("Rejected message from %s: %s exceeds %d bytes", playerID, rejection.Field, rejection.Limit)
+			offendingType := msg.Type
+			if rejection.Field == "type" {
+				offendingType = ""
+			}
+			h.sendPayloadRejected(player, offendingType, rejection)
 			continue
 		}
 
 		log.Printf("Received from %s: type=%s, timestamp=%d", playerID, msg.Type, msg.Timestamp)
+		h.recordSessionMessage("in", playerID, messageBytes)
 
 		if msg.Type == "player:hello" {
 			h.handlePlayerHello(player, msg.Data)
 			continue
 		}
+		if msg.Type == "net:pong" {
+			h.handleNetPong(player, hb, msg.Data)
+			continue
+		}
+		if msg.Type == "time:sync_request" {
+			h.handleTimeSyncRequest(player, msg.Data, receivedAt)
+			continue
+		}
 
 		if !player.HelloSeen {
 			h.sendNoHelloError(player, msg.Type)
@@ -316,9 +696,26 @@ func (h *WebSocketHandler) HandleWebSocket(w http.ResponseWriter, r *http.Reques
 		case "session:leave":
 			h.handleSessionLeave(player)
 
+		case "room:roster_request":
+			h.handleRoomRosterRequest(player)
+
+		case "player:ready":
+			h.handlePlayerReady(playerID, msg.Data)
+
+		case "party:stay_together":
+			h.handlePartyStayTogether(player, msg.Data)
+
+		case "match:rematch_vote":
+			h.handleMatchRematchVote(player, msg.Data)
+
+		case "player:preferences":
+			h.handlePlayerPreferences(player, msg.Data)
+
 		case "input:state":
-			// Handle player input
-			h.handleInputState(playerID, msg.Data)
+			// Handle player input unless its timestamp marks it as replayed
+			if h.gameServer.AcceptInputTimestamp(playerID, msg.Timestamp) {
+				h.handleInputState(playerID, msg.Data, msg.Timestamp)
+			}
 
 		case "player:shoot":
 			// Handle player shooting
@@ -340,6 +737,47 @@ func (h *WebSocketHandler) HandleWebSocket(w http.ResponseWriter, r *http.Reques
 			// Handle player melee attack
 			h.handlePlayerMeleeAttack(playerID, msg.Data)
 
+		case "player:ultimate":
+			// Handle player ultimate activation
+			h.handlePlayerUltimate(playerID)
+
+		case "player:respawn_request":
+			// Handle a dead player asking to respawn
+			h.handlePlayerRespawnRequest(playerID)
+
+		case "player:ping":
+			// Handle a tactical ping for the player's team
+			h.handlePlayerPing(player, msg.Data)
+
+		case "player:emote":
+			// Handle an emote or quick-chat line for the room
+			h.handlePlayerEmote(player, msg.Data)
+
+		case "chat:message":
+			h.handleChatMessage(player, msg.Data)
+
+		case "chat:mute":
+			h.handleChatMute(player, msg.Data)
+
+		case "room:votekick":
+			h.handleRoomVoteKick(player, msg.Data)
+
+		case "player:spawn_choice":
+			// Handle a dead player picking where to respawn
+			h.handlePlayerSpawnChoice(playerID, msg.Data)
+
+		case "player:loadout":
+			h.handlePlayerLoadout(player, msg.Data)
+
+		case "player:rename":
+			h.handlePlayerRename(player, msg.Data)
+
+		case "state:ack":
+			h.handleStateAck(playerID, msg.Data)
+
+		case "feedback:submit":
+			h.handleFeedbackSubmit(player, msg.Data)
+
 		default:
 			// Broadcast other messages to room (for backward compatibility with tests)
 			room := h.roomManager.GetRoomByPlayerID(playerID)
@@ -349,16 +787,6 @@ func (h *WebSocketHandler) HandleWebSocket(w http.ResponseWriter, r *http.Reques
 		}
 	}
 
-	// Clean up on disconnect
-	close(pingDone) // Stop ping goroutine
-	h.roomManager.RemovePlayer(playerID)
-	if player.HelloSeen {
-		h.gameServer.RemovePlayer(playerID)
-	}
-	h.deltaTracker.RemoveClient(playerID) // Clean up delta compression state
-	close(sendChan)
-	<-done // Wait for send goroutine to finish
-
 	log.Printf("Connection closed: %s", playerID)
 }
 
@@ -379,23 +807,137 @@ func (h *WebSocketHandler) handlePlayerHello(player *game.Player, data any) {
 		return
 	}
 
+	if address, reason := h.clusterRedirect(dataMap); address != "" {
+		h.sendRoomRedirect(player, address, reason)
+		return
+	}
+
+	// A name differing from the account's current one is a rename
+	requested := game.FallbackDisplayName
+	if rawDisplayName, exists := dataMap["displayName"]; exists {
+		requested = game.SanitizeDisplayName(rawDisplayName)
+	}
+	dataMap["displayName"] = h.names.joinName(player.ID, requested)
+
 	result := h.sessionFlow.HandleHello(player, dataMap)
 	if result.Rejection != nil {
-		switch result.Rejection.Kind {
-		case game.RoomSessionRejectionBadRoomCode:
-			h.sendBadRoomCodeError(player, result.Rejection.Reason)
-		case game.RoomSessionRejectionRoomFull:
-			h.sendRoomFullError(player, result.Rejection.Code)
-		default:
-			log.Printf("Invalid player:hello mode for %s", player.ID)
-		}
+		h.sendHelloRejection(player, result.Rejection)
 		return
 	}
 
 	player.HelloSeen = true
+	h.names.change(player.ID, player.DisplayName)
+	h.applySessionResult(result)
+}
+
+// SetChatFilter replaces the profanity filter applied to room chat
+func (h *WebSocketHandler) SetChatFilter(filter ProfanityFilter) {
+	h.chat.setFilter(filter)
+}
+
+// forgetNames drops the name history of a player removed for good. With auth
+// on the player ID is the user's account, whose history outlives the
+// connection; without it the next connection is a new player anyway.
+func (h *WebSocketHandler) forgetNames(playerID string) {
+	if !h.auth.enabled() {
+		h.names.forget(playerID)
+	}
+}
+
+func (h *WebSocketHandler) sendHelloRejection(player *game.Player, rejection *game.RoomSessionRejection) {
+	switch rejection.Kind {
+	case game.RoomSessionRejectionBadRoomCode:
+		h.sendBadRoomCodeError(player, rejection.Reason)
+	case game.RoomSessionRejectionRoomFull:
+		h.sendRoomFullError(player, rejection.Code)
+	case game.RoomSessionRejectionAtCapacity:
+		h.sendCapacityNotice(player, rejection.QueuePosition, rejection.RedirectURL)
+	case game.RoomSessionRejectionVoteKicked:
+		h.sendRoomBlockedError(player, rejection.Code, rejection.RetryAfter)
+	default:
+		log.Printf("Invalid player:hello mode for %s", player.ID)
+	}
+}
+
+// admitQueuedPlayers lets players waiting on instance capacity in and keeps
+// the rest informed of their queue position.
+func (h *WebSocketHandler) admitQueuedPlayers() {
+	admissions, notices := h.sessionFlow.AdmitQueued()
+	for _, admission := range admissions {
+		if admission.Result.Rejection != nil {
+			h.sendHelloRejection(admission.Player, admission.Result.Rejection)
+			continue
+		}
+		admission.Player.HelloSeen = true
+		h.applySessionResult(admission.Result)
+	}
+	for _, notice := range notices {
+		h.sendCapacityNotice(notice.Player, notice.QueuePosition, notice.RedirectURL)
+	}
+}
+
+// applySessionResult publishes a session flow outcome and activates any newly
+// active players.
+func (h *WebSocketHandler) applySessionResult(result game.RoomSessionResult) {
 	h.roomManager.PublishSessionPublications(result.Publications)
+	h.roomManager.PublishRoomJoins(result.Joins)
+	h.roomManager.PublishReadyStates(result.ReadyChecks)
+	h.syncLobbySandboxes(result)
 	if len(result.Activations) > 0 {
 		h.sessionRuntime.ActivatePlayers(result.Activations)
+		if result.Room != nil && result.Room.Practice != nil {
+			h.sendPracticeStatus(result.Room)
+		}
+	}
+}
+
+// applyPartyResult starts a group that stayed together after match:ended in
+// its new room. Its players are re-added to the game so they start the new
+// match with fresh stats.
+func (h *WebSocketHandler) applyPartyResult(result game.RoomSessionResult) {
+	for _, activation := range result.Activations {
+		h.sessionRuntime.RemovePlayer(activation.Player.ID)
+		h.deltaTracker.RemoveClient(activation.Player.ID)
+	}
+	h.applySessionResult(result)
+}
+
+// applyRematchResult carries out a decided rematch vote. The room's players
+// are re-added to the game either way: at spawn points with fresh weapons and
+// stats for the restarted match, or once matchmaking places them again.
+func (h *WebSocketHandler) applyRematchResult(result game.RematchResult) {
+	if result.Room == nil {
+		return
+	}
+
+	for _, player := range result.Players {
+		h.sessionRuntime.RemovePlayer(player.ID)
+		h.deltaTracker.RemoveClient(player.ID)
+	}
+	if result.Restarted {
+		if err := h.publication.BroadcastMatchRestarted(result.Room, result.Players); err != nil {
+			log.Printf("Error building match:restarted message: %v", err)
+		}
+	}
+	for _, session := range result.Sessions {
+		h.applySessionResult(session)
+	}
+}
+
+// releasePlayer removes a disconnected player from matchmaking, its room and
+// the game
+func (h *WebSocketHandler) releasePlayer(player *game.Player) {
+	room := h.roomManager.GetRoomByPlayerID(player.ID)
+	h.roomManager.RemovePlayer(player.ID)
+	if player.HelloSeen {
+		h.gameServer.RemovePlayer(player.ID)
+	}
+	h.deltaTracker.RemoveClient(player.ID) // Clean up delta compression state
+	h.sandboxes.close(player.ID)
+	h.chaos.clear(player.ID)
+	if room != nil {
+		h.applyPartyResult(h.sessionFlow.ReformParty(room.ID))
+		h.applyRematchResult(h.sessionFlow.ReconcileRematch(room.ID))
 	}
 }
 
@@ -404,6 +946,7 @@ func (h *WebSocketHandler) handleSessionLeave(player *game.Player) {
 		return
 	}
 
+	room := h.roomManager.GetRoomByPlayerID(player.ID)
 	result := h.sessionFlow.LeaveSession(player.ID)
 	if !result.LeftSession {
 		return
@@ -411,8 +954,16 @@ func (h *WebSocketHandler) handleSessionLeave(player *game.Player) {
 	h.roomManager.PublishSessionPublications(result.Publications)
 	h.sessionRuntime.RemovePlayer(player.ID)
 	h.deltaTracker.RemoveClient(player.ID)
+	h.sandboxes.close(player.ID)
 	player.HelloSeen = false
 	player.DisplayName = game.FallbackDisplayName
+	player.Team = ""
+	player.Ready = false
+	player.StayTogether = false
+	if room != nil {
+		h.applyPartyResult(h.sessionFlow.ReformParty(room.ID))
+		h.applyRematchResult(h.sessionFlow.ReconcileRematch(room.ID))
+	}
 }
 
 func (h *WebSocketHandler) staleRoomSweepLoop(ctx context.Context) {
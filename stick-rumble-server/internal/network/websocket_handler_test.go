@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/mtomcal/stick-rumble-server/internal/buildinfo"
 	"github.com/mtomcal/stick-rumble-server/internal/game"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -43,23 +44,33 @@ type testServer struct {
 	cancel  context.CancelFunc
 }
 
-// newTestServer creates a test server with the default WebSocket handler
-func newTestServer() *testServer {
-	handler := NewWebSocketHandler()
-	server := httptest.NewServer(http.HandlerFunc(handler.HandleWebSocket))
-	ctx, cancel := context.WithCancel(context.Background())
-	handler.Start(ctx)
-	return &testServer{
-		Server:  server,
-		handler: handler,
-		ctx:     ctx,
-		cancel:  cancel,
+// testServerOption adjusts a test server's handler before it starts
+type testServerOption func(*WebSocketHandler)
+
+// withResumeGrace sets how long disconnected players stay parked for a
+// resuming client
+func withResumeGrace(grace time.Duration) testServerOption {
+	return func(handler *WebSocketHandler) {
+		handler.resumer = newSessionResumer(grace)
 	}
 }
 
+// newTestServer creates a test server with the default WebSocket handler
+func newTestServer(opts ...testServerOption) *testServer {
+	return startTestServer(NewWebSocketHandler(), opts)
+}
+
 // newTestServerWithConfig creates a test server with a custom timer interval
-func newTestServerWithConfig(timerInterval time.Duration) *testServer {
-	handler := NewWebSocketHandlerWithConfig(timerInterval)
+func newTestServerWithConfig(timerInterval time.Duration, opts ...testServerOption) *testServer {
+	return startTestServer(NewWebSocketHandlerWithConfig(timerInterval), opts)
+}
+
+// startTestServer applies opts to handler, then serves and starts it; the
+// handler's game loops read its fields from the moment it starts
+func startTestServer(handler *WebSocketHandler, opts []testServerOption) *testServer {
+	for _, opt := range opts {
+		opt(handler)
+	}
 	server := httptest.NewServer(http.HandlerFunc(handler.HandleWebSocket))
 	ctx, cancel := context.WithCancel(context.Background())
 	handler.Start(ctx)
@@ -78,6 +89,12 @@ func (ts *testServer) Close() {
 	ts.Server.Close()
 }
 
+// setAuthSecret requires connections to present tokens signed with secret;
+// call it before connecting clients
+func (ts *testServer) setAuthSecret(secret string) {
+	ts.handler.auth = newTokenAuthenticator(secret, time.Now)
+}
+
 // wsURL returns the WebSocket URL for the test server
 func (ts *testServer) wsURL() string {
 	return "ws" + strings.TrimPrefix(ts.URL, "http")
@@ -196,7 +213,57 @@ func sendShootMessage(t *testing.T, conn *websocket.Conn, aimAngle float64) {
 	sendMessage(t, conn, msg)
 }
 
+// sendShootMessageWithEffect sends a player:shoot message with an equipped trail effect
+func sendShootMessageWithEffect(t *testing.T, conn *websocket.Conn, aimAngle float64, effectID string) {
+	now := time.Now().UnixMilli()
+	msg := Message{
+		Type:      "player:shoot",
+		Timestamp: now,
+		Data: map[string]interface{}{
+			"aimAngle":        aimAngle,
+			"clientTimestamp": float64(now),
+			"effectId":        effectID,
+		},
+	}
+	sendMessage(t, conn, msg)
+}
+
 // sendReloadMessage sends a player:reload message
+func sendReadyMessage(t *testing.T, conn *websocket.Conn, ready bool) {
+	msg := Message{
+		Type:      "player:ready",
+		Timestamp: time.Now().UnixMilli(),
+		Data: map[string]interface{}{
+			"ready": ready,
+		},
+	}
+	sendMessage(t, conn, msg)
+}
+
+// sendStayTogetherMessage sends a party:stay_together vote
+func sendStayTogetherMessage(t *testing.T, conn *websocket.Conn, stay bool) {
+	msg := Message{
+		Type:      "party:stay_together",
+		Timestamp: time.Now().UnixMilli(),
+		Data: map[string]interface{}{
+			"stay": stay,
+		},
+	}
+	sendMessage(t, conn, msg)
+}
+
+// sendRematchVoteMessage sends a match:rematch_vote
+func sendRematchVoteMessage(t *testing.T, conn *websocket.Conn, vote bool) {
+	msg := Message{
+		Type:      "match:rematch_vote",
+		Timestamp: time.Now().UnixMilli(),
+		Data: map[string]interface{}{
+			"vote": vote,
+		},
+	}
+	sendMessage(t, conn, msg)
+}
+
 func sendReloadMessage(t *testing.T, conn *websocket.Conn) {
 	msg := Message{
 		Type:      "player:reload",
@@ -237,6 +304,14 @@ func consumeRoomJoinedAndGetPlayerID(t *testing.T, conn *websocket.Conn) string
 	_, err = readMessageOfType(t, conn, "weapon:spawned", 2*time.Second)
 	require.NoError(t, err, "Should receive weapon:spawned message")
 
+	// Consume shield:spawned message
+	_, err = readMessageOfType(t, conn, "shield:spawned", 2*time.Second)
+	require.NoError(t, err, "Should receive shield:spawned message")
+
+	// Consume health:spawned message
+	_, err = readMessageOfType(t, conn, "health:spawned", 2*time.Second)
+	require.NoError(t, err, "Should receive health:spawned message")
+
 	return playerID
 }
 
@@ -255,6 +330,25 @@ func TestWebSocketConnection(t *testing.T) {
 	assert.NotNil(t, conn)
 }
 
+func TestConnectionStartsWithServerHello(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn := ts.connectRawClient(t)
+	defer conn.Close()
+
+	msg, err := readMessage(t, conn, 2*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "server:hello", msg.Type, "server:hello is the first message on a connection")
+
+	data, ok := msg.Data.(map[string]interface{})
+	require.True(t, ok)
+	info := buildinfo.Get()
+	assert.Equal(t, info.Commit, data["commit"])
+	assert.Equal(t, info.BuildTime, data["buildTime"])
+	assert.Equal(t, info.GoVersion, data["goVersion"])
+}
+
 func TestGameplayMessageBeforeHelloReturnsError(t *testing.T) {
 	ts := newTestServer()
 	defer ts.Close()
@@ -394,6 +488,205 @@ func TestTwoClientRoomCreation(t *testing.T) {
 	assert.NotEqual(t, playerID1, playerID2, "Players should have different IDs")
 }
 
+func TestRoomRosterRequestReturnsCurrentRoster(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn1 := ts.connectRawClient(t)
+	defer conn1.Close()
+	conn2 := ts.connectRawClient(t)
+	defer conn2.Close()
+
+	sendHelloMessage(t, conn1, "Host", "code", "ROSTER")
+	_, _, err := readSessionStatus(t, conn1, "waiting_for_players", 2*time.Second)
+	require.NoError(t, err)
+	sendHelloMessage(t, conn2, "Guest Two", "code", "ROSTER")
+	_, data, err := readSessionStatus(t, conn2, "match_ready", 2*time.Second)
+	require.NoError(t, err)
+
+	sendMessage(t, conn2, Message{
+		Type:      "room:roster_request",
+		Timestamp: time.Now().UnixMilli(),
+	})
+
+	msg, err := readMessageOfType(t, conn2, "room:roster", 2*time.Second)
+	require.NoError(t, err)
+	rosterData, ok := msg.Data.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, data["roomId"], rosterData["roomId"])
+	assert.Equal(t, "ROSTER", rosterData["code"])
+
+	players, ok := rosterData["players"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, players, 2)
+	assert.Equal(t, "Host", players[0].(map[string]interface{})["displayName"])
+	assert.Equal(t, "Guest Two", players[1].(map[string]interface{})["displayName"])
+}
+
+func TestCapacityQueueAdmitsPlayerWhenSlotFrees(t *testing.T) {
+	// A parked player holds its slot until the grace period ends
+	ts := newTestServerWithConfig(50*time.Millisecond, withResumeGrace(100*time.Millisecond))
+	defer ts.Close()
+	ts.handler.roomManager.SetCapacityLimits(game.CapacityLimits{MaxPlayers: 1})
+
+	conn1 := ts.connectRawClient(t)
+	conn2 := ts.connectRawClient(t)
+	defer conn2.Close()
+
+	sendHelloMessage(t, conn1, "Resident", "code", "FIRST")
+	_, _, err := readSessionStatus(t, conn1, "waiting_for_players", 2*time.Second)
+	require.NoError(t, err)
+
+	sendHelloMessage(t, conn2, "Overflow", "code", "SECOND")
+	msg, err := readMessageOfType(t, conn2, "session:capacity", 2*time.Second)
+	require.NoError(t, err)
+	capacityData, ok := msg.Data.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, float64(1), capacityData["queuePosition"])
+
+	require.NoError(t, conn1.Close())
+
+	_, data, err := readSessionStatus(t, conn2, "waiting_for_players", 3*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "SECOND", data["code"])
+}
+
+func TestPlayerReadyStartsMatchOnceEveryoneIsReady(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn1, conn2 := ts.connectTwoClients(t)
+	defer conn1.Close()
+	defer conn2.Close()
+
+	player1ID := consumeRoomJoinedAndGetPlayerID(t, conn1)
+	_ = consumeRoomJoinedAndGetPlayerID(t, conn2)
+
+	room := ts.handler.roomManager.GetRoomByPlayerID(player1ID)
+	require.NotNil(t, room)
+	assert.False(t, room.Match.IsStarted(), "match should wait for the ready check")
+
+	sendReadyMessage(t, conn1, true)
+	msg, err := readMessageOfType(t, conn2, "room:ready_state", 2*time.Second)
+	require.NoError(t, err)
+	for msg.Data.(map[string]interface{})["readyCount"] != float64(1) {
+		msg, err = readMessageOfType(t, conn2, "room:ready_state", 2*time.Second)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, false, msg.Data.(map[string]interface{})["started"])
+
+	sendReadyMessage(t, conn2, true)
+	for msg.Data.(map[string]interface{})["started"] != true {
+		msg, err = readMessageOfType(t, conn1, "room:ready_state", 2*time.Second)
+		require.NoError(t, err)
+	}
+	assert.True(t, room.Match.IsStarted())
+}
+
+func TestPartyStayTogetherRequeuesGroupIntoNewRoom(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn1, conn2 := ts.connectTwoClients(t)
+	defer conn1.Close()
+	defer conn2.Close()
+
+	player1ID := consumeRoomJoinedAndGetPlayerID(t, conn1)
+	player2ID := consumeRoomJoinedAndGetPlayerID(t, conn2)
+
+	room := ts.handler.roomManager.GetRoomByPlayerID(player1ID)
+	require.NotNil(t, room)
+	room.Match.Start()
+	player1, _ := ts.handler.gameServer.GetWorld().GetPlayer(player1ID)
+	player1.IncrementKills()
+	room.Match.EndMatch("kill_target")
+
+	sendStayTogetherMessage(t, conn1, true)
+	msg, err := readMessageOfType(t, conn2, "party:state", 2*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{player1ID}, msg.Data.(map[string]interface{})["stayingPlayerIds"])
+
+	sendStayTogetherMessage(t, conn2, true)
+	_, data, err := readSessionStatus(t, conn1, "match_ready", 2*time.Second)
+	require.NoError(t, err)
+	newRoomID := data["roomId"].(string)
+	assert.NotEqual(t, room.ID, newRoomID, "the group moves into a fresh room")
+	_, data, err = readSessionStatus(t, conn2, "match_ready", 2*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, newRoomID, data["roomId"])
+
+	party := ts.handler.roomManager.GetRoomByPlayerID(player2ID)
+	require.NotNil(t, party)
+	assert.Equal(t, newRoomID, party.ID)
+	assert.False(t, party.Match.IsEnded())
+	state, exists := ts.handler.gameServer.GetPlayerState(player1ID)
+	require.True(t, exists)
+	assert.Zero(t, state.Kills, "the next match starts with fresh stats")
+}
+
+func TestRematchVoteRestartsMatchInSameRoom(t *testing.T) {
+	withSchemaValidation(t)
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn1, conn2 := ts.connectTwoClients(t)
+	defer conn1.Close()
+	defer conn2.Close()
+
+	player1ID := consumeRoomJoinedAndGetPlayerID(t, conn1)
+	player2ID := consumeRoomJoinedAndGetPlayerID(t, conn2)
+
+	room := ts.handler.roomManager.GetRoomByPlayerID(player1ID)
+	require.NotNil(t, room)
+	room.Match.Start()
+	room.Match.AddKill(player1ID)
+	player1, _ := ts.handler.gameServer.GetWorld().GetPlayer(player1ID)
+	player1.IncrementKills()
+	room.Match.EndMatch("kill_target")
+	ts.handler.broadcastMatchEnded(room, ts.handler.gameServer.GetWorld())
+	require.True(t, room.InRematchVote())
+
+	sendRematchVoteMessage(t, conn1, true)
+	sendRematchVoteMessage(t, conn2, true)
+	msg, err := readMessageOfType(t, conn2, "match:restarted", 2*time.Second)
+	require.NoError(t, err)
+	data := msg.Data.(map[string]interface{})
+	assert.Equal(t, room.ID, data["roomId"])
+	assert.ElementsMatch(t, []interface{}{player1ID, player2ID}, data["playerIds"])
+
+	assert.Equal(t, room, ts.handler.roomManager.GetRoomByPlayerID(player2ID))
+	assert.True(t, room.Match.IsStarted())
+	assert.Equal(t, map[string]int{player1ID: 0, player2ID: 0}, room.Match.KillMap())
+	require.Eventually(t, func() bool {
+		state, exists := ts.handler.gameServer.GetPlayerState(player1ID)
+		return exists && state.Kills == 0
+	}, time.Second, 10*time.Millisecond, "the rematch starts with fresh stats")
+}
+
+func TestRematchVoteAgainstDissolvesRoomIntoMatchmaking(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	conn1, conn2 := ts.connectTwoClients(t)
+	defer conn1.Close()
+	defer conn2.Close()
+
+	player1ID := consumeRoomJoinedAndGetPlayerID(t, conn1)
+	_ = consumeRoomJoinedAndGetPlayerID(t, conn2)
+
+	room := ts.handler.roomManager.GetRoomByPlayerID(player1ID)
+	require.NotNil(t, room)
+	room.Match.Start()
+	room.Match.EndMatch("kill_target")
+	ts.handler.broadcastMatchEnded(room, ts.handler.gameServer.GetWorld())
+
+	sendRematchVoteMessage(t, conn1, false)
+	_, data, err := readSessionStatus(t, conn1, "match_ready", 2*time.Second)
+	require.NoError(t, err, "both players are matched again through matchmaking")
+	assert.NotEqual(t, room.ID, data["roomId"])
+	assert.Nil(t, ts.handler.roomManager.GetRoom(room.ID))
+}
+
 func TestSessionLeaveRemovesWaitingPublicPlayerAndAllowsRetry(t *testing.T) {
 	ts := newTestServer()
 	defer ts.Close()
@@ -459,6 +752,13 @@ func TestSessionLeaveIgnoredAfterMatchStart(t *testing.T) {
 	player1ID := consumeRoomJoinedAndGetPlayerID(t, conn1)
 	_ = consumeRoomJoinedAndGetPlayerID(t, conn2)
 
+	sendReadyMessage(t, conn1, true)
+	sendReadyMessage(t, conn2, true)
+	require.Eventually(t, func() bool {
+		room := ts.handler.roomManager.GetRoomByPlayerID(player1ID)
+		return room != nil && room.Match.IsStarted()
+	}, time.Second, 10*time.Millisecond, "both players readying up should start the match")
+
 	sendMessage(t, conn1, Message{
 		Type:      "session:leave",
 		Timestamp: time.Now().UnixMilli(),
@@ -606,7 +906,7 @@ func TestHandleSessionLeaveIgnoredForActivePlayerPreservesSessionState(t *testin
 }
 
 func TestPlayerDisconnection(t *testing.T) {
-	ts := newTestServer()
+	ts := newTestServer(withResumeGrace(100 * time.Millisecond)) // player:left follows the grace period
 	defer ts.Close()
 
 	conn1, conn2 := ts.connectTwoClients(t)
@@ -0,0 +1,147 @@
+package network
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/config"
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
+	"github.com/quic-go/webtransport-go"
+)
+
+// WebTransportPath is where the WebTransport server accepts sessions
+const WebTransportPath = "/wt"
+
+// webTransportStreamWait is how long opening a new session's message stream
+// may wait for the client's stream limit
+const webTransportStreamWait = 10 * time.Second
+
+// NewWebTransportServer returns a WebTransport (HTTP/3) server on addr whose
+// sessions are served like /ws connections. Start it with ListenAndServeTLS
+// and stop it with Close.
+func (h *WebSocketHandler) NewWebTransportServer(addr string) *webtransport.Server {
+	runtimeConfig := config.Load()
+	server := &webtransport.Server{
+		H3: &http3.Server{
+			Addr: addr,
+			TLSConfig: &tls.Config{
+				MinVersion: tls.VersionTLS13,
+				NextProtos: []string{http3.NextProtoH3},
+			},
+			// QUIC keepalives replace WebSocket control pings: a client that
+			// stops answering times out and its stream read fails
+			QUICConfig: &quic.Config{
+				KeepAlivePeriod: pingInterval,
+				MaxIdleTimeout:  pongWait,
+			},
+		},
+		CheckOrigin: func(r *http.Request) bool {
+			return runtimeConfig.AllowsOrigin(r.Header.Get("Origin"))
+		},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(WebTransportPath, func(w http.ResponseWriter, r *http.Request) {
+		h.HandleWebTransport(server, w, r)
+	})
+	server.H3.Handler = mux
+	webtransport.ConfigureHTTP3Server(server.H3)
+	return server
+}
+
+// NewWebTransportServer returns a WebTransport server for the shared global
+// handler
+func NewWebTransportServer(addr string) *webtransport.Server {
+	return getGlobalHandler().NewWebTransportServer(addr)
+}
+
+// HandleWebTransport upgrades a WebTransport session request on server and
+// runs the session. The server opens one bidirectional stream for reliable
+// messages, starting with server:hello, and also sends acknowledged
+// state:delta messages as datagrams.
+func (h *WebSocketHandler) HandleWebTransport(server *webtransport.Server, w http.ResponseWriter, r *http.Request) {
+	userID, address, ok := h.admitClient(w, r, "WebTransport")
+	if !ok {
+		return
+	}
+
+	session, err := server.Upgrade(w, r)
+	if err != nil {
+		log.Printf("WebTransport upgrade failed: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(session.Context(), webTransportStreamWait)
+	stream, err := session.OpenStreamSync(ctx)
+	cancel()
+	if err != nil {
+		log.Printf("WebTransport session from %s got no message stream: %v", address, err)
+		_ = session.CloseWithError(0, "")
+		return
+	}
+
+	// Checked by admitClient; the codec is chosen by the query parameter alone
+	codec, _ := negotiateCodec(r.URL.Query().Get("protocol"), "")
+	h.serveClient(&webTransportConn{session: session, stream: stream}, codec, r, userID, address)
+}
+
+// webTransportConn carries a session over WebTransport. Frames on the
+// stream are prefixed with their length as a 4-byte big-endian integer;
+// datagrams hold one frame each.
+type webTransportConn struct {
+	session *webtransport.Session
+	stream  *webtransport.Stream
+
+	// Simulated and chaos-delayed frames are written from other goroutines;
+	// a length prefix and its frame must not interleave with another write
+	writeMu sync.Mutex
+}
+
+func (c *webTransportConn) ReadFrame() ([]byte, error) {
+	var prefix [4]byte
+	if _, err := io.ReadFull(c.stream, prefix[:]); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(prefix[:])
+	if size > maxClientFrameBytes {
+		// Like the WebSocket read limit, an oversized frame ends the connection
+		return nil, fmt.Errorf("frame of %d bytes exceeds %d", size, maxClientFrameBytes)
+	}
+	frame := make([]byte, size)
+	if _, err := io.ReadFull(c.stream, frame); err != nil {
+		return nil, err
+	}
+	return frame, nil
+}
+
+func (c *webTransportConn) WriteFrame(frame []byte) error {
+	buf := make([]byte, 4+len(frame))
+	binary.BigEndian.PutUint32(buf, uint32(len(frame)))
+	copy(buf[4:], frame)
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	_, err := c.stream.Write(buf)
+	return err
+}
+
+func (c *webTransportConn) SendDatagram(frame []byte) error {
+	return c.session.SendDatagram(frame)
+}
+
+// Close sends reason as the session's error code and message; a zero reason
+// closes the session with code 0 and no message
+func (c *webTransportConn) Close(reason disconnectReason) {
+	_ = c.session.CloseWithError(webtransport.SessionErrorCode(reason.code), closeReasonText(reason.text))
+}
+
+// KeepAlive has nothing to start: the QUIC connection sends its own keepalives
+func (c *webTransportConn) KeepAlive(playerID string, stop <-chan struct{}) {}
@@ -0,0 +1,237 @@
+package network
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/webtransport-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// webTransportClient is a test client holding a WebTransport session and its
+// message stream
+type webTransportClient struct {
+	session *webtransport.Session
+	stream  *webtransport.Stream
+}
+
+// selfSignedCertificate returns a certificate for 127.0.0.1 and a pool that
+// trusts it
+func selfSignedCertificate(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "stick-rumble test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+		DNSNames:              []string{"localhost"},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	parsed, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(parsed)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, pool
+}
+
+// startWebTransport serves ts's handler over WebTransport on a local UDP port
+// and returns a function that dials it with a query string
+func startWebTransport(t *testing.T, ts *testServer) func(query string) (*http.Response, *webtransport.Session, error) {
+	t.Helper()
+	cert, pool := selfSignedCertificate(t)
+	server := ts.handler.NewWebTransportServer("")
+	server.H3.TLSConfig.Certificates = []tls.Certificate{cert}
+
+	udpConn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	go func() { _ = server.Serve(udpConn) }()
+	t.Cleanup(func() {
+		_ = server.Close()
+		_ = udpConn.Close()
+	})
+
+	url := fmt.Sprintf("https://127.0.0.1:%d%s", udpConn.LocalAddr().(*net.UDPAddr).Port, WebTransportPath)
+	dialer := &webtransport.Dialer{
+		TLSClientConfig: &tls.Config{RootCAs: pool},
+		QUICConfig:      &quic.Config{EnableDatagrams: true, EnableStreamResetPartialDelivery: true},
+	}
+	return func(query string) (*http.Response, *webtransport.Session, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		return dialer.Dial(ctx, url+query, nil)
+	}
+}
+
+// connectWebTransport opens a session and accepts its message stream
+func connectWebTransport(t *testing.T, dial func(string) (*http.Response, *webtransport.Session, error), query string) *webTransportClient {
+	t.Helper()
+	_, session, err := dial(query)
+	require.NoError(t, err, "Should open a WebTransport session")
+	t.Cleanup(func() { _ = session.CloseWithError(0, "") })
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	stream, err := session.AcceptStream(ctx)
+	require.NoError(t, err, "The server should open the message stream")
+	return &webTransportClient{session: session, stream: stream}
+}
+
+func (c *webTransportClient) send(t *testing.T, msg Message) {
+	t.Helper()
+	frame, err := json.Marshal(msg)
+	require.NoError(t, err)
+	buf := binary.BigEndian.AppendUint32(nil, uint32(len(frame)))
+	_, err = c.stream.Write(append(buf, frame...))
+	require.NoError(t, err)
+}
+
+func (c *webTransportClient) read(timeout time.Duration) (*Message, error) {
+	_ = c.stream.SetReadDeadline(time.Now().Add(timeout))
+	var prefix [4]byte
+	if _, err := io.ReadFull(c.stream, prefix[:]); err != nil {
+		return nil, err
+	}
+	frame := make([]byte, binary.BigEndian.Uint32(prefix[:]))
+	if _, err := io.ReadFull(c.stream, frame); err != nil {
+		return nil, err
+	}
+	var msg Message
+	if err := json.Unmarshal(frame, &msg); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+func (c *webTransportClient) readOfType(t *testing.T, msgType string, timeout time.Duration) *Message {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for {
+		msg, err := c.read(time.Until(deadline))
+		require.NoError(t, err, "Should receive %s", msgType)
+		if msg.Type == msgType {
+			return msg
+		}
+	}
+}
+
+func sendWebTransportHello(t *testing.T, client *webTransportClient, mode, code string) {
+	data := map[string]interface{}{"displayName": "Datagram Dan", "mode": mode}
+	if code != "" {
+		data["code"] = code
+	}
+	client.send(t, Message{Type: "player:hello", Timestamp: time.Now().UnixMilli(), Data: data})
+}
+
+func TestWebTransportSessionRunsLikeWebSocket(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	dial := startWebTransport(t, ts)
+
+	client := connectWebTransport(t, dial, "")
+	msg, err := client.read(2 * time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "server:hello", msg.Type, "server:hello is the first message on a session")
+
+	sendWebTransportHello(t, client, "public", "")
+	status := client.readOfType(t, "session:status", 2*time.Second)
+	data, ok := status.Data.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "searching_for_match", data["state"])
+	assert.Equal(t, "Datagram Dan", data["displayName"])
+}
+
+func TestWebTransportRejectsUnknownProtocol(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	dial := startWebTransport(t, ts)
+
+	rsp, _, err := dial("?protocol=carrier-pigeon")
+	require.Error(t, err)
+	require.NotNil(t, rsp)
+	assert.Equal(t, http.StatusBadRequest, rsp.StatusCode)
+}
+
+func TestWebTransportSendsAcknowledgedDeltasAsDatagrams(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	ts.setAuthSecret(testAuthSecret)
+	dial := startWebTransport(t, ts)
+
+	playerID := "wt-player"
+	client := connectWebTransport(t, dial, "?token="+signToken(t, testAuthSecret, map[string]any{"sub": playerID}))
+	sendWebTransportHello(t, client, "code", "DGRAM")
+	client.readOfType(t, "session:status", 2*time.Second)
+
+	delta := []byte(`{"type":"state:delta","timestamp":1,"data":{"seq":1}}`)
+	snapshot := []byte(`{"type":"state:snapshot","timestamp":2,"data":{"seq":2}}`)
+
+	// Until the client acknowledges state, deltas stay on the stream
+	require.True(t, ts.handler.roomManager.SendToPlayer(playerID, delta))
+	assert.Equal(t, "state:delta", client.readOfType(t, "state:delta", 2*time.Second).Type)
+
+	seq := sendTrackedState(ts.handler.deltaTracker, playerID, nil, nil)
+	require.True(t, ts.handler.deltaTracker.Acknowledge(playerID, seq))
+
+	require.True(t, ts.handler.roomManager.SendToPlayer(playerID, delta))
+	require.True(t, ts.handler.roomManager.SendToPlayer(playerID, snapshot))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	datagram, err := client.session.ReceiveDatagram(ctx)
+	require.NoError(t, err, "An acknowledged delta should arrive as a datagram")
+	assert.JSONEq(t, string(delta), string(datagram))
+
+	assert.Equal(t, "state:snapshot", client.readOfType(t, "state:snapshot", 2*time.Second).Type,
+		"Snapshots stay on the reliable stream")
+}
+
+func TestWebTransportKickClosesSessionWithCode(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	ts.setAuthSecret(testAuthSecret)
+	dial := startWebTransport(t, ts)
+
+	playerID := "wt-kicked"
+	client := connectWebTransport(t, dial, "?token="+signToken(t, testAuthSecret, map[string]any{"sub": playerID}))
+	sendWebTransportHello(t, client, "code", "KICKS")
+	client.readOfType(t, "session:status", 2*time.Second)
+
+	require.True(t, ts.handler.kickPlayer(playerID, kickedReason("griefing")))
+
+	var sessionErr *webtransport.SessionError
+	for {
+		_, err := client.read(2 * time.Second)
+		if err == nil {
+			continue
+		}
+		require.True(t, errors.As(err, &sessionErr), "Expected a session error, got %v", err)
+		break
+	}
+	assert.Equal(t, webtransport.SessionErrorCode(CloseKicked), sessionErr.ErrorCode)
+	assert.Equal(t, "griefing", sessionErr.Message)
+}
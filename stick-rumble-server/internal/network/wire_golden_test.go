@@ -0,0 +1,620 @@
+package network
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/buildinfo"
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Golden wire-format tests produce every outgoing message type through the
+// code that sends it, with fixed inputs, and compare the serialized bytes to
+// testdata/golden/<type>.json. Schema validation checks that a payload is
+// allowed; these catch any change to what is actually sent, down to field
+// order. After an intended wire change, rewrite the files with:
+//
+//	go test ./internal/network -run TestWireFormatGolden -update
+var updateGolden = flag.Bool("update", false, "rewrite the golden wire-format files in testdata/golden")
+
+const (
+	goldenRoomID    = "golden-room"
+	goldenTimestamp = 1767225600000
+)
+
+// goldenTime is the fixed clock of the golden fixture's message builder
+var goldenTime = time.UnixMilli(goldenTimestamp).UTC()
+
+// goldenTimestampPattern matches envelope timestamps taken from the wall clock
+// by senders that do not go through the message builder
+var goldenTimestampPattern = regexp.MustCompile(`"timestamp":[1-9][0-9]*`)
+
+// goldenUnsentTypes have a server-to-client schema but no sender in the
+// server, so they have no golden file
+var goldenUnsentTypes = map[string]bool{
+	"player-move-data":        true, // Superseded by state:snapshot and state:delta
+	"projectile-destroy-data": true, // Clients drop projectiles from state:delta
+	"room-joined-data":        true, // Legacy bootstrap, replaced by session:status
+}
+
+// goldenFixture is a handler with a two-player code room. Sender acts and
+// receiver's send channel is where the messages are read from.
+type goldenFixture struct {
+	handler  *WebSocketHandler
+	room     *game.Room
+	sender   *game.Player
+	receiver *game.Player
+}
+
+func newGoldenFixture(t *testing.T) *goldenFixture {
+	t.Helper()
+
+	handler := NewWebSocketHandler()
+	handler.outgoingMessages = newOutgoingMessageBuilder(handler.outgoingValidator, func() time.Time { return goldenTime })
+	handler.publication = newServerToClientPublication(handler.outgoingMessages, handler.roomManager)
+	handler.roomManager.SetPublisher(handler.publication)
+
+	sender := game.NewPlayer("player-a", make(chan []byte, 64))
+	sender.DisplayName = "Alpha"
+	receiver := game.NewPlayer("player-b", make(chan []byte, 64))
+	receiver.DisplayName = "Bravo"
+
+	room, ok := handler.roomManager.AddCodePlayer(sender, "GOLDEN")
+	require.True(t, ok)
+	_, ok = handler.roomManager.AddCodePlayer(receiver, "GOLDEN")
+	require.True(t, ok)
+
+	f := &goldenFixture{handler: handler, room: room, sender: sender, receiver: receiver}
+	f.drain()
+	return f
+}
+
+func (f *goldenFixture) drain() {
+	for {
+		select {
+		case <-f.receiver.SendChan:
+		default:
+			return
+		}
+	}
+}
+
+// received returns the last message of messageType waiting on the
+// receiver's send channel
+func (f *goldenFixture) received(t *testing.T, messageType string) []byte {
+	t.Helper()
+
+	var found []byte
+	for {
+		select {
+		case msgBytes := <-f.receiver.SendChan:
+			var msg Message
+			require.NoError(t, json.Unmarshal(msgBytes, &msg))
+			if msg.Type == messageType {
+				found = msgBytes
+			}
+		default:
+			require.NotNil(t, found, "no %s message was sent to the receiver", messageType)
+			return found
+		}
+	}
+}
+
+type goldenCase struct {
+	messageType string
+	send        func(t *testing.T, f *goldenFixture) []byte
+}
+
+// goldenCases sends each outgoing message type once
+var goldenCases = []goldenCase{
+	{"server:hello", func(t *testing.T, f *goldenFixture) []byte {
+		info := buildinfo.Info{Commit: "abc1234", BuildTime: "2026-01-01T00:00:00Z", GoVersion: "go1.25.0"}
+		require.NoError(t, f.handler.publication.SendServerHello(f.receiver, info, "session-token", true))
+		return f.received(t, "server:hello")
+	}},
+	{"session:status", func(t *testing.T, f *goldenFixture) []byte {
+		f.room.MapID = "default_office"
+		require.NoError(t, f.handler.publication.PublishSessionStatus(f.receiver, f.room, game.SessionStatusMatchReady))
+		return f.received(t, "session:status")
+	}},
+	{"session:capacity", func(t *testing.T, f *goldenFixture) []byte {
+		f.handler.sendCapacityNotice(f.receiver, 3, "https://overflow.example.com/play")
+		return f.received(t, "session:capacity")
+	}},
+	{"room:redirect", func(t *testing.T, f *goldenFixture) []byte {
+		f.handler.sendRoomRedirect(f.receiver, "wss://game-2.example.com/ws", RedirectReasonLeastLoaded)
+		return f.received(t, "room:redirect")
+	}},
+	{"session:replaced", func(t *testing.T, f *goldenFixture) []byte {
+		var frame []byte
+		f.handler.writeSessionReplaced(jsonCodec{}, func(b []byte) error {
+			frame = b
+			return nil
+		})
+		return frame
+	}},
+	{"error:no_hello", func(t *testing.T, f *goldenFixture) []byte {
+		f.handler.sendNoHelloError(f.receiver, "input:state")
+		return f.received(t, "error:no_hello")
+	}},
+	{"error:payload_rejected", func(t *testing.T, f *goldenFixture) []byte {
+		f.handler.sendPayloadRejected(f.receiver, "player:rename", payloadRejection{Reason: "field_too_long", Field: "displayName", Limit: 24})
+		return f.received(t, "error:payload_rejected")
+	}},
+	{"error:bad_room_code", func(t *testing.T, f *goldenFixture) []byte {
+		f.handler.sendBadRoomCodeError(f.receiver, "too_short")
+		return f.received(t, "error:bad_room_code")
+	}},
+	{"error:room_full", func(t *testing.T, f *goldenFixture) []byte {
+		f.handler.sendRoomFullError(f.receiver, "GOLDEN")
+		return f.received(t, "error:room_full")
+	}},
+	{"error:room_blocked", func(t *testing.T, f *goldenFixture) []byte {
+		f.handler.sendRoomBlockedError(f.receiver, "GOLDEN", 5*time.Minute)
+		return f.received(t, "error:room_blocked")
+	}},
+	{"player:joined", func(t *testing.T, f *goldenFixture) []byte {
+		f.sender.Team = game.TeamAlpha
+		require.NoError(t, f.handler.publication.PublishPlayerJoined(f.room, f.sender))
+		return f.received(t, "player:joined")
+	}},
+	{"player:left", func(t *testing.T, f *goldenFixture) []byte {
+		require.NoError(t, f.handler.publication.PublishPlayerLeft(f.room, "player-c"))
+		return f.received(t, "player:left")
+	}},
+	{"room:roster", func(t *testing.T, f *goldenFixture) []byte {
+		require.NoError(t, f.handler.publication.SendRoomRoster(f.receiver, f.room))
+		return f.received(t, "room:roster")
+	}},
+	{"room:ready_state", func(t *testing.T, f *goldenFixture) []byte {
+		require.NoError(t, f.handler.publication.PublishReadyState(f.room, game.ReadyCheckState{
+			RoomID:           f.room.ID,
+			ReadyPlayerIDs:   []string{"player-a"},
+			ReadyCount:       1,
+			RequiredCount:    2,
+			TotalPlayers:     2,
+			RemainingSeconds: 12,
+			Active:           true,
+		}))
+		return f.received(t, "room:ready_state")
+	}},
+	{"party:state", func(t *testing.T, f *goldenFixture) []byte {
+		require.NoError(t, f.handler.publication.PublishPartyState(f.room, game.PartyState{
+			RoomID:           f.room.ID,
+			StayingPlayerIDs: []string{"player-a"},
+			TotalPlayers:     2,
+		}))
+		return f.received(t, "party:state")
+	}},
+	{"map:load", func(t *testing.T, f *goldenFixture) []byte {
+		require.NoError(t, f.handler.publication.SendMapLoad(f.receiver, game.MapConfig{
+			ID:     "golden_map",
+			Name:   "Golden Map",
+			Width:  1920,
+			Height: 1080,
+			Obstacles: []game.MapObstacle{{
+				ID: "wall-1", Type: "wall", Shape: "rectangle", X: 100, Y: 200, Width: 300, Height: 40,
+				BlocksMovement: true, BlocksProjectiles: true, BlocksLineOfSight: true,
+			}},
+		}))
+		return f.received(t, "map:load")
+	}},
+	{"lobby:sandbox_state", func(t *testing.T, f *goldenFixture) []byte {
+		require.NoError(t, f.handler.publication.SendLobbySandboxState(f.receiver.ID, game.LobbySandboxState{
+			Player: game.PlayerStateSnapshot{Position: game.Vector2{X: 240, Y: 270}, Velocity: game.Vector2{X: 120}, AimAngle: 0.5},
+			Dummy:  game.PlayerStateSnapshot{Position: game.Vector2{X: 720, Y: 270}, Health: 75},
+			Projectiles: []game.ProjectileSnapshot{
+				{ID: "proj-1", Position: game.Vector2{X: 400, Y: 270}, Velocity: game.Vector2{X: 800}},
+			},
+			Hits:       5,
+			Knockdowns: 1,
+		}))
+		return f.received(t, "lobby:sandbox_state")
+	}},
+	{"queue:status", func(t *testing.T, f *goldenFixture) []byte {
+		require.NoError(t, f.handler.publication.SendQueueStatus(game.QueueStatus{
+			Player:        f.receiver,
+			Position:      2,
+			EstimatedWait: 20 * time.Second,
+			WaitKnown:     true,
+			PlayersOnline: 5,
+		}))
+		return f.received(t, "queue:status")
+	}},
+	{"spawn:options", func(t *testing.T, f *goldenFixture) []byte {
+		require.NoError(t, f.handler.publication.SendSpawnOptions(f.receiver, []game.SpawnOption{
+			{Index: 0, Position: game.Vector2{X: 200, Y: 540}, Danger: 1120, Allowed: false},
+			{Index: 1, Position: game.Vector2{X: 1720, Y: 540}, Danger: 0, Allowed: true},
+		}))
+		return f.received(t, "spawn:options")
+	}},
+	{"team:ping", func(t *testing.T, f *goldenFixture) []byte {
+		f.sender.Team = game.TeamAlpha
+		f.receiver.Team = game.TeamAlpha
+		require.NoError(t, f.handler.publication.BroadcastTeamPing(f.room, "player-a", game.PingEnemy, game.Vector2{X: 640, Y: 360}))
+		return f.received(t, "team:ping")
+	}},
+	{"player:emoted", func(t *testing.T, f *goldenFixture) []byte {
+		require.NoError(t, f.handler.publication.BroadcastPlayerEmoted(f.room, playerEmotedData{PlayerID: "player-a", Emote: game.QuickChatNiceShot}))
+		return f.received(t, "player:emoted")
+	}},
+	{"chat:posted", func(t *testing.T, f *goldenFixture) []byte {
+		require.NoError(t, f.handler.publication.BroadcastChatPosted(f.room, chatPostedData{PlayerID: "player-a", DisplayName: "Alpha", Text: "gl hf", Channel: chatChannelAll}))
+		return f.received(t, "chat:posted")
+	}},
+	{"room:votekick_progress", func(t *testing.T, f *goldenFixture) []byte {
+		require.NoError(t, f.handler.publication.BroadcastVoteKickProgress(f.room, voteKickProgressData{
+			TargetID: "player-c", VoterID: "player-a", Votes: 1, Needed: 2, ExpiresInMs: 30000,
+		}))
+		return f.received(t, "room:votekick_progress")
+	}},
+	{"leaderboard:update", func(t *testing.T, f *goldenFixture) []byte {
+		require.NoError(t, f.handler.publication.SendLeaderboardUpdate([]*game.Player{f.receiver}, leaderboardUpdateData{
+			Matches: 12,
+			Total:   2,
+			Top: []leaderboardUpdateEntry{
+				{Rank: 1, PlayerID: "player-a", DisplayName: "Alpha", XP: 900, KD: 2.5, Wins: 4},
+				{Rank: 2, PlayerID: "player-b", DisplayName: "Bravo", XP: 400, KD: 0.5, Wins: 1},
+			},
+		}))
+		return f.received(t, "leaderboard:update")
+	}},
+	{"player:level_up", func(t *testing.T, f *goldenFixture) []byte {
+		f.handler.HandleGameLoopEvent(game.PlayerLeveledUpEvent{
+			PlayerID: "player-b", Level: 3, PreviousLevel: 2, XP: 1150, NextLevelXP: 1820, Unlocks: []string{"emote.salute"},
+		})
+		return f.received(t, "player:level_up")
+	}},
+	{"server:shutdown", func(t *testing.T, f *goldenFixture) []byte {
+		require.NoError(t, f.handler.publication.SendServerShutdown(f.receiver, 30*time.Second))
+		return f.received(t, "server:shutdown")
+	}},
+	{"practice:status", func(t *testing.T, f *goldenFixture) []byte {
+		f.room.Practice = game.NewAdaptiveBotDifficulty()
+		f.room.Practice.RecordKill()
+		f.room.Practice.RecordDeath()
+		require.NoError(t, f.handler.publication.BroadcastPracticeStatus(f.room))
+		return f.received(t, "practice:status")
+	}},
+	{"net:ping", func(t *testing.T, f *goldenFixture) []byte {
+		require.NoError(t, f.handler.publication.SendNetPing(f.receiver, 7, goldenTime))
+		return f.received(t, "net:ping")
+	}},
+	{"time:sync", func(t *testing.T, f *goldenFixture) []byte {
+		receivedAt := goldenTime.Add(-2 * time.Millisecond)
+		require.NoError(t, f.handler.publication.SendTimeSync(f.receiver, float64(goldenTimestamp-40), receivedAt, goldenTime, 3600))
+		return f.received(t, "time:sync")
+	}},
+	{"world:sync", func(t *testing.T, f *goldenFixture) []byte {
+		match := game.NewMatch()
+		match.RegisterPlayer("player-a")
+		match.RegisterPlayer("player-b")
+		match.Start()
+		match.AddKill("player-a")
+		world := game.NewWorld()
+		world.AddPlayer("player-a")
+		world.AddPlayer("player-b")
+		require.NoError(t, f.handler.publication.SendWorldSync(f.receiver, match, world))
+		return f.received(t, "world:sync")
+	}},
+	{"state:snapshot", func(t *testing.T, f *goldenFixture) []byte {
+		f.handler.sendSnapshot(f.receiver.ID, goldenFrame(100, "proj-1"))
+		return f.received(t, "state:snapshot")
+	}},
+	{"state:delta", func(t *testing.T, f *goldenFixture) []byte {
+		f.handler.broadcastPlayerStatesToClient(f.receiver.ID, goldenFrame(100, "proj-1"))
+		f.handler.broadcastPlayerStatesToClient(f.receiver.ID, goldenFrame(140, "proj-2"))
+		return f.received(t, "state:delta")
+	}},
+	{"entity:removed", func(t *testing.T, f *goldenFixture) []byte {
+		require.NoError(t, f.handler.publication.BroadcastEntityRemoved(f.room, entityRemovedData{
+			Tick: 120,
+			Entities: []removedEntityData{
+				{Kind: entityKindPlayer, ID: "player-b"},
+				{Kind: entityKindProjectile, ID: "proj-1"},
+			},
+		}))
+		return f.received(t, "entity:removed")
+	}},
+	{"weapon:state", func(t *testing.T, f *goldenFixture) []byte {
+		f.handler.gameServer.AddPlayer(f.receiver.ID)
+		f.handler.sendWeaponState(f.receiver.ID)
+		return f.received(t, "weapon:state")
+	}},
+	{"shoot:failed", func(t *testing.T, f *goldenFixture) []byte {
+		f.handler.sendShootFailed(f.receiver.ID, game.ShootFailedEmpty)
+		return f.received(t, "shoot:failed")
+	}},
+	{"projectile:spawn", func(t *testing.T, f *goldenFixture) []byte {
+		f.handler.broadcastProjectileSpawn(&game.Projectile{
+			ID:         "proj-1",
+			OwnerID:    "player-a",
+			WeaponType: "Shotgun",
+			Position:   game.Vector2{X: 100, Y: 200},
+			Velocity:   game.Vector2{X: 800, Y: 0},
+			EffectID:   "ember",
+		}, []game.Projectile{{ID: "proj-2", Velocity: game.Vector2{X: 790, Y: 40}}})
+		return f.received(t, "projectile:spawn")
+	}},
+	{"projectile:explode", func(t *testing.T, f *goldenFixture) []byte {
+		f.handler.broadcastProjectileExplode(game.ProjectileExplodedEvent{
+			ProjectileID: "proj-1",
+			OwnerID:      "player-a",
+			WeaponType:   "RocketLauncher",
+			Position:     game.Vector2{X: 400, Y: 300},
+			Radius:       120,
+		})
+		return f.received(t, "projectile:explode")
+	}},
+	{"player:damaged", func(t *testing.T, f *goldenFixture) []byte {
+		require.NoError(t, f.handler.publication.BroadcastPlayerDamaged(f.room, playerDamagedData{
+			VictimID:     "player-b",
+			AttackerID:   "player-a",
+			Damage:       25,
+			NewHealth:    75,
+			NewShield:    10,
+			ProjectileID: "proj-1",
+			Impulse:      &game.Vector2{X: 120, Y: -30},
+		}))
+		return f.received(t, "player:damaged")
+	}},
+	{"hit:confirmed", func(t *testing.T, f *goldenFixture) []byte {
+		require.NoError(t, f.handler.publication.SendHitConfirmed(f.receiver.ID, hitConfirmedData{
+			VictimID:     "player-a",
+			Damage:       25,
+			ProjectileID: "proj-1",
+		}))
+		return f.received(t, "hit:confirmed")
+	}},
+	{"hit:rejected", func(t *testing.T, f *goldenFixture) []byte {
+		f.handler.sendHitRejected(f.receiver.ID, "melee", &game.HitRejection{Code: game.HitRejectedOutOfRange, VictimID: "player-a"})
+		return f.received(t, "hit:rejected")
+	}},
+	{"melee:hit", func(t *testing.T, f *goldenFixture) []byte {
+		f.handler.broadcastMeleeHit(f.sender.ID, []string{"player-b"}, true, 2)
+		return f.received(t, "melee:hit")
+	}},
+	{"roll:start", func(t *testing.T, f *goldenFixture) []byte {
+		f.handler.broadcastRollStart(f.sender.ID, game.Vector2{X: 1, Y: 0}, goldenTime)
+		return f.received(t, "roll:start")
+	}},
+	{"roll:end", func(t *testing.T, f *goldenFixture) []byte {
+		f.handler.broadcastRollEnd(f.sender.ID, "completed")
+		return f.received(t, "roll:end")
+	}},
+	{"player:death", func(t *testing.T, f *goldenFixture) []byte {
+		require.NoError(t, f.handler.publication.BroadcastPlayerDeath(f.room, playerDeathData{VictimID: "player-b", AttackerID: "player-a"}))
+		return f.received(t, "player:death")
+	}},
+	{"player:kill_credit", func(t *testing.T, f *goldenFixture) []byte {
+		require.NoError(t, f.handler.publication.BroadcastPlayerKillCredit(f.room, playerKillCreditData{
+			KillerID:    "player-a",
+			VictimID:    "player-b",
+			KillerKills: 3,
+			KillerXP:    300,
+		}))
+		return f.received(t, "player:kill_credit")
+	}},
+	{"player:killstreak", func(t *testing.T, f *goldenFixture) []byte {
+		require.NoError(t, f.handler.publication.BroadcastPlayerKillstreak(f.room, playerKillstreakData{
+			PlayerID:  "player-a",
+			Streak:    3,
+			MultiKill: 2,
+			BonusXP:   50,
+		}))
+		return f.received(t, "player:killstreak")
+	}},
+	{"player:assist_credit", func(t *testing.T, f *goldenFixture) []byte {
+		require.NoError(t, f.handler.publication.BroadcastPlayerAssistCredit(f.room, playerAssistCreditData{
+			PlayerID:      "player-b",
+			VictimID:      "player-c",
+			KillerID:      "player-a",
+			Damage:        40,
+			PlayerAssists: 1,
+			PlayerXP:      50,
+		}))
+		return f.received(t, "player:assist_credit")
+	}},
+	{"player:respawn", func(t *testing.T, f *goldenFixture) []byte {
+		require.NoError(t, f.handler.publication.BroadcastPlayerRespawn(f.room, playerRespawnData{
+			PlayerID: "player-a",
+			Position: game.Vector2{X: 960, Y: 540},
+			Health:   100,
+		}))
+		return f.received(t, "player:respawn")
+	}},
+	{"player:renamed", func(t *testing.T, f *goldenFixture) []byte {
+		require.NoError(t, f.handler.publication.PublishPlayerRenamed(f.room, playerRenamedData{PlayerID: "player-a", DisplayName: "Alpha Prime"}))
+		return f.received(t, "player:renamed")
+	}},
+	{"rename:failed", func(t *testing.T, f *goldenFixture) []byte {
+		require.NoError(t, f.handler.publication.SendRenameFailed(f.receiver.ID, renameFailedData{Reason: "cooldown", RetryAfterMs: 4500}))
+		return f.received(t, "rename:failed")
+	}},
+	{"ultimate:activated", func(t *testing.T, f *goldenFixture) []byte {
+		require.NoError(t, f.handler.publication.BroadcastUltimateActivated(f.room, ultimateActivatedData{
+			PlayerID:         "player-a",
+			Ultimate:         "berserk",
+			DurationMs:       6000,
+			DamageMultiplier: 1.5,
+			NewHealth:        100,
+		}))
+		return f.received(t, "ultimate:activated")
+	}},
+	{"weapon:pickup_confirmed", func(t *testing.T, f *goldenFixture) []byte {
+		f.handler.broadcastWeaponPickup(f.sender.ID, "crate-1", "uzi", goldenTime.Add(30*time.Second))
+		return f.received(t, "weapon:pickup_confirmed")
+	}},
+	{"weapon:respawned", func(t *testing.T, f *goldenFixture) []byte {
+		f.handler.broadcastWeaponRespawn(&game.WeaponCrate{ID: "crate-1", Position: game.Vector2{X: 300, Y: 400}, WeaponType: "uzi", IsAvailable: true})
+		return f.received(t, "weapon:respawned")
+	}},
+	{"weapon:spawned", func(t *testing.T, f *goldenFixture) []byte {
+		f.handler.broadcastSpawnedCrate(&game.WeaponCrate{ID: "crate-spawned-1", Position: game.Vector2{X: 300, Y: 400}, WeaponType: "ak47", IsAvailable: true})
+		return f.received(t, "weapon:spawned")
+	}},
+	{"health:pickup_confirmed", func(t *testing.T, f *goldenFixture) []byte {
+		f.handler.broadcastHealthPickup(game.HealthPackPickedUpEvent{
+			PlayerID:    "player-a",
+			PackID:      "health-1",
+			Healed:      30,
+			NewHealth:   90,
+			RespawnTime: goldenTime.Add(20 * time.Second),
+		})
+		return f.received(t, "health:pickup_confirmed")
+	}},
+	{"health:spawned", func(t *testing.T, f *goldenFixture) []byte {
+		f.handler.broadcastHealthRespawn(game.HealthPackRespawnedEvent{PackID: "health-1", Position: game.Vector2{X: 500, Y: 600}})
+		return f.received(t, "health:spawned")
+	}},
+	{"shield:pickup_confirmed", func(t *testing.T, f *goldenFixture) []byte {
+		f.handler.broadcastShieldPickup(game.ShieldPickedUpEvent{
+			PlayerID:    "player-a",
+			CrateID:     "shield-1",
+			Shield:      50,
+			RespawnTime: goldenTime.Add(25 * time.Second),
+		})
+		return f.received(t, "shield:pickup_confirmed")
+	}},
+	{"shield:respawned", func(t *testing.T, f *goldenFixture) []byte {
+		f.handler.broadcastShieldRespawn(game.ShieldCrateRespawnedEvent{CrateID: "shield-1", Position: game.Vector2{X: 700, Y: 800}})
+		return f.received(t, "shield:respawned")
+	}},
+	{"shield:spawned", func(t *testing.T, f *goldenFixture) []byte {
+		f.handler.sendShieldSpawns(f.receiver.ID)
+		return f.received(t, "shield:spawned")
+	}},
+	{"match:timer", func(t *testing.T, f *goldenFixture) []byte {
+		f.handler.broadcastMatchTimerEvent(game.MatchTimerUpdatedEvent{RoomID: f.room.ID, RemainingSeconds: 42})
+		return f.received(t, "match:timer")
+	}},
+	{"match:ended", func(t *testing.T, f *goldenFixture) []byte {
+		f.room.Reseed(424242)
+		f.handler.buildInfo = func() buildinfo.Info {
+			return buildinfo.Info{Commit: "abc1234", BuildTime: "2026-01-01T00:00:00Z", GoVersion: "go1.25.0"}
+		}
+		f.handler.broadcastMatchEndedEvent(game.MatchEndedEvent{
+			RoomID:      f.room.ID,
+			Reason:      "kill_target",
+			Winners:     []game.WinnerSummary{{PlayerID: "player-a", DisplayName: "Alpha"}},
+			FinalScores: []game.PlayerScore{{PlayerID: "player-a", DisplayName: "Alpha", Kills: 20, Deaths: 2, XP: 2100, BestKillStreak: 7}},
+			Scoreboard: []game.PlayerMatchStats{{
+				PlayerID: "player-a", DisplayName: "Alpha", Kills: 20, Deaths: 2, Assists: 3, XP: 2100,
+				ShotsFired: 80, ShotsHit: 60, Accuracy: 0.75, DamageDealt: 2400, BestKillStreak: 7,
+			}},
+		})
+		return f.received(t, "match:ended")
+	}},
+	{"match:restarted", func(t *testing.T, f *goldenFixture) []byte {
+		require.NoError(t, f.handler.publication.BroadcastMatchRestarted(f.room, f.room.GetPlayers()))
+		return f.received(t, "match:restarted")
+	}},
+}
+
+// goldenFrame is a broadcast frame with one player at x and one projectile
+func goldenFrame(x float64, projectileID string) *broadcastFrame {
+	return &broadcastFrame{
+		tick: 1234,
+		players: []game.PlayerStateSnapshot{{
+			ID:          "player-a",
+			DisplayName: "Alpha",
+			Position:    game.Vector2{X: x, Y: 200},
+			Velocity:    game.Vector2{X: 200, Y: 0},
+			AimAngle:    0.5,
+			WeaponType:  "pistol",
+			Health:      100,
+			Stamina:     65,
+			MaxHealth:   100,
+			Kills:       1,
+			XP:          100,
+			Teleport:    game.TeleportRespawn,
+		}},
+		projectiles:           []game.ProjectileSnapshot{{ID: projectileID, OwnerID: "player-a", WeaponType: "pistol", Position: game.Vector2{X: x, Y: 210}, Velocity: game.Vector2{X: 800, Y: 0}}},
+		lastProcessedSequence: map[string]uint64{"player-a": 7},
+		correctedPlayers:      []string{"player-a"},
+	}
+}
+
+// normalizeGolden replaces what differs from run to run, the room ID and wall
+// clock timestamps, and indents the message without reordering its fields
+func normalizeGolden(t *testing.T, msgBytes []byte, roomID string) []byte {
+	t.Helper()
+
+	msgBytes = bytes.ReplaceAll(msgBytes, []byte(roomID), []byte(goldenRoomID))
+	msgBytes = goldenTimestampPattern.ReplaceAll(msgBytes, []byte(fmt.Sprintf(`"timestamp":%d`, goldenTimestamp)))
+
+	var indented bytes.Buffer
+	require.NoError(t, json.Indent(&indented, msgBytes, "", "  "))
+	indented.WriteByte('\n')
+	return indented.Bytes()
+}
+
+func goldenPath(messageType string) string {
+	name := strings.NewReplacer(":", "-", "_", "-").Replace(messageType)
+	return filepath.Join("testdata", "golden", name+".json")
+}
+
+func TestWireFormatGolden(t *testing.T) {
+	for _, tc := range goldenCases {
+		t.Run(tc.messageType, func(t *testing.T) {
+			withSchemaValidation(t)
+			f := newGoldenFixture(t)
+
+			msgBytes := tc.send(t, f)
+			require.NotEmpty(t, msgBytes)
+			got := normalizeGolden(t, msgBytes, f.room.ID)
+
+			path := goldenPath(tc.messageType)
+			if *updateGolden {
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+				require.NoError(t, os.WriteFile(path, got, 0o644))
+				return
+			}
+
+			want, err := os.ReadFile(path)
+			require.NoError(t, err, "missing golden file; run with -update to create it")
+			assert.Equal(t, string(want), string(got), "%s wire format changed; run with -update if intended", tc.messageType)
+		})
+	}
+}
+
+func TestWireFormatGoldenCoversEveryOutgoingSchema(t *testing.T) {
+	covered := make(map[string]bool, len(goldenCases))
+	for _, tc := range goldenCases {
+		covered[outgoingSchemaName(tc.messageType)] = true
+	}
+
+	var missing []string
+	for _, name := range GetServerToClientSchemaLoader().GetSchemaNames() {
+		if !strings.HasSuffix(name, "-data") || covered[name] || goldenUnsentTypes[name] {
+			continue
+		}
+		missing = append(missing, name)
+	}
+	sort.Strings(missing)
+	assert.Empty(t, missing, "outgoing schemas without a golden case")
+}
+
+func TestWireFormatGoldenHasNoStaleFiles(t *testing.T) {
+	want := make(map[string]bool, len(goldenCases))
+	for _, tc := range goldenCases {
+		want[filepath.Base(goldenPath(tc.messageType))] = true
+	}
+
+	entries, err := os.ReadDir(filepath.Join("testdata", "golden"))
+	require.NoError(t, err)
+	for _, entry := range entries {
+		assert.True(t, want[entry.Name()], "golden file %s has no case", entry.Name())
+	}
+}
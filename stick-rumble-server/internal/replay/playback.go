@@ -0,0 +1,379 @@
+package replay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+// ErrNotPlayable is returned when a replay cannot be played back: it was
+// recorded before ticks and actions were, or has no state to start from
+var ErrNotPlayable = errors.New("replay cannot be played back")
+
+// Replay is a whole replay file
+type Replay struct {
+	Header  Header
+	Entries []Entry
+}
+
+// Load reads the replay file at path
+func Load(path string) (*Replay, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadAll(file)
+}
+
+// ReadAll reads a whole replay from r. A replay whose recording was cut short
+// keeps the entries before the cut.
+func ReadAll(r io.Reader) (*Replay, error) {
+	reader, err := NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	replay := &Replay{Header: reader.Header}
+	for {
+		entry, err := reader.Next()
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return replay, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		replay.Entries = append(replay.Entries, entry)
+	}
+}
+
+// Hit is one player damaging another
+type Hit struct {
+	Tick       uint64 `json:"tick"`
+	AttackerID string `json:"attackerId"`
+	VictimID   string `json:"victimId"`
+	Damage     int    `json:"damage"`
+}
+
+// Kill is one player killing another
+type Kill struct {
+	Tick     uint64 `json:"tick"`
+	KillerID string `json:"killerId"`
+	VictimID string `json:"victimId"`
+}
+
+// Score is a player's kills and deaths at the end of the match
+type Score struct {
+	PlayerID string `json:"playerId"`
+	Kills    int    `json:"kills"`
+	Deaths   int    `json:"deaths"`
+}
+
+// Outcome is what a match produced: its hits and kills, each sorted by tick,
+// and the final scores sorted by player ID
+type Outcome struct {
+	Ticks  int     `json:"ticks"`
+	Hits   []Hit   `json:"hits"`
+	Kills  []Kill  `json:"kills"`
+	Scores []Score `json:"scores"`
+}
+
+// Recorded returns the outcome the replay recorded: hits from player:damaged,
+// kills from player:death and the final scores of match:ended, or of the last
+// state if the recording ended before the match did
+func (r *Replay) Recorded() (Outcome, error) {
+	var outcome Outcome
+	var lastState *State
+	for _, entry := range r.Entries {
+		switch entry.Kind {
+		case KindTick:
+			outcome.Ticks++
+		case KindState:
+			state, err := entry.State()
+			if err != nil {
+				return Outcome{}, err
+			}
+			lastState = &state
+		case KindEvent:
+			if err := outcome.addEvent(entry); err != nil {
+				return Outcome{}, err
+			}
+		}
+	}
+
+	if outcome.Scores == nil && lastState != nil {
+		for _, player := range lastState.Players {
+			outcome.Scores = append(outcome.Scores, Score{PlayerID: player.ID, Kills: player.Kills, Deaths: player.Deaths})
+		}
+	}
+	outcome.sort()
+	return outcome, nil
+}
+
+func (o *Outcome) addEvent(entry Entry) error {
+	switch entry.Type {
+	case "player:damaged":
+		var data struct {
+			VictimID   string `json:"victimId"`
+			AttackerID string `json:"attackerId"`
+			Damage     int    `json:"damage"`
+		}
+		if err := json.Unmarshal(entry.Data, &data); err != nil {
+			return fmt.Errorf("decode player:damaged at tick %d: %w", entry.Tick, err)
+		}
+		o.Hits = append(o.Hits, Hit{Tick: entry.Tick, AttackerID: data.AttackerID, VictimID: data.VictimID, Damage: data.Damage})
+	case "player:death":
+		var data struct {
+			VictimID   string `json:"victimId"`
+			AttackerID string `json:"attackerId"`
+		}
+		if err := json.Unmarshal(entry.Data, &data); err != nil {
+			return fmt.Errorf("decode player:death at tick %d: %w", entry.Tick, err)
+		}
+		o.Kills = append(o.Kills, Kill{Tick: entry.Tick, KillerID: data.AttackerID, VictimID: data.VictimID})
+	case "match:ended":
+		var data struct {
+			FinalScores []game.PlayerScore `json:"finalScores"`
+		}
+		if err := json.Unmarshal(entry.Data, &data); err != nil {
+			return fmt.Errorf("decode match:ended at tick %d: %w", entry.Tick, err)
+		}
+		o.Scores = make([]Score, 0, len(data.FinalScores))
+		for _, score := range data.FinalScores {
+			o.Scores = append(o.Scores, Score{PlayerID: score.PlayerID, Kills: score.Kills, Deaths: score.Deaths})
+		}
+	}
+	return nil
+}
+
+// sort orders hits and kills by tick, and those within a tick by player, so
+// the order the simulation resolved one tick's hits in does not matter
+func (o *Outcome) sort() {
+	sort.SliceStable(o.Hits, func(i, j int) bool {
+		a, b := o.Hits[i], o.Hits[j]
+		if a.Tick != b.Tick {
+			return a.Tick < b.Tick
+		}
+		if a.AttackerID != b.AttackerID {
+			return a.AttackerID < b.AttackerID
+		}
+		if a.VictimID != b.VictimID {
+			return a.VictimID < b.VictimID
+		}
+		return a.Damage < b.Damage
+	})
+	sort.SliceStable(o.Kills, func(i, j int) bool {
+		a, b := o.Kills[i], o.Kills[j]
+		if a.Tick != b.Tick {
+			return a.Tick < b.Tick
+		}
+		if a.KillerID != b.KillerID {
+			return a.KillerID < b.KillerID
+		}
+		return a.VictimID < b.VictimID
+	})
+	sort.Slice(o.Scores, func(i, j int) bool { return o.Scores[i].PlayerID < o.Scores[j].PlayerID })
+}
+
+// playback collects what a played back match produces
+type playback struct {
+	gs      *game.GameServer
+	clock   *game.ManualClock
+	rtts    map[string]int64 // Each shooter's recorded RTT, for lag compensation
+	tick    uint64           // Recorded number of the tick being played
+	outcome Outcome
+}
+
+// HandleGameLoopEvent records projectile and explosion hits
+func (p *playback) HandleGameLoopEvent(event game.GameLoopEvent) {
+	resolved, ok := event.(game.ProjectileHitResolvedEvent)
+	if !ok {
+		return
+	}
+	outcome := resolved.Outcome
+	p.outcome.Hits = append(p.outcome.Hits, Hit{
+		Tick:       p.tick,
+		AttackerID: outcome.Hit.AttackerID,
+		VictimID:   outcome.Hit.VictimID,
+		Damage:     outcome.Damage,
+	})
+	if outcome.Killed {
+		p.outcome.Kills = append(p.outcome.Kills, Kill{Tick: p.tick, KillerID: outcome.Hit.AttackerID, VictimID: outcome.Hit.VictimID})
+	}
+}
+
+// Play runs the replay through a headless GameServer on a manual clock. The
+// world starts as the first recorded state left it; then every recorded tick
+// runs with its time, length and inputs, and every action is made again at
+// its recorded time. Hits and kills come from the simulation, and the scores
+// are the kills and deaths of the players left at the end.
+func Play(r *Replay) (Outcome, error) {
+	if r.Header.Version < 2 {
+		return Outcome{}, fmt.Errorf("%w: version %d replays have no ticks or actions", ErrNotPlayable, r.Header.Version)
+	}
+
+	start := -1
+	for i, entry := range r.Entries {
+		if entry.Kind == KindState {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return Outcome{}, fmt.Errorf("%w: no state to start from", ErrNotPlayable)
+	}
+	state, err := r.Entries[start].State()
+	if err != nil {
+		return Outcome{}, err
+	}
+
+	p := &playback{
+		clock: game.NewManualClock(time.UnixMilli(r.Entries[start].Timestamp)),
+		rtts:  make(map[string]int64),
+		tick:  r.Entries[start].Tick,
+	}
+	var rng game.RandomSource
+	if r.Header.Seed != 0 {
+		rng = game.NewRoomRNG(r.Header.Seed)
+	}
+	p.gs = game.NewGameServerWithConfig(game.GameServerConfig{
+		Clock:        p.clock,
+		EventSink:    p,
+		RTTProvider:  func(playerID string) int64 { return p.rtts[playerID] },
+		RandomSource: func(string) game.RandomSource { return rng },
+	})
+	for _, player := range state.Players {
+		p.gs.RestorePlayer(player)
+	}
+
+	for _, entry := range r.Entries[start+1:] {
+		switch entry.Kind {
+		case KindTick:
+			record, err := entry.TickRecord()
+			if err != nil {
+				return Outcome{}, err
+			}
+			p.step(record)
+		case KindAction:
+			action, err := entry.Action()
+			if err != nil {
+				return Outcome{}, err
+			}
+			p.apply(action)
+		}
+	}
+
+	for _, player := range p.gs.GetAllPlayerStates() {
+		p.outcome.Scores = append(p.outcome.Scores, Score{PlayerID: player.ID, Kills: player.Kills, Deaths: player.Deaths})
+	}
+	p.outcome.sort()
+	return p.outcome, nil
+}
+
+func (p *playback) step(record game.TickRecord) {
+	now := time.Unix(0, record.Now)
+	p.clock.SetTime(now)
+	p.tick = record.Tick
+	for _, input := range record.Inputs {
+		p.gs.QueuePlayerInput(input.PlayerID, input.QueuedInput)
+	}
+	p.gs.Step(now, record.DeltaTime)
+	p.outcome.Ticks++
+}
+
+// apply makes a recorded action again. Melee attacks are made here rather
+// than through GameServer.Apply to see whom they hit.
+func (p *playback) apply(action game.Action) {
+	p.clock.SetTime(time.Unix(0, action.At))
+	switch action.Kind {
+	case game.ActionShoot:
+		p.rtts[action.PlayerID] = action.RTT
+	case game.ActionMelee:
+		result := p.gs.PlayerMeleeAttack(action.PlayerID, action.AimAngle)
+		for i, victim := range result.HitPlayers {
+			p.outcome.Hits = append(p.outcome.Hits, Hit{Tick: p.tick, AttackerID: action.PlayerID, VictimID: victim.ID, Damage: result.Damages[i]})
+			if !victim.IsAlive() {
+				p.gs.CreditMeleeKill(action.PlayerID, victim.ID)
+				p.outcome.Kills = append(p.outcome.Kills, Kill{Tick: p.tick, KillerID: action.PlayerID, VictimID: victim.ID})
+			}
+		}
+		return
+	}
+	p.gs.Apply(action)
+}
+
+// Report compares a played back match with the recording
+type Report struct {
+	Recorded   Outcome  `json:"recorded"`
+	Played     Outcome  `json:"played"`
+	Mismatches []string `json:"mismatches"` // The first difference in hits, kills and scores; empty when they agree
+}
+
+// OK reports whether the playback reproduced the recording
+func (r Report) OK() bool {
+	return len(r.Mismatches) == 0
+}
+
+// Verify plays the replay back and compares its hits, kills and final scores
+// with the recorded ones
+func Verify(r *Replay) (Report, error) {
+	recorded, err := r.Recorded()
+	if err != nil {
+		return Report{}, err
+	}
+	played, err := Play(r)
+	if err != nil {
+		return Report{}, err
+	}
+
+	report := Report{Recorded: recorded, Played: played, Mismatches: []string{}}
+	report.compare("hit", len(recorded.Hits), len(played.Hits), func(i int) (string, string) {
+		return formatHit(recorded.Hits[i]), formatHit(played.Hits[i])
+	})
+	report.compare("kill", len(recorded.Kills), len(played.Kills), func(i int) (string, string) {
+		return formatKill(recorded.Kills[i]), formatKill(played.Kills[i])
+	})
+
+	playedScores := make(map[string]Score, len(played.Scores))
+	for _, score := range played.Scores {
+		playedScores[score.PlayerID] = score
+	}
+	for _, score := range recorded.Scores {
+		if got, ok := playedScores[score.PlayerID]; !ok || got != score {
+			report.Mismatches = append(report.Mismatches, fmt.Sprintf("score of %s: recorded %d kills/%d deaths, played %d kills/%d deaths",
+				score.PlayerID, score.Kills, score.Deaths, got.Kills, got.Deaths))
+			break
+		}
+	}
+	return report, nil
+}
+
+// compare adds the first of n pairs that differ, or the count if one side
+// has more
+func (r *Report) compare(what string, recorded, played int, pair func(i int) (string, string)) {
+	for i := range min(recorded, played) {
+		want, got := pair(i)
+		if want != got {
+			r.Mismatches = append(r.Mismatches, fmt.Sprintf("%s %d: recorded %s, played %s", what, i+1, want, got))
+			return
+		}
+	}
+	if recorded != played {
+		r.Mismatches = append(r.Mismatches, fmt.Sprintf("%s count: recorded %d, played %d", what, recorded, played))
+	}
+}
+
+func formatHit(hit Hit) string {
+	return fmt.Sprintf("%s hit %s for %d at tick %d", hit.AttackerID, hit.VictimID, hit.Damage, hit.Tick)
+}
+
+func formatKill(kill Kill) string {
+	return fmt.Sprintf("%s killed %s at tick %d", kill.KillerID, kill.VictimID, kill.Tick)
+}
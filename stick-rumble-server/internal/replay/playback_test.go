@@ -0,0 +1,140 @@
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// matchRecorder records a headless match the way the server's replay
+// recorder does: ticks and actions from the game server, and hits and kills
+// as the events clients see
+type matchRecorder struct {
+	t      *testing.T
+	gs     *game.GameServer
+	clock  *game.ManualClock
+	writer *Writer
+}
+
+func (m *matchRecorder) RecordTick(record game.TickRecord) {
+	require.NoError(m.t, m.writer.WriteTick(record))
+}
+
+func (m *matchRecorder) RecordAction(action game.Action) {
+	require.NoError(m.t, m.writer.WriteAction(m.gs.Tick(), action))
+}
+
+func (m *matchRecorder) HandleGameLoopEvent(event game.GameLoopEvent) {
+	resolved, ok := event.(game.ProjectileHitResolvedEvent)
+	if !ok {
+		return
+	}
+	hit := resolved.Outcome.Hit
+	m.writeEvent("player:damaged", map[string]any{"victimId": hit.VictimID, "attackerId": hit.AttackerID, "damage": resolved.Outcome.Damage})
+	if resolved.Outcome.Killed {
+		m.writeEvent("player:death", map[string]any{"victimId": hit.VictimID, "attackerId": hit.AttackerID})
+	}
+}
+
+func (m *matchRecorder) writeEvent(messageType string, data any) {
+	encoded, err := json.Marshal(data)
+	require.NoError(m.t, err)
+	require.NoError(m.t, m.writer.WriteEvent(m.gs.Tick(), m.clock.Now(), messageType, encoded))
+}
+
+// recordDuel records a shooter walking toward a victim and firing a pistol
+// at it until it dies
+func recordDuel(t *testing.T) *Replay {
+	t.Helper()
+
+	var buf bytes.Buffer
+	start := time.UnixMilli(1704067200000)
+	rec := &matchRecorder{t: t, clock: game.NewManualClock(start)}
+	rec.gs = game.NewGameServerWithConfig(game.GameServerConfig{Clock: rec.clock, EventSink: rec})
+	writer, err := NewWriter(&buf, Header{RoomID: "room-1", MatchID: "match-1", StartedAt: start.UnixMilli()})
+	require.NoError(t, err)
+	rec.writer = writer
+
+	shooter := rec.gs.AddPlayer("shooter")
+	shooter.SetPosition(game.Vector2{X: 100, Y: 650})
+	victim := rec.gs.AddPlayer("victim")
+	victim.SetPosition(game.Vector2{X: 500, Y: 650})
+	require.NoError(t, writer.WriteState(rec.gs.Tick(), start, State{Players: rec.gs.GetAllPlayerStates()}))
+	rec.gs.SetActionRecorder(rec)
+
+	const dt = 1.0 / 60.0
+	for i := 1; i <= 600; i++ {
+		now := rec.clock.Now().Add(time.Second / 60)
+		rec.clock.SetTime(now)
+		if i <= 30 {
+			rec.gs.QueuePlayerInput("shooter", game.QueuedInput{Input: game.InputState{Right: true}, Sequence: uint64(i), HasSequence: true})
+		} else if i == 31 {
+			rec.gs.QueuePlayerInput("shooter", game.QueuedInput{Sequence: uint64(i), HasSequence: true})
+		}
+		rec.gs.Step(now, dt)
+
+		if i%30 == 0 && victim.IsAlive() && i < 500 {
+			rec.gs.PlayerShoot("shooter", 0, 0)
+		}
+	}
+	require.NoError(t, writer.WriteState(rec.gs.Tick(), rec.clock.Now(), State{Players: rec.gs.GetAllPlayerStates()}))
+	require.NoError(t, writer.Close())
+
+	replay, err := ReadAll(&buf)
+	require.NoError(t, err)
+	return replay
+}
+
+func TestVerifyReproducesRecordedMatch(t *testing.T) {
+	replay := recordDuel(t)
+
+	recorded, err := replay.Recorded()
+	require.NoError(t, err)
+	require.NotEmpty(t, recorded.Hits, "the duel lands hits")
+	require.Len(t, recorded.Kills, 1)
+	assert.Equal(t, Kill{Tick: recorded.Kills[0].Tick, KillerID: "shooter", VictimID: "victim"}, recorded.Kills[0])
+	assert.Equal(t, 600, recorded.Ticks)
+
+	report, err := Verify(replay)
+	require.NoError(t, err)
+	assert.True(t, report.OK(), "mismatches: %v", report.Mismatches)
+	assert.Equal(t, recorded.Hits, report.Played.Hits)
+	assert.Equal(t, recorded.Kills, report.Played.Kills)
+	assert.Equal(t, []Score{{PlayerID: "shooter", Kills: 1}, {PlayerID: "victim", Deaths: 1}}, report.Played.Scores)
+}
+
+func TestVerifyReportsDivergence(t *testing.T) {
+	replay := recordDuel(t)
+
+	// Aim the first shot away from the victim, as a changed weapon would
+	for i, entry := range replay.Entries {
+		if entry.Kind != KindAction || entry.Type != game.ActionShoot {
+			continue
+		}
+		action, err := entry.Action()
+		require.NoError(t, err)
+		action.AimAngle = 3
+		replay.Entries[i].Data, err = json.Marshal(action)
+		require.NoError(t, err)
+		break
+	}
+
+	report, err := Verify(replay)
+	require.NoError(t, err)
+	assert.False(t, report.OK())
+	require.NotEmpty(t, report.Mismatches)
+	assert.Contains(t, report.Mismatches[0], "hit 1: recorded shooter hit victim")
+}
+
+func TestPlayRejectsReplaysWithoutActions(t *testing.T) {
+	_, err := Play(&Replay{Header: Header{Format: Format, Version: 1}})
+	assert.ErrorIs(t, err, ErrNotPlayable)
+
+	_, err = Play(&Replay{Header: Header{Format: Format, Version: Version}})
+	assert.ErrorIs(t, err, ErrNotPlayable, "playback starts from a state")
+}
@@ -0,0 +1,229 @@
+// Package replay reads and writes match replay files: every state broadcast
+// of one room's match and the key events between them (shots, hits, deaths
+// and pickups), for replay viewers and for debugging desyncs. Since version 2
+// a replay also holds every simulation tick and the player actions between
+// them, so it can be played back through a headless GameServer.
+//
+// A replay is a gzip-compressed stream of JSON lines. The first line is a
+// Header; every following line is an Entry.
+package replay
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+const (
+	// Format and Version identify the files this package reads and writes
+	Format  = "stick-rumble-match-replay"
+	Version = 2
+
+	// MinVersion is the oldest version this package reads. Version 1
+	// replays have no ticks or actions, so they cannot be played back.
+	MinVersion = 1
+
+	// FileExtension is the extension replay files are written with
+	FileExtension = ".replay.gz"
+)
+
+// Entry kinds
+const (
+	KindState  = "state"  // Data is a State
+	KindEvent  = "event"  // Data is the payload of the Type message
+	KindTick   = "tick"   // Data is a game.TickRecord
+	KindAction = "action" // Data is a game.Action
+)
+
+// ErrFormat is returned when a file is not a replay this build can read
+var ErrFormat = errors.New("not a match replay")
+
+// Header describes the recorded match
+type Header struct {
+	Format    string `json:"format"`
+	Version   int    `json:"version"`
+	RoomID    string `json:"roomId"`
+	MatchID   string `json:"matchId"`
+	MapID     string `json:"mapId"`
+	Seed      int64  `json:"seed"`
+	StartedAt int64  `json:"startedAt"` // Unix ms
+}
+
+// Entry is one recorded state or event
+type Entry struct {
+	Tick      uint64          `json:"tick"` // Simulation tick it was recorded after
+	Timestamp int64           `json:"t"`    // Unix ms
+	Kind      string          `json:"kind"`
+	Type      string          `json:"type,omitempty"` // Message type of an event, e.g. player:death, or kind of an action
+	Data      json.RawMessage `json:"data"`
+}
+
+// State is the room's world in a KindState entry, as sent in state:snapshot
+type State struct {
+	Players     []game.PlayerStateSnapshot `json:"players"`
+	Projectiles []game.ProjectileSnapshot  `json:"projectiles"`
+}
+
+// State decodes the data of a KindState entry
+func (e Entry) State() (State, error) {
+	if e.Kind != KindState {
+		return State{}, fmt.Errorf("entry is a %s, not a state", e.Kind)
+	}
+	var state State
+	if err := json.Unmarshal(e.Data, &state); err != nil {
+		return State{}, fmt.Errorf("decode replay state: %w", err)
+	}
+	return state, nil
+}
+
+// TickRecord decodes the data of a KindTick entry
+func (e Entry) TickRecord() (game.TickRecord, error) {
+	var record game.TickRecord
+	if e.Kind != KindTick {
+		return record, fmt.Errorf("entry is a %s, not a tick", e.Kind)
+	}
+	if err := json.Unmarshal(e.Data, &record); err != nil {
+		return record, fmt.Errorf("decode replay tick: %w", err)
+	}
+	return record, nil
+}
+
+// Action decodes the data of a KindAction entry
+func (e Entry) Action() (game.Action, error) {
+	var action game.Action
+	if e.Kind != KindAction {
+		return action, fmt.Errorf("entry is a %s, not an action", e.Kind)
+	}
+	if err := json.Unmarshal(e.Data, &action); err != nil {
+		return action, fmt.Errorf("decode replay action: %w", err)
+	}
+	return action, nil
+}
+
+// Writer writes a replay. Close flushes the compressed stream but leaves the
+// underlying writer open.
+type Writer struct {
+	gzip    *gzip.Writer
+	encoder *json.Encoder
+	entries int
+}
+
+// NewWriter writes header, stamped with Format and Version, and returns a
+// Writer for the entries that follow it
+func NewWriter(w io.Writer, header Header) (*Writer, error) {
+	gz := gzip.NewWriter(w)
+	writer := &Writer{gzip: gz, encoder: json.NewEncoder(gz)}
+
+	header.Format = Format
+	header.Version = Version
+	if err := writer.encoder.Encode(header); err != nil {
+		return nil, fmt.Errorf("write replay header: %w", err)
+	}
+	return writer, nil
+}
+
+// WriteState records the room's world after tick
+func (w *Writer) WriteState(tick uint64, at time.Time, state State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("encode replay state: %w", err)
+	}
+	return w.write(Entry{Tick: tick, Timestamp: at.UnixMilli(), Kind: KindState, Data: data})
+}
+
+// WriteEvent records a message of messageType whose payload is data
+func (w *Writer) WriteEvent(tick uint64, at time.Time, messageType string, data json.RawMessage) error {
+	return w.write(Entry{Tick: tick, Timestamp: at.UnixMilli(), Kind: KindEvent, Type: messageType, Data: data})
+}
+
+// WriteTick records a simulation tick and the inputs it applied
+func (w *Writer) WriteTick(record game.TickRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("encode replay tick: %w", err)
+	}
+	return w.write(Entry{Tick: record.Tick, Timestamp: time.Unix(0, record.Now).UnixMilli(), Kind: KindTick, Data: data})
+}
+
+// WriteAction records a player action made after tick
+func (w *Writer) WriteAction(tick uint64, action game.Action) error {
+	data, err := json.Marshal(action)
+	if err != nil {
+		return fmt.Errorf("encode replay action: %w", err)
+	}
+	return w.write(Entry{Tick: tick, Timestamp: time.Unix(0, action.At).UnixMilli(), Kind: KindAction, Type: action.Kind, Data: data})
+}
+
+func (w *Writer) write(entry Entry) error {
+	if entry.Data == nil {
+		entry.Data = json.RawMessage("null")
+	}
+	if err := w.encoder.Encode(entry); err != nil {
+		return fmt.Errorf("write replay entry: %w", err)
+	}
+	w.entries++
+	return nil
+}
+
+// Entries returns how many entries have been written
+func (w *Writer) Entries() int {
+	return w.entries
+}
+
+// Close flushes the remaining compressed entries
+func (w *Writer) Close() error {
+	return w.gzip.Close()
+}
+
+// Reader reads a replay one entry at a time
+type Reader struct {
+	Header  Header
+	gzip    *gzip.Reader
+	decoder *json.Decoder
+}
+
+// NewReader reads and checks the replay header. Returns ErrFormat if r does
+// not start with one this build can read.
+func NewReader(r io.Reader) (*Reader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFormat, err)
+	}
+
+	decoder := json.NewDecoder(gz)
+	var header Header
+	if err := decoder.Decode(&header); err != nil {
+		return nil, fmt.Errorf("%w: read header: %v", ErrFormat, err)
+	}
+	if header.Format != Format {
+		return nil, fmt.Errorf("%w: format %q", ErrFormat, header.Format)
+	}
+	if header.Version < MinVersion || header.Version > Version {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrFormat, header.Version)
+	}
+
+	return &Reader{Header: header, gzip: gz, decoder: decoder}, nil
+}
+
+// Next returns the next entry, or io.EOF after the last one. A replay whose
+// recording was cut short ends with io.ErrUnexpectedEOF.
+func (r *Reader) Next() (Entry, error) {
+	var entry Entry
+	if err := r.decoder.Decode(&entry); err != nil {
+		if errors.Is(err, io.EOF) {
+			return Entry{}, io.EOF
+		}
+		return Entry{}, fmt.Errorf("read replay entry: %w", err)
+	}
+	return entry, nil
+}
+
+// Close releases the decompressor; it does not close the underlying reader
+func (r *Reader) Close() error {
+	return r.gzip.Close()
+}
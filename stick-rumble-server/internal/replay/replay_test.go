@@ -0,0 +1,133 @@
+package replay
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayRoundTrip(t *testing.T) {
+	startedAt := time.UnixMilli(1704067200000)
+	var buf bytes.Buffer
+
+	writer, err := NewWriter(&buf, Header{RoomID: "room-1", MatchID: "match-1", MapID: "default_office", Seed: 42, StartedAt: startedAt.UnixMilli()})
+	require.NoError(t, err)
+	state := State{
+		Players:     []game.PlayerStateSnapshot{{ID: "p1", Position: game.Vector2{X: 100, Y: 200}, Health: 100}},
+		Projectiles: []game.ProjectileSnapshot{{ID: "proj-1", OwnerID: "p1", WeaponType: "Pistol"}},
+	}
+	require.NoError(t, writer.WriteState(3, startedAt, state))
+	require.NoError(t, writer.WriteEvent(4, startedAt.Add(16*time.Millisecond), "player:death", json.RawMessage(`{"victimId":"p2","attackerId":"p1"}`)))
+	assert.Equal(t, 2, writer.Entries())
+	require.NoError(t, writer.Close())
+
+	reader, err := NewReader(&buf)
+	require.NoError(t, err)
+	defer reader.Close()
+	assert.Equal(t, Header{Format: Format, Version: Version, RoomID: "room-1", MatchID: "match-1", MapID: "default_office", Seed: 42, StartedAt: startedAt.UnixMilli()}, reader.Header)
+
+	entry, err := reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), entry.Tick)
+	assert.Equal(t, KindState, entry.Kind)
+	decoded, err := entry.State()
+	require.NoError(t, err)
+	assert.Equal(t, state.Projectiles, decoded.Projectiles)
+	require.Len(t, decoded.Players, 1)
+	assert.Equal(t, game.Vector2{X: 100, Y: 200}, decoded.Players[0].Position)
+
+	entry, err = reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, KindEvent, entry.Kind)
+	assert.Equal(t, "player:death", entry.Type)
+	assert.Equal(t, startedAt.UnixMilli()+16, entry.Timestamp)
+	assert.JSONEq(t, `{"victimId":"p2","attackerId":"p1"}`, string(entry.Data))
+	_, err = entry.State()
+	assert.Error(t, err, "an event has no state")
+
+	_, err = reader.Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestReplayReaderRejectsOtherFiles(t *testing.T) {
+	_, err := NewReader(bytes.NewBufferString(`{"format":"stick-rumble-match-replay","version":1}`))
+	assert.ErrorIs(t, err, ErrFormat, "replays are compressed")
+
+	for name, header := range map[string]string{
+		"other format":   `{"format":"stick-rumble-session-recording","version":1}`,
+		"future version": `{"format":"stick-rumble-match-replay","version":3}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			gz := gzip.NewWriter(&buf)
+			_, err := gz.Write([]byte(header + "\n"))
+			require.NoError(t, err)
+			require.NoError(t, gz.Close())
+
+			_, err = NewReader(&buf)
+			assert.ErrorIs(t, err, ErrFormat)
+		})
+	}
+}
+
+func TestReplayTicksAndActionsRoundTrip(t *testing.T) {
+	startedAt := time.UnixMilli(1704067200000)
+	var buf bytes.Buffer
+
+	writer, err := NewWriter(&buf, Header{RoomID: "room-1", MatchID: "match-1"})
+	require.NoError(t, err)
+	record := game.TickRecord{
+		Tick:      9,
+		Now:       startedAt.UnixNano(),
+		DeltaTime: 1.0 / 60.0,
+		Inputs:    []game.PlayerInput{{PlayerID: "p1", QueuedInput: game.QueuedInput{Input: game.InputState{Up: true}, Sequence: 3, HasSequence: true}}},
+	}
+	require.NoError(t, writer.WriteTick(record))
+	shot := game.Action{Kind: game.ActionShoot, PlayerID: "p1", At: startedAt.Add(5 * time.Millisecond).UnixNano(), AimAngle: 1.5, RTT: 80}
+	require.NoError(t, writer.WriteAction(9, shot))
+	require.NoError(t, writer.Close())
+
+	reader, err := NewReader(&buf)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	entry, err := reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, KindTick, entry.Kind)
+	assert.Equal(t, uint64(9), entry.Tick)
+	assert.Equal(t, startedAt.UnixMilli(), entry.Timestamp)
+	decodedTick, err := entry.TickRecord()
+	require.NoError(t, err)
+	assert.Equal(t, record, decodedTick)
+	_, err = entry.Action()
+	assert.Error(t, err, "a tick is not an action")
+
+	entry, err = reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, KindAction, entry.Kind)
+	assert.Equal(t, game.ActionShoot, entry.Type)
+	assert.Equal(t, startedAt.UnixMilli()+5, entry.Timestamp)
+	decodedAction, err := entry.Action()
+	require.NoError(t, err)
+	assert.Equal(t, shot, decodedAction)
+}
+
+func TestReplayReaderAcceptsVersionOne(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte(`{"format":"stick-rumble-match-replay","version":1,"roomId":"room-1"}` + "\n"))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	reader, err := NewReader(&buf)
+	require.NoError(t, err)
+	defer reader.Close()
+	assert.Equal(t, 1, reader.Header.Version, "replays recorded before ticks and actions still load")
+}
@@ -0,0 +1,67 @@
+package scripting
+
+import (
+	"errors"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+// Library finds custom mode scripts for private rooms. A named room whose
+// code matches a script file name (case-insensitively, without the .star
+// extension) runs that script, so players pick a mode by choosing the code.
+type Library struct {
+	dir string
+}
+
+// NewLibrary creates a library reading scripts from dir. An empty dir
+// disables scripting.
+func NewLibrary(dir string) *Library {
+	return &Library{dir: dir}
+}
+
+// ScriptPath returns where the script for a room code would live, or "" when
+// scripting is disabled.
+func (l *Library) ScriptPath(code string) string {
+	if l.dir == "" || code == "" {
+		return ""
+	}
+	return filepath.Join(l.dir, strings.ToLower(code)+".star")
+}
+
+// HookFactory returns a factory that loads the matching script into each new
+// private room. hostFor supplies the room's script host. Rooms without a
+// script, and scripts that fail to load, keep the default rules.
+func (l *Library) HookFactory(hostFor func(room *game.Room) Host) game.GameplayHookFactory {
+	return func(room *game.Room) game.GameplayHook {
+		if room.Kind != game.RoomKindCode {
+			return nil
+		}
+		path := l.ScriptPath(room.Code)
+		if path == "" {
+			return nil
+		}
+
+		source, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			log.Printf("Failed to read mode script %s: %v", path, err)
+			return nil
+		}
+
+		script, err := Load(strings.ToLower(room.Code), source, hostFor(room))
+		if err != nil {
+			log.Printf("Failed to load mode script for room %s: %v", room.ID, err)
+			return nil
+		}
+
+		log.Printf("Room %s running mode script %s", room.ID, script.Name())
+		return script
+	}
+}
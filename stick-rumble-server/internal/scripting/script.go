@@ -0,0 +1,253 @@
+// Package scripting runs custom game mode scripts written in Starlark.
+//
+// A script is loaded per private room and plugs into the room's gameplay
+// hooks. Starlark has no file, network or clock access, scripts cannot load
+// other modules, and every call runs under an execution step limit, so a
+// script can only affect its room through the hooks and the game module.
+package scripting
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"go.starlark.net/syntax"
+)
+
+const (
+	// loadStepLimit bounds the work a script's top level may do when loaded
+	loadStepLimit = 1_000_000
+
+	// callStepLimit bounds the work of a single hook call; hooks run on the
+	// game loop, so a runaway script must not stall a tick
+	callStepLimit = 100_000
+)
+
+// Host carries out a script's actions for the room it was loaded into.
+type Host interface {
+	SpawnWeaponCrate(weaponType string, position game.Vector2) (string, error)
+	EndMatch(reason string)
+}
+
+// RoomScript is a loaded custom mode script. It implements game.GameplayHook;
+// each hook calls the script function of the same purpose when it defines one:
+//
+//	on_damage(event)    -> damage (int) or None to keep it
+//	on_heal(event)      -> amount (int) or None to keep it
+//	on_kill(event)      -> kill XP (int) or None to keep it
+//	allow_pickup(event) -> bool or None to allow
+//	on_ultimate(event)  -> dict overriding name, heal, damage_multiplier
+//	                       and duration_ms, or None to keep the effect
+//
+// Module globals are frozen once the top level has run, so scripts keep
+// mutable match state in the predeclared state dict; calls are serialized per
+// script.
+type RoomScript struct {
+	name    string
+	globals starlark.StringDict
+	state   *starlark.Dict
+	mu      sync.Mutex
+}
+
+// Load runs a script's top level and returns it ready to register as a hook.
+func Load(name string, source []byte, host Host) (*RoomScript, error) {
+	script := &RoomScript{name: name, state: starlark.NewDict(0)}
+
+	thread := script.newThread(loadStepLimit)
+	globals, err := starlark.ExecFileOptions(&syntax.FileOptions{}, thread, name+".star", source, script.predeclared(host))
+	if err != nil {
+		return nil, fmt.Errorf("load script %s: %w", name, err)
+	}
+	script.globals = globals
+	return script, nil
+}
+
+// Name returns the script's name (its file name without extension).
+func (s *RoomScript) Name() string {
+	return s.name
+}
+
+func (s *RoomScript) newThread(maxSteps uint64) *starlark.Thread {
+	thread := &starlark.Thread{
+		Name: "script:" + s.name,
+		Print: func(_ *starlark.Thread, msg string) {
+			log.Printf("[script %s] %s", s.name, msg)
+		},
+	}
+	thread.SetMaxExecutionSteps(maxSteps)
+	return thread
+}
+
+func (s *RoomScript) predeclared(host Host) starlark.StringDict {
+	spawnCrate := starlark.NewBuiltin("spawn_crate", func(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var weaponType string
+		var x, y starlark.Value
+		if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "weapon", &weaponType, "x", &x, "y", &y); err != nil {
+			return nil, err
+		}
+		posX, okX := starlark.AsFloat(x)
+		posY, okY := starlark.AsFloat(y)
+		if !okX || !okY {
+			return nil, fmt.Errorf("%s: x and y must be numbers", fn.Name())
+		}
+
+		crateID, err := host.SpawnWeaponCrate(weaponType, game.Vector2{X: posX, Y: posY})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fn.Name(), err)
+		}
+		return starlark.String(crateID), nil
+	})
+
+	endMatch := starlark.NewBuiltin("end_match", func(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		reason := "script"
+		if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "reason?", &reason); err != nil {
+			return nil, err
+		}
+		if reason == "" {
+			return nil, fmt.Errorf("%s: reason must not be empty", fn.Name())
+		}
+
+		host.EndMatch(reason)
+		return starlark.None, nil
+	})
+
+	return starlark.StringDict{
+		"state": s.state,
+		"game": &starlarkstruct.Module{
+			Name: "game",
+			Members: starlark.StringDict{
+				"spawn_crate": spawnCrate,
+				"end_match":   endMatch,
+			},
+		},
+	}
+}
+
+// call runs a script function with one event argument. It reports false when
+// the script does not define the function or the call failed; failures are
+// logged and leave the rule unchanged.
+func (s *RoomScript) call(function string, event starlark.StringDict) (starlark.Value, bool) {
+	fn, ok := s.globals[function].(starlark.Callable)
+	if !ok {
+		return nil, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	eventValue := starlarkstruct.FromStringDict(starlarkstruct.Default, event)
+	result, err := starlark.Call(s.newThread(callStepLimit), fn, starlark.Tuple{eventValue}, nil)
+	if err != nil {
+		log.Printf("[script %s] %s failed: %v", s.name, function, err)
+		return nil, false
+	}
+	return result, true
+}
+
+func (s *RoomScript) callInt(function string, event starlark.StringDict, current int) int {
+	result, ok := s.call(function, event)
+	if !ok || result == starlark.None {
+		return current
+	}
+
+	value, err := starlark.AsInt32(result)
+	if err != nil {
+		log.Printf("[script %s] %s must return an int or None, got %s", s.name, function, result.Type())
+		return current
+	}
+	return value
+}
+
+func (s *RoomScript) ModifyDamage(event game.DamageEvent) int {
+	return s.callInt("on_damage", starlark.StringDict{
+		"attacker": starlark.String(event.AttackerID),
+		"victim":   starlark.String(event.VictimID),
+		"weapon":   starlark.String(event.Weapon),
+		"source":   starlark.String(event.Source),
+		"damage":   starlark.MakeInt(event.Damage),
+	}, event.Damage)
+}
+
+func (s *RoomScript) ModifyHeal(event game.HealEvent) int {
+	return s.callInt("on_heal", starlark.StringDict{
+		"player": starlark.String(event.PlayerID),
+		"source": starlark.String(event.Source),
+		"amount": starlark.MakeInt(event.Amount),
+	}, event.Amount)
+}
+
+func (s *RoomScript) ModifyKillXP(event game.KillXPEvent) int {
+	return s.callInt("on_kill", starlark.StringDict{
+		"killer": starlark.String(event.KillerID),
+		"victim": starlark.String(event.VictimID),
+		"xp":     starlark.MakeInt(event.XP),
+	}, event.XP)
+}
+
+func (s *RoomScript) AllowPickup(event game.PickupEvent) bool {
+	result, ok := s.call("allow_pickup", starlark.StringDict{
+		"player": starlark.String(event.PlayerID),
+		"crate":  starlark.String(event.CrateID),
+		"weapon": starlark.String(event.WeaponType),
+	})
+	if !ok || result == starlark.None {
+		return true
+	}
+	return bool(result.Truth())
+}
+
+func (s *RoomScript) ModifyUltimate(event game.UltimateEvent) game.UltimateEffect {
+	effect := event.Effect
+	result, ok := s.call("on_ultimate", starlark.StringDict{
+		"player":            starlark.String(event.PlayerID),
+		"name":              starlark.String(effect.Name),
+		"heal":              starlark.MakeInt(effect.Heal),
+		"damage_multiplier": starlark.Float(effect.DamageMultiplier),
+		"duration_ms":       starlark.MakeInt64(effect.Duration.Milliseconds()),
+	})
+	if !ok || result == starlark.None {
+		return effect
+	}
+
+	overrides, isDict := result.(*starlark.Dict)
+	if !isDict {
+		log.Printf("[script %s] on_ultimate must return a dict or None, got %s", s.name, result.Type())
+		return effect
+	}
+	updated := effect
+	for _, item := range overrides.Items() {
+		key, _ := starlark.AsString(item[0])
+		var err error
+		switch key {
+		case "name":
+			name, isString := starlark.AsString(item[1])
+			if !isString {
+				err = fmt.Errorf("want string, got %s", item[1].Type())
+			}
+			updated.Name = name
+		case "heal":
+			updated.Heal, err = starlark.AsInt32(item[1])
+		case "damage_multiplier":
+			multiplier, isNumber := starlark.AsFloat(item[1])
+			if !isNumber {
+				err = fmt.Errorf("want number, got %s", item[1].Type())
+			}
+			updated.DamageMultiplier = multiplier
+		case "duration_ms":
+			var ms int
+			ms, err = starlark.AsInt32(item[1])
+			updated.Duration = time.Duration(ms) * time.Millisecond
+		default:
+			err = fmt.Errorf("unknown key")
+		}
+		if err != nil {
+			log.Printf("[script %s] on_ultimate %s: %v", s.name, item[0], err)
+			return effect
+		}
+	}
+	return updated
+}
@@ -0,0 +1,162 @@
+package scripting
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubHost struct {
+	spawned    []string
+	endReasons []string
+	spawnErr   error
+}
+
+func (h *stubHost) SpawnWeaponCrate(weaponType string, position game.Vector2) (string, error) {
+	if h.spawnErr != nil {
+		return "", h.spawnErr
+	}
+	h.spawned = append(h.spawned, weaponType)
+	return "spawned_1", nil
+}
+
+func (h *stubHost) EndMatch(reason string) {
+	h.endReasons = append(h.endReasons, reason)
+}
+
+const instagibScript = `
+state["kills"] = {}
+
+def on_damage(event):
+    if event.source == "melee":
+        return None
+    return event.damage * 10
+
+def on_heal(event):
+    return 0
+
+def on_kill(event):
+    kills = state["kills"]
+    kills[event.killer] = kills.get(event.killer, 0) + 1
+    if kills[event.killer] >= 3:
+        game.end_match("instagib_winner")
+    return event.xp + 25
+
+def allow_pickup(event):
+    return event.weapon != "shotgun"
+
+crate = game.spawn_crate("shotgun", 100, 200)
+`
+
+func TestRoomScriptHooks(t *testing.T) {
+	host := &stubHost{}
+	script, err := Load("instagib", []byte(instagibScript), host)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"shotgun"}, host.spawned, "top level ran once")
+
+	assert.Equal(t, 250, script.ModifyDamage(game.DamageEvent{Source: game.DamageSourceProjectile, Damage: 25}))
+	assert.Equal(t, 25, script.ModifyDamage(game.DamageEvent{Source: game.DamageSourceMelee, Damage: 25}), "None keeps the damage")
+	assert.Equal(t, 0, script.ModifyHeal(game.HealEvent{Amount: 10}))
+	assert.False(t, script.AllowPickup(game.PickupEvent{WeaponType: "shotgun"}))
+	assert.True(t, script.AllowPickup(game.PickupEvent{WeaponType: "uzi"}))
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, game.KillXPReward+25, script.ModifyKillXP(game.KillXPEvent{KillerID: "p1", XP: game.KillXPReward}))
+	}
+	assert.Equal(t, []string{"instagib_winner"}, host.endReasons, "script state persists between calls")
+}
+
+func TestRoomScriptOverridesUltimate(t *testing.T) {
+	source := `
+def on_ultimate(event):
+    if event.player == "boss":
+        return {"name": "rampage", "damage_multiplier": 3, "duration_ms": event.duration_ms * 2}
+    if event.player == "typo":
+        return {"damage": 5}
+    return None
+`
+	script, err := Load("ultimates", []byte(source), &stubHost{})
+	require.NoError(t, err)
+	base := game.DefaultUltimateEffect()
+
+	boss := script.ModifyUltimate(game.UltimateEvent{PlayerID: "boss", Effect: base})
+	assert.Equal(t, "rampage", boss.Name)
+	assert.Equal(t, 3.0, boss.DamageMultiplier)
+	assert.Equal(t, base.Duration*2, boss.Duration)
+	assert.Equal(t, base.Heal, boss.Heal, "keys left out keep their value")
+
+	assert.Equal(t, base, script.ModifyUltimate(game.UltimateEvent{PlayerID: "typo", Effect: base}), "unknown keys reject the override")
+	assert.Equal(t, base, script.ModifyUltimate(game.UltimateEvent{PlayerID: "p1", Effect: base}))
+}
+
+func TestRoomScriptWithoutHooksKeepsDefaults(t *testing.T) {
+	script, err := Load("empty", []byte(`x = 1`), &stubHost{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 25, script.ModifyDamage(game.DamageEvent{Damage: 25}))
+	assert.Equal(t, 10, script.ModifyHeal(game.HealEvent{Amount: 10}))
+	assert.Equal(t, 100, script.ModifyKillXP(game.KillXPEvent{XP: 100}))
+	assert.True(t, script.AllowPickup(game.PickupEvent{}))
+	assert.Equal(t, game.DefaultUltimateEffect(), script.ModifyUltimate(game.UltimateEvent{Effect: game.DefaultUltimateEffect()}))
+}
+
+func TestRoomScriptFailuresLeaveRulesUnchanged(t *testing.T) {
+	source := `
+def on_damage(event):
+    return "lots"
+
+def on_heal(event):
+    for i in range(1000000000):
+        pass
+
+def on_kill(event):
+    return game.spawn_crate("rocket", 0, 0)
+`
+	host := &stubHost{spawnErr: errors.New("invalid weapon type: rocket")}
+	script, err := Load("broken", []byte(source), host)
+	require.NoError(t, err)
+
+	assert.Equal(t, 25, script.ModifyDamage(game.DamageEvent{Damage: 25}), "wrong return type")
+	assert.Equal(t, 10, script.ModifyHeal(game.HealEvent{Amount: 10}), "step limit stops runaway loops")
+	assert.Equal(t, 100, script.ModifyKillXP(game.KillXPEvent{XP: 100}), "host errors surface as script errors")
+}
+
+func TestLoadRejectsUnsafeOrInvalidScripts(t *testing.T) {
+	_, err := Load("loader", []byte(`load("other.star", "x")`), &stubHost{})
+	assert.Error(t, err, "scripts cannot load other modules")
+
+	_, err = Load("syntax", []byte(`def broken(:`), &stubHost{})
+	assert.Error(t, err)
+
+	_, err = Load("spin", []byte("for i in range(1000000000):\n    pass\n"), &stubHost{})
+	assert.Error(t, err, "top level is step limited")
+}
+
+func TestLibraryLoadsScriptMatchingRoomCode(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "zombies.star"), []byte("def on_heal(event):\n    return 0\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.star"), []byte("def (:"), 0o644))
+
+	hosts := 0
+	factory := NewLibrary(dir).HookFactory(func(room *game.Room) Host {
+		hosts++
+		return &stubHost{}
+	})
+
+	hook := factory(game.NewTypedRoom(game.RoomKindCode, "ZOMBIES"))
+	require.NotNil(t, hook)
+	assert.Equal(t, 0, hook.ModifyHeal(game.HealEvent{Amount: 10}))
+
+	assert.Nil(t, factory(game.NewTypedRoom(game.RoomKindCode, "NOSCRIPT")))
+	assert.Nil(t, factory(game.NewTypedRoom(game.RoomKindCode, "BROKEN")))
+	assert.Nil(t, factory(game.NewTypedRoom(game.RoomKindPublic, "")), "public rooms never run scripts")
+	assert.Equal(t, 2, hosts)
+
+	disabled := NewLibrary("").HookFactory(func(room *game.Room) Host { return &stubHost{} })
+	assert.Nil(t, disabled(game.NewTypedRoom(game.RoomKindCode, "ZOMBIES")))
+}
@@ -0,0 +1,307 @@
+// Package simclient is a headless game client for scripted QA scenarios. A
+// Client speaks the same WebSocket protocol as the browser client, records
+// every message it receives and tracks the player and crate positions it has
+// been told about, so scenarios can drive it like a player and assert on what
+// the server sent back.
+package simclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+// ErrClosed is returned when waiting on a client whose connection has ended.
+var ErrClosed = errors.New("simclient: connection closed")
+
+// Message is one server message as received by the client
+type Message struct {
+	Type      string         `json:"type"`
+	Timestamp int64          `json:"timestamp"`
+	Data      map[string]any `json:"data,omitempty"`
+}
+
+// Crate is a weapon crate as last reported by the server
+type Crate struct {
+	ID          string       `json:"id"`
+	WeaponType  string       `json:"weaponType"`
+	Position    game.Vector2 `json:"position"`
+	IsAvailable bool         `json:"isAvailable"`
+}
+
+// Input is the movement and aim state sent as input:state
+type Input struct {
+	Up          bool    `json:"up"`
+	Down        bool    `json:"down"`
+	Left        bool    `json:"left"`
+	Right       bool    `json:"right"`
+	AimAngle    float64 `json:"aimAngle"`
+	IsSprinting bool    `json:"isSprinting"`
+}
+
+// Client is a headless player connection
+type Client struct {
+	conn    *websocket.Conn
+	writeMu sync.Mutex
+
+	messages  []Message
+	playerID  string
+	positions map[string]game.Vector2 // player ID -> last reported position
+	crates    map[string]Crate        // crate ID -> crate
+	received  chan struct{}           // Closed and replaced whenever a message arrives
+	readErr   error
+	mu        sync.Mutex
+
+	done chan struct{}
+}
+
+// Dial connects to a server's /ws endpoint. The client does not join a room
+// until Hello is sent.
+func Dial(url string) (*Client, error) {
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("dial %s: %w", url, err)
+	}
+
+	c := &Client{
+		conn:      conn,
+		positions: make(map[string]game.Vector2),
+		crates:    make(map[string]Crate),
+		received:  make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	go c.readLoop()
+	return c, nil
+}
+
+// Close ends the connection and waits for the reader to stop
+func (c *Client) Close() error {
+	err := c.conn.Close()
+	<-c.done
+	return err
+}
+
+// Send writes a message of msgType with data to the server
+func (c *Client) Send(msgType string, data any) error {
+	frame, err := json.Marshal(map[string]any{
+		"type":      msgType,
+		"timestamp": time.Now().UnixMilli(),
+		"data":      data,
+	})
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", msgType, err)
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.conn.WriteMessage(websocket.TextMessage, frame)
+}
+
+// Hello sends player:hello; mode is "public" or "code"
+func (c *Client) Hello(displayName, mode, code string) error {
+	data := map[string]any{"displayName": displayName, "mode": mode}
+	if code != "" {
+		data["code"] = code
+	}
+	return c.Send("player:hello", data)
+}
+
+// SendInput sends input:state
+func (c *Client) SendInput(input Input) error {
+	return c.Send("input:state", input)
+}
+
+// Shoot sends player:shoot at aimAngle radians
+func (c *Client) Shoot(aimAngle float64) error {
+	return c.Send("player:shoot", map[string]any{
+		"aimAngle":        aimAngle,
+		"clientTimestamp": time.Now().UnixMilli(),
+	})
+}
+
+// Reload sends player:reload
+func (c *Client) Reload() error {
+	return c.Send("player:reload", map[string]any{})
+}
+
+// PickUp sends weapon:pickup_attempt for a crate
+func (c *Client) PickUp(crateID string) error {
+	return c.Send("weapon:pickup_attempt", map[string]any{"crateId": crateID})
+}
+
+// PlayerID returns the ID the server assigned in session:status, or "" before
+// the client has joined
+func (c *Client) PlayerID() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.playerID
+}
+
+// Position returns a player's last reported position
+func (c *Client) Position(playerID string) (game.Vector2, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	pos, ok := c.positions[playerID]
+	return pos, ok
+}
+
+// Crates returns every weapon crate the client knows about
+func (c *Client) Crates() []Crate {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	crates := make([]Crate, 0, len(c.crates))
+	for _, crate := range c.crates {
+		crates = append(crates, crate)
+	}
+	return crates
+}
+
+// Messages returns a copy of every message received so far, oldest first
+func (c *Client) Messages() []Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Message(nil), c.messages...)
+}
+
+// WaitFor returns the first message at index from or later that match
+// accepts, along with its index, waiting up to timeout for it to arrive.
+// match runs with the client locked and must not call its methods.
+func (c *Client) WaitFor(from int, match func(Message) bool, timeout time.Duration) (Message, int, error) {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	for {
+		c.mu.Lock()
+		for i := from; i < len(c.messages); i++ {
+			if match(c.messages[i]) {
+				msg := c.messages[i]
+				c.mu.Unlock()
+				return msg, i, nil
+			}
+		}
+		from = len(c.messages)
+		received, readErr := c.received, c.readErr
+		c.mu.Unlock()
+
+		if readErr != nil {
+			return Message{}, 0, ErrClosed
+		}
+		select {
+		case <-received:
+		case <-deadline.C:
+			return Message{}, 0, fmt.Errorf("no matching message after %v", timeout)
+		}
+	}
+}
+
+// messageCount returns how many messages have been received so far
+func (c *Client) messageCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.messages)
+}
+
+func (c *Client) readLoop() {
+	defer close(c.done)
+
+	for {
+		_, frame, err := c.conn.ReadMessage()
+		if err != nil {
+			c.mu.Lock()
+			c.readErr = err
+			close(c.received)
+			c.mu.Unlock()
+			return
+		}
+
+		var msg Message
+		if err := json.Unmarshal(frame, &msg); err != nil {
+			continue
+		}
+		if msg.Type == "net:ping" {
+			// Answered at once like the browser client, or the server
+			// closes the connection after a few misses
+			_ = c.Send("net:pong", msg.Data)
+		}
+
+		c.mu.Lock()
+		c.messages = append(c.messages, msg)
+		c.track(msg)
+		close(c.received)
+		c.received = make(chan struct{})
+		c.mu.Unlock()
+	}
+}
+
+// track folds a message into the client's view of the world; callers hold mu
+func (c *Client) track(msg Message) {
+	switch msg.Type {
+	case "session:status":
+		if id, ok := msg.Data["playerId"].(string); ok {
+			c.playerID = id
+		}
+	case "state:snapshot":
+		c.positions = make(map[string]game.Vector2)
+		c.trackPlayers(msg.Data["players"])
+		if crates, ok := decode[[]Crate](msg.Data["weaponCrates"]); ok {
+			c.crates = make(map[string]Crate)
+			for _, crate := range crates {
+				c.crates[crate.ID] = crate
+			}
+		}
+	case "state:delta", "player:move":
+		c.trackPlayers(msg.Data["players"])
+	case "player:respawn":
+		if pos, ok := decode[game.Vector2](msg.Data["position"]); ok {
+			c.positions[fmt.Sprint(msg.Data["playerId"])] = pos
+		}
+	case "weapon:spawned":
+		if crates, ok := decode[[]Crate](msg.Data["crates"]); ok {
+			for _, crate := range crates {
+				c.crates[crate.ID] = crate
+			}
+		}
+	case "weapon:pickup_confirmed":
+		if crate, ok := c.crates[fmt.Sprint(msg.Data["crateId"])]; ok {
+			crate.IsAvailable = false
+			c.crates[crate.ID] = crate
+		}
+	case "weapon:respawned":
+		if crate, ok := c.crates[fmt.Sprint(msg.Data["crateId"])]; ok {
+			crate.IsAvailable = true
+			c.crates[crate.ID] = crate
+		}
+	}
+}
+
+func (c *Client) trackPlayers(raw any) {
+	players, ok := decode[[]struct {
+		ID       string       `json:"id"`
+		Position game.Vector2 `json:"position"`
+	}](raw)
+	if !ok {
+		return
+	}
+	for _, player := range players {
+		c.positions[player.ID] = player.Position
+	}
+}
+
+// decode converts a generically decoded JSON value into T
+func decode[T any](raw any) (T, bool) {
+	var value T
+	if raw == nil {
+		return value, false
+	}
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return value, false
+	}
+	return value, json.Unmarshal(encoded, &value) == nil
+}
@@ -0,0 +1,418 @@
+package simclient
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+)
+
+const (
+	// DefaultStepTimeout bounds each step of a scenario without its own Timeout
+	DefaultStepTimeout = 5 * time.Second
+
+	// arrivalRadius is how close RunTo gets before it stops the player; well
+	// inside WeaponPickupRadius so a RunTo to a crate can be followed by PickUp
+	arrivalRadius = 8.0
+
+	// axisDeadband keeps RunTo from pressing a direction key for an axis that
+	// is already lined up, so it moves diagonally only while it needs to
+	axisDeadband = 4.0
+)
+
+// Scenario is a scripted sequence of steps played by one or more headless
+// clients against a running server, e.g. "reload cancels on weapon pickup".
+type Scenario struct {
+	Name    string
+	Steps   []Step
+	Timeout time.Duration // Per-step timeout; DefaultStepTimeout when zero
+}
+
+// Step is one action or assertion in a scenario
+type Step struct {
+	Description string
+	run         func(r *run) error
+}
+
+// run is the state of one scenario execution
+type run struct {
+	url     string
+	timeout time.Duration
+	clients map[string]*Client
+	marks   map[string]int // alias -> index of the first message Expect may match
+}
+
+// Run plays the scenario against the server at url (its /ws endpoint). It
+// stops at the first failing step and closes every client before returning.
+func (s Scenario) Run(url string) error {
+	r := &run{
+		url:     url,
+		timeout: s.Timeout,
+		clients: make(map[string]*Client),
+		marks:   make(map[string]int),
+	}
+	if r.timeout <= 0 {
+		r.timeout = DefaultStepTimeout
+	}
+	defer r.close()
+
+	for i, step := range s.Steps {
+		if err := step.run(r); err != nil {
+			return fmt.Errorf("scenario %q step %d (%s): %w", s.Name, i+1, step.Description, err)
+		}
+	}
+	return nil
+}
+
+func (r *run) close() {
+	for _, client := range r.clients {
+		_ = client.Close()
+	}
+}
+
+func (r *run) client(alias string) (*Client, error) {
+	client, ok := r.clients[alias]
+	if !ok {
+		return nil, fmt.Errorf("no client %q; connect it first", alias)
+	}
+	return client, nil
+}
+
+// act runs an action for alias; messages received before it are not visible
+// to the Expect steps that follow
+func (r *run) act(alias string, action func(*Client) error) error {
+	client, err := r.client(alias)
+	if err != nil {
+		return err
+	}
+	r.marks[alias] = client.messageCount()
+	return action(client)
+}
+
+// position returns the last reported position of alias's own player
+func (r *run) position(client *Client) (game.Vector2, error) {
+	pos, ok := client.Position(client.PlayerID())
+	if !ok {
+		return game.Vector2{}, fmt.Errorf("position not known yet; wait for the room first")
+	}
+	return pos, nil
+}
+
+// Target resolves a point in the arena when a step runs
+type Target struct {
+	Description string
+	resolve     func(r *run) (game.Vector2, error)
+}
+
+// Point targets a fixed arena position
+func Point(x, y float64) Target {
+	return Target{
+		Description: fmt.Sprintf("(%.0f, %.0f)", x, y),
+		resolve: func(*run) (game.Vector2, error) {
+			return game.Vector2{X: x, Y: y}, nil
+		},
+	}
+}
+
+// PlayerOf targets the current position of another scenario client's player
+func PlayerOf(alias string) Target {
+	return Target{
+		Description: alias,
+		resolve: func(r *run) (game.Vector2, error) {
+			client, err := r.client(alias)
+			if err != nil {
+				return game.Vector2{}, err
+			}
+			return r.position(client)
+		},
+	}
+}
+
+// CrateOf targets the available weapon crate of weaponType nearest to alias's
+// player; an empty weaponType matches any crate
+func CrateOf(alias, weaponType string) Target {
+	return Target{
+		Description: "nearest " + describeWeapon(weaponType) + " crate",
+		resolve: func(r *run) (game.Vector2, error) {
+			crate, err := r.nearestCrate(alias, weaponType)
+			return crate.Position, err
+		},
+	}
+}
+
+func describeWeapon(weaponType string) string {
+	if weaponType == "" {
+		return "weapon"
+	}
+	return weaponType
+}
+
+func (r *run) nearestCrate(alias, weaponType string) (Crate, error) {
+	client, err := r.client(alias)
+	if err != nil {
+		return Crate{}, err
+	}
+	from, err := r.position(client)
+	if err != nil {
+		return Crate{}, err
+	}
+
+	crates := client.Crates()
+	sort.Slice(crates, func(i, j int) bool {
+		return distance(from, crates[i].Position) < distance(from, crates[j].Position)
+	})
+	for _, crate := range crates {
+		if crate.IsAvailable && (weaponType == "" || crate.WeaponType == weaponType) {
+			return crate, nil
+		}
+	}
+	return Crate{}, fmt.Errorf("no available %s crate", describeWeapon(weaponType))
+}
+
+// Connect dials a new client named alias and sends player:hello. An empty
+// code joins the public queue; otherwise the client joins that room code.
+func Connect(alias, code string) Step {
+	return Step{
+		Description: "connect " + alias,
+		run: func(r *run) error {
+			if _, exists := r.clients[alias]; exists {
+				return fmt.Errorf("client %q already connected", alias)
+			}
+			client, err := Dial(r.url)
+			if err != nil {
+				return err
+			}
+			r.clients[alias] = client
+
+			mode := "public"
+			if code != "" {
+				mode = "code"
+			}
+			return client.Hello(alias, mode, code)
+		},
+	}
+}
+
+// WaitForRoom waits until alias's match is ready and its own position has
+// been reported
+func WaitForRoom(alias string) Step {
+	return Step{
+		Description: alias + " waits for room",
+		run: func(r *run) error {
+			client, err := r.client(alias)
+			if err != nil {
+				return err
+			}
+			_, _, err = client.WaitFor(0, func(msg Message) bool {
+				return msg.Type == "session:status" && msg.Data["state"] == string(game.SessionStatusMatchReady)
+			}, r.timeout)
+			if err != nil {
+				return fmt.Errorf("session:status %s: %w", game.SessionStatusMatchReady, err)
+			}
+			deadline := time.Now().Add(r.timeout)
+			for {
+				if _, ok := client.Position(client.PlayerID()); ok {
+					return nil
+				}
+				if _, _, err := client.WaitFor(client.messageCount(), isStateUpdate, time.Until(deadline)); err != nil {
+					return fmt.Errorf("waiting for own position: %w", err)
+				}
+			}
+		},
+	}
+}
+
+// RunTo steers alias's player to target with movement input, then stops it
+func RunTo(alias string, target Target) Step {
+	return Step{
+		Description: alias + " runs to " + target.Description,
+		run: func(r *run) error {
+			to, err := target.resolve(r)
+			if err != nil {
+				return err
+			}
+			return r.act(alias, func(client *Client) error {
+				return r.runTo(client, to)
+			})
+		},
+	}
+}
+
+func (r *run) runTo(client *Client, to game.Vector2) error {
+	deadline := time.Now().Add(r.timeout)
+	var sent *Input
+
+	for {
+		from, err := r.position(client)
+		if err != nil {
+			return err
+		}
+
+		input := Input{AimAngle: aimAngle(from, to)}
+		if distance(from, to) > arrivalRadius {
+			input.Left = to.X < from.X-axisDeadband
+			input.Right = to.X > from.X+axisDeadband
+			input.Up = to.Y < from.Y-axisDeadband
+			input.Down = to.Y > from.Y+axisDeadband
+		}
+		moving := input.Up || input.Down || input.Left || input.Right
+
+		if sent == nil || *sent != input {
+			if err := client.SendInput(input); err != nil {
+				return err
+			}
+			sent = &input
+		}
+		if !moving {
+			return nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("still at (%.0f, %.0f), %.0fpx short of (%.0f, %.0f)", from.X, from.Y, distance(from, to), to.X, to.Y)
+		}
+		if _, _, err := client.WaitFor(client.messageCount(), isStateUpdate, remaining); err != nil {
+			return fmt.Errorf("waiting for position update: %w", err)
+		}
+	}
+}
+
+func isStateUpdate(msg Message) bool {
+	return msg.Type == "state:snapshot" || msg.Type == "state:delta" || msg.Type == "player:move"
+}
+
+// ShootAt aims alias's player at target and fires once
+func ShootAt(alias string, target Target) Step {
+	return Step{
+		Description: alias + " shoots at " + target.Description,
+		run: func(r *run) error {
+			to, err := target.resolve(r)
+			if err != nil {
+				return err
+			}
+			return r.act(alias, func(client *Client) error {
+				from, err := r.position(client)
+				if err != nil {
+					return err
+				}
+				return client.Shoot(aimAngle(from, to))
+			})
+		},
+	}
+}
+
+// Reload starts a reload of alias's weapon
+func Reload(alias string) Step {
+	return Step{
+		Description: alias + " reloads",
+		run: func(r *run) error {
+			return r.act(alias, (*Client).Reload)
+		},
+	}
+}
+
+// PickUp attempts to pick up the available crate of weaponType nearest to
+// alias's player; an empty weaponType matches any crate
+func PickUp(alias, weaponType string) Step {
+	return Step{
+		Description: alias + " picks up " + describeWeapon(weaponType),
+		run: func(r *run) error {
+			crate, err := r.nearestCrate(alias, weaponType)
+			if err != nil {
+				return err
+			}
+			return r.act(alias, func(client *Client) error {
+				return client.PickUp(crate.ID)
+			})
+		},
+	}
+}
+
+// Send sends an arbitrary message from alias, for actions without their own step
+func Send(alias, msgType string, data any) Step {
+	return Step{
+		Description: alias + " sends " + msgType,
+		run: func(r *run) error {
+			return r.act(alias, func(client *Client) error {
+				return client.Send(msgType, data)
+			})
+		},
+	}
+}
+
+// Expect waits for alias to receive a msgType message whose data matches,
+// received after alias's last action and after any message an earlier Expect
+// matched, so consecutive Expects assert message order. A nil match accepts
+// any data.
+func Expect(alias, msgType string, match func(data map[string]any) bool) Step {
+	return Step{
+		Description: alias + " receives " + msgType,
+		run: func(r *run) error {
+			client, err := r.client(alias)
+			if err != nil {
+				return err
+			}
+			_, index, err := client.WaitFor(r.marks[alias], func(msg Message) bool {
+				return msg.Type == msgType && (match == nil || match(msg.Data))
+			}, r.timeout)
+			if err != nil {
+				return err
+			}
+			r.marks[alias] = index + 1
+			return nil
+		},
+	}
+}
+
+// Fields matches message data containing every key with an equal value.
+// Numbers compare by value, so Fields{"currentAmmo": 15} matches a decoded 15.0.
+type Fields map[string]any
+
+// Match reports whether data contains every field
+func (f Fields) Match(data map[string]any) bool {
+	for key, want := range f {
+		got, ok := data[key]
+		if !ok || !equalValue(got, want) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalValue(got, want any) bool {
+	if gotNumber, ok := toFloat(got); ok {
+		wantNumber, ok := toFloat(want)
+		return ok && gotNumber == wantNumber
+	}
+	return got == want
+}
+
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
+
+func distance(a, b game.Vector2) float64 {
+	return math.Hypot(b.X-a.X, b.Y-a.Y)
+}
+
+// aimAngle returns the angle from a to b in the server's 0 to 2π convention
+func aimAngle(a, b game.Vector2) float64 {
+	angle := math.Atan2(b.Y-a.Y, b.X-a.X)
+	if angle < 0 {
+		angle += 2 * math.Pi
+	}
+	return angle
+}
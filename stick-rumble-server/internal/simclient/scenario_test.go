@@ -0,0 +1,121 @@
+package simclient
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mtomcal/stick-rumble-server/internal/game"
+	"github.com/mtomcal/stick-rumble-server/internal/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startServer runs a game server for one test and returns its /ws URL
+func startServer(t *testing.T) string {
+	t.Helper()
+
+	handler := network.NewWebSocketHandler()
+	server := httptest.NewServer(http.HandlerFunc(handler.HandleWebSocket))
+	ctx, cancel := context.WithCancel(context.Background())
+	handler.Start(ctx)
+	t.Cleanup(func() {
+		cancel()
+		handler.Stop()
+		server.Close()
+	})
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func TestFieldsMatch(t *testing.T) {
+	data := map[string]any{"weaponType": "Uzi", "currentAmmo": 30.0, "isReloading": false}
+
+	assert.True(t, Fields{"weaponType": "Uzi", "currentAmmo": 30}.Match(data))
+	assert.True(t, Fields{}.Match(data))
+	assert.False(t, Fields{"isReloading": true}.Match(data))
+	assert.False(t, Fields{"currentAmmo": "30"}.Match(data))
+	assert.False(t, Fields{"maxAmmo": 30}.Match(data))
+}
+
+func TestAimAngle(t *testing.T) {
+	origin := game.Vector2{X: 100, Y: 100}
+
+	assert.InDelta(t, 0, aimAngle(origin, game.Vector2{X: 200, Y: 100}), 1e-9)
+	assert.InDelta(t, math.Pi/2, aimAngle(origin, game.Vector2{X: 100, Y: 200}), 1e-9)
+	assert.InDelta(t, 3*math.Pi/2, aimAngle(origin, game.Vector2{X: 100, Y: 0}), 1e-9, "angles stay within 0 to 2π")
+}
+
+func TestScenarioReportsFailingStep(t *testing.T) {
+	err := Scenario{
+		Name:  "unknown client",
+		Steps: []Step{Reload("ghost")},
+	}.Run("ws://127.0.0.1:1/ws")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `scenario "unknown client" step 1 (ghost reloads)`)
+}
+
+func TestScenarioShootsAtPlayer(t *testing.T) {
+	url := startServer(t)
+
+	err := Scenario{
+		Name: "shot spends a round",
+		Steps: []Step{
+			Connect("alice", "SHOOT"),
+			Connect("bob", "SHOOT"),
+			WaitForRoom("alice"),
+			WaitForRoom("bob"),
+			ShootAt("alice", PlayerOf("bob")),
+			Expect("alice", "weapon:state", Fields{"weaponType": "Pistol", "currentAmmo": game.PistolMagazineSize - 1}.Match),
+			Expect("bob", "projectile:spawn", nil),
+		},
+	}.Run(url)
+	require.NoError(t, err)
+}
+
+func TestScenarioExpectTimesOut(t *testing.T) {
+	url := startServer(t)
+
+	err := Scenario{
+		Name:    "nothing arrives",
+		Timeout: 200 * time.Millisecond,
+		Steps: []Step{
+			Connect("alice", "QUIET"),
+			Expect("alice", "match:ended", nil),
+		},
+	}.Run(url)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "alice receives match:ended")
+}
+
+// QA regression: picking up a weapon mid-reload replaces the weapon and the
+// reload does not carry over to it
+func TestScenarioReloadCancelsOnWeaponPickup(t *testing.T) {
+	url := startServer(t)
+
+	err := Scenario{
+		Name: "reload cancels on weapon pickup",
+		Steps: []Step{
+			Connect("alice", "RELOAD"),
+			Connect("bob", "RELOAD"),
+			WaitForRoom("alice"),
+			WaitForRoom("bob"),
+			RunTo("alice", CrateOf("alice", "")),
+			ShootAt("alice", PlayerOf("bob")),
+			Expect("alice", "weapon:state", Fields{"currentAmmo": game.PistolMagazineSize - 1}.Match),
+			Reload("alice"),
+			Expect("alice", "weapon:state", Fields{"weaponType": "Pistol", "isReloading": true}.Match),
+			PickUp("alice", ""),
+			Expect("alice", "weapon:pickup_confirmed", nil),
+			Expect("alice", "weapon:state", func(data map[string]any) bool {
+				return data["weaponType"] != "Pistol" && data["isReloading"] == false
+			}),
+		},
+	}.Run(url)
+	require.NoError(t, err)
+}